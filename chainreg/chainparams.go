@@ -2,16 +2,46 @@ package chainreg
 
 import (
 	"github.com/ltcsuite/lnd/keychain"
+	"github.com/ltcsuite/lnd/lnwallet/chainfee"
 	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/mempool"
 	"github.com/ltcsuite/ltcd/wire"
 )
 
 // LitecoinNetParams couples the p2p parameters of a network with the
-// corresponding RPC port of a daemon running on the particular network.
+// corresponding RPC port of a daemon running on the particular network and the
+// fee rate floors used on it.
 type LitecoinNetParams struct {
 	*chaincfg.Params
 	RPCPort  string
 	CoinType uint32
+	FeeFloor chainfee.FloorProfile
+}
+
+const (
+	// litecoinFeeFloorMargin is the margin in lit/kw that is added to the
+	// minimum relay fee rate when estimating fees. The weight of a
+	// transaction is rounded up to whole vbytes when its fee is checked
+	// against the relay fee rate, so a transaction paying exactly that
+	// rate per weight unit might not be relayed.
+	litecoinFeeFloorMargin chainfee.SatPerKWeight = 3
+)
+
+// litecoinMinRelayFeePerKw is the minimum fee rate in lit/kw that Litecoin
+// nodes relay transactions at by default. It is taken from the relay policy of
+// ltcd, which follows the one of Litecoin Core.
+var litecoinMinRelayFeePerKw = chainfee.SatPerKVByte(
+	mempool.DefaultMinRelayTxFee,
+).FeePerKWeight()
+
+// LitecoinFloorProfile is the fee rate floor profile used on the Litecoin
+// networks. It is derived from the relay policy of Litecoin nodes: we never
+// create transactions paying less than the minimum relay fee rate, and keep a
+// small margin above it when estimating fees.
+var LitecoinFloorProfile = chainfee.FloorProfile{
+	FeePerKwFloor: litecoinMinRelayFeePerKw +
+		litecoinFeeFloorMargin,
+	AbsoluteFeePerKwFloor: litecoinMinRelayFeePerKw,
 }
 
 // LitecoinSimNetParams contains parameters specific to the simulation test
@@ -20,6 +50,7 @@ var LitecoinSimNetParams = LitecoinNetParams{
 	Params:   &chaincfg.TestNet4Params,
 	RPCPort:  "19556",
 	CoinType: keychain.CoinTypeTestnet,
+	FeeFloor: LitecoinFloorProfile,
 }
 
 // LitecoinTestNetParams contains parameters specific to the 4th version of the
@@ -28,6 +59,7 @@ var LitecoinTestNetParams = LitecoinNetParams{
 	Params:   &chaincfg.TestNet4Params,
 	RPCPort:  "19334",
 	CoinType: keychain.CoinTypeTestnet,
+	FeeFloor: LitecoinFloorProfile,
 }
 
 // LitecoinMainNetParams contains the parameters specific to the current
@@ -36,6 +68,7 @@ var LitecoinMainNetParams = LitecoinNetParams{
 	Params:   &chaincfg.MainNetParams,
 	RPCPort:  "9334",
 	CoinType: keychain.CoinTypeLitecoin,
+	FeeFloor: LitecoinFloorProfile,
 }
 
 // LitecoinRegTestNetParams contains parameters specific to a local litecoin
@@ -44,6 +77,7 @@ var LitecoinRegTestNetParams = LitecoinNetParams{
 	Params:   &chaincfg.RegressionNetParams,
 	RPCPort:  "18334",
 	CoinType: keychain.CoinTypeTestnet,
+	FeeFloor: LitecoinFloorProfile,
 }
 
 // IsTestnet tests if the givern params correspond to a testnet
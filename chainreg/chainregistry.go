@@ -21,6 +21,7 @@ import (
 	"github.com/ltcsuite/lnd/chainntnfs/neutrinonotify"
 	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/lnd/channeldb/models"
+	"github.com/ltcsuite/lnd/clock"
 	"github.com/ltcsuite/lnd/input"
 	"github.com/ltcsuite/lnd/keychain"
 	"github.com/ltcsuite/lnd/kvdb"
@@ -87,6 +88,9 @@ type Config struct {
 	// optional.
 	FeeURL string
 
+	// Fee defines settings for on-chain fee estimation.
+	Fee *lncfg.Fee
+
 	// Dialer is a function closure that will be used to establish outbound
 	// TCP connections to Bitcoin peers in the event of a pruned block being
 	// requested.
@@ -189,6 +193,10 @@ type ChainControl struct {
 	// blockchain.
 	ChainIO lnwallet.BlockChainIO
 
+	// DeadlineEstimator wraps the FeeEstimator to estimate fee rates for
+	// transactions that need to confirm within a given duration.
+	DeadlineEstimator *chainfee.DeadlineEstimator
+
 	// Signer is used to provide signatures over things like transactions.
 	Signer input.Signer
 
@@ -237,6 +245,22 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 		Cfg: cfg,
 	}
 
+	// Select the fee rate floors of the active chain before any fee
+	// estimator is created, as they rely on them.
+	floorProfile := cfg.ActiveNetParams.FeeFloor
+	if cfg.Fee != nil && cfg.Fee.FloorFeeRateSatPerKw != 0 {
+		floor := chainfee.SatPerKWeight(cfg.Fee.FloorFeeRateSatPerKw)
+
+		floorProfile.FeePerKwFloor = floor
+		if floorProfile.AbsoluteFeePerKwFloor > floor {
+			floorProfile.AbsoluteFeePerKwFloor = floor
+		}
+	}
+	if err := chainfee.SetFloorProfile(floorProfile); err != nil {
+		return nil, nil, fmt.Errorf("invalid fee floor: %w", err)
+	}
+	log.Infof("Using fee floor of %v", floorProfile.FeePerKwFloor)
+
 	switch cfg.PrimaryChain() {
 	case LitecoinChain:
 		cc.RoutingPolicy = models.ForwardingPolicy{
@@ -685,30 +709,79 @@ func NewPartialChainControl(cfg *Config) (*PartialChainControl, func(), error) {
 			homeChainConfig.Node)
 	}
 
+	// If the fee URL isn't set, and the user is running neutrino on
+	// mainnet, then we'll use the default mempool.space-style fee API as
+	// neutrino can't estimate fees on its own.
+	feeURL := cfg.FeeURL
+	if feeURL == "" && cfg.Litecoin.MainNet &&
+		homeChainConfig.Node == "neutrino" {
+
+		log.Infof("No --feeurl specified, using default fee API %v",
+			chainfee.DefaultMempoolSpaceURL)
+
+		feeURL = chainfee.DefaultMempoolSpaceURL
+	}
+
 	switch {
-	// If the fee URL isn't set, and the user is running mainnet, then
-	// we'll return an error to instruct them to set a proper fee
-	// estimator.
-	case cfg.FeeURL == "" && cfg.Litecoin.MainNet &&
-		homeChainConfig.Node == "neutrino":
+	// Use a custom fee estimator that was compiled into lnd. The backend
+	// estimator is handed to it so it can be used as a fallback.
+	case cfg.Fee != nil && cfg.Fee.Estimator != "":
+		if cfg.FeeURL != "" {
+			return nil, nil, fmt.Errorf("fee.estimator and feeurl " +
+				"are mutually exclusive")
+		}
 
-		return nil, nil, fmt.Errorf("--feeurl parameter required " +
-			"when running neutrino on mainnet")
+		log.Infof("Using custom fee estimator %v", cfg.Fee.Estimator)
+
+		estimator, err := chainfee.NewRegisteredEstimator(
+			cfg.Fee.Estimator, &chainfee.EstimatorConfig{
+				Backend: cc.FeeEstimator,
+				Args:    cfg.Fee.EstimatorArgs,
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		cc.FeeEstimator = estimator
+
+	// Override default fee estimator with fee rates read from a local
+	// file. The file is watched for changes, so fee rates can be updated
+	// at runtime by external tooling.
+	case strings.HasPrefix(feeURL, chainfee.FileURLPrefix):
+		feePath := strings.TrimPrefix(feeURL, chainfee.FileURLPrefix)
+
+		log.Infof("Using fee estimates from file %v", feePath)
+
+		cc.FeeEstimator = chainfee.NewFileEstimator(
+			feePath, cc.FeeEstimator,
+		)
 
 	// Override default fee estimator if an external service is specified.
-	case cfg.FeeURL != "":
+	// The backend estimator is kept as a fallback for when the service is
+	// unavailable.
+	case feeURL != "":
 		// Do not cache fees on regtest to make it easier to execute
 		// manual or automated test cases.
 		cacheFees := !cfg.Litecoin.RegTest
 
 		log.Infof("Using external fee estimator %v: cached=%v",
-			cfg.FeeURL, cacheFees)
+			feeURL, cacheFees)
 
 		cc.FeeEstimator = chainfee.NewWebAPIEstimator(
-			chainfee.SparseConfFeeSource{
-				URL: cfg.FeeURL,
-			},
-			!cacheFees,
+			chainfee.NewWebAPIFeeSource(feeURL), !cacheFees,
+			cc.FeeEstimator,
+		)
+	}
+
+	// Smooth the fee estimates if requested, so that short-lived spikes
+	// don't immediately trigger commitment fee updates.
+	if cfg.Fee != nil && cfg.Fee.SmoothingWindow > 0 {
+		log.Infof("Smoothing fee estimates over a window of %v",
+			cfg.Fee.SmoothingWindow)
+
+		cc.FeeEstimator = chainfee.NewSmoothingEstimator(
+			cc.FeeEstimator, cfg.Fee.SmoothingWindow,
+			clock.NewDefaultClock(),
 		)
 	}
 
@@ -751,6 +824,11 @@ func NewChainControl(walletConfig lnwallet.Config,
 		KeyRing:             walletConfig.SecretKeyRing,
 	}
 
+	cc.DeadlineEstimator = chainfee.NewDeadlineEstimator(
+		pcc.FeeEstimator, &chainIOBlockTimes{chainIO: cc.ChainIO},
+		pcc.Cfg.ActiveNetParams.TargetTimePerBlock,
+	)
+
 	ccCleanup := func() {
 		if cc.Wallet != nil {
 			if err := cc.Wallet.Shutdown(); err != nil {
@@ -775,6 +853,51 @@ func NewChainControl(walletConfig lnwallet.Config,
 	return cc, ccCleanup, nil
 }
 
+// chainIOBlockTimes is an implementation of the chainfee.BlockTimeSource
+// interface that fetches block timestamps through a BlockChainIO.
+type chainIOBlockTimes struct {
+	chainIO lnwallet.BlockChainIO
+}
+
+// RecentBlockTimes returns the timestamps of up to the last numBlocks blocks of
+// the best chain, ordered from oldest to newest.
+//
+// NOTE: Part of the chainfee.BlockTimeSource interface.
+func (c *chainIOBlockTimes) RecentBlockTimes(
+	numBlocks uint32) ([]time.Time, error) {
+
+	_, bestHeight, err := c.chainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	startHeight := int64(bestHeight) - int64(numBlocks) + 1
+	if startHeight < 0 {
+		startHeight = 0
+	}
+
+	times := make([]time.Time, 0, numBlocks)
+	for height := startHeight; height <= int64(bestHeight); height++ {
+		hash, err := c.chainIO.GetBlockHash(height)
+		if err != nil {
+			return nil, err
+		}
+
+		header, err := c.chainIO.GetBlockHeader(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		times = append(times, header.Timestamp)
+	}
+
+	return times, nil
+}
+
+// A compile-time assertion to ensure that chainIOBlockTimes implements the
+// chainfee.BlockTimeSource interface.
+var _ chainfee.BlockTimeSource = (*chainIOBlockTimes)(nil)
+
 // getBitcoindHealthCheckCmd queries bitcoind for its version to decide which
 // api we should use for our health check. We prefer to use the uptime
 // command, because it has no locking and is an inexpensive call, which was
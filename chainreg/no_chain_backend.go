@@ -16,9 +16,6 @@ import (
 )
 
 var (
-	// defaultFee is the fee that is returned by NoChainBackend.
-	defaultFee = chainfee.FeePerKwFloor
-
 	// noChainBackendName is the backend name returned by NoChainBackend.
 	noChainBackendName = "nochainbackend"
 
@@ -49,11 +46,11 @@ type NoChainBackend struct {
 func (n *NoChainBackend) EstimateFeePerKW(uint32) (chainfee.SatPerKWeight,
 	error) {
 
-	return defaultFee, nil
+	return chainfee.FeeFloor(), nil
 }
 
 func (n *NoChainBackend) RelayFeePerKW() chainfee.SatPerKWeight {
-	return defaultFee
+	return chainfee.FeeFloor()
 }
 
 func (n *NoChainBackend) RegisterConfirmationsNtfn(*chainhash.Hash, []byte,
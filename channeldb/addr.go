@@ -7,6 +7,7 @@ import (
 	"io"
 	"net"
 
+	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/tor"
 )
 
@@ -26,6 +27,9 @@ const (
 
 	// v3OnionAddr denotes a version 3 Tor (prop224) onion service address.
 	v3OnionAddr addressType = 3
+
+	// dnsAddr denotes a DNS hostname and port.
+	dnsAddr addressType = 4
 )
 
 // encodeTCPAddr serializes a TCP address into its compact raw bytes
@@ -121,6 +125,31 @@ func encodeOnionAddr(w io.Writer, addr *tor.OnionAddr) error {
 	return nil
 }
 
+// encodeDNSAddr serializes a DNS address into its compact raw bytes
+// representation.
+func encodeDNSAddr(w io.Writer, addr *lnwire.DNSAddress) error {
+	if err := addr.Validate(); err != nil {
+		return err
+	}
+
+	hostLen := []byte{byte(dnsAddr), byte(len(addr.Hostname))}
+	if _, err := w.Write(hostLen); err != nil {
+		return err
+	}
+
+	if _, err := w.Write([]byte(addr.Hostname)); err != nil {
+		return err
+	}
+
+	var port [2]byte
+	byteOrder.PutUint16(port[:], addr.Port)
+	if _, err := w.Write(port[:]); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // deserializeAddr reads the serialized raw representation of an address and
 // deserializes it into the actual address. This allows us to avoid address
 // resolution within the channeldb package.
@@ -200,6 +229,26 @@ func deserializeAddr(r io.Reader) (net.Addr, error) {
 			OnionService: onionService,
 			Port:         port,
 		}
+	case dnsAddr:
+		var hostLen [1]byte
+		if _, err := io.ReadFull(r, hostLen[:]); err != nil {
+			return nil, err
+		}
+
+		hostname := make([]byte, hostLen[0])
+		if _, err := io.ReadFull(r, hostname); err != nil {
+			return nil, err
+		}
+
+		var port [2]byte
+		if _, err := io.ReadFull(r, port[:]); err != nil {
+			return nil, err
+		}
+
+		address = &lnwire.DNSAddress{
+			Hostname: string(hostname),
+			Port:     binary.BigEndian.Uint16(port[:]),
+		}
 	default:
 		return nil, ErrUnknownAddressType
 	}
@@ -215,6 +264,8 @@ func serializeAddr(w io.Writer, address net.Addr) error {
 		return encodeTCPAddr(w, addr)
 	case *tor.OnionAddr:
 		return encodeOnionAddr(w, addr)
+	case *lnwire.DNSAddress:
+		return encodeDNSAddr(w, addr)
 	default:
 		return ErrUnknownAddressType
 	}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/tor"
 )
 
@@ -46,6 +47,12 @@ var addrTests = []struct {
 			Port:         80,
 		},
 	},
+	{
+		expAddr: &lnwire.DNSAddress{
+			Hostname: "node.example.com",
+			Port:     9735,
+		},
+	},
 
 	// Invalid addresses.
 	{
@@ -108,6 +115,14 @@ var addrTests = []struct {
 		},
 		serErr: "illegal base32",
 	},
+	{
+		expAddr: &lnwire.DNSAddress{
+			// Invalid hostname character.
+			Hostname: "node_1.example.com",
+			Port:     9735,
+		},
+		serErr: "invalid character",
+	},
 }
 
 // TestAddrSerialization tests that the serialization method used by channeldb
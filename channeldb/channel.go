@@ -2224,6 +2224,46 @@ type HTLC struct {
 	ExtraData []byte
 }
 
+// BlindingPoint returns the blinding point that was provided with the HTLC in
+// update_add_htlc, which is read from the HTLC's extra data. It is nil if the
+// HTLC isn't relayed within a blinded route.
+func (h *HTLC) BlindingPoint() (*btcec.PublicKey, error) {
+	var (
+		extraData     = lnwire.ExtraOpaqueData(h.ExtraData)
+		blindingPoint lnwire.BlindingPoint
+	)
+	typeMap, err := extraData.ExtractRecords(&blindingPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	val, ok := typeMap[lnwire.BlindingPointRecordType]
+	if !ok || val != nil {
+		return nil, nil
+	}
+
+	key := btcec.PublicKey(blindingPoint)
+
+	return &key, nil
+}
+
+// SetBlindingPoint stores the blinding point that was provided with the HTLC
+// in update_add_htlc in the HTLC's extra data.
+func (h *HTLC) SetBlindingPoint(key *btcec.PublicKey) error {
+	if key == nil {
+		return nil
+	}
+
+	var extraData lnwire.ExtraOpaqueData
+	blindingPoint := lnwire.BlindingPoint(*key)
+	if err := extraData.PackRecords(&blindingPoint); err != nil {
+		return err
+	}
+	h.ExtraData = extraData
+
+	return nil
+}
+
 // SerializeHtlcs writes out the passed set of HTLC's into the passed writer
 // using the current default on-disk serialization format.
 //
@@ -1606,6 +1606,39 @@ func TestHTLCsExtraData(t *testing.T) {
 	}
 }
 
+// TestHTLCBlindingPoint tests that the blinding point of an HTLC survives
+// the serialization of the HTLC.
+func TestHTLCBlindingPoint(t *testing.T) {
+	t.Parallel()
+
+	htlc := HTLC{
+		Signature:     testSig.Serialize(),
+		Incoming:      true,
+		Amt:           10,
+		RHash:         key,
+		RefundTimeout: 1,
+		OnionBlob:     lnmock.MockOnion(),
+	}
+
+	// An HTLC without extra data isn't relayed within a blinded route.
+	blindingPoint, err := htlc.BlindingPoint()
+	require.NoError(t, err)
+	require.Nil(t, blindingPoint)
+
+	require.NoError(t, htlc.SetBlindingPoint(pubKey))
+
+	var b bytes.Buffer
+	require.NoError(t, SerializeHtlcs(&b, htlc))
+
+	htlcs, err := DeserializeHtlcs(bytes.NewReader(b.Bytes()))
+	require.NoError(t, err)
+	require.Len(t, htlcs, 1)
+
+	blindingPoint, err = htlcs[0].BlindingPoint()
+	require.NoError(t, err)
+	require.True(t, pubKey.IsEqual(blindingPoint))
+}
+
 // TestOnionBlobIncorrectLength tests HTLC deserialization in the case where
 // the OnionBlob saved on disk is of an unexpected length. This error case is
 // only expected in the case of database corruption (or some severe protocol
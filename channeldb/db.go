@@ -7,6 +7,7 @@ import (
 	"net"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-errors/errors"
 	mig "github.com/ltcsuite/lnd/channeldb/migration"
@@ -23,6 +24,8 @@ import (
 	"github.com/ltcsuite/lnd/channeldb/migration29"
 	"github.com/ltcsuite/lnd/channeldb/migration30"
 	"github.com/ltcsuite/lnd/channeldb/migration31"
+	"github.com/ltcsuite/lnd/channeldb/migration32"
+	"github.com/ltcsuite/lnd/channeldb/migration33"
 	"github.com/ltcsuite/lnd/channeldb/migration_01_to_11"
 	"github.com/ltcsuite/lnd/clock"
 	"github.com/ltcsuite/lnd/kvdb"
@@ -285,6 +288,18 @@ var (
 			number:    31,
 			migration: migration31.DeleteLastPublishedTxTLB,
 		},
+		{
+			// Removes the top-level buckets of the utxo nursery,
+			// whose duties were taken over by the resolvers.
+			number:    32,
+			migration: migration32.DeleteNurseryTLBs,
+		},
+		{
+			// Wraps the resolvers stored in the arbitrator logs
+			// in versioned TLV records.
+			number:    33,
+			migration: migration33.MigrateResolverRecords,
+		},
 	}
 
 	// optionalVersions stores all optional migrations that are applied
@@ -330,9 +345,21 @@ type DB struct {
 	keepFailedPaymentAttempts bool
 	storeFinalHtlcResolutions bool
 
+	// storeHtlcFwdEvents determines whether the final outcome of every
+	// forwarded htlc is stored in the htlc forwarding event log.
+	storeHtlcFwdEvents bool
+
+	// htlcFwdEventRetention is the duration htlc forwarding events are
+	// kept for. If zero, they are never pruned.
+	htlcFwdEventRetention time.Duration
+
 	// noRevLogAmtData if true, means that commitment transaction amount
 	// data should not be stored in the revocation log.
 	noRevLogAmtData bool
+
+	// writeCoalescer, if non-nil, coalesces the writes of the forwarding
+	// log and the htlc forwarding log into periodic transactions.
+	writeCoalescer *kvdb.WriteCoalescer
 }
 
 // Open opens or creates channeldb. Any necessary schemas migrations due
@@ -391,6 +418,8 @@ func CreateWithBackend(backend kvdb.Backend,
 		dryRun:                    opts.dryRun,
 		keepFailedPaymentAttempts: opts.keepFailedPaymentAttempts,
 		storeFinalHtlcResolutions: opts.storeFinalHtlcResolutions,
+		storeHtlcFwdEvents:        opts.storeHtlcFwdEvents,
+		htlcFwdEventRetention:     opts.htlcFwdEventRetention,
 		noRevLogAmtData:           opts.NoRevLogAmtData,
 	}
 
@@ -422,9 +451,31 @@ func CreateWithBackend(backend kvdb.Backend,
 		}
 	}
 
+	// Writes of the forwarding logs are only coalesced if a flush
+	// interval is configured.
+	if opts.WriteFlushInterval > 0 {
+		chanDB.writeCoalescer = kvdb.NewWriteCoalescer(
+			backend, opts.WriteFlushInterval,
+			kvdb.DefaultCoalescerMaxPending,
+		)
+		chanDB.writeCoalescer.Start()
+	}
+
 	return chanDB, nil
 }
 
+// Close flushes all coalesced writes and closes the underlying database
+// backend.
+func (d *DB) Close() error {
+	if d.writeCoalescer != nil {
+		if err := d.writeCoalescer.Stop(); err != nil {
+			log.Errorf("Unable to flush coalesced writes: %v", err)
+		}
+	}
+
+	return d.Backend.Close()
+}
+
 // Path returns the file path to the channel database.
 func (d *DB) Path() string {
 	return d.dbPath
@@ -434,6 +485,7 @@ var dbTopLevelBuckets = [][]byte{
 	openChannelBucket,
 	closedChannelBucket,
 	forwardingLogBucket,
+	htlcFwdEventLogBucket,
 	fwdPackagesKey,
 	invoiceBucket,
 	payAddrIndexBucket,
@@ -101,7 +101,9 @@ func decodeForwardingEvent(r io.Reader, f *ForwardingEvent) error {
 
 // AddForwardingEvents adds a series of forwarding events to the database.
 // Before inserting, the set of events will be sorted according to their
-// timestamp. This ensures that all writes to disk are sequential.
+// timestamp. This ensures that all writes to disk are sequential. If write
+// coalescing is enabled, the events are only queued and are written with the
+// next flush.
 func (f *ForwardingLog) AddForwardingEvents(events []ForwardingEvent) error {
 	// Before we create the database transaction, we'll ensure that the set
 	// of forwarding events are properly sorted according to their
@@ -111,7 +113,7 @@ func (f *ForwardingLog) AddForwardingEvents(events []ForwardingEvent) error {
 
 	var timestamp [8]byte
 
-	return kvdb.Batch(f.db.Backend, func(tx kvdb.RwTx) error {
+	update := func(tx kvdb.RwTx) error {
 		// First, we'll fetch the bucket that stores our time series
 		// log.
 		logBucket, err := tx.CreateTopLevelBucket(
@@ -131,7 +133,15 @@ func (f *ForwardingLog) AddForwardingEvents(events []ForwardingEvent) error {
 		}
 
 		return nil
-	})
+	}
+
+	// The coalescer logs failed writes, so we don't wait for the result.
+	if f.db.writeCoalescer != nil {
+		f.db.writeCoalescer.Queue(update)
+		return nil
+	}
+
+	return kvdb.Batch(f.db.Backend, update)
 }
 
 // storeEvent tries to store a forwarding event into the given bucket by trying
@@ -141,10 +151,31 @@ func (f *ForwardingLog) AddForwardingEvents(events []ForwardingEvent) error {
 func storeEvent(bucket walletdb.ReadWriteBucket, event ForwardingEvent,
 	timestampScratchSpace []byte) error {
 
+	event.Timestamp = nextFreeTimestamp(
+		bucket, event.Timestamp, timestampScratchSpace,
+	)
+
+	// With the key encoded, we'll then encode the event
+	// into our buffer, then write it out to disk.
+	var eventBytes [forwardingEventSize]byte
+	eventBuf := bytes.NewBuffer(eventBytes[0:0:forwardingEventSize])
+	err := encodeForwardingEvent(eventBuf, &event)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(timestampScratchSpace, eventBuf.Bytes())
+}
+
+// nextFreeTimestamp finds the first timestamp at or after the given one that
+// isn't used as a key in the given bucket yet. The key of the returned
+// timestamp is written to the passed scratch space.
+func nextFreeTimestamp(bucket walletdb.ReadBucket, timestamp time.Time,
+	timestampScratchSpace []byte) time.Time {
+
 	// First, we'll serialize this timestamp into our
 	// timestamp buffer.
 	byteOrder.PutUint64(
-		timestampScratchSpace, uint64(event.Timestamp.UnixNano()),
+		timestampScratchSpace, uint64(timestamp.UnixNano()),
 	)
 
 	// Next we'll loop until we find a "free" slot in the bucket to store
@@ -166,21 +197,13 @@ func storeEvent(bucket walletdb.ReadWriteBucket, event ForwardingEvent,
 		}
 
 		// Collision, try the next nanosecond timestamp.
-		nextNano := event.Timestamp.UnixNano() + 1
-		event.Timestamp = time.Unix(0, nextNano)
+		nextNano := timestamp.UnixNano() + 1
+		timestamp = time.Unix(0, nextNano)
 		byteOrder.PutUint64(timestampScratchSpace, uint64(nextNano))
 		tries++
 	}
 
-	// With the key encoded, we'll then encode the event
-	// into our buffer, then write it out to disk.
-	var eventBytes [forwardingEventSize]byte
-	eventBuf := bytes.NewBuffer(eventBytes[0:0:forwardingEventSize])
-	err := encodeForwardingEvent(eventBuf, &event)
-	if err != nil {
-		return err
-	}
-	return bucket.Put(timestampScratchSpace, eventBuf.Bytes())
+	return timestamp
 }
 
 // ForwardingEventQuery represents a query to the forwarding log payment
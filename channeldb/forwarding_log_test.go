@@ -360,3 +360,39 @@ func TestForwardingLogStoreEvent(t *testing.T) {
 		}
 	}
 }
+
+// TestForwardingLogWriteCoalescing tests that forwarding events are only
+// written with the next flush if write coalescing is enabled.
+func TestForwardingLogWriteCoalescing(t *testing.T) {
+	t.Parallel()
+
+	db, err := MakeTestDB(t, OptionSetWriteFlushInterval(time.Hour))
+	require.NoError(t, err, "unable to make test db")
+
+	log := db.ForwardingLog()
+
+	timestamp := time.Unix(1234, 0)
+	events := []ForwardingEvent{{
+		Timestamp:      timestamp,
+		IncomingChanID: lnwire.NewShortChanIDFromInt(1),
+		OutgoingChanID: lnwire.NewShortChanIDFromInt(2),
+		AmtIn:          lnwire.MilliSatoshi(2000),
+		AmtOut:         lnwire.MilliSatoshi(1000),
+	}}
+	require.NoError(t, log.AddForwardingEvents(events))
+
+	eventQuery := ForwardingEventQuery{
+		StartTime:    timestamp,
+		EndTime:      timestamp.Add(time.Minute),
+		NumMaxEvents: 10,
+	}
+	timeSlice, err := log.Query(eventQuery)
+	require.NoError(t, err)
+	require.Empty(t, timeSlice.ForwardingEvents)
+
+	require.NoError(t, db.writeCoalescer.Flush())
+
+	timeSlice, err = log.Query(eventQuery)
+	require.NoError(t, err)
+	require.Equal(t, events, timeSlice.ForwardingEvents)
+}
@@ -16,10 +16,12 @@ import (
 
 	"github.com/ltcsuite/lnd/aliasmgr"
 	"github.com/ltcsuite/lnd/batch"
+	"github.com/ltcsuite/lnd/channeldb/models"
 	"github.com/ltcsuite/lnd/input"
 	"github.com/ltcsuite/lnd/kvdb"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/routing/route"
+	"github.com/ltcsuite/lnd/tlv"
 	"github.com/ltcsuite/ltcd/btcec/v2"
 	"github.com/ltcsuite/ltcd/btcec/v2/ecdsa"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
@@ -124,6 +126,14 @@ var (
 	// edge's participants.
 	zombieBucket = []byte("zombie-index")
 
+	// excessChanBucket is a sub-bucket of the main edgeBucket bucket that
+	// holds the channels within the zombie index that were pruned because
+	// one of their nodes exceeded the per-node channel limit. Each entry
+	// exists within the bucket as follows:
+	//
+	// maps: chanID -> nil
+	excessChanBucket = []byte("excess-chan-index")
+
 	// disabledEdgePolicyBucket is a sub-bucket of the main edgeBucket bucket
 	// responsible for maintaining an index of disabled edge policies. Each
 	// entry exists within the bucket as follows:
@@ -268,6 +278,7 @@ func (c *ChannelGraph) getChannelMap(edges kvdb.RBucket) (
 		if bytes.Equal(k, edgeIndexBucket) ||
 			bytes.Equal(k, edgeUpdateIndexBucket) ||
 			bytes.Equal(k, zombieBucket) ||
+			bytes.Equal(k, excessChanBucket) ||
 			bytes.Equal(k, disabledEdgePolicyBucket) ||
 			bytes.Equal(k, channelPointBucket) {
 
@@ -486,11 +497,17 @@ func (c *ChannelGraph) ForEachNodeChannel(tx kvdb.RTx, node route.Vertex,
 	dbCallback := func(tx kvdb.RTx, e *ChannelEdgeInfo, p1,
 		p2 *ChannelEdgePolicy) error {
 
+		var inboundFee models.InboundFee
+		if p1 != nil {
+			inboundFee = p1.InboundFee()
+		}
+
 		var cachedInPolicy *CachedEdgePolicy
 		if p2 != nil {
 			cachedInPolicy = NewCachedPolicy(p2)
 			cachedInPolicy.ToNodePubKey = toNodeCallback
 			cachedInPolicy.ToNodeFeatures = toNodeFeatures
+			cachedInPolicy.InboundFee = inboundFee
 		}
 
 		directedChannel := &DirectedChannel{
@@ -499,6 +516,7 @@ func (c *ChannelGraph) ForEachNodeChannel(tx kvdb.RTx, node route.Vertex,
 			OtherNode:    e.NodeKey2Bytes,
 			Capacity:     e.Capacity,
 			OutPolicySet: p1 != nil,
+			InboundFee:   inboundFee,
 			InPolicy:     cachedInPolicy,
 		}
 
@@ -571,11 +589,17 @@ func (c *ChannelGraph) ForEachNodeCached(cb func(node route.Vertex,
 				return err
 			}
 
+			var inboundFee models.InboundFee
+			if p1 != nil {
+				inboundFee = p1.InboundFee()
+			}
+
 			var cachedInPolicy *CachedEdgePolicy
 			if p2 != nil {
 				cachedInPolicy := NewCachedPolicy(p2)
 				cachedInPolicy.ToNodePubKey = toNodeCallback
 				cachedInPolicy.ToNodeFeatures = toNodeFeatures
+				cachedInPolicy.InboundFee = inboundFee
 			}
 
 			directedChannel := &DirectedChannel{
@@ -584,6 +608,7 @@ func (c *ChannelGraph) ForEachNodeCached(cb func(node route.Vertex,
 				OtherNode:    e.NodeKey2Bytes,
 				Capacity:     e.Capacity,
 				OutPolicySet: p1 != nil,
+				InboundFee:   inboundFee,
 				InPolicy:     cachedInPolicy,
 			}
 
@@ -1708,35 +1733,50 @@ func (c *ChannelGraph) DeleteChannelEdges(strictZombiePruning, markZombie bool,
 	defer c.cacheMu.Unlock()
 
 	err := kvdb.Update(c.db, func(tx kvdb.RwTx) error {
-		edges := tx.ReadWriteBucket(edgeBucket)
-		if edges == nil {
-			return ErrEdgeNotFound
-		}
-		edgeIndex := edges.NestedReadWriteBucket(edgeIndexBucket)
-		if edgeIndex == nil {
-			return ErrEdgeNotFound
-		}
-		chanIndex := edges.NestedReadWriteBucket(channelPointBucket)
-		if chanIndex == nil {
-			return ErrEdgeNotFound
-		}
-		nodes := tx.ReadWriteBucket(nodeBucket)
-		if nodes == nil {
-			return ErrGraphNodeNotFound
+		return c.deleteChannelEdges(
+			tx, strictZombiePruning, markZombie, chanIDs...,
+		)
+	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	for _, chanID := range chanIDs {
+		c.rejectCache.remove(chanID)
+		c.chanCache.remove(chanID)
+	}
+
+	return nil
+}
+
+// DeleteExcessChannelEdges removes the edges with the given channel IDs from
+// the database because one of their nodes exceeds the per-node channel limit.
+// The channels are marked as zombies so they aren't added back through gossip
+// and are also added to the excess channel index, which allows them to be
+// told apart from regular zombies. Unlike regular zombies, either of their
+// nodes is recorded in the zombie index.
+func (c *ChannelGraph) DeleteExcessChannelEdges(chanIDs ...uint64) error {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	err := kvdb.Update(c.db, func(tx kvdb.RwTx) error {
+		err := c.deleteChannelEdges(tx, false, true, chanIDs...)
+		if err != nil {
+			return err
 		}
-		zombieIndex, err := edges.CreateBucketIfNotExists(zombieBucket)
+
+		edges := tx.ReadWriteBucket(edgeBucket)
+		excessIndex, err := edges.CreateBucketIfNotExists(
+			excessChanBucket,
+		)
 		if err != nil {
 			return err
 		}
 
-		var rawChanID [8]byte
+		var k [8]byte
 		for _, chanID := range chanIDs {
-			byteOrder.PutUint64(rawChanID[:], chanID)
-			err := c.delChannelEdge(
-				edges, edgeIndex, chanIndex, zombieIndex, nodes,
-				rawChanID[:], markZombie, strictZombiePruning,
-			)
-			if err != nil {
+			byteOrder.PutUint64(k[:], chanID)
+			if err := excessIndex.Put(k[:], []byte{}); err != nil {
 				return err
 			}
 		}
@@ -1755,6 +1795,47 @@ func (c *ChannelGraph) DeleteChannelEdges(strictZombiePruning, markZombie bool,
 	return nil
 }
 
+// deleteChannelEdges removes the edges with the given channel IDs within the
+// given transaction, marking them as zombies if requested.
+func (c *ChannelGraph) deleteChannelEdges(tx kvdb.RwTx, strictZombiePruning,
+	markZombie bool, chanIDs ...uint64) error {
+
+	edges := tx.ReadWriteBucket(edgeBucket)
+	if edges == nil {
+		return ErrEdgeNotFound
+	}
+	edgeIndex := edges.NestedReadWriteBucket(edgeIndexBucket)
+	if edgeIndex == nil {
+		return ErrEdgeNotFound
+	}
+	chanIndex := edges.NestedReadWriteBucket(channelPointBucket)
+	if chanIndex == nil {
+		return ErrEdgeNotFound
+	}
+	nodes := tx.ReadWriteBucket(nodeBucket)
+	if nodes == nil {
+		return ErrGraphNodeNotFound
+	}
+	zombieIndex, err := edges.CreateBucketIfNotExists(zombieBucket)
+	if err != nil {
+		return err
+	}
+
+	var rawChanID [8]byte
+	for _, chanID := range chanIDs {
+		byteOrder.PutUint64(rawChanID[:], chanID)
+		err := c.delChannelEdge(
+			edges, edgeIndex, chanIndex, zombieIndex, nodes,
+			rawChanID[:], markZombie, strictZombiePruning,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ChannelID attempt to lookup the 8-byte compact channel ID which maps to the
 // passed channel point (outpoint). If the passed channel doesn't exist within
 // the database, then ErrEdgeNotFound is returned.
@@ -3483,6 +3564,72 @@ func (c *ChannelEdgePolicy) IsDisabled() bool {
 	return c.ChannelFlags.IsDisabled()
 }
 
+// InboundFee returns the inbound fee that the node charges for htlcs that
+// arrive over the channel, as encoded in the extra data of its policy. A zero
+// fee is returned if the policy doesn't contain a valid inbound fee.
+func (c *ChannelEdgePolicy) InboundFee() models.InboundFee {
+	var fee lnwire.Fee
+
+	extraData := lnwire.ExtraOpaqueData(c.ExtraOpaqueData)
+	tlvs, err := extraData.ExtractRecords(&fee)
+	if err != nil {
+		return models.InboundFee{}
+	}
+
+	if _, ok := tlvs[lnwire.FeeRecordType]; !ok {
+		return models.InboundFee{}
+	}
+
+	return models.NewInboundFeeFromWire(fee)
+}
+
+// SetInboundFee encodes the inbound fee in the extra data of the policy. A
+// zero fee removes the inbound fee from the policy. Any other records in the
+// extra data are preserved.
+func (c *ChannelEdgePolicy) SetInboundFee(inboundFee models.InboundFee) error {
+	// Parse the records that are currently stored in the extra data, so
+	// that we only replace the inbound fee record.
+	extraData := lnwire.ExtraOpaqueData(c.ExtraOpaqueData)
+	tlvs, err := extraData.ExtractRecords()
+	if err != nil {
+		return fmt.Errorf("unable to parse extra data: %w", err)
+	}
+
+	rawRecords := make(map[uint64][]byte, len(tlvs))
+	for typ, value := range tlvs {
+		if typ == lnwire.FeeRecordType {
+			continue
+		}
+
+		rawRecords[uint64(typ)] = value
+	}
+
+	records := tlv.MapToRecords(rawRecords)
+	if !inboundFee.IsZero() {
+		fee := inboundFee.ToWire()
+		records = append(records, fee.Record())
+	}
+
+	if len(records) == 0 {
+		c.ExtraOpaqueData = nil
+		return nil
+	}
+
+	tlv.SortRecords(records)
+	tlvStream, err := tlv.NewStream(records...)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	if err := tlvStream.Encode(&b); err != nil {
+		return err
+	}
+	c.ExtraOpaqueData = b.Bytes()
+
+	return nil
+}
+
 // ComputeFee computes the fee to forward an HTLC of `amt` milli-satoshis over
 // the passed active payment channel. This value is currently computed as
 // specified in BOLT07, but will likely change in the near future.
@@ -3908,6 +4055,16 @@ func (c *ChannelGraph) MarkEdgeLive(chanID uint64) error {
 
 		var k [8]byte
 		byteOrder.PutUint64(k[:], chanID)
+
+		// A channel that was pruned for exceeding the per-node channel
+		// limit is a regular channel again once it's live.
+		excessIndex := edges.NestedReadWriteBucket(excessChanBucket)
+		if excessIndex != nil {
+			if err := excessIndex.Delete(k[:]); err != nil {
+				return err
+			}
+		}
+
 		return zombieIndex.Delete(k[:])
 	}, func() {})
 	if err != nil {
@@ -3989,6 +4146,78 @@ func isZombieEdge(zombieIndex kvdb.RBucket,
 	return true, pubKey1, pubKey2
 }
 
+// IsExcessChan returns whether the channel with the given ID was pruned
+// because one of its nodes exceeded the per-node channel limit and hasn't been
+// marked as live again since.
+func (c *ChannelGraph) IsExcessChan(chanID uint64) (bool, error) {
+	var isExcess bool
+	err := kvdb.View(c.db, func(tx kvdb.RTx) error {
+		edges := tx.ReadBucket(edgeBucket)
+		if edges == nil {
+			return nil
+		}
+		excessIndex := edges.NestedReadBucket(excessChanBucket)
+		if excessIndex == nil {
+			return nil
+		}
+
+		var k [8]byte
+		byteOrder.PutUint64(k[:], chanID)
+		isExcess = excessIndex.Get(k[:]) != nil
+
+		return nil
+	}, func() {
+		isExcess = false
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return isExcess, nil
+}
+
+// ExcessChans returns the channels that were pruned because one of their nodes
+// exceeded the per-node channel limit, mapped to the public keys of their two
+// nodes.
+func (c *ChannelGraph) ExcessChans() (map[uint64][2][33]byte, error) {
+	excessChans := make(map[uint64][2][33]byte)
+	err := kvdb.View(c.db, func(tx kvdb.RTx) error {
+		edges := tx.ReadBucket(edgeBucket)
+		if edges == nil {
+			return nil
+		}
+		excessIndex := edges.NestedReadBucket(excessChanBucket)
+		if excessIndex == nil {
+			return nil
+		}
+		zombieIndex := edges.NestedReadBucket(zombieBucket)
+		if zombieIndex == nil {
+			return nil
+		}
+
+		return excessIndex.ForEach(func(k, _ []byte) error {
+			chanID := byteOrder.Uint64(k)
+			isZombie, pubKey1, pubKey2 := isZombieEdge(
+				zombieIndex, chanID,
+			)
+			if !isZombie {
+				return nil
+			}
+
+			excessChans[chanID] = [2][33]byte{pubKey1, pubKey2}
+
+			return nil
+		})
+	}, func() {
+		excessChans = make(map[uint64][2][33]byte)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return excessChans, nil
+}
+
 // NumZombies returns the current number of zombie channels in the graph.
 func (c *ChannelGraph) NumZombies() (uint64, error) {
 	var numZombies uint64
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/ltcsuite/lnd/channeldb/models"
 	"github.com/ltcsuite/lnd/kvdb"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/routing/route"
@@ -81,6 +82,12 @@ type CachedEdgePolicy struct {
 	// the edge is in the cache, only on the copy that is returned in
 	// ForEachChannel().
 	ToNodeFeatures *lnwire.FeatureVector
+
+	// InboundFee is the inbound fee that the to node charges for htlcs
+	// arriving over this channel. It is part of the to node's own policy
+	// of the channel, which is why it's never set while the edge is in the
+	// cache, only on the copy that is returned in ForEachChannel().
+	InboundFee models.InboundFee
 }
 
 // ComputeFee computes the fee to forward an HTLC of `amt` milli-satoshis over
@@ -138,6 +145,10 @@ type DirectedChannel struct {
 	// is important to know, not the actual content.
 	OutPolicySet bool
 
+	// InboundFee is the inbound fee that the node charges for htlcs
+	// arriving over this channel, as advertised in its outgoing policy.
+	InboundFee models.InboundFee
+
 	// InPolicy is the incoming policy *from* the other node to this node.
 	// In path finding, we're walking backward from the destination to the
 	// source, so we're always interested in the edge that arrives to us
@@ -159,6 +170,7 @@ func (c *DirectedChannel) DeepCopy() *DirectedChannel {
 		// be sure they don't contain any old data.
 		channelCopy.InPolicy.ToNodePubKey = nil
 		channelCopy.InPolicy.ToNodeFeatures = nil
+		channelCopy.InPolicy.InboundFee = models.InboundFee{}
 	}
 
 	return &channelCopy
@@ -324,11 +336,13 @@ func (c *GraphCache) UpdatePolicy(policy *ChannelEdgePolicy, fromNode,
 		// policy for node 1.
 		case channel.IsNode1 && edge1:
 			channel.OutPolicySet = true
+			channel.InboundFee = policy.InboundFee()
 
 		// This is node 2, and it is edge 2, so this is the outgoing
 		// policy for node 2.
 		case !channel.IsNode1 && !edge1:
 			channel.OutPolicySet = true
+			channel.InboundFee = policy.InboundFee()
 
 		// The other two cases left mean it's the inbound policy for the
 		// node.
@@ -442,6 +456,7 @@ func (c *GraphCache) getChannels(node route.Vertex) []*DirectedChannel {
 		if channelCopy.InPolicy != nil {
 			channelCopy.InPolicy.ToNodePubKey = toNodeCallback
 			channelCopy.InPolicy.ToNodeFeatures = features
+			channelCopy.InPolicy.InboundFee = channelCopy.InboundFee
 		}
 
 		channelsCopy[i] = channelCopy
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/ltcsuite/lnd/channeldb/models"
 	"github.com/ltcsuite/lnd/kvdb"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/routing/route"
@@ -3116,6 +3117,66 @@ func TestGraphZombieIndex(t *testing.T) {
 	assertNumZombies(t, graph, 1)
 }
 
+// TestGraphExcessChanIndex tests that channels pruned for exceeding the
+// per-node channel limit are tracked in the excess index until they are
+// marked as live again.
+func TestGraphExcessChanIndex(t *testing.T) {
+	t.Parallel()
+
+	graph, err := MakeTestGraph(t)
+	require.NoError(t, err, "unable to create test database")
+
+	node1, err := createTestVertex(graph.db)
+	require.NoError(t, err, "unable to create test vertex")
+	node2, err := createTestVertex(graph.db)
+	require.NoError(t, err, "unable to create test vertex")
+
+	if bytes.Compare(node2.PubKeyBytes[:], node1.PubKeyBytes[:]) < 0 {
+		node1, node2 = node2, node1
+	}
+
+	edge, _, _ := createChannelEdge(graph.db, node1, node2)
+	require.NoError(t, graph.AddChannelEdge(edge))
+
+	// A regular zombie isn't reported as an excess channel.
+	require.NoError(t, graph.DeleteChannelEdges(false, true, edge.ChannelID))
+	isExcess, err := graph.IsExcessChan(edge.ChannelID)
+	require.NoError(t, err)
+	require.False(t, isExcess)
+	require.NoError(t, graph.MarkEdgeLive(edge.ChannelID))
+
+	// Deleting the channel as an excess channel marks it as a zombie that
+	// either of its nodes is recorded for.
+	require.NoError(t, graph.AddChannelEdge(edge))
+	require.NoError(t, graph.DeleteExcessChannelEdges(edge.ChannelID))
+
+	isZombie, _, _ := graph.IsZombieEdge(edge.ChannelID)
+	require.True(t, isZombie)
+	isExcess, err = graph.IsExcessChan(edge.ChannelID)
+	require.NoError(t, err)
+	require.True(t, isExcess)
+
+	excessChans, err := graph.ExcessChans()
+	require.NoError(t, err)
+	require.Equal(t, map[uint64][2][33]byte{
+		edge.ChannelID: {node1.PubKeyBytes, node2.PubKeyBytes},
+	}, excessChans)
+
+	// Once the channel is marked as live, it's neither a zombie nor an
+	// excess channel anymore.
+	require.NoError(t, graph.MarkEdgeLive(edge.ChannelID))
+
+	isZombie, _, _ = graph.IsZombieEdge(edge.ChannelID)
+	require.False(t, isZombie)
+	isExcess, err = graph.IsExcessChan(edge.ChannelID)
+	require.NoError(t, err)
+	require.False(t, isExcess)
+
+	excessChans, err = graph.ExcessChans()
+	require.NoError(t, err)
+	require.Empty(t, excessChans)
+}
+
 // compareNodes is used to compare two LightningNodes while excluding the
 // Features struct, which cannot be compared as the semantics for reserializing
 // the featuresMap have not been defined.
@@ -3554,3 +3615,44 @@ func TestGraphLoading(t *testing.T) {
 		graphReloaded.graphCache.nodeFeatures,
 	)
 }
+
+// TestChannelEdgePolicyInboundFee tests that setting the inbound fee of a
+// policy preserves the other records in its extra data.
+func TestChannelEdgePolicyInboundFee(t *testing.T) {
+	t.Parallel()
+
+	// The policy starts out with an unrelated record of type 1 in its
+	// extra data.
+	otherRecord := []byte{0x01, 0x02, 0xab, 0xcd}
+	policy := &ChannelEdgePolicy{
+		ExtraOpaqueData: otherRecord,
+	}
+	require.Zero(t, policy.InboundFee())
+
+	// Setting an inbound fee adds its record next to the existing one.
+	inboundFee := models.InboundFee{
+		Base: -1000,
+		Rate: -200,
+	}
+	require.NoError(t, policy.SetInboundFee(inboundFee))
+	require.Equal(t, inboundFee, policy.InboundFee())
+	require.True(t, bytes.HasPrefix(policy.ExtraOpaqueData, otherRecord))
+
+	// Updating the inbound fee replaces the existing fee record.
+	inboundFee.Rate = -100
+	require.NoError(t, policy.SetInboundFee(inboundFee))
+	require.Equal(t, inboundFee, policy.InboundFee())
+	require.True(t, bytes.HasPrefix(policy.ExtraOpaqueData, otherRecord))
+
+	// Removing the inbound fee leaves only the unrelated record.
+	require.NoError(t, policy.SetInboundFee(models.InboundFee{}))
+	require.Zero(t, policy.InboundFee())
+	require.Equal(t, otherRecord, policy.ExtraOpaqueData)
+
+	// Without any other records, removing the inbound fee clears the
+	// extra data.
+	policy.ExtraOpaqueData = nil
+	require.NoError(t, policy.SetInboundFee(inboundFee))
+	require.NoError(t, policy.SetInboundFee(models.InboundFee{}))
+	require.Empty(t, policy.ExtraOpaqueData)
+}
@@ -0,0 +1,304 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/ltcsuite/lnd/kvdb"
+	"github.com/ltcsuite/lnd/lnwire"
+)
+
+var (
+	// htlcFwdEventLogBucket is the bucket that stores the final outcome of
+	// every forwarded htlc, settled or failed. Like the forwarding log,
+	// each key is a timestamp (in nano seconds since the unix epoch), but
+	// the value is a single htlc forwarding event.
+	htlcFwdEventLogBucket = []byte("htlc-fwd-event-log")
+)
+
+// HtlcForwardOutcome describes the final outcome of a forwarded htlc.
+type HtlcForwardOutcome uint8
+
+const (
+	// HtlcForwardSettled indicates that the forwarded htlc was settled.
+	HtlcForwardSettled HtlcForwardOutcome = 0
+
+	// HtlcForwardFailed indicates that the forwarded htlc was failed,
+	// either by our node or further down the route.
+	HtlcForwardFailed HtlcForwardOutcome = 1
+)
+
+// String returns a human readable representation of the outcome.
+func (o HtlcForwardOutcome) String() string {
+	switch o {
+	case HtlcForwardSettled:
+		return "settled"
+
+	case HtlcForwardFailed:
+		return "failed"
+
+	default:
+		return "unknown"
+	}
+}
+
+// HtlcForwardEvent records the final outcome of a single forwarded htlc. In
+// contrast to the ForwardingEvent, which is only logged for settled circuits,
+// an htlc forwarding event is also logged for failed forwards, along with the
+// reason of the failure if it happened at our node.
+type HtlcForwardEvent struct {
+	// Timestamp is the time the outcome of the htlc was known.
+	Timestamp time.Time
+
+	// IncomingChanID is the channel the htlc arrived on.
+	IncomingChanID lnwire.ShortChannelID
+
+	// IncomingHtlcID is the id of the htlc on the incoming channel.
+	IncomingHtlcID uint64
+
+	// OutgoingChanID is the channel the htlc was forwarded over. For htlcs
+	// that failed before being added to the outgoing channel, this is the
+	// channel requested by the sender.
+	OutgoingChanID lnwire.ShortChannelID
+
+	// OutgoingHtlcID is the id of the htlc on the outgoing channel. It is
+	// nil if the htlc was never added to the outgoing channel.
+	OutgoingHtlcID *uint64
+
+	// AmtIn is the amount of the incoming htlc.
+	AmtIn lnwire.MilliSatoshi
+
+	// AmtOut is the amount of the outgoing htlc.
+	AmtOut lnwire.MilliSatoshi
+
+	// Outcome is the final outcome of the htlc.
+	Outcome HtlcForwardOutcome
+
+	// FailureCode is the wire failure code of an htlc that failed at our
+	// node. It is zero for settled htlcs and htlcs that failed further
+	// down the route, as their failure is encrypted for the sender.
+	FailureCode lnwire.FailCode
+
+	// FailureDetail is a human readable description of why an htlc failed
+	// at our node, if known.
+	FailureDetail string
+}
+
+// encodeHtlcForwardEvent serializes the htlc forwarding event to the passed
+// io.Writer. The timestamp isn't serialized as it's the key of the event.
+func encodeHtlcForwardEvent(w io.Writer, e *HtlcForwardEvent) error {
+	var outgoingHtlcID uint64
+	if e.OutgoingHtlcID != nil {
+		outgoingHtlcID = *e.OutgoingHtlcID
+	}
+
+	return WriteElements(
+		w, e.IncomingChanID, e.IncomingHtlcID, e.OutgoingChanID,
+		e.OutgoingHtlcID != nil, outgoingHtlcID, e.AmtIn, e.AmtOut,
+		uint8(e.Outcome), uint16(e.FailureCode),
+		[]byte(e.FailureDetail),
+	)
+}
+
+// decodeHtlcForwardEvent deserializes an htlc forwarding event from the
+// passed io.Reader. The timestamp must be set by the caller.
+func decodeHtlcForwardEvent(r io.Reader, e *HtlcForwardEvent) error {
+	var (
+		hasOutgoingHtlcID bool
+		outgoingHtlcID    uint64
+		outcome           uint8
+		failureCode       uint16
+		failureDetail     []byte
+	)
+	err := ReadElements(
+		r, &e.IncomingChanID, &e.IncomingHtlcID, &e.OutgoingChanID,
+		&hasOutgoingHtlcID, &outgoingHtlcID, &e.AmtIn, &e.AmtOut,
+		&outcome, &failureCode, &failureDetail,
+	)
+	if err != nil {
+		return err
+	}
+
+	if hasOutgoingHtlcID {
+		e.OutgoingHtlcID = &outgoingHtlcID
+	}
+	e.Outcome = HtlcForwardOutcome(outcome)
+	e.FailureCode = lnwire.FailCode(failureCode)
+	e.FailureDetail = string(failureDetail)
+
+	return nil
+}
+
+// AddHtlcForwardEvents adds a series of htlc forwarding events to the
+// database. This is a no-op unless storing htlc forwarding events is enabled.
+// If a retention period is configured, events older than that are pruned in
+// the same transaction.
+func (f *ForwardingLog) AddHtlcForwardEvents(events []HtlcForwardEvent) error {
+	if !f.db.storeHtlcFwdEvents || len(events) == 0 {
+		return nil
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+
+	var pruneBefore time.Time
+	if f.db.htlcFwdEventRetention > 0 {
+		pruneBefore = f.db.clock.Now().Add(-f.db.htlcFwdEventRetention)
+	}
+
+	var timestamp [8]byte
+
+	update := func(tx kvdb.RwTx) error {
+		logBucket, err := tx.CreateTopLevelBucket(
+			htlcFwdEventLogBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			event.Timestamp = nextFreeTimestamp(
+				logBucket, event.Timestamp, timestamp[:],
+			)
+
+			var b bytes.Buffer
+			err := encodeHtlcForwardEvent(&b, &event)
+			if err != nil {
+				return err
+			}
+
+			err = logBucket.Put(timestamp[:], b.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+
+		if pruneBefore.IsZero() {
+			return nil
+		}
+
+		return pruneHtlcForwardEvents(logBucket, pruneBefore)
+	}
+
+	// The coalescer logs failed writes, so we don't wait for the result.
+	if f.db.writeCoalescer != nil {
+		f.db.writeCoalescer.Queue(update)
+		return nil
+	}
+
+	return kvdb.Batch(f.db.Backend, update)
+}
+
+// pruneHtlcForwardEvents deletes all htlc forwarding events that happened
+// before the given time from the log bucket.
+func pruneHtlcForwardEvents(logBucket kvdb.RwBucket, before time.Time) error {
+	var cutoff [8]byte
+	byteOrder.PutUint64(cutoff[:], uint64(before.UnixNano()))
+
+	// We can't delete keys while iterating, so we'll collect the keys to
+	// delete first.
+	var pruneKeys [][]byte
+	cursor := logBucket.ReadWriteCursor()
+	for k, _ := cursor.First(); k != nil; k, _ = cursor.Next() {
+		if bytes.Compare(k, cutoff[:]) >= 0 {
+			break
+		}
+
+		pruneKeys = append(pruneKeys, append([]byte(nil), k...))
+	}
+
+	for _, k := range pruneKeys {
+		if err := logBucket.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HtlcForwardLogTimeSlice is the response to a query of the htlc forwarding
+// event log.
+type HtlcForwardLogTimeSlice struct {
+	ForwardingEventQuery
+
+	// HtlcForwardEvents is the set of htlc forwarding events that answer
+	// the query embedded above.
+	HtlcForwardEvents []HtlcForwardEvent
+
+	// LastIndexOffset is the index of the last element in the set of
+	// returned events above. Callers can use this to resume their query.
+	LastIndexOffset uint32
+}
+
+// QueryHtlcForwardEvents allows a caller to query the htlc forwarding event
+// log for a particular time slice, using the same query semantics as the
+// forwarding log.
+func (f *ForwardingLog) QueryHtlcForwardEvents(
+	q ForwardingEventQuery) (HtlcForwardLogTimeSlice, error) {
+
+	var resp HtlcForwardLogTimeSlice
+
+	recordsToSkip := q.IndexOffset
+	recordOffset := q.IndexOffset
+
+	err := kvdb.View(f.db, func(tx kvdb.RTx) error {
+		logBucket := tx.ReadBucket(htlcFwdEventLogBucket)
+		if logBucket == nil {
+			return ErrNoForwardingEvents
+		}
+
+		var startTime, endTime [8]byte
+		byteOrder.PutUint64(
+			startTime[:], uint64(q.StartTime.UnixNano()),
+		)
+		byteOrder.PutUint64(endTime[:], uint64(q.EndTime.UnixNano()))
+
+		cursor := logBucket.ReadCursor()
+		k, v := cursor.Seek(startTime[:])
+		for ; k != nil && bytes.Compare(k, endTime[:]) <= 0; k, v =
+			cursor.Next() {
+
+			numEvents := uint32(len(resp.HtlcForwardEvents))
+			if numEvents >= q.NumMaxEvents {
+				return nil
+			}
+
+			if recordsToSkip > 0 {
+				recordsToSkip--
+				continue
+			}
+
+			var event HtlcForwardEvent
+			err := decodeHtlcForwardEvent(
+				bytes.NewReader(v), &event,
+			)
+			if err != nil {
+				return err
+			}
+			event.Timestamp = time.Unix(
+				0, int64(byteOrder.Uint64(k)),
+			)
+
+			resp.HtlcForwardEvents = append(
+				resp.HtlcForwardEvents, event,
+			)
+			recordOffset++
+		}
+
+		return nil
+	}, func() {
+		resp = HtlcForwardLogTimeSlice{
+			ForwardingEventQuery: q,
+		}
+	})
+	if err != nil && err != ErrNoForwardingEvents {
+		return HtlcForwardLogTimeSlice{}, err
+	}
+
+	resp.LastIndexOffset = recordOffset
+
+	return resp, nil
+}
@@ -0,0 +1,179 @@
+package channeldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/clock"
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHtlcForwardEventStorageAndQuery tests that settled and failed htlc
+// forwarding events can be stored and queried, and that nothing is stored if
+// the log is disabled.
+func TestHtlcForwardEventStorageAndQuery(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Unix(1234, 0)
+	outgoingHtlcID := uint64(7)
+	events := []HtlcForwardEvent{
+		{
+			Timestamp:      timestamp,
+			IncomingChanID: lnwire.NewShortChanIDFromInt(1),
+			IncomingHtlcID: 3,
+			OutgoingChanID: lnwire.NewShortChanIDFromInt(2),
+			OutgoingHtlcID: &outgoingHtlcID,
+			AmtIn:          2000,
+			AmtOut:         1000,
+			Outcome:        HtlcForwardSettled,
+		},
+		{
+			Timestamp:      timestamp.Add(time.Minute),
+			IncomingChanID: lnwire.NewShortChanIDFromInt(1),
+			IncomingHtlcID: 4,
+			OutgoingChanID: lnwire.NewShortChanIDFromInt(2),
+			AmtIn:          2000,
+			AmtOut:         1000,
+			Outcome:        HtlcForwardFailed,
+			FailureCode:    lnwire.CodeFeeInsufficient,
+			FailureDetail:  "insufficient fee",
+		},
+	}
+
+	eventQuery := ForwardingEventQuery{
+		StartTime:    timestamp,
+		EndTime:      timestamp.Add(time.Hour),
+		NumMaxEvents: 10,
+	}
+
+	// With the log disabled, the events should be dropped.
+	db, err := MakeTestDB(t)
+	require.NoError(t, err, "unable to make test db")
+
+	log := db.ForwardingLog()
+	require.NoError(t, log.AddHtlcForwardEvents(events))
+
+	timeSlice, err := log.QueryHtlcForwardEvents(eventQuery)
+	require.NoError(t, err)
+	require.Empty(t, timeSlice.HtlcForwardEvents)
+
+	// Once enabled, both events should be returned in order.
+	db, err = MakeTestDB(t, OptionStoreHtlcFwdEvents(true))
+	require.NoError(t, err, "unable to make test db")
+
+	log = db.ForwardingLog()
+	require.NoError(t, log.AddHtlcForwardEvents(events))
+
+	timeSlice, err = log.QueryHtlcForwardEvents(eventQuery)
+	require.NoError(t, err)
+	require.Equal(t, events, timeSlice.HtlcForwardEvents)
+	require.EqualValues(t, 2, timeSlice.LastIndexOffset)
+
+	// Querying with an offset should skip the settled event.
+	eventQuery.IndexOffset = 1
+	timeSlice, err = log.QueryHtlcForwardEvents(eventQuery)
+	require.NoError(t, err)
+	require.Equal(t, events[1:], timeSlice.HtlcForwardEvents)
+}
+
+// TestHtlcForwardEventRetention tests that htlc forwarding events older than
+// the retention period are pruned when new events are added.
+func TestHtlcForwardEventRetention(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_000_000, 0)
+	testClock := clock.NewTestClock(now)
+
+	db, err := MakeTestDB(
+		t, OptionStoreHtlcFwdEvents(true), OptionClock(testClock),
+		OptionHtlcFwdEventRetention(time.Hour),
+	)
+	require.NoError(t, err, "unable to make test db")
+
+	log := db.ForwardingLog()
+
+	newEvent := func(timestamp time.Time) HtlcForwardEvent {
+		return HtlcForwardEvent{
+			Timestamp:      timestamp,
+			IncomingChanID: lnwire.NewShortChanIDFromInt(1),
+			OutgoingChanID: lnwire.NewShortChanIDFromInt(2),
+			Outcome:        HtlcForwardFailed,
+		}
+	}
+
+	oldEvent := newEvent(now.Add(-2 * time.Hour))
+	recentEvent := newEvent(now.Add(-time.Minute))
+	require.NoError(t, log.AddHtlcForwardEvents(
+		[]HtlcForwardEvent{oldEvent, recentEvent},
+	))
+
+	// The old event is outside the retention period and should have been
+	// pruned right away.
+	eventQuery := ForwardingEventQuery{
+		StartTime:    now.Add(-24 * time.Hour),
+		EndTime:      now.Add(24 * time.Hour),
+		NumMaxEvents: 10,
+	}
+	timeSlice, err := log.QueryHtlcForwardEvents(eventQuery)
+	require.NoError(t, err)
+	require.Equal(
+		t, []HtlcForwardEvent{recentEvent}, timeSlice.HtlcForwardEvents,
+	)
+
+	// After an hour has passed, adding a new event prunes the previously
+	// recent one.
+	testClock.SetTime(now.Add(time.Hour))
+	nextEvent := newEvent(now.Add(time.Hour))
+	require.NoError(t, log.AddHtlcForwardEvents(
+		[]HtlcForwardEvent{nextEvent},
+	))
+
+	timeSlice, err = log.QueryHtlcForwardEvents(eventQuery)
+	require.NoError(t, err)
+	require.Equal(
+		t, []HtlcForwardEvent{nextEvent}, timeSlice.HtlcForwardEvents,
+	)
+}
+
+// TestHtlcForwardEventWriteCoalescing tests that htlc forwarding events are
+// only written with the next flush if write coalescing is enabled.
+func TestHtlcForwardEventWriteCoalescing(t *testing.T) {
+	t.Parallel()
+
+	db, err := MakeTestDB(
+		t, OptionStoreHtlcFwdEvents(true),
+		OptionSetWriteFlushInterval(time.Hour),
+	)
+	require.NoError(t, err, "unable to make test db")
+
+	log := db.ForwardingLog()
+
+	timestamp := time.Unix(1234, 0)
+	events := []HtlcForwardEvent{{
+		Timestamp:      timestamp,
+		IncomingChanID: lnwire.NewShortChanIDFromInt(1),
+		IncomingHtlcID: 3,
+		OutgoingChanID: lnwire.NewShortChanIDFromInt(2),
+		AmtIn:          2000,
+		AmtOut:         1000,
+		Outcome:        HtlcForwardFailed,
+		FailureCode:    lnwire.CodeFeeInsufficient,
+	}}
+	require.NoError(t, log.AddHtlcForwardEvents(events))
+
+	eventQuery := ForwardingEventQuery{
+		StartTime:    timestamp,
+		EndTime:      timestamp.Add(time.Minute),
+		NumMaxEvents: 10,
+	}
+	timeSlice, err := log.QueryHtlcForwardEvents(eventQuery)
+	require.NoError(t, err)
+	require.Empty(t, timeSlice.HtlcForwardEvents)
+
+	require.NoError(t, db.writeCoalescer.Flush())
+
+	timeSlice, err = log.QueryHtlcForwardEvents(eventQuery)
+	require.NoError(t, err)
+	require.Equal(t, events, timeSlice.HtlcForwardEvents)
+}
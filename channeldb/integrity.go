@@ -0,0 +1,354 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ltcsuite/lnd/kvdb"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+var (
+	// quarantineBucket is the top-level bucket that holds the raw data of
+	// corrupt entries that were removed from their original bucket by an
+	// integrity scan. Within it, there's a nested bucket for each bucket
+	// entries were quarantined from.
+	quarantineBucket = []byte("integrity-quarantine")
+)
+
+// CorruptEntry describes a database entry that couldn't be decoded during an
+// integrity scan.
+type CorruptEntry struct {
+	// Location describes where the entry is stored in the database.
+	Location string
+
+	// Err is the error that was returned when decoding the entry.
+	Err error
+
+	// Critical is true if the entry is required to safely operate the
+	// node, for example the state of an open channel. Critical entries are
+	// never quarantined.
+	Critical bool
+
+	// Quarantined is true if the entry was moved to the quarantine bucket.
+	Quarantined bool
+}
+
+// String returns a human readable description of the corrupt entry.
+func (c *CorruptEntry) String() string {
+	return fmt.Sprintf("%v: %v (critical=%v, quarantined=%v)", c.Location,
+		c.Err, c.Critical, c.Quarantined)
+}
+
+// IntegrityReport is the result of an integrity scan of the database.
+type IntegrityReport struct {
+	// NumChannels is the number of open channels that were scanned.
+	NumChannels int
+
+	// NumRevocationLogs is the number of revocation log entries that were
+	// scanned.
+	NumRevocationLogs int
+
+	// NumInvoices is the number of invoices that were scanned.
+	NumInvoices int
+
+	// NumForwardingEvents is the number of forwarding events that were
+	// scanned.
+	NumForwardingEvents int
+
+	// Corrupt is the list of entries that couldn't be decoded.
+	Corrupt []*CorruptEntry
+}
+
+// NumCritical returns the number of corrupt entries that are critical.
+func (r *IntegrityReport) NumCritical() int {
+	var numCritical int
+	for _, entry := range r.Corrupt {
+		if entry.Critical {
+			numCritical++
+		}
+	}
+
+	return numCritical
+}
+
+// quarantineKey identifies a corrupt entry that can be quarantined.
+type quarantineKey struct {
+	bucket []byte
+	key    []byte
+	entry  *CorruptEntry
+}
+
+// VerifyIntegrity walks the critical buckets of the database (open channels,
+// revocation logs and invoices) as well as the forwarding log and tries to
+// decode every entry. All entries that fail to decode are reported. If
+// quarantine is true, corrupt entries that aren't critical are moved to the
+// quarantine bucket, so they don't prevent the node from operating. The raw
+// data is kept there for manual inspection.
+func (d *DB) VerifyIntegrity(quarantine bool) (*IntegrityReport, error) {
+	var (
+		report      *IntegrityReport
+		quarantines []*quarantineKey
+	)
+	err := kvdb.View(d, func(tx kvdb.RTx) error {
+		if err := verifyChannels(tx, report); err != nil {
+			return err
+		}
+
+		if err := verifyInvoices(tx, report); err != nil {
+			return err
+		}
+
+		var err error
+		quarantines, err = verifyForwardingLog(tx, report)
+
+		return err
+	}, func() {
+		report = &IntegrityReport{}
+		quarantines = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !quarantine || len(quarantines) == 0 {
+		return report, nil
+	}
+
+	err = kvdb.Update(d, func(tx kvdb.RwTx) error {
+		quarantined, err := tx.CreateTopLevelBucket(quarantineBucket)
+		if err != nil {
+			return err
+		}
+
+		for _, q := range quarantines {
+			err := quarantineEntry(tx, quarantined, q.bucket, q.key)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+	if err != nil {
+		return nil, fmt.Errorf("unable to quarantine corrupt entries: "+
+			"%w", err)
+	}
+
+	for _, q := range quarantines {
+		q.entry.Quarantined = true
+	}
+
+	return report, nil
+}
+
+// quarantineEntry moves the value stored under the given key in the given
+// top-level bucket to the quarantine bucket.
+func quarantineEntry(tx kvdb.RwTx, quarantined kvdb.RwBucket, bucketName,
+	key []byte) error {
+
+	bucket := tx.ReadWriteBucket(bucketName)
+	if bucket == nil {
+		return nil
+	}
+
+	value := bucket.Get(key)
+	if value == nil {
+		return nil
+	}
+
+	target, err := quarantined.CreateBucketIfNotExists(bucketName)
+	if err != nil {
+		return err
+	}
+
+	if err := target.Put(key, value); err != nil {
+		return err
+	}
+
+	return bucket.Delete(key)
+}
+
+// verifyChannels decodes all open channels and their revocation logs.
+func verifyChannels(tx kvdb.RTx, report *IntegrityReport) error {
+	openChanBucket := tx.ReadBucket(openChannelBucket)
+	if openChanBucket == nil {
+		return nil
+	}
+
+	return openChanBucket.ForEach(func(nodePub, v []byte) error {
+		// Any non-bucket entries are ignored.
+		nodeChanBucket := openChanBucket.NestedReadBucket(nodePub)
+		if v != nil || nodeChanBucket == nil {
+			return nil
+		}
+
+		return nodeChanBucket.ForEach(func(chainHash, v []byte) error {
+			chainBucket := nodeChanBucket.NestedReadBucket(chainHash)
+			if v != nil || chainBucket == nil {
+				return nil
+			}
+
+			return chainBucket.ForEach(func(chanPoint, v []byte) error {
+				chanBucket := chainBucket.NestedReadBucket(
+					chanPoint,
+				)
+				if v != nil || chanBucket == nil {
+					return nil
+				}
+
+				verifyChannel(chanBucket, chanPoint, report)
+
+				return nil
+			})
+		})
+	})
+}
+
+// verifyChannel decodes the state and revocation log of a single channel.
+func verifyChannel(chanBucket kvdb.RBucket, chanPointBytes []byte,
+	report *IntegrityReport) {
+
+	report.NumChannels++
+
+	var chanPoint wire.OutPoint
+	err := readOutpoint(bytes.NewReader(chanPointBytes), &chanPoint)
+	if err != nil {
+		report.Corrupt = append(report.Corrupt, &CorruptEntry{
+			Location: fmt.Sprintf("channel %x", chanPointBytes),
+			Err:      err,
+			Critical: true,
+		})
+
+		return
+	}
+
+	if _, err := fetchOpenChannel(chanBucket, &chanPoint); err != nil {
+		report.Corrupt = append(report.Corrupt, &CorruptEntry{
+			Location: fmt.Sprintf("channel %v", chanPoint),
+			Err:      err,
+			Critical: true,
+		})
+	}
+
+	// The revocation logs are required to punish a remote party that
+	// broadcasts a revoked state, so we treat them as critical.
+	verifyLog := func(logBucket kvdb.RBucket,
+		decode func([]byte) error) {
+
+		if logBucket == nil {
+			return
+		}
+
+		_ = logBucket.ForEach(func(k, v []byte) error {
+			if v == nil {
+				return nil
+			}
+
+			report.NumRevocationLogs++
+			if err := decode(v); err != nil {
+				report.Corrupt = append(
+					report.Corrupt, &CorruptEntry{
+						Location: fmt.Sprintf(
+							"revocation log %x "+
+								"of channel %v",
+							k, chanPoint,
+						),
+						Err:      err,
+						Critical: true,
+					},
+				)
+			}
+
+			return nil
+		})
+	}
+
+	verifyLog(
+		chanBucket.NestedReadBucket(revocationLogBucket),
+		func(v []byte) error {
+			_, err := deserializeRevocationLog(bytes.NewReader(v))
+			return err
+		},
+	)
+	verifyLog(
+		chanBucket.NestedReadBucket(revocationLogBucketDeprecated),
+		func(v []byte) error {
+			_, err := deserializeChanCommit(bytes.NewReader(v))
+			return err
+		},
+	)
+}
+
+// verifyInvoices decodes all invoices. Since invoices are referenced by
+// several indexes, corrupt invoices are treated as critical.
+func verifyInvoices(tx kvdb.RTx, report *IntegrityReport) error {
+	invoices := tx.ReadBucket(invoiceBucket)
+	if invoices == nil {
+		return nil
+	}
+
+	invoiceIndex := invoices.NestedReadBucket(invoiceIndexBucket)
+	if invoiceIndex == nil {
+		return nil
+	}
+
+	return invoiceIndex.ForEach(func(k, v []byte) error {
+		// Skip the invoice counter and any sub-buckets.
+		if bytes.Equal(k, numInvoicesKey) || v == nil {
+			return nil
+		}
+
+		report.NumInvoices++
+		if _, err := fetchInvoice(v, invoices); err != nil {
+			report.Corrupt = append(report.Corrupt, &CorruptEntry{
+				Location: fmt.Sprintf("invoice %x", k),
+				Err:      err,
+				Critical: true,
+			})
+		}
+
+		return nil
+	})
+}
+
+// verifyForwardingLog decodes all forwarding events. Corrupt events are only
+// relevant for the forwarding history, so they are returned as candidates for
+// the quarantine.
+func verifyForwardingLog(tx kvdb.RTx,
+	report *IntegrityReport) ([]*quarantineKey, error) {
+
+	logBucket := tx.ReadBucket(forwardingLogBucket)
+	if logBucket == nil {
+		return nil, nil
+	}
+
+	var quarantines []*quarantineKey
+	err := logBucket.ForEach(func(k, v []byte) error {
+		if v == nil {
+			return nil
+		}
+
+		report.NumForwardingEvents++
+
+		var event ForwardingEvent
+		err := decodeForwardingEvent(bytes.NewReader(v), &event)
+		if err != nil {
+			entry := &CorruptEntry{
+				Location: fmt.Sprintf("forwarding event %x", k),
+				Err:      err,
+			}
+			report.Corrupt = append(report.Corrupt, entry)
+
+			quarantines = append(quarantines, &quarantineKey{
+				bucket: forwardingLogBucket,
+				key:    append([]byte(nil), k...),
+				entry:  entry,
+			})
+		}
+
+		return nil
+	})
+
+	return quarantines, err
+}
@@ -0,0 +1,70 @@
+package channeldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/kvdb"
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerifyIntegrity tests that corrupt forwarding events are reported and
+// quarantined, while intact entries are left untouched.
+func TestVerifyIntegrity(t *testing.T) {
+	t.Parallel()
+
+	db, err := MakeTestDB(t)
+	require.NoError(t, err, "unable to make test db")
+
+	event := ForwardingEvent{
+		Timestamp:      time.Unix(1234, 0),
+		IncomingChanID: lnwire.NewShortChanIDFromInt(1),
+		OutgoingChanID: lnwire.NewShortChanIDFromInt(2),
+		AmtIn:          lnwire.MilliSatoshi(2000),
+		AmtOut:         lnwire.MilliSatoshi(1000),
+	}
+	require.NoError(t, db.ForwardingLog().AddForwardingEvents(
+		[]ForwardingEvent{event},
+	))
+
+	// Store a forwarding event that is too short to be decoded.
+	corruptKey := []byte("corrupt!")
+	err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+		logBucket := tx.ReadWriteBucket(forwardingLogBucket)
+		return logBucket.Put(corruptKey, []byte{1, 2, 3})
+	}, func() {})
+	require.NoError(t, err)
+
+	report, err := db.VerifyIntegrity(false)
+	require.NoError(t, err)
+	require.Equal(t, 2, report.NumForwardingEvents)
+	require.Len(t, report.Corrupt, 1)
+	require.False(t, report.Corrupt[0].Critical)
+	require.False(t, report.Corrupt[0].Quarantined)
+	require.Zero(t, report.NumCritical())
+
+	report, err = db.VerifyIntegrity(true)
+	require.NoError(t, err)
+	require.Len(t, report.Corrupt, 1)
+	require.True(t, report.Corrupt[0].Quarantined)
+
+	// The corrupt event must have been moved to the quarantine bucket.
+	err = kvdb.View(db, func(tx kvdb.RTx) error {
+		logBucket := tx.ReadBucket(forwardingLogBucket)
+		require.Nil(t, logBucket.Get(corruptKey))
+
+		quarantined := tx.ReadBucket(quarantineBucket).NestedReadBucket(
+			forwardingLogBucket,
+		)
+		require.Equal(t, []byte{1, 2, 3}, quarantined.Get(corruptKey))
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	report, err = db.VerifyIntegrity(true)
+	require.NoError(t, err)
+	require.Equal(t, 1, report.NumForwardingEvents)
+	require.Empty(t, report.Corrupt)
+}
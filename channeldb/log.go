@@ -10,6 +10,8 @@ import (
 	"github.com/ltcsuite/lnd/channeldb/migration24"
 	"github.com/ltcsuite/lnd/channeldb/migration30"
 	"github.com/ltcsuite/lnd/channeldb/migration31"
+	"github.com/ltcsuite/lnd/channeldb/migration32"
+	"github.com/ltcsuite/lnd/channeldb/migration33"
 	"github.com/ltcsuite/lnd/channeldb/migration_01_to_11"
 	"github.com/ltcsuite/lnd/kvdb"
 )
@@ -42,5 +44,7 @@ func UseLogger(logger btclog.Logger) {
 	migration24.UseLogger(logger)
 	migration30.UseLogger(logger)
 	migration31.UseLogger(logger)
+	migration32.UseLogger(logger)
+	migration33.UseLogger(logger)
 	kvdb.UseLogger(logger)
 }
@@ -0,0 +1,43 @@
+package migration32
+
+import (
+	"bytes"
+
+	"github.com/ltcsuite/lnd/kvdb"
+)
+
+// DeleteNurseryTLBs deletes the top level buckets of the utxo nursery, which
+// are keyed by "utxn" followed by a chain hash. The nursery's duties have been
+// taken over by the contract resolvers, which resume any pending work on their
+// own, so its state can be dropped.
+func DeleteNurseryTLBs(tx kvdb.RwTx) error {
+	// Collect the keys first, as we can't delete buckets while iterating
+	// over them.
+	var keys [][]byte
+	err := tx.ForEachBucket(func(key []byte) error {
+		if len(key) != utxnChainKeyLen ||
+			!bytes.HasPrefix(key, utxnChainPrefix) {
+
+			return nil
+		}
+
+		keys = append(keys, append([]byte(nil), key...))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		log.Infof("Deleting top-level bucket: %x ...", key)
+
+		if err := tx.DeleteTopLevelBucket(key); err != nil {
+			return err
+		}
+
+		log.Infof("Deleted top-level bucket: %x", key)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package migration32
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ltcsuite/lnd/channeldb/migtest"
+	"github.com/ltcsuite/lnd/kvdb"
+	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	hexStr = migtest.Hex
+
+	// utxnBefore is the content of a nursery chain bucket before the
+	// migration. The actual values are not important when deleting the
+	// bucket.
+	utxnBefore = map[string]interface{}{
+		"channel-index": map[string]interface{}{
+			hexStr("0011"): map[string]interface{}{
+				hexStr("0022"): hexStr("0033"),
+			},
+		},
+		"height-index": map[string]interface{}{},
+	}
+
+	// otherKey is a top-level bucket key that shares the nursery prefix,
+	// but isn't a nursery chain bucket.
+	otherKey = []byte("utxn-other")
+
+	otherBefore = map[string]interface{}{
+		"key": hexStr("0044"),
+	}
+)
+
+// TestDeleteNurseryTLBs asserts that the nursery chain buckets are deleted,
+// while other buckets are left untouched.
+func TestDeleteNurseryTLBs(t *testing.T) {
+	t.Parallel()
+
+	mainnetKey := append(
+		[]byte("utxn"), chaincfg.MainNetParams.GenesisHash[:]...,
+	)
+	testnetKey := append(
+		[]byte("utxn"), chaincfg.TestNet4Params.GenesisHash[:]...,
+	)
+
+	// Prime the database with populated nursery buckets for two chains,
+	// and an unrelated bucket.
+	before := func(tx kvdb.RwTx) error {
+		err := migtest.RestoreDB(tx, mainnetKey, utxnBefore)
+		if err != nil {
+			return err
+		}
+
+		err = migtest.RestoreDB(tx, testnetKey, utxnBefore)
+		if err != nil {
+			return err
+		}
+
+		return migtest.RestoreDB(tx, otherKey, otherBefore)
+	}
+
+	// After the migration, ensure that the nursery buckets were deleted
+	// and the unrelated bucket is still there.
+	after := func(tx kvdb.RwTx) error {
+		for _, key := range [][]byte{mainnetKey, testnetKey} {
+			err := migtest.VerifyDB(tx, key, nil)
+			require.ErrorContains(
+				t, err,
+				fmt.Sprintf("bucket %s not found", key),
+			)
+		}
+
+		return migtest.VerifyDB(tx, otherKey, otherBefore)
+	}
+
+	migtest.ApplyMigration(t, before, after, DeleteNurseryTLBs, false)
+}
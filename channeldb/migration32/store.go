@@ -0,0 +1,13 @@
+package migration32
+
+import "github.com/ltcsuite/ltcd/chaincfg/chainhash"
+
+var (
+	// utxnChainPrefix is the prefix of the top-level buckets used by the
+	// utxo nursery. The full key is the prefix followed by the genesis
+	// hash of the chain the nursery was run on.
+	utxnChainPrefix = []byte("utxn")
+
+	// utxnChainKeyLen is the length of a nursery chain bucket key.
+	utxnChainKeyLen = len(utxnChainPrefix) + chainhash.HashSize
+)
@@ -0,0 +1,145 @@
+package migration33
+
+import (
+	"bytes"
+
+	"github.com/ltcsuite/lnd/kvdb"
+	"github.com/ltcsuite/lnd/tlv"
+)
+
+const (
+	// logScopeLen is the length of the keys of the top level buckets that
+	// hold the arbitrator logs, which are a chain hash followed by a
+	// channel point.
+	logScopeLen = 32 + 36
+
+	// resolverIDLen is the length of the keys the resolvers are stored
+	// under within the contracts bucket of an arbitrator log.
+	resolverIDLen = 36
+
+	// resolverRecordMarker is the first byte of a resolver that is stored
+	// as a TLV record. Legacy resolvers start with their resolver type
+	// instead, which never takes this value.
+	resolverRecordMarker byte = 0xff
+
+	// resolverRecordVersion is the version of the resolver records that
+	// are written by this migration.
+	resolverRecordVersion uint8 = 1
+)
+
+// The TLV types of a resolver record.
+const (
+	resolverRecordVersionType tlv.Type = 0
+	resolverRecordTypeType    tlv.Type = 2
+	resolverRecordStateType   tlv.Type = 4
+)
+
+// contractsBucketKey is the key of the bucket within an arbitrator log that
+// holds the resolvers.
+var contractsBucketKey = []byte("contractkey")
+
+// MigrateResolverRecords wraps the resolvers stored in the arbitrator logs in
+// versioned TLV records. A legacy resolver is stored as its type byte
+// followed by its state, which is rewritten as a record that is prefixed by
+// resolverRecordMarker and holds the record version, the resolver type and
+// the unchanged state. Resolvers that are already stored as records are
+// skipped.
+//
+// NOTE: Versions that predate this migration read the marker as an unknown
+// resolver type, so they refuse to run on a migrated database because of its
+// higher version.
+func MigrateResolverRecords(tx kvdb.RwTx) error {
+	// Collect the keys of the arbitrator logs first, as we can't modify
+	// the buckets while iterating over them.
+	var scopeKeys [][]byte
+	err := tx.ForEachBucket(func(key []byte) error {
+		if len(key) != logScopeLen {
+			return nil
+		}
+
+		scopeKeys = append(scopeKeys, append([]byte(nil), key...))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var numMigrated int
+	for _, scopeKey := range scopeKeys {
+		n, err := migrateScope(tx, scopeKey)
+		if err != nil {
+			return err
+		}
+
+		numMigrated += n
+	}
+
+	log.Infof("Migrated %v resolvers of %v arbitrator logs to record "+
+		"version %v", numMigrated, len(scopeKeys),
+		resolverRecordVersion)
+
+	return nil
+}
+
+// migrateScope rewrites the legacy resolvers of the arbitrator log with the
+// given key, and returns the number of rewritten resolvers.
+func migrateScope(tx kvdb.RwTx, scopeKey []byte) (int, error) {
+	scopeBucket := tx.ReadWriteBucket(scopeKey)
+	if scopeBucket == nil {
+		return 0, nil
+	}
+
+	contractBucket := scopeBucket.NestedReadWriteBucket(contractsBucketKey)
+	if contractBucket == nil {
+		return 0, nil
+	}
+
+	records := make(map[string][]byte)
+	err := contractBucket.ForEach(func(resKey, resBytes []byte) error {
+		if len(resKey) != resolverIDLen || len(resBytes) == 0 ||
+			resBytes[0] == resolverRecordMarker {
+
+			return nil
+		}
+
+		record, err := encodeRecord(resBytes[0], resBytes[1:])
+		if err != nil {
+			return err
+		}
+		records[string(resKey)] = record
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for resKey, record := range records {
+		if err := contractBucket.Put([]byte(resKey), record); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(records), nil
+}
+
+// encodeRecord encodes the resolver record of the given type and state.
+func encodeRecord(rType byte, state []byte) ([]byte, error) {
+	version := resolverRecordVersion
+	stream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(resolverRecordVersionType, &version),
+		tlv.MakePrimitiveRecord(resolverRecordTypeType, &rType),
+		tlv.MakePrimitiveRecord(resolverRecordStateType, &state),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBuffer([]byte{resolverRecordMarker})
+	if err := stream.Encode(b); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
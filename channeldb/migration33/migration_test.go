@@ -0,0 +1,81 @@
+package migration33
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ltcsuite/lnd/channeldb/migtest"
+	"github.com/ltcsuite/lnd/kvdb"
+)
+
+var (
+	hexStr = migtest.Hex
+
+	// scopeKey is the key of an arbitrator log, which is a chain hash
+	// followed by a channel point.
+	scopeKey = []byte(hexStr(strings.Repeat("11", logScopeLen)))
+
+	// legacyKey is the key of a resolver that is stored in the legacy
+	// format, and recordKey the key of one that is already stored as a
+	// record.
+	legacyKey = hexStr(strings.Repeat("22", resolverIDLen))
+	recordKey = hexStr(strings.Repeat("33", resolverIDLen))
+
+	// record is a resolver of type 3 with the state aabb, stored as a
+	// record: the marker followed by the version, type and state TLVs.
+	record = hexStr("ff" + "000101" + "020103" + "0402aabb")
+
+	logBefore = map[string]interface{}{
+		"state": hexStr("05"),
+		"contractkey": map[string]interface{}{
+			legacyKey: hexStr("03aabb"),
+			recordKey: record,
+		},
+	}
+
+	logAfter = map[string]interface{}{
+		"state": hexStr("05"),
+		"contractkey": map[string]interface{}{
+			legacyKey: record,
+			recordKey: record,
+		},
+	}
+
+	// otherKey is a top-level bucket that isn't an arbitrator log.
+	otherKey = []byte("other-bucket")
+
+	otherBefore = map[string]interface{}{
+		"contractkey": map[string]interface{}{
+			legacyKey: hexStr("03aabb"),
+		},
+	}
+)
+
+// TestMigrateResolverRecords asserts that legacy resolvers are wrapped in
+// resolver records, while resolvers that are already stored as records and
+// unrelated buckets are left untouched.
+func TestMigrateResolverRecords(t *testing.T) {
+	t.Parallel()
+
+	before := func(tx kvdb.RwTx) error {
+		err := migtest.RestoreDB(tx, scopeKey, logBefore)
+		if err != nil {
+			return err
+		}
+
+		return migtest.RestoreDB(tx, otherKey, otherBefore)
+	}
+
+	after := func(tx kvdb.RwTx) error {
+		err := migtest.VerifyDB(tx, scopeKey, logAfter)
+		if err != nil {
+			return err
+		}
+
+		return migtest.VerifyDB(tx, otherKey, otherBefore)
+	}
+
+	migtest.ApplyMigration(
+		t, before, after, MigrateResolverRecords, false,
+	)
+}
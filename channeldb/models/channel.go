@@ -126,5 +126,10 @@ type ForwardingPolicy struct {
 	// per-hop payload of the incoming HTLC's onion packet.
 	TimeLockDelta uint32
 
+	// InboundFee is the fee that must be paid for incoming HTLCs. It is
+	// added to the fee of the outgoing channel and may be negative, but
+	// the total fee never drops below zero.
+	InboundFee InboundFee
+
 	// TODO(roasbeef): add fee module inside of switch
 }
@@ -0,0 +1,52 @@
+package models
+
+import "github.com/ltcsuite/lnd/lnwire"
+
+const (
+	// feeRateParts is the denominator of inbound fee rates, which are
+	// expressed in parts per million.
+	feeRateParts = 1e6
+)
+
+// InboundFee is a fee that a node charges for htlcs that arrive over a
+// specific channel, on top of the fee it charges on the outgoing channel.
+// Both the base fee and the fee rate may be negative, which allows a node to
+// offer a discount for routing over channels that it wants to drain.
+type InboundFee struct {
+	// Base is the base fee in milli-satoshis.
+	Base int32
+
+	// Rate is the fee rate in parts per million.
+	Rate int32
+}
+
+// NewInboundFeeFromWire constructs an inbound fee from the fee record of a
+// channel update.
+func NewInboundFeeFromWire(fee lnwire.Fee) InboundFee {
+	return InboundFee{
+		Base: fee.BaseFee,
+		Rate: fee.FeeRate,
+	}
+}
+
+// ToWire converts the inbound fee to the fee record of a channel update.
+func (i InboundFee) ToWire() lnwire.Fee {
+	return lnwire.Fee{
+		BaseFee: i.Base,
+		FeeRate: i.Rate,
+	}
+}
+
+// IsZero returns true if the inbound fee doesn't change the fee of a node.
+func (i InboundFee) IsZero() bool {
+	return i.Base == 0 && i.Rate == 0
+}
+
+// CalcFee calculates the inbound fee for the given amount. The amount is the
+// amount that the node forwards on the outgoing channel plus its outgoing
+// fee. The result may be negative.
+func (i InboundFee) CalcFee(amt lnwire.MilliSatoshi) int64 {
+	// The integer division rounds towards zero, so that a negative rate
+	// never results in a larger discount than intended.
+	return int64(i.Base) + int64(i.Rate)*int64(amt)/feeRateParts
+}
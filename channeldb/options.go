@@ -50,6 +50,13 @@ type Options struct {
 	// wait before attempting to commit a pending set of updates.
 	BatchCommitInterval time.Duration
 
+	// WriteFlushInterval is the interval in which the writes of the
+	// forwarding log and the htlc forwarding log are coalesced and flushed
+	// to disk in a single transaction. If zero, these writes are committed
+	// immediately. Channel graph writes are batched according to
+	// BatchCommitInterval instead.
+	WriteFlushInterval time.Duration
+
 	// PreAllocCacheNumNodes is the number of nodes we expect to be in the
 	// graph cache, so we can pre-allocate the map accordingly.
 	PreAllocCacheNumNodes int
@@ -82,6 +89,14 @@ type Options struct {
 	// storeFinalHtlcResolutions determines whether to persistently store
 	// the final resolution of incoming htlcs.
 	storeFinalHtlcResolutions bool
+
+	// storeHtlcFwdEvents determines whether to persistently store the
+	// final outcome of every forwarded htlc.
+	storeHtlcFwdEvents bool
+
+	// htlcFwdEventRetention is the duration stored htlc forwarding events
+	// are kept for. If zero, they are kept forever.
+	htlcFwdEventRetention time.Duration
 }
 
 // DefaultOptions returns an Options populated with default values.
@@ -172,6 +187,14 @@ func OptionSetBatchCommitInterval(interval time.Duration) OptionModifier {
 	}
 }
 
+// OptionSetWriteFlushInterval sets the interval in which the writes of the
+// forwarding log and the htlc forwarding log are coalesced and flushed to disk.
+func OptionSetWriteFlushInterval(interval time.Duration) OptionModifier {
+	return func(o *Options) {
+		o.WriteFlushInterval = interval
+	}
+}
+
 // OptionNoMigration allows the database to be opened in read only mode by
 // disabling migrations.
 func OptionNoMigration(b bool) OptionModifier {
@@ -213,6 +236,22 @@ func OptionStoreFinalHtlcResolutions(
 	}
 }
 
+// OptionStoreHtlcFwdEvents controls whether to persistently store the final
+// outcome of every forwarded htlc, including failed forwards.
+func OptionStoreHtlcFwdEvents(storeHtlcFwdEvents bool) OptionModifier {
+	return func(o *Options) {
+		o.storeHtlcFwdEvents = storeHtlcFwdEvents
+	}
+}
+
+// OptionHtlcFwdEventRetention sets the duration stored htlc forwarding events
+// are kept for before being pruned. A zero duration disables pruning.
+func OptionHtlcFwdEventRetention(retention time.Duration) OptionModifier {
+	return func(o *Options) {
+		o.htlcFwdEventRetention = retention
+	}
+}
+
 // OptionPruneRevocationLog specifies whether the migration for pruning
 // revocation logs needs to be applied or not.
 func OptionPruneRevocationLog(prune bool) OptionModifier {
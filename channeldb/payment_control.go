@@ -16,6 +16,10 @@ const (
 	// paymentSeqBlockSize is the block size used when we batch allocate
 	// payment sequences for future payments.
 	paymentSeqBlockSize = 1000
+
+	// MaxIdempotencyKeyLen is the maximum length of the idempotency key
+	// that a payment can be initiated with.
+	MaxIdempotencyKeyLen = 64
 )
 
 var (
@@ -81,6 +85,20 @@ var (
 	ErrMPPTotalAmountMismatch = errors.New("mp payment total amount " +
 		"mismatch")
 
+	// ErrIdempotencyKeyUsed is returned if we try to initiate a payment
+	// with an idempotency key that is already used by a different payment.
+	ErrIdempotencyKeyUsed = errors.New("idempotency key is used by a " +
+		"different payment")
+
+	// ErrIdempotencyKeyNotFound is returned if no payment was initiated
+	// with the idempotency key that is looked up.
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+
+	// ErrIdempotencyKeyTooLong is returned if a payment is initiated with
+	// an idempotency key that exceeds MaxIdempotencyKeyLen.
+	ErrIdempotencyKeyTooLong = fmt.Errorf("idempotency key exceeds "+
+		"%v bytes", MaxIdempotencyKeyLen)
+
 	// errNoAttemptInfo is returned when no attempt info is stored yet.
 	errNoAttemptInfo = errors.New("unable to find attempt info for " +
 		"inflight payment")
@@ -109,10 +127,16 @@ func NewPaymentControl(db *DB) *PaymentControl {
 // InitPayment checks or records the given PaymentCreationInfo with the DB,
 // making sure it does not already exist as an in-flight payment. When this
 // method returns successfully, the payment is guaranteed to be in the InFlight
-// state.
+// state. If the creation info carries an idempotency key, the key is indexed
+// so that the payment can be looked up by it. ErrIdempotencyKeyUsed is
+// returned if a different payment was initiated with the same key before.
 func (p *PaymentControl) InitPayment(paymentHash lntypes.Hash,
 	info *PaymentCreationInfo) error {
 
+	if len(info.IdempotencyKey) > MaxIdempotencyKeyLen {
+		return ErrIdempotencyKeyTooLong
+	}
+
 	// Obtain a new sequence number for this payment. This is used
 	// to sort the payments in order of creation, and also acts as
 	// a unique identifier for each payment.
@@ -133,6 +157,27 @@ func (p *PaymentControl) InitPayment(paymentHash lntypes.Hash,
 		// from a previous execution of the batched db transaction.
 		updateErr = nil
 
+		// Make sure that the idempotency key, if any, isn't used by a
+		// different payment. This needs to happen before the payment
+		// bucket is created, so that we don't leave an empty bucket
+		// behind.
+		if len(info.IdempotencyKey) > 0 {
+			hash, err := lookupIdempotencyKey(
+				tx, info.IdempotencyKey,
+			)
+			switch {
+			// The key hasn't been used yet, so it's free to take.
+			case err == ErrIdempotencyKeyNotFound:
+
+			case err != nil:
+				return err
+
+			case hash != paymentHash:
+				updateErr = ErrIdempotencyKeyUsed
+				return nil
+			}
+		}
+
 		prefetchPayment(tx, paymentHash)
 		bucket, err := createPaymentBucket(tx, paymentHash)
 		if err != nil {
@@ -206,6 +251,21 @@ func (p *PaymentControl) InitPayment(paymentHash lntypes.Hash,
 			return err
 		}
 
+		// Replace the idempotency key of a previous attempt of this
+		// payment with the one that is supplied now, if any.
+		if err := deleteIdempotencyKey(tx, bucket); err != nil {
+			return err
+		}
+
+		if len(info.IdempotencyKey) > 0 {
+			err := putIdempotencyKey(
+				tx, bucket, info.IdempotencyKey, paymentHash,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
 		// We'll delete any lingering HTLCs to start with, in case we
 		// are initializing a payment that was attempted earlier, but
 		// left in a state where we could retry.
@@ -238,6 +298,13 @@ func (p *PaymentControl) DeleteFailedAttempts(hash lntypes.Hash) error {
 	return nil
 }
 
+// DeletePayment deletes the payment with the given hash along with all of its
+// htlc attempts. Payments that are in flight can't be deleted.
+func (p *PaymentControl) DeletePayment(hash lntypes.Hash) error {
+	const failedHtlcsOnly = false
+	return p.db.DeletePayment(hash, failedHtlcsOnly)
+}
+
 // paymentIndexTypeHash is a payment index type which indicates that we have
 // created an index of payment sequence number to payment hash.
 type paymentIndexType uint8
@@ -587,6 +654,79 @@ func (p *PaymentControl) FetchPayment(paymentHash lntypes.Hash) (
 	return payment, nil
 }
 
+// LookupIdempotencyKey returns the identifier of the payment that was
+// initiated with the given idempotency key. ErrIdempotencyKeyNotFound is
+// returned if there is no such payment.
+func (p *PaymentControl) LookupIdempotencyKey(key []byte) (lntypes.Hash,
+	error) {
+
+	var paymentHash lntypes.Hash
+	err := kvdb.View(p.db, func(tx kvdb.RTx) error {
+		var err error
+		paymentHash, err = lookupIdempotencyKey(tx, key)
+
+		return err
+	}, func() {
+		paymentHash = lntypes.Hash{}
+	})
+	if err != nil {
+		return lntypes.Hash{}, err
+	}
+
+	return paymentHash, nil
+}
+
+// lookupIdempotencyKey returns the identifier of the payment that the given
+// idempotency key is indexed for.
+func lookupIdempotencyKey(tx kvdb.RTx, key []byte) (lntypes.Hash, error) {
+	index := tx.ReadBucket(paymentIdempotencyIndexBucket)
+	if index == nil {
+		return lntypes.Hash{}, ErrIdempotencyKeyNotFound
+	}
+
+	hashBytes := index.Get(key)
+	if hashBytes == nil {
+		return lntypes.Hash{}, ErrIdempotencyKeyNotFound
+	}
+
+	return lntypes.MakeHash(hashBytes)
+}
+
+// putIdempotencyKey stores the idempotency key in the payment's bucket and
+// adds it to the index.
+func putIdempotencyKey(tx kvdb.RwTx, bucket kvdb.RwBucket, key []byte,
+	paymentHash lntypes.Hash) error {
+
+	index, err := tx.CreateTopLevelBucket(paymentIdempotencyIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	if err := index.Put(key, paymentHash[:]); err != nil {
+		return err
+	}
+
+	return bucket.Put(paymentIdempotencyKey, key)
+}
+
+// deleteIdempotencyKey removes the idempotency key that is stored in the
+// payment's bucket, if any, from the bucket and the index.
+func deleteIdempotencyKey(tx kvdb.RwTx, bucket kvdb.RwBucket) error {
+	key := bucket.Get(paymentIdempotencyKey)
+	if key == nil {
+		return nil
+	}
+
+	index := tx.ReadWriteBucket(paymentIdempotencyIndexBucket)
+	if index != nil {
+		if err := index.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return bucket.Delete(paymentIdempotencyKey)
+}
+
 // prefetchPayment attempts to prefetch as much of the payment as possible to
 // reduce DB roundtrips.
 func prefetchPayment(tx kvdb.RTx, paymentHash lntypes.Hash) {
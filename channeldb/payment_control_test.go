@@ -250,6 +250,90 @@ func TestPaymentControlSwitchDoubleSend(t *testing.T) {
 	}
 }
 
+// TestPaymentControlIdempotencyKey checks that payments can be looked up by
+// the idempotency key they were initiated with, and that a key can't be used
+// for different payments.
+func TestPaymentControlIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	db, err := MakeTestDB(t)
+	require.NoError(t, err, "unable to init db")
+
+	pControl := NewPaymentControl(db)
+
+	info, _, _, err := genInfo()
+	require.NoError(t, err, "unable to generate htlc message")
+
+	key := []byte("order-1")
+	info.IdempotencyKey = key
+
+	// Before the payment is initiated, the key is unknown.
+	_, err = pControl.LookupIdempotencyKey(key)
+	require.ErrorIs(t, err, ErrIdempotencyKeyNotFound)
+
+	err = pControl.InitPayment(info.PaymentIdentifier, info)
+	require.NoError(t, err, "unable to send htlc message")
+
+	hash, err := pControl.LookupIdempotencyKey(key)
+	require.NoError(t, err)
+	require.Equal(t, info.PaymentIdentifier, hash)
+	assertPaymentInfo(
+		t, pControl, info.PaymentIdentifier, info, nil, nil,
+	)
+
+	// A different payment can't be initiated with the same key, and no
+	// trace of it should be left behind.
+	info2, _, _, err := genInfo()
+	require.NoError(t, err, "unable to generate htlc message")
+	info2.IdempotencyKey = key
+
+	err = pControl.InitPayment(info2.PaymentIdentifier, info2)
+	require.ErrorIs(t, err, ErrIdempotencyKeyUsed)
+
+	_, err = pControl.FetchPayment(info2.PaymentIdentifier)
+	require.ErrorIs(t, err, ErrPaymentNotInitiated)
+
+	// Keys that exceed the maximum length are rejected.
+	info2.IdempotencyKey = make([]byte, MaxIdempotencyKeyLen+1)
+	err = pControl.InitPayment(info2.PaymentIdentifier, info2)
+	require.ErrorIs(t, err, ErrIdempotencyKeyTooLong)
+
+	// Once the payment failed, it can be retried with a new key, which
+	// replaces the old one.
+	_, err = pControl.Fail(info.PaymentIdentifier, FailureReasonNoRoute)
+	require.NoError(t, err, "unable to fail payment hash")
+
+	newKey := []byte("order-1-retry")
+	info.IdempotencyKey = newKey
+	err = pControl.InitPayment(info.PaymentIdentifier, info)
+	require.NoError(t, err, "unable to send htlc message")
+
+	_, err = pControl.LookupIdempotencyKey(key)
+	require.ErrorIs(t, err, ErrIdempotencyKeyNotFound)
+
+	hash, err = pControl.LookupIdempotencyKey(newKey)
+	require.NoError(t, err)
+	require.Equal(t, info.PaymentIdentifier, hash)
+
+	// Deleting the payment frees up its key.
+	_, err = pControl.Fail(info.PaymentIdentifier, FailureReasonNoRoute)
+	require.NoError(t, err, "unable to fail payment hash")
+
+	err = db.DeletePayment(info.PaymentIdentifier, false)
+	require.NoError(t, err)
+
+	_, err = pControl.LookupIdempotencyKey(newKey)
+	require.ErrorIs(t, err, ErrIdempotencyKeyNotFound)
+
+	info2.IdempotencyKey = newKey
+	err = pControl.InitPayment(info2.PaymentIdentifier, info2)
+	require.NoError(t, err, "unable to send htlc message")
+
+	hash, err = pControl.LookupIdempotencyKey(newKey)
+	require.NoError(t, err)
+	require.Equal(t, info2.PaymentIdentifier, hash)
+}
+
 // TestPaymentControlSuccessesWithoutInFlight checks that the payment
 // control will disallow calls to Success when no payment is in flight.
 func TestPaymentControlSuccessesWithoutInFlight(t *testing.T) {
@@ -92,6 +92,10 @@ var (
 	// store information about the reason a payment failed.
 	paymentFailInfoKey = []byte("payment-fail-info")
 
+	// paymentIdempotencyKey is a key used in the payment's sub-bucket to
+	// store the idempotency key the payment was initiated with, if any.
+	paymentIdempotencyKey = []byte("payment-idempotency-key")
+
 	// paymentsIndexBucket is the name of the top-level bucket within the
 	// database that stores an index of payment sequence numbers to its
 	// payment hash.
@@ -100,6 +104,17 @@ var (
 	// 	|--...
 	// 	|--<sequence-number>: <payment hash>
 	paymentsIndexBucket = []byte("payments-index-bucket")
+
+	// paymentIdempotencyIndexBucket is the name of the top-level bucket
+	// within the database that maps the idempotency keys supplied by
+	// callers to the payment they initiated.
+	// payment-idempotency-index-bucket
+	// 	|--<idempotency key>: <payment hash>
+	// 	|--...
+	// 	|--<idempotency key>: <payment hash>
+	paymentIdempotencyIndexBucket = []byte(
+		"payment-idempotency-index-bucket",
+	)
 )
 
 var (
@@ -190,6 +205,12 @@ type PaymentCreationInfo struct {
 
 	// PaymentRequest is the full payment request, if any.
 	PaymentRequest []byte
+
+	// IdempotencyKey is an optional key supplied by the caller that
+	// initiated the payment. Only a single payment can be initiated with
+	// a given key. The key isn't part of the serialized creation info,
+	// but stored separately in the payment's bucket.
+	IdempotencyKey []byte
 }
 
 // htlcBucketKey creates a composite key from prefix and id where the result is
@@ -263,7 +284,17 @@ func fetchCreationInfo(bucket kvdb.RBucket) (*PaymentCreationInfo, error) {
 	}
 
 	r := bytes.NewReader(b)
-	return deserializePaymentCreationInfo(r)
+	info, err := deserializePaymentCreationInfo(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if key := bucket.Get(paymentIdempotencyKey); key != nil {
+		info.IdempotencyKey = make([]byte, len(key))
+		copy(info.IdempotencyKey, key)
+	}
+
+	return info, nil
 }
 
 func fetchPayment(bucket kvdb.RBucket) (*MPPayment, error) {
@@ -833,6 +864,10 @@ func (d *DB) DeletePayment(paymentHash lntypes.Hash,
 			return err
 		}
 
+		if err := deleteIdempotencyKey(tx, bucket); err != nil {
+			return err
+		}
+
 		if err := payments.DeleteNestedBucket(paymentHash[:]); err != nil {
 			return err
 		}
@@ -871,6 +906,11 @@ func (d *DB) DeletePayments(failedOnly, failedHtlcsOnly bool) error {
 			// deleteHtlcs maps a payment hash to the HTLC IDs we
 			// want to delete for that payment.
 			deleteHtlcs = make(map[lntypes.Hash][][]byte)
+
+			// deleteIdempotencyKeys is the set of idempotency keys
+			// of these payments that need to be removed from the
+			// index.
+			deleteIdempotencyKeys [][]byte
 		)
 		err := payments.ForEach(func(k, _ []byte) error {
 			bucket := payments.NestedReadBucket(k)
@@ -929,6 +969,15 @@ func (d *DB) DeletePayments(failedOnly, failedHtlcsOnly bool) error {
 			}
 
 			deleteIndexes = append(deleteIndexes, seqNrs...)
+
+			key := bucket.Get(paymentIdempotencyKey)
+			if key != nil {
+				deleteIdempotencyKeys = append(
+					deleteIdempotencyKeys,
+					append([]byte(nil), key...),
+				)
+			}
+
 			return nil
 		})
 		if err != nil {
@@ -978,6 +1027,15 @@ func (d *DB) DeletePayments(failedOnly, failedHtlcsOnly bool) error {
 			}
 		}
 
+		// Finally, remove the idempotency keys of the deleted payments
+		// from their index, so that they can be used again.
+		keyIndex := tx.ReadWriteBucket(paymentIdempotencyIndexBucket)
+		for _, k := range deleteIdempotencyKeys {
+			if err := keyIndex.Delete(k); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}, func() {})
 }
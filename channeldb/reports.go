@@ -41,6 +41,7 @@ var (
 	resolverType  tlv.Type = 2
 	outcomeType   tlv.Type = 3
 	spendTxIDType tlv.Type = 4
+	feeType       tlv.Type = 5
 )
 
 // ResolverType indicates the type of resolver that was resolved on chain.
@@ -117,6 +118,12 @@ type ResolverReport struct {
 	// claimed the outpoint. This may be a sweep transaction, or a first
 	// stage success/timeout transaction.
 	SpendTxID *chainhash.Hash
+
+	// Fee is the fee paid by the spending transaction. The transaction
+	// may spend other outputs as well, so reports sharing the same
+	// SpendTxID share the fee. It is zero if the fee is unknown, e.g.
+	// because the output was spent by the remote party.
+	Fee ltcutil.Amount
 }
 
 // PutResolverReport creates and commits a transaction that is used to write a
@@ -199,6 +206,15 @@ func serializeReport(w io.Writer, report *ResolverReport) error {
 		))
 	}
 
+	// The fee is only added if it is known. It has an odd type, so older
+	// versions just skip it.
+	fee := uint64(report.Fee)
+	if fee != 0 {
+		records = append(records, tlv.MakePrimitiveRecord(
+			feeType, &fee,
+		))
+	}
+
 	// Create our stream and encode it.
 	tlvStream, err := tlv.NewStream(records...)
 	if err != nil {
@@ -265,7 +281,7 @@ func (d DB) FetchChannelReports(chainHash chainhash.Hash,
 func deserializeReport(r io.Reader) (*ResolverReport, error) {
 	var (
 		resolver, outcome uint8
-		amt               uint64
+		amt, fee          uint64
 		spentTx           []byte
 	)
 
@@ -274,6 +290,7 @@ func deserializeReport(r io.Reader) (*ResolverReport, error) {
 		tlv.MakePrimitiveRecord(resolverType, &resolver),
 		tlv.MakePrimitiveRecord(outcomeType, &outcome),
 		tlv.MakePrimitiveRecord(spendTxIDType, &spentTx),
+		tlv.MakePrimitiveRecord(feeType, &fee),
 	)
 	if err != nil {
 		return nil, err
@@ -287,6 +304,7 @@ func deserializeReport(r io.Reader) (*ResolverReport, error) {
 		Amount:          ltcutil.Amount(amt),
 		ResolverOutcome: ResolverOutcome(outcome),
 		ResolverType:    ResolverType(resolver),
+		Fee:             ltcutil.Amount(fee),
 	}
 
 	// If our spend tx is set, we set it on our report.
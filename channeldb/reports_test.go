@@ -6,6 +6,7 @@ import (
 
 	"github.com/ltcsuite/lnd/kvdb"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/wire"
 	"github.com/stretchr/testify/require"
 )
@@ -28,11 +29,12 @@ var (
 )
 
 // TestPersistReport tests the writing and retrieval of a report on disk with
-// and without a spend txid.
+// and without a spend txid and fee.
 func TestPersistReport(t *testing.T) {
 	tests := []struct {
 		name      string
 		spendTxID *chainhash.Hash
+		fee       ltcutil.Amount
 	}{
 		{
 			name:      "Non-nil spend txid",
@@ -42,6 +44,11 @@ func TestPersistReport(t *testing.T) {
 			name:      "Nil spend txid",
 			spendTxID: nil,
 		},
+		{
+			name:      "Spend txid with fee",
+			spendTxID: &testChanPoint1.Hash,
+			fee:       1000,
+		},
 	}
 
 	for _, test := range tests {
@@ -62,6 +69,7 @@ func TestPersistReport(t *testing.T) {
 				ResolverType:    1,
 				ResolverOutcome: 2,
 				SpendTxID:       test.spendTxID,
+				Fee:             test.fee,
 			}
 
 			// Write report to disk, and ensure it is identical when
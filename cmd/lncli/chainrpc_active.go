@@ -26,6 +26,7 @@ func chainCommands() []cli.Command {
 				getBestBlockCommand,
 				getBlockHashCommand,
 				getBlockHeaderCommand,
+				getFeeEstimatesCommand,
 			},
 		},
 	}
@@ -257,3 +258,47 @@ func getBlockHash(ctx *cli.Context) error {
 
 	return nil
 }
+
+var getFeeEstimatesCommand = cli.Command{
+	Name:     "getfeeestimates",
+	Category: "On-chain",
+	Usage:    "Get the fee estimates of the active fee estimator.",
+	Description: "Returns the fee rates of the active fee estimator for a " +
+		"set of confirmation targets, together with the minimum " +
+		"relay fee and the source of the fee estimates. If no " +
+		"confirmation targets are specified, the targets cached by " +
+		"the estimator or a default set of targets are used.",
+	Flags: []cli.Flag{
+		cli.Int64SliceFlag{
+			Name: "conf_target",
+			Usage: "a confirmation target to return the fee " +
+				"estimate for, can be specified multiple times",
+		},
+	},
+	Action: actionDecorator(getFeeEstimates),
+}
+
+func getFeeEstimates(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	req := &chainrpc.GetFeeEstimatesRequest{}
+	for _, confTarget := range ctx.Int64Slice("conf_target") {
+		if confTarget <= 0 {
+			return fmt.Errorf("invalid conf target %d", confTarget)
+		}
+
+		req.ConfTargets = append(req.ConfTargets, uint32(confTarget))
+	}
+
+	client, cleanUp := getChainClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.GetFeeEstimates(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 
+	"github.com/ltcsuite/lnd/lncfg"
+	"github.com/ltcsuite/lnd/lnrpc"
 	"github.com/ltcsuite/lnd/lnrpc/routerrpc"
 	"github.com/ltcsuite/lnd/routing/route"
 	"github.com/urfave/cli"
+	"google.golang.org/grpc"
 )
 
 const argsStr = "[source node] [dest node] [unix ts seconds] [amount in msat]"
@@ -18,8 +22,23 @@ var importMissionControlCommand = cli.Command{
 	Category:  "Payments",
 	Usage:     "Import a result to the internal mission control state.",
 	ArgsUsage: fmt.Sprintf("importmc %v", argsStr),
-	Action:    actionDecorator(importMissionControl),
+	Description: `
+	Import a single result, or all results of a file that contains the JSON
+	output of querymc, to the internal mission control state. The results
+	keep their original timestamps, so they are subject to the same time
+	decay as the results the node learned itself. The decay can be
+	configured with setmccfg.
+
+	Imported results are persisted and survive restarts of the node.
+	`,
+	Action: actionDecorator(importMissionControl),
 	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "mc_file",
+			Usage: "the path to a file that contains the JSON " +
+				"output of querymc, all pairs of which are " +
+				"imported",
+		},
 		cli.BoolFlag{
 			Name:  "failure",
 			Usage: "whether the routing history entry was a failure",
@@ -35,6 +54,15 @@ func importMissionControl(ctx *cli.Context) error {
 	conn := getClientConn(ctx, false)
 	defer conn.Close()
 
+	if ctx.IsSet("mc_file") {
+		if ctx.NArg() != 0 {
+			return errors.New("mc_file can't be combined with " +
+				"a single result")
+		}
+
+		return importMissionControlFile(ctx, conn)
+	}
+
 	if ctx.NArg() != 4 {
 		return fmt.Errorf("please provide args: %v", argsStr)
 	}
@@ -97,3 +125,42 @@ func importMissionControl(ctx *cli.Context) error {
 	_, err = client.XImportMissionControl(rpcCtx, req)
 	return err
 }
+
+// importMissionControlFile imports all pairs of a file that contains the JSON
+// output of querymc.
+func importMissionControlFile(ctx *cli.Context, conn *grpc.ClientConn) error {
+	mcFile := lncfg.CleanAndExpandPath(ctx.String("mc_file"))
+	jsonBytes, err := os.ReadFile(mcFile)
+	if err != nil {
+		return fmt.Errorf("error reading JSON from file %v: %v",
+			mcFile, err)
+	}
+
+	history := &routerrpc.QueryMissionControlResponse{}
+	err = lnrpc.ProtoJSONUnmarshalOpts.Unmarshal(jsonBytes, history)
+	if err != nil {
+		return fmt.Errorf("error parsing JSON: %v", err)
+	}
+
+	if len(history.Pairs) == 0 {
+		return errors.New("no pairs found in file")
+	}
+
+	client := routerrpc.NewRouterClient(conn)
+
+	req := &routerrpc.XImportMissionControlRequest{
+		Pairs: history.Pairs,
+		Force: ctx.IsSet("force"),
+	}
+
+	rpcCtx := context.Background()
+	_, err = client.XImportMissionControl(rpcCtx, req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %v pairs to mission control\n",
+		len(history.Pairs))
+
+	return nil
+}
@@ -47,6 +47,20 @@ var (
 			"to route through for this payment",
 	}
 
+	outgoingChanIDFlag = cli.Int64SliceFlag{
+		Name: "outgoing_chan_id",
+		Usage: "short channel id of the outgoing channel to use for " +
+			"the first hop of the payment; can be specified " +
+			"multiple times in the same command",
+	}
+
+	blockedFirstHopFlag = cli.StringSliceFlag{
+		Name: "blocked_first_hop",
+		Usage: "pubkey of a peer that must not be used as the first " +
+			"hop of the payment; can be specified multiple times " +
+			"in the same command",
+	}
+
 	dataFlag = cli.StringFlag{
 		Name: "data",
 		Usage: "attach custom data to the payment. The required " +
@@ -55,6 +69,16 @@ var (
 			"Custom record ids start from 65536.",
 	}
 
+	routeHintsFlag = cli.StringFlag{
+		Name: "route_hints",
+		Usage: "(optional) json encoded list of route hints to reach " +
+			"nodes through private channels, e.g. " +
+			"'[{\"hop_hints\":[{\"node_id\":\"<hex_pubkey>\"," +
+			"\"chan_id\":\"<chan_id>\",\"fee_base_msat\":1000," +
+			"\"fee_proportional_millionths\":1," +
+			"\"cltv_expiry_delta\":40}]}]'",
+	}
+
 	inflightUpdatesFlag = cli.BoolFlag{
 		Name: "inflight_updates",
 		Usage: "if set, intermediate payment state updates will be " +
@@ -99,6 +123,14 @@ var (
 		Name:  "time_pref",
 		Usage: "(optional) expresses time preference (range -1 to 1)",
 	}
+
+	idempotencyKeyFlag = cli.StringFlag{
+		Name: "idempotency_key",
+		Usage: "(optional) hex encoded key of at most 64 bytes that " +
+			"identifies the payment; if a payment was already " +
+			"initiated with the key, its result is tracked " +
+			"instead of sending a new payment",
+	}
 )
 
 // paymentFlags returns common flags for sendpayment and payinvoice.
@@ -128,12 +160,8 @@ func paymentFlags() []cli.Flag {
 		},
 		cltvLimitFlag,
 		lastHopFlag,
-		cli.Uint64Flag{
-			Name: "outgoing_chan_id",
-			Usage: "short channel id of the outgoing channel to " +
-				"use for the first hop of the payment",
-			Value: 0,
-		},
+		outgoingChanIDFlag,
+		blockedFirstHopFlag,
 		cli.BoolFlag{
 			Name:  "force, f",
 			Usage: "will skip payment request confirmation",
@@ -144,7 +172,7 @@ func paymentFlags() []cli.Flag {
 		},
 		dataFlag, inflightUpdatesFlag, maxPartsFlag, jsonFlag,
 		maxShardSizeSatFlag, maxShardSizeMsatFlag, ampFlag,
-		timePrefFlag,
+		timePrefFlag, idempotencyKeyFlag,
 	}
 }
 
@@ -422,10 +450,14 @@ func sendPaymentRequest(ctx *cli.Context,
 	client := lnrpc.NewLightningClient(conn)
 	routerClient := routerrpc.NewRouterClient(conn)
 
-	outChan := ctx.Uint64("outgoing_chan_id")
-	if outChan != 0 {
-		req.OutgoingChanIds = []uint64{outChan}
+	req.OutgoingChanIds = parseOutgoingChanIDs(ctx)
+
+	blockedFirstHops, err := parseBlockedFirstHops(ctx)
+	if err != nil {
+		return err
 	}
+	req.BlockedFirstHops = blockedFirstHops
+
 	if ctx.IsSet(lastHopFlag.Name) {
 		lastHop, err := route.NewVertexFromStr(
 			ctx.String(lastHopFlag.Name),
@@ -466,30 +498,12 @@ func sendPaymentRequest(ctx *cli.Context,
 	}
 
 	// Parse custom data records.
-	data := ctx.String(dataFlag.Name)
-	if data != "" {
-		records := strings.Split(data, ",")
-		for _, r := range records {
-			kv := strings.Split(r, "=")
-			if len(kv) != 2 {
-				return errors.New("invalid data format: " +
-					"multiple equal signs in record")
-			}
-
-			recordID, err := strconv.ParseUint(kv[0], 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid data format: %v",
-					err)
-			}
-
-			hexValue, err := hex.DecodeString(kv[1])
-			if err != nil {
-				return fmt.Errorf("invalid data format: %v",
-					err)
-			}
-
-			req.DestCustomRecords[recordID] = hexValue
-		}
+	customRecords, err := parseCustomRecords(ctx)
+	if err != nil {
+		return err
+	}
+	for recordID, value := range customRecords {
+		req.DestCustomRecords[recordID] = value
 	}
 
 	var feeLimit int64
@@ -536,6 +550,16 @@ func sendPaymentRequest(ctx *cli.Context,
 	// Set time pref.
 	req.TimePref = ctx.Float64(timePrefFlag.Name)
 
+	if ctx.IsSet(idempotencyKeyFlag.Name) {
+		keyHex := ctx.String(idempotencyKeyFlag.Name)
+		key, err := hex.DecodeString(keyHex)
+		if err != nil {
+			return fmt.Errorf("unable to decode idempotency "+
+				"key: %v", err)
+		}
+		req.IdempotencyKey = key
+	}
+
 	// Always print in-flight updates for the table output.
 	printJSON := ctx.Bool(jsonFlag.Name)
 	req.NoInflightUpdates = !ctx.Bool(inflightUpdatesFlag.Name) && printJSON
@@ -924,6 +948,23 @@ func sendToRoute(ctx *cli.Context) error {
 			"bytes, is instead %d", len(rHash))
 	}
 
+	route, err := parseRouteArg(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	req := &routerrpc.SendToRouteRequest{
+		PaymentHash: rHash,
+		Route:       route,
+		SkipTempErr: ctx.Bool("skip_temp_err"),
+	}
+
+	return sendToRouteRequest(ctx, req)
+}
+
+// parseRouteArg parses the route given through the routes flag, a positional
+// argument or stdin.
+func parseRouteArg(ctx *cli.Context, args cli.Args) (*lnrpc.Route, error) {
 	var jsonRoutes string
 	switch {
 	// The user is specifying the routes explicitly via the key word
@@ -940,10 +981,10 @@ func sendToRoute(ctx *cli.Context) error {
 	case args.Present() && args.First() == "-":
 		b, err := ioutil.ReadAll(os.Stdin)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if len(b) == 0 {
-			return fmt.Errorf("queryroutes output is empty")
+			return nil, fmt.Errorf("queryroutes output is empty")
 		}
 
 		jsonRoutes = string(b)
@@ -952,40 +993,31 @@ func sendToRoute(ctx *cli.Context) error {
 	// Try to parse the provided json both in the legacy QueryRoutes format
 	// that contains a list of routes and the single route BuildRoute
 	// format.
-	var route *lnrpc.Route
 	routes := &lnrpc.QueryRoutesResponse{}
-	err = lnrpc.ProtoJSONUnmarshalOpts.Unmarshal([]byte(jsonRoutes), routes)
+	err := lnrpc.ProtoJSONUnmarshalOpts.Unmarshal([]byte(jsonRoutes), routes)
 	if err == nil {
 		if len(routes.Routes) == 0 {
-			return fmt.Errorf("no routes provided")
+			return nil, fmt.Errorf("no routes provided")
 		}
 
 		if len(routes.Routes) != 1 {
-			return fmt.Errorf("expected a single route, but got %v",
-				len(routes.Routes))
+			return nil, fmt.Errorf("expected a single route, but "+
+				"got %v", len(routes.Routes))
 		}
 
-		route = routes.Routes[0]
-	} else {
-		routes := &routerrpc.BuildRouteResponse{}
-		err = lnrpc.ProtoJSONUnmarshalOpts.Unmarshal(
-			[]byte(jsonRoutes), routes,
-		)
-		if err != nil {
-			return fmt.Errorf("unable to unmarshal json string "+
-				"from incoming array of routes: %v", err)
-		}
-
-		route = routes.Route
+		return routes.Routes[0], nil
 	}
 
-	req := &routerrpc.SendToRouteRequest{
-		PaymentHash: rHash,
-		Route:       route,
-		SkipTempErr: ctx.Bool("skip_temp_err"),
+	buildRouteResp := &routerrpc.BuildRouteResponse{}
+	err = lnrpc.ProtoJSONUnmarshalOpts.Unmarshal(
+		[]byte(jsonRoutes), buildRouteResp,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal json string "+
+			"from incoming array of routes: %v", err)
 	}
 
-	return sendToRouteRequest(ctx, req)
+	return buildRouteResp.Route, nil
 }
 
 func sendToRouteRequest(ctx *cli.Context, req *routerrpc.SendToRouteRequest) error {
@@ -1005,6 +1037,62 @@ func sendToRouteRequest(ctx *cli.Context, req *routerrpc.SendToRouteRequest) err
 	return nil
 }
 
+var probeRouteCommand = cli.Command{
+	Name:     "proberoute",
+	Category: "Payments",
+	Usage:    "Probe the liquidity of a route without sending funds.",
+	Description: `
+	Send a probe along a specific route to test whether it has enough
+	liquidity to carry the route amount. The probe uses a random payment
+	hash, so that it can't be settled by the destination. The result of the
+	probe is reported to mission control.
+
+	The route is specified in the same ways as for sendtoroute:
+	    (lncli proberoute --routes=<route>)
+	    (lncli proberoute <route>)
+	    (lncli buildroute --args.. | lncli proberoute -)
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "routes, r",
+			Usage: "a json array string in the format of the " +
+				"response of queryroutes that denotes which " +
+				"route to probe",
+		},
+	},
+	Action: actionDecorator(probeRoute),
+}
+
+func probeRoute(ctx *cli.Context) error {
+	// Show command help if no arguments provided.
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		_ = cli.ShowCommandHelp(ctx, "proberoute")
+		return nil
+	}
+
+	route, err := parseRouteArg(ctx, ctx.Args())
+	if err != nil {
+		return err
+	}
+
+	ctxc := getContext()
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	client := routerrpc.NewRouterClient(conn)
+
+	resp, err := client.ProbeRoute(ctxc, &routerrpc.ProbeRouteRequest{
+		Route: route,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var queryRoutesCommand = cli.Command{
 	Name:        "queryroutes",
 	Category:    "Payments",
@@ -1040,11 +1128,8 @@ var queryRoutesCommand = cli.Command{
 			Name:  "use_mc",
 			Usage: "use mission control probabilities",
 		},
-		cli.Uint64Flag{
-			Name: "outgoing_chan_id",
-			Usage: "(optional) the channel id of the channel " +
-				"that must be taken to the first hop",
-		},
+		outgoingChanIDFlag,
+		blockedFirstHopFlag,
 		cli.StringSliceFlag{
 			Name: "ignore_pair",
 			Usage: "ignore directional node pair " +
@@ -1054,6 +1139,8 @@ var queryRoutesCommand = cli.Command{
 		},
 		timePrefFlag,
 		cltvLimitFlag,
+		dataFlag,
+		routeHintsFlag,
 	},
 	Action: actionDecorator(queryRoutes),
 }
@@ -1123,6 +1210,21 @@ func queryRoutes(ctx *cli.Context) error {
 		}
 	}
 
+	blockedFirstHops, err := parseBlockedFirstHops(ctx)
+	if err != nil {
+		return err
+	}
+
+	customRecords, err := parseCustomRecords(ctx)
+	if err != nil {
+		return err
+	}
+
+	routeHints, err := parseRouteHints(ctx)
+	if err != nil {
+		return err
+	}
+
 	req := &lnrpc.QueryRoutesRequest{
 		PubKey:            dest,
 		Amt:               amt,
@@ -1130,9 +1232,12 @@ func queryRoutes(ctx *cli.Context) error {
 		FinalCltvDelta:    int32(ctx.Int("final_cltv_delta")),
 		UseMissionControl: ctx.Bool("use_mc"),
 		CltvLimit:         uint32(ctx.Uint64(cltvLimitFlag.Name)),
-		OutgoingChanId:    ctx.Uint64("outgoing_chan_id"),
+		OutgoingChanIds:   parseOutgoingChanIDs(ctx),
+		BlockedFirstHops:  blockedFirstHops,
 		TimePref:          ctx.Float64(timePrefFlag.Name),
 		IgnoredPairs:      ignoredPairs,
+		DestCustomRecords: customRecords,
+		RouteHints:        routeHints,
 	}
 
 	route, err := client.QueryRoutes(ctxc, req)
@@ -1144,6 +1249,92 @@ func queryRoutes(ctx *cli.Context) error {
 	return nil
 }
 
+// parseOutgoingChanIDs returns the outgoing channels that were passed with the
+// outgoing_chan_id flag.
+func parseOutgoingChanIDs(ctx *cli.Context) []uint64 {
+	chanIDs := ctx.Int64Slice(outgoingChanIDFlag.Name)
+	if len(chanIDs) == 0 {
+		return nil
+	}
+
+	outgoingChanIDs := make([]uint64, len(chanIDs))
+	for i, chanID := range chanIDs {
+		outgoingChanIDs[i] = uint64(chanID)
+	}
+
+	return outgoingChanIDs
+}
+
+// parseBlockedFirstHops returns the serialized pubkeys of the peers that were
+// passed with the blocked_first_hop flag.
+func parseBlockedFirstHops(ctx *cli.Context) ([][]byte, error) {
+	var blockedFirstHops [][]byte
+	for _, pubKey := range ctx.StringSlice(blockedFirstHopFlag.Name) {
+		firstHop, err := route.NewVertexFromStr(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blocked first hop: %v",
+				err)
+		}
+
+		blockedFirstHops = append(blockedFirstHops, firstHop[:])
+	}
+
+	return blockedFirstHops, nil
+}
+
+// parseCustomRecords returns the custom records that were passed with the data
+// flag.
+func parseCustomRecords(ctx *cli.Context) (map[uint64][]byte, error) {
+	data := ctx.String(dataFlag.Name)
+	if data == "" {
+		return nil, nil
+	}
+
+	customRecords := make(map[uint64][]byte)
+	for _, r := range strings.Split(data, ",") {
+		kv := strings.Split(r, "=")
+		if len(kv) != 2 {
+			return nil, errors.New("invalid data format: " +
+				"multiple equal signs in record")
+		}
+
+		recordID, err := strconv.ParseUint(kv[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data format: %v", err)
+		}
+
+		hexValue, err := hex.DecodeString(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid data format: %v", err)
+		}
+
+		customRecords[recordID] = hexValue
+	}
+
+	return customRecords, nil
+}
+
+// parseRouteHints returns the route hints that were passed as json with the
+// route_hints flag.
+func parseRouteHints(ctx *cli.Context) ([]*lnrpc.RouteHint, error) {
+	jsonHints := ctx.String(routeHintsFlag.Name)
+	if jsonHints == "" {
+		return nil, nil
+	}
+
+	// Wrap the list in a message that has a route hints field, so that the
+	// hints can be decoded with the proto json rules.
+	hints := &lnrpc.QueryRoutesRequest{}
+	err := lnrpc.ProtoJSONUnmarshalOpts.Unmarshal(
+		[]byte(`{"route_hints":`+jsonHints+`}`), hints,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode route hints: %v", err)
+	}
+
+	return hints.RouteHints, nil
+}
+
 // retrieveFeeLimitLegacy retrieves the fee limit based on the different fee
 // limit flags passed. This function will eventually disappear in favor of
 // retrieveFeeLimit and the new payment rpc.
@@ -1295,6 +1486,10 @@ var forwardingHistoryCommand = cli.Command{
 	Finally, callers can skip a series of events using the --index_offset
 	parameter. Each response will contain the offset index of the last
 	entry. Using this callers can manually paginate within a time slice.
+
+	If --include_failed is set, the final outcome of every forwarded htlc
+	is returned, including failed forwards and their failure reasons. This
+	requires lnd to run with --store-htlc-fwd-events.
 	`,
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -1320,6 +1515,11 @@ var forwardingHistoryCommand = cli.Command{
 			Usage: "skip the peer alias lookup per forwarding " +
 				"event in order to improve performance",
 		},
+		cli.BoolFlag{
+			Name: "include_failed",
+			Usage: "include failed forwards and the outcome of " +
+				"every forwarded htlc",
+		},
 	},
 	Action: actionDecorator(forwardingHistory),
 }
@@ -1399,6 +1599,7 @@ func forwardingHistory(ctx *cli.Context) error {
 		IndexOffset:     indexOffset,
 		NumMaxEvents:    maxEvents,
 		PeerAliasLookup: lookupPeerAlias,
+		IncludeFailed:   ctx.Bool("include_failed"),
 	}
 	resp, err := client.ForwardingHistory(ctxc, req)
 	if err != nil {
@@ -1441,6 +1642,8 @@ var buildRouteCommand = cli.Command{
 			Usage: "hex encoded payment address to set in the " +
 				"last hop's mpp record",
 		},
+		dataFlag,
+		routeHintsFlag,
 	},
 }
 
@@ -1486,13 +1689,25 @@ func buildRoute(ctx *cli.Context) error {
 		}
 	}
 
+	customRecords, err := parseCustomRecords(ctx)
+	if err != nil {
+		return err
+	}
+
+	routeHints, err := parseRouteHints(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Call BuildRoute rpc.
 	req := &routerrpc.BuildRouteRequest{
-		AmtMsat:        amtMsat,
-		FinalCltvDelta: int32(ctx.Int64("final_cltv_delta")),
-		HopPubkeys:     rpcHops,
-		OutgoingChanId: ctx.Uint64("outgoing_chan_id"),
-		PaymentAddr:    payAddr,
+		AmtMsat:           amtMsat,
+		FinalCltvDelta:    int32(ctx.Int64("final_cltv_delta")),
+		HopPubkeys:        rpcHops,
+		OutgoingChanId:    ctx.Uint64("outgoing_chan_id"),
+		PaymentAddr:       payAddr,
+		DestCustomRecords: customRecords,
+		RouteHints:        routeHints,
 	}
 
 	route, err := client.BuildRoute(ctxc, req)
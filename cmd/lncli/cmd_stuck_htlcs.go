@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ltcsuite/lnd/lnrpc/routerrpc"
+	"github.com/urfave/cli"
+)
+
+var lookupHtlcCommand = cli.Command{
+	Name:     "lookuphtlc",
+	Category: "Channels",
+	Usage:    "Look up an htlc that is held by the switch.",
+	Description: `
+	Look up an htlc that is currently held by the switch or the forward
+	interceptor, identified by the channel it was received on and its
+	index on that channel. Next to the incoming and outgoing channel, hold
+	time and expiry of the htlc, the state that keeps the htlc from being
+	resolved is returned.
+
+	For payments initiated by this node, the incoming channel id is 0.`,
+	ArgsUsage: "chan_id htlc_id",
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "chan_id",
+			Usage: "the short channel id of the channel the htlc " +
+				"was received on",
+		},
+		cli.Uint64Flag{
+			Name:  "htlc_id",
+			Usage: "the index of the htlc on the incoming channel",
+		},
+	},
+	Action: actionDecorator(lookupHtlc),
+}
+
+func lookupHtlc(ctx *cli.Context) error {
+	ctxc := getContext()
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		_ = cli.ShowCommandHelp(ctx, "lookuphtlc")
+		return nil
+	}
+
+	var (
+		args = ctx.Args()
+		req  = &routerrpc.LookupHtlcRequest{}
+		err  error
+	)
+
+	switch {
+	case ctx.IsSet("chan_id"):
+		req.IncomingChanId = ctx.Uint64("chan_id")
+
+	case args.Present():
+		req.IncomingChanId, err = strconv.ParseUint(
+			args.First(), 10, 64,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to decode chan_id: %w", err)
+		}
+		args = args.Tail()
+
+	default:
+		return fmt.Errorf("chan_id argument missing")
+	}
+
+	switch {
+	case ctx.IsSet("htlc_id"):
+		req.IncomingHtlcId = ctx.Uint64("htlc_id")
+
+	case args.Present():
+		req.IncomingHtlcId, err = strconv.ParseUint(
+			args.First(), 10, 64,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to decode htlc_id: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("htlc_id argument missing")
+	}
+
+	client := routerrpc.NewRouterClient(conn)
+	resp, err := client.LookupHtlc(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listStuckHtlcsCommand = cli.Command{
+	Name:     "liststuckhtlcs",
+	Category: "Channels",
+	Usage:    "List the htlcs that have been held by the switch for long.",
+	Description: `
+	List all htlcs that have been held by the switch or the forward
+	interceptor for at least the given amount of time, sorted by their
+	hold time, longest first. For every htlc, the incoming and outgoing
+	channel, hold time, expiry and the state that keeps it from being
+	resolved are returned.`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "min_hold_time",
+			Usage: "the minimum number of seconds an htlc must " +
+				"have been held to be listed",
+			Value: 60,
+		},
+	},
+	Action: actionDecorator(listStuckHtlcs),
+}
+
+func listStuckHtlcs(ctx *cli.Context) error {
+	ctxc := getContext()
+	conn := getClientConn(ctx, false)
+	defer conn.Close()
+
+	req := &routerrpc.ListStuckHtlcsRequest{
+		MinHoldTimeSec: ctx.Uint64("min_hold_time"),
+	}
+
+	client := routerrpc.NewRouterClient(conn)
+	resp, err := client.ListStuckHtlcs(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
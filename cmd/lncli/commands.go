@@ -747,6 +747,11 @@ var closeChannelCommand = cli.Command{
 	if an upfront shutdown address has not already been set. If neither are
 	set the funds will be delivered to a new wallet address.
 
+	If the channel is being closed cooperatively with RBF based close
+	negotiation, the closing transaction can be replaced with one that pays
+	the fee rate set via --conf_target or --sat_per_vbyte by calling this
+	command again with --bump_fee. The replacement is paid for by this node.
+
 	To view which funding_txids/output_indexes can be used for a channel close,
 	see the channel_point values within the listchannels command output.
 	The format for a channel_point is 'funding_txid:output_index'.`,
@@ -801,6 +806,13 @@ var closeChannelCommand = cli.Command{
 				"be used if an upfront shutdown address is not " +
 				"already set",
 		},
+		cli.BoolFlag{
+			Name: "bump_fee",
+			Usage: "replace the closing transaction of a channel " +
+				"that is being closed cooperatively with RBF " +
+				"based close negotiation with one that pays " +
+				"the given fee rate",
+		},
 	},
 	Action: actionDecorator(closeChannel),
 }
@@ -837,6 +849,7 @@ func closeChannel(ctx *cli.Context) error {
 		TargetConf:      int32(ctx.Int64("conf_target")),
 		SatPerVbyte:     ctx.Uint64(feeRateFlag),
 		DeliveryAddress: ctx.String("delivery_addr"),
+		BumpFee:         ctx.Bool("bump_fee"),
 	}
 
 	// After parsing the request, we'll spin up a goroutine that will
@@ -1431,6 +1444,65 @@ func pendingChannels(ctx *cli.Context) error {
 	return nil
 }
 
+var importPreimageCommand = cli.Command{
+	Name:     "importpreimage",
+	Category: "Channels",
+	Usage: "Import the preimage of a pending incoming htlc of a force " +
+		"closed channel.",
+	Description: `
+	Adds a preimage that was obtained out-of-band to the preimage cache, so
+	pending incoming htlcs of force closed channels with the matching
+	payment hash are claimed on-chain instead of timing out.
+
+	The command fails if no pending incoming htlc is waiting for the
+	preimage. The channel points of the channels that will claim an htlc
+	with the preimage are returned otherwise.`,
+	ArgsUsage: "preimage",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "preimage",
+			Usage: "the hex-encoded 32 byte preimage",
+		},
+	},
+	Action: actionDecorator(importPreimage),
+}
+
+func importPreimage(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		preimage []byte
+		err      error
+	)
+
+	switch {
+	case ctx.IsSet("preimage"):
+		preimage, err = hex.DecodeString(ctx.String("preimage"))
+	case ctx.Args().Present():
+		preimage, err = hex.DecodeString(ctx.Args().First())
+	default:
+		return fmt.Errorf("preimage argument missing")
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to decode preimage argument: %v", err)
+	}
+
+	req := &lnrpc.ImportPreimageRequest{
+		Preimage: preimage,
+	}
+	resp, err := client.ImportPreimage(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var listChannelsCommand = cli.Command{
 	Name:     "listchannels",
 	Category: "Channels",
@@ -1492,6 +1564,35 @@ func listaliases(ctx *cli.Context) error {
 	return nil
 }
 
+var dustExposureCommand = cli.Command{
+	Name:     "dustexposure",
+	Category: "Channels",
+	Usage:    "Show the current dust exposure of all active channels.",
+	Description: `
+	Show the sum of the dust HTLCs of every active channel, which would be
+	burned to fees if one of the channel's commitments confirmed, along with
+	the configured dust thresholds.
+	`,
+	Action: actionDecorator(dustExposure),
+}
+
+func dustExposure(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.DustExposureRequest{}
+
+	resp, err := client.DustExposure(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 func listChannels(ctx *cli.Context) error {
 	ctxc := getContext()
 	client, cleanUp := getClient(ctx)
@@ -1786,6 +1887,34 @@ func getNetworkInfo(ctx *cli.Context) error {
 	return nil
 }
 
+var gcGraphCommand = cli.Command{
+	Name:     "gcgraph",
+	Category: "Channels",
+	Usage:    "Force a garbage collection pass over the channel graph.",
+	Description: `
+	Prunes zombie channels from the channel graph, as well as the least
+	recently updated channels of nodes that exceed the configured
+	routing.maxchannelspernode. The same pass runs periodically in the
+	background at the configured gc-graph-interval.`,
+	Action: actionDecorator(gcGraph),
+}
+
+func gcGraph(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.GarbageCollectGraphRequest{}
+
+	resp, err := client.GarbageCollectGraph(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var debugLevelCommand = cli.Command{
 	Name:  "debuglevel",
 	Usage: "Set the debug level.",
@@ -1841,6 +1970,11 @@ var listChainTxnsCommand = cli.Command{
 				"until the chain tip, including unconfirmed, " +
 				"set this value to -1",
 		},
+		cli.StringFlag{
+			Name: "label",
+			Usage: "(optional) only list transactions with a " +
+				"label matching this search query",
+		},
 	},
 	Description: `
 	List all transactions an address of the wallet was involved in.
@@ -1854,6 +1988,11 @@ var listChainTxnsCommand = cli.Command{
 	transactions (identifiable with BlockHeight=0), set end_height to -1.
 	By default, this call will get all transactions our wallet was involved
 	in, including unconfirmed transactions.
+
+	The label flag can be used to search for transactions by their label.
+	A label matches if all characters of the search query appear in it in
+	the same order, ignoring case. For example, "clschan" matches all
+	channel close transactions.
 `,
 	Action: actionDecorator(listChainTxns),
 }
@@ -1871,6 +2010,9 @@ func listChainTxns(ctx *cli.Context) error {
 	if ctx.IsSet("end_height") {
 		req.EndHeight = int32(ctx.Int64("end_height"))
 	}
+	if ctx.IsSet("label") {
+		req.Label = ctx.String("label")
+	}
 
 	resp, err := client.GetTransactions(ctxc, req)
 	if err != nil {
@@ -2092,6 +2234,25 @@ var updateChannelPolicyCommand = cli.Command{
 				"applied to all forwarded HTLCs. If unset, " +
 				"the max HTLC is left unchanged",
 		},
+		cli.Int64Flag{
+			Name: "inbound_base_fee_msat",
+			Usage: "the base fee in milli-satoshis that will be " +
+				"charged for each HTLC arriving over the " +
+				"channel, on top of the outbound fee. May be " +
+				"negative to give a discount. If neither " +
+				"inbound fee flag is set, the inbound fee is " +
+				"left unchanged",
+		},
+		cli.Int64Flag{
+			Name: "inbound_fee_rate_ppm",
+			Usage: "the fee rate ppm (parts per million) that " +
+				"will be charged proportionally based on the " +
+				"value of each HTLC arriving over the " +
+				"channel, on top of the outbound fee. May be " +
+				"negative to give a discount. If neither " +
+				"inbound fee flag is set, the inbound fee is " +
+				"left unchanged",
+		},
 		cli.StringFlag{
 			Name: "chan_point",
 			Usage: "the channel which this policy update should " +
@@ -2237,6 +2398,29 @@ func updateChannelPolicy(ctx *cli.Context) error {
 		req.MinHtlcMsatSpecified = true
 	}
 
+	if ctx.IsSet("inbound_base_fee_msat") ||
+		ctx.IsSet("inbound_fee_rate_ppm") {
+
+		inboundBaseFee := ctx.Int64("inbound_base_fee_msat")
+		if inboundBaseFee < math.MinInt32 ||
+			inboundBaseFee > math.MaxInt32 {
+
+			return errors.New("inbound_base_fee_msat out of range")
+		}
+
+		inboundFeeRate := ctx.Int64("inbound_fee_rate_ppm")
+		if inboundFeeRate < math.MinInt32 ||
+			inboundFeeRate > math.MaxInt32 {
+
+			return errors.New("inbound_fee_rate_ppm out of range")
+		}
+
+		req.InboundFee = &lnrpc.InboundFee{
+			BaseFeeMsat: int32(inboundBaseFee),
+			FeeRatePpm:  int32(inboundFeeRate),
+		}
+	}
+
 	if chanPoint != nil {
 		req.Scope = &lnrpc.PolicyUpdateRequest_ChanPoint{
 			ChanPoint: chanPoint,
@@ -2463,6 +2647,103 @@ func exportChanBackup(ctx *cli.Context) error {
 	return nil
 }
 
+var backupDatabaseCommand = cli.Command{
+	Name:      "backupdatabase",
+	Category:  "Channels",
+	Usage:     "Take a backup of the live channel database.",
+	ArgsUsage: "output_file",
+	Description: `
+	This command streams a consistent snapshot of the channel database of
+	the running lnd node into the given file, without the need to stop lnd.
+	For the bolt database backend, the resulting file is a regular
+	channel.db file.
+
+	NOTE: Restoring an outdated channel database can lead to a loss of
+	funds, as it might cause lnd to broadcast revoked channel states. Use
+	static channel backups to recover funds after a data loss.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output_file",
+			Usage: "the file to write the database backup to",
+		},
+		cli.Uint64Flag{
+			Name: "chunk_size",
+			Usage: "the maximum number of bytes lnd sends in a " +
+				"single message",
+		},
+	},
+	Action: actionDecorator(backupDatabase),
+}
+
+func backupDatabase(ctx *cli.Context) error {
+	ctxc := getContext()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var outputFileName string
+	switch {
+	case ctx.IsSet("output_file"):
+		outputFileName = ctx.String("output_file")
+
+	case ctx.Args().Present():
+		outputFileName = ctx.Args().First()
+
+	default:
+		return cli.ShowCommandHelp(ctx, "backupdatabase")
+	}
+
+	stream, err := client.BackupDatabase(
+		ctxc, &lnrpc.DatabaseBackupRequest{
+			ChunkSize: uint32(ctx.Uint64("chunk_size")),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	outputFile, err := os.OpenFile(
+		outputFileName, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600,
+	)
+	if err != nil {
+		return err
+	}
+
+	var numBytes uint64
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err == nil && chunk.Offset != numBytes {
+			err = fmt.Errorf("unexpected chunk offset %d, expected "+
+				"%d", chunk.Offset, numBytes)
+		}
+		if err == nil {
+			_, err = outputFile.Write(chunk.Data)
+		}
+		if err != nil {
+			// Don't leave a partial backup behind that could be
+			// mistaken for a complete one.
+			_ = outputFile.Close()
+			_ = os.Remove(outputFileName)
+
+			return err
+		}
+
+		numBytes += uint64(len(chunk.Data))
+	}
+
+	if err := outputFile.Close(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote database backup of %d bytes to %s\n", numBytes,
+		outputFileName)
+
+	return nil
+}
+
 var verifyChanBackupCommand = cli.Command{
 	Name:      "verifychanbackup",
 	Category:  "Channels",
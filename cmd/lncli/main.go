@@ -470,13 +470,16 @@ func main() {
 		getInfoCommand,
 		getRecoveryInfoCommand,
 		pendingChannelsCommand,
+		importPreimageCommand,
 		sendPaymentCommand,
 		payInvoiceCommand,
 		sendToRouteCommand,
+		probeRouteCommand,
 		addInvoiceCommand,
 		lookupInvoiceCommand,
 		listInvoicesCommand,
 		listChannelsCommand,
+		dustExposureCommand,
 		closedChannelsCommand,
 		listPaymentsCommand,
 		describeGraphCommand,
@@ -485,6 +488,7 @@ func main() {
 		getNodeInfoCommand,
 		queryRoutesCommand,
 		getNetworkInfoCommand,
+		gcGraphCommand,
 		debugLevelCommand,
 		decodePayReqCommand,
 		listChainTxnsCommand,
@@ -495,6 +499,8 @@ func main() {
 		updateChannelPolicyCommand,
 		forwardingHistoryCommand,
 		exportChanBackupCommand,
+		backupDatabaseCommand,
+		listDBPrefixesCommand,
 		verifyChanBackupCommand,
 		restoreChanBackupCommand,
 		bakeMacaroonCommand,
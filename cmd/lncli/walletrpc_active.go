@@ -11,7 +11,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
+	"strconv"
 
 	"github.com/ltcsuite/lnd/lnrpc"
 	"github.com/ltcsuite/lnd/lnrpc/walletrpc"
@@ -40,6 +42,8 @@ var (
 		Usage: "Interact with wallet accounts.",
 		Subcommands: []cli.Command{
 			listAccountsCommand,
+			createAccountCommand,
+			exportDescriptorsCommand,
 			importAccountCommand,
 			importPubKeyCommand,
 		},
@@ -81,6 +85,7 @@ func walletCommands() []cli.Command {
 				accountsCommand,
 				requiredReserveCommand,
 				addressesCommand,
+				rescanCommand,
 			},
 		},
 	}
@@ -184,7 +189,8 @@ var bumpFeeCommand = cli.Command{
 	fee transaction that is under the control of the wallet.
 
 	A fee preference must be provided, either through the conf_target or
-	sat_per_vbyte parameters.
+	sat_per_vbyte parameters. A deadline_height can be set along with the
+	conf_target to sweep more aggressively as the deadline approaches.
 
 	Note that this command currently doesn't perform any validation checks
 	on the fee preference being provided. For now, the responsibility of
@@ -212,6 +218,12 @@ var bumpFeeCommand = cli.Command{
 			Usage: "a manual fee expressed in sat/vbyte that " +
 				"should be used when sweeping the output",
 		},
+		cli.Uint64Flag{
+			Name: "deadline_height",
+			Usage: "the block height by which the output should " +
+				"be swept on-chain; the conf_target is " +
+				"lowered as the deadline approaches",
+		},
 		cli.BoolFlag{
 			Name:  "force",
 			Usage: "sweep even if the yield is negative",
@@ -248,10 +260,11 @@ func bumpFee(ctx *cli.Context) error {
 	defer cleanUp()
 
 	resp, err := client.BumpFee(ctxc, &walletrpc.BumpFeeRequest{
-		Outpoint:    protoOutPoint,
-		TargetConf:  uint32(ctx.Uint64("conf_target")),
-		SatPerVbyte: ctx.Uint64(feeRateFlag),
-		Force:       ctx.Bool("force"),
+		Outpoint:       protoOutPoint,
+		TargetConf:     uint32(ctx.Uint64("conf_target")),
+		SatPerVbyte:    ctx.Uint64(feeRateFlag),
+		DeadlineHeight: uint32(ctx.Uint64("deadline_height")),
+		Force:          ctx.Bool("force"),
 	})
 	if err != nil {
 		return err
@@ -582,7 +595,8 @@ var fundPsbtCommand = cli.Command{
 	Name:  "fund",
 	Usage: "Fund a Partially Signed Bitcoin Transaction (PSBT).",
 	ArgsUsage: "[--template_psbt=T | [--outputs=O [--inputs=I]]] " +
-		"[--conf_target=C | --sat_per_vbyte=S] [--change_type=A]",
+		"[--conf_target=C | --sat_per_vbyte=S] [--change_type=A] " +
+		"[--change_position=P]",
 	Description: `
 	The fund command creates a fully populated PSBT that contains enough
 	inputs to fund the outputs specified in either the PSBT or the
@@ -660,6 +674,15 @@ var fundPsbtCommand = cli.Command{
 				"always use the coin selection key scope to " +
 				"generate the change address",
 		},
+		cli.StringFlag{
+			Name: "change_position",
+			Usage: "(optional) the position of the change output " +
+				"if one is added, either first, last or the " +
+				"index to place it at. If no position is " +
+				"provided, the change output is placed " +
+				"according to the wallet's default ordering " +
+				"of the outputs",
+		},
 		cli.Uint64Flag{
 			Name: "min_confs",
 			Usage: "(optional) the minimum number of " +
@@ -790,6 +813,30 @@ func fundPsbt(ctx *cli.Context) error {
 		}
 	}
 
+	if ctx.IsSet("change_position") {
+		switch position := ctx.String("change_position"); position {
+		case "first":
+			//nolint:lll
+			req.ChangePosition = walletrpc.ChangePosition_CHANGE_POSITION_FIRST
+
+		case "last":
+			//nolint:lll
+			req.ChangePosition = walletrpc.ChangePosition_CHANGE_POSITION_LAST
+
+		default:
+			index, err := strconv.ParseUint(position, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid change position: "+
+					"%s, must be either first, last or an "+
+					"output index", position)
+			}
+
+			//nolint:lll
+			req.ChangePosition = walletrpc.ChangePosition_CHANGE_POSITION_INDEX
+			req.ChangeOutputIndex = uint32(index)
+		}
+	}
+
 	walletClient, cleanUp := getWalletClient(ctx)
 	defer cleanUp()
 
@@ -1068,8 +1115,15 @@ func releaseOutput(ctx *cli.Context) error {
 }
 
 var listLeasesCommand = cli.Command{
-	Name:   "listleases",
-	Usage:  "Return a list of currently held leases.",
+	Name:  "listleases",
+	Usage: "Return a list of currently held leases.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "lockid",
+			Usage: "only return leases held with the given " +
+				"hex-encoded lock ID",
+		},
+	},
 	Action: actionDecorator(listLeases),
 }
 
@@ -1080,6 +1134,14 @@ func listLeases(ctx *cli.Context) error {
 	defer cleanUp()
 
 	req := &walletrpc.ListLeasesRequest{}
+	if lockIDStr := ctx.String("lockid"); lockIDStr != "" {
+		lockID, err := hex.DecodeString(lockIDStr)
+		if err != nil {
+			return fmt.Errorf("error parsing lockid: %v", err)
+		}
+		req.Id = lockID
+	}
+
 	response, err := walletClient.ListLeases(ctxc, req)
 	if err != nil {
 		return err
@@ -1143,6 +1205,124 @@ func listAccounts(ctx *cli.Context) error {
 	return nil
 }
 
+var createAccountCommand = cli.Command{
+	Name:      "create",
+	Usage:     "Derive a new on-chain account from the wallet's seed.",
+	ArgsUsage: "name",
+	Description: `
+	Derives a new named account from the wallet's seed. Addresses can then
+	be requested for the account with the --account flag of the
+	newaddress command, which allows funds to be kept apart from those of
+	the default account.
+
+	The address type determines the key scope of the account and with it
+	the type of addresses that can be derived from it. It defaults to
+	p2wkh.
+
+	If an account with the same name already exists (even with a different
+	key scope), an error will be returned. Watch-only wallets cannot create
+	accounts, use the import command instead.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "address_type",
+			Usage: "(optional) specify the type of addresses the " +
+				"account should generate",
+		},
+	},
+	Action: actionDecorator(createAccount),
+}
+
+func createAccount(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() != 1 || ctx.NumFlags() > 1 {
+		return cli.ShowCommandHelp(ctx, "create")
+	}
+
+	addrType, err := parseAddrType(ctx.String("address_type"))
+	if err != nil {
+		return err
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	req := &walletrpc.CreateAccountRequest{
+		Name:        ctx.Args().First(),
+		AddressType: addrType,
+	}
+	resp, err := walletClient.CreateAccount(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var exportDescriptorsCommand = cli.Command{
+	Name:  "descriptors",
+	Usage: "Export the output descriptors of on-chain wallet accounts.",
+	Description: `
+	Exports the output descriptors of the external and internal branch of
+	all accounts that derive their keys from an extended public key. The
+	descriptors include the master key fingerprint, derivation path and
+	checksum of the account, so they can be imported into other wallet
+	software such as Litecoin Core to watch the account.
+
+	A name and address type filter can be provided to only export the
+	descriptors of the matching accounts.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "name",
+			Usage: "(optional) only accounts matching this name " +
+				"are exported",
+		},
+		cli.StringFlag{
+			Name: "address_type",
+			Usage: "(optional) only accounts matching this " +
+				"address type are exported",
+		},
+	},
+	Action: actionDecorator(exportDescriptors),
+}
+
+func exportDescriptors(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() > 0 || ctx.NumFlags() > 2 {
+		return cli.ShowCommandHelp(ctx, "descriptors")
+	}
+
+	addrType, err := parseAddrType(ctx.String("address_type"))
+	if err != nil {
+		return err
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	req := &walletrpc.ExportDescriptorsRequest{
+		Name:        ctx.String("name"),
+		AddressType: addrType,
+	}
+	resp, err := walletClient.ExportDescriptors(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var requiredReserveCommand = cli.Command{
 	Name:  "requiredreserve",
 	Usage: "Returns the wallet reserve.",
@@ -1462,8 +1642,8 @@ var importAccountCommand = cli.Command{
 	pubkeys externally, witness pubkeys internally).
 
 	NOTE: Events (deposits/spends) for keys derived from an account will
-	only be detected by lnd if they happen after the import. Rescans to
-	detect past events will be supported later on.
+	only be detected by lnd if they happen after the import. Use the
+	rescan command to detect past events.
 	`,
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -1538,8 +1718,8 @@ var importPubKeyCommand = cli.Command{
 	The address type must be one of the following: np2wkh, p2wkh.
 
 	NOTE: Events (deposits/spends) for a key will only be detected by lnd if
-	they happen after the import. Rescans to detect past events will be
-	supported later on.
+	they happen after the import. Use the rescan command to detect past
+	events.
 	`,
 	Action: actionDecorator(importPubKey),
 }
@@ -1577,3 +1757,58 @@ func importPubKey(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var rescanCommand = cli.Command{
+	Name:  "rescan",
+	Usage: "Rescan the chain for transactions relevant to the wallet.",
+	Description: `
+	Rescans the chain for transactions relevant to the wallet's addresses
+	and unspent outputs, starting at the given height or the wallet's
+	birthday block if no height is specified. This is useful to detect past
+	events after importing accounts or public keys into the wallet.
+
+	The progress of the rescan is printed until it completes. The wallet
+	isn't considered synced to the chain while the rescan is running.
+	`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "start_height",
+			Usage: "(optional) the height to start the rescan at, " +
+				"defaults to the wallet's birthday block",
+		},
+	},
+	Action: actionDecorator(rescanBlockchain),
+}
+
+func rescanBlockchain(ctx *cli.Context) error {
+	ctxc := getContext()
+
+	// Display the command's help message if we do not have the expected
+	// number of arguments/flags.
+	if ctx.NArg() > 0 || ctx.NumFlags() > 1 {
+		return cli.ShowCommandHelp(ctx, "rescan")
+	}
+
+	walletClient, cleanUp := getWalletClient(ctx)
+	defer cleanUp()
+
+	req := &walletrpc.RescanBlockchainRequest{
+		StartHeight: uint32(ctx.Uint64("start_height")),
+	}
+	stream, err := walletClient.RescanBlockchain(ctxc, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(update)
+	}
+}
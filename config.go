@@ -24,6 +24,7 @@ import (
 	"github.com/ltcsuite/lnd/chainreg"
 	"github.com/ltcsuite/lnd/chanbackup"
 	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/contractcourt"
 	"github.com/ltcsuite/lnd/discovery"
 	"github.com/ltcsuite/lnd/funding"
 	"github.com/ltcsuite/lnd/htlcswitch"
@@ -123,6 +124,10 @@ const (
 	// backing IP of a host has changed.
 	defaultHostSampleInterval = time.Minute * 5
 
+	// defaultExternalIPProbeTimeout is the default timeout of a single
+	// query of an external IP probe.
+	defaultExternalIPProbeTimeout = 10 * time.Second
+
 	defaultChainInterval = time.Minute
 	defaultChainTimeout  = time.Second * 30
 	defaultChainBackoff  = time.Minute * 2
@@ -309,11 +314,14 @@ type Config struct {
 	RawListeners      []string `long:"listen" description:"Add an interface/port to listen for peer connections"`
 	RawExternalIPs    []string `long:"externalip" description:"Add an ip:port to the list of local addresses we claim to listen on to peers. If a port is not specified, the default (9735) will be used regardless of other parameters"`
 	ExternalHosts     []string `long:"externalhosts" description:"Add a hostname:port that should be periodically resolved to announce IPs for. If a port is not specified, the default (9735) will be used."`
+	RawExternalDNS    string   `long:"externaldnsaddr" description:"Announce a hostname:port that peers can connect to, without resolving it. In contrast to externalhosts, the hostname is announced as is and resolved by the connecting peers. If a port is not specified, the default (9735) will be used."`
+	ExternalIPProbes  []string `long:"externalip-probe" description:"Add a URL of a service that responds with the IP address of the requester as plain text. The service is periodically queried to discover the external IPv4 and IPv6 addresses of the node, which are then announced with the ports lnd listens on. If NAT traversal is enabled, it is used as the first source. This reveals the IP address of the node to the service."`
 	RPCListeners      []net.Addr
 	RESTListeners     []net.Addr
 	RestCORS          []string `long:"restcors" description:"Add an ip:port/hostname to allow cross origin access from. To allow all origins, set as \"*\"."`
 	Listeners         []net.Addr
 	ExternalIPs       []net.Addr
+	ExternalDNS       *lnwire.DNSAddress
 	DisableListen     bool          `long:"nolisten" description:"Disable listening for incoming peer connections"`
 	DisableRest       bool          `long:"norest" description:"Disable REST API"`
 	DisableRestTLS    bool          `long:"no-rest-tls" description:"Disable TLS for REST connections"`
@@ -339,7 +347,7 @@ type Config struct {
 	MaxPendingChannels int    `long:"maxpendingchannels" description:"The maximum number of incoming pending channels permitted per peer."`
 	BackupFilePath     string `long:"backupfilepath" description:"The target location of the channel backup file"`
 
-	FeeURL string `long:"feeurl" description:"Optional URL for external fee estimation. If no URL is specified, the method for fee estimation will depend on the chosen backend and network. Must be set for neutrino on mainnet."`
+	FeeURL string `long:"feeurl" description:"Optional URL for external fee estimation. If no URL is specified, the method for fee estimation will depend on the chosen backend and network. Both the sparse conf target format and mempool.space-style fees/recommended endpoints are supported. A file:// URL reads fee rates from a local JSON or YAML file that is reloaded when it changes. Defaults to https://litecoinspace.org/api/v1/fees/recommended for neutrino on mainnet."`
 
 	Litecoin      *lncfg.Chain    `group:"Litecoin" namespace:"litecoin"`
 	LtcdMode      *lncfg.Btcd     `group:"ltcd" namespace:"ltcd"`
@@ -358,19 +366,22 @@ type Config struct {
 
 	NoNetBootstrap bool `long:"nobootstrap" description:"If true, then automatic network bootstrapping will not be attempted."`
 
+	NoDNSPeerAddrs bool `long:"nodnspeeraddrs" description:"If true, DNS hostnames announced by other nodes won't be used to connect to them. Resolving a hostname reveals to the DNS resolver which node we are connecting to. If Tor is active, hostnames are resolved by the Tor exit relay instead."`
+
 	NoSeedBackup             bool   `long:"noseedbackup" description:"If true, NO SEED WILL BE EXPOSED -- EVER, AND THE WALLET WILL BE ENCRYPTED USING THE DEFAULT PASSPHRASE. THIS FLAG IS ONLY FOR TESTING AND SHOULD NEVER BE USED ON MAINNET."`
 	WalletUnlockPasswordFile string `long:"wallet-unlock-password-file" description:"The full path to a file (or pipe/device) that contains the password for unlocking the wallet; if set, no unlocking through RPC is possible and lnd will exit if no wallet exists or the password is incorrect; if wallet-unlock-allow-create is also set then lnd will ignore this flag if no wallet exists and allow a wallet to be created through RPC."`
 	WalletUnlockAllowCreate  bool   `long:"wallet-unlock-allow-create" description:"Don't fail with an error if wallet-unlock-password-file is set but no wallet exists yet."`
 
 	ResetWalletTransactions bool `long:"reset-wallet-transactions" description:"Removes all transaction history from the on-chain wallet on startup, forcing a full chain rescan starting at the wallet's birthday. Implements the same functionality as ltcwallet's dropwtxmgr command. Should be set to false after successful execution to avoid rescanning on every restart of lnd."`
 
-	CoinSelectionStrategy string `long:"coin-selection-strategy" description:"The strategy to use for selecting coins for wallet transactions." choice:"largest" choice:"random"`
+	CoinSelectionStrategy string `long:"coin-selection-strategy" description:"The strategy to use for selecting coins for wallet transactions. The bnb (branch and bound) strategy is used for channel funding, sweeps and FundPsbt, other wallet transactions select the largest coins first with it." choice:"largest" choice:"random" choice:"bnb"`
 
 	PaymentsExpirationGracePeriod time.Duration `long:"payments-expiration-grace-period" description:"A period to wait before force closing channels with outgoing htlcs that have timed-out and are a result of this node initiated payments."`
 	TrickleDelay                  int           `long:"trickledelay" description:"Time in milliseconds between each release of announcements to the network"`
 	ChanEnableTimeout             time.Duration `long:"chan-enable-timeout" description:"The duration that a peer connection must be stable before attempting to send a channel update to re-enable or cancel a pending disables of the peer's channels on the network."`
 	ChanDisableTimeout            time.Duration `long:"chan-disable-timeout" description:"The duration that must elapse after first detecting that an already active channel is actually inactive and sending channel update disabling it to the network. The pending disable can be canceled if the peer reconnects and becomes stable for chan-enable-timeout before the disable update is sent."`
 	ChanStatusSampleInterval      time.Duration `long:"chan-status-sample-interval" description:"The polling interval between attempts to detect if an active channel has become inactive due to its peer going offline."`
+	ChanUpdateBatchWindow         time.Duration `long:"chan-update-batch-window" description:"The duration during which status and policy changes of a channel are collected before a single channel update containing all of them is broadcast. Set to 0 to broadcast every change immediately."`
 	HeightHintCacheQueryDisable   bool          `long:"height-hint-cache-query-disable" description:"Disable queries from the height-hint cache to try to recover channels stuck in the pending close state. Disabling height hint queries may cause longer chain rescans, resulting in a performance hit. Unset this after channels are unstuck so you can get better performance again."`
 	Alias                         string        `long:"alias" description:"The node alias. Used as a moniker by peers and intelligence services"`
 	Color                         string        `long:"color" description:"The color of the node in hex format (i.e. '#3399FF'). Used to customize node appearance in intelligence services"`
@@ -384,10 +395,16 @@ type Config struct {
 
 	ChannelCommitBatchSize uint32 `long:"channel-commit-batch-size" description:"The maximum number of channel state updates that is accumulated before signing a new commitment."`
 
+	ChannelCommitAdaptive bool `long:"channel-commit-adaptive" description:"If set, a new commitment is signed as soon as a channel has no further updates queued, and updates are only batched while the channel is under load. The batch is signed once it reaches channel-commit-batch-size or channel-commit-interval has passed."`
+
 	KeepFailedPaymentAttempts bool `long:"keep-failed-payment-attempts" description:"Keeps persistent record of all failed payment attempts for successfully settled payments."`
 
 	StoreFinalHtlcResolutions bool `long:"store-final-htlc-resolutions" description:"Persistently store the final resolution of incoming htlcs."`
 
+	StoreHtlcFwdEvents bool `long:"store-htlc-fwd-events" description:"Persistently store the final outcome of every forwarded htlc, including failed forwards and their failure reasons. The events can be queried with the include_failed option of the forwarding history."`
+
+	HtlcFwdEventRetention time.Duration `long:"htlc-fwd-event-retention" description:"The duration stored htlc forwarding events are kept for before being pruned. Set to 0 to keep them forever."`
+
 	DefaultRemoteMaxHtlcs uint16 `long:"default-remote-max-htlcs" description:"The default max_htlc applied when opening or accepting channels. This value limits the number of concurrent HTLCs that the remote party can add to the commitment. The maximum possible value is 483."`
 
 	NumGraphSyncPeers      int           `long:"numgraphsyncpeers" description:"The number of peers that we should receive new graph updates from. This option can be tuned to save bandwidth for light clients or routing nodes."`
@@ -427,8 +444,12 @@ type Config struct {
 
 	GcCanceledInvoicesOnTheFly bool `long:"gc-canceled-invoices-on-the-fly" description:"If true, we'll delete newly canceled invoices on the fly."`
 
+	GcGraphInterval time.Duration `long:"gc-graph-interval" description:"The interval at which the channel graph is garbage collected, pruning zombie channels and the channels of nodes that exceed routing.maxchannelspernode."`
+
 	DustThreshold uint64 `long:"dust-threshold" description:"Sets the dust sum threshold in satoshis for a channel after which dust HTLC's will be failed."`
 
+	NodeDustThreshold uint64 `long:"node-dust-threshold" description:"Sets the threshold in satoshis for the dust sum across all channels after which dust HTLC's will be failed. Set to 0 to disable the threshold."`
+
 	Invoices *lncfg.Invoices `group:"invoices" namespace:"invoices"`
 
 	Routing *lncfg.Routing `group:"routing" namespace:"routing"`
@@ -459,8 +480,14 @@ type Config struct {
 
 	RemoteSigner *lncfg.RemoteSigner `group:"remotesigner" namespace:"remotesigner"`
 
+	Hwi *lncfg.Hwi `group:"hwi" namespace:"hwi"`
+
 	Sweeper *lncfg.Sweeper `group:"sweeper" namespace:"sweeper"`
 
+	ContractCourt *lncfg.ContractCourt `group:"contractcourt" namespace:"contractcourt"`
+
+	Fee *lncfg.Fee `group:"fee" namespace:"fee"`
+
 	Htlcswitch *lncfg.Htlcswitch `group:"htlcswitch" namespace:"htlcswitch"`
 
 	GRPC *GRPCConfig `group:"grpc" namespace:"grpc"`
@@ -478,6 +505,10 @@ type Config struct {
 	// network.
 	networkDir string
 
+	// colorSet is true if the node color was explicitly set in either the
+	// config file or by a flag.
+	colorSet bool
+
 	// ActiveNetParams contains parameters of the target chain.
 	ActiveNetParams chainreg.LitecoinNetParams
 
@@ -655,10 +686,18 @@ func DefaultConfig() Config {
 			MaxChannelUpdateBurst: discovery.DefaultMaxChannelUpdateBurst,
 			ChannelUpdateInterval: discovery.DefaultChannelUpdateInterval,
 			SubBatchDelay:         discovery.DefaultSubBatchDelay,
+			NodeAnnRebroadcastInterval: discovery.
+				DefaultNodeAnnRebroadcastInterval,
+			HistoricalSyncPeers: discovery.
+				DefaultNumHistoricalSyncers,
 		},
 		Invoices: &lncfg.Invoices{
 			HoldExpiryDelta: lncfg.DefaultHoldInvoiceExpiryDelta,
 		},
+		Routing: &lncfg.Routing{
+			ZombieExpiry:      routing.DefaultChannelPruneExpiry,
+			MaxQueuedPayments: routing.DefaultMaxQueuedPayments,
+		},
 		MaxOutgoingCltvExpiry:     htlcswitch.DefaultMaxOutgoingCltvExpiry,
 		MaxChannelFeeAllocation:   htlcswitch.DefaultMaxLinkFeeAllocation,
 		MaxCommitFeeRateAnchors:   lnwallet.DefaultAnchorsCommitMaxFeeRateSatPerVByte,
@@ -674,12 +713,37 @@ func DefaultConfig() Config {
 		ChannelCommitBatchSize:    defaultChannelCommitBatchSize,
 		CoinSelectionStrategy:     defaultCoinSelectionStrategy,
 		KeepFailedPaymentAttempts: defaultKeepFailedPaymentAttempts,
+		GcGraphInterval:           routing.DefaultGraphPruneInterval,
 		RemoteSigner: &lncfg.RemoteSigner{
 			Timeout: lncfg.DefaultRemoteSignerRPCTimeout,
 		},
+		Hwi: &lncfg.Hwi{
+			BinaryPath: lncfg.DefaultHwiBinaryPath,
+			Timeout:    lncfg.DefaultHwiTimeout,
+			MaxFee:     lncfg.DefaultHwiMaxFee,
+			MaxFeeRate: lncfg.DefaultHwiMaxFeeRate,
+		},
 		Sweeper: &lncfg.Sweeper{
 			BatchWindowDuration: sweep.DefaultBatchWindowDuration,
+			MaxInputsPerTx:      sweep.DefaultMaxInputsPerTx,
+			MaxSweepWeight:      sweep.DefaultMaxSweepWeight,
 		},
+		ContractCourt: &lncfg.ContractCourt{
+			Budget: &lncfg.Budget{
+				AnchorCPFP: contractcourt.DefaultAnchorRatio,
+				ToLocal:    contractcourt.DefaultBudgetRatio,
+				Htlc:       contractcourt.DefaultBudgetRatio,
+			},
+			Stagger: &lncfg.Stagger{
+				Interval:    contractcourt.DefaultStaggerInterval,
+				UrgentDelta: contractcourt.DefaultStaggerUrgentDelta,
+			},
+			ForceCloseDelay: &lncfg.ForceCloseDelay{
+				Delay:     contractcourt.DefaultForceCloseDelay,
+				MaxBlocks: contractcourt.DefaultForceCloseDelayMaxBlocks, //nolint:lll
+			},
+		},
+		Fee: &lncfg.Fee{},
 		Htlcswitch: &lncfg.Htlcswitch{
 			MailboxDeliveryTimeout: htlcswitch.DefaultMailboxDeliveryTimeout,
 		},
@@ -1014,6 +1078,11 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 			"if the watchtower client is active")
 	}
 
+	if cfg.HtlcFwdEventRetention < 0 {
+		return nil, mkErr("htlc-fwd-event-retention must not be "+
+			"negative: %v", cfg.HtlcFwdEventRetention)
+	}
+
 	// Ensure a valid max channel fee allocation was set.
 	if cfg.MaxChannelFeeAllocation <= 0 || cfg.MaxChannelFeeAllocation > 1 {
 		return nil, mkErr("invalid max channel fee allocation: %v, "+
@@ -1120,6 +1189,10 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 		return nil, mkErr("NAT traversal cannot be used when " +
 			"listening is disabled")
 	}
+	if cfg.DisableListen && len(cfg.ExternalIPProbes) != 0 {
+		return nil, mkErr("external IP probes cannot be used when " +
+			"listening is disabled")
+	}
 	if cfg.NAT && len(cfg.ExternalHosts) != 0 {
 		return nil, mkErr("NAT support and externalhosts are " +
 			"mutually exclusive, only one should be selected")
@@ -1500,6 +1573,18 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 			return nil, err
 		}
 
+		// The external DNS address is announced as is, so we only
+		// validate it.
+		if cfg.RawExternalDNS != "" {
+			cfg.ExternalDNS, err = lncfg.ParseDNSAddress(
+				cfg.RawExternalDNS, defaultPeerPort,
+			)
+			if err != nil {
+				return nil, mkErr("invalid external DNS "+
+					"address: %v", err)
+			}
+		}
+
 		// For the p2p port it makes no sense to listen to an Unix socket.
 		// Also, we would need to refactor the brontide listener to support
 		// that.
@@ -1600,6 +1685,33 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 		return nil, mkErr("error parsing gossip syncer: %v", err)
 	}
 
+	if cfg.GcGraphInterval <= 0 {
+		return nil, mkErr("gc-graph-interval (%v) must be positive",
+			cfg.GcGraphInterval)
+	}
+
+	if cfg.Routing.ZombieExpiry <= 0 {
+		return nil, mkErr("routing.zombieexpiry (%v) must be positive",
+			cfg.Routing.ZombieExpiry)
+	}
+
+	if cfg.Gossip.HistoricalSyncPeers < 1 {
+		return nil, mkErr("gossip.historical-sync-peers (%v) must be "+
+			"at least 1", cfg.Gossip.HistoricalSyncPeers)
+	}
+
+	// Our node announcement must be rebroadcast before other nodes prune
+	// us from their graph as a zombie.
+	if cfg.Gossip.NodeAnnRebroadcastInterval <= 0 ||
+		cfg.Gossip.NodeAnnRebroadcastInterval >=
+			routing.DefaultChannelPruneExpiry {
+
+		return nil, mkErr("gossip.node-ann-rebroadcast-interval (%v) "+
+			"must be positive and less than %v",
+			cfg.Gossip.NodeAnnRebroadcastInterval,
+			routing.DefaultChannelPruneExpiry)
+	}
+
 	// Log a warning if our expiry delta is not greater than our incoming
 	// broadcast delta. We do not fail here because this value may be set
 	// to zero to intentionally keep lnd's behavior unchanged from when we
@@ -1633,8 +1745,12 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 		cfg.HealthChecks,
 		cfg.RPCMiddleware,
 		cfg.RemoteSigner,
+		cfg.Hwi,
 		cfg.Sweeper,
+		cfg.ContractCourt,
+		cfg.Fee,
 		cfg.Htlcswitch,
+		cfg.SubRPCServers.RouterRPC,
 	)
 	if err != nil {
 		return nil, err
@@ -1648,6 +1764,14 @@ func ValidateConfig(cfg Config, interceptor signal.Interceptor, fileParser,
 		return nil, mkErr("unable to parse node color: %v", err)
 	}
 
+	// Remember whether the color was configured explicitly, so the server
+	// only overrides a previously announced color if the user asked for
+	// it, even if the value happens to match the default.
+	cfg.colorSet, err = isSet("Color")
+	if err != nil {
+		return nil, mkErr("error parsing color flag: %v", err)
+	}
+
 	// All good, return the sanitized result.
 	return &cfg, nil
 }
@@ -28,6 +28,9 @@ import (
 	"github.com/ltcsuite/lnd/lnrpc"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/lnwallet/btcwallet"
+	"github.com/ltcsuite/lnd/lnwallet/chainfee"
+	"github.com/ltcsuite/lnd/lnwallet/chanfunding"
+	"github.com/ltcsuite/lnd/lnwallet/hwi"
 	"github.com/ltcsuite/lnd/lnwallet/rpcwallet"
 	"github.com/ltcsuite/lnd/macaroons"
 	"github.com/ltcsuite/lnd/rpcperms"
@@ -38,6 +41,8 @@ import (
 	"github.com/ltcsuite/lnd/watchtower/wtdb"
 	"github.com/ltcsuite/ltcd/chaincfg"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/txscript"
 	"github.com/ltcsuite/ltcd/wire"
 	"github.com/ltcsuite/ltcwallet/waddrmgr"
 	"github.com/ltcsuite/ltcwallet/wallet"
@@ -548,6 +553,7 @@ func (d *DefaultWalletImpl) BuildWalletConfig(ctx context.Context,
 		NeutrinoCS:                  neutrinoCS,
 		ActiveNetParams:             d.cfg.ActiveNetParams,
 		FeeURL:                      d.cfg.FeeURL,
+		Fee:                         d.cfg.Fee,
 		Dialer: func(addr string) (net.Conn, error) {
 			return d.cfg.net.Dial(
 				"tcp", addr, d.cfg.ConnectionTimeout,
@@ -593,6 +599,16 @@ func (d *DefaultWalletImpl) BuildWalletConfig(ctx context.Context,
 	case "random":
 		walletConfig.CoinSelectionStrategy = wallet.CoinSelectionRandom
 
+	// The wallet itself doesn't support branch and bound coin selection,
+	// so the inputs of PSBTs funded through FundPsbt are selected by lnd.
+	// Other transactions the wallet funds directly, like sendcoins, select
+	// the largest coins first. Channel funding and the sweeper use the
+	// branch and bound strategy as well.
+	case "bnb":
+		walletConfig.CoinSelectionStrategy = wallet.CoinSelectionLargest
+		walletConfig.PsbtCoinSelectionStrategy =
+			chanfunding.CoinSelectionBnB
+
 	default:
 		return nil, nil, nil, fmt.Errorf("unknown coin selection "+
 			"strategy %v", d.cfg.CoinSelectionStrategy)
@@ -684,18 +700,34 @@ func (d *DefaultWalletImpl) BuildChainControl(
 		walletController.InternalWallet(), walletConfig.CoinType,
 	)
 
+	onChainWallet, err := newHwiWallet(
+		d.cfg.Hwi, walletController, walletConfig.NetParams,
+	)
+	if err != nil {
+		d.logger.Error(err)
+		return nil, nil, err
+	}
+
+	coinSelectionStrategy, err := chanfunding.ParseCoinSelectionStrategy(
+		d.cfg.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Create, and start the lnwallet, which handles the core payment
 	// channel logic, and exposes control via proxy state machines.
 	lnWalletConfig := lnwallet.Config{
-		Database:           partialChainControl.Cfg.ChanStateDB,
-		Notifier:           partialChainControl.ChainNotifier,
-		WalletController:   walletController,
-		Signer:             walletController,
-		FeeEstimator:       partialChainControl.FeeEstimator,
-		SecretKeyRing:      keyRing,
-		ChainIO:            walletController,
-		DefaultConstraints: partialChainControl.ChannelConstraints,
-		NetParams:          *walletConfig.NetParams,
+		Database:              partialChainControl.Cfg.ChanStateDB,
+		Notifier:              partialChainControl.ChainNotifier,
+		WalletController:      onChainWallet,
+		Signer:                walletController,
+		FeeEstimator:          partialChainControl.FeeEstimator,
+		SecretKeyRing:         keyRing,
+		ChainIO:               walletController,
+		DefaultConstraints:    partialChainControl.ChannelConstraints,
+		NetParams:             *walletConfig.NetParams,
+		CoinSelectionStrategy: coinSelectionStrategy,
 	}
 
 	// The broadcast is already always active for neutrino nodes, so we
@@ -799,18 +831,34 @@ func (d *RPCSignerWalletImpl) BuildChainControl(
 		return nil, nil, err
 	}
 
+	onChainWallet, err := newHwiWallet(
+		d.DefaultWalletImpl.cfg.Hwi, rpcKeyRing, walletConfig.NetParams,
+	)
+	if err != nil {
+		d.logger.Error(err)
+		return nil, nil, err
+	}
+
+	coinSelectionStrategy, err := chanfunding.ParseCoinSelectionStrategy(
+		d.cfg.CoinSelectionStrategy,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Create, and start the lnwallet, which handles the core payment
 	// channel logic, and exposes control via proxy state machines.
 	lnWalletConfig := lnwallet.Config{
-		Database:           partialChainControl.Cfg.ChanStateDB,
-		Notifier:           partialChainControl.ChainNotifier,
-		WalletController:   rpcKeyRing,
-		Signer:             rpcKeyRing,
-		FeeEstimator:       partialChainControl.FeeEstimator,
-		SecretKeyRing:      rpcKeyRing,
-		ChainIO:            walletController,
-		DefaultConstraints: partialChainControl.ChannelConstraints,
-		NetParams:          *walletConfig.NetParams,
+		Database:              partialChainControl.Cfg.ChanStateDB,
+		Notifier:              partialChainControl.ChainNotifier,
+		WalletController:      onChainWallet,
+		Signer:                rpcKeyRing,
+		FeeEstimator:          partialChainControl.FeeEstimator,
+		SecretKeyRing:         rpcKeyRing,
+		ChainIO:               walletController,
+		DefaultConstraints:    partialChainControl.ChannelConstraints,
+		NetParams:             *walletConfig.NetParams,
+		CoinSelectionStrategy: coinSelectionStrategy,
 	}
 
 	// We've created the wallet configuration now, so we can finish
@@ -827,6 +875,69 @@ func (d *RPCSignerWalletImpl) BuildChainControl(
 	return activeChainControl, cleanUp, nil
 }
 
+// hwiChain returns the name of the given network as understood by the
+// --chain flag of an HWI-compatible bridge.
+func hwiChain(netParams *chaincfg.Params) string {
+	switch netParams.Net {
+	case wire.MainNet:
+		return "main"
+
+	case wire.TestNet4:
+		return "test"
+
+	case chaincfg.SigNetParams.Net:
+		return "signet"
+
+	default:
+		return "regtest"
+	}
+}
+
+// newHwiWallet wraps the given wallet controller so that PSBTs spending from
+// the account of the configured hardware wallet are signed on the device. If
+// signing with a hardware wallet isn't enabled, the wallet controller is
+// returned unchanged.
+func newHwiWallet(cfg *lncfg.Hwi, base lnwallet.WalletController,
+	netParams *chaincfg.Params) (lnwallet.WalletController, error) {
+
+	if !cfg.Enable {
+		return base, nil
+	}
+
+	maxFeeRate := chainfee.SatPerKVByte(cfg.MaxFeeRate * 1000)
+	policy := &hwi.Policy{
+		MaxExternalAmount: ltcutil.Amount(cfg.MaxExternalAmount),
+		MaxFee:            ltcutil.Amount(cfg.MaxFee),
+		MaxFeeRate:        maxFeeRate.FeePerKWeight(),
+	}
+	for _, addrStr := range cfg.AllowedAddresses {
+		addr, err := ltcutil.DecodeAddress(addrStr, netParams)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hwi.allowedaddress "+
+				"%v: %w", addrStr, err)
+		}
+
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hwi.allowedaddress "+
+				"%v: %w", addrStr, err)
+		}
+
+		policy.AllowedScripts = append(policy.AllowedScripts, pkScript)
+	}
+
+	bridge := hwi.NewBridge(&hwi.Config{
+		BinaryPath:  cfg.BinaryPath,
+		Chain:       hwiChain(netParams),
+		Fingerprint: cfg.Fingerprint,
+		Timeout:     cfg.Timeout,
+	})
+
+	// The device doesn't need to be connected while lnd is running, it is
+	// only checked once a transaction is handed to it for signing.
+	return hwi.NewWallet(base, bridge, policy, netParams), nil
+}
+
 // DatabaseInstances is a struct that holds all instances to the actual
 // databases that are used in lnd.
 type DatabaseInstances struct {
@@ -870,6 +981,11 @@ type DatabaseInstances struct {
 	// WalletDB is the configuration for loading the wallet database using
 	// the btcwallet's loader.
 	WalletDB btcwallet.LoaderOption
+
+	// ReplicaDB is an optional read-only replica of the graph and channel
+	// state database that is used for expensive read-only queries. This is
+	// nil if no replica is configured.
+	ReplicaDB *channeldb.DB
 }
 
 // DefaultDatabaseBuilder is a type that builds the default database backends
@@ -957,6 +1073,9 @@ func (d *DefaultDatabaseBuilder) BuildDatabase(
 		channeldb.OptionSetBatchCommitInterval(
 			cfg.DB.BatchCommitInterval,
 		),
+		channeldb.OptionSetWriteFlushInterval(
+			cfg.DB.WriteFlushInterval,
+		),
 		channeldb.OptionDryRunMigration(cfg.DryRunMigration),
 		channeldb.OptionSetUseGraphCache(!cfg.DB.NoGraphCache),
 		channeldb.OptionKeepFailedPaymentAttempts(
@@ -965,6 +1084,10 @@ func (d *DefaultDatabaseBuilder) BuildDatabase(
 		channeldb.OptionStoreFinalHtlcResolutions(
 			cfg.StoreFinalHtlcResolutions,
 		),
+		channeldb.OptionStoreHtlcFwdEvents(cfg.StoreHtlcFwdEvents),
+		channeldb.OptionHtlcFwdEventRetention(
+			cfg.HtlcFwdEventRetention,
+		),
 		channeldb.OptionPruneRevocationLog(cfg.DB.PruneRevocation),
 		channeldb.OptionNoRevLogAmtData(cfg.DB.NoRevLogAmtData),
 	}
@@ -1013,12 +1136,45 @@ func (d *DefaultDatabaseBuilder) BuildDatabase(
 	// using the same struct (and DB backend) instance.
 	dbs.ChanStateDB = dbs.GraphDB
 
+	// Scan the channel state for corrupt entries if requested. We only
+	// report what we find, so the node can still be started.
+	if cfg.DB.Verify {
+		verifyChannelDB(d.logger, dbs.ChanStateDB, cfg.DB.VerifyQuarantine)
+	}
+
 	// For now the only InvoiceDB implementation is the *channeldb.DB.
 	//
 	// TODO(positiveblue): use a sql first implementation for this
 	// interface.
 	dbs.InvoiceDB = dbs.GraphDB
 
+	// The replica is opened without any migrations as those are applied
+	// through the primary database. We also don't need the in-memory graph
+	// cache for it, as it isn't used for path finding.
+	if databaseBackends.ReplicaDB != nil {
+		d.logger.Infof("Using read-only database replica for " +
+			"expensive queries")
+
+		dbs.ReplicaDB, err = channeldb.CreateWithBackend(
+			databaseBackends.ReplicaDB,
+			channeldb.OptionNoMigration(true),
+			channeldb.OptionSetUseGraphCache(false),
+			channeldb.OptionSetRejectCacheSize(
+				cfg.Caches.RejectCacheSize,
+			),
+			channeldb.OptionSetChannelCacheSize(
+				cfg.Caches.ChannelCacheSize,
+			),
+		)
+		if err != nil {
+			cleanUp()
+
+			err := fmt.Errorf("unable to open replica DB: %v", err)
+			d.logger.Error(err)
+			return nil, nil, err
+		}
+	}
+
 	// Wrap the watchtower client DB and make sure we clean up.
 	if cfg.WtClient.Active {
 		dbs.TowerClientDB, err = wtdb.OpenClientDB(
@@ -1055,6 +1211,35 @@ func (d *DefaultDatabaseBuilder) BuildDatabase(
 	return dbs, cleanUp, nil
 }
 
+// verifyChannelDB runs an integrity scan of the channel database and logs all
+// corrupt entries that were found.
+func verifyChannelDB(logger btclog.Logger, db *channeldb.DB,
+	quarantine bool) {
+
+	logger.Infof("Verifying integrity of channel database")
+
+	report, err := db.VerifyIntegrity(quarantine)
+	if err != nil {
+		logger.Errorf("Unable to verify channel database: %v", err)
+		return
+	}
+
+	logger.Infof("Scanned %d channels, %d revocation logs, %d invoices "+
+		"and %d forwarding events", report.NumChannels,
+		report.NumRevocationLogs, report.NumInvoices,
+		report.NumForwardingEvents)
+
+	for _, entry := range report.Corrupt {
+		logger.Errorf("Corrupt channel database entry: %v", entry)
+	}
+
+	if numCritical := report.NumCritical(); numCritical > 0 {
+		logger.Criticalf("Found %d corrupt critical entries in the "+
+			"channel database, restore from a backup or close the "+
+			"affected channels", numCritical)
+	}
+}
+
 // waitForWalletPassword blocks until a password is provided by the user to
 // this RPC server.
 func waitForWalletPassword(cfg *Config,
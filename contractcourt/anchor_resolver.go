@@ -127,6 +127,7 @@ func (c *anchorResolver) Resolve() (ContractResolver, error) {
 	var (
 		outcome channeldb.ResolverOutcome
 		spendTx *chainhash.Hash
+		fee     ltcutil.Amount
 	)
 
 	select {
@@ -138,6 +139,7 @@ func (c *anchorResolver) Resolve() (ContractResolver, error) {
 
 			spendTx = &sweepTxID
 			outcome = channeldb.ResolverOutcomeClaimed
+			fee = sweepRes.Fee
 
 		// Anchor was swept by someone else. This is possible after the
 		// 16 block csv lock.
@@ -177,6 +179,7 @@ func (c *anchorResolver) Resolve() (ContractResolver, error) {
 		spendTx, channeldb.ResolverTypeAnchor, outcome,
 	)
 	c.reportLock.Unlock()
+	report.Fee = fee
 
 	c.resolved = true
 	return nil, c.PutResolverReport(nil, report)
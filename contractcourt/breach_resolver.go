@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/tlv"
 )
 
 // breachResolver is a resolver that will handle breached closes. In the
@@ -94,7 +95,14 @@ func (b *breachResolver) SupplementState(_ *channeldb.OpenChannel) {
 
 // Encode encodes the breachResolver to the passed writer.
 func (b *breachResolver) Encode(w io.Writer) error {
-	return binary.Write(w, endian, b.resolved)
+	return encodeResolverState(w, b.records()...)
+}
+
+// records returns the TLV records of the fields of the resolver's state.
+func (b *breachResolver) records() []tlv.Record {
+	return []tlv.Record{
+		boolRecord(resolverResolvedType, &b.resolved),
+	}
 }
 
 // newBreachResolverFromReader attempts to decode an encoded breachResolver
@@ -107,6 +115,26 @@ func newBreachResolverFromReader(r io.Reader, resCfg ResolverConfig) (
 		replyChan:           make(chan struct{}),
 	}
 
+	if err := decodeResolverState(r, b.records()...); err != nil {
+		return nil, err
+	}
+
+	b.initLogger(b)
+
+	return b, nil
+}
+
+// newBreachResolverFromLegacyReader decodes a breachResolver from the passed
+// Reader instance that was encoded by an older version, before the fields of
+// the resolver's state were stored as TLV records.
+func newBreachResolverFromLegacyReader(r io.Reader, resCfg ResolverConfig) (
+	*breachResolver, error) {
+
+	b := &breachResolver{
+		contractResolverKit: *newContractResolverKit(resCfg),
+		replyChan:           make(chan struct{}),
+	}
+
 	if err := binary.Read(r, endian, &b.resolved); err != nil {
 		return nil, err
 	}
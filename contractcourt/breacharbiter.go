@@ -169,6 +169,13 @@ type BreachConfig struct {
 	// breached channels. This is used in conjunction with DB to recover
 	// from crashes, restarts, or other failures.
 	Store RetributionStorer
+
+	// JusticeSplitThreshold is the number of breached outputs at which we
+	// skip the justice transaction that spends all outputs, and instead
+	// right away publish separate transactions for the commitment outputs
+	// and the HTLC outputs. A value of zero means we always try the
+	// single justice transaction first.
+	JusticeSplitThreshold int
 }
 
 // BreachArbiter is a special subsystem which is responsible for watching and
@@ -546,7 +553,13 @@ func convertToSecondLevelRevoke(bo *breachedOutput, breachInfo *retributionInfo,
 	}
 
 	// We'll also redirect the outpoint to this second level output, so the
-	// spending transaction updates it inputs accordingly.
+	// spending transaction updates it inputs accordingly. The second level
+	// transactions are signed with SINGLE|ANYONECANPAY for anchor
+	// channels, so the remote party may have aggregated several of them
+	// into a single transaction along with extra inputs for fees. The
+	// signature commits each input to the output at the same index, so
+	// the second level output is found at the index of the spending
+	// input.
 	spendingTx := spendDetails.SpendingTx
 	spendInputIndex := spendDetails.SpenderInputIndex
 	oldOp := bo.outpoint
@@ -573,6 +586,10 @@ func convertToSecondLevelRevoke(bo *breachedOutput, breachInfo *retributionInfo,
 	// SignDescriptor.
 	bo.signDesc.WitnessScript = bo.secondLevelWitnessScript
 
+	// The second level output can't be spent before the transaction that
+	// created it, so its confirmation height is the new height hint.
+	bo.confHeight = uint32(spendDetails.SpendingHeight)
+
 	brarLog.Warnf("HTLC(%v) for ChannelPoint(%v) has been spent to the "+
 		"second-level, adjusting -> %v", oldOp, breachInfo.chanPoint,
 		bo.outpoint)
@@ -726,16 +743,38 @@ justiceTxBroadcast:
 	}
 	finalTx := justiceTxs.spendAll
 
-	brarLog.Debugf("Broadcasting justice tx: %v", newLogClosure(func() string {
-		return spew.Sdump(finalTx)
-	}))
+	// If the commitment has too many outputs to reliably sweep them all
+	// at once, we'll go straight to the split justice transactions.
+	numOutputs := len(breachInfo.breachedOutputs)
+	if b.cfg.JusticeSplitThreshold > 0 &&
+		numOutputs >= b.cfg.JusticeSplitThreshold {
 
-	// We'll now attempt to broadcast the transaction which finalized the
-	// channel's retribution against the cheating counter party.
-	label := labels.MakeLabel(labels.LabelTypeJusticeTransaction, nil)
-	err = b.cfg.PublishTransaction(finalTx, label)
-	if err != nil {
-		brarLog.Errorf("Unable to broadcast justice tx: %v", err)
+		brarLog.Infof("Splitting justice tx for ChannelPoint(%v) with "+
+			"%d outputs", breachInfo.chanPoint, numOutputs)
+
+		b.publishSplitJusticeTxs(justiceTxs)
+	} else {
+		brarLog.Debugf("Broadcasting justice tx: %v",
+			newLogClosure(func() string {
+				return spew.Sdump(finalTx)
+			}))
+
+		// We'll now attempt to broadcast the transaction which
+		// finalized the channel's retribution against the cheating
+		// counter party.
+		label := labels.MakeLabel(
+			labels.LabelTypeJusticeTransaction, nil,
+		)
+		err = b.cfg.PublishTransaction(finalTx, label)
+		if err != nil {
+			brarLog.Errorf("Unable to broadcast justice tx: %v",
+				err)
+
+			// A single input that can't be relayed shouldn't
+			// prevent us from claiming the other outputs, so we
+			// fall back to the split justice transactions.
+			b.publishSplitJusticeTxs(justiceTxs)
+		}
 	}
 
 	// Regardless of publication succeeded or not, we now wait for any of
@@ -837,80 +876,101 @@ Loop:
 				"height %v), splitting justice tx.",
 				epoch.Height, breachInfo.breachHeight)
 
-			// Otherwise we'll attempt to publish the two separate
-			// justice transactions that sweeps the commitment
+			// Otherwise we'll attempt to publish the separate
+			// justice transactions that sweep the commitment
 			// outputs and the HTLC outputs separately. This is to
 			// mitigate the case where our "spend all" justice TX
 			// doesn't propagate because the HTLC outputs have been
 			// pinned by low fee HTLC txs.
-			label := labels.MakeLabel(
-				labels.LabelTypeJusticeTransaction, nil,
-			)
-			if justiceTxs.spendCommitOuts != nil {
-				tx := justiceTxs.spendCommitOuts
+			b.publishSplitJusticeTxs(justiceTxs)
 
-				brarLog.Debugf("Broadcasting justice tx "+
-					"spending commitment outs: %v",
-					newLogClosure(func() string {
-						return spew.Sdump(tx)
-					}))
-
-				err = b.cfg.PublishTransaction(tx, label)
-				if err != nil {
-					brarLog.Warnf("Unable to broadcast "+
-						"commit out spending justice "+
-						"tx: %v", err)
-				}
+		case err := <-errChan:
+			if err != errBrarShuttingDown {
+				brarLog.Errorf("error waiting for "+
+					"spend event: %v", err)
 			}
+			break Loop
 
-			if justiceTxs.spendHTLCs != nil {
-				tx := justiceTxs.spendHTLCs
+		case <-b.quit:
+			break Loop
+		}
+	}
 
-				brarLog.Debugf("Broadcasting justice tx "+
-					"spending HTLC outs: %v",
-					newLogClosure(func() string {
-						return spew.Sdump(tx)
-					}))
+	// Wait for our go routine to exit.
+	wg.Wait()
+}
 
-				err = b.cfg.PublishTransaction(tx, label)
-				if err != nil {
-					brarLog.Warnf("Unable to broadcast "+
-						"HTLC out spending justice "+
-						"tx: %v", err)
-				}
-			}
+// publishSplitJusticeTxs publishes the justice transactions that sweep the
+// commitment outputs, the commitment level HTLC outputs and the second-level
+// HTLC outputs independently of each other. If the transaction sweeping all
+// commitment level HTLC outputs can't be published, each HTLC output is swept
+// in its own transaction instead.
+func (b *BreachArbiter) publishSplitJusticeTxs(justiceTxs *justiceTxVariants) {
+	label := labels.MakeLabel(labels.LabelTypeJusticeTransaction, nil)
+
+	if justiceTxs.spendCommitOuts != nil {
+		tx := justiceTxs.spendCommitOuts
+
+		brarLog.Debugf("Broadcasting justice tx spending commitment "+
+			"outs: %v", newLogClosure(func() string {
+			return spew.Sdump(tx)
+		}))
+
+		err := b.cfg.PublishTransaction(tx, label)
+		if err != nil {
+			brarLog.Warnf("Unable to broadcast commit out spending "+
+				"justice tx: %v", err)
+		}
+	}
 
-			for _, tx := range justiceTxs.spendSecondLevelHTLCs {
+	if justiceTxs.spendHTLCs != nil {
+		tx := justiceTxs.spendHTLCs
+
+		brarLog.Debugf("Broadcasting justice tx spending HTLC outs: %v",
+			newLogClosure(func() string {
+				return spew.Sdump(tx)
+			}))
+
+		err := b.cfg.PublishTransaction(tx, label)
+		if err != nil {
+			brarLog.Warnf("Unable to broadcast HTLC out spending "+
+				"justice tx: %v", err)
+
+			// One of the HTLC outputs might be the culprit, so
+			// we'll try to claim each of them on its own.
+			for _, tx := range justiceTxs.spendEachHTLC {
 				tx := tx
 
 				brarLog.Debugf("Broadcasting justice tx "+
-					"spending second-level HTLC output: %v",
+					"spending single HTLC out: %v",
 					newLogClosure(func() string {
 						return spew.Sdump(tx)
 					}))
 
-				err = b.cfg.PublishTransaction(tx, label)
+				err := b.cfg.PublishTransaction(tx, label)
 				if err != nil {
 					brarLog.Warnf("Unable to broadcast "+
-						"second-level HTLC out "+
-						"spending justice tx: %v", err)
+						"single HTLC out spending "+
+						"justice tx: %v", err)
 				}
 			}
+		}
+	}
 
-		case err := <-errChan:
-			if err != errBrarShuttingDown {
-				brarLog.Errorf("error waiting for "+
-					"spend event: %v", err)
-			}
-			break Loop
+	for _, tx := range justiceTxs.spendSecondLevelHTLCs {
+		tx := tx
 
-		case <-b.quit:
-			break Loop
+		brarLog.Debugf("Broadcasting justice tx spending second-level "+
+			"HTLC output: %v", newLogClosure(func() string {
+			return spew.Sdump(tx)
+		}))
+
+		err := b.cfg.PublishTransaction(tx, label)
+		if err != nil {
+			brarLog.Warnf("Unable to broadcast second-level HTLC "+
+				"out spending justice tx: %v", err)
 		}
 	}
-
-	// Wait for our go routine to exit.
-	wg.Wait()
 }
 
 // cleanupBreach marks the given channel point as fully resolved and removes the
@@ -1153,9 +1213,8 @@ func (bo *breachedOutput) BlocksToMaturity() uint32 {
 	// If the output is a to_remote output we can claim, and it's of the
 	// confirmed type (or is a taproot channel that always has the CSV 1),
 	// we must wait one block before claiming it.
-	switch bo.witnessType {
-	case input.CommitmentToRemoteConfirmed, input.TaprootRemoteCommitSpend:
-		return 1
+	if bo.witnessType.IsToRemoteConfirmed() {
+		return input.ToRemoteConfirmedDelay
 	}
 
 	// All other breached outputs have no CSV delay.
@@ -1348,18 +1407,22 @@ func newRetributionInfo(chanPoint *wire.OutPoint,
 // nil if none of these exist or if all have been taken to the second level).
 // 4. A set of txs that spend all the second-level HTLC outputs (can be empty if
 // no HTLC second-level txs have been confirmed).
+// 5. A set of txs that each spend a single commitment level HTLC output (only
+// created if there's more than one such output).
 //
-// The reason we create these three variants, is that in certain cases (like
+// The reason we create these variants, is that in certain cases (like
 // with the anchor output HTLC malleability), the channel counter party can pin
 // the HTLC outputs with low fee children, hindering our normal justice tx that
 // attempts to spend these outputs from propagating. In this case we want to
 // spend the to_local output and commitment level HTLC outputs separately,
-// before the CSV locks expire.
+// before the CSV locks expire. Spending each HTLC output on its own makes sure
+// a single HTLC output that can't be swept doesn't block the others.
 type justiceTxVariants struct {
 	spendAll              *wire.MsgTx
 	spendCommitOuts       *wire.MsgTx
 	spendHTLCs            *wire.MsgTx
 	spendSecondLevelHTLCs []*wire.MsgTx
+	spendEachHTLC         []*wire.MsgTx
 }
 
 // createJusticeTx creates transactions which exacts "justice" by sweeping ALL
@@ -1437,6 +1500,26 @@ func (b *BreachArbiter) createJusticeTx(
 	}
 	txs.spendSecondLevelHTLCs = secondLevelSweeps
 
+	// If there's only a single HTLC output, the spendHTLCs tx already
+	// sweeps it on its own.
+	if len(htlcInputs) < 2 {
+		return txs, nil
+	}
+
+	htlcSweeps := make([]*wire.MsgTx, 0, len(htlcInputs))
+	for _, input := range htlcInputs {
+		sweepTx, err := b.createSweepTx(input)
+		if err != nil {
+			brarLog.Errorf("could not create sweep tx for HTLC "+
+				"output: %v", err)
+
+			continue
+		}
+
+		htlcSweeps = append(htlcSweeps, sweepTx)
+	}
+	txs.spendEachHTLC = htlcSweeps
+
 	return txs, nil
 }
 
@@ -2017,3 +2100,35 @@ func (bo *breachedOutput) Decode(r io.Reader) error {
 
 	return nil
 }
+
+// writeOutpoint serializes the passed outpoint to the given writer.
+func writeOutpoint(w io.Writer, o *wire.OutPoint) error {
+	scratch := make([]byte, 4)
+
+	if err := wire.WriteVarBytes(w, 0, o.Hash[:]); err != nil {
+		return err
+	}
+
+	binary.BigEndian.PutUint32(scratch, o.Index)
+	_, err := w.Write(scratch)
+	return err
+}
+
+// readOutpoint deserializes an outpoint written by writeOutpoint from the
+// given reader.
+func readOutpoint(r io.Reader, o *wire.OutPoint) error {
+	scratch := make([]byte, 4)
+
+	txid, err := wire.ReadVarBytes(r, 0, 32, "prevout")
+	if err != nil {
+		return err
+	}
+	copy(o.Hash[:], txid)
+
+	if _, err := r.Read(scratch); err != nil {
+		return err
+	}
+	o.Index = binary.BigEndian.Uint32(scratch)
+
+	return nil
+}
@@ -1232,6 +1232,13 @@ func TestBreachCreateJusticeTx(t *testing.T) {
 	// level HTLC output types.
 	require.Len(t, justiceTxs.spendHTLCs.TxIn, 2)
 
+	// As there's more than one commitment level HTLC output, each of them
+	// should also be spent on its own.
+	require.Len(t, justiceTxs.spendEachHTLC, 2)
+	for _, tx := range justiceTxs.spendEachHTLC {
+		require.Len(t, tx.TxIn, 1)
+	}
+
 	// Finally, check that the spendSecondLevelHTLCs txs are spending the
 	// second level type.
 	require.Len(t, justiceTxs.spendSecondLevelHTLCs, 1)
@@ -1767,6 +1774,66 @@ func testBreachSpends(t *testing.T, test breachTest) {
 	assertBrarCleanup(t, brar, alice.ChanPoint, alice.State().Db)
 }
 
+// TestConvertToSecondLevelRevokeAggregated tests that a breached HTLC output
+// taken to the second level by a transaction that aggregates several second
+// level spends, as allowed for anchor channels, is converted to the output
+// paired with its spending input.
+func TestConvertToSecondLevelRevokeAggregated(t *testing.T) {
+	t.Parallel()
+
+	const spendHeight = 120
+
+	secondLevelScript := []byte{txscript.OP_TRUE}
+	bo := makeBreachedOutput(
+		&breachOutPoints[0], input.HtlcOfferedRevoke,
+		secondLevelScript, &input.SignDescriptor{
+			Output: &wire.TxOut{
+				Value:    10000,
+				PkScript: []byte{txscript.OP_0},
+			},
+		}, 100,
+	)
+
+	// The aggregated transaction spends a fee input first, followed by
+	// two HTLC outputs, each paired with its second level output. A
+	// change output is added last.
+	spendingTx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{
+			{PreviousOutPoint: breachOutPoints[2]},
+			{PreviousOutPoint: breachOutPoints[1]},
+			{PreviousOutPoint: breachOutPoints[0]},
+		},
+		TxOut: []*wire.TxOut{
+			{Value: 5000, PkScript: []byte{txscript.OP_1}},
+			{Value: 8000, PkScript: []byte{txscript.OP_2}},
+			{Value: 9000, PkScript: []byte{txscript.OP_3}},
+			{Value: 1000, PkScript: []byte{txscript.OP_4}},
+		},
+	}
+
+	breachInfo := &retributionInfo{
+		breachedOutputs: []breachedOutput{bo},
+	}
+	convertToSecondLevelRevoke(
+		&breachInfo.breachedOutputs[0], breachInfo,
+		&chainntnfs.SpendDetail{
+			SpentOutPoint:     &breachOutPoints[0],
+			SpendingTx:        spendingTx,
+			SpenderInputIndex: 2,
+			SpendingHeight:    spendHeight,
+		},
+	)
+
+	converted := breachInfo.breachedOutputs[0]
+	expectedOp := wire.OutPoint{Hash: spendingTx.TxHash(), Index: 2}
+	require.Equal(t, expectedOp, converted.outpoint)
+	require.Equal(t, input.HtlcSecondLevelRevoke, converted.witnessType)
+	require.EqualValues(t, 9000, converted.Amount())
+	require.Equal(t, spendingTx.TxOut[2], converted.signDesc.Output)
+	require.Equal(t, secondLevelScript, converted.signDesc.WitnessScript)
+	require.EqualValues(t, spendHeight, converted.HeightHint())
+}
+
 // TestBreachDelayedJusticeConfirmation tests that the breach arbiter will
 // "split" the justice tx in case the first justice tx doesn't confirm within
 // a reasonable time.
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/lnd/input"
@@ -118,6 +119,16 @@ type ArbitratorLog interface {
 	// introduced.
 	FetchChainActions() (ChainActionMap, error)
 
+	// CommitForceCloseDelayStart persists the time at which we first held
+	// off going to chain because the channel peer was offline. A zero
+	// time removes the persisted time.
+	CommitForceCloseDelayStart(start time.Time) error
+
+	// FetchForceCloseDelayStart returns the persisted time at which we
+	// first held off going to chain because the channel peer was offline,
+	// or a zero time if there is none.
+	FetchForceCloseDelayStart() (time.Time, error)
+
 	// WipeHistory is to be called ONLY once *all* contracts have been
 	// fully resolved, and the channel closure if finalized. This method
 	// will delete all on-disk state within the persistent log.
@@ -366,6 +377,11 @@ var (
 	// taprootDataKey is the key we'll use to store taproot specific data
 	// for the set of channels we'll need to sweep/claim.
 	taprootDataKey = []byte("taproot-data")
+
+	// forceCloseDelayStartKey is the key under the logScope that we'll use
+	// to store the time at which we first held off going to chain because
+	// the channel peer was offline.
+	forceCloseDelayStartKey = []byte("force-close-delay-start")
 )
 
 var (
@@ -464,12 +480,9 @@ func (b *boltArbitratorLog) writeResolver(contractBucket kvdb.RwBucket,
 		return nil
 	}
 
-	// First, we'll write to the buffer the type of this resolver. Using
-	// this byte, we can later properly deserialize the resolver properly.
-	var (
-		buf   bytes.Buffer
-		rType resolverType
-	)
+	// First, we'll determine the type of this resolver. Using this type,
+	// we can later properly deserialize the resolver properly.
+	var rType resolverType
 	switch res.(type) {
 	case *htlcTimeoutResolver:
 		rType = resolverTimeout
@@ -484,13 +497,22 @@ func (b *boltArbitratorLog) writeResolver(contractBucket kvdb.RwBucket,
 	case *breachResolver:
 		rType = resolverBreach
 	}
-	if _, err := buf.Write([]byte{byte(rType)}); err != nil {
+
+	// With the type of the resolver known, we can then write out the raw
+	// bytes of the resolver itself, and wrap them in a versioned record.
+	var state bytes.Buffer
+	if err := res.Encode(&state); err != nil {
 		return err
 	}
 
-	// With the type of the resolver written, we can then write out the raw
-	// bytes of the resolver itself.
-	if err := res.Encode(&buf); err != nil {
+	record := &resolverRecord{
+		version: resolverRecordVersion,
+		rType:   rType,
+		state:   state.Bytes(),
+	}
+
+	var buf bytes.Buffer
+	if err := record.encode(&buf); err != nil {
 		return err
 	}
 
@@ -575,52 +597,16 @@ func (b *boltArbitratorLog) FetchUnresolvedContracts() ([]ContractResolver, erro
 				return nil
 			}
 
-			var res ContractResolver
-
-			// We'll snip off the first byte of the raw resolver
-			// bytes in order to extract what type of resolver
-			// we're about to encode.
-			resType := resolverType(resBytes[0])
-
-			// Then we'll create a reader using the remaining
-			// bytes.
-			resReader := bytes.NewReader(resBytes[1:])
-
-			switch resType {
-			case resolverTimeout:
-				res, err = newTimeoutResolverFromReader(
-					resReader, resolverCfg,
-				)
-
-			case resolverSuccess:
-				res, err = newSuccessResolverFromReader(
-					resReader, resolverCfg,
-				)
-
-			case resolverOutgoingContest:
-				res, err = newOutgoingContestResolverFromReader(
-					resReader, resolverCfg,
-				)
-
-			case resolverIncomingContest:
-				res, err = newIncomingContestResolverFromReader(
-					resReader, resolverCfg,
-				)
-
-			case resolverUnilateralSweep:
-				res, err = newCommitSweepResolverFromReader(
-					resReader, resolverCfg,
-				)
-
-			case resolverBreach:
-				res, err = newBreachResolverFromReader(
-					resReader, resolverCfg,
-				)
-
-			default:
-				return fmt.Errorf("unknown resolver type: %v", resType)
+			// We'll decode the record the resolver is stored in
+			// to extract what type of resolver we're about to
+			// decode, and then decode the resolver from its
+			// state.
+			record, err := decodeResolverRecord(resBytes)
+			if err != nil {
+				return err
 			}
 
+			res, err := decodeResolver(record, resolverCfg)
 			if err != nil {
 				return err
 			}
@@ -646,7 +632,7 @@ func (b *boltArbitratorLog) FetchUnresolvedContracts() ([]ContractResolver, erro
 func (b *boltArbitratorLog) InsertUnresolvedContracts(reports []*channeldb.ResolverReport,
 	resolvers ...ContractResolver) error {
 
-	return kvdb.Batch(b.db, func(tx kvdb.RwTx) error {
+	err := kvdb.Batch(b.db, func(tx kvdb.RwTx) error {
 		contractBucket, err := fetchContractWriteBucket(tx, b.scopeKey[:])
 		if err != nil {
 			return err
@@ -669,6 +655,13 @@ func (b *boltArbitratorLog) InsertUnresolvedContracts(reports []*channeldb.Resol
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	b.notifyResolverReports(reports)
+
+	return nil
 }
 
 // SwapContract performs an atomic swap of the old contract for the new
@@ -1109,6 +1102,57 @@ func (b *boltArbitratorLog) fetchConfirmedCommitSet(tx kvdb.RTx) (*CommitSet,
 	return decodeCommitSet(bytes.NewReader(commitSetBytes))
 }
 
+// CommitForceCloseDelayStart persists the time at which we first held off going
+// to chain because the channel peer was offline. A zero time removes the
+// persisted time.
+//
+// NOTE: Part of the ContractResolver interface.
+func (b *boltArbitratorLog) CommitForceCloseDelayStart(start time.Time) error {
+	return kvdb.Update(b.db, func(tx kvdb.RwTx) error {
+		scopeBucket, err := tx.CreateTopLevelBucket(b.scopeKey[:])
+		if err != nil {
+			return err
+		}
+
+		if start.IsZero() {
+			return scopeBucket.Delete(forceCloseDelayStartKey)
+		}
+
+		var startBytes [8]byte
+		endian.PutUint64(startBytes[:], uint64(start.UnixNano()))
+
+		return scopeBucket.Put(forceCloseDelayStartKey, startBytes[:])
+	}, func() {})
+}
+
+// FetchForceCloseDelayStart returns the persisted time at which we first held
+// off going to chain because the channel peer was offline, or a zero time if
+// there is none.
+//
+// NOTE: Part of the ContractResolver interface.
+func (b *boltArbitratorLog) FetchForceCloseDelayStart() (time.Time, error) {
+	var start time.Time
+	err := kvdb.View(b.db, func(tx kvdb.RTx) error {
+		scopeBucket := tx.ReadBucket(b.scopeKey[:])
+		if scopeBucket == nil {
+			return nil
+		}
+
+		startBytes := scopeBucket.Get(forceCloseDelayStartKey)
+		if len(startBytes) != 8 {
+			return nil
+		}
+
+		start = time.Unix(0, int64(endian.Uint64(startBytes)))
+
+		return nil
+	}, func() {
+		start = time.Time{}
+	})
+
+	return start, err
+}
+
 // WipeHistory is to be called ONLY once *all* contracts have been fully
 // resolved, and the channel closure if finalized. This method will delete all
 // on-disk state within the persistent log.
@@ -1145,6 +1189,11 @@ func (b *boltArbitratorLog) WipeHistory() error {
 			return err
 		}
 
+		err = scopeBucket.Delete(forceCloseDelayStartKey)
+		if err != nil {
+			return err
+		}
+
 		// We'll delete any chain actions that are still stored by
 		// removing the enclosing bucket.
 		err = scopeBucket.DeleteNestedBucket(actionsBucketKey)
@@ -1164,7 +1213,7 @@ func (b *boltArbitratorLog) WipeHistory() error {
 func (b *boltArbitratorLog) checkpointContract(c ContractResolver,
 	reports ...*channeldb.ResolverReport) error {
 
-	return kvdb.Update(b.db, func(tx kvdb.RwTx) error {
+	err := kvdb.Update(b.db, func(tx kvdb.RwTx) error {
 		contractBucket, err := fetchContractWriteBucket(tx, b.scopeKey[:])
 		if err != nil {
 			return err
@@ -1182,6 +1231,27 @@ func (b *boltArbitratorLog) checkpointContract(c ContractResolver,
 
 		return nil
 	}, func() {})
+	if err != nil {
+		return err
+	}
+
+	b.notifyResolverReports(reports)
+
+	return nil
+}
+
+// notifyResolverReports notifies subscribers of the given resolver reports,
+// after the transaction they were recorded in has been committed.
+func (b *boltArbitratorLog) notifyResolverReports(
+	reports []*channeldb.ResolverReport) {
+
+	if b.cfg.NotifyResolverReport == nil {
+		return
+	}
+
+	for _, report := range reports {
+		b.cfg.NotifyResolverReport(report)
+	}
 }
 
 // encodeSignDetails encodes the given SignDetails struct to the writer.
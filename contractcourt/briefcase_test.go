@@ -2,6 +2,7 @@ package contractcourt
 
 import (
 	"crypto/rand"
+	"errors"
 	prand "math/rand"
 	"reflect"
 	"testing"
@@ -216,6 +217,10 @@ func assertResolversEqual(t *testing.T, originalResolver ContractResolver,
 			t.Fatalf("expected %v, got %v", ogRes.htlc.HtlcIndex,
 				diskRes.htlc.HtlcIndex)
 		}
+		if ogRes.commitSpendFee != diskRes.commitSpendFee {
+			t.Fatalf("expected %v, got %v", ogRes.commitSpendFee,
+				diskRes.commitSpendFee)
+		}
 	}
 
 	assertSuccessResEqual := func(ogRes, diskRes *htlcSuccessResolver) {
@@ -239,6 +244,10 @@ func assertResolversEqual(t *testing.T, originalResolver ContractResolver,
 			t.Fatalf("expected %v, got %v", ogRes.htlc.RHash,
 				diskRes.htlc.RHash)
 		}
+		if ogRes.commitSpendFee != diskRes.commitSpendFee {
+			t.Fatalf("expected %v, got %v", ogRes.commitSpendFee,
+				diskRes.commitSpendFee)
+		}
 	}
 
 	switch ogRes := originalResolver.(type) {
@@ -285,6 +294,13 @@ func assertResolversEqual(t *testing.T, originalResolver ContractResolver,
 			t.Fatalf("expected %v, got %v", ogRes.chanPoint,
 				diskRes.chanPoint)
 		}
+
+	case *breachResolver:
+		diskRes := diskResolver.(*breachResolver)
+		if ogRes.resolved != diskRes.resolved {
+			t.Fatalf("expected %v, got %v", ogRes.resolved,
+				diskRes.resolved)
+		}
 	}
 }
 
@@ -312,6 +328,7 @@ func TestContractInsertionRetrieval(t *testing.T) {
 		},
 		outputIncubating: true,
 		resolved:         true,
+		commitSpendFee:   1000,
 		broadcastHeight:  102,
 		htlc: channeldb.HTLC{
 			HtlcIndex: 12,
@@ -327,6 +344,7 @@ func TestContractInsertionRetrieval(t *testing.T) {
 		},
 		outputIncubating: true,
 		resolved:         true,
+		commitSpendFee:   2000,
 		broadcastHeight:  109,
 		htlc: channeldb.HTLC{
 			RHash: testPreimage,
@@ -415,6 +433,145 @@ func TestContractInsertionRetrieval(t *testing.T) {
 	}
 }
 
+// TestContractResumeAfterRestart tests that every type of resolver that was
+// checkpointed in the middle of its resolution is restored with the same state
+// once the log is opened again after a restart.
+func TestContractResumeAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	timeoutResolver := &htlcTimeoutResolver{
+		htlcResolution: lnwallet.OutgoingHtlcResolution{
+			Expiry:          99,
+			SignedTimeoutTx: testTx,
+			SignDetails:     testSignDetails,
+			CsvDelay:        99,
+			ClaimOutpoint:   randOutPoint(),
+			SweepSignDesc:   testSignDesc,
+		},
+		outputIncubating: true,
+		broadcastHeight:  102,
+		htlc: channeldb.HTLC{
+			HtlcIndex: 12,
+		},
+	}
+	successResolver := &htlcSuccessResolver{
+		htlcResolution: lnwallet.IncomingHtlcResolution{
+			Preimage:        testPreimage,
+			SignedSuccessTx: testTx,
+			SignDetails:     testSignDetails,
+			CsvDelay:        900,
+			ClaimOutpoint:   randOutPoint(),
+			SweepSignDesc:   testSignDesc,
+		},
+		outputIncubating: true,
+		broadcastHeight:  109,
+		htlc: channeldb.HTLC{
+			RHash: testPreimage,
+		},
+	}
+
+	sweepResolver := &commitSweepResolver{
+		commitResolution: lnwallet.CommitOutputResolution{
+			SelfOutPoint:       testChanPoint2,
+			SelfOutputSignDesc: testSignDesc,
+			MaturityDelay:      99,
+		},
+		broadcastHeight: 109,
+		chanPoint:       testChanPoint1,
+	}
+
+	// The contest resolvers haven't been able to resolve their HTLC yet,
+	// so their internal resolvers haven't started either.
+	contestTimeout := *timeoutResolver
+	contestTimeout.htlcResolution.ClaimOutpoint = randOutPoint()
+	contestTimeout.outputIncubating = false
+	contestSuccess := *successResolver
+	contestSuccess.htlcResolution.ClaimOutpoint = randOutPoint()
+	contestSuccess.outputIncubating = false
+
+	testCases := []struct {
+		name     string
+		resolver ContractResolver
+	}{
+		{
+			name:     "timeout",
+			resolver: timeoutResolver,
+		},
+		{
+			name:     "success",
+			resolver: successResolver,
+		},
+		{
+			name: "outgoing contest",
+			resolver: &htlcOutgoingContestResolver{
+				htlcTimeoutResolver: &contestTimeout,
+			},
+		},
+		{
+			name: "incoming contest",
+			resolver: &htlcIncomingContestResolver{
+				htlcExpiry:          100,
+				htlcSuccessResolver: &contestSuccess,
+			},
+		},
+		{
+			name:     "commit sweep",
+			resolver: sweepResolver,
+		},
+		{
+			name:     "breach",
+			resolver: &breachResolver{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			testDB, err := makeTestDB(t)
+			require.NoError(t, err)
+
+			arbCfg := ChannelArbitratorConfig{
+				PutResolverReport: func(_ kvdb.RwTx,
+					_ *channeldb.ResolverReport) error {
+
+					return nil
+				},
+			}
+			openLog := func() ArbitratorLog {
+				log, err := newBoltArbitratorLog(
+					testDB, arbCfg, testChainHash,
+					testChanPoint1,
+				)
+				require.NoError(t, err)
+
+				return log
+			}
+
+			testLog := openLog()
+			err = testLog.InsertUnresolvedContracts(
+				nil, testCase.resolver,
+			)
+			require.NoError(t, err)
+
+			// Open the log once more, as it happens when the
+			// channel arbitrator is started after a restart, and
+			// make sure the resolver resumes where it left off.
+			testLog = openLog()
+			diskResolvers, err := testLog.FetchUnresolvedContracts()
+			require.NoError(t, err)
+			require.Len(t, diskResolvers, 1)
+
+			require.IsType(t, testCase.resolver, diskResolvers[0])
+			assertResolversEqual(
+				t, testCase.resolver, diskResolvers[0],
+			)
+		})
+	}
+}
+
 // TestContractResolution tests that once we mark a contract as resolved, it's
 // properly removed from the database.
 func TestContractResolution(t *testing.T) {
@@ -468,6 +625,67 @@ func TestContractResolution(t *testing.T) {
 	}
 }
 
+// TestResolverReportNotification tests that resolver reports recorded along
+// with a contract are only notified once their transaction has been committed.
+func TestResolverReportNotification(t *testing.T) {
+	t.Parallel()
+
+	testDB, err := makeTestDB(t)
+	require.NoError(t, err)
+
+	var (
+		putErr   error
+		notified []*channeldb.ResolverReport
+	)
+	testArbCfg := ChannelArbitratorConfig{
+		PutResolverReport: func(_ kvdb.RwTx,
+			_ *channeldb.ResolverReport) error {
+
+			return putErr
+		},
+		NotifyResolverReport: func(report *channeldb.ResolverReport) {
+			notified = append(notified, report)
+		},
+	}
+	testLog, err := newBoltArbitratorLog(
+		testDB, testArbCfg, testChainHash, testChanPoint1,
+	)
+	require.NoError(t, err)
+
+	resolver := &htlcTimeoutResolver{
+		htlcResolution: lnwallet.OutgoingHtlcResolution{
+			ClaimOutpoint: randOutPoint(),
+			SweepSignDesc: testSignDesc,
+		},
+	}
+	report := &channeldb.ResolverReport{
+		OutPoint:     randOutPoint(),
+		ResolverType: channeldb.ResolverTypeOutgoingHtlc,
+	}
+
+	// If storing the report fails, the transaction is rolled back and
+	// the report must not be notified.
+	putErr = errors.New("put failed")
+	err = testLog.InsertUnresolvedContracts(
+		[]*channeldb.ResolverReport{report}, resolver,
+	)
+	require.ErrorIs(t, err, putErr)
+	require.Empty(t, notified)
+
+	// Once the transaction is committed, the report is notified.
+	putErr = nil
+	err = testLog.InsertUnresolvedContracts(
+		[]*channeldb.ResolverReport{report}, resolver,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []*channeldb.ResolverReport{report}, notified)
+
+	// The same applies to reports recorded when checkpointing a contract.
+	err = testLog.checkpointContract(resolver, report)
+	require.NoError(t, err)
+	require.Len(t, notified, 2)
+}
+
 // TestContractSwapping ensures that callers are able to atomically swap to
 // distinct contracts for one another.
 func TestContractSwapping(t *testing.T) {
@@ -0,0 +1,284 @@
+package contractcourt
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/ltcsuite/lnd/clock"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+const (
+	// DefaultStaggerInterval is the default minimum time between the
+	// broadcasts of two force close transactions. It's short enough to
+	// not delay a single force close by much, while still spreading out a
+	// mass force close.
+	DefaultStaggerInterval = 10 * time.Second
+
+	// DefaultStaggerUrgentDelta is the default number of blocks before its
+	// deadline at which a force close transaction is broadcast right away.
+	DefaultStaggerUrgentDelta = 10
+)
+
+// StaggerConfig defines how the broadcasts of force close transactions are
+// spread out over time, e.g. when many channels are force closed at once.
+type StaggerConfig struct {
+	// Interval is the minimum time between two broadcasts. A value of
+	// zero disables staggering, so all transactions are broadcast right
+	// away.
+	Interval time.Duration
+
+	// UrgentDelta is the number of blocks before its deadline at which a
+	// transaction is broadcast without waiting for its turn.
+	UrgentDelta uint32
+}
+
+// broadcastRequest is a transaction broadcast that waits for its turn within
+// the broadcastScheduler.
+type broadcastRequest struct {
+	// chanPoint is the channel the transaction closes. There's at most
+	// one pending request per channel.
+	chanPoint wire.OutPoint
+
+	// deadline is the block height by which the transaction should be
+	// confirmed.
+	deadline int32
+
+	// budget is the fee budget available to bump the transaction, used to
+	// order requests with the same deadline.
+	budget ltcutil.Amount
+
+	// publish broadcasts the transaction.
+	publish func()
+
+	// index is the index of the request within the broadcastQueue.
+	index int
+}
+
+// broadcastQueue is a priority queue of broadcast requests. Requests with an
+// earlier deadline come first, followed by those with a higher budget.
+type broadcastQueue []*broadcastRequest
+
+// Len returns the number of requests in the queue.
+//
+// NOTE: Part of the heap.Interface interface.
+func (q broadcastQueue) Len() int { return len(q) }
+
+// Less returns whether the request at index i should be broadcast before the
+// request at index j.
+//
+// NOTE: Part of the heap.Interface interface.
+func (q broadcastQueue) Less(i, j int) bool {
+	if q[i].deadline != q[j].deadline {
+		return q[i].deadline < q[j].deadline
+	}
+
+	return q[i].budget > q[j].budget
+}
+
+// Swap swaps the requests at index i and j.
+//
+// NOTE: Part of the heap.Interface interface.
+func (q broadcastQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+// Push adds a request to the end of the queue.
+//
+// NOTE: Part of the heap.Interface interface.
+func (q *broadcastQueue) Push(x interface{}) {
+	req := x.(*broadcastRequest)
+	req.index = len(*q)
+	*q = append(*q, req)
+}
+
+// Pop removes the last request of the queue.
+//
+// NOTE: Part of the heap.Interface interface.
+func (q *broadcastQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	req := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+
+	return req
+}
+
+// broadcastScheduler staggers the broadcasts of force close transactions so
+// the mempool isn't flooded with them at peak fee rates when many channels go
+// to chain at once. At most one transaction is broadcast per interval, in the
+// order of their deadlines and budgets. Transactions that are close to their
+// deadline are broadcast right away.
+type broadcastScheduler struct {
+	cfg   StaggerConfig
+	clock clock.Clock
+
+	mu            sync.Mutex
+	queue         broadcastQueue
+	pending       map[wire.OutPoint]*broadcastRequest
+	lastBroadcast time.Time
+
+	// newRequests is signalled whenever a request is queued.
+	newRequests chan struct{}
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// newBroadcastScheduler returns a new broadcastScheduler.
+func newBroadcastScheduler(cfg StaggerConfig,
+	clock clock.Clock) *broadcastScheduler {
+
+	return &broadcastScheduler{
+		cfg:         cfg,
+		clock:       clock,
+		pending:     make(map[wire.OutPoint]*broadcastRequest),
+		newRequests: make(chan struct{}, 1),
+		quit:        make(chan struct{}),
+	}
+}
+
+// Start launches the goroutine that broadcasts the queued transactions.
+func (s *broadcastScheduler) Start() {
+	s.wg.Add(1)
+	go s.broadcastLoop()
+}
+
+// Stop stops the scheduler. Transactions that are still queued are not
+// broadcast.
+func (s *broadcastScheduler) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+// schedule broadcasts the transaction of the given request once it's its
+// turn. It returns false if the transaction was broadcast right away, either
+// because staggering is disabled, the deadline is close or no other
+// transaction was broadcast recently. A pending request for the same channel
+// is replaced.
+func (s *broadcastScheduler) schedule(req *broadcastRequest,
+	currentHeight int32) bool {
+
+	if s.cfg.Interval == 0 {
+		req.publish()
+		return false
+	}
+
+	s.mu.Lock()
+
+	now := s.clock.Now()
+	urgent := req.deadline-currentHeight <= int32(s.cfg.UrgentDelta)
+	due := now.Sub(s.lastBroadcast) >= s.cfg.Interval
+
+	// If the request can be served right away, we'll remove any pending
+	// request for the channel, as it would only broadcast an outdated
+	// transaction.
+	if urgent || (due && len(s.queue) == 0) {
+		s.removeLocked(req.chanPoint)
+		s.lastBroadcast = now
+		s.mu.Unlock()
+
+		req.publish()
+
+		return false
+	}
+
+	s.removeLocked(req.chanPoint)
+	heap.Push(&s.queue, req)
+	s.pending[req.chanPoint] = req
+
+	log.Infof("Staggering broadcast of close tx for ChannelPoint(%v), "+
+		"%v broadcasts queued", req.chanPoint, len(s.queue))
+
+	s.mu.Unlock()
+
+	select {
+	case s.newRequests <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+// isPending returns whether the broadcast of the transaction of the given
+// channel is still queued.
+func (s *broadcastScheduler) isPending(chanPoint wire.OutPoint) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.pending[chanPoint]
+
+	return ok
+}
+
+// removeLocked removes the pending request of the given channel, if any.
+//
+// NOTE: The mutex must be held when calling this method.
+func (s *broadcastScheduler) removeLocked(chanPoint wire.OutPoint) {
+	req, ok := s.pending[chanPoint]
+	if !ok {
+		return
+	}
+
+	heap.Remove(&s.queue, req.index)
+	delete(s.pending, chanPoint)
+}
+
+// nextBroadcast pops the next request from the queue if it's due. Otherwise,
+// it returns the time to wait until the next request is due, which is zero if
+// the queue is empty.
+func (s *broadcastScheduler) nextBroadcast() (*broadcastRequest,
+	time.Duration) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.queue) == 0 {
+		return nil, 0
+	}
+
+	now := s.clock.Now()
+	wait := s.lastBroadcast.Add(s.cfg.Interval).Sub(now)
+	if wait > 0 {
+		return nil, wait
+	}
+
+	req := heap.Pop(&s.queue).(*broadcastRequest)
+	delete(s.pending, req.chanPoint)
+	s.lastBroadcast = now
+
+	return req, 0
+}
+
+// broadcastLoop broadcasts the queued transactions one interval apart.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *broadcastScheduler) broadcastLoop() {
+	defer s.wg.Done()
+
+	for {
+		req, wait := s.nextBroadcast()
+		if req != nil {
+			req.publish()
+			continue
+		}
+
+		// If the queue is empty, we'll wait for a new request.
+		var tick <-chan time.Time
+		if wait > 0 {
+			tick = s.clock.TickAfter(wait)
+		}
+
+		select {
+		case <-tick:
+		case <-s.newRequests:
+		case <-s.quit:
+			return
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package contractcourt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/clock"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBroadcastScheduler asserts that the broadcast scheduler spreads out
+// broadcasts by the configured interval, in the order of their deadlines and
+// budgets, while urgent broadcasts are published right away.
+func TestBroadcastScheduler(t *testing.T) {
+	t.Parallel()
+
+	const (
+		interval      = 10 * time.Second
+		currentHeight = 100
+	)
+
+	startTime := time.Unix(1000, 0)
+	testClock := clock.NewTestClock(startTime)
+	scheduler := newBroadcastScheduler(StaggerConfig{
+		Interval:    interval,
+		UrgentDelta: 5,
+	}, testClock)
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	published := make(chan string, 10)
+	schedule := func(name string, index uint32, deadline int32,
+		budget ltcutil.Amount) bool {
+
+		return scheduler.schedule(&broadcastRequest{
+			chanPoint: wire.OutPoint{Index: index},
+			deadline:  deadline,
+			budget:    budget,
+			publish: func() {
+				published <- name
+			},
+		}, currentHeight)
+	}
+
+	assertPublished := func(name string) {
+		t.Helper()
+
+		select {
+		case p := <-published:
+			require.Equal(t, name, p)
+		case <-time.After(defaultTimeout):
+			t.Fatalf("%v not published", name)
+		}
+	}
+
+	assertNotPublished := func() {
+		t.Helper()
+
+		select {
+		case p := <-published:
+			t.Fatalf("unexpected publish of %v", p)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// The first broadcast is published right away, as no other one was
+	// published recently.
+	require.False(t, schedule("a", 0, 1000, 0))
+	assertPublished("a")
+
+	// The following ones have to wait for their turn.
+	require.True(t, schedule("b", 1, 500, 1))
+	require.True(t, schedule("c", 2, 500, 2))
+	require.True(t, schedule("d", 3, 300, 0))
+	assertNotPublished()
+
+	// A broadcast that is close to its deadline skips the queue.
+	require.False(t, schedule("e", 4, currentHeight+5, 0))
+	assertPublished("e")
+
+	// Scheduling the broadcast of a channel again replaces the pending
+	// one.
+	require.True(t, scheduler.isPending(wire.OutPoint{Index: 1}))
+	require.True(t, schedule("b2", 1, 500, 1))
+
+	// The queued broadcasts are now published one interval apart, the one
+	// with the earliest deadline first, followed by the one with the
+	// higher budget.
+	testClock.SetTime(startTime.Add(interval))
+	assertPublished("d")
+	assertNotPublished()
+
+	testClock.SetTime(startTime.Add(2 * interval))
+	assertPublished("c")
+	assertNotPublished()
+
+	testClock.SetTime(startTime.Add(3 * interval))
+	assertPublished("b2")
+	assertNotPublished()
+
+	require.False(t, scheduler.isPending(wire.OutPoint{Index: 1}))
+}
+
+// TestBroadcastSchedulerDisabled asserts that all broadcasts are published
+// right away if no interval is configured.
+func TestBroadcastSchedulerDisabled(t *testing.T) {
+	t.Parallel()
+
+	scheduler := newBroadcastScheduler(StaggerConfig{}, nil)
+
+	var published int
+	for i := 0; i < 3; i++ {
+		deferred := scheduler.schedule(&broadcastRequest{
+			chanPoint: wire.OutPoint{Index: uint32(i)},
+			deadline:  1000,
+			publish: func() {
+				published++
+			},
+		}, 100)
+		require.False(t, deferred)
+	}
+	require.Equal(t, 3, published)
+}
@@ -0,0 +1,59 @@
+package contractcourt
+
+import "github.com/ltcsuite/ltcd/ltcutil"
+
+const (
+	// DefaultBudgetRatio is the default ratio of the value being swept
+	// that may be spent on fees when sweeping our commitment output or
+	// claiming HTLCs of a force closed channel.
+	DefaultBudgetRatio = 0.5
+
+	// DefaultAnchorRatio is the default ratio of the value of the non-dust
+	// HTLCs on a commitment that may be spent on bumping it via its
+	// anchor. It is higher than DefaultBudgetRatio, as the CPFP pays the
+	// fee of the whole commitment package, and all HTLCs are at stake if
+	// the commitment doesn't confirm before their deadline.
+	DefaultAnchorRatio = 1.0
+)
+
+// BudgetConfig defines the maximum fees that may be spent when sweeping the
+// outputs of a force closed channel. Each budget is expressed as a ratio of
+// the value at stake. A ratio of zero means the fee isn't limited.
+type BudgetConfig struct {
+	// AnchorCPFP is the budget for bumping a commitment transaction via
+	// its anchor, as a ratio of the value of the non-dust HTLCs on the
+	// commitment.
+	AnchorCPFP float64
+
+	// ToLocal is the budget for sweeping our output of a commitment
+	// transaction, as a ratio of the output value.
+	ToLocal float64
+
+	// HTLC is the budget for claiming an HTLC on chain, as a ratio of the
+	// HTLC value.
+	HTLC float64
+
+	// AbandonUneconomicalHTLCs indicates that HTLC outputs whose budget
+	// doesn't cover the fee of sweeping them at the minimum relay fee rate
+	// are abandoned, so the channel can be fully resolved.
+	AbandonUneconomicalHTLCs bool
+}
+
+// calculateBudget returns the fee budget for sweeping the given value using
+// the given ratio. A zero budget is returned if the ratio is zero, which tells
+// the sweeper not to limit the fee.
+func calculateBudget(value ltcutil.Amount, ratio float64) ltcutil.Amount {
+	if ratio <= 0 {
+		return 0
+	}
+
+	budget := ltcutil.Amount(float64(value) * ratio)
+
+	// A zero budget would be interpreted as no budget at all, so we'll
+	// round it up to the smallest possible one instead.
+	if budget == 0 {
+		budget = 1
+	}
+
+	return budget
+}
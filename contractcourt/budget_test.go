@@ -0,0 +1,80 @@
+package contractcourt
+
+import (
+	"testing"
+
+	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCalculateBudget asserts that budgets are derived from the value and
+// ratio, and that a non-zero ratio never results in an unlimited budget.
+func TestCalculateBudget(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		value    ltcutil.Amount
+		ratio    float64
+		expected ltcutil.Amount
+	}{{
+		name:     "no ratio",
+		value:    100_000,
+		expected: 0,
+	}, {
+		name:     "half",
+		value:    100_000,
+		ratio:    0.5,
+		expected: 50_000,
+	}, {
+		name:     "full",
+		value:    100_000,
+		ratio:    1,
+		expected: 100_000,
+	}, {
+		name:     "rounded up to one",
+		value:    1,
+		ratio:    0.5,
+		expected: 1,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			budget := calculateBudget(tc.value, tc.ratio)
+			require.Equal(t, tc.expected, budget)
+		})
+	}
+}
+
+// TestHtlcSetNonDustValue asserts that only HTLCs with an output on the
+// commitment count towards the value at stake of an HTLC set.
+func TestHtlcSetNonDustValue(t *testing.T) {
+	t.Parallel()
+
+	htlcs := newHtlcSet([]channeldb.HTLC{{
+		HtlcIndex:   0,
+		Incoming:    true,
+		Amt:         lnwire.NewMSatFromSatoshis(10_000),
+		OutputIndex: 2,
+	}, {
+		HtlcIndex:   1,
+		Incoming:    true,
+		Amt:         lnwire.NewMSatFromSatoshis(300),
+		OutputIndex: -1,
+	}, {
+		HtlcIndex:   2,
+		Amt:         lnwire.NewMSatFromSatoshis(20_000),
+		OutputIndex: 3,
+	}, {
+		HtlcIndex:   3,
+		Amt:         lnwire.NewMSatFromSatoshis(400),
+		OutputIndex: -1,
+	}})
+
+	require.Equal(t, ltcutil.Amount(30_000), htlcs.nonDustValue())
+}
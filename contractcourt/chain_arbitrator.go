@@ -13,9 +13,11 @@ import (
 	"github.com/ltcsuite/lnd/input"
 	"github.com/ltcsuite/lnd/kvdb"
 	"github.com/ltcsuite/lnd/labels"
+	"github.com/ltcsuite/lnd/lntypes"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/lnwallet/chainfee"
 	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/subscribe"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
 	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/wire"
@@ -112,15 +114,6 @@ type ChainArbitratorConfig struct {
 	// returned.
 	IsOurAddress func(ltcutil.Address) bool
 
-	// IncubateOutput sends either an incoming HTLC, an outgoing HTLC, or
-	// both to the utxo nursery. Once this function returns, the nursery
-	// should have safely persisted the outputs to disk, and should start
-	// the process of incubation. This is used when a resolver wishes to
-	// pass off the output to the nursery as we're only waiting on an
-	// absolute/relative item block.
-	IncubateOutputs func(wire.OutPoint, *lnwallet.OutgoingHtlcResolution,
-		*lnwallet.IncomingHtlcResolution, uint32) error
-
 	// PreimageDB is a global store of all known pre-images. We'll use this
 	// to decide if we should broadcast a commitment transaction to claim
 	// an HTLC on-chain.
@@ -152,6 +145,22 @@ type ChainArbitratorConfig struct {
 	// Sweeper allows resolvers to sweep their final outputs.
 	Sweeper UtxoSweeper
 
+	// Budget defines the maximum fees the arbitrators may spend when
+	// sweeping the outputs of a force closed channel.
+	Budget BudgetConfig
+
+	// Stagger defines how the broadcasts of force close transactions are
+	// spread out over time when many channels go to chain at once.
+	Stagger StaggerConfig
+
+	// ForceCloseDelay defines how long we wait for an offline peer to come
+	// back online before going to chain because of pending HTLCs.
+	ForceCloseDelay ForceCloseDelayConfig
+
+	// IsPeerOnline returns true if the peer with the given compressed
+	// public key is currently connected.
+	IsPeerOnline func(peer [33]byte) bool
+
 	// Registry is the invoice database that is used by resolvers to lookup
 	// preimages and settle invoices.
 	Registry Registry
@@ -182,6 +191,12 @@ type ChainArbitratorConfig struct {
 	// htlcIndex, if it is a forwarded one.
 	IsForwardedHTLC func(chanID lnwire.ShortChannelID, htlcIndex uint64) bool
 
+	// IncomingHtlcExpiry returns the expiry height of the incoming htlc
+	// that the given outgoing htlc was forwarded from, or zero if it
+	// isn't known. If nil, outgoing htlcs are swept without a deadline.
+	IncomingHtlcExpiry func(chanID lnwire.ShortChannelID,
+		htlcIndex uint64) (uint32, error)
+
 	// Clock is the clock implementation that ChannelArbitrator uses.
 	// It is useful for testing.
 	Clock clock.Clock
@@ -232,6 +247,13 @@ type ChainArbitrator struct {
 	// active channels that it must still watch over.
 	chanSource *channeldb.DB
 
+	// broadcaster staggers the broadcasts of force close transactions.
+	broadcaster *broadcastScheduler
+
+	// notifier sends out events about the on-chain resolution of
+	// channels.
+	notifier *resolutionNotifier
+
 	quit chan struct{}
 
 	wg sync.WaitGroup
@@ -247,6 +269,8 @@ func NewChainArbitrator(cfg ChainArbitratorConfig,
 		activeChannels: make(map[wire.OutPoint]*ChannelArbitrator),
 		activeWatchers: make(map[wire.OutPoint]*chainWatcher),
 		chanSource:     db,
+		broadcaster:    newBroadcastScheduler(cfg.Stagger, cfg.Clock),
+		notifier:       newResolutionNotifier(),
 		quit:           make(chan struct{}),
 	}
 }
@@ -347,6 +371,11 @@ func newActiveChannelArbitrator(channel *channeldb.OpenChannel,
 
 	chanPoint := channel.FundingOutpoint
 
+	var peerPub [33]byte
+	if channel.IdentityPub != nil {
+		copy(peerPub[:], channel.IdentityPub.SerializeCompressed())
+	}
+
 	log.Tracef("Creating ChannelArbitrator for ChannelPoint(%v)", chanPoint)
 
 	// Next we'll create the matching configuration struct that contains
@@ -355,6 +384,28 @@ func newActiveChannelArbitrator(channel *channeldb.OpenChannel,
 		ChanPoint:   chanPoint,
 		Channel:     c.getArbChannel(channel),
 		ShortChanID: channel.ShortChanID(),
+		StaggerBroadcast: func(publish func(), deadline int32,
+			budget ltcutil.Amount, currentHeight int32) bool {
+
+			return c.broadcaster.schedule(&broadcastRequest{
+				chanPoint: chanPoint,
+				deadline:  deadline,
+				budget:    budget,
+				publish:   publish,
+			}, currentHeight)
+		},
+		BroadcastPending: func() bool {
+			return c.broadcaster.isPending(chanPoint)
+		},
+
+		PeerOnline: func() bool {
+			if c.cfg.IsPeerOnline == nil {
+				return true
+			}
+
+			return c.cfg.IsPeerOnline(peerPub)
+		},
+		PeerOfflineDelay: c.cfg.ForceCloseDelay.delayFor(peerPub),
 
 		MarkCommitmentBroadcasted: channel.MarkCommitmentBroadcasted,
 		MarkChannelClosed: func(summary *channeldb.ChannelCloseSummary,
@@ -365,6 +416,7 @@ func newActiveChannelArbitrator(channel *channeldb.OpenChannel,
 				return err
 			}
 			c.cfg.NotifyClosedChannel(summary.ChanPoint)
+			c.notifier.notifyChannelClosed(summary)
 			return nil
 		},
 		IsPendingClose:        false,
@@ -373,9 +425,10 @@ func newActiveChannelArbitrator(channel *channeldb.OpenChannel,
 		PutResolverReport: func(tx kvdb.RwTx,
 			report *channeldb.ResolverReport) error {
 
-			return c.chanSource.PutResolverReport(
-				tx, c.cfg.ChainHash, &chanPoint, report,
-			)
+			return c.putResolverReport(tx, chanPoint, report)
+		},
+		NotifyResolverReport: func(report *channeldb.ResolverReport) {
+			c.notifyResolverReport(chanPoint, report)
 		},
 		FetchHistoricalChannel: func() (*channeldb.OpenChannel, error) {
 			chanStateDB := c.chanSource.ChannelStateDB()
@@ -401,7 +454,12 @@ func newActiveChannelArbitrator(channel *channeldb.OpenChannel,
 			c.cfg.NotifyFullyResolvedChannel(chanPoint)
 		}
 
-		return c.ResolveContract(chanPoint)
+		if err := c.ResolveContract(chanPoint); err != nil {
+			return err
+		}
+		c.notifyResolutionComplete(chanPoint)
+
+		return nil
 	}
 
 	// Finally, we'll need to construct a series of htlc Sets based on all
@@ -491,6 +549,102 @@ func (c *ChainArbitrator) ResolveContract(chanPoint wire.OutPoint) error {
 	return nil
 }
 
+// putResolverReport stores the resolver report of a channel. If the
+// transaction provided is nil, the report is written in a new transaction and
+// subscribers are notified of the resolution right away. Otherwise, the caller
+// must notify them through notifyResolverReport once the transaction has been
+// committed, so that subscribers never learn about reports that are rolled
+// back.
+func (c *ChainArbitrator) putResolverReport(tx kvdb.RwTx,
+	chanPoint wire.OutPoint, report *channeldb.ResolverReport) error {
+
+	err := c.chanSource.PutResolverReport(
+		tx, c.cfg.ChainHash, &chanPoint, report,
+	)
+	if err != nil {
+		return err
+	}
+
+	if tx == nil {
+		c.notifyResolverReport(chanPoint, report)
+	}
+
+	return nil
+}
+
+// notifyResolverReport notifies subscribers of a resolver report that has
+// been stored for the given channel.
+func (c *ChainArbitrator) notifyResolverReport(chanPoint wire.OutPoint,
+	report *channeldb.ResolverReport) {
+
+	c.notifier.notify(&ResolverReportEvent{
+		ChanPoint: chanPoint,
+		Report:    report,
+	})
+}
+
+// notifyResolutionComplete notifies subscribers that all outputs of the given
+// channel have been resolved.
+func (c *ChainArbitrator) notifyResolutionComplete(chanPoint wire.OutPoint) {
+	chanStateDB := c.chanSource.ChannelStateDB()
+	summary, err := chanStateDB.FetchClosedChannel(&chanPoint)
+	if err != nil {
+		log.Errorf("Unable to fetch close summary of ChannelPoint(%v) "+
+			"for resolution event: %v", chanPoint, err)
+		return
+	}
+
+	event := &ResolutionCompleteEvent{
+		ChanPoint:   chanPoint,
+		CloseType:   summary.CloseType,
+		ClosingTxid: summary.ClosingTXID,
+	}
+
+	// The fee of the confirmed commitment is only known from the
+	// historical channel state, which older channels might not have.
+	histChan, err := chanStateDB.FetchHistoricalChannel(&chanPoint)
+	switch {
+	case err != nil:
+		log.Debugf("No historical state of ChannelPoint(%v) for "+
+			"resolution event: %v", chanPoint, err)
+
+	case summary.CloseType == channeldb.LocalForceClose:
+		event.CommitFee = histChan.LocalCommitment.CommitFee
+
+	case summary.CloseType == channeldb.RemoteForceClose:
+		event.CommitFee = histChan.RemoteCommitment.CommitFee
+	}
+
+	reports, err := c.chanSource.FetchChannelReports(
+		c.cfg.ChainHash, &chanPoint,
+	)
+	switch err {
+	case nil, channeldb.ErrNoChainHashBucket,
+		channeldb.ErrNoChannelSummaries:
+
+		event.Reports = reports
+		event.SweepFee = sweepFee(reports)
+
+	default:
+		log.Errorf("Unable to fetch resolver reports of "+
+			"ChannelPoint(%v) for resolution event: %v", chanPoint,
+			err)
+		return
+	}
+
+	c.notifier.notify(event)
+}
+
+// SubscribeResolutionEvents returns a subscription client that receives
+// events about the on-chain resolution of channels. The events are of type
+// CommitmentConfirmedEvent, BreachDetectedEvent, ResolverReportEvent and
+// ResolutionCompleteEvent.
+func (c *ChainArbitrator) SubscribeResolutionEvents() (*subscribe.Client,
+	error) {
+
+	return c.notifier.subscribe()
+}
+
 // Start launches all goroutines that the ChainArbitrator needs to operate.
 func (c *ChainArbitrator) Start() error {
 	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
@@ -499,6 +653,14 @@ func (c *ChainArbitrator) Start() error {
 
 	log.Info("ChainArbitrator starting")
 
+	if err := c.notifier.Start(); err != nil {
+		return err
+	}
+
+	// Start the broadcast scheduler first, as closing transactions are
+	// republished while the arbitrators are created.
+	c.broadcaster.Start()
+
 	// First, we'll fetch all the channels that are still open, in order to
 	// collect them within our set of active contracts.
 	openChannels, err := c.chanSource.ChannelStateDB().FetchAllChannels()
@@ -588,9 +750,12 @@ func (c *ChainArbitrator) Start() error {
 			PutResolverReport: func(tx kvdb.RwTx,
 				report *channeldb.ResolverReport) error {
 
-				return c.chanSource.PutResolverReport(
-					tx, c.cfg.ChainHash, &chanPoint, report,
-				)
+				return c.putResolverReport(tx, chanPoint, report)
+			},
+			NotifyResolverReport: func(
+				report *channeldb.ResolverReport) {
+
+				c.notifyResolverReport(chanPoint, report)
 			},
 			FetchHistoricalChannel: func() (*channeldb.OpenChannel, error) {
 				chanStateDB := c.chanSource.ChannelStateDB()
@@ -608,7 +773,12 @@ func (c *ChainArbitrator) Start() error {
 				c.cfg.NotifyFullyResolvedChannel(chanPoint)
 			}
 
-			return c.ResolveContract(chanPoint)
+			if err := c.ResolveContract(chanPoint); err != nil {
+				return err
+			}
+			c.notifyResolutionComplete(chanPoint)
+
+			return nil
 		}
 
 		// We create an empty map of HTLC's here since it's possible
@@ -902,12 +1072,44 @@ func (c *ChainArbitrator) rebroadcast(channel *channeldb.OpenChannel,
 	label := labels.MakeLabel(
 		labels.LabelTypeChannelClose, &channel.ShortChannelID,
 	)
-	err = c.cfg.PublishTx(closeTx, label)
-	if err != nil && err != lnwallet.ErrDoubleSpend {
-		log.Warnf("Unable to broadcast %s close tx(%v): %v",
-			kind, closeTx.TxHash(), err)
+	publish := func() {
+		err := c.cfg.PublishTx(closeTx, label)
+		if err != nil && err != lnwallet.ErrDoubleSpend {
+			log.Warnf("Unable to broadcast %s close tx(%v): %v",
+				kind, closeTx.TxHash(), err)
+		}
 	}
 
+	_, bestHeight, err := c.cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		return err
+	}
+
+	// A cooperative close doesn't have any HTLCs at stake, so it gets the
+	// same deadline as a commitment without any HTLCs. Our commitment is
+	// prioritized by the deadline of its HTLCs and its CPFP budget, just
+	// like when it was first published.
+	var (
+		deadline = bestHeight + anchorSweepConfTarget
+		budget   ltcutil.Amount
+	)
+	arbitrator, ok := c.activeChannels[chanPoint]
+	if ok && state == channeldb.ChanStatusCommitBroadcasted {
+		deadline, budget, err = arbitrator.commitmentPriority(
+			uint32(bestHeight),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.broadcaster.schedule(&broadcastRequest{
+		chanPoint: chanPoint,
+		deadline:  deadline,
+		budget:    budget,
+		publish:   publish,
+	}, bestHeight)
+
 	return nil
 }
 
@@ -961,7 +1163,9 @@ func (c *ChainArbitrator) Stop() error {
 
 	c.wg.Wait()
 
-	return nil
+	c.broadcaster.Stop()
+
+	return c.notifier.Stop()
 }
 
 // ContractUpdate is a message packages the latest set of active HTLCs on a
@@ -1041,6 +1245,29 @@ func (c *ChainArbitrator) GetChannelArbitrator(chanPoint wire.OutPoint) (
 	return arbitrator, nil
 }
 
+// FindIncomingContests returns the channel points of all channels that have an
+// active resolver waiting for the preimage of an incoming HTLC with the given
+// payment hash.
+func (c *ChainArbitrator) FindIncomingContests(
+	hash lntypes.Hash) []wire.OutPoint {
+
+	c.Lock()
+	arbitrators := make(map[wire.OutPoint]*ChannelArbitrator)
+	for chanPoint, arbitrator := range c.activeChannels {
+		arbitrators[chanPoint] = arbitrator
+	}
+	c.Unlock()
+
+	var chanPoints []wire.OutPoint
+	for chanPoint, arbitrator := range arbitrators {
+		if arbitrator.hasIncomingContest(hash) {
+			chanPoints = append(chanPoints, chanPoint)
+		}
+	}
+
+	return chanPoints
+}
+
 // forceCloseReq is a request sent from an outside sub-system to the arbitrator
 // that watches a particular channel to broadcast the commitment transaction,
 // and enter the resolution phase of the channel.
@@ -1090,6 +1317,8 @@ func (c *ChainArbitrator) ForceCloseContract(chanPoint wire.OutPoint) (*wire.Msg
 		errResp: errChan,
 		closeTx: respChan,
 	}:
+	case <-arbitrator.attendantDone:
+		return nil, errAttendantExited
 	case <-c.quit:
 		return nil, ErrChainArbExiting
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/lnd/clock"
 	"github.com/ltcsuite/lnd/lntest/mock"
+	"github.com/ltcsuite/lnd/lntypes"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
 	"github.com/ltcsuite/ltcd/wire"
@@ -218,3 +219,51 @@ func TestResolveContract(t *testing.T) {
 	err = chainArb.ResolveContract(channel.FundingOutpoint)
 	require.NoError(t, err, "second resolve call shouldn't fail")
 }
+
+// TestFindIncomingContests asserts that only the channels with an incoming
+// contest resolver waiting for the given payment hash are returned.
+func TestFindIncomingContests(t *testing.T) {
+	t.Parallel()
+
+	preimage := lntypes.Preimage{1}
+	hash := preimage.Hash()
+
+	incomingContest := func(rHash lntypes.Hash) ContractResolver {
+		return &htlcIncomingContestResolver{
+			htlcSuccessResolver: &htlcSuccessResolver{
+				htlc: channeldb.HTLC{
+					RHash: rHash,
+				},
+			},
+		}
+	}
+
+	// The first channel has an incoming contest for the hash, the second
+	// one for another hash and the third one only an outgoing contest.
+	chainArb := &ChainArbitrator{
+		activeChannels: map[wire.OutPoint]*ChannelArbitrator{
+			testChanPoint1: {
+				activeResolvers: []ContractResolver{
+					incomingContest(lntypes.Hash{}),
+					incomingContest(hash),
+				},
+			},
+			testChanPoint2: {
+				activeResolvers: []ContractResolver{
+					incomingContest(lntypes.Hash{}),
+				},
+			},
+			testChanPoint3: {
+				activeResolvers: []ContractResolver{
+					&htlcOutgoingContestResolver{},
+				},
+			},
+		},
+	}
+
+	require.Equal(
+		t, []wire.OutPoint{testChanPoint1},
+		chainArb.FindIncomingContests(hash),
+	)
+	require.Empty(t, chainArb.FindIncomingContests(lntypes.Hash{2}))
+}
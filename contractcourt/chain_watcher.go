@@ -283,14 +283,14 @@ func (c *chainWatcher) Start() error {
 	// logic will not be triggered for restored, zero-conf channels. Set
 	// the height hint for zero-conf channels.
 	if chanState.IsZeroConf() {
-		if chanState.ZeroConfConfirmed() {
-			// If the zero-conf channel is confirmed, we'll use the
-			// confirmed SCID's block height.
+		// We'll prefer the FundingBroadcastHeight even if the
+		// zero-conf channel is confirmed. A reorg may move the funding
+		// transaction to an earlier block than the one the confirmed
+		// SCID points to, while it can never confirm before it was
+		// broadcast.
+		c.heightHint = chanState.BroadcastHeight()
+		if c.heightHint == 0 && chanState.ZeroConfConfirmed() {
 			c.heightHint = chanState.ZeroConfRealScid().BlockHeight
-		} else {
-			// The zero-conf channel is unconfirmed. We'll need to
-			// use the FundingBroadcastHeight.
-			c.heightHint = chanState.BroadcastHeight()
 		}
 	}
 
@@ -32,6 +32,12 @@ var (
 	// close a channel that's already in the process of doing so.
 	errAlreadyForceClosed = errors.New("channel is already in the " +
 		"process of being force closed")
+
+	// errAttendantExited is an error returned when a request is sent to a
+	// channel arbitrator that no longer processes any requests, as the
+	// contract is already fully resolved.
+	errAttendantExited = errors.New("channel arbitrator is no longer " +
+		"attending the channel")
 )
 
 const (
@@ -129,6 +135,30 @@ type ChannelArbitratorConfig struct {
 	// being broadcast, and we are waiting for the commitment to confirm.
 	MarkCommitmentBroadcasted func(*wire.MsgTx, bool) error
 
+	// StaggerBroadcast hands the publication of our commitment
+	// transaction over to be run once it's its turn, so the mempool isn't
+	// flooded when many channels go to chain at once. The deadline is the
+	// height by which the commitment should confirm. It returns false if
+	// the transaction was published right away. If nil, the commitment is
+	// always published right away.
+	StaggerBroadcast func(publish func(), deadline int32,
+		budget ltcutil.Amount, currentHeight int32) bool
+
+	// BroadcastPending returns true while the broadcast of our commitment
+	// transaction is waiting for its turn. As it's derived from the queued
+	// broadcasts, it also covers the commitments that are republished on
+	// startup. If nil, the broadcast is never pending.
+	BroadcastPending func() bool
+
+	// PeerOnline returns true if the channel peer is currently connected.
+	// If nil, the peer is always considered online.
+	PeerOnline func() bool
+
+	// PeerOfflineDelay is the time we wait for the channel peer to come
+	// back online before going to chain because of HTLCs that are still
+	// far from their expiry. A value of zero disables the delay.
+	PeerOfflineDelay time.Duration
+
 	// MarkChannelClosed marks the channel closed in the database, with the
 	// passed close summary. After this method successfully returns we can
 	// no longer expect to receive chain events for this channel, and must
@@ -164,6 +194,12 @@ type ChannelArbitratorConfig struct {
 	PutResolverReport func(tx kvdb.RwTx,
 		report *channeldb.ResolverReport) error
 
+	// NotifyResolverReport notifies subscribers of a resolver report that
+	// was recorded through PutResolverReport within the given transaction,
+	// once that transaction has been committed. Reports that are recorded
+	// in a new transaction are notified by PutResolverReport itself.
+	NotifyResolverReport func(report *channeldb.ResolverReport)
+
 	// FetchHistoricalChannel retrieves the historical state of a channel.
 	// This is mostly used to supplement the ContractResolvers with
 	// additional information required for proper contract resolution.
@@ -271,6 +307,24 @@ func newHtlcSet(htlcs []channeldb.HTLC) htlcSet {
 	}
 }
 
+// nonDustValue returns the total value of the HTLCs in the set that have an
+// output on the commitment transaction.
+func (h htlcSet) nonDustValue() ltcutil.Amount {
+	var value ltcutil.Amount
+	for _, htlc := range h.incomingHTLCs {
+		if htlc.OutputIndex >= 0 {
+			value += htlc.Amt.ToSatoshis()
+		}
+	}
+	for _, htlc := range h.outgoingHTLCs {
+		if htlc.OutputIndex >= 0 {
+			value += htlc.Amt.ToSatoshis()
+		}
+	}
+
+	return value
+}
+
 // HtlcSetKey is a two-tuple that uniquely identifies a set of HTLCs on a
 // commitment transaction.
 type HtlcSetKey struct {
@@ -373,6 +427,18 @@ type ChannelArbitrator struct {
 	// upon start up to decide which actions to take.
 	state ArbitratorState
 
+	// forceCloseDelayStart is the time at which we first held off going to
+	// chain because the channel peer was offline. It is reset once the
+	// peer is back online, and persisted in the arbitrator log so the
+	// delay isn't restarted by a restart.
+	forceCloseDelayStart time.Time
+
+	// attendantDone is closed once the channel attendant has exited. This
+	// can happen before the arbitrator is stopped, for instance once the
+	// contract is fully resolved, so requests sent to the attendant need
+	// to select on it to not block forever.
+	attendantDone chan struct{}
+
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
@@ -401,6 +467,7 @@ func NewChannelArbitrator(cfg ChannelArbitratorConfig,
 		signalUpdates:    make(chan *signalUpdateMsg),
 		resolutionSignal: make(chan struct{}),
 		forceCloseReqs:   make(chan *forceCloseReq),
+		attendantDone:    make(chan struct{}),
 		activeHTLCs:      htlcSets,
 		unmergedSet:      unmerged,
 		cfg:              cfg,
@@ -470,6 +537,14 @@ func (c *ChannelArbitrator) Start(state *chanArbStartState) error {
 	// Set our state from our starting state.
 	c.state = state.currentState
 
+	// Restore the time at which we started delaying a force close, so a
+	// restart doesn't extend the delay.
+	delayStart, err := c.log.FetchForceCloseDelayStart()
+	if err != nil {
+		return err
+	}
+	c.forceCloseDelayStart = delayStart
+
 	_, bestHeight, err := c.cfg.ChainIO.GetBestBlock()
 	if err != nil {
 		return err
@@ -804,6 +879,26 @@ func (c *ChannelArbitrator) Report() []*ContractReport {
 	return reports
 }
 
+// hasIncomingContest returns true if one of the active resolvers is waiting
+// for the preimage of an incoming HTLC with the given payment hash.
+func (c *ChannelArbitrator) hasIncomingContest(hash lntypes.Hash) bool {
+	c.activeResolversLock.RLock()
+	defer c.activeResolversLock.RUnlock()
+
+	for _, resolver := range c.activeResolvers {
+		r, ok := resolver.(*htlcIncomingContestResolver)
+		if !ok {
+			continue
+		}
+
+		if lntypes.Hash(r.htlc.RHash) == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Stop signals the ChannelArbitrator for a graceful shutdown.
 func (c *ChannelArbitrator) Stop() error {
 	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
@@ -944,6 +1039,15 @@ func (c *ChannelArbitrator) stateStep(
 			return StateDefault, closeTx, nil
 		}
 
+		// If the peer is offline, we give it some time to come back
+		// online and resolve the HTLCs off-chain before going to
+		// chain, as long as none of them is close to its expiry.
+		if trigger == chainTrigger &&
+			c.delayForceClose(triggerHeight, chainActions) {
+
+			return StateDefault, closeTx, nil
+		}
+
 		// Otherwise, we'll log that we checked the HTLC actions as the
 		// commitment transaction has already been broadcast.
 		log.Tracef("ChannelArbitrator(%v): logging chain_actions=%v",
@@ -1092,13 +1196,8 @@ func (c *ChannelArbitrator) stateStep(
 
 		// At this point, we'll now broadcast the commitment
 		// transaction itself.
-		label := labels.MakeLabel(
-			labels.LabelTypeChannelClose, &c.cfg.ShortChanID,
-		)
-		if err := c.cfg.PublishTx(closeTx, label); err != nil {
-			log.Errorf("ChannelArbitrator(%v): unable to broadcast "+
-				"close tx: %v", c.cfg.ChanPoint, err)
-
+		err = c.publishCommitment(closeTx, triggerHeight)
+		if err != nil {
 			// This makes sure we don't fail at startup if the
 			// commitment transaction has too low fees to make it
 			// into mempool. The rebroadcaster makes sure this
@@ -1140,6 +1239,20 @@ func (c *ChannelArbitrator) stateStep(
 				return StateError, closeTx, err
 			}
 
+			// The anchors can't be swept before our commitment is
+			// published, so we'll wait for the next block if its
+			// broadcast is still being staggered.
+			if c.cfg.BroadcastPending != nil &&
+				c.cfg.BroadcastPending() {
+
+				log.Debugf("ChannelArbitrator(%v): commitment "+
+					"broadcast pending, not sweeping "+
+					"anchors yet", c.cfg.ChanPoint)
+
+				nextState = StateCommitmentBroadcasted
+				break
+			}
+
 			err = c.sweepAnchors(anchors, triggerHeight)
 			if err != nil {
 				return StateError, closeTx, err
@@ -1313,6 +1426,16 @@ func (c *ChannelArbitrator) sweepAnchors(anchors *lnwallet.AnchorResolutions,
 		// should force sweeping this anchor.
 		var force bool
 
+		// The budget of the CPFP is derived from the value of the
+		// HTLCs that are at stake. It's only used for forced sweeps,
+		// as otherwise the anchor is only swept when economical.
+		var budget ltcutil.Amount
+
+		// The absolute deadline is passed to the sweeper as well, so
+		// the conf target shrinks with every block that passes
+		// without the commitment being confirmed.
+		var deadlineHeight int32
+
 		// Check the deadline against the default value. If it's less
 		// than the default value of 144, it means there is a deadline
 		// and we will perform a CPFP for this commitment tx.
@@ -1321,11 +1444,17 @@ func (c *ChannelArbitrator) sweepAnchors(anchors *lnwallet.AnchorResolutions,
 			// anchor will be swept even if it isn't economical
 			// purely based on the anchor value.
 			force = true
+
+			budget = calculateBudget(
+				htlcs.nonDustValue(), c.cfg.Budget.AnchorCPFP,
+			)
+			deadlineHeight = int32(heightHint + deadline)
 		}
 
 		log.Debugf("ChannelArbitrator(%v): pre-confirmation sweep of "+
-			"anchor of %s commit tx %v, force=%v", c.cfg.ChanPoint,
-			anchorPath, anchor.CommitAnchor, force)
+			"anchor of %s commit tx %v, force=%v, budget=%v",
+			c.cfg.ChanPoint, anchorPath, anchor.CommitAnchor, force,
+			budget)
 
 		witnessType := input.CommitmentAnchor
 
@@ -1363,6 +1492,8 @@ func (c *ChannelArbitrator) sweepAnchors(anchors *lnwallet.AnchorResolutions,
 				},
 				Force:          force,
 				ExclusiveGroup: &exclusiveGroup,
+				Budget:         budget,
+				DeadlineHeight: deadlineHeight,
 			},
 		)
 		if err != nil {
@@ -1409,6 +1540,73 @@ func (c *ChannelArbitrator) sweepAnchors(anchors *lnwallet.AnchorResolutions,
 	return nil
 }
 
+// publishCommitment publishes our commitment transaction, or hands it over to
+// be published once it's its turn if many channels go to chain at once. An
+// error is only returned if the transaction was published right away.
+func (c *ChannelArbitrator) publishCommitment(closeTx *wire.MsgTx,
+	heightHint uint32) error {
+
+	label := labels.MakeLabel(
+		labels.LabelTypeChannelClose, &c.cfg.ShortChanID,
+	)
+
+	var publishErr error
+	publish := func() {
+		publishErr = c.cfg.PublishTx(closeTx, label)
+		if publishErr != nil {
+			log.Errorf("ChannelArbitrator(%v): unable to "+
+				"broadcast close tx: %v", c.cfg.ChanPoint,
+				publishErr)
+		}
+	}
+
+	if c.cfg.StaggerBroadcast == nil {
+		publish()
+		return publishErr
+	}
+
+	deadline, budget, err := c.commitmentPriority(heightHint)
+	if err != nil {
+		return err
+	}
+
+	deferred := c.cfg.StaggerBroadcast(
+		publish, deadline, budget, int32(heightHint),
+	)
+	if deferred {
+		log.Infof("ChannelArbitrator(%v): staggering broadcast of "+
+			"close tx %v, deadline=%v, budget=%v", c.cfg.ChanPoint,
+			closeTx.TxHash(), deadline, budget)
+
+		return nil
+	}
+
+	return publishErr
+}
+
+// commitmentPriority returns the height by which our commitment transaction
+// should confirm, derived from the deadline of its HTLCs, along with the
+// budget we're willing to spend on getting it confirmed. Both are used to
+// prioritize its broadcast if many channels go to chain at once.
+//
+// NOTE: This must only be called from the channel attendant, or before the
+// arbitrator is started.
+func (c *ChannelArbitrator) commitmentPriority(heightHint uint32) (int32,
+	ltcutil.Amount, error) {
+
+	c.updateActiveHTLCs()
+	htlcs := c.activeHTLCs[LocalHtlcSet]
+	deadline, err := c.findCommitmentDeadline(heightHint, htlcs)
+	if err != nil {
+		return 0, 0, err
+	}
+	budget := calculateBudget(
+		htlcs.nonDustValue(), c.cfg.Budget.AnchorCPFP,
+	)
+
+	return int32(heightHint + deadline), budget, nil
+}
+
 // findCommitmentDeadline finds the deadline (relative block height) for a
 // commitment transaction by extracting the minimum CLTV from its HTLCs. From
 // our PoV, the deadline is defined to be the smaller of,
@@ -1705,6 +1903,82 @@ func (c *ChannelArbitrator) shouldGoOnChain(htlc channeldb.HTLC,
 	return isForwarded || upTime > c.cfg.PaymentsExpirationGracePeriod
 }
 
+// delayForceClose returns true if going to chain for the given chain actions
+// should be held off, because the channel peer is offline and we're still
+// within the configured delay. The delay only applies as long as no HTLC of the
+// chain actions is more than MaxBlocks blocks past the height at which it
+// makes us go to chain, which follows from its expiry and the incoming or
+// outgoing broadcast delta. As this is evaluated for every new block, the
+// decision is revisited once the delay has passed or the HTLCs get too close
+// to their expiry.
+func (c *ChannelArbitrator) delayForceClose(height uint32,
+	chainActions ChainActionMap) bool {
+
+	if c.cfg.PeerOfflineDelay == 0 || c.cfg.PeerOnline == nil {
+		return false
+	}
+
+	if c.cfg.PeerOnline() {
+		c.setForceCloseDelayStart(time.Time{})
+		return false
+	}
+
+	for _, htlcs := range chainActions {
+		for _, htlc := range htlcs {
+			broadcastDelta := c.cfg.OutgoingBroadcastDelta
+			if htlc.Incoming {
+				broadcastDelta = c.cfg.IncomingBroadcastDelta
+			}
+
+			// If the HTLC expires within its broadcast delta,
+			// we'd go to chain right away anyway.
+			if htlc.RefundTimeout < broadcastDelta {
+				return false
+			}
+
+			maxHeight := htlc.RefundTimeout - broadcastDelta +
+				c.cfg.ForceCloseDelay.MaxBlocks
+			if height >= maxHeight {
+				return false
+			}
+		}
+	}
+
+	now := c.cfg.Clock.Now()
+	if c.forceCloseDelayStart.IsZero() {
+		c.setForceCloseDelayStart(now)
+	}
+
+	waited := now.Sub(c.forceCloseDelayStart)
+	if waited >= c.cfg.PeerOfflineDelay {
+		log.Infof("ChannelArbitrator(%v): peer still offline after "+
+			"%v, going to chain", c.cfg.ChanPoint, waited)
+
+		return false
+	}
+
+	log.Infof("ChannelArbitrator(%v): peer is offline, waiting %v for it "+
+		"to come back online before going to chain", c.cfg.ChanPoint,
+		c.cfg.PeerOfflineDelay-waited)
+
+	return true
+}
+
+// setForceCloseDelayStart updates the time at which we first held off going to
+// chain because the channel peer was offline, and persists it in the
+// arbitrator log.
+func (c *ChannelArbitrator) setForceCloseDelayStart(start time.Time) {
+	if c.forceCloseDelayStart.Equal(start) {
+		return
+	}
+	c.forceCloseDelayStart = start
+
+	if err := c.log.CommitForceCloseDelayStart(start); err != nil {
+		log.Errorf("ChannelArbitrator(%v): unable to persist force "+
+			"close delay start: %v", c.cfg.ChanPoint, err)
+	}
+}
+
 // checkCommitChainActions is called for each new block connected to the end of
 // the main chain. Given the new block height, this new method will examine all
 // active HTLC's, and determine if we need to go on-chain to claim any of them.
@@ -2609,7 +2883,16 @@ func (c *ChannelArbitrator) UpdateContractSignals(newSignals *ContractSignals) {
 		newSignals: newSignals,
 		doneChan:   done,
 	}:
+
+	// If the attendant has already exited, e.g. because a zero-conf
+	// channel was fully resolved before its funding transaction confirmed
+	// and its link reported the confirmed SCID, there's no one left to
+	// apply the update.
+	case <-c.attendantDone:
+		return
+
 	case <-c.quit:
+		return
 	}
 
 	select {
@@ -2662,14 +2945,15 @@ func (c *ChannelArbitrator) updateActiveHTLCs() {
 // outgoing HTLC is about to timeout, and when we know the pre-image for an
 // incoming HTLC, but it hasn't yet been settled off-chain. In these cases,
 // we'll: broadcast our commitment, cancel/settle any HTLC's backwards after
-// sufficient confirmation, and finally send our set of outputs to the UTXO
-// Nursery for incubation, and ultimate sweeping.
+// sufficient confirmation, and finally sweep our set of outputs once their
+// time locks have expired.
 //
 // NOTE: This MUST be run as a goroutine.
 func (c *ChannelArbitrator) channelAttendant(bestHeight int32) {
 
 	// TODO(roasbeef): tell top chain arb we're done
 	defer func() {
+		close(c.attendantDone)
 		c.wg.Done()
 	}()
 
@@ -43,6 +43,8 @@ type mockArbitratorLog struct {
 
 	commitSet *CommitSet
 
+	forceCloseDelayStart time.Time
+
 	sync.Mutex
 }
 
@@ -143,6 +145,21 @@ func (b *mockArbitratorLog) FetchConfirmedCommitSet(kvdb.RTx) (*CommitSet, error
 	return b.commitSet, nil
 }
 
+func (b *mockArbitratorLog) CommitForceCloseDelayStart(start time.Time) error {
+	b.Lock()
+	b.forceCloseDelayStart = start
+	b.Unlock()
+
+	return nil
+}
+
+func (b *mockArbitratorLog) FetchForceCloseDelayStart() (time.Time, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.forceCloseDelayStart, nil
+}
+
 func (b *mockArbitratorLog) WipeHistory() error {
 	return nil
 }
@@ -200,7 +217,7 @@ type chanArbTestCtx struct {
 
 	resolvedChan chan struct{}
 
-	incubationRequests chan struct{}
+	publishedTxs chan *wire.MsgTx
 
 	resolutions chan []ResolutionMsg
 
@@ -340,13 +357,18 @@ func createTestChannelArbitrator(t *testing.T, log ArbitratorLog,
 	}
 
 	resolutionChan := make(chan []ResolutionMsg, 1)
-	incubateChan := make(chan struct{})
+	publishedTxs := make(chan *wire.MsgTx, 10)
 
 	chainIO := &mockChainIO{}
 	mockSweeper := newMockSweeper()
 	chainArbCfg := ChainArbitratorConfig{
 		ChainIO: chainIO,
-		PublishTx: func(*wire.MsgTx, string) error {
+		PublishTx: func(tx *wire.MsgTx, _ string) error {
+			select {
+			case publishedTxs <- tx:
+			default:
+			}
+
 			return nil
 		},
 		DeliverResolutionMsg: func(msgs ...ResolutionMsg) error {
@@ -360,13 +382,6 @@ func createTestChannelArbitrator(t *testing.T, log ArbitratorLog,
 			SpendChan: make(chan *chainntnfs.SpendDetail),
 			ConfChan:  make(chan *chainntnfs.TxConfirmation),
 		},
-		IncubateOutputs: func(wire.OutPoint,
-			*lnwallet.OutgoingHtlcResolution,
-			*lnwallet.IncomingHtlcResolution, uint32) error {
-
-			incubateChan <- struct{}{}
-			return nil
-		},
 		OnionProcessor: &mockOnionProcessor{},
 		IsForwardedHTLC: func(chanID lnwire.ShortChannelID,
 			htlcIndex uint64) bool {
@@ -476,7 +491,7 @@ func createTestChannelArbitrator(t *testing.T, log ArbitratorLog,
 	chanArbCtx.resolvedChan = resolvedChan
 	chanArbCtx.resolutions = resolutionChan
 	chanArbCtx.log = log
-	chanArbCtx.incubationRequests = incubateChan
+	chanArbCtx.publishedTxs = publishedTxs
 	chanArbCtx.sweeper = mockSweeper
 
 	return chanArbCtx, nil
@@ -590,6 +605,29 @@ func TestChannelArbitratorRemoteForceClose(t *testing.T) {
 	case <-time.After(defaultTimeout):
 		t.Fatalf("contract was not resolved")
 	}
+
+	// Now that the contract is fully resolved, the attendant exits. A
+	// late signal update, as sent once the funding transaction of a
+	// zero-conf channel confirms, must not block.
+	select {
+	case <-chanArb.attendantDone:
+	case <-time.After(defaultTimeout):
+		t.Fatalf("attendant did not exit")
+	}
+
+	updated := make(chan struct{})
+	go func() {
+		chanArb.UpdateContractSignals(&ContractSignals{
+			ShortChanID: lnwire.NewShortChanIDFromInt(1),
+		})
+		close(updated)
+	}()
+
+	select {
+	case <-updated:
+	case <-time.After(defaultTimeout):
+		t.Fatalf("signal update blocked")
+	}
 }
 
 // TestChannelArbitratorLocalForceClose tests that the ChannelArbitrator goes
@@ -1039,10 +1077,10 @@ func TestChannelArbitratorLocalForceClosePendingHtlc(t *testing.T) {
 	}
 
 	// htlcOutgoingContestResolver is now active and waiting for the HTLC to
-	// expire. It should not yet have passed it on for incubation.
+	// expire. It should not yet have published the timeout transaction.
 	select {
-	case <-chanArbCtx.incubationRequests:
-		t.Fatalf("contract should not be incubated yet")
+	case <-chanArbCtx.publishedTxs:
+		t.Fatalf("timeout tx should not be published yet")
 	default:
 	}
 
@@ -1050,16 +1088,26 @@ func TestChannelArbitratorLocalForceClosePendingHtlc(t *testing.T) {
 	oldNotifier.EpochChan <- &chainntnfs.BlockEpoch{Height: 10}
 
 	// htlcOutgoingContestResolver is now transforming into a
-	// htlcTimeoutResolver and should send the contract off for incubation.
+	// htlcTimeoutResolver, which will wait for the expiry height once more
+	// before publishing the timeout transaction.
+	oldNotifier.EpochChan <- &chainntnfs.BlockEpoch{Height: 10}
+
+	timeoutTxHash := outgoingRes.SignedTimeoutTx.TxHash()
 	select {
-	case <-chanArbCtx.incubationRequests:
+	case tx := <-chanArbCtx.publishedTxs:
+		require.Equal(t, timeoutTxHash, tx.TxHash())
+
 	case <-time.After(defaultTimeout):
-		t.Fatalf("no response received")
+		t.Fatalf("timeout tx not published")
 	}
 
 	// Notify resolver that the HTLC output of the commitment has been
-	// spent.
-	oldNotifier.SpendChan <- &chainntnfs.SpendDetail{SpendingTx: closeTx}
+	// spent by the timeout transaction.
+	oldNotifier.SpendChan <- &chainntnfs.SpendDetail{
+		SpendingTx:     outgoingRes.SignedTimeoutTx,
+		SpenderTxHash:  &timeoutTxHash,
+		SpendingHeight: 11,
+	}
 
 	// Finally, we should also receive a resolution message instructing the
 	// switch to cancel back the HTLC.
@@ -1086,6 +1134,16 @@ func TestChannelArbitratorLocalForceClosePendingHtlc(t *testing.T) {
 	default:
 	}
 
+	// Once the CSV lock of the second-level output has expired, it should
+	// be offered to the sweeper.
+	oldNotifier.EpochChan <- &chainntnfs.BlockEpoch{Height: 11}
+
+	select {
+	case <-chanArbCtx.sweeper.sweptInputs:
+	case <-time.After(defaultTimeout):
+		t.Fatalf("second-level output not offered to sweeper")
+	}
+
 	// Notify resolver that the second level transaction is spent.
 	oldNotifier.SpendChan <- &chainntnfs.SpendDetail{SpendingTx: closeTx}
 
@@ -2073,6 +2131,182 @@ func TestChannelArbitratorPendingExpiredHTLC(t *testing.T) {
 	)
 }
 
+// TestChannelArbitratorForceCloseDelay tests that we hold off going to chain
+// for an offline peer until either the configured delay has passed, the peer
+// comes back online or the HTLCs get too close to their expiry. The test uses
+// the default broadcast deltas and force close delay settings.
+func TestChannelArbitratorForceCloseDelay(t *testing.T) {
+	t.Parallel()
+
+	const (
+		htlcExpiry = 100
+
+		// The default incoming and outgoing broadcast deltas.
+		incomingBroadcastDelta = 10
+		outgoingBroadcastDelta = 0
+
+		maxBlocks    = DefaultForceCloseDelayMaxBlocks
+		offlineDelay = time.Hour
+	)
+
+	testCases := []struct {
+		name string
+
+		// incoming determines whether the HTLC is an incoming HTLC we
+		// know the preimage of, or an outgoing one.
+		incoming bool
+
+		// online marks the peer as online before the final block.
+		online bool
+
+		// elapsed is the time that has passed when the final block
+		// arrives.
+		elapsed time.Duration
+
+		// blocks is the number of blocks past the trigger height of
+		// the final block.
+		blocks int32
+	}{
+		{
+			name:    "delay elapsed",
+			elapsed: offlineDelay,
+			blocks:  1,
+		},
+		{
+			name:    "peer back online",
+			online:  true,
+			elapsed: time.Minute,
+			blocks:  1,
+		},
+		{
+			name:    "outgoing htlc too close to expiry",
+			elapsed: time.Minute,
+			blocks:  maxBlocks,
+		},
+		{
+			name:     "incoming htlc too close to expiry",
+			incoming: true,
+			elapsed:  time.Minute,
+			blocks:   maxBlocks,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			log := &mockArbitratorLog{
+				state:     StateDefault,
+				newStates: make(chan ArbitratorState, 5),
+				resolvers: make(map[ContractResolver]struct{}),
+			}
+			chanArbCtx, err := createTestChannelArbitrator(t, log)
+			require.NoError(t, err, "unable to create "+
+				"ChannelArbitrator")
+			chanArb := chanArbCtx.chanArb
+
+			startTime := time.Date(
+				2020, time.February, 3, 13, 0, 0, 0, time.UTC,
+			)
+			testClock := clock.NewTestClock(startTime)
+			chanArb.cfg.Clock = testClock
+
+			// We signal each check of the peer's status, so we
+			// know when a block has been processed.
+			var (
+				onlineMtx sync.Mutex
+				online    bool
+				checked   = make(chan struct{}, 2)
+			)
+			chanArb.cfg.PeerOnline = func() bool {
+				onlineMtx.Lock()
+				defer onlineMtx.Unlock()
+
+				checked <- struct{}{}
+
+				return online
+			}
+			chanArb.cfg.PeerOfflineDelay = offlineDelay
+			chanArb.cfg.ForceCloseDelay.MaxBlocks = maxBlocks
+			chanArb.cfg.IncomingBroadcastDelta =
+				incomingBroadcastDelta
+			chanArb.cfg.OutgoingBroadcastDelta =
+				outgoingBroadcastDelta
+
+			// We'll add an HTLC that makes us go to chain once we
+			// reach the trigger height. For an incoming HTLC, we
+			// need to know its preimage.
+			htlc := channeldb.HTLC{
+				Incoming:      testCase.incoming,
+				Amt:           10000,
+				HtlcIndex:     99,
+				RefundTimeout: htlcExpiry,
+			}
+			htlcKey := RemoteHtlcSet
+			triggerHeight := int32(
+				htlcExpiry - outgoingBroadcastDelta,
+			)
+			if testCase.incoming {
+				preimage := lntypes.Preimage{1}
+				htlc.RHash = preimage.Hash()
+
+				preimageDB := newMockWitnessBeacon()
+				preimageDB.lookupPreimage[htlc.RHash] = preimage
+				chanArb.cfg.PreimageDB = preimageDB
+				chanArb.cfg.Registry = &mockRegistry{}
+
+				htlcKey = LocalHtlcSet
+				triggerHeight = int32(
+					htlcExpiry - incomingBroadcastDelta,
+				)
+			}
+
+			require.NoError(t, chanArb.Start(nil))
+			t.Cleanup(func() {
+				require.NoError(t, chanArb.Stop())
+			})
+
+			chanArb.UpdateContractSignals(&ContractSignals{
+				ShortChanID: lnwire.ShortChannelID{},
+			})
+			chanArb.notifyContractUpdate(&ContractUpdate{
+				HtlcKey: htlcKey,
+				Htlcs:   []channeldb.HTLC{htlc},
+			})
+
+			// As the peer is offline and we're within the delay,
+			// the force close is delayed. The time we started to
+			// delay the force close is persisted.
+			chanArb.blocks <- triggerHeight
+			select {
+			case <-checked:
+			case <-time.After(stateTimeout):
+				t.Fatalf("peer status not checked")
+			}
+			chanArbCtx.AssertState(StateDefault)
+
+			delayStart, err := log.FetchForceCloseDelayStart()
+			require.NoError(t, err)
+			require.Equal(t, startTime, delayStart)
+
+			onlineMtx.Lock()
+			online = testCase.online
+			onlineMtx.Unlock()
+
+			// Once the final block arrives, we should go to
+			// chain.
+			testClock.SetTime(startTime.Add(testCase.elapsed))
+			chanArb.blocks <- triggerHeight + testCase.blocks
+			chanArbCtx.AssertStateTransitions(
+				StateBroadcastCommit,
+				StateCommitmentBroadcasted,
+			)
+		})
+	}
+}
+
 // TestRemoteCloseInitiator tests the setting of close initiator statuses
 // for remote force closes and breaches.
 func TestRemoteCloseInitiator(t *testing.T) {
@@ -2,6 +2,7 @@ package contractcourt
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -12,6 +13,7 @@ import (
 	"github.com/ltcsuite/lnd/input"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/sweep"
+	"github.com/ltcsuite/lnd/tlv"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
 	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/txscript"
@@ -60,6 +62,10 @@ type commitSweepResolver struct {
 	// chanType denotes the type of channel the contract belongs to.
 	chanType channeldb.ChannelType
 
+	// localChanCfg is the local party's config of the channel the contract
+	// belongs to.
+	localChanCfg channeldb.ChannelConfig
+
 	// currentReport stores the current state of the resolver for reporting
 	// over the rpc interface.
 	currentReport ContractReport
@@ -151,6 +157,80 @@ func waitForSpend(op *wire.OutPoint, pkScript []byte, heightHint uint32,
 	}
 }
 
+// waitForSweep waits for the given outpoint, which was offered to the sweeper,
+// to be spent, and returns the details of the spending tx together with the
+// fee we paid for it, which is zero if the tx isn't ours. If the sweeper gives
+// up on the output because its budget is exhausted, errSweepAbandoned is
+// returned instead.
+func waitForSweep(op *wire.OutPoint, pkScript []byte, heightHint uint32,
+	sweepResult <-chan sweep.Result, notifier chainntnfs.ChainNotifier,
+	quit <-chan struct{}) (*chainntnfs.SpendDetail, ltcutil.Amount,
+	error) {
+
+	spendNtfn, err := notifier.RegisterSpendNtfn(
+		op, pkScript, heightHint,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var fee ltcutil.Amount
+	for {
+		select {
+		case spendDetail, ok := <-spendNtfn.Spend:
+			if !ok {
+				return nil, 0, errResolverShuttingDown
+			}
+
+			// The sweeper signals its result once it sees the
+			// spend as well, so we wait for it to learn the fee we
+			// paid unless we already received it.
+			if sweepResult == nil {
+				return spendDetail, fee, nil
+			}
+
+			select {
+			case result, ok := <-sweepResult:
+				if ok && result.Err == nil {
+					fee = result.Fee
+				}
+
+			case <-quit:
+				return nil, 0, errResolverShuttingDown
+			}
+
+			return spendDetail, fee, nil
+
+		// Besides the fee of our own sweep, only an abandoned sweep is
+		// of interest here. In all other cases we keep waiting for the
+		// spend, so we stop listening for further results.
+		case result, ok := <-sweepResult:
+			if ok && errors.Is(result.Err, sweep.ErrBudgetExhausted) {
+				return nil, 0, errSweepAbandoned
+			}
+			if ok && result.Err == nil {
+				fee = result.Fee
+			}
+			sweepResult = nil
+
+		case <-quit:
+			return nil, 0, errResolverShuttingDown
+		}
+	}
+}
+
+// secondLevelTxFee returns the fee of a pre-signed second-level transaction
+// spending an HTLC of the given amount. Unless the sweeper attaches further
+// inputs to it, its fee is paid from the HTLC's value.
+func secondLevelTxFee(htlcAmt ltcutil.Amount, tx *wire.MsgTx) ltcutil.Amount {
+	fee := htlcAmt
+	for _, txOut := range tx.TxOut {
+		fee -= ltcutil.Amount(txOut.Value)
+	}
+
+	return fee
+}
+
 // getCommitTxConfHeight waits for confirmation of the commitment tx and
 // returns the confirmation height.
 func (c *commitSweepResolver) getCommitTxConfHeight() (uint32, error) {
@@ -200,9 +280,15 @@ func (c *commitSweepResolver) Resolve() (ContractResolver, error) {
 		return nil, err
 	}
 
+	isLocalCommitTx, err := c.isLocalCommitTx()
+	if err != nil {
+		return nil, err
+	}
+	maturityDelay := c.maturityDelay(isLocalCommitTx)
+
 	// Wait up until the CSV expires, unless we also have a CLTV that
 	// expires after.
-	unlockHeight := confHeight + c.commitResolution.MaturityDelay
+	unlockHeight := confHeight + maturityDelay
 	if c.hasCLTV() {
 		unlockHeight = uint32(math.Max(
 			float64(unlockHeight), float64(c.leaseExpiry),
@@ -218,14 +304,14 @@ func (c *commitSweepResolver) Resolve() (ContractResolver, error) {
 	c.reportLock.Unlock()
 
 	// If there is a csv/cltv lock, we'll wait for that.
-	if c.commitResolution.MaturityDelay > 0 || c.hasCLTV() {
+	if maturityDelay > 0 || c.hasCLTV() {
 		// Determine what height we should wait until for the locks to
 		// expire.
 		var waitHeight uint32
 		switch {
 		// If we have both a csv and cltv lock, we'll need to look at
 		// both and see which expires later.
-		case c.commitResolution.MaturityDelay > 0 && c.hasCLTV():
+		case maturityDelay > 0 && c.hasCLTV():
 			c.log.Debugf("waiting for CSV and CLTV lock to expire "+
 				"at height %v", unlockHeight)
 			// If the CSV expires after the CLTV, or there is no
@@ -238,7 +324,7 @@ func (c *commitSweepResolver) Resolve() (ContractResolver, error) {
 
 		// If we only have a csv lock, wait for the height before the
 		// lock expires as the spend path should be unlocked by then.
-		case c.commitResolution.MaturityDelay > 0:
+		case maturityDelay > 0:
 			c.log.Debugf("waiting for CSV lock to expire at "+
 				"height %v", unlockHeight)
 			waitHeight = unlockHeight - 1
@@ -250,31 +336,7 @@ func (c *commitSweepResolver) Resolve() (ContractResolver, error) {
 		}
 	}
 
-	var (
-		isLocalCommitTx bool
-
-		signDesc = c.commitResolution.SelfOutputSignDesc
-	)
-	switch {
-	// For taproot channels, we'll know if this is the local commit based
-	// on the witness script. For local channels, the witness script has an
-	// OP_DROP value.
-	//
-	// TODO(roasbeef): revisit this after the script changes
-	//  * otherwise need to base off the key in script or the CSV value
-	//  (script num encode)
-	case c.chanType.IsTaproot():
-		scriptLen := len(signDesc.WitnessScript)
-		isLocalCommitTx = signDesc.WitnessScript[scriptLen-1] ==
-			txscript.OP_DROP
-
-	// The output is on our local commitment if the script starts with
-	// OP_IF for the revocation clause. On the remote commitment it will
-	// either be a regular P2WKH or a simple sig spend with a CSV delay.
-	default:
-		isLocalCommitTx = signDesc.WitnessScript[0] == txscript.OP_IF
-	}
-	isDelayedOutput := c.commitResolution.MaturityDelay != 0
+	isDelayedOutput := maturityDelay != 0
 
 	c.log.Debugf("isDelayedOutput=%v, isLocalCommitTx=%v", isDelayedOutput,
 		isLocalCommitTx)
@@ -333,14 +395,14 @@ func (c *commitSweepResolver) Resolve() (ContractResolver, error) {
 		inp = input.NewCsvInputWithCltv(
 			&c.commitResolution.SelfOutPoint, witnessType,
 			&c.commitResolution.SelfOutputSignDesc,
-			c.broadcastHeight, c.commitResolution.MaturityDelay,
+			c.broadcastHeight, maturityDelay,
 			c.leaseExpiry,
 		)
 	} else {
 		inp = input.NewCsvInput(
 			&c.commitResolution.SelfOutPoint, witnessType,
 			&c.commitResolution.SelfOutputSignDesc,
-			c.broadcastHeight, c.commitResolution.MaturityDelay,
+			c.broadcastHeight, maturityDelay,
 		)
 	}
 
@@ -352,14 +414,23 @@ func (c *commitSweepResolver) Resolve() (ContractResolver, error) {
 	c.log.Infof("sweeping commit output")
 
 	feePref := sweep.FeePreference{ConfTarget: commitOutputConfTarget}
-	resultChan, err := c.Sweeper.SweepInput(inp, sweep.Params{Fee: feePref})
+	outputValue := c.commitResolution.SelfOutputSignDesc.Output.Value
+	budget := calculateBudget(
+		ltcutil.Amount(outputValue), c.Budget.ToLocal,
+	)
+	resultChan, err := c.Sweeper.SweepInput(
+		inp, sweep.Params{Fee: feePref, Budget: budget},
+	)
 	if err != nil {
 		c.log.Errorf("unable to sweep input: %v", err)
 
 		return nil, err
 	}
 
-	var sweepTxID chainhash.Hash
+	var (
+		sweepTxID chainhash.Hash
+		fee       ltcutil.Amount
+	)
 
 	// Sweeper is going to join this input with other inputs if possible
 	// and publish the sweep tx. When the sweep tx confirms, it signals us
@@ -381,6 +452,8 @@ func (c *commitSweepResolver) Resolve() (ContractResolver, error) {
 		case nil:
 			c.log.Infof("local commitment output fully resolved by "+
 				"sweep tx: %v", sweepResult.Tx.TxHash())
+			fee = sweepResult.Fee
+
 		// Unknown errors.
 		default:
 			c.log.Errorf("unable to sweep input: %v",
@@ -407,6 +480,7 @@ func (c *commitSweepResolver) Resolve() (ContractResolver, error) {
 	report := c.currentReport.resolverReport(
 		&sweepTxID, channeldb.ResolverTypeCommit, outcome,
 	)
+	report.Fee = fee
 	c.resolved = true
 
 	// Checkpoint the resolver with a closure that will write the outcome
@@ -440,6 +514,64 @@ func (c *commitSweepResolver) SupplementState(state *channeldb.OpenChannel) {
 	}
 	c.channelInitiator = state.IsInitiator
 	c.chanType = state.ChanType
+	c.localChanCfg = state.LocalChanCfg
+}
+
+// isLocalCommitTx returns whether the output to sweep is on our local
+// commitment transaction.
+func (c *commitSweepResolver) isLocalCommitTx() (bool, error) {
+	signDesc := c.commitResolution.SelfOutputSignDesc
+
+	// For taproot channels, the to_local and to_remote leaf scripts both
+	// end with a CSV check followed by an OP_DROP, so we'll instead look
+	// at the key used to sign for the output. It's derived from our delay
+	// base point on our local commitment, and from our payment base point
+	// on the remote commitment.
+	if c.chanType.IsTaproot() {
+		signKey := signDesc.KeyDesc.PubKey
+		delayKey := c.localChanCfg.DelayBasePoint.PubKey
+		paymentKey := c.localChanCfg.PaymentBasePoint.PubKey
+
+		switch {
+		case signKey == nil:
+			return false, fmt.Errorf("missing sign key")
+
+		case delayKey != nil && signKey.IsEqual(delayKey):
+			return true, nil
+
+		case paymentKey != nil && signKey.IsEqual(paymentKey):
+			return false, nil
+
+		default:
+			return false, fmt.Errorf("unknown sign key %x",
+				signKey.SerializeCompressed())
+		}
+	}
+
+	// The output is on our local commitment if the script starts with
+	// OP_IF for the revocation clause. On the remote commitment it will
+	// either be a regular P2WKH or a simple sig spend with a CSV delay.
+	return signDesc.WitnessScript[0] == txscript.OP_IF, nil
+}
+
+// maturityDelay returns the relative time lock of the output to sweep. The
+// to_remote output of anchor and taproot channels is always encumbered by a 1
+// block CSV delay, so we enforce it even if the resolution doesn't carry it.
+// Otherwise we'd offer the sweeper an input that can't be swept immediately.
+func (c *commitSweepResolver) maturityDelay(isLocalCommitTx bool) uint32 {
+	delay := c.commitResolution.MaturityDelay
+	if isLocalCommitTx || delay != 0 {
+		return delay
+	}
+
+	if c.chanType.HasAnchors() || c.chanType.IsTaproot() {
+		c.log.Warnf("Missing CSV delay for to_remote output, "+
+			"using %v", input.ToRemoteConfirmedDelay)
+
+		return input.ToRemoteConfirmedDelay
+	}
+
+	return 0
 }
 
 // hasCLTV denotes whether the resolver must wait for an additional CLTV to
@@ -453,29 +585,27 @@ func (c *commitSweepResolver) hasCLTV() bool {
 //
 // NOTE: Part of the ContractResolver interface.
 func (c *commitSweepResolver) Encode(w io.Writer) error {
-	if err := encodeCommitResolution(w, &c.commitResolution); err != nil {
-		return err
-	}
+	return encodeResolverState(w, c.records()...)
+}
 
-	if err := binary.Write(w, endian, c.resolved); err != nil {
-		return err
+// records returns the TLV records of the fields of the resolver's state. The
+// records refer to the resolver's fields, so they are used for both encoding
+// and decoding.
+func (c *commitSweepResolver) records() []tlv.Record {
+	resolution := &c.commitResolution
+
+	return []tlv.Record{
+		encodedRecord(resolverResolutionType, func(w io.Writer) error {
+			return encodeCommitResolution(w, resolution)
+		}, func(r io.Reader) error {
+			return decodeCommitResolution(r, resolution)
+		}),
+		boolRecord(resolverResolvedType, &c.resolved),
+		tlv.MakePrimitiveRecord(
+			resolverBroadcastHeightType, &c.broadcastHeight,
+		),
+		outPointRecord(resolverChanPointType, &c.chanPoint),
 	}
-	if err := binary.Write(w, endian, c.broadcastHeight); err != nil {
-		return err
-	}
-	if _, err := w.Write(c.chanPoint.Hash[:]); err != nil {
-		return err
-	}
-	err := binary.Write(w, endian, c.chanPoint.Index)
-	if err != nil {
-		return err
-	}
-
-	// Previously a sweep tx was serialized at this point. Refactoring
-	// removed this, but keep in mind that this data may still be present in
-	// the database.
-
-	return nil
 }
 
 // newCommitSweepResolverFromReader attempts to decode an encoded
@@ -488,6 +618,26 @@ func newCommitSweepResolverFromReader(r io.Reader, resCfg ResolverConfig) (
 		contractResolverKit: *newContractResolverKit(resCfg),
 	}
 
+	if err := decodeResolverState(r, c.records()...); err != nil {
+		return nil, err
+	}
+
+	c.initLogger(c)
+	c.initReport()
+
+	return c, nil
+}
+
+// newCommitSweepResolverFromLegacyReader decodes a ContractResolver from the
+// passed Reader instance that was encoded by an older version, before the
+// fields of the resolver's state were stored as TLV records.
+func newCommitSweepResolverFromLegacyReader(r io.Reader,
+	resCfg ResolverConfig) (*commitSweepResolver, error) {
+
+	c := &commitSweepResolver{
+		contractResolverKit: *newContractResolverKit(resCfg),
+	}
+
 	if err := decodeCommitResolution(r, &c.commitResolution); err != nil {
 		return nil, err
 	}
@@ -7,13 +7,17 @@ import (
 	"github.com/ltcsuite/lnd/chainntnfs"
 	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/lnd/input"
+	"github.com/ltcsuite/lnd/keychain"
 	"github.com/ltcsuite/lnd/kvdb"
 	"github.com/ltcsuite/lnd/lntest/mock"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/lnwallet/chainfee"
 	"github.com/ltcsuite/lnd/sweep"
+	"github.com/ltcsuite/ltcd/btcec/v2"
 	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/txscript"
 	"github.com/ltcsuite/ltcd/wire"
+	"github.com/stretchr/testify/require"
 )
 
 type commitSweepResolverTestContext struct {
@@ -375,3 +379,197 @@ func TestCommitSweepResolverDelay(t *testing.T) {
 		}
 	}
 }
+
+// TestCommitSweepResolverTaprootLocalCommit tests that the resolver tells
+// whether the output of a taproot channel is on the local or the remote
+// commitment from its sign key, as both leaf scripts end with an OP_DROP.
+func TestCommitSweepResolverTaprootLocalCommit(t *testing.T) {
+	t.Parallel()
+
+	delayKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	paymentKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	otherKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	chanType := channeldb.SimpleTaprootFeatureBit
+	witnessScript := []byte{txscript.OP_CHECKSIG, txscript.OP_DROP}
+	localChanCfg := channeldb.ChannelConfig{
+		DelayBasePoint: keychain.KeyDescriptor{
+			PubKey: delayKey.PubKey(),
+		},
+		PaymentBasePoint: keychain.KeyDescriptor{
+			PubKey: paymentKey.PubKey(),
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		signKey     *btcec.PublicKey
+		localCommit bool
+		expectErr   bool
+	}{{
+		name:        "local commitment",
+		signKey:     delayKey.PubKey(),
+		localCommit: true,
+	}, {
+		name:        "remote commitment",
+		signKey:     paymentKey.PubKey(),
+		localCommit: false,
+	}, {
+		name:      "unknown key",
+		signKey:   otherKey.PubKey(),
+		expectErr: true,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			signDesc := input.SignDescriptor{
+				KeyDesc: keychain.KeyDescriptor{
+					PubKey: tc.signKey,
+				},
+				WitnessScript: witnessScript,
+			}
+			res := lnwallet.CommitOutputResolution{
+				SelfOutputSignDesc: signDesc,
+			}
+			resolver := &commitSweepResolver{
+				commitResolution: res,
+				chanType:         chanType,
+				localChanCfg:     localChanCfg,
+			}
+
+			isLocal, err := resolver.isLocalCommitTx()
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.localCommit, isLocal)
+		})
+	}
+}
+
+// TestCommitSweepResolverMaturityDelay tests that the to_remote output of
+// anchor and taproot channels is always swept with a 1 block CSV delay, even if
+// the resolution doesn't carry it.
+func TestCommitSweepResolverMaturityDelay(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		chanType      channeldb.ChannelType
+		localCommit   bool
+		maturityDelay uint32
+		expectedDelay uint32
+	}{{
+		name:          "legacy to_remote",
+		chanType:      channeldb.SingleFunderTweaklessBit,
+		expectedDelay: 0,
+	}, {
+		name: "anchor to_remote",
+		chanType: channeldb.SingleFunderTweaklessBit |
+			channeldb.AnchorOutputsBit,
+		expectedDelay: input.ToRemoteConfirmedDelay,
+	}, {
+		name: "taproot to_remote",
+		chanType: channeldb.SimpleTaprootFeatureBit |
+			channeldb.AnchorOutputsBit,
+		expectedDelay: input.ToRemoteConfirmedDelay,
+	}, {
+		name: "anchor to_remote with delay",
+		chanType: channeldb.SingleFunderTweaklessBit |
+			channeldb.AnchorOutputsBit,
+		maturityDelay: input.ToRemoteConfirmedDelay,
+		expectedDelay: input.ToRemoteConfirmedDelay,
+	}, {
+		name: "anchor to_local",
+		chanType: channeldb.SingleFunderTweaklessBit |
+			channeldb.AnchorOutputsBit,
+		localCommit:   true,
+		maturityDelay: 144,
+		expectedDelay: 144,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := lnwallet.CommitOutputResolution{
+				SelfOutputSignDesc: input.SignDescriptor{
+					Output: &wire.TxOut{},
+				},
+				MaturityDelay: tc.maturityDelay,
+			}
+			resolver := newCommitSweepResolver(
+				res, 0, wire.OutPoint{}, ResolverConfig{},
+			)
+			resolver.chanType = tc.chanType
+
+			require.Equal(
+				t, tc.expectedDelay,
+				resolver.maturityDelay(tc.localCommit),
+			)
+		})
+	}
+}
+
+// TestWaitForSweep asserts that waitForSweep keeps waiting for the spend on
+// regular sweep results, and returns errSweepAbandoned if the sweeper gives up
+// on the output.
+func TestWaitForSweep(t *testing.T) {
+	t.Parallel()
+
+	op := &wire.OutPoint{Index: 1}
+	quit := make(chan struct{})
+
+	notifier := &mock.ChainNotifier{
+		SpendChan: make(chan *chainntnfs.SpendDetail, 1),
+	}
+
+	// A sweep result that isn't an abandonment is ignored, and the spend
+	// is returned once it arrives.
+	sweepResult := make(chan sweep.Result, 1)
+	sweepResult <- sweep.Result{Err: sweep.ErrTooManyAttempts}
+
+	spend := &chainntnfs.SpendDetail{SpentOutPoint: op}
+	go func() {
+		notifier.SpendChan <- spend
+	}()
+
+	spendDetail, fee, err := waitForSweep(
+		op, nil, 0, sweepResult, notifier, quit,
+	)
+	require.NoError(t, err)
+	require.Equal(t, spend, spendDetail)
+	require.Zero(t, fee)
+
+	// The fee of our own sweep is returned along with the spend, even if
+	// the result of the sweeper arrives after it.
+	notifier.SpendChan <- spend
+
+	sweepResult = make(chan sweep.Result)
+	go func() {
+		sweepResult <- sweep.Result{Fee: 1000}
+	}()
+
+	spendDetail, fee, err = waitForSweep(
+		op, nil, 0, sweepResult, notifier, quit,
+	)
+	require.NoError(t, err)
+	require.Equal(t, spend, spendDetail)
+	require.Equal(t, ltcutil.Amount(1000), fee)
+
+	// An exhausted budget abandons the sweep.
+	sweepResult = make(chan sweep.Result, 1)
+	sweepResult <- sweep.Result{Err: sweep.ErrBudgetExhausted}
+
+	_, _, err = waitForSweep(op, nil, 0, sweepResult, notifier, quit)
+	require.ErrorIs(t, err, errSweepAbandoned)
+}
@@ -24,6 +24,11 @@ const (
 	// secondLevelConfTarget is the confirmation target we'll use when
 	// adding fees to our second-level HTLC transactions.
 	secondLevelConfTarget = 6
+
+	// htlcDeadlineDelta is the number of blocks before the expiry of an
+	// incoming HTLC by which we want our claim of it to be confirmed, as
+	// the remote party is able to time out the HTLC once it has expired.
+	htlcDeadlineDelta = 2
 )
 
 // ContractResolver is an interface which packages a state machine which is
@@ -129,4 +134,8 @@ var (
 	// errResolverShuttingDown is returned when the resolver stops
 	// progressing because it received the quit signal.
 	errResolverShuttingDown = errors.New("resolver shutting down")
+
+	// errSweepAbandoned is returned when the sweeper gave up on an output
+	// because its budget doesn't cover the fee of sweeping it.
+	errSweepAbandoned = errors.New("sweep abandoned")
 )
@@ -0,0 +1,46 @@
+package contractcourt
+
+import "time"
+
+const (
+	// DefaultForceCloseDelay is the default time we wait for an offline
+	// peer to come back online before going to chain for its HTLCs. The
+	// default of zero disables the delay.
+	DefaultForceCloseDelay = 0
+
+	// DefaultForceCloseDelayMaxBlocks is the default number of blocks a
+	// force close may be delayed past the height at which the HTLCs would
+	// make us go to chain. It is kept well below the default incoming
+	// broadcast delta, so enough blocks are left to claim incoming HTLCs
+	// on chain.
+	DefaultForceCloseDelayMaxBlocks = 3
+)
+
+// ForceCloseDelayConfig defines how long a channel arbitrator waits for an
+// offline peer to come back online before going to chain because of pending
+// HTLCs. This gives the peer a chance to resolve the HTLCs off-chain, e.g.
+// after a brief connection loss.
+type ForceCloseDelayConfig struct {
+	// Delay is the time we wait for a peer to come back online. A value
+	// of zero disables the delay, unless overridden for the peer.
+	Delay time.Duration
+
+	// PeerDelays overrides the delay for specific peers, keyed by their
+	// compressed public key.
+	PeerDelays map[[33]byte]time.Duration
+
+	// MaxBlocks is the maximum number of blocks a force close may be
+	// delayed past the height at which an HTLC would make us go to chain,
+	// which is its expiry minus the incoming or outgoing broadcast delta.
+	// Once any HTLC reaches this height, we go to chain right away.
+	MaxBlocks uint32
+}
+
+// delayFor returns the force close delay that applies to the given peer.
+func (f *ForceCloseDelayConfig) delayFor(peer [33]byte) time.Duration {
+	if delay, ok := f.PeerDelays[peer]; ok {
+		return delay
+	}
+
+	return f.Delay
+}
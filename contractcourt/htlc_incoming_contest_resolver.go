@@ -15,6 +15,7 @@ import (
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/queue"
+	"github.com/ltcsuite/lnd/tlv"
 	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/txscript"
 )
@@ -476,13 +477,17 @@ func (h *htlcIncomingContestResolver) IsResolved() bool {
 //
 // NOTE: Part of the ContractResolver interface.
 func (h *htlcIncomingContestResolver) Encode(w io.Writer) error {
-	// We'll first write out the one field unique to this resolver.
-	if err := binary.Write(w, endian, h.htlcExpiry); err != nil {
-		return err
-	}
+	return encodeResolverState(w, h.records()...)
+}
 
-	// Then we'll write out our internal resolver.
-	return h.htlcSuccessResolver.Encode(w)
+// records returns the TLV records of the fields of the resolver's state, which
+// are the fields of the internal resolver and the one field unique to this
+// resolver.
+func (h *htlcIncomingContestResolver) records() []tlv.Record {
+	return append(
+		h.htlcSuccessResolver.records(),
+		tlv.MakePrimitiveRecord(resolverHtlcExpiryType, &h.htlcExpiry),
+	)
 }
 
 // newIncomingContestResolverFromReader attempts to decode an encoded ContractResolver
@@ -491,6 +496,27 @@ func (h *htlcIncomingContestResolver) Encode(w io.Writer) error {
 func newIncomingContestResolverFromReader(r io.Reader, resCfg ResolverConfig) (
 	*htlcIncomingContestResolver, error) {
 
+	h := &htlcIncomingContestResolver{
+		htlcSuccessResolver: &htlcSuccessResolver{
+			contractResolverKit: *newContractResolverKit(resCfg),
+		},
+	}
+
+	if err := decodeResolverState(r, h.records()...); err != nil {
+		return nil, err
+	}
+
+	h.htlcSuccessResolver.initReport()
+
+	return h, nil
+}
+
+// newIncomingContestResolverFromLegacyReader decodes a ContractResolver from
+// the passed Reader instance that was encoded by an older version, before the
+// fields of the resolver's state were stored as TLV records.
+func newIncomingContestResolverFromLegacyReader(r io.Reader,
+	resCfg ResolverConfig) (*htlcIncomingContestResolver, error) {
+
 	h := &htlcIncomingContestResolver{}
 
 	// We'll first read the one field unique to this resolver.
@@ -499,7 +525,7 @@ func newIncomingContestResolverFromReader(r io.Reader, resCfg ResolverConfig) (
 	}
 
 	// Then we'll decode our internal resolver.
-	successResolver, err := newSuccessResolverFromReader(r, resCfg)
+	successResolver, err := newSuccessResolverFromLegacyReader(r, resCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -520,9 +546,20 @@ func (h *htlcIncomingContestResolver) Supplement(htlc channeldb.HTLC) {
 func (h *htlcIncomingContestResolver) decodePayload() (*hop.Payload,
 	[]byte, error) {
 
+	// The blinding point of HTLCs that are relayed within a blinded
+	// route is needed to process the onion.
+	blindingPoint, err := h.htlc.BlindingPoint()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	onionReader := bytes.NewReader(h.htlc.OnionBlob[:])
 	iterator, err := h.OnionProcessor.ReconstructHopIterator(
-		onionReader, h.htlc.RHash[:],
+		onionReader, h.htlc.RHash[:], hop.ReconstructBlindingInfo{
+			BlindingKey:    blindingPoint,
+			IncomingAmt:    h.htlc.Amt,
+			IncomingExpiry: h.htlc.RefundTimeout,
+		},
 	)
 	if err != nil {
 		return nil, nil, err
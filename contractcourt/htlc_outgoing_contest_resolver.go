@@ -211,6 +211,21 @@ func newOutgoingContestResolverFromReader(r io.Reader, resCfg ResolverConfig) (
 	return h, nil
 }
 
+// newOutgoingContestResolverFromLegacyReader decodes a ContractResolver from
+// the passed Reader instance that was encoded by an older version, before the
+// fields of the resolver's state were stored as TLV records.
+func newOutgoingContestResolverFromLegacyReader(r io.Reader,
+	resCfg ResolverConfig) (*htlcOutgoingContestResolver, error) {
+
+	h := &htlcOutgoingContestResolver{}
+	timeoutResolver, err := newTimeoutResolverFromLegacyReader(r, resCfg)
+	if err != nil {
+		return nil, err
+	}
+	h.htlcTimeoutResolver = timeoutResolver
+	return h, nil
+}
+
 // A compile time assertion to ensure htlcOutgoingContestResolver meets the
 // ContractResolver interface.
 var _ htlcContractResolver = (*htlcOutgoingContestResolver)(nil)
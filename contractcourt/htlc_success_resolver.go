@@ -2,6 +2,8 @@ package contractcourt
 
 import (
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"io"
 	"sync"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/ltcsuite/lnd/lnutils"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/sweep"
+	"github.com/ltcsuite/lnd/tlv"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
 	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/txscript"
@@ -23,8 +26,8 @@ import (
 // htlcSuccessResolver is a resolver that's capable of sweeping an incoming
 // HTLC output on-chain. If this is the remote party's commitment, we'll sweep
 // it directly from the commitment output *immediately*. If this is our
-// commitment, we'll first broadcast the success transaction, then send it to
-// the incubator for sweeping. That's it, no need to send any clean up
+// commitment, we'll first broadcast the success transaction, then sweep its
+// output once the CSV lock has expired. That's it, no need to send any clean up
 // messages.
 //
 // TODO(roasbeef): don't need to broadcast?
@@ -33,18 +36,23 @@ type htlcSuccessResolver struct {
 	// contains everything we need to properly resolve this HTLC.
 	htlcResolution lnwallet.IncomingHtlcResolution
 
-	// outputIncubating returns true if we've sent the output to the output
-	// incubator (utxo nursery). In case the htlcResolution has non-nil
-	// SignDetails, it means we will let the Sweeper handle broadcasting
-	// the secondd-level transaction, and sweeping its output. In this case
-	// we let this field indicate whether we need to broadcast the
-	// second-level tx (false) or if it has confirmed and we must sweep the
-	// second-level output (true).
+	// outputIncubating returns true if the second-level transaction has
+	// been handled. In case the htlcResolution has non-nil SignDetails, it
+	// means we will let the Sweeper handle broadcasting the second-level
+	// transaction. In this case we let this field indicate whether we need
+	// to broadcast the second-level tx (false) or if it has confirmed and
+	// we must sweep the second-level output (true). Otherwise, it is set
+	// once we've published the second-level transaction ourselves.
 	outputIncubating bool
 
 	// resolved reflects if the contract has been fully resolved or not.
 	resolved bool
 
+	// commitSpendFee is the fee we paid for the second-level transaction
+	// if the sweeper re-signed it and attached fees to it. It is only
+	// known once that transaction has confirmed.
+	commitSpendFee ltcutil.Amount
+
 	// broadcastHeight is the height that the original contract was
 	// broadcast to the main-chain at. We'll use this value to bound any
 	// historical queries to the chain for spends/confirmations.
@@ -61,9 +69,7 @@ type htlcSuccessResolver struct {
 	htlc channeldb.HTLC
 
 	// currentReport stores the current state of the resolver for reporting
-	// over the rpc interface. This should only be reported in case we have
-	// a non-nil SignDetails on the htlcResolution, otherwise the nursery
-	// will produce reports.
+	// over the rpc interface.
 	currentReport ContractReport
 
 	// reportLock prevents concurrent access to the resolver report.
@@ -113,8 +119,8 @@ func (h *htlcSuccessResolver) ResolverKey() []byte {
 
 // Resolve attempts to resolve an unresolved incoming HTLC that we know the
 // preimage to. If the HTLC is on the commitment of the remote party, then we'll
-// simply sweep it directly. Otherwise, we'll hand this off to the utxo nursery
-// to do its duty. There is no need to make a call to the invoice registry
+// simply sweep it directly. Otherwise, we'll go through the second-level
+// success transaction and sweep its output. There is no need to make a call to the invoice registry
 // anymore. Every HTLC has already passed through the incoming contest resolver
 // and in there the invoice was already marked as settled.
 //
@@ -135,7 +141,14 @@ func (h *htlcSuccessResolver) Resolve() (ContractResolver, error) {
 
 	// Otherwise this an output on our own commitment, and we must start by
 	// broadcasting the second-level success transaction.
-	secondLevelOutpoint, err := h.broadcastSuccessTx()
+	secondLevelOutpoint, sweepResult, err := h.broadcastSuccessTx()
+	if errors.Is(err, errSweepAbandoned) {
+		successTx := h.htlcResolution.SignedSuccessTx
+
+		return nil, h.abandonHtlcOutput(
+			successTx.TxIn[0].PreviousOutPoint,
+		)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -145,11 +158,24 @@ func (h *htlcSuccessResolver) Resolve() (ContractResolver, error) {
 	log.Infof("%T(%x): waiting for second-level HTLC output to be spent "+
 		"after csv_delay=%v", h, h.htlc.RHash[:], h.htlcResolution.CsvDelay)
 
-	spend, err := waitForSpend(
+	spend, fee, err := waitForSweep(
 		secondLevelOutpoint,
 		h.htlcResolution.SweepSignDesc.Output.PkScript,
-		h.broadcastHeight, h.Notifier, h.quit,
+		h.broadcastHeight, sweepResult, h.Notifier, h.quit,
 	)
+	if errors.Is(err, errSweepAbandoned) {
+		log.Warnf("%T(%x): abandoning uneconomical second-level "+
+			"output %v", h, h.htlc.RHash[:], secondLevelOutpoint)
+
+		h.reportLock.Lock()
+		h.currentReport.LimboBalance = 0
+		h.reportLock.Unlock()
+
+		h.resolved = true
+		return nil, h.checkpointClaim(
+			nil, 0, channeldb.ResolverOutcomeAbandoned,
+		)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -161,15 +187,18 @@ func (h *htlcSuccessResolver) Resolve() (ContractResolver, error) {
 
 	h.resolved = true
 	return nil, h.checkpointClaim(
-		spend.SpenderTxHash, channeldb.ResolverOutcomeClaimed,
+		spend.SpenderTxHash, fee, channeldb.ResolverOutcomeClaimed,
 	)
 }
 
 // broadcastSuccessTx handles an HTLC output on our local commitment by
 // broadcasting the second-level success transaction. It returns the ultimate
 // outpoint of the second-level tx, that we must wait to be spent for the
-// resolver to be fully resolved.
-func (h *htlcSuccessResolver) broadcastSuccessTx() (*wire.OutPoint, error) {
+// resolver to be fully resolved, and the sweeper's result channel for it if
+// the sweeper handles the output.
+func (h *htlcSuccessResolver) broadcastSuccessTx() (*wire.OutPoint,
+	chan sweep.Result, error) {
+
 	// If we have non-nil SignDetails, this means that have a 2nd level
 	// HTLC transaction that is signed using sighash SINGLE|ANYONECANPAY
 	// (the case for anchor type channels). In this case we can re-sign it
@@ -181,53 +210,39 @@ func (h *htlcSuccessResolver) broadcastSuccessTx() (*wire.OutPoint, error) {
 	}
 
 	// Otherwise we'll publish the second-level transaction directly and
-	// offer the resolution to the nursery to handle.
+	// sweep its output ourselves once it matures.
 	log.Infof("%T(%x): broadcasting second-layer transition tx: %v",
 		h, h.htlc.RHash[:], spew.Sdump(h.htlcResolution.SignedSuccessTx))
 
 	// We'll now broadcast the second layer transaction so we can kick off
 	// the claiming process.
-	//
-	// TODO(roasbeef): after changing sighashes send to tx bundler
 	label := labels.MakeLabel(
 		labels.LabelTypeChannelClose, &h.ShortChanID,
 	)
 	err := h.PublishTx(h.htlcResolution.SignedSuccessTx, label)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Otherwise, this is an output on our commitment transaction. In this
-	// case, we'll send it to the incubator, but only if we haven't already
-	// done so.
+	// Mark the second-level transaction as published, but only if we
+	// haven't already done so.
 	if !h.outputIncubating {
-		log.Infof("%T(%x): incubating incoming htlc output",
-			h, h.htlc.RHash[:])
-
-		err := h.IncubateOutputs(
-			h.ChanPoint, nil, &h.htlcResolution,
-			h.broadcastHeight,
-		)
-		if err != nil {
-			return nil, err
-		}
-
 		h.outputIncubating = true
 
 		if err := h.Checkpoint(h); err != nil {
 			log.Errorf("unable to Checkpoint: %v", err)
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
-	return &h.htlcResolution.ClaimOutpoint, nil
+	return h.sweepSecondLevelOutput(nil)
 }
 
 // broadcastReSignedSuccessTx handles the case where we have non-nil
 // SignDetails, and offers the second level transaction to the Sweeper, that
 // will re-sign it and attach fees at will.
 func (h *htlcSuccessResolver) broadcastReSignedSuccessTx() (
-	*wire.OutPoint, error) {
+	*wire.OutPoint, chan sweep.Result, error) {
 
 	// Keep track of the tx spending the HTLC output on the commitment, as
 	// this will be the confirmed second-level tx we'll ultimately sweep.
@@ -260,58 +275,103 @@ func (h *htlcSuccessResolver) broadcastReSignedSuccessTx() (
 			)
 		}
 
-		_, err := h.Sweeper.SweepInput(
+		// We'll also pass the HTLC's deadline to the sweeper, so the
+		// fee rate is raised as the expiry of the HTLC approaches.
+		abandon := h.Budget.AbandonUneconomicalHTLCs
+		firstLevelSweep, err := h.Sweeper.SweepInput(
 			&secondLevelInput,
 			sweep.Params{
 				Fee: sweep.FeePreference{
 					ConfTarget: secondLevelConfTarget,
 				},
+				DeadlineHeight:      h.deadlineHeight(),
+				Budget:              h.budget(),
+				AbandonUneconomical: abandon,
 			},
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		log.Infof("%T(%x): waiting for second-level HTLC success "+
 			"transaction to confirm", h, h.htlc.RHash[:])
 
-		// Wait for the second level transaction to confirm.
-		commitSpend, err = waitForSpend(
+		// Wait for the second level transaction to confirm, unless the
+		// sweeper gives up on the HTLC output.
+		var fee ltcutil.Amount
+		commitSpend, fee, err = waitForSweep(
 			&h.htlcResolution.SignedSuccessTx.TxIn[0].PreviousOutPoint,
 			h.htlcResolution.SignDetails.SignDesc.Output.PkScript,
-			h.broadcastHeight, h.Notifier, h.quit,
+			h.broadcastHeight, firstLevelSweep, h.Notifier, h.quit,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Now that the second-level transaction has confirmed, we
 		// checkpoint the state so we'll go to the next stage in case
-		// of restarts.
+		// of restarts. The fee we paid for it is stored as well, as we
+		// only report it once the HTLC is fully resolved.
 		h.outputIncubating = true
+		h.commitSpendFee = fee
 		if err := h.Checkpoint(h); err != nil {
 			log.Errorf("unable to Checkpoint: %v", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		log.Infof("%T(%x): second-level HTLC success transaction "+
 			"confirmed!", h, h.htlc.RHash[:])
 	}
 
+	return h.sweepSecondLevelOutput(commitSpend)
+}
+
+// htlcOutputScript returns the pkScript of the HTLC output on the commitment
+// transaction that the second-level success transaction spends.
+func (h *htlcSuccessResolver) htlcOutputScript() ([]byte, error) {
+	if h.htlcResolution.SignDetails != nil {
+		return h.htlcResolution.SignDetails.SignDesc.Output.PkScript, nil
+	}
+
+	// Without sign details, this is a legacy channel and the witness
+	// script is the last element of the success transaction's witness.
+	witness := h.htlcResolution.SignedSuccessTx.TxIn[0].Witness
+	if len(witness) == 0 {
+		return nil, fmt.Errorf("success tx has no witness")
+	}
+
+	return input.WitnessScriptHash(witness[len(witness)-1])
+}
+
+// sweepSecondLevelOutput waits for the second-level success transaction to
+// confirm and its CSV lock to expire, then offers its output to the sweeper.
+// If commitSpend is nil, the spend of the HTLC output on the commitment is
+// fetched from the notifier.
+func (h *htlcSuccessResolver) sweepSecondLevelOutput(
+	commitSpend *chainntnfs.SpendDetail) (*wire.OutPoint, chan sweep.Result,
+	error) {
+
 	// If we ended up here after a restart, we must again get the
 	// spend notification.
 	if commitSpend == nil {
-		var err error
+		pkScript, err := h.htlcOutputScript()
+		if err != nil {
+			return nil, nil, err
+		}
+
 		commitSpend, err = waitForSpend(
 			&h.htlcResolution.SignedSuccessTx.TxIn[0].PreviousOutPoint,
-			h.htlcResolution.SignDetails.SignDesc.Output.PkScript,
-			h.broadcastHeight, h.Notifier, h.quit,
+			pkScript, h.broadcastHeight, h.Notifier, h.quit,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
+	isTaproot := txscript.IsPayToTaproot(
+		h.htlcResolution.SweepSignDesc.Output.PkScript,
+	)
+
 	// The HTLC success tx has a CSV lock that we must wait for, and if
 	// this is a lease enforced channel and we're the imitator, we may need
 	// to wait for longer.
@@ -339,7 +399,7 @@ func (h *htlcSuccessResolver) broadcastReSignedSuccessTx() (
 
 	err := waitForHeight(waitHeight, h.Notifier, h.quit)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// We'll use this input index to determine the second-level output
@@ -372,21 +432,39 @@ func (h *htlcSuccessResolver) broadcastReSignedSuccessTx() (
 		h.htlc.RHash,
 	)
 	// TODO(roasbeef): need to update above for leased types
-	_, err = h.Sweeper.SweepInput(
+	sweepResult, err := h.Sweeper.SweepInput(
 		inp,
 		sweep.Params{
 			Fee: sweep.FeePreference{
 				ConfTarget: sweepConfTarget,
 			},
+			Budget:              h.budget(),
+			AbandonUneconomical: h.Budget.AbandonUneconomicalHTLCs,
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Will return this outpoint, when this is spent the resolver is fully
 	// resolved.
-	return op, nil
+	return op, sweepResult, nil
+}
+
+// deadlineHeight returns the height by which the claim of the HTLC must be
+// confirmed, which is its CLTV expiry minus htlcDeadlineDelta. Zero is
+// returned if the HTLC's expiry is unknown.
+func (h *htlcSuccessResolver) deadlineHeight() int32 {
+	if h.htlc.RefundTimeout <= htlcDeadlineDelta {
+		return 0
+	}
+
+	return int32(h.htlc.RefundTimeout - htlcDeadlineDelta)
+}
+
+// budget returns the maximum fee that may be spent on claiming the HTLC.
+func (h *htlcSuccessResolver) budget() ltcutil.Amount {
+	return calculateBudget(h.htlc.Amt.ToSatoshis(), h.Budget.HTLC)
 }
 
 // resolveRemoteCommitOutput handles sweeping an HTLC output on the remote
@@ -428,6 +506,31 @@ func (h *htlcSuccessResolver) resolveRemoteCommitOutput() (
 			))
 		}
 
+		// As we sweep the output ourselves, we also need to check
+		// whether it's worth sweeping at all.
+		if h.Budget.AbandonUneconomicalHTLCs {
+			uneconomical, err := h.isUneconomical(inp)
+			if err != nil {
+				return nil, err
+			}
+
+			if uneconomical {
+				return nil, h.abandonHtlcOutput(
+					h.htlcResolution.ClaimOutpoint,
+				)
+			}
+		}
+
+		// The sweep transaction isn't handed to the sweeper, so we'll
+		// apply the HTLC's deadline to its fee preference ourselves.
+		_, currentHeight, err := h.ChainIO.GetBestBlock()
+		if err != nil {
+			return nil, err
+		}
+		feePref := sweep.FeePreference{
+			ConfTarget: sweepConfTarget,
+		}.WithDeadline(h.deadlineHeight(), currentHeight)
+
 		// With the input created, we can now generate the full sweep
 		// transaction, that we'll use to move these coins back into
 		// the backing wallet.
@@ -437,12 +540,8 @@ func (h *htlcSuccessResolver) resolveRemoteCommitOutput() (
 		// complete.
 		//
 		// TODO: Use time-based sweeper and result chan.
-		var err error
 		h.sweepTx, err = h.Sweeper.CreateSweepTx(
-			[]input.Input{inp},
-			sweep.FeePreference{
-				ConfTarget: sweepConfTarget,
-			}, 0,
+			[]input.Input{inp}, feePref, 0,
 		)
 		if err != nil {
 			return nil, err
@@ -498,18 +597,87 @@ func (h *htlcSuccessResolver) resolveRemoteCommitOutput() (
 	// confirmations, we'll mark ourselves as fully resolved and exit.
 	h.resolved = true
 
+	// The sweep tx only spends the HTLC output, so its fee is what's left
+	// of the output's value.
+	fee := h.htlcResolution.SweepSignDesc.Output.Value
+	for _, txOut := range h.sweepTx.TxOut {
+		fee -= txOut.Value
+	}
+
 	// Checkpoint the resolver, and write the outcome to disk.
 	return nil, h.checkpointClaim(
-		&sweepTXID,
+		&sweepTXID, ltcutil.Amount(fee),
 		channeldb.ResolverOutcomeClaimed,
 	)
 }
 
+// isUneconomical returns true if the budget of the HTLC doesn't cover the fee
+// of sweeping the given input at the minimum relay fee rate.
+func (h *htlcSuccessResolver) isUneconomical(inp input.Input) (bool, error) {
+	budget := h.budget()
+	if budget == 0 {
+		return false, nil
+	}
+
+	var estimator input.TxWeightEstimator
+	err := inp.WitnessType().AddWeightEstimation(&estimator)
+	if err != nil {
+		return false, err
+	}
+
+	weight := int64(estimator.Weight())
+	minFee := h.Sweeper.RelayFeePerKW().FeeForWeight(weight)
+
+	return minFee > budget, nil
+}
+
+// abandonHtlcOutput marks the contract as resolved after giving up on the
+// given HTLC output on the commitment, as its value doesn't cover the fee of
+// sweeping it. As we won't claim the HTLC on chain anymore, the remote party
+// will eventually time it out.
+func (h *htlcSuccessResolver) abandonHtlcOutput(op wire.OutPoint) error {
+	log.Warnf("%T(%x): abandoning uneconomical htlc output %v", h,
+		h.htlc.RHash[:], op)
+
+	err := h.ChainArbitratorConfig.PutFinalHtlcOutcome(
+		h.ChannelArbitratorConfig.ShortChanID, h.htlc.HtlcIndex, false,
+	)
+	if err != nil {
+		return err
+	}
+
+	h.ChainArbitratorConfig.HtlcNotifier.NotifyFinalHtlcEvent(
+		models.CircuitKey{
+			ChanID: h.ShortChanID,
+			HtlcID: h.htlc.HtlcIndex,
+		},
+		channeldb.FinalHtlcInfo{
+			Settled:  false,
+			Offchain: false,
+		},
+	)
+
+	h.resolved = true
+	h.reportLock.Lock()
+	h.currentReport.LimboBalance = 0
+	h.reportLock.Unlock()
+
+	report := &channeldb.ResolverReport{
+		OutPoint:        op,
+		Amount:          h.htlc.Amt.ToSatoshis(),
+		ResolverType:    channeldb.ResolverTypeIncomingHtlc,
+		ResolverOutcome: channeldb.ResolverOutcomeAbandoned,
+	}
+
+	return h.Checkpoint(h, report)
+}
+
 // checkpointClaim checkpoints the success resolver with the reports it needs.
 // If this htlc was claimed two stages, it will write reports for both stages,
-// otherwise it will just write for the single htlc claim.
+// otherwise it will just write for the single htlc claim. The fee is the one
+// paid by spendTx.
 func (h *htlcSuccessResolver) checkpointClaim(spendTx *chainhash.Hash,
-	outcome channeldb.ResolverOutcome) error {
+	fee ltcutil.Amount, outcome channeldb.ResolverOutcome) error {
 
 	// Mark the htlc as final settled.
 	err := h.ChainArbitratorConfig.PutFinalHtlcOutcome(
@@ -540,6 +708,7 @@ func (h *htlcSuccessResolver) checkpointClaim(spendTx *chainhash.Hash,
 			ResolverType:    channeldb.ResolverTypeIncomingHtlc,
 			ResolverOutcome: outcome,
 			SpendTxID:       spendTx,
+			Fee:             fee,
 		},
 	}
 
@@ -552,12 +721,20 @@ func (h *htlcSuccessResolver) checkpointClaim(spendTx *chainhash.Hash,
 		spendTx := h.htlcResolution.SignedSuccessTx
 		spendTxID := spendTx.TxHash()
 
+		// If the sweeper re-signed the transaction, it attached the
+		// fee.
+		fee := h.commitSpendFee
+		if h.htlcResolution.SignDetails == nil {
+			fee = secondLevelTxFee(h.htlc.Amt.ToSatoshis(), spendTx)
+		}
+
 		report := &channeldb.ResolverReport{
 			OutPoint:        spendTx.TxIn[0].PreviousOutPoint,
 			Amount:          h.htlc.Amt.ToSatoshis(),
 			ResolverType:    channeldb.ResolverTypeIncomingHtlc,
 			ResolverOutcome: channeldb.ResolverOutcomeFirstStage,
 			SpendTxID:       &spendTxID,
+			Fee:             fee,
 		}
 		reports = append(reports, report)
 	}
@@ -584,12 +761,6 @@ func (h *htlcSuccessResolver) IsResolved() bool {
 
 // report returns a report on the resolution state of the contract.
 func (h *htlcSuccessResolver) report() *ContractReport {
-	// If the sign details are nil, the report will be created by handled
-	// by the nursery.
-	if h.htlcResolution.SignDetails == nil {
-		return nil
-	}
-
 	h.reportLock.Lock()
 	defer h.reportLock.Unlock()
 	cpy := h.currentReport
@@ -597,8 +768,7 @@ func (h *htlcSuccessResolver) report() *ContractReport {
 }
 
 func (h *htlcSuccessResolver) initReport() {
-	// We create the initial report. This will only be reported for
-	// resolvers not handled by the nursery.
+	// We create the initial report.
 	finalAmt := h.htlc.Amt.ToSatoshis()
 	if h.htlcResolution.SignedSuccessTx != nil {
 		finalAmt = ltcutil.Amount(
@@ -621,33 +791,32 @@ func (h *htlcSuccessResolver) initReport() {
 //
 // NOTE: Part of the ContractResolver interface.
 func (h *htlcSuccessResolver) Encode(w io.Writer) error {
-	// First we'll encode our inner HTLC resolution.
-	if err := encodeIncomingResolution(w, &h.htlcResolution); err != nil {
-		return err
-	}
-
-	// Next, we'll write out the fields that are specified to the contract
-	// resolver.
-	if err := binary.Write(w, endian, h.outputIncubating); err != nil {
-		return err
-	}
-	if err := binary.Write(w, endian, h.resolved); err != nil {
-		return err
-	}
-	if err := binary.Write(w, endian, h.broadcastHeight); err != nil {
-		return err
-	}
-	if _, err := w.Write(h.htlc.RHash[:]); err != nil {
-		return err
-	}
+	return encodeResolverState(w, h.records()...)
+}
 
-	// We encode the sign details last for backwards compatibility.
-	err := encodeSignDetails(w, h.htlcResolution.SignDetails)
-	if err != nil {
-		return err
+// records returns the TLV records of the fields of the resolver's state. The
+// records refer to the resolver's fields, so they are used for both encoding
+// and decoding.
+func (h *htlcSuccessResolver) records() []tlv.Record {
+	resolution := &h.htlcResolution
+
+	return []tlv.Record{
+		encodedRecord(resolverResolutionType, func(w io.Writer) error {
+			return encodeIncomingResolution(w, resolution)
+		}, func(r io.Reader) error {
+			return decodeIncomingResolution(r, resolution)
+		}),
+		boolRecord(resolverIncubatingType, &h.outputIncubating),
+		boolRecord(resolverResolvedType, &h.resolved),
+		tlv.MakePrimitiveRecord(
+			resolverBroadcastHeightType, &h.broadcastHeight,
+		),
+		tlv.MakePrimitiveRecord(
+			resolverPaymentHashType, &h.htlc.RHash,
+		),
+		signDetailsRecord(&resolution.SignDetails),
+		amountRecord(resolverCommitSpendFeeType, &h.commitSpendFee),
 	}
-
-	return nil
 }
 
 // newSuccessResolverFromReader attempts to decode an encoded ContractResolver
@@ -660,6 +829,25 @@ func newSuccessResolverFromReader(r io.Reader, resCfg ResolverConfig) (
 		contractResolverKit: *newContractResolverKit(resCfg),
 	}
 
+	if err := decodeResolverState(r, h.records()...); err != nil {
+		return nil, err
+	}
+
+	h.initReport()
+
+	return h, nil
+}
+
+// newSuccessResolverFromLegacyReader decodes a ContractResolver from the
+// passed Reader instance that was encoded by an older version, before the
+// fields of the resolver's state were stored as TLV records.
+func newSuccessResolverFromLegacyReader(r io.Reader, resCfg ResolverConfig) (
+	*htlcSuccessResolver, error) {
+
+	h := &htlcSuccessResolver{
+		contractResolverKit: *newContractResolverKit(resCfg),
+	}
+
 	// First we'll decode our inner HTLC resolution.
 	if err := decodeIncomingResolution(r, &h.htlcResolution); err != nil {
 		return nil, err
@@ -18,6 +18,7 @@ import (
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
 	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/wire"
+	"github.com/stretchr/testify/require"
 )
 
 var testHtlcAmt = lnwire.MilliSatoshi(200000)
@@ -60,16 +61,12 @@ func newHtlcResolverTestContext(t *testing.T,
 	chainCfg := ChannelArbitratorConfig{
 		ChainArbitratorConfig: ChainArbitratorConfig{
 			Notifier:   notifier,
+			ChainIO:    &mock.ChainIO{},
 			PreimageDB: witnessBeacon,
 			PublishTx: func(_ *wire.MsgTx, _ string) error {
 				return nil
 			},
 			Sweeper: newMockSweeper(),
-			IncubateOutputs: func(wire.OutPoint, *lnwallet.OutgoingHtlcResolution,
-				*lnwallet.IncomingHtlcResolution, uint32) error {
-
-				return nil
-			},
 			DeliverResolutionMsg: func(msgs ...ResolutionMsg) error {
 				if len(msgs) != 1 {
 					return fmt.Errorf("expected 1 "+
@@ -169,6 +166,7 @@ func TestHtlcSuccessSingleStage(t *testing.T) {
 		ResolverType:    channeldb.ResolverTypeIncomingHtlc,
 		ResolverOutcome: channeldb.ResolverOutcomeClaimed,
 		SpendTxID:       &sweepTxid,
+		Fee:             ltcutil.Amount(testSignDesc.Output.Value),
 	}
 
 	checkpoints := []checkpoint{
@@ -209,7 +207,7 @@ func TestHtlcSuccessSingleStage(t *testing.T) {
 }
 
 // TestSecondStageResolution tests successful sweep of a second stage htlc
-// claim, going through the Nursery.
+// claim, where the success tx is published by the resolver itself.
 func TestHtlcSuccessSecondStageResolution(t *testing.T) {
 	commitOutpoint := wire.OutPoint{Index: 2}
 	htlcOutpoint := wire.OutPoint{Index: 3}
@@ -228,6 +226,9 @@ func TestHtlcSuccessSecondStageResolution(t *testing.T) {
 			TxIn: []*wire.TxIn{
 				{
 					PreviousOutPoint: commitOutpoint,
+					Witness: [][]byte{
+						{}, {}, {}, {}, {0xbb, 0xbb},
+					},
 				},
 			},
 			TxOut: []*wire.TxOut{
@@ -248,6 +249,7 @@ func TestHtlcSuccessSecondStageResolution(t *testing.T) {
 		ResolverType:    channeldb.ResolverTypeIncomingHtlc,
 		ResolverOutcome: channeldb.ResolverOutcomeFirstStage,
 		SpendTxID:       &successTx,
+		Fee:             testHtlcAmt.ToSatoshis() - 111,
 	}
 
 	secondStage := &channeldb.ResolverReport{
@@ -260,16 +262,36 @@ func TestHtlcSuccessSecondStageResolution(t *testing.T) {
 
 	checkpoints := []checkpoint{
 		{
-			// The resolver will send the output to the Nursery.
+			// The resolver will publish the success tx.
 			incubating: true,
 		},
 		{
-			// It will then wait for the Nursery to spend the
-			// output. We send a spend notification for our output
-			// to resolve our htlc.
+			// It will then wait for the success tx to confirm and
+			// its CSV lock to expire, before offering the output
+			// to the sweeper. We send a spend notification for
+			// our output to resolve our htlc.
 			preCheckpoint: func(ctx *htlcResolverTestContext,
 				_ bool) error {
 
+				ctx.notifier.SpendChan <- &chainntnfs.SpendDetail{
+					SpendingTx:     twoStageResolution.SignedSuccessTx,
+					SpenderTxHash:  &successTx,
+					SpendingHeight: 10,
+				}
+
+				ctx.notifier.EpochChan <- &chainntnfs.BlockEpoch{
+					Height: 10,
+				}
+
+				resolver := ctx.resolver.(*htlcSuccessResolver)
+				inp := <-resolver.Sweeper.(*mockSweeper).sweptInputs
+				op := inp.OutPoint()
+				exp := wire.OutPoint{Hash: successTx}
+				if *op != exp {
+					return fmt.Errorf("swept outpoint %v, "+
+						"expected %v", op, exp)
+				}
+
 				ctx.notifier.SpendChan <- &chainntnfs.SpendDetail{
 					SpendingTx:    sweepTx,
 					SpenderTxHash: &sweepHash,
@@ -634,3 +656,64 @@ func runFromCheckpoint(t *testing.T, ctx *htlcResolverTestContext,
 
 	return checkpointedState
 }
+
+// TestHtlcSuccessDeadlineHeight tests that the deadline passed to the sweeper
+// is derived from the HTLC's expiry.
+func TestHtlcSuccessDeadlineHeight(t *testing.T) {
+	t.Parallel()
+
+	resolver := &htlcSuccessResolver{
+		htlc: channeldb.HTLC{RefundTimeout: 500},
+	}
+	require.EqualValues(
+		t, 500-htlcDeadlineDelta, resolver.deadlineHeight(),
+	)
+
+	// Without a known expiry, there's no deadline.
+	resolver.htlc.RefundTimeout = 0
+	require.Zero(t, resolver.deadlineHeight())
+}
+
+// TestHtlcSuccessIsUneconomical tests that an HTLC output on the remote
+// commitment is only considered uneconomical if its budget doesn't cover the
+// fee of sweeping it at the relay fee rate.
+func TestHtlcSuccessIsUneconomical(t *testing.T) {
+	t.Parallel()
+
+	resolver := &htlcSuccessResolver{
+		contractResolverKit: *newContractResolverKit(ResolverConfig{
+			ChannelArbitratorConfig: ChannelArbitratorConfig{
+				ChainArbitratorConfig: ChainArbitratorConfig{
+					Sweeper: newMockSweeper(),
+					Budget: BudgetConfig{
+						HTLC: DefaultBudgetRatio,
+					},
+				},
+			},
+		}),
+	}
+
+	inp := input.MakeHtlcSucceedInput(
+		&wire.OutPoint{}, &testSignDesc, nil, 0, 0,
+	)
+
+	// Half of a 100 sat HTLC doesn't cover the fee at the relay fee rate.
+	resolver.htlc.Amt = lnwire.NewMSatFromSatoshis(100)
+	uneconomical, err := resolver.isUneconomical(&inp)
+	require.NoError(t, err)
+	require.True(t, uneconomical)
+
+	// Half of a 10k sat HTLC does.
+	resolver.htlc.Amt = lnwire.NewMSatFromSatoshis(10_000)
+	uneconomical, err = resolver.isUneconomical(&inp)
+	require.NoError(t, err)
+	require.False(t, uneconomical)
+
+	// Without a budget, the fee isn't limited, so no HTLC is
+	// uneconomical.
+	resolver.Budget.HTLC = 0
+	resolver.htlc.Amt = lnwire.NewMSatFromSatoshis(100)
+	uneconomical, err = resolver.isUneconomical(&inp)
+	require.NoError(t, err)
+	require.False(t, uneconomical)
+}
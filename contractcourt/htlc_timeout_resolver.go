@@ -2,6 +2,7 @@ package contractcourt
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"sync"
@@ -10,11 +11,13 @@ import (
 	"github.com/ltcsuite/lnd/chainntnfs"
 	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/lnd/input"
+	"github.com/ltcsuite/lnd/labels"
 	"github.com/ltcsuite/lnd/lntypes"
 	"github.com/ltcsuite/lnd/lnutils"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/sweep"
+	"github.com/ltcsuite/lnd/tlv"
 	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/txscript"
 	"github.com/ltcsuite/ltcd/wire"
@@ -32,13 +35,23 @@ type htlcTimeoutResolver struct {
 	// resolve this outgoing HTLC.
 	htlcResolution lnwallet.OutgoingHtlcResolution
 
-	// outputIncubating returns true if we've sent the output to the output
-	// incubator (utxo nursery).
+	// outputIncubating returns true if we've started spending the HTLC
+	// output. For anchor channels, this is set once the second-level
+	// transaction offered to the sweeper has confirmed. Otherwise, it is
+	// set once the HTLC has expired and the timeout transaction was
+	// published, or the output was offered to the sweeper.
 	outputIncubating bool
 
 	// resolved reflects if the contract has been fully resolved or not.
 	resolved bool
 
+	// commitSpendFee is the fee we paid for the transaction spending the
+	// HTLC output on the commitment if the sweeper published it, which is
+	// either the re-signed second-level transaction or the sweep of the
+	// output on the remote commitment. It is only known once that
+	// transaction has confirmed.
+	commitSpendFee ltcutil.Amount
+
 	// broadcastHeight is the height that the original contract was
 	// broadcast to the main-chain at. We'll use this value to bound any
 	// historical queries to the chain for spends/confirmations.
@@ -49,10 +62,13 @@ type htlcTimeoutResolver struct {
 	// htlc contains information on the htlc that we are resolving on-chain.
 	htlc channeldb.HTLC
 
+	// firstLevelSweep is the result channel of the sweeper for the HTLC
+	// output on the commitment, if the sweeper spends it. It is used to
+	// learn whether the sweeper abandoned the output.
+	firstLevelSweep chan sweep.Result
+
 	// currentReport stores the current state of the resolver for reporting
-	// over the rpc interface. This should only be reported in case we have
-	// a non-nil SignDetails on the htlcResolution, otherwise the nursery
-	// will produce reports.
+	// over the rpc interface.
 	currentReport ContractReport
 
 	// reportLock prevents concurrent access to the resolver report.
@@ -395,6 +411,9 @@ func (h *htlcTimeoutResolver) Resolve() (ContractResolver, error) {
 	// second-level timeout transaction, or directly if this is the remote
 	// commitment.
 	commitSpend, err := h.spendHtlcOutput()
+	if errors.Is(err, errSweepAbandoned) {
+		return nil, h.abandonHtlcOutput()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -456,42 +475,177 @@ func (h *htlcTimeoutResolver) sweepSecondLevelTx() error {
 			h.broadcastHeight,
 		))
 	}
-	_, err := h.Sweeper.SweepInput(
+
+	// We'll also pass the deadline of the incoming HTLC to the sweeper, so
+	// the fee rate is raised as its expiry approaches.
+	deadline, err := h.deadlineHeight()
+	if err != nil {
+		return err
+	}
+
+	sweepResult, err := h.Sweeper.SweepInput(
 		inp,
 		sweep.Params{
 			Fee: sweep.FeePreference{
 				ConfTarget: secondLevelConfTarget,
 			},
-			Force: true,
+			Force:               true,
+			Budget:              h.budget(),
+			AbandonUneconomical: h.Budget.AbandonUneconomicalHTLCs,
+			DeadlineHeight:      deadline,
 		},
 	)
 	if err != nil {
 		return err
 	}
 
+	h.firstLevelSweep = sweepResult
+
 	// TODO(yy): checkpoint here?
 	return err
 }
 
-// sendSecondLevelTxLegacy sends a second level timeout transaction to the utxo
-// nursery. This transaction uses the legacy SIGHASH_ALL flag.
-func (h *htlcTimeoutResolver) sendSecondLevelTxLegacy() error {
-	log.Debugf("%T(%v): incubating htlc output", h,
-		h.htlcResolution.ClaimOutpoint)
+// budget returns the maximum fee that may be spent on claiming the HTLC.
+func (h *htlcTimeoutResolver) budget() ltcutil.Amount {
+	return calculateBudget(h.htlc.Amt.ToSatoshis(), h.Budget.HTLC)
+}
 
-	err := h.IncubateOutputs(
-		h.ChanPoint, &h.htlcResolution, nil,
-		h.broadcastHeight,
-	)
+// deadlineHeight returns the height by which the timeout of the HTLC must be
+// confirmed. For a forwarded HTLC, this is the expiry of the incoming HTLC
+// minus htlcDeadlineDelta, as we can't fail the incoming HTLC back before. Zero
+// is returned if there's no deadline.
+func (h *htlcTimeoutResolver) deadlineHeight() (int32, error) {
+	if h.IncomingHtlcExpiry == nil {
+		return 0, nil
+	}
+
+	expiry, err := h.IncomingHtlcExpiry(h.ShortChanID, h.htlc.HtlcIndex)
+	if err != nil {
+		return 0, err
+	}
+
+	if expiry <= htlcDeadlineDelta {
+		return 0, nil
+	}
+
+	return int32(expiry - htlcDeadlineDelta), nil
+}
+
+// claimExpiredOutput waits for the HTLC to expire, then starts spending the
+// output via the timeout clause. If this is our commitment, the second-level
+// timeout transaction, which uses the legacy SIGHASH_ALL flag, is published.
+// Otherwise, the output on the remote commitment is offered to the sweeper.
+// As this isn't persisted by anyone else, it is repeated after a restart.
+func (h *htlcTimeoutResolver) claimExpiredOutput() error {
+	// If we already started spending the output before a restart, the
+	// HTLC has expired and there's no need to wait for it again.
+	if !h.outputIncubating {
+		log.Debugf("%T(%v): waiting for htlc to expire at height %v",
+			h, h.htlcResolution.ClaimOutpoint,
+			h.htlcResolution.Expiry)
+
+		err := waitForHeight(
+			h.htlcResolution.Expiry, h.Notifier, h.quit,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if h.htlcResolution.SignedTimeoutTx != nil {
+		err = h.publishTimeoutTx()
+	} else {
+		err = h.sweepRemoteCommitOutput()
+	}
 	if err != nil {
 		return err
 	}
 
+	// There's no need to checkpoint again if we've already done so before
+	// a restart.
+	if h.outputIncubating {
+		return nil
+	}
+
 	h.outputIncubating = true
 
 	return h.Checkpoint(h)
 }
 
+// publishTimeoutTx publishes the second-level timeout transaction of an HTLC
+// on our commitment.
+func (h *htlcTimeoutResolver) publishTimeoutTx() error {
+	log.Infof("%T(%v): publishing timeout tx (txid=%v): %v", h,
+		h.htlcResolution.ClaimOutpoint,
+		h.htlcResolution.SignedTimeoutTx.TxHash(),
+		spew.Sdump(h.htlcResolution.SignedTimeoutTx))
+
+	label := labels.MakeLabel(
+		labels.LabelTypeChannelClose, &h.ShortChanID,
+	)
+	err := h.PublishTx(h.htlcResolution.SignedTimeoutTx, label)
+
+	// In case the tx does not meet mempool fee requirements we continue
+	// because the tx is rebroadcasted in the background and there is
+	// nothing we can do to bump this transaction anyways. A double spend
+	// is detected when we watch for the spend of the HTLC output.
+	if err != nil && !errors.Is(err, lnwallet.ErrDoubleSpend) &&
+		!errors.Is(err, lnwallet.ErrMempoolFee) {
+
+		return err
+	}
+
+	return nil
+}
+
+// sweepRemoteCommitOutput offers the expired HTLC output on the remote
+// commitment to the sweeper, which spends it directly via the timeout clause.
+func (h *htlcTimeoutResolver) sweepRemoteCommitOutput() error {
+	log.Infof("%T(%v): offering expired htlc output on remote commitment "+
+		"to sweeper", h, h.htlcResolution.ClaimOutpoint)
+
+	var witType input.StandardWitnessType
+	if h.isTaproot() {
+		witType = input.TaprootHtlcOfferedRemoteTimeout
+	} else {
+		witType = input.HtlcOfferedRemoteTimeout
+	}
+
+	// The CSV delay is set to what the resolution encodes, since the
+	// sequence number must be set accordingly.
+	inp := input.NewCsvInputWithCltv(
+		&h.htlcResolution.ClaimOutpoint, witType,
+		&h.htlcResolution.SweepSignDesc, h.broadcastHeight,
+		h.htlcResolution.CsvDelay, h.htlcResolution.Expiry,
+	)
+
+	deadline, err := h.deadlineHeight()
+	if err != nil {
+		return err
+	}
+
+	sweepResult, err := h.Sweeper.SweepInput(
+		inp,
+		sweep.Params{
+			Fee: sweep.FeePreference{
+				ConfTarget: sweepConfTarget,
+			},
+			Force:               true,
+			Budget:              h.budget(),
+			AbandonUneconomical: h.Budget.AbandonUneconomicalHTLCs,
+			DeadlineHeight:      deadline,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	h.firstLevelSweep = sweepResult
+
+	return nil
+}
+
 // spendHtlcOutput handles the initial spend of an HTLC output via the timeout
 // clause. If this is our local commitment, the second-level timeout TX will be
 // used to spend the output into the next stage. If this is the remote
@@ -510,18 +664,19 @@ func (h *htlcTimeoutResolver) spendHtlcOutput() (*chainntnfs.SpendDetail, error)
 			return nil, err
 		}
 
-	// If we have no SignDetails, and we haven't already sent the output to
-	// the utxo nursery, then we'll do so now.
-	case h.htlcResolution.SignDetails == nil && !h.outputIncubating:
-		if err := h.sendSecondLevelTxLegacy(); err != nil {
-			log.Errorf("Sending timeout tx to nursery: %v", err)
+	// If we have no SignDetails, we'll publish the timeout transaction or
+	// sweep the output on the remote commitment ourselves once the HTLC
+	// has expired.
+	case h.htlcResolution.SignDetails == nil:
+		if err := h.claimExpiredOutput(); err != nil {
+			log.Errorf("Claiming expired htlc output: %v", err)
 
 			return nil, err
 		}
 	}
 
-	// Now that we've handed off the HTLC to the nursery or sweeper, we'll
-	// watch for a spend of the output, and make our next move off of that.
+	// Now that we've started spending the HTLC output, we'll watch for a
+	// spend of the output, and make our next move off of that.
 	// Depending on if this is our commitment, or the remote party's
 	// commitment, we'll be watching a different outpoint and script.
 	return h.watchHtlcSpend()
@@ -558,15 +713,22 @@ func (h *htlcTimeoutResolver) waitForConfirmedSpend(op *wire.OutPoint,
 	log.Infof("%T(%v): waiting for spent of HTLC output %v to be "+
 		"fully confirmed", h, h.htlcResolution.ClaimOutpoint, op)
 
-	// We'll block here until either we exit, or the HTLC output on the
-	// commitment transaction has been spent.
-	spend, err := waitForSpend(
-		op, pkScript, h.broadcastHeight, h.Notifier, h.quit,
+	// We'll block here until either we exit, the HTLC output on the
+	// commitment transaction has been spent, or the sweeper abandoned it.
+	spend, fee, err := waitForSweep(
+		op, pkScript, h.broadcastHeight, h.firstLevelSweep, h.Notifier,
+		h.quit,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	// The fee is only known if the sweeper spent the output since our
+	// last restart, so we keep the one we might have persisted before.
+	if fee != 0 {
+		h.commitSpendFee = fee
+	}
+
 	// Once confirmed, persist the state on disk.
 	if err := h.checkPointSecondLevelTx(); err != nil {
 		return nil, err
@@ -614,14 +776,16 @@ func (h *htlcTimeoutResolver) handleCommitSpend(
 		// accordingly.
 		spendTxID = commitSpend.SpenderTxHash
 
+		// spendFee is the fee paid by spendTxID.
+		spendFee = h.commitSpendFee
+
 		reports []*channeldb.ResolverReport
 	)
 
-	switch {
-	// If the sweeper is handling the second level transaction, wait for
-	// the CSV and possible CLTV lock to expire, before sweeping the output
-	// on the second-level.
-	case h.htlcResolution.SignDetails != nil:
+	// If this was our local commitment, wait for the CSV and possible CLTV
+	// lock of the second-level timeout transaction to expire, before
+	// sweeping its output.
+	if h.htlcResolution.SignedTimeoutTx != nil {
 		waitHeight := h.deriveWaitHeight(
 			h.htlcResolution.CsvDelay, commitSpend,
 		)
@@ -672,12 +836,22 @@ func (h *htlcTimeoutResolver) handleCommitSpend(
 			h.htlcResolution.CsvDelay, h.broadcastHeight,
 			h.htlc.RHash,
 		)
-		_, err = h.Sweeper.SweepInput(
+
+		deadline, err := h.deadlineHeight()
+		if err != nil {
+			return nil, err
+		}
+
+		abandon := h.Budget.AbandonUneconomicalHTLCs
+		sweepResult, err := h.Sweeper.SweepInput(
 			inp,
 			sweep.Params{
 				Fee: sweep.FeePreference{
 					ConfTarget: sweepConfTarget,
 				},
+				Budget:              h.budget(),
+				AbandonUneconomical: abandon,
+				DeadlineHeight:      deadline,
 			},
 		)
 		if err != nil {
@@ -685,27 +859,30 @@ func (h *htlcTimeoutResolver) handleCommitSpend(
 		}
 
 		// Update the claim outpoint to point to the second-level
-		// transaction created by the sweeper.
+		// transaction, which may have been re-signed by the sweeper.
 		claimOutpoint = *op
-		fallthrough
 
-	// Finally, if this was an output on our commitment transaction, we'll
-	// wait for the second-level HTLC output to be spent, and for that
-	// transaction itself to confirm.
-	case h.htlcResolution.SignedTimeoutTx != nil:
-		log.Infof("%T(%v): waiting for nursery/sweeper to spend CSV "+
-			"delayed output", h, claimOutpoint)
-		sweepTx, err := waitForSpend(
+		// Finally, we'll wait for the second-level HTLC output to be
+		// spent, and for that transaction itself to confirm.
+		log.Infof("%T(%v): waiting for sweeper to spend CSV delayed "+
+			"output", h, claimOutpoint)
+		sweepTx, sweepFee, err := waitForSweep(
 			&claimOutpoint,
 			h.htlcResolution.SweepSignDesc.Output.PkScript,
-			h.broadcastHeight, h.Notifier, h.quit,
+			h.broadcastHeight, sweepResult, h.Notifier, h.quit,
 		)
+		if errors.Is(err, errSweepAbandoned) {
+			return nil, h.abandonSecondLevelOutput(
+				commitSpend, claimOutpoint,
+			)
+		}
 		if err != nil {
 			return nil, err
 		}
 
 		// Update the spend txid to the hash of the sweep transaction.
 		spendTxID = sweepTx.SpenderTxHash
+		spendFee = sweepFee
 
 		// Once our sweep of the timeout tx has confirmed, we add a
 		// resolution for our timeoutTx tx first stage transaction.
@@ -719,6 +896,7 @@ func (h *htlcTimeoutResolver) handleCommitSpend(
 			ResolverType:    channeldb.ResolverTypeOutgoingHtlc,
 			ResolverOutcome: channeldb.ResolverOutcomeFirstStage,
 			SpendTxID:       spendHash,
+			Fee:             h.timeoutTxFee(),
 		})
 	}
 
@@ -738,11 +916,94 @@ func (h *htlcTimeoutResolver) handleCommitSpend(
 		ResolverType:    channeldb.ResolverTypeOutgoingHtlc,
 		ResolverOutcome: channeldb.ResolverOutcomeTimeout,
 		SpendTxID:       spendTxID,
+		Fee:             spendFee,
 	})
 
 	return nil, h.Checkpoint(h, reports...)
 }
 
+// abandonHtlcOutput marks the contract as resolved after the sweeper gave up on
+// the HTLC output on the commitment, as its value doesn't cover the fee of
+// sweeping it. The HTLC is failed back on the incoming link, as we won't claim
+// it anymore.
+func (h *htlcTimeoutResolver) abandonHtlcOutput() error {
+	log.Warnf("%T(%v): abandoning uneconomical htlc output", h,
+		h.htlcResolution.ClaimOutpoint)
+
+	failureMsg := &lnwire.FailPermanentChannelFailure{}
+	err := h.DeliverResolutionMsg(ResolutionMsg{
+		SourceChan: h.ShortChanID,
+		HtlcIndex:  h.htlc.HtlcIndex,
+		Failure:    failureMsg,
+	})
+	if err != nil {
+		return err
+	}
+
+	h.resolved = true
+	h.reportLock.Lock()
+	h.currentReport.LimboBalance = 0
+	h.reportLock.Unlock()
+
+	report := &channeldb.ResolverReport{
+		OutPoint:        h.HtlcPoint(),
+		Amount:          h.htlc.Amt.ToSatoshis(),
+		ResolverType:    channeldb.ResolverTypeOutgoingHtlc,
+		ResolverOutcome: channeldb.ResolverOutcomeAbandoned,
+	}
+
+	return h.Checkpoint(h, report)
+}
+
+// abandonSecondLevelOutput marks the contract as resolved after the sweeper
+// gave up on the second-level output, as its value doesn't cover the fee of
+// sweeping it. The confirmed timeout transaction is still recorded as the
+// first stage of the resolution.
+func (h *htlcTimeoutResolver) abandonSecondLevelOutput(
+	commitSpend *chainntnfs.SpendDetail, claimOutpoint wire.OutPoint) error {
+
+	log.Warnf("%T(%v): abandoning uneconomical second-level output %v",
+		h, h.htlcResolution.ClaimOutpoint, claimOutpoint)
+
+	h.resolved = true
+	h.reportLock.Lock()
+	h.currentReport.LimboBalance = 0
+	h.reportLock.Unlock()
+
+	timeoutTx := commitSpend.SpendingTx
+	index := commitSpend.SpenderInputIndex
+
+	amt := ltcutil.Amount(h.htlcResolution.SweepSignDesc.Output.Value)
+	reports := []*channeldb.ResolverReport{{
+		OutPoint:        timeoutTx.TxIn[index].PreviousOutPoint,
+		Amount:          h.htlc.Amt.ToSatoshis(),
+		ResolverType:    channeldb.ResolverTypeOutgoingHtlc,
+		ResolverOutcome: channeldb.ResolverOutcomeFirstStage,
+		SpendTxID:       commitSpend.SpenderTxHash,
+		Fee:             h.timeoutTxFee(),
+	}, {
+		OutPoint:        claimOutpoint,
+		Amount:          amt,
+		ResolverType:    channeldb.ResolverTypeOutgoingHtlc,
+		ResolverOutcome: channeldb.ResolverOutcomeAbandoned,
+	}}
+
+	return h.Checkpoint(h, reports...)
+}
+
+// timeoutTxFee returns the fee paid by the confirmed second-level timeout
+// transaction.
+func (h *htlcTimeoutResolver) timeoutTxFee() ltcutil.Amount {
+	// If the sweeper re-signed the transaction, it attached the fee.
+	if h.htlcResolution.SignDetails != nil {
+		return h.commitSpendFee
+	}
+
+	return secondLevelTxFee(
+		h.htlc.Amt.ToSatoshis(), h.htlcResolution.SignedTimeoutTx,
+	)
+}
+
 // Stop signals the resolver to cancel any current resolution processes, and
 // suspend.
 //
@@ -761,12 +1022,6 @@ func (h *htlcTimeoutResolver) IsResolved() bool {
 
 // report returns a report on the resolution state of the contract.
 func (h *htlcTimeoutResolver) report() *ContractReport {
-	// If the sign details are nil, the report will be created by handled
-	// by the nursery.
-	if h.htlcResolution.SignDetails == nil {
-		return nil
-	}
-
 	h.reportLock.Lock()
 	defer h.reportLock.Unlock()
 	cpy := h.currentReport
@@ -774,8 +1029,7 @@ func (h *htlcTimeoutResolver) report() *ContractReport {
 }
 
 func (h *htlcTimeoutResolver) initReport() {
-	// We create the initial report. This will only be reported for
-	// resolvers not handled by the nursery.
+	// We create the initial report.
 	finalAmt := h.htlc.Amt.ToSatoshis()
 	if h.htlcResolution.SignedTimeoutTx != nil {
 		finalAmt = ltcutil.Amount(
@@ -798,41 +1052,57 @@ func (h *htlcTimeoutResolver) initReport() {
 //
 // NOTE: Part of the ContractResolver interface.
 func (h *htlcTimeoutResolver) Encode(w io.Writer) error {
-	// First, we'll write out the relevant fields of the
-	// OutgoingHtlcResolution to the writer.
-	if err := encodeOutgoingResolution(w, &h.htlcResolution); err != nil {
-		return err
-	}
+	return encodeResolverState(w, h.records()...)
+}
 
-	// With that portion written, we can now write out the fields specific
-	// to the resolver itself.
-	if err := binary.Write(w, endian, h.outputIncubating); err != nil {
-		return err
-	}
-	if err := binary.Write(w, endian, h.resolved); err != nil {
-		return err
-	}
-	if err := binary.Write(w, endian, h.broadcastHeight); err != nil {
-		return err
+// records returns the TLV records of the fields of the resolver's state. The
+// records refer to the resolver's fields, so they are used for both encoding
+// and decoding.
+func (h *htlcTimeoutResolver) records() []tlv.Record {
+	resolution := &h.htlcResolution
+
+	return []tlv.Record{
+		encodedRecord(resolverResolutionType, func(w io.Writer) error {
+			return encodeOutgoingResolution(w, resolution)
+		}, func(r io.Reader) error {
+			return decodeOutgoingResolution(r, resolution)
+		}),
+		boolRecord(resolverIncubatingType, &h.outputIncubating),
+		boolRecord(resolverResolvedType, &h.resolved),
+		tlv.MakePrimitiveRecord(
+			resolverBroadcastHeightType, &h.broadcastHeight,
+		),
+		tlv.MakePrimitiveRecord(
+			resolverHtlcIndexType, &h.htlc.HtlcIndex,
+		),
+		signDetailsRecord(&resolution.SignDetails),
+		amountRecord(resolverCommitSpendFeeType, &h.commitSpendFee),
 	}
+}
 
-	if err := binary.Write(w, endian, h.htlc.HtlcIndex); err != nil {
-		return err
+// newTimeoutResolverFromReader attempts to decode an encoded ContractResolver
+// from the passed Reader instance, returning an active ContractResolver
+// instance.
+func newTimeoutResolverFromReader(r io.Reader, resCfg ResolverConfig) (
+	*htlcTimeoutResolver, error) {
+
+	h := &htlcTimeoutResolver{
+		contractResolverKit: *newContractResolverKit(resCfg),
 	}
 
-	// We encode the sign details last for backwards compatibility.
-	err := encodeSignDetails(w, h.htlcResolution.SignDetails)
-	if err != nil {
-		return err
+	if err := decodeResolverState(r, h.records()...); err != nil {
+		return nil, err
 	}
 
-	return nil
+	h.initReport()
+
+	return h, nil
 }
 
-// newTimeoutResolverFromReader attempts to decode an encoded ContractResolver
-// from the passed Reader instance, returning an active ContractResolver
-// instance.
-func newTimeoutResolverFromReader(r io.Reader, resCfg ResolverConfig) (
+// newTimeoutResolverFromLegacyReader decodes a ContractResolver from the
+// passed Reader instance that was encoded by an older version, before the
+// fields of the resolver's state were stored as TLV records.
+func newTimeoutResolverFromLegacyReader(r io.Reader, resCfg ResolverConfig) (
 	*htlcTimeoutResolver, error) {
 
 	h := &htlcTimeoutResolver{
@@ -938,17 +1208,76 @@ func (h *htlcTimeoutResolver) waitForMempoolOrBlockSpend(op wire.OutPoint,
 	// goroutine will return on the quit channel.
 	go h.consumeSpendEvents(result, blockSpent.Spend, mempoolSpent.Spend)
 
-	// Wait for the spend event to be received.
-	select {
-	case event := <-result:
-		// Cancel the mempool subscription as we don't need it anymore.
-		h.Mempool.CancelMempoolSpendEvent(mempoolSpent)
+	// Wait for the spend event to be received, unless the sweeper gives up
+	// on the output.
+	var (
+		sweepResult = h.firstLevelSweep
+		fee         ltcutil.Amount
+	)
+	for {
+		select {
+		case event := <-result:
+			// Cancel the mempool subscription as we don't need it
+			// anymore.
+			h.Mempool.CancelMempoolSpendEvent(mempoolSpent)
+
+			if event.err != nil {
+				return nil, event.err
+			}
+
+			// The sweeper signals its result once it sees the
+			// confirmed spend as well, so we wait for it to learn
+			// the fee we paid unless we already received it.
+			if sweepResult != nil {
+				select {
+				case res, ok := <-sweepResult:
+					if ok && res.Err == nil {
+						fee = res.Fee
+					}
+
+				case <-h.quit:
+					return nil, errResolverShuttingDown
+				}
+			}
+
+			return event.spend, h.recordCommitSpendFee(fee)
+
+		// Besides the fee of our own sweep, only an abandoned sweep is
+		// of interest here. In all other cases we keep waiting for the
+		// spend, so we stop listening for further results.
+		case res, ok := <-sweepResult:
+			if ok && errors.Is(res.Err, sweep.ErrBudgetExhausted) {
+				h.Mempool.CancelMempoolSpendEvent(mempoolSpent)
 
-		return event.spend, event.err
+				return nil, errSweepAbandoned
+			}
+			if ok && res.Err == nil {
+				fee = res.Fee
+			}
+			sweepResult = nil
 
-	case <-h.quit:
-		return nil, errResolverShuttingDown
+		case <-h.quit:
+			return nil, errResolverShuttingDown
+		}
+	}
+}
+
+// recordCommitSpendFee stores the fee we paid for the confirmed spend of the
+// HTLC output, unless it is unknown. As the state may already have been
+// checkpointed when the spend confirmed, it is checkpointed again to persist
+// the fee.
+func (h *htlcTimeoutResolver) recordCommitSpendFee(fee ltcutil.Amount) error {
+	if fee == 0 {
+		return nil
 	}
+
+	h.commitSpendFee = fee
+
+	if h.htlcResolution.SignDetails == nil || !h.outputIncubating {
+		return nil
+	}
+
+	return h.Checkpoint(h)
 }
 
 // consumeSpendEvents consumes the spend events from the block and mempool
@@ -270,7 +270,8 @@ func TestHtlcTimeoutResolver(t *testing.T) {
 		t.Logf("Running test case: %v", testCase.name)
 
 		checkPointChan := make(chan struct{}, 1)
-		incubateChan := make(chan struct{}, 1)
+		publishChan := make(chan *wire.MsgTx, 1)
+		sweeper := newMockSweeper()
 		resolutionChan := make(chan ResolutionMsg, 1)
 		reportChan := make(chan *channeldb.ResolverReport)
 
@@ -278,14 +279,11 @@ func TestHtlcTimeoutResolver(t *testing.T) {
 			ChainArbitratorConfig: ChainArbitratorConfig{
 				Notifier:   notifier,
 				PreimageDB: witnessBeacon,
-				IncubateOutputs: func(wire.OutPoint,
-					*lnwallet.OutgoingHtlcResolution,
-					*lnwallet.IncomingHtlcResolution,
-					uint32) error {
-
-					incubateChan <- struct{}{}
+				PublishTx: func(tx *wire.MsgTx, _ string) error {
+					publishChan <- tx
 					return nil
 				},
+				Sweeper: sweeper,
 				DeliverResolutionMsg: func(msgs ...ResolutionMsg) error {
 					if len(msgs) != 1 {
 						return fmt.Errorf("expected 1 "+
@@ -351,6 +349,7 @@ func TestHtlcTimeoutResolver(t *testing.T) {
 					ResolverType:    channeldb.ResolverTypeOutgoingHtlc,
 					ResolverOutcome: channeldb.ResolverOutcomeFirstStage,
 					SpendTxID:       &timeoutTxID,
+					Fee:             testHtlcAmt.ToSatoshis(),
 				})
 			}
 		}
@@ -369,14 +368,38 @@ func TestHtlcTimeoutResolver(t *testing.T) {
 			}
 		}()
 
-		// At the output isn't yet in the nursery, we expect that we
-		// should receive an incubation request.
+		// The resolver will wait for the HTLC to expire before
+		// spending it.
 		select {
-		case <-incubateChan:
-		case err := <-resolveErr:
-			t.Fatalf("unable to resolve HTLC: %v", err)
+		case notifier.EpochChan <- &chainntnfs.BlockEpoch{}:
 		case <-time.After(time.Second * 5):
-			t.Fatalf("failed to receive incubation request")
+			t.Fatalf("failed to request block epoch")
+		}
+
+		// Once expired, we expect the timeout tx to be published if
+		// this is our commitment, otherwise the output should be
+		// offered to the sweeper directly.
+		if testCase.remoteCommit {
+			select {
+			case <-sweeper.sweptInputs:
+			case err := <-resolveErr:
+				t.Fatalf("unable to resolve HTLC: %v", err)
+			case <-time.After(time.Second * 5):
+				t.Fatalf("failed to receive sweep request")
+			}
+		} else {
+			select {
+			case tx := <-publishChan:
+				require.Equal(
+					t, resolver.htlcResolution.SignedTimeoutTx,
+					tx,
+				)
+
+			case err := <-resolveErr:
+				t.Fatalf("unable to resolve HTLC: %v", err)
+			case <-time.After(time.Second * 5):
+				t.Fatalf("failed to receive published tx")
+			}
 		}
 
 		// Next, the resolver should request a spend notification for
@@ -391,8 +414,9 @@ func TestHtlcTimeoutResolver(t *testing.T) {
 
 		select {
 		case notifier.SpendChan <- &chainntnfs.SpendDetail{
-			SpendingTx:    spendingTx,
-			SpenderTxHash: &spendTxHash,
+			SpendingTx:     spendingTx,
+			SpenderTxHash:  &spendTxHash,
+			SpendingHeight: 10,
 		}:
 		case <-time.After(time.Second * 5):
 			t.Fatalf("failed to request spend ntfn")
@@ -441,11 +465,28 @@ func TestHtlcTimeoutResolver(t *testing.T) {
 				t.Fatalf("resolution not sent")
 			}
 
-			// We should also get another request for the spend
-			// notification of the second-level transaction to
-			// indicate that it's been swept by the nursery, but
-			// only if this is a local commitment transaction.
+			// If this is a local commitment transaction, the
+			// second-level output should be offered to the sweeper
+			// once its CSV lock has expired, after which we'll
+			// get another request for the spend notification of
+			// the second-level transaction.
 			if !testCase.remoteCommit {
+				select {
+				case notifier.EpochChan <- &chainntnfs.BlockEpoch{
+					Height: 10,
+				}:
+				case <-time.After(time.Second * 5):
+					t.Fatalf("failed to request block epoch")
+				}
+
+				select {
+				case <-sweeper.sweptInputs:
+				case err := <-resolveErr:
+					t.Fatalf("unable to resolve HTLC: %v", err)
+				case <-time.After(time.Second * 5):
+					t.Fatalf("failed to receive sweep request")
+				}
+
 				select {
 				case notifier.SpendChan <- &chainntnfs.SpendDetail{
 					SpendingTx:    spendingTx,
@@ -525,15 +566,22 @@ func TestHtlcTimeoutSingleStage(t *testing.T) {
 
 	checkpoints := []checkpoint{
 		{
-			// Output should be handed off to the nursery.
+			// Once the HTLC has expired, we start spending the
+			// output via the timeout clause.
+			preCheckpoint: func(ctx *htlcResolverTestContext,
+				_ bool) error {
+
+				ctx.notifier.EpochChan <- &chainntnfs.BlockEpoch{}
+				return nil
+			},
 			incubating: true,
 		},
 		{
-			// We send a confirmation the sweep tx from published
-			// by the nursery.
+			// We send a confirmation of the sweep tx published
+			// by the sweeper.
 			preCheckpoint: func(ctx *htlcResolverTestContext,
 				_ bool) error {
-				// The nursery will create and publish a sweep
+				// The sweeper will create and publish a sweep
 				// tx.
 				ctx.notifier.SpendChan <- &chainntnfs.SpendDetail{
 					SpendingTx:    sweepTx,
@@ -621,6 +669,7 @@ func TestHtlcTimeoutSecondStage(t *testing.T) {
 		ResolverType:    channeldb.ResolverTypeOutgoingHtlc,
 		ResolverOutcome: channeldb.ResolverOutcomeFirstStage,
 		SpendTxID:       &timeoutTxid,
+		Fee:             testHtlcAmt.ToSatoshis() - 111,
 	}
 
 	secondState := &channeldb.ResolverReport{
@@ -633,7 +682,14 @@ func TestHtlcTimeoutSecondStage(t *testing.T) {
 
 	checkpoints := []checkpoint{
 		{
-			// Output should be handed off to the nursery.
+			// Once the HTLC has expired, we start spending the
+			// output via the timeout clause.
+			preCheckpoint: func(ctx *htlcResolverTestContext,
+				_ bool) error {
+
+				ctx.notifier.EpochChan <- &chainntnfs.BlockEpoch{}
+				return nil
+			},
 			incubating: true,
 		},
 		{
@@ -641,10 +697,11 @@ func TestHtlcTimeoutSecondStage(t *testing.T) {
 			// that our sweep succeeded.
 			preCheckpoint: func(ctx *htlcResolverTestContext,
 				_ bool) error {
-				// The nursery will publish the timeout tx.
+				// The timeout tx confirms.
 				ctx.notifier.SpendChan <- &chainntnfs.SpendDetail{
-					SpendingTx:    timeoutTx,
-					SpenderTxHash: &timeoutTxid,
+					SpendingTx:     timeoutTx,
+					SpenderTxHash:  &timeoutTxid,
+					SpendingHeight: 10,
 				}
 
 				// The resolver should deliver a failure
@@ -659,6 +716,21 @@ func TestHtlcTimeoutSecondStage(t *testing.T) {
 					t.Fatalf("resolution not sent")
 				}
 
+				// Once the CSV lock has expired, the
+				// second-level output is offered to the
+				// sweeper.
+				ctx.notifier.EpochChan <- &chainntnfs.BlockEpoch{
+					Height: 10,
+				}
+
+				resolver := ctx.resolver.(*htlcTimeoutResolver)
+				inp := <-resolver.Sweeper.(*mockSweeper).sweptInputs
+				op := inp.OutPoint()
+				if op.Hash != timeoutTxid {
+					return fmt.Errorf("outpoint %v swept, "+
+						"expected %v", op, timeoutTxid)
+				}
+
 				// Deliver spend of timeout tx.
 				ctx.notifier.SpendChan <- &chainntnfs.SpendDetail{
 					SpendingTx:    sweepTx,
@@ -749,7 +821,14 @@ func TestHtlcTimeoutSingleStageRemoteSpend(t *testing.T) {
 
 	checkpoints := []checkpoint{
 		{
-			// Output should be handed off to the nursery.
+			// Once the HTLC has expired, we start spending the
+			// output via the timeout clause.
+			preCheckpoint: func(ctx *htlcResolverTestContext,
+				_ bool) error {
+
+				ctx.notifier.EpochChan <- &chainntnfs.BlockEpoch{}
+				return nil
+			},
 			incubating: true,
 		},
 		{
@@ -859,7 +938,14 @@ func TestHtlcTimeoutSecondStageRemoteSpend(t *testing.T) {
 
 	checkpoints := []checkpoint{
 		{
-			// Output should be handed off to the nursery.
+			// Once the HTLC has expired, we start spending the
+			// output via the timeout clause.
+			preCheckpoint: func(ctx *htlcResolverTestContext,
+				_ bool) error {
+
+				ctx.notifier.EpochChan <- &chainntnfs.BlockEpoch{}
+				return nil
+			},
 			incubating: true,
 		},
 		{
@@ -1324,3 +1410,37 @@ func testHtlcTimeout(t *testing.T, resolution lnwallet.OutgoingHtlcResolution,
 		_ = runFromCheckpoint(t, ctx, checkpoints[i+1:])
 	}
 }
+
+// TestHtlcTimeoutDeadlineHeight tests that the deadline passed to the sweeper
+// is derived from the expiry of the incoming HTLC.
+func TestHtlcTimeoutDeadlineHeight(t *testing.T) {
+	t.Parallel()
+
+	resolver := &htlcTimeoutResolver{
+		htlc: channeldb.HTLC{HtlcIndex: 3},
+	}
+
+	// Without a way to look up the incoming HTLC, there's no deadline.
+	deadline, err := resolver.deadlineHeight()
+	require.NoError(t, err)
+	require.Zero(t, deadline)
+
+	var incomingExpiry uint32 = 500
+	resolver.IncomingHtlcExpiry = func(_ lnwire.ShortChannelID,
+		htlcIndex uint64) (uint32, error) {
+
+		require.EqualValues(t, 3, htlcIndex)
+
+		return incomingExpiry, nil
+	}
+
+	deadline, err = resolver.deadlineHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 500-htlcDeadlineDelta, deadline)
+
+	// If the HTLC isn't forwarded, there's no deadline either.
+	incomingExpiry = 0
+	deadline, err = resolver.deadlineHeight()
+	require.NoError(t, err)
+	require.Zero(t, deadline)
+}
@@ -13,16 +13,12 @@ var (
 
 	// brarLog is the logger used by the breach arb.
 	brarLog btclog.Logger
-
-	// utxnLog is the logger used by the utxo nursary.
-	utxnLog btclog.Logger
 )
 
 // The default amount of logging is none.
 func init() {
 	UseLogger(build.NewSubLogger("CNCT", nil))
 	UseBreachLogger(build.NewSubLogger("BRAR", nil))
-	UseNurseryLogger(build.NewSubLogger("UTXN", nil))
 }
 
 // DisableLog disables all library log output.  Logging output is disabled
@@ -45,13 +41,6 @@ func UseBreachLogger(logger btclog.Logger) {
 	brarLog = logger
 }
 
-// UseNurseryLogger uses a specified Logger to output package logging info.
-// This should be used in preference to SetLogWriter if the caller is also
-// using btclog.
-func UseNurseryLogger(logger btclog.Logger) {
-	utxnLog = logger
-}
-
 // logClosure is used to provide a closure over expensive logging operations so
 // don't have to be performed when the logging level doesn't warrant it.
 type logClosure func() string
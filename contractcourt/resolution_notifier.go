@@ -0,0 +1,192 @@
+package contractcourt
+
+import (
+	"sync"
+
+	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/subscribe"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+// CommitmentConfirmedEvent is sent when a commitment transaction of a channel
+// confirmed, either our own or the one of the remote party.
+type CommitmentConfirmedEvent struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// CloseType is either LocalForceClose or RemoteForceClose.
+	CloseType channeldb.ClosureType
+
+	// ClosingTxid is the hash of the confirmed commitment transaction.
+	ClosingTxid chainhash.Hash
+
+	// CloseHeight is the height the commitment transaction confirmed at.
+	CloseHeight uint32
+
+	// SettledBalance is our balance on the commitment that can be swept
+	// without a time lock.
+	SettledBalance ltcutil.Amount
+
+	// TimeLockedBalance is our balance on the commitment that is only
+	// spendable after a time lock.
+	TimeLockedBalance ltcutil.Amount
+}
+
+// BreachDetectedEvent is sent when a revoked commitment transaction of the
+// remote party confirmed.
+type BreachDetectedEvent struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// BreachTxid is the hash of the revoked commitment transaction.
+	BreachTxid chainhash.Hash
+
+	// BreachHeight is the height the revoked commitment confirmed at.
+	BreachHeight uint32
+}
+
+// ResolverReportEvent is sent when an output of a confirmed commitment
+// transaction was resolved, e.g. an HTLC was claimed or timed out.
+type ResolverReportEvent struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// Report describes the resolution of the output.
+	Report *channeldb.ResolverReport
+}
+
+// ResolutionCompleteEvent is sent once all outputs of a closed channel have
+// been resolved.
+type ResolutionCompleteEvent struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// CloseType is the way the channel was closed.
+	CloseType channeldb.ClosureType
+
+	// ClosingTxid is the hash of the transaction that closed the channel.
+	ClosingTxid chainhash.Hash
+
+	// CommitFee is the fee of the confirmed commitment transaction, as
+	// recorded in our channel state. It's paid by the channel initiator
+	// and zero if unknown, e.g. for breaches.
+	CommitFee ltcutil.Amount
+
+	// SweepFee is the fee we paid for the transactions that spent the
+	// outputs of the commitment, including second-level HTLC transactions,
+	// as recorded in the reports. Each transaction is only counted once,
+	// but its fee is counted in full even if it also swept outputs of
+	// other channels.
+	SweepFee ltcutil.Amount
+
+	// Reports holds the resolutions of all outputs of the channel.
+	Reports []*channeldb.ResolverReport
+}
+
+// TotalFee returns the fee of the on-chain resolution of the channel, which
+// is the fee of the commitment plus the fees of its sweeps.
+func (e *ResolutionCompleteEvent) TotalFee() ltcutil.Amount {
+	return e.CommitFee + e.SweepFee
+}
+
+// sweepFee returns the sum of the fees of the distinct spending transactions
+// of the given reports.
+func sweepFee(reports []*channeldb.ResolverReport) ltcutil.Amount {
+	var (
+		fee     ltcutil.Amount
+		counted = make(map[chainhash.Hash]struct{})
+	)
+	for _, report := range reports {
+		if report.SpendTxID == nil || report.Fee == 0 {
+			continue
+		}
+
+		if _, ok := counted[*report.SpendTxID]; ok {
+			continue
+		}
+		counted[*report.SpendTxID] = struct{}{}
+
+		fee += report.Fee
+	}
+
+	return fee
+}
+
+// resolutionNotifier notifies clients of the progress of the on-chain
+// resolution of channels. Events are served on a best-effort basis; they are
+// not persisted, delivery is not guaranteed and some events may be replayed
+// upon restart.
+type resolutionNotifier struct {
+	started sync.Once
+	stopped sync.Once
+
+	ntfnServer *subscribe.Server
+}
+
+// newResolutionNotifier creates a new resolutionNotifier.
+func newResolutionNotifier() *resolutionNotifier {
+	return &resolutionNotifier{
+		ntfnServer: subscribe.NewServer(),
+	}
+}
+
+// Start starts the notification server.
+func (r *resolutionNotifier) Start() error {
+	var err error
+	r.started.Do(func() {
+		err = r.ntfnServer.Start()
+	})
+
+	return err
+}
+
+// Stop signals the notifier for a graceful shutdown.
+func (r *resolutionNotifier) Stop() error {
+	var err error
+	r.stopped.Do(func() {
+		err = r.ntfnServer.Stop()
+	})
+
+	return err
+}
+
+// subscribe returns a subscribe.Client that receives all resolution events.
+func (r *resolutionNotifier) subscribe() (*subscribe.Client, error) {
+	return r.ntfnServer.Subscribe()
+}
+
+// notify sends the event to all subscribers.
+func (r *resolutionNotifier) notify(event interface{}) {
+	if err := r.ntfnServer.SendUpdate(event); err != nil {
+		log.Warnf("Unable to send resolution event %T: %v", event,
+			err)
+	}
+}
+
+// notifyChannelClosed sends the event matching the close of a channel. Only
+// force closes and breaches are resolved on chain, so cooperative closes are
+// skipped.
+func (r *resolutionNotifier) notifyChannelClosed(
+	summary *channeldb.ChannelCloseSummary) {
+
+	switch summary.CloseType {
+	case channeldb.LocalForceClose, channeldb.RemoteForceClose:
+		r.notify(&CommitmentConfirmedEvent{
+			ChanPoint:         summary.ChanPoint,
+			CloseType:         summary.CloseType,
+			ClosingTxid:       summary.ClosingTXID,
+			CloseHeight:       summary.CloseHeight,
+			SettledBalance:    summary.SettledBalance,
+			TimeLockedBalance: summary.TimeLockedBalance,
+		})
+
+	case channeldb.BreachClose:
+		r.notify(&BreachDetectedEvent{
+			ChanPoint:    summary.ChanPoint,
+			BreachTxid:   summary.ClosingTXID,
+			BreachHeight: summary.CloseHeight,
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package contractcourt
+
+import (
+	"testing"
+
+	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSweepFee asserts that the fee of every spending transaction is counted
+// once, and that reports without a spend or fee are skipped.
+func TestSweepFee(t *testing.T) {
+	t.Parallel()
+
+	sweepTx := chainhash.Hash{1}
+	timeoutTx := chainhash.Hash{2}
+
+	reports := []*channeldb.ResolverReport{
+		{SpendTxID: &sweepTx, Fee: 1000},
+		{SpendTxID: &timeoutTx, Fee: 300},
+		{SpendTxID: &sweepTx, Fee: 1000},
+		{SpendTxID: &timeoutTx},
+		{Fee: 500},
+	}
+	require.Equal(t, ltcutil.Amount(1300), sweepFee(reports))
+
+	event := &ResolutionCompleteEvent{
+		CommitFee: 250,
+		SweepFee:  sweepFee(reports),
+	}
+	require.Equal(t, ltcutil.Amount(1550), event.TotalFee())
+}
@@ -0,0 +1,459 @@
+package contractcourt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/ltcsuite/lnd/input"
+	"github.com/ltcsuite/lnd/tlv"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+const (
+	// resolverRecordMarker is the first byte of a resolver that is stored
+	// as a TLV record. Resolvers written by older versions start with
+	// their resolver type instead, which never takes this value. They are
+	// rewritten as records by channeldb migration 33, which also keeps
+	// older versions, that would read the marker as an unknown resolver
+	// type, from running on the migrated database.
+	resolverRecordMarker byte = 0xff
+
+	// resolverRecordLegacyVersion is the version of the resolver records
+	// written by channeldb migration 33. They hold the resolver's state,
+	// as it was encoded by older versions, within a single state field.
+	resolverRecordLegacyVersion uint8 = 1
+
+	// resolverRecordVersion is the version of the resolver records that
+	// are written by this version. They hold every field of the
+	// resolver's state as an individual TLV record. It needs to be bumped
+	// whenever the encoding of a resolver's state changes in a way older
+	// versions can't decode.
+	resolverRecordVersion uint8 = 2
+)
+
+// The TLV types of a resolver record. New fields must use odd types, so older
+// versions skip them, unless they can't resolve the contract without them.
+const (
+	resolverRecordVersionType tlv.Type = 0
+	resolverRecordTypeType    tlv.Type = 2
+	resolverRecordStateType   tlv.Type = 4
+)
+
+// The TLV types of the fields of a resolver's state. They are stored within
+// the stream of the resolver record, so they must be larger than the types of
+// the record itself. Every resolver only stores the fields that apply to it.
+const (
+	resolverResolutionType      tlv.Type = 10
+	resolverIncubatingType      tlv.Type = 12
+	resolverResolvedType        tlv.Type = 14
+	resolverBroadcastHeightType tlv.Type = 16
+	resolverHtlcIndexType       tlv.Type = 18
+	resolverPaymentHashType     tlv.Type = 20
+	resolverHtlcExpiryType      tlv.Type = 22
+	resolverChanPointType       tlv.Type = 24
+	resolverSignDetailsType     tlv.Type = 26
+	resolverCommitSpendFeeType  tlv.Type = 27
+)
+
+// resolverRecord is the envelope a contract resolver is stored in within the
+// arbitrator log. It tags the resolver's state with its type and the version
+// of its encoding.
+type resolverRecord struct {
+	// version is the encoding version of the resolver's state.
+	version uint8
+
+	// rType is the type of the resolver.
+	rType resolverType
+
+	// state is the resolver's state. For records of the legacy version,
+	// it is the state as written by older versions. Otherwise, it is the
+	// TLV stream of the resolver's fields as written by its Encode
+	// method.
+	state []byte
+}
+
+// encode writes the resolver record, prefixed with resolverRecordMarker, to
+// the passed writer.
+func (r *resolverRecord) encode(w io.Writer) error {
+	rType := uint8(r.rType)
+	records := []tlv.Record{
+		tlv.MakePrimitiveRecord(resolverRecordVersionType, &r.version),
+		tlv.MakePrimitiveRecord(resolverRecordTypeType, &rType),
+	}
+	if r.version == resolverRecordLegacyVersion {
+		records = append(records, tlv.MakePrimitiveRecord(
+			resolverRecordStateType, &r.state,
+		))
+	}
+
+	stream, err := tlv.NewStream(records...)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write([]byte{resolverRecordMarker}); err != nil {
+		return err
+	}
+
+	if err := stream.Encode(w); err != nil {
+		return err
+	}
+
+	// The fields of the resolver's state follow the fields of the record
+	// within the same stream, which stays canonical as their types are
+	// all larger.
+	if r.version == resolverRecordLegacyVersion {
+		return nil
+	}
+
+	_, err = w.Write(r.state)
+
+	return err
+}
+
+// decodeResolverRecord decodes the raw bytes of a stored resolver.
+func decodeResolverRecord(b []byte) (*resolverRecord, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty resolver record")
+	}
+
+	// Resolvers written before the TLV record was introduced have been
+	// migrated, so we don't expect to find any of them.
+	if b[0] != resolverRecordMarker {
+		return nil, fmt.Errorf("resolver record without marker, "+
+			"found type %v", b[0])
+	}
+
+	var (
+		r     resolverRecord
+		rType uint8
+	)
+	stream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(resolverRecordVersionType, &r.version),
+		tlv.MakePrimitiveRecord(resolverRecordTypeType, &rType),
+		tlv.MakePrimitiveRecord(resolverRecordStateType, &r.state),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedTypes, err := stream.DecodeWithParsedTypes(bytes.NewReader(b[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, typ := range []tlv.Type{
+		resolverRecordVersionType, resolverRecordTypeType,
+	} {
+
+		if _, ok := parsedTypes[typ]; !ok {
+			return nil, fmt.Errorf("resolver record is missing "+
+				"type %v", typ)
+		}
+	}
+	r.rType = resolverType(rType)
+
+	// Refuse to decode resolvers written by a newer version, as we might
+	// otherwise misinterpret their state.
+	if r.version > resolverRecordVersion {
+		return nil, fmt.Errorf("unknown resolver record version %v, "+
+			"latest known version is %v", r.version,
+			resolverRecordVersion)
+	}
+
+	// The fields of the resolver's state are part of the record's stream,
+	// so the resolver decodes it once more and checks for unknown
+	// required fields itself.
+	if r.version != resolverRecordLegacyVersion {
+		if _, ok := parsedTypes[resolverRecordStateType]; ok {
+			return nil, fmt.Errorf("resolver record of version "+
+				"%v contains legacy state", r.version)
+		}
+		r.state = b[1:]
+
+		return &r, nil
+	}
+
+	if _, ok := parsedTypes[resolverRecordStateType]; !ok {
+		return nil, fmt.Errorf("resolver record is missing type %v",
+			resolverRecordStateType)
+	}
+
+	// Any other even type is a field we'd need to understand to resolve
+	// the contract correctly.
+	for typ := range parsedTypes {
+		switch typ {
+		case resolverRecordVersionType, resolverRecordTypeType,
+			resolverRecordStateType:
+
+			continue
+		}
+
+		if typ%2 == 0 {
+			return nil, fmt.Errorf("resolver record contains "+
+				"unknown required type %v", typ)
+		}
+	}
+
+	return &r, nil
+}
+
+// decodeResolver creates the resolver that is stored in the given record.
+func decodeResolver(record *resolverRecord,
+	resCfg ResolverConfig) (ContractResolver, error) {
+
+	r := bytes.NewReader(record.state)
+
+	if record.version == resolverRecordLegacyVersion {
+		switch record.rType {
+		case resolverTimeout:
+			return newTimeoutResolverFromLegacyReader(r, resCfg)
+
+		case resolverSuccess:
+			return newSuccessResolverFromLegacyReader(r, resCfg)
+
+		case resolverOutgoingContest:
+			return newOutgoingContestResolverFromLegacyReader(
+				r, resCfg,
+			)
+
+		case resolverIncomingContest:
+			return newIncomingContestResolverFromLegacyReader(
+				r, resCfg,
+			)
+
+		case resolverUnilateralSweep:
+			return newCommitSweepResolverFromLegacyReader(
+				r, resCfg,
+			)
+
+		case resolverBreach:
+			return newBreachResolverFromLegacyReader(r, resCfg)
+		}
+
+		return nil, fmt.Errorf("unknown resolver type: %v",
+			record.rType)
+	}
+
+	switch record.rType {
+	case resolverTimeout:
+		return newTimeoutResolverFromReader(r, resCfg)
+
+	case resolverSuccess:
+		return newSuccessResolverFromReader(r, resCfg)
+
+	case resolverOutgoingContest:
+		return newOutgoingContestResolverFromReader(r, resCfg)
+
+	case resolverIncomingContest:
+		return newIncomingContestResolverFromReader(r, resCfg)
+
+	case resolverUnilateralSweep:
+		return newCommitSweepResolverFromReader(r, resCfg)
+
+	case resolverBreach:
+		return newBreachResolverFromReader(r, resCfg)
+	}
+
+	return nil, fmt.Errorf("unknown resolver type: %v", record.rType)
+}
+
+// encodeResolverState writes the given fields of a resolver's state as a TLV
+// stream.
+func encodeResolverState(w io.Writer, records ...tlv.Record) error {
+	tlv.SortRecords(records)
+
+	stream, err := tlv.NewStream(records...)
+	if err != nil {
+		return err
+	}
+
+	return stream.Encode(w)
+}
+
+// decodeResolverState decodes the given fields of a resolver's state from a
+// TLV stream. All fields of even types must be present. Unknown fields of even
+// types hold state we'd need to resolve the contract correctly, so they are
+// refused. The fields of the resolver record the state may be embedded in are
+// skipped.
+func decodeResolverState(r io.Reader, records ...tlv.Record) error {
+	tlv.SortRecords(records)
+
+	stream, err := tlv.NewStream(records...)
+	if err != nil {
+		return err
+	}
+
+	parsedTypes, err := stream.DecodeWithParsedTypes(r)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[tlv.Type]struct{}, len(records))
+	for _, record := range records {
+		typ := record.Type()
+		known[typ] = struct{}{}
+
+		if _, ok := parsedTypes[typ]; !ok && typ%2 == 0 {
+			return fmt.Errorf("resolver state is missing type %v",
+				typ)
+		}
+	}
+
+	for typ := range parsedTypes {
+		if _, ok := known[typ]; ok || typ%2 != 0 {
+			continue
+		}
+
+		switch typ {
+		case resolverRecordVersionType, resolverRecordTypeType:
+			continue
+		}
+
+		return fmt.Errorf("resolver state contains unknown required "+
+			"type %v", typ)
+	}
+
+	return nil
+}
+
+// boolRecord returns a TLV record for the given bool.
+func boolRecord(typ tlv.Type, b *bool) tlv.Record {
+	return tlv.MakeStaticRecord(typ, b, 1, eBool, dBool)
+}
+
+// amountRecord returns a TLV record for the given amount.
+func amountRecord(typ tlv.Type, amt *ltcutil.Amount) tlv.Record {
+	return tlv.MakeStaticRecord(typ, amt, 8, eAmount, dAmount)
+}
+
+// eAmount encodes an amount as an uint64.
+func eAmount(w io.Writer, val interface{}, buf *[8]byte) error {
+	if amt, ok := val.(*ltcutil.Amount); ok {
+		return tlv.EUint64T(w, uint64(*amt), buf)
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "ltcutil.Amount")
+}
+
+// dAmount decodes an amount from an uint64.
+func dAmount(r io.Reader, val interface{}, buf *[8]byte, l uint64) error {
+	if amt, ok := val.(*ltcutil.Amount); ok && l == 8 {
+		var v uint64
+		if err := tlv.DUint64(r, &v, buf, l); err != nil {
+			return err
+		}
+
+		*amt = ltcutil.Amount(v)
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(val, "ltcutil.Amount", l, 8)
+}
+
+// eBool encodes a bool as a single byte.
+func eBool(w io.Writer, val interface{}, buf *[8]byte) error {
+	if b, ok := val.(*bool); ok {
+		var v uint8
+		if *b {
+			v = 1
+		}
+
+		return tlv.EUint8T(w, v, buf)
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "bool")
+}
+
+// dBool decodes a bool from a single byte.
+func dBool(r io.Reader, val interface{}, buf *[8]byte, l uint64) error {
+	if b, ok := val.(*bool); ok && l == 1 {
+		var v uint8
+		if err := tlv.DUint8(r, &v, buf, l); err != nil {
+			return err
+		}
+
+		*b = v != 0
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(val, "bool", l, 1)
+}
+
+// encodedRecord returns a TLV record for a value that is serialized with the
+// given encode and decode functions. It is used for the parts of a resolver's
+// state that already have an encoding of their own.
+func encodedRecord(typ tlv.Type, encode func(io.Writer) error,
+	decode func(io.Reader) error) tlv.Record {
+
+	size := func() uint64 {
+		var b bytes.Buffer
+		if err := encode(&b); err != nil {
+			return 0
+		}
+
+		return uint64(b.Len())
+	}
+
+	encoder := func(w io.Writer, _ interface{}, _ *[8]byte) error {
+		return encode(w)
+	}
+
+	decoder := func(r io.Reader, _ interface{}, _ *[8]byte,
+		l uint64) error {
+
+		value := make([]byte, l)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return err
+		}
+
+		valueReader := bytes.NewReader(value)
+		if err := decode(valueReader); err != nil {
+			return err
+		}
+
+		if valueReader.Len() != 0 {
+			return fmt.Errorf("%v trailing bytes in resolver "+
+				"state type %v", valueReader.Len(), typ)
+		}
+
+		return nil
+	}
+
+	return tlv.MakeDynamicRecord(typ, nil, size, encoder, decoder)
+}
+
+// signDetailsRecord returns a TLV record for the optional sign details of an
+// HTLC resolution.
+func signDetailsRecord(signDetails **input.SignDetails) tlv.Record {
+	return encodedRecord(resolverSignDetailsType, func(w io.Writer) error {
+		return encodeSignDetails(w, *signDetails)
+	}, func(r io.Reader) error {
+		var err error
+		*signDetails, err = decodeSignDetails(r)
+
+		return err
+	})
+}
+
+// outPointRecord returns a TLV record for the given outpoint.
+func outPointRecord(typ tlv.Type, op *wire.OutPoint) tlv.Record {
+	return encodedRecord(typ, func(w io.Writer) error {
+		if _, err := w.Write(op.Hash[:]); err != nil {
+			return err
+		}
+
+		return binary.Write(w, endian, op.Index)
+	}, func(r io.Reader) error {
+		if _, err := io.ReadFull(r, op.Hash[:]); err != nil {
+			return err
+		}
+
+		return binary.Read(r, endian, &op.Index)
+	})
+}
@@ -0,0 +1,119 @@
+package contractcourt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ltcsuite/lnd/tlv"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeTestRecord encodes a resolver record holding the given state.
+func encodeTestRecord(t *testing.T, version uint8, rType resolverType,
+	state []byte) []byte {
+
+	record := &resolverRecord{
+		version: version,
+		rType:   rType,
+		state:   state,
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, record.encode(&b))
+	require.Equal(t, resolverRecordMarker, b.Bytes()[0])
+
+	return b.Bytes()
+}
+
+// decodeTestRecord decodes a resolver record and the resolver it holds.
+func decodeTestRecord(b []byte) (ContractResolver, error) {
+	record, err := decodeResolverRecord(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResolver(record, ResolverConfig{})
+}
+
+// TestResolverRecordEncoding asserts that resolvers survive a round trip
+// through their record, that records written by migration 33 can still be
+// decoded, and that records of unknown versions or with unknown required
+// fields are refused.
+func TestResolverRecordEncoding(t *testing.T) {
+	t.Parallel()
+
+	resolver := &breachResolver{resolved: true}
+
+	var state bytes.Buffer
+	require.NoError(t, resolver.Encode(&state))
+
+	b := encodeTestRecord(
+		t, resolverRecordVersion, resolverBreach, state.Bytes(),
+	)
+
+	decoded, err := decodeTestRecord(b)
+	require.NoError(t, err)
+	require.IsType(t, &breachResolver{}, decoded)
+	require.True(t, decoded.(*breachResolver).resolved)
+
+	// A record of the legacy version holds the state as it was encoded by
+	// older versions within a single field.
+	b = encodeTestRecord(
+		t, resolverRecordLegacyVersion, resolverBreach, []byte{1},
+	)
+
+	decoded, err = decodeTestRecord(b)
+	require.NoError(t, err)
+	require.IsType(t, &breachResolver{}, decoded)
+	require.True(t, decoded.(*breachResolver).resolved)
+
+	// A record of the current version must not hold a legacy state. The
+	// version is the value of the first field, following the marker, the
+	// type and the length.
+	b[3] = resolverRecordVersion
+	_, err = decodeResolverRecord(b)
+	require.ErrorContains(t, err, "contains legacy state")
+
+	// A legacy record, which is the resolver type followed by its state,
+	// should have been migrated, so it is refused.
+	_, err = decodeResolverRecord([]byte{byte(resolverBreach), 4, 5})
+	require.ErrorContains(t, err, "resolver record without marker")
+
+	// encodeWithExtra encodes the resolver with an additional field, as a
+	// future version might write it.
+	encodeWithExtra := func(version uint8, extraType tlv.Type) []byte {
+		extra := uint8(9)
+		stream, err := tlv.NewStream(
+			tlv.MakePrimitiveRecord(extraType, &extra),
+		)
+		require.NoError(t, err)
+
+		var extraState bytes.Buffer
+		require.NoError(t, stream.Encode(&extraState))
+
+		return encodeTestRecord(
+			t, version, resolverBreach,
+			append(state.Bytes(), extraState.Bytes()...),
+		)
+	}
+
+	// Unknown odd fields are skipped.
+	decoded, err = decodeTestRecord(
+		encodeWithExtra(resolverRecordVersion, 31),
+	)
+	require.NoError(t, err)
+	require.True(t, decoded.(*breachResolver).resolved)
+
+	// Unknown even fields are required, so the resolver is refused.
+	_, err = decodeTestRecord(encodeWithExtra(resolverRecordVersion, 30))
+	require.Error(t, err)
+
+	// Records of a newer version are refused as well.
+	_, err = decodeTestRecord(encodeWithExtra(resolverRecordVersion+1, 31))
+	require.ErrorContains(t, err, "unknown resolver record version")
+
+	// Fields the resolver relies on are required.
+	b = encodeTestRecord(t, resolverRecordVersion, resolverBreach, nil)
+	_, err = decodeTestRecord(b)
+	require.Error(t, err)
+}
@@ -0,0 +1,180 @@
+package contractcourt
+
+import (
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+// ResolverKind identifies the type of a contract resolver.
+type ResolverKind uint8
+
+const (
+	// ResolverKindUnknown is used for resolvers of an unknown type.
+	ResolverKindUnknown ResolverKind = iota
+
+	// ResolverKindCommitSweep denotes a resolver that sweeps our output
+	// of a commitment transaction.
+	ResolverKindCommitSweep
+
+	// ResolverKindAnchor denotes a resolver that sweeps an anchor output.
+	ResolverKindAnchor
+
+	// ResolverKindHtlcTimeout denotes a resolver that claims an outgoing
+	// HTLC after its timeout.
+	ResolverKindHtlcTimeout
+
+	// ResolverKindHtlcOutgoingContest denotes a resolver that waits for an
+	// outgoing HTLC to either time out or be claimed by the remote party.
+	ResolverKindHtlcOutgoingContest
+
+	// ResolverKindHtlcSuccess denotes a resolver that claims an incoming
+	// HTLC with its preimage.
+	ResolverKindHtlcSuccess
+
+	// ResolverKindHtlcIncomingContest denotes a resolver that waits for
+	// the preimage of an incoming HTLC to become available.
+	ResolverKindHtlcIncomingContest
+
+	// ResolverKindBreach denotes a resolver that waits for the breach
+	// arbiter to finish handling a breach.
+	ResolverKindBreach
+)
+
+// String returns a human readable name of the resolver kind.
+func (k ResolverKind) String() string {
+	switch k {
+	case ResolverKindCommitSweep:
+		return "CommitSweep"
+
+	case ResolverKindAnchor:
+		return "Anchor"
+
+	case ResolverKindHtlcTimeout:
+		return "HtlcTimeout"
+
+	case ResolverKindHtlcOutgoingContest:
+		return "HtlcOutgoingContest"
+
+	case ResolverKindHtlcSuccess:
+		return "HtlcSuccess"
+
+	case ResolverKindHtlcIncomingContest:
+		return "HtlcIncomingContest"
+
+	case ResolverKindBreach:
+		return "Breach"
+
+	default:
+		return "Unknown"
+	}
+}
+
+// resolverKind returns the kind of the given resolver.
+func resolverKind(resolver ContractResolver) ResolverKind {
+	// The contest resolvers embed the resolvers they hand over to, so they
+	// need to be matched by their own type.
+	switch resolver.(type) {
+	case *commitSweepResolver:
+		return ResolverKindCommitSweep
+
+	case *anchorResolver:
+		return ResolverKindAnchor
+
+	case *htlcOutgoingContestResolver:
+		return ResolverKindHtlcOutgoingContest
+
+	case *htlcTimeoutResolver:
+		return ResolverKindHtlcTimeout
+
+	case *htlcIncomingContestResolver:
+		return ResolverKindHtlcIncomingContest
+
+	case *htlcSuccessResolver:
+		return ResolverKindHtlcSuccess
+
+	case *breachResolver:
+		return ResolverKindBreach
+
+	default:
+		return ResolverKindUnknown
+	}
+}
+
+// ResolverState describes the current state of an active contract resolver.
+type ResolverState struct {
+	// Kind is the type of the resolver.
+	Kind ResolverKind
+
+	// Report is the latest report of the resolver. It is nil if the
+	// resolver doesn't report on its progress.
+	Report *ContractReport
+
+	// HtlcPoint is the outpoint of the HTLC output on the commitment
+	// transaction. It is only set for HTLC resolvers.
+	HtlcPoint *wire.OutPoint
+
+	// DeadlineHeight is the block height by which the resolution should
+	// be confirmed. A value of zero means there's no deadline.
+	DeadlineHeight uint32
+}
+
+// ResolverStates returns the state of all active contract resolvers of the
+// channel.
+func (c *ChannelArbitrator) ResolverStates() []*ResolverState {
+	c.activeResolversLock.RLock()
+	defer c.activeResolversLock.RUnlock()
+
+	states := make([]*ResolverState, 0, len(c.activeResolvers))
+	for _, resolver := range c.activeResolvers {
+		state := &ResolverState{
+			Kind: resolverKind(resolver),
+		}
+
+		if r, ok := resolver.(reportingContractResolver); ok {
+			state.Report = r.report()
+		}
+
+		if r, ok := resolver.(htlcContractResolver); ok {
+			htlcPoint := r.HtlcPoint()
+			state.HtlcPoint = &htlcPoint
+		}
+
+		// Incoming HTLCs must be claimed before they expire, as the
+		// remote party can time them out afterwards. Forwarded
+		// outgoing HTLCs must be timed out before the incoming HTLC
+		// expires, so we can fail it back.
+		switch r := resolver.(type) {
+		case *htlcIncomingContestResolver:
+			state.DeadlineHeight = uint32(r.deadlineHeight())
+
+		case *htlcSuccessResolver:
+			state.DeadlineHeight = uint32(r.deadlineHeight())
+
+		case *htlcOutgoingContestResolver:
+			state.DeadlineHeight = c.timeoutDeadline(
+				r.htlcTimeoutResolver,
+			)
+
+		case *htlcTimeoutResolver:
+			state.DeadlineHeight = c.timeoutDeadline(r)
+		}
+
+		states = append(states, state)
+	}
+
+	return states
+}
+
+// timeoutDeadline returns the deadline of the given timeout resolver, or zero
+// if it can't be determined.
+func (c *ChannelArbitrator) timeoutDeadline(r *htlcTimeoutResolver) uint32 {
+	deadline, err := r.deadlineHeight()
+	if err != nil {
+		log.Warnf("ChannelArbitrator(%v): unable to determine "+
+			"deadline of htlc %v: %v", c.cfg.ChanPoint,
+			r.htlc.HtlcIndex, err)
+
+		return 0
+	}
+
+	return uint32(deadline)
+}
@@ -0,0 +1,99 @@
+package contractcourt
+
+import (
+	"testing"
+
+	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/input"
+	"github.com/ltcsuite/lnd/lnwallet"
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChannelArbitratorResolverStates asserts that the state of every active
+// resolver is reported, including its kind, outpoints and deadline.
+func TestChannelArbitratorResolverStates(t *testing.T) {
+	t.Parallel()
+
+	// The outgoing HTLC was forwarded from an incoming HTLC that expires
+	// at height 450.
+	var resCfg ResolverConfig
+	resCfg.IncomingHtlcExpiry = func(lnwire.ShortChannelID,
+		uint64) (uint32, error) {
+
+		return 450, nil
+	}
+
+	commitPoint := wire.OutPoint{Index: 0}
+	commitResolver := newCommitSweepResolver(
+		lnwallet.CommitOutputResolution{
+			SelfOutPoint: commitPoint,
+			SelfOutputSignDesc: input.SignDescriptor{
+				Output: &wire.TxOut{Value: 50_000},
+			},
+		}, 0, wire.OutPoint{}, resCfg,
+	)
+
+	incomingPoint := wire.OutPoint{Index: 1}
+	incomingResolver := newIncomingContestResolver(
+		lnwallet.IncomingHtlcResolution{
+			ClaimOutpoint: incomingPoint,
+		}, 0, channeldb.HTLC{
+			Amt:           lnwire.NewMSatFromSatoshis(20_000),
+			RefundTimeout: 500,
+		}, resCfg,
+	)
+
+	outgoingPoint := wire.OutPoint{Index: 2}
+	outgoingResolver := newOutgoingContestResolver(
+		lnwallet.OutgoingHtlcResolution{
+			ClaimOutpoint: outgoingPoint,
+			Expiry:        400,
+		}, 0, channeldb.HTLC{
+			Amt:           lnwire.NewMSatFromSatoshis(30_000),
+			RefundTimeout: 400,
+		}, resCfg,
+	)
+
+	arb := &ChannelArbitrator{
+		activeResolvers: []ContractResolver{
+			commitResolver, incomingResolver, outgoingResolver,
+			newBreachResolver(resCfg),
+		},
+	}
+
+	states := arb.ResolverStates()
+	require.Len(t, states, 4)
+
+	// The commitment output has no deadline and isn't an HTLC.
+	require.Equal(t, ResolverKindCommitSweep, states[0].Kind)
+	require.Equal(t, commitPoint, states[0].Report.Outpoint)
+	require.Equal(t, ltcutil.Amount(50_000), states[0].Report.Amount)
+	require.Nil(t, states[0].HtlcPoint)
+	require.Zero(t, states[0].DeadlineHeight)
+
+	// The incoming HTLC must be claimed before it expires.
+	require.Equal(t, ResolverKindHtlcIncomingContest, states[1].Kind)
+	require.Equal(t, incomingPoint, states[1].Report.Outpoint)
+	require.Equal(t, &incomingPoint, states[1].HtlcPoint)
+	require.EqualValues(
+		t, 500-htlcDeadlineDelta, states[1].DeadlineHeight,
+	)
+
+	// The outgoing HTLC must be timed out before the incoming HTLC
+	// expires.
+	require.Equal(t, ResolverKindHtlcOutgoingContest, states[2].Kind)
+	require.Equal(t, outgoingPoint, states[2].Report.Outpoint)
+	require.Equal(t, ltcutil.Amount(30_000), states[2].Report.Amount)
+	require.Equal(t, &outgoingPoint, states[2].HtlcPoint)
+	require.EqualValues(
+		t, 450-htlcDeadlineDelta, states[2].DeadlineHeight,
+	)
+
+	// The breach resolver doesn't report on any outputs.
+	require.Equal(t, ResolverKindBreach, states[3].Kind)
+	require.Nil(t, states[3].Report)
+	require.Nil(t, states[3].HtlcPoint)
+}
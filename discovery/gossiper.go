@@ -56,6 +56,10 @@ const (
 	// broadcasting the next announcement batch.
 	DefaultSubBatchDelay = 5 * time.Second
 
+	// DefaultNodeAnnRebroadcastInterval is the default interval after
+	// which we refresh and rebroadcast our own node announcement.
+	DefaultNodeAnnRebroadcastInterval = 24 * time.Hour
+
 	// maxRejectedUpdates tracks the max amount of rejected channel updates
 	// we'll maintain. This is the global size across all peers. We'll
 	// allocate ~3 MB max to the cache.
@@ -233,6 +237,11 @@ type Config struct {
 	// having zombie channels.
 	RebroadcastInterval time.Duration
 
+	// NodeAnnRebroadcast determines when our own node announcement is
+	// stale and needs to be refreshed and rebroadcast. If nil, the node
+	// announcement is rebroadcast every RebroadcastInterval.
+	NodeAnnRebroadcast *netann.NodeAnnRebroadcastSchedule
+
 	// WaitingProofStore is a persistent storage of partial channel proof
 	// announcement messages. We use it to buffer half of the material
 	// needed to reconstruct a full authenticated channel announcement.
@@ -260,6 +269,11 @@ type Config struct {
 	// gossip syncers will be passive.
 	NumActiveSyncers int
 
+	// NumHistoricalSyncers is the number of peers that we perform the
+	// initial historical sync with in parallel. Each channel is only
+	// queried from one of these peers.
+	NumHistoricalSyncers int
+
 	// RotateTicker is a ticker responsible for notifying the SyncManager
 	// when it should rotate its active syncers. A single active syncer with
 	// a chansSynced state will be exchanged for a passive syncer in order
@@ -509,6 +523,7 @@ func New(cfg Config, selfKeyDesc *keychain.KeyDescriptor) *AuthenticatedGossiper
 		RotateTicker:            cfg.RotateTicker,
 		HistoricalSyncTicker:    cfg.HistoricalSyncTicker,
 		NumActiveSyncers:        cfg.NumActiveSyncers,
+		NumHistoricalSyncers:    cfg.NumHistoricalSyncers,
 		IgnoreHistoricalFilters: cfg.IgnoreHistoricalFilters,
 		BestHeight:              gossiper.latestHeight,
 		PinnedSyncers:           cfg.PinnedSyncers,
@@ -1664,13 +1679,21 @@ func (d *AuthenticatedGossiper) retransmitStaleAnns(now time.Time) error {
 
 	// We'll also check that our NodeAnnouncement is not too old.
 	currentNodeAnn := d.cfg.FetchSelfAnnouncement()
-	timestamp := time.Unix(int64(currentNodeAnn.Timestamp), 0)
-	timeElapsed := now.Sub(timestamp)
 
-	// If it's been a full day since we've re-broadcasted the
-	// node announcement, refresh it and resend it.
+	var nodeAnnStale bool
+	if d.cfg.NodeAnnRebroadcast != nil {
+		nodeAnnStale = d.cfg.NodeAnnRebroadcast.IsStale(
+			currentNodeAnn.Timestamp, now,
+		)
+	} else {
+		timestamp := time.Unix(int64(currentNodeAnn.Timestamp), 0)
+		nodeAnnStale = now.Sub(timestamp) >= d.cfg.RebroadcastInterval
+	}
+
+	// If our node announcement has become stale, refresh it and resend
+	// it.
 	nodeAnnStr := ""
-	if timeElapsed >= d.cfg.RebroadcastInterval {
+	if nodeAnnStale {
 		newNodeAnn, err := d.cfg.UpdateSelfAnnouncement()
 		if err != nil {
 			return fmt.Errorf("unable to get refreshed node "+
@@ -1686,6 +1709,10 @@ func (d *AuthenticatedGossiper) retransmitStaleAnns(now time.Time) error {
 			log.Errorf("Unable to add refreshed node announcement "+
 				"to graph: %v", err)
 		}
+
+		if d.cfg.NodeAnnRebroadcast != nil {
+			d.cfg.NodeAnnRebroadcast.Rebroadcasted()
+		}
 	}
 
 	// If we don't have any updates to re-broadcast, then we'll exit
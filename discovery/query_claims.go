@@ -0,0 +1,82 @@
+package discovery
+
+import (
+	"sync"
+
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/routing/route"
+)
+
+// chanQueryClaims keeps track of the channels that we're querying from our
+// peers while performing historical syncs with several of them in parallel.
+// Every channel is only claimed by a single peer, which ensures that we don't
+// request the same channel announcements from multiple peers.
+type chanQueryClaims struct {
+	// claims maps a channel to the peer that we're querying it from.
+	claims map[lnwire.ShortChannelID]route.Vertex
+
+	// peerClaims maps a peer to the channels that we're querying from it.
+	peerClaims map[route.Vertex][]lnwire.ShortChannelID
+
+	mu sync.Mutex
+}
+
+// newChanQueryClaims returns a new, empty set of channel query claims.
+func newChanQueryClaims() *chanQueryClaims {
+	return &chanQueryClaims{
+		claims:     make(map[lnwire.ShortChannelID]route.Vertex),
+		peerClaims: make(map[route.Vertex][]lnwire.ShortChannelID),
+	}
+}
+
+// claim claims the given channels for the peer and returns the ones that it
+// should query. Channels that are already claimed by other peers are filtered
+// out.
+func (c *chanQueryClaims) claim(peer route.Vertex,
+	chanIDs []lnwire.ShortChannelID) []lnwire.ShortChannelID {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	unclaimed := make([]lnwire.ShortChannelID, 0, len(chanIDs))
+	for _, chanID := range chanIDs {
+		owner, ok := c.claims[chanID]
+		switch {
+		// We're already querying the channel from another peer, so
+		// we can skip it.
+		case ok && owner != peer:
+			continue
+
+		// The channel is newly claimed by this peer.
+		case !ok:
+			c.claims[chanID] = peer
+			c.peerClaims[peer] = append(c.peerClaims[peer], chanID)
+		}
+
+		unclaimed = append(unclaimed, chanID)
+	}
+
+	return unclaimed
+}
+
+// release releases all channels that were claimed by the peer, either because
+// we have received them from the peer, or because the peer disconnected. In
+// the latter case, the channels can be claimed by the peer that replaces it.
+func (c *chanQueryClaims) release(peer route.Vertex) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, chanID := range c.peerClaims[peer] {
+		delete(c.claims, chanID)
+	}
+	delete(c.peerClaims, peer)
+}
+
+// reset releases the channels claimed by all peers.
+func (c *chanQueryClaims) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.claims = make(map[lnwire.ShortChannelID]route.Vertex)
+	c.peerClaims = make(map[route.Vertex][]lnwire.ShortChannelID)
+}
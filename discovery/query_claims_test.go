@@ -0,0 +1,62 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChanQueryClaims tests that every channel is only claimed by a single
+// peer, and that released channels can be claimed by other peers.
+func TestChanQueryClaims(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peer1 = route.Vertex{1}
+		peer2 = route.Vertex{2}
+
+		chan1 = lnwire.NewShortChanIDFromInt(1)
+		chan2 = lnwire.NewShortChanIDFromInt(2)
+		chan3 = lnwire.NewShortChanIDFromInt(3)
+	)
+
+	claims := newChanQueryClaims()
+
+	// The first peer claims all channels, as none are claimed yet.
+	require.Equal(t, []lnwire.ShortChannelID{chan1, chan2}, claims.claim(
+		peer1, []lnwire.ShortChannelID{chan1, chan2},
+	))
+
+	// The second peer only gets the channel that isn't queried from the
+	// first peer.
+	require.Equal(t, []lnwire.ShortChannelID{chan3}, claims.claim(
+		peer2, []lnwire.ShortChannelID{chan1, chan2, chan3},
+	))
+
+	// Claiming a channel again for the same peer returns it again.
+	require.Equal(t, []lnwire.ShortChannelID{chan1}, claims.claim(
+		peer1, []lnwire.ShortChannelID{chan1, chan3},
+	))
+
+	// Once the first peer releases its channels, they can be claimed by
+	// the second peer.
+	claims.release(peer1)
+	require.Equal(t, []lnwire.ShortChannelID{chan1, chan2, chan3},
+		claims.claim(
+			peer2, []lnwire.ShortChannelID{chan1, chan2, chan3},
+		),
+	)
+
+	claims.release(peer2)
+	require.Empty(t, claims.claims)
+	require.Empty(t, claims.peerClaims)
+
+	// Resetting the claims releases the channels of all peers.
+	claims.claim(peer1, []lnwire.ShortChannelID{chan1})
+	claims.claim(peer2, []lnwire.ShortChannelID{chan2})
+	claims.reset()
+	require.Empty(t, claims.claims)
+	require.Empty(t, claims.peerClaims)
+}
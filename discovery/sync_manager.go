@@ -22,6 +22,10 @@ const (
 	// force a historical sync to ensure we have as much of the public
 	// network as possible.
 	DefaultHistoricalSyncInterval = time.Hour
+
+	// DefaultNumHistoricalSyncers is the default number of peers that we
+	// perform the initial historical sync with.
+	DefaultNumHistoricalSyncers = 1
 )
 
 var (
@@ -73,6 +77,13 @@ type SyncManagerCfg struct {
 	// gossip syncers will be passive.
 	NumActiveSyncers int
 
+	// NumHistoricalSyncers is the number of peers that we perform the
+	// initial historical sync with in parallel. The channels that are
+	// unknown to us are split among these peers, so that each of them is
+	// only queried from a single peer. If zero, the initial historical
+	// sync is performed with a single peer.
+	NumHistoricalSyncers int
+
 	// RotateTicker is a ticker responsible for notifying the SyncManager
 	// when it should rotate its active syncers. A single active syncer with
 	// a chansSynced state will be exchanged for a passive syncer in order
@@ -119,6 +130,14 @@ type SyncManager struct {
 	// NOTE: This must be used atomically.
 	initialHistoricalSyncCompleted int32
 
+	// numInitialHistoricalSyncs is the number of initial historical syncs
+	// that are currently in progress. While more than one of them is in
+	// progress, a syncer completing its historical sync doesn't mark the
+	// graph as synced.
+	//
+	// NOTE: This must be used atomically.
+	numInitialHistoricalSyncs int32
+
 	start sync.Once
 	stop  sync.Once
 
@@ -151,12 +170,23 @@ type SyncManager struct {
 	// duration of the connection.
 	pinnedActiveSyncers map[route.Vertex]*GossipSyncer
 
+	// chanClaims keeps track of the channels that are queried from each of
+	// the peers we're performing the initial historical sync with. It is
+	// nil unless we perform the initial historical sync with several
+	// peers, and is reset once it has completed.
+	chanClaims *chanQueryClaims
+
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
 
 // newSyncManager constructs a new SyncManager backed by the given config.
 func newSyncManager(cfg *SyncManagerCfg) *SyncManager {
+	var chanClaims *chanQueryClaims
+	if cfg.NumHistoricalSyncers > 1 {
+		chanClaims = newChanQueryClaims()
+	}
+
 	return &SyncManager{
 		cfg:          *cfg,
 		newSyncers:   make(chan *newSyncer),
@@ -168,7 +198,8 @@ func newSyncManager(cfg *SyncManagerCfg) *SyncManager {
 		pinnedActiveSyncers: make(
 			map[route.Vertex]*GossipSyncer, len(cfg.PinnedSyncers),
 		),
-		quit: make(chan struct{}),
+		chanClaims: chanClaims,
+		quit:       make(chan struct{}),
 	}
 }
 
@@ -218,21 +249,35 @@ func (m *SyncManager) syncerHandler() {
 	defer m.cfg.HistoricalSyncTicker.Stop()
 
 	var (
-		// initialHistoricalSyncer is the syncer we are currently
-		// performing an initial historical sync with.
-		initialHistoricalSyncer *GossipSyncer
-
-		// initialHistoricalSyncSignal is a signal that will fire once
-		// the initial historical sync has been completed. This is
-		// crucial to ensure that another historical sync isn't
-		// attempted just because the initialHistoricalSyncer was
+		// initialHistoricalSyncers is the set of syncers we are
+		// currently performing an initial historical sync with.
+		initialHistoricalSyncers = make(
+			map[route.Vertex]*GossipSyncer,
+		)
+
+		// initialHistoricalSyncDone receives the syncers once their
+		// initial historical sync has been completed. This is crucial
+		// to ensure that another historical sync isn't attempted just
+		// because one of the initialHistoricalSyncers was
 		// disconnected.
-		initialHistoricalSyncSignal chan struct{}
+		initialHistoricalSyncDone = make(chan *GossipSyncer)
 	)
 
+	updateNumInitialHistoricalSyncs := func() {
+		atomic.StoreInt32(
+			&m.numInitialHistoricalSyncs,
+			int32(len(initialHistoricalSyncers)),
+		)
+	}
+
 	setInitialHistoricalSyncer := func(s *GossipSyncer) {
-		initialHistoricalSyncer = s
-		initialHistoricalSyncSignal = s.ResetSyncedSignal()
+		initialHistoricalSyncers[s.cfg.peerPub] = s
+		updateNumInitialHistoricalSyncs()
+
+		m.wg.Add(1)
+		go m.notifyHistoricalSyncDone(
+			s, s.ResetSyncedSignal(), initialHistoricalSyncDone,
+		)
 
 		// Restart the timer for our new historical sync peer. This will
 		// ensure that all initial syncers receive an equivalent
@@ -314,6 +359,17 @@ func (m *SyncManager) syncerHandler() {
 			// internal state has been updated.
 			close(newSyncer.doneChan)
 
+			// If we're still performing the initial historical
+			// sync with fewer peers than configured, we'll also
+			// perform it with the new peer.
+			numSyncs := len(initialHistoricalSyncers)
+			if !isPinnedSyncer && m.cfg.NumActiveSyncers > 0 &&
+				!m.IsGraphSynced() && numSyncs > 0 &&
+				numSyncs < m.numHistoricalSyncers() {
+
+				attemptHistoricalSync = true
+			}
+
 			// We'll force a historical sync with the first peer we
 			// connect to, to ensure we get as much of the graph as
 			// possible.
@@ -350,21 +406,24 @@ func (m *SyncManager) syncerHandler() {
 			m.removeGossipSyncer(staleSyncer.peer)
 			close(staleSyncer.doneChan)
 
-			// If we don't have an initialHistoricalSyncer, or we do
-			// but it is not the peer being disconnected, then we
-			// have nothing left to do and can proceed.
-			switch {
-			case initialHistoricalSyncer == nil:
-				fallthrough
-			case staleSyncer.peer != initialHistoricalSyncer.cfg.peerPub:
-				fallthrough
-			case m.cfg.NumActiveSyncers == 0:
+			// If the peer being disconnected isn't one of our
+			// initialHistoricalSyncers, then we have nothing left
+			// to do and can proceed.
+			_, ok := initialHistoricalSyncers[staleSyncer.peer]
+			if !ok {
 				continue
 			}
 
-			// Otherwise, our initialHistoricalSyncer corresponds to
-			// the peer being disconnected, so we'll have to find a
-			// replacement.
+			delete(initialHistoricalSyncers, staleSyncer.peer)
+			updateNumInitialHistoricalSyncs()
+
+			if m.cfg.NumActiveSyncers == 0 {
+				continue
+			}
+
+			// Otherwise, one of our initialHistoricalSyncers
+			// corresponds to the peer being disconnected, so we'll
+			// have to find a replacement.
 			log.Debug("Finding replacement for initial " +
 				"historical sync")
 
@@ -381,14 +440,43 @@ func (m *SyncManager) syncerHandler() {
 
 			setInitialHistoricalSyncer(s)
 
-		// Our initial historical sync signal has completed, so we'll
-		// nil all of the relevant fields as they're no longer needed.
-		case <-initialHistoricalSyncSignal:
-			initialHistoricalSyncer = nil
-			initialHistoricalSyncSignal = nil
+		// One of our initial historical syncs has completed, so we'll
+		// stop tracking its syncer.
+		case s := <-initialHistoricalSyncDone:
+			// If we're no longer tracking the syncer, e.g. because
+			// its historical sync was replaced, we can ignore it.
+			if initialHistoricalSyncers[s.cfg.peerPub] != s {
+				continue
+			}
+
+			delete(initialHistoricalSyncers, s.cfg.peerPub)
+			updateNumInitialHistoricalSyncs()
+
+			// If we're still waiting for the historical sync with
+			// other peers, the initial historical sync hasn't
+			// completed yet.
+			if len(initialHistoricalSyncers) > 0 {
+				log.Debugf("Historical sync with "+
+					"GossipSyncer(%x) completed, %v "+
+					"remaining", s.cfg.peerPub,
+					len(initialHistoricalSyncers))
+				continue
+			}
 
 			log.Debug("Initial historical sync completed")
 
+			// The syncers skip marking the graph as synced while
+			// several historical syncs are in progress, so we'll
+			// make sure it is marked now.
+			m.markGraphSynced()
+
+			// Subsequent historical syncs are performed with a
+			// single peer, so the channel claims are no longer
+			// needed.
+			if m.chanClaims != nil {
+				m.chanClaims.reset()
+			}
+
 			// With the initial historical sync complete, we can
 			// begin receiving new graph updates at tip. We'll
 			// determine whether we can have any more active
@@ -452,17 +540,82 @@ func (m *SyncManager) syncerHandler() {
 
 			// Otherwise, we'll track the peer we've performed a
 			// historical sync with in order to handle the case
-			// where our previous historical sync peer did not
+			// where our previous historical sync peers did not
 			// respond to our queries and we haven't ingested as
-			// much of the graph as we should.
+			// much of the graph as we should. The previous peers
+			// are replaced, along with any others needed to reach
+			// our configured number of historical sync peers.
+			for peer := range initialHistoricalSyncers {
+				delete(initialHistoricalSyncers, peer)
+			}
 			setInitialHistoricalSyncer(s)
 
+			for len(initialHistoricalSyncers) <
+				m.numHistoricalSyncers() {
+
+				extra := m.forceHistoricalSync()
+				if extra == nil {
+					break
+				}
+				setInitialHistoricalSyncer(extra)
+			}
+
 		case <-m.quit:
 			return
 		}
 	}
 }
 
+// notifyHistoricalSyncDone sends the syncer on the done channel once its
+// historical sync has completed, as indicated by the synced signal.
+//
+// NOTE: This must be run as a goroutine.
+func (m *SyncManager) notifyHistoricalSyncDone(s *GossipSyncer,
+	syncedSignal chan struct{}, done chan<- *GossipSyncer) {
+
+	defer m.wg.Done()
+
+	select {
+	case <-syncedSignal:
+	case <-s.quit:
+		return
+	case <-m.quit:
+		return
+	}
+
+	select {
+	case done <- s:
+	case <-s.quit:
+	case <-m.quit:
+	}
+}
+
+// claimChanIDs claims the given unknown channels for the peer and returns the
+// ones that it should query. Channels are only claimed while the initial
+// historical sync is in progress, as subsequent historical syncs are performed
+// with a single peer.
+func (m *SyncManager) claimChanIDs(peer route.Vertex,
+	chanIDs []lnwire.ShortChannelID) []lnwire.ShortChannelID {
+
+	if m.IsGraphSynced() ||
+		atomic.LoadInt32(&m.numInitialHistoricalSyncs) == 0 {
+
+		return chanIDs
+	}
+
+	return m.chanClaims.claim(peer, chanIDs)
+}
+
+// numHistoricalSyncers returns the number of peers that we perform the initial
+// historical sync with.
+func (m *SyncManager) numHistoricalSyncers() int {
+	if m.cfg.NumHistoricalSyncers < 1 {
+		return DefaultNumHistoricalSyncers
+	}
+
+	return m.cfg.NumHistoricalSyncers
+}
+
 // isPinnedSyncer returns true if the passed GossipSyncer is one of our pinned
 // sync peers.
 func (m *SyncManager) isPinnedSyncer(s *GossipSyncer) bool {
@@ -476,7 +629,7 @@ func (m *SyncManager) createGossipSyncer(peer lnpeer.Peer) *GossipSyncer {
 	log.Infof("Creating new GossipSyncer for peer=%x", nodeID[:])
 
 	encoding := lnwire.EncodingSortedPlain
-	s := newGossipSyncer(gossipSyncerCfg{
+	cfg := gossipSyncerCfg{
 		chainHash:     m.cfg.ChainHash,
 		peerPub:       nodeID,
 		channelSeries: m.cfg.ChanSeries,
@@ -495,7 +648,23 @@ func (m *SyncManager) createGossipSyncer(peer lnpeer.Peer) *GossipSyncer {
 		bestHeight:                m.cfg.BestHeight,
 		markGraphSynced:           m.markGraphSynced,
 		maxQueryChanRangeReplies:  maxQueryChanRangeReplies,
-	})
+	}
+
+	// If we're performing the initial historical sync with several peers,
+	// we'll make sure that every unknown channel is only queried from one
+	// of them.
+	if m.chanClaims != nil {
+		cfg.claimChanIDs = func(chanIDs []lnwire.ShortChannelID) (
+			unclaimed []lnwire.ShortChannelID) {
+
+			return m.claimChanIDs(nodeID, chanIDs)
+		}
+		cfg.releaseChanIDs = func() {
+			m.chanClaims.release(nodeID)
+		}
+	}
+
+	s := newGossipSyncer(cfg)
 
 	// Gossip syncers are initialized by default in a PassiveSync type
 	// and chansSynced state so that they can reply to any peer queries or
@@ -527,6 +696,12 @@ func (m *SyncManager) removeGossipSyncer(peer route.Vertex) {
 	// to prevent blocking the SyncManager.
 	go s.Stop()
 
+	// Any channels we were querying from the peer can now be queried from
+	// others.
+	if m.chanClaims != nil {
+		m.chanClaims.release(peer)
+	}
+
 	// If it's a non-active syncer, then we can just exit now.
 	if _, ok := m.inactiveSyncers[peer]; ok {
 		delete(m.inactiveSyncers, peer)
@@ -772,8 +947,13 @@ func (m *SyncManager) gossipSyncers() map[route.Vertex]*GossipSyncer {
 }
 
 // markGraphSynced allows us to report that the initial historical sync has
-// completed.
+// completed. While more than one initial historical sync is in progress, this
+// is a no-op, as the graph is only synced once all of them have completed.
 func (m *SyncManager) markGraphSynced() {
+	if atomic.LoadInt32(&m.numInitialHistoricalSyncs) > 1 {
+		return
+	}
+
 	atomic.StoreInt32(&m.initialHistoricalSyncCompleted, 1)
 }
 
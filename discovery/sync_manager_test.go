@@ -449,6 +449,68 @@ func TestSyncManagerGraphSyncedAfterHistoricalSyncReplacement(t *testing.T) {
 	}
 }
 
+// TestSyncManagerParallelInitialHistoricalSync ensures that the initial
+// historical sync is performed with the configured number of peers in
+// parallel, and that the graph is only considered as synced once all of them
+// have completed.
+func TestSyncManagerParallelInitialHistoricalSync(t *testing.T) {
+	t.Parallel()
+
+	hID := lnwire.ShortChannelID{BlockHeight: latestKnownHeight}
+	syncMgr := newSyncManager(&SyncManagerCfg{
+		ChanSeries:           newMockChannelGraphTimeSeries(hID),
+		RotateTicker:         ticker.NewForce(DefaultSyncerRotationInterval),
+		HistoricalSyncTicker: ticker.NewForce(DefaultHistoricalSyncInterval),
+		NumActiveSyncers:     3,
+		NumHistoricalSyncers: 2,
+		BestHeight: func() uint32 {
+			return latestKnownHeight
+		},
+	})
+	require.NotNil(t, syncMgr.chanClaims)
+
+	syncMgr.Start()
+	defer syncMgr.Stop()
+
+	// The first two peers should both attempt an initial historical sync.
+	peers := make([]*mockPeer, 0, 2)
+	syncers := make([]*GossipSyncer, 0, 2)
+	for i := 0; i < 2; i++ {
+		peer := randPeer(t, syncMgr.quit)
+		syncMgr.InitSyncState(peer)
+
+		peers = append(peers, peer)
+		syncers = append(
+			syncers, assertSyncerExistence(t, syncMgr, peer),
+		)
+	}
+
+	// An additional peer shouldn't attempt another historical sync.
+	extraPeer := randPeer(t, syncMgr.quit)
+	syncMgr.InitSyncState(extraPeer)
+	assertNoMsgSent(t, extraPeer)
+
+	// Completing the historical sync with the first peer shouldn't mark
+	// the graph as synced, as the second one is still in progress.
+	assertTransitionToChansSynced(t, syncers[0], peers[0])
+	require.Never(t, syncMgr.IsGraphSynced, time.Second,
+		100*time.Millisecond)
+
+	// Once the second one completes, the graph should be considered as
+	// synced.
+	assertTransitionToChansSynced(t, syncers[1], peers[1])
+	require.Eventually(t, syncMgr.IsGraphSynced, time.Second,
+		100*time.Millisecond)
+
+	// With the initial historical sync completed, channels are no longer
+	// claimed, so any peer may query them.
+	chanIDs := []lnwire.ShortChannelID{lnwire.NewShortChanIDFromInt(1)}
+	for _, peer := range peers {
+		pub := route.Vertex(peer.PubKey())
+		require.Equal(t, chanIDs, syncMgr.claimChanIDs(pub, chanIDs))
+	}
+}
+
 // TestSyncManagerWaitUntilInitialHistoricalSync ensures that no GossipSyncers
 // are initialized as ActiveSync until the initial historical sync has been
 // completed. Once it does, the pending GossipSyncers should be transitioned to
@@ -284,6 +284,16 @@ type gossipSyncerCfg struct {
 	// have completed at least one historical sync.
 	markGraphSynced func()
 
+	// claimChanIDs filters out the unknown channels that are already being
+	// queried from other peers, and claims the remaining ones for this
+	// peer. It is only set if we perform historical syncs with several
+	// peers in parallel.
+	claimChanIDs func([]lnwire.ShortChannelID) []lnwire.ShortChannelID
+
+	// releaseChanIDs releases the channels claimed by this peer once they
+	// have been queried. It is only set if claimChanIDs is set.
+	releaseChanIDs func()
+
 	// maxQueryChanRangeReplies is the maximum number of replies we'll allow
 	// for a single QueryChannelRange request.
 	maxQueryChanRangeReplies uint32
@@ -554,8 +564,13 @@ func (g *GossipSyncer) channelGraphSyncer() {
 			}
 
 			// If we're fully synchronized, then we can transition
-			// to our terminal state.
+			// to our terminal state. The channels we've queried are
+			// now known to us, so other peers won't query them
+			// again.
 			g.setSyncState(chansSynced)
+			if g.cfg.releaseChanIDs != nil {
+				g.cfg.releaseChanIDs()
+			}
 
 			// Ensure that the sync manager becomes aware that the
 			// historical sync completed so synced_to_graph is
@@ -862,6 +877,17 @@ func (g *GossipSyncer) processChanRangeReply(msg *lnwire.ReplyChannelRange) erro
 		return fmt.Errorf("unable to filter chan ids: %v", err)
 	}
 
+	// If we're syncing with several peers in parallel, we'll only query
+	// the channels that aren't already queried from one of them.
+	if g.cfg.claimChanIDs != nil {
+		numNewChans := len(newChans)
+		newChans = g.cfg.claimChanIDs(newChans)
+
+		log.Infof("GossipSyncer(%x): %v of %v new chans are queried "+
+			"from other peers", g.cfg.peerPub[:],
+			numNewChans-len(newChans), numNewChans)
+	}
+
 	// As we've received the entirety of the reply, we no longer need to
 	// hold on to the set of buffered replies or the original query that
 	// prompted the replies, so we'll let that be garbage collected now.
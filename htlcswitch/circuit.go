@@ -201,6 +201,11 @@ func (c *PaymentCircuit) Decode(r io.Reader) error {
 		// Sphinx encrypter was used as this is a forwarded HTLC.
 		c.ErrorEncrypter = hop.NewSphinxErrorEncrypter()
 
+	case hop.EncrypterTypeIntroduction:
+		// The HTLC was received as the introduction node of a blinded
+		// route.
+		c.ErrorEncrypter = hop.NewBlankIntroductionErrorEncrypter()
+
 	case hop.EncrypterTypeMock:
 		// Test encrypter.
 		c.ErrorEncrypter = NewMockObfuscator()
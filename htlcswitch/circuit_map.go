@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
@@ -94,6 +95,21 @@ type CircuitFwdActions struct {
 	Fails []*PaymentCircuit
 }
 
+// PendingCircuit is a snapshot of a circuit that has been committed to the
+// circuit map, but hasn't been deleted yet.
+type PendingCircuit struct {
+	PaymentCircuit
+
+	// CommitTime is the time the circuit was committed to the circuit map.
+	// For circuits that were restored from disk, this is the time the
+	// circuit map was loaded.
+	CommitTime time.Time
+
+	// Closing is true if a settle or fail has been received for the
+	// circuit, which hasn't been removed from the incoming link yet.
+	Closing bool
+}
+
 // CircuitMap is an interface for managing the construction and teardown of
 // payment circuits used by the switch.
 type CircuitMap interface {
@@ -129,6 +145,10 @@ type CircuitMap interface {
 	// NumOpen returns the number of circuits with HTLCs that have been
 	// forwarded via an outgoing link.
 	NumOpen() int
+
+	// PendingCircuits returns a snapshot of all circuits added by
+	// CommitCircuits that haven't been deleted yet.
+	PendingCircuits() []PendingCircuit
 }
 
 var (
@@ -188,6 +208,11 @@ type circuitMap struct {
 	// circuit from disk.
 	closed map[CircuitKey]struct{}
 
+	// commitTimes records the time each pending circuit was committed to
+	// the circuit map. This is only kept in memory, so circuits restored
+	// from disk are assigned the time they were loaded.
+	commitTimes map[CircuitKey]time.Time
+
 	// hashIndex is a volatile index that facilitates fast queries by
 	// payment hash against the contents of circuits. This index can be
 	// reconstructed entirely from the set of persisted full circuits on
@@ -599,6 +624,12 @@ func (cm *circuitMap) restoreMemState() error {
 	cm.opened = opened
 	cm.closed = make(map[CircuitKey]struct{})
 
+	now := time.Now()
+	cm.commitTimes = make(map[CircuitKey]time.Time, len(pending))
+	for inKey := range pending {
+		cm.commitTimes[inKey] = now
+	}
+
 	log.Infof("Payment circuits loaded: num_pending=%v, num_open=%v",
 		len(pending), len(opened))
 
@@ -820,6 +851,8 @@ func (cm *circuitMap) CommitCircuits(circuits ...*PaymentCircuit) (
 	// NOTE: We track an additional addFails subsequence, which permits us
 	// to fail back all packets that weren't dropped if we encounter an
 	// error when committing the circuits.
+	now := time.Now()
+
 	cm.mtx.Lock()
 	var adds, drops, fails, addFails []*PaymentCircuit
 	for _, circuit := range circuits {
@@ -857,6 +890,7 @@ func (cm *circuitMap) CommitCircuits(circuits ...*PaymentCircuit) (
 		}
 
 		cm.pending[inKey] = circuit
+		cm.commitTimes[inKey] = now
 		adds = append(adds, circuit)
 		addFails = append(addFails, circuit)
 	}
@@ -914,6 +948,7 @@ func (cm *circuitMap) CommitCircuits(circuits ...*PaymentCircuit) (
 	cm.mtx.Lock()
 	for _, circuit := range adds {
 		delete(cm.pending, circuit.InKey())
+		delete(cm.commitTimes, circuit.InKey())
 	}
 	cm.mtx.Unlock()
 
@@ -1085,6 +1120,7 @@ func (cm *circuitMap) DeleteCircuits(inKeys ...CircuitKey) error {
 	var (
 		closingCircuits = make(map[CircuitKey]struct{})
 		removedCircuits = make(map[CircuitKey]*PaymentCircuit)
+		commitTimes     = make(map[CircuitKey]time.Time)
 	)
 
 	cm.mtx.Lock()
@@ -1099,6 +1135,9 @@ func (cm *circuitMap) DeleteCircuits(inKeys ...CircuitKey) error {
 		}
 		delete(cm.pending, inKey)
 
+		commitTimes[inKey] = cm.commitTimes[inKey]
+		delete(cm.commitTimes, inKey)
+
 		if _, ok := cm.closed[inKey]; ok {
 			closingCircuits[inKey] = struct{}{}
 			delete(cm.closed, inKey)
@@ -1158,6 +1197,7 @@ func (cm *circuitMap) DeleteCircuits(inKeys ...CircuitKey) error {
 	cm.mtx.Lock()
 	for inKey, circuit := range removedCircuits {
 		cm.pending[inKey] = circuit
+		cm.commitTimes[inKey] = commitTimes[inKey]
 
 		if _, ok := closingCircuits[inKey]; ok {
 			cm.closed[inKey] = struct{}{}
@@ -1210,3 +1250,23 @@ func (cm *circuitMap) NumOpen() int {
 
 	return len(cm.opened)
 }
+
+// PendingCircuits returns a snapshot of all circuits that have been committed
+// to the circuit map, but haven't been deleted yet. The returned circuits are
+// copies, so they can be inspected without holding the circuit map's lock.
+func (cm *circuitMap) PendingCircuits() []PendingCircuit {
+	cm.mtx.RLock()
+	defer cm.mtx.RUnlock()
+
+	circuits := make([]PendingCircuit, 0, len(cm.pending))
+	for inKey, circuit := range cm.pending {
+		_, closing := cm.closed[inKey]
+		circuits = append(circuits, PendingCircuit{
+			PaymentCircuit: *circuit,
+			CommitTime:     cm.commitTimes[inKey],
+			Closing:        closing,
+		})
+	}
+
+	return circuits
+}
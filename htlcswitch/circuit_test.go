@@ -80,6 +80,9 @@ func initTestExtracter() {
 	// We also set this error extracter on startup, otherwise it will be nil
 	// at compile-time.
 	halfCircuitTests[2].encrypter = testExtracter
+	halfCircuitTests[3].encrypter = hop.NewIntroductionErrorEncrypter(
+		testExtracter, []byte{0x04},
+	)
 }
 
 // newOnionProcessor creates starts a new htlcswitch.OnionProcessor using a temp
@@ -175,6 +178,16 @@ var halfCircuitTests = []struct {
 		// repopulate this encrypter.
 		encrypter: testExtracter,
 	},
+	{
+		hash:     hash3,
+		inValue:  10000,
+		outValue: 9000,
+		chanID:   lnwire.NewShortChanIDFromInt(4),
+		htlcID:   4,
+		// NOTE: The introduction error encrypter wraps testExtracter,
+		// which is repopulated in initTestExtracter.
+		encrypter: nil,
+	},
 }
 
 // TestHalfCircuitSerialization checks that the half circuits can be properly
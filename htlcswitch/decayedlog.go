@@ -48,12 +48,13 @@ func NewBoltBackendCreator(dbPath,
 
 	return func(boltCfg *kvdb.BoltConfig) (kvdb.Backend, error) {
 		cfg := &kvdb.BoltBackendConfig{
-			DBPath:            dbPath,
-			DBFileName:        dbFileName,
-			NoFreelistSync:    boltCfg.NoFreelistSync,
-			AutoCompact:       boltCfg.AutoCompact,
-			AutoCompactMinAge: boltCfg.AutoCompactMinAge,
-			DBTimeout:         boltCfg.DBTimeout,
+			DBPath:                      dbPath,
+			DBFileName:                  dbFileName,
+			NoFreelistSync:              boltCfg.NoFreelistSync,
+			AutoCompact:                 boltCfg.AutoCompact,
+			AutoCompactMinAge:           boltCfg.AutoCompactMinAge,
+			AutoCompactMinFragmentation: boltCfg.AutoCompactMinFragmentation,
+			DBTimeout:                   boltCfg.DBTimeout,
 		}
 
 		// Use default path for log database.
@@ -54,6 +54,21 @@ const (
 	// OutgoingFailureForwardsDisabled is returned when the switch is
 	// configured to disallow forwards.
 	OutgoingFailureForwardsDisabled
+
+	// OutgoingFailurePeerPendingHtlcLimit is returned when the peer that
+	// forwarded the htlc already has the maximum number of htlcs pending
+	// in our switch.
+	OutgoingFailurePeerPendingHtlcLimit
+
+	// OutgoingFailurePeerHtlcRateLimit is returned when the peer that
+	// forwarded the htlc exceeded the rate of htlc adds it is allowed to
+	// forward.
+	OutgoingFailurePeerHtlcRateLimit
+
+	// OutgoingFailureHtlcSlotsReserved is returned when forwarding the htlc
+	// would use up the commitment slots of the outgoing channel that are
+	// reserved for our own payments.
+	OutgoingFailureHtlcSlotsReserved
 )
 
 // FailureString returns the string representation of a failure detail.
@@ -91,6 +106,15 @@ func (fd OutgoingFailure) FailureString() string {
 	case OutgoingFailureForwardsDisabled:
 		return "node configured to disallow forwards"
 
+	case OutgoingFailurePeerPendingHtlcLimit:
+		return "peer exceeded pending htlc limit"
+
+	case OutgoingFailurePeerHtlcRateLimit:
+		return "peer exceeded htlc rate limit"
+
+	case OutgoingFailureHtlcSlotsReserved:
+		return "outgoing htlc slots reserved for local payments"
+
 	default:
 		return "unknown failure detail"
 	}
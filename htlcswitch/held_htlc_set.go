@@ -3,6 +3,7 @@ package htlcswitch
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ltcsuite/lnd/channeldb/models"
 )
@@ -12,11 +13,16 @@ import (
 // way.
 type heldHtlcSet struct {
 	set map[models.CircuitKey]InterceptedForward
+
+	// heldSince records the time at which each forward was added to the
+	// set.
+	heldSince map[models.CircuitKey]time.Time
 }
 
 func newHeldHtlcSet() *heldHtlcSet {
 	return &heldHtlcSet{
-		set: make(map[models.CircuitKey]InterceptedForward),
+		set:       make(map[models.CircuitKey]InterceptedForward),
+		heldSince: make(map[models.CircuitKey]time.Time),
 	}
 }
 
@@ -35,6 +41,7 @@ func (h *heldHtlcSet) popAll(cb func(InterceptedForward)) {
 	}
 
 	h.set = make(map[models.CircuitKey]InterceptedForward)
+	h.heldSince = make(map[models.CircuitKey]time.Time)
 }
 
 // popAutoFails calls the callback for each forward that has an auto-fail height
@@ -48,6 +55,7 @@ func (h *heldHtlcSet) popAutoFails(height uint32, cb func(InterceptedForward)) {
 		cb(fwd)
 
 		delete(h.set, key)
+		delete(h.heldSince, key)
 	}
 }
 
@@ -59,6 +67,7 @@ func (h *heldHtlcSet) pop(key models.CircuitKey) (InterceptedForward, error) {
 	}
 
 	delete(h.set, key)
+	delete(h.heldSince, key)
 
 	return intercepted, nil
 }
@@ -84,6 +93,29 @@ func (h *heldHtlcSet) push(key models.CircuitKey,
 	}
 
 	h.set[key] = fwd
+	h.heldSince[key] = time.Now()
 
 	return nil
 }
+
+// pendingHtlcs returns the held forwards as pending htlcs, along with the time
+// they have been held for.
+func (h *heldHtlcSet) pendingHtlcs(now time.Time) []PendingHtlc {
+	htlcs := make([]PendingHtlc, 0, len(h.set))
+	for key, fwd := range h.set {
+		packet := fwd.Packet()
+		htlcs = append(htlcs, PendingHtlc{
+			Incoming:       key,
+			OutgoingChanID: packet.OutgoingChanID,
+			PaymentHash:    packet.Hash,
+			IncomingAmount: packet.IncomingAmount,
+			OutgoingAmount: packet.OutgoingAmount,
+			IncomingExpiry: packet.IncomingExpiry,
+			OutgoingExpiry: packet.OutgoingExpiry,
+			HoldTime:       now.Sub(h.heldSince[key]),
+			State:          PendingHtlcIntercepted,
+		})
+	}
+
+	return htlcs
+}
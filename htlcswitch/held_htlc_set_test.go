@@ -2,6 +2,7 @@ package htlcswitch
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ltcsuite/lnd/channeldb/models"
 	"github.com/ltcsuite/lnd/lnwire"
@@ -124,3 +125,52 @@ func TestHeldHtlcSetAutoFails(t *testing.T) {
 		},
 	)
 }
+
+// TestHeldHtlcSetPendingHtlcs tests that the held forwards are reported as
+// intercepted pending htlcs along with the time they have been held for.
+func TestHeldHtlcSetPendingHtlcs(t *testing.T) {
+	set := newHeldHtlcSet()
+
+	require.Empty(t, set.pendingHtlcs(time.Now()))
+
+	key := models.CircuitKey{
+		ChanID: lnwire.NewShortChanIDFromInt(1),
+		HtlcID: 2,
+	}
+	fwd := &interceptedForward{
+		packet: &htlcPacket{
+			incomingChanID:  key.ChanID,
+			incomingHTLCID:  key.HtlcID,
+			outgoingChanID:  lnwire.NewShortChanIDFromInt(3),
+			incomingAmount:  2000,
+			incomingTimeout: 150,
+		},
+		htlc: &lnwire.UpdateAddHTLC{
+			PaymentHash: [32]byte{1},
+			Amount:      1000,
+			Expiry:      110,
+		},
+	}
+	require.NoError(t, set.push(key, fwd))
+
+	now := time.Now().Add(time.Minute)
+	htlcs := set.pendingHtlcs(now)
+	require.Len(t, htlcs, 1)
+
+	htlc := htlcs[0]
+	require.Equal(t, key, htlc.Incoming)
+	require.Nil(t, htlc.Outgoing)
+	require.Equal(t, lnwire.NewShortChanIDFromInt(3), htlc.OutgoingChanID)
+	require.EqualValues(t, [32]byte{1}, htlc.PaymentHash)
+	require.EqualValues(t, 2000, htlc.IncomingAmount)
+	require.EqualValues(t, 1000, htlc.OutgoingAmount)
+	require.EqualValues(t, 150, htlc.IncomingExpiry)
+	require.EqualValues(t, 110, htlc.OutgoingExpiry)
+	require.GreaterOrEqual(t, htlc.HoldTime, time.Minute-time.Second)
+	require.Equal(t, PendingHtlcIntercepted, htlc.State)
+
+	// Once the forward is resolved, it is no longer reported.
+	_, err := set.pop(key)
+	require.NoError(t, err)
+	require.Empty(t, set.pendingHtlcs(now))
+}
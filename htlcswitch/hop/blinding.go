@@ -0,0 +1,206 @@
+package hop
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/record"
+	"github.com/ltcsuite/ltcd/btcec/v2"
+)
+
+// ErrInvalidBlinding is returned when the route blinding information of an
+// incoming HTLC could not be processed. Nodes in a blinded route must not
+// reveal any details about the failure to the sender, so this error is
+// reported back as an invalid blinding failure.
+var ErrInvalidBlinding = errors.New("invalid route blinding")
+
+// BlindingProcessor is an interface that provides the cryptographic
+// operations that are required to process the hops of a blinded route.
+type BlindingProcessor interface {
+	// DecryptBlindedHopData decrypts the encrypted recipient data that
+	// the creator of a blinded route provided for our node, using the
+	// blinding point of the route.
+	DecryptBlindedHopData(ephemPub *btcec.PublicKey,
+		encryptedData []byte) ([]byte, error)
+
+	// NextEphemeral derives the blinding point that should be passed on
+	// to the next hop in a blinded route.
+	NextEphemeral(*btcec.PublicKey) (*btcec.PublicKey, error)
+}
+
+// BlindingKit contains the information about an incoming HTLC that is needed
+// to process it as part of a blinded route.
+type BlindingKit struct {
+	// Processor decrypts the encrypted data of our hop and derives the
+	// blinding point for the next hop.
+	Processor BlindingProcessor
+
+	// UpdateAddBlinding is the blinding point that was provided in the
+	// incoming update_add_htlc. It is only set if we are not the
+	// introduction node of the blinded route.
+	UpdateAddBlinding *btcec.PublicKey
+
+	// IncomingAmount is the amount of the incoming HTLC.
+	IncomingAmount lnwire.MilliSatoshi
+
+	// IncomingCltv is the expiry height of the incoming HTLC.
+	IncomingCltv uint32
+}
+
+// forwardingInfo decrypts the encrypted data in the payload of a hop in a
+// blinded route, and uses it to derive the forwarding information for the
+// incoming HTLC. Any failure is wrapped in ErrInvalidBlinding.
+func (b *BlindingKit) forwardingInfo(payload *Payload,
+	isFinalHop bool) (*ForwardingInfo, error) {
+
+	// The blinding point is either provided in the update_add_htlc, or in
+	// the payload if we are the introduction node, but never in both.
+	var blindingPoint *btcec.PublicKey
+	switch {
+	case b.UpdateAddBlinding != nil && payload.BlindingPoint() != nil:
+		return nil, fmt.Errorf("%w: blinding point set in both "+
+			"update_add_htlc and payload", ErrInvalidBlinding)
+
+	case b.UpdateAddBlinding != nil:
+		blindingPoint = b.UpdateAddBlinding
+
+	case payload.BlindingPoint() != nil:
+		blindingPoint = payload.BlindingPoint()
+
+	default:
+		return nil, fmt.Errorf("%w: encrypted data without blinding "+
+			"point", ErrInvalidBlinding)
+	}
+
+	// We don't create blinded routes to ourselves yet, so we can only
+	// act as a relaying node.
+	if isFinalHop || payload.TotalAmtMsat() != 0 {
+		return nil, fmt.Errorf("%w: receiving over blinded routes is "+
+			"not supported", ErrInvalidBlinding)
+	}
+
+	if b.Processor == nil {
+		return nil, fmt.Errorf("%w: no blinding processor",
+			ErrInvalidBlinding)
+	}
+
+	decrypted, err := b.Processor.DecryptBlindedHopData(
+		blindingPoint, payload.EncryptedData(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to decrypt hop data: %v",
+			ErrInvalidBlinding, err)
+	}
+
+	routeData, err := record.DecodeBlindedRouteData(
+		bytes.NewReader(decrypted),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to decode hop data: %v",
+			ErrInvalidBlinding, err)
+	}
+
+	err = ValidateBlindedRouteData(
+		routeData, b.IncomingAmount, b.IncomingCltv,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBlinding, err)
+	}
+
+	relayInfo := routeData.RelayInfo
+	fwdAmt, err := calculateForwardingAmount(
+		b.IncomingAmount, relayInfo.BaseFee, relayInfo.FeeRate,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBlinding, err)
+	}
+
+	// The creator of the route may instruct us to hand a specific
+	// blinding point to the next hop, otherwise we derive it ourselves.
+	nextBlinding := routeData.NextBlindingOverride
+	if nextBlinding == nil {
+		nextBlinding, err = b.Processor.NextEphemeral(blindingPoint)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to derive next "+
+				"blinding point: %v", ErrInvalidBlinding, err)
+		}
+	}
+
+	return &ForwardingInfo{
+		Network:         LitecoinNetwork,
+		NextHop:         *routeData.ShortChannelID,
+		AmountToForward: fwdAmt,
+		OutgoingCTLV: b.IncomingCltv - uint32(
+			relayInfo.CltvExpiryDelta,
+		),
+		NextBlinding: nextBlinding,
+	}, nil
+}
+
+// ValidateBlindedRouteData checks that the decrypted data of a relaying hop
+// in a blinded route contains all the fields required to forward the HTLC,
+// and that the incoming HTLC satisfies the constraints of the route.
+func ValidateBlindedRouteData(data *record.BlindedRouteData,
+	incomingAmount lnwire.MilliSatoshi, incomingCltv uint32) error {
+
+	switch {
+	case data.ShortChannelID == nil:
+		return errors.New("blinded route data missing short channel id")
+
+	case data.RelayInfo == nil:
+		return errors.New("blinded route data missing relay info")
+
+	case uint32(data.RelayInfo.CltvExpiryDelta) > incomingCltv:
+		return fmt.Errorf("incoming expiry %v below cltv expiry "+
+			"delta %v", incomingCltv,
+			data.RelayInfo.CltvExpiryDelta)
+	}
+
+	if data.Constraints != nil {
+		if incomingCltv > data.Constraints.MaxCltvExpiry {
+			return fmt.Errorf("incoming expiry %v exceeds maximum "+
+				"expiry %v", incomingCltv,
+				data.Constraints.MaxCltvExpiry)
+		}
+
+		if incomingAmount < data.Constraints.HtlcMinimumMsat {
+			return fmt.Errorf("incoming amount %v below minimum "+
+				"%v", incomingAmount,
+				data.Constraints.HtlcMinimumMsat)
+		}
+	}
+
+	if data.Features != nil {
+		unknown := data.Features.UnknownRequiredFeatures()
+		if len(unknown) > 0 {
+			return fmt.Errorf("blinded route data requires unknown "+
+				"features: %v", unknown)
+		}
+	}
+
+	return nil
+}
+
+// calculateForwardingAmount calculates the amount to forward for a hop in a
+// blinded route, given the incoming amount and the relay fees of the hop. As
+// described in BOLT 04, the amount is rounded up so that the hop never
+// receives less than its advertised fee:
+//
+//	ceil((incoming - base_fee) * 1e6 / (1e6 + fee_rate))
+func calculateForwardingAmount(incomingAmount, baseFee lnwire.MilliSatoshi,
+	feeRate uint32) (lnwire.MilliSatoshi, error) {
+
+	if incomingAmount < baseFee {
+		return 0, fmt.Errorf("incoming amount %v below base fee %v",
+			incomingAmount, baseFee)
+	}
+
+	numerator := uint64(incomingAmount-baseFee) * 1_000_000
+	denominator := 1_000_000 + uint64(feeRate)
+
+	return lnwire.MilliSatoshi(
+		(numerator + denominator - 1) / denominator,
+	), nil
+}
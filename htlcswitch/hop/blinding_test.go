@@ -0,0 +1,275 @@
+package hop
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/record"
+	"github.com/ltcsuite/ltcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// mockProcessor is a mock implementation of the BlindingProcessor interface
+// that returns pre-set values.
+type mockProcessor struct {
+	decryptErr   error
+	decrypted    []byte
+	nextEphemErr error
+	nextEphem    *btcec.PublicKey
+}
+
+// DecryptBlindedHopData returns the pre-set decrypted data.
+func (m *mockProcessor) DecryptBlindedHopData(_ *btcec.PublicKey,
+	_ []byte) ([]byte, error) {
+
+	return m.decrypted, m.decryptErr
+}
+
+// NextEphemeral returns the pre-set next blinding point.
+func (m *mockProcessor) NextEphemeral(*btcec.PublicKey) (*btcec.PublicKey,
+	error) {
+
+	return m.nextEphem, m.nextEphemErr
+}
+
+// TestCalculateForwardingAmount tests that the amount forwarded by a hop in a
+// blinded route is rounded up so that the hop receives at least its fee.
+func TestCalculateForwardingAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		incomingAmount lnwire.MilliSatoshi
+		baseFee        lnwire.MilliSatoshi
+		feeRate        uint32
+		forwardAmount  lnwire.MilliSatoshi
+		expectErr      bool
+	}{
+		{
+			name:           "no fees",
+			incomingAmount: 100_000,
+			forwardAmount:  100_000,
+		},
+		{
+			name:           "base fee only",
+			incomingAmount: 100_000,
+			baseFee:        1000,
+			forwardAmount:  99_000,
+		},
+		{
+			name:           "fee rate only",
+			incomingAmount: 100_000,
+			feeRate:        500,
+			forwardAmount:  99_951,
+		},
+		{
+			name:           "base fee and fee rate",
+			incomingAmount: 100_000,
+			baseFee:        1000,
+			feeRate:        1,
+			forwardAmount:  99_000,
+		},
+		{
+			name:           "base fee exceeds incoming",
+			incomingAmount: 1000,
+			baseFee:        1001,
+			expectErr:      true,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := calculateForwardingAmount(
+				testCase.incomingAmount, testCase.baseFee,
+				testCase.feeRate,
+			)
+			if testCase.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.forwardAmount, actual)
+		})
+	}
+}
+
+// TestBlindingKitForwardingInfo tests the derivation of the forwarding
+// information of a hop in a blinded route from its encrypted data.
+func TestBlindingKitForwardingInfo(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	blindingPoint := privKey.PubKey()
+
+	privKey, err = btcec.NewPrivateKey()
+	require.NoError(t, err)
+	nextBlinding := privKey.PubKey()
+
+	scid := lnwire.NewShortChanIDFromInt(1)
+	routeData := &record.BlindedRouteData{
+		ShortChannelID: &scid,
+		RelayInfo: &record.PaymentRelayInfo{
+			CltvExpiryDelta: 40,
+			FeeRate:         0,
+			BaseFee:         1000,
+		},
+		Constraints: &record.PaymentConstraints{
+			MaxCltvExpiry:   1000,
+			HtlcMinimumMsat: 10_000,
+		},
+	}
+	encoded, err := record.EncodeBlindedRouteData(routeData)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		updateAddBlinding *btcec.PublicKey
+		payload           *Payload
+		isFinalHop        bool
+		processor         *mockProcessor
+		incomingAmount    lnwire.MilliSatoshi
+		incomingCltv      uint32
+		expectedFwdInfo   *ForwardingInfo
+	}{
+		{
+			name:              "intermediate hop",
+			updateAddBlinding: blindingPoint,
+			payload: &Payload{
+				encryptedData: []byte{1},
+			},
+			processor: &mockProcessor{
+				decrypted: encoded,
+				nextEphem: nextBlinding,
+			},
+			incomingAmount: 20_000,
+			incomingCltv:   500,
+			expectedFwdInfo: &ForwardingInfo{
+				Network:         LitecoinNetwork,
+				NextHop:         scid,
+				AmountToForward: 19_000,
+				OutgoingCTLV:    460,
+				NextBlinding:    nextBlinding,
+			},
+		},
+		{
+			name: "introduction node",
+			payload: &Payload{
+				encryptedData: []byte{1},
+				blindingPoint: blindingPoint,
+			},
+			processor: &mockProcessor{
+				decrypted: encoded,
+				nextEphem: nextBlinding,
+			},
+			incomingAmount: 20_000,
+			incomingCltv:   500,
+			expectedFwdInfo: &ForwardingInfo{
+				Network:         LitecoinNetwork,
+				NextHop:         scid,
+				AmountToForward: 19_000,
+				OutgoingCTLV:    460,
+				NextBlinding:    nextBlinding,
+			},
+		},
+		{
+			name:              "two blinding points",
+			updateAddBlinding: blindingPoint,
+			payload: &Payload{
+				encryptedData: []byte{1},
+				blindingPoint: blindingPoint,
+			},
+			processor: &mockProcessor{
+				decrypted: encoded,
+			},
+		},
+		{
+			name: "no blinding point",
+			payload: &Payload{
+				encryptedData: []byte{1},
+			},
+			processor: &mockProcessor{
+				decrypted: encoded,
+			},
+		},
+		{
+			name:              "final hop",
+			updateAddBlinding: blindingPoint,
+			payload: &Payload{
+				encryptedData: []byte{1},
+			},
+			isFinalHop: true,
+			processor: &mockProcessor{
+				decrypted: encoded,
+			},
+		},
+		{
+			name:              "decryption failure",
+			updateAddBlinding: blindingPoint,
+			payload: &Payload{
+				encryptedData: []byte{1},
+			},
+			processor: &mockProcessor{
+				decryptErr: errors.New("decrypt"),
+			},
+			incomingAmount: 20_000,
+			incomingCltv:   500,
+		},
+		{
+			name:              "expiry above maximum",
+			updateAddBlinding: blindingPoint,
+			payload: &Payload{
+				encryptedData: []byte{1},
+			},
+			processor: &mockProcessor{
+				decrypted: encoded,
+			},
+			incomingAmount: 20_000,
+			incomingCltv:   1001,
+		},
+		{
+			name:              "amount below minimum",
+			updateAddBlinding: blindingPoint,
+			payload: &Payload{
+				encryptedData: []byte{1},
+			},
+			processor: &mockProcessor{
+				decrypted: encoded,
+			},
+			incomingAmount: 9_999,
+			incomingCltv:   500,
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			kit := BlindingKit{
+				Processor:         testCase.processor,
+				UpdateAddBlinding: testCase.updateAddBlinding,
+				IncomingAmount:    testCase.incomingAmount,
+				IncomingCltv:      testCase.incomingCltv,
+			}
+
+			fwdInfo, err := kit.forwardingInfo(
+				testCase.payload, testCase.isFinalHop,
+			)
+			if testCase.expectedFwdInfo == nil {
+				require.ErrorIs(t, err, ErrInvalidBlinding)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectedFwdInfo, fwdInfo)
+		})
+	}
+}
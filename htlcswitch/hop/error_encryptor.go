@@ -2,6 +2,7 @@ package hop
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"io"
 
@@ -25,6 +26,10 @@ const (
 
 	// EncrypterTypeMock is used to identify a mock obfuscator instance.
 	EncrypterTypeMock = 2
+
+	// EncrypterTypeIntroduction is used to identify a sphinx onion error
+	// encrypter of the introduction node of a blinded route.
+	EncrypterTypeIntroduction = 3
 )
 
 // ErrorEncrypterExtracter defines a function signature that extracts an
@@ -203,3 +208,129 @@ func (s *SphinxErrorEncrypter) Reextract(
 // A compile time check to ensure SphinxErrorEncrypter implements the
 // ErrorEncrypter interface.
 var _ ErrorEncrypter = (*SphinxErrorEncrypter)(nil)
+
+// IntroductionErrorEncrypter is the error encrypter of the introduction node
+// of a blinded route. The sender must not learn anything about the blinded
+// part of the route, so it replaces every failure, including the ones that are
+// received from downstream, with an invalid blinding failure.
+type IntroductionErrorEncrypter struct {
+	ErrorEncrypter
+
+	// OnionSHA256 is the hash of the onion blob that was received with
+	// the incoming HTLC, which is reported in the invalid blinding
+	// failure.
+	OnionSHA256 [sha256.Size]byte
+}
+
+// NewIntroductionErrorEncrypter wraps the error encrypter of an HTLC that is
+// received as the introduction node of a blinded route.
+func NewIntroductionErrorEncrypter(encrypter ErrorEncrypter,
+	onionBlob []byte) *IntroductionErrorEncrypter {
+
+	return &IntroductionErrorEncrypter{
+		ErrorEncrypter: encrypter,
+		OnionSHA256:    sha256.Sum256(onionBlob),
+	}
+}
+
+// NewBlankIntroductionErrorEncrypter initializes a blank introduction error
+// encrypter, that should be used to deserialize an encoded
+// IntroductionErrorEncrypter.
+func NewBlankIntroductionErrorEncrypter() *IntroductionErrorEncrypter {
+	return &IntroductionErrorEncrypter{
+		ErrorEncrypter: NewSphinxErrorEncrypter(),
+	}
+}
+
+// invalidBlinding returns the encrypted invalid blinding failure that replaces
+// every failure of the HTLC.
+func (i *IntroductionErrorEncrypter) invalidBlinding() (lnwire.OpaqueReason,
+	error) {
+
+	failure := &lnwire.FailInvalidBlinding{
+		OnionSHA256: i.OnionSHA256,
+	}
+
+	return i.ErrorEncrypter.EncryptFirstHop(failure)
+}
+
+// EncryptFirstHop replaces the failure with an invalid blinding failure, and
+// encrypts it as the source of the error.
+//
+// NOTE: Part of the ErrorEncrypter interface.
+func (i *IntroductionErrorEncrypter) EncryptFirstHop(
+	_ lnwire.FailureMessage) (lnwire.OpaqueReason, error) {
+
+	return i.invalidBlinding()
+}
+
+// EncryptMalformedError replaces the malformed failure that was received from
+// downstream with an invalid blinding failure, and encrypts it as the source
+// of the error.
+//
+// NOTE: Part of the ErrorEncrypter interface.
+func (i *IntroductionErrorEncrypter) EncryptMalformedError(
+	reason lnwire.OpaqueReason) lnwire.OpaqueReason {
+
+	return i.replaceFailure(reason)
+}
+
+// IntermediateEncrypt replaces the failure that was received from downstream
+// with an invalid blinding failure, and encrypts it as the source of the
+// error.
+//
+// NOTE: Part of the ErrorEncrypter interface.
+func (i *IntroductionErrorEncrypter) IntermediateEncrypt(
+	reason lnwire.OpaqueReason) lnwire.OpaqueReason {
+
+	return i.replaceFailure(reason)
+}
+
+// replaceFailure replaces a failure that was received from downstream with an
+// encrypted invalid blinding failure.
+func (i *IntroductionErrorEncrypter) replaceFailure(
+	reason lnwire.OpaqueReason) lnwire.OpaqueReason {
+
+	invalidBlinding, err := i.invalidBlinding()
+	if err != nil {
+		// Encoding the fixed size failure can't fail, but we must
+		// never pass on the failure from within the blinded route.
+		log.Errorf("Unable to encrypt invalid blinding failure: %v",
+			err)
+
+		return i.ErrorEncrypter.EncryptMalformedError(nil)
+	}
+
+	return invalidBlinding
+}
+
+// Type returns the identifier for an introduction error encrypter.
+func (i *IntroductionErrorEncrypter) Type() EncrypterType {
+	return EncrypterTypeIntroduction
+}
+
+// Encode serializes the ephemeral public key of the error encrypter, followed
+// by the hash of the incoming onion blob.
+func (i *IntroductionErrorEncrypter) Encode(w io.Writer) error {
+	if err := i.ErrorEncrypter.Encode(w); err != nil {
+		return err
+	}
+
+	_, err := w.Write(i.OnionSHA256[:])
+	return err
+}
+
+// Decode reconstructs the ephemeral public key of the error encrypter and the
+// hash of the incoming onion blob.
+func (i *IntroductionErrorEncrypter) Decode(r io.Reader) error {
+	if err := i.ErrorEncrypter.Decode(r); err != nil {
+		return err
+	}
+
+	_, err := io.ReadFull(r, i.OnionSHA256[:])
+	return err
+}
+
+// A compile time check to ensure IntroductionErrorEncrypter implements the
+// ErrorEncrypter interface.
+var _ ErrorEncrypter = (*IntroductionErrorEncrypter)(nil)
@@ -47,16 +47,22 @@ type sphinxHopIterator struct {
 	// includes the information required to properly forward the packet to
 	// the next hop.
 	processedPacket *sphinx.ProcessedPacket
+
+	// blindingKit contains the information required to process the packet
+	// if the HTLC is part of a blinded route.
+	blindingKit BlindingKit
 }
 
 // makeSphinxHopIterator converts a processed packet returned from a sphinx
 // router and converts it into an hop iterator for usage in the link.
 func makeSphinxHopIterator(ogPacket *sphinx.OnionPacket,
-	packet *sphinx.ProcessedPacket) *sphinxHopIterator {
+	packet *sphinx.ProcessedPacket,
+	blindingKit BlindingKit) *sphinxHopIterator {
 
 	return &sphinxHopIterator{
 		ogPacket:        ogPacket,
 		processedPacket: packet,
+		blindingKit:     blindingKit,
 	}
 }
 
@@ -79,20 +85,61 @@ func (r *sphinxHopIterator) EncodeNextHop(w io.Writer) error {
 //
 // NOTE: Part of the HopIterator interface.
 func (r *sphinxHopIterator) HopPayload() (*Payload, error) {
+	isBlinded := r.blindingKit.UpdateAddBlinding != nil
+
 	switch r.processedPacket.Payload.Type {
 
 	// If this is the legacy payload, then we'll extract the information
-	// directly from the pre-populated ForwardingInstructions field.
+	// directly from the pre-populated ForwardingInstructions field. Hops
+	// in a blinded route always use TLV payloads.
 	case sphinx.PayloadLegacy:
+		if isBlinded {
+			return nil, fmt.Errorf("%w: legacy payload in blinded "+
+				"route", ErrInvalidBlinding)
+		}
+
 		fwdInst := r.processedPacket.ForwardingInstructions
 		return NewLegacyPayload(fwdInst), nil
 
 	// Otherwise, if this is the TLV payload, then we'll make a new stream
 	// to decode only what we need to make routing decisions.
 	case sphinx.PayloadTLV:
-		return NewPayloadFromReader(bytes.NewReader(
+		payload, err := NewPayloadFromReader(bytes.NewReader(
 			r.processedPacket.Payload.Payload,
 		))
+		switch {
+		// Hops that follow the introduction node of a blinded route
+		// report any failure as an invalid blinding.
+		case err != nil && isBlinded:
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBlinding, err)
+
+		case err != nil:
+			return nil, err
+		}
+
+		// If there's no encrypted data for us, this is a regular hop
+		// unless the incoming HTLC claims otherwise.
+		if payload.EncryptedData() == nil {
+			if isBlinded {
+				return nil, fmt.Errorf("%w: no encrypted data "+
+					"in payload", ErrInvalidBlinding)
+			}
+
+			return payload, nil
+		}
+
+		// Otherwise, we are part of a blinded route and the
+		// forwarding information needs to be recovered from the
+		// encrypted data.
+		fwdInfo, err := r.blindingKit.forwardingInfo(
+			payload, r.processedPacket.Action == sphinx.ExitNode,
+		)
+		if err != nil {
+			return nil, err
+		}
+		payload.FwdInfo = *fwdInfo
+
+		return payload, nil
 
 	default:
 		return nil, fmt.Errorf("unknown sphinx payload type: %v",
@@ -149,7 +196,8 @@ func (p *OnionProcessor) Stop() error {
 // instance using the rHash as the associated data when checking the relevant
 // MACs during the decoding process.
 func (p *OnionProcessor) DecodeHopIterator(r io.Reader, rHash []byte,
-	incomingCltv uint32) (Iterator, lnwire.FailCode) {
+	incomingCltv uint32, incomingAmount lnwire.MilliSatoshi,
+	blindingPoint *btcec.PublicKey) (Iterator, lnwire.FailCode) {
 
 	onionPkt := &sphinx.OnionPacket{}
 	if err := onionPkt.Decode(r); err != nil {
@@ -170,7 +218,7 @@ func (p *OnionProcessor) DecodeHopIterator(r io.Reader, rHash []byte,
 	// case of a replay, an attacker is *forced* to use the same payment
 	// hash twice, thereby losing their money entirely.
 	sphinxPacket, err := p.router.ProcessOnionPacket(
-		onionPkt, rHash, incomingCltv,
+		onionPkt, rHash, incomingCltv, processOnionOpts(blindingPoint)...,
 	)
 	if err != nil {
 		switch err {
@@ -186,14 +234,40 @@ func (p *OnionProcessor) DecodeHopIterator(r io.Reader, rHash []byte,
 		}
 	}
 
-	return makeSphinxHopIterator(onionPkt, sphinxPacket), lnwire.CodeNone
+	blindingKit := BlindingKit{
+		Processor:         p.router,
+		UpdateAddBlinding: blindingPoint,
+		IncomingAmount:    incomingAmount,
+		IncomingCltv:      incomingCltv,
+	}
+
+	return makeSphinxHopIterator(
+		onionPkt, sphinxPacket, blindingKit,
+	), lnwire.CodeNone
+}
+
+// ReconstructBlindingInfo contains the information about an incoming HTLC
+// that is needed to reconstruct its hop iterator if it is relayed within a
+// blinded route.
+type ReconstructBlindingInfo struct {
+	// BlindingKey is the blinding point that was provided in the
+	// update_add_htlc of the HTLC. It is nil unless we are a relaying node
+	// after the introduction node of a blinded route.
+	BlindingKey *btcec.PublicKey
+
+	// IncomingAmt is the amount of the incoming HTLC.
+	IncomingAmt lnwire.MilliSatoshi
+
+	// IncomingExpiry is the expiry height of the incoming HTLC.
+	IncomingExpiry uint32
 }
 
 // ReconstructHopIterator attempts to decode a valid sphinx packet from the passed io.Reader
 // instance using the rHash as the associated data when checking the relevant
-// MACs during the decoding process.
-func (p *OnionProcessor) ReconstructHopIterator(r io.Reader, rHash []byte) (
-	Iterator, error) {
+// MACs during the decoding process. The blinding info of the HTLC is needed to
+// process the packet if the HTLC is relayed within a blinded route.
+func (p *OnionProcessor) ReconstructHopIterator(r io.Reader, rHash []byte,
+	blindingInfo ReconstructBlindingInfo) (Iterator, error) {
 
 	onionPkt := &sphinx.OnionPacket{}
 	if err := onionPkt.Decode(r); err != nil {
@@ -205,21 +279,33 @@ func (p *OnionProcessor) ReconstructHopIterator(r io.Reader, rHash []byte) (
 	// associated data in order to thwart attempts a replay attacks. In the
 	// case of a replay, an attacker is *forced* to use the same payment
 	// hash twice, thereby losing their money entirely.
-	sphinxPacket, err := p.router.ReconstructOnionPacket(onionPkt, rHash)
+	sphinxPacket, err := p.router.ReconstructOnionPacket(
+		onionPkt, rHash,
+		processOnionOpts(blindingInfo.BlindingKey)...,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	return makeSphinxHopIterator(onionPkt, sphinxPacket), nil
+	blindingKit := BlindingKit{
+		Processor:         p.router,
+		UpdateAddBlinding: blindingInfo.BlindingKey,
+		IncomingAmount:    blindingInfo.IncomingAmt,
+		IncomingCltv:      blindingInfo.IncomingExpiry,
+	}
+
+	return makeSphinxHopIterator(onionPkt, sphinxPacket, blindingKit), nil
 }
 
 // DecodeHopIteratorRequest encapsulates all date necessary to process an onion
 // packet, perform sphinx replay detection, and schedule the entry for garbage
 // collection.
 type DecodeHopIteratorRequest struct {
-	OnionReader  io.Reader
-	RHash        []byte
-	IncomingCltv uint32
+	OnionReader    io.Reader
+	RHash          []byte
+	IncomingCltv   uint32
+	IncomingAmount lnwire.MilliSatoshi
+	BlindingPoint  *btcec.PublicKey
 }
 
 // DecodeHopIteratorResponse encapsulates the outcome of a batched sphinx onion
@@ -277,6 +363,7 @@ func (p *OnionProcessor) DecodeHopIterators(id []byte,
 
 		err = tx.ProcessOnionPacket(
 			seqNum, onionPkt, req.RHash, req.IncomingCltv,
+			processOnionOpts(req.BlindingPoint)...,
 		)
 		switch err {
 		case nil:
@@ -376,12 +463,33 @@ func (p *OnionProcessor) DecodeHopIterators(id []byte,
 
 		// Finally, construct a hop iterator from our processed sphinx
 		// packet, simultaneously caching the original onion packet.
-		resp.HopIterator = makeSphinxHopIterator(&onionPkts[i], &packets[i])
+		blindingKit := BlindingKit{
+			Processor:         p.router,
+			UpdateAddBlinding: reqs[i].BlindingPoint,
+			IncomingAmount:    reqs[i].IncomingAmount,
+			IncomingCltv:      reqs[i].IncomingCltv,
+		}
+		resp.HopIterator = makeSphinxHopIterator(
+			&onionPkts[i], &packets[i], blindingKit,
+		)
 	}
 
 	return resps, nil
 }
 
+// processOnionOpts returns the options that should be used to process an
+// onion packet. If the HTLC is part of a blinded route, the blinding point
+// provided in the update_add_htlc is needed to derive the shared secret.
+func processOnionOpts(blindingPoint *btcec.PublicKey) []sphinx.ProcessOnionOpt {
+	if blindingPoint == nil {
+		return nil
+	}
+
+	return []sphinx.ProcessOnionOpt{
+		sphinx.WithBlindingPoint(blindingPoint),
+	}
+}
+
 // ExtractErrorEncrypter takes an io.Reader which should contain the onion
 // packet as original received by a forwarding node and creates an
 // ErrorEncrypter instance using the derived shared secret. In the case that en
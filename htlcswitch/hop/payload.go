@@ -9,6 +9,7 @@ import (
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/record"
 	"github.com/ltcsuite/lnd/tlv"
+	"github.com/ltcsuite/ltcd/btcec/v2"
 )
 
 // PayloadViolation is an enum encapsulating the possible invalid payload
@@ -97,6 +98,18 @@ type Payload struct {
 	// metadata is additional data that is sent along with the payment to
 	// the payee.
 	metadata []byte
+
+	// encryptedData is the encrypted recipient data that the creator of a
+	// blinded route provided for this hop.
+	encryptedData []byte
+
+	// blindingPoint is the blinding point of a blinded route, which is
+	// only provided in the payload of the introduction node.
+	blindingPoint *btcec.PublicKey
+
+	// totalAmtMsat is the total amount of a payment to a blinded route,
+	// which is only provided in the payload of the final hop.
+	totalAmtMsat lnwire.MilliSatoshi
 }
 
 // NewLegacyPayload builds a Payload from the amount, cltv, and next hop
@@ -117,14 +130,21 @@ func NewLegacyPayload(f *sphinx.HopData) *Payload {
 
 // NewPayloadFromReader builds a new Hop from the passed io.Reader. The reader
 // should correspond to the bytes encapsulated in a TLV onion payload.
+//
+// NOTE: For intermediate hops in a blinded route the forwarding information
+// is only known once the encrypted data has been decrypted, so the returned
+// ForwardingInfo is left blank.
 func NewPayloadFromReader(r io.Reader) (*Payload, error) {
 	var (
-		cid      uint64
-		amt      uint64
-		cltv     uint32
-		mpp      = &record.MPP{}
-		amp      = &record.AMP{}
-		metadata []byte
+		cid           uint64
+		amt           uint64
+		cltv          uint32
+		mpp           = &record.MPP{}
+		amp           = &record.AMP{}
+		metadata      []byte
+		encryptedData []byte
+		blindingPoint *btcec.PublicKey
+		totalAmtMsat  uint64
 	)
 
 	tlvStream, err := tlv.NewStream(
@@ -133,7 +153,10 @@ func NewPayloadFromReader(r io.Reader) (*Payload, error) {
 		record.NewNextHopIDRecord(&cid),
 		mpp.Record(),
 		amp.Record(),
+		record.NewEncryptedDataRecord(&encryptedData),
+		record.NewBlindingPointRecord(&blindingPoint),
 		record.NewMetadataRecord(&metadata),
+		record.NewTotalAmtMsatBlinded(&totalAmtMsat),
 	)
 	if err != nil {
 		return nil, err
@@ -147,9 +170,18 @@ func NewPayloadFromReader(r io.Reader) (*Payload, error) {
 	}
 
 	// Validate whether the sender properly included or omitted tlv records
-	// in accordance with BOLT 04.
+	// in accordance with BOLT 04. Hops in a blinded route follow a
+	// different set of rules, as they receive most of their forwarding
+	// information in the encrypted data.
 	nextHop := lnwire.NewShortChanIDFromInt(cid)
-	err = ValidateParsedPayloadTypes(parsedTypes, nextHop)
+	isFinalHop := nextHop == Exit
+
+	_, isBlinded := parsedTypes[record.EncryptedDataOnionType]
+	if isBlinded {
+		isFinalHop, err = ValidateBlindedPayloadTypes(parsedTypes)
+	} else {
+		err = ValidateParsedPayloadTypes(parsedTypes, nextHop)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +192,7 @@ func NewPayloadFromReader(r io.Reader) (*Payload, error) {
 		return nil, ErrInvalidPayload{
 			Type:      *violatingType,
 			Violation: RequiredViolation,
-			FinalHop:  nextHop == Exit,
+			FinalHop:  isFinalHop,
 		}
 	}
 
@@ -182,6 +214,12 @@ func NewPayloadFromReader(r io.Reader) (*Payload, error) {
 		metadata = nil
 	}
 
+	// If no blinding point was parsed, make sure that we don't return a
+	// dangling pointer in the resulting payload.
+	if _, ok := parsedTypes[record.BlindingPointOnionType]; !ok {
+		blindingPoint = nil
+	}
+
 	// Filter out the custom records.
 	customRecords := NewCustomRecords(parsedTypes)
 
@@ -195,6 +233,9 @@ func NewPayloadFromReader(r io.Reader) (*Payload, error) {
 		MPP:           mpp,
 		AMP:           amp,
 		metadata:      metadata,
+		encryptedData: encryptedData,
+		blindingPoint: blindingPoint,
+		totalAmtMsat:  lnwire.MilliSatoshi(totalAmtMsat),
 		customRecords: customRecords,
 	}, nil
 }
@@ -232,9 +273,29 @@ func ValidateParsedPayloadTypes(parsedTypes tlv.TypeMap,
 	_, hasNextHop := parsedTypes[record.NextHopOnionType]
 	_, hasMPP := parsedTypes[record.MPPOnionType]
 	_, hasAMP := parsedTypes[record.AMPOnionType]
+	_, hasBlindingPoint := parsedTypes[record.BlindingPointOnionType]
+	_, hasTotalAmt := parsedTypes[record.TotalAmtMsatBlindedType]
 
 	switch {
 
+	// The blinding point is only provided to the introduction node of a
+	// blinded route, which must also receive encrypted data.
+	case hasBlindingPoint:
+		return ErrInvalidPayload{
+			Type:      record.BlindingPointOnionType,
+			Violation: IncludedViolation,
+			FinalHop:  isFinalHop,
+		}
+
+	// The blinded total amount is only provided to the final hop of a
+	// blinded route, which must also receive encrypted data.
+	case hasTotalAmt:
+		return ErrInvalidPayload{
+			Type:      record.TotalAmtMsatBlindedType,
+			Violation: IncludedViolation,
+			FinalHop:  isFinalHop,
+		}
+
 	// All hops must include an amount to forward.
 	case !hasAmt:
 		return ErrInvalidPayload{
@@ -281,6 +342,83 @@ func ValidateParsedPayloadTypes(parsedTypes tlv.TypeMap,
 	return nil
 }
 
+// ValidateBlindedPayloadTypes checks the types parsed from the payload of a
+// hop in a blinded route to ensure that the proper fields are either included
+// or omitted. Intermediate hops in a blinded route receive their forwarding
+// information in the encrypted data, whereas the final hop must be provided
+// with the amount, expiry and total amount of the payment. The returned
+// boolean is true if the payload was parsed for the final hop. The
+// requirements for this method are described in BOLT 04.
+func ValidateBlindedPayloadTypes(parsedTypes tlv.TypeMap) (bool, error) {
+	_, hasAmt := parsedTypes[record.AmtOnionType]
+	_, hasLockTime := parsedTypes[record.LockTimeOnionType]
+	_, hasNextHop := parsedTypes[record.NextHopOnionType]
+	_, hasMPP := parsedTypes[record.MPPOnionType]
+	_, hasAMP := parsedTypes[record.AMPOnionType]
+	_, hasTotalAmt := parsedTypes[record.TotalAmtMsatBlindedType]
+
+	// Any of the payment amount fields mark the payload as the final hop,
+	// in which case all of them must be present.
+	isFinalHop := hasAmt || hasLockTime || hasTotalAmt
+
+	switch {
+
+	// Hops in a blinded route receive the next hop in the encrypted data.
+	case hasNextHop:
+		return isFinalHop, ErrInvalidPayload{
+			Type:      record.NextHopOnionType,
+			Violation: IncludedViolation,
+			FinalHop:  isFinalHop,
+		}
+
+	// Blinded routes do not support MPP fields.
+	case hasMPP:
+		return isFinalHop, ErrInvalidPayload{
+			Type:      record.MPPOnionType,
+			Violation: IncludedViolation,
+			FinalHop:  isFinalHop,
+		}
+
+	// Blinded routes do not support AMP fields.
+	case hasAMP:
+		return isFinalHop, ErrInvalidPayload{
+			Type:      record.AMPOnionType,
+			Violation: IncludedViolation,
+			FinalHop:  isFinalHop,
+		}
+
+	// Intermediate hops don't need any of the following checks.
+	case !isFinalHop:
+		return false, nil
+
+	// The final hop must include an amount.
+	case !hasAmt:
+		return true, ErrInvalidPayload{
+			Type:      record.AmtOnionType,
+			Violation: OmittedViolation,
+			FinalHop:  true,
+		}
+
+	// The final hop must include a cltv expiry.
+	case !hasLockTime:
+		return true, ErrInvalidPayload{
+			Type:      record.LockTimeOnionType,
+			Violation: OmittedViolation,
+			FinalHop:  true,
+		}
+
+	// The final hop must include the total amount of the payment.
+	case !hasTotalAmt:
+		return true, ErrInvalidPayload{
+			Type:      record.TotalAmtMsatBlindedType,
+			Violation: OmittedViolation,
+			FinalHop:  true,
+		}
+	}
+
+	return true, nil
+}
+
 // MultiPath returns the record corresponding the option_mpp parsed from the
 // onion payload.
 func (h *Payload) MultiPath() *record.MPP {
@@ -305,6 +443,24 @@ func (h *Payload) Metadata() []byte {
 	return h.metadata
 }
 
+// EncryptedData returns the encrypted recipient data that the creator of a
+// blinded route provided for this hop.
+func (h *Payload) EncryptedData() []byte {
+	return h.encryptedData
+}
+
+// BlindingPoint returns the blinding point of a blinded route, which is only
+// provided in the payload of the introduction node.
+func (h *Payload) BlindingPoint() *btcec.PublicKey {
+	return h.blindingPoint
+}
+
+// TotalAmtMsat returns the total amount of a payment to a blinded route, which
+// is only provided in the payload of the final hop.
+func (h *Payload) TotalAmtMsat() lnwire.MilliSatoshi {
+	return h.totalAmtMsat
+}
+
 // getMinRequiredViolation checks for unrecognized required (even) fields in the
 // standard range and returns the lowest required type. Always returning the
 // lowest required type allows a failure message to be deterministic.
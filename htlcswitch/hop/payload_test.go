@@ -21,6 +21,7 @@ type decodePayloadTest struct {
 	shouldHaveMPP      bool
 	shouldHaveAMP      bool
 	shouldHaveMetadata bool
+	shouldHaveEncData  bool
 }
 
 var decodePayloadTests = []decodePayloadTest{
@@ -271,6 +272,91 @@ var decodePayloadTests = []decodePayloadTest{
 		},
 		shouldHaveMetadata: true,
 	},
+	{
+		name: "intermediate blinded hop valid",
+		payload: []byte{
+			// encrypted data
+			0x0a, 0x03, 0x03, 0x02, 0x01,
+		},
+		shouldHaveEncData: true,
+	},
+	{
+		name: "final blinded hop valid",
+		payload: []byte{
+			// amount
+			0x02, 0x00,
+			// cltv
+			0x04, 0x00,
+			// encrypted data
+			0x0a, 0x03, 0x03, 0x02, 0x01,
+			// total amount
+			0x12, 0x01, 0x01,
+		},
+		shouldHaveEncData: true,
+	},
+	{
+		name: "intermediate blinded hop next sid present",
+		payload: []byte{
+			// next hop id
+			0x06, 0x08, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00,
+			// encrypted data
+			0x0a, 0x03, 0x03, 0x02, 0x01,
+		},
+		expErr: hop.ErrInvalidPayload{
+			Type:      record.NextHopOnionType,
+			Violation: hop.IncludedViolation,
+			FinalHop:  false,
+		},
+	},
+	{
+		name: "final blinded hop no total amount",
+		payload: []byte{
+			// amount
+			0x02, 0x00,
+			// cltv
+			0x04, 0x00,
+			// encrypted data
+			0x0a, 0x03, 0x03, 0x02, 0x01,
+		},
+		expErr: hop.ErrInvalidPayload{
+			Type:      record.TotalAmtMsatBlindedType,
+			Violation: hop.OmittedViolation,
+			FinalHop:  true,
+		},
+	},
+	{
+		name: "final blinded hop no expiry",
+		payload: []byte{
+			// amount
+			0x02, 0x00,
+			// encrypted data
+			0x0a, 0x03, 0x03, 0x02, 0x01,
+			// total amount
+			0x12, 0x01, 0x01,
+		},
+		expErr: hop.ErrInvalidPayload{
+			Type:      record.LockTimeOnionType,
+			Violation: hop.OmittedViolation,
+			FinalHop:  true,
+		},
+	},
+	{
+		name: "final hop total amount without encrypted data",
+		payload: []byte{
+			// amount
+			0x02, 0x00,
+			// cltv
+			0x04, 0x00,
+			// total amount
+			0x12, 0x01, 0x01,
+		},
+		expErr: hop.ErrInvalidPayload{
+			Type:      record.TotalAmtMsatBlindedType,
+			Violation: hop.IncludedViolation,
+			FinalHop:  true,
+		},
+	},
 }
 
 // TestDecodeHopPayloadRecordValidation asserts that parsing the payloads in the
@@ -308,6 +394,7 @@ func testDecodeHopPayloadValidation(t *testing.T, test decodePayloadTest) {
 		}
 		testMetadata   = []byte{1, 2, 3}
 		testChildIndex = uint32(9)
+		testEncData    = []byte{3, 2, 1}
 	)
 
 	p, err := hop.NewPayloadFromReader(bytes.NewReader(test.payload))
@@ -354,6 +441,12 @@ func testDecodeHopPayloadValidation(t *testing.T, test decodePayloadTest) {
 		t.Fatalf("unexpected metadata")
 	}
 
+	if test.shouldHaveEncData {
+		require.Equal(t, testEncData, p.EncryptedData())
+	} else {
+		require.Nil(t, p.EncryptedData())
+	}
+
 	// Convert expected nil map to empty map, because we always expect an
 	// initiated map from the payload.
 	expCustomRecords := make(record.CustomSet)
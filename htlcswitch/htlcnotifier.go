@@ -146,6 +146,14 @@ type HtlcInfo struct {
 
 	// OutgoingAmt is the amount of the htlc on our outgoing channel.
 	OutgoingAmt lnwire.MilliSatoshi
+
+	// IncomingEndorsement is the experimental endorsement signal of the
+	// htlc on our incoming channel.
+	IncomingEndorsement lnwire.Endorsement
+
+	// OutgoingEndorsement is the experimental endorsement signal of the
+	// htlc on our outgoing channel. It is only known for adds.
+	OutgoingEndorsement lnwire.Endorsement
 }
 
 // String returns a string representation of a htlc.
@@ -447,12 +455,19 @@ func newHtlcKey(pkt *htlcPacket) HtlcKey {
 
 // newHtlcInfo returns HtlcInfo for the packet provided.
 func newHtlcInfo(pkt *htlcPacket) HtlcInfo {
-	return HtlcInfo{
-		IncomingTimeLock: pkt.incomingTimeout,
-		OutgoingTimeLock: pkt.outgoingTimeout,
-		IncomingAmt:      pkt.incomingAmount,
-		OutgoingAmt:      pkt.amount,
+	info := HtlcInfo{
+		IncomingTimeLock:    pkt.incomingTimeout,
+		OutgoingTimeLock:    pkt.outgoingTimeout,
+		IncomingAmt:         pkt.incomingAmount,
+		OutgoingAmt:         pkt.amount,
+		IncomingEndorsement: pkt.inWireCustomRecords.Endorsement(),
 	}
+
+	if htlc, ok := pkt.htlc.(*lnwire.UpdateAddHTLC); ok {
+		info.OutgoingEndorsement = htlc.CustomRecords.Endorsement()
+	}
+
+	return info
 }
 
 // getEventType returns the htlc type based on the fields set in the htlc
@@ -3,7 +3,9 @@ package htlcswitch
 import (
 	"crypto/sha256"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/ltcsuite/lnd/chainntnfs"
@@ -60,6 +62,10 @@ type InterceptableSwitch struct {
 	// heldHtlcSet keeps track of outstanding intercepted forwards.
 	heldHtlcSet *heldHtlcSet
 
+	// heldHtlcRequests is where requests for the htlcs that are held by
+	// the interceptor are sent to the main event loop.
+	heldHtlcRequests chan chan []PendingHtlc
+
 	// cltvRejectDelta defines the number of blocks before the expiry of the
 	// htlc where we no longer intercept it and instead cancel it back.
 	cltvRejectDelta uint32
@@ -105,6 +111,10 @@ const (
 
 	// FwdActionFail fails the intercepted packet back to the sender.
 	FwdActionFail
+
+	// FwdActionResumeModified forwards the intercepted packet to the switch
+	// with modified outgoing htlc parameters.
+	FwdActionResumeModified
 )
 
 // FwdResolution defines the action to be taken on an intercepted packet.
@@ -126,6 +136,19 @@ type FwdResolution struct {
 	// FailureCode is the failure code that is to be passed back to the
 	// sender if action is FwdActionFail.
 	FailureCode lnwire.FailCode
+
+	// OutgoingAmount, if set, replaces the amount of the outgoing htlc if
+	// action is FwdActionResumeModified.
+	OutgoingAmount *lnwire.MilliSatoshi
+
+	// OutgoingExpiry, if set, replaces the expiry of the outgoing htlc if
+	// action is FwdActionResumeModified.
+	OutgoingExpiry *uint32
+
+	// CustomRecords, if set, are the custom records that are attached to
+	// the outgoing htlc if action is FwdActionResumeModified. They must
+	// have been validated by the caller.
+	CustomRecords lnwire.CustomRecords
 }
 
 type fwdResolution struct {
@@ -176,6 +199,7 @@ func NewInterceptableSwitch(cfg *InterceptableSwitchConfig) (
 		onchainIntercepted:      make(chan InterceptedForward),
 		interceptorRegistration: make(chan ForwardInterceptor),
 		heldHtlcSet:             newHeldHtlcSet(),
+		heldHtlcRequests:        make(chan chan []PendingHtlc),
 		resolutionChan:          make(chan *fwdResolution),
 		requireInterceptor:      cfg.RequireInterceptor,
 		cltvRejectDelta:         cfg.CltvRejectDelta,
@@ -290,6 +314,9 @@ func (s *InterceptableSwitch) run() error {
 		case res := <-s.resolutionChan:
 			res.errChan <- s.resolve(res.resolution)
 
+		case respChan := <-s.heldHtlcRequests:
+			respChan <- s.heldHtlcSet.pendingHtlcs(time.Now())
+
 		case currentBlock, ok := <-s.blockEpochStream.Epochs:
 			if !ok {
 				return errBlockStreamStopped
@@ -307,6 +334,103 @@ func (s *InterceptableSwitch) run() error {
 	}
 }
 
+// heldHtlcs returns the htlcs that are currently held by the interceptor.
+func (s *InterceptableSwitch) heldHtlcs() ([]PendingHtlc, error) {
+	respChan := make(chan []PendingHtlc, 1)
+
+	select {
+	case s.heldHtlcRequests <- respChan:
+	case <-s.quit:
+		return nil, ErrSwitchExiting
+	}
+
+	select {
+	case htlcs := <-respChan:
+		return htlcs, nil
+	case <-s.quit:
+		return nil, ErrSwitchExiting
+	}
+}
+
+// PendingHtlcs returns all htlcs that are currently held by the switch or the
+// interceptor, along with the state that keeps them from being resolved. The
+// htlcs are sorted by their hold time, starting with the longest.
+func (s *InterceptableSwitch) PendingHtlcs() ([]PendingHtlc, error) {
+	htlcs, err := s.htlcSwitch.PendingHtlcs()
+	if err != nil {
+		return nil, err
+	}
+
+	held, err := s.heldHtlcs()
+	if err != nil {
+		return nil, err
+	}
+
+	// An htlc that is reforwarded after a restart may already have been
+	// committed to the circuit map. If it is held by the interceptor, that
+	// is what keeps it from being resolved.
+	heldIndex := make(map[CircuitKey]int, len(held))
+	for i := range held {
+		heldIndex[held[i].Incoming] = i
+	}
+	for i := range htlcs {
+		if _, ok := heldIndex[htlcs[i].Incoming]; !ok {
+			continue
+		}
+
+		htlcs[i].State = PendingHtlcIntercepted
+		delete(heldIndex, htlcs[i].Incoming)
+	}
+	for _, i := range heldIndex {
+		htlcs = append(htlcs, held[i])
+	}
+
+	sort.Slice(htlcs, func(i, j int) bool {
+		return htlcs[i].HoldTime > htlcs[j].HoldTime
+	})
+
+	return htlcs, nil
+}
+
+// LookupPendingHtlc returns the htlc identified by its incoming circuit key,
+// along with the state that keeps it from being resolved. Next to the htlcs
+// held by the switch, htlcs held by the interceptor are considered.
+// ErrUnknownCircuit is returned if neither holds the htlc.
+func (s *InterceptableSwitch) LookupPendingHtlc(
+	inKey CircuitKey) (*PendingHtlc, error) {
+
+	htlc, err := s.htlcSwitch.LookupPendingHtlc(inKey)
+	if err != nil && err != ErrUnknownCircuit {
+		return nil, err
+	}
+
+	held, err := s.heldHtlcs()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range held {
+		if held[i].Incoming != inKey {
+			continue
+		}
+
+		// The htlc may already have been committed to the circuit
+		// map if it was reforwarded after a restart.
+		if htlc != nil {
+			htlc.State = PendingHtlcIntercepted
+			return htlc, nil
+		}
+
+		return &held[i], nil
+	}
+
+	if htlc == nil {
+		return nil, ErrUnknownCircuit
+	}
+
+	return htlc, nil
+}
+
 func (s *InterceptableSwitch) failExpiredHtlcs() {
 	s.heldHtlcSet.popAutoFails(
 		uint32(s.currentHeight),
@@ -373,6 +497,12 @@ func (s *InterceptableSwitch) resolve(res *FwdResolution) error {
 	case FwdActionResume:
 		return intercepted.Resume()
 
+	case FwdActionResumeModified:
+		return intercepted.ResumeModified(
+			res.OutgoingAmount, res.OutgoingExpiry,
+			res.CustomRecords,
+		)
+
 	case FwdActionSettle:
 		return intercepted.Settle(res.Preimage)
 
@@ -605,6 +735,9 @@ func (f *interceptedForward) Packet() InterceptedPacket {
 		CustomRecords:  f.packet.customRecords,
 		OnionBlob:      f.htlc.OnionBlob,
 		AutoFailHeight: f.autoFailHeight,
+
+		InWireCustomRecords:  f.packet.inWireCustomRecords,
+		OutWireCustomRecords: f.htlc.CustomRecords,
 	}
 }
 
@@ -615,6 +748,35 @@ func (f *interceptedForward) Resume() error {
 	return f.htlcSwitch.ForwardPackets(nil, f.packet)
 }
 
+// ResumeModified resumes the default behavior, but with the amount, expiry and
+// custom records of the outgoing htlc replaced by the given values if set. The
+// modified htlc still needs to satisfy the policy of the outgoing channel.
+func (f *interceptedForward) ResumeModified(amount *lnwire.MilliSatoshi,
+	expiry *uint32, customRecords lnwire.CustomRecords) error {
+
+	// Modify copies of the htlc and packet, so that the intercepted
+	// forward is left untouched if anything goes wrong.
+	htlc := *f.htlc
+	if amount != nil {
+		htlc.Amount = *amount
+	}
+	if expiry != nil {
+		htlc.Expiry = *expiry
+	}
+	if customRecords != nil {
+		htlc.CustomRecords = customRecords
+	}
+
+	packet := *f.packet
+	packet.htlc = &htlc
+	packet.amount = htlc.Amount
+	packet.outgoingTimeout = htlc.Expiry
+
+	// Forward to the switch. A link quit channel isn't needed, because we
+	// are on a different thread now.
+	return f.htlcSwitch.ForwardPackets(nil, &packet)
+}
+
 // Fail notifies the intention to Fail an existing hold forward with an
 // encrypted failure reason.
 func (f *interceptedForward) Fail(reason []byte) error {
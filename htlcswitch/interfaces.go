@@ -197,10 +197,12 @@ type ChannelLink interface {
 	// satisfy the current forwarding policy fo the target link. Otherwise,
 	// a LinkError with a valid protocol failure message should be returned
 	// in order to signal to the source of the HTLC, the policy consistency
-	// issue.
+	// issue. The inbound fee is the fee that the incoming link charges on
+	// top of the fee of the outgoing link.
 	CheckHtlcForward(payHash [32]byte, incomingAmt lnwire.MilliSatoshi,
 		amtToForward lnwire.MilliSatoshi,
 		incomingTimeout, outgoingTimeout uint32,
+		inboundFee models.InboundFee,
 		heightNow uint32, scid lnwire.ShortChannelID) *LinkError
 
 	// CheckHtlcTransit should return a nil error if the passed HTLC details
@@ -239,6 +241,11 @@ type ForwardingLog interface {
 	// sub-systems can then query the contents of the log for analysis,
 	// visualizations, etc.
 	AddForwardingEvents([]channeldb.ForwardingEvent) error
+
+	// AddHtlcForwardEvents writes out a batch of htlc forwarding events,
+	// which record the final outcome of settled as well as failed
+	// forwards.
+	AddHtlcForwardEvents([]channeldb.HtlcForwardEvent) error
 }
 
 // TowerClient is the primary interface used by the daemon to backup pre-signed
@@ -306,6 +313,14 @@ type InterceptedPacket struct {
 	// were included in the payload.
 	CustomRecords record.CustomSet
 
+	// InWireCustomRecords are the custom records that were attached to the
+	// update_add_htlc message of the incoming htlc.
+	InWireCustomRecords lnwire.CustomRecords
+
+	// OutWireCustomRecords are the custom records that will be attached to
+	// the outgoing htlc, unless they're modified by the interceptor.
+	OutWireCustomRecords lnwire.CustomRecords
+
 	// OnionBlob is the onion packet for the next hop
 	OnionBlob [lnwire.OnionPacketSize]byte
 
@@ -328,6 +343,14 @@ type InterceptedForward interface {
 	// this htlc which usually means forward it.
 	Resume() error
 
+	// ResumeModified notifies the intention to resume an existing hold
+	// forward with the amount, expiry and custom records of the outgoing
+	// htlc replaced by the given values. Nil values leave the htlc
+	// unchanged. The custom records must have been validated by the
+	// caller.
+	ResumeModified(amount *lnwire.MilliSatoshi, expiry *uint32,
+		customRecords lnwire.CustomRecords) error
+
 	// Settle notifies the intention to settle an existing hold
 	// forward with a given preimage.
 	Settle(lntypes.Preimage) error
@@ -202,6 +202,14 @@ type ChannelLinkConfig struct {
 	// before we do a state update.
 	BatchSize uint32
 
+	// AdaptiveBatching, if set, makes the link sign a new commitment as
+	// soon as it has no further updates waiting in its mailbox, while
+	// updates are only batched if the link is under load. This applies to
+	// Adds as well as Settles and Fails. The batch is signed once it is
+	// full or the BatchTicker fires, which bounds the latency added by
+	// batching.
+	AdaptiveBatching bool
+
 	// UnsafeReplay will cause a link to replay the adds in its latest
 	// commitment txn after the link is restarted. This should only be used
 	// in testing, it is here to ensure the sphinx replay detection on the
@@ -243,6 +251,15 @@ type ChannelLinkConfig struct {
 	// the initiator for channels of the anchor type.
 	MaxAnchorsCommitFeeRate chainfee.SatPerKWeight
 
+	// ReservedHtlcSlots is the number of commitment slots of the channel
+	// that are reserved for our own outgoing payments. Forwarded htlcs are
+	// rejected once only the reserved slots are left.
+	ReservedHtlcSlots uint16
+
+	// FwdExpEndorsement indicates whether the experimental endorsement
+	// signal of incoming htlcs should be forwarded to the outgoing htlcs.
+	FwdExpEndorsement bool
+
 	// NotifyActiveLink allows the link to tell the ChannelNotifier when a
 	// link is first started.
 	NotifyActiveLink func(wire.OutPoint)
@@ -1230,6 +1247,16 @@ func (l *channelLink) htlcManager() {
 		case msg := <-l.upstream:
 			l.handleUpstreamMsg(msg)
 
+			// With adaptive batching, a pending batch may have been
+			// held back because this message was still queued. If
+			// no further updates are waiting now, we'll sign it
+			// instead of waiting for the batch ticker.
+			if l.cfg.AdaptiveBatching && !l.failed &&
+				l.channel.PendingLocalUpdateCount() > 0 {
+
+				l.tryBatchUpdateCommitTx(nil)
+			}
+
 		// A htlc resolution is received. This means that we now have a
 		// resolution for a previously accepted htlc.
 		case hodlItem := <-l.hodlQueue.ChanOut():
@@ -1463,18 +1490,21 @@ func (l *channelLink) handleDownstreamUpdateAdd(pkt *htlcPacket) error {
 	_ = l.cfg.Peer.SendMessage(false, htlc)
 
 	// Send a forward event notification to htlcNotifier.
+	inEndorsement := pkt.inWireCustomRecords.Endorsement()
 	l.cfg.HtlcNotifier.NotifyForwardingEvent(
 		newHtlcKey(pkt),
 		HtlcInfo{
-			IncomingTimeLock: pkt.incomingTimeout,
-			IncomingAmt:      pkt.incomingAmount,
-			OutgoingTimeLock: htlc.Expiry,
-			OutgoingAmt:      htlc.Amount,
+			IncomingTimeLock:    pkt.incomingTimeout,
+			IncomingAmt:         pkt.incomingAmount,
+			OutgoingTimeLock:    htlc.Expiry,
+			OutgoingAmt:         htlc.Amount,
+			IncomingEndorsement: inEndorsement,
+			OutgoingEndorsement: htlc.CustomRecords.Endorsement(),
 		},
 		getEventType(pkt),
 	)
 
-	l.tryBatchUpdateCommitTx()
+	l.tryBatchUpdateCommitTx(pkt)
 
 	return nil
 }
@@ -1556,8 +1586,9 @@ func (l *channelLink) handleDownstreamPkt(pkt *htlcPacket) {
 			getEventType(pkt),
 		)
 
-		// Immediately update the commitment tx to minimize latency.
-		l.updateCommitTxOrFail()
+		// Update the commitment tx to minimize latency, unless the
+		// update can be batched with others.
+		l.updateCommitTxOrBatch(pkt)
 
 	case *lnwire.UpdateFailHTLC:
 		// If hodl.FailOutgoing mode is active, we exit early to
@@ -1573,13 +1604,7 @@ func (l *channelLink) handleDownstreamPkt(pkt *htlcPacket) {
 		// An HTLC cancellation has been triggered somewhere upstream,
 		// we'll remove then HTLC from our local state machine.
 		inKey := pkt.inKey()
-		err := l.channel.FailHTLC(
-			pkt.incomingHTLCID,
-			htlc.Reason,
-			pkt.sourceRef,
-			pkt.destRef,
-			&inKey,
-		)
+		failMsg, err := l.failIncomingHTLC(pkt, htlc)
 		if err != nil {
 			l.log.Errorf("unable to cancel incoming HTLC for "+
 				"circuit-key=%v: %v", inKey, err)
@@ -1605,16 +1630,9 @@ func (l *channelLink) handleDownstreamPkt(pkt *htlcPacket) {
 
 		l.closedCircuits = append(l.closedCircuits, pkt.inKey())
 
-		// With the HTLC removed, we'll need to populate the wire
-		// message to target the specific channel and HTLC to be
-		// canceled. The "Reason" field will have already been set
-		// within the switch.
-		htlc.ChanID = l.ChanID()
-		htlc.ID = pkt.incomingHTLCID
-
 		// We send the HTLC message to the peer which initially created
 		// the HTLC.
-		l.cfg.Peer.SendMessage(false, htlc)
+		l.cfg.Peer.SendMessage(false, failMsg)
 
 		// If the packet does not have a link failure set, it failed
 		// further down the route so we notify a forwarding failure.
@@ -1634,15 +1652,84 @@ func (l *channelLink) handleDownstreamPkt(pkt *htlcPacket) {
 			)
 		}
 
-		// Immediately update the commitment tx to minimize latency.
-		l.updateCommitTxOrFail()
+		// Update the commitment tx to minimize latency, unless the
+		// update can be batched with others.
+		l.updateCommitTxOrBatch(pkt)
 	}
 }
 
+// failIncomingHTLC removes the incoming HTLC of the given fail packet from our
+// local state machine, and returns the message that fails the HTLC towards the
+// remote peer. Relaying nodes within a blinded route must not pass on the
+// failure, so the HTLC is failed as malformed with an invalid blinding failure
+// instead.
+func (l *channelLink) failIncomingHTLC(pkt *htlcPacket,
+	fail *lnwire.UpdateFailHTLC) (lnwire.Message, error) {
+
+	inKey := pkt.inKey()
+
+	blindingPoint, onionBlob, err := l.channel.IncomingHtlcBlinding(
+		pkt.incomingHTLCID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if blindingPoint != nil {
+		shaOnionBlob := sha256.Sum256(onionBlob)
+		err := l.channel.MalformedFailHTLC(
+			pkt.incomingHTLCID, lnwire.CodeInvalidBlinding,
+			shaOnionBlob, pkt.sourceRef, pkt.destRef, &inKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &lnwire.UpdateFailMalformedHTLC{
+			ChanID:       l.ChanID(),
+			ID:           pkt.incomingHTLCID,
+			ShaOnionBlob: shaOnionBlob,
+			FailureCode:  lnwire.CodeInvalidBlinding,
+		}, nil
+	}
+
+	err = l.channel.FailHTLC(
+		pkt.incomingHTLCID, fail.Reason, pkt.sourceRef, pkt.destRef,
+		&inKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// With the HTLC removed, we'll need to populate the wire message to
+	// target the specific channel and HTLC to be canceled. The "Reason"
+	// field will have already been set within the switch.
+	fail.ChanID = l.ChanID()
+	fail.ID = pkt.incomingHTLCID
+
+	return fail, nil
+}
+
 // tryBatchUpdateCommitTx updates the commitment transaction if the batch is
-// full.
-func (l *channelLink) tryBatchUpdateCommitTx() {
+// full. With adaptive batching, the commitment transaction is also updated if
+// no further updates are waiting to be added to the batch. The packet that is
+// currently being processed, if any, isn't considered to be waiting.
+func (l *channelLink) tryBatchUpdateCommitTx(pkt *htlcPacket) {
 	if l.channel.PendingLocalUpdateCount() < uint64(l.cfg.BatchSize) {
+		if !l.cfg.AdaptiveBatching || l.mailBox.HasPendingUpdates(pkt) {
+			return
+		}
+	}
+
+	l.updateCommitTxOrFail()
+}
+
+// updateCommitTxOrBatch immediately updates the commitment transaction to
+// minimize latency. If adaptive batching is enabled, the update is added to
+// the current batch instead as long as the link is under load.
+func (l *channelLink) updateCommitTxOrBatch(pkt *htlcPacket) {
+	if l.cfg.AdaptiveBatching {
+		l.tryBatchUpdateCommitTx(pkt)
 		return
 	}
 
@@ -2378,7 +2465,22 @@ func (l *channelLink) Bandwidth() lnwire.MilliSatoshi {
 	// Get the balance available on the channel for new HTLCs. This takes
 	// the channel reserve into account so HTLCs up to this value won't
 	// violate it.
-	return l.channel.AvailableBalance()
+	bandwidth := l.channel.AvailableBalance()
+
+	// HTLCs that were handed to the link, but are still waiting in the
+	// mailbox, will use up the balance as soon as they are added to the
+	// channel. Reserve their amount already, so that concurrent payments
+	// don't pick this link for HTLCs that it won't be able to carry.
+	if l.mailBox == nil {
+		return bandwidth
+	}
+
+	pending := l.mailBox.PendingAddAmount()
+	if pending >= bandwidth {
+		return 0
+	}
+
+	return bandwidth - pending
 }
 
 // MayAddOutgoingHtlc indicates whether we can add an outgoing htlc with the
@@ -2544,6 +2646,7 @@ func (l *channelLink) UpdateForwardingPolicy(
 func (l *channelLink) CheckHtlcForward(payHash [32]byte,
 	incomingHtlcAmt, amtToForward lnwire.MilliSatoshi,
 	incomingTimeout, outgoingTimeout uint32,
+	inboundFee models.InboundFee,
 	heightNow uint32, originalScid lnwire.ShortChannelID) *LinkError {
 
 	l.RLock()
@@ -2553,7 +2656,17 @@ func (l *channelLink) CheckHtlcForward(payHash [32]byte,
 	// Using the amount of the incoming HTLC, we'll calculate the expected
 	// fee this incoming HTLC must carry in order to satisfy the
 	// constraints of the outgoing link.
-	expectedFee := ExpectedFee(policy, amtToForward)
+	outFee := ExpectedFee(policy, amtToForward)
+
+	// Add the inbound fee of the incoming link. It is calculated on the
+	// amount including the outgoing fee. A negative inbound fee acts as a
+	// discount, but the total fee can never become negative.
+	inFee := inboundFee.CalcFee(amtToForward + outFee)
+	expectedFeeMsat := int64(outFee) + inFee
+	if expectedFeeMsat < 0 {
+		expectedFeeMsat = 0
+	}
+	expectedFee := lnwire.MilliSatoshi(expectedFeeMsat)
 
 	// If the actual fee is less than our expected fee, then we'll reject
 	// this HTLC as it didn't provide a sufficient amount of fees, or the
@@ -2585,6 +2698,25 @@ func (l *channelLink) CheckHtlcForward(payHash [32]byte,
 		return err
 	}
 
+	// Make sure we don't use up the commitment slots that are reserved
+	// for our own payments, so that a peer jamming our channels with
+	// forwards can't prevent us from paying.
+	reservedSlots := l.cfg.ReservedHtlcSlots
+	if reservedSlots > 0 &&
+		l.channel.AvailableOutgoingHtlcSlots() <= reservedSlots {
+
+		l.log.Warnf("outgoing htlc(%x) would use reserved htlc slots",
+			payHash[:])
+
+		cb := func(upd *lnwire.ChannelUpdate) lnwire.FailureMessage {
+			return lnwire.NewTemporaryChannelFailure(upd)
+		}
+		failure := l.createFailureWithUpdate(false, originalScid, cb)
+		return NewDetailedLinkError(
+			failure, OutgoingFailureHtlcSlotsReserved,
+		)
+	}
+
 	// Finally, we'll ensure that the time-lock on the outgoing HTLC meets
 	// the following constraint: the incoming time-lock minus our time-lock
 	// delta should equal the outgoing time lock. Otherwise, whether the
@@ -2917,6 +3049,28 @@ func (l *channelLink) processRemoteSettleFails(fwdPkg *channeldb.FwdPkg,
 	}
 }
 
+// experimentalEndorsement returns the custom records of an outgoing htlc that
+// carry the experimental endorsement signal, given the custom records of the
+// incoming htlc. The outgoing htlc is only endorsed if the incoming htlc was,
+// otherwise it's explicitly unendorsed. Nil is returned if we don't forward
+// the signal.
+func (l *channelLink) experimentalEndorsement(
+	incoming lnwire.CustomRecords) lnwire.CustomRecords {
+
+	if !l.cfg.FwdExpEndorsement {
+		return nil
+	}
+
+	endorsement := lnwire.EndorsementUnendorsed
+	if incoming.Endorsement() == lnwire.EndorsementEndorsed {
+		endorsement = lnwire.EndorsementEndorsed
+	}
+
+	var records lnwire.CustomRecords
+
+	return records.WithEndorsement(endorsement)
+}
+
 // processRemoteAdds serially processes each of the Add payment descriptors
 // which have been "locked-in" by receiving a revocation from the remote party.
 // The forwarding package provided instructs how to process this batch,
@@ -2944,9 +3098,11 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			onionReader := bytes.NewReader(pd.OnionBlob)
 
 			req := hop.DecodeHopIteratorRequest{
-				OnionReader:  onionReader,
-				RHash:        pd.RHash[:],
-				IncomingCltv: pd.Timeout,
+				OnionReader:    onionReader,
+				RHash:          pd.RHash[:],
+				IncomingCltv:   pd.Timeout,
+				IncomingAmount: pd.Amount,
+				BlindingPoint:  pd.BlindingPoint,
 			}
 
 			decodeReqs = append(decodeReqs, req)
@@ -2966,6 +3122,13 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 		return
 	}
 
+	// Fetch the inbound fee of this link. It is passed along with the
+	// forwarded htlcs, so that the switch can check that the sender paid
+	// for it.
+	l.RLock()
+	inboundFee := l.cfg.FwrdingPolicy.InboundFee
+	l.RUnlock()
+
 	var switchPackets []*htlcPacket
 
 	for i, pd := range lockedInHtlcs {
@@ -3030,7 +3193,24 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 		heightNow := l.cfg.BestHeight()
 
 		pld, err := chanIterator.HopPayload()
-		if err != nil {
+		switch {
+		// If the HTLC is part of a blinded route, we must not reveal
+		// why we failed to process it. Nodes after the introduction
+		// node of the route fail the HTLC as malformed, so that the
+		// error can be replaced by the previous hop, while the
+		// introduction node returns an invalid blinding failure to the
+		// sender.
+		case goErrors.Is(err, hop.ErrInvalidBlinding):
+			l.log.Errorf("unable to process blinded hop: %v", err)
+
+			failure := lnwire.NewInvalidBlinding(onionBlob[:])
+			l.sendHTLCError(
+				pd, NewLinkError(failure), obfuscator, false,
+			)
+
+			continue
+
+		case err != nil:
 			// If we're unable to process the onion payload, or we
 			// received invalid onion payload failure, then we
 			// should send an error back to the caller so the HTLC
@@ -3056,6 +3236,15 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			continue
 		}
 
+		// If the blinding point is provided in our payload, we are the
+		// introduction node of a blinded route and replace any failure
+		// of the HTLC with an invalid blinding failure.
+		if pd.BlindingPoint == nil && pld.BlindingPoint() != nil {
+			obfuscator = hop.NewIntroductionErrorEncrypter(
+				obfuscator, onionBlob[:],
+			)
+		}
+
 		fwdInfo := pld.ForwardingInfo()
 
 		switch fwdInfo.NextHop {
@@ -3097,9 +3286,13 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				// Otherwise, it was already processed, we can
 				// can collect it and continue.
 				addMsg := &lnwire.UpdateAddHTLC{
-					Expiry:      fwdInfo.OutgoingCTLV,
-					Amount:      fwdInfo.AmountToForward,
-					PaymentHash: pd.RHash,
+					Expiry:        fwdInfo.OutgoingCTLV,
+					Amount:        fwdInfo.AmountToForward,
+					PaymentHash:   pd.RHash,
+					BlindingPoint: fwdInfo.NextBlinding,
+					CustomRecords: l.experimentalEndorsement(
+						pd.CustomRecords,
+					),
 				}
 
 				// Finally, we'll encode the onion packet for
@@ -3123,7 +3316,10 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 					obfuscator:      obfuscator,
 					incomingTimeout: pd.Timeout,
 					outgoingTimeout: fwdInfo.OutgoingCTLV,
+					inboundFee:      inboundFee,
 					customRecords:   pld.CustomRecords(),
+
+					inWireCustomRecords: pd.CustomRecords,
 				}
 				switchPackets = append(
 					switchPackets, updatePacket,
@@ -3139,9 +3335,13 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			// create the outgoing HTLC using the parameters as
 			// specified in the forwarding info.
 			addMsg := &lnwire.UpdateAddHTLC{
-				Expiry:      fwdInfo.OutgoingCTLV,
-				Amount:      fwdInfo.AmountToForward,
-				PaymentHash: pd.RHash,
+				Expiry:        fwdInfo.OutgoingCTLV,
+				Amount:        fwdInfo.AmountToForward,
+				PaymentHash:   pd.RHash,
+				BlindingPoint: fwdInfo.NextBlinding,
+				CustomRecords: l.experimentalEndorsement(
+					pd.CustomRecords,
+				),
 			}
 
 			// Finally, we'll encode the onion packet for the
@@ -3187,7 +3387,10 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 					obfuscator:      obfuscator,
 					incomingTimeout: pd.Timeout,
 					outgoingTimeout: fwdInfo.OutgoingCTLV,
+					inboundFee:      inboundFee,
 					customRecords:   pld.CustomRecords(),
+
+					inWireCustomRecords: pd.CustomRecords,
 				}
 
 				fwdPkg.FwdFilter.Set(idx)
@@ -3380,23 +3583,35 @@ func (l *channelLink) forwardBatch(replay bool, packets ...*htlcPacket) {
 func (l *channelLink) sendHTLCError(pd *lnwallet.PaymentDescriptor,
 	failure *LinkError, e hop.ErrorEncrypter, isReceive bool) {
 
-	reason, err := e.EncryptFirstHop(failure.WireMessage())
-	if err != nil {
-		l.log.Errorf("unable to obfuscate error: %v", err)
-		return
-	}
+	// Relaying nodes within a blinded route must not reveal why they
+	// failed the HTLC, so it is failed as malformed with an invalid
+	// blinding failure.
+	if pd.BlindingPoint != nil {
+		l.sendMalformedHTLCError(
+			pd.HtlcIndex, lnwire.CodeInvalidBlinding, pd.OnionBlob,
+			pd.SourceRef,
+		)
+	} else {
+		reason, err := e.EncryptFirstHop(failure.WireMessage())
+		if err != nil {
+			l.log.Errorf("unable to obfuscate error: %v", err)
+			return
+		}
 
-	err = l.channel.FailHTLC(pd.HtlcIndex, reason, pd.SourceRef, nil, nil)
-	if err != nil {
-		l.log.Errorf("unable cancel htlc: %v", err)
-		return
-	}
+		err = l.channel.FailHTLC(
+			pd.HtlcIndex, reason, pd.SourceRef, nil, nil,
+		)
+		if err != nil {
+			l.log.Errorf("unable cancel htlc: %v", err)
+			return
+		}
 
-	l.cfg.Peer.SendMessage(false, &lnwire.UpdateFailHTLC{
-		ChanID: l.ChanID(),
-		ID:     pd.HtlcIndex,
-		Reason: reason,
-	})
+		l.cfg.Peer.SendMessage(false, &lnwire.UpdateFailHTLC{
+			ChanID: l.ChanID(),
+			ID:     pd.HtlcIndex,
+			Reason: reason,
+		})
+	}
 
 	// Notify a link failure on our incoming link. Outgoing htlc information
 	// is not available at this point, because we have not decrypted the
@@ -3416,8 +3631,9 @@ func (l *channelLink) sendHTLCError(pd *lnwallet.PaymentDescriptor,
 			},
 		},
 		HtlcInfo{
-			IncomingTimeLock: pd.Timeout,
-			IncomingAmt:      pd.Amount,
+			IncomingTimeLock:    pd.Timeout,
+			IncomingAmt:         pd.Amount,
+			IncomingEndorsement: pd.CustomRecords.Endorsement(),
 		},
 		eventType,
 		failure,
@@ -3431,7 +3647,9 @@ func (l *channelLink) sendMalformedHTLCError(htlcIndex uint64,
 	code lnwire.FailCode, onionBlob []byte, sourceRef *channeldb.AddRef) {
 
 	shaOnionBlob := sha256.Sum256(onionBlob)
-	err := l.channel.MalformedFailHTLC(htlcIndex, code, shaOnionBlob, sourceRef)
+	err := l.channel.MalformedFailHTLC(
+		htlcIndex, code, shaOnionBlob, sourceRef, nil, nil,
+	)
 	if err != nil {
 		l.log.Errorf("unable cancel htlc: %v", err)
 		return
@@ -5749,7 +5749,8 @@ func TestCheckHtlcForward(t *testing.T) {
 
 	t.Run("satisfied", func(t *testing.T) {
 		result := link.CheckHtlcForward(hash, 1500, 1000,
-			200, 150, 0, lnwire.ShortChannelID{})
+			200, 150, models.InboundFee{}, 0,
+			lnwire.ShortChannelID{})
 		if result != nil {
 			t.Fatalf("expected policy to be satisfied")
 		}
@@ -5757,7 +5758,8 @@ func TestCheckHtlcForward(t *testing.T) {
 
 	t.Run("below minhtlc", func(t *testing.T) {
 		result := link.CheckHtlcForward(hash, 100, 50,
-			200, 150, 0, lnwire.ShortChannelID{})
+			200, 150, models.InboundFee{}, 0,
+			lnwire.ShortChannelID{})
 		if _, ok := result.WireMessage().(*lnwire.FailAmountBelowMinimum); !ok {
 			t.Fatalf("expected FailAmountBelowMinimum failure code")
 		}
@@ -5765,7 +5767,8 @@ func TestCheckHtlcForward(t *testing.T) {
 
 	t.Run("above maxhtlc", func(t *testing.T) {
 		result := link.CheckHtlcForward(hash, 1500, 1200,
-			200, 150, 0, lnwire.ShortChannelID{})
+			200, 150, models.InboundFee{}, 0,
+			lnwire.ShortChannelID{})
 		if _, ok := result.WireMessage().(*lnwire.FailTemporaryChannelFailure); !ok {
 			t.Fatalf("expected FailTemporaryChannelFailure failure code")
 		}
@@ -5773,7 +5776,8 @@ func TestCheckHtlcForward(t *testing.T) {
 
 	t.Run("insufficient fee", func(t *testing.T) {
 		result := link.CheckHtlcForward(hash, 1005, 1000,
-			200, 150, 0, lnwire.ShortChannelID{})
+			200, 150, models.InboundFee{}, 0,
+			lnwire.ShortChannelID{})
 		if _, ok := result.WireMessage().(*lnwire.FailFeeInsufficient); !ok {
 			t.Fatalf("expected FailFeeInsufficient failure code")
 		}
@@ -5786,7 +5790,7 @@ func TestCheckHtlcForward(t *testing.T) {
 
 		result := link.CheckHtlcForward(
 			hash, 100005, 100000, 200,
-			150, 0, lnwire.ShortChannelID{},
+			150, models.InboundFee{}, 0, lnwire.ShortChannelID{},
 		)
 		_, ok := result.WireMessage().(*lnwire.FailFeeInsufficient)
 		require.True(t, ok, "expected FailFeeInsufficient failure code")
@@ -5794,7 +5798,8 @@ func TestCheckHtlcForward(t *testing.T) {
 
 	t.Run("expiry too soon", func(t *testing.T) {
 		result := link.CheckHtlcForward(hash, 1500, 1000,
-			200, 150, 190, lnwire.ShortChannelID{})
+			200, 150, models.InboundFee{}, 190,
+			lnwire.ShortChannelID{})
 		if _, ok := result.WireMessage().(*lnwire.FailExpiryTooSoon); !ok {
 			t.Fatalf("expected FailExpiryTooSoon failure code")
 		}
@@ -5802,7 +5807,8 @@ func TestCheckHtlcForward(t *testing.T) {
 
 	t.Run("incorrect cltv expiry", func(t *testing.T) {
 		result := link.CheckHtlcForward(hash, 1500, 1000,
-			200, 190, 0, lnwire.ShortChannelID{})
+			200, 190, models.InboundFee{}, 0,
+			lnwire.ShortChannelID{})
 		if _, ok := result.WireMessage().(*lnwire.FailIncorrectCltvExpiry); !ok {
 			t.Fatalf("expected FailIncorrectCltvExpiry failure code")
 		}
@@ -5812,11 +5818,34 @@ func TestCheckHtlcForward(t *testing.T) {
 	t.Run("cltv expiry too far in the future", func(t *testing.T) {
 		// Check that expiry isn't too far in the future.
 		result := link.CheckHtlcForward(hash, 1500, 1000,
-			10200, 10100, 0, lnwire.ShortChannelID{})
+			10200, 10100, models.InboundFee{}, 0,
+			lnwire.ShortChannelID{})
 		if _, ok := result.WireMessage().(*lnwire.FailExpiryTooFar); !ok {
 			t.Fatalf("expected FailExpiryTooFar failure code")
 		}
 	})
+
+	t.Run("satisfied with negative inbound fee", func(t *testing.T) {
+		result := link.CheckHtlcForward(hash, 1005, 1000,
+			200, 150, models.InboundFee{Base: -5}, 0,
+			lnwire.ShortChannelID{})
+		require.Nil(t, result)
+	})
+
+	t.Run("negative inbound fee capped", func(t *testing.T) {
+		result := link.CheckHtlcForward(hash, 1000, 1000,
+			200, 150, models.InboundFee{Base: -15}, 0,
+			lnwire.ShortChannelID{})
+		require.Nil(t, result)
+	})
+
+	t.Run("insufficient inbound fee", func(t *testing.T) {
+		result := link.CheckHtlcForward(hash, 1010, 1000,
+			200, 150, models.InboundFee{Base: 5}, 0,
+			lnwire.ShortChannelID{})
+		_, ok := result.WireMessage().(*lnwire.FailFeeInsufficient)
+		require.True(t, ok, "expected FailFeeInsufficient failure code")
+	})
 }
 
 // TestChannelLinkCanceledInvoice in this test checks the interaction
@@ -6864,3 +6893,197 @@ func TestChannelLinkShortFailureRelay(t *testing.T) {
 	default:
 	}
 }
+
+// TestChannelLinkExperimentalEndorsement tests that the experimental
+// endorsement signal of an incoming htlc is only forwarded if enabled, and
+// that outgoing htlcs are only endorsed if the incoming htlc was.
+func TestChannelLinkExperimentalEndorsement(t *testing.T) {
+	t.Parallel()
+
+	endorsed := lnwire.CustomRecords(nil).WithEndorsement(
+		lnwire.EndorsementEndorsed,
+	)
+	unendorsed := lnwire.CustomRecords(nil).WithEndorsement(
+		lnwire.EndorsementUnendorsed,
+	)
+	other := lnwire.CustomRecords{
+		lnwire.MinCustomRecordsTlvType: {1},
+	}
+
+	// Without forwarding the signal, outgoing htlcs don't carry it.
+	link := &channelLink{}
+	require.Nil(t, link.experimentalEndorsement(endorsed))
+
+	// Otherwise the outgoing htlc is endorsed if the incoming one was, and
+	// unendorsed in all other cases. Other custom records of the incoming
+	// htlc aren't forwarded.
+	link.cfg.FwdExpEndorsement = true
+	require.Equal(t, endorsed, link.experimentalEndorsement(endorsed))
+	require.Equal(t, unendorsed, link.experimentalEndorsement(unendorsed))
+	require.Equal(t, unendorsed, link.experimentalEndorsement(other))
+	require.Equal(t, unendorsed, link.experimentalEndorsement(nil))
+}
+
+// TestChannelLinkAdaptiveBatching asserts that a link with adaptive batching
+// signs a new commitment right away if no further updates are queued, without
+// waiting for the batch ticker.
+func TestChannelLinkAdaptiveBatching(t *testing.T) {
+	t.Parallel()
+
+	const chanAmt = ltcutil.SatoshiPerBitcoin * 5
+	const chanReserve = ltcutil.SatoshiPerBitcoin * 1
+	aliceLink, bobChannel, _, start, _, err :=
+		newSingleLinkTestHarness(t, chanAmt, chanReserve)
+	require.NoError(t, err)
+
+	coreLink := aliceLink.(*channelLink)
+	coreLink.cfg.AdaptiveBatching = true
+
+	require.NoError(t, start())
+	t.Cleanup(aliceLink.Stop)
+
+	ctx := linkTestContext{
+		t:          t,
+		aliceLink:  aliceLink,
+		aliceMsgs:  coreLink.cfg.Peer.(*mockPeer).sentMsgs,
+		bobChannel: bobChannel,
+	}
+
+	// Send an htlc from Alice to Bob. As no further updates are queued,
+	// Alice signs the htlc right away, even though the batch ticker never
+	// fires.
+	htlc, _ := generateHtlcAndInvoice(t, 0)
+	ctx.sendHtlcAliceToBob(0, htlc)
+	ctx.receiveHtlcAliceToBob()
+	ctx.receiveCommitSigAliceToBob(1)
+
+	// Bob revokes his previous commitment and signs the htlc as well.
+	ctx.sendRevAndAckBobToAlice()
+	ctx.sendCommitSigBobToAlice(1)
+	ctx.receiveRevAndAckAliceToBob()
+
+	// Both commitments are in sync, so no further messages are expected.
+	ctx.assertNoMsgFromAlice(500 * time.Millisecond)
+}
+
+// TestChannelLinkAdaptiveBatchingQueued asserts that a link with adaptive
+// batching signs the htlcs that are queued in its mailbox with a single
+// commitment.
+func TestChannelLinkAdaptiveBatchingQueued(t *testing.T) {
+	t.Parallel()
+
+	const chanAmt = ltcutil.SatoshiPerBitcoin * 5
+	const chanReserve = ltcutil.SatoshiPerBitcoin * 1
+	aliceLink, bobChannel, _, start, _, err :=
+		newSingleLinkTestHarness(t, chanAmt, chanReserve)
+	require.NoError(t, err)
+
+	coreLink := aliceLink.(*channelLink)
+	coreLink.cfg.AdaptiveBatching = true
+
+	// Attach Alice's mailbox before the link is started, so that we can
+	// queue multiple htlcs before the link processes any of them.
+	mailbox := coreLink.cfg.Switch.mailOrchestrator.GetOrCreateMailBox(
+		aliceLink.ChanID(), aliceLink.ShortChanID(),
+	)
+	aliceLink.AttachMailBox(mailbox)
+
+	ctx := linkTestContext{
+		t:          t,
+		aliceLink:  aliceLink,
+		aliceMsgs:  coreLink.cfg.Peer.(*mockPeer).sentMsgs,
+		bobChannel: bobChannel,
+	}
+
+	const numHtlcs = 3
+	for i := 0; i < numHtlcs; i++ {
+		htlc, _ := generateHtlcAndInvoice(t, uint64(i))
+		ctx.sendHtlcAliceToBob(i, htlc)
+	}
+
+	require.NoError(t, start())
+	t.Cleanup(aliceLink.Stop)
+
+	// Alice adds all htlcs before signing them with a single commitment,
+	// even though the batch ticker never fires.
+	for i := 0; i < numHtlcs; i++ {
+		ctx.receiveHtlcAliceToBob()
+	}
+	ctx.receiveCommitSigAliceToBob(numHtlcs)
+
+	// Bob revokes his previous commitment and signs the htlcs as well.
+	ctx.sendRevAndAckBobToAlice()
+	ctx.sendCommitSigBobToAlice(numHtlcs)
+	ctx.receiveRevAndAckAliceToBob()
+
+	// Both commitments are in sync, so no further messages are expected.
+	ctx.assertNoMsgFromAlice(500 * time.Millisecond)
+}
+
+// loadedMailBox is a mailbox that always reports pending updates, simulating
+// a link that is permanently under load.
+type loadedMailBox struct {
+	MailBox
+}
+
+// HasPendingUpdates always returns true.
+func (m *loadedMailBox) HasPendingUpdates(*htlcPacket) bool {
+	return true
+}
+
+// TestChannelLinkAdaptiveBatchingTicker asserts that a link with adaptive
+// batching that is under load holds back its batch until the batch ticker
+// fires, which bounds the latency added by batching.
+func TestChannelLinkAdaptiveBatchingTicker(t *testing.T) {
+	t.Parallel()
+
+	const chanAmt = ltcutil.SatoshiPerBitcoin * 5
+	const chanReserve = ltcutil.SatoshiPerBitcoin * 1
+	aliceLink, bobChannel, batchTick, start, _, err :=
+		newSingleLinkTestHarness(t, chanAmt, chanReserve)
+	require.NoError(t, err)
+
+	coreLink := aliceLink.(*channelLink)
+	coreLink.cfg.AdaptiveBatching = true
+
+	// Replace Alice's mailbox with one that always reports pending
+	// updates before the link is added to the switch.
+	orchestrator := coreLink.cfg.Switch.mailOrchestrator
+	mailbox := orchestrator.GetOrCreateMailBox(
+		aliceLink.ChanID(), aliceLink.ShortChanID(),
+	)
+	orchestrator.mu.Lock()
+	orchestrator.mailboxes[aliceLink.ChanID()] = &loadedMailBox{
+		MailBox: mailbox,
+	}
+	orchestrator.mu.Unlock()
+
+	require.NoError(t, start())
+	t.Cleanup(aliceLink.Stop)
+
+	ctx := linkTestContext{
+		t:          t,
+		aliceLink:  aliceLink,
+		aliceMsgs:  coreLink.cfg.Peer.(*mockPeer).sentMsgs,
+		bobChannel: bobChannel,
+	}
+
+	// Send an htlc from Alice to Bob. As the link is under load, Alice
+	// doesn't sign the htlc right away.
+	htlc, _ := generateHtlcAndInvoice(t, 0)
+	ctx.sendHtlcAliceToBob(0, htlc)
+	ctx.receiveHtlcAliceToBob()
+	ctx.assertNoMsgFromAlice(500 * time.Millisecond)
+
+	// Once the batch ticker fires, Alice signs the htlc.
+	select {
+	case batchTick <- time.Now():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("batch ticker not consumed")
+	}
+	ctx.receiveCommitSigAliceToBob(1)
+
+	ctx.sendRevAndAckBobToAlice()
+	ctx.sendCommitSigBobToAlice(1)
+	ctx.receiveRevAndAckAliceToBob()
+}
@@ -78,6 +78,21 @@ type MailBox interface {
 	// local and remote commitments.
 	DustPackets() (lnwire.MilliSatoshi, lnwire.MilliSatoshi)
 
+	// PendingAddAmount returns the total amount of the Adds in the mailbox
+	// that haven't been delivered to the link yet.
+	PendingAddAmount() lnwire.MilliSatoshi
+
+	// LookupAdd returns the Add identified by its incoming circuit key if
+	// it is still held by the mailbox, which is the case until the link
+	// acks it.
+	LookupAdd(inKey CircuitKey) (*htlcPacket, bool)
+
+	// HasPendingUpdates returns true if the mailbox holds wire messages or
+	// packets that haven't been delivered to the link yet. The given
+	// packet, which the link is currently processing, is never considered
+	// pending.
+	HasPendingUpdates(current *htlcPacket) bool
+
 	// Start starts the mailbox and any goroutines it needs to operate
 	// properly.
 	Start()
@@ -678,6 +693,79 @@ func (m *memoryMailBox) DustPackets() (lnwire.MilliSatoshi,
 	return localDustSum, remoteDustSum
 }
 
+// PendingAddAmount returns the total amount of the Adds in the mailbox that
+// haven't been delivered to the link yet. These Adds aren't part of the
+// channel state yet, but will use up its balance once they are delivered.
+// Adds that were delivered, but haven't been acked by the link yet, aren't
+// included, as they are already accounted for in the channel state.
+//
+// NOTE: This method is part of the MailBox interface.
+func (m *memoryMailBox) PendingAddAmount() lnwire.MilliSatoshi {
+	m.pktCond.L.Lock()
+	defer m.pktCond.L.Unlock()
+
+	var total lnwire.MilliSatoshi
+	for e := m.addHead; e != nil; e = e.Next() {
+		total += e.Value.(*pktWithExpiry).pkt.amount
+	}
+
+	return total
+}
+
+// LookupAdd returns the Add identified by its incoming circuit key if it is
+// still held by the mailbox. This includes Adds that were delivered to the
+// link, but haven't been acked by it yet.
+//
+// NOTE: This method is part of the MailBox interface.
+func (m *memoryMailBox) LookupAdd(inKey CircuitKey) (*htlcPacket, bool) {
+	m.pktCond.L.Lock()
+	defer m.pktCond.L.Unlock()
+
+	entry, ok := m.addIndex[inKey]
+	if !ok {
+		return nil, false
+	}
+
+	return entry.Value.(*pktWithExpiry).pkt, true
+}
+
+// HasPendingUpdates returns true if the mailbox holds wire messages or packets
+// that haven't been delivered to the link yet. The link uses this as a signal
+// that it's under load, and that more updates are about to be processed.
+//
+// The courier only advances the head of its queues after the link received a
+// packet, so the given packet, which the link is currently processing, is
+// skipped if it's still at the head.
+//
+// NOTE: This method is part of the MailBox interface.
+func (m *memoryMailBox) HasPendingUpdates(current *htlcPacket) bool {
+	m.wireCond.L.Lock()
+	numMessages := m.wireMessages.Len()
+	m.wireCond.L.Unlock()
+
+	if numMessages > 0 {
+		return true
+	}
+
+	m.pktCond.L.Lock()
+	defer m.pktCond.L.Unlock()
+
+	repHead := m.repHead
+	if repHead != nil && current != nil && repHead.Value == current {
+		repHead = repHead.Next()
+	}
+
+	addHead := m.addHead
+	if addHead != nil && current != nil {
+		//nolint:forcetypeassert
+		if addHead.Value.(*pktWithExpiry).pkt == current {
+			addHead = addHead.Next()
+		}
+	}
+
+	return repHead != nil || addHead != nil
+}
+
 // FailAdd fails an UpdateAddHTLC that exists within the mailbox, removing it
 // from the in-memory replay buffer. This will prevent the packet from being
 // delivered after the link restarts if the switch has remained online. The
@@ -967,3 +1055,33 @@ func (mo *mailOrchestrator) Deliver(
 
 	return nil
 }
+
+// LookupAdd searches the mailboxes of all channels, as well as the packets
+// that haven't been claimed by a mailbox yet, for the Add identified by its
+// incoming circuit key.
+func (mo *mailOrchestrator) LookupAdd(inKey CircuitKey) (*htlcPacket, bool) {
+	mo.mu.RLock()
+	mailboxes := make([]MailBox, 0, len(mo.mailboxes))
+	for _, mailbox := range mo.mailboxes {
+		mailboxes = append(mailboxes, mailbox)
+	}
+
+	for _, pkts := range mo.unclaimedPackets {
+		for _, pkt := range pkts {
+			_, isAdd := pkt.htlc.(*lnwire.UpdateAddHTLC)
+			if isAdd && pkt.inKey() == inKey {
+				mo.mu.RUnlock()
+				return pkt, true
+			}
+		}
+	}
+	mo.mu.RUnlock()
+
+	for _, mailbox := range mailboxes {
+		if pkt, ok := mailbox.LookupAdd(inKey); ok {
+			return pkt, true
+		}
+	}
+
+	return nil, false
+}
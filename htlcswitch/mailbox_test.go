@@ -571,6 +571,134 @@ func TestMailBoxDuplicateAddPacket(t *testing.T) {
 	})
 }
 
+// TestMailBoxPendingAddAmount asserts that the mailbox reports the amount of
+// the Adds that haven't been delivered to the link yet.
+func TestMailBoxPendingAddAmount(t *testing.T) {
+	t.Parallel()
+
+	ctx := newMailboxContext(t, time.Now(), testExpiry)
+
+	_, _, aliceID, bobID := genIDs()
+
+	const (
+		firstAmt  = lnwire.MilliSatoshi(100_000)
+		secondAmt = lnwire.MilliSatoshi(200_000)
+	)
+
+	// requirePending asserts that the pending amount eventually matches
+	// the expected amount, as the courier advances the queue head
+	// asynchronously after delivering a packet.
+	requirePending := func(expected lnwire.MilliSatoshi) {
+		t.Helper()
+
+		require.Eventually(t, func() bool {
+			return ctx.mailbox.PendingAddAmount() == expected
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	requirePending(0)
+
+	// Settles and fails don't use up any balance, so they are not
+	// included.
+	err := ctx.mailbox.AddPacket(&htlcPacket{
+		incomingChanID: bobID,
+		incomingHTLCID: 0,
+		amount:         firstAmt,
+		htlc:           &lnwire.UpdateFulfillHTLC{},
+	})
+	require.NoError(t, err)
+
+	select {
+	case <-ctx.mailbox.PacketOutBox():
+	case <-time.After(50 * time.Millisecond):
+		t.Fatalf("did not receive packet in time")
+	}
+
+	requirePending(0)
+
+	for i, amt := range []lnwire.MilliSatoshi{firstAmt, secondAmt} {
+		err := ctx.mailbox.AddPacket(&htlcPacket{
+			outgoingChanID: aliceID,
+			incomingChanID: bobID,
+			incomingHTLCID: uint64(i + 1),
+			amount:         amt,
+			htlc:           &lnwire.UpdateAddHTLC{},
+		})
+		require.NoError(t, err)
+	}
+
+	requirePending(firstAmt + secondAmt)
+
+	// Once an Add is delivered to the link, it is accounted for in the
+	// channel state, so it is no longer pending.
+	select {
+	case <-ctx.mailbox.PacketOutBox():
+	case <-time.After(50 * time.Millisecond):
+		t.Fatalf("did not receive packet in time")
+	}
+
+	requirePending(secondAmt)
+
+	// After a reset, undelivered Adds will be delivered again, so they
+	// are pending again.
+	require.NoError(t, ctx.mailbox.ResetPackets())
+	requirePending(firstAmt + secondAmt)
+}
+
+// TestMailBoxHasPendingUpdates asserts that the mailbox reports pending
+// updates as long as it holds wire messages or packets that haven't been
+// delivered yet.
+func TestMailBoxHasPendingUpdates(t *testing.T) {
+	t.Parallel()
+
+	ctx := newMailboxContext(t, time.Now(), testExpiry)
+
+	// requirePending asserts that the mailbox eventually reports the
+	// expected state, as the couriers deliver updates asynchronously.
+	requirePending := func(expected bool) {
+		t.Helper()
+
+		require.Eventually(t, func() bool {
+			return ctx.mailbox.HasPendingUpdates(nil) == expected
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	requirePending(false)
+
+	// Undelivered packets are pending until they are received.
+	pkts := ctx.sendAdds(0, 2)
+	requirePending(true)
+
+	ctx.receivePkts(pkts[:1])
+	requirePending(true)
+
+	// The packet that is currently being processed isn't pending, even
+	// if the courier didn't notice its delivery yet.
+	require.Eventually(t, func() bool {
+		return !ctx.mailbox.HasPendingUpdates(pkts[1])
+	}, time.Second, 10*time.Millisecond)
+
+	ctx.receivePkts(pkts[1:])
+	requirePending(false)
+
+	// The wire courier dequeues the first message while waiting for it to
+	// be received, so only the second message is pending.
+	for i := 0; i < 2; i++ {
+		err := ctx.mailbox.AddMessage(&lnwire.UpdateFee{})
+		require.NoError(t, err)
+	}
+	requirePending(true)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ctx.mailbox.MessageOutBox():
+		case <-time.After(50 * time.Millisecond):
+			t.Fatalf("did not receive message in time")
+		}
+	}
+	requirePending(false)
+}
+
 // TestMailBoxDustHandling tests that DustPackets returns the expected values
 // for the local and remote dust sum after calling SetFeeRate and
 // SetDustClosure.
@@ -129,6 +129,8 @@ type mockForwardingLog struct {
 	sync.Mutex
 
 	events map[time.Time]channeldb.ForwardingEvent
+
+	htlcEvents []channeldb.HtlcForwardEvent
 }
 
 func (m *mockForwardingLog) AddForwardingEvents(events []channeldb.ForwardingEvent) error {
@@ -142,6 +144,17 @@ func (m *mockForwardingLog) AddForwardingEvents(events []channeldb.ForwardingEve
 	return nil
 }
 
+func (m *mockForwardingLog) AddHtlcForwardEvents(
+	events []channeldb.HtlcForwardEvent) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.htlcEvents = append(m.htlcEvents, events...)
+
+	return nil
+}
+
 type mockServer struct {
 	started  int32 // To be used atomically.
 	shutdown int32 // To be used atomically.
@@ -179,6 +192,7 @@ func initSwitchWithDB(startingHeight uint32, db *channeldb.DB) (*Switch, error)
 		FwdingLog: &mockForwardingLog{
 			events: make(map[time.Time]channeldb.ForwardingEvent),
 		},
+		StoreHtlcFwdEvents: true,
 		FetchLastChannelUpdate: func(scid lnwire.ShortChannelID) (
 			*lnwire.ChannelUpdate, error) {
 
@@ -843,7 +857,7 @@ func (f *mockChannelLink) HandleChannelUpdate(lnwire.Message) {
 func (f *mockChannelLink) UpdateForwardingPolicy(_ models.ForwardingPolicy) {
 }
 func (f *mockChannelLink) CheckHtlcForward([32]byte, lnwire.MilliSatoshi,
-	lnwire.MilliSatoshi, uint32, uint32, uint32,
+	lnwire.MilliSatoshi, uint32, uint32, models.InboundFee, uint32,
 	lnwire.ShortChannelID) *LinkError {
 
 	return f.checkHtlcForwardResult
@@ -1094,6 +1108,10 @@ func (m *mockCircuitMap) NumOpen() int {
 	return 0
 }
 
+func (m *mockCircuitMap) PendingCircuits() []PendingCircuit {
+	return nil
+}
+
 type mockOnionErrorDecryptor struct {
 	sourceIdx int
 	message   []byte
@@ -2,6 +2,7 @@ package htlcswitch
 
 import (
 	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/channeldb/models"
 	"github.com/ltcsuite/lnd/htlcswitch/hop"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/record"
@@ -93,10 +94,19 @@ type htlcPacket struct {
 	// link.
 	outgoingTimeout uint32
 
+	// inboundFee is the inbound fee that the incoming link charged at the
+	// time the HTLC was received. It is added to the fee of the outgoing
+	// link when checking the forward.
+	inboundFee models.InboundFee
+
 	// customRecords are user-defined records in the custom type range that
 	// were included in the payload.
 	customRecords record.CustomSet
 
+	// inWireCustomRecords are the custom records that were attached
+	// to the update_add_htlc message of the incoming htlc.
+	inWireCustomRecords lnwire.CustomRecords
+
 	// originalOutgoingChanID is used when sending back failure messages.
 	// It is only used for forwarded Adds on option_scid_alias channels.
 	// This is to avoid possible confusion if a payer uses the public SCID
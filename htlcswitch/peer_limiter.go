@@ -0,0 +1,177 @@
+package htlcswitch
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// peerHtlcLimiterConfig holds the limits that are enforced on the htlcs a
+// single peer forwards through our node.
+type peerHtlcLimiterConfig struct {
+	// maxPending is the maximum number of htlcs a single peer can have
+	// pending in our switch across all of its channels. A value of zero
+	// disables the limit.
+	maxPending uint32
+
+	// addRate is the number of htlc adds per second a single peer is
+	// allowed to forward through our node. A value of zero disables the
+	// limit.
+	addRate float64
+
+	// addBurst is the number of htlc adds a single peer is allowed to
+	// forward at once, on top of the sustained add rate.
+	addBurst uint32
+}
+
+// peerHtlcState tracks the htlcs that are currently forwarded on behalf of a
+// single peer.
+type peerHtlcState struct {
+	// pending is the set of incoming htlcs of the peer that have been
+	// accepted by the limiter, but haven't been resolved yet.
+	pending map[CircuitKey]struct{}
+
+	// limiter restricts the rate of incoming htlc adds of the peer. It is
+	// nil if no rate limit is configured.
+	limiter *rate.Limiter
+
+	// lastAdd is the time the last htlc of the peer was accepted.
+	lastAdd time.Time
+}
+
+// peerHtlcLimiter enforces a limit on the number of concurrent and per-second
+// incoming htlc adds of every peer. This prevents a single peer from using up
+// all of our commitment slots and liquidity by jamming our channels.
+type peerHtlcLimiter struct {
+	cfg peerHtlcLimiterConfig
+
+	// peers maps the compressed public key of a peer to the state of its
+	// forwarded htlcs.
+	peers map[[33]byte]*peerHtlcState
+
+	// circuits maps the incoming circuit key of every pending htlc to the
+	// peer that forwarded it, so htlcs can be released by circuit key.
+	circuits map[CircuitKey][33]byte
+
+	mu sync.Mutex
+}
+
+// newPeerHtlcLimiter creates a new limiter enforcing the given limits.
+func newPeerHtlcLimiter(cfg peerHtlcLimiterConfig) *peerHtlcLimiter {
+	// Without an explicit burst, allow at least the number of adds of a
+	// single second at once.
+	if cfg.addRate > 0 && cfg.addBurst == 0 {
+		cfg.addBurst = uint32(cfg.addRate)
+		if float64(cfg.addBurst) < cfg.addRate {
+			cfg.addBurst++
+		}
+	}
+
+	return &peerHtlcLimiter{
+		cfg:      cfg,
+		peers:    make(map[[33]byte]*peerHtlcState),
+		circuits: make(map[CircuitKey][33]byte),
+	}
+}
+
+// enabled returns true if any limit is configured.
+func (p *peerHtlcLimiter) enabled() bool {
+	return p.cfg.maxPending > 0 || p.cfg.addRate > 0
+}
+
+// acquire attempts to add the incoming htlc identified by the circuit key to
+// the pending htlcs of the peer. Nil is returned if the htlc is within the
+// limits of the peer, otherwise the failure detail that describes which limit
+// was exceeded. Acquiring an htlc that is already pending is a no-op.
+func (p *peerHtlcLimiter) acquire(peer [33]byte, key CircuitKey,
+	now time.Time) FailureDetail {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.circuits[key]; ok {
+		return nil
+	}
+
+	state, ok := p.peers[peer]
+	if !ok {
+		state = &peerHtlcState{
+			pending: make(map[CircuitKey]struct{}),
+		}
+		if p.cfg.addRate > 0 {
+			state.limiter = rate.NewLimiter(
+				rate.Limit(p.cfg.addRate),
+				int(p.cfg.addBurst),
+			)
+		}
+		p.peers[peer] = state
+	}
+
+	if p.cfg.maxPending > 0 &&
+		uint32(len(state.pending)) >= p.cfg.maxPending {
+
+		return OutgoingFailurePeerPendingHtlcLimit
+	}
+
+	if state.limiter != nil && !state.limiter.AllowN(now, 1) {
+		return OutgoingFailurePeerHtlcRateLimit
+	}
+
+	state.pending[key] = struct{}{}
+	state.lastAdd = now
+	p.circuits[key] = peer
+
+	return nil
+}
+
+// release removes the incoming htlc identified by the circuit key from the
+// pending htlcs of its peer. Releasing an unknown htlc is a no-op, which is
+// the case for all htlcs that were pending before a restart.
+func (p *peerHtlcLimiter) release(key CircuitKey, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	peer, ok := p.circuits[key]
+	if !ok {
+		return
+	}
+	delete(p.circuits, key)
+
+	state, ok := p.peers[peer]
+	if !ok {
+		return
+	}
+	delete(state.pending, key)
+
+	// Once the peer has no pending htlcs left and its rate limiter has
+	// been refilled, its state is no longer needed.
+	if len(state.pending) > 0 {
+		return
+	}
+
+	if state.limiter != nil {
+		refill := time.Duration(
+			float64(p.cfg.addBurst) / p.cfg.addRate *
+				float64(time.Second),
+		)
+		if now.Sub(state.lastAdd) < refill {
+			return
+		}
+	}
+
+	delete(p.peers, peer)
+}
+
+// numPending returns the number of pending htlcs of the given peer.
+func (p *peerHtlcLimiter) numPending(peer [33]byte) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state, ok := p.peers[peer]
+	if !ok {
+		return 0
+	}
+
+	return len(state.pending)
+}
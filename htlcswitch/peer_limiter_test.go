@@ -0,0 +1,128 @@
+package htlcswitch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPeerHtlcLimiterPending tests that the number of pending htlcs of a peer
+// is limited, and that releasing htlcs frees up the slots again.
+func TestPeerHtlcLimiterPending(t *testing.T) {
+	t.Parallel()
+
+	limiter := newPeerHtlcLimiter(peerHtlcLimiterConfig{
+		maxPending: 2,
+	})
+	require.True(t, limiter.enabled())
+
+	now := time.Unix(1000, 0)
+	peer1 := [33]byte{1}
+	peer2 := [33]byte{2}
+
+	key := func(htlcID uint64) CircuitKey {
+		return CircuitKey{
+			ChanID: lnwire.NewShortChanIDFromInt(1),
+			HtlcID: htlcID,
+		}
+	}
+
+	require.Nil(t, limiter.acquire(peer1, key(0), now))
+	require.Nil(t, limiter.acquire(peer1, key(1), now))
+
+	// Acquiring an htlc that is already pending doesn't count twice.
+	require.Nil(t, limiter.acquire(peer1, key(1), now))
+	require.Equal(t, 2, limiter.numPending(peer1))
+
+	// The third htlc of the peer exceeds the limit, while other peers are
+	// unaffected.
+	require.Equal(
+		t, OutgoingFailurePeerPendingHtlcLimit,
+		limiter.acquire(peer1, key(2), now),
+	)
+	require.Nil(t, limiter.acquire(peer2, key(3), now))
+
+	// Once an htlc is released, the peer can add another one. Releasing
+	// unknown htlcs is a no-op.
+	limiter.release(key(0), now)
+	limiter.release(key(0), now)
+	limiter.release(key(100), now)
+	require.Equal(t, 1, limiter.numPending(peer1))
+	require.Nil(t, limiter.acquire(peer1, key(2), now))
+
+	// Releasing all htlcs of a peer removes its state.
+	limiter.release(key(3), now)
+	require.Zero(t, limiter.numPending(peer2))
+	require.NotContains(t, limiter.peers, peer2)
+}
+
+// TestPeerHtlcLimiterRate tests that the rate of htlc adds of a peer is
+// limited, and that the state of idle peers is cleaned up.
+func TestPeerHtlcLimiterRate(t *testing.T) {
+	t.Parallel()
+
+	limiter := newPeerHtlcLimiter(peerHtlcLimiterConfig{
+		addRate: 1.5,
+	})
+	require.True(t, limiter.enabled())
+
+	// Without an explicit burst, the adds of a single second are allowed
+	// at once.
+	require.EqualValues(t, 2, limiter.cfg.addBurst)
+
+	now := time.Unix(1000, 0)
+	peer := [33]byte{1}
+
+	key := func(htlcID uint64) CircuitKey {
+		return CircuitKey{
+			ChanID: lnwire.NewShortChanIDFromInt(1),
+			HtlcID: htlcID,
+		}
+	}
+
+	require.Nil(t, limiter.acquire(peer, key(0), now))
+	require.Nil(t, limiter.acquire(peer, key(1), now))
+	require.Equal(
+		t, OutgoingFailurePeerHtlcRateLimit,
+		limiter.acquire(peer, key(2), now),
+	)
+
+	// Releasing htlcs doesn't refill the rate limiter, so the state is
+	// kept even though the peer has no pending htlcs.
+	limiter.release(key(0), now)
+	limiter.release(key(1), now)
+	require.Contains(t, limiter.peers, peer)
+	require.Equal(
+		t, OutgoingFailurePeerHtlcRateLimit,
+		limiter.acquire(peer, key(2), now),
+	)
+
+	// After a second, another add is allowed.
+	now = now.Add(time.Second)
+	require.Nil(t, limiter.acquire(peer, key(2), now))
+
+	// Once the limiter is refilled, releasing the last htlc removes the
+	// state of the peer.
+	limiter.release(key(2), now.Add(2*time.Second))
+	require.NotContains(t, limiter.peers, peer)
+}
+
+// TestPeerHtlcLimiterDisabled tests that a limiter without limits accepts all
+// htlcs.
+func TestPeerHtlcLimiterDisabled(t *testing.T) {
+	t.Parallel()
+
+	limiter := newPeerHtlcLimiter(peerHtlcLimiterConfig{})
+	require.False(t, limiter.enabled())
+
+	now := time.Unix(1000, 0)
+	for i := uint64(0); i < 1000; i++ {
+		key := CircuitKey{
+			ChanID: lnwire.NewShortChanIDFromInt(1),
+			HtlcID: i,
+		}
+		require.Nil(t, limiter.acquire([33]byte{1}, key, now))
+	}
+}
@@ -0,0 +1,291 @@
+package htlcswitch
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ltcsuite/lnd/htlcswitch/hop"
+	"github.com/ltcsuite/lnd/lntypes"
+	"github.com/ltcsuite/lnd/lnwire"
+)
+
+// PendingHtlcState describes what a pending htlc in the switch is waiting on
+// before it can be resolved.
+type PendingHtlcState uint8
+
+const (
+	// PendingHtlcUnassigned indicates that the htlc has been committed to
+	// the circuit map, but isn't queued for any outgoing link. This is the
+	// case for htlcs whose packet was lost during a restart and is waiting
+	// to be reforwarded by the incoming link.
+	PendingHtlcUnassigned PendingHtlcState = iota
+
+	// PendingHtlcQueued indicates that the htlc is queued in the mailbox
+	// of the outgoing link, waiting to be added to its commitment.
+	PendingHtlcQueued
+
+	// PendingHtlcOutgoingIneligible indicates that the htlc is queued in
+	// the mailbox of an outgoing link that isn't eligible to forward, for
+	// example because the channel hasn't been reestablished yet or is
+	// being closed.
+	PendingHtlcOutgoingIneligible
+
+	// PendingHtlcOutgoingOffline indicates that the outgoing link of the
+	// htlc isn't active, which is usually the case if the peer is
+	// offline.
+	PendingHtlcOutgoingOffline
+
+	// PendingHtlcAwaitingDownstream indicates that the htlc has been
+	// added to the outgoing channel, and we are waiting for the remote
+	// peer to settle or fail it.
+	PendingHtlcAwaitingDownstream
+
+	// PendingHtlcResolving indicates that a settle or fail has been
+	// received for the htlc, which hasn't been removed from the incoming
+	// channel yet.
+	PendingHtlcResolving
+
+	// PendingHtlcIncomingOffline indicates that a settle or fail has been
+	// received for the htlc, but it can't be removed from the incoming
+	// channel because the incoming link isn't active.
+	PendingHtlcIncomingOffline
+
+	// PendingHtlcIntercepted indicates that the htlc is held by the
+	// forward interceptor, waiting to be resumed, settled or failed. Unless
+	// they were reforwarded after a restart, held htlcs haven't been
+	// committed to the circuit map yet.
+	PendingHtlcIntercepted
+)
+
+// String returns a human readable representation of the pending htlc state.
+func (s PendingHtlcState) String() string {
+	switch s {
+	case PendingHtlcUnassigned:
+		return "unassigned"
+
+	case PendingHtlcQueued:
+		return "queued"
+
+	case PendingHtlcOutgoingIneligible:
+		return "outgoing_ineligible"
+
+	case PendingHtlcOutgoingOffline:
+		return "outgoing_offline"
+
+	case PendingHtlcAwaitingDownstream:
+		return "awaiting_downstream"
+
+	case PendingHtlcResolving:
+		return "resolving"
+
+	case PendingHtlcIncomingOffline:
+		return "incoming_offline"
+
+	case PendingHtlcIntercepted:
+		return "intercepted"
+
+	default:
+		return "unknown"
+	}
+}
+
+// PendingHtlc describes an htlc that is currently held by the switch, along
+// with the state that keeps it from being resolved.
+type PendingHtlc struct {
+	// Incoming is the circuit key of the incoming htlc. For payments
+	// initiated by our node, the channel id is hop.Source.
+	Incoming CircuitKey
+
+	// Outgoing is the circuit key of the outgoing htlc. It is nil if the
+	// htlc hasn't been added to the outgoing channel yet.
+	Outgoing *CircuitKey
+
+	// OutgoingChanID is the channel the htlc is forwarded over. It is
+	// zero if the htlc isn't assigned to an outgoing channel.
+	OutgoingChanID lnwire.ShortChannelID
+
+	// PaymentHash is the payment hash of the htlc.
+	PaymentHash lntypes.Hash
+
+	// IncomingAmount is the amount of the incoming htlc.
+	IncomingAmount lnwire.MilliSatoshi
+
+	// OutgoingAmount is the amount of the outgoing htlc.
+	OutgoingAmount lnwire.MilliSatoshi
+
+	// IncomingExpiry is the expiry height of the incoming htlc. It is zero
+	// if unknown, which is the case for payments initiated by our node.
+	IncomingExpiry uint32
+
+	// OutgoingExpiry is the expiry height of the outgoing htlc. It is zero
+	// if unknown.
+	OutgoingExpiry uint32
+
+	// HoldTime is the time that has passed since the htlc was committed to
+	// the circuit map or, for htlcs held by the interceptor, since it was
+	// intercepted. For htlcs that were pending before a restart, it is the
+	// time since the switch was started.
+	HoldTime time.Duration
+
+	// State is the state that keeps the htlc from being resolved.
+	State PendingHtlcState
+}
+
+// htlcExpiries indexes the expiry heights of the htlcs that are locked in on
+// the commitments of our open channels, keyed by their circuit keys.
+type htlcExpiries struct {
+	incoming map[CircuitKey]uint32
+	outgoing map[CircuitKey]uint32
+}
+
+// fetchHtlcExpiries reads the expiry heights of all htlcs that are locked in
+// on the commitments of our open channels.
+func (s *Switch) fetchHtlcExpiries() (*htlcExpiries, error) {
+	channels, err := s.cfg.FetchAllOpenChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	expiries := &htlcExpiries{
+		incoming: make(map[CircuitKey]uint32),
+		outgoing: make(map[CircuitKey]uint32),
+	}
+	for _, channel := range channels {
+		chanID := channel.ShortChanID()
+		for _, htlc := range channel.ActiveHtlcs() {
+			key := CircuitKey{
+				ChanID: chanID,
+				HtlcID: htlc.HtlcIndex,
+			}
+
+			if htlc.Incoming {
+				expiries.incoming[key] = htlc.RefundTimeout
+			} else {
+				expiries.outgoing[key] = htlc.RefundTimeout
+			}
+		}
+	}
+
+	return expiries, nil
+}
+
+// PendingHtlcs returns all htlcs that are currently held by the switch, along
+// with the state that keeps them from being resolved. The htlcs are sorted by
+// their hold time, starting with the longest.
+func (s *Switch) PendingHtlcs() ([]PendingHtlc, error) {
+	expiries, err := s.fetchHtlcExpiries()
+	if err != nil {
+		return nil, err
+	}
+
+	circuits := s.circuits.PendingCircuits()
+	now := time.Now()
+
+	htlcs := make([]PendingHtlc, 0, len(circuits))
+	for i := range circuits {
+		htlcs = append(
+			htlcs, s.pendingHtlc(&circuits[i], expiries, now),
+		)
+	}
+
+	sort.Slice(htlcs, func(i, j int) bool {
+		return htlcs[i].HoldTime > htlcs[j].HoldTime
+	})
+
+	return htlcs, nil
+}
+
+// LookupPendingHtlc returns the htlc identified by its incoming circuit key,
+// along with the state that keeps it from being resolved. ErrUnknownCircuit is
+// returned if the switch doesn't hold the htlc.
+func (s *Switch) LookupPendingHtlc(inKey CircuitKey) (*PendingHtlc, error) {
+	for _, circuit := range s.circuits.PendingCircuits() {
+		if circuit.Incoming != inKey {
+			continue
+		}
+
+		expiries, err := s.fetchHtlcExpiries()
+		if err != nil {
+			return nil, err
+		}
+
+		htlc := s.pendingHtlc(&circuit, expiries, time.Now())
+
+		return &htlc, nil
+	}
+
+	return nil, ErrUnknownCircuit
+}
+
+// pendingHtlc determines the state of the htlc of the given pending circuit.
+func (s *Switch) pendingHtlc(circuit *PendingCircuit, expiries *htlcExpiries,
+	now time.Time) PendingHtlc {
+
+	htlc := PendingHtlc{
+		Incoming:       circuit.Incoming,
+		Outgoing:       circuit.Outgoing,
+		PaymentHash:    circuit.PaymentHash,
+		IncomingAmount: circuit.IncomingAmount,
+		OutgoingAmount: circuit.OutgoingAmount,
+		IncomingExpiry: expiries.incoming[circuit.Incoming],
+		HoldTime:       now.Sub(circuit.CommitTime),
+	}
+	if circuit.HasKeystone() {
+		htlc.OutgoingChanID = circuit.Outgoing.ChanID
+		htlc.OutgoingExpiry = expiries.outgoing[*circuit.Outgoing]
+	}
+
+	switch {
+	// A settle or fail has been received, so the htlc is only waiting to
+	// be removed from the incoming channel. Payments initiated by our
+	// node don't have an incoming link.
+	case circuit.Closing:
+		htlc.State = PendingHtlcResolving
+		if circuit.Incoming.ChanID == hop.Source {
+			break
+		}
+
+		_, err := s.GetLinkByShortID(circuit.Incoming.ChanID)
+		if err != nil {
+			htlc.State = PendingHtlcIncomingOffline
+		}
+
+	// The htlc has been added to the outgoing channel, so we're waiting
+	// for the remote peer to resolve it.
+	case circuit.HasKeystone():
+		htlc.State = PendingHtlcAwaitingDownstream
+
+		_, err := s.GetLinkByShortID(circuit.Outgoing.ChanID)
+		if err != nil {
+			htlc.State = PendingHtlcOutgoingOffline
+		}
+
+	// Otherwise, the htlc either waits in the mailbox of the outgoing link
+	// or hasn't been assigned to an outgoing link at all.
+	default:
+		pkt, ok := s.mailOrchestrator.LookupAdd(circuit.Incoming)
+		if !ok {
+			htlc.State = PendingHtlcUnassigned
+			break
+		}
+
+		htlc.OutgoingChanID = pkt.outgoingChanID
+		if add, ok := pkt.htlc.(*lnwire.UpdateAddHTLC); ok {
+			htlc.OutgoingExpiry = add.Expiry
+		}
+
+		link, err := s.GetLinkByShortID(pkt.outgoingChanID)
+		switch {
+		case err != nil:
+			htlc.State = PendingHtlcOutgoingOffline
+
+		case !link.EligibleToForward():
+			htlc.State = PendingHtlcOutgoingIneligible
+
+		default:
+			htlc.State = PendingHtlcQueued
+		}
+	}
+
+	return htlc
+}
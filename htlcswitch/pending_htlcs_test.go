@@ -0,0 +1,159 @@
+package htlcswitch
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSwitchPendingHtlcs tests that the switch reports the htlcs it holds
+// along with the state that keeps them from being resolved, as they make
+// their way through the switch.
+func TestSwitchPendingHtlcs(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(
+		t, "alice", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+	bobPeer, err := newMockServer(
+		t, "bob", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	s, err := initSwitchWithTempDB(t, testStartingHeight)
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, emptyScid, alicePeer, true, false,
+		false, false,
+	)
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, emptyScid, bobPeer, true, false, false,
+		false,
+	)
+	require.NoError(t, s.AddLink(aliceChannelLink))
+	require.NoError(t, s.AddLink(bobChannelLink))
+
+	inKey := CircuitKey{
+		ChanID: aliceChannelLink.ShortChanID(),
+		HtlcID: 0,
+	}
+
+	// assertPendingHtlc asserts that the switch holds exactly one htlc in
+	// the given state, and returns it.
+	assertPendingHtlc := func(state PendingHtlcState) *PendingHtlc {
+		t.Helper()
+
+		htlcs, err := s.PendingHtlcs()
+		require.NoError(t, err)
+		require.Len(t, htlcs, 1)
+		require.Equal(t, state, htlcs[0].State)
+		require.Equal(t, inKey, htlcs[0].Incoming)
+		require.GreaterOrEqual(t, htlcs[0].HoldTime, time.Duration(0))
+
+		htlc, err := s.LookupPendingHtlc(inKey)
+		require.NoError(t, err)
+		require.Equal(t, state, htlc.State)
+
+		return htlc
+	}
+
+	preimage, err := genPreimage()
+	require.NoError(t, err)
+	rhash := sha256.Sum256(preimage[:])
+
+	// Forward an htlc from alice to bob. Until bob's link adds it to its
+	// commitment, the htlc is queued in bob's mailbox.
+	packet := &htlcPacket{
+		incomingChanID: aliceChannelLink.ShortChanID(),
+		incomingHTLCID: 0,
+		outgoingChanID: bobChannelLink.ShortChanID(),
+		incomingAmount: 2,
+		amount:         1,
+		obfuscator:     NewMockObfuscator(),
+		htlc: &lnwire.UpdateAddHTLC{
+			PaymentHash: rhash,
+			Amount:      1,
+			Expiry:      testStartingHeight + 10,
+		},
+	}
+	require.NoError(t, s.ForwardPackets(nil, packet))
+
+	select {
+	case <-bobChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("request was not propagated to destination")
+	}
+
+	htlc := assertPendingHtlc(PendingHtlcQueued)
+	require.Nil(t, htlc.Outgoing)
+	require.Equal(t, bobChannelLink.ShortChanID(), htlc.OutgoingChanID)
+	require.EqualValues(t, testStartingHeight+10, htlc.OutgoingExpiry)
+	require.EqualValues(t, rhash, htlc.PaymentHash)
+	require.EqualValues(t, 2, htlc.IncomingAmount)
+	require.EqualValues(t, 1, htlc.OutgoingAmount)
+
+	// Looking up an htlc the switch doesn't hold fails.
+	_, err = s.LookupPendingHtlc(CircuitKey{
+		ChanID: aliceChannelLink.ShortChanID(),
+		HtlcID: 1,
+	})
+	require.ErrorIs(t, err, ErrUnknownCircuit)
+
+	// Once bob's link adds the htlc to its commitment, we're waiting for
+	// bob to resolve it.
+	require.NoError(t, bobChannelLink.completeCircuit(packet))
+
+	htlc = assertPendingHtlc(PendingHtlcAwaitingDownstream)
+	require.NotNil(t, htlc.Outgoing)
+	require.Equal(t, bobChannelLink.ShortChanID(), htlc.Outgoing.ChanID)
+
+	// If bob goes offline, the outgoing link keeps the htlc from being
+	// resolved.
+	s.RemoveLink(chanID2)
+	assertPendingHtlc(PendingHtlcOutgoingOffline)
+
+	// After the htlc is settled, it is waiting to be removed from alice's
+	// channel.
+	packet = &htlcPacket{
+		outgoingChanID: bobChannelLink.ShortChanID(),
+		outgoingHTLCID: 0,
+		amount:         1,
+		htlc: &lnwire.UpdateFulfillHTLC{
+			PaymentPreimage: preimage,
+		},
+	}
+	require.NoError(t, s.ForwardPackets(nil, packet))
+
+	var settle *htlcPacket
+	select {
+	case settle = <-aliceChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("settle was not propagated to source")
+	}
+
+	assertPendingHtlc(PendingHtlcResolving)
+
+	// If alice goes offline, the incoming link keeps the htlc from being
+	// resolved.
+	s.RemoveLink(chanID1)
+	assertPendingHtlc(PendingHtlcIncomingOffline)
+
+	// Once the circuit is deleted, the switch no longer holds the htlc.
+	require.NoError(t, aliceChannelLink.deleteCircuit(settle))
+
+	htlcs, err := s.PendingHtlcs()
+	require.NoError(t, err)
+	require.Empty(t, htlcs)
+
+	_, err = s.LookupPendingHtlc(inKey)
+	require.ErrorIs(t, err, ErrUnknownCircuit)
+}
@@ -43,6 +43,11 @@ const (
 	// DefaultMailboxDeliveryTimeout is the duration after which Adds will
 	// be cancelled if they could not get added to an outgoing commitment.
 	DefaultMailboxDeliveryTimeout = time.Minute
+
+	// dustExposureInterval is the duration for which the node-wide dust
+	// exposure summed across all channels is cached before it is
+	// recomputed.
+	dustExposureInterval = time.Second
 )
 
 var (
@@ -133,6 +138,11 @@ type Config struct {
 	// settle is eventually received.
 	FwdingLog ForwardingLog
 
+	// StoreHtlcFwdEvents determines whether the final outcome of every
+	// forwarded htlc, including failed forwards, is recorded in the
+	// forwarding log.
+	StoreHtlcFwdEvents bool
+
 	// LocalChannelClose kicks-off the workflow to execute a cooperative or
 	// forced unilateral closure of the channel initiated by a local
 	// subsystem.
@@ -213,6 +223,14 @@ type Config struct {
 	// fail incoming or outgoing dust payments for a particular channel.
 	DustThreshold lnwire.MilliSatoshi
 
+	// NodeDustThreshold is the threshold in milli-satoshis for the dust
+	// exposure summed across all channels after which we'll fail incoming
+	// or outgoing dust payments. A value of zero disables the threshold.
+	// The summed exposure is cached for a second, so HTLCs that were added
+	// or resolved in the meantime are only taken into account once it is
+	// recomputed.
+	NodeDustThreshold lnwire.MilliSatoshi
+
 	// SignAliasUpdate is used when sending FailureMessages backwards for
 	// option_scid_alias channels. This avoids a potential privacy leak by
 	// replacing the public, confirmed SCID with the alias in the
@@ -222,6 +240,22 @@ type Config struct {
 
 	// IsAlias returns whether or not a given SCID is an alias.
 	IsAlias func(scid lnwire.ShortChannelID) bool
+
+	// MaxPendingHtlcsPerPeer is the maximum number of htlcs a single peer
+	// can have pending in the switch across all of its channels. Further
+	// htlcs of the peer are failed back. A value of zero disables the
+	// limit.
+	MaxPendingHtlcsPerPeer uint32
+
+	// HtlcAddRatePerPeer is the number of htlc adds per second a single
+	// peer is allowed to forward through our node. A value of zero
+	// disables the limit.
+	HtlcAddRatePerPeer float64
+
+	// HtlcAddBurstPerPeer is the number of htlc adds a single peer is
+	// allowed to forward at once, on top of the sustained rate. If zero,
+	// the burst defaults to the number of adds of a single second.
+	HtlcAddBurstPerPeer uint32
 }
 
 // Switch is the central messaging bus for all incoming/outgoing HTLCs.
@@ -241,6 +275,16 @@ type Switch struct {
 	// This will be retrieved by the registered links atomically.
 	bestHeight uint32
 
+	// dustExposureMtx guards the cached node-wide dust exposure below.
+	dustExposureMtx sync.Mutex
+
+	// dustExposure is the dust exposure summed across all channels at
+	// dustExposureTime.
+	dustExposure lnwire.MilliSatoshi
+
+	// dustExposureTime is the time dustExposure was last computed at.
+	dustExposureTime time.Time
+
 	wg   sync.WaitGroup
 	quit chan struct{}
 
@@ -318,6 +362,11 @@ type Switch struct {
 	fwdEventMtx         sync.Mutex
 	pendingFwdingEvents []channeldb.ForwardingEvent
 
+	// pendingHtlcFwdEvents is the set of htlc forwarding events, recording
+	// the final outcome of settled and failed forwards, which haven't yet
+	// been written to the forwarding log. It is guarded by fwdEventMtx.
+	pendingHtlcFwdEvents []channeldb.HtlcForwardEvent
+
 	// blockEpochStream is an active block epoch event stream backed by an
 	// active ChainNotifier instance. This will be used to retrieve the
 	// latest height of the chain.
@@ -347,6 +396,10 @@ type Switch struct {
 	// key includes the value itself and also any other aliases. This MUST
 	// be accessed with the indexMtx.
 	baseIndex map[lnwire.ShortChannelID]lnwire.ShortChannelID
+
+	// peerLimiter enforces the per-peer limits on incoming htlcs that are
+	// forwarded through the switch.
+	peerLimiter *peerHtlcLimiter
 }
 
 // New creates the new instance of htlc switch.
@@ -378,7 +431,12 @@ func New(cfg Config, currentHeight uint32) (*Switch, error) {
 		chanCloseRequests: make(chan *ChanClose),
 		resolutionMsgs:    make(chan *resolutionMsg),
 		resMsgStore:       resStore,
-		quit:              make(chan struct{}),
+		peerLimiter: newPeerHtlcLimiter(peerHtlcLimiterConfig{
+			maxPending: cfg.MaxPendingHtlcsPerPeer,
+			addRate:    cfg.HtlcAddRatePerPeer,
+			addBurst:   cfg.HtlcAddBurstPerPeer,
+		}),
+		quit: make(chan struct{}),
 	}
 
 	s.aliasToReal = make(map[lnwire.ShortChannelID]lnwire.ShortChannelID)
@@ -648,6 +706,29 @@ func (s *Switch) IsForwardedHTLC(chanID lnwire.ShortChannelID,
 	return circuit != nil && circuit.Incoming.ChanID != hop.Source
 }
 
+// IncomingHtlcExpiry returns the expiry height of the incoming htlc that the
+// given outgoing htlc was forwarded from. Zero is returned if the htlc isn't
+// forwarded, or if the incoming htlc isn't locked in on any of our open
+// channels anymore.
+func (s *Switch) IncomingHtlcExpiry(chanID lnwire.ShortChannelID,
+	htlcIndex uint64) (uint32, error) {
+
+	circuit := s.circuits.LookupOpenCircuit(models.CircuitKey{
+		ChanID: chanID,
+		HtlcID: htlcIndex,
+	})
+	if circuit == nil || circuit.Incoming.ChanID == hop.Source {
+		return 0, nil
+	}
+
+	expiries, err := s.fetchHtlcExpiries()
+	if err != nil {
+		return 0, err
+	}
+
+	return expiries.incoming[circuit.Incoming], nil
+}
+
 // ForwardPackets adds a list of packets to the switch for processing. Fails
 // and settles are added on a first past, simultaneously constructing circuits
 // for any adds. After persisting the circuits, another pass of the adds is
@@ -1127,6 +1208,13 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			return s.failAddPacket(packet, linkErr)
 		}
 
+		// Enforce the per-peer htlc limits, so that a single peer
+		// can't use up all of our commitment slots by jamming our
+		// channels.
+		if linkErr := s.acquirePeerHtlc(packet); linkErr != nil {
+			return s.failAddPacket(packet, linkErr)
+		}
+
 		s.indexMtx.RLock()
 		targetLink, err := s.getLinkByMapping(packet)
 		if err != nil {
@@ -1175,7 +1263,8 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 				failure = link.CheckHtlcForward(
 					htlc.PaymentHash, packet.incomingAmount,
 					packet.amount, packet.incomingTimeout,
-					packet.outgoingTimeout, currentHeight,
+					packet.outgoingTimeout,
+					packet.inboundFee, currentHeight,
 					packet.originalOutgoingChanID,
 				)
 			}
@@ -1362,6 +1451,16 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			}
 		}
 
+		// Record the final outcome of forwarded htlcs, whether they
+		// were settled or failed, and release them from the limits of
+		// the peer that forwarded them.
+		if packet.incomingChanID != hop.Source {
+			s.addHtlcFwdEvent(packet, circuit)
+			s.peerLimiter.release(
+				circuit.Incoming, s.cfg.Clock.Now(),
+			)
+		}
+
 		// A blank IncomingChanID in a circuit indicates that it is a pending
 		// user-initiated payment.
 		if packet.incomingChanID == hop.Source {
@@ -1449,6 +1548,42 @@ func (s *Switch) checkCircularForward(incoming, outgoing lnwire.ShortChannelID,
 	)
 }
 
+// acquirePeerHtlc adds the incoming htlc of the packet to the pending htlcs of
+// the peer that forwarded it. A link error is returned if the peer exceeded
+// its htlc limits.
+func (s *Switch) acquirePeerHtlc(packet *htlcPacket) *LinkError {
+	if !s.peerLimiter.enabled() {
+		return nil
+	}
+
+	// If the incoming link is gone, there's no peer to account the htlc
+	// to. The htlc will be failed back once we attempt to evaluate the
+	// dust exposure of the incoming link.
+	s.indexMtx.RLock()
+	incomingLink, err := s.getLinkByShortID(packet.incomingChanID)
+	s.indexMtx.RUnlock()
+	if err != nil {
+		return nil
+	}
+
+	peerKey := incomingLink.Peer().PubKey()
+	failure := s.peerLimiter.acquire(
+		peerKey, packet.inKey(), s.cfg.Clock.Now(),
+	)
+	if failure == nil {
+		return nil
+	}
+
+	log.Debugf("Rejecting htlc %v of peer %x: %v", packet.inKey(),
+		peerKey, failure.FailureString())
+
+	// Nothing is wrong with the outgoing channel, so we don't include a
+	// channel update.
+	return NewDetailedLinkError(
+		lnwire.NewTemporaryChannelFailure(nil), failure,
+	)
+}
+
 // failAddPacket encrypts a fail packet back to an add packet's source.
 // The ciphertext will be derived from the failure message proivded by context.
 // This method returns the failErr if all other steps complete successfully.
@@ -1484,6 +1619,15 @@ func (s *Switch) failAddPacket(packet *htlcPacket, failure *LinkError) error {
 		htlc: &lnwire.UpdateFailHTLC{
 			Reason: reason,
 		},
+
+		inWireCustomRecords: packet.inWireCustomRecords,
+	}
+
+	// Record the failed forward, as the fail packet is delivered to the
+	// incoming link directly and won't be routed through the switch.
+	if packet.incomingChanID != hop.Source {
+		s.addHtlcFwdEvent(failPkt, nil)
+		s.peerLimiter.release(packet.inKey(), s.cfg.Clock.Now())
 	}
 
 	// Route a fail packet back to the source link.
@@ -2727,7 +2871,9 @@ func (s *Switch) FlushForwardingEvents() error {
 	s.fwdEventMtx.Lock()
 
 	// If we won't have any forwarding events, then we can exit early.
-	if len(s.pendingFwdingEvents) == 0 {
+	if len(s.pendingFwdingEvents) == 0 &&
+		len(s.pendingHtlcFwdEvents) == 0 {
+
 		s.fwdEventMtx.Unlock()
 		return nil
 	}
@@ -2735,15 +2881,82 @@ func (s *Switch) FlushForwardingEvents() error {
 	events := make([]channeldb.ForwardingEvent, len(s.pendingFwdingEvents))
 	copy(events[:], s.pendingFwdingEvents[:])
 
+	htlcEvents := make(
+		[]channeldb.HtlcForwardEvent, len(s.pendingHtlcFwdEvents),
+	)
+	copy(htlcEvents, s.pendingHtlcFwdEvents)
+
 	// With the copy obtained, we can now clear out the header pointer of
 	// the current slice. This way, we can re-use the underlying storage
 	// allocated for the slice.
 	s.pendingFwdingEvents = s.pendingFwdingEvents[:0]
+	s.pendingHtlcFwdEvents = s.pendingHtlcFwdEvents[:0]
 	s.fwdEventMtx.Unlock()
 
 	// Finally, we'll write out the copied events to the persistent
 	// forwarding log.
-	return s.cfg.FwdingLog.AddForwardingEvents(events)
+	if len(events) > 0 {
+		err := s.cfg.FwdingLog.AddForwardingEvents(events)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(htlcEvents) == 0 {
+		return nil
+	}
+
+	return s.cfg.FwdingLog.AddHtlcForwardEvents(htlcEvents)
+}
+
+// addHtlcFwdEvent queues an htlc forwarding event recording the final outcome
+// of the forwarded htlc the given settle or fail packet resolves, if storing
+// these events is enabled. The circuit may be nil if the htlc failed before a
+// circuit was opened.
+func (s *Switch) addHtlcFwdEvent(pkt *htlcPacket, circuit *PaymentCircuit) {
+	// Don't bother queueing events that won't be stored.
+	if !s.cfg.StoreHtlcFwdEvents {
+		return
+	}
+
+	event := channeldb.HtlcForwardEvent{
+		Timestamp:      time.Now(),
+		IncomingChanID: pkt.incomingChanID,
+		IncomingHtlcID: pkt.incomingHTLCID,
+		OutgoingChanID: pkt.outgoingChanID,
+		AmtIn:          pkt.incomingAmount,
+		AmtOut:         pkt.amount,
+		Outcome:        channeldb.HtlcForwardSettled,
+	}
+
+	if circuit != nil {
+		event.IncomingChanID = circuit.Incoming.ChanID
+		event.IncomingHtlcID = circuit.Incoming.HtlcID
+		event.AmtIn = circuit.IncomingAmount
+		event.AmtOut = circuit.OutgoingAmount
+
+		if circuit.Outgoing != nil {
+			outgoingHtlcID := circuit.Outgoing.HtlcID
+			event.OutgoingChanID = circuit.Outgoing.ChanID
+			event.OutgoingHtlcID = &outgoingHtlcID
+		}
+	}
+
+	if _, ok := pkt.htlc.(*lnwire.UpdateFailHTLC); ok {
+		event.Outcome = channeldb.HtlcForwardFailed
+
+		// Only failures at our own node carry a readable reason,
+		// failures further down the route are encrypted for the
+		// sender.
+		if pkt.linkFailure != nil {
+			event.FailureCode = pkt.linkFailure.WireMessage().Code()
+			event.FailureDetail = pkt.linkFailure.Error()
+		}
+	}
+
+	s.fwdEventMtx.Lock()
+	s.pendingHtlcFwdEvents = append(s.pendingHtlcFwdEvents, event)
+	s.fwdEventMtx.Unlock()
 }
 
 // BestHeight returns the best height known to the switch.
@@ -2817,10 +3030,117 @@ func (s *Switch) evaluateDustThreshold(link ChannelLink,
 		}
 	}
 
+	// The HTLC is within the threshold of the channel, so also make sure
+	// it doesn't push the dust exposure of all channels combined above
+	// the node-wide threshold.
+	if s.cfg.NodeDustThreshold > 0 &&
+		s.exceedsNodeDustThreshold(amount, incoming) {
+
+		return true
+	}
+
 	// If we reached this point, this HTLC is fine to forward.
 	return false
 }
 
+// ChannelDustExposure describes the dust exposure of a single channel, which
+// is the sum of all dust HTLCs that would be burned to fees if one of the
+// channel's commitments confirmed.
+type ChannelDustExposure struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// ShortChanID is the short channel id of the channel.
+	ShortChanID lnwire.ShortChannelID
+
+	// LocalDust is the dust sum on our commitment, including the dust
+	// HTLCs that are waiting in the channel's mailbox.
+	LocalDust lnwire.MilliSatoshi
+
+	// RemoteDust is the dust sum on the remote party's commitment,
+	// including the dust HTLCs that are waiting in the channel's mailbox.
+	RemoteDust lnwire.MilliSatoshi
+}
+
+// Exposure returns the dust exposure of the channel. As only one of the
+// commitments can confirm, it is the larger of the two dust sums.
+func (c *ChannelDustExposure) Exposure() lnwire.MilliSatoshi {
+	if c.LocalDust > c.RemoteDust {
+		return c.LocalDust
+	}
+
+	return c.RemoteDust
+}
+
+// DustExposure returns the current dust exposure of all active channels.
+func (s *Switch) DustExposure() []ChannelDustExposure {
+	s.indexMtx.RLock()
+	links := make([]ChannelLink, 0, len(s.linkIndex))
+	for _, link := range s.linkIndex {
+		links = append(links, link)
+	}
+	s.indexMtx.RUnlock()
+
+	exposures := make([]ChannelDustExposure, 0, len(links))
+	for _, link := range links {
+		cid := link.ChanID()
+		sid := link.ShortChanID()
+		mailbox := s.mailOrchestrator.GetOrCreateMailBox(cid, sid)
+		localMailDust, remoteMailDust := mailbox.DustPackets()
+
+		exposures = append(exposures, ChannelDustExposure{
+			ChanPoint:   *link.ChannelPoint(),
+			ShortChanID: sid,
+			LocalDust:   link.getDustSum(false) + localMailDust,
+			RemoteDust:  link.getDustSum(true) + remoteMailDust,
+		})
+	}
+
+	return exposures
+}
+
+// totalDustExposure returns the dust exposure summed across all active
+// channels.
+func (s *Switch) totalDustExposure() lnwire.MilliSatoshi {
+	var total lnwire.MilliSatoshi
+	for _, exposure := range s.DustExposure() {
+		total += exposure.Exposure()
+	}
+
+	return total
+}
+
+// exceedsNodeDustThreshold returns whether accepting a dust HTLC of the given
+// amount pushes the dust exposure summed across all channels above the
+// node-wide threshold.
+func (s *Switch) exceedsNodeDustThreshold(amount lnwire.MilliSatoshi,
+	incoming bool) bool {
+
+	s.dustExposureMtx.Lock()
+	defer s.dustExposureMtx.Unlock()
+
+	// Summing the exposure of all channels is linear in the number of
+	// links, so we only do so once per interval. Accepted HTLCs aren't
+	// added to the cached sum, as a forwarded HTLC is evaluated on both
+	// its incoming and outgoing link and would be counted twice. Instead,
+	// both the HTLCs that were added and those that were resolved in the
+	// meantime are picked up once the sum is recomputed.
+	now := s.cfg.Clock.Now()
+	if now.Sub(s.dustExposureTime) >= dustExposureInterval {
+		s.dustExposure = s.totalDustExposure()
+		s.dustExposureTime = now
+	}
+
+	// Optionally include the HTLC amount only for outgoing HTLCs, as
+	// incoming ones are already part of the commitment's dust.
+	totalSum := s.dustExposure
+	if !incoming {
+		totalSum += amount
+	}
+
+	return totalSum > s.cfg.NodeDustThreshold
+}
+
 // failMailboxUpdate is passed to the mailbox orchestrator which in turn passes
 // it to individual mailboxes. It allows the mailboxes to construct a
 // FailureMessage when failing back HTLC's due to expiry and may include an
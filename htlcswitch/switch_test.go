@@ -15,6 +15,7 @@ import (
 	"github.com/ltcsuite/lnd/chainntnfs"
 	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/lnd/channeldb/models"
+	"github.com/ltcsuite/lnd/clock"
 	"github.com/ltcsuite/lnd/contractcourt"
 	"github.com/ltcsuite/lnd/htlcswitch/hodl"
 	"github.com/ltcsuite/lnd/htlcswitch/hop"
@@ -3001,6 +3002,26 @@ func TestMultiHopPaymentForwardingEvents(t *testing.T) {
 				event.AmtOut, finalAmt)
 		}
 	}
+
+	// The final outcome of each htlc should also have been recorded as an
+	// htlc forwarding event by Bob only.
+	require.Empty(t, aliceLog.htlcEvents)
+	require.Empty(t, carolLog.htlcEvents)
+	require.Len(t, bobLog.htlcEvents, numPayments)
+	for _, event := range bobLog.htlcEvents {
+		require.Equal(t, channeldb.HtlcForwardSettled, event.Outcome)
+		require.Equal(
+			t, n.aliceChannelLink.ShortChanID(),
+			event.IncomingChanID,
+		)
+		require.Equal(
+			t, n.carolChannelLink.ShortChanID(),
+			event.OutgoingChanID,
+		)
+		require.NotNil(t, event.OutgoingHtlcID)
+		require.Equal(t, htlcAmt, event.AmtIn)
+		require.Equal(t, finalAmt, event.AmtOut)
+	}
 }
 
 // TestUpdateFailMalformedHTLCErrorConversion tests that we're able to properly
@@ -3993,6 +4014,44 @@ func TestSwitchHoldForward(t *testing.T) {
 	assertOutgoingLinkReceive(t, c.aliceChannelLink, true)
 	assertNumCircuits(t, c.s, 0, 0)
 
+	// Test resume a hold forward with a modified outgoing htlc.
+	require.NoError(t, switchForwardInterceptor.ForwardPackets(
+		linkQuit, false, c.createTestPacket(),
+	))
+	assertNumCircuits(t, c.s, 0, 0)
+	assertOutgoingLinkReceive(t, c.bobChannelLink, false)
+
+	intercepted := c.forwardInterceptor.getIntercepted()
+	modifiedAmt := lnwire.MilliSatoshi(2)
+	modifiedExpiry := uint32(testStartingHeight + 5)
+	customRecords := lnwire.CustomRecords{
+		lnwire.MinCustomRecordsTlvType: []byte{1, 2, 3},
+	}
+	require.NoError(t, switchForwardInterceptor.Resolve(&FwdResolution{
+		Action:         FwdActionResumeModified,
+		Key:            intercepted.IncomingCircuit,
+		OutgoingAmount: &modifiedAmt,
+		OutgoingExpiry: &modifiedExpiry,
+		CustomRecords:  customRecords,
+	}))
+	receivedPkt = assertOutgoingLinkReceive(t, c.bobChannelLink, true)
+	assertNumCircuits(t, c.s, 1, 1)
+
+	receivedHtlc, ok := receivedPkt.htlc.(*lnwire.UpdateAddHTLC)
+	require.True(t, ok)
+	require.Equal(t, modifiedAmt, receivedHtlc.Amount)
+	require.Equal(t, modifiedExpiry, receivedHtlc.Expiry)
+	require.Equal(t, customRecords, receivedHtlc.CustomRecords)
+
+	// Settle the htlc to close the circuit.
+	require.NoError(t, switchForwardInterceptor.ForwardPackets(
+		linkQuit, false,
+		c.createSettlePacket(receivedPkt.outgoingHTLCID),
+	))
+
+	assertOutgoingLinkReceive(t, c.aliceChannelLink, true)
+	assertNumCircuits(t, c.s, 0, 0)
+
 	// Test resume a hold forward after disconnection.
 	require.NoError(t, switchForwardInterceptor.ForwardPackets(
 		linkQuit, false, c.createTestPacket(),
@@ -4668,6 +4727,140 @@ func TestSwitchMailboxDust(t *testing.T) {
 	}
 }
 
+// TestSwitchNodeDustThreshold tests that the switch fails dust HTLCs once the
+// dust exposure summed across all channels exceeds the node-wide threshold,
+// even if the exposure of the outgoing channel is within its threshold.
+func TestSwitchNodeDustThreshold(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(
+		t, "alice", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	bobPeer, err := newMockServer(
+		t, "bob", testStartingHeight, nil, testDefaultDelta,
+	)
+	require.NoError(t, err)
+
+	s, err := initSwitchWithTempDB(t, testStartingHeight)
+	require.NoError(t, err)
+
+	s.cfg.NodeDustThreshold = lnwire.NewMSatFromSatoshis(100_000)
+
+	testClock := clock.NewTestClock(time.Unix(1_700_000_000, 0))
+	s.cfg.Clock = testClock
+
+	err = s.Start()
+	require.NoError(t, err)
+	defer func() {
+		_ = s.Stop()
+	}()
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+
+	aliceLink := newMockChannelLink(
+		s, chanID1, aliceChanID, emptyScid, alicePeer, true, false,
+		false, false,
+	)
+	err = s.AddLink(aliceLink)
+	require.NoError(t, err)
+
+	bobLink := newMockChannelLink(
+		s, chanID2, bobChanID, emptyScid, bobPeer, true, false, false,
+		false,
+	)
+	err = s.AddLink(bobLink)
+	require.NoError(t, err)
+
+	// mockChannelLink sets the local and remote dust limits of the mailbox
+	// to 400 satoshis and the feerate to 0, so an HTLC of 350 satoshis is
+	// dust on both commitments.
+	preimage, err := genPreimage()
+	require.NoError(t, err)
+	rhash := sha256.Sum256(preimage[:])
+	amt := lnwire.NewMSatFromSatoshis(350)
+	newAddMsg := func(chanID lnwire.ChannelID) *lnwire.UpdateAddHTLC {
+		return &lnwire.UpdateAddHTLC{
+			PaymentHash: rhash,
+			Amount:      amt,
+			ChanID:      chanID,
+		}
+	}
+
+	// With no dust exposure yet, a dust HTLC can be sent to Bob.
+	err = s.SendHTLC(bobChanID, 0, newAddMsg(chanID2))
+	require.NoError(t, err)
+
+	// We'll now fill up Alice's mailbox with dust packets until the total
+	// dust exposure is just above the node-wide threshold. Alice's own
+	// exposure stays well within the threshold of a single channel.
+	aliceCount := 285
+	mailbox := s.mailOrchestrator.GetOrCreateMailBox(chanID1, aliceChanID)
+	for i := 0; i < aliceCount; i++ {
+		alicePkt := &htlcPacket{
+			incomingChanID: bobChanID,
+			incomingHTLCID: uint64(i),
+			outgoingChanID: aliceChanID,
+			obfuscator:     NewMockObfuscator(),
+			incomingAmount: amt,
+			amount:         amt,
+			htlc:           newAddMsg(chanID1),
+		}
+
+		err = mailbox.AddPacket(alicePkt)
+		require.NoError(t, err)
+	}
+
+	// The dust exposure of both channels should be reported.
+	aliceDust := amt * lnwire.MilliSatoshi(aliceCount)
+	exposures := s.DustExposure()
+	require.Len(t, exposures, 2)
+
+	var total lnwire.MilliSatoshi
+	for _, exposure := range exposures {
+		expected := amt
+		if exposure.ShortChanID == aliceChanID {
+			expected = aliceDust
+		}
+
+		require.Equal(t, expected, exposure.LocalDust)
+		require.Equal(t, expected, exposure.RemoteDust)
+		require.Equal(t, expected, exposure.Exposure())
+		total += exposure.Exposure()
+	}
+	require.Greater(t, total+amt, s.cfg.NodeDustThreshold)
+	require.Less(t, aliceDust+amt, s.cfg.DustThreshold)
+
+	// The node-wide dust exposure is cached, so the packets that were
+	// added to Alice's mailbox directly are only picked up once the
+	// interval passed.
+	advance := func() {
+		testClock.SetTime(testClock.Now().Add(dustExposureInterval))
+	}
+	err = s.SendHTLC(bobChanID, 1, newAddMsg(chanID2))
+	require.NoError(t, err)
+
+	// Sending another dust HTLC to Bob should now fail, as it pushes the
+	// total dust exposure above the node-wide threshold.
+	advance()
+	err = s.SendHTLC(bobChanID, 2, newAddMsg(chanID2))
+	require.ErrorIs(t, err, errDustThresholdExceeded)
+
+	// Once some of Alice's dust HTLCs are resolved, there is room for a
+	// dust HTLC to Bob again.
+	for i := 0; i < 10; i++ {
+		require.True(t, mailbox.AckPacket(CircuitKey{
+			ChanID: bobChanID,
+			HtlcID: uint64(i),
+		}))
+	}
+
+	advance()
+	err = s.SendHTLC(bobChanID, 3, newAddMsg(chanID2))
+	require.NoError(t, err)
+}
+
 // TestSwitchResolution checks the ability of the switch to persist and handle
 // resolution messages.
 func TestSwitchResolution(t *testing.T) {
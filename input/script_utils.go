@@ -2511,6 +2511,10 @@ func CommitScriptUnencumbered(key *btcec.PublicKey) ([]byte, error) {
 	return builder.Script()
 }
 
+// ToRemoteConfirmedDelay is the relative time lock, in blocks, that encumbers
+// the to_remote output of anchor and taproot channels.
+const ToRemoteConfirmedDelay = 1
+
 // CommitScriptToRemoteConfirmed constructs the script for the output on the
 // commitment transaction paying to the remote party of said commitment
 // transaction. The money can only be spend after one confirmation.
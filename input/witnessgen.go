@@ -720,6 +720,23 @@ func (wt StandardWitnessType) WitnessGenerator(signer Signer,
 	}
 }
 
+// IsToRemoteConfirmed returns true if the witness type spends our to_remote
+// output on the counterparty's commitment transaction that is encumbered by a
+// 1 block CSV delay. Inputs of these types must be constructed with a
+// relative time lock of ToRemoteConfirmedDelay, otherwise the sweeping
+// transaction is invalid.
+func (wt StandardWitnessType) IsToRemoteConfirmed() bool {
+	switch wt {
+	case CommitmentToRemoteConfirmed, LeaseCommitmentToRemoteConfirmed,
+		TaprootRemoteCommitSpend:
+
+		return true
+
+	default:
+		return false
+	}
+}
+
 // SizeUpperBound returns the maximum length of the witness of this witness
 // type if it would be included in a tx. We also return if the output itself is
 // a nested p2sh output, if so then we need to take into account the extra
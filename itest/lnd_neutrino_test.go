@@ -29,4 +29,9 @@ func testNeutrino(ht *lntest.HarnessTest) {
 		PeerAddrs: statusRes.Peers[0],
 	}
 	ht.Alice.RPC.AddPeer(addPeerReq)
+
+	// Mine a few blocks and make sure the block and filter headers follow
+	// the miner.
+	ht.MineEmptyBlocks(3)
+	ht.AssertNeutrinoSynced(ht.Alice)
 }
@@ -276,7 +276,7 @@ func runCPFP(ht *lntest.HarnessTest, alice, bob *node.HarnessNode) {
 	bumpFeeReq := &walletrpc.BumpFeeRequest{
 		Outpoint: op,
 		SatPerVbyte: uint64(
-			sweep.DefaultMaxFeeRate.FeePerKVByte() / 2000,
+			sweep.DefaultMaxFeeRate().FeePerKVByte() / 2000,
 		),
 	}
 	bob.RPC.BumpFee(bumpFeeReq)
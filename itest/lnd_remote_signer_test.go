@@ -1,7 +1,6 @@
 package itest
 
 import (
-	"fmt"
 	"testing"
 
 	"github.com/ltcsuite/lnd/keychain"
@@ -222,21 +221,10 @@ func testRemoteSigner(ht *lntest.HarnessTest) {
 		// the Signer node for any operation that requires access to
 		// private keys.
 		watchOnly := st.NewNodeRemoteSigner(
-			"WatchOnly", append([]string{
-				"--remotesigner.enable",
-				fmt.Sprintf(
-					"--remotesigner.rpchost=localhost:%d",
-					signer.Cfg.RPCPort,
-				),
-				fmt.Sprintf(
-					"--remotesigner.tlscertpath=%s",
-					signer.Cfg.TLSCertPath,
-				),
-				fmt.Sprintf(
-					"--remotesigner.macaroonpath=%s",
-					signer.Cfg.AdminMacPath,
-				),
-			}, commitArgs...),
+			"WatchOnly", append(
+				lntest.NodeArgsForRemoteSigner(signer),
+				commitArgs...,
+			),
 			password, &lnrpc.WatchOnly{
 				MasterKeyBirthdayTimestamp: 0,
 				MasterKeyFingerprint:       nil,
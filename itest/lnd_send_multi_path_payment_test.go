@@ -86,13 +86,9 @@ func testSendMultiPathPayment(ht *lntest.HarnessTest) {
 		"expected shards not reached")
 
 	// Make sure Bob show the invoice as settled for the full amount.
-	inv := mts.bob.RPC.LookupInvoice(rHash)
-
-	require.EqualValues(ht, paymentAmt, inv.AmtPaidSat,
-		"incorrect payment amt")
-
-	require.Equal(ht, lnrpc.Invoice_SETTLED, inv.State,
-		"Invoice not settled")
+	inv := ht.AssertInvoiceSettledWithAmt(
+		mts.bob, rHash, int64(paymentAmt),
+	)
 
 	settled := 0
 	for _, htlc := range inv.Htlcs {
@@ -47,9 +47,7 @@ func testSingleHopInvoice(ht *lntest.HarnessTest) {
 	ht.CompletePaymentRequests(alice, []string{invoiceResp.PaymentRequest})
 
 	// Bob's invoice should now be found and marked as settled.
-	dbInvoice := bob.RPC.LookupInvoice(invoiceResp.RHash)
-	require.Equal(ht, lnrpc.Invoice_SETTLED, dbInvoice.State,
-		"bob's invoice should be marked as settled")
+	ht.AssertInvoiceSettledWithAmt(bob, invoiceResp.RHash, paymentAmt)
 
 	// With the payment completed all balance related stats should be
 	// properly updated.
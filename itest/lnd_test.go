@@ -89,6 +89,11 @@ func TestLightningNetworkDaemon(t *testing.T) {
 	testCases, trancheIndex, trancheOffset := getTestCaseSplitTranche()
 	node.ApplyPortOffset(uint32(trancheIndex) * 1000)
 
+	// Release the ports reserved by this tranche once all nodes, the
+	// miner and the chain backend are stopped, which happens in the
+	// deferred harness stop below.
+	defer node.ReleasePorts()
+
 	// Create a simple fee service.
 	feeService := lntest.NewFeeService(t)
 
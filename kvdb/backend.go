@@ -71,6 +71,12 @@ type BoltBackendConfig struct {
 	// be considered again.
 	AutoCompactMinAge time.Duration
 
+	// AutoCompactMinFragmentation specifies the minimum estimated fraction
+	// of unused pages a bolt database file must have for the compaction
+	// to be considered. A value of zero compacts the file regardless of
+	// its fragmentation.
+	AutoCompactMinFragmentation float64
+
 	// DBTimeout specifies the timeout value to use when opening the wallet
 	// database.
 	DBTimeout time.Duration
@@ -138,6 +144,27 @@ func compactAndSwap(cfg *BoltBackendConfig) error {
 		return nil
 	}
 
+	// Compacting only makes sense if a sizeable part of the file is made up
+	// of pages that are no longer in use.
+	if cfg.AutoCompactMinFragmentation > 0 {
+		fragmentation, err := boltFragmentation(
+			sourceFilePath, cfg.DBTimeout,
+		)
+		if err != nil {
+			return fmt.Errorf("cannot determine fragmentation of "+
+				"source DB file: %v", err)
+		}
+
+		if fragmentation < cfg.AutoCompactMinFragmentation {
+			log.Infof("Not compacting database file at %v, its "+
+				"fragmentation of %.2f is below the minimum of "+
+				"%.2f", sourceFilePath, fragmentation,
+				cfg.AutoCompactMinFragmentation)
+
+			return nil
+		}
+	}
+
 	log.Infof("Compacting database file at %v", sourceFilePath)
 
 	// If the old temporary DB file still exists, then we'll delete it
@@ -185,6 +212,14 @@ func compactAndSwap(cfg *BoltBackendConfig) error {
 		sourceFilePath, initialSize, newSize,
 		float64(initialSize)/float64(newSize))
 
+	// Before we replace the original file, we make sure the compacted copy
+	// holds exactly the same data. If it doesn't, the original file is
+	// kept and the copy is removed.
+	err = verifyCompaction(sourceFilePath, tempDestFilePath, cfg)
+	if err != nil {
+		return fmt.Errorf("error verifying compacted DB: %v", err)
+	}
+
 	// We try to store the current timestamp in a file with the suffix
 	// .last-compacted so we can figure out how long ago the last compaction
 	// was. But since this shouldn't fail the compaction process itself, we
@@ -206,6 +241,41 @@ func compactAndSwap(cfg *BoltBackendConfig) error {
 	return os.Rename(tempDestFilePath, sourceFilePath)
 }
 
+// verifyCompaction checks that the compacted database file holds the same
+// buckets, keys and values as the source database file.
+func verifyCompaction(srcPath, dstPath string, cfg *BoltBackendConfig) error {
+	src, err := Open(
+		BoltBackendName, srcPath, cfg.NoFreelistSync, cfg.DBTimeout,
+	)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := Open(
+		BoltBackendName, dstPath, cfg.NoFreelistSync, cfg.DBTimeout,
+	)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	return VerifyMigration(src, dst)
+}
+
+// CompactBoltDB compacts the existing bolt database file described by the
+// given config if auto compaction is enabled and the file is due for it,
+// without opening it afterwards. This can be used for database files that are
+// opened by other packages.
+func CompactBoltDB(cfg *BoltBackendConfig) error {
+	dbFilePath := filepath.Join(cfg.DBPath, cfg.DBFileName)
+	if !cfg.AutoCompact || !fileExists(dbFilePath) {
+		return nil
+	}
+
+	return compactAndSwap(cfg)
+}
+
 // lastCompactionDate returns the date the given database file was last
 // compacted or a zero time.Time if no compaction was recorded before. The
 // compaction date is read from a file in the same directory and with the same
@@ -32,6 +32,12 @@ type BoltBackendConfig struct {
 	// be considered again.
 	AutoCompactMinAge time.Duration
 
+	// AutoCompactMinFragmentation specifies the minimum estimated fraction
+	// of unused pages a bolt database file must have for the compaction
+	// to be considered. A value of zero compacts the file regardless of
+	// its fragmentation.
+	AutoCompactMinFragmentation float64
+
 	// DBTimeout specifies the timeout value to use when opening the wallet
 	// database.
 	DBTimeout time.Duration
@@ -43,6 +49,12 @@ func GetBoltBackend(cfg *BoltBackendConfig) (Backend, error) {
 	return nil, fmt.Errorf("bolt backend not supported in WebAssembly")
 }
 
+// CompactBoltDB compacts the existing bolt database file described by the
+// given config if auto compaction is enabled and the file is due for it.
+func CompactBoltDB(cfg *BoltBackendConfig) error {
+	return fmt.Errorf("bolt backend not supported in WebAssembly")
+}
+
 func GetTestBackend(path, name string) (Backend, func(), error) {
 	return nil, nil, fmt.Errorf("bolt backend not supported in WebAssembly")
 }
@@ -0,0 +1,35 @@
+package kvdb
+
+import (
+	"io"
+)
+
+// countingWriter is an io.Writer that counts the bytes written to the
+// underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write writes the given bytes to the underlying writer.
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+
+	return n, err
+}
+
+// StreamBackup writes a consistent snapshot of the given live database to w
+// and returns the number of bytes written. For bolt databases the snapshot is
+// written from a single read transaction using bolt's Tx.WriteTo, so it
+// doesn't block writers and the database doesn't need to be closed. The
+// result is a regular bolt database file. Backends that can't produce a
+// snapshot return an error.
+func StreamBackup(db Backend, w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	if err := db.Copy(cw); err != nil {
+		return cw.n, err
+	}
+
+	return cw.n, nil
+}
@@ -0,0 +1,42 @@
+package kvdb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestStreamBackup tests that a backup of a live bolt database can be opened
+// as a regular bolt database that holds the same data.
+func TestStreamBackup(t *testing.T) {
+	t.Parallel()
+
+	db := NewBoltFixture(t).NewBackend()
+	populateMigrationSource(t, db)
+
+	dir := t.TempDir()
+	backupFile, err := os.Create(filepath.Join(dir, "backup.db"))
+	require.NoError(t, err)
+
+	n, err := StreamBackup(db, backupFile)
+	require.NoError(t, err)
+	require.NoError(t, backupFile.Close())
+
+	info, err := os.Stat(backupFile.Name())
+	require.NoError(t, err)
+	require.Equal(t, info.Size(), n)
+
+	backup, err := GetBoltBackend(&BoltBackendConfig{
+		DBPath:     dir,
+		DBFileName: "backup.db",
+		DBTimeout:  DefaultDBTimeout,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, backup.Close())
+	})
+
+	require.NoError(t, VerifyMigration(db, backup))
+}
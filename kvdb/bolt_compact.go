@@ -230,6 +230,54 @@ func (cmd *compacter) walk(db *bbolt.DB, walkFn walkFunc) error {
 	})
 }
 
+// boltFragmentation returns an estimate of the fraction of pages of the given
+// bolt database file that are not in use by any bucket. Those pages are only
+// given back to the file system by a compaction.
+func boltFragmentation(dbPath string, timeout time.Duration) (float64, error) {
+	db, err := bbolt.Open(dbPath, 0444, &bbolt.Options{
+		ReadOnly: true,
+		Timeout:  timeout,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Errorf("Error closing DB: %v", err)
+		}
+	}()
+
+	var fragmentation float64
+	err = db.View(func(tx *bbolt.Tx) error {
+		pageSize := int64(db.Info().PageSize)
+		totalPages := tx.Size() / pageSize
+
+		// The two meta pages, the freelist and the root page are
+		// always in use.
+		usedPages := int64(4)
+		err := tx.ForEach(func(_ []byte, b *bbolt.Bucket) error {
+			stats := b.Stats()
+			usedPages += int64(stats.BranchPageN +
+				stats.BranchOverflowN + stats.LeafPageN +
+				stats.LeafOverflowN)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if totalPages > usedPages {
+			fragmentation = float64(totalPages-usedPages) /
+				float64(totalPages)
+		}
+
+		return nil
+	})
+
+	return fragmentation, err
+}
+
 // LoggableKeyName returns a printable name of the given key.
 func LoggableKeyName(key []byte) string {
 	strKey := string(key)
@@ -0,0 +1,128 @@
+//go:build !js
+// +build !js
+
+package kvdb
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createFragmentedDB creates a bolt database in the given directory that has
+// most of its pages unused because the data was deleted again.
+func createFragmentedDB(t *testing.T, dir, name string) {
+	db, err := Create(
+		BoltBackendName, filepath.Join(dir, name), true,
+		DefaultDBTimeout,
+	)
+	require.NoError(t, err)
+
+	value := make([]byte, 1000)
+	err = Update(db, func(tx RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket([]byte("bucket"))
+		if err != nil {
+			return err
+		}
+
+		for i := uint32(0); i < 2000; i++ {
+			var key [4]byte
+			binary.BigEndian.PutUint32(key[:], i)
+			if err := bucket.Put(key[:], value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	// Delete all but the first ten keys again.
+	err = Update(db, func(tx RwTx) error {
+		bucket := tx.ReadWriteBucket([]byte("bucket"))
+		for i := uint32(10); i < 2000; i++ {
+			var key [4]byte
+			binary.BigEndian.PutUint32(key[:], i)
+			if err := bucket.Delete(key[:]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	require.NoError(t, db.Close())
+}
+
+// TestCompactMinFragmentation tests that a database file is only compacted if
+// its fragmentation exceeds the configured minimum and that the data is kept
+// intact.
+func TestCompactMinFragmentation(t *testing.T) {
+	t.Parallel()
+
+	const name = "test.db"
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, name)
+	createFragmentedDB(t, dir, name)
+
+	fragmentation, err := boltFragmentation(dbPath, DefaultDBTimeout)
+	require.NoError(t, err)
+	require.Greater(t, fragmentation, 0.9)
+
+	fileSize := func() int64 {
+		info, err := os.Stat(dbPath)
+		require.NoError(t, err)
+
+		return info.Size()
+	}
+	initialSize := fileSize()
+
+	// With a minimum fragmentation above the actual one, the file
+	// shouldn't be touched.
+	cfg := &BoltBackendConfig{
+		DBPath:                      dir,
+		DBFileName:                  name,
+		DBTimeout:                   DefaultDBTimeout,
+		AutoCompact:                 true,
+		AutoCompactMinFragmentation: (1 + fragmentation) / 2,
+	}
+	require.NoError(t, CompactBoltDB(cfg))
+	require.Equal(t, initialSize, fileSize())
+
+	// Lowering the minimum should compact the file now.
+	cfg.AutoCompactMinFragmentation = 0.5
+	require.NoError(t, CompactBoltDB(cfg))
+	require.Less(t, fileSize(), initialSize)
+
+	fragmentation, err = boltFragmentation(dbPath, DefaultDBTimeout)
+	require.NoError(t, err)
+	require.Less(t, fragmentation, 0.5)
+
+	db, err := GetBoltBackend(&BoltBackendConfig{
+		DBPath:     dir,
+		DBFileName: name,
+		DBTimeout:  DefaultDBTimeout,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	err = View(db, func(tx RTx) error {
+		var numKeys int
+		err := tx.ReadBucket([]byte("bucket")).ForEach(
+			func(_, _ []byte) error {
+				numKeys++
+				return nil
+			},
+		)
+		require.Equal(t, 10, numKeys)
+
+		return err
+	}, func() {})
+	require.NoError(t, err)
+}
@@ -33,3 +33,65 @@ func (b *boltFixture) NewBackend() walletdb.DB {
 
 	return db
 }
+
+// TestBackendTypes returns the database backends that unit tests can be run
+// against with the current build tags. Bolt is always available, sqlite and
+// postgres require the kvdb_sqlite and kvdb_postgres build tags respectively.
+func TestBackendTypes() []string {
+	backendTypes := []string{BoltBackendName}
+
+	if SqliteBackend {
+		backendTypes = append(backendTypes, SqliteBackendName)
+	}
+
+	if PostgresBackend {
+		backendTypes = append(backendTypes, PostgresBackendName)
+	}
+
+	return backendTypes
+}
+
+// NewTestBackend creates a new empty database of the given backend type that
+// is closed once the test finishes. The test is skipped if the backend isn't
+// available with the current build tags. For postgres, an embedded postgres
+// instance is started on demand and stopped again once the last test that uses
+// it finished.
+func NewTestBackend(t *testing.T, backendType string) Backend {
+	var db Backend
+	switch backendType {
+	case BoltBackendName:
+		db = NewBoltFixture(t).NewBackend()
+
+	case SqliteBackendName:
+		if !SqliteBackend {
+			t.Skipf("%v backend not available", backendType)
+		}
+
+		var err error
+		db, err = StartSqliteTestBackend(t.TempDir(), "test.db", "test")
+		require.NoError(t, err)
+
+	case PostgresBackendName:
+		if !PostgresBackend {
+			t.Skipf("%v backend not available", backendType)
+		}
+
+		release, err := acquireTestPostgres()
+		require.NoError(t, err)
+		t.Cleanup(release)
+
+		// An empty name creates a database with a random name.
+		f, err := NewPostgresFixture("")
+		require.NoError(t, err)
+		db = f.DB()
+
+	default:
+		t.Fatalf("unknown test backend type %v", backendType)
+	}
+
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	return db
+}
@@ -0,0 +1,208 @@
+package kvdb
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCoalescerMaxPending is the default number of queued writes
+	// that trigger a flush before the flush interval has elapsed.
+	DefaultCoalescerMaxPending = 1000
+)
+
+// coalescedWrite is a single write that was queued in a WriteCoalescer.
+type coalescedWrite struct {
+	update  func(tx RwTx) error
+	errChan chan error
+}
+
+// WriteCoalescer queues writes of high-frequency writers and applies them
+// periodically in a single transaction, which reduces the number of commits
+// (and therefore fsyncs with bolt) at the cost of the writes becoming durable
+// only with the next flush. In contrast to the batch package, writers don't
+// need to wait for their write to be committed.
+//
+// If one of the queued writes fails, it is removed from the batch and the
+// remaining writes are applied again, so each write must be safe to be
+// executed multiple times.
+type WriteCoalescer struct {
+	db            Backend
+	flushInterval time.Duration
+	maxPending    int
+
+	mu      sync.Mutex
+	pending []*coalescedWrite
+	stopped bool
+
+	// flushMu makes sure only one flush is running at a time so the
+	// writes are applied in the order they were queued.
+	flushMu sync.Mutex
+
+	flushSignal chan struct{}
+	startOnce   sync.Once
+	stopOnce    sync.Once
+	quit        chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewWriteCoalescer creates a new WriteCoalescer that flushes the queued
+// writes to the given database every flushInterval, or as soon as maxPending
+// writes are queued.
+func NewWriteCoalescer(db Backend, flushInterval time.Duration,
+	maxPending int) *WriteCoalescer {
+
+	if maxPending <= 0 {
+		maxPending = DefaultCoalescerMaxPending
+	}
+
+	return &WriteCoalescer{
+		db:            db,
+		flushInterval: flushInterval,
+		maxPending:    maxPending,
+		flushSignal:   make(chan struct{}, 1),
+		quit:          make(chan struct{}),
+	}
+}
+
+// Start starts flushing the queued writes periodically.
+func (c *WriteCoalescer) Start() {
+	c.startOnce.Do(func() {
+		c.wg.Add(1)
+		go c.flushLoop()
+	})
+}
+
+// Stop stops the periodic flushing and applies all writes that are still
+// queued. Writes that are queued after Stop was called are applied
+// immediately.
+func (c *WriteCoalescer) Stop() error {
+	var err error
+	c.stopOnce.Do(func() {
+		close(c.quit)
+		c.wg.Wait()
+
+		c.mu.Lock()
+		c.stopped = true
+		c.mu.Unlock()
+
+		err = c.Flush()
+	})
+
+	return err
+}
+
+// Queue adds the given write to the next flush. The returned channel receives
+// the result of the write once it was committed. It is buffered, so callers
+// that aren't interested in the result don't need to read from it. Failed
+// writes are logged in any case.
+func (c *WriteCoalescer) Queue(update func(tx RwTx) error) <-chan error {
+	write := &coalescedWrite{
+		update:  update,
+		errChan: make(chan error, 1),
+	}
+
+	c.mu.Lock()
+	stopped := c.stopped
+	c.pending = append(c.pending, write)
+	numPending := len(c.pending)
+	c.mu.Unlock()
+
+	switch {
+	// Once the coalescer is stopped, there won't be another periodic
+	// flush, so we apply the write right away.
+	case stopped:
+		_ = c.Flush()
+
+	// If too many writes are queued, we don't wait for the flush
+	// interval to elapse.
+	case numPending >= c.maxPending:
+		select {
+		case c.flushSignal <- struct{}{}:
+		default:
+		}
+	}
+
+	return write.errChan
+}
+
+// Write adds the given write to the next flush and waits until it was
+// committed.
+func (c *WriteCoalescer) Write(update func(tx RwTx) error) error {
+	return <-c.Queue(update)
+}
+
+// Flush applies all queued writes in a single transaction. Writes that fail
+// are removed from the batch and the remaining writes are applied again. The
+// error of the final commit is returned.
+func (c *WriteCoalescer) Flush() error {
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+
+	c.mu.Lock()
+	writes := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+
+	for len(writes) > 0 {
+		failIdx := -1
+		err := Update(c.db, func(tx RwTx) error {
+			for i, write := range writes {
+				if err := write.update(tx); err != nil {
+					failIdx = i
+					return err
+				}
+			}
+
+			return nil
+		}, func() {
+			failIdx = -1
+		})
+
+		// If a single write failed, we report its error and apply the
+		// remaining ones again.
+		if failIdx >= 0 {
+			log.Errorf("Coalesced write failed: %v", err)
+
+			writes[failIdx].errChan <- err
+			writes = append(writes[:failIdx], writes[failIdx+1:]...)
+
+			continue
+		}
+
+		if err != nil {
+			log.Errorf("Unable to commit %d coalesced writes: %v",
+				len(writes), err)
+		}
+
+		for _, write := range writes {
+			write.errChan <- err
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// flushLoop flushes the queued writes every flush interval or once too many
+// writes are queued.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *WriteCoalescer) flushLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.flushSignal:
+		case <-c.quit:
+			return
+		}
+
+		_ = c.Flush()
+	}
+}
@@ -0,0 +1,145 @@
+package kvdb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// putWrite returns a coalesced write that stores the given key in the test
+// bucket.
+func putWrite(key string) func(tx RwTx) error {
+	return func(tx RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(testBucket)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put([]byte(key), []byte(key))
+	}
+}
+
+// requireKeys asserts that exactly the given keys are stored in the test
+// bucket.
+func requireKeys(t *testing.T, db Backend, keys ...string) {
+	var stored []string
+	err := View(db, func(tx RTx) error {
+		bucket := tx.ReadBucket(testBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, _ []byte) error {
+			stored = append(stored, string(k))
+			return nil
+		})
+	}, func() {
+		stored = nil
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, keys, stored)
+}
+
+// testWriteCoalescerFlush tests that queued writes are applied together and
+// that a failing write doesn't prevent the others from being committed.
+func testWriteCoalescerFlush(t *testing.T, db Backend) {
+	c := NewWriteCoalescer(db, time.Hour, 0)
+
+	errFail := errors.New("fail")
+	results := []<-chan error{
+		c.Queue(putWrite("a")),
+		c.Queue(func(tx RwTx) error {
+			return errFail
+		}),
+		c.Queue(putWrite("b")),
+	}
+
+	// Nothing is written before the flush.
+	requireKeys(t, db)
+
+	require.NoError(t, c.Flush())
+	requireKeys(t, db, "a", "b")
+
+	require.NoError(t, <-results[0])
+	require.ErrorIs(t, <-results[1], errFail)
+	require.NoError(t, <-results[2])
+}
+
+// testWriteCoalescerMaxPending tests that the writes are flushed as soon as
+// the maximum number of pending writes is reached.
+func testWriteCoalescerMaxPending(t *testing.T, db Backend) {
+	c := NewWriteCoalescer(db, time.Hour, 3)
+	c.Start()
+	t.Cleanup(func() {
+		require.NoError(t, c.Stop())
+	})
+
+	var results []<-chan error
+	for i := 0; i < 3; i++ {
+		results = append(results, c.Queue(putWrite(fmt.Sprint(i))))
+	}
+
+	for _, result := range results {
+		select {
+		case err := <-result:
+			require.NoError(t, err)
+
+		case <-time.After(5 * time.Second):
+			t.Fatal("writes not flushed")
+		}
+	}
+	requireKeys(t, db, "0", "1", "2")
+}
+
+// testWriteCoalescerStop tests that the queued writes are flushed on stop and
+// that writes queued afterwards are applied immediately.
+func testWriteCoalescerStop(t *testing.T, db Backend) {
+	c := NewWriteCoalescer(db, time.Hour, 0)
+	c.Start()
+
+	result := c.Queue(putWrite("a"))
+	require.NoError(t, c.Stop())
+	require.NoError(t, <-result)
+	requireKeys(t, db, "a")
+
+	require.NoError(t, c.Write(putWrite("b")))
+	requireKeys(t, db, "a", "b")
+}
+
+// TestWriteCoalescer runs the write coalescer tests against all available
+// database backends.
+func TestWriteCoalescer(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(*testing.T, Backend)
+	}{
+		{
+			name: "flush",
+			test: testWriteCoalescerFlush,
+		},
+		{
+			name: "max pending",
+			test: testWriteCoalescerMaxPending,
+		},
+		{
+			name: "stop",
+			test: testWriteCoalescerStop,
+		},
+	}
+
+	for _, backendType := range TestBackendTypes() {
+		for _, test := range tests {
+			backendType, test := backendType, test
+
+			name := fmt.Sprintf("%v/%v", backendType, test.name)
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				test.test(t, NewTestBackend(t, backendType))
+			})
+		}
+	}
+}
@@ -41,5 +41,7 @@ type BoltConfig struct {
 
 	AutoCompactMinAge time.Duration `long:"auto-compact-min-age" description:"How long ago the last compaction of a database file must be for it to be considered for auto compaction again. Can be set to 0 to compact on every startup."`
 
+	AutoCompactMinFragmentation float64 `long:"auto-compact-min-fragmentation" description:"The minimum estimated fraction (between 0 and 1) of unused pages in a database file for it to be considered for auto compaction. Can be set to 0 to compact regardless of the fragmentation."`
+
 	DBTimeout time.Duration `long:"dbtimeout" description:"Specify the timeout value used when opening the database."`
 }
@@ -0,0 +1,777 @@
+package kvdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	// encryptionMetaBucket is the top-level bucket of an encrypted
+	// database that holds the value used to check the encryption key. It
+	// is hidden from the users of the encrypted backend.
+	encryptionMetaBucket = []byte("kvdb-encryption")
+
+	// encryptionCheckKey is the key in the meta bucket that holds the
+	// encrypted check value.
+	encryptionCheckKey = []byte("check")
+
+	// encryptionCheckValue is the plaintext of the check value.
+	encryptionCheckValue = []byte("kvdb-encryption-check")
+
+	// ErrWrongEncryptionKey is returned if an encrypted database is opened
+	// with a key other than the one it was encrypted with.
+	ErrWrongEncryptionKey = errors.New("database is encrypted with a " +
+		"different key")
+
+	// ErrNotEncrypted is returned if the key of a database that isn't
+	// encrypted is rotated.
+	ErrNotEncrypted = errors.New("database is not encrypted")
+)
+
+// ValueEncrypter encrypts and decrypts the values stored in an encrypted
+// database. Keys are stored in plaintext so the ordering of keys within their
+// buckets is preserved.
+type ValueEncrypter interface {
+	// Encrypt returns the ciphertext of the given value. The associated
+	// data must be authenticated together with the value, so the
+	// ciphertext can't be decrypted with any other associated data.
+	Encrypt(plaintext, ad []byte) ([]byte, error)
+
+	// Decrypt returns the plaintext of the given encrypted value that was
+	// encrypted with the given associated data.
+	Decrypt(ciphertext, ad []byte) ([]byte, error)
+}
+
+// OpenEncryptedBackend wraps the given database so that all values are
+// transparently encrypted when written and decrypted when read. If the
+// database isn't encrypted yet, all its existing values are encrypted in a
+// single transaction first. If it was encrypted with a different key,
+// ErrWrongEncryptionKey is returned.
+//
+// Every value is bound to the path of its bucket and its key, so a ciphertext
+// that is copied to another location of the database can't be decrypted.
+func OpenEncryptedBackend(db Backend, enc ValueEncrypter) (Backend, error) {
+	err := Update(db, func(tx RwTx) error {
+		meta := tx.ReadWriteBucket(encryptionMetaBucket)
+		if meta != nil {
+			return checkEncryptionKey(meta, enc)
+		}
+
+		log.Infof("Encrypting existing database values")
+
+		if err := reencryptTx(tx, nil, enc); err != nil {
+			return err
+		}
+
+		return putEncryptionCheck(tx, enc)
+	}, func() {})
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptedBackend{Backend: db, enc: enc}, nil
+}
+
+// RotateEncryptionKey re-encrypts all values of the given encrypted database
+// that was encrypted with oldEnc using newEnc. The rotation happens in a
+// single transaction, so it is either applied completely or not at all. If
+// the database is already encrypted with the new key, nothing is done.
+func RotateEncryptionKey(db Backend, oldEnc, newEnc ValueEncrypter) error {
+	return Update(db, func(tx RwTx) error {
+		meta := tx.ReadWriteBucket(encryptionMetaBucket)
+		if meta == nil {
+			return ErrNotEncrypted
+		}
+
+		// If a previous rotation already succeeded, there's nothing
+		// left to do.
+		if checkEncryptionKey(meta, newEnc) == nil {
+			return nil
+		}
+		if err := checkEncryptionKey(meta, oldEnc); err != nil {
+			return err
+		}
+
+		log.Infof("Rotating database encryption key")
+
+		if err := reencryptTx(tx, oldEnc, newEnc); err != nil {
+			return err
+		}
+
+		return putEncryptionCheck(tx, newEnc)
+	}, func() {})
+}
+
+// encryptionCheckAD returns the associated data of the check value.
+func encryptionCheckAD() []byte {
+	return valueAD(bucketPath(nil, encryptionMetaBucket), encryptionCheckKey)
+}
+
+// checkEncryptionKey makes sure the check value in the given meta bucket can
+// be decrypted with the given encrypter.
+func checkEncryptionKey(meta RBucket, enc ValueEncrypter) error {
+	plaintext, err := enc.Decrypt(
+		meta.Get(encryptionCheckKey), encryptionCheckAD(),
+	)
+	if err != nil || !bytes.Equal(plaintext, encryptionCheckValue) {
+		return ErrWrongEncryptionKey
+	}
+
+	return nil
+}
+
+// putEncryptionCheck stores the check value encrypted with the given
+// encrypter in the meta bucket.
+func putEncryptionCheck(tx RwTx, enc ValueEncrypter) error {
+	meta, err := tx.CreateTopLevelBucket(encryptionMetaBucket)
+	if err != nil {
+		return err
+	}
+
+	check, err := enc.Encrypt(encryptionCheckValue, encryptionCheckAD())
+	if err != nil {
+		return err
+	}
+
+	return meta.Put(encryptionCheckKey, check)
+}
+
+// reencryptTx decrypts all values of the database with oldEnc and encrypts
+// them again with newEnc. A nil oldEnc means the values are not encrypted
+// yet.
+func reencryptTx(tx RwTx, oldEnc, newEnc ValueEncrypter) error {
+	var topLevel [][]byte
+	err := tx.ForEachBucket(func(key []byte) error {
+		if !bytes.Equal(key, encryptionMetaBucket) {
+			topLevel = append(topLevel, copyBytes(key))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range topLevel {
+		bucket := tx.ReadWriteBucket(key)
+		if bucket == nil {
+			return fmt.Errorf("top-level bucket %x not found", key)
+		}
+
+		err := reencryptBucket(
+			bucket, bucketPath(nil, key), oldEnc, newEnc,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reencryptBucket recursively re-encrypts all values of the given bucket that
+// is located at the given path.
+func reencryptBucket(bucket RwBucket, path []byte, oldEnc,
+	newEnc ValueEncrypter) error {
+
+	// The bucket must not be modified while iterating over it, so we
+	// collect its content first.
+	var keys, values, nested [][]byte
+	err := bucket.ForEach(func(k, v []byte) error {
+		if v == nil {
+			nested = append(nested, copyBytes(k))
+			return nil
+		}
+
+		keys = append(keys, copyBytes(k))
+		values = append(values, copyBytes(v))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, key := range keys {
+		ad := valueAD(path, key)
+
+		value := values[i]
+		if oldEnc != nil {
+			value, err = decryptValue(oldEnc, value, ad)
+			if err != nil {
+				return fmt.Errorf("error decrypting key %x: %w",
+					key, err)
+			}
+		}
+
+		value, err = newEnc.Encrypt(value, ad)
+		if err != nil {
+			return err
+		}
+
+		if err := bucket.Put(key, value); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range nested {
+		nestedBucket := bucket.NestedReadWriteBucket(key)
+		if nestedBucket == nil {
+			return fmt.Errorf("nested bucket %x not found", key)
+		}
+
+		err := reencryptBucket(
+			nestedBucket, bucketPath(path, key), oldEnc, newEnc,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bucketPath returns the path of the bucket with the given key that is nested
+// in the bucket at the given parent path. Every key is length prefixed, so
+// different sequences of keys never result in the same path.
+func bucketPath(parent, key []byte) []byte {
+	path := make([]byte, len(parent), len(parent)+4+len(key))
+	copy(path, parent)
+
+	path = binary.BigEndian.AppendUint32(path, uint32(len(key)))
+
+	return append(path, key...)
+}
+
+// valueAD returns the associated data of the value with the given key in the
+// bucket at the given path. It binds the ciphertext to its location in the
+// database, so it can't be swapped with the value of another key.
+func valueAD(path, key []byte) []byte {
+	return bucketPath(path, key)
+}
+
+// decryptValue decrypts the given value. As a nil value denotes a nested
+// bucket, an empty plaintext is always returned as a non-nil slice.
+func decryptValue(enc ValueEncrypter, value, ad []byte) ([]byte, error) {
+	plaintext, err := enc.Decrypt(value, ad)
+	if err != nil {
+		return nil, err
+	}
+
+	if plaintext == nil {
+		plaintext = []byte{}
+	}
+
+	return plaintext, nil
+}
+
+// encryptedTx holds the state that is shared by all buckets and cursors of a
+// transaction of an encrypted database.
+type encryptedTx struct {
+	enc ValueEncrypter
+
+	// err is the first decryption error of the read methods that can't
+	// return an error themselves. It is returned when the transaction
+	// ends, so a value that can't be decrypted always fails the
+	// transaction.
+	err error
+}
+
+// decrypt decrypts the value of the given key in the bucket at the given path.
+// If the value can't be decrypted, the error is recorded on the transaction
+// and nil is returned.
+func (t *encryptedTx) decrypt(path, key, value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+
+	plaintext, err := decryptValue(t.enc, value, valueAD(path, key))
+	if err != nil {
+		if t.err == nil {
+			t.err = fmt.Errorf("error decrypting key %x: %w", key,
+				err)
+		}
+
+		return nil
+	}
+
+	return plaintext
+}
+
+// decryptForEach wraps the given ForEach callback of the bucket at the given
+// path so it is called with the decrypted values.
+func (t *encryptedTx) decryptForEach(path []byte,
+	f func(k, v []byte) error) func(k, v []byte) error {
+
+	return func(k, v []byte) error {
+		if v == nil {
+			return f(k, nil)
+		}
+
+		plaintext, err := decryptValue(t.enc, v, valueAD(path, k))
+		if err != nil {
+			return fmt.Errorf("error decrypting key %x: %w", k, err)
+		}
+
+		return f(k, plaintext)
+	}
+}
+
+// encryptedBackend is a database that encrypts all values it stores.
+type encryptedBackend struct {
+	Backend
+
+	enc ValueEncrypter
+}
+
+// BeginReadTx opens a database read transaction.
+func (e *encryptedBackend) BeginReadTx() (RTx, error) {
+	tx, err := e.Backend.BeginReadTx()
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptedRTx{RTx: tx, state: &encryptedTx{enc: e.enc}}, nil
+}
+
+// BeginReadWriteTx opens a database read+write transaction.
+func (e *encryptedBackend) BeginReadWriteTx() (RwTx, error) {
+	tx, err := e.Backend.BeginReadWriteTx()
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptedRwTx{RwTx: tx, state: &encryptedTx{enc: e.enc}}, nil
+}
+
+// View opens a database read transaction and executes the function f with
+// the transaction passed as a parameter. Any value that couldn't be decrypted
+// during the transaction causes an error to be returned.
+func (e *encryptedBackend) View(f func(tx RTx) error, reset func()) error {
+	return e.Backend.View(func(tx RTx) error {
+		state := &encryptedTx{enc: e.enc}
+		if err := f(&encryptedRTx{RTx: tx, state: state}); err != nil {
+			return err
+		}
+
+		return state.err
+	}, reset)
+}
+
+// Update opens a database read/write transaction and executes the function f
+// with the transaction passed as a parameter. Any value that couldn't be
+// decrypted during the transaction causes it to be rolled back and an error to
+// be returned.
+func (e *encryptedBackend) Update(f func(tx RwTx) error, reset func()) error {
+	return e.Backend.Update(func(tx RwTx) error {
+		state := &encryptedTx{enc: e.enc}
+		if err := f(&encryptedRwTx{RwTx: tx, state: state}); err != nil {
+			return err
+		}
+
+		return state.err
+	}, reset)
+}
+
+// forEachBucket iterates over all top-level buckets except the encryption
+// meta bucket.
+func forEachBucket(tx RTx, f func(key []byte) error) error {
+	return tx.ForEachBucket(func(key []byte) error {
+		if bytes.Equal(key, encryptionMetaBucket) {
+			return nil
+		}
+
+		return f(key)
+	})
+}
+
+// encryptedRTx is a read transaction of an encrypted database.
+type encryptedRTx struct {
+	RTx
+
+	state *encryptedTx
+}
+
+// ReadBucket opens the top-level bucket with the given key.
+func (t *encryptedRTx) ReadBucket(key []byte) RBucket {
+	bucket := t.RTx.ReadBucket(key)
+	if bucket == nil {
+		return nil
+	}
+
+	return &encryptedRBucket{
+		RBucket: bucket,
+		state:   t.state,
+		path:    bucketPath(nil, key),
+	}
+}
+
+// ForEachBucket iterates over all top-level buckets.
+func (t *encryptedRTx) ForEachBucket(f func(key []byte) error) error {
+	return forEachBucket(t.RTx, f)
+}
+
+// RootBucket returns the root bucket of the transaction, if the backend
+// supports it.
+func (t *encryptedRTx) RootBucket() RBucket {
+	bucket := RootBucket(t.RTx)
+	if bucket == nil {
+		return nil
+	}
+
+	return &encryptedRBucket{RBucket: bucket, state: t.state}
+}
+
+// Rollback closes the transaction. If a value couldn't be decrypted during the
+// transaction, the decryption error is returned.
+func (t *encryptedRTx) Rollback() error {
+	if err := t.RTx.Rollback(); err != nil {
+		return err
+	}
+
+	return t.state.err
+}
+
+// encryptedRwTx is a read-write transaction of an encrypted database.
+type encryptedRwTx struct {
+	RwTx
+
+	state *encryptedTx
+}
+
+// ReadBucket opens the top-level bucket with the given key.
+func (t *encryptedRwTx) ReadBucket(key []byte) RBucket {
+	bucket := t.RwTx.ReadBucket(key)
+	if bucket == nil {
+		return nil
+	}
+
+	return &encryptedRBucket{
+		RBucket: bucket,
+		state:   t.state,
+		path:    bucketPath(nil, key),
+	}
+}
+
+// ForEachBucket iterates over all top-level buckets.
+func (t *encryptedRwTx) ForEachBucket(f func(key []byte) error) error {
+	return forEachBucket(t.RwTx, f)
+}
+
+// RootBucket returns the root bucket of the transaction, if the backend
+// supports it.
+func (t *encryptedRwTx) RootBucket() RBucket {
+	bucket := RootBucket(t.RwTx)
+	if bucket == nil {
+		return nil
+	}
+
+	return &encryptedRBucket{RBucket: bucket, state: t.state}
+}
+
+// ReadWriteBucket opens the top-level bucket with the given key for writing.
+func (t *encryptedRwTx) ReadWriteBucket(key []byte) RwBucket {
+	bucket := t.RwTx.ReadWriteBucket(key)
+	if bucket == nil {
+		return nil
+	}
+
+	return &encryptedRwBucket{
+		RwBucket: bucket,
+		state:    t.state,
+		path:     bucketPath(nil, key),
+	}
+}
+
+// CreateTopLevelBucket creates the top-level bucket with the given key if it
+// doesn't exist yet.
+func (t *encryptedRwTx) CreateTopLevelBucket(key []byte) (RwBucket, error) {
+	bucket, err := t.RwTx.CreateTopLevelBucket(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptedRwBucket{
+		RwBucket: bucket,
+		state:    t.state,
+		path:     bucketPath(nil, key),
+	}, nil
+}
+
+// Commit commits the transaction. If a value couldn't be decrypted during the
+// transaction, it is rolled back instead and the decryption error is returned.
+func (t *encryptedRwTx) Commit() error {
+	if t.state.err != nil {
+		_ = t.RwTx.Rollback()
+		return t.state.err
+	}
+
+	return t.RwTx.Commit()
+}
+
+// encryptedRBucket is a read bucket of an encrypted database.
+type encryptedRBucket struct {
+	RBucket
+
+	state *encryptedTx
+
+	// path is the path of the bucket within the database that is used as
+	// the associated data of its values.
+	path []byte
+}
+
+// NestedReadBucket retrieves the nested bucket with the given key.
+func (b *encryptedRBucket) NestedReadBucket(key []byte) RBucket {
+	bucket := b.RBucket.NestedReadBucket(key)
+	if bucket == nil {
+		return nil
+	}
+
+	return &encryptedRBucket{
+		RBucket: bucket,
+		state:   b.state,
+		path:    bucketPath(b.path, key),
+	}
+}
+
+// ForEach calls the given function with every decrypted key/value pair of
+// the bucket.
+func (b *encryptedRBucket) ForEach(f func(k, v []byte) error) error {
+	return b.RBucket.ForEach(b.state.decryptForEach(b.path, f))
+}
+
+// ForAll is an optimized version of ForEach, if the backend supports it.
+func (b *encryptedRBucket) ForAll(f func(k, v []byte) error) error {
+	return ForAll(b.RBucket, b.state.decryptForEach(b.path, f))
+}
+
+// Prefetch attempts to prefetch all values under the given paths.
+func (b *encryptedRBucket) Prefetch(paths ...[]string) {
+	Prefetch(b.RBucket, paths...)
+}
+
+// Get returns the decrypted value of the given key.
+func (b *encryptedRBucket) Get(key []byte) []byte {
+	return b.state.decrypt(b.path, key, b.RBucket.Get(key))
+}
+
+// ReadCursor returns a cursor over the decrypted key/value pairs of the
+// bucket.
+func (b *encryptedRBucket) ReadCursor() RCursor {
+	return &encryptedRCursor{
+		RCursor: b.RBucket.ReadCursor(),
+		state:   b.state,
+		path:    b.path,
+	}
+}
+
+// Sequence returns the current sequence number of the bucket, if the backend
+// exposes it.
+func (b *encryptedRBucket) Sequence() uint64 {
+	return bucketSequence(b.RBucket)
+}
+
+// encryptedRwBucket is a read-write bucket of an encrypted database.
+type encryptedRwBucket struct {
+	RwBucket
+
+	state *encryptedTx
+
+	// path is the path of the bucket within the database that is used as
+	// the associated data of its values.
+	path []byte
+}
+
+// NestedReadBucket retrieves the nested bucket with the given key.
+func (b *encryptedRwBucket) NestedReadBucket(key []byte) RBucket {
+	bucket := b.RwBucket.NestedReadBucket(key)
+	if bucket == nil {
+		return nil
+	}
+
+	return &encryptedRBucket{
+		RBucket: bucket,
+		state:   b.state,
+		path:    bucketPath(b.path, key),
+	}
+}
+
+// ForEach calls the given function with every decrypted key/value pair of
+// the bucket.
+func (b *encryptedRwBucket) ForEach(f func(k, v []byte) error) error {
+	return b.RwBucket.ForEach(b.state.decryptForEach(b.path, f))
+}
+
+// ForAll is an optimized version of ForEach, if the backend supports it.
+func (b *encryptedRwBucket) ForAll(f func(k, v []byte) error) error {
+	return ForAll(b.RwBucket, b.state.decryptForEach(b.path, f))
+}
+
+// Prefetch attempts to prefetch all values under the given paths.
+func (b *encryptedRwBucket) Prefetch(paths ...[]string) {
+	Prefetch(b.RwBucket, paths...)
+}
+
+// Get returns the decrypted value of the given key.
+func (b *encryptedRwBucket) Get(key []byte) []byte {
+	return b.state.decrypt(b.path, key, b.RwBucket.Get(key))
+}
+
+// ReadCursor returns a cursor over the decrypted key/value pairs of the
+// bucket.
+func (b *encryptedRwBucket) ReadCursor() RCursor {
+	return &encryptedRCursor{
+		RCursor: b.RwBucket.ReadCursor(),
+		state:   b.state,
+		path:    b.path,
+	}
+}
+
+// NestedReadWriteBucket retrieves the nested bucket with the given key for
+// writing.
+func (b *encryptedRwBucket) NestedReadWriteBucket(key []byte) RwBucket {
+	bucket := b.RwBucket.NestedReadWriteBucket(key)
+	if bucket == nil {
+		return nil
+	}
+
+	return &encryptedRwBucket{
+		RwBucket: bucket,
+		state:    b.state,
+		path:     bucketPath(b.path, key),
+	}
+}
+
+// CreateBucket creates and returns a new nested bucket with the given key.
+func (b *encryptedRwBucket) CreateBucket(key []byte) (RwBucket, error) {
+	bucket, err := b.RwBucket.CreateBucket(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptedRwBucket{
+		RwBucket: bucket,
+		state:    b.state,
+		path:     bucketPath(b.path, key),
+	}, nil
+}
+
+// CreateBucketIfNotExists creates and returns a new nested bucket with the
+// given key if it doesn't exist yet.
+func (b *encryptedRwBucket) CreateBucketIfNotExists(key []byte) (RwBucket,
+	error) {
+
+	bucket, err := b.RwBucket.CreateBucketIfNotExists(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptedRwBucket{
+		RwBucket: bucket,
+		state:    b.state,
+		path:     bucketPath(b.path, key),
+	}, nil
+}
+
+// Put encrypts the given value and stores it under the given key.
+func (b *encryptedRwBucket) Put(key, value []byte) error {
+	ciphertext, err := b.state.enc.Encrypt(value, valueAD(b.path, key))
+	if err != nil {
+		return err
+	}
+
+	return b.RwBucket.Put(key, ciphertext)
+}
+
+// ReadWriteCursor returns a cursor over the decrypted key/value pairs of the
+// bucket that can also delete them.
+func (b *encryptedRwBucket) ReadWriteCursor() RwCursor {
+	return &encryptedRwCursor{
+		RwCursor: b.RwBucket.ReadWriteCursor(),
+		state:    b.state,
+		path:     b.path,
+	}
+}
+
+// Tx returns the transaction of the bucket.
+func (b *encryptedRwBucket) Tx() RwTx {
+	return &encryptedRwTx{RwTx: b.RwBucket.Tx(), state: b.state}
+}
+
+// encryptedRCursor is a cursor that returns decrypted values.
+type encryptedRCursor struct {
+	RCursor
+
+	state *encryptedTx
+	path  []byte
+}
+
+// First positions the cursor at the first key/value pair.
+func (c *encryptedRCursor) First() ([]byte, []byte) {
+	k, v := c.RCursor.First()
+	return k, c.state.decrypt(c.path, k, v)
+}
+
+// Last positions the cursor at the last key/value pair.
+func (c *encryptedRCursor) Last() ([]byte, []byte) {
+	k, v := c.RCursor.Last()
+	return k, c.state.decrypt(c.path, k, v)
+}
+
+// Next moves the cursor one key/value pair forward.
+func (c *encryptedRCursor) Next() ([]byte, []byte) {
+	k, v := c.RCursor.Next()
+	return k, c.state.decrypt(c.path, k, v)
+}
+
+// Prev moves the cursor one key/value pair backward.
+func (c *encryptedRCursor) Prev() ([]byte, []byte) {
+	k, v := c.RCursor.Prev()
+	return k, c.state.decrypt(c.path, k, v)
+}
+
+// Seek positions the cursor at the given key or the next one after it.
+func (c *encryptedRCursor) Seek(seek []byte) ([]byte, []byte) {
+	k, v := c.RCursor.Seek(seek)
+	return k, c.state.decrypt(c.path, k, v)
+}
+
+// encryptedRwCursor is a read-write cursor that returns decrypted values.
+type encryptedRwCursor struct {
+	RwCursor
+
+	state *encryptedTx
+	path  []byte
+}
+
+// First positions the cursor at the first key/value pair.
+func (c *encryptedRwCursor) First() ([]byte, []byte) {
+	k, v := c.RwCursor.First()
+	return k, c.state.decrypt(c.path, k, v)
+}
+
+// Last positions the cursor at the last key/value pair.
+func (c *encryptedRwCursor) Last() ([]byte, []byte) {
+	k, v := c.RwCursor.Last()
+	return k, c.state.decrypt(c.path, k, v)
+}
+
+// Next moves the cursor one key/value pair forward.
+func (c *encryptedRwCursor) Next() ([]byte, []byte) {
+	k, v := c.RwCursor.Next()
+	return k, c.state.decrypt(c.path, k, v)
+}
+
+// Prev moves the cursor one key/value pair backward.
+func (c *encryptedRwCursor) Prev() ([]byte, []byte) {
+	k, v := c.RwCursor.Prev()
+	return k, c.state.decrypt(c.path, k, v)
+}
+
+// Seek positions the cursor at the given key or the next one after it.
+func (c *encryptedRwCursor) Seek(seek []byte) ([]byte, []byte) {
+	k, v := c.RwCursor.Seek(seek)
+	return k, c.state.decrypt(c.path, k, v)
+}
@@ -0,0 +1,243 @@
+package kvdb
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testEncrypter is a ValueEncrypter that uses AES-GCM with a random key.
+type testEncrypter struct {
+	aead cipher.AEAD
+}
+
+// newTestEncrypter creates a new testEncrypter with a random key.
+func newTestEncrypter(t *testing.T) *testEncrypter {
+	var key [32]byte
+	_, err := rand.Read(key[:])
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(key[:])
+	require.NoError(t, err)
+
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	return &testEncrypter{aead: aead}
+}
+
+// Encrypt returns the ciphertext of the given value with the nonce prepended.
+func (e *testEncrypter) Encrypt(plaintext, ad []byte) ([]byte, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return e.aead.Seal(nonce, nonce, plaintext, ad), nil
+}
+
+// Decrypt returns the plaintext of the given encrypted value.
+func (e *testEncrypter) Decrypt(ciphertext, ad []byte) ([]byte, error) {
+	if len(ciphertext) < e.aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	nonce := ciphertext[:e.aead.NonceSize()]
+
+	return e.aead.Open(nil, nonce, ciphertext[len(nonce):], ad)
+}
+
+// requireMigrationSource asserts that the given database holds the data
+// written by populateMigrationSource.
+func requireMigrationSource(t *testing.T, db Backend) {
+	err := View(db, func(tx RTx) error {
+		var topLevel []string
+		err := tx.ForEachBucket(func(key []byte) error {
+			topLevel = append(topLevel, string(key))
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"apple", "durian"}, topLevel)
+
+		apple := tx.ReadBucket([]byte("apple"))
+		require.Equal(t, []byte("val"), apple.Get([]byte("key")))
+		require.Equal(t, []byte{}, apple.Get([]byte("empty")))
+
+		banana := apple.NestedReadBucket([]byte("banana"))
+		require.NotNil(t, banana.NestedReadBucket([]byte("cherry")))
+
+		var numKeys int
+		err = banana.ForEach(func(k, v []byte) error {
+			if v == nil {
+				return nil
+			}
+
+			require.Equal(t, []byte{k[0], k[0]}, v)
+			numKeys++
+
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 10, numKeys)
+
+		k, v := banana.ReadCursor().Seek([]byte{5})
+		require.Equal(t, []byte{5}, k)
+		require.Equal(t, []byte{5, 5}, v)
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+}
+
+// TestEncryptedBackend tests that existing values are encrypted when a
+// database is opened as encrypted, that new values are stored encrypted and
+// that the database can't be opened with a different key.
+func TestEncryptedBackend(t *testing.T) {
+	t.Parallel()
+
+	db := NewBoltFixture(t).NewBackend()
+	populateMigrationSource(t, db)
+
+	enc := newTestEncrypter(t)
+	encDB, err := OpenEncryptedBackend(db, enc)
+	require.NoError(t, err)
+	requireMigrationSource(t, encDB)
+
+	err = Update(encDB, func(tx RwTx) error {
+		return tx.ReadWriteBucket([]byte("apple")).Put(
+			[]byte("new"), []byte("secret"),
+		)
+	}, func() {})
+	require.NoError(t, err)
+
+	// Reading the raw database should only reveal ciphertexts.
+	err = View(db, func(tx RTx) error {
+		apple := tx.ReadBucket([]byte("apple"))
+		require.NotEqual(t, []byte("val"), apple.Get([]byte("key")))
+		require.NotEqual(t, []byte("secret"), apple.Get([]byte("new")))
+
+		plaintext, err := enc.Decrypt(
+			apple.Get([]byte("new")),
+			valueAD(bucketPath(nil, []byte("apple")), []byte("new")),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []byte("secret"), plaintext)
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	// Opening the database again with the same key must not encrypt the
+	// values a second time.
+	encDB, err = OpenEncryptedBackend(db, enc)
+	require.NoError(t, err)
+	requireMigrationSource(t, encDB)
+
+	_, err = OpenEncryptedBackend(db, newTestEncrypter(t))
+	require.ErrorIs(t, err, ErrWrongEncryptionKey)
+}
+
+// TestRotateEncryptionKey tests that all values are re-encrypted with the new
+// key and that a rotation can be repeated safely.
+func TestRotateEncryptionKey(t *testing.T) {
+	t.Parallel()
+
+	db := NewBoltFixture(t).NewBackend()
+	populateMigrationSource(t, db)
+
+	oldEnc := newTestEncrypter(t)
+	newEnc := newTestEncrypter(t)
+
+	require.ErrorIs(
+		t, RotateEncryptionKey(db, oldEnc, newEnc), ErrNotEncrypted,
+	)
+
+	_, err := OpenEncryptedBackend(db, oldEnc)
+	require.NoError(t, err)
+
+	require.ErrorIs(
+		t, RotateEncryptionKey(db, newTestEncrypter(t), newEnc),
+		ErrWrongEncryptionKey,
+	)
+
+	require.NoError(t, RotateEncryptionKey(db, oldEnc, newEnc))
+	require.NoError(t, RotateEncryptionKey(db, oldEnc, newEnc))
+
+	_, err = OpenEncryptedBackend(db, oldEnc)
+	require.ErrorIs(t, err, ErrWrongEncryptionKey)
+
+	encDB, err := OpenEncryptedBackend(db, newEnc)
+	require.NoError(t, err)
+	requireMigrationSource(t, encDB)
+}
+
+// TestEncryptedValueLocation tests that an encrypted value can't be read once
+// it is moved to another key and that the decryption error fails the
+// transaction instead of being ignored.
+func TestEncryptedValueLocation(t *testing.T) {
+	t.Parallel()
+
+	db := NewBoltFixture(t).NewBackend()
+	populateMigrationSource(t, db)
+
+	encDB, err := OpenEncryptedBackend(db, newTestEncrypter(t))
+	require.NoError(t, err)
+
+	// Copy the ciphertext of one key to another key of a different
+	// bucket in the raw database.
+	err = Update(db, func(tx RwTx) error {
+		apple := tx.ReadWriteBucket([]byte("apple"))
+		banana := apple.NestedReadWriteBucket([]byte("banana"))
+
+		return banana.Put([]byte{1}, apple.Get([]byte("key")))
+	}, func() {})
+	require.NoError(t, err)
+
+	// Reading the moved value must fail the transaction even though Get
+	// itself can't return an error.
+	err = View(encDB, func(tx RTx) error {
+		banana := tx.ReadBucket([]byte("apple")).NestedReadBucket(
+			[]byte("banana"),
+		)
+		require.Nil(t, banana.Get([]byte{1}))
+
+		return nil
+	}, func() {})
+	require.ErrorContains(t, err, "error decrypting key 01")
+
+	// An update that reads the value must not be committed.
+	err = Update(encDB, func(tx RwTx) error {
+		apple := tx.ReadWriteBucket([]byte("apple"))
+		banana := apple.NestedReadWriteBucket([]byte("banana"))
+
+		k, _ := banana.ReadCursor().Seek([]byte{1})
+		require.Equal(t, []byte{1}, k)
+
+		return apple.Put([]byte("key"), []byte("changed"))
+	}, func() {})
+	require.ErrorContains(t, err, "error decrypting key 01")
+
+	err = View(encDB, func(tx RTx) error {
+		apple := tx.ReadBucket([]byte("apple"))
+		require.Equal(t, []byte("val"), apple.Get([]byte("key")))
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	// Iterating over the bucket returns the error directly.
+	err = View(encDB, func(tx RTx) error {
+		banana := tx.ReadBucket([]byte("apple")).NestedReadBucket(
+			[]byte("banana"),
+		)
+
+		return banana.ForEach(func(k, v []byte) error {
+			return nil
+		})
+	}, func() {})
+	require.ErrorContains(t, err, "error decrypting key 01")
+}
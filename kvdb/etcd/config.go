@@ -1,6 +1,15 @@
 package etcd
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultLeaderRetryTimeout is the default time we retry requests that
+	// were rejected because the etcd cluster is electing a new leader.
+	DefaultLeaderRetryTimeout = 10 * time.Second
+)
 
 // Config holds etcd configuration alongside with configuration related to our higher level interface.
 //
@@ -34,6 +43,8 @@ type Config struct {
 
 	MaxMsgSize int `long:"max_msg_size" description:"The maximum message size in bytes that we may send to etcd."`
 
+	LeaderRetryTimeout time.Duration `long:"leader_retry_timeout" description:"The maximum time a transaction is retried if the etcd cluster rejects it while electing a new leader. Set to zero to fail such transactions immediately."`
+
 	// SingleWriter should be set to true if we intend to only allow a
 	// single writer to the database at a time.
 	SingleWriter bool
@@ -64,6 +75,7 @@ func (c *Config) CloneWithSubNamespace(subNamespace string) *Config {
 		InsecureSkipVerify: c.InsecureSkipVerify,
 		CollectStats:       c.CollectStats,
 		MaxMsgSize:         c.MaxMsgSize,
+		LeaderRetryTimeout: c.LeaderRetryTimeout,
 		SingleWriter:       c.SingleWriter,
 	}
 }
@@ -85,6 +97,7 @@ func (c *Config) CloneWithSingleWriter() *Config {
 		InsecureSkipVerify: c.InsecureSkipVerify,
 		CollectStats:       c.CollectStats,
 		MaxMsgSize:         c.MaxMsgSize,
+		LeaderRetryTimeout: c.LeaderRetryTimeout,
 		SingleWriter:       true,
 	}
 }
@@ -203,6 +203,7 @@ func newEtcdBackend(ctx context.Context, cfg Config) (*db, error) {
 func (db *db) getSTMOptions() []STMOptionFunc {
 	opts := []STMOptionFunc{
 		WithAbortContext(db.ctx),
+		WithLeaderRetryTimeout(db.cfg.LeaderRetryTimeout),
 	}
 
 	if db.cfg.CollectStats {
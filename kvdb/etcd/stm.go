@@ -5,15 +5,30 @@ package etcd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/google/btree"
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	v3 "go.etcd.io/etcd/client/v3"
 )
 
+const (
+	// leaderRetryBackoff is the initial time we wait before retrying a
+	// request that was rejected because the cluster is electing a new
+	// leader. The backoff is doubled after each attempt.
+	leaderRetryBackoff = 50 * time.Millisecond
+
+	// maxLeaderRetryBackoff is the maximum time we wait between two
+	// attempts of a request that was rejected because the cluster is
+	// electing a new leader.
+	maxLeaderRetryBackoff = time.Second
+)
+
 type CommitStats struct {
 	Rset    int
 	Wset    int
@@ -189,6 +204,10 @@ type STMOptions struct {
 	// ctx holds an externally provided abort context.
 	ctx                 context.Context
 	commitStatsCallback func(bool, CommitStats)
+
+	// leaderRetryTimeout is the maximum time we retry requests that were
+	// rejected because the cluster is electing a new leader.
+	leaderRetryTimeout time.Duration
 }
 
 // STMOptionFunc is a function that updates the passed STMOptions.
@@ -208,6 +227,66 @@ func WithCommitStatsCallback(cb func(bool, CommitStats)) STMOptionFunc {
 	}
 }
 
+// WithLeaderRetryTimeout specifies for how long reads and commits that were
+// rejected because the cluster is electing a new leader are retried before
+// the error is returned.
+func WithLeaderRetryTimeout(timeout time.Duration) STMOptionFunc {
+	return func(so *STMOptions) {
+		so.leaderRetryTimeout = timeout
+	}
+}
+
+// isLeaderElectionError returns true if the given error means that the
+// cluster rejected the request because it currently has no leader or the
+// leader changed while the request was processed. Reads failing with such an
+// error can safely be sent again.
+func isLeaderElectionError(err error) bool {
+	return errors.Is(err, rpctypes.ErrNoLeader) ||
+		errors.Is(err, rpctypes.ErrLeaderChanged)
+}
+
+// isUncommittedError returns true if the given error means that the cluster
+// rejected a transaction before proposing it, so that it was never applied
+// and can safely be sent again. A transaction that fails because the leader
+// changed may have been applied by the new leader nevertheless, so retrying it
+// could apply its writes twice.
+func isUncommittedError(err error) bool {
+	return errors.Is(err, rpctypes.ErrNoLeader)
+}
+
+// retryLeaderElection calls the given request function and retries it with
+// an exponential backoff for as long as it fails with an error the retryable
+// function accepts, the leader retry timeout didn't expire and the STM isn't
+// aborted.
+func (s *stm) retryLeaderElection(retryable func(error) bool,
+	request func() error) error {
+
+	var (
+		deadline = time.Now().Add(s.options.leaderRetryTimeout)
+		backoff  = leaderRetryBackoff
+	)
+
+	for {
+		err := request()
+		if err == nil || !retryable(err) ||
+			time.Now().Add(backoff).After(deadline) {
+
+			return err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-s.options.ctx.Done():
+			return err
+		}
+
+		backoff *= 2
+		if backoff > maxLeaderRetryBackoff {
+			backoff = maxLeaderRetryBackoff
+		}
+	}
+}
+
 // RunSTM runs the apply function by creating an STM using serializable snapshot
 // isolation, passing it to the apply and handling commit errors and retries.
 func RunSTM(cli *v3.Client, apply func(STM) error, txQueue *commitQueue,
@@ -644,10 +723,17 @@ func (s *stm) FetchRangePaginatedRaw(prefix string, limit int64,
 // then fetch will try to fix the STM's snapshot revision (if not already set).
 // We'll also cache the returned key/value in the read set.
 func (s *stm) fetch(key string, opts ...v3.OpOption) ([]KV, error) {
-	s.callCount++
-	resp, err := s.client.Get(
-		s.options.ctx, key, append(opts, s.getOpts...)...,
-	)
+	var resp *v3.GetResponse
+	err := s.retryLeaderElection(isLeaderElectionError, func() error {
+		s.callCount++
+
+		var err error
+		resp, err = s.client.Get(
+			s.options.ctx, key, append(opts, s.getOpts...)...,
+		)
+
+		return err
+	})
 	if err != nil {
 		return nil, DatabaseError{
 			msg: "stm.fetch() failed",
@@ -1103,19 +1189,32 @@ func (s *stm) commit() (CommitStats, error) {
 
 	// Create the compare set.
 	cmps := append(rset, wset...)
-	// Create a transaction with the optional abort context.
-	txn := s.client.Txn(s.options.ctx)
 
-	// If the compare set holds, try executing the puts.
-	txn = txn.If(cmps...)
-	txn = txn.Then(s.wset.puts()...)
+	// A commit that is rejected because the cluster has no leader was
+	// never applied, so we can send it again once a new leader is in place
+	// instead of failing the whole transaction. If the leader changed
+	// while the commit was processed, we don't know whether it was applied
+	// and return the error instead.
+	var txnresp *v3.TxnResponse
+	err := s.retryLeaderElection(isUncommittedError, func() error {
+		// Create a transaction with the optional abort context.
+		txn := s.client.Txn(s.options.ctx)
 
-	// Prefetch keys and ranges in case of conflict to save as many
-	// round-trips as possible.
-	txn = txn.Else(s.rset.prefetchSet()...)
+		// If the compare set holds, try executing the puts.
+		txn = txn.If(cmps...)
+		txn = txn.Then(s.wset.puts()...)
 
-	s.callCount++
-	txnresp, err := txn.Commit()
+		// Prefetch keys and ranges in case of conflict to save as many
+		// round-trips as possible.
+		txn = txn.Else(s.rset.prefetchSet()...)
+
+		s.callCount++
+
+		var err error
+		txnresp, err = txn.Commit()
+
+		return err
+	})
 	if err != nil {
 		return stats, DatabaseError{
 			msg: "stm.Commit() failed",
@@ -7,8 +7,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 )
 
 func reverseKVs(a []KV) []KV {
@@ -410,3 +412,100 @@ func TestManualTxError(t *testing.T) {
 	// We expect that the transacton indeed did not commit.
 	require.Equal(t, "def", f.Get("123"))
 }
+
+// TestRetryLeaderElection tests that requests rejected during a leader
+// election are retried until the leader retry timeout expires, while all other
+// errors are returned immediately. Commits are only retried if they were
+// rejected before being proposed.
+func TestRetryLeaderElection(t *testing.T) {
+	t.Parallel()
+
+	errOther := errors.New("other error")
+
+	testCases := []struct {
+		name          string
+		timeout       time.Duration
+		retryable     func(error) bool
+		errs          []error
+		expectedErr   error
+		expectedCalls int
+	}{{
+		name:          "success",
+		timeout:       time.Second,
+		retryable:     isLeaderElectionError,
+		errs:          []error{nil},
+		expectedCalls: 1,
+	}, {
+		name:      "retry until leader elected",
+		timeout:   time.Second,
+		retryable: isLeaderElectionError,
+		errs: []error{
+			rpctypes.ErrNoLeader, rpctypes.ErrLeaderChanged, nil,
+		},
+		expectedCalls: 3,
+	}, {
+		name:          "other error",
+		timeout:       time.Second,
+		retryable:     isLeaderElectionError,
+		errs:          []error{errOther, nil},
+		expectedErr:   errOther,
+		expectedCalls: 1,
+	}, {
+		name:          "retries disabled",
+		retryable:     isLeaderElectionError,
+		errs:          []error{rpctypes.ErrNoLeader, nil},
+		expectedErr:   rpctypes.ErrNoLeader,
+		expectedCalls: 1,
+	}, {
+		name:      "timeout expired",
+		timeout:   2 * leaderRetryBackoff,
+		retryable: isLeaderElectionError,
+		errs: []error{
+			rpctypes.ErrNoLeader, rpctypes.ErrNoLeader,
+			rpctypes.ErrNoLeader, nil,
+		},
+		expectedErr:   rpctypes.ErrNoLeader,
+		expectedCalls: 2,
+	}, {
+		name:      "commit retried without leader",
+		timeout:   time.Second,
+		retryable: isUncommittedError,
+		errs: []error{
+			rpctypes.ErrNoLeader, rpctypes.ErrNoLeader, nil,
+		},
+		expectedCalls: 3,
+	}, {
+		name:      "commit not retried after leader change",
+		timeout:   time.Second,
+		retryable: isUncommittedError,
+		errs: []error{
+			rpctypes.ErrNoLeader, rpctypes.ErrLeaderChanged, nil,
+		},
+		expectedErr:   rpctypes.ErrLeaderChanged,
+		expectedCalls: 2,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &stm{
+				options: &STMOptions{
+					ctx:                context.Background(),
+					leaderRetryTimeout: tc.timeout,
+				},
+			}
+
+			var calls int
+			err := s.retryLeaderElection(tc.retryable, func() error {
+				err := tc.errs[calls]
+				calls++
+
+				return err
+			})
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package kvdb
 
 import (
+	"context"
+
 	"github.com/ltcsuite/ltcwallet/walletdb"
 )
 
@@ -13,7 +15,7 @@ import (
 // database backend used), the reset function will be called before each retry
 // respectively.
 func Update(db Backend, f func(tx RwTx) error, reset func()) error {
-	return db.Update(f, reset)
+	return UpdateCtx(context.Background(), db, f, reset)
 }
 
 // View opens a database read transaction and executes the function f with the
@@ -24,7 +26,7 @@ func Update(db Backend, f func(tx RwTx) error, reset func()) error {
 // expect retries of the f closure (depending on the database backend used), the
 // reset function will be called before each retry respectively.
 func View(db Backend, f func(tx RTx) error, reset func()) error {
-	return db.View(f, reset)
+	return ViewCtx(context.Background(), db, f, reset)
 }
 
 // Batch is identical to the Update call, but it attempts to combine several
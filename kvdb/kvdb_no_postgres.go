@@ -4,6 +4,7 @@
 package kvdb
 
 import (
+	"context"
 	"errors"
 
 	"github.com/ltcsuite/lnd/kvdb/postgres"
@@ -18,3 +19,13 @@ func NewPostgresFixture(dbName string) (postgres.Fixture, error) {
 func StartEmbeddedPostgres() (func() error, error) {
 	return nil, errors.New("postgres backend not available")
 }
+
+func ListPostgresPrefixes(ctx context.Context,
+	cfg *postgres.Config) ([]string, error) {
+
+	return nil, errors.New("postgres backend not available")
+}
+
+func acquireTestPostgres() (func(), error) {
+	return nil, errors.New("postgres backend not available")
+}
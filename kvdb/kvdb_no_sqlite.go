@@ -3,9 +3,11 @@
 package kvdb
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 
+	"github.com/ltcsuite/lnd/kvdb/sqlite"
 	"github.com/ltcsuite/ltcwallet/walletdb"
 )
 
@@ -23,3 +25,10 @@ const SqliteBackend = false
 func StartSqliteTestBackend(path, name, table string) (walletdb.DB, error) {
 	return nil, errSqliteNotAvailable
 }
+
+// ListSqlitePrefixes is a stub returning nil, and errSqliteNotAvailable error.
+func ListSqlitePrefixes(ctx context.Context, cfg *sqlite.Config, dbPath,
+	fileName string) ([]string, error) {
+
+	return nil, errSqliteNotAvailable
+}
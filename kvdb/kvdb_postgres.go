@@ -3,7 +3,13 @@
 
 package kvdb
 
-import "github.com/ltcsuite/lnd/kvdb/postgres"
+import (
+	"context"
+	"sync"
+
+	"github.com/ltcsuite/lnd/kvdb/postgres"
+	"github.com/ltcsuite/lnd/kvdb/sqlbase"
+)
 
 const PostgresBackend = true
 
@@ -14,3 +20,53 @@ func NewPostgresFixture(dbName string) (postgres.Fixture, error) {
 func StartEmbeddedPostgres() (func() error, error) {
 	return postgres.StartEmbeddedPostgres()
 }
+
+// ListPostgresPrefixes returns the prefixes of all logical databases that are
+// stored in the postgres database of the given config.
+func ListPostgresPrefixes(ctx context.Context,
+	cfg *postgres.Config) ([]string, error) {
+
+	sqlbase.Init(cfg.MaxConnections)
+
+	return postgres.ListPrefixes(ctx, cfg)
+}
+
+var (
+	// testPostgresMtx guards the reference count of the embedded postgres
+	// instance used by NewTestBackend.
+	testPostgresMtx sync.Mutex
+
+	// testPostgresRefs is the number of tests currently using the
+	// embedded postgres instance.
+	testPostgresRefs int
+
+	// testPostgresStop stops the embedded postgres instance.
+	testPostgresStop func() error
+)
+
+// acquireTestPostgres makes sure the embedded postgres instance is running and
+// returns a closure that stops it again once all users released it.
+func acquireTestPostgres() (func(), error) {
+	testPostgresMtx.Lock()
+	defer testPostgresMtx.Unlock()
+
+	if testPostgresRefs == 0 {
+		stop, err := postgres.StartEmbeddedPostgres()
+		if err != nil {
+			return nil, err
+		}
+
+		testPostgresStop = stop
+	}
+	testPostgresRefs++
+
+	return func() {
+		testPostgresMtx.Lock()
+		defer testPostgresMtx.Unlock()
+
+		testPostgresRefs--
+		if testPostgresRefs == 0 {
+			_ = testPostgresStop()
+		}
+	}, nil
+}
@@ -37,3 +37,13 @@ func StartSqliteTestBackend(path, name, table string) (walletdb.DB, error) {
 		}, path, name, table,
 	)
 }
+
+// ListSqlitePrefixes returns the prefixes of all logical databases that are
+// stored in the given sqlite database file.
+func ListSqlitePrefixes(ctx context.Context, cfg *sqlite.Config, dbPath,
+	fileName string) ([]string, error) {
+
+	sqlbase.Init(cfg.MaxConnections)
+
+	return sqlite.ListPrefixes(ctx, cfg, dbPath, fileName)
+}
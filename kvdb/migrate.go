@@ -0,0 +1,407 @@
+package kvdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	// DefaultMigrationChunkSize is the default number of keys and buckets
+	// that are copied to the destination database in a single transaction.
+	DefaultMigrationChunkSize = 1000
+)
+
+var (
+	// migrationProgressBucket is the top-level bucket in the destination
+	// database that holds the progress of an ongoing migration. It is
+	// written in the same transaction as each chunk, so an interrupted
+	// migration can always be resumed from the last committed chunk. The
+	// bucket is removed once the migration was verified.
+	migrationProgressBucket = []byte("kvdb-migration-progress")
+
+	// migrationNumCopiedKey is the key in the progress bucket that holds
+	// the number of items that were already copied.
+	migrationNumCopiedKey = []byte("num-copied")
+
+	// ErrMigrationMismatch is returned if the verification pass finds a
+	// difference between the source and the destination database.
+	ErrMigrationMismatch = errors.New("migrated data does not match source")
+)
+
+// migrationItem is a single key/value pair or bucket of the source database
+// that is copied to the destination.
+type migrationItem struct {
+	// path is the list of buckets the item is nested in, starting with
+	// the top-level bucket. This is empty for top-level buckets.
+	path [][]byte
+
+	key   []byte
+	value []byte
+
+	// isBucket is true if the item is a bucket, in which case value is
+	// nil and sequence is the sequence number of the bucket.
+	isBucket bool
+	sequence uint64
+}
+
+// MigrationProgress is called after each chunk that was copied with the total
+// number of items (keys and buckets) copied so far.
+type MigrationProgress func(numCopied uint64)
+
+// MigrateBackend copies all buckets and keys of the source database to the
+// destination database in chunks of chunkSize items per transaction, then
+// verifies that both databases hold the same data. The source database must
+// not be modified while the migration is running. If a previous migration to
+// the same destination was interrupted, it is resumed after the last chunk
+// that was copied.
+func MigrateBackend(src, dst Backend, chunkSize int,
+	progress MigrationProgress) error {
+
+	if chunkSize <= 0 {
+		chunkSize = DefaultMigrationChunkSize
+	}
+
+	numCopied, err := migrationNumCopied(dst)
+	if err != nil {
+		return err
+	}
+	if numCopied > 0 {
+		log.Infof("Resuming migration after %d copied items", numCopied)
+	}
+
+	var (
+		numVisited uint64
+		chunk      []*migrationItem
+	)
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+
+		err := writeMigrationChunk(dst, chunk, numVisited)
+		if err != nil {
+			return err
+		}
+		chunk = chunk[:0]
+
+		if progress != nil {
+			progress(numVisited)
+		}
+
+		return nil
+	}
+
+	err = walkBackend(src, func(item *migrationItem) error {
+		// Skip everything that was already copied in a previous run.
+		numVisited++
+		if numVisited <= numCopied {
+			return nil
+		}
+
+		chunk = append(chunk, item)
+		if len(chunk) < chunkSize {
+			return nil
+		}
+
+		return flush()
+	})
+	if err != nil {
+		return fmt.Errorf("error copying data: %w", err)
+	}
+	if err := flush(); err != nil {
+		return fmt.Errorf("error copying data: %w", err)
+	}
+
+	log.Infof("Copied %d items, verifying migrated data", numVisited)
+
+	if err := VerifyMigration(src, dst); err != nil {
+		return err
+	}
+
+	// Everything was copied correctly, so we can remove the progress
+	// marker now.
+	return Update(dst, func(tx RwTx) error {
+		return tx.DeleteTopLevelBucket(migrationProgressBucket)
+	}, func() {})
+}
+
+// migrationNumCopied returns the number of items a previous, interrupted
+// migration to the given database already copied.
+func migrationNumCopied(dst Backend) (uint64, error) {
+	var numCopied uint64
+	err := View(dst, func(tx RTx) error {
+		bucket := tx.ReadBucket(migrationProgressBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		value := bucket.Get(migrationNumCopiedKey)
+		if len(value) != 8 {
+			return fmt.Errorf("invalid migration progress")
+		}
+		numCopied = binary.BigEndian.Uint64(value)
+
+		return nil
+	}, func() {
+		numCopied = 0
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error reading migration progress: %w",
+			err)
+	}
+
+	return numCopied, nil
+}
+
+// writeMigrationChunk writes the given items to the destination database
+// together with the new number of copied items in a single transaction.
+func writeMigrationChunk(dst Backend, chunk []*migrationItem,
+	numCopied uint64) error {
+
+	return Update(dst, func(tx RwTx) error {
+		for _, item := range chunk {
+			if err := writeMigrationItem(tx, item); err != nil {
+				return err
+			}
+		}
+
+		progress, err := tx.CreateTopLevelBucket(migrationProgressBucket)
+		if err != nil {
+			return err
+		}
+
+		var value [8]byte
+		binary.BigEndian.PutUint64(value[:], numCopied)
+
+		return progress.Put(migrationNumCopiedKey, value[:])
+	}, func() {})
+}
+
+// writeMigrationItem writes a single item to the destination database. As a
+// chunk might have been written before without its progress being recorded,
+// existing buckets are tolerated.
+func writeMigrationItem(tx RwTx, item *migrationItem) error {
+	if len(item.path) == 0 {
+		if !item.isBucket {
+			return fmt.Errorf("unexpected top-level key %x", item.key)
+		}
+
+		bucket, err := tx.CreateTopLevelBucket(item.key)
+		if err != nil {
+			return err
+		}
+
+		return bucket.SetSequence(item.sequence)
+	}
+
+	bucket := tx.ReadWriteBucket(item.path[0])
+	for _, name := range item.path[1:] {
+		if bucket == nil {
+			break
+		}
+		bucket = bucket.NestedReadWriteBucket(name)
+	}
+	if bucket == nil {
+		return fmt.Errorf("parent bucket of %x not found", item.key)
+	}
+
+	if !item.isBucket {
+		return bucket.Put(item.key, item.value)
+	}
+
+	nested, err := bucket.CreateBucketIfNotExists(item.key)
+	if err != nil {
+		return err
+	}
+
+	return nested.SetSequence(item.sequence)
+}
+
+// walkBackend calls the given callback for every bucket and key of the
+// database in a deterministic order, with each bucket being visited before
+// its content.
+func walkBackend(db Backend, cb func(item *migrationItem) error) error {
+	return View(db, func(tx RTx) error {
+		return tx.ForEachBucket(func(key []byte) error {
+			bucket := tx.ReadBucket(key)
+			if bucket == nil {
+				return fmt.Errorf("top-level bucket %x not found",
+					key)
+			}
+
+			err := cb(&migrationItem{
+				key:      copyBytes(key),
+				isBucket: true,
+				sequence: bucketSequence(bucket),
+			})
+			if err != nil {
+				return err
+			}
+
+			return walkBucket(bucket, [][]byte{copyBytes(key)}, cb)
+		})
+	}, func() {})
+}
+
+// walkBucket recursively calls the given callback for every nested bucket and
+// key of the given bucket.
+func walkBucket(bucket RBucket, path [][]byte,
+	cb func(item *migrationItem) error) error {
+
+	return bucket.ForEach(func(k, v []byte) error {
+		item := &migrationItem{
+			path: path,
+			key:  copyBytes(k),
+		}
+
+		if v != nil {
+			item.value = copyBytes(v)
+			return cb(item)
+		}
+
+		nested := bucket.NestedReadBucket(k)
+		if nested == nil {
+			return fmt.Errorf("nested bucket %x not found", k)
+		}
+
+		item.isBucket = true
+		item.sequence = bucketSequence(nested)
+		if err := cb(item); err != nil {
+			return err
+		}
+
+		nestedPath := make([][]byte, len(path), len(path)+1)
+		copy(nestedPath, path)
+		nestedPath = append(nestedPath, item.key)
+
+		return walkBucket(nested, nestedPath, cb)
+	})
+}
+
+// bucketSequence returns the sequence number of the given bucket, if the
+// backend exposes it for read buckets.
+func bucketSequence(bucket RBucket) uint64 {
+	if seqBucket, ok := bucket.(interface{ Sequence() uint64 }); ok {
+		return seqBucket.Sequence()
+	}
+
+	return 0
+}
+
+// copyBytes returns a copy of the given byte slice, as keys and values are
+// only valid for the lifetime of the transaction they were read in.
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	c := make([]byte, len(b))
+	copy(c, b)
+
+	return c
+}
+
+// VerifyMigration checks that the destination database holds exactly the same
+// buckets, keys and values as the source database. The progress bucket of an
+// ongoing migration is ignored.
+func VerifyMigration(src, dst Backend) error {
+	return View(src, func(srcTx RTx) error {
+		return View(dst, func(dstTx RTx) error {
+			return verifyTopLevel(srcTx, dstTx)
+		}, func() {})
+	}, func() {})
+}
+
+// verifyTopLevel compares the top-level buckets of the two transactions.
+func verifyTopLevel(srcTx, dstTx RTx) error {
+	var numSrc int
+	err := srcTx.ForEachBucket(func(key []byte) error {
+		numSrc++
+
+		dstBucket := dstTx.ReadBucket(key)
+		if dstBucket == nil {
+			return fmt.Errorf("%w: top-level bucket %x missing",
+				ErrMigrationMismatch, key)
+		}
+
+		return verifyBucket(srcTx.ReadBucket(key), dstBucket, [][]byte{key})
+	})
+	if err != nil {
+		return err
+	}
+
+	var numDst int
+	err = dstTx.ForEachBucket(func(key []byte) error {
+		if !bytes.Equal(key, migrationProgressBucket) {
+			numDst++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if numSrc != numDst {
+		return fmt.Errorf("%w: expected %d top-level buckets, found %d",
+			ErrMigrationMismatch, numSrc, numDst)
+	}
+
+	return nil
+}
+
+// verifyBucket recursively compares the content of the two buckets.
+func verifyBucket(src, dst RBucket, path [][]byte) error {
+	if bucketSequence(src) != bucketSequence(dst) {
+		return fmt.Errorf("%w: sequence of bucket %x differs",
+			ErrMigrationMismatch, path)
+	}
+
+	var numSrc int
+	err := src.ForEach(func(k, v []byte) error {
+		numSrc++
+
+		if v != nil {
+			if !bytes.Equal(v, dst.Get(k)) {
+				return fmt.Errorf("%w: value of key %x in "+
+					"bucket %x differs", ErrMigrationMismatch,
+					k, path)
+			}
+
+			return nil
+		}
+
+		dstNested := dst.NestedReadBucket(k)
+		if dstNested == nil {
+			return fmt.Errorf("%w: bucket %x in bucket %x missing",
+				ErrMigrationMismatch, k, path)
+		}
+
+		nestedPath := make([][]byte, len(path), len(path)+1)
+		copy(nestedPath, path)
+		nestedPath = append(nestedPath, k)
+
+		return verifyBucket(src.NestedReadBucket(k), dstNested, nestedPath)
+	})
+	if err != nil {
+		return err
+	}
+
+	var numDst int
+	err = dst.ForEach(func(_, _ []byte) error {
+		numDst++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if numSrc != numDst {
+		return fmt.Errorf("%w: expected %d keys in bucket %x, found %d",
+			ErrMigrationMismatch, numSrc, path, numDst)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package kvdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// populateMigrationSource fills the given database with a few top-level
+// buckets, nested buckets, keys and sequence numbers.
+func populateMigrationSource(t *testing.T, db Backend) {
+	err := Update(db, func(tx RwTx) error {
+		apple, err := tx.CreateTopLevelBucket([]byte("apple"))
+		if err != nil {
+			return err
+		}
+		if err := apple.SetSequence(42); err != nil {
+			return err
+		}
+		if err := apple.Put([]byte("key"), []byte("val")); err != nil {
+			return err
+		}
+		if err := apple.Put([]byte("empty"), []byte{}); err != nil {
+			return err
+		}
+
+		banana, err := apple.CreateBucket([]byte("banana"))
+		if err != nil {
+			return err
+		}
+		if err := banana.SetSequence(7); err != nil {
+			return err
+		}
+		for i := byte(0); i < 10; i++ {
+			err := banana.Put([]byte{i}, []byte{i, i})
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = banana.CreateBucket([]byte("cherry"))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateTopLevelBucket([]byte("durian"))
+		return err
+	}, func() {})
+	require.NoError(t, err)
+}
+
+// TestMigrateBackend tests that all data is copied to the destination database
+// in chunks and that the progress marker is removed afterwards.
+func TestMigrateBackend(t *testing.T) {
+	t.Parallel()
+
+	src := NewBoltFixture(t).NewBackend()
+	dst := NewBoltFixture(t).NewBackend()
+	populateMigrationSource(t, src)
+
+	var progress []uint64
+	err := MigrateBackend(src, dst, 4, func(numCopied uint64) {
+		progress = append(progress, numCopied)
+	})
+	require.NoError(t, err)
+
+	// There are 16 items in total, which are copied in chunks of four.
+	require.Equal(t, []uint64{4, 8, 12, 16}, progress)
+
+	require.NoError(t, VerifyMigration(src, dst))
+
+	numCopied, err := migrationNumCopied(dst)
+	require.NoError(t, err)
+	require.Zero(t, numCopied)
+
+	err = View(dst, func(tx RTx) error {
+		require.Nil(t, tx.ReadBucket(migrationProgressBucket))
+
+		apple := tx.ReadBucket([]byte("apple"))
+		require.NotNil(t, apple)
+		require.EqualValues(t, 42, bucketSequence(apple))
+
+		banana := apple.NestedReadBucket([]byte("banana"))
+		require.NotNil(t, banana)
+		require.EqualValues(t, 7, bucketSequence(banana))
+		require.Equal(t, []byte{3, 3}, banana.Get([]byte{3}))
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+}
+
+// TestMigrateBackendResume tests that an interrupted migration is resumed
+// after the last chunk that was copied.
+func TestMigrateBackendResume(t *testing.T) {
+	t.Parallel()
+
+	src := NewBoltFixture(t).NewBackend()
+	dst := NewBoltFixture(t).NewBackend()
+	populateMigrationSource(t, src)
+
+	// Simulate a migration that was interrupted after copying the first
+	// five items.
+	var items []*migrationItem
+	err := walkBackend(src, func(item *migrationItem) error {
+		items = append(items, item)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, writeMigrationChunk(dst, items[:5], 5))
+
+	require.ErrorIs(t, VerifyMigration(src, dst), ErrMigrationMismatch)
+
+	var progress []uint64
+	err = MigrateBackend(src, dst, 100, func(numCopied uint64) {
+		progress = append(progress, numCopied)
+	})
+	require.NoError(t, err)
+
+	// Only a single chunk with the remaining items should have been
+	// written.
+	require.Equal(t, []uint64{16}, progress)
+	require.NoError(t, VerifyMigration(src, dst))
+}
+
+// TestVerifyMigration tests that the verification detects differences between
+// the source and destination database.
+func TestVerifyMigration(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		modify func(tx RwTx) error
+	}{{
+		name: "changed value",
+		modify: func(tx RwTx) error {
+			return tx.ReadWriteBucket([]byte("apple")).Put(
+				[]byte("key"), []byte("other"),
+			)
+		},
+	}, {
+		name: "extra key",
+		modify: func(tx RwTx) error {
+			return tx.ReadWriteBucket([]byte("apple")).Put(
+				[]byte("extra"), []byte("val"),
+			)
+		},
+	}, {
+		name: "missing bucket",
+		modify: func(tx RwTx) error {
+			return tx.ReadWriteBucket([]byte("apple")).
+				DeleteNestedBucket([]byte("banana"))
+		},
+	}, {
+		name: "extra top-level bucket",
+		modify: func(tx RwTx) error {
+			_, err := tx.CreateTopLevelBucket([]byte("extra"))
+			return err
+		},
+	}, {
+		name: "changed sequence",
+		modify: func(tx RwTx) error {
+			return tx.ReadWriteBucket([]byte("apple")).SetSequence(1)
+		},
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			src := NewBoltFixture(t).NewBackend()
+			dst := NewBoltFixture(t).NewBackend()
+			populateMigrationSource(t, src)
+
+			require.NoError(t, MigrateBackend(src, dst, 0, nil))
+			require.NoError(t, Update(dst, tc.modify, func() {}))
+
+			require.ErrorIs(
+				t, VerifyMigration(src, dst), ErrMigrationMismatch,
+			)
+		})
+	}
+}
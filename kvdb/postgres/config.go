@@ -1,12 +1,72 @@
 package postgres
 
-import "time"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// namespaceRegex matches the namespaces that can safely be used as part of an
+// unquoted table name.
+var namespaceRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
 
 // Config holds postgres configuration data.
 //
 //nolint:lll
 type Config struct {
-	Dsn            string        `long:"dsn" description:"Database connection string."`
-	Timeout        time.Duration `long:"timeout" description:"Database connection timeout. Set to zero to disable."`
-	MaxConnections int           `long:"maxconnections" description:"The maximum number of open connections to the database. Set to zero for unlimited."`
+	Dsn                string        `long:"dsn" description:"Database connection string."`
+	Timeout            time.Duration `long:"timeout" description:"Database connection timeout. Set to zero to disable."`
+	MaxConnections     int           `long:"maxconnections" description:"The maximum number of open connections to the database. Set to zero for unlimited."`
+	MaxIdleConnections int           `long:"maxidleconnections" description:"The maximum number of idle connections kept in the connection pool. Set to zero to use the default of 2."`
+	ConnMaxLifetime    time.Duration `long:"connmaxlifetime" description:"The maximum amount of time a connection may be reused. Set to zero to reuse connections forever."`
+	ConnMaxIdleTime    time.Duration `long:"connmaxidletime" description:"The maximum amount of time a connection may be idle before it is closed. Set to zero to keep idle connections open."`
+	StatementTimeout   time.Duration `long:"statementtimeout" description:"The maximum amount of time the server may spend on a single statement before aborting it. Set to zero to disable."`
+	ReplicaDsn         string        `long:"replicadsn" description:"Optional connection string of a read-only replica of the database. If set, expensive read-only queries are served by the replica."`
+	Namespace          string        `long:"namespace" description:"Optional namespace that is prepended to the names of all tables, which allows multiple lnd nodes to share one database. Must start with a lowercase letter and only contain lowercase letters, digits and underscores."`
+
+	// ReadOnly should be set to true if the database is a read-only
+	// replica.
+	ReadOnly bool
+}
+
+// ReplicaConfig returns a copy of the configuration that connects to the
+// read-only replica instead of the primary database.
+func (c *Config) ReplicaConfig() *Config {
+	replica := *c
+	replica.Dsn = c.ReplicaDsn
+	replica.ReplicaDsn = ""
+	replica.ReadOnly = true
+
+	return &replica
+}
+
+// TablePrefix returns the table name prefix of the logical database with the
+// given prefix, taking the configured namespace into account.
+func (c *Config) TablePrefix(prefix string) (string, error) {
+	if c.Namespace == "" {
+		return prefix, nil
+	}
+
+	if !namespaceRegex.MatchString(c.Namespace) {
+		return "", fmt.Errorf("invalid postgres namespace %q",
+			c.Namespace)
+	}
+
+	return fmt.Sprintf("%s_%s", c.Namespace, prefix), nil
+}
+
+// stripNamespace returns the given table name prefix without the configured
+// namespace. False is returned if the prefix isn't part of the namespace.
+func (c *Config) stripNamespace(tablePrefix string) (string, bool) {
+	if c.Namespace == "" {
+		return tablePrefix, true
+	}
+
+	prefix := strings.TrimPrefix(tablePrefix, c.Namespace+"_")
+	if prefix == tablePrefix || prefix == "" {
+		return "", false
+	}
+
+	return prefix, true
 }
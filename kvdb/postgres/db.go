@@ -4,6 +4,10 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/ltcsuite/lnd/kvdb/sqlbase"
 	"github.com/ltcsuite/ltcwallet/walletdb"
@@ -21,15 +25,90 @@ var sqliteCmdReplacements = sqlbase.SQLiteCmdReplacements{
 func newPostgresBackend(ctx context.Context, config *Config, prefix string) (
 	walletdb.DB, error) {
 
+	dsn, err := withStatementTimeout(config.Dsn, config.StatementTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	tablePrefix, err := config.TablePrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := &sqlbase.Config{
 		DriverName:            "pgx",
-		Dsn:                   config.Dsn,
+		Dsn:                   dsn,
 		Timeout:               config.Timeout,
 		Schema:                "public",
-		TableNamePrefix:       prefix,
+		TableNamePrefix:       tablePrefix,
 		SQLiteCmdReplacements: sqliteCmdReplacements,
 		WithTxLevelLock:       true,
+		MaxIdleConnections:    config.MaxIdleConnections,
+		ConnMaxLifetime:       config.ConnMaxLifetime,
+		ConnMaxIdleTime:       config.ConnMaxIdleTime,
+		ReadOnly:              config.ReadOnly,
 	}
 
 	return sqlbase.NewSqlBackend(ctx, cfg)
 }
+
+// ListPrefixes returns the prefixes of all logical databases that are stored
+// in the postgres database, for example "channeldb" or "macaroondb". If a
+// namespace is configured, only the databases in that namespace are returned,
+// with the namespace removed from their prefix. The sqlbase connection set
+// must be initialized before calling this function.
+func ListPrefixes(ctx context.Context, config *Config) ([]string, error) {
+	cfg := &sqlbase.Config{
+		DriverName: "pgx",
+		Dsn:        config.Dsn,
+		Timeout:    config.Timeout,
+		Schema:     "public",
+	}
+
+	tablePrefixes, err := sqlbase.ListPrefixes(
+		ctx, cfg, "SELECT table_name FROM information_schema.tables "+
+			"WHERE table_schema = $1", cfg.Schema,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var prefixes []string
+	for _, tablePrefix := range tablePrefixes {
+		if prefix, ok := config.stripNamespace(tablePrefix); ok {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	return prefixes, nil
+}
+
+// withStatementTimeout returns the given connection string with the
+// statement_timeout runtime parameter set to the given timeout, which makes
+// the server abort any statement that takes longer. Both the URL and the
+// keyword/value connection string formats are supported. A zero timeout
+// leaves the connection string untouched.
+func withStatementTimeout(dsn string, timeout time.Duration) (string, error) {
+	if timeout == 0 {
+		return dsn, nil
+	}
+
+	millis := fmt.Sprintf("%d", timeout.Milliseconds())
+
+	if !strings.HasPrefix(dsn, "postgres://") &&
+		!strings.HasPrefix(dsn, "postgresql://") {
+
+		return fmt.Sprintf("%s statement_timeout=%s", dsn, millis), nil
+	}
+
+	dsnURL, err := url.Parse(dsn)
+	if err != nil {
+		return "", fmt.Errorf("invalid postgres dsn: %w", err)
+	}
+
+	query := dsnURL.Query()
+	query.Set("statement_timeout", millis)
+	dsnURL.RawQuery = query.Encode()
+
+	return dsnURL.String(), nil
+}
@@ -60,3 +60,93 @@ func TestPanic(t *testing.T) {
 
 	require.Contains(t, err.Error(), "terminating connection")
 }
+
+// TestWithStatementTimeout tests that the statement timeout is added to both
+// connection string formats.
+func TestWithStatementTimeout(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		dsn      string
+		timeout  time.Duration
+		expected string
+	}{{
+		name:     "no timeout",
+		dsn:      "postgres://localhost/lnd",
+		expected: "postgres://localhost/lnd",
+	}, {
+		name:    "url",
+		dsn:     "postgres://user@localhost/lnd?sslmode=disable",
+		timeout: 5 * time.Second,
+		expected: "postgres://user@localhost/lnd?sslmode=disable&" +
+			"statement_timeout=5000",
+	}, {
+		name:     "keyword value",
+		dsn:      "host=localhost dbname=lnd",
+		timeout:  time.Minute,
+		expected: "host=localhost dbname=lnd statement_timeout=60000",
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dsn, err := withStatementTimeout(tc.dsn, tc.timeout)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, dsn)
+		})
+	}
+}
+
+// TestReplicaConfig tests that the replica configuration connects to the
+// replica in read-only mode.
+func TestReplicaConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Dsn:            "postgres://primary/lnd",
+		ReplicaDsn:     "postgres://replica/lnd",
+		MaxConnections: 10,
+	}
+
+	replica := cfg.ReplicaConfig()
+	require.Equal(t, "postgres://replica/lnd", replica.Dsn)
+	require.Empty(t, replica.ReplicaDsn)
+	require.True(t, replica.ReadOnly)
+	require.Equal(t, 10, replica.MaxConnections)
+
+	// The original config must not be modified.
+	require.Equal(t, "postgres://primary/lnd", cfg.Dsn)
+	require.False(t, cfg.ReadOnly)
+}
+
+// TestTablePrefix tests that the namespace is prepended to the table name
+// prefixes and removed again when listing them.
+func TestTablePrefix(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{}
+	prefix, err := cfg.TablePrefix("channeldb")
+	require.NoError(t, err)
+	require.Equal(t, "channeldb", prefix)
+
+	cfg.Namespace = "node_1"
+	prefix, err = cfg.TablePrefix("channeldb")
+	require.NoError(t, err)
+	require.Equal(t, "node_1_channeldb", prefix)
+
+	prefix, ok := cfg.stripNamespace("node_1_channeldb")
+	require.True(t, ok)
+	require.Equal(t, "channeldb", prefix)
+
+	_, ok = cfg.stripNamespace("node_2_channeldb")
+	require.False(t, ok)
+
+	for _, namespace := range []string{"Node", "1node", "node-1", "a;b"} {
+		cfg.Namespace = namespace
+		_, err := cfg.TablePrefix("channeldb")
+		require.Error(t, err, namespace)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"io"
 	"math"
 	"math/rand"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -70,6 +72,39 @@ type Config struct {
 	// WithTxLevelLock when set will ensure that there is a transaction
 	// level lock.
 	WithTxLevelLock bool
+
+	// MaxIdleConnections is the maximum number of idle connections that
+	// are kept in the connection pool. If zero, the default of the sql
+	// package is used.
+	MaxIdleConnections int
+
+	// ConnMaxLifetime is the maximum amount of time a connection may be
+	// reused. If zero, connections are reused forever.
+	ConnMaxLifetime time.Duration
+
+	// ConnMaxIdleTime is the maximum amount of time a connection may be
+	// idle before it is closed. If zero, idle connections are kept open.
+	ConnMaxIdleTime time.Duration
+
+	// ReadOnly should be set if the database is a read-only replica. The
+	// tables are then expected to exist already and read-write
+	// transactions are refused.
+	ReadOnly bool
+}
+
+// configurePool applies the configured connection pool settings to the given
+// connection pool. Settings that aren't configured keep the defaults of the
+// sql package.
+func (c *Config) configurePool(db *sql.DB) {
+	if c.MaxIdleConnections != 0 {
+		db.SetMaxIdleConns(c.MaxIdleConnections)
+	}
+	if c.ConnMaxLifetime != 0 {
+		db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	}
+	if c.ConnMaxIdleTime != 0 {
+		db.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+	}
 }
 
 // db holds a reference to the sql db connection.
@@ -141,16 +176,22 @@ func NewSqlBackend(ctx context.Context, cfg *Config) (*db, error) {
 		table, cfg.Schema, cfg.SQLiteCmdReplacements,
 	)
 
-	dbConn, err := dbConns.Open(cfg.DriverName, cfg.Dsn)
+	dbConn, err := dbConns.Open(
+		cfg.DriverName, cfg.Dsn, cfg.configurePool,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = dbConn.ExecContext(ctx, query)
-	if err != nil {
-		_ = dbConn.Close()
+	// A read-only replica can't create any tables, they are created by the
+	// primary database instead.
+	if !cfg.ReadOnly {
+		_, err = dbConn.ExecContext(ctx, query)
+		if err != nil {
+			_ = dbConn.Close()
 
-		return nil, err
+			return nil, err
+		}
 	}
 
 	return &db{
@@ -162,6 +203,65 @@ func NewSqlBackend(ctx context.Context, cfg *Config) (*db, error) {
 	}, nil
 }
 
+// ListPrefixes returns the table name prefixes of all kv tables in the
+// database the config points to, which are the namespaces of the logical
+// databases stored in it. The given query must return the names of all
+// tables the prefixes should be extracted from.
+func ListPrefixes(ctx context.Context, cfg *Config, listTablesQuery string,
+	args ...interface{}) ([]string, error) {
+
+	dbConnsMu.Lock()
+	defer dbConnsMu.Unlock()
+
+	if dbConns == nil {
+		return nil, errors.New("db connection set not initialized")
+	}
+
+	dbConn, err := dbConns.Open(cfg.DriverName, cfg.Dsn, cfg.configurePool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = dbConns.Close(cfg.Dsn)
+	}()
+
+	if cfg.Timeout != 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	rows, err := dbConn.QueryContext(ctx, listTablesQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suffix := "_" + kvTableName
+
+	var prefixes []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, err
+		}
+
+		prefix := strings.TrimSuffix(table, suffix)
+		if prefix == table || prefix == "" {
+			continue
+		}
+
+		prefixes = append(prefixes, prefix)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(prefixes)
+
+	return prefixes, nil
+}
+
 // getTimeoutCtx gets a timeout context for database requests.
 func (db *db) getTimeoutCtx() (context.Context, func()) {
 	if db.cfg.Timeout == time.Duration(0) {
@@ -32,8 +32,11 @@ func newDbConnSet(maxConnections int) *dbConnSet {
 }
 
 // Open opens a new database connection. If a connection already exists for the
-// given dsn, the existing connection is returned.
-func (d *dbConnSet) Open(driver, dsn string) (*sql.DB, error) {
+// given dsn, the existing connection is returned. Otherwise, the optional
+// configure function is called to tune the new connection pool.
+func (d *dbConnSet) Open(driver, dsn string,
+	configure func(*sql.DB)) (*sql.DB, error) {
+
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
@@ -56,6 +59,10 @@ func (d *dbConnSet) Open(driver, dsn string) (*sql.DB, error) {
 		db.SetMaxOpenConns(d.maxConnections)
 	}
 
+	if configure != nil {
+		configure(db)
+	}
+
 	d.dbConn[dsn] = &dbConn{
 		db:    db,
 		count: 1,
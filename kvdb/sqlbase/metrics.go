@@ -0,0 +1,47 @@
+package sqlbase
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// QueryType is the type of a statement executed against the database.
+type QueryType string
+
+const (
+	// QueryTypeQuery is the type of statements that return rows.
+	QueryTypeQuery QueryType = "query"
+
+	// QueryTypeExec is the type of statements that don't return rows.
+	QueryTypeExec QueryType = "exec"
+
+	// QueryTypeCommit is the type of transaction commits.
+	QueryTypeCommit QueryType = "commit"
+)
+
+// QueryObserver is called with the latency of each statement that is executed
+// against the database, along with the table name prefix (namespace) of the
+// database it was executed for.
+type QueryObserver func(prefix string, queryType QueryType,
+	latency time.Duration)
+
+// queryObserver holds the currently set QueryObserver, if any.
+var queryObserver atomic.Value
+
+// SetQueryObserver sets the observer that is notified about the latency of
+// every statement executed by the sql backends. This can be used to export
+// query latency metrics. Setting a nil observer disables the notifications.
+func SetQueryObserver(observer QueryObserver) {
+	queryObserver.Store(observer)
+}
+
+// observeQuery notifies the query observer, if any, about a statement that
+// was started at the given time.
+func observeQuery(prefix string, queryType QueryType, start time.Time) {
+	observer, ok := queryObserver.Load().(QueryObserver)
+	if !ok || observer == nil {
+		return
+	}
+
+	observer(prefix, queryType, time.Since(start))
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"database/sql"
 	"sync"
+	"time"
 
 	"github.com/ltcsuite/ltcwallet/walletdb"
 )
@@ -28,6 +29,18 @@ type readWriteTx struct {
 // newReadWriteTx creates an rw transaction using a connection from the
 // specified pool.
 func newReadWriteTx(db *db, readOnly bool) (*readWriteTx, error) {
+	// Serializable transactions aren't available on read-only replicas,
+	// but as there are no concurrent writers through this connection,
+	// repeatable read gives us the same consistent snapshot.
+	isolation := sql.LevelSerializable
+	if db.cfg.ReadOnly {
+		if !readOnly {
+			return nil, ErrReadOnly
+		}
+
+		isolation = sql.LevelRepeatableRead
+	}
+
 	locker := newNoopLocker()
 	if db.cfg.WithTxLevelLock {
 		// Obtain the global lock instance. An alternative here is to
@@ -50,7 +63,7 @@ func newReadWriteTx(db *db, readOnly bool) (*readWriteTx, error) {
 		context.Background(),
 		&sql.TxOptions{
 			ReadOnly:  readOnly,
-			Isolation: sql.LevelSerializable,
+			Isolation: isolation,
 		},
 	)
 	if err != nil {
@@ -155,7 +168,9 @@ func (tx *readWriteTx) Commit() error {
 	}
 
 	// Try committing the transaction.
+	start := time.Now()
 	err := tx.tx.Commit()
+	observeQuery(tx.db.prefix, QueryTypeCommit, start)
 	if err == nil && tx.onCommit != nil {
 		tx.onCommit()
 	}
@@ -177,7 +192,12 @@ func (tx *readWriteTx) QueryRow(query string, args ...interface{}) (*sql.Row,
 	func()) {
 
 	ctx, cancel := tx.db.getTimeoutCtx()
-	return tx.tx.QueryRowContext(ctx, query, args...), cancel
+
+	start := time.Now()
+	row := tx.tx.QueryRowContext(ctx, query, args...)
+	observeQuery(tx.db.prefix, QueryTypeQuery, start)
+
+	return row, cancel
 }
 
 // Query executes a multi-row query call with a timeout context.
@@ -185,7 +205,10 @@ func (tx *readWriteTx) Query(query string, args ...interface{}) (*sql.Rows,
 	func(), error) {
 
 	ctx, cancel := tx.db.getTimeoutCtx()
+
+	start := time.Now()
 	rows, err := tx.tx.QueryContext(ctx, query, args...)
+	observeQuery(tx.db.prefix, QueryTypeQuery, start)
 	if err != nil {
 		cancel()
 
@@ -202,7 +225,11 @@ func (tx *readWriteTx) Exec(query string, args ...interface{}) (sql.Result,
 	ctx, cancel := tx.db.getTimeoutCtx()
 	defer cancel()
 
-	return tx.tx.ExecContext(ctx, query, args...)
+	start := time.Now()
+	result, err := tx.tx.ExecContext(ctx, query, args...)
+	observeQuery(tx.db.prefix, QueryTypeExec, start)
+
+	return result, err
 }
 
 // noopLocker is an implementation of a no-op sync.Locker.
@@ -9,6 +9,10 @@ var (
 	// ErrRetriesExceeded is returned when a transaction is retried more
 	// than the max allowed valued without a success.
 	ErrRetriesExceeded = errors.New("db tx retries exceeded")
+
+	// ErrReadOnly is returned when a read-write transaction is started on
+	// a read-only replica.
+	ErrReadOnly = errors.New("db is a read-only replica")
 )
 
 // MapSQLError attempts to interpret a given error as a database agnostic SQL
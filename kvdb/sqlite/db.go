@@ -30,6 +30,37 @@ const (
 func NewSqliteBackend(ctx context.Context, cfg *Config, dbPath, fileName,
 	prefix string) (walletdb.DB, error) {
 
+	sqlCfg := &sqlbase.Config{
+		DriverName:      "sqlite",
+		Dsn:             sqliteDsn(cfg, dbPath, fileName),
+		Timeout:         cfg.Timeout,
+		TableNamePrefix: prefix,
+	}
+
+	return sqlbase.NewSqlBackend(ctx, sqlCfg)
+}
+
+// ListPrefixes returns the prefixes of all logical databases that are stored
+// in the given sqlite database file, for example "channeldb" or
+// "macaroondb". The sqlbase connection set must be initialized before calling
+// this function.
+func ListPrefixes(ctx context.Context, cfg *Config, dbPath,
+	fileName string) ([]string, error) {
+
+	sqlCfg := &sqlbase.Config{
+		DriverName: "sqlite",
+		Dsn:        sqliteDsn(cfg, dbPath, fileName),
+		Timeout:    cfg.Timeout,
+	}
+
+	return sqlbase.ListPrefixes(
+		ctx, sqlCfg, "SELECT name FROM sqlite_master WHERE type = ?",
+		"table",
+	)
+}
+
+// sqliteDsn returns the connection string of the given sqlite database file.
+func sqliteDsn(cfg *Config, dbPath, fileName string) string {
 	// First, we add a set of mandatory pragma options to the query.
 	pragmaOptions := []struct {
 		name  string
@@ -68,16 +99,8 @@ func NewSqliteBackend(ctx context.Context, cfg *Config, dbPath, fileName,
 	// with the series of pragma options as a query URL string. For more
 	// details on the formatting here, see the modernc.org/sqlite docs:
 	// https://pkg.go.dev/modernc.org/sqlite#Driver.Open.
-	dsn := fmt.Sprintf(
+	return fmt.Sprintf(
 		"%v?%v&%v", filepath.Join(dbPath, fileName),
 		sqliteOptions.Encode(), sqliteTxLockImmediate,
 	)
-	sqlCfg := &sqlbase.Config{
-		DriverName:      "sqlite",
-		Dsn:             dsn,
-		Timeout:         cfg.Timeout,
-		TableNamePrefix: prefix,
-	}
-
-	return sqlbase.NewSqlBackend(ctx, sqlCfg)
 }
@@ -33,3 +33,29 @@ func TestInterface(t *testing.T) {
 
 	walletdbtest.TestInterface(t, dbType, ctx, cfg, dir, "tmp.db", "temp")
 }
+
+// TestListPrefixes tests that the prefixes of all logical databases stored in
+// a sqlite file are listed.
+func TestListPrefixes(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	sqlbase.Init(0)
+
+	cfg := &Config{
+		BusyTimeout: time.Second * 5,
+	}
+
+	for _, prefix := range []string{"macaroondb", "channeldb"} {
+		sqlDB, err := NewSqliteBackend(ctx, cfg, dir, "tmp.db", prefix)
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			require.NoError(t, sqlDB.Close())
+		})
+	}
+
+	prefixes, err := ListPrefixes(ctx, cfg, dir, "tmp.db")
+	require.NoError(t, err)
+	require.Equal(t, []string{"channeldb", "macaroondb"}, prefixes)
+}
@@ -0,0 +1,240 @@
+package kvdb
+
+import (
+	"encoding/hex"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+)
+
+const (
+	// maxCallerFrames is the maximum number of stack frames that are
+	// captured to find the call site of a slow transaction.
+	maxCallerFrames = 16
+
+	// kvdbPackagePrefix is the function name prefix of all frames in this
+	// package, which are skipped when looking for the call site of a
+	// transaction.
+	kvdbPackagePrefix = "github.com/ltcsuite/lnd/kvdb."
+)
+
+var (
+	// txObserver holds the TxObserver that is notified about every
+	// finished transaction, if one is set.
+	txObserver atomic.Value
+
+	// slowTxThreshold is the duration after which a transaction is logged
+	// as slow. A value of zero disables the logging.
+	slowTxThreshold atomic.Int64
+)
+
+// TxStats holds the statistics of a single finished transaction.
+type TxStats struct {
+	// ReadOnly is true if this was a read-only transaction.
+	ReadOnly bool
+
+	// Duration is the time between the transaction acquiring its database
+	// lock and it being committed or rolled back.
+	Duration time.Duration
+
+	// Buckets is the sorted list of names of all top-level buckets the
+	// transaction accessed. This is only populated if a TxObserver is set.
+	Buckets []string
+}
+
+// TxObserver is called with the statistics of each transaction that was run
+// through Update, View, UpdateCtx or ViewCtx.
+type TxObserver func(stats *TxStats)
+
+// txObserverBox wraps a TxObserver so nil observers can be stored in an
+// atomic.Value.
+type txObserverBox struct {
+	observer TxObserver
+}
+
+// SetTxObserver sets the observer that is notified about every finished
+// transaction. This is used to export transaction metrics to the monitoring
+// subsystem. A nil observer disables the tracking.
+func SetTxObserver(observer TxObserver) {
+	txObserver.Store(txObserverBox{observer: observer})
+}
+
+// loadTxObserver returns the currently set TxObserver or nil if none is set.
+func loadTxObserver() TxObserver {
+	box, ok := txObserver.Load().(txObserverBox)
+	if !ok {
+		return nil
+	}
+
+	return box.observer
+}
+
+// SetSlowTxThreshold sets the duration after which a transaction is logged
+// together with its call site. A threshold of zero disables the logging.
+func SetSlowTxThreshold(threshold time.Duration) {
+	slowTxThreshold.Store(int64(threshold))
+}
+
+// stats returns the statistics of the finished transaction.
+func (r *txRun) stats() *TxStats {
+	r.Lock()
+	defer r.Unlock()
+
+	stats := &TxStats{
+		ReadOnly: r.readOnly,
+		Duration: time.Since(r.startTime),
+	}
+	for name := range r.buckets {
+		stats.Buckets = append(stats.Buckets, name)
+	}
+	sort.Strings(stats.Buckets)
+
+	return stats
+}
+
+// trackBucket records that the transaction accessed the given top-level
+// bucket.
+func (r *txRun) trackBucket(key []byte) {
+	name := bucketLabel(key)
+
+	r.Lock()
+	r.buckets[name] = struct{}{}
+	r.Unlock()
+}
+
+// trackRTx wraps the given read transaction so that the top-level buckets it
+// accesses are recorded, if bucket tracking is enabled.
+func (r *txRun) trackRTx(tx RTx) RTx {
+	if r.buckets == nil {
+		return tx
+	}
+
+	return &trackedRTx{RTx: tx, run: r}
+}
+
+// trackRwTx wraps the given read-write transaction so that the top-level
+// buckets it accesses are recorded, if bucket tracking is enabled.
+func (r *txRun) trackRwTx(tx RwTx) RwTx {
+	if r.buckets == nil {
+		return tx
+	}
+
+	return &trackedRwTx{RwTx: tx, run: r}
+}
+
+// trackedRTx is a read transaction that records the top-level buckets it
+// accesses.
+type trackedRTx struct {
+	RTx
+
+	run *txRun
+}
+
+// ReadBucket opens the top-level bucket with the given key and records the
+// access.
+func (t *trackedRTx) ReadBucket(key []byte) RBucket {
+	t.run.trackBucket(key)
+	return t.RTx.ReadBucket(key)
+}
+
+// RootBucket returns the root bucket of the wrapped transaction, if the
+// backend supports it.
+func (t *trackedRTx) RootBucket() RBucket {
+	return RootBucket(t.RTx)
+}
+
+// trackedRwTx is a read-write transaction that records the top-level buckets
+// it accesses.
+type trackedRwTx struct {
+	RwTx
+
+	run *txRun
+}
+
+// ReadBucket opens the top-level bucket with the given key and records the
+// access.
+func (t *trackedRwTx) ReadBucket(key []byte) RBucket {
+	t.run.trackBucket(key)
+	return t.RwTx.ReadBucket(key)
+}
+
+// ReadWriteBucket opens the top-level bucket with the given key for writing
+// and records the access.
+func (t *trackedRwTx) ReadWriteBucket(key []byte) RwBucket {
+	t.run.trackBucket(key)
+	return t.RwTx.ReadWriteBucket(key)
+}
+
+// CreateTopLevelBucket creates the top-level bucket with the given key and
+// records the access.
+func (t *trackedRwTx) CreateTopLevelBucket(key []byte) (RwBucket, error) {
+	t.run.trackBucket(key)
+	return t.RwTx.CreateTopLevelBucket(key)
+}
+
+// DeleteTopLevelBucket deletes the top-level bucket with the given key and
+// records the access.
+func (t *trackedRwTx) DeleteTopLevelBucket(key []byte) error {
+	t.run.trackBucket(key)
+	return t.RwTx.DeleteTopLevelBucket(key)
+}
+
+// RootBucket returns the root bucket of the wrapped transaction, if the
+// backend supports it.
+func (t *trackedRwTx) RootBucket() RBucket {
+	return RootBucket(t.RwTx)
+}
+
+// bucketLabel returns a printable name of the given bucket key that can be
+// used as a metric label.
+func bucketLabel(key []byte) string {
+	for _, r := range string(key) {
+		if r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return hex.EncodeToString(key)
+		}
+	}
+
+	return string(key)
+}
+
+// captureCallers returns the program counters of the calling goroutine's
+// stack, which are resolved to a call site only if needed.
+func captureCallers() []uintptr {
+	pcs := make([]uintptr, maxCallerFrames)
+
+	// Skip runtime.Callers, captureCallers and runTx.
+	return pcs[:runtime.Callers(3, pcs)]
+}
+
+// callSite returns the first frame of the given program counters that is
+// outside of this package (or in its tests), which is the code that started
+// the transaction.
+func callSite(pcs []uintptr) string {
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, kvdbPackagePrefix) ||
+			strings.HasSuffix(frame.File, "_test.go") {
+
+			return fmt.Sprintf("%s (%s:%d)", frame.Function,
+				frame.File, frame.Line)
+		}
+
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// logSlowTx logs a transaction that took longer than the slow transaction
+// threshold together with its call site.
+func logSlowTx(stats *TxStats, pcs []uintptr) {
+	log.Warnf("Slow transaction (read_only=%v) took %v, buckets=%v, "+
+		"called from %s", stats.ReadOnly,
+		stats.Duration.Truncate(time.Millisecond), stats.Buckets,
+		callSite(pcs))
+}
@@ -0,0 +1,99 @@
+package kvdb
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btclog"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTxObserver tests that the observer is notified about each transaction
+// with the top-level buckets it accessed.
+func TestTxObserver(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		stats []*TxStats
+	)
+	SetTxObserver(func(s *TxStats) {
+		mu.Lock()
+		stats = append(stats, s)
+		mu.Unlock()
+	})
+	t.Cleanup(func() {
+		SetTxObserver(nil)
+	})
+
+	db := NewBoltFixture(t).NewBackend()
+
+	err := Update(db, func(tx RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(testBucket)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateTopLevelBucket([]byte{0x01, 0x02})
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(testKey, []byte("value"))
+	}, func() {})
+	require.NoError(t, err)
+
+	err = View(db, func(tx RTx) error {
+		require.NotNil(t, tx.ReadBucket(testBucket))
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	err = View(db, func(tx RTx) error {
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Len(t, stats, 3)
+	require.False(t, stats[0].ReadOnly)
+	require.Equal(t, []string{"0102", "bucket"}, stats[0].Buckets)
+	require.True(t, stats[1].ReadOnly)
+	require.Equal(t, []string{"bucket"}, stats[1].Buckets)
+	require.True(t, stats[2].ReadOnly)
+	require.Empty(t, stats[2].Buckets)
+}
+
+// TestSlowTxThreshold tests that only transactions exceeding the slow
+// transaction threshold are logged together with their call site.
+func TestSlowTxThreshold(t *testing.T) {
+	var logs bytes.Buffer
+	logger := btclog.NewBackend(&logs).Logger("KVDB")
+	logger.SetLevel(btclog.LevelWarn)
+	UseLogger(logger)
+	SetSlowTxThreshold(20 * time.Millisecond)
+	t.Cleanup(func() {
+		UseLogger(btclog.Disabled)
+		SetSlowTxThreshold(0)
+	})
+
+	db := NewBoltFixture(t).NewBackend()
+
+	err := View(db, func(tx RTx) error {
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+	require.Empty(t, logs.String())
+
+	err = View(db, func(tx RTx) error {
+		time.Sleep(30 * time.Millisecond)
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	require.Contains(t, logs.String(), "Slow transaction (read_only=true)")
+	require.Contains(t, logs.String(), "TestSlowTxThreshold")
+	require.Contains(t, logs.String(), "txmetrics_test.go")
+}
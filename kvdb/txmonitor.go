@@ -0,0 +1,271 @@
+package kvdb
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// lockDeadline is the time a transaction may wait for its database
+	// lock before the stack traces of all active transactions are logged.
+	// A value of zero disables the detection.
+	lockDeadline atomic.Int64
+
+	// activeTxns holds all transactions that are currently running
+	// through UpdateCtx or ViewCtx while the deadlock detection is
+	// enabled.
+	activeTxns   = make(map[uint64]*activeTx)
+	activeTxnsMu sync.Mutex
+
+	// nextTxID is the ID of the next transaction that is started.
+	nextTxID atomic.Uint64
+)
+
+// activeTx holds the information about a running transaction that is logged
+// if another transaction can't acquire its lock.
+type activeTx struct {
+	readOnly bool
+	started  time.Time
+
+	// stack is the stack trace of the goroutine at the time the
+	// transaction was started.
+	stack []byte
+}
+
+// SetLockDeadline sets the time a transaction may wait for its database lock
+// before the stack traces of all transactions that currently hold a lock are
+// logged. This is meant to debug stuck database transactions. A deadline of
+// zero disables the detection.
+func SetLockDeadline(deadline time.Duration) {
+	lockDeadline.Store(int64(deadline))
+}
+
+// txRun holds the state of a single transaction executed by runTx.
+type txRun struct {
+	sync.Mutex
+
+	ctx      context.Context
+	readOnly bool
+
+	// deadline is the lock deadline that was active when the transaction
+	// was requested.
+	deadline time.Duration
+
+	// started is set once the transaction closure was called, which means
+	// the database lock was acquired.
+	started bool
+
+	// abandoned is set if the context was canceled before the transaction
+	// was started. The closure then returns immediately without doing
+	// anything if the lock is acquired later.
+	abandoned bool
+
+	// startTime is the time the transaction closure was first called.
+	startTime time.Time
+
+	// buckets is the set of top-level buckets the transaction accessed.
+	// This is nil if the accessed buckets aren't tracked.
+	buckets map[string]struct{}
+}
+
+// UpdateCtx is identical to Update but aborts the transaction if the given
+// context is canceled. If the context is canceled while waiting for the
+// database lock, the context error is returned immediately and the closure
+// is never executed. If it is canceled while the closure runs, the closure is
+// allowed to finish but the transaction is rolled back instead of committed.
+func UpdateCtx(ctx context.Context, db Backend, f func(tx RwTx) error,
+	reset func()) error {
+
+	return runTx(ctx, false, func(run *txRun) error {
+		return db.Update(func(tx RwTx) error {
+			return run.exec(func() error {
+				return f(run.trackRwTx(tx))
+			})
+		}, reset)
+	})
+}
+
+// ViewCtx is identical to View but aborts the transaction if the given context
+// is canceled. If the context is canceled while waiting for the database lock,
+// the context error is returned immediately and the closure is never
+// executed. If it is canceled while the closure runs, the closure is allowed to
+// finish but the context error is returned.
+func ViewCtx(ctx context.Context, db Backend, f func(tx RTx) error,
+	reset func()) error {
+
+	return runTx(ctx, true, func(run *txRun) error {
+		return db.View(func(tx RTx) error {
+			return run.exec(func() error {
+				return f(run.trackRTx(tx))
+			})
+		}, reset)
+	})
+}
+
+// exec executes the transaction closure once the transaction was started. It
+// aborts the transaction if the context is canceled.
+func (r *txRun) exec(f func() error) error {
+	r.Lock()
+	if r.abandoned {
+		r.Unlock()
+		return r.ctx.Err()
+	}
+	if !r.started {
+		r.started = true
+		r.startTime = time.Now()
+	}
+	r.Unlock()
+
+	if r.deadline != 0 {
+		id := registerTx(r.readOnly)
+		defer unregisterTx(id)
+	}
+
+	if err := f(); err != nil {
+		return err
+	}
+
+	// If the context was canceled while the closure was running, we make
+	// sure the transaction isn't committed.
+	return r.ctx.Err()
+}
+
+// runTx runs a transaction with the given execute function, which must call
+// the exec method of the passed txRun with the transaction closure once the
+// transaction was started. The transaction is aborted once the context is
+// canceled, the active transactions are logged if acquiring the lock takes
+// longer than the lock deadline and the transaction is reported to the
+// transaction observer and slow transaction log.
+func runTx(ctx context.Context, readOnly bool,
+	execute func(run *txRun) error) error {
+
+	run := &txRun{
+		ctx:      ctx,
+		readOnly: readOnly,
+		deadline: time.Duration(lockDeadline.Load()),
+	}
+
+	observer := loadTxObserver()
+	if observer != nil {
+		run.buckets = make(map[string]struct{})
+	}
+
+	// We only capture the call site if we might need to log it, as this is
+	// relatively expensive.
+	slowThreshold := time.Duration(slowTxThreshold.Load())
+	var callers []uintptr
+	if slowThreshold != 0 {
+		callers = captureCallers()
+	}
+
+	// finish reports the finished transaction.
+	finish := func(err error) error {
+		if !run.started || (observer == nil && slowThreshold == 0) {
+			return err
+		}
+
+		stats := run.stats()
+		if slowThreshold != 0 && stats.Duration >= slowThreshold {
+			logSlowTx(stats, callers)
+		}
+		if observer != nil {
+			observer(stats)
+		}
+
+		return err
+	}
+
+	if run.deadline != 0 {
+		requested := time.Now()
+		timer := time.AfterFunc(run.deadline, func() {
+			run.Lock()
+			defer run.Unlock()
+
+			if !run.started && !run.abandoned {
+				logActiveTxns(readOnly, time.Since(requested))
+			}
+		})
+		defer timer.Stop()
+	}
+
+	// If the context can't be canceled, we can just run the transaction
+	// in this goroutine.
+	if ctx.Done() == nil {
+		return finish(execute(run))
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- execute(run)
+	}()
+
+	select {
+	case err := <-errChan:
+		return finish(err)
+
+	case <-ctx.Done():
+	}
+
+	// The context was canceled. If the closure didn't start yet, we
+	// abandon the transaction and return right away. Otherwise we need to
+	// wait for the closure to return, as it might still access state of
+	// the caller.
+	run.Lock()
+	if !run.started {
+		run.abandoned = true
+		run.Unlock()
+
+		return ctx.Err()
+	}
+	run.Unlock()
+
+	return finish(<-errChan)
+}
+
+// registerTx adds a started transaction to the set of active transactions and
+// returns its ID.
+func registerTx(readOnly bool) uint64 {
+	buf := make([]byte, 8192)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	id := nextTxID.Add(1)
+
+	activeTxnsMu.Lock()
+	activeTxns[id] = &activeTx{
+		readOnly: readOnly,
+		started:  time.Now(),
+		stack:    buf,
+	}
+	activeTxnsMu.Unlock()
+
+	return id
+}
+
+// unregisterTx removes a finished transaction from the set of active
+// transactions.
+func unregisterTx(id uint64) {
+	activeTxnsMu.Lock()
+	delete(activeTxns, id)
+	activeTxnsMu.Unlock()
+}
+
+// logActiveTxns logs the stack traces of all active transactions because a
+// transaction is waiting for its lock for longer than the lock deadline.
+func logActiveTxns(readOnly bool, waiting time.Duration) {
+	activeTxnsMu.Lock()
+	defer activeTxnsMu.Unlock()
+
+	log.Warnf("Transaction (read_only=%v) is waiting for its database "+
+		"lock for %v, %d transactions are active", readOnly,
+		waiting.Truncate(time.Millisecond), len(activeTxns))
+
+	for id, tx := range activeTxns {
+		log.Warnf("Active transaction %d (read_only=%v) running for "+
+			"%v, started at:\n%s", id, tx.readOnly,
+			time.Since(tx.started).Truncate(time.Millisecond),
+			tx.stack)
+	}
+}
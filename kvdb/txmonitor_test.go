@@ -0,0 +1,134 @@
+package kvdb
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btclog"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testBucket = []byte("bucket")
+	testKey    = []byte("key")
+)
+
+// TestUpdateCtxCancelWaiting tests that a transaction that is waiting for its
+// lock is abandoned once its context is canceled.
+func TestUpdateCtxCancelWaiting(t *testing.T) {
+	t.Parallel()
+
+	db := NewBoltFixture(t).NewBackend()
+
+	// Hold the write lock so the transaction below can't start.
+	tx, err := db.BeginReadWriteTx()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), 50*time.Millisecond,
+	)
+	defer cancel()
+
+	var (
+		mu  sync.Mutex
+		ran bool
+	)
+	err = UpdateCtx(ctx, db, func(tx RwTx) error {
+		mu.Lock()
+		ran = true
+		mu.Unlock()
+
+		return nil
+	}, func() {})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Once the lock is released, the abandoned transaction acquires it
+	// but must not execute the closure.
+	require.NoError(t, tx.Rollback())
+	err = View(db, func(tx RTx) error {
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.False(t, ran)
+}
+
+// TestUpdateCtxCancelRunning tests that a transaction is rolled back if its
+// context is canceled while the closure runs.
+func TestUpdateCtxCancelRunning(t *testing.T) {
+	t.Parallel()
+
+	db := NewBoltFixture(t).NewBackend()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := UpdateCtx(ctx, db, func(tx RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(testBucket)
+		if err != nil {
+			return err
+		}
+
+		cancel()
+
+		return bucket.Put(testKey, []byte("value"))
+	}, func() {})
+	require.ErrorIs(t, err, context.Canceled)
+
+	err = ViewCtx(context.Background(), db, func(tx RTx) error {
+		require.Nil(t, tx.ReadBucket(testBucket))
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+}
+
+// TestLockDeadline tests that the active transactions are logged once a
+// transaction waits for its lock for longer than the lock deadline.
+func TestLockDeadline(t *testing.T) {
+	var logs bytes.Buffer
+	logger := btclog.NewBackend(&logs).Logger("KVDB")
+	logger.SetLevel(btclog.LevelWarn)
+	UseLogger(logger)
+	SetLockDeadline(20 * time.Millisecond)
+	t.Cleanup(func() {
+		UseLogger(btclog.Disabled)
+		SetLockDeadline(0)
+	})
+
+	db := NewBoltFixture(t).NewBackend()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	holderErr := make(chan error, 1)
+	go func() {
+		holderErr <- Update(db, func(tx RwTx) error {
+			close(started)
+			<-release
+
+			return nil
+		}, func() {})
+	}()
+	<-started
+
+	// Release the holder only after the waiting transaction had enough
+	// time to detect the stuck lock.
+	time.AfterFunc(100*time.Millisecond, func() {
+		close(release)
+	})
+
+	err := Update(db, func(tx RwTx) error {
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+	require.NoError(t, <-holderErr)
+
+	require.Contains(t, logs.String(), "is waiting for its database lock")
+	require.Contains(t, logs.String(), "TestLockDeadline")
+
+	activeTxnsMu.Lock()
+	defer activeTxnsMu.Unlock()
+	require.Empty(t, activeTxns)
+}
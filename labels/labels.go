@@ -16,6 +16,7 @@ package labels
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/ltcwallet/wtxmgr"
@@ -90,3 +91,23 @@ func MakeLabel(labelType LabelType, channelID *lnwire.ShortChannelID) string {
 	return fmt.Sprintf("%v:%v:%v-%v", LabelVersionZero, labelType,
 		ShortChanID, channelID.ToUint64())
 }
+
+// Match returns true if the given label fuzzily matches the search query. The
+// query matches if all of its characters appear in the label in the same
+// order, ignoring case, which means that any substring of a label as well as
+// abbreviations like "clschan" for "closechannel" are matches. An empty query
+// matches all labels.
+func Match(label, query string) bool {
+	label = strings.ToLower(label)
+
+	for _, r := range strings.ToLower(query) {
+		idx := strings.IndexRune(label, r)
+		if idx == -1 {
+			return false
+		}
+
+		label = label[idx+len(string(r)):]
+	}
+
+	return true
+}
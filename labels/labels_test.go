@@ -0,0 +1,67 @@
+package labels
+
+import (
+	"testing"
+
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMatch tests the fuzzy matching of labels against a search query.
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	chanID := lnwire.NewShortChanIDFromInt(123)
+	closeLabel := MakeLabel(LabelTypeChannelClose, &chanID)
+
+	testCases := []struct {
+		name  string
+		label string
+		query string
+		match bool
+	}{{
+		name:  "empty query",
+		label: closeLabel,
+		query: "",
+		match: true,
+	}, {
+		name:  "substring",
+		label: closeLabel,
+		query: "closechannel",
+		match: true,
+	}, {
+		name:  "abbreviation",
+		label: closeLabel,
+		query: "clschan",
+		match: true,
+	}, {
+		name:  "case insensitive",
+		label: "Rent Payment",
+		query: "rENT",
+		match: true,
+	}, {
+		name:  "wrong order",
+		label: closeLabel,
+		query: "channelclose",
+		match: false,
+	}, {
+		name:  "missing character",
+		label: External,
+		query: "externals",
+		match: false,
+	}, {
+		name:  "empty label",
+		label: "",
+		query: "sweep",
+		match: false,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.match, Match(tc.label, tc.query))
+		})
+	}
+}
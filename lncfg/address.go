@@ -270,6 +270,31 @@ func ParseAddressString(strAddress string, defaultPort string,
 	}
 }
 
+// ParseDNSAddress converts a string of the form <hostname>[:<port>] into an
+// lnwire.DNSAddress without resolving the hostname. If no port is specified,
+// the defaultPort will be used.
+func ParseDNSAddress(strAddress string,
+	defaultPort uint16) (*lnwire.DNSAddress, error) {
+
+	host, portStr, err := net.SplitHostPort(strAddress)
+	if err != nil {
+		host = strAddress
+		portStr = strconv.Itoa(int(defaultPort))
+	}
+
+	if net.ParseIP(host) != nil {
+		return nil, fmt.Errorf("%v is an IP address, not a hostname",
+			host)
+	}
+
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %v: %v", portStr, err)
+	}
+
+	return lnwire.NewDNSAddress(host, uint16(port))
+}
+
 // ParseLNAddressString converts a string of the form <pubkey>@<addr> into an
 // lnwire.NetAddress. The <pubkey> must be presented in hex, and result in a
 // 33-byte, compressed public key that lies on the secp256k1 curve. The <addr>
@@ -316,3 +316,47 @@ func TestIsPrivate(t *testing.T) {
 		})
 	}
 }
+
+// TestParseDNSAddress tests that hostnames are parsed into DNS addresses
+// without being resolved and that IP addresses are rejected.
+func TestParseDNSAddress(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		address   string
+		expected  string
+		expectErr bool
+	}{
+		{
+			address:  "node.example.com",
+			expected: "node.example.com:9735",
+		},
+		{
+			address:  "node.example.com:1234",
+			expected: "node.example.com:1234",
+		},
+		{
+			address:   "127.0.0.1:1234",
+			expectErr: true,
+		},
+		{
+			address:   "node.example.com:99999",
+			expectErr: true,
+		},
+		{
+			address:   "node_1.example.com",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		addr, err := ParseDNSAddress(test.address, 9735)
+		if test.expectErr {
+			require.Error(t, err, test.address)
+			continue
+		}
+
+		require.NoError(t, err, test.address)
+		require.Equal(t, test.expected, addr.String())
+	}
+}
@@ -0,0 +1,135 @@
+package lncfg
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//nolint:lll
+type ContractCourt struct {
+	Budget *Budget `group:"budget" namespace:"budget"`
+
+	Stagger *Stagger `group:"stagger" namespace:"stagger"`
+
+	ForceCloseDelay *ForceCloseDelay `group:"forceclosedelay" namespace:"forceclosedelay"`
+
+	JusticeSplitThreshold int `long:"justicesplitthreshold" description:"The number of breached outputs at which the justice transaction is split right away into separate transactions sweeping the commitment outputs and the HTLC outputs, so a single input that can't be relayed doesn't block claiming the others. Set to 0 to first try sweeping all outputs in a single transaction."`
+}
+
+// Budget holds the maximum fees that may be spent when sweeping the outputs of
+// a force closed channel, expressed as ratios of the value being swept.
+//
+//nolint:lll
+type Budget struct {
+	AnchorCPFP float64 `long:"anchorcpfp" description:"The maximum fee to spend on bumping a commitment transaction via its anchor, as a ratio of the value of the non-dust HTLCs on the commitment. Set to 0 to not limit the fee."`
+
+	ToLocal float64 `long:"tolocal" description:"The maximum fee to spend on sweeping our output of a commitment transaction, as a ratio of the output value. Set to 0 to not limit the fee."`
+
+	Htlc float64 `long:"htlc" description:"The maximum fee to spend on claiming an HTLC on chain, as a ratio of the HTLC value. Set to 0 to not limit the fee."`
+
+	KeepUneconomical bool `long:"keepuneconomical" description:"Keep trying to claim HTLC outputs whose budget doesn't cover the fee of sweeping them at the minimum relay fee rate. By default such outputs are abandoned, so the channel can be fully resolved instead of remaining pending."`
+}
+
+// Stagger holds the options that control how the broadcasts of force close
+// transactions are spread out when many channels go to chain at once.
+//
+//nolint:lll
+type Stagger struct {
+	Interval time.Duration `long:"interval" description:"The minimum time between the broadcasts of two force close transactions. Force closes are broadcast in the order of their deadlines and fee budgets. Set to 0 to broadcast all force close transactions right away."`
+
+	UrgentDelta uint32 `long:"urgentdelta" description:"The number of blocks before its deadline at which a force close transaction is broadcast without waiting for its turn."`
+}
+
+// ForceCloseDelay holds the options that control how long we wait for an
+// offline peer to come back online before force closing a channel with it
+// because of pending HTLCs.
+//
+//nolint:lll
+type ForceCloseDelay struct {
+	Delay time.Duration `long:"delay" description:"The time to wait for an offline peer to come back online before force closing a channel with it because of pending HTLCs. Only applies until any of the HTLCs is maxblocks blocks past the height at which it would make us go to chain. Set to 0 to force close right away."`
+
+	MaxBlocks uint32 `long:"maxblocks" description:"The maximum number of blocks a force close may be delayed past the height at which an HTLC would make us go to chain, which is its expiry minus the incoming or outgoing broadcast delta. Must be smaller than the incoming broadcast delta, so enough blocks are left to claim incoming HTLCs on chain."`
+
+	PeerDelays []string `long:"peerdelay" description:"Override the delay for a single peer, in the form <pubkey>:<delay>. Can be specified multiple times."`
+}
+
+// ParsePeerDelays parses the per-peer delay overrides, keyed by the compressed
+// public key of the peer.
+func (f *ForceCloseDelay) ParsePeerDelays() (map[[33]byte]time.Duration,
+	error) {
+
+	delays := make(map[[33]byte]time.Duration, len(f.PeerDelays))
+	for _, peerDelay := range f.PeerDelays {
+		parts := strings.Split(peerDelay, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid peer delay %q, expected "+
+				"<pubkey>:<delay>", peerDelay)
+		}
+
+		pubKey, err := hex.DecodeString(parts[0])
+		if err != nil || len(pubKey) != 33 {
+			return nil, fmt.Errorf("invalid pubkey in peer delay "+
+				"%q", peerDelay)
+		}
+
+		delay, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid delay in peer delay "+
+				"%q: %v", peerDelay, err)
+		}
+		if delay < 0 {
+			return nil, fmt.Errorf("delay in peer delay %q must "+
+				"not be negative", peerDelay)
+		}
+
+		var peer [33]byte
+		copy(peer[:], pubKey)
+		delays[peer] = delay
+	}
+
+	return delays, nil
+}
+
+// Validate checks the values configured for the contract court.
+func (c *ContractCourt) Validate() error {
+	ratios := []struct {
+		name  string
+		ratio float64
+	}{
+		{"anchorcpfp", c.Budget.AnchorCPFP},
+		{"tolocal", c.Budget.ToLocal},
+		{"htlc", c.Budget.Htlc},
+	}
+	for _, r := range ratios {
+		if r.ratio < 0 || r.ratio > 1 {
+			return fmt.Errorf("budget.%s must be in the range "+
+				"[0, 1]", r.name)
+		}
+	}
+
+	if c.Stagger.Interval < 0 {
+		return fmt.Errorf("stagger.interval must not be negative")
+	}
+
+	if c.ForceCloseDelay.Delay < 0 {
+		return fmt.Errorf("forceclosedelay.delay must not be negative")
+	}
+
+	if c.ForceCloseDelay.MaxBlocks >= DefaultIncomingBroadcastDelta {
+		return fmt.Errorf("forceclosedelay.maxblocks must be smaller "+
+			"than the incoming broadcast delta of %d",
+			DefaultIncomingBroadcastDelta)
+	}
+
+	if _, err := c.ForceCloseDelay.ParsePeerDelays(); err != nil {
+		return fmt.Errorf("forceclosedelay.peerdelay: %w", err)
+	}
+
+	if c.JusticeSplitThreshold < 0 {
+		return fmt.Errorf("justicesplitthreshold must not be negative")
+	}
+
+	return nil
+}
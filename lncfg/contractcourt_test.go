@@ -0,0 +1,147 @@
+package lncfg_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/lncfg"
+)
+
+// testPubKey is a valid compressed public key used in the per-peer force
+// close delay tests.
+const testPubKey = "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f" +
+	"2815b16f81798"
+
+// TestValidateContractCourt asserts that validating the ContractCourt config
+// only succeeds if all budget ratios are within [0, 1], the stagger interval,
+// justice split threshold and force close delay aren't negative, and the
+// per-peer force close delays are well-formed.
+func TestValidateContractCourt(t *testing.T) {
+	tests := []struct {
+		name           string
+		budget         lncfg.Budget
+		stagger        lncfg.Stagger
+		splitThreshold int
+		closeDelay     lncfg.ForceCloseDelay
+		valid          bool
+	}{
+		{
+			name:  "no budget",
+			valid: true,
+		},
+		{
+			name: "max valid",
+			budget: lncfg.Budget{
+				AnchorCPFP: 1,
+				ToLocal:    1,
+				Htlc:       1,
+			},
+			valid: true,
+		},
+		{
+			name: "anchorcpfp negative",
+			budget: lncfg.Budget{
+				AnchorCPFP: -0.1,
+			},
+		},
+		{
+			name: "tolocal too large",
+			budget: lncfg.Budget{
+				ToLocal: 1.1,
+			},
+		},
+		{
+			name: "htlc too large",
+			budget: lncfg.Budget{
+				Htlc: 2,
+			},
+		},
+		{
+			name: "stagger interval",
+			stagger: lncfg.Stagger{
+				Interval:    time.Second,
+				UrgentDelta: 10,
+			},
+			valid: true,
+		},
+		{
+			name: "stagger interval negative",
+			stagger: lncfg.Stagger{
+				Interval: -time.Second,
+			},
+		},
+		{
+			name:           "justice split threshold",
+			splitThreshold: 10,
+			valid:          true,
+		},
+		{
+			name:           "justice split threshold negative",
+			splitThreshold: -1,
+		},
+		{
+			name: "force close delay",
+			closeDelay: lncfg.ForceCloseDelay{
+				Delay:     time.Hour,
+				MaxBlocks: 3,
+				PeerDelays: []string{
+					testPubKey + ":30m",
+					testPubKey + ":0s",
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "force close delay negative",
+			closeDelay: lncfg.ForceCloseDelay{
+				Delay: -time.Hour,
+			},
+		},
+		{
+			name: "force close delay max blocks too large",
+			closeDelay: lncfg.ForceCloseDelay{
+				Delay:     time.Hour,
+				MaxBlocks: lncfg.DefaultIncomingBroadcastDelta,
+			},
+		},
+		{
+			name: "peer delay invalid format",
+			closeDelay: lncfg.ForceCloseDelay{
+				PeerDelays: []string{testPubKey},
+			},
+		},
+		{
+			name: "peer delay invalid pubkey",
+			closeDelay: lncfg.ForceCloseDelay{
+				PeerDelays: []string{"02aa:30m"},
+			},
+		},
+		{
+			name: "peer delay negative",
+			closeDelay: lncfg.ForceCloseDelay{
+				PeerDelays: []string{testPubKey + ":-1m"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &lncfg.ContractCourt{
+				Budget:                &test.budget,
+				Stagger:               &test.stagger,
+				JusticeSplitThreshold: test.splitThreshold,
+				ForceCloseDelay:       &test.closeDelay,
+			}
+
+			err := cfg.Validate()
+			switch {
+			case test.valid && err != nil:
+				t.Fatalf("valid config was invalid: %v", err)
+			case !test.valid && err == nil:
+				t.Fatalf("invalid config was valid")
+			}
+		})
+	}
+}
@@ -2,16 +2,22 @@ package lncfg
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/btcsuite/btclog"
+	"github.com/ltcsuite/lnd/keychain"
 	"github.com/ltcsuite/lnd/kvdb"
 	"github.com/ltcsuite/lnd/kvdb/etcd"
 	"github.com/ltcsuite/lnd/kvdb/postgres"
 	"github.com/ltcsuite/lnd/kvdb/sqlbase"
 	"github.com/ltcsuite/lnd/kvdb/sqlite"
+	"github.com/ltcsuite/lnd/lnencrypt"
 	"github.com/ltcsuite/lnd/lnrpc"
 	"github.com/ltcsuite/lnd/lnwallet/btcwallet"
 )
@@ -64,6 +70,10 @@ const (
 
 	// NSNeutrinoDB is the namespace name that we use for the neutrino DB.
 	NSNeutrinoDB = "neutrinodb"
+
+	// replicaCloseFuncKey is the key of the close function of the read-only
+	// replica of the channel DB.
+	replicaCloseFuncKey = NSChannelDB + "-replica"
 )
 
 // DB holds database configuration for LND.
@@ -74,6 +84,12 @@ type DB struct {
 
 	BatchCommitInterval time.Duration `long:"batch-commit-interval" description:"The maximum duration the channel graph batch schedulers will wait before attempting to commit a batch of pending updates. This can be tradeoff database contenion for commit latency."`
 
+	WriteFlushInterval time.Duration `long:"write-flush-interval" description:"If set, the events of the forwarding log and the htlc forwarding log are coalesced and flushed to disk in a single transaction at this interval. This reduces the number of disk syncs at the cost of recent events being lost on a crash. Channel graph updates are batched according to batch-commit-interval instead. Set to 0 to commit these events immediately."`
+
+	Verify bool `long:"verify" description:"Scan the channel database on startup and report open channels, revocation logs, invoices and forwarding events that can't be decoded."`
+
+	VerifyQuarantine bool `long:"verify-quarantine" description:"If set together with verify, corrupt entries that aren't critical (forwarding events) are moved to a quarantine bucket so they don't prevent lnd from operating."`
+
 	Etcd *etcd.Config `group:"etcd" namespace:"etcd" description:"Etcd settings."`
 
 	Bolt *kvdb.BoltConfig `group:"bolt" namespace:"bolt" description:"Bolt settings."`
@@ -87,6 +103,22 @@ type DB struct {
 	PruneRevocation bool `long:"prune-revocation" description:"Run the optional migration that prunes the revocation logs to save disk space."`
 
 	NoRevLogAmtData bool `long:"no-rev-log-amt-data" description:"If set, the to-local and to-remote output amounts of revoked commitment transactions will not be stored in the revocation log. Note that once this data is lost, a watchtower client will not be able to back up the revoked state."`
+
+	Migrate bool `long:"migrate" description:"Copy all data of the existing bolt databases to the configured postgres or sqlite backend, verify the copy and then exit. An interrupted migration is resumed when lnd is started with this flag again."`
+
+	MigrateChunkSize int `long:"migrate-chunk-size" description:"The number of keys that are copied in a single database transaction during a migration."`
+
+	TxLockDeadline time.Duration `long:"tx-lock-deadline" description:"If set, the stack traces of all active database transactions are logged when a transaction waits longer than this for its database lock. Used to debug stuck database transactions. Set to zero to disable."`
+
+	SlowQueryThreshold time.Duration `long:"slow-query-threshold" description:"If set, database transactions that take longer than this are logged together with the code that started them. Set to zero to disable."`
+
+	Encrypt []string `long:"encrypt" description:"Encrypt all values of the given database at rest with the key from encryption-key-file. Existing values are encrypted on the next start. Can be specified multiple times. Valid values: macaroondb, decayedlogdb, towerclientdb, towerserverdb."`
+
+	EncryptionKeyFile string `long:"encryption-key-file" description:"The path to a file holding the hex encoded 32-byte key used to encrypt the databases set with encrypt, for example provided by an external key management system."`
+
+	OldEncryptionKeyFile string `long:"old-encryption-key-file" description:"If set, the encrypted databases are re-encrypted from the key in this file to the key in encryption-key-file on startup. Used to rotate the encryption key."`
+
+	EncryptWithSeed []string `long:"encrypt-with-seed" description:"Encrypt all values of the given database at rest with a key derived from the wallet seed instead of the key from encryption-key-file. As the key is only available once the wallet is unlocked, only databases that aren't used before that can be encrypted this way. Can be specified multiple times. Valid values: decayedlogdb."`
 }
 
 // DefaultDB creates and returns a new default DB config.
@@ -94,6 +126,7 @@ func DefaultDB() *DB {
 	return &DB{
 		Backend:             BoltBackend,
 		BatchCommitInterval: DefaultBatchCommitInterval,
+		MigrateChunkSize:    kvdb.DefaultMigrationChunkSize,
 		Bolt: &kvdb.BoltConfig{
 			NoFreelistSync:    true,
 			AutoCompactMinAge: kvdb.DefaultBoltAutoCompactMinAge,
@@ -101,7 +134,8 @@ func DefaultDB() *DB {
 		},
 		Etcd: &etcd.Config{
 			// Allow at most 32 MiB messages by default.
-			MaxMsgSize: 32768 * 1024,
+			MaxMsgSize:         32768 * 1024,
+			LeaderRetryTimeout: etcd.DefaultLeaderRetryTimeout,
 		},
 		Postgres: &postgres.Config{
 			MaxConnections: defaultPostgresMaxConnections,
@@ -122,6 +156,10 @@ func (db *DB) Validate() error {
 			return fmt.Errorf("postgres dsn must be set")
 		}
 
+		if _, err := db.Postgres.TablePrefix(NSChannelDB); err != nil {
+			return err
+		}
+
 	case EtcdBackend:
 		if !db.Etcd.Embedded && db.Etcd.Host == "" {
 			return fmt.Errorf("etcd host must be set")
@@ -146,12 +184,62 @@ func (db *DB) Validate() error {
 			"backend '%v'", db.Backend)
 	}
 
+	// A migration copies the bolt databases to one of the SQL backends.
+	if db.Migrate && db.Backend != PostgresBackend &&
+		db.Backend != SqliteBackend {
+
+		return fmt.Errorf("can only migrate to the '%v' or '%v' "+
+			"database backend", PostgresBackend, SqliteBackend)
+	}
+
+	for _, ns := range db.Encrypt {
+		switch ns {
+		case NSMacaroonDB, NSDecayedLogDB, NSTowerClientDB,
+			NSTowerServerDB:
+
+		default:
+			return fmt.Errorf("cannot encrypt unknown database "+
+				"'%v', must be one of '%v', '%v', '%v' or '%v'",
+				ns, NSMacaroonDB, NSDecayedLogDB,
+				NSTowerClientDB, NSTowerServerDB)
+		}
+	}
+	if len(db.Encrypt) > 0 && db.EncryptionKeyFile == "" {
+		return fmt.Errorf("encryption-key-file must be set to " +
+			"encrypt databases")
+	}
+	if db.OldEncryptionKeyFile != "" && len(db.Encrypt) == 0 {
+		return fmt.Errorf("old-encryption-key-file can only be used " +
+			"together with encrypt")
+	}
+
+	// The macaroon and watchtower databases are already used before the
+	// wallet is unlocked, so they can't be encrypted with a key derived
+	// from the seed.
+	for _, ns := range db.EncryptWithSeed {
+		if ns != NSDecayedLogDB {
+			return fmt.Errorf("cannot encrypt database '%v' with "+
+				"the seed, must be '%v'", ns, NSDecayedLogDB)
+		}
+
+		for _, fileNs := range db.Encrypt {
+			if fileNs == ns {
+				return fmt.Errorf("database '%v' cannot be "+
+					"set in both encrypt and "+
+					"encrypt-with-seed", ns)
+			}
+		}
+	}
+
 	return nil
 }
 
 // Init should be called upon start to pre-initialize database access dependent
 // on configuration.
 func (db *DB) Init(ctx context.Context, dbPath string) error {
+	kvdb.SetLockDeadline(db.TxLockDeadline)
+	kvdb.SetSlowTxThreshold(db.SlowQueryThreshold)
+
 	// Start embedded etcd server if requested.
 	switch {
 	case db.Backend == EtcdBackend && db.Etcd.Embedded:
@@ -214,6 +302,11 @@ type DatabaseBackends struct {
 	// the underlying wallet database from.
 	WalletDB btcwallet.LoaderOption
 
+	// ReplicaDB points to a read-only replica of the graph and channel
+	// state database backend that can be used for expensive read-only
+	// queries. This is nil if no replica is configured.
+	ReplicaDB kvdb.Backend
+
 	// Remote indicates whether the database backends are remote, possibly
 	// replicated instances or local bbolt or sqlite backed databases.
 	Remote bool
@@ -223,12 +316,39 @@ type DatabaseBackends struct {
 	CloseFuncs map[string]func() error
 }
 
-// GetBackends returns a set of kvdb.Backends as set in the DB config.
+// GetBackends returns a set of kvdb.Backends as set in the DB config. The
+// databases that are configured to be encrypted at rest are wrapped
+// accordingly.
 func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 	walletDBPath, towerServerDBPath string, towerClientEnabled,
 	towerServerEnabled bool, logger btclog.Logger) (*DatabaseBackends,
 	error) {
 
+	backends, err := db.getBackends(
+		ctx, chanDBPath, walletDBPath, towerServerDBPath,
+		towerClientEnabled, towerServerEnabled, logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.encryptBackends(backends); err != nil {
+		for _, closeFunc := range backends.CloseFuncs {
+			_ = closeFunc()
+		}
+
+		return nil, err
+	}
+
+	return backends, nil
+}
+
+// getBackends opens the set of kvdb.Backends as set in the DB config.
+func (db *DB) getBackends(ctx context.Context, chanDBPath,
+	walletDBPath, towerServerDBPath string, towerClientEnabled,
+	towerServerEnabled bool, logger btclog.Logger) (*DatabaseBackends,
+	error) {
+
 	// We keep track of all the kvdb backends we actually open and return a
 	// reference to their close function so they can be cleaned up properly
 	// on error or shutdown.
@@ -402,6 +522,22 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 		}
 		closeFuncs[NSWalletDB] = postgresWalletBackend.Close
 
+		// The read-only replica is optional, expensive queries are
+		// served by the primary database if it isn't configured.
+		var postgresReplicaBackend kvdb.Backend
+		if db.Postgres.ReplicaDsn != "" {
+			postgresReplicaBackend, err = kvdb.Open(
+				kvdb.PostgresBackendName, ctx,
+				db.Postgres.ReplicaConfig(), NSChannelDB,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("error opening postgres "+
+					"replica DB: %v", err)
+			}
+			closeFuncs[replicaCloseFuncKey] =
+				postgresReplicaBackend.Close
+		}
+
 		// Warn if the user is trying to switch over to a Postgres DB
 		// while there is a wallet or channel bbolt DB still present.
 		warnExistingBoltDBs(
@@ -429,6 +565,7 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 			WalletDB: btcwallet.LoaderWithExternalWalletDB(
 				postgresWalletBackend,
 			),
+			ReplicaDB:  postgresReplicaBackend,
 			Remote:     true,
 			CloseFuncs: closeFuncs,
 		}, nil
@@ -537,12 +674,13 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 
 	// We're using all bbolt based databases by default.
 	boltBackend, err := kvdb.GetBoltBackend(&kvdb.BoltBackendConfig{
-		DBPath:            chanDBPath,
-		DBFileName:        ChannelDBName,
-		DBTimeout:         db.Bolt.DBTimeout,
-		NoFreelistSync:    db.Bolt.NoFreelistSync,
-		AutoCompact:       db.Bolt.AutoCompact,
-		AutoCompactMinAge: db.Bolt.AutoCompactMinAge,
+		DBPath:                      chanDBPath,
+		DBFileName:                  ChannelDBName,
+		DBTimeout:                   db.Bolt.DBTimeout,
+		NoFreelistSync:              db.Bolt.NoFreelistSync,
+		AutoCompact:                 db.Bolt.AutoCompact,
+		AutoCompactMinAge:           db.Bolt.AutoCompactMinAge,
+		AutoCompactMinFragmentation: db.Bolt.AutoCompactMinFragmentation,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error opening bolt DB: %v", err)
@@ -550,12 +688,13 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 	closeFuncs[NSChannelDB] = boltBackend.Close
 
 	macaroonBackend, err := kvdb.GetBoltBackend(&kvdb.BoltBackendConfig{
-		DBPath:            walletDBPath,
-		DBFileName:        MacaroonDBName,
-		DBTimeout:         db.Bolt.DBTimeout,
-		NoFreelistSync:    db.Bolt.NoFreelistSync,
-		AutoCompact:       db.Bolt.AutoCompact,
-		AutoCompactMinAge: db.Bolt.AutoCompactMinAge,
+		DBPath:                      walletDBPath,
+		DBFileName:                  MacaroonDBName,
+		DBTimeout:                   db.Bolt.DBTimeout,
+		NoFreelistSync:              db.Bolt.NoFreelistSync,
+		AutoCompact:                 db.Bolt.AutoCompact,
+		AutoCompactMinAge:           db.Bolt.AutoCompactMinAge,
+		AutoCompactMinFragmentation: db.Bolt.AutoCompactMinFragmentation,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error opening macaroon DB: %v", err)
@@ -563,12 +702,13 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 	closeFuncs[NSMacaroonDB] = macaroonBackend.Close
 
 	decayedLogBackend, err := kvdb.GetBoltBackend(&kvdb.BoltBackendConfig{
-		DBPath:            chanDBPath,
-		DBFileName:        DecayedLogDbName,
-		DBTimeout:         db.Bolt.DBTimeout,
-		NoFreelistSync:    db.Bolt.NoFreelistSync,
-		AutoCompact:       db.Bolt.AutoCompact,
-		AutoCompactMinAge: db.Bolt.AutoCompactMinAge,
+		DBPath:                      chanDBPath,
+		DBFileName:                  DecayedLogDbName,
+		DBTimeout:                   db.Bolt.DBTimeout,
+		NoFreelistSync:              db.Bolt.NoFreelistSync,
+		AutoCompact:                 db.Bolt.AutoCompact,
+		AutoCompactMinAge:           db.Bolt.AutoCompactMinAge,
+		AutoCompactMinFragmentation: db.Bolt.AutoCompactMinFragmentation,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error opening decayed log DB: %v", err)
@@ -581,12 +721,13 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 	if towerClientEnabled {
 		towerClientBackend, err = kvdb.GetBoltBackend(
 			&kvdb.BoltBackendConfig{
-				DBPath:            chanDBPath,
-				DBFileName:        TowerClientDBName,
-				DBTimeout:         db.Bolt.DBTimeout,
-				NoFreelistSync:    db.Bolt.NoFreelistSync,
-				AutoCompact:       db.Bolt.AutoCompact,
-				AutoCompactMinAge: db.Bolt.AutoCompactMinAge,
+				DBPath:                      chanDBPath,
+				DBFileName:                  TowerClientDBName,
+				DBTimeout:                   db.Bolt.DBTimeout,
+				NoFreelistSync:              db.Bolt.NoFreelistSync,
+				AutoCompact:                 db.Bolt.AutoCompact,
+				AutoCompactMinAge:           db.Bolt.AutoCompactMinAge,
+				AutoCompactMinFragmentation: db.Bolt.AutoCompactMinFragmentation,
 			},
 		)
 		if err != nil {
@@ -602,12 +743,13 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 	if towerServerEnabled {
 		towerServerBackend, err = kvdb.GetBoltBackend(
 			&kvdb.BoltBackendConfig{
-				DBPath:            towerServerDBPath,
-				DBFileName:        TowerServerDBName,
-				DBTimeout:         db.Bolt.DBTimeout,
-				NoFreelistSync:    db.Bolt.NoFreelistSync,
-				AutoCompact:       db.Bolt.AutoCompact,
-				AutoCompactMinAge: db.Bolt.AutoCompactMinAge,
+				DBPath:                      towerServerDBPath,
+				DBFileName:                  TowerServerDBName,
+				DBTimeout:                   db.Bolt.DBTimeout,
+				NoFreelistSync:              db.Bolt.NoFreelistSync,
+				AutoCompact:                 db.Bolt.AutoCompact,
+				AutoCompactMinAge:           db.Bolt.AutoCompactMinAge,
+				AutoCompactMinFragmentation: db.Bolt.AutoCompactMinFragmentation,
 			},
 		)
 		if err != nil {
@@ -617,6 +759,21 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 		closeFuncs[NSTowerServerDB] = towerServerBackend.Close
 	}
 
+	// The wallet database is opened by the wallet loader, so we compact it
+	// here, before it is opened.
+	err = kvdb.CompactBoltDB(&kvdb.BoltBackendConfig{
+		DBPath:                      walletDBPath,
+		DBFileName:                  WalletDBName,
+		DBTimeout:                   db.Bolt.DBTimeout,
+		NoFreelistSync:              db.Bolt.NoFreelistSync,
+		AutoCompact:                 db.Bolt.AutoCompact,
+		AutoCompactMinAge:           db.Bolt.AutoCompactMinAge,
+		AutoCompactMinFragmentation: db.Bolt.AutoCompactMinFragmentation,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error compacting wallet DB: %v", err)
+	}
+
 	returnEarly = false
 
 	return &DatabaseBackends{
@@ -639,14 +796,243 @@ func (db *DB) GetBackends(ctx context.Context, chanDBPath,
 	}, nil
 }
 
+// encryptBackends wraps all databases that are configured to be encrypted at
+// rest, encrypting their existing values or rotating their key if requested.
+func (db *DB) encryptBackends(backends *DatabaseBackends) error {
+	if len(db.Encrypt) == 0 {
+		return nil
+	}
+
+	enc, err := readEncryptionKeyFile(db.EncryptionKeyFile)
+	if err != nil {
+		return err
+	}
+
+	var oldEnc *lnencrypt.Encrypter
+	if db.OldEncryptionKeyFile != "" {
+		oldEnc, err = readEncryptionKeyFile(db.OldEncryptionKeyFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	encryptable := map[string]*kvdb.Backend{
+		NSMacaroonDB:    &backends.MacaroonDB,
+		NSDecayedLogDB:  &backends.DecayedLogDB,
+		NSTowerClientDB: &backends.TowerClientDB,
+		NSTowerServerDB: &backends.TowerServerDB,
+	}
+	for _, ns := range db.Encrypt {
+		backend, ok := encryptable[ns]
+		if !ok {
+			return fmt.Errorf("cannot encrypt unknown database %v",
+				ns)
+		}
+
+		// The watchtower databases are only opened if the watchtower
+		// client or server is enabled.
+		if *backend == nil {
+			continue
+		}
+
+		// A database that was added to the list together with the key
+		// rotation isn't encrypted yet, so there's nothing to rotate.
+		if oldEnc != nil {
+			err := kvdb.RotateEncryptionKey(*backend, oldEnc, enc)
+			if err != nil && !errors.Is(err, kvdb.ErrNotEncrypted) {
+				return fmt.Errorf("error rotating encryption "+
+					"key of %v: %w", ns, err)
+			}
+		}
+
+		encrypted, err := kvdb.OpenEncryptedBackend(*backend, enc)
+		if err != nil {
+			return fmt.Errorf("error opening encrypted %v: %w", ns,
+				err)
+		}
+		*backend = encrypted
+	}
+
+	return nil
+}
+
+// EncryptWithKeyRing wraps the given database with the given namespace so that
+// its values are encrypted at rest with a key derived from the wallet seed, if
+// it is configured to be. As the key ring is only available once the wallet is
+// unlocked, this can't be done by GetBackends. Databases that aren't
+// configured to be encrypted with the seed are returned unchanged.
+func (db *DB) EncryptWithKeyRing(ns string, backend kvdb.Backend,
+	keyRing keychain.KeyRing) (kvdb.Backend, error) {
+
+	var encrypt bool
+	for _, seedNs := range db.EncryptWithSeed {
+		encrypt = encrypt || seedNs == ns
+	}
+	if !encrypt || backend == nil {
+		return backend, nil
+	}
+
+	enc, err := lnencrypt.KeyRingEncrypter(keyRing)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving encryption key: %w", err)
+	}
+
+	encrypted, err := kvdb.OpenEncryptedBackend(backend, enc)
+	if err != nil {
+		return nil, fmt.Errorf("error opening encrypted %v: %w", ns, err)
+	}
+
+	return encrypted, nil
+}
+
+// readEncryptionKeyFile reads the hex encoded database encryption key from the
+// given file.
+func readEncryptionKeyFile(path string) (*lnencrypt.Encrypter, error) {
+	keyHex, err := os.ReadFile(CleanAndExpandPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading encryption key file: %w",
+			err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(keyHex)))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding encryption key: %w", err)
+	}
+
+	return lnencrypt.KeyEncrypter(key)
+}
+
+// boltMigration describes which bolt database file is copied to which
+// namespace of the SQL backends during a migration.
+type boltMigration struct {
+	// namespace is the namespace of the data in the SQL backends.
+	namespace string
+
+	// dbPath and dbFileName describe the location of the bolt database.
+	dbPath     string
+	dbFileName string
+
+	// sqliteFileName is the name of the sqlite database in dbPath that
+	// holds the namespace.
+	sqliteFileName string
+}
+
+// MigrateFromBolt copies the content of all existing bolt databases to the
+// configured SQL backend in chunks of MigrateChunkSize keys per transaction,
+// using the same namespaces as GetBackends. Each database is verified after
+// it was copied. If the migration is interrupted, it can be resumed by calling
+// this method again. The bolt databases are left untouched and lnd must not be
+// running while they are migrated.
+func (db *DB) MigrateFromBolt(ctx context.Context, chanDBPath, walletDBPath,
+	towerServerDBPath string, logger btclog.Logger) error {
+
+	migrations := []boltMigration{{
+		namespace:      NSChannelDB,
+		dbPath:         chanDBPath,
+		dbFileName:     ChannelDBName,
+		sqliteFileName: SqliteChannelDBName,
+	}, {
+		namespace:      NSMacaroonDB,
+		dbPath:         walletDBPath,
+		dbFileName:     MacaroonDBName,
+		sqliteFileName: SqliteChainDBName,
+	}, {
+		namespace:      NSDecayedLogDB,
+		dbPath:         chanDBPath,
+		dbFileName:     DecayedLogDbName,
+		sqliteFileName: SqliteChannelDBName,
+	}, {
+		namespace:      NSTowerClientDB,
+		dbPath:         chanDBPath,
+		dbFileName:     TowerClientDBName,
+		sqliteFileName: SqliteChannelDBName,
+	}, {
+		namespace:      NSTowerServerDB,
+		dbPath:         towerServerDBPath,
+		dbFileName:     TowerServerDBName,
+		sqliteFileName: SqliteTowerDBName,
+	}, {
+		namespace:      NSWalletDB,
+		dbPath:         walletDBPath,
+		dbFileName:     WalletDBName,
+		sqliteFileName: SqliteChainDBName,
+	}}
+
+	for _, m := range migrations {
+		boltFile := filepath.Join(m.dbPath, m.dbFileName)
+		if !lnrpc.FileExists(boltFile) {
+			logger.Infof("Skipping migration of %v, file not found",
+				boltFile)
+
+			continue
+		}
+
+		logger.Infof("Migrating %v to %v namespace %v", boltFile,
+			db.Backend, m.namespace)
+
+		if err := db.migrateBoltDB(ctx, m, logger); err != nil {
+			return fmt.Errorf("error migrating %v: %w", boltFile,
+				err)
+		}
+
+		logger.Infof("Migrated and verified %v", boltFile)
+	}
+
+	return nil
+}
+
+// migrateBoltDB copies a single bolt database to its SQL namespace.
+func (db *DB) migrateBoltDB(ctx context.Context, m boltMigration,
+	logger btclog.Logger) error {
+
+	src, err := kvdb.GetBoltBackend(&kvdb.BoltBackendConfig{
+		DBPath:         m.dbPath,
+		DBFileName:     m.dbFileName,
+		DBTimeout:      db.Bolt.DBTimeout,
+		NoFreelistSync: db.Bolt.NoFreelistSync,
+	})
+	if err != nil {
+		return fmt.Errorf("error opening bolt DB: %w", err)
+	}
+	defer src.Close()
+
+	var dst kvdb.Backend
+	switch db.Backend {
+	case PostgresBackend:
+		dst, err = kvdb.Open(
+			kvdb.PostgresBackendName, ctx, db.Postgres, m.namespace,
+		)
+
+	case SqliteBackend:
+		dst, err = kvdb.Open(
+			kvdb.SqliteBackendName, ctx, db.Sqlite, m.dbPath,
+			m.sqliteFileName, m.namespace,
+		)
+
+	default:
+		err = fmt.Errorf("unsupported backend %v", db.Backend)
+	}
+	if err != nil {
+		return fmt.Errorf("error opening %v DB: %w", db.Backend, err)
+	}
+	defer dst.Close()
+
+	return kvdb.MigrateBackend(
+		src, dst, db.MigrateChunkSize, func(numCopied uint64) {
+			logger.Debugf("Copied %d items of %v", numCopied,
+				m.namespace)
+		},
+	)
+}
+
 // warnExistingBoltDBs checks if there is an existing bbolt database in the
 // given location and logs a warning if so.
 func warnExistingBoltDBs(log btclog.Logger, dbType, dir, fileName string) {
 	if lnrpc.FileExists(filepath.Join(dir, fileName)) {
 		log.Warnf("Found existing bbolt database file in %s/%s while "+
 			"using database type %s. Existing data will NOT be "+
-			"migrated to %s automatically!", dir, fileName, dbType,
-			dbType)
+			"migrated to %s automatically! Use --db.migrate to "+
+			"copy it.", dir, fileName, dbType, dbType)
 	}
 }
 
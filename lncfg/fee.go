@@ -0,0 +1,52 @@
+package lncfg
+
+import (
+	"fmt"
+	"time"
+)
+
+// Fee holds the configuration options for on-chain fee estimation.
+//
+//nolint:lll
+type Fee struct {
+	Estimator     string `long:"estimator" description:"The name of a custom fee estimator compiled into lnd to use instead of the chain backend and --feeurl. Leave empty to use the default estimator."`
+	EstimatorArgs string `long:"estimatorargs" description:"An opaque argument string passed to the custom fee estimator selected with --fee.estimator."`
+
+	FloorFeeRateSatPerKw uint64 `long:"floorfeerate-satperkw" description:"The lowest fee rate in sat/kw (not sat/vbyte like the other fee rate options) used for fee estimates and accepted for commitment transactions. Overrides the floor of the active chain, which allows using a lower floor on chains with cheaper relay policies. Set to 0 to use the floor of the active chain."`
+
+	SmoothingWindow time.Duration `long:"smoothingwindow" description:"The time window of the exponential moving average applied to fee estimates. Sudden fee spikes reported by the backend only fully take effect after persisting for several windows. Set to 0 to disable smoothing."`
+
+	MinSweepFeeRate uint64 `long:"minsweepfeerate" description:"The minimum fee rate in sat/vbyte used by the sweeper. Set to 0 to disable the limit."`
+	MaxSweepFeeRate uint64 `long:"maxsweepfeerate" description:"The maximum fee rate in sat/vbyte used by the sweeper. Set to 0 to disable the limit."`
+
+	MinCommitFeeRate uint64 `long:"mincommitfeerate" description:"The minimum fee rate in sat/vbyte proposed in commitment fee updates. Set to 0 to disable the limit."`
+	MaxCommitFeeRate uint64 `long:"maxcommitfeerate" description:"The maximum fee rate in sat/vbyte proposed in commitment fee updates. This applies in addition to max-commit-fee-rate-anchors for anchor channels. Set to 0 to disable the limit."`
+
+	MinCoopCloseFeeRate uint64 `long:"mincoopclosefeerate" description:"The minimum fee rate in sat/vbyte used for cooperative closes if no fee rate is specified. Set to 0 to disable the limit."`
+	MaxCoopCloseFeeRate uint64 `long:"maxcoopclosefeerate" description:"The maximum fee rate in sat/vbyte used for cooperative closes if no fee rate is specified. Set to 0 to disable the limit."`
+}
+
+// Validate checks the values configured for fee estimation.
+func (f *Fee) Validate() error {
+	if f.SmoothingWindow < 0 {
+		return fmt.Errorf("smoothingwindow must not be negative")
+	}
+
+	limits := []struct {
+		name     string
+		min, max uint64
+	}{
+		{"sweep", f.MinSweepFeeRate, f.MaxSweepFeeRate},
+		{"commit", f.MinCommitFeeRate, f.MaxCommitFeeRate},
+		{"coopclose", f.MinCoopCloseFeeRate, f.MaxCoopCloseFeeRate},
+	}
+	for _, l := range limits {
+		if l.max != 0 && l.min > l.max {
+			return fmt.Errorf("min%vfeerate (%d) must not be greater "+
+				"than max%vfeerate (%d)", l.name, l.min, l.name,
+				l.max)
+		}
+	}
+
+	return nil
+}
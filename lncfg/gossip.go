@@ -13,11 +13,15 @@ type Gossip struct {
 
 	PinnedSyncers discovery.PinnedSyncers
 
+	HistoricalSyncPeers int `long:"historical-sync-peers" description:"The number of peers that the initial historical graph sync is performed with in parallel. The channels that are unknown to us are split among these peers, so that every channel is only requested from one of them. A value greater than 1 speeds up the initial graph sync of a new node."`
+
 	MaxChannelUpdateBurst int `long:"max-channel-update-burst" description:"The maximum number of updates for a specific channel and direction that lnd will accept over the channel update interval."`
 
 	ChannelUpdateInterval time.Duration `long:"channel-update-interval" description:"The interval used to determine how often lnd should allow a burst of new updates for a specific channel and direction."`
 
 	SubBatchDelay time.Duration `long:"sub-batch-delay" description:"The duration to wait before sending the next announcement batch if there are multiple. Use a small value if there are a lot announcements and they need to be broadcast quickly."`
+
+	NodeAnnRebroadcastInterval time.Duration `long:"node-ann-rebroadcast-interval" description:"The interval after which our own node announcement is refreshed and rebroadcast, so other nodes don't prune us from their graph as a zombie. A random jitter of up to a tenth of the interval is subtracted to spread the rebroadcasts. The node announcement is only rebroadcast if the node has public channels."`
 }
 
 // Parse the pubkeys for the pinned syncers.
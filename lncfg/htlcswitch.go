@@ -11,11 +11,25 @@ var (
 	// where both side send 483 payments at the same time to stress test
 	// lnd.
 	MaxMailboxDeliveryTimeout = 2 * time.Minute
+
+	// MaxReservedHtlcSlots is the max allowed number of commitment slots
+	// reserved for outgoing payments. It is half of the maximum number of
+	// htlcs a single party can offer, as defined in BOLT 02, so that at
+	// least the same number of slots is left for forwards.
+	MaxReservedHtlcSlots uint16 = 483 / 2
 )
 
 //nolint:lll
 type Htlcswitch struct {
 	MailboxDeliveryTimeout time.Duration `long:"mailboxdeliverytimeout" description:"The timeout value when delivering HTLCs to a channel link. Setting this value too small will result in local payment failures if large number of payments are sent over a short period."`
+
+	MaxPendingHtlcsPerPeer uint32 `long:"maxpendinghtlcsperpeer" description:"The maximum number of HTLCs a single peer can have pending in the switch across all of its channels. Further HTLCs forwarded by the peer are failed back. Set to 0 to disable the limit."`
+
+	HtlcAddRatePerPeer float64 `long:"htlcaddrateperpeer" description:"The number of HTLC adds per second a single peer is allowed to forward through our node. Set to 0 to disable the limit."`
+
+	HtlcAddBurstPerPeer uint32 `long:"htlcaddburstperpeer" description:"The number of HTLC adds a single peer is allowed to forward at once on top of htlcaddrateperpeer. Defaults to the number of adds of a single second if not set."`
+
+	ReservedHtlcSlots uint16 `long:"reservedhtlcslots" description:"The number of commitment slots of each channel that are reserved for our own outgoing payments and can't be used by forwarded HTLCs."`
 }
 
 // Validate checks the values configured for htlcswitch.
@@ -30,5 +44,19 @@ func (h *Htlcswitch) Validate() error {
 			MaxMailboxDeliveryTimeout)
 	}
 
+	if h.HtlcAddRatePerPeer < 0 {
+		return fmt.Errorf("htlcaddrateperpeer must not be negative")
+	}
+
+	if h.HtlcAddBurstPerPeer > 0 && h.HtlcAddRatePerPeer == 0 {
+		return fmt.Errorf("htlcaddburstperpeer requires " +
+			"htlcaddrateperpeer to be set")
+	}
+
+	if h.ReservedHtlcSlots > MaxReservedHtlcSlots {
+		return fmt.Errorf("reservedhtlcslots: %v exceeds maximum: %v",
+			h.ReservedHtlcSlots, MaxReservedHtlcSlots)
+	}
+
 	return nil
 }
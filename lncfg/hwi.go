@@ -0,0 +1,78 @@
+package lncfg
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultHwiBinaryPath is the default name of the HWI-compatible
+	// bridge binary, which is looked up in the PATH.
+	DefaultHwiBinaryPath = "hwi"
+
+	// DefaultHwiTimeout is the default time a single bridge command may
+	// take. Signing requires the user to confirm the transaction on the
+	// device, so this needs to be generous.
+	DefaultHwiTimeout = 5 * time.Minute
+
+	// DefaultHwiMaxFee is the default maximum fee in satoshis a single
+	// transaction signed by the device may pay.
+	DefaultHwiMaxFee = 1_000_000
+
+	// DefaultHwiMaxFeeRate is the default maximum fee rate in sat/vbyte a
+	// single transaction signed by the device may pay.
+	DefaultHwiMaxFeeRate = 100
+)
+
+// Hwi holds the configuration options for signing on-chain transactions on a
+// hardware wallet through an HWI-compatible bridge.
+//
+//nolint:lll
+type Hwi struct {
+	Enable            bool          `long:"enable" description:"Sign PSBTs that spend from the hardware wallet's account on the device through an HWI-compatible bridge. The device's account must be imported into the wallet as a watch-only account with the walletrpc ImportAccount call."`
+	BinaryPath        string        `long:"binarypath" description:"The path to the HWI-compatible bridge binary."`
+	Fingerprint       string        `long:"fingerprint" description:"The hex encoded master key fingerprint of the device to sign with."`
+	Timeout           time.Duration `long:"timeout" description:"The maximum time a single bridge command, including the confirmation of a transaction on the device, may take. Valid time units are {s, m, h}."`
+	AllowedAddresses  []string      `long:"allowedaddress" description:"An address the device may pay to without any limit, for example a cold storage address. Can be specified multiple times."`
+	MaxExternalAmount int64         `long:"maxexternalamount" description:"The maximum total amount in satoshis a single transaction signed by the device may pay to outputs that are neither change nor allowed addresses. If this is zero, no such outputs are allowed."`
+	MaxFee            int64         `long:"maxfee" description:"The maximum fee in satoshis a single transaction signed by the device may pay."`
+	MaxFeeRate        uint64        `long:"maxfeerate" description:"The maximum fee rate in sat/vbyte a single transaction signed by the device may pay."`
+}
+
+// Validate checks the values configured for the hardware wallet bridge.
+func (h *Hwi) Validate() error {
+	if !h.Enable {
+		return nil
+	}
+
+	if h.BinaryPath == "" {
+		return fmt.Errorf("hwi: binarypath must be set")
+	}
+
+	if h.Timeout < time.Second {
+		return fmt.Errorf("hwi: timeout of %v is invalid, cannot be "+
+			"smaller than %v", h.Timeout, time.Second)
+	}
+
+	fingerprint, err := hex.DecodeString(h.Fingerprint)
+	if err != nil || len(fingerprint) != 4 {
+		return fmt.Errorf("hwi: fingerprint must be 4 hex encoded "+
+			"bytes, got %q", h.Fingerprint)
+	}
+
+	if h.MaxExternalAmount < 0 {
+		return fmt.Errorf("hwi: maxexternalamount must not be " +
+			"negative")
+	}
+
+	if h.MaxFee <= 0 {
+		return fmt.Errorf("hwi: maxfee must be positive")
+	}
+
+	if h.MaxFeeRate == 0 {
+		return fmt.Errorf("hwi: maxfeerate must be positive")
+	}
+
+	return nil
+}
@@ -11,4 +11,6 @@ const DefaultHoldInvoiceExpiryDelta = DefaultIncomingBroadcastDelta + 2
 //nolint:lll
 type Invoices struct {
 	HoldExpiryDelta uint32 `long:"holdexpirydelta" description:"The number of blocks before a hold invoice's htlc expires that the invoice should be canceled to prevent a force close. Force closes will not be prevented if this value is not greater than DefaultIncomingBroadcastDelta."`
+
+	StrictHopHints bool `long:"stricthophints" description:"If true, the private channels that are included as hop hints in invoices are selected based on a score of their remote balance, the uptime of the peer and the reachability of the peer in the public graph, instead of based on their remote balance alone. Channels that score zero are never included."`
 }
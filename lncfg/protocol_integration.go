@@ -28,6 +28,10 @@ type ProtocolOptions struct {
 	// experimental simple taproot chans commitment type.
 	TaprootChans bool `long:"simple-taproot-chans" description:"if set, then lnd will create and accept requests for channels using the simple taproot commitment type"`
 
+	// RbfCoopClose should be set if we want to signal support for the
+	// experimental RBF based cooperative close negotiation.
+	RbfCoopClose bool `long:"rbf-coop-close" description:"if set, then lnd will use RBF based cooperative close negotiation with peers that support it, which lets either party bump the fee of the close transaction"`
+
 	// Anchors enables anchor commitments.
 	// TODO(halseth): transition itests to anchors instead!
 	Anchors bool `long:"anchors" description:"enable support for anchor commitments"`
@@ -50,6 +54,10 @@ type ProtocolOptions struct {
 	// NoOptionAnySegwit should be set to true if we don't want to use any
 	// Taproot (and beyond) addresses for co-op closing.
 	NoOptionAnySegwit bool `long:"no-any-segwit" description:"disallow using any segiwt witness version as a co-op close address"`
+
+	// NoExperimentalEndorsementOption should be set to true if we don't
+	// want to forward the experimental endorsement signal of htlcs.
+	NoExperimentalEndorsementOption bool `long:"no-experimental-endorsement" description:"do not forward the experimental endorsement signal of htlcs"`
 }
 
 // Wumbo returns true if lnd should permit the creation and acceptance of wumbo
@@ -85,3 +93,9 @@ func (l *ProtocolOptions) ZeroConf() bool {
 func (l *ProtocolOptions) NoAnySegwit() bool {
 	return l.NoOptionAnySegwit
 }
+
+// NoExpEndorsement returns true if we don't forward the experimental
+// endorsement signal of htlcs.
+func (l *ProtocolOptions) NoExpEndorsement() bool {
+	return l.NoExperimentalEndorsementOption
+}
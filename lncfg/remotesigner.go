@@ -25,6 +25,12 @@ type RemoteSigner struct {
 
 // Validate checks the values configured for our remote RPC signer.
 func (r *RemoteSigner) Validate() error {
+	if r.MigrateWatchOnly && !r.Enable {
+		return fmt.Errorf("remote signer: cannot turn on wallet " +
+			"migration to watch-only if remote signing is not " +
+			"enabled")
+	}
+
 	if !r.Enable {
 		return nil
 	}
@@ -35,10 +41,17 @@ func (r *RemoteSigner) Validate() error {
 			time.Millisecond)
 	}
 
-	if r.MigrateWatchOnly && !r.Enable {
-		return fmt.Errorf("remote signer: cannot turn on wallet " +
-			"migration to watch-only if remote signing is not " +
-			"enabled")
+	// All private key operations are forwarded to the remote signer, so
+	// we need to be able to connect to it.
+	switch {
+	case r.RPCHost == "":
+		return fmt.Errorf("remote signer: rpchost must be set")
+
+	case r.MacaroonPath == "":
+		return fmt.Errorf("remote signer: macaroonpath must be set")
+
+	case r.TLSCertPath == "":
+		return fmt.Errorf("remote signer: tlscertpath must be set")
 	}
 
 	return nil
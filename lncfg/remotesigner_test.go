@@ -0,0 +1,96 @@
+package lncfg_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/lncfg"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateRemoteSigner asserts that the remote signer config is only valid
+// if all connection parameters are set when remote signing is enabled.
+func TestValidateRemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	validCfg := func() *lncfg.RemoteSigner {
+		return &lncfg.RemoteSigner{
+			Enable:       true,
+			RPCHost:      "signer.example:10009",
+			MacaroonPath: "/path/to/admin.macaroon",
+			TLSCertPath:  "/path/to/tls.cert",
+			Timeout:      lncfg.DefaultRemoteSignerRPCTimeout,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(cfg *lncfg.RemoteSigner)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(*lncfg.RemoteSigner) {},
+		},
+		{
+			name: "disabled",
+			modify: func(cfg *lncfg.RemoteSigner) {
+				*cfg = lncfg.RemoteSigner{}
+			},
+		},
+		{
+			name: "migrate without remote signing",
+			modify: func(cfg *lncfg.RemoteSigner) {
+				cfg.Enable = false
+				cfg.MigrateWatchOnly = true
+			},
+			err: "cannot turn on wallet migration",
+		},
+		{
+			name: "timeout too small",
+			modify: func(cfg *lncfg.RemoteSigner) {
+				cfg.Timeout = time.Microsecond
+			},
+			err: "timeout of 1µs is invalid",
+		},
+		{
+			name: "missing rpc host",
+			modify: func(cfg *lncfg.RemoteSigner) {
+				cfg.RPCHost = ""
+			},
+			err: "rpchost must be set",
+		},
+		{
+			name: "missing macaroon",
+			modify: func(cfg *lncfg.RemoteSigner) {
+				cfg.MacaroonPath = ""
+			},
+			err: "macaroonpath must be set",
+		},
+		{
+			name: "missing tls cert",
+			modify: func(cfg *lncfg.RemoteSigner) {
+				cfg.TLSCertPath = ""
+			},
+			err: "tlscertpath must be set",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := validCfg()
+			test.modify(cfg)
+
+			err := cfg.Validate()
+			if test.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package lncfg
 
+import "time"
+
 // Routing holds the configuration options for routing.
 //
 //nolint:lll
@@ -7,4 +9,12 @@ type Routing struct {
 	AssumeChannelValid bool `long:"assumechanvalid" description:"Skip checking channel spentness during graph validation. This speedup comes at the risk of using an unvalidated view of the network for routing. (default: false)"`
 
 	StrictZombiePruning bool `long:"strictgraphpruning" description:"If true, then the graph will be pruned more aggressively for zombies. In practice this means that edges with a single stale edge will be considered a zombie."`
+
+	ZombieExpiry time.Duration `long:"zombieexpiry" description:"The duration after which a channel without any recent updates is considered a zombie and pruned from the graph."`
+
+	MaxChannelsPerNode uint32 `long:"maxchannelspernode" description:"The maximum number of channels kept in the graph for any single remote node. If a node has more channels, the least recently updated ones are pruned when the graph is garbage collected and are only accepted again once the node is back under the limit. Our own channels are never pruned. Set to 0 to keep all channels."`
+
+	MaxConcurrentPayments uint32 `long:"maxconcurrentpayments" description:"The maximum number of outgoing payments that are executed concurrently. Additional payments are queued and executed in the order in which they were dispatched once a running payment completes. Set to 0 to not limit the number of concurrent payments."`
+
+	MaxQueuedPayments uint32 `long:"maxqueuedpayments" description:"The maximum number of payments that may wait for a running payment to complete. Payments dispatched while the queue is full are rejected. Set to 0 to not limit the number of queued payments."`
 }
@@ -5,9 +5,17 @@ import (
 	"time"
 )
 
+// maxStandardTxWeight is the maximum weight of a transaction that is still
+// relayed by nodes with a default mempool policy.
+const maxStandardTxWeight = 400_000
+
 //nolint:lll
 type Sweeper struct {
 	BatchWindowDuration time.Duration `long:"batchwindowduration" description:"Duration of the sweep batch window. The sweep is held back during the batch window to allow more inputs to be added and thereby lower the fee per input."`
+
+	MaxInputsPerTx int `long:"maxinputspertx" description:"The maximum number of inputs in a single sweep transaction. If more inputs need to be swept, they are split across multiple transactions."`
+
+	MaxSweepWeight int64 `long:"maxsweepweight" description:"The maximum weight of a single sweep transaction in weight units. Inputs that don't fit are swept in additional transactions. Must not exceed the standardness limit of 400000."`
 }
 
 // Validate checks the values configured for the sweeper.
@@ -16,5 +24,14 @@ func (s *Sweeper) Validate() error {
 		return fmt.Errorf("batchwindowduration must be positive")
 	}
 
+	if s.MaxInputsPerTx <= 0 {
+		return fmt.Errorf("maxinputspertx must be positive")
+	}
+
+	if s.MaxSweepWeight <= 0 || s.MaxSweepWeight > maxStandardTxWeight {
+		return fmt.Errorf("maxsweepweight must be in the range "+
+			"(0, %v]", maxStandardTxWeight)
+	}
+
 	return nil
 }
@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"net/http/pprof"
 	"os"
+	"path/filepath"
 	"runtime"
 	runtimePprof "runtime/pprof"
 	"strings"
@@ -254,6 +255,27 @@ func Main(cfg *Config, lisCfg ListenerCfg, implCfg *ImplementationCfg,
 		return mkErr("error initializing DBs: %v", err)
 	}
 
+	// If requested, we only copy the existing bolt databases to the
+	// configured SQL backend and exit afterwards.
+	if cfg.DB.Migrate {
+		err := cfg.DB.MigrateFromBolt(
+			ctx, cfg.graphDatabaseDir(), cfg.networkDir,
+			filepath.Join(
+				cfg.Watchtower.TowerDir,
+				cfg.registeredChains.PrimaryChain().String(),
+				lncfg.NormalizeNetwork(cfg.ActiveNetParams.Name),
+			), ltndLog,
+		)
+		if err != nil {
+			return mkErr("error migrating DBs: %v", err)
+		}
+
+		ltndLog.Infof("Database migration complete, restart lnd " +
+			"without --db.migrate to use the migrated data")
+
+		return nil
+	}
+
 	tlsManagerCfg := &TLSManagerCfg{
 		TLSCertPath:        cfg.TLSCertPath,
 		TLSKeyPath:         cfg.TLSKeyPath,
@@ -450,6 +472,16 @@ func Main(cfg *Config, lisCfg ListenerCfg, implCfg *ImplementationCfg,
 
 	defer cleanUp()
 
+	// Now that the wallet is unlocked, the databases that are encrypted
+	// with a key derived from the seed can be opened.
+	dbs.DecayedLogDB, err = cfg.DB.EncryptWithKeyRing(
+		lncfg.NSDecayedLogDB, dbs.DecayedLogDB,
+		activeChainControl.KeyRing,
+	)
+	if err != nil {
+		return mkErr("unable to encrypt database: %v", err)
+	}
+
 	// Finally before we start the server, we'll register the "holy
 	// trinity" of interface for our current "home chain" with the active
 	// chainRegistry interface.
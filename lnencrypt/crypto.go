@@ -1,6 +1,7 @@
 package lnencrypt
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"fmt"
@@ -69,6 +70,42 @@ func KeyRingEncrypter(keyRing keychain.KeyRing) (*Encrypter, error) {
 	}, nil
 }
 
+// KeyEncrypter returns an Encrypter that uses the given raw key, for example
+// one that is provided by an external key management system.
+func KeyEncrypter(key []byte) (*Encrypter, error) {
+	if len(key) != chacha20poly1305.KeySize {
+		return nil, fmt.Errorf("invalid encryption key length %d, "+
+			"expected %d", len(key), chacha20poly1305.KeySize)
+	}
+
+	encryptionKey := make([]byte, len(key))
+	copy(encryptionKey, key)
+
+	return &Encrypter{
+		encryptionKey: encryptionKey,
+	}, nil
+}
+
+// Encrypt returns the encrypted form of the given payload. The associated data
+// isn't encrypted but authenticated together with the payload, so the
+// ciphertext can only be decrypted with the same associated data. This allows
+// the Encrypter to be used as a kvdb.ValueEncrypter.
+func (e Encrypter) Encrypt(payload, ad []byte) ([]byte, error) {
+	var b bytes.Buffer
+	if err := e.encryptToWriter(payload, ad, &b); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// Decrypt returns the plaintext of the given encrypted payload that was
+// encrypted with the given associated data. This allows the Encrypter to be
+// used as a kvdb.ValueEncrypter.
+func (e Encrypter) Decrypt(payload, ad []byte) ([]byte, error) {
+	return e.decrypt(payload, ad)
+}
+
 // EncryptPayloadToWriter attempts to write the set of provided bytes into the
 // passed io.Writer in an encrypted form. We use a 24-byte chachapoly AEAD
 // instance with a randomized nonce that's pre-pended to the final payload and
@@ -76,6 +113,13 @@ func KeyRingEncrypter(keyRing keychain.KeyRing) (*Encrypter, error) {
 func (e Encrypter) EncryptPayloadToWriter(payload []byte,
 	w io.Writer) error {
 
+	return e.encryptToWriter(payload, nil, w)
+}
+
+// encryptToWriter writes the encrypted payload into the passed io.Writer. The
+// random nonce followed by the given associated data is used as the associated
+// data of the AEAD.
+func (e Encrypter) encryptToWriter(payload, ad []byte, w io.Writer) error {
 	// Before encryption, we'll initialize our cipher with the target
 	// encryption key, and also read out our random 24-byte nonce we use
 	// for encryption. Note that we use NewX, not New, as the latter
@@ -91,7 +135,9 @@ func (e Encrypter) EncryptPayloadToWriter(payload []byte,
 
 	// Finally, we encrypted the final payload, and write out our
 	// ciphertext with nonce pre-pended.
-	ciphertext := cipher.Seal(nil, nonce[:], payload, nonce[:])
+	ciphertext := cipher.Seal(
+		nil, nonce[:], payload, associatedData(nonce[:], ad),
+	)
 
 	if _, err := w.Write(nonce[:]); err != nil {
 		return err
@@ -116,6 +162,13 @@ func (e Encrypter) DecryptPayloadFromReader(payload io.Reader) ([]byte,
 	if err != nil {
 		return nil, err
 	}
+
+	return e.decrypt(packedPayload, nil)
+}
+
+// decrypt decrypts the given nonce prefixed ciphertext that was encrypted with
+// the given associated data.
+func (e Encrypter) decrypt(packedPayload, ad []byte) ([]byte, error) {
 	if len(packedPayload) < chacha20poly1305.NonceSizeX {
 		return nil, fmt.Errorf("payload size too small, must be at "+
 			"least %v bytes", chacha20poly1305.NonceSizeX)
@@ -130,10 +183,18 @@ func (e Encrypter) DecryptPayloadFromReader(payload io.Reader) ([]byte,
 	if err != nil {
 		return nil, err
 	}
-	plaintext, err := cipher.Open(nil, nonce, ciphertext, nonce)
+	plaintext, err := cipher.Open(
+		nil, nonce, ciphertext, associatedData(nonce, ad),
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	return plaintext, nil
 }
+
+// associatedData returns the associated data of the AEAD, which is the nonce
+// followed by the given associated data of the payload.
+func associatedData(nonce, ad []byte) []byte {
+	return append(append([]byte{}, nonce...), ad...)
+}
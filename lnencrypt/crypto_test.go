@@ -115,3 +115,48 @@ func TestInvalidKeyGeneration(t *testing.T) {
 		t.Fatal("expected error due to fail key gen")
 	}
 }
+
+// TestKeyEncrypter tests that an Encrypter can be created from a raw key and
+// that its payloads can only be decrypted with the same key and associated
+// data.
+func TestKeyEncrypter(t *testing.T) {
+	t.Parallel()
+
+	_, err := KeyEncrypter(make([]byte, 16))
+	if err == nil {
+		t.Fatal("expected error due to invalid key length")
+	}
+
+	key := bytes.Repeat([]byte{1}, 32)
+	encrypter, err := KeyEncrypter(key)
+	if err != nil {
+		t.Fatalf("unable to create encrypter: %v", err)
+	}
+
+	ad := []byte("associated data")
+	ciphertext, err := encrypter.Encrypt([]byte("payload"), ad)
+	if err != nil {
+		t.Fatalf("unable to encrypt payload: %v", err)
+	}
+
+	plaintext, err := encrypter.Decrypt(ciphertext, ad)
+	if err != nil {
+		t.Fatalf("unable to decrypt payload: %v", err)
+	}
+	if !bytes.Equal(plaintext, []byte("payload")) {
+		t.Fatalf("expected payload, got %x", plaintext)
+	}
+
+	otherEncrypter, err := KeyEncrypter(bytes.Repeat([]byte{2}, 32))
+	if err != nil {
+		t.Fatalf("unable to create encrypter: %v", err)
+	}
+	if _, err := otherEncrypter.Decrypt(ciphertext, ad); err == nil {
+		t.Fatal("expected error decrypting with wrong key")
+	}
+
+	// The ciphertext must not be accepted with other associated data.
+	if _, err := encrypter.Decrypt(ciphertext, nil); err == nil {
+		t.Fatal("expected error decrypting with wrong associated data")
+	}
+}
@@ -7,14 +7,17 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/ltcsuite/lnd/chainntnfs"
 	"github.com/ltcsuite/lnd/lnrpc"
+	"github.com/ltcsuite/lnd/lnwallet/chainfee"
 	"github.com/ltcsuite/lnd/macaroons"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
 	"github.com/ltcsuite/ltcd/wire"
@@ -58,6 +61,10 @@ var (
 			Entity: "onchain",
 			Action: "read",
 		}},
+		"/chainrpc.ChainKit/GetFeeEstimates": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
 		"/chainrpc.ChainNotifier/RegisterConfirmationsNtfn": {{
 			Entity: "onchain",
 			Action: "read",
@@ -77,6 +84,11 @@ var (
 	// main configuration file in this package.
 	DefaultChainNotifierMacFilename = "chainnotifier.macaroon"
 
+	// defaultFeeEstimateConfTargets are the conf targets returned by
+	// GetFeeEstimates if neither the request nor the fee estimator specify
+	// any.
+	defaultFeeEstimateConfTargets = []uint32{1, 2, 3, 6, 12, 24, 144, 1008}
+
 	// ErrChainNotifierServerShuttingDown is an error returned when we are
 	// waiting for a notification to arrive but the chain notifier server
 	// has been shut down.
@@ -353,6 +365,55 @@ func (s *Server) GetBlockHash(_ context.Context,
 	}, nil
 }
 
+// GetFeeEstimates returns the fee rates of the active fee estimator for a set
+// of confirmation targets, together with the minimum relay fee and a
+// description of the estimator's source.
+func (s *Server) GetFeeEstimates(_ context.Context,
+	req *GetFeeEstimatesRequest) (*GetFeeEstimatesResponse, error) {
+
+	if s.cfg.FeeEstimator == nil {
+		return nil, errors.New("no fee estimator available")
+	}
+
+	info := chainfee.Describe(s.cfg.FeeEstimator)
+
+	// Use the requested conf targets if any, otherwise fall back to the
+	// ones cached by the estimator or our defaults.
+	confTargets := req.ConfTargets
+	if len(confTargets) == 0 {
+		confTargets = info.CachedConfTargets
+	}
+	if len(confTargets) == 0 {
+		confTargets = defaultFeeEstimateConfTargets
+	}
+
+	confTargets = append([]uint32(nil), confTargets...)
+	sort.Slice(confTargets, func(i, j int) bool {
+		return confTargets[i] < confTargets[j]
+	})
+
+	feeEstimates := make([]*FeeEstimate, 0, len(confTargets))
+	for _, confTarget := range confTargets {
+		feeRate, err := s.cfg.FeeEstimator.EstimateFeePerKW(confTarget)
+		if err != nil {
+			return nil, fmt.Errorf("unable to estimate fee rate for "+
+				"conf target %d: %w", confTarget, err)
+		}
+
+		feeEstimates = append(feeEstimates, &FeeEstimate{
+			ConfTarget:  confTarget,
+			SatPerKw:    uint64(feeRate),
+			SatPerVbyte: uint64(feeRate.FeePerVByte()),
+		})
+	}
+
+	return &GetFeeEstimatesResponse{
+		FeeEstimates:        feeEstimates,
+		MinRelayFeeSatPerKw: uint64(s.cfg.FeeEstimator.RelayFeePerKW()),
+		Source:              info.Source,
+	}, nil
+}
+
 // RegisterConfirmationsNtfn is a synchronous response-streaming RPC that
 // registers an intent for a client to be notified once a confirmation request
 // has reached its required number of confirmations on-chain.
@@ -32,6 +32,11 @@ type ChainKitClient interface {
 	// GetBlockHash returns the hash of the block in the best blockchain
 	// at the given height.
 	GetBlockHash(ctx context.Context, in *GetBlockHashRequest, opts ...grpc.CallOption) (*GetBlockHashResponse, error)
+	// lncli: `chain getfeeestimates`
+	// GetFeeEstimates returns the fee rates of the active fee estimator for a set
+	// of confirmation targets, together with the minimum relay fee and a
+	// description of the estimator's source.
+	GetFeeEstimates(ctx context.Context, in *GetFeeEstimatesRequest, opts ...grpc.CallOption) (*GetFeeEstimatesResponse, error)
 }
 
 type chainKitClient struct {
@@ -78,6 +83,15 @@ func (c *chainKitClient) GetBlockHash(ctx context.Context, in *GetBlockHashReque
 	return out, nil
 }
 
+func (c *chainKitClient) GetFeeEstimates(ctx context.Context, in *GetFeeEstimatesRequest, opts ...grpc.CallOption) (*GetFeeEstimatesResponse, error) {
+	out := new(GetFeeEstimatesResponse)
+	err := c.cc.Invoke(ctx, "/chainrpc.ChainKit/GetFeeEstimates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChainKitServer is the server API for ChainKit service.
 // All implementations must embed UnimplementedChainKitServer
 // for forward compatibility
@@ -96,6 +110,11 @@ type ChainKitServer interface {
 	// GetBlockHash returns the hash of the block in the best blockchain
 	// at the given height.
 	GetBlockHash(context.Context, *GetBlockHashRequest) (*GetBlockHashResponse, error)
+	// lncli: `chain getfeeestimates`
+	// GetFeeEstimates returns the fee rates of the active fee estimator for a set
+	// of confirmation targets, together with the minimum relay fee and a
+	// description of the estimator's source.
+	GetFeeEstimates(context.Context, *GetFeeEstimatesRequest) (*GetFeeEstimatesResponse, error)
 	mustEmbedUnimplementedChainKitServer()
 }
 
@@ -115,6 +134,9 @@ func (UnimplementedChainKitServer) GetBestBlock(context.Context, *GetBestBlockRe
 func (UnimplementedChainKitServer) GetBlockHash(context.Context, *GetBlockHashRequest) (*GetBlockHashResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockHash not implemented")
 }
+func (UnimplementedChainKitServer) GetFeeEstimates(context.Context, *GetFeeEstimatesRequest) (*GetFeeEstimatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeeEstimates not implemented")
+}
 func (UnimplementedChainKitServer) mustEmbedUnimplementedChainKitServer() {}
 
 // UnsafeChainKitServer may be embedded to opt out of forward compatibility for this service.
@@ -200,6 +222,24 @@ func _ChainKit_GetBlockHash_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChainKit_GetFeeEstimates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeeEstimatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainKitServer).GetFeeEstimates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chainrpc.ChainKit/GetFeeEstimates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainKitServer).GetFeeEstimates(ctx, req.(*GetFeeEstimatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChainKit_ServiceDesc is the grpc.ServiceDesc for ChainKit service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -223,6 +263,10 @@ var ChainKit_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockHash",
 			Handler:    _ChainKit_GetBlockHash_Handler,
 		},
+		{
+			MethodName: "GetFeeEstimates",
+			Handler:    _ChainKit_GetFeeEstimates_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "chainrpc/chainkit.proto",
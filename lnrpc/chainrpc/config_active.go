@@ -6,6 +6,7 @@ package chainrpc
 import (
 	"github.com/ltcsuite/lnd/chainntnfs"
 	"github.com/ltcsuite/lnd/lnwallet"
+	"github.com/ltcsuite/lnd/lnwallet/chainfee"
 	"github.com/ltcsuite/lnd/macaroons"
 )
 
@@ -36,4 +37,8 @@ type Config struct {
 
 	// Chain provides access to the most up-to-date blockchain data.
 	Chain lnwallet.BlockChainIO
+
+	// FeeEstimator is the active fee estimator whose fee rates are exposed
+	// through the GetFeeEstimates call.
+	FeeEstimator chainfee.Estimator
 }
@@ -12,12 +12,15 @@ import (
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/ltcsuite/lnd/chanfitness"
 	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/lnd/invoices"
+	"github.com/ltcsuite/lnd/kvdb"
 	"github.com/ltcsuite/lnd/lntypes"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/netann"
 	"github.com/ltcsuite/lnd/routing"
+	"github.com/ltcsuite/lnd/routing/route"
 	"github.com/ltcsuite/lnd/zpay32"
 	"github.com/ltcsuite/ltcd/btcec/v2"
 	"github.com/ltcsuite/ltcd/chaincfg"
@@ -42,6 +45,16 @@ const (
 	// maxHopHints is the maximum number of hint paths that will be included
 	// in an invoice.
 	maxHopHints = 20
+
+	// hopHintReachableNodes is the number of nodes other than ourselves
+	// that a peer needs to have channels with to be considered fully
+	// reachable when scoring hop hints.
+	hopHintReachableNodes = 5
+
+	// unknownUptimeScore is the uptime score that is assigned to channels
+	// for which no uptime has been recorded yet. We don't want to exclude
+	// new channels, but prefer channels with a proven track record.
+	unknownUptimeScore = 0.5
 )
 
 // AddInvoiceConfig contains dependencies for invoice creation.
@@ -83,6 +96,15 @@ type AddInvoiceConfig struct {
 	// GetAlias allows the peer's alias SCID to be retrieved for private
 	// option_scid_alias channels.
 	GetAlias func(lnwire.ChannelID) (lnwire.ShortChannelID, error)
+
+	// StrictHopHints indicates whether hop hints should be selected based
+	// on their score rather than their remote balance.
+	StrictHopHints bool
+
+	// GetChanInfo returns the uptime information that has been recorded
+	// for a channel. It is used to score hop hints.
+	GetChanInfo func(wire.OutPoint, route.Vertex) (*chanfitness.ChannelInfo,
+		error)
 }
 
 // AddInvoiceData contains the required data to create a new invoice.
@@ -645,6 +667,21 @@ type SelectHopHintsCfg struct {
 
 	// MaxHopHints is the maximum number of hop hints we are interested in.
 	MaxHopHints int
+
+	// StrictSelection indicates whether the potential hints should be
+	// ordered by their score rather than their remote balance. Channels
+	// with a zero score are not considered in this case.
+	StrictSelection bool
+
+	// GetChanInfo returns the uptime information that has been recorded
+	// for a channel. If nil, all channels are assumed to have an unknown
+	// uptime.
+	GetChanInfo func(wire.OutPoint, route.Vertex) (*chanfitness.ChannelInfo,
+		error)
+
+	// ForEachNodeChannel iterates through all channels of the given node.
+	ForEachNodeChannel func(tx kvdb.RTx, node route.Vertex,
+		cb func(channel *channeldb.DirectedChannel) error) error
 }
 
 func newSelectHopHintsCfg(invoicesCfg *AddInvoiceConfig,
@@ -657,6 +694,9 @@ func newSelectHopHintsCfg(invoicesCfg *AddInvoiceConfig,
 		FetchChannelEdgesByID: invoicesCfg.Graph.FetchChannelEdgesByID,
 		GetAlias:              invoicesCfg.GetAlias,
 		MaxHopHints:           maxHopHints,
+		StrictSelection:       invoicesCfg.StrictHopHints,
+		GetChanInfo:           invoicesCfg.GetChanInfo,
+		ForEachNodeChannel:    invoicesCfg.Graph.ForEachNodeChannel,
 	}
 }
 
@@ -718,6 +758,138 @@ func getPotentialHints(cfg *SelectHopHintsCfg) ([]*channeldb.OpenChannel,
 	return privateChannels, nil
 }
 
+// hopHintScore returns a score in the range [0, 1] that expresses how suitable
+// a channel is as a hop hint for an invoice of the given amount. The score is
+// the product of the following factors:
+//   - Balance: the fraction of the invoice amount that the remote balance can
+//     carry. For zero amount invoices, any non-zero balance is sufficient.
+//   - Uptime: the fraction of the channel's lifetime that the peer was seen
+//     online.
+//   - Reachability: the number of other nodes the peer has channels with,
+//     relative to hopHintReachableNodes.
+func hopHintScore(remoteBalance, amt lnwire.MilliSatoshi, uptime float64,
+	numPeerNodes int) float64 {
+
+	var balance float64
+	switch {
+	case remoteBalance == 0:
+		balance = 0
+
+	case amt == 0 || remoteBalance >= amt:
+		balance = 1
+
+	default:
+		balance = float64(remoteBalance) / float64(amt)
+	}
+
+	reachability := math.Min(
+		float64(numPeerNodes)/hopHintReachableNodes, 1,
+	)
+
+	return balance * uptime * reachability
+}
+
+// chanUptime returns the fraction of the channel's lifetime that the peer was
+// online. If no uptime is known for the channel, unknownUptimeScore is
+// returned.
+func chanUptime(cfg *SelectHopHintsCfg,
+	channel *channeldb.OpenChannel) float64 {
+
+	if cfg.GetChanInfo == nil {
+		return unknownUptimeScore
+	}
+
+	info, err := cfg.GetChanInfo(
+		channel.FundingOutpoint, route.NewVertex(channel.IdentityPub),
+	)
+	if err != nil {
+		log.Debugf("Unable to fetch uptime of channel %v: %v",
+			channel.FundingOutpoint, err)
+
+		return unknownUptimeScore
+	}
+
+	if info.Lifetime == 0 {
+		return unknownUptimeScore
+	}
+
+	return math.Min(float64(info.Uptime)/float64(info.Lifetime), 1)
+}
+
+// peerNodeCount returns the number of nodes other than ourselves that the peer
+// has channels with.
+func peerNodeCount(cfg *SelectHopHintsCfg, peer route.Vertex) (int, error) {
+	nodes := make(map[route.Vertex]struct{})
+	err := cfg.ForEachNodeChannel(nil, peer,
+		func(channel *channeldb.DirectedChannel) error {
+			nodes[channel.OtherNode] = struct{}{}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// Our own node is always among the nodes, as the peer has a channel
+	// with us.
+	if len(nodes) == 0 {
+		return 0, nil
+	}
+
+	return len(nodes) - 1, nil
+}
+
+// scorePotentialHints orders the potential hints by descending score for an
+// invoice of the given amount. Channels with a zero score are dropped.
+func scorePotentialHints(cfg *SelectHopHintsCfg, amt lnwire.MilliSatoshi,
+	potentialHints []*channeldb.OpenChannel) ([]*channeldb.OpenChannel,
+	error) {
+
+	// Cache the reachability per peer, as we may have multiple channels
+	// with the same peer.
+	peerNodes := make(map[route.Vertex]int)
+
+	scores := make(map[*channeldb.OpenChannel]float64, len(potentialHints))
+	scored := make([]*channeldb.OpenChannel, 0, len(potentialHints))
+	for _, channel := range potentialHints {
+		peer := route.NewVertex(channel.IdentityPub)
+		numNodes, ok := peerNodes[peer]
+		if !ok {
+			var err error
+			numNodes, err = peerNodeCount(cfg, peer)
+			if err != nil {
+				return nil, err
+			}
+			peerNodes[peer] = numNodes
+		}
+
+		score := hopHintScore(
+			channel.LocalCommitment.RemoteBalance, amt,
+			chanUptime(cfg, channel), numNodes,
+		)
+
+		log.Tracef("Hop hint score of channel %v: %v",
+			channel.ShortChannelID, score)
+
+		if score == 0 {
+			continue
+		}
+
+		scores[channel] = score
+		scored = append(scored, channel)
+	}
+
+	// Sort the channels in descending score. A stable sort is used to
+	// retain the ordering by remote balance for channels with equal
+	// scores.
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scores[scored[i]] > scores[scored[j]]
+	})
+
+	return scored, nil
+}
+
 // shouldIncludeChannel returns true if the channel passes all the checks to
 // be a hopHint in a given invoice.
 func shouldIncludeChannel(cfg *SelectHopHintsCfg,
@@ -830,6 +1002,15 @@ func PopulateHopHints(cfg *SelectHopHintsCfg, amtMSat lnwire.MilliSatoshi,
 		return nil, err
 	}
 
+	if cfg.StrictSelection {
+		potentialHints, err = scorePotentialHints(
+			cfg, amtMSat, potentialHints,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	targetBandwidth := amtMSat * hopHintFactor
 	selectedHints := selectHopHints(
 		cfg, nHintsLeft, targetBandwidth, potentialHints,
@@ -2,11 +2,16 @@ package invoicesrpc
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/ltcsuite/lnd/chanfitness"
 	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/kvdb"
 	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/routing/route"
 	"github.com/ltcsuite/lnd/zpay32"
 	"github.com/ltcsuite/ltcd/btcec/v2"
 	"github.com/ltcsuite/ltcd/wire"
@@ -885,3 +890,150 @@ func TestPopulateHopHints(t *testing.T) {
 		})
 	}
 }
+
+var hopHintScoreTestCases = []struct {
+	name          string
+	remoteBalance lnwire.MilliSatoshi
+	amt           lnwire.MilliSatoshi
+	uptime        float64
+	numPeerNodes  int
+	score         float64
+}{{
+	name:          "sufficient balance, full uptime and reachability",
+	remoteBalance: 2000,
+	amt:           1000,
+	uptime:        1,
+	numPeerNodes:  hopHintReachableNodes,
+	score:         1,
+}, {
+	name:          "insufficient balance",
+	remoteBalance: 500,
+	amt:           1000,
+	uptime:        1,
+	numPeerNodes:  hopHintReachableNodes * 2,
+	score:         0.5,
+}, {
+	name:          "zero amount invoice",
+	remoteBalance: 1,
+	amt:           0,
+	uptime:        0.5,
+	numPeerNodes:  hopHintReachableNodes,
+	score:         0.5,
+}, {
+	name:          "no remote balance",
+	remoteBalance: 0,
+	amt:           0,
+	uptime:        1,
+	numPeerNodes:  hopHintReachableNodes,
+	score:         0,
+}, {
+	name:          "peer never online",
+	remoteBalance: 1000,
+	amt:           1000,
+	uptime:        0,
+	numPeerNodes:  hopHintReachableNodes,
+	score:         0,
+}, {
+	name:          "peer only connected to us",
+	remoteBalance: 1000,
+	amt:           1000,
+	uptime:        1,
+	numPeerNodes:  0,
+	score:         0,
+}, {
+	name:          "partial reachability",
+	remoteBalance: 1000,
+	amt:           1000,
+	uptime:        1,
+	numPeerNodes:  1,
+	score:         1.0 / hopHintReachableNodes,
+}}
+
+func TestHopHintScore(t *testing.T) {
+	for _, tc := range hopHintScoreTestCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			score := hopHintScore(
+				tc.remoteBalance, tc.amt, tc.uptime,
+				tc.numPeerNodes,
+			)
+			require.InDelta(t, tc.score, score, 1e-9)
+		})
+	}
+}
+
+// TestScorePotentialHints tests that potential hints are ordered by their
+// score and that channels with a zero score are dropped.
+func TestScorePotentialHints(t *testing.T) {
+	t.Parallel()
+
+	newChannel := func(scid uint64,
+		remoteBalance lnwire.MilliSatoshi) *channeldb.OpenChannel {
+
+		return &channeldb.OpenChannel{
+			LocalCommitment: channeldb.ChannelCommitment{
+				RemoteBalance: remoteBalance,
+			},
+			FundingOutpoint: wire.OutPoint{Index: uint32(scid)},
+			ShortChannelID:  lnwire.NewShortChanIDFromInt(scid),
+			IdentityPub:     getTestPubKey(),
+		}
+	}
+
+	// Channel 1 has the highest balance, but its peer has never been
+	// online. Channel 2 has no uptime information yet, and channel 3 has
+	// a proven track record.
+	chan1 := newChannel(1, 10_000)
+	chan2 := newChannel(2, 5_000)
+	chan3 := newChannel(3, 5_000)
+
+	uptimes := map[wire.OutPoint]*chanfitness.ChannelInfo{
+		chan1.FundingOutpoint: {
+			Lifetime: time.Hour,
+		},
+		chan3.FundingOutpoint: {
+			Lifetime: time.Hour,
+			Uptime:   time.Hour,
+		},
+	}
+
+	cfg := &SelectHopHintsCfg{
+		StrictSelection: true,
+		GetChanInfo: func(chanPoint wire.OutPoint,
+			_ route.Vertex) (*chanfitness.ChannelInfo, error) {
+
+			info, ok := uptimes[chanPoint]
+			if !ok {
+				return nil, errors.New("unknown channel")
+			}
+
+			return info, nil
+		},
+		ForEachNodeChannel: func(_ kvdb.RTx, _ route.Vertex,
+			cb func(*channeldb.DirectedChannel) error) error {
+
+			// Report channels with ourselves and enough other
+			// nodes to make the peer fully reachable.
+			for i := 0; i <= hopHintReachableNodes; i++ {
+				err := cb(&channeldb.DirectedChannel{
+					ChannelID: uint64(i),
+					OtherNode: route.Vertex{byte(i)},
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	scored, err := scorePotentialHints(
+		cfg, 5_000, []*channeldb.OpenChannel{chan1, chan2, chan3},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []*channeldb.OpenChannel{chan3, chan2}, scored)
+}
@@ -4,12 +4,15 @@
 package invoicesrpc
 
 import (
+	"github.com/ltcsuite/lnd/chanfitness"
 	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/lnd/invoices"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/macaroons"
 	"github.com/ltcsuite/lnd/netann"
+	"github.com/ltcsuite/lnd/routing/route"
 	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/wire"
 )
 
 // Config is the primary configuration struct for the invoices RPC server. It
@@ -64,4 +67,13 @@ type Config struct {
 	// GetAlias returns the peer's alias SCID if it exists given the
 	// 32-byte ChannelID.
 	GetAlias func(lnwire.ChannelID) (lnwire.ShortChannelID, error)
+
+	// StrictHopHints indicates whether the hop hints of invoices should be
+	// selected based on their score rather than their remote balance.
+	StrictHopHints bool
+
+	// GetChanInfo returns the uptime information that has been recorded
+	// for a channel.
+	GetChanInfo func(wire.OutPoint, route.Vertex) (*chanfitness.ChannelInfo,
+		error)
 }
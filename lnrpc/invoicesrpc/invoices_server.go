@@ -333,6 +333,8 @@ func (s *Server) AddHoldInvoice(ctx context.Context,
 		GenInvoiceFeatures:    s.cfg.GenInvoiceFeatures,
 		GenAmpInvoiceFeatures: s.cfg.GenAmpInvoiceFeatures,
 		GetAlias:              s.cfg.GetAlias,
+		StrictHopHints:        s.cfg.StrictHopHints,
+		GetChanInfo:           s.cfg.GetChanInfo,
 	}
 
 	hash, err := lntypes.MakeHash(invoice.Hash)
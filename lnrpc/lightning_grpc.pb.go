@@ -113,10 +113,21 @@ type LightningClient interface {
 	// workflow and is waiting for confirmations for the funding txn, or is in the
 	// process of closure, either initiated cooperatively or non-cooperatively.
 	PendingChannels(ctx context.Context, in *PendingChannelsRequest, opts ...grpc.CallOption) (*PendingChannelsResponse, error)
+	// lncli: `importpreimage`
+	// ImportPreimage adds a preimage that was obtained out-of-band to the
+	// preimage cache, so pending incoming HTLCs of force closed channels can be
+	// claimed on-chain before they time out. An error is returned if no active
+	// resolver is waiting for the preimage.
+	ImportPreimage(ctx context.Context, in *ImportPreimageRequest, opts ...grpc.CallOption) (*ImportPreimageResponse, error)
 	// lncli: `listchannels`
 	// ListChannels returns a description of all the open channels that this node
 	// is a participant in.
 	ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error)
+	// lncli: `dustexposure`
+	// DustExposure returns the current dust exposure of all active channels. The
+	// dust exposure of a channel is the sum of its dust HTLCs, which would be
+	// burned to fees if one of the channel's commitments confirmed.
+	DustExposure(ctx context.Context, in *DustExposureRequest, opts ...grpc.CallOption) (*DustExposureResponse, error)
 	// SubscribeChannelEvents creates a uni-directional stream from the server to
 	// the client in which any updates relevant to the state of the channels are
 	// sent over. Events include new active channels, inactive channels, and closed
@@ -285,6 +296,12 @@ type LightningClient interface {
 	// GetNetworkInfo returns some basic stats about the known channel graph from
 	// the point of view of the node.
 	GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error)
+	// lncli: `gcgraph`
+	// GarbageCollectGraph forces a garbage collection pass over the channel
+	// graph, pruning zombie channels and the channels of nodes that exceed the
+	// configured maximum number of channels per node. The same pass also runs
+	// periodically in the background, as configured by gc-graph-interval.
+	GarbageCollectGraph(ctx context.Context, in *GarbageCollectGraphRequest, opts ...grpc.CallOption) (*GarbageCollectGraphResponse, error)
 	// lncli: `stop`
 	// StopDaemon will send a shutdown request to the interrupt handler, triggering
 	// a graceful shutdown of the daemon.
@@ -355,6 +372,14 @@ type LightningClient interface {
 	// ups, but the updated set of encrypted multi-chan backups with the closed
 	// channel(s) removed.
 	SubscribeChannelBackups(ctx context.Context, in *ChannelBackupSubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelBackupsClient, error)
+	// lncli: `backupdatabase`
+	// BackupDatabase streams a consistent snapshot of the live channel database
+	// in chunks, so a hot backup can be taken without stopping lnd. For bolt
+	// databases the result is a regular channel.db file. This is not supported
+	// for the postgres and sqlite database backends. As the backup contains the
+	// channels' revocation secrets, it requires the offchain:write and
+	// signer:generate permissions.
+	BackupDatabase(ctx context.Context, in *DatabaseBackupRequest, opts ...grpc.CallOption) (Lightning_BackupDatabaseClient, error)
 	// lncli: `bakemacaroon`
 	// BakeMacaroon allows the creation of a new macaroon with custom read and
 	// write permissions. No first-party caveats are added since this can be done
@@ -626,6 +651,15 @@ func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChanne
 	return out, nil
 }
 
+func (c *lightningClient) ImportPreimage(ctx context.Context, in *ImportPreimageRequest, opts ...grpc.CallOption) (*ImportPreimageResponse, error) {
+	out := new(ImportPreimageResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/ImportPreimage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error) {
 	out := new(ListChannelsResponse)
 	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/ListChannels", in, out, opts...)
@@ -635,6 +669,15 @@ func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequ
 	return out, nil
 }
 
+func (c *lightningClient) DustExposure(ctx context.Context, in *DustExposureRequest, opts ...grpc.CallOption) (*DustExposureResponse, error) {
+	out := new(DustExposureResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/DustExposure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) SubscribeChannelEvents(ctx context.Context, in *ChannelEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelEventsClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Lightning_ServiceDesc.Streams[2], "/lnrpc.Lightning/SubscribeChannelEvents", opts...)
 	if err != nil {
@@ -1038,6 +1081,15 @@ func (c *lightningClient) GetNetworkInfo(ctx context.Context, in *NetworkInfoReq
 	return out, nil
 }
 
+func (c *lightningClient) GarbageCollectGraph(ctx context.Context, in *GarbageCollectGraphRequest, opts ...grpc.CallOption) (*GarbageCollectGraphResponse, error) {
+	out := new(GarbageCollectGraphResponse)
+	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/GarbageCollectGraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
 	out := new(StopResponse)
 	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/StopDaemon", in, out, opts...)
@@ -1183,6 +1235,38 @@ func (x *lightningSubscribeChannelBackupsClient) Recv() (*ChanBackupSnapshot, er
 	return m, nil
 }
 
+func (c *lightningClient) BackupDatabase(ctx context.Context, in *DatabaseBackupRequest, opts ...grpc.CallOption) (Lightning_BackupDatabaseClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Lightning_ServiceDesc.Streams[11], "/lnrpc.Lightning/BackupDatabase", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningBackupDatabaseClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_BackupDatabaseClient interface {
+	Recv() (*DatabaseBackupChunk, error)
+	grpc.ClientStream
+}
+
+type lightningBackupDatabaseClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningBackupDatabaseClient) Recv() (*DatabaseBackupChunk, error) {
+	m := new(DatabaseBackupChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *lightningClient) BakeMacaroon(ctx context.Context, in *BakeMacaroonRequest, opts ...grpc.CallOption) (*BakeMacaroonResponse, error) {
 	out := new(BakeMacaroonResponse)
 	err := c.cc.Invoke(ctx, "/lnrpc.Lightning/BakeMacaroon", in, out, opts...)
@@ -1229,7 +1313,7 @@ func (c *lightningClient) CheckMacaroonPermissions(ctx context.Context, in *Chec
 }
 
 func (c *lightningClient) RegisterRPCMiddleware(ctx context.Context, opts ...grpc.CallOption) (Lightning_RegisterRPCMiddlewareClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Lightning_ServiceDesc.Streams[11], "/lnrpc.Lightning/RegisterRPCMiddleware", opts...)
+	stream, err := c.cc.NewStream(ctx, &Lightning_ServiceDesc.Streams[12], "/lnrpc.Lightning/RegisterRPCMiddleware", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1269,7 +1353,7 @@ func (c *lightningClient) SendCustomMessage(ctx context.Context, in *SendCustomM
 }
 
 func (c *lightningClient) SubscribeCustomMessages(ctx context.Context, in *SubscribeCustomMessagesRequest, opts ...grpc.CallOption) (Lightning_SubscribeCustomMessagesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Lightning_ServiceDesc.Streams[12], "/lnrpc.Lightning/SubscribeCustomMessages", opts...)
+	stream, err := c.cc.NewStream(ctx, &Lightning_ServiceDesc.Streams[13], "/lnrpc.Lightning/SubscribeCustomMessages", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1417,10 +1501,21 @@ type LightningServer interface {
 	// workflow and is waiting for confirmations for the funding txn, or is in the
 	// process of closure, either initiated cooperatively or non-cooperatively.
 	PendingChannels(context.Context, *PendingChannelsRequest) (*PendingChannelsResponse, error)
+	// lncli: `importpreimage`
+	// ImportPreimage adds a preimage that was obtained out-of-band to the
+	// preimage cache, so pending incoming HTLCs of force closed channels can be
+	// claimed on-chain before they time out. An error is returned if no active
+	// resolver is waiting for the preimage.
+	ImportPreimage(context.Context, *ImportPreimageRequest) (*ImportPreimageResponse, error)
 	// lncli: `listchannels`
 	// ListChannels returns a description of all the open channels that this node
 	// is a participant in.
 	ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error)
+	// lncli: `dustexposure`
+	// DustExposure returns the current dust exposure of all active channels. The
+	// dust exposure of a channel is the sum of its dust HTLCs, which would be
+	// burned to fees if one of the channel's commitments confirmed.
+	DustExposure(context.Context, *DustExposureRequest) (*DustExposureResponse, error)
 	// SubscribeChannelEvents creates a uni-directional stream from the server to
 	// the client in which any updates relevant to the state of the channels are
 	// sent over. Events include new active channels, inactive channels, and closed
@@ -1589,6 +1684,12 @@ type LightningServer interface {
 	// GetNetworkInfo returns some basic stats about the known channel graph from
 	// the point of view of the node.
 	GetNetworkInfo(context.Context, *NetworkInfoRequest) (*NetworkInfo, error)
+	// lncli: `gcgraph`
+	// GarbageCollectGraph forces a garbage collection pass over the channel
+	// graph, pruning zombie channels and the channels of nodes that exceed the
+	// configured maximum number of channels per node. The same pass also runs
+	// periodically in the background, as configured by gc-graph-interval.
+	GarbageCollectGraph(context.Context, *GarbageCollectGraphRequest) (*GarbageCollectGraphResponse, error)
 	// lncli: `stop`
 	// StopDaemon will send a shutdown request to the interrupt handler, triggering
 	// a graceful shutdown of the daemon.
@@ -1659,6 +1760,14 @@ type LightningServer interface {
 	// ups, but the updated set of encrypted multi-chan backups with the closed
 	// channel(s) removed.
 	SubscribeChannelBackups(*ChannelBackupSubscription, Lightning_SubscribeChannelBackupsServer) error
+	// lncli: `backupdatabase`
+	// BackupDatabase streams a consistent snapshot of the live channel database
+	// in chunks, so a hot backup can be taken without stopping lnd. For bolt
+	// databases the result is a regular channel.db file. This is not supported
+	// for the postgres and sqlite database backends. As the backup contains the
+	// channels' revocation secrets, it requires the offchain:write and
+	// signer:generate permissions.
+	BackupDatabase(*DatabaseBackupRequest, Lightning_BackupDatabaseServer) error
 	// lncli: `bakemacaroon`
 	// BakeMacaroon allows the creation of a new macaroon with custom read and
 	// write permissions. No first-party caveats are added since this can be done
@@ -1773,9 +1882,15 @@ func (UnimplementedLightningServer) GetRecoveryInfo(context.Context, *GetRecover
 func (UnimplementedLightningServer) PendingChannels(context.Context, *PendingChannelsRequest) (*PendingChannelsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PendingChannels not implemented")
 }
+func (UnimplementedLightningServer) ImportPreimage(context.Context, *ImportPreimageRequest) (*ImportPreimageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportPreimage not implemented")
+}
 func (UnimplementedLightningServer) ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListChannels not implemented")
 }
+func (UnimplementedLightningServer) DustExposure(context.Context, *DustExposureRequest) (*DustExposureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DustExposure not implemented")
+}
 func (UnimplementedLightningServer) SubscribeChannelEvents(*ChannelEventSubscription, Lightning_SubscribeChannelEventsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeChannelEvents not implemented")
 }
@@ -1857,6 +1972,9 @@ func (UnimplementedLightningServer) QueryRoutes(context.Context, *QueryRoutesReq
 func (UnimplementedLightningServer) GetNetworkInfo(context.Context, *NetworkInfoRequest) (*NetworkInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkInfo not implemented")
 }
+func (UnimplementedLightningServer) GarbageCollectGraph(context.Context, *GarbageCollectGraphRequest) (*GarbageCollectGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GarbageCollectGraph not implemented")
+}
 func (UnimplementedLightningServer) StopDaemon(context.Context, *StopRequest) (*StopResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopDaemon not implemented")
 }
@@ -1890,6 +2008,9 @@ func (UnimplementedLightningServer) RestoreChannelBackups(context.Context, *Rest
 func (UnimplementedLightningServer) SubscribeChannelBackups(*ChannelBackupSubscription, Lightning_SubscribeChannelBackupsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeChannelBackups not implemented")
 }
+func (UnimplementedLightningServer) BackupDatabase(*DatabaseBackupRequest, Lightning_BackupDatabaseServer) error {
+	return status.Errorf(codes.Unimplemented, "method BackupDatabase not implemented")
+}
 func (UnimplementedLightningServer) BakeMacaroon(context.Context, *BakeMacaroonRequest) (*BakeMacaroonResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BakeMacaroon not implemented")
 }
@@ -2263,6 +2384,24 @@ func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_ImportPreimage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportPreimageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ImportPreimage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ImportPreimage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ImportPreimage(ctx, req.(*ImportPreimageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListChannelsRequest)
 	if err := dec(in); err != nil {
@@ -2281,6 +2420,24 @@ func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_DustExposure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DustExposureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DustExposure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DustExposure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DustExposure(ctx, req.(*DustExposureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_SubscribeChannelEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ChannelEventSubscription)
 	if err := stream.RecvMsg(m); err != nil {
@@ -2803,6 +2960,24 @@ func _Lightning_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_GarbageCollectGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GarbageCollectGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GarbageCollectGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GarbageCollectGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GarbageCollectGraph(ctx, req.(*GarbageCollectGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_StopDaemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StopRequest)
 	if err := dec(in); err != nil {
@@ -3007,6 +3182,27 @@ func (x *lightningSubscribeChannelBackupsServer) Send(m *ChanBackupSnapshot) err
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Lightning_BackupDatabase_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DatabaseBackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).BackupDatabase(m, &lightningBackupDatabaseServer{stream})
+}
+
+type Lightning_BackupDatabaseServer interface {
+	Send(*DatabaseBackupChunk) error
+	grpc.ServerStream
+}
+
+type lightningBackupDatabaseServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningBackupDatabaseServer) Send(m *DatabaseBackupChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Lightning_BakeMacaroon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BakeMacaroonRequest)
 	if err := dec(in); err != nil {
@@ -3269,10 +3465,18 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PendingChannels",
 			Handler:    _Lightning_PendingChannels_Handler,
 		},
+		{
+			MethodName: "ImportPreimage",
+			Handler:    _Lightning_ImportPreimage_Handler,
+		},
 		{
 			MethodName: "ListChannels",
 			Handler:    _Lightning_ListChannels_Handler,
 		},
+		{
+			MethodName: "DustExposure",
+			Handler:    _Lightning_DustExposure_Handler,
+		},
 		{
 			MethodName: "ClosedChannels",
 			Handler:    _Lightning_ClosedChannels_Handler,
@@ -3353,6 +3557,10 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNetworkInfo",
 			Handler:    _Lightning_GetNetworkInfo_Handler,
 		},
+		{
+			MethodName: "GarbageCollectGraph",
+			Handler:    _Lightning_GarbageCollectGraph_Handler,
+		},
 		{
 			MethodName: "StopDaemon",
 			Handler:    _Lightning_StopDaemon_Handler,
@@ -3481,6 +3689,11 @@ var Lightning_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Lightning_SubscribeChannelBackups_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "BackupDatabase",
+			Handler:       _Lightning_BackupDatabase_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "RegisterRPCMiddleware",
 			Handler:       _Lightning_RegisterRPCMiddleware_Handler,
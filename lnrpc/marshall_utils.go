@@ -5,6 +5,7 @@ import (
 	"errors"
 	fmt "fmt"
 
+	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/ltcd/chaincfg"
@@ -179,3 +180,85 @@ func MarshalOutPoint(op *wire.OutPoint) *OutPoint {
 		OutputIndex: op.Index,
 	}
 }
+
+// MarshalClosureType converts a channeldb.ClosureType to its proto
+// counterpart.
+func MarshalClosureType(
+	closeType channeldb.ClosureType) ChannelCloseSummary_ClosureType {
+
+	switch closeType {
+	case channeldb.LocalForceClose:
+		return ChannelCloseSummary_LOCAL_FORCE_CLOSE
+
+	case channeldb.RemoteForceClose:
+		return ChannelCloseSummary_REMOTE_FORCE_CLOSE
+
+	case channeldb.BreachClose:
+		return ChannelCloseSummary_BREACH_CLOSE
+
+	case channeldb.FundingCanceled:
+		return ChannelCloseSummary_FUNDING_CANCELED
+
+	case channeldb.Abandoned:
+		return ChannelCloseSummary_ABANDONED
+
+	default:
+		return ChannelCloseSummary_COOPERATIVE_CLOSE
+	}
+}
+
+// MarshalResolution converts a channeldb.ResolverReport to its proto
+// counterpart.
+func MarshalResolution(report *channeldb.ResolverReport) (*Resolution,
+	error) {
+
+	res := &Resolution{
+		AmountSat: uint64(report.Amount),
+		Outpoint:  MarshalOutPoint(&report.OutPoint),
+	}
+
+	if report.SpendTxID != nil {
+		res.SweepTxid = report.SpendTxID.String()
+	}
+
+	switch report.ResolverType {
+	case channeldb.ResolverTypeAnchor:
+		res.ResolutionType = ResolutionType_ANCHOR
+
+	case channeldb.ResolverTypeIncomingHtlc:
+		res.ResolutionType = ResolutionType_INCOMING_HTLC
+
+	case channeldb.ResolverTypeOutgoingHtlc:
+		res.ResolutionType = ResolutionType_OUTGOING_HTLC
+
+	case channeldb.ResolverTypeCommit:
+		res.ResolutionType = ResolutionType_COMMIT
+
+	default:
+		return nil, fmt.Errorf("unknown resolver type: %v",
+			report.ResolverType)
+	}
+
+	switch report.ResolverOutcome {
+	case channeldb.ResolverOutcomeClaimed:
+		res.Outcome = ResolutionOutcome_CLAIMED
+
+	case channeldb.ResolverOutcomeUnclaimed:
+		res.Outcome = ResolutionOutcome_UNCLAIMED
+
+	case channeldb.ResolverOutcomeAbandoned:
+		res.Outcome = ResolutionOutcome_ABANDONED
+
+	case channeldb.ResolverOutcomeFirstStage:
+		res.Outcome = ResolutionOutcome_FIRST_STAGE
+
+	case channeldb.ResolverOutcomeTimeout:
+		res.Outcome = ResolutionOutcome_TIMEOUT
+
+	default:
+		return nil, fmt.Errorf("unknown outcome: %v",
+			report.ResolverOutcome)
+	}
+
+	return res, nil
+}
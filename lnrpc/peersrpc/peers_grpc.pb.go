@@ -20,7 +20,9 @@ const _ = grpc.SupportPackageIsVersion7
 type PeersClient interface {
 	// lncli: peers updatenodeannouncement
 	// UpdateNodeAnnouncement allows the caller to update the node parameters
-	// and broadcasts a new version of the node announcement to its peers.
+	// and broadcasts a new version of the node announcement to its peers. The
+	// new announcement is persisted, and a changed alias or color is kept across
+	// restarts unless it is explicitly set in the config.
 	UpdateNodeAnnouncement(ctx context.Context, in *NodeAnnouncementUpdateRequest, opts ...grpc.CallOption) (*NodeAnnouncementUpdateResponse, error)
 }
 
@@ -47,7 +49,9 @@ func (c *peersClient) UpdateNodeAnnouncement(ctx context.Context, in *NodeAnnoun
 type PeersServer interface {
 	// lncli: peers updatenodeannouncement
 	// UpdateNodeAnnouncement allows the caller to update the node parameters
-	// and broadcasts a new version of the node announcement to its peers.
+	// and broadcasts a new version of the node announcement to its peers. The
+	// new announcement is persisted, and a changed alias or color is kept across
+	// restarts unless it is explicitly set in the config.
 	UpdateNodeAnnouncement(context.Context, *NodeAnnouncementUpdateRequest) (*NodeAnnouncementUpdateResponse, error)
 	mustEmbedUnimplementedPeersServer()
 }
@@ -60,6 +60,12 @@ func DefaultConfig() *Config {
 			NodeWeight: routing.DefaultBimodalNodeWeight,
 			DecayTime:  routing.DefaultBimodalDecayTime,
 		},
+		MppConfig: &MppConfig{
+			MaxParts:      DefaultMaxParts,
+			MinShardAmt:   routing.DefaultShardMinAmt.ToSatoshis(),
+			SplitStrategy: routing.DefaultSplitStrategy,
+			SplitFactor:   routing.DefaultSplitFactor,
+		},
 	}
 
 	return &Config{
@@ -76,6 +82,7 @@ func GetRoutingConfig(cfg *Config) *RoutingConfig {
 		AttemptCostPPM:           cfg.AttemptCostPPM,
 		MaxMcHistory:             cfg.MaxMcHistory,
 		McFlushInterval:          cfg.McFlushInterval,
+		ProfilePathFinding:       cfg.ProfilePathFinding,
 		AprioriConfig: &AprioriConfig{
 			HopProbability:   cfg.AprioriConfig.HopProbability,
 			Weight:           cfg.AprioriConfig.Weight,
@@ -87,5 +94,11 @@ func GetRoutingConfig(cfg *Config) *RoutingConfig {
 			NodeWeight: cfg.BimodalConfig.NodeWeight,
 			DecayTime:  cfg.BimodalConfig.DecayTime,
 		},
+		MppConfig: &MppConfig{
+			MaxParts:      cfg.MppConfig.MaxParts,
+			MinShardAmt:   cfg.MppConfig.MinShardAmt,
+			SplitStrategy: cfg.MppConfig.SplitStrategy,
+			SplitFactor:   cfg.MppConfig.SplitFactor,
+		},
 	}
 }
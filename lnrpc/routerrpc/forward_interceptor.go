@@ -91,6 +91,13 @@ func (r *forwardInterceptor) onIntercept(
 		CustomRecords:           htlc.CustomRecords,
 		OnionBlob:               htlc.OnionBlob[:],
 		AutoFailHeight:          htlc.AutoFailHeight,
+		InWireCustomRecords:     htlc.InWireCustomRecords,
+		IncomingEndorsement: rpcEndorsement(
+			htlc.InWireCustomRecords.Endorsement(),
+		),
+		OutgoingEndorsement: rpcEndorsement(
+			htlc.OutWireCustomRecords.Endorsement(),
+		),
 	}
 
 	return r.stream.Send(interceptionRequest)
@@ -119,6 +126,35 @@ func (r *forwardInterceptor) resolveFromClient(
 			Action: htlcswitch.FwdActionResume,
 		})
 
+	case ResolveHoldForwardAction_RESUME_MODIFIED:
+		res := &htlcswitch.FwdResolution{
+			Key:    circuitKey,
+			Action: htlcswitch.FwdActionResumeModified,
+		}
+
+		if in.OutAmountMsat != 0 {
+			amt := lnwire.MilliSatoshi(in.OutAmountMsat)
+			res.OutgoingAmount = &amt
+		}
+		if in.OutExpiry != 0 {
+			expiry := in.OutExpiry
+			res.OutgoingExpiry = &expiry
+		}
+		if len(in.OutWireCustomRecords) > 0 {
+			customRecords := lnwire.CustomRecords(
+				in.OutWireCustomRecords,
+			)
+			if err := customRecords.Validate(); err != nil {
+				return status.Errorf(
+					codes.InvalidArgument,
+					"invalid custom records: %v", err,
+				)
+			}
+			res.CustomRecords = customRecords
+		}
+
+		return r.htlcSwitch.Resolve(res)
+
 	case ResolveHoldForwardAction_FAIL:
 		// Fail with an encrypted reason.
 		if in.FailureMessage != nil {
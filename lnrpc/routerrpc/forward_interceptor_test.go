@@ -0,0 +1,66 @@
+package routerrpc
+
+import (
+	"testing"
+
+	"github.com/ltcsuite/lnd/htlcswitch"
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockInterceptableForwarder records the resolutions passed to it.
+type mockInterceptableForwarder struct {
+	resolutions []*htlcswitch.FwdResolution
+}
+
+func (m *mockInterceptableForwarder) SetInterceptor(
+	htlcswitch.ForwardInterceptor) {
+}
+
+func (m *mockInterceptableForwarder) Resolve(
+	res *htlcswitch.FwdResolution) error {
+
+	m.resolutions = append(m.resolutions, res)
+
+	return nil
+}
+
+// TestResolveModifiedCustomRecords tests that the custom records of a
+// modified forward are validated before the resolution is passed on.
+func TestResolveModifiedCustomRecords(t *testing.T) {
+	t.Parallel()
+
+	forwarder := &mockInterceptableForwarder{}
+	interceptor := newForwardInterceptor(forwarder, nil)
+
+	resp := &ForwardHtlcInterceptResponse{
+		IncomingCircuitKey: &CircuitKey{
+			ChanId: 1,
+			HtlcId: 2,
+		},
+		Action: ResolveHoldForwardAction_RESUME_MODIFIED,
+	}
+
+	// Custom records outside of the custom range are rejected, without
+	// resolving the held forward.
+	resp.OutWireCustomRecords = map[uint64][]byte{1: {1}}
+	err := interceptor.resolveFromClient(resp)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Empty(t, forwarder.resolutions)
+
+	// Valid custom records are passed on with the resolution.
+	resp.OutWireCustomRecords = map[uint64][]byte{
+		lnwire.MinCustomRecordsTlvType: {1, 2, 3},
+	}
+	require.NoError(t, interceptor.resolveFromClient(resp))
+	require.Len(t, forwarder.resolutions, 1)
+	require.Equal(
+		t, htlcswitch.FwdActionResumeModified,
+		forwarder.resolutions[0].Action,
+	)
+	require.Equal(t, lnwire.CustomRecords{
+		lnwire.MinCustomRecordsTlvType: {1, 2, 3},
+	}, forwarder.resolutions[0].CustomRecords)
+}
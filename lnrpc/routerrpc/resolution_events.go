@@ -0,0 +1,87 @@
+package routerrpc
+
+import (
+	"fmt"
+
+	"github.com/ltcsuite/lnd/contractcourt"
+	"github.com/ltcsuite/lnd/lnrpc"
+)
+
+// rpcResolutionEvent returns a rpc resolution event from a contractcourt
+// event.
+func rpcResolutionEvent(resolutionEvent interface{}) (*ResolutionEvent,
+	error) {
+
+	switch e := resolutionEvent.(type) {
+	case *contractcourt.CommitmentConfirmedEvent:
+		closeType := lnrpc.MarshalClosureType(e.CloseType)
+		confirmed := &CommitmentConfirmedEvent{
+			CloseType:         closeType,
+			ClosingTxid:       e.ClosingTxid.String(),
+			CloseHeight:       e.CloseHeight,
+			SettledBalance:    int64(e.SettledBalance),
+			TimeLockedBalance: int64(e.TimeLockedBalance),
+		}
+
+		return &ResolutionEvent{
+			ChannelPoint: e.ChanPoint.String(),
+			Event: &ResolutionEvent_CommitmentConfirmed{
+				CommitmentConfirmed: confirmed,
+			},
+		}, nil
+
+	case *contractcourt.BreachDetectedEvent:
+		return &ResolutionEvent{
+			ChannelPoint: e.ChanPoint.String(),
+			Event: &ResolutionEvent_BreachDetected{
+				BreachDetected: &BreachDetectedEvent{
+					BreachTxid:   e.BreachTxid.String(),
+					BreachHeight: e.BreachHeight,
+				},
+			},
+		}, nil
+
+	case *contractcourt.ResolverReportEvent:
+		resolution, err := lnrpc.MarshalResolution(e.Report)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ResolutionEvent{
+			ChannelPoint: e.ChanPoint.String(),
+			Event: &ResolutionEvent_Resolution{
+				Resolution: resolution,
+			},
+		}, nil
+
+	case *contractcourt.ResolutionCompleteEvent:
+		resolutions := make([]*lnrpc.Resolution, 0, len(e.Reports))
+		for _, report := range e.Reports {
+			resolution, err := lnrpc.MarshalResolution(report)
+			if err != nil {
+				return nil, err
+			}
+
+			resolutions = append(resolutions, resolution)
+		}
+
+		complete := &ResolutionCompleteEvent{
+			CloseType:    lnrpc.MarshalClosureType(e.CloseType),
+			ClosingTxid:  e.ClosingTxid.String(),
+			CommitFeeSat: int64(e.CommitFee),
+			Resolutions:  resolutions,
+			SweepFeeSat:  int64(e.SweepFee),
+			TotalFeeSat:  int64(e.TotalFee()),
+		}
+
+		return &ResolutionEvent{
+			ChannelPoint: e.ChanPoint.String(),
+			Event: &ResolutionEvent_ResolutionComplete{
+				ResolutionComplete: complete,
+			},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown event type: %T", e)
+	}
+}
@@ -0,0 +1,129 @@
+package routerrpc
+
+import (
+	"testing"
+
+	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/contractcourt"
+	"github.com/ltcsuite/lnd/lnrpc"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRpcResolutionEvent tests the conversion of contractcourt resolution
+// events to their rpc counterparts.
+func TestRpcResolutionEvent(t *testing.T) {
+	t.Parallel()
+
+	chanPoint := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 2}
+	closingTxid := chainhash.Hash{3}
+	report := &channeldb.ResolverReport{
+		OutPoint:        wire.OutPoint{Hash: closingTxid, Index: 1},
+		Amount:          1000,
+		ResolverType:    channeldb.ResolverTypeOutgoingHtlc,
+		ResolverOutcome: channeldb.ResolverOutcomeTimeout,
+		SpendTxID:       &chainhash.Hash{4},
+	}
+	rpcReport := &lnrpc.Resolution{
+		ResolutionType: lnrpc.ResolutionType_OUTGOING_HTLC,
+		Outcome:        lnrpc.ResolutionOutcome_TIMEOUT,
+		Outpoint:       lnrpc.MarshalOutPoint(&report.OutPoint),
+		AmountSat:      1000,
+		SweepTxid:      report.SpendTxID.String(),
+	}
+
+	remoteForceClose := lnrpc.ChannelCloseSummary_REMOTE_FORCE_CLOSE
+	localForceClose := lnrpc.ChannelCloseSummary_LOCAL_FORCE_CLOSE
+
+	tests := []struct {
+		name     string
+		event    interface{}
+		expected isResolutionEvent_Event
+	}{
+		{
+			name: "commitment confirmed",
+			event: &contractcourt.CommitmentConfirmedEvent{
+				ChanPoint:      chanPoint,
+				CloseType:      channeldb.RemoteForceClose,
+				ClosingTxid:    closingTxid,
+				CloseHeight:    100,
+				SettledBalance: 5000,
+			},
+			expected: &ResolutionEvent_CommitmentConfirmed{
+				CommitmentConfirmed: &CommitmentConfirmedEvent{
+					CloseType:      remoteForceClose,
+					ClosingTxid:    closingTxid.String(),
+					CloseHeight:    100,
+					SettledBalance: 5000,
+				},
+			},
+		},
+		{
+			name: "breach detected",
+			event: &contractcourt.BreachDetectedEvent{
+				ChanPoint:    chanPoint,
+				BreachTxid:   closingTxid,
+				BreachHeight: 100,
+			},
+			expected: &ResolutionEvent_BreachDetected{
+				BreachDetected: &BreachDetectedEvent{
+					BreachTxid:   closingTxid.String(),
+					BreachHeight: 100,
+				},
+			},
+		},
+		{
+			name: "resolver report",
+			event: &contractcourt.ResolverReportEvent{
+				ChanPoint: chanPoint,
+				Report:    report,
+			},
+			expected: &ResolutionEvent_Resolution{
+				Resolution: rpcReport,
+			},
+		},
+		{
+			name: "resolution complete",
+			event: &contractcourt.ResolutionCompleteEvent{
+				ChanPoint:   chanPoint,
+				CloseType:   channeldb.LocalForceClose,
+				ClosingTxid: closingTxid,
+				CommitFee:   250,
+				SweepFee:    500,
+				Reports: []*channeldb.ResolverReport{
+					report,
+				},
+			},
+			expected: &ResolutionEvent_ResolutionComplete{
+				ResolutionComplete: &ResolutionCompleteEvent{
+					CloseType:    localForceClose,
+					ClosingTxid:  closingTxid.String(),
+					CommitFeeSat: 250,
+					Resolutions: []*lnrpc.Resolution{
+						rpcReport,
+					},
+					SweepFeeSat: 500,
+					TotalFeeSat: 750,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			event, err := rpcResolutionEvent(test.event)
+			require.NoError(t, err)
+			require.Equal(t, chanPoint.String(), event.ChannelPoint)
+			require.Equal(t, test.expected, event.Event)
+		})
+	}
+
+	// Unknown events result in an error.
+	_, err := rpcResolutionEvent(struct{}{})
+	require.Error(t, err)
+}
@@ -84,10 +84,19 @@ type RouterBackend struct {
 	// when an RPC caller doesn't specify a value.
 	DefaultFinalCltvDelta uint16
 
+	// DefaultMaxParts is the default maximum number of partial payments
+	// used when an RPC caller doesn't specify a value. If it is zero,
+	// DefaultMaxParts is used.
+	DefaultMaxParts uint32
+
 	// SubscribeHtlcEvents returns a subscription client for the node's
 	// htlc events.
 	SubscribeHtlcEvents func() (*subscribe.Client, error)
 
+	// SubscribeResolutionEvents returns a subscription client for the
+	// on-chain resolution events of the node's channels.
+	SubscribeResolutionEvents func() (*subscribe.Client, error)
+
 	// InterceptableForwarder exposes the ability to intercept forward events
 	// by letting the router register a ForwardInterceptor.
 	InterceptableForwarder htlcswitch.InterceptableHtlcForwarder
@@ -101,6 +110,20 @@ type RouterBackend struct {
 	// SetChannelAuto exposes the ability to restore automatic channel state
 	// management after manually setting channel status.
 	SetChannelAuto func(wire.OutPoint) error
+
+	// PaymentQueuePosition returns the one-based position of a payment in
+	// the router's payment queue, and false if the payment isn't queued.
+	PaymentQueuePosition func(identifier lntypes.Hash) (int, bool)
+
+	// PendingHtlcs returns all htlcs that are currently held by the
+	// switch or the forward interceptor, sorted by their hold time,
+	// longest first.
+	PendingHtlcs func() ([]htlcswitch.PendingHtlc, error)
+
+	// LookupPendingHtlc returns the htlc held by the switch or the
+	// forward interceptor that is identified by its incoming circuit key.
+	LookupPendingHtlc func(inKey htlcswitch.CircuitKey) (
+		*htlcswitch.PendingHtlc, error)
 }
 
 // MissionControl defines the mission control dependencies of routerrpc.
@@ -119,8 +142,7 @@ type MissionControl interface {
 	GetHistorySnapshot() *routing.MissionControlSnapshot
 
 	// ImportHistory imports the mission control snapshot to our internal
-	// state. This import will only be applied in-memory, and will not be
-	// persisted across restarts.
+	// state. The imported results are persisted across restarts.
 	ImportHistory(snapshot *routing.MissionControlSnapshot, force bool) error
 
 	// GetPairHistorySnapshot returns the stored history for a given node
@@ -291,11 +313,27 @@ func (r *RouterBackend) QueryRoutes(ctx context.Context,
 		DestFeatures:      features,
 	}
 
-	// Pass along an outgoing channel restriction if specified.
+	// Pass along restrictions on the outgoing channels that may be used.
+	restrictions.OutgoingChannelIDs = in.OutgoingChanIds
+
+	// Add the deprecated single outgoing channel restriction if present.
 	if in.OutgoingChanId != 0 {
+		if restrictions.OutgoingChannelIDs != nil {
+			return nil, errors.New("outgoing_chan_id and " +
+				"outgoing_chan_ids are mutually exclusive")
+		}
+
 		restrictions.OutgoingChannelIDs = []uint64{in.OutgoingChanId}
 	}
 
+	// Pass along the peers that may not be used as the first hop.
+	restrictions.BlockedFirstHops, err = unmarshallVertices(
+		in.BlockedFirstHops,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Pass along a last hop restriction if specified.
 	if len(in.LastHopPubkey) > 0 {
 		lastHop, err := route.NewVertexFromBytes(
@@ -561,6 +599,22 @@ func (r *RouterBackend) extractIntentFromSendRequest(
 		)
 	}
 
+	// Pass along the peers that may not be used as the first hop.
+	blockedFirstHops, err := unmarshallVertices(
+		rpcPayReq.BlockedFirstHops,
+	)
+	if err != nil {
+		return nil, err
+	}
+	payIntent.BlockedFirstHops = blockedFirstHops
+
+	// Pass along the idempotency key of the payment, if any.
+	if len(rpcPayReq.IdempotencyKey) > channeldb.MaxIdempotencyKeyLen {
+		return nil, fmt.Errorf("idempotency key exceeds %v bytes",
+			channeldb.MaxIdempotencyKeyLen)
+	}
+	payIntent.IdempotencyKey = rpcPayReq.IdempotencyKey
+
 	// Pass along a last hop restriction if specified.
 	if len(rpcPayReq.LastHopPubkey) > 0 {
 		lastHop, err := route.NewVertexFromBytes(
@@ -585,7 +639,11 @@ func (r *RouterBackend) extractIntentFromSendRequest(
 	// isn't set, then we'll use the current default value for this
 	// setting.
 	maxParts := rpcPayReq.MaxParts
-	if maxParts == 0 {
+	switch {
+	case maxParts == 0 && r.DefaultMaxParts != 0:
+		maxParts = r.DefaultMaxParts
+
+	case maxParts == 0:
 		maxParts = DefaultMaxParts
 	}
 	payIntent.MaxParts = maxParts
@@ -885,6 +943,25 @@ func (r *RouterBackend) extractIntentFromSendRequest(
 	return payIntent, nil
 }
 
+// unmarshallVertices unmarshalls a list of serialized node public keys.
+func unmarshallVertices(rpcVertices [][]byte) ([]route.Vertex, error) {
+	if len(rpcVertices) == 0 {
+		return nil, nil
+	}
+
+	vertices := make([]route.Vertex, 0, len(rpcVertices))
+	for _, rpcVertex := range rpcVertices {
+		vertex, err := route.NewVertexFromBytes(rpcVertex)
+		if err != nil {
+			return nil, err
+		}
+
+		vertices = append(vertices, vertex)
+	}
+
+	return vertices, nil
+}
+
 // unmarshallRouteHints unmarshalls a list of route hints.
 func unmarshallRouteHints(rpcRouteHints []*lnrpc.RouteHint) (
 	[][]zpay32.HopHint, error) {
@@ -1344,6 +1421,19 @@ func (r *RouterBackend) MarshallPayment(payment *channeldb.MPPayment) (
 		return nil, err
 	}
 
+	// Payments are only registered once they leave the payment queue, so
+	// only the in-flight updates sent while waiting, which don't have any
+	// attempts, can refer to a queued payment.
+	var queuePosition uint32
+	if payment.Status == channeldb.StatusInFlight &&
+		len(payment.HTLCs) == 0 && r.PaymentQueuePosition != nil {
+
+		position, ok := r.PaymentQueuePosition(paymentID)
+		if ok {
+			queuePosition = uint32(position)
+		}
+	}
+
 	return &lnrpc.Payment{
 		// TODO: set this to setID for AMP-payments?
 		PaymentHash:     hex.EncodeToString(paymentID[:]),
@@ -1361,6 +1451,7 @@ func (r *RouterBackend) MarshallPayment(payment *channeldb.MPPayment) (
 		Htlcs:           htlcs,
 		PaymentIndex:    payment.SequenceNum,
 		FailureReason:   failureReason,
+		QueuePosition:   queuePosition,
 	}, nil
 }
 
@@ -66,8 +66,9 @@ func testQueryRoutes(t *testing.T, useMissionControl bool, useMsat bool,
 	}
 
 	var (
-		lastHop      = route.Vertex{64}
-		outgoingChan = uint64(383322)
+		lastHop       = route.Vertex{64}
+		outgoingChans = []uint64{383322, 383323}
+		blockedHop    = route.Vertex{65}
 	)
 
 	hintNode, err := route.NewVertexFromStr(hintNodeKey)
@@ -100,7 +101,8 @@ func testQueryRoutes(t *testing.T, useMissionControl bool, useMsat bool,
 		}},
 		UseMissionControl: useMissionControl,
 		LastHopPubkey:     lastHop[:],
-		OutgoingChanId:    outgoingChan,
+		OutgoingChanIds:   outgoingChans,
+		BlockedFirstHops:  [][]byte{blockedHop[:]},
 		DestFeatures:      []lnrpc.FeatureBit{lnrpc.FeatureBit_MPP_OPT},
 		RouteHints:        rpcRouteHints,
 	}
@@ -164,9 +166,13 @@ func testQueryRoutes(t *testing.T, useMissionControl bool, useMsat bool,
 			t.Fatal("unexpected last hop")
 		}
 
-		if restrictions.OutgoingChannelIDs[0] != outgoingChan {
-			t.Fatal("unexpected outgoing channel id")
-		}
+		require.Equal(
+			t, outgoingChans, restrictions.OutgoingChannelIDs,
+		)
+		require.Equal(
+			t, []route.Vertex{blockedHop},
+			restrictions.BlockedFirstHops,
+		)
 
 		if !restrictions.DestFeatures.HasFeature(lnwire.MPPOptional) {
 			t.Fatal("unexpected dest features")
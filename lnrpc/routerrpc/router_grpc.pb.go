@@ -49,6 +49,11 @@ type RouterClient interface {
 	// route manually. This can be used for things like rebalancing, and atomic
 	// swaps.
 	SendToRouteV2(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*lnrpc.HTLCAttempt, error)
+	// ProbeRoute sends a probe along the specified route to test whether it has
+	// enough liquidity for the route amount, without sending any funds. The
+	// probe uses a random payment hash that the destination can't settle. The
+	// result of the probe is reported to mission control.
+	ProbeRoute(ctx context.Context, in *ProbeRouteRequest, opts ...grpc.CallOption) (*ProbeRouteResponse, error)
 	// ResetMissionControl clears all mission control state and starts with a clean
 	// slate.
 	ResetMissionControl(ctx context.Context, in *ResetMissionControlRequest, opts ...grpc.CallOption) (*ResetMissionControlResponse, error)
@@ -57,8 +62,8 @@ type RouterClient interface {
 	QueryMissionControl(ctx context.Context, in *QueryMissionControlRequest, opts ...grpc.CallOption) (*QueryMissionControlResponse, error)
 	// XImportMissionControl is an experimental API that imports the state provided
 	// to the internal mission control's state, using all results which are more
-	// recent than our existing values. These values will only be imported
-	// in-memory, and will not be persisted across restarts.
+	// recent than our existing values. The imported values are persisted, and
+	// will be applied again after a restart.
 	XImportMissionControl(ctx context.Context, in *XImportMissionControlRequest, opts ...grpc.CallOption) (*XImportMissionControlResponse, error)
 	// GetMissionControlConfig returns mission control's current config.
 	GetMissionControlConfig(ctx context.Context, in *GetMissionControlConfigRequest, opts ...grpc.CallOption) (*GetMissionControlConfigResponse, error)
@@ -77,6 +82,10 @@ type RouterClient interface {
 	// SubscribeHtlcEvents creates a uni-directional stream from the server to
 	// the client which delivers a stream of htlc events.
 	SubscribeHtlcEvents(ctx context.Context, in *SubscribeHtlcEventsRequest, opts ...grpc.CallOption) (Router_SubscribeHtlcEventsClient, error)
+	// SubscribeResolutionEvents creates a uni-directional stream from the server
+	// to the client which delivers events about the on-chain resolution of force
+	// closed and breached channels.
+	SubscribeResolutionEvents(ctx context.Context, in *SubscribeResolutionEventsRequest, opts ...grpc.CallOption) (Router_SubscribeResolutionEventsClient, error)
 	// Deprecated: Do not use.
 	//
 	// Deprecated, use SendPaymentV2. SendPayment attempts to route a payment
@@ -100,6 +109,14 @@ type RouterClient interface {
 	// channel to stay disabled until a subsequent manual request of either
 	// "enable" or "auto".
 	UpdateChanStatus(ctx context.Context, in *UpdateChanStatusRequest, opts ...grpc.CallOption) (*UpdateChanStatusResponse, error)
+	// LookupHtlc returns an htlc that is currently held by the switch or the
+	// forward interceptor, identified by its incoming channel and htlc index,
+	// along with the state that keeps it from being resolved.
+	LookupHtlc(ctx context.Context, in *LookupHtlcRequest, opts ...grpc.CallOption) (*LookupHtlcResponse, error)
+	// ListStuckHtlcs returns all htlcs that have been held by the switch or the
+	// forward interceptor for at least the given amount of time, along with the
+	// state that keeps them from being resolved.
+	ListStuckHtlcs(ctx context.Context, in *ListStuckHtlcsRequest, opts ...grpc.CallOption) (*ListStuckHtlcsResponse, error)
 }
 
 type routerClient struct {
@@ -234,6 +251,15 @@ func (c *routerClient) SendToRouteV2(ctx context.Context, in *SendToRouteRequest
 	return out, nil
 }
 
+func (c *routerClient) ProbeRoute(ctx context.Context, in *ProbeRouteRequest, opts ...grpc.CallOption) (*ProbeRouteResponse, error) {
+	out := new(ProbeRouteResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/ProbeRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *routerClient) ResetMissionControl(ctx context.Context, in *ResetMissionControlRequest, opts ...grpc.CallOption) (*ResetMissionControlResponse, error) {
 	out := new(ResetMissionControlResponse)
 	err := c.cc.Invoke(ctx, "/routerrpc.Router/ResetMissionControl", in, out, opts...)
@@ -329,9 +355,41 @@ func (x *routerSubscribeHtlcEventsClient) Recv() (*HtlcEvent, error) {
 	return m, nil
 }
 
+func (c *routerClient) SubscribeResolutionEvents(ctx context.Context, in *SubscribeResolutionEventsRequest, opts ...grpc.CallOption) (Router_SubscribeResolutionEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Router_ServiceDesc.Streams[4], "/routerrpc.Router/SubscribeResolutionEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routerSubscribeResolutionEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Router_SubscribeResolutionEventsClient interface {
+	Recv() (*ResolutionEvent, error)
+	grpc.ClientStream
+}
+
+type routerSubscribeResolutionEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *routerSubscribeResolutionEventsClient) Recv() (*ResolutionEvent, error) {
+	m := new(ResolutionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Deprecated: Do not use.
 func (c *routerClient) SendPayment(ctx context.Context, in *SendPaymentRequest, opts ...grpc.CallOption) (Router_SendPaymentClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Router_ServiceDesc.Streams[4], "/routerrpc.Router/SendPayment", opts...)
+	stream, err := c.cc.NewStream(ctx, &Router_ServiceDesc.Streams[5], "/routerrpc.Router/SendPayment", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -364,7 +422,7 @@ func (x *routerSendPaymentClient) Recv() (*PaymentStatus, error) {
 
 // Deprecated: Do not use.
 func (c *routerClient) TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Router_TrackPaymentClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Router_ServiceDesc.Streams[5], "/routerrpc.Router/TrackPayment", opts...)
+	stream, err := c.cc.NewStream(ctx, &Router_ServiceDesc.Streams[6], "/routerrpc.Router/TrackPayment", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -396,7 +454,7 @@ func (x *routerTrackPaymentClient) Recv() (*PaymentStatus, error) {
 }
 
 func (c *routerClient) HtlcInterceptor(ctx context.Context, opts ...grpc.CallOption) (Router_HtlcInterceptorClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Router_ServiceDesc.Streams[6], "/routerrpc.Router/HtlcInterceptor", opts...)
+	stream, err := c.cc.NewStream(ctx, &Router_ServiceDesc.Streams[7], "/routerrpc.Router/HtlcInterceptor", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -435,6 +493,24 @@ func (c *routerClient) UpdateChanStatus(ctx context.Context, in *UpdateChanStatu
 	return out, nil
 }
 
+func (c *routerClient) LookupHtlc(ctx context.Context, in *LookupHtlcRequest, opts ...grpc.CallOption) (*LookupHtlcResponse, error) {
+	out := new(LookupHtlcResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/LookupHtlc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerClient) ListStuckHtlcs(ctx context.Context, in *ListStuckHtlcsRequest, opts ...grpc.CallOption) (*ListStuckHtlcsResponse, error) {
+	out := new(ListStuckHtlcsResponse)
+	err := c.cc.Invoke(ctx, "/routerrpc.Router/ListStuckHtlcs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RouterServer is the server API for Router service.
 // All implementations must embed UnimplementedRouterServer
 // for forward compatibility
@@ -469,6 +545,11 @@ type RouterServer interface {
 	// route manually. This can be used for things like rebalancing, and atomic
 	// swaps.
 	SendToRouteV2(context.Context, *SendToRouteRequest) (*lnrpc.HTLCAttempt, error)
+	// ProbeRoute sends a probe along the specified route to test whether it has
+	// enough liquidity for the route amount, without sending any funds. The
+	// probe uses a random payment hash that the destination can't settle. The
+	// result of the probe is reported to mission control.
+	ProbeRoute(context.Context, *ProbeRouteRequest) (*ProbeRouteResponse, error)
 	// ResetMissionControl clears all mission control state and starts with a clean
 	// slate.
 	ResetMissionControl(context.Context, *ResetMissionControlRequest) (*ResetMissionControlResponse, error)
@@ -477,8 +558,8 @@ type RouterServer interface {
 	QueryMissionControl(context.Context, *QueryMissionControlRequest) (*QueryMissionControlResponse, error)
 	// XImportMissionControl is an experimental API that imports the state provided
 	// to the internal mission control's state, using all results which are more
-	// recent than our existing values. These values will only be imported
-	// in-memory, and will not be persisted across restarts.
+	// recent than our existing values. The imported values are persisted, and
+	// will be applied again after a restart.
 	XImportMissionControl(context.Context, *XImportMissionControlRequest) (*XImportMissionControlResponse, error)
 	// GetMissionControlConfig returns mission control's current config.
 	GetMissionControlConfig(context.Context, *GetMissionControlConfigRequest) (*GetMissionControlConfigResponse, error)
@@ -497,6 +578,10 @@ type RouterServer interface {
 	// SubscribeHtlcEvents creates a uni-directional stream from the server to
 	// the client which delivers a stream of htlc events.
 	SubscribeHtlcEvents(*SubscribeHtlcEventsRequest, Router_SubscribeHtlcEventsServer) error
+	// SubscribeResolutionEvents creates a uni-directional stream from the server
+	// to the client which delivers events about the on-chain resolution of force
+	// closed and breached channels.
+	SubscribeResolutionEvents(*SubscribeResolutionEventsRequest, Router_SubscribeResolutionEventsServer) error
 	// Deprecated: Do not use.
 	//
 	// Deprecated, use SendPaymentV2. SendPayment attempts to route a payment
@@ -520,6 +605,14 @@ type RouterServer interface {
 	// channel to stay disabled until a subsequent manual request of either
 	// "enable" or "auto".
 	UpdateChanStatus(context.Context, *UpdateChanStatusRequest) (*UpdateChanStatusResponse, error)
+	// LookupHtlc returns an htlc that is currently held by the switch or the
+	// forward interceptor, identified by its incoming channel and htlc index,
+	// along with the state that keeps it from being resolved.
+	LookupHtlc(context.Context, *LookupHtlcRequest) (*LookupHtlcResponse, error)
+	// ListStuckHtlcs returns all htlcs that have been held by the switch or the
+	// forward interceptor for at least the given amount of time, along with the
+	// state that keeps them from being resolved.
+	ListStuckHtlcs(context.Context, *ListStuckHtlcsRequest) (*ListStuckHtlcsResponse, error)
 	mustEmbedUnimplementedRouterServer()
 }
 
@@ -545,6 +638,9 @@ func (UnimplementedRouterServer) SendToRoute(context.Context, *SendToRouteReques
 func (UnimplementedRouterServer) SendToRouteV2(context.Context, *SendToRouteRequest) (*lnrpc.HTLCAttempt, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendToRouteV2 not implemented")
 }
+func (UnimplementedRouterServer) ProbeRoute(context.Context, *ProbeRouteRequest) (*ProbeRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProbeRoute not implemented")
+}
 func (UnimplementedRouterServer) ResetMissionControl(context.Context, *ResetMissionControlRequest) (*ResetMissionControlResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResetMissionControl not implemented")
 }
@@ -569,6 +665,9 @@ func (UnimplementedRouterServer) BuildRoute(context.Context, *BuildRouteRequest)
 func (UnimplementedRouterServer) SubscribeHtlcEvents(*SubscribeHtlcEventsRequest, Router_SubscribeHtlcEventsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeHtlcEvents not implemented")
 }
+func (UnimplementedRouterServer) SubscribeResolutionEvents(*SubscribeResolutionEventsRequest, Router_SubscribeResolutionEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeResolutionEvents not implemented")
+}
 func (UnimplementedRouterServer) SendPayment(*SendPaymentRequest, Router_SendPaymentServer) error {
 	return status.Errorf(codes.Unimplemented, "method SendPayment not implemented")
 }
@@ -581,6 +680,12 @@ func (UnimplementedRouterServer) HtlcInterceptor(Router_HtlcInterceptorServer) e
 func (UnimplementedRouterServer) UpdateChanStatus(context.Context, *UpdateChanStatusRequest) (*UpdateChanStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateChanStatus not implemented")
 }
+func (UnimplementedRouterServer) LookupHtlc(context.Context, *LookupHtlcRequest) (*LookupHtlcResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupHtlc not implemented")
+}
+func (UnimplementedRouterServer) ListStuckHtlcs(context.Context, *ListStuckHtlcsRequest) (*ListStuckHtlcsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStuckHtlcs not implemented")
+}
 func (UnimplementedRouterServer) mustEmbedUnimplementedRouterServer() {}
 
 // UnsafeRouterServer may be embedded to opt out of forward compatibility for this service.
@@ -711,6 +816,24 @@ func _Router_SendToRouteV2_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Router_ProbeRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).ProbeRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/ProbeRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).ProbeRoute(ctx, req.(*ProbeRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Router_ResetMissionControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ResetMissionControlRequest)
 	if err := dec(in); err != nil {
@@ -858,6 +981,27 @@ func (x *routerSubscribeHtlcEventsServer) Send(m *HtlcEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Router_SubscribeResolutionEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeResolutionEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RouterServer).SubscribeResolutionEvents(m, &routerSubscribeResolutionEventsServer{stream})
+}
+
+type Router_SubscribeResolutionEventsServer interface {
+	Send(*ResolutionEvent) error
+	grpc.ServerStream
+}
+
+type routerSubscribeResolutionEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *routerSubscribeResolutionEventsServer) Send(m *ResolutionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Router_SendPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SendPaymentRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -944,6 +1088,42 @@ func _Router_UpdateChanStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Router_LookupHtlc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupHtlcRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).LookupHtlc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/LookupHtlc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).LookupHtlc(ctx, req.(*LookupHtlcRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Router_ListStuckHtlcs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStuckHtlcsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).ListStuckHtlcs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/routerrpc.Router/ListStuckHtlcs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).ListStuckHtlcs(ctx, req.(*ListStuckHtlcsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Router_ServiceDesc is the grpc.ServiceDesc for Router service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -963,6 +1143,10 @@ var Router_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendToRouteV2",
 			Handler:    _Router_SendToRouteV2_Handler,
 		},
+		{
+			MethodName: "ProbeRoute",
+			Handler:    _Router_ProbeRoute_Handler,
+		},
 		{
 			MethodName: "ResetMissionControl",
 			Handler:    _Router_ResetMissionControl_Handler,
@@ -995,6 +1179,14 @@ var Router_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateChanStatus",
 			Handler:    _Router_UpdateChanStatus_Handler,
 		},
+		{
+			MethodName: "LookupHtlc",
+			Handler:    _Router_LookupHtlc_Handler,
+		},
+		{
+			MethodName: "ListStuckHtlcs",
+			Handler:    _Router_ListStuckHtlcs_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1017,6 +1209,11 @@ var Router_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Router_SubscribeHtlcEvents_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeResolutionEvents",
+			Handler:       _Router_SubscribeResolutionEvents_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SendPayment",
 			Handler:       _Router_SendPayment_Handler,
@@ -12,10 +12,12 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/htlcswitch"
 	"github.com/ltcsuite/lnd/lnrpc"
 	"github.com/ltcsuite/lnd/lntypes"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/macaroons"
+	"github.com/ltcsuite/lnd/record"
 	"github.com/ltcsuite/lnd/routing"
 	"github.com/ltcsuite/lnd/routing/route"
 	"github.com/ltcsuite/ltcd/ltcutil"
@@ -64,6 +66,10 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/routerrpc.Router/ProbeRoute": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/routerrpc.Router/SendToRoute": {{
 			Entity: "offchain",
 			Action: "write",
@@ -112,6 +118,13 @@ var (
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/routerrpc.Router/SubscribeResolutionEvents": {{
+			Entity: "onchain",
+			Action: "read",
+		}, {
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/routerrpc.Router/SendPayment": {{
 			Entity: "offchain",
 			Action: "write",
@@ -128,6 +141,14 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/routerrpc.Router/LookupHtlc": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/routerrpc.Router/ListStuckHtlcs": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 	}
 
 	// DefaultRouterMacFilename is the default name of the router macaroon
@@ -321,9 +342,56 @@ func (s *Server) SendPaymentV2(req *SendPaymentRequest,
 	// Get the payment hash.
 	payHash := payment.Identifier()
 
+	// If a payment was already initiated with the idempotency key, we
+	// don't send a new payment but stream back the updates of the
+	// existing one.
+	if len(payment.IdempotencyKey) > 0 {
+		tracked, err := s.trackIdempotencyKey(req, payHash, stream)
+		if tracked || err != nil {
+			return err
+		}
+	}
+
+	// Wait until the router admits the payment. The payment is only
+	// registered in the db once it is admitted, so that payments that are
+	// still queued on shutdown aren't resumed and failed on startup.
+	queued, err := s.cfg.Router.QueuePayment(payHash)
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	defer func() {
+		// The slot is released by the router once the payment was
+		// sent, so this only takes effect on errors.
+		if queued != nil {
+			queued.Release()
+		}
+	}()
+
+	err = s.notifyQueuedPayment(payment, stream, req.NoInflightUpdates)
+	if err != nil {
+		return err
+	}
+
+	if err := queued.Wait(stream.Context().Done()); err != nil {
+		return err
+	}
+
 	// Init the payment in db.
 	paySession, shardTracker, err := s.cfg.Router.PreparePayment(payment)
-	if err != nil {
+	switch {
+	// The key may have been used by a concurrent request in the meantime,
+	// in which case we track the payment that was initiated with it.
+	case errors.Is(err, channeldb.ErrIdempotencyKeyUsed):
+		queued.Release()
+
+		tracked, err := s.trackIdempotencyKey(req, payHash, stream)
+		if !tracked && err == nil {
+			err = channeldb.ErrIdempotencyKeyUsed
+		}
+
+		return err
+
+	case err != nil:
 		return err
 	}
 
@@ -334,8 +402,12 @@ func (s *Server) SendPaymentV2(req *SendPaymentRequest,
 		return err
 	}
 
-	// Send the payment.
-	err = s.cfg.Router.SendPaymentAsync(payment, paySession, shardTracker)
+	// Send the payment. From here on, the router releases the slot of the
+	// payment once it has completed.
+	err = s.cfg.Router.SendPaymentAsync(
+		payment, paySession, shardTracker, queued,
+	)
+	queued = nil
 	if err == nil {
 		// If the payment was sent successfully, we can start tracking
 		// the events.
@@ -360,6 +432,77 @@ func (s *Server) SendPaymentV2(req *SendPaymentRequest,
 	return err
 }
 
+// notifyQueuedPayment sends an in-flight update with the position of the
+// payment in the router's payment queue to the client, if the payment has to
+// wait for other payments to complete.
+func (s *Server) notifyQueuedPayment(payment *routing.LightningPayment,
+	stream Router_SendPaymentV2Server, noInflightUpdates bool) error {
+
+	position, ok := s.cfg.Router.PaymentQueuePosition(payment.Identifier())
+	if !ok || noInflightUpdates {
+		return nil
+	}
+
+	log.Debugf("Payment %x waiting at position %v of the payment queue",
+		payment.Identifier(), position)
+
+	rpcPayment, err := s.cfg.RouterBackend.MarshallPayment(
+		&channeldb.MPPayment{
+			Info: &channeldb.PaymentCreationInfo{
+				PaymentIdentifier: payment.Identifier(),
+				Value:             payment.Amount,
+				CreationTime:      time.Now(),
+				PaymentRequest:    payment.PaymentRequest,
+			},
+			Status: channeldb.StatusInFlight,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(rpcPayment)
+}
+
+// trackIdempotencyKey streams back the updates of the payment that was
+// initiated with the idempotency key of the request. False is returned if no
+// payment was initiated with the key yet. For non-AMP payments, the key must
+// have been used for a payment with the same hash.
+func (s *Server) trackIdempotencyKey(req *SendPaymentRequest,
+	payHash lntypes.Hash, stream Router_SendPaymentV2Server) (bool, error) {
+
+	router := s.cfg.RouterBackend
+	existingHash, err := router.Tower.LookupIdempotencyKey(
+		req.IdempotencyKey,
+	)
+	switch {
+	case errors.Is(err, channeldb.ErrIdempotencyKeyNotFound):
+		return false, nil
+
+	case err != nil:
+		return false, err
+	}
+
+	// AMP payments use a random set id as identifier, which differs for
+	// every attempt to send the payment.
+	if !req.Amp && existingHash != payHash {
+		return false, status.Errorf(codes.InvalidArgument, "%v: %v",
+			channeldb.ErrIdempotencyKeyUsed, existingHash)
+	}
+
+	log.Debugf("Payment %v already initiated with idempotency key %x, "+
+		"tracking it", existingHash, req.IdempotencyKey)
+
+	sub, err := s.subscribePayment(existingHash)
+	if err != nil {
+		return false, err
+	}
+
+	return true, s.trackPayment(
+		sub, existingHash, stream, req.NoInflightUpdates,
+	)
+}
+
 // EstimateRouteFee allows callers to obtain a lower bound w.r.t how much it
 // may cost to send an HTLC to the target end destination.
 func (s *Server) EstimateRouteFee(ctx context.Context,
@@ -455,6 +598,38 @@ func (s *Server) SendToRouteV2(ctx context.Context,
 	return nil, err
 }
 
+// ProbeRoute sends a probe along the given route to test whether it has enough
+// liquidity for the route amount. The probe can't be settled, so no funds are
+// sent. The result of the probe is reported to mission control.
+func (s *Server) ProbeRoute(ctx context.Context,
+	req *ProbeRouteRequest) (*ProbeRouteResponse, error) {
+
+	if req.Route == nil {
+		return nil, status.Error(codes.InvalidArgument,
+			"no route provided")
+	}
+
+	route, err := s.cfg.RouterBackend.UnmarshallRoute(req.Route)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt, success, err := s.cfg.Router.ProbeRoute(route)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcAttempt, err := s.cfg.RouterBackend.MarshalHTLCAttempt(*attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProbeRouteResponse{
+		Success: success,
+		Attempt: rpcAttempt,
+	}, nil
+}
+
 // ResetMissionControl clears all mission control state and starts with a clean
 // slate.
 func (s *Server) ResetMissionControl(ctx context.Context,
@@ -978,9 +1153,43 @@ func (s *Server) BuildRoute(ctx context.Context,
 		payAddr = &backingPayAddr
 	}
 
+	// Decode the TLV records destined for the final hop.
+	customRecords := record.CustomSet(req.DestCustomRecords)
+	if err := customRecords.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Convert the route hints to an edge map. The hints are expected to
+	// lead to the last hop of the route.
+	var routeHintEdges map[route.Vertex][]*channeldb.CachedEdgePolicy
+	if len(req.RouteHints) > 0 {
+		if len(hops) == 0 {
+			return nil, errors.New("route hints require at least " +
+				"one hop")
+		}
+
+		routeHints, err := unmarshallRouteHints(req.RouteHints)
+		if err != nil {
+			return nil, err
+		}
+
+		routeHintEdges, err = routing.RouteHintsToEdges(
+			routeHints, hops[len(hops)-1],
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	destFeatures, err := UnmarshalFeatures(req.DestFeatures)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build the route and return it to the caller.
 	route, err := s.cfg.Router.BuildRoute(
 		amt, hops, outgoingChan, req.FinalCltvDelta, payAddr,
+		customRecords, routeHintEdges, destFeatures,
 	)
 	if err != nil {
 		return nil, err
@@ -1047,6 +1256,58 @@ func (s *Server) SubscribeHtlcEvents(req *SubscribeHtlcEventsRequest,
 	}
 }
 
+// SubscribeResolutionEvents creates a uni-directional stream from the server
+// to the client which delivers the progress of the on-chain resolution of the
+// node's channels.
+func (s *Server) SubscribeResolutionEvents(
+	req *SubscribeResolutionEventsRequest,
+	stream Router_SubscribeResolutionEventsServer) error {
+
+	resolutionClient, err := s.cfg.RouterBackend.SubscribeResolutionEvents()
+	if err != nil {
+		return err
+	}
+	defer resolutionClient.Cancel()
+
+	// Send out an initial subscribed event so that the caller knows the
+	// point from which new events will be transmitted.
+	if err := stream.Send(&ResolutionEvent{
+		Event: &ResolutionEvent_SubscribedEvent{
+			SubscribedEvent: &SubscribedEvent{},
+		},
+	}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case event := <-resolutionClient.Updates():
+			rpcEvent, err := rpcResolutionEvent(event)
+			if err != nil {
+				return err
+			}
+
+			if err := stream.Send(rpcEvent); err != nil {
+				return err
+			}
+
+		// If the stream's context is cancelled, return an error.
+		case <-stream.Context().Done():
+			log.Debugf("resolution event stream cancelled")
+			return stream.Context().Err()
+
+		// If the subscribe client terminates, exit with an error.
+		case <-resolutionClient.Quit():
+			return errors.New("resolution event subscription " +
+				"terminated")
+
+		// If the server has been signalled to shut down, exit.
+		case <-s.quit:
+			return errServerShuttingDown
+		}
+	}
+}
+
 // HtlcInterceptor is a bidirectional stream for streaming interception
 // requests to the caller.
 // Upon connection it does the following:
@@ -1108,3 +1369,113 @@ func (s *Server) UpdateChanStatus(ctx context.Context,
 	}
 	return &UpdateChanStatusResponse{}, nil
 }
+
+// LookupHtlc returns an htlc that is currently held by the switch or the
+// forward interceptor, identified by its incoming channel and htlc index, along
+// with the state that keeps it from being resolved. For payments initiated by
+// our node, the incoming channel is zero and the htlc index is the attempt id.
+// If neither the switch nor the interceptor hold the htlc, a NotFound error is
+// returned.
+func (s *Server) LookupHtlc(_ context.Context,
+	req *LookupHtlcRequest) (*LookupHtlcResponse, error) {
+
+	inKey := htlcswitch.CircuitKey{
+		ChanID: lnwire.NewShortChanIDFromInt(req.IncomingChanId),
+		HtlcID: req.IncomingHtlcId,
+	}
+
+	htlc, err := s.cfg.RouterBackend.LookupPendingHtlc(inKey)
+	switch {
+	case errors.Is(err, htlcswitch.ErrUnknownCircuit):
+		return nil, status.Errorf(codes.NotFound, "htlc %v is not "+
+			"held by the switch or the interceptor", inKey)
+
+	case err != nil:
+		return nil, err
+	}
+
+	return &LookupHtlcResponse{
+		Htlc: marshallPendingHtlc(htlc),
+	}, nil
+}
+
+// ListStuckHtlcs returns all htlcs that have been held by the switch or the
+// forward interceptor for at least the requested amount of time, sorted by
+// their hold time, longest first. If the requested minimum hold time is zero,
+// all held htlcs are returned.
+func (s *Server) ListStuckHtlcs(_ context.Context,
+	req *ListStuckHtlcsRequest) (*ListStuckHtlcsResponse, error) {
+
+	htlcs, err := s.cfg.RouterBackend.PendingHtlcs()
+	if err != nil {
+		return nil, err
+	}
+
+	minHoldTime := time.Duration(req.MinHoldTimeSec) * time.Second
+
+	resp := &ListStuckHtlcsResponse{}
+	for i := range htlcs {
+		// The htlcs are sorted by their hold time, so we can stop at
+		// the first one that hasn't been held long enough.
+		if htlcs[i].HoldTime < minHoldTime {
+			break
+		}
+
+		resp.Htlcs = append(resp.Htlcs, marshallPendingHtlc(&htlcs[i]))
+	}
+
+	return resp, nil
+}
+
+// marshallPendingHtlc converts a pending htlc to its rpc
+// representation.
+func marshallPendingHtlc(htlc *htlcswitch.PendingHtlc) *PendingHtlc {
+	rpcHtlc := &PendingHtlc{
+		IncomingChanId:  htlc.Incoming.ChanID.ToUint64(),
+		IncomingHtlcId:  htlc.Incoming.HtlcID,
+		OutgoingChanId:  htlc.OutgoingChanID.ToUint64(),
+		OutgoingAdded:   htlc.Outgoing != nil,
+		PaymentHash:     htlc.PaymentHash[:],
+		IncomingAmtMsat: uint64(htlc.IncomingAmount),
+		OutgoingAmtMsat: uint64(htlc.OutgoingAmount),
+		IncomingExpiry:  htlc.IncomingExpiry,
+		OutgoingExpiry:  htlc.OutgoingExpiry,
+		HoldTimeSec:     uint64(htlc.HoldTime / time.Second),
+		State:           rpcPendingHtlcState(htlc.State),
+	}
+	if htlc.Outgoing != nil {
+		rpcHtlc.OutgoingHtlcId = htlc.Outgoing.HtlcID
+	}
+
+	return rpcHtlc
+}
+
+// rpcPendingHtlcState converts the state of a pending htlc to its rpc
+// representation.
+func rpcPendingHtlcState(state htlcswitch.PendingHtlcState) PendingHtlcState {
+	switch state {
+	case htlcswitch.PendingHtlcQueued:
+		return PendingHtlcState_PENDING_HTLC_QUEUED
+
+	case htlcswitch.PendingHtlcOutgoingIneligible:
+		return PendingHtlcState_PENDING_HTLC_OUTGOING_INELIGIBLE
+
+	case htlcswitch.PendingHtlcOutgoingOffline:
+		return PendingHtlcState_PENDING_HTLC_OUTGOING_OFFLINE
+
+	case htlcswitch.PendingHtlcAwaitingDownstream:
+		return PendingHtlcState_PENDING_HTLC_AWAITING_DOWNSTREAM
+
+	case htlcswitch.PendingHtlcResolving:
+		return PendingHtlcState_PENDING_HTLC_RESOLVING
+
+	case htlcswitch.PendingHtlcIncomingOffline:
+		return PendingHtlcState_PENDING_HTLC_INCOMING_OFFLINE
+
+	case htlcswitch.PendingHtlcIntercepted:
+		return PendingHtlcState_PENDING_HTLC_INTERCEPTED
+
+	default:
+		return PendingHtlcState_PENDING_HTLC_UNASSIGNED
+	}
+}
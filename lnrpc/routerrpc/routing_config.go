@@ -1,8 +1,11 @@
 package routerrpc
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/routing"
 	"github.com/ltcsuite/ltcd/ltcutil"
 )
 
@@ -41,6 +44,79 @@ type RoutingConfig struct {
 
 	// BimodalConfig defines parameters for the bimodal probability.
 	BimodalConfig *BimodalConfig `group:"bimodal" namespace:"bimodal" description:"configuration for the bimodal pathfinding probability estimator"`
+
+	// MppConfig defines parameters for splitting multi-part payments.
+	MppConfig *MppConfig `group:"mpp" namespace:"mpp" description:"configuration for splitting multi-part payments"`
+
+	// ProfilePathFinding attaches pprof labels to path finding, so that
+	// profiles can be broken down by path finding mode and payment.
+	ProfilePathFinding bool `long:"profilepathfinding" description:"Attach pprof labels to path finding calls, so that cpu and allocation profiles can be filtered by path finding mode and payment"`
+}
+
+// NewEstimator creates the probability estimator that is selected by the
+// routing config, using the parameters configured for it.
+func (r *RoutingConfig) NewEstimator() (routing.Estimator, error) {
+	switch r.ProbabilityEstimatorType {
+	case routing.AprioriEstimatorName:
+		aCfg := r.AprioriConfig
+		aprioriConfig := routing.AprioriConfig{
+			AprioriHopProbability: aCfg.HopProbability,
+			PenaltyHalfLife:       aCfg.PenaltyHalfLife,
+			AprioriWeight:         aCfg.Weight,
+			CapacityFraction:      aCfg.CapacityFraction,
+		}
+
+		return routing.NewAprioriEstimator(aprioriConfig)
+
+	case routing.BimodalEstimatorName:
+		bCfg := r.BimodalConfig
+		bimodalConfig := routing.BimodalConfig{
+			BimodalNodeWeight: bCfg.NodeWeight,
+			BimodalScaleMsat:  lnwire.MilliSatoshi(bCfg.Scale),
+			BimodalDecayTime:  bCfg.DecayTime,
+		}
+
+		return routing.NewBimodalEstimator(bimodalConfig)
+
+	default:
+		return nil, fmt.Errorf("unknown estimator type %v",
+			r.ProbabilityEstimatorType)
+	}
+}
+
+// NewSplitConfig creates the config that is used to split multi-part
+// payments.
+func (r *RoutingConfig) NewSplitConfig() routing.SplitConfig {
+	return routing.SplitConfig{
+		Strategy: r.MppConfig.SplitStrategy,
+		MinShardAmt: lnwire.NewMSatFromSatoshis(
+			r.MppConfig.MinShardAmt,
+		),
+		SplitFactor: r.MppConfig.SplitFactor,
+	}
+}
+
+// Validate checks that the selected probability estimator can be created with
+// the configured parameters, and that the payment splitting parameters are
+// sane, so that misconfigurations are caught on startup.
+//
+// NOTE: This is part of the lncfg.Validator interface.
+func (r *RoutingConfig) Validate() error {
+	if _, err := r.NewEstimator(); err != nil {
+		return fmt.Errorf("invalid estimator config: %w", err)
+	}
+
+	if r.MppConfig.MaxParts == 0 {
+		return fmt.Errorf("invalid mpp config: max parts must be " +
+			"positive")
+	}
+
+	splitConfig := r.NewSplitConfig()
+	if err := splitConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid mpp config: %w", err)
+	}
+
+	return nil
 }
 
 // AprioriConfig defines parameters for the apriori probability.
@@ -86,3 +162,24 @@ type BimodalConfig struct {
 	// time for previous successes or failures.
 	DecayTime time.Duration `long:"decaytime" description:"Describes the information decay of knowledge about previous successes and failures in channels."`
 }
+
+// MppConfig defines parameters for splitting multi-part payments.
+//
+//nolint:lll
+type MppConfig struct {
+	// MaxParts is the maximum number of partial payments a payment may be
+	// split into, if the caller doesn't specify it.
+	MaxParts uint32 `long:"maxparts" description:"The default maximum number of partial payments a payment may be split into, used if the payment request doesn't specify it."`
+
+	// MinShardAmt is the amount below which a payment won't be split any
+	// further.
+	MinShardAmt ltcutil.Amount `long:"minshardamt" description:"The minimum amount in satoshis of a partial payment. Payments won't be split into smaller parts."`
+
+	// SplitStrategy is the strategy used to split a payment if no route
+	// can be found for the full amount.
+	SplitStrategy string `long:"splitstrategy" choice:"halving" choice:"equal" choice:"adaptive" description:"The strategy used to split a payment if no route can be found for the full amount. 'halving' divides the amount by the split factor, 'equal' splits the amount into an increasing number of equal parts and 'adaptive' starts out with a part that fits into the local channel with the highest balance, dividing it by the split factor from there on."`
+
+	// SplitFactor is the factor by which the amount of a partial payment
+	// is divided if no route can be found for it.
+	SplitFactor uint32 `long:"splitfactor" description:"The factor by which the amount of a partial payment is divided if no route can be found for it. Not used by the 'equal' split strategy."`
+}
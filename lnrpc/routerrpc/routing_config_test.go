@@ -0,0 +1,174 @@
+package routerrpc
+
+import (
+	"testing"
+
+	"github.com/ltcsuite/lnd/routing"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoutingConfigEstimator tests that the routing config creates the
+// selected probability estimator and rejects invalid parameters.
+func TestRoutingConfigEstimator(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		modify        func(cfg *RoutingConfig)
+		expectedType  routing.Estimator
+		expectedError error
+	}{
+		{
+			name:         "default apriori",
+			modify:       func(cfg *RoutingConfig) {},
+			expectedType: &routing.AprioriEstimator{},
+		},
+		{
+			name: "bimodal",
+			modify: func(cfg *RoutingConfig) {
+				cfg.ProbabilityEstimatorType =
+					routing.BimodalEstimatorName
+			},
+			expectedType: &routing.BimodalEstimator{},
+		},
+		{
+			name: "invalid bimodal node weight",
+			modify: func(cfg *RoutingConfig) {
+				cfg.ProbabilityEstimatorType =
+					routing.BimodalEstimatorName
+				cfg.BimodalConfig.NodeWeight = 2
+			},
+			expectedError: routing.ErrInvalidNodeWeight,
+		},
+		{
+			name: "unknown estimator",
+			modify: func(cfg *RoutingConfig) {
+				cfg.ProbabilityEstimatorType = "unknown"
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := DefaultConfig().RoutingConfig
+			testCase.modify(&cfg)
+
+			estimator, err := cfg.NewEstimator()
+			if testCase.expectedType == nil {
+				require.Error(t, err)
+				require.Error(t, cfg.Validate())
+
+				if testCase.expectedError != nil {
+					require.ErrorIs(
+						t, err, testCase.expectedError,
+					)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, cfg.Validate())
+			require.IsType(t, testCase.expectedType, estimator)
+		})
+	}
+}
+
+// TestRoutingConfigMpp tests that the routing config creates the configured
+// payment split config and rejects invalid parameters.
+func TestRoutingConfigMpp(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		modify    func(cfg *MppConfig)
+		expectErr bool
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *MppConfig) {},
+		},
+		{
+			name: "adaptive",
+			modify: func(cfg *MppConfig) {
+				cfg.SplitStrategy = routing.SplitStrategyAdaptive
+				cfg.SplitFactor = 4
+			},
+		},
+		{
+			name: "zero max parts",
+			modify: func(cfg *MppConfig) {
+				cfg.MaxParts = 0
+			},
+			expectErr: true,
+		},
+		{
+			name: "zero min shard amount",
+			modify: func(cfg *MppConfig) {
+				cfg.MinShardAmt = 0
+			},
+			expectErr: true,
+		},
+		{
+			name: "split factor too low",
+			modify: func(cfg *MppConfig) {
+				cfg.SplitFactor = 1
+			},
+			expectErr: true,
+		},
+		{
+			name: "unknown strategy",
+			modify: func(cfg *MppConfig) {
+				cfg.SplitStrategy = "unknown"
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := DefaultConfig().RoutingConfig
+			testCase.modify(cfg.MppConfig)
+
+			if testCase.expectErr {
+				require.Error(t, cfg.Validate())
+				return
+			}
+
+			require.NoError(t, cfg.Validate())
+
+			splitConfig := cfg.NewSplitConfig()
+			require.Equal(
+				t, cfg.MppConfig.SplitStrategy,
+				splitConfig.Strategy,
+			)
+			require.Equal(
+				t, cfg.MppConfig.SplitFactor,
+				splitConfig.SplitFactor,
+			)
+			require.Equal(
+				t, cfg.MppConfig.MinShardAmt,
+				splitConfig.MinShardAmt.ToSatoshis(),
+			)
+		})
+	}
+}
+
+// TestGetRoutingConfig tests that the routing config of the sub server config
+// is copied without dropping any options.
+func TestGetRoutingConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.ProfilePathFinding = true
+
+	require.Equal(t, &cfg.RoutingConfig, GetRoutingConfig(cfg))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ltcsuite/lnd/htlcswitch"
 	"github.com/ltcsuite/lnd/invoices"
 	"github.com/ltcsuite/lnd/lnrpc"
+	"github.com/ltcsuite/lnd/lnwire"
 )
 
 // rpcHtlcEvent returns a rpc htlc event from a htlcswitch event.
@@ -122,10 +123,27 @@ func rpcHtlcEvent(htlcEvent interface{}) (*HtlcEvent, error) {
 // switch's htlc info struct.
 func rpcInfo(info htlcswitch.HtlcInfo) *HtlcInfo {
 	return &HtlcInfo{
-		IncomingTimelock: info.IncomingTimeLock,
-		OutgoingTimelock: info.OutgoingTimeLock,
-		IncomingAmtMsat:  uint64(info.IncomingAmt),
-		OutgoingAmtMsat:  uint64(info.OutgoingAmt),
+		IncomingTimelock:    info.IncomingTimeLock,
+		OutgoingTimelock:    info.OutgoingTimeLock,
+		IncomingAmtMsat:     uint64(info.IncomingAmt),
+		OutgoingAmtMsat:     uint64(info.OutgoingAmt),
+		IncomingEndorsement: rpcEndorsement(info.IncomingEndorsement),
+		OutgoingEndorsement: rpcEndorsement(info.OutgoingEndorsement),
+	}
+}
+
+// rpcEndorsement maps an experimental endorsement signal to its rpc
+// counterpart.
+func rpcEndorsement(endorsement lnwire.Endorsement) HtlcEndorsement {
+	switch endorsement {
+	case lnwire.EndorsementUnendorsed:
+		return HtlcEndorsement_ENDORSEMENT_UNENDORSED
+
+	case lnwire.EndorsementEndorsed:
+		return HtlcEndorsement_ENDORSEMENT_ENDORSED
+
+	default:
+		return HtlcEndorsement_ENDORSEMENT_NONE
 	}
 }
 
@@ -254,6 +272,15 @@ func rpcOutgoingFailure(failureDetail htlcswitch.OutgoingFailure) (
 	case htlcswitch.OutgoingFailureForwardsDisabled:
 		return FailureDetail_FORWARDS_DISABLED, nil
 
+	case htlcswitch.OutgoingFailurePeerPendingHtlcLimit:
+		return FailureDetail_PEER_PENDING_HTLC_LIMIT, nil
+
+	case htlcswitch.OutgoingFailurePeerHtlcRateLimit:
+		return FailureDetail_PEER_HTLC_RATE_LIMIT, nil
+
+	case htlcswitch.OutgoingFailureHtlcSlotsReserved:
+		return FailureDetail_HTLC_SLOTS_RESERVED, nil
+
 	default:
 		return 0, fmt.Errorf("unknown outgoing failure "+
 			"detail: %v", failureDetail.FailureString())
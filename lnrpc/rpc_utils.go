@@ -76,8 +76,19 @@ func RPCTransaction(tx *lnwallet.TransactionDetail) *Transaction {
 			address = o.Addresses[0].EncodeAddress()
 		}
 
+		// MWEB outputs are witness programs of an unknown version to
+		// txscript, so we'll identify them by their script instead.
+		outputType := MarshallOutputType(o.OutputType)
+		switch {
+		case lnwallet.IsMwebPegIn(o.PkScript):
+			outputType = OutputScriptType_SCRIPT_TYPE_MWEB_PEGIN
+
+		case lnwallet.IsMwebHogAddr(o.PkScript):
+			outputType = OutputScriptType_SCRIPT_TYPE_MWEB_HOGADDR
+		}
+
 		outputDetails = append(outputDetails, &OutputDetail{
-			OutputType:   MarshallOutputType(o.OutputType),
+			OutputType:   outputType,
 			Address:      address,
 			PkScript:     hex.EncodeToString(o.PkScript),
 			OutputIndex:  int64(o.OutputIndex),
@@ -113,6 +124,7 @@ func RPCTransaction(tx *lnwallet.TransactionDetail) *Transaction {
 		RawTxHex:          hex.EncodeToString(tx.RawTx),
 		Label:             tx.Label,
 		PreviousOutpoints: previousOutpoints,
+		IsMwebPegOut:      tx.IsMwebPegOut,
 	}
 }
 
@@ -213,11 +225,9 @@ func CalculateFeeRate(satPerByte, satPerVByte uint64, targetConf uint32,
 	}
 
 	// Default to satPerVByte, and overwrite it if satPerByte is set.
-	satPerKw := chainfee.SatPerKVByte(satPerVByte * 1000).FeePerKWeight()
+	satPerKw := chainfee.SatPerVByte(satPerVByte).FeePerKWeight()
 	if satPerByte != 0 {
-		satPerKw = chainfee.SatPerKVByte(
-			satPerByte * 1000,
-		).FeePerKWeight()
+		satPerKw = chainfee.SatPerVByte(satPerByte).FeePerKWeight()
 	}
 
 	// Based on the passed fee related parameters, we'll determine an
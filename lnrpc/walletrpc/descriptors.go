@@ -0,0 +1,168 @@
+package walletrpc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/ltcutil/hdkeychain"
+)
+
+const (
+	// descriptorInputCharset is the character set of output descriptors as
+	// defined in BIP-0380. The position of a character determines its
+	// value in the descriptor checksum.
+	descriptorInputCharset = "0123456789()[],'/*abcdefgh@:$%{}" +
+		"IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~" +
+		"ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+
+	// descriptorChecksumCharset is the character set the descriptor
+	// checksum is encoded with.
+	descriptorChecksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// descriptorChecksumLength is the number of characters of a
+	// descriptor checksum.
+	descriptorChecksumLength = 8
+)
+
+// descriptorGenerator is the generator of the BCH code the descriptor checksum
+// is based on.
+var descriptorGenerator = [5]uint64{
+	0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd,
+}
+
+// AccountDescriptors returns the output descriptors of the external and
+// internal branch of the given account, as returned by the walletkit's
+// ListAccounts RPC. The descriptors use the syntax of BIP-0380 and following,
+// including their checksum, so they can be imported into other wallet software
+// such as Litecoin Core to set up a watch-only mirror of the account. The key
+// origin is only added if the account has a master key fingerprint.
+func AccountDescriptors(acct *Account, params *chaincfg.Params) (string,
+	string, error) {
+
+	if acct.ExtendedPublicKey == "" {
+		return "", "", fmt.Errorf("account %s has no extended public "+
+			"key", acct.Name)
+	}
+
+	accountKey, err := hdkeychain.NewKeyFromString(acct.ExtendedPublicKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Descriptors always use the plain xpub/tpub version of the network,
+	// the script type is defined by the descriptor itself.
+	accountKey, err = accountKey.CloneWithVersion(params.HDPublicKeyID[:])
+	if err != nil {
+		return "", "", err
+	}
+
+	key := accountKey.String()
+	switch len(acct.MasterKeyFingerprint) {
+	case 0:
+
+	case 4:
+		path := strings.TrimPrefix(acct.DerivationPath, "m")
+		key = fmt.Sprintf("[%s%s]%s",
+			hex.EncodeToString(acct.MasterKeyFingerprint), path,
+			key)
+
+	default:
+		return "", "", fmt.Errorf("invalid master key fingerprint "+
+			"length %d", len(acct.MasterKeyFingerprint))
+	}
+
+	var externalFmt, internalFmt string
+	switch acct.AddressType {
+	case AddressType_WITNESS_PUBKEY_HASH:
+		externalFmt, internalFmt = "wpkh(%s/0/*)", "wpkh(%s/1/*)"
+
+	case AddressType_NESTED_WITNESS_PUBKEY_HASH:
+		externalFmt, internalFmt = "sh(wpkh(%s/0/*))", "sh(wpkh(%s/1/*))"
+
+	// Our own BIP-0049Plus schema uses nested witness pubkeys externally
+	// but witness pubkeys internally.
+	case AddressType_HYBRID_NESTED_WITNESS_PUBKEY_HASH:
+		externalFmt, internalFmt = "sh(wpkh(%s/0/*))", "wpkh(%s/1/*)"
+
+	case AddressType_TAPROOT_PUBKEY:
+		externalFmt, internalFmt = "tr(%s/0/*)", "tr(%s/1/*)"
+
+	default:
+		return "", "", fmt.Errorf("unsupported address type %v",
+			acct.AddressType)
+	}
+
+	external, err := addDescriptorChecksum(fmt.Sprintf(externalFmt, key))
+	if err != nil {
+		return "", "", err
+	}
+	internal, err := addDescriptorChecksum(fmt.Sprintf(internalFmt, key))
+	if err != nil {
+		return "", "", err
+	}
+
+	return external, internal, nil
+}
+
+// addDescriptorChecksum appends the BIP-0380 checksum to the given descriptor.
+func addDescriptorChecksum(desc string) (string, error) {
+	symbols := make([]uint64, 0, len(desc)*4/3+descriptorChecksumLength)
+
+	// Each character contributes its lower five bits as a symbol, while
+	// the upper bits of every group of three characters are combined into
+	// an additional symbol.
+	var (
+		groups    uint64
+		numGroups int
+	)
+	for _, c := range desc {
+		pos := strings.IndexRune(descriptorInputCharset, c)
+		if pos < 0 {
+			return "", fmt.Errorf("invalid character %q in "+
+				"descriptor", c)
+		}
+
+		symbols = append(symbols, uint64(pos)&31)
+		groups = groups*3 + uint64(pos)>>5
+		numGroups++
+
+		if numGroups == 3 {
+			symbols = append(symbols, groups)
+			groups, numGroups = 0, 0
+		}
+	}
+	if numGroups > 0 {
+		symbols = append(symbols, groups)
+	}
+
+	symbols = append(symbols, make([]uint64, descriptorChecksumLength)...)
+	checksum := descriptorPolyMod(symbols) ^ 1
+
+	var sb strings.Builder
+	sb.WriteString(desc)
+	sb.WriteByte('#')
+	for i := 0; i < descriptorChecksumLength; i++ {
+		shift := 5 * (descriptorChecksumLength - 1 - i)
+		sb.WriteByte(descriptorChecksumCharset[(checksum>>shift)&31])
+	}
+
+	return sb.String(), nil
+}
+
+// descriptorPolyMod computes the BCH checksum of the given symbols.
+func descriptorPolyMod(symbols []uint64) uint64 {
+	chk := uint64(1)
+	for _, value := range symbols {
+		top := chk >> 35
+		chk = (chk&0x7ffffffff)<<5 ^ value
+		for i := 0; i < len(descriptorGenerator); i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= descriptorGenerator[i]
+			}
+		}
+	}
+
+	return chk
+}
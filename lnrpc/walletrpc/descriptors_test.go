@@ -0,0 +1,106 @@
+package walletrpc
+
+import (
+	"testing"
+
+	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAddDescriptorChecksum tests the descriptor checksum against the test
+// vectors of BIP-0380.
+func TestAddDescriptorChecksum(t *testing.T) {
+	t.Parallel()
+
+	desc, err := addDescriptorChecksum("raw(deadbeef)")
+	require.NoError(t, err)
+	require.Equal(t, "raw(deadbeef)#89f8spxm", desc)
+
+	_, err = addDescriptorChecksum("raw(deadbeef)é")
+	require.Error(t, err)
+}
+
+// TestAccountDescriptors tests that the descriptors of an account are derived
+// correctly for all supported address types.
+func TestAccountDescriptors(t *testing.T) {
+	t.Parallel()
+
+	// The account key of the BIP-0084 test vectors, once with its zpub
+	// version as returned by ListAccounts and once as plain xpub.
+	const (
+		zpub = "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPh" +
+			"XNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs"
+		xpub = "xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwC" +
+			"D3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V"
+	)
+
+	testCases := []struct {
+		name        string
+		addrType    AddressType
+		mkfp        []byte
+		expExternal string
+		expInternal string
+	}{
+		{
+			name:        "p2wkh",
+			addrType:    AddressType_WITNESS_PUBKEY_HASH,
+			mkfp:        []byte{0x73, 0xc5, 0xda, 0x0a},
+			expExternal: "wpkh([73c5da0a/84'/0'/0']" + xpub + "/0/*)",
+			expInternal: "wpkh([73c5da0a/84'/0'/0']" + xpub + "/1/*)",
+		},
+		{
+			name:        "np2wkh",
+			addrType:    AddressType_NESTED_WITNESS_PUBKEY_HASH,
+			expExternal: "sh(wpkh(" + xpub + "/0/*))",
+			expInternal: "sh(wpkh(" + xpub + "/1/*))",
+		},
+		{
+			name:        "hybrid np2wkh",
+			addrType:    AddressType_HYBRID_NESTED_WITNESS_PUBKEY_HASH,
+			expExternal: "sh(wpkh(" + xpub + "/0/*))",
+			expInternal: "wpkh(" + xpub + "/1/*)",
+		},
+		{
+			name:        "p2tr",
+			addrType:    AddressType_TAPROOT_PUBKEY,
+			expExternal: "tr(" + xpub + "/0/*)",
+			expInternal: "tr(" + xpub + "/1/*)",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			acct := &Account{
+				Name:                 "test",
+				AddressType:          tc.addrType,
+				ExtendedPublicKey:    zpub,
+				MasterKeyFingerprint: tc.mkfp,
+				DerivationPath:       "m/84'/0'/0'",
+			}
+			external, internal, err := AccountDescriptors(
+				acct, &chaincfg.MainNetParams,
+			)
+			require.NoError(t, err)
+
+			expExternal, err := addDescriptorChecksum(
+				tc.expExternal,
+			)
+			require.NoError(t, err)
+			expInternal, err := addDescriptorChecksum(
+				tc.expInternal,
+			)
+			require.NoError(t, err)
+
+			require.Equal(t, expExternal, external)
+			require.Equal(t, expInternal, internal)
+		})
+	}
+
+	// Accounts without an extended public key, such as the default
+	// imported account, have no descriptors.
+	_, _, err := AccountDescriptors(&Account{}, &chaincfg.MainNetParams)
+	require.Error(t, err)
+}
@@ -34,7 +34,8 @@ type WalletKitClient interface {
 	// selection if it remains unspent. The ID should match the one used to
 	// originally lock the output.
 	ReleaseOutput(ctx context.Context, in *ReleaseOutputRequest, opts ...grpc.CallOption) (*ReleaseOutputResponse, error)
-	// ListLeases lists all currently locked utxos.
+	// ListLeases lists all currently locked utxos, optionally filtered by lock
+	// ID.
 	ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (*ListLeasesResponse, error)
 	// DeriveNextKey attempts to derive the *next* key within the key family
 	// (account in BIP43) specified. This method should return the next external
@@ -49,6 +50,18 @@ type WalletKitClient interface {
 	// name and key scope filter can be provided to filter through all of the
 	// wallet accounts and return only those matching.
 	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	// CreateAccount derives a new named account from the wallet's seed. The
+	// address type determines the key scope of the account, which in turn
+	// determines the type of addresses that can be requested for it through
+	// NextAddr. Accounts cannot be created by watch-only wallets, which should
+	// use ImportAccount instead.
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error)
+	// ExportDescriptors returns the output descriptors of the external and
+	// internal branch of the wallet's accounts, including their key origin and
+	// checksum. The descriptors can be imported into other wallet software, such
+	// as Litecoin Core, to set up a watch-only mirror of the accounts. A name and
+	// address type filter can be provided to only export the matching accounts.
+	ExportDescriptors(ctx context.Context, in *ExportDescriptorsRequest, opts ...grpc.CallOption) (*ExportDescriptorsResponse, error)
 	// RequiredReserve returns the minimum amount of satoshis that should be kept
 	// in the wallet in order to fee bump anchor channels if necessary. The value
 	// scales with the number of public anchor channels but is capped at a maximum.
@@ -111,8 +124,8 @@ type WalletKitClient interface {
 	// externally, witness pubkeys internally).
 	//
 	// NOTE: Events (deposits/spends) for keys derived from an account will only be
-	// detected by lnd if they happen after the import. Rescans to detect past
-	// events will be supported later on.
+	// detected by lnd if they happen after the import. Use RescanBlockchain to
+	// detect past events.
 	ImportAccount(ctx context.Context, in *ImportAccountRequest, opts ...grpc.CallOption) (*ImportAccountResponse, error)
 	// ImportPublicKey imports a public key as watch-only into the wallet. The
 	// public key is converted into a simple address of the given type and that
@@ -121,8 +134,7 @@ type WalletKitClient interface {
 	// spend or script spend outputs.
 	//
 	// NOTE: Events (deposits/spends) for a key will only be detected by lnd if
-	// they happen after the import. Rescans to detect past events will be
-	// supported later on.
+	// they happen after the import. Use RescanBlockchain to detect past events.
 	ImportPublicKey(ctx context.Context, in *ImportPublicKeyRequest, opts ...grpc.CallOption) (*ImportPublicKeyResponse, error)
 	// ImportTapscript imports a Taproot script and internal key and adds the
 	// resulting Taproot output key as a watch-only output script into the wallet.
@@ -130,12 +142,32 @@ type WalletKitClient interface {
 	// path) use ImportPublicKey.
 	//
 	// NOTE: Events (deposits/spends) for a key will only be detected by lnd if
-	// they happen after the import. Rescans to detect past events will be
-	// supported later on.
+	// they happen after the import. Use RescanBlockchain to detect past events.
 	//
 	// NOTE: Taproot keys imported through this RPC currently _cannot_ be used for
 	// funding PSBTs. Only tracking the balance and UTXOs is currently supported.
 	ImportTapscript(ctx context.Context, in *ImportTapscriptRequest, opts ...grpc.CallOption) (*ImportTapscriptResponse, error)
+	// ImportWitnessScript imports a witness script and adds the resulting
+	// pay-to-witness-script-hash (P2WSH) output script into the wallet. Use
+	// ImportTapscript for Taproot scripts.
+	//
+	// NOTE: Events (deposits/spends) for a script will only be detected by lnd if
+	// they happen after the import. Use RescanBlockchain to detect past events.
+	//
+	// NOTE: Outputs of imported scripts can be spent with SignPsbt and
+	// FinalizePsbt, which add the witness script to the inputs spending them.
+	// Scripts consisting of a single public key of the wallet followed by
+	// OP_CHECKSIG are signed without any further information. Inputs of other
+	// scripts need BIP32 derivation info and are finalized as multisig scripts.
+	// The outputs currently _cannot_ be selected for funding PSBTs.
+	ImportWitnessScript(ctx context.Context, in *ImportWitnessScriptRequest, opts ...grpc.CallOption) (*ImportWitnessScriptResponse, error)
+	// RescanBlockchain rescans the chain for transactions relevant to the wallet,
+	// starting at the given height or the wallet's birthday block if no height is
+	// set. This is useful to detect past events after importing accounts or keys.
+	// The progress of the rescan is streamed until it completes, at which point
+	// the stream is closed. The wallet isn't considered synced to the chain while
+	// the rescan is running.
+	RescanBlockchain(ctx context.Context, in *RescanBlockchainRequest, opts ...grpc.CallOption) (WalletKit_RescanBlockchainClient, error)
 	// PublishTransaction attempts to publish the passed transaction to the
 	// network. Once this returns without an error, the wallet will continually
 	// attempt to re-broadcast the transaction on start up, until it enters the
@@ -318,6 +350,24 @@ func (c *walletKitClient) ListAccounts(ctx context.Context, in *ListAccountsRequ
 	return out, nil
 }
 
+func (c *walletKitClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error) {
+	out := new(CreateAccountResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/CreateAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) ExportDescriptors(ctx context.Context, in *ExportDescriptorsRequest, opts ...grpc.CallOption) (*ExportDescriptorsResponse, error) {
+	out := new(ExportDescriptorsResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/ExportDescriptors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletKitClient) RequiredReserve(ctx context.Context, in *RequiredReserveRequest, opts ...grpc.CallOption) (*RequiredReserveResponse, error) {
 	out := new(RequiredReserveResponse)
 	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/RequiredReserve", in, out, opts...)
@@ -381,6 +431,47 @@ func (c *walletKitClient) ImportTapscript(ctx context.Context, in *ImportTapscri
 	return out, nil
 }
 
+func (c *walletKitClient) ImportWitnessScript(ctx context.Context, in *ImportWitnessScriptRequest, opts ...grpc.CallOption) (*ImportWitnessScriptResponse, error) {
+	out := new(ImportWitnessScriptResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/ImportWitnessScript", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletKitClient) RescanBlockchain(ctx context.Context, in *RescanBlockchainRequest, opts ...grpc.CallOption) (WalletKit_RescanBlockchainClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WalletKit_ServiceDesc.Streams[0], "/walletrpc.WalletKit/RescanBlockchain", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletKitRescanBlockchainClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletKit_RescanBlockchainClient interface {
+	Recv() (*RescanProgress, error)
+	grpc.ClientStream
+}
+
+type walletKitRescanBlockchainClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletKitRescanBlockchainClient) Recv() (*RescanProgress, error) {
+	m := new(RescanProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *walletKitClient) PublishTransaction(ctx context.Context, in *Transaction, opts ...grpc.CallOption) (*PublishResponse, error) {
 	out := new(PublishResponse)
 	err := c.cc.Invoke(ctx, "/walletrpc.WalletKit/PublishTransaction", in, out, opts...)
@@ -490,7 +581,8 @@ type WalletKitServer interface {
 	// selection if it remains unspent. The ID should match the one used to
 	// originally lock the output.
 	ReleaseOutput(context.Context, *ReleaseOutputRequest) (*ReleaseOutputResponse, error)
-	// ListLeases lists all currently locked utxos.
+	// ListLeases lists all currently locked utxos, optionally filtered by lock
+	// ID.
 	ListLeases(context.Context, *ListLeasesRequest) (*ListLeasesResponse, error)
 	// DeriveNextKey attempts to derive the *next* key within the key family
 	// (account in BIP43) specified. This method should return the next external
@@ -505,6 +597,18 @@ type WalletKitServer interface {
 	// name and key scope filter can be provided to filter through all of the
 	// wallet accounts and return only those matching.
 	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	// CreateAccount derives a new named account from the wallet's seed. The
+	// address type determines the key scope of the account, which in turn
+	// determines the type of addresses that can be requested for it through
+	// NextAddr. Accounts cannot be created by watch-only wallets, which should
+	// use ImportAccount instead.
+	CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error)
+	// ExportDescriptors returns the output descriptors of the external and
+	// internal branch of the wallet's accounts, including their key origin and
+	// checksum. The descriptors can be imported into other wallet software, such
+	// as Litecoin Core, to set up a watch-only mirror of the accounts. A name and
+	// address type filter can be provided to only export the matching accounts.
+	ExportDescriptors(context.Context, *ExportDescriptorsRequest) (*ExportDescriptorsResponse, error)
 	// RequiredReserve returns the minimum amount of satoshis that should be kept
 	// in the wallet in order to fee bump anchor channels if necessary. The value
 	// scales with the number of public anchor channels but is capped at a maximum.
@@ -567,8 +671,8 @@ type WalletKitServer interface {
 	// externally, witness pubkeys internally).
 	//
 	// NOTE: Events (deposits/spends) for keys derived from an account will only be
-	// detected by lnd if they happen after the import. Rescans to detect past
-	// events will be supported later on.
+	// detected by lnd if they happen after the import. Use RescanBlockchain to
+	// detect past events.
 	ImportAccount(context.Context, *ImportAccountRequest) (*ImportAccountResponse, error)
 	// ImportPublicKey imports a public key as watch-only into the wallet. The
 	// public key is converted into a simple address of the given type and that
@@ -577,8 +681,7 @@ type WalletKitServer interface {
 	// spend or script spend outputs.
 	//
 	// NOTE: Events (deposits/spends) for a key will only be detected by lnd if
-	// they happen after the import. Rescans to detect past events will be
-	// supported later on.
+	// they happen after the import. Use RescanBlockchain to detect past events.
 	ImportPublicKey(context.Context, *ImportPublicKeyRequest) (*ImportPublicKeyResponse, error)
 	// ImportTapscript imports a Taproot script and internal key and adds the
 	// resulting Taproot output key as a watch-only output script into the wallet.
@@ -586,12 +689,32 @@ type WalletKitServer interface {
 	// path) use ImportPublicKey.
 	//
 	// NOTE: Events (deposits/spends) for a key will only be detected by lnd if
-	// they happen after the import. Rescans to detect past events will be
-	// supported later on.
+	// they happen after the import. Use RescanBlockchain to detect past events.
 	//
 	// NOTE: Taproot keys imported through this RPC currently _cannot_ be used for
 	// funding PSBTs. Only tracking the balance and UTXOs is currently supported.
 	ImportTapscript(context.Context, *ImportTapscriptRequest) (*ImportTapscriptResponse, error)
+	// ImportWitnessScript imports a witness script and adds the resulting
+	// pay-to-witness-script-hash (P2WSH) output script into the wallet. Use
+	// ImportTapscript for Taproot scripts.
+	//
+	// NOTE: Events (deposits/spends) for a script will only be detected by lnd if
+	// they happen after the import. Use RescanBlockchain to detect past events.
+	//
+	// NOTE: Outputs of imported scripts can be spent with SignPsbt and
+	// FinalizePsbt, which add the witness script to the inputs spending them.
+	// Scripts consisting of a single public key of the wallet followed by
+	// OP_CHECKSIG are signed without any further information. Inputs of other
+	// scripts need BIP32 derivation info and are finalized as multisig scripts.
+	// The outputs currently _cannot_ be selected for funding PSBTs.
+	ImportWitnessScript(context.Context, *ImportWitnessScriptRequest) (*ImportWitnessScriptResponse, error)
+	// RescanBlockchain rescans the chain for transactions relevant to the wallet,
+	// starting at the given height or the wallet's birthday block if no height is
+	// set. This is useful to detect past events after importing accounts or keys.
+	// The progress of the rescan is streamed until it completes, at which point
+	// the stream is closed. The wallet isn't considered synced to the chain while
+	// the rescan is running.
+	RescanBlockchain(*RescanBlockchainRequest, WalletKit_RescanBlockchainServer) error
 	// PublishTransaction attempts to publish the passed transaction to the
 	// network. Once this returns without an error, the wallet will continually
 	// attempt to re-broadcast the transaction on start up, until it enters the
@@ -723,6 +846,12 @@ func (UnimplementedWalletKitServer) NextAddr(context.Context, *AddrRequest) (*Ad
 func (UnimplementedWalletKitServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
 }
+func (UnimplementedWalletKitServer) CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (UnimplementedWalletKitServer) ExportDescriptors(context.Context, *ExportDescriptorsRequest) (*ExportDescriptorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportDescriptors not implemented")
+}
 func (UnimplementedWalletKitServer) RequiredReserve(context.Context, *RequiredReserveRequest) (*RequiredReserveResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RequiredReserve not implemented")
 }
@@ -744,6 +873,12 @@ func (UnimplementedWalletKitServer) ImportPublicKey(context.Context, *ImportPubl
 func (UnimplementedWalletKitServer) ImportTapscript(context.Context, *ImportTapscriptRequest) (*ImportTapscriptResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ImportTapscript not implemented")
 }
+func (UnimplementedWalletKitServer) ImportWitnessScript(context.Context, *ImportWitnessScriptRequest) (*ImportWitnessScriptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportWitnessScript not implemented")
+}
+func (UnimplementedWalletKitServer) RescanBlockchain(*RescanBlockchainRequest, WalletKit_RescanBlockchainServer) error {
+	return status.Errorf(codes.Unimplemented, "method RescanBlockchain not implemented")
+}
 func (UnimplementedWalletKitServer) PublishTransaction(context.Context, *Transaction) (*PublishResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PublishTransaction not implemented")
 }
@@ -931,6 +1066,42 @@ func _WalletKit_ListAccounts_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletKit_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/CreateAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_ExportDescriptors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportDescriptorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).ExportDescriptors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/ExportDescriptors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).ExportDescriptors(ctx, req.(*ExportDescriptorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WalletKit_RequiredReserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RequiredReserveRequest)
 	if err := dec(in); err != nil {
@@ -1057,6 +1228,45 @@ func _WalletKit_ImportTapscript_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletKit_ImportWitnessScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportWitnessScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletKitServer).ImportWitnessScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletKit/ImportWitnessScript",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletKitServer).ImportWitnessScript(ctx, req.(*ImportWitnessScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletKit_RescanBlockchain_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RescanBlockchainRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletKitServer).RescanBlockchain(m, &walletKitRescanBlockchainServer{stream})
+}
+
+type WalletKit_RescanBlockchainServer interface {
+	Send(*RescanProgress) error
+	grpc.ServerStream
+}
+
+type walletKitRescanBlockchainServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletKitRescanBlockchainServer) Send(m *RescanProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _WalletKit_PublishTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Transaction)
 	if err := dec(in); err != nil {
@@ -1276,6 +1486,14 @@ var WalletKit_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAccounts",
 			Handler:    _WalletKit_ListAccounts_Handler,
 		},
+		{
+			MethodName: "CreateAccount",
+			Handler:    _WalletKit_CreateAccount_Handler,
+		},
+		{
+			MethodName: "ExportDescriptors",
+			Handler:    _WalletKit_ExportDescriptors_Handler,
+		},
 		{
 			MethodName: "RequiredReserve",
 			Handler:    _WalletKit_RequiredReserve_Handler,
@@ -1304,6 +1522,10 @@ var WalletKit_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ImportTapscript",
 			Handler:    _WalletKit_ImportTapscript_Handler,
 		},
+		{
+			MethodName: "ImportWitnessScript",
+			Handler:    _WalletKit_ImportWitnessScript_Handler,
+		},
 		{
 			MethodName: "PublishTransaction",
 			Handler:    _WalletKit_PublishTransaction_Handler,
@@ -1345,6 +1567,12 @@ var WalletKit_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _WalletKit_FinalizePsbt_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RescanBlockchain",
+			Handler:       _WalletKit_RescanBlockchain_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "walletrpc/walletkit.proto",
 }
@@ -15,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -152,6 +153,14 @@ var (
 			Entity: "onchain",
 			Action: "read",
 		}},
+		"/walletrpc.WalletKit/ExportDescriptors": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+		"/walletrpc.WalletKit/CreateAccount": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
 		"/walletrpc.WalletKit/RequiredReserve": {{
 			Entity: "onchain",
 			Action: "read",
@@ -168,6 +177,14 @@ var (
 			Entity: "onchain",
 			Action: "write",
 		}},
+		"/walletrpc.WalletKit/ImportWitnessScript": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/walletrpc.WalletKit/RescanBlockchain": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
 	}
 
 	// DefaultWalletKitMacFilename is the default name of the wallet kit
@@ -447,15 +464,9 @@ func (w *WalletKit) ListUnspent(ctx context.Context,
 func (w *WalletKit) LeaseOutput(ctx context.Context,
 	req *LeaseOutputRequest) (*LeaseOutputResponse, error) {
 
-	if len(req.Id) != 32 {
-		return nil, errors.New("id must be 32 random bytes")
-	}
-	var lockID wtxmgr.LockID
-	copy(lockID[:], req.Id)
-
-	// Don't allow ID's of 32 bytes, but all zeros.
-	if lockID == (wtxmgr.LockID{}) {
-		return nil, errors.New("id must be 32 random bytes")
+	lockID, err := parseLockID(req.Id)
+	if err != nil {
+		return nil, err
 	}
 
 	// Don't allow our internal ID to be used externally for locking. Only
@@ -499,11 +510,10 @@ func (w *WalletKit) LeaseOutput(ctx context.Context,
 func (w *WalletKit) ReleaseOutput(ctx context.Context,
 	req *ReleaseOutputRequest) (*ReleaseOutputResponse, error) {
 
-	if len(req.Id) != 32 {
-		return nil, errors.New("id must be 32 random bytes")
+	lockID, err := parseLockID(req.Id)
+	if err != nil {
+		return nil, err
 	}
-	var lockID wtxmgr.LockID
-	copy(lockID[:], req.Id)
 
 	op, err := UnmarshallOutPoint(req.Outpoint)
 	if err != nil {
@@ -522,20 +532,57 @@ func (w *WalletKit) ReleaseOutput(ctx context.Context,
 	return &ReleaseOutputResponse{}, nil
 }
 
-// ListLeases returns a list of all currently locked utxos.
+// ListLeases returns a list of all currently locked utxos. If a lock ID is
+// specified, only the leases acquired with that ID are returned.
 func (w *WalletKit) ListLeases(ctx context.Context,
 	req *ListLeasesRequest) (*ListLeasesResponse, error) {
 
+	var filterID *wtxmgr.LockID
+	if len(req.Id) != 0 {
+		lockID, err := parseLockID(req.Id)
+		if err != nil {
+			return nil, err
+		}
+		filterID = &lockID
+	}
+
 	leases, err := w.cfg.Wallet.ListLeasedOutputs()
 	if err != nil {
 		return nil, err
 	}
 
+	if filterID != nil {
+		filtered := make([]*base.ListLeasedOutputResult, 0, len(leases))
+		for _, lease := range leases {
+			if lease.LockID == *filterID {
+				filtered = append(filtered, lease)
+			}
+		}
+		leases = filtered
+	}
+
 	return &ListLeasesResponse{
 		LockedUtxos: marshallLeases(leases),
 	}, nil
 }
 
+// parseLockID parses a lease lock ID, which must consist of 32 bytes that are
+// not all zero.
+func parseLockID(id []byte) (wtxmgr.LockID, error) {
+	var lockID wtxmgr.LockID
+	if len(id) != len(lockID) {
+		return lockID, errors.New("id must be 32 random bytes")
+	}
+	copy(lockID[:], id)
+
+	// Don't allow ID's of 32 bytes, but all zeros.
+	if lockID == (wtxmgr.LockID{}) {
+		return lockID, errors.New("id must be 32 random bytes")
+	}
+
+	return lockID, nil
+}
+
 // DeriveNextKey attempts to derive the *next* key within the key family
 // (account in BIP43) specified. This method should return the next external
 // child within this branch.
@@ -782,12 +829,12 @@ func (w *WalletKit) PendingSweeps(ctx context.Context,
 
 		op := lnrpc.MarshalOutPoint(&pendingInput.OutPoint)
 		amountSat := uint32(pendingInput.Amount)
-		satPerVbyte := uint64(pendingInput.LastFeeRate.FeePerKVByte() / 1000)
+		satPerVbyte := uint64(pendingInput.LastFeeRate.FeePerVByte())
 		broadcastAttempts := uint32(pendingInput.BroadcastAttempts)
 		nextBroadcastHeight := uint32(pendingInput.NextBroadcastHeight)
 
 		requestedFee := pendingInput.Params.Fee
-		requestedFeeRate := uint64(requestedFee.FeeRate.FeePerKVByte() / 1000)
+		requestedFeeRate := uint64(requestedFee.FeeRate.FeePerVByte())
 
 		rpcPendingSweeps = append(rpcPendingSweeps, &PendingSweep{
 			Outpoint:             op,
@@ -861,25 +908,30 @@ func (w *WalletKit) BumpFee(ctx context.Context,
 	}
 
 	// Construct the request's fee preference.
-	satPerKw := chainfee.SatPerKVByte(in.SatPerVbyte * 1000).FeePerKWeight()
+	satPerKw := chainfee.SatPerVByte(in.SatPerVbyte).FeePerKWeight()
 	if in.SatPerByte != 0 {
-		satPerKw = chainfee.SatPerKVByte(
-			in.SatPerByte * 1000,
-		).FeePerKWeight()
+		satPerKw = chainfee.SatPerVByte(in.SatPerByte).FeePerKWeight()
 	}
 	feePreference := sweep.FeePreference{
 		ConfTarget: uint32(in.TargetConf),
 		FeeRate:    satPerKw,
 	}
 
+	// A deadline only lowers the conf target as it approaches, so it
+	// can't be used without one.
+	if in.DeadlineHeight != 0 && in.TargetConf == 0 {
+		return nil, fmt.Errorf("deadline height requires a target conf")
+	}
+
 	// We'll attempt to bump the fee of the input through the UtxoSweeper.
 	// If it is currently attempting to sweep the input, then it'll simply
 	// bump its fee, which will result in a replacement transaction (RBF)
 	// being broadcast. If it is not aware of the input however,
 	// lnwallet.ErrNotMine is returned.
 	params := sweep.ParamsUpdate{
-		Fee:   feePreference,
-		Force: in.Force,
+		Fee:            feePreference,
+		Force:          in.Force,
+		DeadlineHeight: int32(in.DeadlineHeight),
 	}
 
 	_, err = w.cfg.Sweeper.UpdateParams(*op, params)
@@ -946,7 +998,10 @@ func (w *WalletKit) BumpFee(ctx context.Context,
 		op, witnessType, signDesc, uint32(currentHeight),
 	)
 
-	sweepParams := sweep.Params{Fee: feePreference}
+	sweepParams := sweep.Params{
+		Fee:            feePreference,
+		DeadlineHeight: int32(in.DeadlineHeight),
+	}
 	if _, err = w.cfg.Sweeper.SweepInput(inp, sweepParams); err != nil {
 		return nil, err
 	}
@@ -1057,7 +1112,9 @@ func (w *WalletKit) LabelTransaction(ctx context.Context,
 // for default accounts and single imported public keys (only P2TR for now).
 // Otherwise, P2WPKH will be used by default. No custom address type should be
 // provided for custom accounts as we will always generate the change address
-// using the coin selection key scope.
+// using the coin selection key scope. The change output can be pinned to the
+// first, the last or a given position of the outputs to make the layout of
+// the transaction predictable.
 //
 // NOTE: If this method returns without an error, it is the caller's
 // responsibility to either spend the locked UTXOs (by finalizing and then
@@ -1162,8 +1219,8 @@ func (w *WalletKit) FundPsbt(_ context.Context,
 
 	// Convert the fee to sat/kW from the specified sat/vByte.
 	case req.GetSatPerVbyte() != 0:
-		feeSatPerKW = chainfee.SatPerKVByte(
-			req.GetSatPerVbyte() * 1000,
+		feeSatPerKW = chainfee.SatPerVByte(
+			req.GetSatPerVbyte(),
 		).FeePerKWeight()
 
 	default:
@@ -1224,6 +1281,16 @@ func (w *WalletKit) FundPsbt(_ context.Context,
 			return fmt.Errorf("wallet couldn't fund PSBT: %v", err)
 		}
 
+		// Move the change output to the requested position, if the
+		// wallet added one.
+		changeIndex, err = moveChangeOutput(
+			packet, changeIndex, req.ChangePosition,
+			req.ChangeOutputIndex,
+		)
+		if err != nil {
+			return err
+		}
+
 		// Make sure we can properly serialize the packet. If this goes
 		// wrong then something isn't right with the inputs and we
 		// probably shouldn't try to lock any of them.
@@ -1579,6 +1646,87 @@ func (w *WalletKit) ListAccounts(ctx context.Context,
 	return &ListAccountsResponse{Accounts: rpcAccounts}, nil
 }
 
+// CreateAccount derives a new named account from the wallet's seed. The
+// address type determines the key scope of the account and with it the type of
+// addresses that can be requested for it.
+func (w *WalletKit) CreateAccount(_ context.Context,
+	req *CreateAccountRequest) (*CreateAccountResponse, error) {
+
+	var addrType lnwallet.AddressType
+	switch req.AddressType {
+	case AddressType_UNKNOWN, AddressType_WITNESS_PUBKEY_HASH:
+		addrType = lnwallet.WitnessPubKey
+
+	case AddressType_NESTED_WITNESS_PUBKEY_HASH,
+		AddressType_HYBRID_NESTED_WITNESS_PUBKEY_HASH:
+
+		addrType = lnwallet.NestedWitnessPubKey
+
+	case AddressType_TAPROOT_PUBKEY:
+		addrType = lnwallet.TaprootPubkey
+
+	default:
+		return nil, fmt.Errorf("unhandled address type %v",
+			req.AddressType)
+	}
+
+	accountProps, err := w.cfg.Wallet.CreateAccount(req.Name, addrType)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcAccount, err := marshalWalletAccount(w.internalScope(), accountProps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateAccountResponse{Account: rpcAccount}, nil
+}
+
+// ExportDescriptors returns the output descriptors of the external and internal
+// branch of the wallet's accounts. Accounts that don't derive their keys from an
+// extended public key, such as the default imported accounts, and the accounts
+// of lnd's internal key scope are skipped.
+func (w *WalletKit) ExportDescriptors(ctx context.Context,
+	req *ExportDescriptorsRequest) (*ExportDescriptorsResponse, error) {
+
+	accounts, err := w.ListAccounts(ctx, &ListAccountsRequest{
+		Name:        req.Name,
+		AddressType: req.AddressType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	internalPath := fmt.Sprintf("m/%d'/", w.internalScope().Purpose)
+
+	resp := &ExportDescriptorsResponse{}
+	for _, account := range accounts.Accounts {
+		if account.ExtendedPublicKey == "" ||
+			strings.HasPrefix(account.DerivationPath, internalPath) {
+
+			continue
+		}
+
+		external, internal, err := AccountDescriptors(
+			account, w.cfg.ChainParams,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to export descriptors "+
+				"of account %s: %w", account.Name, err)
+		}
+
+		resp.Accounts = append(resp.Accounts, &AccountDescriptor{
+			Name:               account.Name,
+			AddressType:        account.AddressType,
+			ExternalDescriptor: external,
+			InternalDescriptor: internal,
+		})
+	}
+
+	return resp, nil
+}
+
 // RequiredReserve returns the minimum amount of satoshis that should be
 // kept in the wallet in order to fee bump anchor channels if necessary.
 // The value scales with the number of public anchor channels but is
@@ -2056,3 +2204,93 @@ func (w *WalletKit) ImportTapscript(_ context.Context,
 		P2TrAddress: addr.Address().String(),
 	}, nil
 }
+
+// ImportWitnessScript imports a witness script and adds the resulting P2WSH
+// output script into the wallet.
+//
+// NOTE: Outputs of imported scripts can be spent with SignPsbt and
+// FinalizePsbt, but they currently _cannot_ be selected for funding PSBTs.
+func (w *WalletKit) ImportWitnessScript(_ context.Context,
+	req *ImportWitnessScriptRequest) (*ImportWitnessScriptResponse,
+	error) {
+
+	if len(req.WitnessScript) == 0 {
+		return nil, fmt.Errorf("missing witness script")
+	}
+	if len(req.WitnessScript) > txscript.MaxScriptSize {
+		return nil, fmt.Errorf("witness script exceeds maximum size "+
+			"of %d bytes", txscript.MaxScriptSize)
+	}
+
+	scope := waddrmgr.KeyScopeBIP0084
+	addr, err := w.cfg.Wallet.ImportWitnessScript(
+		scope, req.WitnessScript,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error importing script into wallet: %v",
+			err)
+	}
+
+	return &ImportWitnessScriptResponse{
+		P2WshAddress: addr.Address().String(),
+	}, nil
+}
+
+// RescanBlockchain rescans the chain for transactions relevant to the wallet,
+// starting at the given height or the wallet's birthday block if no height is
+// set. The progress of the rescan is streamed until it completes.
+func (w *WalletKit) RescanBlockchain(req *RescanBlockchainRequest,
+	stream WalletKit_RescanBlockchainServer) error {
+
+	if req.StartHeight > math.MaxInt32 {
+		return fmt.Errorf("invalid start height %d", req.StartHeight)
+	}
+
+	// The rescan continues in the background if the client goes away, so
+	// the wallet must never block on a progress update. We therefore only
+	// keep the latest update around until it's sent to the client.
+	updates := make(chan *RescanProgress, 1)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- w.cfg.Wallet.Rescan(
+			int32(req.StartHeight),
+			func(height int32, progress float64) {
+				select {
+				case <-updates:
+				default:
+				}
+
+				updates <- &RescanProgress{
+					Height:   uint32(height),
+					Progress: progress,
+				}
+			},
+		)
+	}()
+
+	for {
+		select {
+		case update := <-updates:
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+
+		case err := <-errChan:
+			if err != nil {
+				return err
+			}
+
+			// Make sure the final update reaches the client before
+			// we close the stream.
+			select {
+			case update := <-updates:
+				return stream.Send(update)
+			default:
+				return nil
+			}
+
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
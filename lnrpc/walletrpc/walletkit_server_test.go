@@ -48,3 +48,21 @@ func TestWitnessTypeMapping(t *testing.T) {
 		})
 	}
 }
+
+// TestParseLockID tests that only non-zero 32 byte lock IDs are accepted.
+func TestParseLockID(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseLockID(nil)
+	require.Error(t, err)
+
+	_, err = parseLockID(make([]byte, 31))
+	require.Error(t, err)
+
+	_, err = parseLockID(make([]byte, 32))
+	require.Error(t, err)
+
+	lockID, err := parseLockID(LndInternalLockID[:])
+	require.NoError(t, err)
+	require.Equal(t, LndInternalLockID, lockID)
+}
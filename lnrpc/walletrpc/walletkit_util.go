@@ -8,6 +8,7 @@ import (
 
 	"github.com/ltcsuite/lnd/lnrpc"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil/psbt"
 	"github.com/ltcsuite/ltcd/wire"
 )
 
@@ -96,3 +97,72 @@ func doubleHashMessage(prefix string, msg string) ([]byte, error) {
 
 	return digest, nil
 }
+
+// moveChangeOutput moves the change output at the given index of the packet to
+// the requested position and returns its new index. The target index is only
+// used for ChangePosition_CHANGE_POSITION_INDEX. The relative order of all
+// other outputs is kept. A negative change index means the packet doesn't have
+// a change output, in which case it is left untouched.
+func moveChangeOutput(packet *psbt.Packet, changeIndex int32,
+	position ChangePosition, targetIndex uint32) (int32, error) {
+
+	if changeIndex < 0 {
+		return changeIndex, nil
+	}
+
+	numOutputs := len(packet.UnsignedTx.TxOut)
+	if int(changeIndex) >= numOutputs || len(packet.Outputs) != numOutputs {
+		return 0, fmt.Errorf("invalid change output index %d for "+
+			"packet with %d outputs", changeIndex, numOutputs)
+	}
+
+	var newIndex int
+	switch position {
+	case ChangePosition_CHANGE_POSITION_UNSPECIFIED:
+		return changeIndex, nil
+
+	case ChangePosition_CHANGE_POSITION_FIRST:
+		newIndex = 0
+
+	case ChangePosition_CHANGE_POSITION_LAST:
+		newIndex = numOutputs - 1
+
+	case ChangePosition_CHANGE_POSITION_INDEX:
+		if int(targetIndex) >= numOutputs {
+			return 0, fmt.Errorf("change output index %d exceeds "+
+				"the %d outputs of the template", targetIndex,
+				numOutputs-1)
+		}
+		newIndex = int(targetIndex)
+
+	default:
+		return 0, fmt.Errorf("unknown change position %v", position)
+	}
+
+	changeTxOut := packet.UnsignedTx.TxOut[changeIndex]
+	changeOutput := packet.Outputs[changeIndex]
+
+	txOuts := make([]*wire.TxOut, 0, numOutputs)
+	outputs := make([]psbt.POutput, 0, numOutputs)
+	for idx := range packet.UnsignedTx.TxOut {
+		if idx == int(changeIndex) {
+			continue
+		}
+
+		if len(txOuts) == newIndex {
+			txOuts = append(txOuts, changeTxOut)
+			outputs = append(outputs, changeOutput)
+		}
+		txOuts = append(txOuts, packet.UnsignedTx.TxOut[idx])
+		outputs = append(outputs, packet.Outputs[idx])
+	}
+	if len(txOuts) == newIndex {
+		txOuts = append(txOuts, changeTxOut)
+		outputs = append(outputs, changeOutput)
+	}
+
+	packet.UnsignedTx.TxOut = txOuts
+	packet.Outputs = outputs
+
+	return int32(newIndex), nil
+}
@@ -3,6 +3,8 @@ package walletrpc
 import (
 	"testing"
 
+	"github.com/ltcsuite/ltcd/ltcutil/psbt"
+	"github.com/ltcsuite/ltcd/wire"
 	"github.com/stretchr/testify/require"
 )
 
@@ -72,3 +74,122 @@ func TestParseDerivationPath(t *testing.T) {
 		})
 	}
 }
+
+// TestMoveChangeOutput tests that the change output of a PSBT packet is moved
+// to the requested position without changing the order of the other outputs.
+func TestMoveChangeOutput(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		changeIndex int32
+		position    ChangePosition
+		targetIndex uint32
+		expectedIdx int32
+		expected    []int64
+	}{
+		{
+			name:        "no change",
+			changeIndex: -1,
+			position:    ChangePosition_CHANGE_POSITION_LAST,
+			expectedIdx: -1,
+			expected:    []int64{1, 2, 3},
+		},
+		{
+			name:        "unspecified",
+			changeIndex: 1,
+			position:    ChangePosition_CHANGE_POSITION_UNSPECIFIED,
+			expectedIdx: 1,
+			expected:    []int64{1, 2, 3},
+		},
+		{
+			name:        "first",
+			changeIndex: 1,
+			position:    ChangePosition_CHANGE_POSITION_FIRST,
+			expectedIdx: 0,
+			expected:    []int64{2, 1, 3},
+		},
+		{
+			name:        "last",
+			changeIndex: 0,
+			position:    ChangePosition_CHANGE_POSITION_LAST,
+			expectedIdx: 2,
+			expected:    []int64{2, 3, 1},
+		},
+		{
+			name:        "already last",
+			changeIndex: 2,
+			position:    ChangePosition_CHANGE_POSITION_LAST,
+			expectedIdx: 2,
+			expected:    []int64{1, 2, 3},
+		},
+		{
+			name:        "index",
+			changeIndex: 0,
+			position:    ChangePosition_CHANGE_POSITION_INDEX,
+			targetIndex: 1,
+			expectedIdx: 1,
+			expected:    []int64{2, 1, 3},
+		},
+		{
+			name:        "last index",
+			changeIndex: 1,
+			position:    ChangePosition_CHANGE_POSITION_INDEX,
+			targetIndex: 2,
+			expectedIdx: 2,
+			expected:    []int64{1, 3, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			packet, err := psbt.New(nil, []*wire.TxOut{
+				{Value: 1}, {Value: 2}, {Value: 3},
+			}, 2, 0, nil)
+			require.NoError(t, err)
+
+			// Tag the PSBT outputs so we can make sure they are
+			// moved together with the transaction outputs.
+			for idx := range packet.Outputs {
+				packet.Outputs[idx].RedeemScript = []byte{
+					byte(packet.UnsignedTx.TxOut[idx].Value),
+				}
+			}
+
+			changeIdx, err := moveChangeOutput(
+				packet, tc.changeIndex, tc.position,
+				tc.targetIndex,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedIdx, changeIdx)
+
+			for idx, txOut := range packet.UnsignedTx.TxOut {
+				require.Equal(t, tc.expected[idx], txOut.Value)
+				require.Equal(
+					t, []byte{byte(txOut.Value)},
+					packet.Outputs[idx].RedeemScript,
+				)
+			}
+		})
+	}
+
+	packet, err := psbt.New(nil, []*wire.TxOut{{Value: 1}}, 2, 0, nil)
+	require.NoError(t, err)
+	_, err = moveChangeOutput(
+		packet, 1, ChangePosition_CHANGE_POSITION_LAST, 0,
+	)
+	require.Error(t, err)
+
+	// The change output can't be placed beyond the last output.
+	packet, err = psbt.New(nil, []*wire.TxOut{
+		{Value: 1}, {Value: 2},
+	}, 2, 0, nil)
+	require.NoError(t, err)
+	_, err = moveChangeOutput(
+		packet, 1, ChangePosition_CHANGE_POSITION_INDEX, 2,
+	)
+	require.Error(t, err)
+}
@@ -1,5 +1,5 @@
-//go:build !bitcoind && !neutrino
-// +build !bitcoind,!neutrino
+//go:build !litecoind && !neutrino
+// +build !litecoind,!neutrino
 
 package lntest
 
@@ -7,9 +7,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
-	"strings"
 
 	"github.com/ltcsuite/lnd/lntest/node"
 	"github.com/ltcsuite/ltcd/btcjson"
@@ -135,27 +133,12 @@ func NewBackend(miner string, netParams *chaincfg.Params) (
 		// the log files, including any compressed log files from
 		// logrorate, before deleting the temporary log dir.
 		logDir := fmt.Sprintf("%s/%s", baseLogDir, netParams.Name)
-		files, err := ioutil.ReadDir(logDir)
+		err := copyLogFiles(
+			logDir, node.GetLogDir(), "ltcd.log",
+			"output_ltcd_chainbackend.log",
+		)
 		if err != nil {
-			errStr += fmt.Sprintf(
-				"unable to read log directory: %v\n", err,
-			)
-		}
-
-		for _, file := range files {
-			logFile := fmt.Sprintf("%s/%s", logDir, file.Name())
-			newFilename := strings.Replace(
-				file.Name(), "ltcd.log",
-				"output_ltcd_chainbackend.log", 1,
-			)
-			logDestination := fmt.Sprintf(
-				"%s/%s", node.GetLogDir(), newFilename,
-			)
-			err := node.CopyFile(logDestination, logFile)
-			if err != nil {
-				errStr += fmt.Sprintf("unable to copy file: "+
-					"%v\n", err)
-			}
+			errStr += err.Error() + "\n"
 		}
 
 		if err = os.RemoveAll(baseLogDir); err != nil {
@@ -0,0 +1,109 @@
+package lntest
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/ltcsuite/lnd/lntest/node"
+	"github.com/ltcsuite/lnd/lntest/wait"
+)
+
+const (
+	// postgresImage is the docker image used to run the postgres server
+	// for nodes using the postgres backend.
+	postgresImage = "postgres:13-alpine"
+
+	// etcdImage is the docker image used to run the etcd server for nodes
+	// using the etcd backend.
+	etcdImage = "gcr.io/etcd-development/etcd:v3.5.9"
+)
+
+// dbContainer is a database server running in an ephemeral docker container.
+type dbContainer struct {
+	// id is the ID of the docker container.
+	id string
+
+	// addr is the host:port the database server can be reached at.
+	addr string
+}
+
+// startDBContainer starts a database server for the given backend in a new
+// docker container and waits until it accepts connections. The container is
+// removed once it's stopped.
+func startDBContainer(backend node.DatabaseBackend) (*dbContainer, error) {
+	port := node.NextAvailablePort()
+	addr := fmt.Sprintf(node.ListenerFormat, port)
+
+	var args, readyCmd []string
+	switch backend {
+	case node.BackendPostgres:
+		args = []string{
+			"-e", "POSTGRES_PASSWORD=postgres",
+			"-p", fmt.Sprintf("%s:5432", addr),
+			postgresImage, "-N", "500",
+		}
+
+		// The server is restarted once it has been initialized, so
+		// we check the TCP listener, which is only opened after the
+		// restart.
+		readyCmd = []string{
+			"pg_isready", "-h", "127.0.0.1", "-U", "postgres",
+		}
+
+	case node.BackendEtcd:
+		// Use the same limits as the embedded etcd instance.
+		args = []string{
+			"-p", fmt.Sprintf("%s:2379", addr),
+			etcdImage, "etcd",
+			"--listen-client-urls=http://0.0.0.0:2379",
+			"--advertise-client-urls=http://" + addr,
+			"--max-txn-ops=16384",
+			"--max-request-bytes=16777216",
+		}
+		readyCmd = []string{"etcdctl", "endpoint", "health"}
+
+	default:
+		return nil, fmt.Errorf("no container for db backend %v",
+			backend)
+	}
+
+	args = append([]string{"run", "--rm", "-d"}, args...)
+	out, err := exec.Command("docker", args...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("unable to start container: %w: %s",
+			err, out)
+	}
+
+	c := &dbContainer{
+		id:   strings.TrimSpace(string(out)),
+		addr: addr,
+	}
+
+	err = wait.NoError(func() error {
+		args := append([]string{"exec", c.id}, readyCmd...)
+		out, err := exec.Command("docker", args...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, out)
+		}
+
+		return nil
+	}, DefaultTimeout)
+	if err != nil {
+		_ = c.stop()
+		return nil, fmt.Errorf("db container not ready: %w", err)
+	}
+
+	return c, nil
+}
+
+// stop stops and removes the container.
+func (c *dbContainer) stop() error {
+	out, err := exec.Command("docker", "rm", "-f", c.id).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("unable to remove container %s: %w: %s",
+			c.id, err, out)
+	}
+
+	return nil
+}
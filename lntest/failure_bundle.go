@@ -0,0 +1,75 @@
+package lntest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ltcsuite/lnd/lntest/node"
+)
+
+// failureBundleDir is the directory within the log dir that holds the
+// artifacts collected from failed tests.
+const failureBundleDir = "failures"
+
+// copyLogFiles copies the log files in srcDir to dstDir, including any
+// compressed log files from logrotate. The given name is replaced with the
+// new name in the file names.
+func copyLogFiles(srcDir, dstDir, name, newName string) error {
+	files, err := os.ReadDir(srcDir)
+	if err != nil {
+		return fmt.Errorf("unable to read log directory: %w", err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		newFilename := strings.Replace(file.Name(), name, newName, 1)
+		err := node.CopyFile(
+			filepath.Join(dstDir, newFilename),
+			filepath.Join(srcDir, file.Name()),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to copy file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// collectFailureBundle collects the artifacts needed to debug a failed test
+// into a single directory named after the test. For each active node, its
+// logs, a dump of its goroutines and a copy of its database are collected,
+// along with the miner's logs.
+//
+// NOTE: this must be called before the nodes are shut down, as their data
+// directories are removed on shutdown.
+func (h *HarnessTest) collectFailureBundle() {
+	testName := strings.NewReplacer("/", "_", " ", "_").Replace(h.Name())
+	dir := filepath.Join(node.GetLogDir(), failureBundleDir, testName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		h.Logf("unable to create failure bundle dir: %v", err)
+		return
+	}
+
+	minerLogDir := filepath.Join(h.Miner.logPath, harnessNetParams.Name)
+	err := copyLogFiles(minerLogDir, dir, "ltcd.log", minerLogFilename)
+	if err != nil {
+		h.Logf("unable to collect miner logs: %v", err)
+	}
+
+	for _, hn := range h.manager.activeNodes {
+		nodeDir := filepath.Join(
+			dir, fmt.Sprintf("%d-%s", hn.Cfg.NodeID, hn.Name()),
+		)
+		if err := hn.CollectArtifacts(nodeDir); err != nil {
+			h.Logf("unable to collect artifacts of %s: %v",
+				hn.Name(), err)
+		}
+	}
+
+	h.Logf("failure artifacts saved to %s", dir)
+}
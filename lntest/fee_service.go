@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"sync"
 	"testing"
 
@@ -31,6 +32,16 @@ type WebFeeService interface {
 	// SetFeeRate sets the estimated fee rate for a given confirmation
 	// target.
 	SetFeeRate(feeRate chainfee.SatPerKWeight, conf uint32)
+
+	// NodeURL returns the service's endpoint for the node with the given
+	// ID. The node receives the fee rates set with SetNodeFeeRate, and the
+	// fee rates set with SetFeeRate for all other confirmation targets.
+	NodeURL(nodeID uint32) string
+
+	// SetNodeFeeRate sets the estimated fee rate for a given confirmation
+	// target that is only returned to the node with the given ID.
+	SetNodeFeeRate(nodeID uint32, feeRate chainfee.SatPerKWeight,
+		conf uint32)
 }
 
 const (
@@ -51,6 +62,10 @@ type FeeService struct {
 	feeRateMap map[uint32]uint32
 	url        string
 
+	// nodeFeeRateMaps holds the fee rates per confirmation target that
+	// are only returned to a specific node, keyed by the node's ID.
+	nodeFeeRateMaps map[uint32]map[uint32]uint32
+
 	srv  *http.Server
 	wg   sync.WaitGroup
 	lock sync.Mutex
@@ -75,6 +90,7 @@ func NewFeeService(t *testing.T) *FeeService {
 	f.feeRateMap = map[uint32]uint32{
 		feeServiceTarget: DefaultFeeRateSatPerKw,
 	}
+	f.nodeFeeRateMaps = make(map[uint32]map[uint32]uint32)
 
 	listenAddr := fmt.Sprintf(":%v", port)
 	mux := http.NewServeMux()
@@ -102,16 +118,29 @@ func (f *FeeService) Start() error {
 	return nil
 }
 
-// handleRequest handles a client request for fee estimates.
-func (f *FeeService) handleRequest(w http.ResponseWriter, _ *http.Request) {
+// handleRequest handles a client request for fee estimates. If the request
+// was made by a specific node, its fee rates take precedence.
+func (f *FeeService) handleRequest(w http.ResponseWriter, r *http.Request) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
+	fees := make(map[uint32]uint32, len(f.feeRateMap))
+	for conf, fee := range f.feeRateMap {
+		fees[conf] = fee
+	}
+
+	nodeID, err := strconv.ParseUint(r.URL.Query().Get("node"), 10, 32)
+	if err == nil {
+		for conf, fee := range f.nodeFeeRateMaps[uint32(nodeID)] {
+			fees[conf] = fee
+		}
+	}
+
 	bytes, err := json.Marshal(
 		struct {
 			Fees map[uint32]uint32 `json:"fee_by_block_target"`
 		}{
-			Fees: f.feeRateMap,
+			Fees: fees,
 		},
 	)
 	require.NoErrorf(f, err, "cannot serialize estimates")
@@ -138,7 +167,28 @@ func (f *FeeService) SetFeeRate(fee chainfee.SatPerKWeight, conf uint32) {
 	f.feeRateMap[conf] = uint32(fee.FeePerKVByte())
 }
 
+// SetNodeFeeRate sets a fee for the given confirmation target that is only
+// returned to the node with the given ID.
+func (f *FeeService) SetNodeFeeRate(nodeID uint32, fee chainfee.SatPerKWeight,
+	conf uint32) {
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	feeRateMap, ok := f.nodeFeeRateMaps[nodeID]
+	if !ok {
+		feeRateMap = make(map[uint32]uint32)
+		f.nodeFeeRateMaps[nodeID] = feeRateMap
+	}
+	feeRateMap[conf] = uint32(fee.FeePerKVByte())
+}
+
 // URL returns the service endpoint.
 func (f *FeeService) URL() string {
 	return f.url
 }
+
+// NodeURL returns the service endpoint for the node with the given ID.
+func (f *FeeService) NodeURL(nodeID uint32) string {
+	return fmt.Sprintf("%s?node=%d", f.url, nodeID)
+}
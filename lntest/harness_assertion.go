@@ -15,6 +15,7 @@ import (
 	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/lnd/lnrpc"
 	"github.com/ltcsuite/lnd/lnrpc/invoicesrpc"
+	"github.com/ltcsuite/lnd/lnrpc/neutrinorpc"
 	"github.com/ltcsuite/lnd/lnrpc/routerrpc"
 	"github.com/ltcsuite/lnd/lnrpc/walletrpc"
 	"github.com/ltcsuite/lnd/lntest/node"
@@ -55,6 +56,52 @@ func (h *HarnessTest) WaitForBlockchainSync(hn *node.HarnessNode) {
 	}, DefaultTimeout)
 
 	require.NoError(h, err, "timeout waiting for blockchain sync")
+
+	// For neutrino nodes, we also make sure the compact filters are synced
+	// so light client regressions are caught early.
+	if h.IsNeutrinoBackend() {
+		h.AssertNeutrinoSynced(hn)
+	}
+}
+
+// AssertNeutrinoSynced asserts that the neutrino backend of the given node is
+// synced to the miner's best block, including the compact filter headers. This
+// is verified by fetching the compact filter of the best block, which neutrino
+// only returns once it's able to validate it against the filter header chain.
+func (h *HarnessTest) AssertNeutrinoSynced(hn *node.HarnessNode) {
+	bestHash, bestHeight := h.Miner.GetBestBlock()
+
+	err := wait.NoError(func() error {
+		status := hn.RPC.Status(nil)
+		if !status.Synced {
+			return fmt.Errorf("%s's neutrino backend is not synced",
+				hn.Name())
+		}
+
+		if status.BlockHash != bestHash.String() {
+			return fmt.Errorf("%s's neutrino backend is synced to "+
+				"the wrong block (expected=%s at height %d, "+
+				"actual=%s at height %d)", hn.Name(), bestHash,
+				bestHeight, status.BlockHash, status.BlockHeight)
+		}
+
+		ctxt, cancel := context.WithTimeout(h.runCtx, DefaultTimeout)
+		defer cancel()
+
+		_, err := hn.RPC.NeutrinoKit.GetCFilter(
+			ctxt, &neutrinorpc.GetCFilterRequest{
+				Hash: bestHash.String(),
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("%s's compact filter headers are not "+
+				"synced: %w", hn.Name(), err)
+		}
+
+		return nil
+	}, DefaultTimeout)
+
+	require.NoError(h, err, "timeout waiting for neutrino sync")
 }
 
 // WaitForBlockchainSyncTo waits until the node is synced to bestBlock.
@@ -136,6 +183,32 @@ func (h *HarnessTest) ConnectNodesPerm(a, b *node.HarnessNode) {
 	h.AssertPeerConnected(a, b)
 }
 
+// ConnectNodesViaProxy starts a fault-injecting proxy in front of node b's P2P
+// port and creates a persistent connection from node a to node b through it.
+// The proxy is returned so the caller can delay, blackhole or cut the
+// connection, and it's stopped once the test ends.
+func (h *HarnessTest) ConnectNodesViaProxy(a,
+	b *node.HarnessNode) *node.P2PProxy {
+
+	proxy, err := node.NewP2PProxy(b.Cfg.P2PAddr())
+	require.NoErrorf(h, err, "unable to create proxy for %s", b.Name())
+	h.Cleanup(proxy.Stop)
+
+	bobInfo := b.RPC.GetInfo()
+
+	req := &lnrpc.ConnectPeerRequest{
+		Addr: &lnrpc.LightningAddress{
+			Pubkey: bobInfo.IdentityPubkey,
+			Host:   proxy.Addr(),
+		},
+		Perm: true,
+	}
+	a.RPC.ConnectPeer(req)
+	h.AssertPeerConnected(a, b)
+
+	return proxy
+}
+
 // DisconnectNodes disconnects the given two nodes and asserts the
 // disconnection is succeeded. The request is made from node a and sent to node
 // b.
@@ -1129,6 +1202,41 @@ func (h *HarnessTest) AssertInvoiceSettled(hn *node.HarnessNode, addr []byte) {
 	require.NoError(h, err, "timeout waiting for invoice settled state")
 }
 
+// AssertInvoiceSettledWithAmt subscribes to the invoice specified by its
+// payment hash and asserts it becomes settled for the given amount. The
+// settled invoice is returned.
+func (h *HarnessTest) AssertInvoiceSettledWithAmt(hn *node.HarnessNode,
+	rHash []byte, amtPaidSat int64) *lnrpc.Invoice {
+
+	stream := hn.RPC.SubscribeSingleInvoice(rHash)
+	invoice := h.AssertInvoiceState(stream, lnrpc.Invoice_SETTLED)
+	require.Equalf(h, amtPaidSat, invoice.AmtPaidSat, "%s: invoice %x "+
+		"amount paid mismatch", hn.Name(), rHash)
+
+	return invoice
+}
+
+// AssertPaymentSucceeds sends a payment from the passed node to the given
+// payment request and asserts it succeeds. The preimage returned by the
+// payment is checked against its payment hash.
+func (h *HarnessTest) AssertPaymentSucceeds(hn *node.HarnessNode,
+	payReq string) *lnrpc.Payment {
+
+	req := &routerrpc.SendPaymentRequest{
+		PaymentRequest: payReq,
+		TimeoutSeconds: int32(wait.PaymentTimeout.Seconds()),
+		FeeLimitMsat:   noFeeLimitMsat,
+	}
+	payment := h.SendPaymentAssertSettled(hn, req)
+
+	preimage, err := lntypes.MakePreimageFromStr(payment.PaymentPreimage)
+	require.NoError(h, err, "unable to parse payment preimage")
+	require.Equal(h, payment.PaymentHash, preimage.Hash().String(),
+		"preimage doesn't match payment hash")
+
+	return payment
+}
+
 // AssertNodeNumChannels polls the provided node's list channels rpc until it
 // reaches the desired number of total channels.
 func (h *HarnessTest) AssertNodeNumChannels(hn *node.HarnessNode,
@@ -2540,3 +2648,51 @@ func (h *HarnessTest) MineClosingTx(cp *lnrpc.ChannelPoint,
 
 	return closeTx
 }
+
+// AssertBlocksReorgedOut asserts that the given blocks are no longer part of
+// the miner's chain, and that their transactions, except for the coinbase,
+// were added back to the mempool.
+func (h *HarnessTest) AssertBlocksReorgedOut(blocks []*wire.MsgBlock) {
+	for _, block := range blocks {
+		blockHash := block.BlockHash()
+
+		header, err := h.Miner.Client.GetBlockHeaderVerbose(&blockHash)
+		require.NoErrorf(h, err, "unable to get block %v", blockHash)
+
+		mainHash, err := h.Miner.Client.GetBlockHash(
+			int64(header.Height),
+		)
+		require.NoErrorf(h, err, "unable to get block hash at %d",
+			header.Height)
+		require.NotEqualf(h, blockHash, *mainHash, "block %v at "+
+			"height %d not reorged out", blockHash, header.Height)
+
+		for _, tx := range block.Transactions[1:] {
+			txid := tx.TxHash()
+			h.Miner.AssertTxInMempool(&txid)
+		}
+	}
+}
+
+// AssertChannelSurvivedReorg asserts that the given channel is active, and
+// that its funding transaction is confirmed on the miner's current chain.
+func (h *HarnessTest) AssertChannelSurvivedReorg(hn *node.HarnessNode,
+	chanPoint *lnrpc.ChannelPoint) *lnrpc.Channel {
+
+	channel := h.AssertChannelActive(hn, chanPoint)
+
+	fundingTxid := h.GetChanPointFundingTxid(chanPoint)
+	err := wait.NoError(func() error {
+		tx := h.Miner.GetRawTransactionVerbose(fundingTxid)
+		if tx.Confirmations == 0 {
+			return fmt.Errorf("funding tx %v unconfirmed",
+				fundingTxid)
+		}
+
+		return nil
+	}, DefaultTimeout)
+	require.NoErrorf(h, err, "%s: funding tx of channel %s not "+
+		"confirmed", hn.Name(), chanPoint)
+
+	return channel
+}
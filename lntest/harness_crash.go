@@ -0,0 +1,179 @@
+package lntest
+
+import (
+	"fmt"
+
+	"github.com/ltcsuite/lnd/lnrpc"
+	"github.com/ltcsuite/lnd/lnrpc/walletrpc"
+	"github.com/ltcsuite/lnd/lntest/node"
+	"github.com/ltcsuite/lnd/lntest/wait"
+	"github.com/ltcsuite/ltcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// killAndRestartNode kills the given node with SIGKILL, executes the optional
+// callback while the node is down, then starts and unlocks the node again and
+// waits for it to be synced to the chain.
+func (h *HarnessTest) killAndRestartNode(hn *node.HarnessNode,
+	callback func() error) {
+
+	err := hn.KillAndRestart(h.runCtx, callback)
+	require.NoErrorf(h, err, "failed to kill and restart node %s",
+		hn.Name())
+
+	err = h.manager.unlockNode(hn)
+	require.NoErrorf(h, err, "failed to unlock node %s", hn.Name())
+
+	if !hn.Cfg.SkipUnlock {
+		// Give the node some time to catch up with the chain before we
+		// continue with the tests.
+		h.WaitForBlockchainSync(hn)
+	}
+}
+
+// KillAndRestartNode simulates a crash of the given node by killing it with
+// SIGKILL, then restarts and unlocks it.
+func (h *HarnessTest) KillAndRestartNode(hn *node.HarnessNode) {
+	h.killAndRestartNode(hn, nil)
+}
+
+// KillAndRestartNodeWhen waits until the given condition returns nil, then
+// immediately kills the node with SIGKILL and restarts it. This can be used to
+// crash a node at a specific point of a protocol flow.
+func (h *HarnessTest) KillAndRestartNodeWhen(hn *node.HarnessNode,
+	cond func() error) {
+
+	err := wait.NoError(cond, DefaultTimeout)
+	require.NoErrorf(h, err, "%s: kill condition not met", hn.Name())
+
+	h.killAndRestartNode(hn, nil)
+}
+
+// KillAndRestartNodeMidCommit waits until the given channel has pending HTLCs,
+// which means the node is in the middle of updating the channel's commitment
+// transactions, then kills the node with SIGKILL and restarts it. The channel
+// state as seen before the crash is returned so it can be passed to
+// AssertChannelRecovered.
+func (h *HarnessTest) KillAndRestartNodeMidCommit(hn *node.HarnessNode,
+	chanPoint *lnrpc.ChannelPoint) *lnrpc.Channel {
+
+	var channel *lnrpc.Channel
+	h.KillAndRestartNodeWhen(hn, func() error {
+		c, err := h.findChannel(hn, chanPoint)
+		if err != nil {
+			return err
+		}
+
+		if len(c.PendingHtlcs) == 0 {
+			return fmt.Errorf("channel %s has no pending htlcs",
+				chanPoint)
+		}
+
+		channel = c
+
+		return nil
+	})
+
+	return channel
+}
+
+// KillAndRestartNodeDrainMempool kills the given node with SIGKILL and mines a
+// block containing all transactions in the miner's mempool while the node is
+// down. When the node is restarted, the transactions it broadcast before the
+// crash are no longer in the mempool but already confirmed. The mined block
+// is returned.
+func (h *HarnessTest) KillAndRestartNodeDrainMempool(
+	hn *node.HarnessNode) *wire.MsgBlock {
+
+	var block *wire.MsgBlock
+	h.killAndRestartNode(hn, func() error {
+		block = h.Miner.MineBlocks(1)[0]
+		return nil
+	})
+
+	// The mempool is expected to be empty now.
+	h.Miner.AssertNumTxsInMempool(0)
+
+	return block
+}
+
+// KillAndRestartNodeWithReorg kills the given node with SIGKILL and replaces
+// the last depth blocks of the chain with a longer competing chain while the
+// node is down. When the node is restarted, its last known best block is no
+// longer part of the main chain, so it has to rewind to the fork point.
+func (h *HarnessTest) KillAndRestartNodeWithReorg(hn *node.HarnessNode,
+	depth uint32) {
+
+	h.killAndRestartNode(hn, func() error {
+		// Mine a competing chain that is one block longer than the
+		// one it replaces.
+		h.reorgChain(depth, depth+1)
+
+		return nil
+	})
+}
+
+// AssertChannelRecovered asserts that the given channel is active again after
+// the node was restarted, and that its commitment state didn't go backwards
+// compared to the given channel state taken before the restart.
+func (h *HarnessTest) AssertChannelRecovered(hn *node.HarnessNode,
+	chanPoint *lnrpc.ChannelPoint, before *lnrpc.Channel) *lnrpc.Channel {
+
+	var channel *lnrpc.Channel
+	err := wait.NoError(func() error {
+		c, err := h.findChannel(hn, chanPoint)
+		if err != nil {
+			return err
+		}
+
+		if !c.Active {
+			return fmt.Errorf("channel %s not active", chanPoint)
+		}
+
+		if c.NumUpdates < before.NumUpdates {
+			return fmt.Errorf("channel %s lost state: num updates "+
+				"%d before restart, %d after", chanPoint,
+				before.NumUpdates, c.NumUpdates)
+		}
+
+		channel = c
+
+		return nil
+	}, DefaultTimeout)
+	require.NoErrorf(h, err, "%s: channel not recovered", hn.Name())
+
+	return channel
+}
+
+// AssertPendingSweepsRecovered asserts that all the given sweeps, usually
+// taken before the node was restarted, are offered to the sweeper again.
+func (h *HarnessTest) AssertPendingSweepsRecovered(hn *node.HarnessNode,
+	sweeps []*walletrpc.PendingSweep) []*walletrpc.PendingSweep {
+
+	var recovered []*walletrpc.PendingSweep
+	err := wait.NoError(func() error {
+		resp := hn.RPC.PendingSweeps()
+
+		pending := make(map[string]struct{})
+		for _, s := range resp.PendingSweeps {
+			op := fmt.Sprintf("%s:%d", s.Outpoint.TxidStr,
+				s.Outpoint.OutputIndex)
+			pending[op] = struct{}{}
+		}
+
+		for _, s := range sweeps {
+			op := fmt.Sprintf("%s:%d", s.Outpoint.TxidStr,
+				s.Outpoint.OutputIndex)
+			if _, ok := pending[op]; !ok {
+				return fmt.Errorf("sweep %s not recovered", op)
+			}
+		}
+
+		recovered = resp.PendingSweeps
+
+		return nil
+	}, DefaultTimeout)
+	require.NoErrorf(h, err, "%s: pending sweeps not recovered", hn.Name())
+
+	return recovered
+}
@@ -4,10 +4,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -61,29 +60,66 @@ type HarnessMiner struct {
 	logFilename string
 }
 
+// minerConfig holds the optional settings of a miner.
+type minerConfig struct {
+	// extraArgs are additional command line args passed to ltcd.
+	extraArgs []string
+}
+
+// MinerOption is a functional option that modifies the settings of a miner.
+type MinerOption func(*minerConfig)
+
+// WithMinRelayTxFee sets the minimum fee rate in litoshis per kB a transaction
+// must pay to be accepted into the miner's mempool. Since ltcd derives its
+// dust limit from this fee rate, it also raises the dust limit of the miner.
+//
+// NOTE: ltcd doesn't allow configuring the dust relay fee or the data carrier
+// size separately from this fee rate.
+func WithMinRelayTxFee(feeRate ltcutil.Amount) MinerOption {
+	return func(cfg *minerConfig) {
+		cfg.extraArgs = append(cfg.extraArgs, fmt.Sprintf(
+			"--minrelaytxfee=%v", feeRate.ToBTC(),
+		))
+	}
+}
+
+// WithMinerArgs passes the given extra command line args to ltcd.
+func WithMinerArgs(args ...string) MinerOption {
+	return func(cfg *minerConfig) {
+		cfg.extraArgs = append(cfg.extraArgs, args...)
+	}
+}
+
 // NewMiner creates a new miner using ltcd backend with the default log file
 // dir and name.
-func NewMiner(ctxt context.Context, t *testing.T) *HarnessMiner {
+func NewMiner(ctxt context.Context, t *testing.T,
+	opts ...MinerOption) *HarnessMiner {
+
 	t.Helper()
-	return newMiner(ctxt, t, minerLogDir, minerLogFilename)
+	return newMiner(ctxt, t, minerLogDir, minerLogFilename, opts...)
 }
 
 // NewTempMiner creates a new miner using ltcd backend with the specified log
 // file dir and name.
 func NewTempMiner(ctxt context.Context, t *testing.T,
-	tempDir, tempLogFilename string) *HarnessMiner {
+	tempDir, tempLogFilename string, opts ...MinerOption) *HarnessMiner {
 
 	t.Helper()
 
-	return newMiner(ctxt, t, tempDir, tempLogFilename)
+	return newMiner(ctxt, t, tempDir, tempLogFilename, opts...)
 }
 
 // newMiner creates a new miner using ltcd's rpctest.
 func newMiner(ctxb context.Context, t *testing.T, minerDirName,
-	logFilename string) *HarnessMiner {
+	logFilename string, opts ...MinerOption) *HarnessMiner {
 
 	t.Helper()
 
+	cfg := &minerConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	handler := &rpcclient.NotificationHandlers{}
 	btcdBinary := node.GetBtcdBinary()
 	baseLogPath := fmt.Sprintf("%s/%s", node.GetLogDir(), minerDirName)
@@ -99,6 +135,7 @@ func newMiner(ctxb context.Context, t *testing.T, minerDirName,
 		// Don't disconnect if a reply takes too long.
 		"--nostalldetect",
 	}
+	args = append(args, cfg.extraArgs...)
 
 	miner, err := rpctest.New(harnessNetParams, handler, args, btcdBinary)
 	require.NoError(t, err, "unable to create mining node")
@@ -121,19 +158,10 @@ func (h *HarnessMiner) saveLogs() {
 	// After shutting down the miner, we'll make a copy of the log files
 	// before deleting the temporary log dir.
 	path := fmt.Sprintf("%s/%s", h.logPath, harnessNetParams.Name)
-	files, err := ioutil.ReadDir(path)
-	require.NoError(h, err, "unable to read log directory")
-
-	for _, file := range files {
-		newFilename := strings.Replace(
-			file.Name(), "ltcd.log", h.logFilename, 1,
-		)
-		copyPath := fmt.Sprintf("%s/../%s", h.logPath, newFilename)
-
-		logFile := fmt.Sprintf("%s/%s", path, file.Name())
-		err := CopyFile(filepath.Clean(copyPath), logFile)
-		require.NoError(h, err, "unable to copy file")
-	}
+	err := copyLogFiles(
+		path, filepath.Dir(h.logPath), "ltcd.log", h.logFilename,
+	)
+	require.NoError(h, err, "unable to copy log files")
 
 	err = os.RemoveAll(h.logPath)
 	require.NoErrorf(h, err, "cannot remove dir %s", h.logPath)
@@ -199,25 +227,63 @@ func (h *HarnessMiner) MineBlocks(num uint32) []*wire.MsgBlock {
 // in the provided miner's mempool. It will asserrt if this number is not met
 // after the given timeout.
 func (h *HarnessMiner) AssertNumTxsInMempool(n int) []*chainhash.Hash {
-	var (
-		mem []*chainhash.Hash
-		err error
-	)
+	ctxt, cancel := context.WithTimeout(h.runCtx, wait.MinerMempoolTimeout)
+	defer cancel()
+
+	mem, err := h.WaitForNumTxsInMempool(ctxt, n)
+	require.NoError(h, err, "assert tx in mempool timeout")
+
+	return mem
+}
+
+// WaitForNumTxsInMempool polls the miner's mempool until it has the desired
+// number of transactions or the context is done, in which case an error is
+// returned.
+func (h *HarnessMiner) WaitForNumTxsInMempool(ctx context.Context,
+	n int) ([]*chainhash.Hash, error) {
+
+	var mem []*chainhash.Hash
+	err := wait.NoErrorCtx(ctx, func() error {
+		var err error
+		mem, err = h.Client.GetRawMempool()
+		if err != nil {
+			return fmt.Errorf("unable to get mempool: %w", err)
+		}
 
-	err = wait.NoError(func() error {
-		// We require the RPC call to be succeeded and won't wait for
-		// it as it's an unexpected behavior.
-		mem = h.GetRawMempool()
 		if len(mem) == n {
 			return nil
 		}
 
 		return fmt.Errorf("want %v, got %v in mempool: %v",
 			n, len(mem), mem)
-	}, wait.MinerMempoolTimeout)
-	require.NoError(h, err, "assert tx in mempool timeout")
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	return mem
+	return mem, nil
+}
+
+// WaitForTxInMempool polls the miner's mempool until the given transaction is
+// found or the context is done, in which case an error is returned.
+func (h *HarnessMiner) WaitForTxInMempool(ctx context.Context,
+	txid *chainhash.Hash) error {
+
+	return wait.NoErrorCtx(ctx, func() error {
+		mem, err := h.Client.GetRawMempool()
+		if err != nil {
+			return fmt.Errorf("unable to get mempool: %w", err)
+		}
+
+		for _, memTx := range mem {
+			if *memTx == *txid {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("txid %v not found in mempool: %v", txid,
+			mem)
+	})
 }
 
 // AssertTxInBlock asserts that a given txid can be found in the passed block.
@@ -343,6 +409,32 @@ func (h *HarnessMiner) AssertTxNotInMempool(txid chainhash.Hash) *wire.MsgTx {
 	return msgTx
 }
 
+// AssertTxNumConfs asserts the given transaction has the expected number of
+// confirmations on the miner's current chain. Zero confirmations means the
+// transaction is unconfirmed, e.g. because it was reorged out.
+func (h *HarnessMiner) AssertTxNumConfs(txid *chainhash.Hash,
+	numConfs uint32) *btcjson.TxRawResult {
+
+	var tx *btcjson.TxRawResult
+	err := wait.NoError(func() error {
+		var err error
+		tx, err = h.Client.GetRawTransactionVerbose(txid)
+		if err != nil {
+			return err
+		}
+
+		if tx.Confirmations != uint64(numConfs) {
+			return fmt.Errorf("txid %v has %d confirmations, want "+
+				"%d", txid, tx.Confirmations, numConfs)
+		}
+
+		return nil
+	}, wait.DefaultTimeout)
+	require.NoError(h, err, "timeout checking confirmations")
+
+	return tx
+}
+
 // SendOutputsWithoutChange uses the miner to send the given outputs using the
 // specified fee rate and returns the txid.
 func (h *HarnessMiner) SendOutputsWithoutChange(outputs []*wire.TxOut,
@@ -496,16 +588,112 @@ func (h *HarnessMiner) MineEmptyBlocks(num int) []*wire.MsgBlock {
 	return blocks
 }
 
+// BlockGenerator mines blocks in the background at a fixed interval, which
+// gives tests a more realistic confirmation pacing than mining blocks on
+// demand.
+type BlockGenerator struct {
+	miner    *HarnessMiner
+	interval time.Duration
+
+	mu sync.Mutex
+
+	// numBlocks is the number of blocks mined so far.
+	numBlocks int
+
+	// err is the first error encountered while mining.
+	err error
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// StartBlockGenerator starts mining a block, including any transactions in
+// the mempool, each time the given interval elapses. The returned generator
+// must be stopped before the test ends.
+func (h *HarnessMiner) StartBlockGenerator(
+	interval time.Duration) *BlockGenerator {
+
+	g := &BlockGenerator{
+		miner:    h,
+		interval: interval,
+		quit:     make(chan struct{}),
+	}
+
+	g.wg.Add(1)
+	go g.generateBlocks()
+
+	return g
+}
+
+// generateBlocks mines a block each time the interval elapses.
+//
+// NOTE: This MUST be run as a goroutine.
+func (g *BlockGenerator) generateBlocks() {
+	defer g.wg.Done()
+
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// We can't fail the test from this goroutine, so the
+			// error is reported once the generator is stopped.
+			_, err := g.miner.Client.Generate(1)
+
+			g.mu.Lock()
+			if err != nil {
+				g.err = fmt.Errorf("unable to generate block: "+
+					"%w", err)
+				g.mu.Unlock()
+
+				return
+			}
+			g.numBlocks++
+			g.mu.Unlock()
+
+		case <-g.quit:
+			return
+
+		case <-g.miner.runCtx.Done():
+			return
+		}
+	}
+}
+
+// NumBlocks returns the number of blocks mined by the generator so far.
+func (g *BlockGenerator) NumBlocks() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.numBlocks
+}
+
+// Stop stops the generator and returns the error that made it stop early, if
+// any.
+func (g *BlockGenerator) Stop() error {
+	close(g.quit)
+	g.wg.Wait()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.err
+}
+
 // SpawnTempMiner creates a temp miner and syncs it with the current miner.
 // Once miners are synced, the temp miner is disconnected from the original
-// miner and returned.
-func (h *HarnessMiner) SpawnTempMiner() *HarnessMiner {
+// miner and returned. The given options can be used to run the temp miner
+// with a different relay policy than the current miner.
+func (h *HarnessMiner) SpawnTempMiner(opts ...MinerOption) *HarnessMiner {
 	require := require.New(h.T)
 
 	// Setup a temp miner.
 	tempLogDir := ".tempminerlogs"
 	logFilename := "output-temp_miner.log"
-	tempMiner := NewTempMiner(h.runCtx, h.T, tempLogDir, logFilename)
+	tempMiner := NewTempMiner(
+		h.runCtx, h.T, tempLogDir, logFilename, opts...,
+	)
 
 	// Make sure to clean the miner when the test ends.
 	h.T.Cleanup(tempMiner.Stop)
@@ -533,6 +721,40 @@ func (h *HarnessMiner) SpawnTempMiner() *HarnessMiner {
 	return tempMiner
 }
 
+// SpawnTempMinerAt creates a temp miner whose chain is a copy of the current
+// miner's chain up to the given height. The temp miner is not connected to the
+// original miner, so blocks mined on it form a competing chain that forks off
+// at the given height.
+func (h *HarnessMiner) SpawnTempMinerAt(height int32) *HarnessMiner {
+	require := require.New(h.T)
+
+	// Setup a temp miner that starts from the genesis block.
+	tempLogDir := ".tempminerlogs"
+	logFilename := "output-temp_miner.log"
+	tempMiner := NewTempMiner(h.runCtx, h.T, tempLogDir, logFilename)
+
+	// Make sure to clean the miner when the test ends.
+	h.T.Cleanup(tempMiner.Stop)
+
+	// Setup the miner.
+	require.NoError(tempMiner.SetUp(false, 0), "unable to setup miner")
+
+	// Copy the blocks up to the fork height from the original miner.
+	for i := int32(1); i <= height; i++ {
+		blockHash, err := h.Client.GetBlockHash(int64(i))
+		require.NoErrorf(err, "unable to get block hash at %d", i)
+
+		block := ltcutil.NewBlock(h.GetBlock(blockHash))
+		err = tempMiner.Client.SubmitBlock(block, nil)
+		require.NoErrorf(err, "unable to submit block at %d", i)
+	}
+
+	_, tempMinerHeight := tempMiner.GetBestBlock()
+	require.Equal(height, tempMinerHeight, "temp miner height mismatch")
+
+	return tempMiner
+}
+
 // ConnectMiner connects the miner to a temp miner.
 func (h *HarnessMiner) ConnectMiner(tempMiner *HarnessMiner) {
 	require := require.New(h.T)
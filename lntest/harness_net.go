@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -43,6 +44,10 @@ const (
 	lndErrorChanSize = 10
 )
 
+// remoteSignerPassword is the wallet password of the watch-only nodes created
+// by NewRemoteSignerPair.
+var remoteSignerPassword = []byte("remotesignerpassword")
+
 // TestCase defines a test case that's been used in the integration test.
 type TestCase struct {
 	// Name specifies the test name.
@@ -148,9 +153,9 @@ func (h *HarnessTest) Start(chain node.BackendConfig, miner *HarnessMiner) {
 	err := h.feeService.Start()
 	require.NoError(h, err, "failed to start fee service")
 
-	// Assemble the node manager with chainBackend and feeServiceURL.
+	// Assemble the node manager with chainBackend and feeService.
 	h.manager.chainBackend = chain
-	h.manager.feeServiceURL = h.feeService.URL()
+	h.manager.feeService = h.feeService
 
 	// Assemble the miner.
 	h.Miner = miner
@@ -270,6 +275,10 @@ func (h *HarnessTest) Stop() {
 
 	// Stop the miner.
 	h.Miner.Stop()
+
+	// Remove the database containers used by the nodes.
+	err = h.manager.stopDBContainers()
+	require.NoError(h, err, "failed to stop db containers")
 }
 
 // RunTestCase executes a harness test case. Any errors or panics will be
@@ -343,6 +352,7 @@ func (h *HarnessTest) Subtest(t *testing.T) *HarnessTest {
 		// Don't bother run the cleanups if the test is failed.
 		if st.Failed() {
 			st.Log("test failed, skipped cleanup")
+			st.collectFailureBundle()
 			st.shutdownAllNodes()
 			return
 		}
@@ -474,10 +484,13 @@ func (h *HarnessTest) SetTestName(name string) {
 
 // NewNode creates a new node and asserts its creation. The node is guaranteed
 // to have finished its initialization and all its subservers are started.
-func (h *HarnessTest) NewNode(name string,
-	extraArgs []string) *node.HarnessNode {
+// The given options are applied to the node's config before it's created.
+func (h *HarnessTest) NewNode(name string, extraArgs []string,
+	opts ...NodeOption) *node.HarnessNode {
 
-	node, err := h.manager.newNode(h.T, name, extraArgs, nil, false)
+	node, err := h.manager.newNode(
+		h.T, name, extraArgs, nil, false, opts...,
+	)
 	require.NoErrorf(h, err, "unable to create new node for %s", name)
 
 	// Start the node.
@@ -569,10 +582,11 @@ func (h *HarnessTest) RestartNodeWithExtraArgs(hn *node.HarnessNode,
 // NewNodeWithSeed fully initializes a new HarnessNode after creating a fresh
 // aezeed. The provided password is used as both the aezeed password and the
 // wallet password. The generated mnemonic is returned along with the
-// initialized harness node.
+// initialized harness node. The given options are applied to the node's
+// config before it's created.
 func (h *HarnessTest) NewNodeWithSeed(name string,
-	extraArgs []string, password []byte,
-	statelessInit bool) (*node.HarnessNode, []string, []byte) {
+	extraArgs []string, password []byte, statelessInit bool,
+	opts ...NodeOption) (*node.HarnessNode, []string, []byte) {
 
 	// Create a request to generate a new aezeed. The new seed will have
 	// the same password as the internal wallet.
@@ -581,18 +595,18 @@ func (h *HarnessTest) NewNodeWithSeed(name string,
 		SeedEntropy:      nil,
 	}
 
-	return h.newNodeWithSeed(name, extraArgs, req, statelessInit)
+	return h.newNodeWithSeed(name, extraArgs, req, statelessInit, opts...)
 }
 
 // newNodeWithSeed creates and initializes a new HarnessNode such that it'll be
 // ready to accept RPC calls. A `GenSeedRequest` is needed to generate the
 // seed.
 func (h *HarnessTest) newNodeWithSeed(name string,
-	extraArgs []string, req *lnrpc.GenSeedRequest,
-	statelessInit bool) (*node.HarnessNode, []string, []byte) {
+	extraArgs []string, req *lnrpc.GenSeedRequest, statelessInit bool,
+	opts ...NodeOption) (*node.HarnessNode, []string, []byte) {
 
 	node, err := h.manager.newNode(
-		h.T, name, extraArgs, req.AezeedPassphrase, true,
+		h.T, name, extraArgs, req.AezeedPassphrase, true, opts...,
 	)
 	require.NoErrorf(h, err, "unable to create new node for %s", name)
 
@@ -735,6 +749,39 @@ func (h *HarnessTest) NewNodeRemoteSigner(name string, extraArgs []string,
 	return hn
 }
 
+// NewRemoteSignerPair creates a signer node with a random seed and a
+// watch-only node that uses it as its remote signer. The watch-only node is
+// created with the given name and extra args, and imports the accounts of the
+// signer, so both nodes share the same identity. The signer is returned
+// first.
+func (h *HarnessTest) NewRemoteSignerPair(name string,
+	extraArgs []string) (*node.HarnessNode, *node.HarnessNode) {
+
+	signer := h.NewNode(name+"Signer", nil)
+
+	// The watch-only wallet is created from the xpubs of the signer's
+	// accounts.
+	accounts := signer.RPC.ListAccounts(&walletrpc.ListAccountsRequest{})
+	watchOnlyAccounts, err := walletrpc.AccountsToWatchOnly(
+		accounts.Accounts,
+	)
+	require.NoError(h, err, "unable to convert accounts")
+
+	args := append(NodeArgsForRemoteSigner(signer), extraArgs...)
+	watchOnly := h.NewNodeRemoteSigner(
+		name, args, remoteSignerPassword, &lnrpc.WatchOnly{
+			Accounts: watchOnlyAccounts,
+		},
+	)
+
+	// Both nodes should use the identity key of the signer.
+	resp := watchOnly.RPC.GetInfo()
+	require.Equal(h, signer.PubKeyStr, resp.IdentityPubkey,
+		"identity mismatch")
+
+	return signer, watchOnly
+}
+
 // KillNode kills the node (but won't wait for the node process to stop).
 func (h *HarnessTest) KillNode(hn *node.HarnessNode) {
 	require.NoErrorf(h, hn.Kill(), "%s: kill got error", hn.Name())
@@ -1628,6 +1675,97 @@ func (h *HarnessTest) MineBlocks(num uint32) []*wire.MsgBlock {
 	return blocks
 }
 
+// ReorgChain replaces the last depth blocks of the chain with newBlocks empty
+// blocks mined on a competing chain, and asserts all active nodes have synced
+// to the new chain. The transactions confirmed in the replaced blocks are
+// added back to the miner's mempool. The blocks of the competing chain are
+// returned.
+//
+// NOTE: newBlocks must be greater than depth, as the miner only switches to a
+// chain with more work.
+func (h *HarnessTest) ReorgChain(depth, newBlocks uint32) []*wire.MsgBlock {
+	blocks := h.reorgChain(depth, newBlocks)
+
+	// Make sure all the active nodes are synced.
+	bestBlock := blocks[len(blocks)-1]
+	h.AssertActiveNodesSyncedTo(bestBlock)
+
+	return blocks
+}
+
+// reorgChain replaces the last depth blocks of the chain with newBlocks empty
+// blocks mined on a competing chain. Unlike ReorgChain, it doesn't wait for the
+// active nodes to sync.
+func (h *HarnessTest) reorgChain(depth, newBlocks uint32) []*wire.MsgBlock {
+	require.Greaterf(h, newBlocks, depth, "competing chain of %d blocks "+
+		"can't replace %d blocks", newBlocks, depth)
+
+	_, height := h.Miner.GetBestBlock()
+	forkHeight := height - int32(depth)
+	require.Positivef(h, forkHeight, "can't reorg %d blocks at height %d",
+		depth, height)
+
+	// Mine the competing chain on a temp miner that forks off at the fork
+	// height.
+	tempMiner := h.Miner.SpawnTempMinerAt(forkHeight)
+	blocks := tempMiner.MineEmptyBlocks(int(newBlocks))
+
+	// Connect the miners so the original miner reorgs to the competing
+	// chain, then disconnect them again.
+	h.Miner.ConnectMiner(tempMiner)
+	h.Miner.AssertMinerBlockHeightDelta(tempMiner, 0)
+	h.Miner.DisconnectMiner(tempMiner)
+
+	return blocks
+}
+
+// StartBlockGenerator starts mining blocks in the background at the given
+// interval. The returned function stops the generator and asserts that no
+// error occurred while mining. The generator is also stopped when the test
+// ends.
+func (h *HarnessTest) StartBlockGenerator(interval time.Duration) func() {
+	g := h.Miner.StartBlockGenerator(interval)
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			require.NoError(h, g.Stop(), "block generator failed")
+			h.Logf("Block generator mined %d blocks", g.NumBlocks())
+		})
+	}
+	h.Cleanup(stop)
+
+	return stop
+}
+
+// AssertNumTxsInMempool polls until finding the desired number of
+// transactions in the miner's mempool and asserts if this number is not met
+// before the test's context times out or the mempool timeout is reached.
+func (h *HarnessTest) AssertNumTxsInMempool(n int) []*chainhash.Hash {
+	ctxt, cancel := context.WithTimeout(
+		h.runCtx, wait.MinerMempoolTimeout,
+	)
+	defer cancel()
+
+	txids, err := h.Miner.WaitForNumTxsInMempool(ctxt, n)
+	require.NoError(h, err, "assert num txs in mempool failed")
+
+	return txids
+}
+
+// AssertTxInMempool polls until the given transaction is found in the miner's
+// mempool and asserts if it's not found before the test's context times out
+// or the mempool timeout is reached.
+func (h *HarnessTest) AssertTxInMempool(txid *chainhash.Hash) {
+	ctxt, cancel := context.WithTimeout(
+		h.runCtx, wait.MinerMempoolTimeout,
+	)
+	defer cancel()
+
+	err := h.Miner.WaitForTxInMempool(ctxt, txid)
+	require.NoError(h, err, "assert tx in mempool failed")
+}
+
 // MineBlocksAndAssertNumTxes mines blocks and asserts the number of
 // transactions are found in the first block. It also asserts all active nodes
 // have synced to the chain.
@@ -0,0 +1,286 @@
+package lntest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ltcsuite/lnd/lnrpc"
+	"github.com/ltcsuite/lnd/lntest/node"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/stretchr/testify/require"
+)
+
+// networkFundingFeeBuffer is the amount added on top of the channel capacity
+// when funding the wallet of a channel opener, which pays for the fee of the
+// funding transaction.
+const networkFundingFeeBuffer = ltcutil.Amount(100_000)
+
+// networkChannel describes a channel of a Network.
+type networkChannel struct {
+	// name is the name of the channel in the form of "A-B".
+	name string
+
+	// local is the name of the node that opens the channel.
+	local string
+
+	// remote is the name of the channel peer.
+	remote string
+
+	// params are the parameters used to open the channel.
+	params OpenChannelParams
+}
+
+// NetworkBuilder declaratively describes a topology of nodes and channels
+// that is created by calling Build. Channels are described by strings in the
+// form of "A-B:<amt>[:<push>]", where A opens a channel to B with a capacity
+// of amt and pushes the optional push amount to B. Amounts are in litoshis
+// and can use the k and M suffixes, e.g. "A-B:1M:250k".
+//
+// A typical usage looks like,
+//
+//	net := lntest.NewNetwork(ht).Nodes(3).
+//		Channels("A-B:1M", "B-C:2M:500k").Build()
+//	defer net.CloseChannels()
+//
+//	alice, carol := net.Node("A"), net.Node("C")
+type NetworkBuilder struct {
+	h *HarnessTest
+
+	// nodes is the list of node names in the order they were added.
+	nodes []string
+
+	// nodeArgs holds the extra args of each node.
+	nodeArgs map[string][]string
+
+	// channels is the list of channels in the order they were added.
+	channels []*networkChannel
+}
+
+// NewNetwork creates a new NetworkBuilder using the given harness.
+func NewNetwork(h *HarnessTest) *NetworkBuilder {
+	return &NetworkBuilder{
+		h:        h,
+		nodeArgs: make(map[string][]string),
+	}
+}
+
+// Nodes adds num nodes to the network which are named by the letters of the
+// alphabet in order, starting with A.
+func (b *NetworkBuilder) Nodes(num int) *NetworkBuilder {
+	require.LessOrEqual(b.h, num, 26, "too many nodes")
+
+	for i := 0; i < num; i++ {
+		b.Node(string(rune('A' + i)))
+	}
+
+	return b
+}
+
+// Node adds a node with the given name and extra args to the network. If the
+// node was already added, its extra args are replaced.
+func (b *NetworkBuilder) Node(name string,
+	extraArgs ...string) *NetworkBuilder {
+
+	if _, ok := b.nodeArgs[name]; !ok {
+		b.nodes = append(b.nodes, name)
+	}
+	b.nodeArgs[name] = extraArgs
+
+	return b
+}
+
+// Channels adds public channels described by the given specs to the network.
+func (b *NetworkBuilder) Channels(specs ...string) *NetworkBuilder {
+	return b.addChannels(false, specs)
+}
+
+// PrivateChannels adds private channels described by the given specs to the
+// network.
+func (b *NetworkBuilder) PrivateChannels(specs ...string) *NetworkBuilder {
+	return b.addChannels(true, specs)
+}
+
+// addChannels parses the given channel specs and adds them to the network.
+func (b *NetworkBuilder) addChannels(private bool,
+	specs []string) *NetworkBuilder {
+
+	for _, spec := range specs {
+		c, err := parseChannelSpec(spec)
+		require.NoError(b.h, err)
+
+		for _, name := range []string{c.local, c.remote} {
+			_, ok := b.nodeArgs[name]
+			require.Truef(b.h, ok, "unknown node %s in channel %s",
+				name, spec)
+		}
+
+		c.params.Private = private
+		b.channels = append(b.channels, c)
+	}
+
+	return b
+}
+
+// parseChannelSpec parses a channel spec in the form of "A-B:<amt>[:<push>]".
+func parseChannelSpec(spec string) (*networkChannel, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, fmt.Errorf("invalid channel spec %q", spec)
+	}
+
+	peers := strings.Split(parts[0], "-")
+	if len(peers) != 2 || peers[0] == "" || peers[1] == "" {
+		return nil, fmt.Errorf("invalid channel peers in %q", spec)
+	}
+
+	amt, err := parseNetworkAmount(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity in %q: %w", spec, err)
+	}
+
+	var push ltcutil.Amount
+	if len(parts) == 3 {
+		push, err = parseNetworkAmount(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid push amount in %q: %w",
+				spec, err)
+		}
+	}
+
+	return &networkChannel{
+		name:   parts[0],
+		local:  peers[0],
+		remote: peers[1],
+		params: OpenChannelParams{
+			Amt:     amt,
+			PushAmt: push,
+		},
+	}, nil
+}
+
+// parseNetworkAmount parses an amount in litoshis that may use the k or M
+// suffix.
+func parseNetworkAmount(s string) (ltcutil.Amount, error) {
+	multiplier := int64(1)
+	switch {
+	case strings.HasSuffix(s, "k"):
+		multiplier = 1_000
+		s = strings.TrimSuffix(s, "k")
+
+	case strings.HasSuffix(s, "M"):
+		multiplier = 1_000_000
+		s = strings.TrimSuffix(s, "M")
+	}
+
+	amt, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return ltcutil.Amount(amt * float64(multiplier)), nil
+}
+
+// Build creates the nodes, connects and funds them, opens all the channels and
+// waits until every node has seen all public channels in its graph and the
+// channel balances are as expected.
+func (b *NetworkBuilder) Build() *Network {
+	h := b.h
+
+	n := &Network{
+		h:          h,
+		nodes:      make(map[string]*node.HarnessNode, len(b.nodes)),
+		chanPoints: make(map[string]*lnrpc.ChannelPoint),
+		channels:   b.channels,
+	}
+
+	for _, name := range b.nodes {
+		n.nodes[name] = h.NewNode(name, b.nodeArgs[name])
+	}
+
+	// Fund each opener with a separate UTXO for each of its channels so
+	// all channels can be opened at once.
+	reqs := make([]*OpenChannelRequest, 0, len(b.channels))
+	for _, c := range b.channels {
+		local, remote := n.nodes[c.local], n.nodes[c.remote]
+
+		h.FundCoins(c.params.Amt+networkFundingFeeBuffer, local)
+		h.EnsureConnected(local, remote)
+
+		reqs = append(reqs, &OpenChannelRequest{
+			Local:  local,
+			Remote: remote,
+			Param:  c.params,
+		})
+	}
+
+	if len(reqs) == 0 {
+		return n
+	}
+
+	chanPoints := h.OpenMultiChannelsAsync(reqs)
+	for i, c := range b.channels {
+		n.chanPoints[c.name] = chanPoints[i]
+	}
+
+	// Wait until all nodes have seen all public channels in their graph.
+	for _, hn := range n.nodes {
+		for _, c := range b.channels {
+			if c.params.Private {
+				continue
+			}
+
+			h.AssertTopologyChannelOpen(hn, n.chanPoints[c.name])
+		}
+	}
+
+	// The remote side of each channel should only have the pushed
+	// amount.
+	for _, c := range b.channels {
+		h.AssertChannelLocalBalance(
+			n.nodes[c.remote], n.chanPoints[c.name],
+			int64(c.params.PushAmt),
+		)
+	}
+
+	return n
+}
+
+// Network is a set of nodes and channels created by a NetworkBuilder.
+type Network struct {
+	h *HarnessTest
+
+	// nodes holds the nodes of the network keyed by their names.
+	nodes map[string]*node.HarnessNode
+
+	// chanPoints holds the channel points of the channels keyed by their
+	// names in the form of "A-B".
+	chanPoints map[string]*lnrpc.ChannelPoint
+
+	// channels is the list of channels in the order they were opened.
+	channels []*networkChannel
+}
+
+// Node returns the node with the given name.
+func (n *Network) Node(name string) *node.HarnessNode {
+	hn, ok := n.nodes[name]
+	require.Truef(n.h, ok, "unknown node %s", name)
+
+	return hn
+}
+
+// ChanPoint returns the channel point of the channel with the given name in
+// the form of "A-B".
+func (n *Network) ChanPoint(name string) *lnrpc.ChannelPoint {
+	cp, ok := n.chanPoints[name]
+	require.Truef(n.h, ok, "unknown channel %s", name)
+
+	return cp
+}
+
+// CloseChannels cooperatively closes all channels of the network.
+func (n *Network) CloseChannels() {
+	for _, c := range n.channels {
+		n.h.CloseChannel(n.nodes[c.local], n.chanPoints[c.name])
+	}
+}
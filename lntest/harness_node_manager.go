@@ -10,6 +10,7 @@ import (
 	"github.com/ltcsuite/lnd/lnrpc"
 	"github.com/ltcsuite/lnd/lntest/node"
 	"github.com/ltcsuite/lnd/lntest/wait"
+	"github.com/ltcsuite/lnd/lnwallet/chainfee"
 )
 
 // nodeManager is responsible for hanlding the start and stop of a given node.
@@ -43,8 +44,29 @@ type nodeManager struct {
 	// node's unique ID.
 	nodeCounter uint32
 
-	// feeServiceURL is the url of the fee service.
-	feeServiceURL string
+	// feeService is the fee service that provides fee estimates to the
+	// nodes.
+	feeService WebFeeService
+
+	// dbContainers holds the database servers started for nodes that use
+	// a different database backend than dbBackend, format:
+	// {backend: *dbContainer}.
+	dbContainers map[node.DatabaseBackend]*dbContainer
+}
+
+// NodeOption is a functional option that modifies the config of a node before
+// it's created.
+type NodeOption func(*node.BaseNodeConfig)
+
+// WithDBBackend makes the node use the given database backend instead of the
+// one the harness was set up with. For the postgres and etcd backends, the
+// node's database is hosted on a server running in an ephemeral docker
+// container, which is shared by all the nodes using the same backend and
+// removed once the harness is stopped.
+func WithDBBackend(backend node.DatabaseBackend) NodeOption {
+	return func(cfg *node.BaseNodeConfig) {
+		cfg.DBBackend = backend
+	}
 }
 
 // newNodeManager creates a new node manager instance.
@@ -56,6 +78,7 @@ func newNodeManager(lndBinary string,
 		dbBackend:    dbBackend,
 		activeNodes:  make(map[uint32]*node.HarnessNode),
 		standbyNodes: make(map[uint32]*node.HarnessNode),
+		dbContainers: make(map[node.DatabaseBackend]*dbContainer),
 	}
 }
 
@@ -70,7 +93,13 @@ func (nm *nodeManager) nextNodeID() uint32 {
 // node can be used immediately. Otherwise, the node will require an additional
 // initialization phase where the wallet is either created or restored.
 func (nm *nodeManager) newNode(t *testing.T, name string, extraArgs []string,
-	password []byte, noAuth bool) (*node.HarnessNode, error) {
+	password []byte, noAuth bool,
+	opts ...NodeOption) (*node.HarnessNode, error) {
+
+	nodeID := nm.nextNodeID()
+	setFeeRate := func(feeRate chainfee.SatPerKWeight, conf uint32) {
+		nm.feeService.SetNodeFeeRate(nodeID, feeRate, conf)
+	}
 
 	cfg := &node.BaseNodeConfig{
 		Name:              name,
@@ -78,14 +107,23 @@ func (nm *nodeManager) newNode(t *testing.T, name string, extraArgs []string,
 		Password:          password,
 		BackendCfg:        nm.chainBackend,
 		ExtraArgs:         extraArgs,
-		FeeURL:            nm.feeServiceURL,
+		FeeURL:            nm.feeService.NodeURL(nodeID),
+		SetFeeRate:        setFeeRate,
 		DBBackend:         nm.dbBackend,
-		NodeID:            nm.nextNodeID(),
+		NodeID:            nodeID,
 		LndBinary:         nm.lndBinary,
 		NetParams:         harnessNetParams,
 		SkipUnlock:        noAuth,
 	}
 
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if err := nm.setupDBContainer(cfg); err != nil {
+		return nil, err
+	}
+
 	node, err := node.NewHarnessNode(t, cfg)
 	if err != nil {
 		return nil, err
@@ -98,6 +136,63 @@ func (nm *nodeManager) newNode(t *testing.T, name string, extraArgs []string,
 	return node, nil
 }
 
+// setupDBContainer points the node to the database server of its backend if
+// it differs from the harness' one, starting the server's container if it's
+// not yet running.
+func (nm *nodeManager) setupDBContainer(cfg *node.BaseNodeConfig) error {
+	if cfg.DBBackend == nm.dbBackend {
+		return nil
+	}
+
+	switch cfg.DBBackend {
+	case node.BackendPostgres, node.BackendEtcd:
+
+	default:
+		return nil
+	}
+
+	nm.Lock()
+	defer nm.Unlock()
+
+	c, ok := nm.dbContainers[cfg.DBBackend]
+	if !ok {
+		var err error
+		c, err = startDBContainer(cfg.DBBackend)
+		if err != nil {
+			return err
+		}
+		nm.dbContainers[cfg.DBBackend] = c
+	}
+
+	if cfg.DBBackend == node.BackendPostgres {
+		cfg.PostgresHost = c.addr
+	} else {
+		cfg.EtcdHost = c.addr
+	}
+
+	return nil
+}
+
+// stopDBContainers stops all the database containers started by the manager.
+func (nm *nodeManager) stopDBContainers() error {
+	nm.Lock()
+	defer nm.Unlock()
+
+	var errStr string
+	for backend, c := range nm.dbContainers {
+		if err := c.stop(); err != nil {
+			errStr += err.Error() + "\n"
+		}
+		delete(nm.dbContainers, backend)
+	}
+
+	if errStr != "" {
+		return fmt.Errorf("unable to stop db containers: %s", errStr)
+	}
+
+	return nil
+}
+
 // RegisterNode records a new HarnessNode in the NetworkHarnesses map of known
 // nodes. This method should only be called with nodes that have successfully
 // retrieved their public keys via FetchNodeInfo.
@@ -14,7 +14,7 @@ import (
 // instance is ready for usage. The setups are,
 // 1. create the directories to hold lnd files.
 // 2. start a btcd miner.
-// 3. start a chain backend(btcd, bitcoind, or neutrino).
+// 3. start a chain backend(ltcd, litecoind, or neutrino).
 // 4. connect the miner and the chain backend.
 // 5. start the HarnessTest.
 func SetupHarness(t *testing.T, binaryPath, dbBackendName string,
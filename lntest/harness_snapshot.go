@@ -0,0 +1,116 @@
+package lntest
+
+import (
+	"os"
+
+	"github.com/ltcsuite/lnd/lntest/node"
+	"github.com/stretchr/testify/require"
+)
+
+// NodeSnapshot is a copy of a node's state taken after a common setup, such as
+// funding its wallet and opening channels. It can be restored as the starting
+// point of many tests, which saves the time needed to repeat the setup.
+//
+// NOTE: the snapshot doesn't include the chain, so it can only be restored as
+// long as the transactions it relies on are still part of the miner's chain,
+// and none of the snapshotted channels were closed on chain since.
+type NodeSnapshot struct {
+	// name is the name of the snapshotted node.
+	name string
+
+	// extraArgs are the extra args the node was started with.
+	extraArgs []string
+
+	// password is the wallet password of the node.
+	password []byte
+
+	// dir is the directory that holds the copy of the node's data dir.
+	dir string
+}
+
+// SnapshotNodes takes a snapshot of each of the given nodes. Each node is
+// stopped while its data directory is copied, then restarted. Nodes that
+// share channels should be snapshotted together, so their channel states
+// match once restored. The snapshots are removed once the test ends.
+func (h *HarnessTest) SnapshotNodes(
+	nodes ...*node.HarnessNode) []*NodeSnapshot {
+
+	snapshots := make([]*NodeSnapshot, 0, len(nodes))
+	for _, hn := range nodes {
+		dir, err := os.MkdirTemp("", "lndtest-snapshot")
+		require.NoError(h, err, "unable to create snapshot dir")
+		h.Cleanup(func() {
+			require.NoError(h, os.RemoveAll(dir))
+		})
+
+		cb := func() error { return hn.Snapshot(dir) }
+		err = h.manager.restartNode(h.runCtx, hn, cb)
+		require.NoErrorf(h, err, "failed to snapshot node %s",
+			hn.Name())
+
+		err = h.manager.unlockNode(hn)
+		require.NoErrorf(h, err, "failed to unlock node %s", hn.Name())
+
+		snapshots = append(snapshots, &NodeSnapshot{
+			name:      hn.Name(),
+			extraArgs: hn.Cfg.ExtraArgs,
+			password:  hn.Cfg.Password,
+			dir:       dir,
+		})
+	}
+
+	// Give the nodes some time to catch up with the chain before we
+	// continue with the tests.
+	for _, hn := range nodes {
+		h.WaitForBlockchainSync(hn)
+	}
+
+	return snapshots
+}
+
+// RestoreNode creates a new node from the given snapshot and starts it. The
+// new node has the same identity, wallet and channels as the snapshotted node
+// had when the snapshot was taken.
+//
+// NOTE: the snapshotted node, or any other node restored from the same
+// snapshot, must be shut down before, as they share the same identity and
+// channel state.
+func (h *HarnessTest) RestoreNode(s *NodeSnapshot) *node.HarnessNode {
+	hn, err := h.manager.newNode(h.T, s.name, s.extraArgs, s.password, false)
+	require.NoErrorf(h, err, "unable to create new node for %s", s.name)
+
+	err = hn.RestoreSnapshot(s.dir)
+	require.NoErrorf(h, err, "unable to restore snapshot of %s", s.name)
+
+	err = hn.Start(h.runCtx)
+	require.NoErrorf(h, err, "failed to start node %s", s.name)
+
+	err = h.manager.unlockNode(hn)
+	require.NoErrorf(h, err, "failed to unlock node %s", s.name)
+
+	// Give the node some time to catch up with the chain before we
+	// continue with the tests.
+	h.WaitForBlockchainSync(hn)
+
+	return hn
+}
+
+// RestoreNodes restores each of the given snapshots using RestoreNode and
+// makes sure the restored nodes are connected to each other, so their
+// channels become active again.
+func (h *HarnessTest) RestoreNodes(
+	snapshots ...*NodeSnapshot) []*node.HarnessNode {
+
+	nodes := make([]*node.HarnessNode, 0, len(snapshots))
+	for _, s := range snapshots {
+		nodes = append(nodes, h.RestoreNode(s))
+	}
+
+	for i, a := range nodes {
+		for _, b := range nodes[i+1:] {
+			h.EnsureConnected(a, b)
+		}
+	}
+
+	return nodes
+}
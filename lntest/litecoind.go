@@ -1,5 +1,5 @@
-//go:build bitcoind && !notxindex && !rpcpolling
-// +build bitcoind,!notxindex,!rpcpolling
+//go:build litecoind && !notxindex && !rpcpolling
+// +build litecoind,!notxindex,!rpcpolling
 
 package lntest
 
@@ -8,9 +8,9 @@ import (
 )
 
 // NewBackend starts a litecoind node with the txindex enabled and returns a
-// BitcoindBackendConfig for that node.
+// LitecoindBackendConfig for that node.
 func NewBackend(miner string, netParams *chaincfg.Params) (
-	*BitcoindBackendConfig, func() error, error) {
+	*LitecoindBackendConfig, func() error, error) {
 
 	extraArgs := []string{
 		"-debug",
@@ -1,5 +1,5 @@
-//go:build bitcoind
-// +build bitcoind
+//go:build litecoind
+// +build litecoind
 
 package lntest
 
@@ -13,16 +13,24 @@ import (
 	"time"
 
 	"github.com/ltcsuite/lnd/lntest/node"
+	"github.com/ltcsuite/lnd/lntest/wait"
 	"github.com/ltcsuite/ltcd/chaincfg"
 	"github.com/ltcsuite/ltcd/rpcclient"
 )
 
-// logDirPattern is the pattern of the name of the temporary log directory.
-const logDirPattern = "%s/.backendlogs"
+const (
+	// logDirPattern is the pattern of the name of the temporary log
+	// directory.
+	logDirPattern = "%s/.backendlogs"
 
-// BitcoindBackendConfig is an implementation of the BackendConfig interface
-// backed by a Bitcoind node.
-type BitcoindBackendConfig struct {
+	// litecoindStartTimeout is the maximum time we wait for litecoind to
+	// accept RPC requests after it was started.
+	litecoindStartTimeout = 30 * time.Second
+)
+
+// LitecoindBackendConfig is an implementation of the BackendConfig interface
+// backed by a litecoind node.
+type LitecoindBackendConfig struct {
 	rpcHost      string
 	rpcUser      string
 	rpcPass      string
@@ -36,13 +44,13 @@ type BitcoindBackendConfig struct {
 	minerAddr string
 }
 
-// A compile time assertion to ensure BitcoindBackendConfig meets the
+// A compile time assertion to ensure LitecoindBackendConfig meets the
 // BackendConfig interface.
-var _ node.BackendConfig = (*BitcoindBackendConfig)(nil)
+var _ node.BackendConfig = (*LitecoindBackendConfig)(nil)
 
 // GenArgs returns the arguments needed to be passed to LND at startup for
 // using this node as a chain backend.
-func (b BitcoindBackendConfig) GenArgs() []string {
+func (b LitecoindBackendConfig) GenArgs() []string {
 	var args []string
 	args = append(args, "--litecoin.node=litecoind")
 	args = append(args, fmt.Sprintf("--litecoind.rpchost=%v", b.rpcHost))
@@ -66,29 +74,29 @@ func (b BitcoindBackendConfig) GenArgs() []string {
 }
 
 // ConnectMiner is called to establish a connection to the test miner.
-func (b BitcoindBackendConfig) ConnectMiner() error {
+func (b LitecoindBackendConfig) ConnectMiner() error {
 	return b.rpcClient.AddNode(b.minerAddr, rpcclient.ANAdd)
 }
 
 // DisconnectMiner is called to disconnect the miner.
-func (b BitcoindBackendConfig) DisconnectMiner() error {
+func (b LitecoindBackendConfig) DisconnectMiner() error {
 	return b.rpcClient.AddNode(b.minerAddr, rpcclient.ANRemove)
 }
 
 // Credentials returns the rpc username, password and host for the backend.
-func (b BitcoindBackendConfig) Credentials() (string, string, string, error) {
+func (b LitecoindBackendConfig) Credentials() (string, string, string, error) {
 	return b.rpcUser, b.rpcPass, b.rpcHost, nil
 }
 
 // Name returns the name of the backend type.
-func (b BitcoindBackendConfig) Name() string {
+func (b LitecoindBackendConfig) Name() string {
 	return "litecoind"
 }
 
 // newBackend starts a litecoind node with the given extra parameters and returns
-// a BitcoindBackendConfig for that node.
+// a LitecoindBackendConfig for that node.
 func newBackend(miner string, netParams *chaincfg.Params, extraArgs []string,
-	rpcPolling bool) (*BitcoindBackendConfig, func() error, error) {
+	rpcPolling bool) (*LitecoindBackendConfig, func() error, error) {
 
 	baseLogDir := fmt.Sprintf(logDirPattern, node.GetLogDir())
 	if netParams != &chaincfg.RegressionNetParams {
@@ -104,7 +112,7 @@ func newBackend(miner string, netParams *chaincfg.Params, extraArgs []string,
 		return nil, nil, err
 	}
 
-	tempBitcoindDir, err := ioutil.TempDir("", "litecoind")
+	tempLitecoindDir, err := ioutil.TempDir("", "litecoind")
 	if err != nil {
 		return nil, nil,
 			fmt.Errorf("unable to create temp directory: %v", err)
@@ -118,7 +126,7 @@ func newBackend(miner string, netParams *chaincfg.Params, extraArgs []string,
 	p2pPort := node.NextAvailablePort()
 
 	cmdArgs := []string{
-		"-datadir=" + tempBitcoindDir,
+		"-datadir=" + tempLitecoindDir,
 		"-whitelist=127.0.0.1", // whitelist localhost to speed up relay
 		"-rpcauth=weks:469e9bb14ab2360f8e226efed5ca6f" +
 			"d$507c670e800a95284294edb5773b05544b" +
@@ -130,20 +138,20 @@ func newBackend(miner string, netParams *chaincfg.Params, extraArgs []string,
 		"-debuglogfile=" + logFile,
 	}
 	cmdArgs = append(cmdArgs, extraArgs...)
-	bitcoind := exec.Command("litecoind", cmdArgs...)
+	litecoind := exec.Command("litecoind", cmdArgs...)
 
-	err = bitcoind.Start()
+	err = litecoind.Start()
 	if err != nil {
-		if err := os.RemoveAll(tempBitcoindDir); err != nil {
+		if err := os.RemoveAll(tempLitecoindDir); err != nil {
 			fmt.Printf("unable to remote temp dir %v: %v",
-				tempBitcoindDir, err)
+				tempLitecoindDir, err)
 		}
 		return nil, nil, fmt.Errorf("couldn't start litecoind: %v", err)
 	}
 
 	cleanUp := func() error {
-		_ = bitcoind.Process.Kill()
-		_ = bitcoind.Wait()
+		_ = litecoind.Process.Kill()
+		_ = litecoind.Wait()
 
 		var errStr string
 		// After shutting down the chain backend, we'll make a copy of
@@ -160,10 +168,10 @@ func newBackend(miner string, netParams *chaincfg.Params, extraArgs []string,
 				"cannot remove dir %s: %v\n", baseLogDir, err,
 			)
 		}
-		if err := os.RemoveAll(tempBitcoindDir); err != nil {
+		if err := os.RemoveAll(tempLitecoindDir); err != nil {
 			errStr += fmt.Sprintf(
 				"cannot remove dir %s: %v\n",
-				tempBitcoindDir, err,
+				tempLitecoindDir, err,
 			)
 		}
 		if errStr != "" {
@@ -172,9 +180,6 @@ func newBackend(miner string, netParams *chaincfg.Params, extraArgs []string,
 		return nil
 	}
 
-	// Allow process to start.
-	time.Sleep(1 * time.Second)
-
 	rpcHost := fmt.Sprintf("127.0.0.1:%d", rpcPort)
 	rpcUser := "weks"
 	rpcPass := "weks"
@@ -196,7 +201,18 @@ func newBackend(miner string, netParams *chaincfg.Params, extraArgs []string,
 			err)
 	}
 
-	bd := BitcoindBackendConfig{
+	// Wait until litecoind is ready to serve RPC requests. While it's
+	// still starting up, requests fail with a connection or warmup error.
+	err = wait.NoError(func() error {
+		_, err := client.GetBlockCount()
+		return err
+	}, litecoindStartTimeout)
+	if err != nil {
+		_ = cleanUp()
+		return nil, nil, fmt.Errorf("litecoind not ready: %v", err)
+	}
+
+	bd := LitecoindBackendConfig{
 		rpcHost:      rpcHost,
 		rpcUser:      rpcUser,
 		rpcPass:      rpcPass,
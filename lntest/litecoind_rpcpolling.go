@@ -1,5 +1,5 @@
-//go:build bitcoind && rpcpolling
-// +build bitcoind,rpcpolling
+//go:build litecoind && rpcpolling
+// +build litecoind,rpcpolling
 
 package lntest
 
@@ -8,9 +8,9 @@ import (
 )
 
 // NewBackend starts a litecoind node without the txindex enabled and returns a
-// BitoindBackendConfig for that node.
+// LitecoindBackendConfig for that node.
 func NewBackend(miner string, netParams *chaincfg.Params) (
-	*BitcoindBackendConfig, func() error, error) {
+	*LitecoindBackendConfig, func() error, error) {
 
 	extraArgs := []string{
 		"-debug",
@@ -69,6 +69,13 @@ func (w *WalletController) ConfirmedBalance(int32, string) (ltcutil.Amount,
 	return 0, nil
 }
 
+// MwebBalance currently returns dummy values.
+func (w *WalletController) MwebBalance(int32, string) (ltcutil.Amount,
+	error) {
+
+	return 0, nil
+}
+
 // NewAddress is called to get new addresses for delivery, change etc.
 func (w *WalletController) NewAddress(lnwallet.AddressType, bool,
 	string) (ltcutil.Address, error) {
@@ -117,6 +124,13 @@ func (w *WalletController) ListAddresses(string,
 	return nil, nil
 }
 
+// CreateAccount currently returns a dummy value.
+func (w *WalletController) CreateAccount(string,
+	lnwallet.AddressType) (*waddrmgr.AccountProperties, error) {
+
+	return nil, nil
+}
+
 // ImportAccount currently returns a dummy value.
 func (w *WalletController) ImportAccount(string, *hdkeychain.ExtendedKey,
 	uint32, *waddrmgr.AddressType, bool) (*waddrmgr.AccountProperties,
@@ -139,6 +153,13 @@ func (w *WalletController) ImportTaprootScript(waddrmgr.KeyScope,
 	return nil, nil
 }
 
+// ImportWitnessScript currently returns a dummy value.
+func (w *WalletController) ImportWitnessScript(waddrmgr.KeyScope,
+	[]byte) (waddrmgr.ManagedAddress, error) {
+
+	return nil, nil
+}
+
 // SendOutputs currently returns dummy values.
 func (w *WalletController) SendOutputs([]*wire.TxOut,
 	chainfee.SatPerKWeight, int32, string) (*wire.MsgTx, error) {
@@ -257,6 +278,11 @@ func (w *WalletController) GetRecoveryInfo() (bool, float64, error) {
 	return true, float64(1), nil
 }
 
+// Rescan currently does nothing.
+func (w *WalletController) Rescan(int32, func(int32, float64)) error {
+	return nil
+}
+
 // Start currently does nothing.
 func (w *WalletController) Start() error {
 	return nil
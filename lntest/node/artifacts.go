@@ -0,0 +1,89 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// goroutineDumpTimeout is the timeout of the request to the profile port of a
+// node that's used to fetch its goroutine dump.
+const goroutineDumpTimeout = 10 * time.Second
+
+// CollectArtifacts saves the artifacts needed to debug a failed test into the
+// given directory. This includes the node's logs, a dump of its goroutines and
+// a copy of its database files. Since the node might be in any state after a
+// failure, an error of a single artifact doesn't stop the others from being
+// collected.
+func (hn *HarnessNode) CollectArtifacts(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("unable to create artifacts dir: %w", err)
+	}
+
+	var errStr string
+
+	logDir := filepath.Join(dir, "logs")
+	if err := os.MkdirAll(logDir, 0700); err != nil {
+		errStr += err.Error() + "\n"
+	} else if err := copyAll(logDir, hn.Cfg.LogDir); err != nil {
+		errStr += fmt.Sprintf("unable to copy logs: %v\n", err)
+	}
+
+	dumpFile := filepath.Join(dir, "goroutines.txt")
+	if err := hn.dumpGoroutines(dumpFile); err != nil {
+		errStr += fmt.Sprintf("unable to dump goroutines: %v\n", err)
+	}
+
+	// The database files only exist locally for the bbolt and sqlite
+	// backends.
+	switch hn.Cfg.DBBackend {
+	case BackendBbolt, BackendSqlite:
+		dbDir := filepath.Join(dir, "db")
+		if err := os.MkdirAll(dbDir, 0700); err != nil {
+			errStr += err.Error() + "\n"
+		} else if err := copyAll(dbDir, hn.Cfg.DBDir()); err != nil {
+			errStr += fmt.Sprintf("unable to copy database: %v\n",
+				err)
+		}
+	}
+
+	if errStr != "" {
+		return errors.New(errStr)
+	}
+
+	return nil
+}
+
+// dumpGoroutines fetches the stack traces of all goroutines of the node from
+// its profile port and writes them to the given file.
+func (hn *HarnessNode) dumpGoroutines(file string) error {
+	client := &http.Client{Timeout: goroutineDumpTimeout}
+	url := fmt.Sprintf("http://"+ListenerFormat+
+		"/debug/pprof/goroutine?debug=2", hn.Cfg.ProfilePort)
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %v", resp.Status)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
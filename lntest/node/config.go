@@ -13,6 +13,7 @@ import (
 	"github.com/ltcsuite/lnd/chanbackup"
 	"github.com/ltcsuite/lnd/kvdb/etcd"
 	"github.com/ltcsuite/lnd/lntest/wait"
+	"github.com/ltcsuite/lnd/lnwallet/chainfee"
 	"github.com/ltcsuite/ltcd/chaincfg"
 )
 
@@ -116,9 +117,22 @@ type BaseNodeConfig struct {
 
 	FeeURL string
 
+	// SetFeeRate sets the fee rate for the given confirmation target that
+	// the fee service only returns to this node.
+	SetFeeRate func(feeRate chainfee.SatPerKWeight, conf uint32)
+
 	DBBackend   DatabaseBackend
 	PostgresDsn string
 
+	// PostgresHost is the host:port of the postgres server the node's
+	// database is created on. If empty, the server on the default itest
+	// port is used.
+	PostgresHost string
+
+	// EtcdHost is the host:port of an external etcd server the node
+	// connects to. If empty, the node runs an embedded etcd instance.
+	EtcdHost string
+
 	// NodeID is a unique ID used to identify the node.
 	NodeID uint32
 
@@ -155,6 +169,15 @@ func (cfg BaseNodeConfig) DBPath() string {
 	return filepath.Join(cfg.DBDir(), "channel.db")
 }
 
+// pgHost returns the address of the postgres server used by the node.
+func (cfg BaseNodeConfig) pgHost() string {
+	if cfg.PostgresHost == "" {
+		return defaultPostgresHost
+	}
+
+	return cfg.PostgresHost
+}
+
 func (cfg BaseNodeConfig) ChanBackupPath() string {
 	return filepath.Join(
 		cfg.DataDir, "chain", "litecoin",
@@ -251,6 +274,20 @@ func (cfg *BaseNodeConfig) GenArgs() []string {
 	switch cfg.DBBackend {
 	case BackendEtcd:
 		args = append(args, "--db.backend=etcd")
+
+		// Use a namespace per node on an external etcd server, so
+		// several nodes can share it.
+		if cfg.EtcdHost != "" {
+			args = append(
+				args, "--db.etcd.host="+cfg.EtcdHost,
+				"--db.etcd.disabletls",
+				fmt.Sprintf("--db.etcd.namespace=node%d",
+					cfg.NodeID),
+			)
+
+			break
+		}
+
 		args = append(args, "--db.etcd.embedded")
 		args = append(
 			args, fmt.Sprintf(
@@ -324,11 +361,19 @@ func ExtraArgsEtcd(etcdCfg *etcd.Config, name string, cluster bool,
 }
 
 // NextAvailablePort returns the first port that is available for listening by
-// a new node. It panics if no port is found and the maximum available TCP port
-// is reached.
+// a new node. The port is reserved in the central port registry, so it won't
+// be handed out to other itest processes running in parallel. It panics if no
+// port is found and the maximum available TCP port is reached.
 func NextAvailablePort() int {
 	port := atomic.AddUint32(&lastPort, 1)
 	for port < 65535 {
+		// Skip the port if another itest process has already reserved
+		// it.
+		if !reservePort(port) {
+			port = atomic.AddUint32(&lastPort, 1)
+			continue
+		}
+
 		// If there are no errors while attempting to listen on this
 		// port, close the socket and return it as available. While it
 		// could be the case that some other process picks up this port
@@ -344,6 +389,8 @@ func NextAvailablePort() int {
 				return int(port)
 			}
 		}
+
+		releasePort(port)
 		port = atomic.AddUint32(&lastPort, 1)
 	}
 
@@ -0,0 +1,301 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RPCFaults injects latency and failures into the RPC calls made by the test
+// harness to a node. A zero value injects no faults.
+type RPCFaults struct {
+	mu sync.Mutex
+
+	// delay is the latency added to each RPC call.
+	delay time.Duration
+
+	// failures is the number of subsequent RPC calls that fail.
+	failures int
+}
+
+// SetDelay adds the given latency to every subsequent RPC call.
+func (f *RPCFaults) SetDelay(delay time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.delay = delay
+}
+
+// FailNext makes the next num RPC calls fail with codes.Unavailable without
+// reaching the node.
+func (f *RPCFaults) FailNext(num int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failures = num
+}
+
+// Reset removes all injected faults.
+func (f *RPCFaults) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.delay = 0
+	f.failures = 0
+}
+
+// inject applies the configured faults to a single RPC call of the given
+// method.
+func (f *RPCFaults) inject(ctx context.Context, method string) error {
+	f.mu.Lock()
+	delay := f.delay
+	fail := f.failures > 0
+	if fail {
+		f.failures--
+	}
+	f.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if fail {
+		return status.Errorf(codes.Unavailable, "injected fault "+
+			"for %s", method)
+	}
+
+	return nil
+}
+
+// unaryInterceptor is a grpc.UnaryClientInterceptor that injects the faults.
+func (f *RPCFaults) unaryInterceptor(ctx context.Context, method string,
+	req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+	if err := f.inject(ctx, method); err != nil {
+		return err
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// streamInterceptor is a grpc.StreamClientInterceptor that injects the faults
+// when a stream is created.
+func (f *RPCFaults) streamInterceptor(ctx context.Context,
+	desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption) (grpc.ClientStream, error) {
+
+	if err := f.inject(ctx, method); err != nil {
+		return nil, err
+	}
+
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+// p2pProxyBufferSize is the size of the buffer used to forward data between
+// the two sides of a proxied connection.
+const p2pProxyBufferSize = 64 * 1024
+
+// P2PProxy is a TCP proxy that sits in front of the P2P port of a node and
+// injects faults into the connections made through it. Since the P2P traffic
+// is encrypted, faults are applied to the raw byte stream. Data can be delayed
+// or dropped, but not reordered.
+type P2PProxy struct {
+	// target is the P2P address of the node behind the proxy.
+	target string
+
+	listener net.Listener
+
+	mu sync.Mutex
+
+	// delay is the latency added to all data forwarded by the proxy.
+	delay time.Duration
+
+	// blackhole indicates whether all data should be dropped silently,
+	// which leaves the connections open but makes the peers time out.
+	blackhole bool
+
+	// conns is the set of open connections of both sides.
+	conns map[net.Conn]struct{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewP2PProxy creates and starts a new proxy that forwards connections to the
+// given target address.
+func NewP2PProxy(target string) (*P2PProxy, error) {
+	addr := fmt.Sprintf(ListenerFormat, NextAvailablePort())
+	listener, err := net.Listen("tcp4", addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on %s: %w", addr, err)
+	}
+
+	p := &P2PProxy{
+		target:   target,
+		listener: listener,
+		conns:    make(map[net.Conn]struct{}),
+		quit:     make(chan struct{}),
+	}
+
+	p.wg.Add(1)
+	go p.acceptConns()
+
+	return p, nil
+}
+
+// Addr returns the address other nodes should connect to in order to reach
+// the target node through the proxy.
+func (p *P2PProxy) Addr() string {
+	return p.listener.Addr().String()
+}
+
+// SetDelay adds the given latency to all data forwarded by the proxy.
+func (p *P2PProxy) SetDelay(delay time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.delay = delay
+}
+
+// SetBlackhole sets whether all data should be dropped silently while keeping
+// the connections open.
+//
+// NOTE: once data was dropped, the encrypted stream of the affected
+// connections can't be decrypted anymore, so the peers will disconnect after
+// the blackhole is removed.
+func (p *P2PProxy) SetBlackhole(blackhole bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.blackhole = blackhole
+}
+
+// DisconnectAll closes all connections made through the proxy, which
+// simulates a network failure between the peers.
+func (p *P2PProxy) DisconnectAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for conn := range p.conns {
+		_ = conn.Close()
+	}
+}
+
+// Stop closes the listener and all connections, and waits for the proxy's
+// goroutines to exit.
+func (p *P2PProxy) Stop() {
+	close(p.quit)
+	_ = p.listener.Close()
+	p.DisconnectAll()
+	p.wg.Wait()
+}
+
+// acceptConns accepts new connections and forwards them to the target.
+//
+// NOTE: This MUST be run as a goroutine.
+func (p *P2PProxy) acceptConns() {
+	defer p.wg.Done()
+
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		target, err := net.Dial("tcp", p.target)
+		if err != nil {
+			_ = conn.Close()
+			continue
+		}
+
+		if !p.trackConns(conn, target) {
+			return
+		}
+
+		p.wg.Add(2)
+		go p.forward(conn, target)
+		go p.forward(target, conn)
+	}
+}
+
+// trackConns adds the given connections to the set of open connections. It
+// returns false and closes them if the proxy is shutting down.
+func (p *P2PProxy) trackConns(conns ...net.Conn) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	select {
+	case <-p.quit:
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+
+		return false
+
+	default:
+	}
+
+	for _, conn := range conns {
+		p.conns[conn] = struct{}{}
+	}
+
+	return true
+}
+
+// forward copies data from src to dst while applying the configured faults.
+// Once either side fails, both connections are closed.
+//
+// NOTE: This MUST be run as a goroutine.
+func (p *P2PProxy) forward(src, dst net.Conn) {
+	defer p.wg.Done()
+
+	defer func() {
+		p.mu.Lock()
+		delete(p.conns, src)
+		delete(p.conns, dst)
+		p.mu.Unlock()
+
+		_ = src.Close()
+		_ = dst.Close()
+	}()
+
+	buf := make([]byte, p2pProxyBufferSize)
+	for {
+		n, err := src.Read(buf)
+		if err != nil {
+			return
+		}
+
+		p.mu.Lock()
+		delay, blackhole := p.delay, p.blackhole
+		p.mu.Unlock()
+
+		if blackhole {
+			continue
+		}
+
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-p.quit:
+				return
+			}
+		}
+
+		if _, err := dst.Write(buf[:n]); err != nil {
+			return
+		}
+	}
+}
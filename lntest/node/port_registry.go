@@ -0,0 +1,123 @@
+package node
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// staleReservationTimeout is the age after which a port reservation is
+// considered stale. Reservations are removed when the process that created
+// them exits normally, so this only applies to processes that crashed. The
+// value is chosen to exceed the default itest timeout.
+const staleReservationTimeout = 4 * time.Hour
+
+var (
+	// portRegistryDir is the directory of the central port registry that
+	// is shared between all itest processes on the same machine, e.g. the
+	// tranches that are run in parallel. Each reserved port is represented
+	// by a file in this directory, which makes sure no two processes use
+	// the same port for their nodes, miners and chain backends.
+	portRegistryDir = flag.String(
+		"portregistry", filepath.Join(os.TempDir(), "lnd-itest-ports"),
+		"directory of the port registry shared between parallel "+
+			"itest processes, set to an empty string to disable",
+	)
+
+	// reservedPortsMtx protects reservedPorts.
+	reservedPortsMtx sync.Mutex
+
+	// reservedPorts is the list of registry files created by this process.
+	reservedPorts []string
+)
+
+// reservePort registers the given port in the central port registry. It
+// returns false if the port is already reserved by another process. If the
+// registry can't be used, every port is treated as available.
+func reservePort(port uint32) bool {
+	dir := *portRegistryDir
+	if dir == "" {
+		return true
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return true
+	}
+
+	path := filepath.Join(dir, strconv.FormatUint(uint64(port), 10))
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(
+			path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600,
+		)
+		switch {
+		case err == nil:
+			_, _ = fmt.Fprintf(file, "%d\n", os.Getpid())
+			_ = file.Close()
+
+			reservedPortsMtx.Lock()
+			reservedPorts = append(reservedPorts, path)
+			reservedPortsMtx.Unlock()
+
+			return true
+
+		case !errors.Is(err, os.ErrExist):
+			return true
+		}
+
+		// The port is reserved already. We'll take over the
+		// reservation if it was left behind by a crashed process.
+		info, err := os.Stat(path)
+		if err != nil || time.Since(info.ModTime()) <
+			staleReservationTimeout {
+
+			return false
+		}
+
+		_ = os.Remove(path)
+	}
+
+	return false
+}
+
+// releasePort removes the reservation of the given port from the central port
+// registry.
+func releasePort(port uint32) {
+	dir := *portRegistryDir
+	if dir == "" {
+		return
+	}
+
+	path := filepath.Join(dir, strconv.FormatUint(uint64(port), 10))
+
+	reservedPortsMtx.Lock()
+	defer reservedPortsMtx.Unlock()
+
+	for i, reserved := range reservedPorts {
+		if reserved != path {
+			continue
+		}
+
+		_ = os.Remove(path)
+		reservedPorts = append(reservedPorts[:i], reservedPorts[i+1:]...)
+
+		return
+	}
+}
+
+// ReleasePorts removes all reservations that were made by this process from
+// the central port registry. It should be called once all nodes, miners and
+// chain backends of the process were stopped.
+func ReleasePorts() {
+	reservedPortsMtx.Lock()
+	defer reservedPortsMtx.Unlock()
+
+	for _, path := range reservedPorts {
+		_ = os.Remove(path)
+	}
+	reservedPorts = nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ltcsuite/lnd/input"
 	"github.com/ltcsuite/lnd/lnrpc"
+	"github.com/ltcsuite/lnd/lntest/node"
 	"github.com/ltcsuite/lnd/lntest/wait"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/lnwallet/chainfee"
@@ -170,6 +171,20 @@ func NodeArgsForCommitType(commitType lnrpc.CommitmentType) []string {
 	return nil
 }
 
+// NodeArgsForRemoteSigner returns the command line flags to supply to a
+// watch-only node so it uses the given node as its remote signer.
+func NodeArgsForRemoteSigner(signer *node.HarnessNode) []string {
+	return []string{
+		"--remotesigner.enable",
+		fmt.Sprintf("--remotesigner.rpchost=localhost:%d",
+			signer.Cfg.RPCPort),
+		fmt.Sprintf("--remotesigner.tlscertpath=%s",
+			signer.Cfg.TLSCertPath),
+		fmt.Sprintf("--remotesigner.macaroonpath=%s",
+			signer.Cfg.AdminMacPath),
+	}
+}
+
 // CalcStaticFee calculates appropriate fees for commitment transactions. This
 // function provides a simple way to allow test balance assertions to take fee
 // calculations into account.
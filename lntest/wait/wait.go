@@ -1,6 +1,7 @@
 package wait
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -71,6 +72,29 @@ func NoError(f func() error, timeout time.Duration) error {
 	return nil
 }
 
+// NoErrorCtx polls the passed method f until it executes without error or the
+// context is done, in which case the last error encountered is returned.
+func NoErrorCtx(ctx context.Context, f func() error) error {
+	var lastErr error
+	for {
+		select {
+		case <-time.After(PollInterval):
+
+		case <-ctx.Done():
+			if lastErr == nil {
+				return ctx.Err()
+			}
+
+			return lastErr
+		}
+
+		lastErr = f()
+		if lastErr == nil {
+			return nil
+		}
+	}
+}
+
 // Invariant is a helper test function that will wait for a timeout period of
 // time, verifying that a statement remains true for the entire duration.  This
 // function is helpful as timing doesn't always line up well when running
@@ -82,6 +82,11 @@ var (
 	// requested for the default imported account within the wallet.
 	errNoImportedAddrGen = errors.New("addresses cannot be generated for " +
 		"the default imported account")
+
+	// errWatchOnlyAccountCreation is an error returned when a new account
+	// is requested for a wallet without private key material.
+	errWatchOnlyAccountCreation = errors.New("accounts cannot be " +
+		"derived by a watch-only wallet, import an account xpub instead")
 )
 
 // BtcWallet is an implementation of the lnwallet.WalletController interface
@@ -490,23 +495,76 @@ func (b *BtcWallet) ConfirmedBalance(confs int32,
 	return balance, nil
 }
 
-// keyScopeForAccountAddr determines the appropriate key scope of an account
-// based on its name/address type.
-func (b *BtcWallet) keyScopeForAccountAddr(accountName string,
-	addrType lnwallet.AddressType) (waddrmgr.KeyScope, uint32, error) {
+// MwebBalance returns the sum of the wallet's unspent outputs that have at
+// least confs confirmations and were received through a MWEB peg-out, i.e. are
+// outputs of a block's integration (HogEx) transaction. The account parameter
+// serves as a filter to retrieve the balance for a specific account. When
+// empty, the MWEB balance of all wallet accounts is returned.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) MwebBalance(confs int32,
+	accountFilter string) (ltcutil.Amount, error) {
+
+	witnessOutputs, err := b.ListUnspentWitness(
+		confs, math.MaxInt32, accountFilter,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// Several of our outputs may be paid out by the same transaction, so
+	// we'll only look up each transaction once.
+	isHogEx := make(map[chainhash.Hash]bool)
+
+	var balance ltcutil.Amount
+	for _, witnessOutput := range witnessOutputs {
+		txid := witnessOutput.OutPoint.Hash
+
+		hogEx, ok := isHogEx[txid]
+		if !ok {
+			tx, err := b.FetchTx(txid)
+			if err != nil {
+				return 0, err
+			}
+
+			hogEx = tx != nil && lnwallet.IsHogEx(tx)
+			isHogEx[txid] = hogEx
+		}
+
+		if hogEx {
+			balance += witnessOutput.Value
+		}
+	}
+
+	return balance, nil
+}
+
+// keyScopeForAddrType maps an address type to the key scope its addresses are
+// derived from.
+func keyScopeForAddrType(
+	addrType lnwallet.AddressType) (waddrmgr.KeyScope, error) {
 
-	// Map the requested address type to its key scope.
-	var addrKeyScope waddrmgr.KeyScope
 	switch addrType {
 	case lnwallet.WitnessPubKey:
-		addrKeyScope = waddrmgr.KeyScopeBIP0084
+		return waddrmgr.KeyScopeBIP0084, nil
 	case lnwallet.NestedWitnessPubKey:
-		addrKeyScope = waddrmgr.KeyScopeBIP0049Plus
+		return waddrmgr.KeyScopeBIP0049Plus, nil
 	case lnwallet.TaprootPubkey:
-		addrKeyScope = waddrmgr.KeyScopeBIP0086
+		return waddrmgr.KeyScopeBIP0086, nil
 	default:
-		return waddrmgr.KeyScope{}, 0,
-			fmt.Errorf("unknown address type")
+		return waddrmgr.KeyScope{}, fmt.Errorf("unknown address type")
+	}
+}
+
+// keyScopeForAccountAddr determines the appropriate key scope of an account
+// based on its name/address type.
+func (b *BtcWallet) keyScopeForAccountAddr(accountName string,
+	addrType lnwallet.AddressType) (waddrmgr.KeyScope, uint32, error) {
+
+	// Map the requested address type to its key scope.
+	addrKeyScope, err := keyScopeForAddrType(addrType)
+	if err != nil {
+		return waddrmgr.KeyScope{}, 0, err
 	}
 
 	// The default account spans across multiple key scopes, so the
@@ -838,6 +896,47 @@ func (b *BtcWallet) ListAddresses(name string,
 	return addresses, nil
 }
 
+// CreateAccount derives a new named account from the wallet's seed under the
+// key scope of the given address type. As with imported accounts, we first
+// check that there is no account with the same name in any key scope.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) CreateAccount(name string,
+	addrType lnwallet.AddressType) (*waddrmgr.AccountProperties, error) {
+
+	switch name {
+	case "":
+		return nil, errors.New("account name must not be empty")
+
+	case lnwallet.DefaultAccountName, waddrmgr.ImportedAddrAccountName:
+		return nil, fmt.Errorf("account name '%s' is reserved", name)
+	}
+
+	if b.wallet.Manager.WatchOnly() {
+		return nil, errWatchOnlyAccountCreation
+	}
+
+	keyScope, err := keyScopeForAddrType(addrType)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = b.ListAccounts(name, nil)
+	if err == nil {
+		return nil, fmt.Errorf("account '%s' already exists", name)
+	}
+	if !waddrmgr.IsError(err, waddrmgr.ErrAccountNotFound) {
+		return nil, err
+	}
+
+	account, err := b.wallet.NextAccount(keyScope, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.wallet.AccountProperties(keyScope, account)
+}
+
 // ImportAccount imports an account backed by an account extended public key.
 // The master key fingerprint denotes the fingerprint of the root key
 // corresponding to the account public key (also known as the key with
@@ -951,6 +1050,57 @@ func (b *BtcWallet) ImportTaprootScript(scope waddrmgr.KeyScope,
 	)
 }
 
+// ImportWitnessScript imports a user-provided witness script into the
+// address manager. The imported script will act as a
+// pay-to-witness-script-hash address. As the script is stored in the address
+// manager, SignPsbt and FinalizePsbt can add it to inputs spending its
+// outputs.
+func (b *BtcWallet) ImportWitnessScript(scope waddrmgr.KeyScope,
+	witnessScript []byte) (waddrmgr.ManagedAddress, error) {
+
+	// As with taproot scripts, we don't encrypt the script so it can also
+	// be imported into and decrypted by a watch-only wallet.
+	const isSecretScript = false
+
+	// Only v0 (P2WSH) scripts are imported through this method, v1 scripts
+	// need to be imported as a full tapscript.
+	const witnessVersionSegwit byte = 0
+
+	manager, err := b.wallet.Manager.FetchScopedKeyManager(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// The script is only relevant for blocks after the import, so we use
+	// the current sync state as its birthday.
+	syncedTo := b.wallet.Manager.SyncedTo()
+
+	var addr waddrmgr.ManagedScriptAddress
+	err = walletdb.Update(b.db, func(tx walletdb.ReadWriteTx) error {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+
+		var err error
+		addr, err = manager.ImportWitnessScript(
+			addrmgrNs, witnessScript, &syncedTo,
+			witnessVersionSegwit, isSecretScript,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the chain backend notifies us about any future payments to
+	// the script's address.
+	err = b.chain.NotifyReceived([]ltcutil.Address{addr.Address()})
+	if err != nil {
+		return nil, fmt.Errorf("unable to subscribe for address "+
+			"notifications: %w", err)
+	}
+
+	return addr, nil
+}
+
 // SendOutputs funds, signs, and broadcasts a Bitcoin transaction paying out to
 // the specified outputs. In the case the wallet has insufficient funds, or the
 // outputs are non-standard, a non-nil error will be returned.
@@ -1147,6 +1297,13 @@ func (b *BtcWallet) ListUnspentWitness(minConfs, maxConfs int32,
 			return nil, err
 		}
 
+		// Coins that were pegged into the MWEB can't be spent on the
+		// canonical chain, so they must never end up in coin
+		// selection.
+		if lnwallet.IsMwebOutput(pkScript) {
+			continue
+		}
+
 		addressType := lnwallet.UnknownAddressType
 		if txscript.IsPayToWitnessPubKeyHash(pkScript) {
 			addressType = lnwallet.WitnessPubKey
@@ -1347,6 +1504,7 @@ func minedTransactionsToDetails(
 			RawTx:             tx.Transaction,
 			Label:             tx.Label,
 			PreviousOutpoints: previousOutpoints,
+			IsMwebPegOut:      lnwallet.IsHogEx(wireTx),
 		}
 
 		balanceDelta, err := extractBalanceDelta(tx, wireTx)
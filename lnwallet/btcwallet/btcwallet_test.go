@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/ltcsuite/lnd/lnwallet"
+	"github.com/ltcsuite/ltcd/chaincfg"
 	"github.com/ltcsuite/ltcd/wire"
+	"github.com/ltcsuite/ltcwallet/waddrmgr"
 	"github.com/ltcsuite/ltcwallet/wallet"
 	"github.com/stretchr/testify/require"
 )
@@ -132,3 +134,39 @@ func TestPreviousOutpoints(t *testing.T) {
 		})
 	}
 }
+
+// TestCreateAccount tests that named accounts can be derived from the wallet's
+// seed and used to generate addresses of their address type.
+func TestCreateAccount(t *testing.T) {
+	netParams := &chaincfg.RegressionNetParams
+	w, _ := newTestWallet(t, netParams, seedBytes)
+
+	// The default and imported account names are reserved.
+	_, err := w.CreateAccount(
+		lnwallet.DefaultAccountName, lnwallet.TaprootPubkey,
+	)
+	require.Error(t, err)
+	_, err = w.CreateAccount(
+		waddrmgr.ImportedAddrAccountName, lnwallet.TaprootPubkey,
+	)
+	require.Error(t, err)
+
+	account, err := w.CreateAccount("savings", lnwallet.TaprootPubkey)
+	require.NoError(t, err)
+	require.Equal(t, "savings", account.AccountName)
+	require.Equal(t, waddrmgr.KeyScopeBIP0086, account.KeyScope)
+
+	accounts, err := w.ListAccounts("savings", nil)
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+
+	// The account can only derive addresses of its own type.
+	_, err = w.NewAddress(lnwallet.TaprootPubkey, false, "savings")
+	require.NoError(t, err)
+	_, err = w.NewAddress(lnwallet.WitnessPubKey, false, "savings")
+	require.Error(t, err)
+
+	// Account names must be unique across key scopes.
+	_, err = w.CreateAccount("savings", lnwallet.WitnessPubKey)
+	require.Error(t, err)
+}
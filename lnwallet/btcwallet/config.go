@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/ltcsuite/lnd/lnwallet/chanfunding"
 	"github.com/ltcsuite/ltcd/chaincfg"
 	"github.com/ltcsuite/ltcd/wire"
 	"github.com/ltcsuite/ltcwallet/chain"
@@ -72,6 +73,12 @@ type Config struct {
 	// coins when funding a transaction.
 	CoinSelectionStrategy wallet.CoinSelectionStrategy
 
+	// PsbtCoinSelectionStrategy is an optional strategy that is used for
+	// selecting the inputs of PSBTs funded through FundPsbt instead of the
+	// wallet's own coin selection. This allows FundPsbt to use strategies
+	// the wallet doesn't support, like branch and bound.
+	PsbtCoinSelectionStrategy chanfunding.CoinSelectionStrategy
+
 	// WatchOnly indicates that the wallet was initialized with public key
 	// material only and does not contain any private keys.
 	WatchOnly bool
@@ -3,14 +3,18 @@ package btcwallet
 import (
 	"bytes"
 	"crypto/sha256"
+	"errors"
 	"fmt"
+	"math"
 
 	"github.com/ltcsuite/lnd/input"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/lnwallet/chainfee"
+	"github.com/ltcsuite/lnd/lnwallet/chanfunding"
 	"github.com/ltcsuite/ltcd/btcec/v2"
 	"github.com/ltcsuite/ltcd/btcec/v2/schnorr"
 	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/ltcutil/hdkeychain"
 	"github.com/ltcsuite/ltcd/ltcutil/psbt"
 	"github.com/ltcsuite/ltcd/txscript"
 	"github.com/ltcsuite/ltcd/wire"
@@ -56,6 +60,18 @@ func (b *BtcWallet) FundPsbt(packet *psbt.Packet, minConfs int32,
 	feeRate chainfee.SatPerKWeight, accountName string,
 	changeScope *waddrmgr.KeyScope) (int32, error) {
 
+	// If a coin selection strategy the wallet doesn't support is
+	// configured, we select the inputs ourselves and only let the wallet
+	// add the change output.
+	if b.cfg.PsbtCoinSelectionStrategy != nil &&
+		len(packet.UnsignedTx.TxIn) == 0 {
+
+		err := b.selectPsbtInputs(packet, minConfs, feeRate, accountName)
+		if err != nil {
+			return 0, err
+		}
+	}
+
 	// The fee rate is passed in using units of sat/kw, so we'll convert
 	// this to sat/KB as the CreateSimpleTx method requires this unit.
 	feeSatPerKB := ltcutil.Amount(feeRate.FeePerKVByte())
@@ -103,7 +119,7 @@ func (b *BtcWallet) FundPsbt(packet *psbt.Packet, minConfs int32,
 		}
 		keyScope = &scope
 		changeScope = keyScope
-		accountNum = account
+		accountNum = account.AccountNumber
 	}
 
 	var opts []wallet.TxCreateOption
@@ -119,6 +135,45 @@ func (b *BtcWallet) FundPsbt(packet *psbt.Packet, minConfs int32,
 	)
 }
 
+// selectPsbtInputs adds inputs to the given packet that fund its outputs at
+// the given fee rate, using the configured PSBT coin selection strategy. The
+// coins are selected from the unspent and unlocked outputs of the given
+// account.
+func (b *BtcWallet) selectPsbtInputs(packet *psbt.Packet, minConfs int32,
+	feeRate chainfee.SatPerKWeight, accountName string) error {
+
+	utxos, err := b.ListUnspentWitness(minConfs, math.MaxInt32, accountName)
+	if err != nil {
+		return err
+	}
+
+	coins := make([]chanfunding.Coin, 0, len(utxos))
+	for _, utxo := range utxos {
+		coins = append(coins, chanfunding.Coin{
+			TxOut: wire.TxOut{
+				Value:    int64(utxo.Value),
+				PkScript: utxo.PkScript,
+			},
+			OutPoint: utxo.OutPoint,
+		})
+	}
+
+	selected, err := chanfunding.CoinSelectOutputs(
+		b.cfg.PsbtCoinSelectionStrategy, feeRate,
+		packet.UnsignedTx.TxOut, coins,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, coin := range selected {
+		packet.UnsignedTx.AddTxIn(wire.NewTxIn(&coin.OutPoint, nil, nil))
+		packet.Inputs = append(packet.Inputs, psbt.PInput{})
+	}
+
+	return nil
+}
+
 // SignPsbt expects a partial transaction with all inputs and outputs fully
 // declared and tries to sign all unsigned inputs that have all required fields
 // (UTXO information, BIP32 derivation information, witness or sig scripts) set.
@@ -165,93 +220,132 @@ func (b *BtcWallet) SignPsbt(packet *psbt.Packet) ([]uint32, error) {
 			continue
 		}
 
-		// Skip this input if there is no BIP32 derivation info
-		// available.
-		if len(in.Bip32Derivation) == 0 {
-			continue
-		}
-
-		// TODO(guggero): For multisig, we'll need to find out what key
-		// to use and there should be multiple derivation paths in the
-		// BIP32 derivation field.
+		// Outputs of imported witness scripts can be signed for without
+		// the caller providing the witness script.
+		b.addImportedWitnessScript(in)
 
-		// Let's try and derive the key now. This method will decide if
-		// it's a BIP49/84 key for normal on-chain funds or a key of the
-		// custom purpose 1017 key scope.
-		derivationInfo := in.Bip32Derivation[0]
-		privKey, err := b.deriveKeyByBIP32Path(derivationInfo.Bip32Path)
+		signed, err := b.signPsbtInput(in, tx, sigHashes, idx)
 		if err != nil {
-			log.Warnf("SignPsbt: Skipping input %d, error "+
-				"deriving signing key: %v", idx, err)
-			continue
+			return nil, err
 		}
-
-		// We need to make sure we actually derived the key that was
-		// expected to be derived.
-		pubKeysEqual := bytes.Equal(
-			derivationInfo.PubKey,
-			privKey.PubKey().SerializeCompressed(),
-		)
-		if !pubKeysEqual {
-			log.Warnf("SignPsbt: Skipping input %d, derived "+
-				"public key %x does not match bip32 "+
-				"derivation info public key %x", idx,
-				privKey.PubKey().SerializeCompressed(),
-				derivationInfo.PubKey)
-			continue
+		if signed {
+			signedInputs = append(signedInputs, uint32(idx))
 		}
+	}
+	return signedInputs, nil
+}
 
-		// Do we need to tweak anything? Single or double tweaks are
-		// sent as custom/proprietary fields in the PSBT input section.
-		privKey = maybeTweakPrivKeyPsbt(in.Unknowns, privKey)
+// signPsbtInput signs the given input with the key of its BIP32 derivation
+// info. False is returned if the input carries no derivation info or the key
+// can't be derived by the wallet.
+func (b *BtcWallet) signPsbtInput(in *psbt.PInput, tx *wire.MsgTx,
+	sigHashes *txscript.TxSigHashes, idx int) (bool, error) {
+
+	// Skip this input if there is no BIP32 derivation info
+	// available.
+	bip32Path, expectedPubKey, ok := signingDerivation(in)
+	if !ok {
+		return false, nil
+	}
 
-		// What kind of signature is expected from us and do we have all
-		// information we need?
-		signMethod, err := validateSigningMethod(in)
-		if err != nil {
-			return nil, err
-		}
+	// TODO(guggero): For multisig, we'll need to find out what key
+	// to use and there should be multiple derivation paths in the
+	// BIP32 derivation field.
 
-		switch signMethod {
-		// For p2wkh, np2wkh and p2wsh.
-		case input.WitnessV0SignMethod:
-			err = signSegWitV0(in, tx, sigHashes, idx, privKey)
+	// Let's try and derive the key now. This method will decide if
+	// it's a BIP49/84/86 key for normal on-chain funds or a key of
+	// the custom purpose 1017 key scope.
+	privKey, err := b.deriveKeyByBIP32Path(bip32Path)
+	if err != nil {
+		log.Warnf("SignPsbt: Skipping input %d, error "+
+			"deriving signing key: %v", idx, err)
+		return false, nil
+	}
 
-		// For p2tr BIP0086 key spend only.
-		case input.TaprootKeySpendBIP0086SignMethod:
-			rootHash := make([]byte, 0)
-			err = signSegWitV1KeySpend(
-				in, tx, sigHashes, idx, privKey, rootHash,
-			)
+	// We need to make sure we actually derived the key that was
+	// expected to be derived. Taproot derivation info only
+	// contains the x-only public key.
+	derivedPubKey := privKey.PubKey().SerializeCompressed()
+	if len(expectedPubKey) == schnorr.PubKeyBytesLen {
+		derivedPubKey = schnorr.SerializePubKey(privKey.PubKey())
+	}
+	if !bytes.Equal(expectedPubKey, derivedPubKey) {
+		log.Warnf("SignPsbt: Skipping input %d, derived "+
+			"public key %x does not match bip32 "+
+			"derivation info public key %x", idx,
+			derivedPubKey, expectedPubKey)
+		return false, nil
+	}
 
-		// For p2tr with script commitment key spend path.
-		case input.TaprootKeySpendSignMethod:
-			rootHash := in.TaprootMerkleRoot
-			err = signSegWitV1KeySpend(
-				in, tx, sigHashes, idx, privKey, rootHash,
-			)
+	// Do we need to tweak anything? Single or double tweaks are
+	// sent as custom/proprietary fields in the PSBT input section.
+	privKey = maybeTweakPrivKeyPsbt(in.Unknowns, privKey)
 
-		// For p2tr script spend path.
-		case input.TaprootScriptSpendSignMethod:
-			leafScript := in.TaprootLeafScript[0]
-			leaf := txscript.TapLeaf{
-				LeafVersion: leafScript.LeafVersion,
-				Script:      leafScript.Script,
-			}
-			err = signSegWitV1ScriptSpend(
-				in, tx, sigHashes, idx, privKey, leaf,
-			)
+	// What kind of signature is expected from us and do we have all
+	// information we need?
+	signMethod, err := validateSigningMethod(in)
+	if err != nil {
+		return false, err
+	}
 
-		default:
-			err = fmt.Errorf("unsupported signing method for "+
-				"PSBT signing: %v", signMethod)
-		}
-		if err != nil {
-			return nil, err
+	switch signMethod {
+	// For p2wkh, np2wkh and p2wsh.
+	case input.WitnessV0SignMethod:
+		err = signSegWitV0(in, tx, sigHashes, idx, privKey)
+
+	// For p2tr BIP0086 key spend only.
+	case input.TaprootKeySpendBIP0086SignMethod:
+		rootHash := make([]byte, 0)
+		err = signSegWitV1KeySpend(
+			in, tx, sigHashes, idx, privKey, rootHash,
+		)
+
+	// For p2tr with script commitment key spend path.
+	case input.TaprootKeySpendSignMethod:
+		rootHash := in.TaprootMerkleRoot
+		err = signSegWitV1KeySpend(
+			in, tx, sigHashes, idx, privKey, rootHash,
+		)
+
+	// For p2tr script spend path.
+	case input.TaprootScriptSpendSignMethod:
+		leafScript := in.TaprootLeafScript[0]
+		leaf := txscript.TapLeaf{
+			LeafVersion: leafScript.LeafVersion,
+			Script:      leafScript.Script,
 		}
-		signedInputs = append(signedInputs, uint32(idx))
+		err = signSegWitV1ScriptSpend(
+			in, tx, sigHashes, idx, privKey, leaf,
+		)
+
+	default:
+		err = fmt.Errorf("unsupported signing method for "+
+			"PSBT signing: %v", signMethod)
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// signingDerivation returns the BIP32 derivation path of the key that should
+// sign the given input, together with the public key the path is expected to
+// derive. Taproot inputs created by external tools often only carry the
+// taproot specific derivation info, in which case the x-only public key is
+// returned.
+func signingDerivation(in *psbt.PInput) ([]uint32, []byte, bool) {
+	switch {
+	case len(in.Bip32Derivation) > 0:
+		derivation := in.Bip32Derivation[0]
+		return derivation.Bip32Path, derivation.PubKey, true
+
+	case len(in.TaprootBip32Derivation) > 0:
+		derivation := in.TaprootBip32Derivation[0]
+		return derivation.Bip32Path, derivation.XOnlyPubKey, true
+
+	default:
+		return nil, nil, false
 	}
-	return signedInputs, nil
 }
 
 // validateSigningMethod attempts to detect the signing method that is required
@@ -453,6 +547,216 @@ func prepareScriptsV0(in *psbt.PInput) []byte {
 	}
 }
 
+// importedWitnessScript returns the witness script of the given output script
+// if it pays to a witness script that was imported into the wallet.
+func (b *BtcWallet) importedWitnessScript(pkScript []byte) ([]byte, bool) {
+	if !txscript.IsPayToWitnessScriptHash(pkScript) {
+		return nil, false
+	}
+
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, b.netParams)
+	if err != nil || len(addrs) != 1 {
+		return nil, false
+	}
+
+	managedAddr, err := b.wallet.AddressInfo(addrs[0])
+	if err != nil {
+		return nil, false
+	}
+
+	scriptAddr, ok := managedAddr.(waddrmgr.ManagedScriptAddress)
+	if !ok {
+		return nil, false
+	}
+
+	witnessScript, err := scriptAddr.Script()
+	if err != nil {
+		return nil, false
+	}
+
+	return witnessScript, true
+}
+
+// addImportedWitnessScript adds the witness script to the given input if it
+// spends an output of an imported witness script. If the script is locked to a
+// single key of the wallet and the input carries no BIP32 derivation info, the
+// derivation of that key is added as well. False is returned if the input
+// doesn't spend an output of an imported witness script.
+func (b *BtcWallet) addImportedWitnessScript(in *psbt.PInput) bool {
+	witnessScript, ok := b.importedWitnessScript(in.WitnessUtxo.PkScript)
+	if !ok {
+		return false
+	}
+
+	if len(in.WitnessScript) == 0 {
+		in.WitnessScript = witnessScript
+	}
+
+	pubKey, ok := singleKeyScriptPubKey(witnessScript)
+	if !ok || len(in.Bip32Derivation) > 0 {
+		return true
+	}
+
+	derivation, err := b.keyDerivation(pubKey)
+	if err != nil {
+		log.Debugf("Unable to find derivation of key %x of imported "+
+			"witness script: %v", pubKey, err)
+
+		return true
+	}
+	in.Bip32Derivation = []*psbt.Bip32Derivation{derivation}
+
+	return true
+}
+
+// keyDerivation returns the BIP32 derivation info of the given public key if
+// the wallet knows the key as the key of one of its P2WKH addresses.
+func (b *BtcWallet) keyDerivation(pubKey []byte) (*psbt.Bip32Derivation,
+	error) {
+
+	addr, err := ltcutil.NewAddressWitnessPubKeyHash(
+		ltcutil.Hash160(pubKey), b.netParams,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	managedAddr, err := b.wallet.AddressInfo(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeyAddr, ok := managedAddr.(waddrmgr.ManagedPubKeyAddress)
+	if !ok {
+		return nil, fmt.Errorf("address %v is not a public key "+
+			"address", addr)
+	}
+
+	scope, path, ok := pubKeyAddr.DerivationInfo()
+	if !ok {
+		return nil, fmt.Errorf("no derivation info for address %v",
+			addr)
+	}
+
+	return &psbt.Bip32Derivation{
+		PubKey:               pubKey,
+		MasterKeyFingerprint: path.MasterKeyFingerprint,
+		Bip32Path: []uint32{
+			scope.Purpose + hdkeychain.HardenedKeyStart,
+			scope.Coin + hdkeychain.HardenedKeyStart,
+			path.InternalAccount + hdkeychain.HardenedKeyStart,
+			path.Branch, path.Index,
+		},
+	}, nil
+}
+
+// singleKeyScriptPubKey returns the public key of a witness script that
+// consists of a single compressed public key followed by OP_CHECKSIG.
+func singleKeyScriptPubKey(witnessScript []byte) ([]byte, bool) {
+	const scriptLen = btcec.PubKeyBytesLenCompressed + 2
+	if len(witnessScript) != scriptLen ||
+		witnessScript[0] != txscript.OP_DATA_33 ||
+		witnessScript[scriptLen-1] != txscript.OP_CHECKSIG {
+
+		return nil, false
+	}
+
+	return witnessScript[1 : scriptLen-1], true
+}
+
+// finalizeImportedWitnessScripts signs and finalizes all inputs of the packet
+// that spend outputs of imported witness scripts, as the wallet's finalizer
+// only knows how to spend outputs of its own keys.
+func (b *BtcWallet) finalizeImportedWitnessScripts(packet *psbt.Packet) error {
+	tx := packet.UnsignedTx
+	prevOutputFetcher := wallet.PsbtPrevOutputFetcher(packet)
+	sigHashes := txscript.NewTxSigHashes(tx, prevOutputFetcher)
+	for idx := range tx.TxIn {
+		in := &packet.Inputs[idx]
+		if in.WitnessUtxo == nil || len(in.FinalScriptWitness) > 0 {
+			continue
+		}
+
+		if !b.addImportedWitnessScript(in) {
+			continue
+		}
+
+		// Add our signature unless the input was already signed with
+		// our key through SignPsbt.
+		_, pubKey, ok := signingDerivation(in)
+		if ok && !hasPartialSig(in, pubKey) {
+			_, err := b.signPsbtInput(in, tx, sigHashes, idx)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := finalizeWitnessScriptInput(packet, idx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hasPartialSig returns true if the input carries a signature of the given
+// public key.
+func hasPartialSig(in *psbt.PInput, pubKey []byte) bool {
+	for _, sig := range in.PartialSigs {
+		if bytes.Equal(sig.PubKey, pubKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// finalizeWitnessScriptInput finalizes the P2WSH input at the given index.
+// Scripts that are locked to a single key are finalized with the signature of
+// that key, all other scripts are finalized as multisig scripts.
+func finalizeWitnessScriptInput(packet *psbt.Packet, idx int) error {
+	in := &packet.Inputs[idx]
+
+	pubKey, ok := singleKeyScriptPubKey(in.WitnessScript)
+	if !ok {
+		_, err := psbt.MaybeFinalize(packet, idx)
+		if err != nil {
+			return fmt.Errorf("error finalizing input %d spending "+
+				"imported witness script: %w", idx, err)
+		}
+
+		return nil
+	}
+
+	for _, sig := range in.PartialSigs {
+		if !bytes.Equal(sig.PubKey, pubKey) {
+			continue
+		}
+
+		var witness bytes.Buffer
+		err := psbt.WriteTxWitness(&witness, wire.TxWitness{
+			sig.Signature, in.WitnessScript,
+		})
+		if err != nil {
+			return fmt.Errorf("error serializing witness: %w", err)
+		}
+
+		// Like the PSBT finalizer, we remove all fields that are no
+		// longer needed once the input is final.
+		*in = psbt.PInput{
+			WitnessUtxo:        in.WitnessUtxo,
+			NonWitnessUtxo:     in.NonWitnessUtxo,
+			FinalScriptWitness: witness.Bytes(),
+			Unknowns:           in.Unknowns,
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("input %d spending imported witness script is not "+
+		"signed by key %x", idx, pubKey)
+}
+
 // maybeTweakPrivKeyPsbt examines if there are any tweak parameters given in the
 // custom/proprietary PSBT fields and may perform a mapping on the passed
 // private key in order to utilize the tweaks, if populated.
@@ -487,7 +791,8 @@ func maybeTweakPrivKeyPsbt(unknowns []*psbt.Unknown,
 // unsigned non-witness inputs or inputs without UTXO information attached or
 // inputs without witness data that do not belong to lnd's wallet, this method
 // will fail. If no error is returned, the PSBT is ready to be extracted and the
-// final TX within to be broadcast.
+// final TX within to be broadcast. If the account is watch-only, all inputs
+// must already have been signed by an external signer and are only finalized.
 //
 // NOTE: This method does NOT publish the transaction after it's been
 // finalized successfully.
@@ -516,20 +821,58 @@ func (b *BtcWallet) FinalizePsbt(packet *psbt.Packet, accountName string) error
 		if err != nil {
 			return err
 		}
+
+		// A watch-only account can't sign for any of its inputs. They
+		// must have been signed by an external signer already, so we
+		// only need to finalize them.
+		if account.IsWatchOnly {
+			return finalizeExternallySigned(packet)
+		}
+
 		keyScope = &scope
-		accountNum = account
+		accountNum = account.AccountNumber
+	}
+
+	// The wallet can't finalize inputs spending outputs of imported
+	// witness scripts, so we do that before finalizing the other inputs.
+	if err := b.finalizeImportedWitnessScripts(packet); err != nil {
+		return err
 	}
 
 	return b.wallet.FinalizePsbt(keyScope, accountNum, packet)
 }
 
+// finalizeExternallySigned finalizes all inputs of the packet that don't have
+// final witness data attached yet, using the signatures an external signer
+// added to them. An error is returned if any input can't be finalized.
+func finalizeExternallySigned(packet *psbt.Packet) error {
+	for idx, in := range packet.Inputs {
+		if len(in.FinalScriptWitness) > 0 || len(in.FinalScriptSig) > 0 {
+			continue
+		}
+
+		_, err := psbt.MaybeFinalize(packet, idx)
+		switch {
+		case errors.Is(err, psbt.ErrNotFinalizable):
+			return fmt.Errorf("input %d of watch-only account is "+
+				"not fully signed", idx)
+
+		case err != nil:
+			return fmt.Errorf("error finalizing input %d: %w", idx,
+				err)
+		}
+	}
+
+	return nil
+}
+
 // lookupFirstCustomAccount returns the first custom account found. In theory,
 // there should be only one custom account for the given name. However, due to a
 // lack of check, users could have created custom accounts with various key
 // scopes. This behaviour has been fixed but, we still need to handle this
 // specific case to avoid non-deterministic behaviour implied by LookupAccount.
 func (b *BtcWallet) lookupFirstCustomAccount(
-	name string) (waddrmgr.KeyScope, uint32, error) {
+	name string) (waddrmgr.KeyScope, *waddrmgr.AccountProperties, error) {
 
 	var (
 		account  *waddrmgr.AccountProperties
@@ -542,7 +885,7 @@ func (b *BtcWallet) lookupFirstCustomAccount(
 			continue
 		}
 		if err != nil {
-			return keyScope, 0, err
+			return keyScope, nil, err
 		}
 
 		keyScope = scope
@@ -550,8 +893,8 @@ func (b *BtcWallet) lookupFirstCustomAccount(
 		break
 	}
 	if account == nil {
-		return waddrmgr.KeyScope{}, 0, newAccountNotFoundError(name)
+		return waddrmgr.KeyScope{}, nil, newAccountNotFoundError(name)
 	}
 
-	return keyScope, account.AccountNumber, nil
+	return keyScope, account, nil
 }
@@ -9,7 +9,9 @@ import (
 
 	"github.com/ltcsuite/lnd/input"
 	"github.com/ltcsuite/lnd/keychain"
+	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/ltcd/btcec/v2"
+	"github.com/ltcsuite/ltcd/btcec/v2/schnorr"
 	"github.com/ltcsuite/ltcd/chaincfg"
 	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/ltcutil/psbt"
@@ -56,6 +58,7 @@ const (
 	nestedP2WKH                 testInputType = 2
 	singleKeyP2WSH              testInputType = 3
 	singleKeyDoubleTweakedP2WSH testInputType = 4
+	taprootBIP0086              testInputType = 5
 )
 
 func (i testInputType) keyPath() []uint32 {
@@ -84,6 +87,14 @@ func (i testInputType) keyPath() []uint32 {
 			0, 9,
 		}
 
+	case taprootBIP0086:
+		return []uint32{
+			hardenedKey(waddrmgr.KeyScopeBIP0086.Purpose),
+			hardenedKey(2),
+			hardenedKey(0),
+			0, 0,
+		}
+
 	default:
 		return []uint32{
 			hardenedKey(waddrmgr.KeyScopeBIP0084.Purpose),
@@ -164,6 +175,15 @@ func (i testInputType) output(t *testing.T,
 		addr, err = ltcutil.NewAddressWitnessScriptHash(h[:], netParams)
 		require.NoError(t, err)
 
+	case taprootBIP0086:
+		taprootKey := txscript.ComputeTaprootKeyNoScript(
+			privKey.PubKey(),
+		)
+		addr, err = ltcutil.NewAddressTaproot(
+			schnorr.SerializePubKey(taprootKey), netParams,
+		)
+		require.NoError(t, err)
+
 	default:
 		t.Fatalf("invalid input type")
 	}
@@ -202,6 +222,16 @@ func (i testInputType) decorateInput(t *testing.T, privKey *btcec.PrivateKey,
 			Key:   PsbtKeyTypeInputSignatureTweakDouble,
 			Value: testCommitSecret.Serialize(),
 		}}
+
+	// External signers usually only add the taproot specific derivation
+	// info to taproot inputs.
+	case taprootBIP0086:
+		in.Bip32Derivation = nil
+		in.TaprootBip32Derivation = []*psbt.TaprootBip32Derivation{{
+			XOnlyPubKey: schnorr.SerializePubKey(privKey.PubKey()),
+			Bip32Path:   i.keyPath(),
+		}}
+		in.SighashType = txscript.SigHashDefault
 	}
 }
 
@@ -272,6 +302,9 @@ func TestSignPsbt(t *testing.T) {
 	}, {
 		name:      "single key double tweaked P2WSH",
 		inputType: singleKeyDoubleTweakedP2WSH,
+	}, {
+		name:      "taproot BIP0086 key spend",
+		inputType: taprootBIP0086,
 	}}
 
 	for _, tc := range testCases {
@@ -343,3 +376,92 @@ func TestSignPsbt(t *testing.T) {
 		require.NoError(t, vm.Execute())
 	}
 }
+
+// TestFinalizeExternallySigned makes sure inputs of watch-only accounts are
+// only finalized if they were fully signed by an external signer.
+func TestFinalizeExternallySigned(t *testing.T) {
+	t.Parallel()
+
+	spendTx := wire.NewMsgTx(2)
+	spendTx.AddTxIn(&wire.TxIn{})
+	spendTx.AddTxOut(&wire.TxOut{Value: testValue})
+
+	packet, err := psbt.NewFromUnsignedTx(spendTx)
+	require.NoError(t, err)
+
+	err = finalizeExternallySigned(packet)
+	require.ErrorContains(t, err, "not fully signed")
+
+	// Inputs that already are final are skipped.
+	packet.Inputs[0].FinalScriptWitness = []byte{0x00}
+	require.NoError(t, finalizeExternallySigned(packet))
+}
+
+// TestFinalizeImportedWitnessScript makes sure the output of an imported
+// witness script that is locked to a key of the wallet can be spent.
+func TestFinalizeImportedWitnessScript(t *testing.T) {
+	w, _ := newTestWallet(t, netParams, seedBytes)
+
+	// The script is locked to the key of a regular wallet address.
+	addr, err := w.NewAddress(
+		lnwallet.WitnessPubKey, false, lnwallet.DefaultAccountName,
+	)
+	require.NoError(t, err)
+
+	managedAddr, err := w.AddressInfo(addr)
+	require.NoError(t, err)
+	pubKeyAddr, ok := managedAddr.(waddrmgr.ManagedPubKeyAddress)
+	require.True(t, ok)
+
+	builder := txscript.NewScriptBuilder()
+	builder.AddData(pubKeyAddr.PubKey().SerializeCompressed())
+	builder.AddOp(txscript.OP_CHECKSIG)
+	witnessScript, err := builder.Script()
+	require.NoError(t, err)
+
+	scriptAddr, err := w.ImportWitnessScript(
+		waddrmgr.KeyScopeBIP0084, witnessScript,
+	)
+	require.NoError(t, err)
+
+	pkScript, err := txscript.PayToAddrScript(scriptAddr.Address())
+	require.NoError(t, err)
+
+	refTx := wire.NewMsgTx(2)
+	refTx.AddTxIn(&wire.TxIn{})
+	refTx.AddTxOut(&wire.TxOut{Value: testValue, PkScript: pkScript})
+
+	spendTx := wire.NewMsgTx(2)
+	spendTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{
+			Hash:  refTx.TxHash(),
+			Index: 0,
+		},
+	})
+	spendTx.AddTxOut(&wire.TxOut{
+		Value:    testValue - 1000,
+		PkScript: pkScript,
+	})
+
+	// The PSBT only carries the UTXO information. The witness script and
+	// the derivation of the signing key are added by the wallet.
+	packet, err := psbt.NewFromUnsignedTx(spendTx)
+	require.NoError(t, err)
+	packet.Inputs[0].WitnessUtxo = refTx.TxOut[0]
+
+	err = w.FinalizePsbt(packet, lnwallet.DefaultAccountName)
+	require.NoError(t, err)
+
+	finalTx, err := psbt.Extract(packet)
+	require.NoError(t, err)
+	require.Equal(t, witnessScript, finalTx.TxIn[0].Witness[1])
+
+	vm, err := txscript.NewEngine(
+		pkScript, finalTx, 0, txscript.StandardVerifyFlags, nil, nil,
+		testValue, txscript.NewCannedPrevOutputFetcher(
+			pkScript, testValue,
+		),
+	)
+	require.NoError(t, err)
+	require.NoError(t, vm.Execute())
+}
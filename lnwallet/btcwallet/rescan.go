@@ -0,0 +1,191 @@
+package btcwallet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+	"github.com/ltcsuite/ltcwallet/waddrmgr"
+	base "github.com/ltcsuite/ltcwallet/wallet"
+	"github.com/ltcsuite/ltcwallet/walletdb"
+)
+
+// rescanProgressInterval is the interval at which the progress of a rescan is
+// reported.
+const rescanProgressInterval = 5 * time.Second
+
+// Rescan rewinds the wallet to the given height and rescans the chain from
+// there for transactions relevant to the wallet's addresses and unspent
+// outputs. A start height of zero rescans from the wallet's birthday block.
+// The progress callback is invoked periodically with the height the wallet
+// has been rescanned to and the progress made so far as a value between 0 and
+// 1. This method blocks until the rescan has completed.
+//
+// NOTE: The wallet's synced height is rewound to the start height, so the
+// wallet won't be considered synced to the chain until the rescan completes.
+// If lnd is restarted before that, the wallet continues the rescan from the
+// height it has reached on startup.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) Rescan(startHeight int32,
+	progress func(height int32, progress float64)) error {
+
+	_, bestHeight, err := b.cfg.ChainSource.GetBestBlock()
+	if err != nil {
+		return err
+	}
+
+	if startHeight < 0 || startHeight > bestHeight {
+		return fmt.Errorf("start height %d is outside of the chain, "+
+			"best height is %d", startHeight, bestHeight)
+	}
+
+	var (
+		startStamp waddrmgr.BlockStamp
+		addrs      []ltcutil.Address
+		outPoints  = make(map[wire.OutPoint]ltcutil.Address)
+	)
+	err = walletdb.View(b.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+
+		if startHeight == 0 {
+			birthday, _, err := b.wallet.Manager.BirthdayBlock(
+				addrmgrNs,
+			)
+			if err != nil {
+				return err
+			}
+			startStamp = birthday
+		}
+
+		err := b.wallet.Manager.ForEachActiveAddress(
+			addrmgrNs, func(addr ltcutil.Address) error {
+				addrs = append(addrs, addr)
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		// We also watch our unspent outputs, so we'll notice any spends
+		// of them within the rescanned blocks.
+		credits, err := b.wallet.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			return err
+		}
+		for _, credit := range credits {
+			_, creditAddrs, _, err := txscript.ExtractPkScriptAddrs(
+				credit.PkScript, b.netParams,
+			)
+			if err != nil {
+				return err
+			}
+			if len(creditAddrs) == 0 {
+				continue
+			}
+
+			outPoints[credit.OutPoint] = creditAddrs[0]
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to prepare rescan: %w", err)
+	}
+
+	if startHeight != 0 {
+		hash, err := b.cfg.ChainSource.GetBlockHash(int64(startHeight))
+		if err != nil {
+			return err
+		}
+		header, err := b.cfg.ChainSource.GetBlockHeader(hash)
+		if err != nil {
+			return err
+		}
+
+		startStamp = waddrmgr.BlockStamp{
+			Height:    startHeight,
+			Hash:      *hash,
+			Timestamp: header.Timestamp,
+		}
+	}
+
+	// Rewind the wallet to the start of the rescan. As the rescan
+	// progresses, the wallet's synced height catches up again, which is
+	// what we base the reported progress on.
+	err = walletdb.Update(b.db, func(tx walletdb.ReadWriteTx) error {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return b.wallet.Manager.SetSyncedTo(addrmgrNs, &startStamp)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to rewind wallet: %w", err)
+	}
+
+	log.Infof("Rescanning chain from height %d for %d addresses and %d "+
+		"outpoints", startStamp.Height, len(addrs), len(outPoints))
+
+	errChan := b.wallet.SubmitRescan(&base.RescanJob{
+		Addrs:      addrs,
+		OutPoints:  outPoints,
+		BlockStamp: startStamp,
+	})
+
+	ticker := time.NewTicker(rescanProgressInterval)
+	defer ticker.Stop()
+
+	progress(startStamp.Height, 0)
+	for {
+		select {
+		case err := <-errChan:
+			if err != nil {
+				return fmt.Errorf("rescan failed: %w", err)
+			}
+
+			log.Infof("Finished rescan from height %d",
+				startStamp.Height)
+
+			_, bestHeight, err = b.cfg.ChainSource.GetBestBlock()
+			if err != nil {
+				return err
+			}
+			progress(bestHeight, 1)
+
+			return nil
+
+		case <-ticker.C:
+			if b.wallet.ShuttingDown() {
+				return fmt.Errorf("wallet shutting down")
+			}
+
+			_, bestHeight, err = b.cfg.ChainSource.GetBestBlock()
+			if err != nil {
+				return err
+			}
+
+			syncedHeight := b.wallet.Manager.SyncedTo().Height
+			progress(syncedHeight, rescanProgress(
+				startStamp.Height, syncedHeight, bestHeight,
+			))
+		}
+	}
+}
+
+// rescanProgress returns the progress of a rescan from the start height to the
+// best height that has reached the given synced height, as a value between 0
+// and 1.
+func rescanProgress(startHeight, syncedHeight, bestHeight int32) float64 {
+	switch {
+	case syncedHeight <= startHeight:
+		return 0
+
+	case syncedHeight >= bestHeight:
+		return 1
+	}
+
+	return float64(syncedHeight-startHeight) /
+		float64(bestHeight-startHeight)
+}
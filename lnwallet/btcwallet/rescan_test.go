@@ -0,0 +1,67 @@
+package btcwallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRescanProgress tests that the progress of a rescan is derived correctly
+// from the wallet's synced height.
+func TestRescanProgress(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		start    int32
+		synced   int32
+		best     int32
+		expected float64
+	}{
+		{
+			name:     "not started",
+			start:    100,
+			synced:   100,
+			best:     200,
+			expected: 0,
+		},
+		{
+			name:     "half way",
+			start:    100,
+			synced:   150,
+			best:     200,
+			expected: 0.5,
+		},
+		{
+			name:     "done",
+			start:    100,
+			synced:   200,
+			best:     200,
+			expected: 1,
+		},
+		{
+			name:     "new block during rescan",
+			start:    100,
+			synced:   201,
+			best:     200,
+			expected: 1,
+		},
+		{
+			name:     "rescan from tip",
+			start:    200,
+			synced:   200,
+			best:     200,
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			progress := rescanProgress(tc.start, tc.synced, tc.best)
+			require.Equal(t, tc.expected, progress)
+		})
+	}
+}
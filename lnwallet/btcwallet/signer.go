@@ -148,7 +148,8 @@ func (b *BtcWallet) deriveKeyByBIP32Path(path []uint32) (*btcec.PrivateKey,
 			"unknown purpose %d", purpose)
 	}
 
-	// Okay, we made sure it's a BIP49/84 key, so we need to derive it now.
+	// Okay, we made sure it's a BIP49/84/86 key, so we need to derive it
+	// now.
 	// Interestingly, the ltcwallet never actually uses a coin type other
 	// than 2 for those keys, so we need to make sure this behavior is
 	// replicated here.
@@ -297,6 +298,13 @@ func (b *BtcWallet) fetchPrivKey(
 	// This will only work if we've already derived this address in the
 	// past, since the wallet relies on a mapping of addr -> key.
 	key, err := b.wallet.PrivKeyForAddress(addr)
+
+	// Keys of the BIP-0086 scope are only known to the wallet through
+	// their P2TR address, so we'll try that one before giving up.
+	if waddrmgr.IsError(err, waddrmgr.ErrAddressNotFound) {
+		key, err = b.privKeyForTaprootAddr(keyDesc.PubKey)
+	}
+
 	switch {
 	// If we didn't find this key in the wallet, then there's a chance that
 	// this is actually an "empty" key locator. The legacy KeyLocator
@@ -313,6 +321,22 @@ func (b *BtcWallet) fetchPrivKey(
 	}
 }
 
+// privKeyForTaprootAddr returns the private key of the BIP-0086 P2TR address
+// that commits to the given internal key without a script path.
+func (b *BtcWallet) privKeyForTaprootAddr(
+	internalKey *btcec.PublicKey) (*btcec.PrivateKey, error) {
+
+	taprootKey := txscript.ComputeTaprootKeyNoScript(internalKey)
+	addr, err := ltcutil.NewAddressTaproot(
+		schnorr.SerializePubKey(taprootKey), b.netParams,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.wallet.PrivKeyForAddress(addr)
+}
+
 // maybeTweakPrivKey examines the single and double tweak parameters on the
 // passed sign descriptor and may perform a mapping on the passed private key
 // in order to utilize the tweaks, if populated.
@@ -438,8 +462,9 @@ func (b *BtcWallet) SignOutputRaw(tx *wire.MsgTx,
 
 // ComputeInputScript generates a complete InputScript for the passed
 // transaction with the signature as defined within the passed SignDescriptor.
-// This method is capable of generating the proper input script for both
-// regular p2wkh output and p2wkh outputs nested within a regular p2sh output.
+// This method is capable of generating the proper input script for regular
+// p2wkh outputs, p2wkh outputs nested within a regular p2sh output and BIP-0086
+// p2tr outputs spent through the key path.
 //
 // This is a part of the WalletController interface.
 func (b *BtcWallet) ComputeInputScript(tx *wire.MsgTx,
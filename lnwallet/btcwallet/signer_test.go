@@ -1,6 +1,7 @@
 package btcwallet
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"math"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/ltcsuite/lnd/blockcache"
 	"github.com/ltcsuite/lnd/input"
+	"github.com/ltcsuite/lnd/keychain"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/ltcd/btcec/v2"
 	"github.com/ltcsuite/ltcd/btcec/v2/schnorr"
@@ -202,6 +204,30 @@ func TestBip32KeyDerivation(t *testing.T) {
 	}
 }
 
+// TestFetchPrivKeyTaproot makes sure that the private key of a BIP-0086 key can
+// be fetched by its public key alone.
+func TestFetchPrivKeyTaproot(t *testing.T) {
+	netParams := &chaincfg.RegressionNetParams
+	w, _ := newTestWallet(t, netParams, seedBytes)
+
+	addr, err := w.NewAddress(
+		lnwallet.TaprootPubkey, false, lnwallet.DefaultAccountName,
+	)
+	require.NoError(t, err)
+	require.Equal(t, firstAddressTaproot, addr.String())
+
+	addrInfo, err := w.AddressInfo(addr)
+	require.NoError(t, err)
+	pubKeyAddr, ok := addrInfo.(waddrmgr.ManagedPubKeyAddress)
+	require.True(t, ok)
+
+	privKey, err := w.fetchPrivKey(&keychain.KeyDescriptor{
+		PubKey: pubKeyAddr.PubKey(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, pubKeyAddr.PubKey(), privKey.PubKey())
+}
+
 // TestScriptImport tests the btcwallet's tapscript import capabilities by
 // importing both a full taproot script tree and a partially revealed branch
 // with a proof to make sure the resulting addresses match up.
@@ -288,6 +314,32 @@ func TestScriptImport(t *testing.T) {
 	))
 }
 
+// TestWitnessScriptImport tests that a witness script can be imported into the
+// wallet as a P2WSH address.
+func TestWitnessScriptImport(t *testing.T) {
+	netParams := &chaincfg.RegressionNetParams
+	w, _ := newTestWallet(t, netParams, seedBytes)
+
+	builder := txscript.NewScriptBuilder()
+	builder.AddData(testPubKey.SerializeCompressed())
+	builder.AddOp(txscript.OP_CHECKSIG)
+	witnessScript, err := builder.Script()
+	require.NoError(t, err)
+
+	scriptHash := sha256.Sum256(witnessScript)
+	expectedAddr, err := ltcutil.NewAddressWitnessScriptHash(
+		scriptHash[:], netParams,
+	)
+	require.NoError(t, err)
+
+	addr, err := w.ImportWitnessScript(
+		waddrmgr.KeyScopeBIP0084, witnessScript,
+	)
+	require.NoError(t, err)
+	require.Equal(t, expectedAddr.String(), addr.Address().String())
+	require.True(t, w.IsOurAddress(expectedAddr))
+}
+
 func newTestWallet(t *testing.T, netParams *chaincfg.Params,
 	seedBytes []byte) (*BtcWallet, *rpctest.Harness) {
 
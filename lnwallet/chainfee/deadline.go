@@ -0,0 +1,184 @@
+package chainfee
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ltcsuite/lnd/clock"
+)
+
+const (
+	// defaultBlockTimeSamples is the number of recent blocks that are used
+	// to determine the average time between blocks.
+	defaultBlockTimeSamples uint32 = 24
+
+	// defaultBlockIntervalCacheDuration is the time the average block
+	// interval is cached for. Determining the interval requires fetching
+	// the headers of all sampled blocks, while it barely changes from one
+	// block to the next.
+	defaultBlockIntervalCacheDuration = 10 * time.Minute
+)
+
+var (
+	// ErrInvalidDeadline is returned when a deadline of zero or less is
+	// passed to the DeadlineEstimator.
+	ErrInvalidDeadline = errors.New("deadline must be positive")
+)
+
+// BlockTimeSource provides the timestamps of the most recent blocks of the
+// chain backend.
+type BlockTimeSource interface {
+	// RecentBlockTimes returns the timestamps of up to the last numBlocks
+	// blocks of the best chain, ordered from oldest to newest.
+	RecentBlockTimes(numBlocks uint32) ([]time.Time, error)
+}
+
+// DeadlineEstimator extends an Estimator with the ability to estimate a fee
+// rate for a transaction that needs to confirm within a given duration rather
+// than a given number of blocks. The deadline is converted into a conf target
+// using the average time between recent blocks.
+type DeadlineEstimator struct {
+	Estimator
+
+	// blockTimes is used to query the timestamps of recent blocks.
+	blockTimes BlockTimeSource
+
+	// targetBlockInterval is the expected time between blocks of the
+	// network. It is used whenever the recent block times can't be used
+	// to determine the actual interval.
+	targetBlockInterval time.Duration
+
+	// numSamples is the number of recent blocks considered when computing
+	// the average block interval.
+	numSamples uint32
+
+	// clock is used to expire the cached block interval.
+	clock clock.Clock
+
+	// cacheDuration is the time the average block interval is cached for.
+	cacheDuration time.Duration
+
+	// intervalMtx guards the fields below.
+	intervalMtx sync.Mutex
+
+	// cachedInterval is the average block interval that was last
+	// determined from the recent block times. It is zero if there is none.
+	cachedInterval time.Duration
+
+	// cachedAt is the time the cached interval was determined.
+	cachedAt time.Time
+}
+
+// NewDeadlineEstimator returns a new DeadlineEstimator that wraps the given
+// estimator. The targetBlockInterval is the expected block interval of the
+// network and serves as a fallback if the block times can't be queried.
+func NewDeadlineEstimator(estimator Estimator, blockTimes BlockTimeSource,
+	targetBlockInterval time.Duration) *DeadlineEstimator {
+
+	return &DeadlineEstimator{
+		Estimator:           estimator,
+		blockTimes:          blockTimes,
+		targetBlockInterval: targetBlockInterval,
+		numSamples:          defaultBlockTimeSamples,
+		clock:               clock.NewDefaultClock(),
+		cacheDuration:       defaultBlockIntervalCacheDuration,
+	}
+}
+
+// EstimateFeeRate returns the fee rate expressed in sat/kw for a transaction
+// that should confirm within the given deadline.
+func (d *DeadlineEstimator) EstimateFeeRate(
+	deadline time.Duration) (SatPerKWeight, error) {
+
+	confTarget, err := d.ConfTargetForDeadline(deadline)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Debugf("Using conf target of %v for deadline of %v", confTarget,
+		deadline)
+
+	return d.EstimateFeePerKW(confTarget)
+}
+
+// ConfTargetForDeadline converts the given deadline into a number of blocks
+// that are expected to be mined before the deadline expires. The returned conf
+// target is clamped to the range supported by the fee estimators.
+func (d *DeadlineEstimator) ConfTargetForDeadline(
+	deadline time.Duration) (uint32, error) {
+
+	if deadline <= 0 {
+		return 0, ErrInvalidDeadline
+	}
+
+	interval := d.blockInterval()
+
+	// Round down, as rounding up could make us miss the deadline.
+	blocks := int64(deadline / interval)
+	switch {
+	case blocks < int64(minBlockTarget):
+		return minBlockTarget, nil
+
+	case blocks > int64(maxBlockTarget):
+		return maxBlockTarget, nil
+	}
+
+	return uint32(blocks), nil
+}
+
+// blockInterval returns the average time between the recent blocks. If that
+// can't be determined, the target block interval of the network is returned.
+// The average is cached for cacheDuration, so that the block times don't need
+// to be fetched for every estimate.
+func (d *DeadlineEstimator) blockInterval() time.Duration {
+	d.intervalMtx.Lock()
+	defer d.intervalMtx.Unlock()
+
+	now := d.clock.Now()
+	if d.cachedInterval != 0 && now.Sub(d.cachedAt) < d.cacheDuration {
+		return d.cachedInterval
+	}
+
+	interval, err := d.averageBlockInterval()
+	if err != nil {
+		log.Debugf("Unable to determine block interval, using target "+
+			"interval of %v: %v", d.targetBlockInterval, err)
+
+		return d.targetBlockInterval
+	}
+
+	d.cachedInterval = interval
+	d.cachedAt = now
+
+	return interval
+}
+
+// averageBlockInterval computes the average time between the recent blocks
+// returned by the block time source.
+func (d *DeadlineEstimator) averageBlockInterval() (time.Duration, error) {
+	if d.blockTimes == nil {
+		return 0, errors.New("no block time source")
+	}
+
+	times, err := d.blockTimes.RecentBlockTimes(d.numSamples)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(times) < 2 {
+		return 0, fmt.Errorf("need at least 2 blocks, got %d",
+			len(times))
+	}
+
+	// Block timestamps are not strictly increasing, so we only look at
+	// the total span which is good enough for an average.
+	span := times[len(times)-1].Sub(times[0])
+	interval := span / time.Duration(len(times)-1)
+	if interval <= 0 {
+		return 0, fmt.Errorf("invalid block interval %v", interval)
+	}
+
+	return interval, nil
+}
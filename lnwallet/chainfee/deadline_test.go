@@ -0,0 +1,230 @@
+package chainfee
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockBlockTimeSource returns a fixed set of block times.
+type mockBlockTimeSource struct {
+	times []time.Time
+	err   error
+	calls int
+}
+
+func (m *mockBlockTimeSource) RecentBlockTimes(
+	numBlocks uint32) ([]time.Time, error) {
+
+	m.calls++
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	if int(numBlocks) < len(m.times) {
+		return m.times[len(m.times)-int(numBlocks):], nil
+	}
+
+	return m.times, nil
+}
+
+// blockTimes creates n block times spaced by the given interval.
+func blockTimes(n int, interval time.Duration) []time.Time {
+	start := time.Unix(1_700_000_000, 0)
+
+	times := make([]time.Time, n)
+	for i := range times {
+		times[i] = start.Add(time.Duration(i) * interval)
+	}
+
+	return times
+}
+
+// TestConfTargetForDeadline checks that deadlines are converted into conf
+// targets using the recent block interval.
+func TestConfTargetForDeadline(t *testing.T) {
+	t.Parallel()
+
+	const targetInterval = 150 * time.Second
+
+	testCases := []struct {
+		name       string
+		source     BlockTimeSource
+		deadline   time.Duration
+		confTarget uint32
+		err        error
+	}{
+		{
+			name:     "invalid deadline",
+			source:   &mockBlockTimeSource{},
+			deadline: 0,
+			err:      ErrInvalidDeadline,
+		},
+		{
+			name: "regular blocks",
+			source: &mockBlockTimeSource{
+				times: blockTimes(30, targetInterval),
+			},
+			deadline:   time.Hour,
+			confTarget: 24,
+		},
+		{
+			name: "slow blocks",
+			source: &mockBlockTimeSource{
+				times: blockTimes(30, 2*targetInterval),
+			},
+			deadline:   time.Hour,
+			confTarget: 12,
+		},
+		{
+			name: "round down",
+			source: &mockBlockTimeSource{
+				times: blockTimes(30, targetInterval),
+			},
+			deadline:   time.Hour - time.Second,
+			confTarget: 23,
+		},
+		{
+			name: "deadline below one block",
+			source: &mockBlockTimeSource{
+				times: blockTimes(30, targetInterval),
+			},
+			deadline:   time.Minute,
+			confTarget: minBlockTarget,
+		},
+		{
+			name: "deadline above max target",
+			source: &mockBlockTimeSource{
+				times: blockTimes(30, targetInterval),
+			},
+			deadline:   30 * 24 * time.Hour,
+			confTarget: maxBlockTarget,
+		},
+		{
+			name: "source error uses target interval",
+			source: &mockBlockTimeSource{
+				err: errors.New("backend down"),
+			},
+			deadline:   time.Hour,
+			confTarget: 24,
+		},
+		{
+			name: "single block uses target interval",
+			source: &mockBlockTimeSource{
+				times: blockTimes(1, time.Hour),
+			},
+			deadline:   time.Hour,
+			confTarget: 24,
+		},
+		{
+			name:       "no source uses target interval",
+			deadline:   time.Hour,
+			confTarget: 24,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			estimator := NewDeadlineEstimator(
+				NewStaticEstimator(FeePerKwFloor, FeePerKwFloor),
+				tc.source, targetInterval,
+			)
+
+			confTarget, err := estimator.ConfTargetForDeadline(
+				tc.deadline,
+			)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.confTarget, confTarget)
+		})
+	}
+}
+
+// TestEstimateFeeRate checks that the fee rate for a deadline is taken from
+// the conf target it maps to.
+func TestEstimateFeeRate(t *testing.T) {
+	t.Parallel()
+
+	webEstimator := NewWebAPIEstimator(nil, false, nil)
+	webEstimator.feeByBlockTarget = map[uint32]uint32{
+		1:  40000,
+		24: 4000,
+	}
+
+	estimator := NewDeadlineEstimator(
+		webEstimator, &mockBlockTimeSource{
+			times: blockTimes(30, 150*time.Second),
+		}, 150*time.Second,
+	)
+
+	feeRate, err := estimator.EstimateFeeRate(time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, SatPerKVByte(4000).FeePerKWeight(), feeRate)
+
+	feeRate, err = estimator.EstimateFeeRate(time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, SatPerKVByte(40000).FeePerKWeight(), feeRate)
+
+	_, err = estimator.EstimateFeeRate(-time.Minute)
+	require.ErrorIs(t, err, ErrInvalidDeadline)
+}
+
+// TestBlockIntervalCache checks that the average block interval is cached and
+// only determined again once the cache expired.
+func TestBlockIntervalCache(t *testing.T) {
+	t.Parallel()
+
+	const targetInterval = 150 * time.Second
+
+	source := &mockBlockTimeSource{
+		times: blockTimes(30, targetInterval),
+	}
+	estimator := NewDeadlineEstimator(
+		NewStaticEstimator(FeePerKwFloor, FeePerKwFloor), source,
+		targetInterval,
+	)
+	testClock := clock.NewTestClock(time.Unix(1_700_000_000, 0))
+	estimator.clock = testClock
+
+	confTarget, err := estimator.ConfTargetForDeadline(time.Hour)
+	require.NoError(t, err)
+	require.EqualValues(t, 24, confTarget)
+	require.Equal(t, 1, source.calls)
+
+	// Within the cache duration, the cached interval is used even if the
+	// block times changed.
+	source.times = blockTimes(30, 2*targetInterval)
+	confTarget, err = estimator.ConfTargetForDeadline(time.Hour)
+	require.NoError(t, err)
+	require.EqualValues(t, 24, confTarget)
+	require.Equal(t, 1, source.calls)
+
+	// Once the cache expired, the block times are queried again.
+	testClock.SetTime(testClock.Now().Add(
+		defaultBlockIntervalCacheDuration,
+	))
+	confTarget, err = estimator.ConfTargetForDeadline(time.Hour)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, confTarget)
+	require.Equal(t, 2, source.calls)
+
+	// Failures aren't cached, so the block times are queried again on
+	// the next estimate.
+	testClock.SetTime(testClock.Now().Add(
+		defaultBlockIntervalCacheDuration,
+	))
+	source.err = errors.New("backend down")
+	confTarget, err = estimator.ConfTargetForDeadline(time.Hour)
+	require.NoError(t, err)
+	require.EqualValues(t, 24, confTarget)
+
+	_, err = estimator.ConfTargetForDeadline(time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, 4, source.calls)
+}
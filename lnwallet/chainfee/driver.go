@@ -0,0 +1,88 @@
+package chainfee
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// EstimatorConfig houses the parameters that are passed to an
+// EstimatorFactory when a custom fee estimator is created.
+type EstimatorConfig struct {
+	// Backend is the fee estimator of the active chain backend. Custom
+	// estimators can use it as a fallback.
+	Backend Estimator
+
+	// Args is the opaque argument string set through --fee.estimatorargs.
+	// Its format is defined by the estimator.
+	Args string
+}
+
+// EstimatorFactory creates a new fee estimator from the given config.
+type EstimatorFactory func(cfg *EstimatorConfig) (Estimator, error)
+
+var (
+	estimators   = make(map[string]EstimatorFactory)
+	estimatorMtx sync.Mutex
+)
+
+// RegisterEstimator registers a fee estimator factory under the given name.
+// Custom estimators are expected to call this function from within their
+// package's init() method, after which they can be selected with
+// --fee.estimator.
+//
+// NOTE: This function is safe for concurrent access.
+func RegisterEstimator(name string, factory EstimatorFactory) error {
+	estimatorMtx.Lock()
+	defer estimatorMtx.Unlock()
+
+	if name == "" {
+		return fmt.Errorf("fee estimator name must not be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("fee estimator factory must not be nil")
+	}
+	if _, ok := estimators[name]; ok {
+		return fmt.Errorf("fee estimator %v already registered", name)
+	}
+
+	estimators[name] = factory
+
+	return nil
+}
+
+// RegisteredEstimators returns the names of all registered fee estimators in
+// alphabetical order.
+//
+// NOTE: This function is safe for concurrent access.
+func RegisteredEstimators() []string {
+	estimatorMtx.Lock()
+	defer estimatorMtx.Unlock()
+
+	names := make([]string, 0, len(estimators))
+	for name := range estimators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NewRegisteredEstimator creates a new instance of the fee estimator that was
+// registered under the given name.
+//
+// NOTE: This function is safe for concurrent access.
+func NewRegisteredEstimator(name string, cfg *EstimatorConfig) (Estimator,
+	error) {
+
+	estimatorMtx.Lock()
+	factory, ok := estimators[name]
+	estimatorMtx.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown fee estimator %v, registered "+
+			"estimators: %v", name, RegisteredEstimators())
+	}
+
+	return factory(cfg)
+}
@@ -0,0 +1,42 @@
+package chainfee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegisterEstimator checks that custom fee estimators can be registered
+// and instantiated by name.
+func TestRegisterEstimator(t *testing.T) {
+	backend := NewStaticEstimator(FeePerKwFloor, FeePerKwFloor)
+
+	factory := func(cfg *EstimatorConfig) (Estimator, error) {
+		require.Equal(t, "rate=5000", cfg.Args)
+
+		return NewFilter(cfg.Backend, 5000, 0), nil
+	}
+
+	require.NoError(t, RegisterEstimator("test-oracle", factory))
+	require.Contains(t, RegisteredEstimators(), "test-oracle")
+
+	// Registering the same name twice or invalid drivers must fail.
+	require.Error(t, RegisterEstimator("test-oracle", factory))
+	require.Error(t, RegisterEstimator("", factory))
+	require.Error(t, RegisterEstimator("test-nil", nil))
+
+	estimator, err := NewRegisteredEstimator(
+		"test-oracle", &EstimatorConfig{
+			Backend: backend,
+			Args:    "rate=5000",
+		},
+	)
+	require.NoError(t, err)
+
+	feeRate, err := estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, SatPerKWeight(5000), feeRate)
+
+	_, err = NewRegisteredEstimator("unknown", &EstimatorConfig{})
+	require.ErrorContains(t, err, "unknown fee estimator")
+}
@@ -9,6 +9,7 @@ import (
 	prand "math/rand"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -547,6 +548,95 @@ func (s SparseConfFeeSource) ParseResponse(r io.Reader) (map[uint32]uint32, erro
 // WebAPIFeeSource interface.
 var _ WebAPIFeeSource = (*SparseConfFeeSource)(nil)
 
+// DefaultMempoolSpaceURL is the default URL of a mempool.space-style fee API
+// that serves fee recommendations for the Litecoin network.
+const DefaultMempoolSpaceURL = "https://litecoinspace.org/api/v1/fees/recommended"
+
+// MempoolSpaceFeeSource is an implementation of the WebAPIFeeSource that
+// queries the `fees/recommended` endpoint of a mempool.space-style API. The
+// endpoint returns a small set of named fee rates in sat/vbyte which are mapped
+// to block targets as follows:
+//
+//   - fastestFee:  1 block
+//   - halfHourFee: 3 blocks
+//   - hourFee:     6 blocks
+//   - economyFee:  144 blocks
+//   - minimumFee:  maxBlockTarget
+type MempoolSpaceFeeSource struct {
+	// URL is the fee recommendation endpoint of the API.
+	URL string
+}
+
+// GenQueryURL generates the full query URL. The value returned by this
+// method should be able to be used directly as a path for an HTTP GET
+// request.
+//
+// NOTE: Part of the WebAPIFeeSource interface.
+func (s MempoolSpaceFeeSource) GenQueryURL() string {
+	return s.URL
+}
+
+// ParseResponse attempts to parse the body of the response generated by the
+// above query URL. The returned map holds fee rates in sat/kvbyte keyed by
+// block target.
+//
+// NOTE: Part of the WebAPIFeeSource interface.
+func (s MempoolSpaceFeeSource) ParseResponse(r io.Reader) (map[uint32]uint32,
+	error) {
+
+	type jsonResp struct {
+		FastestFee  uint32 `json:"fastestFee"`
+		HalfHourFee uint32 `json:"halfHourFee"`
+		HourFee     uint32 `json:"hourFee"`
+		EconomyFee  uint32 `json:"economyFee"`
+		MinimumFee  uint32 `json:"minimumFee"`
+	}
+
+	var resp jsonResp
+	jsonReader := json.NewDecoder(r)
+	if err := jsonReader.Decode(&resp); err != nil {
+		return nil, err
+	}
+
+	// The API returns fee rates in sat/vbyte, while the estimator expects
+	// them in sat/kvbyte. Targets without a fee rate are left out so that
+	// the estimator falls back to the closest cached target.
+	fees := make(map[uint32]uint32)
+	addFee := func(target, satPerVByte uint32) {
+		if satPerVByte == 0 {
+			return
+		}
+		fees[target] = satPerVByte * 1000
+	}
+	addFee(1, resp.FastestFee)
+	addFee(3, resp.HalfHourFee)
+	addFee(6, resp.HourFee)
+	addFee(144, resp.EconomyFee)
+	addFee(maxBlockTarget, resp.MinimumFee)
+
+	if len(fees) == 0 {
+		return nil, errors.New("no fee rates found in response")
+	}
+
+	return fees, nil
+}
+
+// A compile-time assertion to ensure that MempoolSpaceFeeSource implements the
+// WebAPIFeeSource interface.
+var _ WebAPIFeeSource = (*MempoolSpaceFeeSource)(nil)
+
+// NewWebAPIFeeSource returns the WebAPIFeeSource matching the given URL. URLs
+// pointing at a mempool.space-style `fees/recommended` endpoint use the
+// MempoolSpaceFeeSource, all others are expected to serve the sparse conf
+// target format.
+func NewWebAPIFeeSource(url string) WebAPIFeeSource {
+	if strings.HasSuffix(strings.TrimRight(url, "/"), "/fees/recommended") {
+		return MempoolSpaceFeeSource{URL: url}
+	}
+
+	return SparseConfFeeSource{URL: url}
+}
+
 // WebAPIEstimator is an implementation of the Estimator interface that
 // queries an HTTP-based fee estimation from an existing web API.
 type WebAPIEstimator struct {
@@ -570,17 +660,26 @@ type WebAPIEstimator struct {
 	// estimates.
 	noCache bool
 
+	// fallback is an optional estimator that is queried whenever the web
+	// API has no fee rate available, e.g. because it is unreachable.
+	fallback Estimator
+
 	quit chan struct{}
 	wg   sync.WaitGroup
 }
 
-// NewWebAPIEstimator creates a new WebAPIEstimator from a given URL and a
-// fallback default fee. The fees are updated whenever a new block is mined.
-func NewWebAPIEstimator(api WebAPIFeeSource, noCache bool) *WebAPIEstimator {
+// NewWebAPIEstimator creates a new WebAPIEstimator from a given URL and an
+// optional fallback estimator. The fees are refreshed periodically at a
+// jittered interval. If the fallback estimator is non-nil, it is used whenever
+// the web API can't provide a fee rate.
+func NewWebAPIEstimator(api WebAPIFeeSource, noCache bool,
+	fallback Estimator) *WebAPIEstimator {
+
 	return &WebAPIEstimator{
 		apiSource:        api,
 		feeByBlockTarget: make(map[uint32]uint32),
 		noCache:          noCache,
+		fallback:         fallback,
 		quit:             make(chan struct{}),
 	}
 }
@@ -611,13 +710,20 @@ func (w *WebAPIEstimator) EstimateFeePerKW(numBlocks uint32) (
 	// instead.
 	if err != nil {
 		log.Errorf("Unable to query estimator: %v", err)
+
+		if w.fallback != nil {
+			log.Debugf("Web API falling back to backend estimator "+
+				"for conf target of %v", numBlocks)
+
+			return w.fallback.EstimateFeePerKW(numBlocks)
+		}
 	}
 
 	// If the result is too low, then we'll clamp it to our current fee
 	// floor.
 	satPerKw := SatPerKVByte(feePerKb).FeePerKWeight()
-	if satPerKw < FeePerKwFloor {
-		satPerKw = FeePerKwFloor
+	if satPerKw < FeeFloor() {
+		satPerKw = FeeFloor()
 	}
 
 	log.Debugf("Web API returning %v sat/kw for conf target of %v",
@@ -631,13 +737,19 @@ func (w *WebAPIEstimator) EstimateFeePerKW(numBlocks uint32) (
 //
 // NOTE: This method is part of the Estimator interface.
 func (w *WebAPIEstimator) Start() error {
-	// No update loop is needed when we don't cache.
-	if w.noCache {
-		return nil
-	}
-
 	var err error
 	w.started.Do(func() {
+		if w.fallback != nil {
+			if err = w.fallback.Start(); err != nil {
+				return
+			}
+		}
+
+		// No update loop is needed when we don't cache.
+		if w.noCache {
+			return
+		}
+
 		log.Infof("Starting web API fee estimator")
 
 		w.updateFeeTicker = time.NewTicker(w.randomFeeUpdateTimeout())
@@ -655,12 +767,17 @@ func (w *WebAPIEstimator) Start() error {
 //
 // NOTE: This method is part of the Estimator interface.
 func (w *WebAPIEstimator) Stop() error {
-	// Update loop is not running when we don't cache.
-	if w.noCache {
-		return nil
-	}
-
+	var err error
 	w.stopped.Do(func() {
+		if w.fallback != nil {
+			err = w.fallback.Stop()
+		}
+
+		// Update loop is not running when we don't cache.
+		if w.noCache {
+			return
+		}
+
 		log.Infof("Stopping web API fee estimator")
 
 		w.updateFeeTicker.Stop()
@@ -668,15 +785,20 @@ func (w *WebAPIEstimator) Stop() error {
 		close(w.quit)
 		w.wg.Wait()
 	})
-	return nil
+	return err
 }
 
 // RelayFeePerKW returns the minimum fee rate required for transactions to be
-// relayed.
+// relayed. If a fallback estimator is configured, its relay fee is used as it
+// reflects the policy of our chain backend.
 //
 // NOTE: This method is part of the Estimator interface.
 func (w *WebAPIEstimator) RelayFeePerKW() SatPerKWeight {
-	return FeePerKwFloor
+	if w.fallback != nil {
+		return w.fallback.RelayFeePerKW()
+	}
+
+	return FeeFloor()
 }
 
 // randomFeeUpdateTimeout returns a random timeout between minFeeUpdateTimeout
@@ -697,14 +819,30 @@ func (w *WebAPIEstimator) getCachedFee(numBlocks uint32) (uint32, error) {
 	w.feesMtx.Lock()
 	defer w.feesMtx.Unlock()
 
+	fee, err := feeForTarget(w.feeByBlockTarget, numBlocks)
+	if err != nil {
+		return 0, fmt.Errorf("web API error: %w", err)
+	}
+
+	return fee, nil
+}
+
+// feeForTarget looks up the fee rate for the given conf target in a map of
+// fee rates keyed by conf target. When the fee rate cannot be found, it will
+// search the map by decrementing the conf target until a fee rate is found. If
+// still not found, it will return the fee rate of the minimum conf target in
+// the map, in other words, the most expensive fee rate it knows of.
+func feeForTarget(feeByBlockTarget map[uint32]uint32,
+	numBlocks uint32) (uint32, error) {
+
 	// If the cache is empty, return an error.
-	if len(w.feeByBlockTarget) == 0 {
-		return 0, fmt.Errorf("web API error: %w", errEmptyCache)
+	if len(feeByBlockTarget) == 0 {
+		return 0, errEmptyCache
 	}
 
-	// Search the conf target from the cache. We expect a query to the web
-	// API has been made and the result has been cached at this point.
-	fee, ok := w.feeByBlockTarget[numBlocks]
+	// Search the conf target from the cache. We expect a query to the fee
+	// source has been made and the result has been cached at this point.
+	fee, ok := feeByBlockTarget[numBlocks]
 
 	// If the conf target can be found, exit early.
 	if ok {
@@ -715,18 +853,18 @@ func (w *WebAPIEstimator) getCachedFee(numBlocks uint32) (uint32, error) {
 	// using a lower conf target. This is a conservative approach as the
 	// fee rate returned will be larger than what's requested.
 	for target := numBlocks; target >= minBlockTarget; target-- {
-		fee, ok := w.feeByBlockTarget[target]
+		fee, ok := feeByBlockTarget[target]
 		if !ok {
 			continue
 		}
 
-		log.Warnf("Web API does not have a fee rate for target=%d, "+
-			"using the fee rate for target=%d instead",
+		log.Warnf("Fee source does not have a fee rate for "+
+			"target=%d, using the fee rate for target=%d instead",
 			numBlocks, target)
 
 		// Return the fee rate found, which will be more expensive than
 		// requested. We will not cache the fee rate here in the hope
-		// that the web API will later populate this value.
+		// that the fee source will later populate this value.
 		return fee, nil
 	}
 
@@ -738,22 +876,22 @@ func (w *WebAPIEstimator) getCachedFee(numBlocks uint32) (uint32, error) {
 	// than the minimum conf target cached, so we return the minimum conf
 	// target from the cache.
 	minTargetCached := uint32(math.MaxUint32)
-	for target := range w.feeByBlockTarget {
+	for target := range feeByBlockTarget {
 		if target < minTargetCached {
 			minTargetCached = target
 		}
 	}
 
-	fee, ok = w.feeByBlockTarget[minTargetCached]
+	fee, ok = feeByBlockTarget[minTargetCached]
 	if !ok {
 		// We should never get here, just a vanity check.
-		return 0, fmt.Errorf("web API error: %w, conf target: %d",
+		return 0, fmt.Errorf("%w, conf target: %d",
 			errNoFeeRateFound, numBlocks)
 	}
 
 	// Log an error instead of a warning as a cheaper fee rate may delay
 	// the confirmation for some important transactions.
-	log.Errorf("Web API does not have a fee rate for target=%d, "+
+	log.Errorf("Fee source does not have a fee rate for target=%d, "+
 		"using the fee rate for target=%d instead",
 		numBlocks, minTargetCached)
 
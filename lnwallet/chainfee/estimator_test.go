@@ -151,6 +151,82 @@ func TestSparseConfFeeSource(t *testing.T) {
 	}
 }
 
+// TestMempoolSpaceFeeSource checks that MempoolSpaceFeeSource parses API
+// responses as expected.
+func TestMempoolSpaceFeeSource(t *testing.T) {
+	t.Parallel()
+
+	feeSource := MempoolSpaceFeeSource{URL: DefaultMempoolSpaceURL}
+	require.Equal(t, DefaultMempoolSpaceURL, feeSource.GenQueryURL())
+
+	// Parse a response with all fields set, except the economy fee.
+	resp := `{"fastestFee":20,"halfHourFee":10,"hourFee":5,` +
+		`"economyFee":0,"minimumFee":1}`
+	fees, err := feeSource.ParseResponse(bytes.NewReader([]byte(resp)))
+	require.NoError(t, err)
+	require.Equal(t, map[uint32]uint32{
+		1:              20000,
+		3:              10000,
+		6:              5000,
+		maxBlockTarget: 1000,
+	}, fees)
+
+	// A response without any fee rate is rejected.
+	_, err = feeSource.ParseResponse(bytes.NewReader([]byte(`{}`)))
+	require.Error(t, err)
+
+	// Malformed JSON is rejected.
+	_, err = feeSource.ParseResponse(bytes.NewReader([]byte(`{`)))
+	require.Error(t, err)
+}
+
+// TestNewWebAPIFeeSource checks that the fee source is selected based on the
+// URL.
+func TestNewWebAPIFeeSource(t *testing.T) {
+	t.Parallel()
+
+	require.IsType(
+		t, MempoolSpaceFeeSource{},
+		NewWebAPIFeeSource(DefaultMempoolSpaceURL),
+	)
+	require.IsType(
+		t, MempoolSpaceFeeSource{},
+		NewWebAPIFeeSource("https://mempool.space/api/v1/fees/recommended/"),
+	)
+	require.IsType(
+		t, SparseConfFeeSource{},
+		NewWebAPIFeeSource("https://nodes.lightning.computer/fees/v1/ltc"),
+	)
+}
+
+// TestWebAPIFeeEstimatorFallback checks that the fallback estimator is used
+// when the web API has no fee rates available.
+func TestWebAPIFeeEstimatorFallback(t *testing.T) {
+	t.Parallel()
+
+	const (
+		fallbackFee SatPerKWeight = 5000
+		relayFee    SatPerKWeight = 1000
+	)
+
+	fallback := NewStaticEstimator(fallbackFee, relayFee)
+	estimator := NewWebAPIEstimator(nil, false, fallback)
+
+	// With an empty cache the fallback's fee rate is returned.
+	feeRate, err := estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, fallbackFee, feeRate)
+
+	// The relay fee is taken from the fallback as well.
+	require.Equal(t, relayFee, estimator.RelayFeePerKW())
+
+	// Once fee rates are cached, they take precedence.
+	estimator.feeByBlockTarget = map[uint32]uint32{6: 2000}
+	feeRate, err = estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, SatPerKVByte(2000).FeePerKWeight(), feeRate)
+}
+
 // TestWebAPIFeeEstimator checks that the WebAPIFeeEstimator returns fee rates
 // as expected.
 func TestWebAPIFeeEstimator(t *testing.T) {
@@ -221,7 +297,7 @@ func TestWebAPIFeeEstimator(t *testing.T) {
 		fees: feeRateResp,
 	}
 
-	estimator := NewWebAPIEstimator(feeSource, false)
+	estimator := NewWebAPIEstimator(feeSource, false, nil)
 
 	// Test that requesting a fee when no fees have been cached won't fail.
 	feeRate, err := estimator.EstimateFeePerKW(5)
@@ -269,7 +345,7 @@ func TestGetCachedFee(t *testing.T) {
 	)
 
 	// Create a dummy estimator without WebAPIFeeSource.
-	estimator := NewWebAPIEstimator(nil, false)
+	estimator := NewWebAPIEstimator(nil, false, nil)
 
 	// When the cache is empty, an error should be returned.
 	cachedFee, err := estimator.getCachedFee(minTarget)
@@ -0,0 +1,302 @@
+package chainfee
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// FileURLPrefix is the URL scheme prefix that selects the
+	// FileEstimator when passed as fee URL.
+	FileURLPrefix = "file://"
+
+	// defaultFileCheckInterval is the interval in which the FileEstimator
+	// checks its file for changes.
+	defaultFileCheckInterval = 10 * time.Second
+)
+
+// FileEstimator is an implementation of the Estimator interface that reads
+// fee rates from a local JSON or YAML file. A JSON file is expected to be in
+// the same format as served by a SparseConfFeeSource:
+//
+//	{"fee_by_block_target": {"1": 50000, "6": 20000, "144": 1000}}
+//
+// where the values are fee rates in sat/kvbyte. Files with a .yaml or .yml
+// extension are parsed as YAML, using the same structure:
+//
+//	fee_by_block_target:
+//	  1: 50000
+//	  6: 20000
+//	  144: 1000
+//
+// The file is watched for changes and reloaded whenever it is modified, which
+// allows operators to drive fee rates from external tooling.
+type FileEstimator struct {
+	started sync.Once
+	stopped sync.Once
+
+	// path is the path of the file fee rates are read from.
+	path string
+
+	// fallback is an optional estimator that is queried whenever the file
+	// doesn't provide a fee rate.
+	fallback Estimator
+
+	// checkInterval is the interval in which the file is checked for
+	// changes.
+	checkInterval time.Duration
+
+	// feesMtx guards the fields below.
+	feesMtx sync.Mutex
+
+	// feeByBlockTarget holds the fee rates read from the file.
+	feeByBlockTarget map[uint32]uint32
+
+	// modTime and size are the modification time and size of the file
+	// when it was last read.
+	modTime time.Time
+	size    int64
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewFileEstimator creates a new FileEstimator that reads its fee rates from
+// the given path. If the fallback estimator is non-nil, it is used whenever the
+// file can't provide a fee rate.
+func NewFileEstimator(path string, fallback Estimator) *FileEstimator {
+	return &FileEstimator{
+		path:             path,
+		fallback:         fallback,
+		checkInterval:    defaultFileCheckInterval,
+		feeByBlockTarget: make(map[uint32]uint32),
+		quit:             make(chan struct{}),
+	}
+}
+
+// Start signals the Estimator to start any processes or goroutines it needs
+// to perform its duty.
+//
+// NOTE: This method is part of the Estimator interface.
+func (f *FileEstimator) Start() error {
+	var err error
+	f.started.Do(func() {
+		log.Infof("Starting file fee estimator using %v", f.path)
+
+		if f.fallback != nil {
+			if err = f.fallback.Start(); err != nil {
+				return
+			}
+		}
+
+		// The file must be readable on startup, otherwise we assume
+		// a configuration error. As the estimator won't be used, the
+		// fallback is stopped again.
+		if err = f.reload(); err != nil {
+			err = fmt.Errorf("unable to read fee file: %w", err)
+
+			if f.fallback == nil {
+				return
+			}
+
+			if stopErr := f.fallback.Stop(); stopErr != nil {
+				log.Errorf("Unable to stop fallback fee "+
+					"estimator: %v", stopErr)
+			}
+
+			return
+		}
+
+		f.wg.Add(1)
+		go f.watchFile()
+	})
+
+	return err
+}
+
+// Stop stops any spawned goroutines and cleans up the resources used by the
+// fee estimator.
+//
+// NOTE: This method is part of the Estimator interface.
+func (f *FileEstimator) Stop() error {
+	var err error
+	f.stopped.Do(func() {
+		log.Infof("Stopping file fee estimator")
+
+		close(f.quit)
+		f.wg.Wait()
+
+		if f.fallback != nil {
+			err = f.fallback.Stop()
+		}
+	})
+
+	return err
+}
+
+// EstimateFeePerKW takes in a target for the number of blocks until an initial
+// confirmation and returns the estimated fee expressed in sat/kw.
+//
+// NOTE: This method is part of the Estimator interface.
+func (f *FileEstimator) EstimateFeePerKW(numBlocks uint32) (SatPerKWeight,
+	error) {
+
+	if numBlocks > maxBlockTarget {
+		numBlocks = maxBlockTarget
+	} else if numBlocks < minBlockTarget {
+		return 0, fmt.Errorf("conf target of %v is too low, minimum "+
+			"accepted is %v", numBlocks, minBlockTarget)
+	}
+
+	f.feesMtx.Lock()
+	feePerKb, err := feeForTarget(f.feeByBlockTarget, numBlocks)
+	f.feesMtx.Unlock()
+
+	if err != nil {
+		log.Errorf("Unable to query fee file: %v", err)
+
+		if f.fallback != nil {
+			return f.fallback.EstimateFeePerKW(numBlocks)
+		}
+	}
+
+	// If the result is too low, then we'll clamp it to our current fee
+	// floor.
+	satPerKw := SatPerKVByte(feePerKb).FeePerKWeight()
+	if satPerKw < FeeFloor() {
+		satPerKw = FeeFloor()
+	}
+
+	log.Debugf("Fee file returning %v sat/kw for conf target of %v",
+		int64(satPerKw), numBlocks)
+
+	return satPerKw, nil
+}
+
+// RelayFeePerKW returns the minimum fee rate required for transactions to be
+// relayed.
+//
+// NOTE: This method is part of the Estimator interface.
+func (f *FileEstimator) RelayFeePerKW() SatPerKWeight {
+	if f.fallback != nil {
+		return f.fallback.RelayFeePerKW()
+	}
+
+	return FeeFloor()
+}
+
+// reload reads the fee file and replaces the cached fee rates. If the file
+// can't be parsed, the previous fee rates are kept.
+func (f *FileEstimator) reload() error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	fees, err := parseFeeFile(f.path, file)
+	if err != nil {
+		return err
+	}
+
+	f.feesMtx.Lock()
+	f.feeByBlockTarget = fees
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	f.feesMtx.Unlock()
+
+	log.Debugf("Loaded %d fee rates from %v", len(fees), f.path)
+
+	return nil
+}
+
+// yamlFeeFile is the structure of a YAML fee file.
+type yamlFeeFile struct {
+	FeeByBlockTarget map[uint32]uint32 `yaml:"fee_by_block_target"`
+}
+
+// parseFeeFile parses the fee rates of the fee file at the given path. Files
+// with a .yaml or .yml extension are parsed as YAML, all others as JSON.
+func parseFeeFile(path string, r io.Reader) (map[uint32]uint32, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var resp yamlFeeFile
+		if err := yaml.NewDecoder(r).Decode(&resp); err != nil {
+			return nil, err
+		}
+
+		if resp.FeeByBlockTarget == nil {
+			resp.FeeByBlockTarget = make(map[uint32]uint32)
+		}
+
+		return resp.FeeByBlockTarget, nil
+
+	default:
+		return SparseConfFeeSource{}.ParseResponse(r)
+	}
+}
+
+// changed returns true if the fee file was modified since it was last read.
+func (f *FileEstimator) changed() (bool, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return false, err
+	}
+
+	f.feesMtx.Lock()
+	defer f.feesMtx.Unlock()
+
+	return !info.ModTime().Equal(f.modTime) || info.Size() != f.size, nil
+}
+
+// watchFile periodically checks the fee file for modifications and reloads it
+// when it changed.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *FileEstimator) watchFile() {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(f.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			changed, err := f.changed()
+			if err != nil {
+				log.Errorf("Unable to stat fee file: %v", err)
+				continue
+			}
+
+			if !changed {
+				continue
+			}
+
+			log.Infof("Fee file %v changed, reloading", f.path)
+
+			if err := f.reload(); err != nil {
+				log.Errorf("Unable to reload fee file, keeping "+
+					"previous fee rates: %v", err)
+			}
+
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// A compile-time assertion to ensure that FileEstimator implements the
+// Estimator interface.
+var _ Estimator = (*FileEstimator)(nil)
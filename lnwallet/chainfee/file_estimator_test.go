@@ -0,0 +1,161 @@
+package chainfee
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeFeeFile writes the given content to the fee file and bumps its
+// modification time so that the change is detected.
+func writeFeeFile(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// TestFileEstimator checks that the FileEstimator returns the fee rates from
+// its file and picks up changes to it.
+func TestFileEstimator(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "fees.json")
+	now := time.Now()
+	writeFeeFile(
+		t, path, `{"fee_by_block_target": {"2": 4000, "6": 2000}}`, now,
+	)
+
+	estimator := NewFileEstimator(path, nil)
+	estimator.checkInterval = 10 * time.Millisecond
+
+	require.NoError(t, estimator.Start())
+	t.Cleanup(func() {
+		require.NoError(t, estimator.Stop())
+	})
+
+	feeRate, err := estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, SatPerKVByte(2000).FeePerKWeight(), feeRate)
+
+	feeRate, err = estimator.EstimateFeePerKW(3)
+	require.NoError(t, err)
+	require.Equal(t, SatPerKVByte(4000).FeePerKWeight(), feeRate)
+
+	_, err = estimator.EstimateFeePerKW(0)
+	require.Error(t, err)
+
+	// Update the file, the new fee rate should be picked up.
+	writeFeeFile(
+		t, path, `{"fee_by_block_target": {"6": 8000}}`,
+		now.Add(time.Second),
+	)
+	require.Eventually(t, func() bool {
+		feeRate, err := estimator.EstimateFeePerKW(6)
+		return err == nil &&
+			feeRate == SatPerKVByte(8000).FeePerKWeight()
+	}, time.Second, 10*time.Millisecond)
+
+	// Writing an invalid file keeps the previous fee rates.
+	writeFeeFile(t, path, `{"fee_by_block_target": `, now.Add(2*time.Second))
+	time.Sleep(50 * time.Millisecond)
+
+	feeRate, err = estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, SatPerKVByte(8000).FeePerKWeight(), feeRate)
+}
+
+// TestFileEstimatorStartErrors checks that the FileEstimator fails to start
+// if the fee file can't be read.
+func TestFileEstimatorStartErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	estimator := NewFileEstimator(filepath.Join(dir, "missing.json"), nil)
+	require.Error(t, estimator.Start())
+
+	path := filepath.Join(dir, "invalid.json")
+	writeFeeFile(t, path, `not json`, time.Now())
+	estimator = NewFileEstimator(path, nil)
+	require.Error(t, estimator.Start())
+}
+
+// TestFileEstimatorFallback checks that the fallback estimator is used when
+// the file has no fee rates.
+func TestFileEstimatorFallback(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "fees.json")
+	writeFeeFile(t, path, `{"fee_by_block_target": {}}`, time.Now())
+
+	fallback := NewStaticEstimator(5000, 1000)
+	estimator := NewFileEstimator(path, fallback)
+	require.NoError(t, estimator.Start())
+	t.Cleanup(func() {
+		require.NoError(t, estimator.Stop())
+	})
+
+	feeRate, err := estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, SatPerKWeight(5000), feeRate)
+	require.Equal(t, SatPerKWeight(1000), estimator.RelayFeePerKW())
+}
+
+// TestFileEstimatorYAML checks that the FileEstimator reads its fee rates from
+// a YAML file.
+func TestFileEstimatorYAML(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "fees.yaml")
+	writeFeeFile(
+		t, path, "fee_by_block_target:\n  2: 4000\n  6: 2000\n",
+		time.Now(),
+	)
+
+	estimator := NewFileEstimator(path, nil)
+	require.NoError(t, estimator.Start())
+	t.Cleanup(func() {
+		require.NoError(t, estimator.Stop())
+	})
+
+	feeRate, err := estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, SatPerKVByte(2000).FeePerKWeight(), feeRate)
+
+	feeRate, err = estimator.EstimateFeePerKW(3)
+	require.NoError(t, err)
+	require.Equal(t, SatPerKVByte(4000).FeePerKWeight(), feeRate)
+}
+
+// stopTrackingEstimator is an Estimator that records whether it was stopped.
+type stopTrackingEstimator struct {
+	*StaticEstimator
+
+	stopped bool
+}
+
+// Stop marks the estimator as stopped.
+func (s *stopTrackingEstimator) Stop() error {
+	s.stopped = true
+
+	return s.StaticEstimator.Stop()
+}
+
+// TestFileEstimatorStartStopsFallback checks that the fallback estimator is
+// stopped again if the FileEstimator fails to start.
+func TestFileEstimatorStartStopsFallback(t *testing.T) {
+	t.Parallel()
+
+	fallback := &stopTrackingEstimator{
+		StaticEstimator: NewStaticEstimator(5000, 1000),
+	}
+	estimator := NewFileEstimator(
+		filepath.Join(t.TempDir(), "missing.json"), fallback,
+	)
+	require.Error(t, estimator.Start())
+	require.True(t, fallback.stopped)
+}
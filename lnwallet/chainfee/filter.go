@@ -0,0 +1,89 @@
+package chainfee
+
+// Filter wraps an Estimator and clamps its fee estimates to a configured
+// range. Different consumers of fee estimates (the sweeper, commitment fee
+// updates and cooperative closes) can each be given their own Filter so that
+// operators can bound the fee rates used for each of them independently.
+//
+// A Filter doesn't own the estimator it wraps, so starting or stopping it
+// has no effect on the wrapped estimator.
+type Filter struct {
+	Estimator
+
+	// minFeeRate is the lowest fee rate returned by the filter. A value
+	// of zero means no lower bound is applied.
+	minFeeRate SatPerKWeight
+
+	// maxFeeRate is the highest fee rate returned by the filter. A value
+	// of zero means no upper bound is applied.
+	maxFeeRate SatPerKWeight
+}
+
+// NewFilter creates a new Filter that clamps the estimates of the given
+// estimator to [minFeeRate, maxFeeRate]. A zero bound is ignored.
+func NewFilter(estimator Estimator, minFeeRate,
+	maxFeeRate SatPerKWeight) *Filter {
+
+	return &Filter{
+		Estimator:  estimator,
+		minFeeRate: minFeeRate,
+		maxFeeRate: maxFeeRate,
+	}
+}
+
+// Start is a no-op as the wrapped estimator is started by its owner.
+//
+// NOTE: This method is part of the Estimator interface.
+func (f *Filter) Start() error {
+	return nil
+}
+
+// Stop is a no-op as the wrapped estimator is stopped by its owner.
+//
+// NOTE: This method is part of the Estimator interface.
+func (f *Filter) Stop() error {
+	return nil
+}
+
+// EstimateFeePerKW takes in a target for the number of blocks until an initial
+// confirmation and returns the estimate of the wrapped estimator clamped to
+// the filter's range.
+//
+// NOTE: This method is part of the Estimator interface.
+func (f *Filter) EstimateFeePerKW(numBlocks uint32) (SatPerKWeight, error) {
+	feeRate, err := f.Estimator.EstimateFeePerKW(numBlocks)
+	if err != nil {
+		return 0, err
+	}
+
+	return f.Clamp(feeRate), nil
+}
+
+// Clamp bounds the given fee rate to the filter's range. The upper bound
+// takes precedence, but the result is never below the relay fee of the
+// wrapped estimator.
+func (f *Filter) Clamp(feeRate SatPerKWeight) SatPerKWeight {
+	clamped := feeRate
+	if f.minFeeRate != 0 && clamped < f.minFeeRate {
+		clamped = f.minFeeRate
+	}
+	if f.maxFeeRate != 0 && clamped > f.maxFeeRate {
+		clamped = f.maxFeeRate
+	}
+
+	// A fee rate below the relay fee would prevent the transaction from
+	// propagating, so we never go below it.
+	if relayFee := f.RelayFeePerKW(); clamped < relayFee {
+		clamped = relayFee
+	}
+
+	if clamped != feeRate {
+		log.Debugf("Clamped fee rate %v to %v", feeRate, clamped)
+	}
+
+	return clamped
+}
+
+// A compile-time assertion to ensure that Filter implements the Estimator
+// interface.
+var _ Estimator = (*Filter)(nil)
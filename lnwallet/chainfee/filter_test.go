@@ -0,0 +1,81 @@
+package chainfee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFilter checks that the Filter clamps fee estimates to its range.
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		estimate SatPerKWeight
+		relayFee SatPerKWeight
+		min      SatPerKWeight
+		max      SatPerKWeight
+		expected SatPerKWeight
+	}{
+		{
+			name:     "unbounded",
+			estimate: 5000,
+			relayFee: FeePerKwFloor,
+			expected: 5000,
+		},
+		{
+			name:     "within range",
+			estimate: 5000,
+			relayFee: FeePerKwFloor,
+			min:      1000,
+			max:      10000,
+			expected: 5000,
+		},
+		{
+			name:     "below min",
+			estimate: 500,
+			relayFee: FeePerKwFloor,
+			min:      1000,
+			expected: 1000,
+		},
+		{
+			name:     "above max",
+			estimate: 50000,
+			relayFee: FeePerKwFloor,
+			max:      10000,
+			expected: 10000,
+		},
+		{
+			name:     "max wins over min",
+			estimate: 500,
+			relayFee: FeePerKwFloor,
+			min:      2000,
+			max:      1000,
+			expected: 1000,
+		},
+		{
+			name:     "max below relay fee",
+			estimate: 5000,
+			relayFee: 2000,
+			max:      1000,
+			expected: 2000,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter := NewFilter(
+				NewStaticEstimator(tc.estimate, tc.relayFee),
+				tc.min, tc.max,
+			)
+
+			feeRate, err := filter.EstimateFeePerKW(6)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, feeRate)
+		})
+	}
+}
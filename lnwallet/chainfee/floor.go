@@ -0,0 +1,83 @@
+package chainfee
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// FloorProfile holds the fee rate floors used for a particular chain. Chains
+// with cheaper relay policies can use lower floors than the defaults.
+type FloorProfile struct {
+	// FeePerKwFloor is the lowest fee rate in sat/kw that we should use
+	// for estimating transaction fees before signing.
+	FeePerKwFloor SatPerKWeight
+
+	// AbsoluteFeePerKwFloor is the lowest fee rate in sat/kw of a
+	// transaction that we should ever _create_.
+	AbsoluteFeePerKwFloor SatPerKWeight
+}
+
+// DefaultFloorProfile is the floor profile matching the default relay policy
+// of 1 sat/vbyte.
+var DefaultFloorProfile = FloorProfile{
+	FeePerKwFloor:         FeePerKwFloor,
+	AbsoluteFeePerKwFloor: AbsoluteFeePerKwFloor,
+}
+
+// Validate checks that the floors of the profile are sane.
+func (p FloorProfile) Validate() error {
+	if p.AbsoluteFeePerKwFloor == 0 {
+		return fmt.Errorf("absolute fee floor must be positive")
+	}
+
+	if p.AbsoluteFeePerKwFloor > p.FeePerKwFloor {
+		return fmt.Errorf("absolute fee floor %v must not be greater "+
+			"than fee floor %v", p.AbsoluteFeePerKwFloor,
+			p.FeePerKwFloor)
+	}
+
+	return nil
+}
+
+// activeFloorProfile is the floor profile of the active chain.
+var activeFloorProfile atomic.Pointer[FloorProfile]
+
+// SetFloorProfile sets the floor profile of the active chain. It is meant to
+// be called once at startup, before any fee rates are estimated.
+//
+// NOTE: This function is safe for concurrent access.
+func SetFloorProfile(profile FloorProfile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+
+	activeFloorProfile.Store(&profile)
+
+	return nil
+}
+
+// activeProfile returns the floor profile of the active chain, or the default
+// one if none was set.
+func activeProfile() FloorProfile {
+	if profile := activeFloorProfile.Load(); profile != nil {
+		return *profile
+	}
+
+	return DefaultFloorProfile
+}
+
+// FeeFloor returns the lowest fee rate in sat/kw that we should use for
+// estimating transaction fees on the active chain.
+//
+// NOTE: This function is safe for concurrent access.
+func FeeFloor() SatPerKWeight {
+	return activeProfile().FeePerKwFloor
+}
+
+// AbsoluteFeeFloor returns the lowest fee rate in sat/kw of a transaction
+// that we should ever create on the active chain.
+//
+// NOTE: This function is safe for concurrent access.
+func AbsoluteFeeFloor() SatPerKWeight {
+	return activeProfile().AbsoluteFeePerKwFloor
+}
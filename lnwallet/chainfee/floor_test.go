@@ -0,0 +1,47 @@
+package chainfee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFloorProfile checks that the floor profile of the active chain is used
+// to clamp fee rates.
+//
+// NOTE: This test must not run in parallel as it modifies the global floor
+// profile.
+func TestFloorProfile(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, SetFloorProfile(DefaultFloorProfile))
+	})
+
+	// Without a profile being set, the defaults are used.
+	require.Equal(t, FeePerKwFloor, FeeFloor())
+	require.Equal(t, AbsoluteFeePerKwFloor, AbsoluteFeeFloor())
+
+	// Invalid profiles are rejected.
+	require.Error(t, SetFloorProfile(FloorProfile{FeePerKwFloor: 100}))
+	require.Error(t, SetFloorProfile(FloorProfile{
+		FeePerKwFloor:         100,
+		AbsoluteFeePerKwFloor: 200,
+	}))
+
+	lowFloor := FloorProfile{
+		FeePerKwFloor:         100,
+		AbsoluteFeePerKwFloor: 100,
+	}
+	require.NoError(t, SetFloorProfile(lowFloor))
+	require.Equal(t, SatPerKWeight(100), FeeFloor())
+	require.Equal(t, SatPerKWeight(100), AbsoluteFeeFloor())
+
+	// The backend's minimum fee is now only clamped to the lower floor.
+	feeManager, err := newMinFeeManager(time.Minute, func() (
+		SatPerKWeight, error) {
+
+		return 50, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, SatPerKWeight(100), feeManager.fetchMinFee())
+}
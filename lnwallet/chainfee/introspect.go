@@ -0,0 +1,116 @@
+package chainfee
+
+import (
+	"fmt"
+	"sort"
+)
+
+// EstimatorInfo describes an Estimator, which is useful to debug why a
+// specific fee rate was chosen.
+type EstimatorInfo struct {
+	// Source is a human readable description of where the estimator gets
+	// its fee rates from.
+	Source string
+
+	// CachedConfTargets are the conf targets the estimator currently has
+	// fee rates cached for, in ascending order. It is empty for estimators
+	// that query their backend on demand.
+	CachedConfTargets []uint32
+}
+
+// Describer is an optional interface that can be implemented by custom
+// estimators to provide information about themselves to Describe.
+type Describer interface {
+	// Describe returns information about the estimator.
+	Describe() EstimatorInfo
+}
+
+// Describe returns information about the given estimator. Estimators that
+// wrap another estimator are described together with the wrapped one.
+func Describe(estimator Estimator) EstimatorInfo {
+	switch e := estimator.(type) {
+	case Describer:
+		return e.Describe()
+
+	case *StaticEstimator, StaticEstimator:
+		return EstimatorInfo{Source: "static"}
+
+	case *BtcdEstimator:
+		return EstimatorInfo{Source: "ltcd"}
+
+	case *BitcoindEstimator:
+		return EstimatorInfo{
+			Source: fmt.Sprintf("litecoind (%v)", e.feeMode),
+		}
+
+	case *WebAPIEstimator:
+		e.feesMtx.Lock()
+		targets := sortedTargets(e.feeByBlockTarget)
+		e.feesMtx.Unlock()
+
+		return EstimatorInfo{
+			Source: withFallback(
+				fmt.Sprintf("web API (%v)", e.apiSource.GenQueryURL()),
+				e.fallback,
+			),
+			CachedConfTargets: targets,
+		}
+
+	case *FileEstimator:
+		e.feesMtx.Lock()
+		targets := sortedTargets(e.feeByBlockTarget)
+		e.feesMtx.Unlock()
+
+		return EstimatorInfo{
+			Source: withFallback(
+				fmt.Sprintf("file (%v)", e.path), e.fallback,
+			),
+			CachedConfTargets: targets,
+		}
+
+	case *SmoothingEstimator:
+		info := Describe(e.Estimator)
+		info.Source = fmt.Sprintf("%v, smoothed over %v", info.Source,
+			e.window)
+
+		return info
+
+	case *Filter:
+		info := Describe(e.Estimator)
+		info.Source = fmt.Sprintf("%v, clamped to [%v, %v]",
+			info.Source, e.minFeeRate, e.maxFeeRate)
+
+		return info
+
+	case *DeadlineEstimator:
+		return Describe(e.Estimator)
+
+	default:
+		return EstimatorInfo{Source: fmt.Sprintf("%T", estimator)}
+	}
+}
+
+// withFallback appends the description of the fallback estimator, if any, to
+// the given source.
+func withFallback(source string, fallback Estimator) string {
+	if fallback == nil {
+		return source
+	}
+
+	return fmt.Sprintf("%v with fallback to %v", source,
+		Describe(fallback).Source)
+}
+
+// sortedTargets returns the conf targets of the given fee map in ascending
+// order.
+func sortedTargets(feeByBlockTarget map[uint32]uint32) []uint32 {
+	targets := make([]uint32, 0, len(feeByBlockTarget))
+	for target := range feeByBlockTarget {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i] < targets[j]
+	})
+
+	return targets
+}
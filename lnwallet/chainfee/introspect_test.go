@@ -0,0 +1,44 @@
+package chainfee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDescribe checks that estimators and their wrappers are described
+// correctly.
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	static := NewStaticEstimator(FeePerKwFloor, FeePerKwFloor)
+	require.Equal(t, EstimatorInfo{Source: "static"}, Describe(static))
+
+	webEstimator := NewWebAPIEstimator(
+		SparseConfFeeSource{URL: "https://fees.example"}, false, static,
+	)
+	webEstimator.feeByBlockTarget = map[uint32]uint32{
+		144: 1000,
+		2:   20000,
+		6:   5000,
+	}
+
+	info := Describe(webEstimator)
+	require.Equal(t, "web API (https://fees.example) with fallback to "+
+		"static", info.Source)
+	require.Equal(t, []uint32{2, 6, 144}, info.CachedConfTargets)
+
+	// Wrappers keep the cached conf targets of the wrapped estimator.
+	smoothed := NewSmoothingEstimator(
+		webEstimator, time.Minute, clock.NewDefaultClock(),
+	)
+	filter := NewFilter(smoothed, 0, 10000)
+
+	info = Describe(filter)
+	require.Equal(t, "web API (https://fees.example) with fallback to "+
+		"static, smoothed over 1m0s, clamped to [0 sat/kw, "+
+		"10000 sat/kw]", info.Source)
+	require.Equal(t, []uint32{2, 6, 144}, info.CachedConfTargets)
+}
@@ -34,8 +34,8 @@ func newMinFeeManager(minUpdateInterval time.Duration,
 
 	// Ensure that the minimum fee we use is always clamped by our fee
 	// floor.
-	if minFee < FeePerKwFloor {
-		minFee = FeePerKwFloor
+	if minFee < FeeFloor() {
+		minFee = FeeFloor()
 	}
 
 	return &minFeeManager{
@@ -69,12 +69,17 @@ func (m *minFeeManager) fetchMinFee() SatPerKWeight {
 	// By default, we'll use the backend node's minimum fee as the
 	// minimum fee rate we'll propose for transactions. However, if this
 	// happens to be lower than our fee floor, we'll enforce that instead.
-	m.minFeePerKW = newMinFee
-	if m.minFeePerKW < FeePerKwFloor {
-		m.minFeePerKW = FeePerKwFloor
+	if newMinFee < FeeFloor() {
+		newMinFee = FeeFloor()
 	}
 	m.lastUpdatedTime = time.Now()
 
+	if newMinFee != m.minFeePerKW {
+		log.Infof("Minimum fee rate of chain backend changed from %v "+
+			"to %v", m.minFeePerKW, newMinFee)
+	}
+	m.minFeePerKW = newMinFee
+
 	log.Debugf("Using minimum fee rate of %v sat/kw",
 		int64(m.minFeePerKW))
 
@@ -8,16 +8,43 @@ import (
 )
 
 const (
-	// FeePerKwFloor is the lowest fee rate in sat/kw that we should use for
-	// estimating transaction fees before signing.
+	// FeePerKwFloor is the default lowest fee rate in sat/kw that we should
+	// use for estimating transaction fees before signing. The floor of the
+	// active chain is returned by FeeFloor.
 	FeePerKwFloor SatPerKWeight = 253
 
-	// AbsoluteFeePerKwFloor is the lowest fee rate in sat/kw of a
+	// AbsoluteFeePerKwFloor is the default lowest fee rate in sat/kw of a
 	// transaction that we should ever _create_. This is the the equivalent
-	// of 1 sat/byte in sat/kw.
+	// of 1 sat/byte in sat/kw. The floor of the active chain is returned by
+	// AbsoluteFeeFloor.
 	AbsoluteFeePerKwFloor SatPerKWeight = 250
 )
 
+// SatPerVByte represents a fee rate in sat/vbyte.
+type SatPerVByte ltcutil.Amount
+
+// FeeForVSize calculates the fee resulting from this fee rate and the given
+// vsize in vbytes.
+func (s SatPerVByte) FeeForVSize(vbytes int64) ltcutil.Amount {
+	return ltcutil.Amount(s) * ltcutil.Amount(vbytes)
+}
+
+// FeePerKWeight converts the current fee rate from sat/vb to sat/kw. The
+// conversion is lossless as one sat/vb always equals 250 sat/kw.
+func (s SatPerVByte) FeePerKWeight() SatPerKWeight {
+	return SatPerKWeight(s * 1000 / blockchain.WitnessScaleFactor)
+}
+
+// FeePerKVByte converts the current fee rate from sat/vb to sat/kb.
+func (s SatPerVByte) FeePerKVByte() SatPerKVByte {
+	return SatPerKVByte(s * 1000)
+}
+
+// String returns a human-readable string of the fee rate.
+func (s SatPerVByte) String() string {
+	return fmt.Sprintf("%v sat/vb", int64(s))
+}
+
 // SatPerKVByte represents a fee rate in sat/kb.
 type SatPerKVByte ltcutil.Amount
 
@@ -32,6 +59,12 @@ func (s SatPerKVByte) FeePerKWeight() SatPerKWeight {
 	return SatPerKWeight(s / blockchain.WitnessScaleFactor)
 }
 
+// FeePerVByte converts the current fee rate from sat/kb to sat/vb, rounding
+// down any fraction of a sat/vb.
+func (s SatPerKVByte) FeePerVByte() SatPerVByte {
+	return SatPerVByte(s / 1000)
+}
+
 // String returns a human-readable string of the fee rate.
 func (s SatPerKVByte) String() string {
 	return fmt.Sprintf("%v sat/kb", int64(s))
@@ -52,6 +85,12 @@ func (s SatPerKWeight) FeePerKVByte() SatPerKVByte {
 	return SatPerKVByte(s * blockchain.WitnessScaleFactor)
 }
 
+// FeePerVByte converts the current fee rate from sat/kw to sat/vb. As sat/vb
+// is the coarser unit, any fraction of a sat/vb is rounded down.
+func (s SatPerKWeight) FeePerVByte() SatPerVByte {
+	return SatPerVByte(s * blockchain.WitnessScaleFactor / 1000)
+}
+
 // String returns a human-readable string of the fee rate.
 func (s SatPerKWeight) String() string {
 	return fmt.Sprintf("%v sat/kw", int64(s))
@@ -0,0 +1,31 @@
+package chainfee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSatPerVByteConversions checks the conversions between the different
+// fee rate units.
+func TestSatPerVByteConversions(t *testing.T) {
+	t.Parallel()
+
+	// Converting sat/vb to sat/kw and back is lossless.
+	for _, rate := range []SatPerVByte{0, 1, 2, 10, 1000} {
+		require.Equal(t, SatPerKWeight(rate*250), rate.FeePerKWeight())
+		require.Equal(t, rate, rate.FeePerKWeight().FeePerVByte())
+		require.Equal(t, SatPerKVByte(rate*1000), rate.FeePerKVByte())
+		require.Equal(t, rate, rate.FeePerKVByte().FeePerVByte())
+	}
+
+	// Fractions of a sat/vb are rounded down.
+	require.Equal(t, SatPerVByte(1), FeePerKwFloor.FeePerVByte())
+	require.Equal(t, SatPerVByte(1), SatPerKVByte(1999).FeePerVByte())
+
+	// The fee for a given size is the same regardless of the unit.
+	rate := SatPerVByte(7)
+	fee := rate.FeeForVSize(250)
+	require.Equal(t, fee, rate.FeePerKVByte().FeeForVSize(250))
+	require.Equal(t, fee, rate.FeePerKWeight().FeeForWeight(1000))
+}
@@ -0,0 +1,108 @@
+package chainfee
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ltcsuite/lnd/clock"
+)
+
+// smoothedRate is the smoothed fee rate of a single conf target.
+type smoothedRate struct {
+	// rate is the current moving average in sat/kw.
+	rate float64
+
+	// lastUpdate is the time the moving average was last updated.
+	lastUpdate time.Time
+}
+
+// SmoothingEstimator wraps an Estimator and smooths its fee estimates per conf
+// target using a time-weighted exponential moving average. This prevents a
+// single spike returned by the backend from immediately propagating into
+// commitment fee updates, which could otherwise trigger force closes with
+// peers that don't agree with the sudden fee rate.
+//
+// The weight of a new sample depends on the time elapsed since the previous
+// sample, so the smoothing behaves the same regardless of how often a conf
+// target is queried. After one window has passed, a new sample contributes
+// ~63% to the average.
+type SmoothingEstimator struct {
+	Estimator
+
+	// window is the time constant of the moving average.
+	window time.Duration
+
+	clock clock.Clock
+
+	mu    sync.Mutex
+	rates map[uint32]*smoothedRate
+}
+
+// NewSmoothingEstimator wraps the given estimator with a moving average using
+// the given smoothing window.
+func NewSmoothingEstimator(estimator Estimator, window time.Duration,
+	clock clock.Clock) *SmoothingEstimator {
+
+	return &SmoothingEstimator{
+		Estimator: estimator,
+		window:    window,
+		clock:     clock,
+		rates:     make(map[uint32]*smoothedRate),
+	}
+}
+
+// EstimateFeePerKW takes in a target for the number of blocks until an initial
+// confirmation and returns the smoothed fee estimate expressed in sat/kw.
+//
+// NOTE: This method is part of the Estimator interface.
+func (s *SmoothingEstimator) EstimateFeePerKW(
+	numBlocks uint32) (SatPerKWeight, error) {
+
+	sample, err := s.Estimator.EstimateFeePerKW(numBlocks)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+
+	// The first sample of a conf target is used as is.
+	current, ok := s.rates[numBlocks]
+	if !ok || s.window <= 0 {
+		s.rates[numBlocks] = &smoothedRate{
+			rate:       float64(sample),
+			lastUpdate: now,
+		}
+
+		return sample, nil
+	}
+
+	// Weigh the new sample by the time elapsed since the last one.
+	elapsed := now.Sub(current.lastUpdate)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	alpha := 1 - math.Exp(-float64(elapsed)/float64(s.window))
+
+	current.rate += alpha * (float64(sample) - current.rate)
+	current.lastUpdate = now
+
+	smoothed := SatPerKWeight(math.Round(current.rate))
+
+	// Never return a fee rate below what the backend is willing to relay.
+	if relayFee := s.RelayFeePerKW(); smoothed < relayFee {
+		smoothed = relayFee
+	}
+
+	log.Tracef("Smoothed fee rate for conf target %v: sample=%v, "+
+		"smoothed=%v", numBlocks, sample, smoothed)
+
+	return smoothed, nil
+}
+
+// A compile-time assertion to ensure that SmoothingEstimator implements the
+// Estimator interface.
+var _ Estimator = (*SmoothingEstimator)(nil)
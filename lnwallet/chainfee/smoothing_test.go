@@ -0,0 +1,93 @@
+package chainfee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/clock"
+	"github.com/stretchr/testify/require"
+)
+
+// mutableEstimator is a static estimator whose fee rate can be changed.
+type mutableEstimator struct {
+	StaticEstimator
+}
+
+func (m *mutableEstimator) EstimateFeePerKW(uint32) (SatPerKWeight, error) {
+	return m.feePerKW, nil
+}
+
+// TestSmoothingEstimator checks that fee rate spikes are smoothed over the
+// configured window.
+func TestSmoothingEstimator(t *testing.T) {
+	t.Parallel()
+
+	const window = 10 * time.Minute
+
+	backend := &mutableEstimator{
+		StaticEstimator: StaticEstimator{
+			feePerKW: 1000,
+			relayFee: FeePerKwFloor,
+		},
+	}
+	testClock := clock.NewTestClock(time.Unix(1_700_000_000, 0))
+	estimator := NewSmoothingEstimator(backend, window, testClock)
+
+	// The first sample is returned as is.
+	feeRate, err := estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, feeRate)
+
+	// A spike right after the first sample barely moves the average.
+	backend.feePerKW = 10000
+	testClock.SetTime(testClock.Now().Add(time.Second))
+	feeRate, err = estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Less(t, int64(feeRate), int64(1100))
+
+	// Other conf targets are tracked independently.
+	feeRate, err = estimator.EstimateFeePerKW(2)
+	require.NoError(t, err)
+	require.EqualValues(t, 10000, feeRate)
+
+	// If the spike persists for many windows, the average converges to
+	// it.
+	testClock.SetTime(testClock.Now().Add(10 * window))
+	feeRate, err = estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.InDelta(t, 10000, int64(feeRate), 1)
+
+	// A drop below the relay fee is clamped.
+	backend.feePerKW = 0
+	testClock.SetTime(testClock.Now().Add(10 * window))
+	feeRate, err = estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.Equal(t, FeePerKwFloor, feeRate)
+}
+
+// TestSmoothingEstimatorOneWindow checks the weight of a sample after exactly
+// one smoothing window.
+func TestSmoothingEstimatorOneWindow(t *testing.T) {
+	t.Parallel()
+
+	const window = time.Minute
+
+	backend := &mutableEstimator{
+		StaticEstimator: StaticEstimator{
+			feePerKW: 1000,
+			relayFee: FeePerKwFloor,
+		},
+	}
+	testClock := clock.NewTestClock(time.Unix(1_700_000_000, 0))
+	estimator := NewSmoothingEstimator(backend, window, testClock)
+
+	_, err := estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+
+	// After one window, the new sample contributes 1-1/e of the update.
+	backend.feePerKW = 2000
+	testClock.SetTime(testClock.Now().Add(window))
+	feeRate, err := estimator.EstimateFeePerKW(6)
+	require.NoError(t, err)
+	require.EqualValues(t, 1632, feeRate)
+}
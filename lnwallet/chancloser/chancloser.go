@@ -48,6 +48,17 @@ var (
 	// ErrInvalidShutdownScript is returned when we receive an address from
 	// a peer that isn't either a p2wsh or p2tr address.
 	ErrInvalidShutdownScript = fmt.Errorf("invalid shutdown script")
+
+	// ErrRbfCloseNotSupported is returned when the fee of a close
+	// transaction should be bumped, but the channel isn't closed with RBF
+	// based close negotiation.
+	ErrRbfCloseNotSupported = fmt.Errorf("channel isn't closed with RBF " +
+		"based close negotiation")
+
+	// ErrRbfFeeTooLow is returned when a replacement for a close
+	// transaction doesn't pay a higher fee than the current one.
+	ErrRbfFeeTooLow = fmt.Errorf("fee doesn't exceed the fee of the " +
+		"current close transaction")
 )
 
 // closeState represents all the possible states the channel closer state
@@ -128,6 +139,13 @@ type ChanCloseCfg struct {
 	// FeeEstimator is used to estimate the absolute starting co-op close
 	// fee.
 	FeeEstimator CoopFeeEstimator
+
+	// RbfClose indicates that both parties support RBF based close
+	// negotiation. Instead of negotiating a fee that the channel initiator
+	// pays, the party that proposes a close transaction pays its fee, and
+	// either party can replace the transaction with one that pays a
+	// higher fee until it confirms.
+	RbfClose bool
 }
 
 // ChanCloser is a state machine that handles the cooperative channel closure
@@ -197,6 +215,15 @@ type ChanCloser struct {
 
 	// locallyInitiated is true if we initiated the channel close.
 	locallyInitiated bool
+
+	// closingFee is the fee of the current closing transaction.
+	closingFee ltcutil.Amount
+
+	// rbfOffers tracks the close transactions we proposed with RBF based
+	// close negotiation, keyed by their fee. Once the remote party signs
+	// one of them, we use our matching signature to complete the closing
+	// transaction.
+	rbfOffers map[ltcutil.Amount]*lnwire.ClosingComplete
 }
 
 // calcCoopCloseFee computes an "ideal" absolute co-op close fee given the
@@ -264,14 +291,16 @@ func NewChanCloser(cfg ChanCloseCfg, deliveryScript []byte,
 		localDeliveryScript: deliveryScript,
 		priorFeeOffers:      make(map[ltcutil.Amount]*lnwire.ClosingSigned),
 		locallyInitiated:    locallyInitiated,
+		rbfOffers: make(
+			map[ltcutil.Amount]*lnwire.ClosingComplete,
+		),
 	}
 }
 
-// initFeeBaseline computes our ideal fee rate, and also the largest fee we'll
-// accept given information about the delivery script of the remote party.
-func (c *ChanCloser) initFeeBaseline() {
-	// Depending on if a balance ends up being dust or not, we'll pass a
-	// nil TxOut into the EstimateFee call which can handle it.
+// closeTxOuts returns the outputs of the closing transaction that are used to
+// estimate its fee. Depending on if a balance ends up being dust or not, a nil
+// TxOut is returned, which the EstimateFee call can handle.
+func (c *ChanCloser) closeTxOuts() (*wire.TxOut, *wire.TxOut) {
 	var localTxOut, remoteTxOut *wire.TxOut
 	if !c.cfg.Channel.LocalBalanceDust() {
 		localTxOut = &wire.TxOut{
@@ -286,6 +315,14 @@ func (c *ChanCloser) initFeeBaseline() {
 		}
 	}
 
+	return localTxOut, remoteTxOut
+}
+
+// initFeeBaseline computes our ideal fee rate, and also the largest fee we'll
+// accept given information about the delivery script of the remote party.
+func (c *ChanCloser) initFeeBaseline() {
+	localTxOut, remoteTxOut := c.closeTxOuts()
+
 	// Given the target fee-per-kw, we'll compute what our ideal _total_
 	// fee will be starting at for this fee negotiation.
 	c.idealFeeSat = c.cfg.FeeEstimator.EstimateFee(
@@ -421,6 +458,12 @@ func (c *ChanCloser) NegotiationHeight() uint32 {
 	return c.negotiationHeight
 }
 
+// RbfClose returns true if the channel is closed with RBF based close
+// negotiation.
+func (c *ChanCloser) RbfClose() bool {
+	return c.cfg.RbfClose
+}
+
 // validateShutdownScript attempts to match and validate the script provided in
 // our peer's shutdown message with the upfront shutdown script we have on
 // record. For any script specified, we also make sure it matches our
@@ -474,7 +517,10 @@ func validateShutdownScript(disconnect func() error, upfrontScript,
 // This method will update the state accordingly and return two primary values:
 // the next set of messages to be sent, and a bool indicating if the fee
 // negotiation process has completed. If the second value is true, then this
-// means the ChanCloser can be garbage collected.
+// means the ChanCloser can be garbage collected. With RBF based close
+// negotiation, the second value is true whenever a new closing transaction was
+// broadcast, and the ChanCloser must be kept until the channel is closed, as
+// the closing transaction may still be replaced.
 func (c *ChanCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message,
 	bool, error) {
 
@@ -564,8 +610,10 @@ func (c *ChanCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message,
 		c.state = closeFeeNegotiation
 
 		// We'll also craft our initial close proposal in order to keep the
-		// negotiation moving, but only if we're the negotiator.
-		if chanInitiator {
+		// negotiation moving, but only if we're the negotiator. With RBF
+		// based close negotiation, the remote party started the close,
+		// so they'll propose the first close transaction.
+		if chanInitiator && !c.cfg.RbfClose {
 			closeSigned, err := c.proposeCloseSigned(c.idealFeeSat)
 			if err != nil {
 				return nil, false, fmt.Errorf("unable to sign "+
@@ -630,6 +678,24 @@ func (c *ChanCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message,
 		chancloserLog.Infof("ChannelPoint(%v): shutdown response received, "+
 			"entering fee negotiation", c.chanPoint)
 
+		// With RBF based close negotiation, the party that started the
+		// close proposes the first close transaction, paying its fee.
+		if c.cfg.RbfClose {
+			if !c.locallyInitiated {
+				return nil, false, nil
+			}
+
+			closingComplete, err := c.proposeClosingComplete(
+				c.idealFeeSat,
+			)
+			if err != nil {
+				return nil, false, fmt.Errorf("unable to sign "+
+					"new co op close offer: %w", err)
+			}
+
+			return []lnwire.Message{closingComplete}, false, nil
+		}
+
 		// Starting with our ideal fee rate, we'll create an initial closing
 		// proposal, but only if we're the initiator, as otherwise, the other
 		// party will send their initial proposal first.
@@ -649,6 +715,13 @@ func (c *ChanCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message,
 	// then this indicates the remote party is responding to a close signed
 	// message we sent, or kicking off the process with their own.
 	case closeFeeNegotiation:
+		// With RBF based close negotiation, there's no negotiation of
+		// the fee, we only sign the proposals of the remote party and
+		// complete our own ones.
+		if c.cfg.RbfClose {
+			return c.processRbfCloseMsg(msg)
+		}
+
 		// First, we'll assert that we're actually getting a ClosingSigned
 		// message, otherwise an invalid state transition was attempted.
 		closeSignedMsg, ok := msg.(*lnwire.ClosingSigned)
@@ -828,6 +901,23 @@ func (c *ChanCloser) ProcessCloseMsg(msg lnwire.Message) ([]lnwire.Message,
 	// should only be the remote party echoing the last ClosingSigned message
 	// that we agreed on.
 	case closeFinished:
+		// With RBF based close negotiation, either party may replace
+		// the closing transaction with one that pays a higher fee. As
+		// the current closing transaction stays valid, an invalid
+		// replacement doesn't fail the close.
+		if c.cfg.RbfClose {
+			msgs, closeFin, err := c.processRbfCloseMsg(msg)
+			if err != nil {
+				chancloserLog.Warnf("ChannelPoint(%v): unable "+
+					"to replace closing tx: %v",
+					c.chanPoint, err)
+
+				return nil, false, nil
+			}
+
+			return msgs, closeFin, nil
+		}
+
 		if _, ok := msg.(*lnwire.ClosingSigned); !ok {
 			return nil, false, fmt.Errorf("expected lnwire.ClosingSigned, "+
 				"instead have %v", spew.Sdump(msg))
@@ -915,6 +1005,236 @@ func (c *ChanCloser) proposeCloseSigned(fee ltcutil.Amount) (*lnwire.ClosingSign
 	return closeSignedMsg, nil
 }
 
+// rbfCloseOpts returns the options to create a close transaction with RBF based
+// close negotiation, which is paid for by the given party and uses the given
+// lock time.
+func rbfCloseOpts(feePayer lnwallet.CloseFeePayer,
+	lockTime uint32) []lnwallet.ChanCloseOpt {
+
+	return []lnwallet.ChanCloseOpt{
+		lnwallet.WithCoopCloseFeePayer(feePayer),
+		lnwallet.WithCoopCloseRBF(),
+		lnwallet.WithCoopCloseLockTime(lockTime),
+	}
+}
+
+// proposeClosingComplete signs a close transaction with the given fee that we
+// pay for, and returns the ClosingComplete message that proposes it to the
+// remote party.
+func (c *ChanCloser) proposeClosingComplete(
+	fee ltcutil.Amount) (*lnwire.ClosingComplete, error) {
+
+	lockTime := c.negotiationHeight
+	rawSig, _, _, err := c.cfg.Channel.CreateCloseProposal(
+		fee, c.localDeliveryScript, c.remoteDeliveryScript,
+		rbfCloseOpts(lnwallet.CloseFeePayerLocal, lockTime)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := lnwire.NewSigFromSignature(rawSig)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lastFeeProposal = fee
+
+	chancloserLog.Infof("ChannelPoint(%v): proposing close tx paying fee "+
+		"of %v sat", c.chanPoint, int64(fee))
+
+	closingComplete := &lnwire.ClosingComplete{
+		ChannelID:   c.cid,
+		FeeSatoshis: fee,
+		LockTime:    lockTime,
+		ClosingSig:  sig,
+	}
+
+	// We'll save the proposal, so we can complete the closing transaction
+	// once the remote party signed it.
+	c.rbfOffers[fee] = closingComplete
+
+	return closingComplete, nil
+}
+
+// BumpFee proposes a replacement for the current closing transaction that pays
+// the given fee rate, which we pay for. The returned ClosingComplete message
+// should be sent to the remote party. The given request replaces the original
+// close request, so its caller is notified about the replacement.
+func (c *ChanCloser) BumpFee(feeRate chainfee.SatPerKWeight,
+	req *htlcswitch.ChanClose) (*lnwire.ClosingComplete, error) {
+
+	if !c.cfg.RbfClose {
+		return nil, ErrRbfCloseNotSupported
+	}
+
+	// We can only propose a close transaction once both parties sent
+	// their shutdown message.
+	if c.state != closeFeeNegotiation && c.state != closeFinished {
+		return nil, fmt.Errorf("close negotiation hasn't started yet")
+	}
+
+	localTxOut, remoteTxOut := c.closeTxOuts()
+	fee := c.cfg.FeeEstimator.EstimateFee(
+		0, localTxOut, remoteTxOut, feeRate,
+	)
+
+	// The replacement must pay a higher fee than the transaction it
+	// replaces, otherwise it won't be relayed.
+	if c.closingTx != nil && fee <= c.closingFee {
+		return nil, fmt.Errorf("%w: %v <= %v", ErrRbfFeeTooLow, fee,
+			c.closingFee)
+	}
+
+	closingComplete, err := c.proposeClosingComplete(fee)
+	if err != nil {
+		return nil, err
+	}
+
+	c.closeReq = req
+
+	return closingComplete, nil
+}
+
+// processRbfCloseMsg processes a message of the RBF based close negotiation.
+// A ClosingComplete message proposes a close transaction that the remote party
+// pays for, which we sign and return our signature for. A ClosingSig message
+// carries the remote party's signature for one of our proposals. In both
+// cases the closing transaction is completed and broadcast.
+func (c *ChanCloser) processRbfCloseMsg(msg lnwire.Message) ([]lnwire.Message,
+	bool, error) {
+
+	switch msg := msg.(type) {
+	case *lnwire.ClosingComplete:
+		opts := rbfCloseOpts(lnwallet.CloseFeePayerRemote, msg.LockTime)
+		localSig, _, _, err := c.cfg.Channel.CreateCloseProposal(
+			msg.FeeSatoshis, c.localDeliveryScript,
+			c.remoteDeliveryScript, opts...,
+		)
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to sign close "+
+				"proposal: %w", err)
+		}
+
+		remoteSig, err := msg.ClosingSig.ToSignature()
+		if err != nil {
+			return nil, false, err
+		}
+
+		err = c.completeRbfClose(
+			localSig, remoteSig, msg.FeeSatoshis, opts,
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		sig, err := lnwire.NewSigFromSignature(localSig)
+		if err != nil {
+			return nil, false, err
+		}
+
+		closingSig := &lnwire.ClosingSig{
+			ChannelID:   c.cid,
+			FeeSatoshis: msg.FeeSatoshis,
+			LockTime:    msg.LockTime,
+			ClosingSig:  sig,
+		}
+
+		return []lnwire.Message{closingSig}, true, nil
+
+	case *lnwire.ClosingSig:
+		offer, ok := c.rbfOffers[msg.FeeSatoshis]
+		if !ok || offer.LockTime != msg.LockTime {
+			return nil, false, fmt.Errorf("received signature for "+
+				"unknown close proposal with fee %v and lock "+
+				"time %v", msg.FeeSatoshis, msg.LockTime)
+		}
+
+		localSig, err := offer.ClosingSig.ToSignature()
+		if err != nil {
+			return nil, false, err
+		}
+
+		remoteSig, err := msg.ClosingSig.ToSignature()
+		if err != nil {
+			return nil, false, err
+		}
+
+		err = c.completeRbfClose(
+			localSig, remoteSig, msg.FeeSatoshis,
+			rbfCloseOpts(lnwallet.CloseFeePayerLocal, msg.LockTime),
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return nil, true, nil
+
+	default:
+		return nil, false, fmt.Errorf("expected lnwire.ClosingComplete "+
+			"or lnwire.ClosingSig, instead have %v",
+			spew.Sdump(msg))
+	}
+}
+
+// completeRbfClose completes the closing transaction with the given
+// signatures, and broadcasts it. If we already broadcast a closing
+// transaction, the new one must pay a higher fee to replace it.
+func (c *ChanCloser) completeRbfClose(localSig, remoteSig input.Signature,
+	fee ltcutil.Amount, opts []lnwallet.ChanCloseOpt) error {
+
+	if c.closingTx != nil && fee <= c.closingFee {
+		return fmt.Errorf("%w: %v <= %v", ErrRbfFeeTooLow, fee,
+			c.closingFee)
+	}
+
+	closeTx, _, err := c.cfg.Channel.CompleteCooperativeClose(
+		localSig, remoteSig, c.localDeliveryScript,
+		c.remoteDeliveryScript, fee, opts...,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Before publishing the closing tx, we persist it to the database,
+	// such that it can be republished if something goes wrong.
+	err = c.cfg.Channel.MarkCoopBroadcasted(closeTx, c.locallyInitiated)
+	if err != nil {
+		return err
+	}
+
+	chancloserLog.Infof("Broadcasting cooperative close tx paying fee "+
+		"of %v sat: %v", int64(fee), newLogClosure(func() string {
+		return spew.Sdump(closeTx)
+	}))
+
+	chanID := c.cfg.Channel.ShortChanID()
+	closeLabel := labels.MakeLabel(labels.LabelTypeChannelClose, &chanID)
+
+	if err := c.cfg.BroadcastTx(closeTx, closeLabel); err != nil {
+		// If this was meant to replace a prior closing transaction,
+		// that one is still valid, so we'll restore it in the
+		// database.
+		if c.closingTx != nil {
+			restoreErr := c.cfg.Channel.MarkCoopBroadcasted(
+				c.closingTx, c.locallyInitiated,
+			)
+			if restoreErr != nil {
+				chancloserLog.Errorf("Unable to restore "+
+					"closing tx: %v", restoreErr)
+			}
+		}
+
+		return err
+	}
+
+	c.closingTx = closeTx
+	c.closingFee = fee
+	c.state = closeFinished
+
+	return nil
+}
+
 // feeInAcceptableRange returns true if the passed remote fee is deemed to be
 // in an "acceptable" range to our local fee. This is an attempt at a
 // compromise and to ensure that the fee negotiation has a stopping point. We
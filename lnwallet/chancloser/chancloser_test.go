@@ -14,6 +14,7 @@ import (
 	"github.com/ltcsuite/lnd/lnwallet/chainfee"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/ltcd/btcec/v2"
+	"github.com/ltcsuite/ltcd/btcec/v2/ecdsa"
 	"github.com/ltcsuite/ltcd/btcec/v2/schnorr/musig2"
 	"github.com/ltcsuite/ltcd/chaincfg"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
@@ -144,6 +145,11 @@ type mockChannel struct {
 	chanType  channeldb.ChannelType
 	localKey  keychain.KeyDescriptor
 	remoteKey keychain.KeyDescriptor
+
+	// sig is the signature returned for close proposals of non-taproot
+	// channels. If set, cooperative closes are completed with a closing
+	// transaction.
+	sig input.Signature
 }
 
 func (m *mockChannel) ChannelPoint() *wire.OutPoint {
@@ -184,7 +190,7 @@ func (m *mockChannel) CreateCloseProposal(fee ltcutil.Amount,
 		), nil, 0, nil
 	}
 
-	return nil, nil, 0, nil
+	return m.sig, nil, 0, nil
 }
 
 func (m *mockChannel) CompleteCooperativeClose(localSig,
@@ -192,6 +198,10 @@ func (m *mockChannel) CompleteCooperativeClose(localSig,
 	proposedFee ltcutil.Amount,
 	_ ...lnwallet.ChanCloseOpt) (*wire.MsgTx, ltcutil.Amount, error) {
 
+	if m.sig != nil {
+		return wire.NewMsgTx(2), proposedFee, nil
+	}
+
 	return nil, 0, nil
 }
 
@@ -594,3 +604,122 @@ func TestTaprootFastClose(t *testing.T) {
 	require.True(t, closeFinished)
 	require.Len(t, bobMsgs, 0)
 }
+
+// TestRbfCoopClose tests that with RBF based close negotiation, the party that
+// started the close proposes a close transaction that it pays for, and that
+// either party can replace the broadcast closing transaction with one that
+// pays a higher fee.
+func TestRbfCoopClose(t *testing.T) {
+	t.Parallel()
+
+	priv, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	sig := ecdsa.Sign(priv, chainhash.DoubleHashB([]byte("close")))
+
+	broadcastSignal := make(chan struct{}, 4)
+	newCloser := func(initiator bool) *ChanCloser {
+		closer := NewChanCloser(
+			ChanCloseCfg{
+				Channel: &mockChannel{
+					initiator: initiator,
+					sig:       sig,
+				},
+				BroadcastTx: func(*wire.MsgTx, string) error {
+					broadcastSignal <- struct{}{}
+					return nil
+				},
+				MaxFee:       chainfee.SatPerKWeight(1000),
+				FeeEstimator: &SimpleCoopFeeEstimator{},
+				DisableChannel: func(wire.OutPoint) error {
+					return nil
+				},
+				RbfClose: true,
+			}, nil, chainfee.SatPerKWeight(506), 0, nil, initiator,
+		)
+		closer.initFeeBaseline()
+
+		return closer
+	}
+
+	assertBroadcast := func() {
+		t.Helper()
+
+		_, err := lnutils.RecvOrTimeout(broadcastSignal, time.Second)
+		require.NoError(t, err)
+	}
+
+	// Alice starts the close, so she'll propose the first close
+	// transaction.
+	aliceCloser := newCloser(true)
+	bobCloser := newCloser(false)
+
+	msg, err := aliceCloser.ShutdownChan()
+	require.NoError(t, err)
+
+	bobMsgs, closeFinished, err := bobCloser.ProcessCloseMsg(msg)
+	require.NoError(t, err)
+	require.False(t, closeFinished)
+	require.Len(t, bobMsgs, 1)
+	require.IsType(t, &lnwire.Shutdown{}, bobMsgs[0])
+
+	// Once Alice receives Bob's shutdown, she proposes a close
+	// transaction that she pays for.
+	aliceMsgs, closeFinished, err := aliceCloser.ProcessCloseMsg(bobMsgs[0])
+	require.NoError(t, err)
+	require.False(t, closeFinished)
+	require.Len(t, aliceMsgs, 1)
+	proposal := assertType[*lnwire.ClosingComplete](t, aliceMsgs[0])
+	require.Equal(t, aliceCloser.idealFeeSat, proposal.FeeSatoshis)
+
+	// Bob signs the proposal, broadcasts the closing transaction and sends
+	// his signature back to Alice.
+	bobMsgs, closeFinished, err = bobCloser.ProcessCloseMsg(proposal)
+	require.NoError(t, err)
+	require.True(t, closeFinished)
+	require.Len(t, bobMsgs, 1)
+	closingSig := assertType[*lnwire.ClosingSig](t, bobMsgs[0])
+	require.Equal(t, proposal.FeeSatoshis, closingSig.FeeSatoshis)
+	assertBroadcast()
+
+	// Alice completes the closing transaction with Bob's signature and
+	// broadcasts it as well.
+	aliceMsgs, closeFinished, err = aliceCloser.ProcessCloseMsg(closingSig)
+	require.NoError(t, err)
+	require.True(t, closeFinished)
+	require.Empty(t, aliceMsgs)
+	assertBroadcast()
+
+	// A replacement that doesn't pay a higher fee is rejected.
+	_, err = bobCloser.BumpFee(chainfee.SatPerKWeight(253), nil)
+	require.ErrorIs(t, err, ErrRbfFeeTooLow)
+
+	// Bob may bump the fee of the closing transaction, paying the fee of
+	// the replacement himself.
+	bump, err := bobCloser.BumpFee(chainfee.SatPerKWeight(1000), nil)
+	require.NoError(t, err)
+	require.Greater(t, bump.FeeSatoshis, proposal.FeeSatoshis)
+
+	aliceMsgs, closeFinished, err = aliceCloser.ProcessCloseMsg(bump)
+	require.NoError(t, err)
+	require.True(t, closeFinished)
+	require.Len(t, aliceMsgs, 1)
+	assertBroadcast()
+
+	bobMsgs, closeFinished, err = bobCloser.ProcessCloseMsg(aliceMsgs[0])
+	require.NoError(t, err)
+	require.True(t, closeFinished)
+	require.Empty(t, bobMsgs)
+	assertBroadcast()
+
+	require.Equal(t, bump.FeeSatoshis, aliceCloser.closingFee)
+	require.Equal(t, bump.FeeSatoshis, bobCloser.closingFee)
+
+	// Signatures for unknown proposals don't replace the closing
+	// transaction.
+	closingSig.FeeSatoshis++
+	aliceMsgs, closeFinished, err = aliceCloser.ProcessCloseMsg(closingSig)
+	require.NoError(t, err)
+	require.False(t, closeFinished)
+	require.Empty(t, aliceMsgs)
+	require.Equal(t, bump.FeeSatoshis, aliceCloser.closingFee)
+}
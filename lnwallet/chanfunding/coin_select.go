@@ -49,11 +49,16 @@ type Coin struct {
 }
 
 // selectInputs selects a slice of inputs necessary to meet the specified
-// selection amount. If input selection is unable to succeed due to insufficient
-// funds, a non-nil error is returned. Additionally, the total amount of the
-// selected coins are returned in order for the caller to properly handle
-// change+fees.
-func selectInputs(amt ltcutil.Amount, coins []Coin) (ltcutil.Amount, []Coin, error) {
+// selection amount. The coins are considered in the order determined by the
+// given coin selection strategy. If input selection is unable to succeed due to
+// insufficient funds, a non-nil error is returned. Additionally, the total
+// amount of the selected coins are returned in order for the caller to properly
+// handle change+fees.
+func selectInputs(strategy CoinSelectionStrategy, amt ltcutil.Amount,
+	coins []Coin, feeRate chainfee.SatPerKWeight) (ltcutil.Amount, []Coin,
+	error) {
+
+	coins = arrangeCoins(strategy, coins, amt, feeRate)
 
 	satSelected := ltcutil.Amount(0)
 	for i, coin := range coins {
@@ -66,6 +71,41 @@ func selectInputs(amt ltcutil.Amount, coins []Coin) (ltcutil.Amount, []Coin, err
 	return 0, nil, &ErrInsufficientFunds{amt, satSelected}
 }
 
+// addCoinInput adds the weight of an input spending the given coin to the
+// weight estimator.
+func addCoinInput(weightEstimate *input.TxWeightEstimator, coin Coin) error {
+	switch {
+	case txscript.IsPayToWitnessPubKeyHash(coin.PkScript):
+		weightEstimate.AddP2WKHInput()
+
+	case txscript.IsPayToScriptHash(coin.PkScript):
+		weightEstimate.AddNestedP2WKHInput()
+
+	case txscript.IsPayToTaproot(coin.PkScript):
+		weightEstimate.AddTaprootKeySpendInput(txscript.SigHashDefault)
+
+	default:
+		return &errUnsupportedInput{coin.PkScript}
+	}
+
+	return nil
+}
+
+// coinSpendFee returns the fee for adding an input spending the given coin to
+// a transaction at the specified fee rate.
+func coinSpendFee(coin Coin, feeRate chainfee.SatPerKWeight) (ltcutil.Amount,
+	error) {
+
+	var emptyEstimate, weightEstimate input.TxWeightEstimator
+	if err := addCoinInput(&weightEstimate, coin); err != nil {
+		return 0, err
+	}
+
+	weight := weightEstimate.Weight() - emptyEstimate.Weight()
+
+	return feeRate.FeeForWeight(int64(weight)), nil
+}
+
 // calculateFees returns for the specified utxos and fee rate two fee
 // estimates, one calculated using a change output and one without. The weight
 // added to the estimator from a change output is for a P2WKH output.
@@ -74,20 +114,8 @@ func calculateFees(utxos []Coin, feeRate chainfee.SatPerKWeight) (ltcutil.Amount
 
 	var weightEstimate input.TxWeightEstimator
 	for _, utxo := range utxos {
-		switch {
-		case txscript.IsPayToWitnessPubKeyHash(utxo.PkScript):
-			weightEstimate.AddP2WKHInput()
-
-		case txscript.IsPayToScriptHash(utxo.PkScript):
-			weightEstimate.AddNestedP2WKHInput()
-
-		case txscript.IsPayToTaproot(utxo.PkScript):
-			weightEstimate.AddTaprootKeySpendInput(
-				txscript.SigHashDefault,
-			)
-
-		default:
-			return 0, 0, &errUnsupportedInput{utxo.PkScript}
+		if err := addCoinInput(&weightEstimate, utxo); err != nil {
+			return 0, 0, err
 		}
 	}
 
@@ -128,16 +156,47 @@ func sanityCheckFee(totalOut, fee ltcutil.Amount) error {
 // change output to fund amt satoshis, adhering to the specified fee rate. The
 // specified fee rate should be expressed in sat/kw for coin selection to
 // function properly.
-func CoinSelect(feeRate chainfee.SatPerKWeight, amt, dustLimit ltcutil.Amount,
-	coins []Coin) ([]Coin, ltcutil.Amount, error) {
+func CoinSelect(strategy CoinSelectionStrategy, feeRate chainfee.SatPerKWeight,
+	amt, dustLimit ltcutil.Amount, coins []Coin) ([]Coin, ltcutil.Amount,
+	error) {
+
+	// Strategies that search for a changeless selection get to pick the
+	// coins of the first round. The inputs need to fund the amount and the
+	// fee of the transaction without inputs on top of their own fees.
+	var changeless []Coin
+	if selector, ok := strategy.(changelessSelector); ok {
+		baseFee, _, err := calculateFees(nil, feeRate)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		changeless = selector.selectChangeless(
+			coins, amt+baseFee, feeRate,
+		)
+	}
 
 	amtNeeded := amt
 	for {
+		var (
+			totalSat      ltcutil.Amount
+			selectedUtxos []Coin
+			err           error
+		)
+
 		// First perform an initial round of coin selection to estimate
-		// the required fee.
-		totalSat, selectedUtxos, err := selectInputs(amtNeeded, coins)
-		if err != nil {
-			return nil, 0, err
+		// the required fee, unless a changeless selection was found.
+		if changeless != nil {
+			selectedUtxos, changeless = changeless, nil
+			for _, coin := range selectedUtxos {
+				totalSat += ltcutil.Amount(coin.Value)
+			}
+		} else {
+			totalSat, selectedUtxos, err = selectInputs(
+				strategy, amtNeeded, coins, feeRate,
+			)
+			if err != nil {
+				return nil, 0, err
+			}
 		}
 
 		// Obtain fee estimates both with and without using a change
@@ -197,13 +256,15 @@ func CoinSelect(feeRate chainfee.SatPerKWeight, amt, dustLimit ltcutil.Amount,
 // CoinSelectSubtractFees attempts to select coins such that we'll spend up to
 // amt in total after fees, adhering to the specified fee rate. The selected
 // coins, the final output and change values are returned.
-func CoinSelectSubtractFees(feeRate chainfee.SatPerKWeight, amt,
-	dustLimit ltcutil.Amount, coins []Coin) ([]Coin, ltcutil.Amount,
-	ltcutil.Amount, error) {
+func CoinSelectSubtractFees(strategy CoinSelectionStrategy,
+	feeRate chainfee.SatPerKWeight, amt, dustLimit ltcutil.Amount,
+	coins []Coin) ([]Coin, ltcutil.Amount, ltcutil.Amount, error) {
 
 	// First perform an initial round of coin selection to estimate
 	// the required fee.
-	totalSat, selectedUtxos, err := selectInputs(amt, coins)
+	totalSat, selectedUtxos, err := selectInputs(
+		strategy, amt, coins, feeRate,
+	)
 	if err != nil {
 		return nil, 0, 0, err
 	}
@@ -258,9 +319,10 @@ func CoinSelectSubtractFees(feeRate chainfee.SatPerKWeight, amt,
 // maxAmount exclusive of fees and optional reserve if sufficient funds are
 // available. If insufficient funds are available this method selects all
 // available coins.
-func CoinSelectUpToAmount(feeRate chainfee.SatPerKWeight, minAmount, maxAmount,
-	reserved, dustLimit ltcutil.Amount, coins []Coin) ([]Coin,
-	ltcutil.Amount, ltcutil.Amount, error) {
+func CoinSelectUpToAmount(strategy CoinSelectionStrategy,
+	feeRate chainfee.SatPerKWeight, minAmount, maxAmount, reserved,
+	dustLimit ltcutil.Amount, coins []Coin) ([]Coin, ltcutil.Amount,
+	ltcutil.Amount, error) {
 
 	var (
 		// selectSubtractFee is tracking if our coin selection was
@@ -280,7 +342,7 @@ func CoinSelectUpToAmount(feeRate chainfee.SatPerKWeight, minAmount, maxAmount,
 	// First we try to select coins to create an output of the specified
 	// maxAmount with or without a change output that covers the miner fee.
 	selected, changeAmt, err := CoinSelect(
-		feeRate, maxAmount, dustLimit, coins,
+		strategy, feeRate, maxAmount, dustLimit, coins,
 	)
 
 	var errInsufficientFunds *ErrInsufficientFunds
@@ -319,7 +381,8 @@ func CoinSelectUpToAmount(feeRate chainfee.SatPerKWeight, minAmount, maxAmount,
 	// our total balance minus fees and optional reserve.
 	if selectSubtractFee {
 		selected, outputAmount, changeAmt, err = CoinSelectSubtractFees(
-			feeRate, totalBalance-reserved, dustLimit, coins,
+			strategy, feeRate, totalBalance-reserved, dustLimit,
+			coins,
 		)
 		if err != nil {
 			return nil, 0, 0, err
@@ -353,3 +416,47 @@ func CoinSelectUpToAmount(feeRate chainfee.SatPerKWeight, minAmount, maxAmount,
 
 	return selected, outputAmount, changeAmt, nil
 }
+
+// CoinSelectOutputs selects coins to fund the given outputs at the specified
+// fee rate, considering them in the order determined by the given coin
+// selection strategy. Only the inputs are selected, adding a change output for
+// any excess value is left to the caller. Coins with unsupported script types
+// are skipped.
+func CoinSelectOutputs(strategy CoinSelectionStrategy,
+	feeRate chainfee.SatPerKWeight, outputs []*wire.TxOut,
+	coins []Coin) ([]Coin, error) {
+
+	var (
+		weightEstimate input.TxWeightEstimator
+		totalOut       ltcutil.Amount
+	)
+	for _, txOut := range outputs {
+		weightEstimate.AddTxOutput(txOut)
+		totalOut += ltcutil.Amount(txOut.Value)
+	}
+
+	// The inputs need to pay for the outputs and the fee of the
+	// transaction without inputs on top of their own fees.
+	baseFee := feeRate.FeeForWeight(int64(weightEstimate.Weight()))
+	coins = arrangeCoins(strategy, coins, totalOut+baseFee, feeRate)
+
+	var (
+		selected    []Coin
+		satSelected ltcutil.Amount
+	)
+	for _, coin := range coins {
+		if err := addCoinInput(&weightEstimate, coin); err != nil {
+			continue
+		}
+
+		selected = append(selected, coin)
+		satSelected += ltcutil.Amount(coin.Value)
+
+		fee := feeRate.FeeForWeight(int64(weightEstimate.Weight()))
+		if satSelected >= totalOut+fee {
+			return selected, nil
+		}
+	}
+
+	return nil, &ErrInsufficientFunds{totalOut + baseFee, satSelected}
+}
@@ -0,0 +1,303 @@
+package chanfunding
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/ltcsuite/lnd/input"
+	"github.com/ltcsuite/lnd/lnwallet/chainfee"
+	"github.com/ltcsuite/ltcd/blockchain"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+const (
+	// CoinSelectionLargestName is the config name of the largest-first coin
+	// selection strategy.
+	CoinSelectionLargestName = "largest"
+
+	// CoinSelectionRandomName is the config name of the random coin
+	// selection strategy.
+	CoinSelectionRandomName = "random"
+
+	// CoinSelectionBnBName is the config name of the branch and bound coin
+	// selection strategy.
+	CoinSelectionBnBName = "bnb"
+
+	// bnbMaxTries is the maximum number of branches the branch and bound
+	// strategy explores before giving up on finding a changeless
+	// selection.
+	bnbMaxTries = 100000
+
+	// bnbChangeWeight is the weight of creating a P2TR change output and
+	// spending it later on. A selection that exceeds the target by less
+	// than the fee for this weight is better off without a change output.
+	bnbChangeWeight = input.P2TROutputSize*blockchain.WitnessScaleFactor +
+		input.InputSize*blockchain.WitnessScaleFactor +
+		input.TaprootKeyPathWitnessSize
+)
+
+// CoinSelectionStrategy decides which coins are preferred during coin
+// selection. Coins are selected in the order returned by ArrangeCoins until
+// the target amount is reached.
+type CoinSelectionStrategy interface {
+	// ArrangeCoins returns the given coins in the order they should be
+	// selected in to fund the target amount at the given fee rate.
+	ArrangeCoins(coins []Coin, target ltcutil.Amount,
+		feeRate chainfee.SatPerKWeight) []Coin
+}
+
+var (
+	// CoinSelectionLargest always selects the largest coins first. This
+	// results in the smallest number of inputs.
+	CoinSelectionLargest CoinSelectionStrategy = &largestFirstStrategy{}
+
+	// CoinSelectionRandom selects coins in random order, which makes it
+	// harder to fingerprint the wallet through its transactions.
+	CoinSelectionRandom CoinSelectionStrategy = &randomStrategy{}
+
+	// CoinSelectionBnB uses a branch and bound search over the effective
+	// values of the coins, that is their values minus the fee for spending
+	// them, to find a set of coins that funds the target without requiring
+	// a change output. If no such set exists, the largest coins are
+	// selected first.
+	CoinSelectionBnB CoinSelectionStrategy = &bnbStrategy{}
+)
+
+// ParseCoinSelectionStrategy returns the coin selection strategy with the
+// given config name.
+func ParseCoinSelectionStrategy(name string) (CoinSelectionStrategy, error) {
+	switch name {
+	case CoinSelectionLargestName:
+		return CoinSelectionLargest, nil
+
+	case CoinSelectionRandomName:
+		return CoinSelectionRandom, nil
+
+	case CoinSelectionBnBName:
+		return CoinSelectionBnB, nil
+
+	default:
+		return nil, fmt.Errorf("unknown coin selection strategy %v",
+			name)
+	}
+}
+
+// arrangeCoins arranges the coins with the given strategy, falling back to
+// the largest-first strategy if none is set.
+func arrangeCoins(strategy CoinSelectionStrategy, coins []Coin,
+	target ltcutil.Amount, feeRate chainfee.SatPerKWeight) []Coin {
+
+	if strategy == nil {
+		strategy = CoinSelectionLargest
+	}
+
+	return strategy.ArrangeCoins(coins, target, feeRate)
+}
+
+// largestFirstStrategy is a CoinSelectionStrategy that arranges coins by
+// descending value.
+type largestFirstStrategy struct{}
+
+// ArrangeCoins returns the given coins sorted by descending value.
+//
+// NOTE: This is part of the CoinSelectionStrategy interface.
+func (*largestFirstStrategy) ArrangeCoins(coins []Coin, _ ltcutil.Amount,
+	_ chainfee.SatPerKWeight) []Coin {
+
+	return sortLargestFirst(coins)
+}
+
+// randomStrategy is a CoinSelectionStrategy that arranges coins in random
+// order.
+type randomStrategy struct{}
+
+// ArrangeCoins returns the given coins in random order.
+//
+// NOTE: This is part of the CoinSelectionStrategy interface.
+func (*randomStrategy) ArrangeCoins(coins []Coin, _ ltcutil.Amount,
+	_ chainfee.SatPerKWeight) []Coin {
+
+	shuffled := make([]Coin, len(coins))
+	copy(shuffled, coins)
+
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return shuffled
+}
+
+// changelessSelector is implemented by coin selection strategies that can
+// search for a set of coins that funds a target without a change output.
+type changelessSelector interface {
+	// selectChangeless returns a set of coins whose effective values, that
+	// is their values minus the fee for spending them, add up to at least
+	// the target but don't exceed it by more than the cost of a change
+	// output. Nil is returned if no such set was found.
+	selectChangeless(coins []Coin, target ltcutil.Amount,
+		feeRate chainfee.SatPerKWeight) []Coin
+}
+
+// bnbStrategy is a CoinSelectionStrategy that prefers a set of coins that
+// doesn't require a change output.
+type bnbStrategy struct{}
+
+// A compile-time check to ensure bnbStrategy implements changelessSelector.
+var _ changelessSelector = (*bnbStrategy)(nil)
+
+// ArrangeCoins searches for a set of coins with a total effective value
+// between the target and the target plus the cost of a change output. If one
+// is found, these coins are placed first, followed by all other coins in
+// largest-first order so the selection can still grow if the fee requires it.
+//
+// NOTE: This is part of the CoinSelectionStrategy interface.
+func (b *bnbStrategy) ArrangeCoins(coins []Coin, target ltcutil.Amount,
+	feeRate chainfee.SatPerKWeight) []Coin {
+
+	sorted := sortLargestFirst(coins)
+
+	match := b.selectChangeless(sorted, target, feeRate)
+	if match == nil {
+		return sorted
+	}
+
+	arranged := make([]Coin, 0, len(sorted))
+	selected := make(map[wire.OutPoint]struct{}, len(match))
+	for _, coin := range match {
+		arranged = append(arranged, coin)
+		selected[coin.OutPoint] = struct{}{}
+	}
+	for _, coin := range sorted {
+		if _, ok := selected[coin.OutPoint]; !ok {
+			arranged = append(arranged, coin)
+		}
+	}
+
+	return arranged
+}
+
+// selectChangeless searches for a set of coins with a total effective value
+// between the target and the target plus the cost of a change output. Coins
+// that cost more to spend than they are worth, or whose script type isn't
+// supported, are never part of the set.
+//
+// NOTE: This is part of the changelessSelector interface.
+func (*bnbStrategy) selectChangeless(coins []Coin, target ltcutil.Amount,
+	feeRate chainfee.SatPerKWeight) []Coin {
+
+	// Only coins that add value at the given fee rate are worth
+	// searching through.
+	candidates := make([]effectiveCoin, 0, len(coins))
+	for _, coin := range coins {
+		spendFee, err := coinSpendFee(coin, feeRate)
+		if err != nil {
+			continue
+		}
+
+		value := ltcutil.Amount(coin.Value) - spendFee
+		if value <= 0 {
+			continue
+		}
+
+		candidates = append(candidates, effectiveCoin{
+			Coin:           coin,
+			effectiveValue: value,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].effectiveValue >
+			candidates[j].effectiveValue
+	})
+
+	changeCost := feeRate.FeeForWeight(bnbChangeWeight)
+	match := bnbSearch(candidates, target, target+changeCost)
+	if match == nil {
+		return nil
+	}
+
+	selected := make([]Coin, 0, len(match))
+	for _, idx := range match {
+		selected = append(selected, candidates[idx].Coin)
+	}
+
+	return selected
+}
+
+// effectiveCoin is a coin along with its value after paying for the input
+// that spends it.
+type effectiveCoin struct {
+	Coin
+
+	// effectiveValue is the value of the coin minus the fee for spending
+	// it.
+	effectiveValue ltcutil.Amount
+}
+
+// bnbSearch does a depth first search over the given coins, which must be
+// sorted by descending effective value, for a set with a total effective
+// value between lower and upper. The indexes of the first matching set are
+// returned, or nil if none was found within bnbMaxTries branches. As coins are
+// always included before they are excluded, no prefix of a returned set
+// reaches lower on its own.
+func bnbSearch(coins []effectiveCoin, lower, upper ltcutil.Amount) []int {
+	// remaining[i] is the total effective value of coins[i:], which lets
+	// us prune branches that can't reach the lower bound anymore.
+	remaining := make([]ltcutil.Amount, len(coins)+1)
+	for i := len(coins) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + coins[i].effectiveValue
+	}
+
+	var (
+		tries    int
+		selected []int
+		match    []int
+	)
+
+	var search func(idx int, sum ltcutil.Amount) bool
+	search = func(idx int, sum ltcutil.Amount) bool {
+		tries++
+
+		switch {
+		case sum > upper:
+			return false
+
+		case sum >= lower:
+			match = append([]int(nil), selected...)
+			return true
+
+		case idx == len(coins), sum+remaining[idx] < lower,
+			tries > bnbMaxTries:
+
+			return false
+		}
+
+		// Explore the branch that includes the coin first, then the one
+		// that excludes it.
+		selected = append(selected, idx)
+		if search(idx+1, sum+coins[idx].effectiveValue) {
+			return true
+		}
+		selected = selected[:len(selected)-1]
+
+		return search(idx+1, sum)
+	}
+	search(0, 0)
+
+	return match
+}
+
+// sortLargestFirst returns a copy of the given coins sorted by descending
+// value.
+func sortLargestFirst(coins []Coin) []Coin {
+	sorted := make([]Coin, len(coins))
+	copy(sorted, coins)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Value > sorted[j].Value
+	})
+
+	return sorted
+}
@@ -0,0 +1,200 @@
+package chanfunding
+
+import (
+	"testing"
+
+	"github.com/ltcsuite/lnd/input"
+	"github.com/ltcsuite/lnd/lnwallet/chainfee"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// coinValues returns the values of the given coins.
+func coinValues(coins []Coin) []int64 {
+	values := make([]int64, 0, len(coins))
+	for _, coin := range coins {
+		values = append(values, coin.Value)
+	}
+
+	return values
+}
+
+// TestCoinSelectionStrategies checks the order in which the coin selection
+// strategies arrange coins.
+func TestCoinSelectionStrategies(t *testing.T) {
+	t.Parallel()
+
+	const feeRate = chainfee.SatPerKWeight(1000)
+
+	p2wkhSpendFee, err := coinSpendFee(
+		Coin{TxOut: wire.TxOut{PkScript: p2wkhScript}}, feeRate,
+	)
+	require.NoError(t, err)
+
+	var coins []Coin
+	for i, value := range []int64{100, 50_000, 30_000, 20_000, 7_000} {
+		coins = append(coins, Coin{
+			TxOut: wire.TxOut{
+				PkScript: p2wkhScript,
+				Value:    value,
+			},
+			OutPoint: wire.OutPoint{Index: uint32(i)},
+		})
+	}
+
+	testCases := []struct {
+		name     string
+		strategy string
+		target   ltcutil.Amount
+		expected []int64
+	}{
+		{
+			name:     "largest first",
+			strategy: CoinSelectionLargestName,
+			target:   27_000,
+			expected: []int64{50_000, 30_000, 20_000, 7_000, 100},
+		},
+		{
+			// After paying for their inputs, 20_000 + 7_000 are
+			// within the cost of change of the target, so these
+			// coins are selected first.
+			name:     "bnb changeless match",
+			strategy: CoinSelectionBnBName,
+			target:   27_000 - 2*p2wkhSpendFee,
+			expected: []int64{20_000, 7_000, 50_000, 30_000, 100},
+		},
+		{
+			// 20_000 + 7_000 match the target exactly, but can't
+			// pay for their own inputs, so we fall back to largest
+			// first.
+			name:     "bnb effective values",
+			strategy: CoinSelectionBnBName,
+			target:   27_000,
+			expected: []int64{50_000, 30_000, 20_000, 7_000, 100},
+		},
+		{
+			// There is no combination within the cost of change of
+			// the target, so we fall back to largest first.
+			name:     "bnb no match",
+			strategy: CoinSelectionBnBName,
+			target:   45_000,
+			expected: []int64{50_000, 30_000, 20_000, 7_000, 100},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			strategy, err := ParseCoinSelectionStrategy(tc.strategy)
+			require.NoError(t, err)
+
+			arranged := strategy.ArrangeCoins(
+				coins, tc.target, feeRate,
+			)
+			require.Equal(t, tc.expected, coinValues(arranged))
+		})
+	}
+
+	// The random strategy must return a permutation of the coins.
+	arranged := CoinSelectionRandom.ArrangeCoins(coins, 27_000, feeRate)
+	require.ElementsMatch(t, coins, arranged)
+
+	_, err = ParseCoinSelectionStrategy("unknown")
+	require.Error(t, err)
+}
+
+// TestCoinSelectBnB checks that CoinSelect with the branch and bound strategy
+// funds the amount without a change output if the effective values of the
+// coins allow it.
+func TestCoinSelectBnB(t *testing.T) {
+	t.Parallel()
+
+	const (
+		feeRate   = chainfee.SatPerKWeight(1000)
+		dustLimit = ltcutil.Amount(1000)
+	)
+
+	var coins []Coin
+	for i, value := range []int64{80_000, 40_000, 25_000} {
+		coins = append(coins, Coin{
+			TxOut: wire.TxOut{
+				PkScript: p2wkhScript,
+				Value:    value,
+			},
+			OutPoint: wire.OutPoint{Index: uint32(i)},
+		})
+	}
+
+	// Spending the 40_000 and 25_000 coins leaves the amount plus a few
+	// satoshis after paying the fee of a transaction without change.
+	amt := 65_000 - fundingFee(feeRate, 2, false) - 10
+
+	selected, changeAmt, err := CoinSelect(
+		CoinSelectionBnB, feeRate, amt, dustLimit, coins,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []int64{40_000, 25_000}, coinValues(selected))
+	require.Zero(t, changeAmt)
+
+	// The largest first strategy selects the largest coin and creates a
+	// change output instead.
+	selected, changeAmt, err = CoinSelect(
+		CoinSelectionLargest, feeRate, amt, dustLimit, coins,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []int64{80_000}, coinValues(selected))
+	require.NotZero(t, changeAmt)
+}
+
+// TestCoinSelectOutputs checks that CoinSelectOutputs selects the inputs for
+// arbitrary outputs in the order of the coin selection strategy.
+func TestCoinSelectOutputs(t *testing.T) {
+	t.Parallel()
+
+	const feeRate = chainfee.SatPerKWeight(1000)
+
+	var coins []Coin
+	for i, value := range []int64{80_000, 40_000, 25_000} {
+		coins = append(coins, Coin{
+			TxOut: wire.TxOut{
+				PkScript: p2wkhScript,
+				Value:    value,
+			},
+			OutPoint: wire.OutPoint{Index: uint32(i)},
+		})
+	}
+
+	// Spending the 40_000 and 25_000 coins leaves the output value plus a
+	// few satoshis after paying the fee of a transaction without change.
+	var weightEstimate input.TxWeightEstimator
+	weightEstimate.AddP2WKHInput()
+	weightEstimate.AddP2WKHInput()
+	weightEstimate.AddP2WKHOutput()
+	fee := feeRate.FeeForWeight(int64(weightEstimate.Weight()))
+
+	outputs := []*wire.TxOut{{
+		Value:    int64(65_000 - fee - 10),
+		PkScript: p2wkhScript,
+	}}
+
+	selected, err := CoinSelectOutputs(
+		CoinSelectionBnB, feeRate, outputs, coins,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []int64{40_000, 25_000}, coinValues(selected))
+
+	// The largest first strategy only needs the largest coin.
+	selected, err = CoinSelectOutputs(
+		CoinSelectionLargest, feeRate, outputs, coins,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []int64{80_000}, coinValues(selected))
+
+	// The coins can't fund outputs exceeding their total value.
+	outputs[0].Value = 145_000
+	_, err = CoinSelectOutputs(CoinSelectionBnB, feeRate, outputs, coins)
+	require.ErrorAs(t, err, new(*ErrInsufficientFunds))
+}
@@ -264,7 +264,8 @@ func TestCoinSelect(t *testing.T) {
 			t.Parallel()
 
 			selected, changeAmt, err := CoinSelect(
-				feeRate, test.outputValue, dustLimit, test.coins,
+				CoinSelectionLargest, feeRate, test.outputValue,
+				dustLimit, test.coins,
 			)
 			if !test.expectErr && err != nil {
 				t.Fatalf(err.Error())
@@ -480,7 +481,8 @@ func TestCoinSelectSubtractFees(t *testing.T) {
 			}
 
 			selected, localFundingAmt, changeAmt, err := CoinSelectSubtractFees(
-				feeRate, test.spendValue, dustLimit, test.coins,
+				CoinSelectionLargest, feeRate, test.spendValue,
+				dustLimit, test.coins,
 			)
 			if err != nil {
 				switch {
@@ -718,8 +720,9 @@ func TestCoinSelectUpToAmount(t *testing.T) {
 			t.Parallel()
 			selected, localFundingAmt, changeAmt,
 				err := CoinSelectUpToAmount(
-				feeRate, test.minValue, test.maxValue,
-				test.reserved, dustLimit, test.coins,
+				CoinSelectionLargest, feeRate, test.minValue,
+				test.maxValue, test.reserved, dustLimit,
+				test.coins,
 			)
 			if len(test.expectErr) == 0 && err != nil {
 				t.Fatalf(err.Error())
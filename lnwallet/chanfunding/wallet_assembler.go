@@ -222,6 +222,11 @@ type WalletConfig struct {
 	// DustLimit is the current dust limit. We'll use this to ensure that
 	// we don't make dust outputs on the funding transaction.
 	DustLimit ltcutil.Amount
+
+	// CoinSelectionStrategy is the strategy that is used for selecting
+	// coins when funding a channel. If nil, the largest coins are selected
+	// first.
+	CoinSelectionStrategy CoinSelectionStrategy
 }
 
 // WalletAssembler is an instance of the Assembler interface that is backed by
@@ -281,9 +286,7 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 		}
 
 		// Find all unlocked unspent witness outputs that satisfy the
-		// minimum number of confirmations required. Coin selection in
-		// this function currently ignores the configured coin selection
-		// strategy.
+		// minimum number of confirmations required.
 		allCoins, err = w.cfg.CoinSource.ListCoins(
 			r.MinConfs, math.MaxInt32,
 		)
@@ -383,8 +386,9 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 
 			selectedCoins, localContributionAmt, changeAmt,
 				err = CoinSelectUpToAmount(
-				r.FeeRate, r.MinFundAmt, r.FundUpToMaxAmt,
-				reserve, w.cfg.DustLimit, coins,
+				w.cfg.CoinSelectionStrategy, r.FeeRate,
+				r.MinFundAmt, r.FundUpToMaxAmt, reserve,
+				w.cfg.DustLimit, coins,
 			)
 			if err != nil {
 				return err
@@ -417,7 +421,8 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 			dustLimit := w.cfg.DustLimit
 			selectedCoins, localContributionAmt, changeAmt,
 				err = CoinSelectSubtractFees(
-				r.FeeRate, r.LocalAmt, dustLimit, coins,
+				w.cfg.CoinSelectionStrategy, r.FeeRate,
+				r.LocalAmt, dustLimit, coins,
 			)
 			if err != nil {
 				return err
@@ -429,7 +434,8 @@ func (w *WalletAssembler) ProvisionChannel(r *Request) (Intent, error) {
 			dustLimit := w.cfg.DustLimit
 			localContributionAmt = r.LocalAmt
 			selectedCoins, changeAmt, err = CoinSelect(
-				r.FeeRate, r.LocalAmt, dustLimit, coins,
+				w.cfg.CoinSelectionStrategy, r.FeeRate,
+				r.LocalAmt, dustLimit, coins,
 			)
 			if err != nil {
 				return err
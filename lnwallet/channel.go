@@ -358,6 +358,18 @@ type PaymentDescriptor struct {
 	// NOTE: Populated only in payment descriptor with MalformedFail type.
 	ShaOnionBlob [sha256.Size]byte
 
+	// BlindingPoint is the blinding point that was provided in the
+	// update_add_htlc if the HTLC is part of a blinded route.
+	//
+	// NOTE: Populated only on add payment descriptor entry types.
+	BlindingPoint *btcec.PublicKey
+
+	// CustomRecords are the custom TLV records that were provided in the
+	// update_add_htlc.
+	//
+	// NOTE: Populated only on add payment descriptor entry types.
+	CustomRecords lnwire.CustomRecords
+
 	// FailReason stores the reason why a particular payment was canceled.
 	//
 	// NOTE: Populate only in fail payment descriptor entry types.
@@ -434,6 +446,8 @@ func PayDescsFromRemoteLogUpdates(chanID lnwire.ShortChannelID, height uint64,
 			}
 			pd.OnionBlob = make([]byte, len(wireMsg.OnionBlob))
 			copy(pd.OnionBlob[:], wireMsg.OnionBlob[:])
+			pd.BlindingPoint = wireMsg.BlindingPoint
+			pd.CustomRecords = wireMsg.CustomRecords
 
 		case *lnwire.UpdateFulfillHTLC:
 			pd = PaymentDescriptor{
@@ -722,7 +736,9 @@ func (c *commitment) populateHtlcIndexes(chanType channeldb.ChannelType,
 
 // toDiskCommit converts the target commitment into a format suitable to be
 // written to disk after an accepted state transition.
-func (c *commitment) toDiskCommit(ourCommit bool) *channeldb.ChannelCommitment {
+func (c *commitment) toDiskCommit(
+	ourCommit bool) (*channeldb.ChannelCommitment, error) {
+
 	numHtlcs := len(c.outgoingHTLCs) + len(c.incomingHTLCs)
 
 	commit := &channeldb.ChannelCommitment{
@@ -757,6 +773,10 @@ func (c *commitment) toDiskCommit(ourCommit bool) *channeldb.ChannelCommitment {
 		}
 		copy(h.OnionBlob[:], htlc.OnionBlob)
 
+		if err := h.SetBlindingPoint(htlc.BlindingPoint); err != nil {
+			return nil, err
+		}
+
 		if ourCommit && htlc.sig != nil {
 			h.Signature = htlc.sig.Serialize()
 		}
@@ -781,6 +801,10 @@ func (c *commitment) toDiskCommit(ourCommit bool) *channeldb.ChannelCommitment {
 		}
 		copy(h.OnionBlob[:], htlc.OnionBlob)
 
+		if err := h.SetBlindingPoint(htlc.BlindingPoint); err != nil {
+			return nil, err
+		}
+
 		if ourCommit && htlc.sig != nil {
 			h.Signature = htlc.sig.Serialize()
 		}
@@ -788,7 +812,7 @@ func (c *commitment) toDiskCommit(ourCommit bool) *channeldb.ChannelCommitment {
 		commit.Htlcs = append(commit.Htlcs, h)
 	}
 
-	return commit
+	return commit, nil
 }
 
 // diskHtlcToPayDesc converts an HTLC previously written to disk within a
@@ -860,6 +884,13 @@ func (lc *LightningChannel) diskHtlcToPayDesc(feeRate chainfee.SatPerKWeight,
 		remoteOutputIndex = htlc.OutputIndex
 	}
 
+	// The blinding point of HTLCs that are relayed within a blinded route
+	// is stored in the HTLC's extra data.
+	blindingPoint, err := htlc.BlindingPoint()
+	if err != nil {
+		return pd, err
+	}
+
 	// With the scripts reconstructed (depending on if this is our commit
 	// vs theirs or a pending commit for the remote party), we can now
 	// re-create the original payment descriptor.
@@ -871,6 +902,7 @@ func (lc *LightningChannel) diskHtlcToPayDesc(feeRate chainfee.SatPerKWeight,
 		HtlcIndex:          htlc.HtlcIndex,
 		LogIndex:           htlc.LogIndex,
 		OnionBlob:          htlc.OnionBlob[:],
+		BlindingPoint:      blindingPoint,
 		localOutputIndex:   localOutputIndex,
 		remoteOutputIndex:  remoteOutputIndex,
 		ourPkScript:        ourP2WSH,
@@ -1586,6 +1618,8 @@ func (lc *LightningChannel) logUpdateToPayDesc(logUpdate *channeldb.LogUpdate,
 		}
 		pd.OnionBlob = make([]byte, len(wireMsg.OnionBlob))
 		copy(pd.OnionBlob[:], wireMsg.OnionBlob[:])
+		pd.BlindingPoint = wireMsg.BlindingPoint
+		pd.CustomRecords = wireMsg.CustomRecords
 
 		isDustRemote := HtlcIsDust(
 			lc.channelState.ChanType, false, false, feeRate,
@@ -1783,6 +1817,8 @@ func (lc *LightningChannel) remoteLogUpdateToPayDesc(logUpdate *channeldb.LogUpd
 		}
 		pd.OnionBlob = make([]byte, len(wireMsg.OnionBlob))
 		copy(pd.OnionBlob, wireMsg.OnionBlob[:])
+		pd.BlindingPoint = wireMsg.BlindingPoint
+		pd.CustomRecords = wireMsg.CustomRecords
 
 		// We don't need to generate an htlc script yet. This will be
 		// done once we sign our remote commitment.
@@ -3060,7 +3096,7 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 
 	effFeeRate := chainfee.SatPerKWeight(fee) * 1000 /
 		chainfee.SatPerKWeight(weight)
-	if effFeeRate < chainfee.AbsoluteFeePerKwFloor {
+	if effFeeRate < chainfee.AbsoluteFeeFloor() {
 		return nil, fmt.Errorf("height=%v, for ChannelPoint(%v) "+
 			"attempts to create commitment with feerate %v: %v",
 			nextHeight, lc.channelState.FundingOutpoint,
@@ -3635,11 +3671,13 @@ func (lc *LightningChannel) createCommitDiff(
 		switch pd.EntryType {
 		case Add:
 			htlc := &lnwire.UpdateAddHTLC{
-				ChanID:      chanID,
-				ID:          pd.HtlcIndex,
-				Amount:      pd.Amount,
-				Expiry:      pd.Timeout,
-				PaymentHash: pd.RHash,
+				ChanID:        chanID,
+				ID:            pd.HtlcIndex,
+				Amount:        pd.Amount,
+				Expiry:        pd.Timeout,
+				PaymentHash:   pd.RHash,
+				BlindingPoint: pd.BlindingPoint,
+				CustomRecords: pd.CustomRecords,
 			}
 			copy(htlc.OnionBlob[:], pd.OnionBlob)
 			logUpdate.UpdateMsg = htlc
@@ -3709,7 +3747,10 @@ func (lc *LightningChannel) createCommitDiff(
 	// With the set of log updates mapped into wire messages, we'll now
 	// convert the in-memory commit into a format suitable for writing to
 	// disk.
-	diskCommit := newCommit.toDiskCommit(false)
+	diskCommit, err := newCommit.toDiskCommit(false)
+	if err != nil {
+		return nil, err
+	}
 
 	return &channeldb.CommitDiff{
 		Commitment: *diskCommit,
@@ -3772,11 +3813,13 @@ func (lc *LightningChannel) getUnsignedAckedUpdates() []channeldb.LogUpdate {
 		switch pd.EntryType {
 		case Add:
 			htlc := &lnwire.UpdateAddHTLC{
-				ChanID:      chanID,
-				ID:          pd.HtlcIndex,
-				Amount:      pd.Amount,
-				Expiry:      pd.Timeout,
-				PaymentHash: pd.RHash,
+				ChanID:        chanID,
+				ID:            pd.HtlcIndex,
+				Amount:        pd.Amount,
+				Expiry:        pd.Timeout,
+				PaymentHash:   pd.RHash,
+				BlindingPoint: pd.BlindingPoint,
+				CustomRecords: pd.CustomRecords,
 			}
 			copy(htlc.OnionBlob[:], pd.OnionBlob)
 			logUpdate.UpdateMsg = htlc
@@ -3882,9 +3925,9 @@ func (lc *LightningChannel) validateCommitmentSanity(theirLogCounter,
 
 	// Ensure that the fee being applied is enough to be relayed across the
 	// network in a reasonable time frame.
-	if feePerKw < chainfee.FeePerKwFloor {
+	if feePerKw < chainfee.FeeFloor() {
 		return fmt.Errorf("commitment fee per kw %v below fee floor %v",
-			feePerKw, chainfee.FeePerKwFloor)
+			feePerKw, chainfee.FeeFloor())
 	}
 
 	// If the added HTLCs will decrease the balance, make sure they won't
@@ -5359,7 +5402,10 @@ func (lc *LightningChannel) RevokeCurrentCommitment() (*lnwire.RevokeAndAck,
 	// Additionally, generate a channel delta for this state transition for
 	// persistent storage.
 	chainTail := lc.localCommitChain.tail()
-	newCommitment := chainTail.toDiskCommit(true)
+	newCommitment, err := chainTail.toDiskCommit(true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	// Get the unsigned acked remotes updates that are currently in memory.
 	// We need them after a restart to sync our remote commitment with what
@@ -5545,11 +5591,13 @@ func (lc *LightningChannel) ReceiveRevocation(revMsg *lnwire.RevokeAndAck) (
 		switch pd.EntryType {
 		case Add:
 			htlc := &lnwire.UpdateAddHTLC{
-				ChanID:      chanID,
-				ID:          pd.HtlcIndex,
-				Amount:      pd.Amount,
-				Expiry:      pd.Timeout,
-				PaymentHash: pd.RHash,
+				ChanID:        chanID,
+				ID:            pd.HtlcIndex,
+				Amount:        pd.Amount,
+				Expiry:        pd.Timeout,
+				PaymentHash:   pd.RHash,
+				BlindingPoint: pd.BlindingPoint,
+				CustomRecords: pd.CustomRecords,
 			}
 			copy(htlc.OnionBlob[:], pd.OnionBlob)
 			logUpdate.UpdateMsg = htlc
@@ -5860,6 +5908,23 @@ func (lc *LightningChannel) MayAddOutgoingHtlc(amt lnwire.MilliSatoshi) error {
 	return nil
 }
 
+// AvailableOutgoingHtlcSlots returns the number of htlcs we can still offer
+// to the remote party before reaching the maximum number of htlcs in flight.
+// Offered htlcs that are pending removal still occupy a slot until they're
+// removed from our update log.
+func (lc *LightningChannel) AvailableOutgoingHtlcSlots() uint16 {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	maxHtlcs := lc.channelState.LocalChanCfg.MaxAcceptedHtlcs
+	numOffered := len(lc.localUpdateLog.htlcIndex)
+	if numOffered >= int(maxHtlcs) {
+		return 0
+	}
+
+	return maxHtlcs - uint16(numOffered)
+}
+
 // htlcAddDescriptor returns a payment descriptor for the htlc and open key
 // provided to add to our local update log.
 func (lc *LightningChannel) htlcAddDescriptor(htlc *lnwire.UpdateAddHTLC,
@@ -5874,6 +5939,8 @@ func (lc *LightningChannel) htlcAddDescriptor(htlc *lnwire.UpdateAddHTLC,
 		HtlcIndex:      lc.localUpdateLog.htlcCounter,
 		OnionBlob:      htlc.OnionBlob[:],
 		OpenCircuitKey: openKey,
+		BlindingPoint:  htlc.BlindingPoint,
+		CustomRecords:  htlc.CustomRecords,
 	}
 }
 
@@ -5922,13 +5989,15 @@ func (lc *LightningChannel) ReceiveHTLC(htlc *lnwire.UpdateAddHTLC) (uint64, err
 	}
 
 	pd := &PaymentDescriptor{
-		EntryType: Add,
-		RHash:     PaymentHash(htlc.PaymentHash),
-		Timeout:   htlc.Expiry,
-		Amount:    htlc.Amount,
-		LogIndex:  lc.remoteUpdateLog.logIndex,
-		HtlcIndex: lc.remoteUpdateLog.htlcCounter,
-		OnionBlob: htlc.OnionBlob[:],
+		EntryType:     Add,
+		RHash:         PaymentHash(htlc.PaymentHash),
+		Timeout:       htlc.Expiry,
+		Amount:        htlc.Amount,
+		LogIndex:      lc.remoteUpdateLog.logIndex,
+		HtlcIndex:     lc.remoteUpdateLog.htlcCounter,
+		OnionBlob:     htlc.OnionBlob[:],
+		BlindingPoint: htlc.BlindingPoint,
+		CustomRecords: htlc.CustomRecords,
 	}
 
 	localACKedIndex := lc.remoteCommitChain.tail().ourMessageIndex
@@ -6127,14 +6196,26 @@ func (lc *LightningChannel) FailHTLC(htlcIndex uint64, reason []byte,
 // commitment update. This method is intended to be called in order to cancel
 // in _incoming_ HTLC.
 //
-// The additional sourceRef specifies the location of the Add HTLC within a
-// forwarding package that this HTLC is failing. This value should never be
-// empty.
+// The additional arguments correspond to:
 //
-// NOTE: It is okay for sourceRef to be nil when unit testing the wallet.
+//   - sourceRef: specifies the location of the Add HTLC within a forwarding
+//     package that this HTLC is failing. This value should never be empty.
+//
+//   - destRef: specifies the location of the Fail HTLC within another
+//     channel's forwarding package. This value can be nil if the corresponding
+//     Add HTLC was never locked into an outgoing commitment txn.
+//
+//   - closeKey: identifies the circuit that should be deleted after this Fail
+//     HTLC is included in a commitment txn. This value should only be nil if
+//     the HTLC was failed locally before committing a circuit to the circuit
+//     map.
+//
+// NOTE: It is okay for sourceRef, destRef, and closeKey to be nil when unit
+// testing the wallet.
 func (lc *LightningChannel) MalformedFailHTLC(htlcIndex uint64,
 	failCode lnwire.FailCode, shaOnionBlob [sha256.Size]byte,
-	sourceRef *channeldb.AddRef) error {
+	sourceRef *channeldb.AddRef, destRef *channeldb.SettleFailRef,
+	closeKey *models.CircuitKey) error {
 
 	lc.Lock()
 	defer lc.Unlock()
@@ -6151,14 +6232,16 @@ func (lc *LightningChannel) MalformedFailHTLC(htlcIndex uint64,
 	}
 
 	pd := &PaymentDescriptor{
-		Amount:       htlc.Amount,
-		RHash:        htlc.RHash,
-		ParentIndex:  htlcIndex,
-		LogIndex:     lc.localUpdateLog.logIndex,
-		EntryType:    MalformedFail,
-		FailCode:     failCode,
-		ShaOnionBlob: shaOnionBlob,
-		SourceRef:    sourceRef,
+		Amount:           htlc.Amount,
+		RHash:            htlc.RHash,
+		ParentIndex:      htlcIndex,
+		LogIndex:         lc.localUpdateLog.logIndex,
+		EntryType:        MalformedFail,
+		FailCode:         failCode,
+		ShaOnionBlob:     shaOnionBlob,
+		SourceRef:        sourceRef,
+		DestRef:          destRef,
+		ClosedCircuitKey: closeKey,
 	}
 
 	lc.localUpdateLog.appendUpdate(pd)
@@ -6171,6 +6254,23 @@ func (lc *LightningChannel) MalformedFailHTLC(htlcIndex uint64,
 	return nil
 }
 
+// IncomingHtlcBlinding returns the blinding point and the onion blob that were
+// provided with the incoming HTLC of the given index. The blinding point is
+// nil if the HTLC isn't relayed within a blinded route.
+func (lc *LightningChannel) IncomingHtlcBlinding(
+	htlcIndex uint64) (*btcec.PublicKey, []byte, error) {
+
+	lc.RLock()
+	defer lc.RUnlock()
+
+	htlc := lc.remoteUpdateLog.lookupHtlc(htlcIndex)
+	if htlc == nil {
+		return nil, nil, ErrUnknownHtlcIndex{lc.ShortChanID(), htlcIndex}
+	}
+
+	return htlc.BlindingPoint, htlc.OnionBlob, nil
+}
+
 // ReceiveFailHTLC attempts to cancel a targeted HTLC by its log index,
 // inserting an entry which will remove the target log entry within the next
 // commitment update. This method should be called in response to the upstream
@@ -7531,6 +7631,16 @@ func NewLocalForceCloseSummary(chanState *channeldb.OpenChannel,
 // close process.
 type chanCloseOpt struct {
 	musigSession *MusigSession
+
+	// feePayer is the party that pays the fee of the close transaction.
+	feePayer CloseFeePayer
+
+	// enableRBF indicates whether the close transaction should signal RBF,
+	// so a close fee can be bumped after broadcast.
+	enableRBF bool
+
+	// lockTime is the lock time of the close transaction.
+	lockTime uint32
 }
 
 // ChanCloseOpt is a closure type that cen be used to modify the set of default
@@ -7551,6 +7661,54 @@ func WithCoopCloseMusigSession(session *MusigSession) ChanCloseOpt {
 	}
 }
 
+// WithCoopCloseFeePayer can be used to specify which party pays the fee of the
+// close transaction. By default, the initiator of the channel pays the fee.
+// With RBF based close negotiation, each party pays the fee of the close
+// transactions it proposes, which lets either party bump the fee on its own.
+func WithCoopCloseFeePayer(payer CloseFeePayer) ChanCloseOpt {
+	return func(opts *chanCloseOpt) {
+		opts.feePayer = payer
+	}
+}
+
+// WithCoopCloseRBF signals that the close transaction should be replaceable,
+// so it can be superseded by a close transaction with a higher fee after
+// broadcast.
+func WithCoopCloseRBF() ChanCloseOpt {
+	return func(opts *chanCloseOpt) {
+		opts.enableRBF = true
+	}
+}
+
+// WithCoopCloseLockTime sets the lock time of the close transaction. With RBF
+// based close negotiation, the proposer of a close transaction picks its lock
+// time.
+func WithCoopCloseLockTime(lockTime uint32) ChanCloseOpt {
+	return func(opts *chanCloseOpt) {
+		opts.lockTime = lockTime
+	}
+}
+
+// closeTxOpts returns the options for creating the close transaction that
+// follow from the channel type and the given close options.
+func (lc *LightningChannel) closeTxOpts(opts *chanCloseOpt) []CloseTxOpt {
+	var closeTxOpts []CloseTxOpt
+
+	// If this is a taproot channel, or an RBF'able close was requested,
+	// then we use an RBF'able funding input.
+	if lc.channelState.ChanType.IsTaproot() || opts.enableRBF {
+		closeTxOpts = append(closeTxOpts, WithRBFCloseTx())
+	}
+
+	if opts.lockTime != 0 {
+		closeTxOpts = append(
+			closeTxOpts, WithCloseTxLockTime(opts.lockTime),
+		)
+	}
+
+	return closeTxOpts
+}
+
 // CreateCloseProposal is used by both parties in a cooperative channel close
 // workflow to generate proposed close transactions and signatures. This method
 // should only be executed once all pending HTLCs (if any) on the channel have
@@ -7569,39 +7727,35 @@ func (lc *LightningChannel) CreateCloseProposal(proposedFee ltcutil.Amount,
 	lc.Lock()
 	defer lc.Unlock()
 
-	// If we've already closed the channel, then ignore this request.
-	if lc.status == channelClosed {
-		// TODO(roasbeef): check to ensure no pending payments
-		return nil, nil, 0, ErrChanClosing
-	}
-
 	opts := defaultCloseOpts()
 	for _, optFunc := range closeOpts {
 		optFunc(opts)
 	}
 
+	// If we've already closed the channel, then ignore this request,
+	// unless this is an RBF'able close which may replace a prior close
+	// transaction.
+	if lc.status == channelClosed && !opts.enableRBF {
+		// TODO(roasbeef): check to ensure no pending payments
+		return nil, nil, 0, ErrChanClosing
+	}
+
 	// Get the final balances after subtracting the proposed fee, taking
 	// care not to persist the adjusted balance, as the feeRate may change
 	// during the channel closing process.
 	ourBalance, theirBalance, err := CoopCloseBalance(
 		lc.channelState.ChanType, lc.channelState.IsInitiator,
-		proposedFee, lc.channelState.LocalCommitment,
+		proposedFee, lc.channelState.LocalCommitment, opts.feePayer,
 	)
 	if err != nil {
 		return nil, nil, 0, err
 	}
 
-	var closeTxOpts []CloseTxOpt
-
-	// If this is a taproot channel, then we use an RBF'able funding input.
-	if lc.channelState.ChanType.IsTaproot() {
-		closeTxOpts = append(closeTxOpts, WithRBFCloseTx())
-	}
-
 	closeTx := CreateCooperativeCloseTx(
 		fundingTxIn(lc.channelState), lc.channelState.LocalChanCfg.DustLimit,
 		lc.channelState.RemoteChanCfg.DustLimit, ourBalance, theirBalance,
-		localDeliveryScript, remoteDeliveryScript, closeTxOpts...,
+		localDeliveryScript, remoteDeliveryScript,
+		lc.closeTxOpts(opts)...,
 	)
 
 	// Ensure that the transaction doesn't explicitly violate any
@@ -7657,40 +7811,37 @@ func (lc *LightningChannel) CompleteCooperativeClose(
 	lc.Lock()
 	defer lc.Unlock()
 
-	// If the channel is already closed, then ignore this request.
-	if lc.status == channelClosed {
-		// TODO(roasbeef): check to ensure no pending payments
-		return nil, 0, ErrChanClosing
-	}
-
 	opts := defaultCloseOpts()
 	for _, optFunc := range closeOpts {
 		optFunc(opts)
 	}
 
+	// If the channel is already closed, then ignore this request, unless
+	// this is an RBF'able close which may replace a prior close
+	// transaction.
+	if lc.status == channelClosed && !opts.enableRBF {
+		// TODO(roasbeef): check to ensure no pending payments
+		return nil, 0, ErrChanClosing
+	}
+
 	// Get the final balances after subtracting the proposed fee.
 	ourBalance, theirBalance, err := CoopCloseBalance(
 		lc.channelState.ChanType, lc.channelState.IsInitiator,
-		proposedFee, lc.channelState.LocalCommitment,
+		proposedFee, lc.channelState.LocalCommitment, opts.feePayer,
 	)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	var closeTxOpts []CloseTxOpt
-
-	// If this is a taproot channel, then we use an RBF'able funding input.
-	if lc.channelState.ChanType.IsTaproot() {
-		closeTxOpts = append(closeTxOpts, WithRBFCloseTx())
-	}
-
 	// Create the transaction used to return the current settled balance
-	// on this active channel back to both parties. In this current model,
-	// the initiator pays full fees for the cooperative close transaction.
+	// on this active channel back to both parties. Unless specified
+	// otherwise, the initiator pays full fees for the cooperative close
+	// transaction.
 	closeTx := CreateCooperativeCloseTx(
 		fundingTxIn(lc.channelState), lc.channelState.LocalChanCfg.DustLimit,
 		lc.channelState.RemoteChanCfg.DustLimit, ourBalance, theirBalance,
-		localDeliveryScript, remoteDeliveryScript, closeTxOpts...,
+		localDeliveryScript, remoteDeliveryScript,
+		lc.closeTxOpts(opts)...,
 	)
 
 	// Ensure that the transaction doesn't explicitly validate any
@@ -8313,6 +8464,9 @@ type closeTxOpts struct {
 	// enableRBF indicates whether the cooperative close tx should signal
 	// RBF or not.
 	enableRBF bool
+
+	// lockTime is the lock time of the cooperative close tx.
+	lockTime uint32
 }
 
 // defaultCloseTxOpts returns a closeTxOpts struct with default values.
@@ -8333,6 +8487,13 @@ func WithRBFCloseTx() CloseTxOpt {
 	}
 }
 
+// WithCloseTxLockTime sets the lock time of the cooperative close tx.
+func WithCloseTxLockTime(lockTime uint32) CloseTxOpt {
+	return func(o *closeTxOpts) {
+		o.lockTime = lockTime
+	}
+}
+
 // CreateCooperativeCloseTx creates a transaction which if signed by both
 // parties, then broadcast cooperatively closes an active channel. The creation
 // of the closure transaction is modified by a boolean indicating if the party
@@ -8360,6 +8521,7 @@ func CreateCooperativeCloseTx(fundingTxIn wire.TxIn,
 	// within the channel then a refund output for that particular side can
 	// be omitted.
 	closeTx := wire.NewMsgTx(2)
+	closeTx.LockTime = opts.lockTime
 	closeTx.AddTxIn(&fundingTxIn)
 
 	// Create both cooperative closure outputs, properly respecting the
@@ -8454,7 +8616,7 @@ func (lc *LightningChannel) MaxFeeRate(maxAllocation float64) chainfee.SatPerKWe
 	// Ensure the fee rate doesn't dip below the fee floor.
 	maxFeeRate := maxFee / (float64(weight) / 1000)
 	return chainfee.SatPerKWeight(
-		math.Max(maxFeeRate, float64(chainfee.FeePerKwFloor)),
+		math.Max(maxFeeRate, float64(chainfee.FeeFloor())),
 	)
 }
 
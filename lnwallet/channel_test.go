@@ -23,6 +23,7 @@ import (
 	"github.com/ltcsuite/ltcd/btcec/v2/schnorr/musig2"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
 	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/mempool"
 	"github.com/ltcsuite/ltcd/txscript"
 	"github.com/ltcsuite/ltcd/wire"
 	"github.com/stretchr/testify/require"
@@ -799,6 +800,87 @@ func testCoopClose(t *testing.T, testCase *coopCloseTestCase) {
 	}
 }
 
+// TestCooperativeCloseFeePayer tests that a close transaction can be paid for
+// by the party that isn't the channel initiator, and that it signals RBF and
+// uses the requested lock time so it can be fee bumped after broadcast.
+func TestCooperativeCloseFeePayer(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, err := CreateTestChannels(
+		t, channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err, "unable to create test channels")
+
+	aliceDeliveryScript := bobsPrivKey[:]
+	bobDeliveryScript := testHdSeed[:]
+
+	const (
+		closeFee = ltcutil.Amount(5_000)
+		lockTime = 800_000
+	)
+
+	// Bob, who isn't the initiator, proposes a close transaction that he
+	// pays the fee for himself.
+	bobSig, _, _, err := bobChannel.CreateCloseProposal(
+		closeFee, bobDeliveryScript, aliceDeliveryScript,
+		WithCoopCloseFeePayer(CloseFeePayerLocal), WithCoopCloseRBF(),
+		WithCoopCloseLockTime(lockTime),
+	)
+	require.NoError(t, err, "unable to create bob coop close proposal")
+
+	aliceSig, _, _, err := aliceChannel.CreateCloseProposal(
+		closeFee, aliceDeliveryScript, bobDeliveryScript,
+		WithCoopCloseFeePayer(CloseFeePayerRemote), WithCoopCloseRBF(),
+		WithCoopCloseLockTime(lockTime),
+	)
+	require.NoError(t, err, "unable to create alice coop close proposal")
+
+	closeTx, aliceTxBalance, err := aliceChannel.CompleteCooperativeClose(
+		aliceSig, bobSig, aliceDeliveryScript, bobDeliveryScript,
+		closeFee, WithCoopCloseFeePayer(CloseFeePayerRemote),
+		WithCoopCloseRBF(), WithCoopCloseLockTime(lockTime),
+	)
+	require.NoError(t, err, "unable to complete alice cooperative close")
+
+	require.EqualValues(t, lockTime, closeTx.LockTime)
+	require.EqualValues(
+		t, mempool.MaxRBFSequence, closeTx.TxIn[0].Sequence,
+	)
+
+	// Alice is the initiator, so she gets her commitment fee back, but
+	// doesn't pay for the close.
+	localCommit := aliceChannel.channelState.LocalCommitment
+	expBalanceAlice := localCommit.LocalBalance.ToSatoshis() +
+		localCommit.CommitFee
+	require.Equal(t, expBalanceAlice, aliceTxBalance)
+
+	_, bobTxBalance, err := bobChannel.CompleteCooperativeClose(
+		bobSig, aliceSig, bobDeliveryScript, aliceDeliveryScript,
+		closeFee, WithCoopCloseFeePayer(CloseFeePayerLocal),
+		WithCoopCloseRBF(), WithCoopCloseLockTime(lockTime),
+	)
+	require.NoError(t, err, "unable to complete bob cooperative close")
+
+	expBalanceBob := bobChannel.channelState.LocalCommitment.
+		LocalBalance.ToSatoshis() - closeFee
+	require.Equal(t, expBalanceBob, bobTxBalance)
+
+	// As the close transaction signals RBF, Bob can propose a replacement
+	// with a higher fee after the close was completed.
+	_, _, _, err = bobChannel.CreateCloseProposal(
+		2*closeFee, bobDeliveryScript, aliceDeliveryScript,
+		WithCoopCloseFeePayer(CloseFeePayerLocal), WithCoopCloseRBF(),
+		WithCoopCloseLockTime(lockTime),
+	)
+	require.NoError(t, err, "unable to create replacement proposal")
+
+	// A close transaction that doesn't signal RBF can't be replaced.
+	_, _, _, err = bobChannel.CreateCloseProposal(
+		2*closeFee, bobDeliveryScript, aliceDeliveryScript,
+	)
+	require.ErrorIs(t, err, ErrChanClosing)
+}
+
 // TestForceClose checks that the resulting ForceCloseSummary is correct when a
 // peer is ForceClosing the channel. Will check outputs both above and below
 // the dust limit. Additionally, we'll ensure that the node which executed the
@@ -6932,6 +7014,59 @@ func restoreAndAssert(t *testing.T, channel *LightningChannel, numAddsLocal,
 	assertInLog(t, newChannel.remoteUpdateLog, numAddsRemote, numFailsRemote)
 }
 
+// TestChannelRestoreBlindingPoint asserts that the blinding point of an HTLC
+// that is relayed within a blinded route is restored from the commitment after
+// a restart, and that the HTLC can be failed as malformed.
+func TestChannelRestoreBlindingPoint(t *testing.T) {
+	t.Parallel()
+
+	aliceChannel, bobChannel, err := CreateTestChannels(
+		t, channeldb.SingleFunderTweaklessBit,
+	)
+	require.NoError(t, err, "unable to create test channels")
+
+	blindingPriv, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	// Add an HTLC with a blinding point from Alice to Bob, and lock it in
+	// for both.
+	htlcAmount := lnwire.NewMSatFromSatoshis(20000)
+	htlcAlice, _ := createHTLC(0, htlcAmount)
+	htlcAlice.BlindingPoint = blindingPriv.PubKey()
+	_, err = aliceChannel.AddHTLC(htlcAlice, nil)
+	require.NoError(t, err, "alice unable to add htlc")
+	_, err = bobChannel.ReceiveHTLC(htlcAlice)
+	require.NoError(t, err, "bob unable to recv add htlc")
+
+	err = ForceStateTransition(aliceChannel, bobChannel)
+	require.NoError(t, err, "unable to complete state update")
+
+	// After a restart, Bob restores the HTLC from his commitment, which
+	// must include the blinding point.
+	bobChannel, err = restartChannel(bobChannel)
+	require.NoError(t, err, "unable to restart bob")
+
+	blindingPoint, onionBlob, err := bobChannel.IncomingHtlcBlinding(0)
+	require.NoError(t, err)
+	require.True(t, htlcAlice.BlindingPoint.IsEqual(blindingPoint))
+	require.Equal(t, htlcAlice.OnionBlob[:], onionBlob)
+
+	_, _, err = bobChannel.IncomingHtlcBlinding(1)
+	require.IsType(t, ErrUnknownHtlcIndex{}, err)
+
+	// Bob fails the HTLC as malformed, which Alice must accept.
+	err = bobChannel.MalformedFailHTLC(
+		0, lnwire.CodeInvalidBlinding, sha256.Sum256(onionBlob), nil,
+		nil, nil,
+	)
+	require.NoError(t, err, "bob unable to fail htlc")
+	err = aliceChannel.ReceiveFailHTLC(0, []byte{})
+	require.NoError(t, err, "alice unable to recv fail")
+
+	err = ForceStateTransition(bobChannel, aliceChannel)
+	require.NoError(t, err, "unable to complete state update")
+}
+
 // TestChannelRestoreUpdateLogsFailedHTLC runs through a scenario where an
 // HTLC is added and failed, and asserts along the way that we would restore
 // the update logs of the channel to the expected state at any point.
@@ -313,7 +313,7 @@ func CommitScriptToRemote(chanType channeldb.ChannelType, initiator bool,
 		return &WitnessScriptDesc{
 			OutputScript:  p2wsh,
 			WitnessScript: script,
-		}, 1, nil
+		}, input.ToRemoteConfirmedDelay, nil
 
 	// For taproot channels, we'll use a slightly different format, where
 	// we use a NUMS key to force the remote party to take a script path,
@@ -326,7 +326,7 @@ func CommitScriptToRemote(chanType channeldb.ChannelType, initiator bool,
 			return nil, 0, err
 		}
 
-		return toRemoteScriptTree, 1, nil
+		return toRemoteScriptTree, input.ToRemoteConfirmedDelay, nil
 
 	// If this channel type has anchors, we derive the delayed to_remote
 	// script.
@@ -344,7 +344,7 @@ func CommitScriptToRemote(chanType channeldb.ChannelType, initiator bool,
 		return &WitnessScriptDesc{
 			OutputScript:  p2wsh,
 			WitnessScript: script,
-		}, 1, nil
+		}, input.ToRemoteConfirmedDelay, nil
 
 	default:
 		// Otherwise the to_remote will be a simple p2wkh.
@@ -958,11 +958,30 @@ func CreateCommitTx(chanType channeldb.ChannelType,
 	return commitTx, nil
 }
 
+// CloseFeePayer denotes which party pays the fee of a cooperative close
+// transaction.
+type CloseFeePayer uint8
+
+const (
+	// CloseFeePayerInitiator denotes that the initiator of the channel pays
+	// the fee, as done in the legacy fee range negotiation.
+	CloseFeePayerInitiator CloseFeePayer = iota
+
+	// CloseFeePayerLocal denotes that the local party pays the fee. With
+	// RBF based close negotiation, the party that proposes a close
+	// transaction pays its fee.
+	CloseFeePayerLocal
+
+	// CloseFeePayerRemote denotes that the remote party pays the fee.
+	CloseFeePayerRemote
+)
+
 // CoopCloseBalance returns the final balances that should be used to create
-// the cooperative close tx, given the channel type and transaction fee.
+// the cooperative close tx, given the channel type, transaction fee and the
+// party that pays the fee.
 func CoopCloseBalance(chanType channeldb.ChannelType, isInitiator bool,
-	coopCloseFee ltcutil.Amount, localCommit channeldb.ChannelCommitment) (
-	ltcutil.Amount, ltcutil.Amount, error) {
+	coopCloseFee ltcutil.Amount, localCommit channeldb.ChannelCommitment,
+	feePayer CloseFeePayer) (ltcutil.Amount, ltcutil.Amount, error) {
 
 	// Get both parties' balances from the latest commitment.
 	ourBalance := localCommit.LocalBalance.ToSatoshis()
@@ -978,21 +997,34 @@ func CoopCloseBalance(chanType channeldb.ChannelType, isInitiator bool,
 		initiatorDelta += 2 * anchorSize
 	}
 
-	// The initiator will pay the full coop close fee, subtract that value
-	// from their balance.
-	initiatorDelta -= coopCloseFee
-
 	if isInitiator {
 		ourBalance += initiatorDelta
 	} else {
 		theirBalance += initiatorDelta
 	}
 
-	// During fee negotiation it should always be verified that the
-	// initiator can pay the proposed fee, but we do a sanity check just to
-	// be sure here.
+	// Subtract the full coop close fee from the balance of the party that
+	// pays it, which is the initiator unless specified otherwise.
+	localPays := isInitiator
+	switch feePayer {
+	case CloseFeePayerLocal:
+		localPays = true
+
+	case CloseFeePayerRemote:
+		localPays = false
+	}
+
+	if localPays {
+		ourBalance -= coopCloseFee
+	} else {
+		theirBalance -= coopCloseFee
+	}
+
+	// During fee negotiation it should always be verified that the fee
+	// payer can pay the proposed fee, but we do a sanity check just to be
+	// sure here.
 	if ourBalance < 0 || theirBalance < 0 {
-		return 0, 0, fmt.Errorf("initiator cannot afford proposed " +
+		return 0, 0, fmt.Errorf("fee payer cannot afford proposed " +
 			"coop close fee")
 	}
 
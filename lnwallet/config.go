@@ -6,6 +6,7 @@ import (
 	"github.com/ltcsuite/lnd/input"
 	"github.com/ltcsuite/lnd/keychain"
 	"github.com/ltcsuite/lnd/lnwallet/chainfee"
+	"github.com/ltcsuite/lnd/lnwallet/chanfunding"
 	"github.com/ltcsuite/ltcd/chaincfg"
 )
 
@@ -57,6 +58,11 @@ type Config struct {
 	// it will be operating on.
 	NetParams chaincfg.Params
 
+	// CoinSelectionStrategy is the strategy that is used for selecting
+	// coins when funding channels. If nil, the largest coins are selected
+	// first.
+	CoinSelectionStrategy chanfunding.CoinSelectionStrategy
+
 	// Rebroadcaster is an optional config param that can be used to
 	// passively rebroadcast transactions in the background until they're
 	// detected as being confirmed.
@@ -0,0 +1,258 @@
+package hwi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ltcsuite/ltcd/ltcutil/psbt"
+)
+
+const (
+	// DefaultBinaryPath is the name of the HWI-compatible bridge binary
+	// that is looked up in the PATH if no explicit path is configured.
+	DefaultBinaryPath = "hwi"
+
+	// DefaultTimeout is the default time we give the bridge to complete a
+	// command. Signing requires the user to confirm the transaction on the
+	// device, so this needs to be generous.
+	DefaultTimeout = 5 * time.Minute
+)
+
+var (
+	// ErrNotSigned is returned if the bridge completed the signing
+	// command without adding any signatures, for example because the user
+	// rejected the transaction on the device.
+	ErrNotSigned = errors.New("transaction was not signed by the device")
+)
+
+// Config holds the configuration of the bridge to a hardware wallet.
+type Config struct {
+	// BinaryPath is the path to the HWI-compatible bridge binary.
+	BinaryPath string
+
+	// Chain is the name of the chain the device should sign for, as
+	// understood by the bridge's --chain flag (main, test, signet or
+	// regtest).
+	Chain string
+
+	// Fingerprint is the hex encoded master key fingerprint of the device
+	// to use.
+	Fingerprint string
+
+	// Timeout is the maximum time a single bridge command may take.
+	Timeout time.Duration
+}
+
+// Device describes a hardware wallet as reported by the bridge's enumerate
+// command.
+type Device struct {
+	// Type is the type of the device, for example "ledger" or "trezor".
+	Type string `json:"type"`
+
+	// Model is the model of the device.
+	Model string `json:"model"`
+
+	// Path is the path the device is connected on.
+	Path string `json:"path"`
+
+	// Fingerprint is the hex encoded master key fingerprint of the device.
+	// This is empty if the device is locked.
+	Fingerprint string `json:"fingerprint"`
+
+	// NeedsPinSent is true if the device must be unlocked with a PIN
+	// before it can be used.
+	NeedsPinSent bool `json:"needs_pin_sent"`
+
+	// NeedsPassphraseSent is true if a passphrase must be sent to the
+	// device before it can be used.
+	NeedsPassphraseSent bool `json:"needs_passphrase_sent"`
+
+	// Error is set if the bridge failed to communicate with the device.
+	Error string `json:"error,omitempty"`
+}
+
+// bridgeError is the error object the bridge returns if a command fails.
+type bridgeError struct {
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+}
+
+// signTxResult is the result of the bridge's signtx command.
+type signTxResult struct {
+	Psbt   string `json:"psbt"`
+	Signed bool   `json:"signed"`
+}
+
+// commandRunner runs the bridge with the given arguments and returns what it
+// wrote to stdout.
+type commandRunner func(ctx context.Context, args ...string) ([]byte, error)
+
+// Bridge talks to a hardware wallet by shelling out to an HWI-compatible
+// bridge binary.
+type Bridge struct {
+	cfg *Config
+
+	run commandRunner
+}
+
+// NewBridge creates a new bridge to the hardware wallet described by the
+// given config.
+func NewBridge(cfg *Config) *Bridge {
+	if cfg.BinaryPath == "" {
+		cfg.BinaryPath = DefaultBinaryPath
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	return &Bridge{
+		cfg: cfg,
+		run: func(ctx context.Context, args ...string) ([]byte, error) {
+			cmd := exec.CommandContext(ctx, cfg.BinaryPath, args...)
+			return cmd.Output()
+		},
+	}
+}
+
+// Fingerprint returns the master key fingerprint of the configured device in
+// the byte order used by the BIP32 derivation fields of a PSBT.
+func (b *Bridge) Fingerprint() (uint32, error) {
+	fingerprint, err := hex.DecodeString(b.cfg.Fingerprint)
+	if err != nil || len(fingerprint) != 4 {
+		return 0, fmt.Errorf("invalid device fingerprint %q",
+			b.cfg.Fingerprint)
+	}
+
+	return binary.LittleEndian.Uint32(fingerprint), nil
+}
+
+// command runs a bridge command with the global flags of our config and
+// returns its output. If the bridge reports an error, it is returned instead.
+func (b *Bridge) command(ctx context.Context, withDevice bool,
+	args ...string) ([]byte, error) {
+
+	ctxt, cancel := context.WithTimeout(ctx, b.cfg.Timeout)
+	defer cancel()
+
+	var flags []string
+	if b.cfg.Chain != "" {
+		flags = append(flags, "--chain", b.cfg.Chain)
+	}
+	if withDevice {
+		flags = append(flags, "--fingerprint", b.cfg.Fingerprint)
+	}
+
+	output, runErr := b.run(ctxt, append(flags, args...)...)
+
+	// The bridge reports errors as a JSON object on stdout, which is more
+	// descriptive than its exit status, so we'll check for one first.
+	var bErr bridgeError
+	trimmed := bytes.TrimSpace(output)
+	if bytes.HasPrefix(trimmed, []byte("{")) &&
+		json.Unmarshal(trimmed, &bErr) == nil && bErr.Error != "" {
+
+		return nil, fmt.Errorf("bridge error (code %d): %s", bErr.Code,
+			bErr.Error)
+	}
+
+	if runErr != nil {
+		return nil, fmt.Errorf("unable to run bridge command %v: %w",
+			args[0], runErr)
+	}
+
+	return output, nil
+}
+
+// Enumerate returns all hardware wallets the bridge can find.
+func (b *Bridge) Enumerate(ctx context.Context) ([]Device, error) {
+	output, err := b.command(ctx, false, "enumerate")
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []Device
+	if err := json.Unmarshal(output, &devices); err != nil {
+		return nil, fmt.Errorf("unable to parse devices: %w", err)
+	}
+
+	return devices, nil
+}
+
+// CheckDevice makes sure the configured device is connected and unlocked.
+func (b *Bridge) CheckDevice(ctx context.Context) error {
+	devices, err := b.Enumerate(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, device := range devices {
+		if !strings.EqualFold(device.Fingerprint, b.cfg.Fingerprint) {
+			continue
+		}
+
+		if device.Error != "" {
+			return fmt.Errorf("hardware wallet %s: %s",
+				b.cfg.Fingerprint, device.Error)
+		}
+
+		log.Infof("Found %s hardware wallet %s at %s", device.Model,
+			device.Fingerprint, device.Path)
+
+		return nil
+	}
+
+	return fmt.Errorf("hardware wallet %s not found, make sure it is "+
+		"connected and unlocked", b.cfg.Fingerprint)
+}
+
+// SignPsbt hands the given packet to the device for signing and returns the
+// packet with the device's signatures added. The user must confirm the
+// transaction on the device.
+func (b *Bridge) SignPsbt(ctx context.Context,
+	packet *psbt.Packet) (*psbt.Packet, error) {
+
+	if _, err := b.Fingerprint(); err != nil {
+		return nil, err
+	}
+
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode PSBT: %w", err)
+	}
+
+	output, err := b.command(ctx, true, "signtx", encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var result signTxResult
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("unable to parse signing result: %w", err)
+	}
+	if !result.Signed {
+		return nil, ErrNotSigned
+	}
+
+	signedPacket, err := psbt.NewFromRawBytes(
+		strings.NewReader(result.Psbt), true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse signed PSBT: %w", err)
+	}
+
+	// Make sure the device didn't sign a different transaction than the
+	// one we handed to it.
+	if signedPacket.UnsignedTx.TxHash() != packet.UnsignedTx.TxHash() {
+		return nil, fmt.Errorf("device signed a different transaction")
+	}
+
+	return signedPacket, nil
+}
@@ -0,0 +1,126 @@
+package hwi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBridge returns a bridge that hands the arguments of each command to
+// the given runner instead of executing a binary.
+func newTestBridge(run commandRunner) *Bridge {
+	bridge := NewBridge(&Config{
+		Chain:       "test",
+		Fingerprint: "73c5da0a",
+	})
+	bridge.run = run
+
+	return bridge
+}
+
+// TestBridgeEnumerate tests that the devices reported by the bridge are parsed
+// and that bridge errors are surfaced.
+func TestBridgeEnumerate(t *testing.T) {
+	t.Parallel()
+
+	var args []string
+	bridge := newTestBridge(func(_ context.Context,
+		a ...string) ([]byte, error) {
+
+		args = a
+		return []byte(`[{"type": "trezor", "model": "trezor_t", ` +
+			`"path": "webusb:000:1", "fingerprint": "73c5da0a", ` +
+			`"needs_pin_sent": false, ` +
+			`"needs_passphrase_sent": true}]`), nil
+	})
+
+	devices, err := bridge.Enumerate(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"--chain", "test", "enumerate"}, args)
+	require.Equal(t, []Device{{
+		Type:                "trezor",
+		Model:               "trezor_t",
+		Path:                "webusb:000:1",
+		Fingerprint:         "73c5da0a",
+		NeedsPassphraseSent: true,
+	}}, devices)
+
+	// The error object of the bridge takes precedence over its exit
+	// status.
+	bridge = newTestBridge(func(context.Context, ...string) ([]byte,
+		error) {
+
+		return []byte(`{"error": "No device found", "code": -3}`),
+			errors.New("exit status 1")
+	})
+
+	_, err = bridge.Enumerate(context.Background())
+	require.ErrorContains(t, err, "No device found")
+}
+
+// TestBridgeFingerprint tests the conversion of the configured fingerprint to
+// the byte order of a PSBT's derivation paths.
+func TestBridgeFingerprint(t *testing.T) {
+	t.Parallel()
+
+	bridge := newTestBridge(nil)
+	fingerprint, err := bridge.Fingerprint()
+	require.NoError(t, err)
+	require.Equal(t, uint32(0x0ada_c573), fingerprint)
+
+	bridge.cfg.Fingerprint = "73c5da"
+	_, err = bridge.Fingerprint()
+	require.Error(t, err)
+}
+
+// TestBridgeCheckDevice tests that the configured device is only accepted if
+// it is connected and unlocked.
+func TestBridgeCheckDevice(t *testing.T) {
+	t.Parallel()
+
+	devices := `[{"type": "ledger", "model": "ledger_nano_s", ` +
+		`"path": "0001:0005:00", "fingerprint": "%s"}]`
+
+	testCases := []struct {
+		name        string
+		fingerprint string
+		expectErr   bool
+	}{{
+		name:        "connected",
+		fingerprint: "73C5DA0A",
+	}, {
+		name:        "other device",
+		fingerprint: "0b1f8e2c",
+		expectErr:   true,
+	}, {
+		name:        "locked",
+		fingerprint: "",
+		expectErr:   true,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			bridge := newTestBridge(func(context.Context,
+				...string) ([]byte, error) {
+
+				return []byte(fmt.Sprintf(
+					devices, tc.fingerprint,
+				)), nil
+			})
+
+			err := bridge.CheckDevice(context.Background())
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package hwi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ltcsuite/lnd/input"
+	"github.com/ltcsuite/lnd/lnwallet/chainfee"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/ltcutil/psbt"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+var (
+	// ErrPolicyViolation is returned if a transaction pays to outputs the
+	// signing policy doesn't allow.
+	ErrPolicyViolation = errors.New("transaction violates signing policy")
+)
+
+// ChangeFunc returns true if the given output script pays to a change address
+// of the device. The decision must be based on the scripts derived from the
+// device's keys known to the wallet, not on the derivation paths of the PSBT,
+// as these are provided by the caller.
+type ChangeFunc func(pkScript []byte) bool
+
+// Policy restricts which transactions are handed to the device for signing.
+// Outputs that pay back to a change address of the device are always allowed.
+// The device itself verifies such change outputs before signing.
+type Policy struct {
+	// AllowedScripts is a list of output scripts that may be paid to
+	// without any limit, for example the addresses of a cold storage.
+	AllowedScripts [][]byte
+
+	// MaxExternalAmount is the maximum total amount a single transaction
+	// may pay to outputs that are neither change nor in AllowedScripts.
+	// If this is zero, no such outputs are allowed.
+	MaxExternalAmount ltcutil.Amount
+
+	// MaxFee is the maximum absolute fee a single transaction may pay.
+	MaxFee ltcutil.Amount
+
+	// MaxFeeRate is the maximum fee rate a single transaction may pay.
+	MaxFeeRate chainfee.SatPerKWeight
+}
+
+// isAllowed returns true if the given script is one of the allowed scripts.
+func (p *Policy) isAllowed(pkScript []byte) bool {
+	for _, allowed := range p.AllowedScripts {
+		if bytes.Equal(allowed, pkScript) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check returns an error if the given packet pays more than the policy allows
+// to external outputs or in fees. Change outputs are identified with the given
+// function.
+func (p *Policy) Check(packet *psbt.Packet, isChange ChangeFunc) error {
+	if len(packet.Outputs) != len(packet.UnsignedTx.TxOut) {
+		return fmt.Errorf("PSBT has %d outputs but its transaction has "+
+			"%d", len(packet.Outputs), len(packet.UnsignedTx.TxOut))
+	}
+
+	var external ltcutil.Amount
+	for _, txOut := range packet.UnsignedTx.TxOut {
+		if isChange(txOut.PkScript) || p.isAllowed(txOut.PkScript) {
+			continue
+		}
+
+		external += ltcutil.Amount(txOut.Value)
+	}
+
+	if external > p.MaxExternalAmount {
+		return fmt.Errorf("%w: paying %v to external outputs exceeds "+
+			"the maximum of %v", ErrPolicyViolation, external,
+			p.MaxExternalAmount)
+	}
+
+	return p.checkFee(packet)
+}
+
+// checkFee returns an error if the fee or the fee rate of the given packet
+// exceeds the maximum of the policy. As the fee can't be verified without
+// knowing the value of all inputs, packets without UTXO information for every
+// input are rejected.
+func (p *Policy) checkFee(packet *psbt.Packet) error {
+	if len(packet.Inputs) != len(packet.UnsignedTx.TxIn) {
+		return fmt.Errorf("PSBT has %d inputs but its transaction has "+
+			"%d", len(packet.Inputs), len(packet.UnsignedTx.TxIn))
+	}
+
+	var (
+		weightEstimate input.TxWeightEstimator
+		inputTotal     ltcutil.Amount
+		outputTotal    ltcutil.Amount
+	)
+	for idx, txIn := range packet.UnsignedTx.TxIn {
+		utxo, err := inputUtxo(&packet.Inputs[idx], txIn)
+		if err != nil {
+			return fmt.Errorf("%w: input %d: %v", ErrPolicyViolation,
+				idx, err)
+		}
+
+		switch {
+		case txscript.IsPayToWitnessPubKeyHash(utxo.PkScript):
+			weightEstimate.AddP2WKHInput()
+
+		case txscript.IsPayToScriptHash(utxo.PkScript):
+			weightEstimate.AddNestedP2WKHInput()
+
+		case txscript.IsPayToTaproot(utxo.PkScript):
+			weightEstimate.AddTaprootKeySpendInput(
+				txscript.SigHashDefault,
+			)
+
+		default:
+			return fmt.Errorf("%w: unable to estimate the fee rate "+
+				"of input %d with script %x",
+				ErrPolicyViolation, idx, utxo.PkScript)
+		}
+
+		inputTotal += ltcutil.Amount(utxo.Value)
+	}
+
+	for _, txOut := range packet.UnsignedTx.TxOut {
+		weightEstimate.AddTxOutput(txOut)
+		outputTotal += ltcutil.Amount(txOut.Value)
+	}
+
+	fee := inputTotal - outputTotal
+	if fee > p.MaxFee {
+		return fmt.Errorf("%w: fee of %v exceeds the maximum of %v",
+			ErrPolicyViolation, fee, p.MaxFee)
+	}
+
+	feeRate := chainfee.SatPerKWeight(
+		fee * 1000 / ltcutil.Amount(weightEstimate.Weight()),
+	)
+	if feeRate > p.MaxFeeRate {
+		return fmt.Errorf("%w: fee rate of %v exceeds the maximum of %v",
+			ErrPolicyViolation, feeRate, p.MaxFeeRate)
+	}
+
+	return nil
+}
+
+// inputUtxo returns the output the given input spends, taken from the UTXO
+// information of the PSBT input.
+func inputUtxo(in *psbt.PInput, txIn *wire.TxIn) (*wire.TxOut, error) {
+	switch {
+	case in.WitnessUtxo != nil:
+		return in.WitnessUtxo, nil
+
+	case in.NonWitnessUtxo != nil:
+		prevOut := txIn.PreviousOutPoint
+		if in.NonWitnessUtxo.TxHash() != prevOut.Hash ||
+			int(prevOut.Index) >= len(in.NonWitnessUtxo.TxOut) {
+
+			return nil, fmt.Errorf("UTXO information doesn't match "+
+				"outpoint %v", prevOut)
+		}
+
+		return in.NonWitnessUtxo.TxOut[prevOut.Index], nil
+
+	default:
+		return nil, errors.New("missing UTXO information")
+	}
+}
@@ -0,0 +1,117 @@
+package hwi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ltcsuite/lnd/lnwallet/chainfee"
+	"github.com/ltcsuite/ltcd/ltcutil/psbt"
+	"github.com/ltcsuite/ltcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	externalScript = testP2WKHScript(1)
+	coldScript     = testP2WKHScript(2)
+	changeScript   = testP2WKHScript(3)
+	inputScript    = testP2WKHScript(4)
+)
+
+// testP2WKHScript returns a P2WKH script with a key hash of the given byte.
+func testP2WKHScript(b byte) []byte {
+	return append([]byte{0x00, 0x14}, bytes.Repeat([]byte{b}, 20)...)
+}
+
+// isTestChange identifies changeScript as the only change output.
+func isTestChange(pkScript []byte) bool {
+	return bytes.Equal(pkScript, changeScript)
+}
+
+// TestPolicyCheck tests that the policy only allows change outputs, allowed
+// scripts and external outputs up to the configured amount, and bounds the
+// fee of the transaction.
+func TestPolicyCheck(t *testing.T) {
+	t.Parallel()
+
+	// newPacket creates a packet spending an input of the given value and
+	// paying the given amounts to an external output, the cold storage and
+	// a change output of the device.
+	newPacket := func(in, external, cold, change int64) *psbt.Packet {
+		packet, err := psbt.New(
+			[]*wire.OutPoint{{Index: 1}}, []*wire.TxOut{
+				wire.NewTxOut(external, externalScript),
+				wire.NewTxOut(cold, coldScript),
+				wire.NewTxOut(change, changeScript),
+			}, 2, 0, []uint32{wire.MaxTxInSequenceNum},
+		)
+		require.NoError(t, err)
+
+		packet.Inputs[0].WitnessUtxo = wire.NewTxOut(in, inputScript)
+
+		return packet
+	}
+
+	policy := &Policy{
+		AllowedScripts:    [][]byte{coldScript},
+		MaxExternalAmount: 50_000,
+		MaxFee:            10_000,
+		MaxFeeRate:        chainfee.SatPerKWeight(10_000),
+	}
+
+	testCases := []struct {
+		name   string
+		packet *psbt.Packet
+		valid  bool
+	}{{
+		name: "within limit",
+		packet: newPacket(
+			2_052_000, 50_000, 1_000_000, 1_000_000,
+		),
+		valid: true,
+	}, {
+		name:   "exceeds limit",
+		packet: newPacket(52_001, 50_001, 0, 0),
+		valid:  false,
+	}, {
+		name:   "exceeds max fee",
+		packet: newPacket(2_020_001, 0, 1_000_000, 1_000_000),
+		valid:  false,
+	}, {
+		// A fee below the absolute maximum that still pays more than
+		// the maximum fee rate for the small transaction.
+		name:   "exceeds max fee rate",
+		packet: newPacket(2_009_000, 0, 1_000_000, 1_000_000),
+		valid:  false,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := policy.Check(tc.packet, isTestChange)
+			if tc.valid {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrPolicyViolation)
+		})
+	}
+
+	// An output isn't change just because the PSBT claims it is derived
+	// from the device's keys.
+	packet := newPacket(102_000, 0, 0, 100_000)
+	packet.Outputs[2].Bip32Derivation = []*psbt.Bip32Derivation{{
+		MasterKeyFingerprint: 0x0adac573,
+	}}
+	notChange := func([]byte) bool { return false }
+	require.ErrorIs(t, policy.Check(packet, notChange), ErrPolicyViolation)
+
+	// Without UTXO information the fee can't be verified.
+	packet = newPacket(102_000, 0, 0, 100_000)
+	packet.Inputs[0].WitnessUtxo = nil
+	require.ErrorIs(
+		t, policy.Check(packet, isTestChange), ErrPolicyViolation,
+	)
+}
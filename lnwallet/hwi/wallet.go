@@ -0,0 +1,210 @@
+package hwi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ltcsuite/lnd/lnwallet"
+	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/ltcutil/psbt"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcwallet/waddrmgr"
+)
+
+// Wallet is a wallet controller that keeps track of addresses and
+// transactions with a watch-only base wallet but signs PSBTs on a hardware
+// wallet through an HWI-compatible bridge. This allows the on-chain funds to
+// be kept on the device. The device's account is imported into the base wallet
+// as a watch-only account, and transactions spending from it are created with
+// FundPsbt and then signed by the device with SignPsbt or FinalizePsbt. All
+// other signing requests are handled by the base wallet.
+type Wallet struct {
+	// WalletController is the embedded wallet controller of the base
+	// wallet. We shadow SignPsbt and FinalizePsbt to hand the packets that
+	// spend from the device's account to the device instead.
+	lnwallet.WalletController
+
+	bridge *Bridge
+
+	policy *Policy
+
+	netParams *chaincfg.Params
+}
+
+// A compile time check to ensure Wallet implements the WalletController
+// interface.
+var _ lnwallet.WalletController = (*Wallet)(nil)
+
+// NewWallet creates a new wallet that signs with the device behind the given
+// bridge, as long as the transactions satisfy the given policy.
+func NewWallet(watchOnly lnwallet.WalletController, bridge *Bridge,
+	policy *Policy, netParams *chaincfg.Params) *Wallet {
+
+	return &Wallet{
+		WalletController: watchOnly,
+		bridge:           bridge,
+		policy:           policy,
+		netParams:        netParams,
+	}
+}
+
+// Enumerate returns all hardware wallets the bridge can find.
+func (w *Wallet) Enumerate(ctx context.Context) ([]Device, error) {
+	return w.bridge.Enumerate(ctx)
+}
+
+// hasDeviceInputs returns true if any of the inputs of the packet is derived
+// from the master key with the given fingerprint.
+func hasDeviceInputs(packet *psbt.Packet, fingerprint uint32) bool {
+	for idx := range packet.Inputs {
+		in := &packet.Inputs[idx]
+		for _, derivation := range in.Bip32Derivation {
+			if derivation.MasterKeyFingerprint == fingerprint {
+				return true
+			}
+		}
+
+		for _, derivation := range in.TaprootBip32Derivation {
+			if derivation.MasterKeyFingerprint == fingerprint {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isDeviceChange returns a function that identifies the change outputs of the
+// device with the given master key fingerprint. An output is only considered
+// change if the watch-only wallet derived its script from the keys of the
+// device's account on the internal branch.
+func (w *Wallet) isDeviceChange(fingerprint uint32) ChangeFunc {
+	return func(pkScript []byte) bool {
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			pkScript, w.netParams,
+		)
+		if err != nil || len(addrs) != 1 {
+			return false
+		}
+
+		addrInfo, err := w.WalletController.AddressInfo(addrs[0])
+		if err != nil {
+			return false
+		}
+
+		pubKeyAddr, ok := addrInfo.(waddrmgr.ManagedPubKeyAddress)
+		if !ok || !pubKeyAddr.Internal() {
+			return false
+		}
+
+		_, path, ok := pubKeyAddr.DerivationInfo()
+
+		return ok && path.MasterKeyFingerprint == fingerprint
+	}
+}
+
+// SignPsbt expects a partial transaction with all inputs and outputs fully
+// declared. If any of its inputs belong to the device, the packet is handed to
+// the device for signing if it satisfies the signing policy. Otherwise it is
+// signed by the base wallet. The indexes of the signed inputs are returned.
+//
+// NOTE: This is a part of the WalletController interface.
+func (w *Wallet) SignPsbt(packet *psbt.Packet) ([]uint32, error) {
+	fingerprint, err := w.bridge.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasDeviceInputs(packet, fingerprint) {
+		return w.WalletController.SignPsbt(packet)
+	}
+
+	err = w.policy.Check(packet, w.isDeviceChange(fingerprint))
+	if err != nil {
+		return nil, err
+	}
+
+	// The device is only needed when signing, so we only check that it is
+	// connected and unlocked now to give a descriptive error otherwise.
+	if err := w.bridge.CheckDevice(context.Background()); err != nil {
+		return nil, fmt.Errorf("unable to use hardware wallet: %w",
+			err)
+	}
+
+	log.Infof("Signing transaction %v on hardware wallet %s",
+		packet.UnsignedTx.TxHash(), w.bridge.cfg.Fingerprint)
+
+	signedPacket, err := w.bridge.SignPsbt(context.Background(), packet)
+	if err != nil {
+		return nil, fmt.Errorf("error signing PSBT on hardware wallet: %w",
+			err)
+	}
+
+	signedInputs := newlySignedInputs(packet, signedPacket)
+
+	// The caller expects the packet to be modified instead of a new
+	// instance to be returned. So we just overwrite all fields in the
+	// original packet.
+	packet.UnsignedTx = signedPacket.UnsignedTx
+	packet.Inputs = signedPacket.Inputs
+	packet.Outputs = signedPacket.Outputs
+	packet.Unknowns = signedPacket.Unknowns
+
+	return signedInputs, nil
+}
+
+// FinalizePsbt expects a partial transaction with all inputs and outputs fully
+// declared. The inputs that belong to the device are signed on the device and
+// finalized, then the base wallet signs and finalizes all remaining inputs.
+//
+// NOTE: This is a part of the WalletController interface.
+func (w *Wallet) FinalizePsbt(packet *psbt.Packet, account string) error {
+	fingerprint, err := w.bridge.Fingerprint()
+	if err != nil {
+		return err
+	}
+
+	if hasDeviceInputs(packet, fingerprint) {
+		signedInputs, err := w.SignPsbt(packet)
+		if err != nil {
+			return err
+		}
+
+		// The base wallet only skips inputs that already carry their
+		// final witness, so we finalize the ones the device signed.
+		for _, idx := range signedInputs {
+			err := psbt.Finalize(packet, int(idx))
+			if err != nil {
+				return fmt.Errorf("error finalizing input %d "+
+					"signed by hardware wallet: %w", idx,
+					err)
+			}
+		}
+	}
+
+	return w.WalletController.FinalizePsbt(packet, account)
+}
+
+// newlySignedInputs returns the indexes of the inputs that have signatures in
+// the signed packet which they didn't have in the original packet.
+func newlySignedInputs(original, signed *psbt.Packet) []uint32 {
+	var indexes []uint32
+	for idx := range signed.Inputs {
+		if idx >= len(original.Inputs) {
+			break
+		}
+
+		before, after := &original.Inputs[idx], &signed.Inputs[idx]
+		switch {
+		case len(after.PartialSigs) > len(before.PartialSigs),
+			len(after.TaprootKeySpendSig) > 0 &&
+				len(before.TaprootKeySpendSig) == 0,
+			len(after.TaprootScriptSpendSig) >
+				len(before.TaprootScriptSpendSig):
+
+			indexes = append(indexes, uint32(idx))
+		}
+	}
+
+	return indexes
+}
@@ -190,6 +190,11 @@ type TransactionDetail struct {
 
 	// PreviousOutpoints are the inputs for a transaction.
 	PreviousOutpoints []PreviousOutPoint
+
+	// IsMwebPegOut denotes whether the transaction is the integration
+	// (HogEx) transaction of a block, which pays out the coins that were
+	// pegged out of the MWEB.
+	IsMwebPegOut bool
 }
 
 // TransactionSubscription is an interface which describes an object capable of
@@ -245,6 +250,15 @@ type WalletController interface {
 	ConfirmedBalance(confs int32, accountFilter string) (ltcutil.Amount,
 		error)
 
+	// MwebBalance returns the sum of the wallet's unspent outputs that
+	// have at least confs confirmations and were received through a MWEB
+	// peg-out. These outputs are part of the confirmed balance as well.
+	// The account parameter serves as a filter to retrieve the balance for
+	// a specific account. When empty, the MWEB balance of all wallet
+	// accounts is returned.
+	MwebBalance(confs int32, accountFilter string) (ltcutil.Amount,
+		error)
+
 	// NewAddress returns the next external or internal address for the
 	// wallet dictated by the value of the `change` parameter. If change is
 	// true, then an internal address should be used, otherwise an external
@@ -279,6 +293,12 @@ type WalletController interface {
 	ListAccounts(string, *waddrmgr.KeyScope) ([]*waddrmgr.AccountProperties,
 		error)
 
+	// CreateAccount derives a new named account from the wallet's seed
+	// under the key scope of the given address type. Addresses of that
+	// type can then be requested for the account through NewAddress.
+	CreateAccount(name string,
+		addrType AddressType) (*waddrmgr.AccountProperties, error)
+
 	// RequiredReserve returns the minimum amount of satoshis that should be
 	// kept in the wallet in order to fee bump anchor channels if necessary.
 	// The value scales with the number of public anchor channels but is
@@ -331,6 +351,17 @@ type WalletController interface {
 	ImportTaprootScript(scope waddrmgr.KeyScope,
 		tapscript *waddrmgr.Tapscript) (waddrmgr.ManagedAddress, error)
 
+	// ImportWitnessScript imports a user-provided witness script into the
+	// wallet. The imported script will act as a
+	// pay-to-witness-script-hash address. Inputs spending its outputs are
+	// signed by SignPsbt and FinalizePsbt, which add the witness script
+	// to them.
+	//
+	// NOTE: Outputs of imported witness scripts currently _cannot_ be
+	// used for funding PSBTs.
+	ImportWitnessScript(scope waddrmgr.KeyScope,
+		witnessScript []byte) (waddrmgr.ManagedAddress, error)
+
 	// SendOutputs funds, signs, and broadcasts a Bitcoin transaction paying
 	// out to the specified outputs. In the case the wallet has insufficient
 	// funds, or the outputs are non-standard, an error should be returned.
@@ -490,7 +521,9 @@ type WalletController interface {
 	// or inputs without UTXO information attached or inputs without witness
 	// data that do not belong to lnd's wallet, this method will fail. If no
 	// error is returned, the PSBT is ready to be extracted and the final TX
-	// within to be broadcast.
+	// within to be broadcast. If the account is watch-only, all inputs must
+	// already have been signed by an external signer and are only
+	// finalized.
 	//
 	// NOTE: This method does NOT publish the transaction after it's been
 	// finalized successfully.
@@ -518,6 +551,16 @@ type WalletController interface {
 	// recovery progress made so far.
 	GetRecoveryInfo() (bool, float64, error)
 
+	// Rescan rewinds the wallet to the given height and rescans the chain
+	// from there for transactions relevant to the wallet's addresses and
+	// unspent outputs. A start height of zero rescans from the wallet's
+	// birthday block. The progress callback is invoked periodically with
+	// the height the wallet has been rescanned to and the progress made
+	// so far as a value between 0 and 1. This method blocks until the
+	// rescan has completed.
+	Rescan(startHeight int32,
+		progress func(height int32, progress float64)) error
+
 	// Start initializes the wallet, making any necessary connections,
 	// starting up required goroutines etc.
 	Start() error
@@ -72,6 +72,13 @@ func (w *mockWalletController) ConfirmedBalance(int32, string) (ltcutil.Amount,
 	return 0, nil
 }
 
+// MwebBalance currently returns dummy values.
+func (w *mockWalletController) MwebBalance(int32, string) (ltcutil.Amount,
+	error) {
+
+	return 0, nil
+}
+
 // NewAddress is called to get new addresses for delivery, change etc.
 func (w *mockWalletController) NewAddress(AddressType, bool,
 	string) (ltcutil.Address, error) {
@@ -122,6 +129,13 @@ func (w *mockWalletController) ListAddresses(string,
 	return nil, nil
 }
 
+// CreateAccount currently returns a dummy value.
+func (w *mockWalletController) CreateAccount(string,
+	AddressType) (*waddrmgr.AccountProperties, error) {
+
+	return nil, nil
+}
+
 // ImportAccount currently returns a dummy value.
 func (w *mockWalletController) ImportAccount(string, *hdkeychain.ExtendedKey,
 	uint32, *waddrmgr.AddressType, bool) (*waddrmgr.AccountProperties,
@@ -144,6 +158,13 @@ func (w *mockWalletController) ImportTaprootScript(waddrmgr.KeyScope,
 	return nil, nil
 }
 
+// ImportWitnessScript currently returns a dummy value.
+func (w *mockWalletController) ImportWitnessScript(waddrmgr.KeyScope,
+	[]byte) (waddrmgr.ManagedAddress, error) {
+
+	return nil, nil
+}
+
 // SendOutputs currently returns dummy values.
 func (w *mockWalletController) SendOutputs([]*wire.TxOut,
 	chainfee.SatPerKWeight, int32, string) (*wire.MsgTx, error) {
@@ -267,6 +288,11 @@ func (w *mockWalletController) GetRecoveryInfo() (bool, float64, error) {
 	return true, float64(1), nil
 }
 
+// Rescan currently does nothing.
+func (w *mockWalletController) Rescan(int32, func(int32, float64)) error {
+	return nil
+}
+
 // Start currently does nothing.
 func (w *mockWalletController) Start() error {
 	return nil
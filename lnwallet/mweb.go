@@ -0,0 +1,72 @@
+package lnwallet
+
+import (
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+const (
+	// mwebPegInVersion is the witness version of the canonical outputs
+	// that move coins into the MWEB. The witness program of such an output
+	// is the 32-byte hash of the MWEB kernel that commits to the peg-in.
+	mwebPegInVersion = 9
+
+	// mwebHogAddrVersion is the witness version of the HogAddr, the output
+	// of the integration (HogEx) transaction that holds all coins that
+	// currently live in the MWEB.
+	mwebHogAddrVersion = 8
+
+	// mwebProgramLen is the length of the witness program of both MWEB
+	// output types.
+	mwebProgramLen = 32
+)
+
+// isMwebProgram returns true if the given script is a 32-byte witness program
+// of the given version.
+func isMwebProgram(pkScript []byte, version int) bool {
+	if !txscript.IsWitnessProgram(pkScript) {
+		return false
+	}
+
+	progVersion, program, err := txscript.ExtractWitnessProgramInfo(
+		pkScript,
+	)
+	if err != nil {
+		return false
+	}
+
+	return progVersion == version && len(program) == mwebProgramLen
+}
+
+// IsMwebPegIn returns true if the given script is a MWEB peg-in output. The
+// coins of such an output are moved into the MWEB and can't be spent on the
+// canonical chain by the wallet.
+func IsMwebPegIn(pkScript []byte) bool {
+	return isMwebProgram(pkScript, mwebPegInVersion)
+}
+
+// IsMwebHogAddr returns true if the given script is the HogAddr output of an
+// integration transaction.
+func IsMwebHogAddr(pkScript []byte) bool {
+	return isMwebProgram(pkScript, mwebHogAddrVersion)
+}
+
+// IsMwebOutput returns true if the given script belongs to one of the outputs
+// that hold coins on behalf of the MWEB. These are never spendable by the
+// wallet.
+func IsMwebOutput(pkScript []byte) bool {
+	return IsMwebPegIn(pkScript) || IsMwebHogAddr(pkScript)
+}
+
+// IsHogEx returns true if the given transaction is the integration (HogEx)
+// transaction of a block. All of its outputs except for the HogAddr pay out
+// coins that were pegged out of the MWEB. As the MWEB flag of a transaction
+// isn't retained once it is serialized without the MWEB data, the transaction
+// is also recognized by its first output being the HogAddr.
+func IsHogEx(tx *wire.MsgTx) bool {
+	if tx.IsHogEx {
+		return true
+	}
+
+	return len(tx.TxOut) > 0 && IsMwebHogAddr(tx.TxOut[0].PkScript)
+}
@@ -0,0 +1,96 @@
+package lnwallet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// witnessProgram returns a witness program script with the given version
+// opcode and a program of the given length.
+func witnessProgram(t *testing.T, versionOp byte, programLen int) []byte {
+	t.Helper()
+
+	script, err := txscript.NewScriptBuilder().
+		AddOp(versionOp).
+		AddData(bytes.Repeat([]byte{0x01}, programLen)).
+		Script()
+	require.NoError(t, err)
+
+	return script
+}
+
+// TestMwebScripts tests that the MWEB peg-in and HogAddr outputs are told apart
+// from regular witness outputs.
+func TestMwebScripts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pegIn   = witnessProgram(t, txscript.OP_9, 32)
+		hogAddr = witnessProgram(t, txscript.OP_8, 32)
+		p2wsh   = witnessProgram(t, txscript.OP_0, 32)
+		p2tr    = witnessProgram(t, txscript.OP_1, 32)
+		short   = witnessProgram(t, txscript.OP_9, 20)
+	)
+
+	testCases := []struct {
+		name    string
+		script  []byte
+		pegIn   bool
+		hogAddr bool
+	}{{
+		name:   "peg-in",
+		script: pegIn,
+		pegIn:  true,
+	}, {
+		name:    "hogaddr",
+		script:  hogAddr,
+		hogAddr: true,
+	}, {
+		name:   "p2wsh",
+		script: p2wsh,
+	}, {
+		name:   "p2tr",
+		script: p2tr,
+	}, {
+		name:   "short program",
+		script: short,
+	}, {
+		name:   "empty",
+		script: nil,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.pegIn, IsMwebPegIn(tc.script))
+			require.Equal(t, tc.hogAddr, IsMwebHogAddr(tc.script))
+			require.Equal(
+				t, tc.pegIn || tc.hogAddr,
+				IsMwebOutput(tc.script),
+			)
+		})
+	}
+
+	// An integration transaction is recognized by its first output paying
+	// to the HogAddr, even without the MWEB flag.
+	hogEx := wire.NewMsgTx(2)
+	hogEx.AddTxOut(wire.NewTxOut(100_000, hogAddr))
+	hogEx.AddTxOut(wire.NewTxOut(50_000, p2tr))
+	require.True(t, IsHogEx(hogEx))
+
+	regular := wire.NewMsgTx(2)
+	regular.AddTxOut(wire.NewTxOut(50_000, p2tr))
+	regular.AddTxOut(wire.NewTxOut(100_000, hogAddr))
+	require.False(t, IsHogEx(regular))
+
+	regular.IsHogEx = true
+	require.True(t, IsHogEx(regular))
+
+	require.False(t, IsHogEx(wire.NewMsgTx(2)))
+}
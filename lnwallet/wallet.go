@@ -834,11 +834,12 @@ func (l *LightningWallet) handleFundingReserveRequest(req *InitFundingReserveMsg
 		// P2WPKH dust limit and to avoid threading through two
 		// different dust limits.
 		cfg := chanfunding.WalletConfig{
-			CoinSource:       &CoinSource{l},
-			CoinSelectLocker: l,
-			CoinLocker:       l,
-			Signer:           l.Cfg.Signer,
-			DustLimit:        DustLimitForSize(input.P2WSHSize),
+			CoinSource:            &CoinSource{l},
+			CoinSelectLocker:      l,
+			CoinLocker:            l,
+			Signer:                l.Cfg.Signer,
+			DustLimit:             DustLimitForSize(input.P2WSHSize),
+			CoinSelectionStrategy: l.Cfg.CoinSelectionStrategy,
 		}
 		req.ChanFunder = chanfunding.NewWalletAssembler(cfg)
 	} else {
@@ -0,0 +1,58 @@
+package lnwire
+
+import (
+	"io"
+
+	"github.com/ltcsuite/lnd/tlv"
+	"github.com/ltcsuite/ltcd/btcec/v2"
+)
+
+const (
+	// BlindingPointRecordType is the TLV type used to transmit the
+	// blinding point of a blinded route in update_add_htlc.
+	BlindingPointRecordType tlv.Type = 0
+)
+
+// BlindingPoint is the ephemeral public key that a node in a blinded route
+// uses to derive the key that decrypts its encrypted recipient data. It is
+// handed to every node after the introduction node by the previous hop.
+type BlindingPoint btcec.PublicKey
+
+// Record returns a TLV record that can be used to encode/decode the blinding
+// point from a given TLV stream.
+func (b *BlindingPoint) Record() tlv.Record {
+	return tlv.MakeStaticRecord(
+		BlindingPointRecordType, b, btcec.PubKeyBytesLenCompressed,
+		blindingPointEncoder, blindingPointDecoder,
+	)
+}
+
+// blindingPointEncoder is a custom TLV encoder for the BlindingPoint record.
+func blindingPointEncoder(w io.Writer, val interface{}, buf *[8]byte) error {
+	if v, ok := val.(*BlindingPoint); ok {
+		key := (*btcec.PublicKey)(v)
+		return tlv.EPubKey(w, &key, buf)
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "lnwire.BlindingPoint")
+}
+
+// blindingPointDecoder is a custom TLV decoder for the BlindingPoint record.
+func blindingPointDecoder(r io.Reader, val interface{}, buf *[8]byte,
+	l uint64) error {
+
+	if v, ok := val.(*BlindingPoint); ok {
+		var key *btcec.PublicKey
+		if err := tlv.DPubKey(r, &key, buf, l); err != nil {
+			return err
+		}
+
+		*v = BlindingPoint(*key)
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(
+		val, "lnwire.BlindingPoint", l, btcec.PubKeyBytesLenCompressed,
+	)
+}
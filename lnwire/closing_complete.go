@@ -0,0 +1,86 @@
+package lnwire
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/ltcsuite/ltcd/ltcutil"
+)
+
+// ClosingComplete is sent by a party to a channel once the channel is clear of
+// HTLCs and both parties have sent their shutdown message, to propose a close
+// transaction that it pays the fee for. The closer picks the fee and the lock
+// time of the transaction, and the remote party responds with a ClosingSig
+// message. Since the closer pays the whole fee, it may send another
+// ClosingComplete with a higher fee at any time to replace the close
+// transaction, until the transaction confirms.
+type ClosingComplete struct {
+	// ChannelID serves to identify which channel is to be closed.
+	ChannelID ChannelID
+
+	// FeeSatoshis is the total fee in satoshis that the closer pays for
+	// the proposed close transaction.
+	FeeSatoshis ltcutil.Amount
+
+	// LockTime is the lock time of the proposed close transaction.
+	LockTime uint32
+
+	// ClosingSig is the closer's signature for the proposed close
+	// transaction.
+	ClosingSig Sig
+
+	// ExtraData is the set of data that was appended to this message to
+	// fill out the full maximum transport message size. These fields can
+	// be used to specify optional data such as custom TLV fields.
+	ExtraData ExtraOpaqueData
+}
+
+// A compile time check to ensure ClosingComplete implements the lnwire.Message
+// interface.
+var _ Message = (*ClosingComplete)(nil)
+
+// Decode deserializes a serialized ClosingComplete message stored in the
+// passed io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *ClosingComplete) Decode(r io.Reader, pver uint32) error {
+	return ReadElements(r,
+		&c.ChannelID,
+		&c.FeeSatoshis,
+		&c.LockTime,
+		&c.ClosingSig,
+		&c.ExtraData,
+	)
+}
+
+// Encode serializes the target ClosingComplete into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (c *ClosingComplete) Encode(w *bytes.Buffer, pver uint32) error {
+	if err := WriteChannelID(w, c.ChannelID); err != nil {
+		return err
+	}
+
+	if err := WriteSatoshi(w, c.FeeSatoshis); err != nil {
+		return err
+	}
+
+	if err := WriteUint32(w, c.LockTime); err != nil {
+		return err
+	}
+
+	if err := WriteSig(w, c.ClosingSig); err != nil {
+		return err
+	}
+
+	return WriteBytes(w, c.ExtraData)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (c *ClosingComplete) MsgType() MessageType {
+	return MsgClosingComplete
+}
@@ -80,6 +80,14 @@ func NewCustom(msgType MessageType, data []byte) (*Custom, error) {
 			"and not overridden", msgType, CustomTypeStart)
 	}
 
+	// The experimental messages of RBF based cooperative close
+	// negotiation live in the custom range, so they can't be sent as
+	// custom messages.
+	if msgType == MsgClosingComplete || msgType == MsgClosingSig {
+		return nil, fmt.Errorf("msg type: %d is reserved for %v",
+			msgType, msgType)
+	}
+
 	return &Custom{
 		Type: msgType,
 		Data: data,
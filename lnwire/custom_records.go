@@ -0,0 +1,75 @@
+package lnwire
+
+import (
+	"fmt"
+
+	"github.com/ltcsuite/lnd/tlv"
+)
+
+const (
+	// MinCustomRecordsTlvType is the minimum custom record type, as
+	// defined in BOLT 01.
+	MinCustomRecordsTlvType = 65536
+)
+
+// CustomRecords stores a set of custom TLV records that are attached to a
+// message, keyed by their type.
+type CustomRecords map[uint64][]byte
+
+// Validate checks that all custom records are in the custom type range.
+func (c CustomRecords) Validate() error {
+	for key := range c {
+		if key < MinCustomRecordsTlvType {
+			return fmt.Errorf("custom record type %v is below the "+
+				"custom type range starting at %v", key,
+				MinCustomRecordsTlvType)
+		}
+	}
+
+	return nil
+}
+
+// RecordProducers returns the custom records as a set of record producers,
+// so they can be packed into the extra data of a message.
+func (c CustomRecords) RecordProducers() []tlv.RecordProducer {
+	producers := make([]tlv.RecordProducer, 0, len(c))
+	for key, value := range c {
+		value := value
+		producers = append(producers, &customRecordProducer{
+			record: tlv.MakePrimitiveRecord(tlv.Type(key), &value),
+		})
+	}
+
+	return producers
+}
+
+// customRecordsFromTypeMap extracts the custom records from the parsed types
+// of a TLV stream. Nil is returned if there are none.
+func customRecordsFromTypeMap(typeMap tlv.TypeMap) CustomRecords {
+	var records CustomRecords
+	for typ, value := range typeMap {
+		if typ < MinCustomRecordsTlvType || value == nil {
+			continue
+		}
+
+		if records == nil {
+			records = make(CustomRecords)
+		}
+		records[uint64(typ)] = value
+	}
+
+	return records
+}
+
+// customRecordProducer wraps a custom TLV record to implement the
+// tlv.RecordProducer interface.
+type customRecordProducer struct {
+	record tlv.Record
+}
+
+// Record returns the wrapped TLV record.
+//
+// NOTE: This is part of the tlv.RecordProducer interface.
+func (c *customRecordProducer) Record() tlv.Record {
+	return c.record
+}
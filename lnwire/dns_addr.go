@@ -0,0 +1,90 @@
+package lnwire
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+var (
+	// ErrEmptyDNSHostname is returned when a DNS address has an empty
+	// hostname.
+	ErrEmptyDNSHostname = errors.New("hostname cannot be empty")
+
+	// ErrDNSHostnameTooLong is returned when a DNS hostname doesn't fit
+	// into the single length byte of its encoding.
+	ErrDNSHostnameTooLong = errors.New("hostname exceeds 255 bytes")
+
+	// ErrNilDNSAddress is returned when the supplied address is nil.
+	ErrNilDNSAddress = errors.New("cannot write nil DNSAddress")
+)
+
+// DNSAddress is used to represent a DNS hostname and port that a node can be
+// reached at, as defined by BOLT 7. The hostname isn't resolved when the
+// address is decoded, so it's up to the connecting side whether and how to
+// resolve it.
+type DNSAddress struct {
+	// Hostname is the DNS hostname of the address. It only consists of
+	// ASCII letters, digits, hyphens and dots.
+	Hostname string
+
+	// Port is the port number of the address.
+	Port uint16
+}
+
+// A compile-time assertion to ensure that DNSAddress meets the net.Addr
+// interface.
+var _ net.Addr = (*DNSAddress)(nil)
+
+// NewDNSAddress creates a new DNSAddress from the given hostname and port
+// after validating the hostname.
+func NewDNSAddress(hostname string, port uint16) (*DNSAddress, error) {
+	addr := &DNSAddress{
+		Hostname: hostname,
+		Port:     port,
+	}
+	if err := addr.Validate(); err != nil {
+		return nil, err
+	}
+
+	return addr, nil
+}
+
+// Validate checks that the hostname is non-empty, fits into its encoding and
+// only consists of the allowed characters.
+func (d *DNSAddress) Validate() error {
+	switch {
+	case len(d.Hostname) == 0:
+		return ErrEmptyDNSHostname
+
+	case len(d.Hostname) > 255:
+		return ErrDNSHostnameTooLong
+	}
+
+	for _, c := range []byte(d.Hostname) {
+		isValid := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+			(c >= '0' && c <= '9') || c == '-' || c == '.'
+		if !isValid {
+			return fmt.Errorf("hostname %q contains invalid "+
+				"character %q", d.Hostname, c)
+		}
+	}
+
+	return nil
+}
+
+// String returns the host and port of the address in the host:port format.
+//
+// This part of the net.Addr interface.
+func (d *DNSAddress) String() string {
+	return net.JoinHostPort(d.Hostname, strconv.Itoa(int(d.Port)))
+}
+
+// Network returns the network this address is bound to. A DNS address always
+// refers to a TCP endpoint.
+//
+// This part of the net.Addr interface.
+func (d *DNSAddress) Network() string {
+	return "tcp"
+}
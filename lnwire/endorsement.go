@@ -0,0 +1,99 @@
+package lnwire
+
+import "github.com/ltcsuite/lnd/tlv"
+
+const (
+	// ExperimentalEndorsementType is the custom record type of the
+	// experimental endorsement signal of an htlc, which is used to run
+	// channel jamming mitigation experiments.
+	ExperimentalEndorsementType tlv.Type = 106823
+
+	// ExperimentalUnendorsed is the value of the experimental endorsement
+	// record of an htlc that isn't endorsed.
+	ExperimentalUnendorsed byte = 0
+
+	// ExperimentalEndorsed is the value of the experimental endorsement
+	// record of an endorsed htlc. The signal uses a single byte, but only
+	// its first three bits are in use, so a value of 7 is considered
+	// endorsed.
+	ExperimentalEndorsed byte = 7
+)
+
+// Endorsement describes the experimental endorsement signal of an htlc.
+type Endorsement uint8
+
+const (
+	// EndorsementNone indicates that the htlc doesn't carry an
+	// endorsement signal.
+	EndorsementNone Endorsement = iota
+
+	// EndorsementUnendorsed indicates that the htlc isn't endorsed.
+	EndorsementUnendorsed
+
+	// EndorsementEndorsed indicates that the htlc is endorsed.
+	EndorsementEndorsed
+)
+
+// String returns a human readable representation of the endorsement signal.
+func (e Endorsement) String() string {
+	switch e {
+	case EndorsementNone:
+		return "none"
+
+	case EndorsementUnendorsed:
+		return "unendorsed"
+
+	case EndorsementEndorsed:
+		return "endorsed"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Endorsement returns the experimental endorsement signal of the custom
+// records. Any value other than ExperimentalEndorsed is considered
+// unendorsed.
+func (c CustomRecords) Endorsement() Endorsement {
+	value, ok := c[uint64(ExperimentalEndorsementType)]
+	if !ok {
+		return EndorsementNone
+	}
+
+	if len(value) == 1 && value[0] == ExperimentalEndorsed {
+		return EndorsementEndorsed
+	}
+
+	return EndorsementUnendorsed
+}
+
+// WithEndorsement returns a copy of the custom records with the experimental
+// endorsement signal set to the given value. EndorsementNone removes the
+// signal from the copy.
+func (c CustomRecords) WithEndorsement(e Endorsement) CustomRecords {
+	records := make(CustomRecords, len(c)+1)
+	for key, value := range c {
+		records[key] = value
+	}
+
+	switch e {
+	case EndorsementUnendorsed:
+		records[uint64(ExperimentalEndorsementType)] = []byte{
+			ExperimentalUnendorsed,
+		}
+
+	case EndorsementEndorsed:
+		records[uint64(ExperimentalEndorsementType)] = []byte{
+			ExperimentalEndorsed,
+		}
+
+	default:
+		delete(records, uint64(ExperimentalEndorsementType))
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	return records
+}
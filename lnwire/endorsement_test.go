@@ -0,0 +1,54 @@
+package lnwire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestExperimentalEndorsement tests reading and setting the experimental
+// endorsement signal of custom records.
+func TestExperimentalEndorsement(t *testing.T) {
+	t.Parallel()
+
+	endorsementType := uint64(ExperimentalEndorsementType)
+
+	// Records without the signal aren't endorsed either way.
+	var records CustomRecords
+	require.Equal(t, EndorsementNone, records.Endorsement())
+
+	// Only the endorsed value is considered endorsed, any other value is
+	// treated as unendorsed.
+	records = CustomRecords{endorsementType: {ExperimentalEndorsed}}
+	require.Equal(t, EndorsementEndorsed, records.Endorsement())
+
+	records = CustomRecords{endorsementType: {ExperimentalUnendorsed}}
+	require.Equal(t, EndorsementUnendorsed, records.Endorsement())
+
+	records = CustomRecords{endorsementType: {1}}
+	require.Equal(t, EndorsementUnendorsed, records.Endorsement())
+
+	records = CustomRecords{endorsementType: {7, 7}}
+	require.Equal(t, EndorsementUnendorsed, records.Endorsement())
+
+	// Setting the signal returns a copy and keeps other records intact.
+	records = CustomRecords{MinCustomRecordsTlvType: {1, 2, 3}}
+	endorsed := records.WithEndorsement(EndorsementEndorsed)
+	require.Equal(t, CustomRecords{
+		MinCustomRecordsTlvType: {1, 2, 3},
+		endorsementType:         {ExperimentalEndorsed},
+	}, endorsed)
+	require.Equal(t, EndorsementNone, records.Endorsement())
+
+	unendorsed := endorsed.WithEndorsement(EndorsementUnendorsed)
+	require.Equal(t, EndorsementUnendorsed, unendorsed.Endorsement())
+	require.Equal(t, EndorsementEndorsed, endorsed.Endorsement())
+
+	// Removing the signal from records that don't carry anything else
+	// returns nil.
+	require.Equal(
+		t, records, endorsed.WithEndorsement(EndorsementNone),
+	)
+	records = CustomRecords{endorsementType: {ExperimentalEndorsed}}
+	require.Nil(t, records.WithEndorsement(EndorsementNone))
+}
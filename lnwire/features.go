@@ -231,6 +231,20 @@ const (
 	// TODO: Decide on actual feature bit value.
 	ScriptEnforcedLeaseOptional FeatureBit = 2023
 
+	// RbfCoopCloseRequiredStaging is a required bit that indicates the
+	// node requires RBF based cooperative close negotiation, in which the
+	// party that proposes a close transaction pays its fee and can bump
+	// it. As the message format doesn't follow the spec, this is an
+	// experimental bit outside of the range used by the spec.
+	RbfCoopCloseRequiredStaging FeatureBit = 2024
+
+	// RbfCoopCloseOptionalStaging is an optional bit that indicates the
+	// node supports RBF based cooperative close negotiation, in which the
+	// party that proposes a close transaction pays its fee and can bump
+	// it. As the message format doesn't follow the spec, this is an
+	// experimental bit outside of the range used by the spec.
+	RbfCoopCloseOptionalStaging FeatureBit = 2025
+
 	// SimpleTaprootChannelsRequredFinal is a required bit that indicates
 	// the node is able to create taproot-native channels. This is the
 	// final feature bit to be used once the channel type is finalized.
@@ -319,6 +333,8 @@ var Features = map[FeatureBit]string{
 	SimpleTaprootChannelsOptionalFinal:   "simple-taproot-chans",
 	SimpleTaprootChannelsRequiredStaging: "simple-taproot-chans-x",
 	SimpleTaprootChannelsOptionalStaging: "simple-taproot-chans-x",
+	RbfCoopCloseRequiredStaging:          "rbf-coop-close-x",
+	RbfCoopCloseOptionalStaging:          "rbf-coop-close-x",
 }
 
 // RawFeatureVector represents a set of feature bits as defined in BOLT-09.  A
@@ -0,0 +1,66 @@
+package lnwire
+
+import (
+	"io"
+
+	"github.com/ltcsuite/lnd/tlv"
+)
+
+const (
+	// FeeRecordType is the type of the record used to communicate the
+	// inbound fee of a channel in the extra data of a channel update.
+	FeeRecordType tlv.Type = 55555
+)
+
+// Fee represents a fee schedule that consists of a base fee and a fee rate
+// that is expressed in parts per million. Unlike the fees of a channel
+// update, both values may be negative.
+type Fee struct {
+	// BaseFee is the base fee in milli-satoshis.
+	BaseFee int32
+
+	// FeeRate is the fee rate in parts per million.
+	FeeRate int32
+}
+
+// Record returns a TLV record that can be used to encode/decode the Fee type
+// from a given TLV stream.
+func (f *Fee) Record() tlv.Record {
+	return tlv.MakeStaticRecord(
+		FeeRecordType, f, 8, feeEncoder, feeDecoder,
+	)
+}
+
+// feeEncoder is a custom TLV encoder for the Fee record.
+func feeEncoder(w io.Writer, val interface{}, buf *[8]byte) error {
+	if v, ok := val.(*Fee); ok {
+		err := tlv.EUint32T(w, uint32(v.BaseFee), buf)
+		if err != nil {
+			return err
+		}
+
+		return tlv.EUint32T(w, uint32(v.FeeRate), buf)
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "lnwire.Fee")
+}
+
+// feeDecoder is a custom TLV decoder for the Fee record.
+func feeDecoder(r io.Reader, val interface{}, buf *[8]byte, l uint64) error {
+	if v, ok := val.(*Fee); ok && l == 8 {
+		var baseFee, feeRate uint32
+		if err := tlv.DUint32(r, &baseFee, buf, 4); err != nil {
+			return err
+		}
+		if err := tlv.DUint32(r, &feeRate, buf, 4); err != nil {
+			return err
+		}
+
+		v.BaseFee = int32(baseFee)
+		v.FeeRate = int32(feeRate)
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(val, "lnwire.Fee", l, 8)
+}
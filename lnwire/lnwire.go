@@ -52,6 +52,10 @@ const (
 
 	// v3OnionAddr denotes a version 3 Tor (prop224) onion service address.
 	v3OnionAddr addressType = 4
+
+	// dnsAddr denotes a DNS hostname. In contrast to the other address
+	// types, its length depends on the length of the hostname.
+	dnsAddr addressType = 5
 )
 
 // AddrLen returns the number of bytes that it takes to encode the target
@@ -868,6 +872,35 @@ func ReadElement(r io.Reader, element interface{}) error {
 				}
 				addrBytesRead += aType.AddrLen()
 
+			case dnsAddr:
+				var hostLen [1]byte
+				_, err := io.ReadFull(addrBuf, hostLen[:])
+				if err != nil {
+					return err
+				}
+
+				hostname := make([]byte, hostLen[0])
+				_, err = io.ReadFull(addrBuf, hostname)
+				if err != nil {
+					return err
+				}
+
+				var p [2]byte
+				if _, err := io.ReadFull(addrBuf, p[:]); err != nil {
+					return err
+				}
+
+				dnsAddress := &DNSAddress{
+					Hostname: string(hostname),
+					Port:     binary.BigEndian.Uint16(p[:]),
+				}
+				if err := dnsAddress.Validate(); err != nil {
+					return err
+				}
+
+				address = dnsAddress
+				addrBytesRead += 1 + uint16(hostLen[0]) + 2
+
 			default:
 				// If we don't understand this address type,
 				// we just store it along with the remaining
@@ -11,6 +11,7 @@ import (
 	"math/rand"
 	"net"
 	"reflect"
+	"strings"
 	"testing"
 	"testing/quick"
 	"time"
@@ -197,6 +198,20 @@ func randV3OnionAddr(r *rand.Rand) (*tor.OnionAddr, error) {
 	return &tor.OnionAddr{OnionService: onionService, Port: addrPort}, nil
 }
 
+func randDNSAddr(r *rand.Rand) *DNSAddress {
+	const hostChars = "abcdefghijklmnopqrstuvwxyz0123456789-."
+
+	hostname := make([]byte, r.Intn(255)+1)
+	for i := range hostname {
+		hostname[i] = hostChars[r.Intn(len(hostChars))]
+	}
+
+	return &DNSAddress{
+		Hostname: string(hostname),
+		Port:     uint16(r.Int31n(math.MaxUint16)),
+	}
+}
+
 func randOpaqueAddr(r *rand.Rand) (*OpaqueAddrs, error) {
 	payloadLen := r.Int63n(64) + 1
 	payload := make([]byte, payloadLen)
@@ -240,7 +255,8 @@ func randAddrs(r *rand.Rand) ([]net.Addr, error) {
 	}
 
 	return []net.Addr{
-		tcp4Addr, tcp6Addr, v2OnionAddr, v3OnionAddr, opaqueAddrs,
+		tcp4Addr, tcp6Addr, v2OnionAddr, v3OnionAddr, randDNSAddr(r),
+		opaqueAddrs,
 	}, nil
 }
 
@@ -327,6 +343,38 @@ func TestDecodeUnknownAddressType(t *testing.T) {
 	require.Equal(t, hex.EncodeToString(data), addrs[2].String())
 }
 
+// TestDNSAddressEncoding tests that DNS addresses are encoded and decoded
+// correctly and that invalid hostnames are rejected.
+func TestDNSAddressEncoding(t *testing.T) {
+	t.Parallel()
+
+	dnsAddr, err := NewDNSAddress("node.example.com", 9735)
+	require.NoError(t, err)
+	require.Equal(t, "node.example.com:9735", dnsAddr.String())
+
+	tcpAddr := &net.TCPAddr{
+		IP:   net.IP{127, 0, 0, 1},
+		Port: 8080,
+	}
+
+	buffer := bytes.NewBuffer(make([]byte, 0, MaxMsgBody))
+	err = WriteNetAddrs(buffer, []net.Addr{dnsAddr, tcpAddr})
+	require.NoError(t, err)
+
+	var addrs []net.Addr
+	require.NoError(t, ReadElement(buffer, &addrs))
+	require.Equal(t, []net.Addr{dnsAddr, tcpAddr}, addrs)
+
+	_, err = NewDNSAddress("", 9735)
+	require.ErrorIs(t, err, ErrEmptyDNSHostname)
+
+	_, err = NewDNSAddress(strings.Repeat("a", 256), 9735)
+	require.ErrorIs(t, err, ErrDNSHostnameTooLong)
+
+	_, err = NewDNSAddress("node_1.example.com", 9735)
+	require.Error(t, err)
+}
+
 func TestMaxOutPointIndex(t *testing.T) {
 	t.Parallel()
 
@@ -651,6 +699,46 @@ func TestLightningWireProtocol(t *testing.T) {
 
 			v[0] = reflect.ValueOf(*req)
 		},
+		MsgUpdateAddHTLC: func(v []reflect.Value, r *rand.Rand) {
+			req := &UpdateAddHTLC{
+				ID:     uint64(r.Int63()),
+				Amount: MilliSatoshi(r.Int63()),
+				Expiry: r.Uint32(),
+			}
+
+			if _, err := r.Read(req.ChanID[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+			if _, err := r.Read(req.PaymentHash[:]); err != nil {
+				t.Fatalf("unable to generate hash: %v", err)
+				return
+			}
+			if _, err := r.Read(req.OnionBlob[:]); err != nil {
+				t.Fatalf("unable to generate onion: %v", err)
+				return
+			}
+
+			if r.Int31()%2 == 0 {
+				pubKey, err := randPubKey()
+				if err != nil {
+					t.Fatalf("unable to generate key: %v",
+						err)
+					return
+				}
+				req.BlindingPoint = pubKey
+			}
+
+			if r.Int31()%2 == 0 {
+				req.CustomRecords = CustomRecords{
+					MinCustomRecordsTlvType + 1: []byte{
+						byte(r.Int31()),
+					},
+				}
+			}
+
+			v[0] = reflect.ValueOf(*req)
+		},
 		MsgShutdown: func(v []reflect.Value, r *rand.Rand) {
 			var c [32]byte
 			_, err := r.Read(c[:])
@@ -708,6 +796,46 @@ func TestLightningWireProtocol(t *testing.T) {
 
 			v[0] = reflect.ValueOf(req)
 		},
+		MsgClosingComplete: func(v []reflect.Value, r *rand.Rand) {
+			req := ClosingComplete{
+				FeeSatoshis: ltcutil.Amount(r.Int63()),
+				LockTime:    r.Uint32(),
+				ExtraData:   make([]byte, 0),
+			}
+			var err error
+			req.ClosingSig, err = NewSigFromSignature(testSig)
+			if err != nil {
+				t.Fatalf("unable to parse sig: %v", err)
+				return
+			}
+
+			if _, err := r.Read(req.ChannelID[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgClosingSig: func(v []reflect.Value, r *rand.Rand) {
+			req := ClosingSig{
+				FeeSatoshis: ltcutil.Amount(r.Int63()),
+				LockTime:    r.Uint32(),
+				ExtraData:   make([]byte, 0),
+			}
+			var err error
+			req.ClosingSig, err = NewSigFromSignature(testSig)
+			if err != nil {
+				t.Fatalf("unable to parse sig: %v", err)
+				return
+			}
+
+			if _, err := r.Read(req.ChannelID[:]); err != nil {
+				t.Fatalf("unable to generate chan id: %v", err)
+				return
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
 		MsgCommitSig: func(v []reflect.Value, r *rand.Rand) {
 			req := NewCommitSig()
 			if _, err := r.Read(req.ChanID[:]); err != nil {
@@ -1153,6 +1281,18 @@ func TestLightningWireProtocol(t *testing.T) {
 				return mainScenario(&m)
 			},
 		},
+		{
+			msgType: MsgClosingComplete,
+			scenario: func(m ClosingComplete) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgClosingSig,
+			scenario: func(m ClosingSig) bool {
+				return mainScenario(&m)
+			},
+		},
 		{
 			msgType: MsgUpdateAddHTLC,
 			scenario: func(m UpdateAddHTLC) bool {
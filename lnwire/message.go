@@ -53,6 +53,15 @@ const (
 	MsgGossipTimestampRange                = 265
 )
 
+// The message types of RBF based cooperative close negotiation. The format of
+// these messages doesn't follow the closing_complete and closing_sig messages
+// of the spec, so they use experimental types within the custom range until
+// the spec format is implemented.
+const (
+	MsgClosingComplete = CustomTypeStart + 40
+	MsgClosingSig      = CustomTypeStart + 41
+)
+
 // ErrorEncodeMessage is used when failed to encode the message payload.
 func ErrorEncodeMessage(err error) error {
 	return fmt.Errorf("failed to encode message to buffer, got %w", err)
@@ -94,6 +103,10 @@ func (t MessageType) String() string {
 		return "Shutdown"
 	case MsgClosingSigned:
 		return "ClosingSigned"
+	case MsgClosingComplete:
+		return "ClosingComplete"
+	case MsgClosingSig:
+		return "ClosingSig"
 	case MsgUpdateAddHTLC:
 		return "UpdateAddHTLC"
 	case MsgUpdateFailHTLC:
@@ -196,6 +209,10 @@ func makeEmptyMessage(msgType MessageType) (Message, error) {
 		msg = &Shutdown{}
 	case MsgClosingSigned:
 		msg = &ClosingSigned{}
+	case MsgClosingComplete:
+		msg = &ClosingComplete{}
+	case MsgClosingSig:
+		msg = &ClosingSig{}
 	case MsgUpdateAddHTLC:
 		msg = &UpdateAddHTLC{}
 	case MsgUpdateFailHTLC:
@@ -271,6 +271,8 @@ func makeAllMessages(t testing.TB, r *rand.Rand) []lnwire.Message {
 	msgAll = append(msgAll, newMsgChannelReady(t, r))
 	msgAll = append(msgAll, newMsgShutdown(t, r))
 	msgAll = append(msgAll, newMsgClosingSigned(t, r))
+	msgAll = append(msgAll, newMsgClosingComplete(t, r))
+	msgAll = append(msgAll, newMsgClosingSig(t, r))
 	msgAll = append(msgAll, newMsgUpdateAddHTLC(t, r))
 	msgAll = append(msgAll, newMsgUpdateFulfillHTLC(t, r))
 	msgAll = append(msgAll, newMsgUpdateFailHTLC(t, r))
@@ -499,6 +501,40 @@ func newMsgClosingSigned(t testing.TB, r *rand.Rand) *lnwire.ClosingSigned {
 	return msg
 }
 
+func newMsgClosingComplete(t testing.TB,
+	r *rand.Rand) *lnwire.ClosingComplete {
+
+	t.Helper()
+
+	msg := &lnwire.ClosingComplete{
+		FeeSatoshis: ltcutil.Amount(r.Int63()),
+		LockTime:    r.Uint32(),
+		ClosingSig:  testNodeSig,
+		ExtraData:   createExtraData(t, r),
+	}
+
+	_, err := r.Read(msg.ChannelID[:])
+	require.NoError(t, err, "unable to generate chan id")
+
+	return msg
+}
+
+func newMsgClosingSig(t testing.TB, r *rand.Rand) *lnwire.ClosingSig {
+	t.Helper()
+
+	msg := &lnwire.ClosingSig{
+		FeeSatoshis: ltcutil.Amount(r.Int63()),
+		LockTime:    r.Uint32(),
+		ClosingSig:  testNodeSig,
+		ExtraData:   createExtraData(t, r),
+	}
+
+	_, err := r.Read(msg.ChannelID[:])
+	require.NoError(t, err, "unable to generate chan id")
+
+	return msg
+}
+
 func newMsgUpdateAddHTLC(t testing.TB, r *rand.Rand) *lnwire.UpdateAddHTLC {
 	t.Helper()
 
@@ -3,6 +3,9 @@ package lnwire
 import (
 	"bytes"
 	"io"
+
+	"github.com/ltcsuite/lnd/tlv"
+	"github.com/ltcsuite/ltcd/btcec/v2"
 )
 
 // OnionPacketSize is the size of the serialized Sphinx onion packet included
@@ -54,6 +57,17 @@ type UpdateAddHTLC struct {
 	// used in the subsequent UpdateAddHTLC message.
 	OnionBlob [OnionPacketSize]byte
 
+	// BlindingPoint is the ephemeral public key that the receiving node
+	// needs to decrypt its encrypted recipient data if the HTLC is part of
+	// a blinded route. It is only set for HTLCs that are forwarded to
+	// nodes that follow the introduction node of the blinded route.
+	BlindingPoint *btcec.PublicKey
+
+	// CustomRecords is the set of custom TLV records that are attached to
+	// the HTLC. These are sent in the extra data of the message, not in
+	// the onion, so they are only visible to the receiving peer.
+	CustomRecords CustomRecords
+
 	// ExtraData is the set of data that was appended to this message to
 	// fill out the full maximum transport message size. These fields can
 	// be used to specify optional data such as custom TLV fields.
@@ -74,15 +88,46 @@ var _ Message = (*UpdateAddHTLC)(nil)
 //
 // This is part of the lnwire.Message interface.
 func (c *UpdateAddHTLC) Decode(r io.Reader, pver uint32) error {
-	return ReadElements(r,
+	err := ReadElements(r,
 		&c.ChanID,
 		&c.ID,
 		&c.Amount,
 		c.PaymentHash[:],
 		&c.Expiry,
 		c.OnionBlob[:],
-		&c.ExtraData,
 	)
+	if err != nil {
+		return err
+	}
+
+	var tlvRecords ExtraOpaqueData
+	if err := ReadElements(r, &tlvRecords); err != nil {
+		return err
+	}
+
+	// Next we'll parse out the set of known records. For now, this is just
+	// the BlindingPointRecordType.
+	var blindingPoint BlindingPoint
+	typeMap, err := tlvRecords.ExtractRecords(&blindingPoint)
+	if err != nil {
+		return err
+	}
+
+	// We'll only set BlindingPoint if the corresponding TLV type was
+	// included in the stream.
+	if val, ok := typeMap[BlindingPointRecordType]; ok && val == nil {
+		key := btcec.PublicKey(blindingPoint)
+		c.BlindingPoint = &key
+	}
+
+	// Any records in the custom range are exposed as custom records.
+	c.CustomRecords = customRecordsFromTypeMap(typeMap)
+
+	if len(tlvRecords) != 0 {
+		c.ExtraData = tlvRecords
+	}
+
+	return nil
 }
 
 // Encode serializes the target UpdateAddHTLC into the passed io.Writer
@@ -114,6 +159,30 @@ func (c *UpdateAddHTLC) Encode(w *bytes.Buffer, pver uint32) error {
 		return err
 	}
 
+	// We'll only encode the BlindingPoint and custom records in a TLV
+	// segment if they exist. Otherwise the extra data is passed through as
+	// is.
+	var recordProducers []tlv.RecordProducer
+	if c.BlindingPoint != nil {
+		blindingPoint := BlindingPoint(*c.BlindingPoint)
+		recordProducers = append(recordProducers, &blindingPoint)
+	}
+	if len(c.CustomRecords) > 0 {
+		if err := c.CustomRecords.Validate(); err != nil {
+			return err
+		}
+
+		recordProducers = append(
+			recordProducers, c.CustomRecords.RecordProducers()...,
+		)
+	}
+	if len(recordProducers) > 0 {
+		err := EncodeMessageExtraData(&c.ExtraData, recordProducers...)
+		if err != nil {
+			return err
+		}
+	}
+
 	return WriteBytes(w, c.ExtraData)
 }
 
@@ -365,6 +365,26 @@ func WriteOnionAddr(buf *bytes.Buffer, addr *tor.OnionAddr) error {
 	return WriteUint16(buf, uint16(addr.Port))
 }
 
+// WriteDNSAddr appends the DNS address to the provided buffer.
+func WriteDNSAddr(buf *bytes.Buffer, addr *DNSAddress) error {
+	if addr == nil {
+		return ErrNilDNSAddress
+	}
+
+	if err := addr.Validate(); err != nil {
+		return err
+	}
+
+	data := make([]byte, 0, 2+len(addr.Hostname))
+	data = append(data, uint8(dnsAddr), uint8(len(addr.Hostname)))
+	data = append(data, addr.Hostname...)
+	if _, err := buf.Write(data); err != nil {
+		return err
+	}
+
+	return WriteUint16(buf, addr.Port)
+}
+
 // WriteOpaqueAddrs appends the payload of the given OpaqueAddrs to buffer.
 func WriteOpaqueAddrs(buf *bytes.Buffer, addr *OpaqueAddrs) error {
 	if addr == nil {
@@ -394,6 +414,10 @@ func WriteNetAddrs(buf *bytes.Buffer, addresses []net.Addr) error {
 			if err := WriteOnionAddr(addrBuf, a); err != nil {
 				return err
 			}
+		case *DNSAddress:
+			if err := WriteDNSAddr(addrBuf, a); err != nil {
+				return err
+			}
 		case *OpaqueAddrs:
 			if err := WriteOpaqueAddrs(addrBuf, a); err != nil {
 				return err
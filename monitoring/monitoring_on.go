@@ -6,15 +6,58 @@ package monitoring
 import (
 	"net/http"
 	"sync"
+	"time"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/ltcsuite/lnd/kvdb"
+	"github.com/ltcsuite/lnd/kvdb/sqlbase"
 	"github.com/ltcsuite/lnd/lncfg"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 )
 
 var started sync.Once
 
+// sqlQueryLatency tracks the latency of the queries the SQL database backends
+// run, labeled by the table prefix and the type of the query.
+var sqlQueryLatency = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: "lnd",
+		Subsystem: "db",
+		Name:      "sql_query_latency_seconds",
+		Help:      "Latency of SQL database backend queries.",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14),
+	},
+	[]string{"prefix", "type"},
+)
+
+// dbTxTotal counts the database transactions, labeled by the top-level bucket
+// they accessed and whether they were read or write transactions.
+var dbTxTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "lnd",
+		Subsystem: "db",
+		Name:      "transactions_total",
+		Help:      "Number of database transactions per top-level bucket.",
+	},
+	[]string{"bucket", "type"},
+)
+
+// dbTxDuration tracks the duration of the database transactions, labeled by
+// the top-level bucket they accessed and whether they were read or write
+// transactions.
+var dbTxDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: "lnd",
+		Subsystem: "db",
+		Name:      "transaction_duration_seconds",
+		Help:      "Duration of database transactions per top-level bucket.",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14),
+	},
+	[]string{"bucket", "type"},
+)
+
 // GetPromInterceptors returns the set of interceptors for Prometheus
 // monitoring.
 func GetPromInterceptors() ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor) {
@@ -43,6 +86,18 @@ func ExportPrometheusMetrics(grpcServer *grpc.Server, cfg lncfg.Prometheus) erro
 			grpc_prometheus.EnableHandlingTimeHistogram()
 		}
 
+		prometheus.MustRegister(sqlQueryLatency)
+		sqlbase.SetQueryObserver(func(prefix string,
+			queryType sqlbase.QueryType, latency time.Duration) {
+
+			sqlQueryLatency.WithLabelValues(
+				prefix, string(queryType),
+			).Observe(latency.Seconds())
+		})
+
+		prometheus.MustRegister(dbTxTotal, dbTxDuration)
+		kvdb.SetTxObserver(observeDBTx)
+
 		http.Handle("/metrics", promhttp.Handler())
 		go func() {
 			http.ListenAndServe(cfg.Listen, nil)
@@ -51,3 +106,24 @@ func ExportPrometheusMetrics(grpcServer *grpc.Server, cfg lncfg.Prometheus) erro
 
 	return nil
 }
+
+// observeDBTx records the metrics of a finished database transaction for each
+// of the top-level buckets it accessed.
+func observeDBTx(stats *kvdb.TxStats) {
+	txType := "write"
+	if stats.ReadOnly {
+		txType = "read"
+	}
+
+	buckets := stats.Buckets
+	if len(buckets) == 0 {
+		buckets = []string{"none"}
+	}
+
+	for _, bucket := range buckets {
+		dbTxTotal.WithLabelValues(bucket, txType).Inc()
+		dbTxDuration.WithLabelValues(bucket, txType).Observe(
+			stats.Duration.Seconds(),
+		)
+	}
+}
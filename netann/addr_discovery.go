@@ -0,0 +1,313 @@
+package netann
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ltcsuite/lnd/ticker"
+)
+
+// ExternalIPSource is a source that's able to discover the external IP
+// address of the node. The NAT traversal techniques of the nat package
+// implement this interface.
+type ExternalIPSource interface {
+	// ExternalIP returns the external IP address.
+	ExternalIP() (net.IP, error)
+
+	// Name returns a human readable name of the source.
+	Name() string
+}
+
+// maxProbeResponseSize is the maximum number of bytes we read from the
+// response of an external IP probe.
+const maxProbeResponseSize = 128
+
+// HTTPIPProbe is an ExternalIPSource that queries an external service which
+// responds with the IP address the request originated from as plain text.
+// Depending on the resolved address of the service, the probe returns either
+// the external IPv4 or IPv6 address of the node.
+type HTTPIPProbe struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPIPProbe creates a new HTTPIPProbe that queries the given URL.
+func NewHTTPIPProbe(url string, timeout time.Duration) *HTTPIPProbe {
+	return &HTTPIPProbe{
+		url: url,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// ExternalIP queries the probe URL and returns the IP address it responded
+// with.
+//
+// NOTE: This is part of the ExternalIPSource interface.
+func (p *HTTPIPProbe) ExternalIP() (net.IP, error) {
+	resp, err := p.client.Get(p.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %v", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProbeResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address in response: %q",
+			body)
+	}
+
+	if !isPublicIP(ip) {
+		return nil, fmt.Errorf("%v is not a public IP address", ip)
+	}
+
+	return ip, nil
+}
+
+// Name returns a human readable name of the probe.
+//
+// NOTE: This is part of the ExternalIPSource interface.
+func (p *HTTPIPProbe) Name() string {
+	return fmt.Sprintf("probe %v", p.url)
+}
+
+// isPublicIP returns true if the given IP address is routable on the public
+// internet.
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsMulticast()
+}
+
+// AddrDiscovererConfig is the main config for the AddrDiscoverer.
+type AddrDiscovererConfig struct {
+	// Sources is the list of sources used to discover the external IP
+	// addresses of the node, in the order of preference. For each address
+	// family, the address of the first source that succeeds is used.
+	Sources []ExternalIPSource
+
+	// Ports is the set of ports the node is listening on. Each discovered
+	// IP address is announced with each of these ports.
+	Ports []uint16
+
+	// RefreshTicker ticks each time we should check for any address
+	// changes.
+	RefreshTicker ticker.Ticker
+
+	// AdvertisedIPs is the set of addresses that we've already announced
+	// with our current NodeAnnouncement. This set will be used to avoid
+	// unnecessary NodeAnnouncement updates.
+	AdvertisedIPs map[string]struct{}
+
+	// AnnounceNewIPs announces a new set of IP addresses for the backing
+	// Lightning node. The first set of addresses is the new set of
+	// addresses that we should advertise, while the other set are the
+	// stale addresses that we should no longer advertise.
+	AnnounceNewIPs func([]net.Addr, map[string]struct{}) error
+}
+
+// AddrDiscoverer is a sub-system that periodically discovers the external
+// IPv4 and IPv6 addresses of the node using a set of sources like UPnP,
+// NAT-PMP or external probes. If the discovered addresses change, a new
+// NodeAnnouncement that includes the new addresses is generated.
+type AddrDiscoverer struct {
+	cfg AddrDiscovererConfig
+
+	// announced is the set of addresses that were announced because of a
+	// discovery, keyed by their string representation.
+	announced map[string]net.Addr
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+// NewAddrDiscoverer returns a new instance of the AddrDiscoverer.
+func NewAddrDiscoverer(cfg AddrDiscovererConfig) *AddrDiscoverer {
+	return &AddrDiscoverer{
+		cfg:       cfg,
+		announced: make(map[string]net.Addr),
+		quit:      make(chan struct{}),
+	}
+}
+
+// Start starts the AddrDiscoverer.
+func (a *AddrDiscoverer) Start() error {
+	a.startOnce.Do(func() {
+		log.Info("AddrDiscoverer starting")
+		a.wg.Add(1)
+		go a.discoveryLoop()
+	})
+
+	return nil
+}
+
+// Stop signals the AddrDiscoverer for a graceful stop.
+func (a *AddrDiscoverer) Stop() error {
+	a.stopOnce.Do(func() {
+		log.Info("AddrDiscoverer shutting down")
+		close(a.quit)
+		a.wg.Wait()
+	})
+
+	return nil
+}
+
+// discoverIPs queries the sources for the external addresses of the node and
+// returns the discovered IPv4 and IPv6 address. A nil address means the
+// address of that family couldn't be discovered.
+func (a *AddrDiscoverer) discoverIPs() (net.IP, net.IP) {
+	var ipv4, ipv6 net.IP
+	for _, source := range a.cfg.Sources {
+		if ipv4 != nil && ipv6 != nil {
+			break
+		}
+
+		ip, err := source.ExternalIP()
+		if err != nil {
+			log.Debugf("Unable to discover external IP using "+
+				"%v: %v", source.Name(), err)
+			continue
+		}
+
+		switch {
+		case ip.To4() != nil && ipv4 == nil:
+			ipv4 = ip
+
+		case ip.To4() == nil && ipv6 == nil:
+			ipv6 = ip
+		}
+	}
+
+	return ipv4, ipv6
+}
+
+// refreshAddrs discovers the external addresses of the node and announces
+// them if they changed since the last refresh.
+func (a *AddrDiscoverer) refreshAddrs() {
+	ipv4, ipv6 := a.discoverIPs()
+
+	// We'll keep announcing the previous address of a family if we
+	// weren't able to discover it this time.
+	current := make(map[string]net.Addr)
+	for key, addr := range a.announced {
+		tcpAddr, ok := addr.(*net.TCPAddr)
+		if !ok {
+			continue
+		}
+
+		isIPv4 := tcpAddr.IP.To4() != nil
+		if (isIPv4 && ipv4 == nil) || (!isIPv4 && ipv6 == nil) {
+			current[key] = addr
+		}
+	}
+
+	for _, ip := range []net.IP{ipv4, ipv6} {
+		if ip == nil {
+			continue
+		}
+
+		for _, port := range a.cfg.Ports {
+			addr := &net.TCPAddr{IP: ip, Port: int(port)}
+			current[addr.String()] = addr
+		}
+	}
+
+	var addrsToUpdate []net.Addr
+	for key, addr := range current {
+		if _, ok := a.announced[key]; ok {
+			continue
+		}
+
+		// If this address has already been announced, then we'll
+		// skip it to avoid triggering an unnecessary node announcement
+		// update.
+		if _, ok := a.cfg.AdvertisedIPs[key]; ok {
+			continue
+		}
+
+		addrsToUpdate = append(addrsToUpdate, addr)
+	}
+
+	addrsToRemove := make(map[string]struct{})
+	for key := range a.announced {
+		if _, ok := current[key]; !ok {
+			addrsToRemove[key] = struct{}{}
+		}
+	}
+
+	// Even if nothing needs to be announced, for example because the
+	// discovered addresses were advertised already, we track them, so
+	// they are removed once they become stale.
+	if len(addrsToUpdate) == 0 && len(addrsToRemove) == 0 {
+		log.Debugf("No external address changes detected")
+		a.announced = current
+
+		return
+	}
+
+	log.Infof("External address change detected, announcing %v, "+
+		"removing %v", addrsToUpdate, mapKeys(addrsToRemove))
+
+	err := a.cfg.AnnounceNewIPs(addrsToUpdate, addrsToRemove)
+	if err != nil {
+		log.Warnf("Unable to announce new external addresses: %v", err)
+
+		// We keep the previous set, so the changes are announced
+		// again with the next refresh.
+		return
+	}
+
+	a.announced = current
+}
+
+// discoveryLoop periodically discovers the external addresses of the node.
+//
+// NOTE: This MUST be run as a goroutine.
+func (a *AddrDiscoverer) discoveryLoop() {
+	defer a.wg.Done()
+
+	a.refreshAddrs()
+
+	a.cfg.RefreshTicker.Resume()
+	defer a.cfg.RefreshTicker.Stop()
+
+	for {
+		select {
+		case <-a.cfg.RefreshTicker.Ticks():
+			log.Debugf("AddrDiscoverer checking for any external " +
+				"address changes...")
+
+			a.refreshAddrs()
+
+		case <-a.quit:
+			return
+		}
+	}
+}
+
+// mapKeys returns the keys of the given set as a list, used for logging.
+func mapKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,247 @@
+package netann
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/ticker"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIPSource is an ExternalIPSource that returns a preset IP address.
+type mockIPSource struct {
+	ips chan net.IP
+}
+
+// ExternalIP returns the next preset IP address, or an error if there is
+// none.
+func (m *mockIPSource) ExternalIP() (net.IP, error) {
+	select {
+	case ip := <-m.ips:
+		return ip, nil
+
+	default:
+		return nil, errors.New("no ip")
+	}
+}
+
+// Name returns the name of the mock source.
+func (m *mockIPSource) Name() string {
+	return "mock"
+}
+
+// TestAddrDiscovererUpdates tests that the AddrDiscoverer announces the
+// discovered addresses and replaces them once they change.
+func TestAddrDiscovererUpdates(t *testing.T) {
+	t.Parallel()
+
+	type annReq struct {
+		newAddrs     []net.Addr
+		removedAddrs map[string]struct{}
+	}
+	annReqs := make(chan annReq, 1)
+
+	v4Source := &mockIPSource{ips: make(chan net.IP, 1)}
+	v6Source := &mockIPSource{ips: make(chan net.IP, 1)}
+	refreshTicker := ticker.NewForce(time.Hour)
+
+	discoverer := NewAddrDiscoverer(AddrDiscovererConfig{
+		Sources:       []ExternalIPSource{v4Source, v6Source},
+		Ports:         []uint16{9735},
+		RefreshTicker: refreshTicker,
+		AdvertisedIPs: map[string]struct{}{
+			"1.1.1.1:9735": {},
+		},
+		AnnounceNewIPs: func(newAddrs []net.Addr,
+			removedAddrs map[string]struct{}) error {
+
+			annReqs <- annReq{
+				newAddrs:     newAddrs,
+				removedAddrs: removedAddrs,
+			}
+
+			return nil
+		},
+	})
+
+	// The first discovered address was already advertised, so no update
+	// should be triggered.
+	v4Source.ips <- net.ParseIP("1.1.1.1")
+	require.NoError(t, discoverer.Start())
+	t.Cleanup(func() {
+		require.NoError(t, discoverer.Stop())
+	})
+
+	tick := func(v4, v6 net.IP) {
+		if v4 != nil {
+			v4Source.ips <- v4
+		}
+		if v6 != nil {
+			v6Source.ips <- v6
+		}
+
+		select {
+		case refreshTicker.Force <- time.Now():
+		case <-time.After(time.Second):
+			t.Fatal("discoverer didn't consume tick")
+		}
+	}
+
+	expectAnn := func(newAddrs []string, removedAddrs ...string) {
+		select {
+		case req := <-annReqs:
+			var announced []string
+			for _, addr := range req.newAddrs {
+				announced = append(announced, addr.String())
+			}
+			require.ElementsMatch(t, newAddrs, announced)
+
+			removed := make(map[string]struct{})
+			for _, addr := range removedAddrs {
+				removed[addr] = struct{}{}
+			}
+			require.Equal(t, removed, req.removedAddrs)
+
+		case <-time.After(time.Second):
+			t.Fatal("no announcement")
+		}
+	}
+
+	// An IPv6 address is discovered in addition to the unchanged IPv4
+	// address.
+	tick(net.ParseIP("1.1.1.1"), net.ParseIP("2001:db8::1"))
+	expectAnn([]string{"[2001:db8::1]:9735"})
+
+	// If the IPv6 address can't be discovered, the previous one is kept,
+	// while the changed IPv4 address replaces the old one.
+	tick(net.ParseIP("8.8.8.8"), nil)
+	expectAnn([]string{"8.8.8.8:9735"}, "1.1.1.1:9735")
+
+	// Nothing changed, so there shouldn't be an announcement. We tick
+	// twice to make sure the first refresh has completed.
+	tick(net.ParseIP("8.8.8.8"), net.ParseIP("2001:db8::1"))
+	tick(net.ParseIP("8.8.8.8"), net.ParseIP("2001:db8::1"))
+	select {
+	case req := <-annReqs:
+		t.Fatalf("unexpected announcement: %v", req)
+	default:
+	}
+}
+
+// TestAddrDiscovererRemovesStale tests that an address that was discovered
+// without triggering an announcement, because it was advertised already, is
+// removed once it becomes stale.
+func TestAddrDiscovererRemovesStale(t *testing.T) {
+	t.Parallel()
+
+	removedAddrs := make(chan map[string]struct{}, 1)
+
+	source := &mockIPSource{ips: make(chan net.IP, 1)}
+	refreshTicker := ticker.NewForce(time.Hour)
+
+	discoverer := NewAddrDiscoverer(AddrDiscovererConfig{
+		Sources:       []ExternalIPSource{source},
+		Ports:         []uint16{9735},
+		RefreshTicker: refreshTicker,
+		AdvertisedIPs: map[string]struct{}{
+			"1.1.1.1:9735": {},
+		},
+		AnnounceNewIPs: func(_ []net.Addr,
+			removed map[string]struct{}) error {
+
+			removedAddrs <- removed
+
+			return nil
+		},
+	})
+
+	// The discovered address was advertised already, so the first refresh
+	// doesn't announce anything.
+	source.ips <- net.ParseIP("1.1.1.1")
+	require.NoError(t, discoverer.Start())
+	t.Cleanup(func() {
+		require.NoError(t, discoverer.Stop())
+	})
+
+	// Once the address changes, the advertised address is removed.
+	source.ips <- net.ParseIP("8.8.8.8")
+	select {
+	case refreshTicker.Force <- time.Now():
+	case <-time.After(time.Second):
+		t.Fatal("discoverer didn't consume tick")
+	}
+
+	select {
+	case removed := <-removedAddrs:
+		require.Equal(t, map[string]struct{}{
+			"1.1.1.1:9735": {},
+		}, removed)
+
+	case <-time.After(time.Second):
+		t.Fatal("no announcement")
+	}
+}
+
+// TestHTTPIPProbe tests that the HTTPIPProbe parses the IP address returned
+// by the probe service and rejects invalid and private addresses.
+func TestHTTPIPProbe(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		response   string
+		expectedIP net.IP
+		expectErr  bool
+	}{
+		{
+			name:       "ipv4",
+			response:   "8.8.8.8\n",
+			expectedIP: net.ParseIP("8.8.8.8"),
+		},
+		{
+			name:       "ipv6",
+			response:   "2001:4860:4860::8888",
+			expectedIP: net.ParseIP("2001:4860:4860::8888"),
+		},
+		{
+			name:      "invalid",
+			response:  "<html>",
+			expectErr: true,
+		},
+		{
+			name:      "private",
+			response:  "192.168.1.1",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) {
+					fmt.Fprint(w, tc.response)
+				},
+			))
+			t.Cleanup(server.Close)
+
+			probe := NewHTTPIPProbe(server.URL, time.Second)
+			ip, err := probe.ExternalIP()
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, tc.expectedIP.Equal(ip))
+		})
+	}
+}
@@ -87,6 +87,26 @@ type ChanStatusConfig struct {
 	// manager to check if the channels being monitored have become
 	// inactive.
 	ChanStatusSampleInterval time.Duration
+
+	// UpdateBatchWindow is the duration during which status and policy
+	// changes of a channel are collected before a single channel update
+	// that contains all of them is signed and broadcast. This avoids
+	// spamming the network with updates if many changes are made in a
+	// short time, e.g. when adjusting the fees of many channels. If zero,
+	// every change is broadcast immediately.
+	UpdateBatchWindow time.Duration
+}
+
+// pendingChanUpdate holds the changes of a channel that are collected during
+// the update batch window.
+type pendingChanUpdate struct {
+	// disabled is the disabled bit the next update should have, or nil if
+	// the status of the channel wasn't changed.
+	disabled *bool
+
+	// modifiers are the policy changes that should be applied to the next
+	// update.
+	modifiers []ChannelUpdateModifier
 }
 
 // ChanStatusManager facilitates requests to enable or disable a channel via a
@@ -121,6 +141,19 @@ type ChanStatusManager struct {
 	// state management into the primary event loop.
 	autoRequests chan statusRequest
 
+	// policyRequests pipes external requests to change the policy of a
+	// channel into the primary event loop.
+	policyRequests chan policyRequest
+
+	// pendingUpdates contains the changes per channel that are collected
+	// during the current update batch window. Access to the map is
+	// serialized by the statusManager's event loop.
+	pendingUpdates map[wire.OutPoint]*pendingChanUpdate
+
+	// batchTimer is the timer for the current update batch window. It is
+	// nil if no batch window is running.
+	batchTimer *time.Timer
+
 	// statusSampleTicker fires at the interval prescribed by
 	// ChanStatusSampleInterval to check if channels in chanStates have
 	// become inactive.
@@ -166,6 +199,8 @@ func NewChanStatusManager(cfg *ChanStatusConfig) (*ChanStatusManager, error) {
 		enableRequests:     make(chan statusRequest),
 		disableRequests:    make(chan statusRequest),
 		autoRequests:       make(chan statusRequest),
+		policyRequests:     make(chan policyRequest),
+		pendingUpdates:     make(map[wire.OutPoint]*pendingChanUpdate),
 		quit:               make(chan struct{}),
 	}, nil
 }
@@ -285,6 +320,42 @@ func (m *ChanStatusManager) RequestAuto(outpoint wire.OutPoint) error {
 	return m.submitRequest(m.autoRequests, outpoint, true)
 }
 
+// RequestPolicyUpdate submits a request to apply the given modifiers to the
+// next channel update of the channel identified by the provided outpoint. If an
+// update batch window is configured, the changes are combined with all other
+// changes of the channel made within the window into a single update.
+// Otherwise, a new update is signed and broadcast immediately.
+func (m *ChanStatusManager) RequestPolicyUpdate(outpoint wire.OutPoint,
+	modifiers ...ChannelUpdateModifier) error {
+
+	req := policyRequest{
+		outpoint:  outpoint,
+		modifiers: modifiers,
+		errChan:   make(chan error, 1),
+	}
+
+	select {
+	case m.policyRequests <- req:
+	case <-m.quit:
+		return ErrChanStatusManagerExiting
+	}
+
+	select {
+	case err := <-req.errChan:
+		return err
+	case <-m.quit:
+		return ErrChanStatusManagerExiting
+	}
+}
+
+// policyRequest is passed to the statusManager to request a change in the
+// policy of a particular channel point.
+type policyRequest struct {
+	outpoint  wire.OutPoint
+	modifiers []ChannelUpdateModifier
+	errChan   chan error
+}
+
 // statusRequest is passed to the statusManager to request a change in status
 // for a particular channel point.  The exact action is governed by passing the
 // request through one of the enableRequests or disableRequests channels.
@@ -347,6 +418,17 @@ func (m *ChanStatusManager) statusManager() {
 		case req := <-m.autoRequests:
 			req.errChan <- m.processAutoRequest(req.outpoint)
 
+		// Process any requests to change the policy of a channel.
+		case req := <-m.policyRequests:
+			req.errChan <- m.queueUpdate(
+				req.outpoint, nil, req.modifiers...,
+			)
+
+		// The update batch window elapsed, so we'll broadcast all
+		// collected changes.
+		case <-m.batchTimeout():
+			m.flushPendingUpdates()
+
 		// Use long-polling to detect when channels become inactive.
 		case <-m.statusSampleTicker.C:
 			// First, do a sweep and mark any ChanStatusEnabled
@@ -362,11 +444,29 @@ func (m *ChanStatusManager) statusManager() {
 			m.disableInactiveChannels()
 
 		case <-m.quit:
+			if m.batchTimer != nil {
+				m.batchTimer.Stop()
+			}
+			if len(m.pendingUpdates) > 0 {
+				log.Debugf("Dropping %d pending channel updates",
+					len(m.pendingUpdates))
+			}
+
 			return
 		}
 	}
 }
 
+// batchTimeout returns the channel that fires once the current update batch
+// window elapsed, or nil if no batch window is running.
+func (m *ChanStatusManager) batchTimeout() <-chan time.Time {
+	if m.batchTimer == nil {
+		return nil
+	}
+
+	return m.batchTimer.C
+}
+
 // processEnableRequest attempts to enable the given outpoint.
 //
 //   - If the channel is not active at the time of the request,
@@ -618,13 +718,79 @@ func (m *ChanStatusManager) fetchChannels() ([]*channeldb.OpenChannel, error) {
 }
 
 // signAndSendNextUpdate computes and signs a valid update for the passed
-// outpoint, with the ability to toggle the disabled bit. The new update will
-// use the current time as the update's timestamp, or increment the old
-// timestamp by 1 to ensure the update can propagate. If signing is successful,
-// the new update will be sent out on the network.
+// outpoint, with the ability to toggle the disabled bit. If an update batch
+// window is configured, the update is only sent once the window elapsed.
 func (m *ChanStatusManager) signAndSendNextUpdate(outpoint wire.OutPoint,
 	disabled bool) error {
 
+	return m.queueUpdate(outpoint, &disabled)
+}
+
+// queueUpdate adds the given status and policy changes to the pending update
+// of the outpoint and starts a new update batch window if none is running. If
+// no batch window is configured, the update is signed and sent immediately.
+func (m *ChanStatusManager) queueUpdate(outpoint wire.OutPoint,
+	disabled *bool, modifiers ...ChannelUpdateModifier) error {
+
+	if m.cfg.UpdateBatchWindow <= 0 {
+		return m.sendUpdate(outpoint, &pendingChanUpdate{
+			disabled:  disabled,
+			modifiers: modifiers,
+		})
+	}
+
+	// We'll make sure we know the channel, so the caller learns about a
+	// closed channel right away instead of only when the batch is sent.
+	_, _, err := m.fetchLastChanUpdateByOutPoint(outpoint)
+	if err != nil {
+		return err
+	}
+
+	pending, ok := m.pendingUpdates[outpoint]
+	if !ok {
+		pending = &pendingChanUpdate{}
+		m.pendingUpdates[outpoint] = pending
+	}
+	if disabled != nil {
+		pending.disabled = disabled
+	}
+	pending.modifiers = append(pending.modifiers, modifiers...)
+
+	if m.batchTimer == nil {
+		m.batchTimer = time.NewTimer(m.cfg.UpdateBatchWindow)
+	}
+
+	return nil
+}
+
+// flushPendingUpdates signs and sends a single update for each channel that
+// has changes pending from the elapsed update batch window.
+func (m *ChanStatusManager) flushPendingUpdates() {
+	m.batchTimer = nil
+
+	pendingUpdates := m.pendingUpdates
+	m.pendingUpdates = make(map[wire.OutPoint]*pendingChanUpdate)
+
+	log.Debugf("Sending batched updates for %d channels",
+		len(pendingUpdates))
+
+	for outpoint, pending := range pendingUpdates {
+		err := m.sendUpdate(outpoint, pending)
+		if err != nil {
+			log.Errorf("Unable to send batched update for "+
+				"channel(%v): %v", outpoint, err)
+		}
+	}
+}
+
+// sendUpdate computes and signs a valid update for the passed outpoint that
+// contains the given changes. The new update will use the current time as the
+// update's timestamp, or increment the old timestamp by 1 to ensure the update
+// can propagate. If signing is successful, the new update will be sent out on
+// the network.
+func (m *ChanStatusManager) sendUpdate(outpoint wire.OutPoint,
+	pending *pendingChanUpdate) error {
+
 	// Retrieve the latest update for this channel. We'll use this
 	// as our starting point to send the new update.
 	chanUpdate, private, err := m.fetchLastChanUpdateByOutPoint(outpoint)
@@ -632,9 +798,30 @@ func (m *ChanStatusManager) signAndSendNextUpdate(outpoint wire.OutPoint,
 		return err
 	}
 
+	modifiers := pending.modifiers
+	if pending.disabled != nil {
+		// If the status was toggled back and forth within the batch
+		// window and there are no other changes, there's nothing to
+		// announce.
+		isDisabled := chanUpdate.ChannelFlags&
+			lnwire.ChanUpdateDisabled != 0
+		if m.cfg.UpdateBatchWindow > 0 && len(modifiers) == 0 &&
+			isDisabled == *pending.disabled {
+
+			log.Debugf("Channel(%v) status unchanged, skipped "+
+				"announcement", outpoint)
+
+			return nil
+		}
+
+		modifiers = append(
+			modifiers, ChanUpdSetDisable(*pending.disabled),
+		)
+	}
+	modifiers = append(modifiers, ChanUpdSetTimestamp)
+
 	err = SignChannelUpdate(
-		m.cfg.MessageSigner, m.cfg.OurKeyLoc, chanUpdate,
-		ChanUpdSetDisable(disabled), ChanUpdSetTimestamp,
+		m.cfg.MessageSigner, m.cfg.OurKeyLoc, chanUpdate, modifiers...,
 	)
 	if err != nil {
 		return err
@@ -927,3 +927,79 @@ func TestChanStatusManagerStateMachine(t *testing.T) {
 		})
 	}
 }
+
+// TestChanStatusManagerUpdateBatching tests that all status and policy changes
+// of a channel made within the update batch window are announced with a single
+// channel update, and that status changes that cancel each other out aren't
+// announced at all.
+func TestChanStatusManagerUpdateBatching(t *testing.T) {
+	t.Parallel()
+
+	const (
+		numChannels = 5
+		batchWindow = 200 * time.Millisecond
+	)
+
+	cfg, graph, htlcSwitch := newManagerCfg(t, numChannels, true)
+	cfg.UpdateBatchWindow = batchWindow
+
+	mgr, err := netann.NewChanStatusManager(cfg)
+	require.NoError(t, err)
+	require.NoError(t, mgr.Start())
+	t.Cleanup(func() {
+		require.NoError(t, mgr.Stop())
+	})
+
+	h := testHarness{
+		t:          t,
+		graph:      graph,
+		htlcSwitch: htlcSwitch,
+		mgr:        mgr,
+	}
+	h.markActive(graph.chans())
+
+	// Toggling the status back and forth within the window doesn't result
+	// in any update.
+	h.assertDisables(graph.chans(), nil, true)
+	h.assertEnables(graph.chans(), nil, true)
+	h.assertNoUpdates(2 * batchWindow)
+
+	// Combine a status and a policy change for each channel. The policy
+	// change must not affect the disabled bit.
+	const baseFee = lnwire.MilliSatoshi(1000)
+	setBaseFee := netann.ChanUpdSetPolicy(&channeldb.ChannelEdgePolicy{
+		FeeBaseMSat: baseFee,
+	})
+	h.assertDisables(graph.chans(), nil, true)
+	for _, channel := range graph.chans() {
+		err := mgr.RequestPolicyUpdate(
+			channel.FundingOutpoint, setBaseFee,
+		)
+		require.NoError(t, err)
+	}
+
+	// We expect exactly one update per channel that contains both
+	// changes.
+	expSids := sidsFromChans(graph.chans())
+	for i := 0; i < numChannels; i++ {
+		select {
+		case upd := <-graph.updates:
+			_, ok := expSids[upd.ShortChannelID]
+			require.True(t, ok, "unexpected update")
+			delete(expSids, upd.ShortChannelID)
+
+			require.NotZero(
+				t, upd.ChannelFlags&lnwire.ChanUpdateDisabled,
+			)
+			require.EqualValues(t, baseFee, upd.BaseFee)
+
+		case <-time.After(5 * batchWindow):
+			t.Fatalf("expected update")
+		}
+	}
+	h.assertNoUpdates(2 * batchWindow)
+
+	// Requests for unknown channels are rejected right away.
+	err = mgr.RequestPolicyUpdate(randOutpoint(t), setBaseFee)
+	require.ErrorIs(t, err, channeldb.ErrEdgeNotFound)
+}
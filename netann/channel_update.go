@@ -36,6 +36,21 @@ func ChanUpdSetDisable(disabled bool) ChannelUpdateModifier {
 	}
 }
 
+// ChanUpdSetPolicy is a functional option that sets the forwarding policy of
+// the update to the one of the given edge policy. The channel flags are left
+// untouched, so the disabled bit isn't affected.
+func ChanUpdSetPolicy(policy *channeldb.ChannelEdgePolicy) ChannelUpdateModifier {
+	return func(update *lnwire.ChannelUpdate) {
+		update.MessageFlags = policy.MessageFlags
+		update.TimeLockDelta = policy.TimeLockDelta
+		update.HtlcMinimumMsat = policy.MinHTLC
+		update.HtlcMaximumMsat = policy.MaxHTLC
+		update.BaseFee = uint32(policy.FeeBaseMSat)
+		update.FeeRate = uint32(policy.FeeProportionalMillionths)
+		update.ExtraOpaqueData = policy.ExtraOpaqueData
+	}
+}
+
 // ChanUpdSetTimestamp is a functional option that sets the timestamp of the
 // update to the current time, or increments it if the timestamp is already in
 // the future.
@@ -0,0 +1,82 @@
+package netann
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// nodeAnnJitterDivisor determines the maximum jitter applied to the node
+// announcement rebroadcast interval as a fraction of the interval.
+const nodeAnnJitterDivisor = 10
+
+// NodeAnnRebroadcastSchedule tracks when our own node announcement becomes
+// stale and needs to be refreshed and rebroadcast, so other nodes don't prune
+// us from their graph as a zombie. To prevent nodes that were started at the
+// same time from rebroadcasting in lockstep, the interval is shortened by a
+// random jitter of up to a tenth of the interval, which is drawn again after
+// each rebroadcast.
+//
+// NOTE: The timestamp of the refreshed announcement should be set using
+// NodeAnnSetTimestamp, which makes sure it increases monotonically.
+type NodeAnnRebroadcastSchedule struct {
+	interval time.Duration
+
+	mu     sync.Mutex
+	jitter time.Duration
+}
+
+// NewNodeAnnRebroadcastSchedule creates a new schedule that considers a node
+// announcement stale once it's older than the given interval minus the
+// jitter.
+func NewNodeAnnRebroadcastSchedule(
+	interval time.Duration) *NodeAnnRebroadcastSchedule {
+
+	s := &NodeAnnRebroadcastSchedule{
+		interval: interval,
+	}
+	s.resetJitter()
+
+	return s
+}
+
+// resetJitter draws a new random jitter.
+//
+// NOTE: The mutex MUST be held or the schedule not be shared yet.
+func (s *NodeAnnRebroadcastSchedule) resetJitter() {
+	maxJitter := int64(s.interval / nodeAnnJitterDivisor)
+	if maxJitter <= 0 {
+		s.jitter = 0
+		return
+	}
+
+	s.jitter = time.Duration(rand.Int63n(maxJitter + 1))
+}
+
+// NextRebroadcast returns the time at which a node announcement with the
+// given timestamp becomes stale.
+func (s *NodeAnnRebroadcastSchedule) NextRebroadcast(
+	timestamp uint32) time.Time {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return time.Unix(int64(timestamp), 0).Add(s.interval - s.jitter)
+}
+
+// IsStale returns true if a node announcement with the given timestamp should
+// be refreshed and rebroadcast at the given time.
+func (s *NodeAnnRebroadcastSchedule) IsStale(timestamp uint32,
+	now time.Time) bool {
+
+	return !now.Before(s.NextRebroadcast(timestamp))
+}
+
+// Rebroadcasted marks that a refreshed node announcement was broadcast, which
+// draws a new jitter for the next rebroadcast.
+func (s *NodeAnnRebroadcastSchedule) Rebroadcasted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resetJitter()
+}
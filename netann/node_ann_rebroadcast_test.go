@@ -0,0 +1,38 @@
+package netann
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestNodeAnnRebroadcastSchedule tests that a node announcement becomes stale
+// within the jitter window before the rebroadcast interval elapsed.
+func TestNodeAnnRebroadcastSchedule(t *testing.T) {
+	t.Parallel()
+
+	const interval = 7 * 24 * time.Hour
+	maxJitter := interval / nodeAnnJitterDivisor
+
+	s := NewNodeAnnRebroadcastSchedule(interval)
+
+	timestamp := uint32(time.Now().Unix())
+	annTime := time.Unix(int64(timestamp), 0)
+
+	for i := 0; i < 100; i++ {
+		next := s.NextRebroadcast(timestamp)
+		require.False(t, next.Before(annTime.Add(interval-maxJitter)))
+		require.False(t, next.After(annTime.Add(interval)))
+
+		// The announcement is never stale before the jitter window
+		// and always stale once the full interval elapsed.
+		require.False(t, s.IsStale(
+			timestamp, annTime.Add(interval-maxJitter-time.Second),
+		))
+		require.True(t, s.IsStale(timestamp, annTime.Add(interval)))
+		require.True(t, s.IsStale(timestamp, next))
+
+		s.Rebroadcasted()
+	}
+}
@@ -30,6 +30,42 @@ func NodeAnnSetAddrs(addrs []net.Addr) func(*lnwire.NodeAnnouncement) {
 	}
 }
 
+// NodeAnnReplaceAddrs is a functional option that removes the stale addresses
+// from the given node announcement and adds the new addresses that aren't
+// announced yet. The addresses are compared by their string representation.
+func NodeAnnReplaceAddrs(stale,
+	fresh []net.Addr) func(*lnwire.NodeAnnouncement) {
+
+	return func(nodeAnn *lnwire.NodeAnnouncement) {
+		remove := make(map[string]struct{}, len(stale))
+		for _, addr := range stale {
+			remove[addr.String()] = struct{}{}
+		}
+
+		addrs := make([]net.Addr, 0, len(nodeAnn.Addresses)+len(fresh))
+		known := make(map[string]struct{}, len(nodeAnn.Addresses))
+		for _, addr := range nodeAnn.Addresses {
+			if _, ok := remove[addr.String()]; ok {
+				continue
+			}
+
+			addrs = append(addrs, addr)
+			known[addr.String()] = struct{}{}
+		}
+
+		for _, addr := range fresh {
+			if _, ok := known[addr.String()]; ok {
+				continue
+			}
+
+			addrs = append(addrs, addr)
+			known[addr.String()] = struct{}{}
+		}
+
+		nodeAnn.Addresses = addrs
+	}
+}
+
 // NodeAnnSetColor is a functional option that sets the color of the
 // given node announcement.
 func NodeAnnSetColor(newColor color.RGBA) func(*lnwire.NodeAnnouncement) {
@@ -0,0 +1,41 @@
+package netann
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/tor"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNodeAnnReplaceAddrs tests that stale addresses are removed from the node
+// announcement and new addresses are added without duplicates.
+func TestNodeAnnReplaceAddrs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ipAddr   = &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 9735}
+		oldOnion = &tor.OnionAddr{
+			OnionService: "old.onion",
+			Port:         9735,
+		}
+		newOnion = &tor.OnionAddr{
+			OnionService: "new.onion",
+			Port:         9735,
+		}
+	)
+
+	nodeAnn := &lnwire.NodeAnnouncement{
+		Addresses: []net.Addr{ipAddr, oldOnion},
+	}
+
+	NodeAnnReplaceAddrs(
+		[]net.Addr{oldOnion}, []net.Addr{newOnion},
+	)(nodeAnn)
+	require.Equal(t, []net.Addr{ipAddr, newOnion}, nodeAnn.Addresses)
+
+	// Adding an address that's already announced is a no-op.
+	NodeAnnReplaceAddrs(nil, []net.Addr{newOnion, ipAddr})(nodeAnn)
+	require.Equal(t, []net.Addr{ipAddr, newOnion}, nodeAnn.Addresses)
+}
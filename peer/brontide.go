@@ -219,6 +219,10 @@ type Config struct {
 	// initializing the coop close process.
 	FeeEstimator chainfee.Estimator
 
+	// CommitFeeEstimator is used by the channel links to compute the
+	// fee-per-kw proposed in commitment fee updates.
+	CommitFeeEstimator chainfee.Estimator
+
 	// Signer is used when creating *lnwallet.LightningChannel instances.
 	Signer input.Signer
 
@@ -307,6 +311,15 @@ type Config struct {
 	// initiator for anchor channel commitments.
 	MaxAnchorsCommitFeeRate chainfee.SatPerKWeight
 
+	// ReservedHtlcSlots is used when creating ChannelLinks and is the
+	// number of commitment slots of each channel that are reserved for our
+	// own outgoing payments.
+	ReservedHtlcSlots uint16
+
+	// FwdExpEndorsement is used when creating ChannelLinks and indicates
+	// whether the experimental endorsement signal of htlcs is forwarded.
+	FwdExpEndorsement bool
+
 	// CoopCloseTargetConfs is the confirmation target that will be used
 	// to estimate the fee rate to use during a cooperative channel
 	// closure initiated by the remote peer.
@@ -334,6 +347,11 @@ type Config struct {
 	// that is accumulated before signing a new commitment.
 	ChannelCommitBatchSize uint32
 
+	// ChannelCommitAdaptive, if set, signs a new commitment as soon as a
+	// channel has no further updates queued, and only batches updates
+	// while the channel is under load.
+	ChannelCommitAdaptive bool
+
 	// HandleCustomMessage is called whenever a custom message is received
 	// from the peer.
 	HandleCustomMessage func(peer [33]byte, msg *lnwire.Custom) error
@@ -464,6 +482,16 @@ type Brontide struct {
 	// well as lnwire.ClosingSigned messages.
 	chanCloseMsgs chan *closeMsg
 
+	// coopCloseBumps is a channel over which local requests to bump the
+	// fee of a cooperative close with RBF based close negotiation are
+	// sent.
+	coopCloseBumps chan *htlcswitch.ChanClose
+
+	// rbfCloseSpends is a channel over which the spends of channels that
+	// are closed with RBF based close negotiation are sent once they
+	// confirm.
+	rbfCloseSpends chan *rbfCloseSpend
+
 	// remoteFeatures is the feature vector received from the peer during
 	// the connection handshake.
 	remoteFeatures *lnwire.FeatureVector
@@ -515,6 +543,8 @@ func NewBrontide(cfg Config) *Brontide {
 		localCloseChanReqs: make(chan *htlcswitch.ChanClose),
 		linkFailures:       make(chan linkFailureReport),
 		chanCloseMsgs:      make(chan *closeMsg),
+		coopCloseBumps:     make(chan *htlcswitch.ChanClose),
+		rbfCloseSpends:     make(chan *rbfCloseSpend),
 		resentChanSyncMsg:  make(map[lnwire.ChannelID]struct{}),
 		startReady:         make(chan struct{}),
 		quit:               make(chan struct{}),
@@ -711,6 +741,14 @@ func (p *Brontide) taprootShutdownAllowed() bool {
 		p.LocalFeatures().HasFeature(lnwire.ShutdownAnySegwitOptional)
 }
 
+// rbfCoopCloseAllowed returns true if both parties have negotiated RBF based
+// cooperative close negotiation.
+func (p *Brontide) rbfCoopCloseAllowed() bool {
+	return p.RemoteFeatures().HasFeature(
+		lnwire.RbfCoopCloseOptionalStaging,
+	) && p.LocalFeatures().HasFeature(lnwire.RbfCoopCloseOptionalStaging)
+}
+
 // QuitSignal is a method that should return a channel which will be sent upon
 // or closed once the backing peer exits. This allows callers using the
 // interface to cancel any processing in the event the backing implementation
@@ -892,6 +930,7 @@ func (p *Brontide) loadActiveChannels(chans []*channeldb.OpenChannel) (
 				BaseFee:       selfPolicy.FeeBaseMSat,
 				FeeRate:       selfPolicy.FeeProportionalMillionths,
 				TimeLockDelta: uint32(selfPolicy.TimeLockDelta),
+				InboundFee:    selfPolicy.InboundFee(),
 			}
 		} else {
 			p.log.Warnf("Unable to find our forwarding policy "+
@@ -1011,7 +1050,7 @@ func (p *Brontide) addLink(chanPoint *wire.OutPoint,
 		Circuits:               p.cfg.Switch.CircuitModifier(),
 		ForwardPackets:         p.cfg.InterceptSwitch.ForwardPackets,
 		FwrdingPolicy:          *forwardingPolicy,
-		FeeEstimator:           p.cfg.FeeEstimator,
+		FeeEstimator:           p.cfg.CommitFeeEstimator,
 		PreimageCache:          p.cfg.WitnessBeacon,
 		ChainEvents:            chainEvents,
 		UpdateContractSignals:  updateContractSignals,
@@ -1024,6 +1063,7 @@ func (p *Brontide) addLink(chanPoint *wire.OutPoint,
 			p.cfg.PendingCommitInterval,
 		),
 		BatchSize:               p.cfg.ChannelCommitBatchSize,
+		AdaptiveBatching:        p.cfg.ChannelCommitAdaptive,
 		UnsafeReplay:            p.cfg.UnsafeReplay,
 		MinFeeUpdateTimeout:     htlcswitch.DefaultMinLinkFeeUpdateTimeout,
 		MaxFeeUpdateTimeout:     htlcswitch.DefaultMaxLinkFeeUpdateTimeout,
@@ -1032,6 +1072,8 @@ func (p *Brontide) addLink(chanPoint *wire.OutPoint,
 		MaxOutgoingCltvExpiry:   p.cfg.MaxOutgoingCltvExpiry,
 		MaxFeeAllocation:        p.cfg.MaxChannelFeeAllocation,
 		MaxAnchorsCommitFeeRate: p.cfg.MaxAnchorsCommitFeeRate,
+		ReservedHtlcSlots:       p.cfg.ReservedHtlcSlots,
+		FwdExpEndorsement:       p.cfg.FwdExpEndorsement,
 		NotifyActiveLink:        p.cfg.ChannelNotifier.NotifyActiveLinkEvent,
 		NotifyActiveChannel:     p.cfg.ChannelNotifier.NotifyActiveChannelEvent,
 		NotifyInactiveChannel:   p.cfg.ChannelNotifier.NotifyInactiveChannelEvent,
@@ -1618,6 +1660,20 @@ out:
 				break out
 			}
 
+		case *lnwire.ClosingComplete:
+			select {
+			case p.chanCloseMsgs <- &closeMsg{msg.ChannelID, msg}:
+			case <-p.quit:
+				break out
+			}
+
+		case *lnwire.ClosingSig:
+			select {
+			case p.chanCloseMsgs <- &closeMsg{msg.ChannelID, msg}:
+			case <-p.quit:
+				break out
+			}
+
 		case *lnwire.Warning:
 			targetChan = msg.ChanID
 			isLinkUpdate = p.handleWarningOrError(targetChan, msg)
@@ -1881,6 +1937,14 @@ func messageSummary(msg lnwire.Message) string {
 		return fmt.Sprintf("chan_id=%v, fee_sat=%v", msg.ChannelID,
 			msg.FeeSatoshis)
 
+	case *lnwire.ClosingComplete:
+		return fmt.Sprintf("chan_id=%v, fee_sat=%v, locktime=%v",
+			msg.ChannelID, msg.FeeSatoshis, msg.LockTime)
+
+	case *lnwire.ClosingSig:
+		return fmt.Sprintf("chan_id=%v, fee_sat=%v, locktime=%v",
+			msg.ChannelID, msg.FeeSatoshis, msg.LockTime)
+
 	case *lnwire.UpdateAddHTLC:
 		return fmt.Sprintf("chan_id=%v, id=%v, amt=%v, expiry=%v, hash=%x",
 			msg.ChanID, msg.ID, msg.Amount, msg.Expiry, msg.PaymentHash[:])
@@ -2000,10 +2064,8 @@ func (p *Brontide) logWireMessage(msg lnwire.Message, read bool) {
 			preposition = "from"
 		}
 
-		var msgType string
-		if msg.MsgType() < lnwire.CustomTypeStart {
-			msgType = msg.MsgType().String()
-		} else {
+		msgType := msg.MsgType().String()
+		if _, ok := msg.(*lnwire.Custom); ok {
 			msgType = "custom"
 		}
 
@@ -2463,6 +2525,16 @@ out:
 		case closeMsg := <-p.chanCloseMsgs:
 			p.handleCloseMsg(closeMsg)
 
+		// We've received a local request to bump the fee of a
+		// cooperative close with RBF based close negotiation.
+		case req := <-p.coopCloseBumps:
+			p.handleCoopCloseBump(req)
+
+		// A channel that is closed with RBF based close negotiation
+		// has been closed on-chain, so we can conclude the close.
+		case spend := <-p.rbfCloseSpends:
+			p.handleRbfCloseSpend(spend)
+
 		// The channel reannounce delay has elapsed, broadcast the
 		// reenabled channel updates to the network. This should only
 		// fire once, so we set the reenableTimeout channel to nil to
@@ -2925,6 +2997,8 @@ func (p *Brontide) createChanCloser(channel *lnwallet.LightningChannel,
 			},
 			ChainParams: &p.cfg.Wallet.Cfg.NetParams,
 			Quit:        p.quit,
+			RbfClose: p.rbfCoopCloseAllowed() &&
+				!channel.ChanType().IsTaproot(),
 		},
 		deliveryScript,
 		fee,
@@ -3188,6 +3262,13 @@ func (p *Brontide) fetchLinkFromKeyAndCid(
 // confirmed, the channel will be marked as fully closed within the database,
 // and any clients will be notified of updates to the closing state.
 func (p *Brontide) finalizeChanClosure(chanCloser *chancloser.ChanCloser) {
+	// With RBF based close negotiation, the closing transaction may still
+	// be replaced, so we wait for the channel to be closed by any of them.
+	if chanCloser.RbfClose() {
+		p.finalizeRbfChanClosure(chanCloser)
+		return
+	}
+
 	closeReq := chanCloser.CloseRequest()
 
 	// First, we'll clear all indexes related to the channel in question.
@@ -3242,6 +3323,213 @@ func (p *Brontide) finalizeChanClosure(chanCloser *chancloser.ChanCloser) {
 		})
 }
 
+// rbfCloseSpend is sent to the channelManager once a channel that is closed
+// with RBF based close negotiation has been closed on-chain.
+type rbfCloseSpend struct {
+	chanID      lnwire.ChannelID
+	closingTxid chainhash.Hash
+}
+
+// finalizeRbfChanClosure performs the clean up steps once a closing
+// transaction negotiated with RBF based close negotiation has been broadcast.
+// As either party may replace the closing transaction, the state machine is
+// kept until the funding output has been spent by any of them.
+func (p *Brontide) finalizeRbfChanClosure(chanCloser *chancloser.ChanCloser) {
+	closeReq := chanCloser.CloseRequest()
+
+	closingTx, err := chanCloser.ClosingTx()
+	if err != nil {
+		p.log.Error(err)
+		if closeReq != nil {
+			sendCloseErr(closeReq, err)
+		}
+
+		return
+	}
+
+	// If this is a locally requested shutdown, update the caller with the
+	// latest closing transaction.
+	if closeReq != nil {
+		closingTxid := closingTx.TxHash()
+		sendCloseUpdate(closeReq, &PendingUpdate{
+			Txid: closingTxid[:],
+		})
+	}
+
+	// If the channel was already wiped, this is a replacement of a prior
+	// closing transaction, and we're already waiting for the close.
+	chanPoint := chanCloser.Channel().ChannelPoint()
+	chanID := lnwire.NewChanIDFromOutPoint(chanPoint)
+	if _, ok := p.activeChannels.Load(chanID); !ok {
+		return
+	}
+
+	p.WipeChannel(chanPoint)
+
+	fundingPkScript := chanCloser.Channel().FundingTxOut().PkScript
+	heightHint := chanCloser.NegotiationHeight()
+
+	p.wg.Add(1)
+	go p.waitForRbfChanClose(chanID, chanPoint, fundingPkScript, heightHint)
+}
+
+// waitForRbfChanClose waits until the funding output of the channel closed
+// with RBF based close negotiation has been spent, and notifies the
+// channelManager about the spend.
+//
+// NOTE: This MUST be run as a goroutine.
+func (p *Brontide) waitForRbfChanClose(chanID lnwire.ChannelID,
+	chanPoint *wire.OutPoint, fundingPkScript []byte, heightHint uint32) {
+
+	defer p.wg.Done()
+
+	spendNtfn, err := p.cfg.ChainNotifier.RegisterSpendNtfn(
+		chanPoint, fundingPkScript, heightHint,
+	)
+	if err != nil {
+		p.log.Errorf("Unable to register spend notification for "+
+			"ChannelPoint(%v): %v", chanPoint, err)
+		return
+	}
+	defer spendNtfn.Cancel()
+
+	var spend *chainntnfs.SpendDetail
+	select {
+	case spend = <-spendNtfn.Spend:
+		// In the case that the ChainNotifier is shutting down, all
+		// subscriber notification channels will be closed, generating
+		// a nil receive.
+		if spend == nil {
+			return
+		}
+
+	case <-p.quit:
+		return
+	}
+
+	p.log.Infof("ChannelPoint(%v) is now closed at height %v with txid %v",
+		chanPoint, spend.SpendingHeight, spend.SpenderTxHash)
+
+	select {
+	case p.rbfCloseSpends <- &rbfCloseSpend{
+		chanID:      chanID,
+		closingTxid: *spend.SpenderTxHash,
+	}:
+	case <-p.quit:
+	}
+}
+
+// handleRbfCloseSpend concludes a close with RBF based close negotiation once
+// the channel has been closed on-chain, and notifies the caller that requested
+// the close.
+func (p *Brontide) handleRbfCloseSpend(spend *rbfCloseSpend) {
+	chanCloser, ok := p.activeChanCloses[spend.chanID]
+	if !ok {
+		return
+	}
+	delete(p.activeChanCloses, spend.chanID)
+
+	if closeReq := chanCloser.CloseRequest(); closeReq != nil {
+		sendCloseUpdate(closeReq, &ChannelCloseUpdate{
+			ClosingTxid: spend.closingTxid[:],
+			Success:     true,
+		})
+	}
+}
+
+// sendCloseUpdate delivers an update about a close with RBF based close
+// negotiation to the caller that requested it, without blocking the
+// channelManager. Since the closing transaction may be replaced any number of
+// times, a caller that doesn't keep up with the updates would otherwise stall
+// the peer. In that case the oldest queued update is dropped, so the caller
+// still receives the latest state of the close.
+func sendCloseUpdate(req *htlcswitch.ChanClose, update interface{}) {
+	for {
+		select {
+		case req.Updates <- update:
+			return
+		default:
+		}
+
+		select {
+		case <-req.Updates:
+		default:
+		}
+	}
+}
+
+// sendCloseErr delivers an error to the caller that requested a close with
+// RBF based close negotiation. The error is dropped if an earlier error hasn't
+// been read by the caller yet.
+func sendCloseErr(req *htlcswitch.ChanClose, err error) {
+	select {
+	case req.Err <- err:
+	default:
+	}
+}
+
+// handleCoopCloseBump proposes a closing transaction that replaces the current
+// one of a cooperative close with RBF based close negotiation, paying the fee
+// rate of the given request.
+func (p *Brontide) handleCoopCloseBump(req *htlcswitch.ChanClose) {
+	chanID := lnwire.NewChanIDFromOutPoint(req.ChanPoint)
+
+	chanCloser, ok := p.activeChanCloses[chanID]
+	if !ok {
+		req.Err <- fmt.Errorf("ChannelPoint(%v) isn't being closed "+
+			"cooperatively", req.ChanPoint)
+		return
+	}
+
+	prevReq := chanCloser.CloseRequest()
+
+	closingComplete, err := chanCloser.BumpFee(req.TargetFeePerKw, req)
+	if err != nil {
+		p.log.Errorf("Unable to bump fee of close of "+
+			"ChannelPoint(%v): %v", req.ChanPoint, err)
+		req.Err <- err
+		return
+	}
+
+	// The caller of the previous request is notified that its closing
+	// transaction is going to be replaced. Updates about the close are now
+	// sent to the caller of the new request.
+	if prevReq != nil {
+		sendCloseUpdate(prevReq, &ChannelCloseUpdate{
+			Success: false,
+		})
+	}
+
+	p.queueMsg(closingComplete, nil)
+}
+
+// BumpCoopCloseFee requests to replace the closing transaction of a
+// cooperative close with RBF based close negotiation with one that pays the
+// given fee rate, which we pay for. Updates about the close are sent over the
+// returned update channel, and any error over the returned error channel.
+func (p *Brontide) BumpCoopCloseFee(chanPoint *wire.OutPoint,
+	feeRate chainfee.SatPerKWeight) (chan interface{}, chan error) {
+
+	updateChan := make(chan interface{}, 2)
+	errChan := make(chan error, 1)
+
+	req := &htlcswitch.ChanClose{
+		CloseType:      contractcourt.CloseRegular,
+		ChanPoint:      chanPoint,
+		Updates:        updateChan,
+		TargetFeePerKw: feeRate,
+		Err:            errChan,
+	}
+
+	select {
+	case p.coopCloseBumps <- req:
+	case <-p.quit:
+		errChan <- lnpeer.ErrPeerExiting
+	}
+
+	return updateChan, errChan
+}
+
 // WaitForChanToClose uses the passed notifier to wait until the channel has
 // been detected as closed on chain and then concludes by executing the
 // following actions: the channel point will be sent over the settleChan, and
@@ -2,6 +2,7 @@ package peer
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 	"time"
 
@@ -1446,3 +1447,32 @@ func TestStartupWriteMessageRace(t *testing.T) {
 		}
 	}
 }
+
+// TestSendCloseUpdate tests that updates about a close with RBF based close
+// negotiation don't block if the caller stops reading them, and that the
+// caller still receives the latest updates.
+func TestSendCloseUpdate(t *testing.T) {
+	t.Parallel()
+
+	req := &htlcswitch.ChanClose{
+		Updates: make(chan interface{}, 2),
+		Err:     make(chan error, 1),
+	}
+
+	// Send more updates than the update channel can hold. None of the
+	// sends may block.
+	for i := byte(0); i < 5; i++ {
+		sendCloseUpdate(req, &PendingUpdate{Txid: []byte{i}})
+	}
+	sendCloseUpdate(req, &ChannelCloseUpdate{Success: true})
+
+	// Only the two latest updates are kept.
+	require.Equal(t, &PendingUpdate{Txid: []byte{4}}, <-req.Updates)
+	require.Equal(t, &ChannelCloseUpdate{Success: true}, <-req.Updates)
+
+	// Errors that aren't read don't block either.
+	errClose := errors.New("close failed")
+	sendCloseErr(req, errClose)
+	sendCloseErr(req, errClose)
+	require.ErrorIs(t, <-req.Err, errClose)
+}
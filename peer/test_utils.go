@@ -391,21 +391,22 @@ func createTestPeer(t *testing.T, notifier chainntnfs.ChainNotifier,
 	})
 
 	cfg := &Config{
-		Addr:              cfgAddr,
-		PubKeyBytes:       pubKey,
-		ErrorBuffer:       errBuffer,
-		ChainIO:           chainIO,
-		Switch:            mockSwitch,
-		ChanActiveTimeout: chanActiveTimeout,
-		InterceptSwitch:   interceptableSwitch,
-		ChannelDB:         dbAlice.ChannelStateDB(),
-		FeeEstimator:      estimator,
-		Wallet:            wallet,
-		ChainNotifier:     notifier,
-		ChanStatusMgr:     chanStatusMgr,
-		Features:          lnwire.NewFeatureVector(nil, lnwire.Features),
-		DisconnectPeer:    func(b *btcec.PublicKey) error { return nil },
-		ChannelNotifier:   channelNotifier,
+		Addr:               cfgAddr,
+		PubKeyBytes:        pubKey,
+		ErrorBuffer:        errBuffer,
+		ChainIO:            chainIO,
+		Switch:             mockSwitch,
+		ChanActiveTimeout:  chanActiveTimeout,
+		InterceptSwitch:    interceptableSwitch,
+		ChannelDB:          dbAlice.ChannelStateDB(),
+		FeeEstimator:       estimator,
+		CommitFeeEstimator: estimator,
+		Wallet:             wallet,
+		ChainNotifier:      notifier,
+		ChanStatusMgr:      chanStatusMgr,
+		Features:           lnwire.NewFeatureVector(nil, lnwire.Features),
+		DisconnectPeer:     func(b *btcec.PublicKey) error { return nil },
+		ChannelNotifier:    channelNotifier,
 	}
 
 	alicePeer := NewBrontide(*cfg)
@@ -0,0 +1,339 @@
+package record
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/tlv"
+	"github.com/ltcsuite/ltcd/btcec/v2"
+)
+
+const (
+	// blindedScidType is the type of the short channel id of the next hop
+	// in a blinded route's encrypted data.
+	blindedScidType tlv.Type = 2
+
+	// blindedNextBlindingOverrideType is the type of the blinding point
+	// that replaces the derived next blinding point in a blinded route's
+	// encrypted data.
+	blindedNextBlindingOverrideType tlv.Type = 8
+
+	// blindedRelayInfoType is the type of the payment relay information in
+	// a blinded route's encrypted data.
+	blindedRelayInfoType tlv.Type = 10
+
+	// blindedConstraintsType is the type of the payment constraints in a
+	// blinded route's encrypted data.
+	blindedConstraintsType tlv.Type = 12
+
+	// blindedFeaturesType is the type of the allowed features in a
+	// blinded route's encrypted data.
+	blindedFeaturesType tlv.Type = 14
+)
+
+// BlindedRouteData contains the information that the creator of a blinded
+// route encrypts for a relaying node in the route.
+type BlindedRouteData struct {
+	// ShortChannelID is the channel that the HTLC should be forwarded on.
+	ShortChannelID *lnwire.ShortChannelID
+
+	// NextBlindingOverride is an optional blinding point that replaces the
+	// blinding point we would derive for the next hop. It is used to
+	// concatenate two blinded routes.
+	NextBlindingOverride *btcec.PublicKey
+
+	// RelayInfo describes the fee and expiry delta that the relaying node
+	// charges for forwarding the HTLC.
+	RelayInfo *PaymentRelayInfo
+
+	// Constraints contains the restrictions that the route creator put on
+	// the HTLCs that are forwarded through the blinded route.
+	Constraints *PaymentConstraints
+
+	// Features is the set of features that the HTLC may make use of.
+	Features *lnwire.FeatureVector
+}
+
+// PaymentRelayInfo describes the relay policy of a node in a blinded route.
+type PaymentRelayInfo struct {
+	// CltvExpiryDelta is the expiry delta the node charges for forwarding
+	// the HTLC.
+	CltvExpiryDelta uint16
+
+	// FeeRate is the proportional fee, in parts per million, that the node
+	// charges for forwarding the HTLC.
+	FeeRate uint32
+
+	// BaseFee is the base fee that the node charges for forwarding the
+	// HTLC.
+	BaseFee lnwire.MilliSatoshi
+}
+
+// PaymentConstraints are the restrictions on the HTLCs that are forwarded
+// through a blinded route.
+type PaymentConstraints struct {
+	// MaxCltvExpiry is the maximum expiry height for the HTLC.
+	MaxCltvExpiry uint32
+
+	// HtlcMinimumMsat is the minimum amount of the HTLC.
+	HtlcMinimumMsat lnwire.MilliSatoshi
+}
+
+// DecodeBlindedRouteData decodes the decrypted data of a blinded route.
+func DecodeBlindedRouteData(r io.Reader) (*BlindedRouteData, error) {
+	var (
+		data BlindedRouteData
+
+		scid          uint64
+		blindingPoint *btcec.PublicKey
+		relayInfo     PaymentRelayInfo
+		constraints   PaymentConstraints
+		rawFeatures   []byte
+	)
+
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(blindedScidType, &scid),
+		tlv.MakePrimitiveRecord(
+			blindedNextBlindingOverrideType, &blindingPoint,
+		),
+		relayInfo.record(),
+		constraints.record(),
+		tlv.MakePrimitiveRecord(blindedFeaturesType, &rawFeatures),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	typeMap, err := tlvStream.DecodeWithParsedTypes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := typeMap[blindedScidType]; ok {
+		shortChanID := lnwire.NewShortChanIDFromInt(scid)
+		data.ShortChannelID = &shortChanID
+	}
+
+	if _, ok := typeMap[blindedNextBlindingOverrideType]; ok {
+		data.NextBlindingOverride = blindingPoint
+	}
+
+	if _, ok := typeMap[blindedRelayInfoType]; ok {
+		data.RelayInfo = &relayInfo
+	}
+
+	if _, ok := typeMap[blindedConstraintsType]; ok {
+		data.Constraints = &constraints
+	}
+
+	if _, ok := typeMap[blindedFeaturesType]; ok {
+		features := lnwire.NewRawFeatureVector()
+		err := features.DecodeBase256(
+			bytes.NewReader(rawFeatures), len(rawFeatures),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		data.Features = lnwire.NewFeatureVector(
+			features, lnwire.Features,
+		)
+	}
+
+	return &data, nil
+}
+
+// EncodeBlindedRouteData encodes the data of a blinded route for a relaying
+// node, before it is encrypted.
+func EncodeBlindedRouteData(data *BlindedRouteData) ([]byte, error) {
+	var records []tlv.Record
+
+	if data.ShortChannelID != nil {
+		scid := data.ShortChannelID.ToUint64()
+		records = append(records, tlv.MakePrimitiveRecord(
+			blindedScidType, &scid,
+		))
+	}
+
+	if data.NextBlindingOverride != nil {
+		records = append(records, tlv.MakePrimitiveRecord(
+			blindedNextBlindingOverrideType,
+			&data.NextBlindingOverride,
+		))
+	}
+
+	if data.RelayInfo != nil {
+		records = append(records, data.RelayInfo.record())
+	}
+
+	if data.Constraints != nil {
+		records = append(records, data.Constraints.record())
+	}
+
+	if data.Features != nil {
+		var b bytes.Buffer
+		err := data.Features.RawFeatureVector.EncodeBase256(&b)
+		if err != nil {
+			return nil, err
+		}
+
+		rawFeatures := b.Bytes()
+		records = append(records, tlv.MakePrimitiveRecord(
+			blindedFeaturesType, &rawFeatures,
+		))
+	}
+
+	tlvStream, err := tlv.NewStream(records...)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := tlvStream.Encode(&b); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+const (
+	// minRelayInfoLength is the minimum length of a serialized payment
+	// relay record, which occurs when the truncated base fee takes 0 bytes.
+	minRelayInfoLength = 6
+
+	// maxRelayInfoLength is the maximum length of a serialized payment
+	// relay record.
+	maxRelayInfoLength = 10
+)
+
+// record returns a tlv.Record that can be used to encode or decode the payment
+// relay information.
+func (p *PaymentRelayInfo) record() tlv.Record {
+	size := func() uint64 {
+		return minRelayInfoLength + tlv.SizeTUint32(uint32(p.BaseFee))
+	}
+
+	return tlv.MakeDynamicRecord(
+		blindedRelayInfoType, p, size, encodePaymentRelay,
+		decodePaymentRelay,
+	)
+}
+
+// encodePaymentRelay writes the payment relay record to the provided
+// io.Writer.
+func encodePaymentRelay(w io.Writer, val interface{}, buf *[8]byte) error {
+	if v, ok := val.(*PaymentRelayInfo); ok {
+		err := tlv.EUint16T(w, v.CltvExpiryDelta, buf)
+		if err != nil {
+			return err
+		}
+
+		if err := tlv.EUint32T(w, v.FeeRate, buf); err != nil {
+			return err
+		}
+
+		return tlv.ETUint32T(w, uint32(v.BaseFee), buf)
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "PaymentRelayInfo")
+}
+
+// decodePaymentRelay reads the payment relay record from the provided
+// io.Reader.
+func decodePaymentRelay(r io.Reader, val interface{}, buf *[8]byte,
+	l uint64) error {
+
+	v, ok := val.(*PaymentRelayInfo)
+	if !ok || l < minRelayInfoLength || l > maxRelayInfoLength {
+		return tlv.NewTypeForDecodingErr(
+			val, "PaymentRelayInfo", l, maxRelayInfoLength,
+		)
+	}
+
+	var fixed [minRelayInfoLength]byte
+	if _, err := io.ReadFull(r, fixed[:]); err != nil {
+		return err
+	}
+	v.CltvExpiryDelta = binary.BigEndian.Uint16(fixed[:2])
+	v.FeeRate = binary.BigEndian.Uint32(fixed[2:])
+
+	var baseFee uint32
+	err := tlv.DTUint32(r, &baseFee, buf, l-minRelayInfoLength)
+	if err != nil {
+		return err
+	}
+	v.BaseFee = lnwire.MilliSatoshi(baseFee)
+
+	return nil
+}
+
+const (
+	// minConstraintsLength is the minimum length of a serialized payment
+	// constraints record, which occurs when the truncated htlc minimum
+	// takes 0 bytes.
+	minConstraintsLength = 4
+
+	// maxConstraintsLength is the maximum length of a serialized payment
+	// constraints record.
+	maxConstraintsLength = 12
+)
+
+// record returns a tlv.Record that can be used to encode or decode the payment
+// constraints.
+func (p *PaymentConstraints) record() tlv.Record {
+	size := func() uint64 {
+		return minConstraintsLength +
+			tlv.SizeTUint64(uint64(p.HtlcMinimumMsat))
+	}
+
+	return tlv.MakeDynamicRecord(
+		blindedConstraintsType, p, size, encodePaymentConstraints,
+		decodePaymentConstraints,
+	)
+}
+
+// encodePaymentConstraints writes the payment constraints record to the
+// provided io.Writer.
+func encodePaymentConstraints(w io.Writer, val interface{},
+	buf *[8]byte) error {
+
+	if v, ok := val.(*PaymentConstraints); ok {
+		if err := tlv.EUint32T(w, v.MaxCltvExpiry, buf); err != nil {
+			return err
+		}
+
+		return tlv.ETUint64T(w, uint64(v.HtlcMinimumMsat), buf)
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "PaymentConstraints")
+}
+
+// decodePaymentConstraints reads the payment constraints record from the
+// provided io.Reader.
+func decodePaymentConstraints(r io.Reader, val interface{}, buf *[8]byte,
+	l uint64) error {
+
+	v, ok := val.(*PaymentConstraints)
+	if !ok || l < minConstraintsLength || l > maxConstraintsLength {
+		return tlv.NewTypeForDecodingErr(
+			val, "PaymentConstraints", l, maxConstraintsLength,
+		)
+	}
+
+	var maxCltv [minConstraintsLength]byte
+	if _, err := io.ReadFull(r, maxCltv[:]); err != nil {
+		return err
+	}
+	v.MaxCltvExpiry = binary.BigEndian.Uint32(maxCltv[:])
+
+	var htlcMin uint64
+	err := tlv.DTUint64(r, &htlcMin, buf, l-minConstraintsLength)
+	if err != nil {
+		return err
+	}
+	v.HtlcMinimumMsat = lnwire.MilliSatoshi(htlcMin)
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package record
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/ltcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const testPubKey = "02a1633cafcc01ebfb6d78e39f687a1f0995c62fc95f51ead10a02ee0be551b5dc"
+
+// TestBlindedDataEncoding tests encoding and decoding of the encrypted data
+// of a blinded route.
+func TestBlindedDataEncoding(t *testing.T) {
+	t.Parallel()
+
+	pubKeyBytes, err := hex.DecodeString(testPubKey)
+	require.NoError(t, err)
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes)
+	require.NoError(t, err)
+
+	scid := lnwire.NewShortChanIDFromInt(1234)
+
+	testCases := []struct {
+		name string
+		data *BlindedRouteData
+	}{
+		{
+			name: "empty",
+			data: &BlindedRouteData{},
+		},
+		{
+			name: "relaying node",
+			data: &BlindedRouteData{
+				ShortChannelID: &scid,
+				RelayInfo: &PaymentRelayInfo{
+					CltvExpiryDelta: 144,
+					FeeRate:         1000,
+					BaseFee:         0,
+				},
+				Constraints: &PaymentConstraints{
+					MaxCltvExpiry:   1000,
+					HtlcMinimumMsat: 1,
+				},
+			},
+		},
+		{
+			name: "all fields",
+			data: &BlindedRouteData{
+				ShortChannelID:       &scid,
+				NextBlindingOverride: pubKey,
+				RelayInfo: &PaymentRelayInfo{
+					CltvExpiryDelta: 40,
+					FeeRate:         1,
+					BaseFee:         4294967295,
+				},
+				Constraints: &PaymentConstraints{
+					MaxCltvExpiry:   1000000,
+					HtlcMinimumMsat: 18446744073709551615,
+				},
+				Features: lnwire.NewFeatureVector(
+					lnwire.NewRawFeatureVector(
+						lnwire.AMPOptional,
+					), lnwire.Features,
+				),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := EncodeBlindedRouteData(testCase.data)
+			require.NoError(t, err)
+
+			decoded, err := DecodeBlindedRouteData(
+				bytes.NewReader(encoded),
+			)
+			require.NoError(t, err)
+			require.Equal(t, testCase.data, decoded)
+		})
+	}
+}
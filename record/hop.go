@@ -2,6 +2,7 @@ package record
 
 import (
 	"github.com/ltcsuite/lnd/tlv"
+	"github.com/ltcsuite/ltcd/btcec/v2"
 )
 
 const (
@@ -17,9 +18,21 @@ const (
 	// of the next hop.
 	NextHopOnionType tlv.Type = 6
 
+	// EncryptedDataOnionType is the type used in the onion to reference
+	// the encrypted data of a blinded route that is provided to a hop.
+	EncryptedDataOnionType tlv.Type = 10
+
+	// BlindingPointOnionType is the type used in the onion to reference
+	// the blinding point of a blinded route for the introduction node.
+	BlindingPointOnionType tlv.Type = 12
+
 	// MetadataOnionType is the type used in the onion for the payment
 	// metadata.
 	MetadataOnionType tlv.Type = 16
+
+	// TotalAmtMsatBlindedType is the type used in the onion for the total
+	// amount of a payment to a blinded route.
+	TotalAmtMsatBlindedType tlv.Type = 18
 )
 
 // NewAmtToFwdRecord creates a tlv.Record that encodes the amount_to_forward
@@ -61,3 +74,31 @@ func NewMetadataRecord(metadata *[]byte) tlv.Record {
 		tlv.EVarBytes, tlv.DVarBytes,
 	)
 }
+
+// NewEncryptedDataRecord creates a tlv.Record that encodes the
+// encrypted_recipient_data (type 10) for an onion payload.
+func NewEncryptedDataRecord(data *[]byte) tlv.Record {
+	return tlv.MakeDynamicRecord(
+		EncryptedDataOnionType, data, func() uint64 {
+			return uint64(len(*data))
+		},
+		tlv.EVarBytes, tlv.DVarBytes,
+	)
+}
+
+// NewBlindingPointRecord creates a tlv.Record that encodes the blinding_point
+// (type 12) for an onion payload.
+func NewBlindingPointRecord(point **btcec.PublicKey) tlv.Record {
+	return tlv.MakePrimitiveRecord(BlindingPointOnionType, point)
+}
+
+// NewTotalAmtMsatBlinded creates a tlv.Record that encodes the total_amount_msat
+// (type 18) for an onion payload.
+func NewTotalAmtMsatBlinded(amt *uint64) tlv.Record {
+	return tlv.MakeDynamicRecord(
+		TotalAmtMsatBlindedType, amt, func() uint64 {
+			return tlv.SizeTUint64(*amt)
+		},
+		tlv.ETUint64, tlv.DTUint64,
+	)
+}
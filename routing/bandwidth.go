@@ -98,7 +98,9 @@ func (b *bandwidthManager) getBandwidth(cid lnwire.ShortChannelID,
 	}
 
 	// Otherwise, we'll return the current best estimate for the available
-	// bandwidth for the link.
+	// bandwidth for the link. This is the live balance of the channel,
+	// which already excludes HTLCs that are in flight or still queued for
+	// the link, such as the previous shards of a payment.
 	return link.Bandwidth()
 }
 
@@ -0,0 +1,208 @@
+package bench
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/htlcswitch"
+	"github.com/ltcsuite/lnd/lnrpc"
+	"github.com/ltcsuite/lnd/lnwallet"
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/routing"
+	"github.com/ltcsuite/lnd/routing/route"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+// benchFinalCltvDelta is the final cltv delta of the benchmarked routes.
+const benchFinalCltvDelta = 40
+
+// benchChain is a chain backend that only reports a fixed best block, which is
+// all that is needed to query routes.
+type benchChain struct {
+	lnwallet.BlockChainIO
+}
+
+// GetBestBlock returns a fixed block height.
+func (c *benchChain) GetBestBlock() (*chainhash.Hash, int32, error) {
+	return &chainhash.Hash{}, 800_000, nil
+}
+
+// benchLink is a link of our own node that can forward any htlc up to its
+// bandwidth.
+type benchLink struct {
+	htlcswitch.ChannelLink
+	bandwidth lnwire.MilliSatoshi
+}
+
+// Bandwidth returns the bandwidth of the link.
+func (l *benchLink) Bandwidth() lnwire.MilliSatoshi {
+	return l.bandwidth
+}
+
+// EligibleToForward returns true, as links are always online.
+func (l *benchLink) EligibleToForward() bool {
+	return true
+}
+
+// MayAddOutgoingHtlc always allows adding an htlc.
+func (l *benchLink) MayAddOutgoingHtlc(_ lnwire.MilliSatoshi) error {
+	return nil
+}
+
+// localLinks returns links for all channels of the source node, with half of
+// the capacity of each channel available as bandwidth.
+func localLinks(b *testing.B, graph *Graph,
+	source route.Vertex) map[lnwire.ShortChannelID]*benchLink {
+
+	links := make(map[lnwire.ShortChannelID]*benchLink)
+	err := graph.ForEachNodeChannel(nil, source,
+		func(channel *channeldb.DirectedChannel) error {
+			shortID := lnwire.NewShortChanIDFromInt(
+				channel.ChannelID,
+			)
+			links[shortID] = &benchLink{
+				bandwidth: lnwire.NewMSatFromSatoshis(
+					channel.Capacity / 2,
+				),
+			}
+
+			return nil
+		},
+	)
+	require.NoError(b, err)
+
+	return links
+}
+
+// BenchmarkQueryRoutes benchmarks querying a route through the channel
+// router, which is what the QueryRoutes rpc does.
+func BenchmarkQueryRoutes(b *testing.B) {
+	graph, source, targets, err := Setup()
+	if err == ErrNoSnapshot {
+		b.Skip(err)
+	}
+	require.NoError(b, err)
+
+	links := localLinks(b, graph, source)
+
+	estimator, err := routing.NewAprioriEstimator(
+		routing.DefaultAprioriConfig(),
+	)
+	require.NoError(b, err)
+
+	router, err := routing.New(routing.Config{
+		Graph: graph.ChannelGraph,
+		Chain: &benchChain{},
+		GetLink: func(shortID lnwire.ShortChannelID) (
+			htlcswitch.ChannelLink, error) {
+
+			link, ok := links[shortID]
+			if !ok {
+				return nil, htlcswitch.ErrChannelLinkNotFound
+			}
+
+			return link, nil
+		},
+		PathFindingConfig: routing.PathFindingConfig{
+			AttemptCost:    lnwire.NewMSatFromSatoshis(100),
+			AttemptCostPPM: 1000,
+			MinProbability: 0.01,
+			ProfileLabels:  true,
+		},
+	})
+	require.NoError(b, err)
+
+	restrictions := &routing.RestrictParams{
+		ProbabilitySource: func(_, toNode route.Vertex,
+			amt lnwire.MilliSatoshi,
+			capacity ltcutil.Amount) float64 {
+
+			return estimator.PairProbability(
+				time.Now(), nil, toNode, amt, capacity,
+			)
+		},
+		FeeLimit:  lnwire.MaxMilliSatoshi,
+		CltvLimit: math.MaxUint32,
+	}
+
+	for _, amt := range Amounts {
+		amt := amt
+
+		b.Run(fmt.Sprintf("amt=%v", amt), func(b *testing.B) {
+			var found int
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				target := targets[i%len(targets)]
+
+				_, _, err := router.FindRoute(
+					source, target, amt, 0, restrictions,
+					nil, nil, benchFinalCltvDelta,
+				)
+				if err == nil {
+					found++
+				}
+			}
+
+			b.ReportMetric(float64(found)/float64(b.N), "found/op")
+		})
+	}
+}
+
+// TestUnmarshallPolicy tests the conversion of snapshot policies to graph
+// policies.
+func TestUnmarshallPolicy(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, unmarshallPolicy(1, nil, 0))
+
+	rpcPolicy := &lnrpc.RoutingPolicy{
+		TimeLockDelta:    40,
+		MinHtlc:          1000,
+		FeeBaseMsat:      1,
+		FeeRateMilliMsat: 100,
+		Disabled:         true,
+		MaxHtlcMsat:      5_000_000,
+		LastUpdate:       1000,
+	}
+
+	policy := unmarshallPolicy(1, rpcPolicy, lnwire.ChanUpdateDirection)
+	require.Equal(t, &channeldb.ChannelEdgePolicy{
+		ChannelID:    1,
+		LastUpdate:   time.Unix(1000, 0),
+		MessageFlags: lnwire.ChanUpdateRequiredMaxHtlc,
+		ChannelFlags: lnwire.ChanUpdateDirection |
+			lnwire.ChanUpdateDisabled,
+		TimeLockDelta:             40,
+		MinHTLC:                   1000,
+		MaxHTLC:                   5_000_000,
+		FeeBaseMSat:               1,
+		FeeProportionalMillionths: 100,
+	}, policy)
+}
+
+// TestParseOutPoint tests parsing of channel points in snapshots.
+func TestParseOutPoint(t *testing.T) {
+	t.Parallel()
+
+	hash := chainhash.Hash{1}
+	txid := hash.String()
+
+	op, err := parseOutPoint(txid + ":3")
+	require.NoError(t, err)
+	require.Equal(t, &wire.OutPoint{Hash: hash, Index: 3}, op)
+
+	_, err = parseOutPoint(txid)
+	require.Error(t, err)
+
+	_, err = parseOutPoint(txid + ":x")
+	require.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package bench
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/routing/route"
+)
+
+const (
+	// SnapshotEnv is the environment variable that holds the path to the
+	// describegraph snapshot to benchmark on.
+	SnapshotEnv = "LND_BENCH_GRAPH"
+
+	// DBDirEnv is the environment variable that holds the directory of
+	// the graph database that the snapshot is imported into. If not set,
+	// the database is kept next to the snapshot.
+	DBDirEnv = "LND_BENCH_GRAPH_DB"
+
+	// SourceEnv is the environment variable that holds the hex encoded
+	// public key of the node that path finding starts from. If not set,
+	// the node with the most channels is used.
+	SourceEnv = "LND_BENCH_SOURCE"
+
+	// numTargets is the number of payment destinations that the
+	// benchmarks cycle through.
+	numTargets = 100
+)
+
+// Amounts are the payment amounts that path finding is benchmarked with.
+var Amounts = []lnwire.MilliSatoshi{
+	10_000_000,
+	100_000_000,
+	1_000_000_000,
+}
+
+var (
+	// setupOnce ensures that the snapshot is only loaded once per process,
+	// as importing it can take a while.
+	setupOnce sync.Once
+
+	// setupGraph is the graph that was loaded from the snapshot.
+	setupGraph *Graph
+
+	// setupErr is the error that occurred while loading the snapshot.
+	setupErr error
+)
+
+// ErrNoSnapshot is returned by Setup if no graph snapshot is configured.
+// Benchmarks are expected to be skipped in this case.
+var ErrNoSnapshot = fmt.Errorf("no graph snapshot configured, set %v to "+
+	"the output of lncli describegraph", SnapshotEnv)
+
+// Setup returns the graph snapshot that is configured through the
+// environment, together with the source node and the destinations to
+// benchmark path finding with. ErrNoSnapshot is returned if no snapshot is
+// configured.
+func Setup() (*Graph, route.Vertex, []route.Vertex, error) {
+	snapshotPath := os.Getenv(SnapshotEnv)
+	if snapshotPath == "" {
+		return nil, route.Vertex{}, nil, ErrNoSnapshot
+	}
+
+	setupOnce.Do(func() {
+		setupGraph, setupErr = setup(snapshotPath)
+	})
+	if setupErr != nil {
+		return nil, route.Vertex{}, nil, fmt.Errorf("unable to load "+
+			"graph snapshot: %w", setupErr)
+	}
+
+	source, err := setupGraph.SourceNode()
+	if err != nil {
+		return nil, route.Vertex{}, nil, fmt.Errorf("unable to fetch "+
+			"source node: %w", err)
+	}
+
+	targets := setupGraph.randomTargets(source.PubKeyBytes, numTargets)
+
+	return setupGraph, source.PubKeyBytes, targets, nil
+}
+
+// setup loads the snapshot at the given path and sets the source node.
+func setup(snapshotPath string) (*Graph, error) {
+	dbDir := os.Getenv(DBDirEnv)
+	if dbDir == "" {
+		dbDir = snapshotPath + ".db"
+	}
+
+	graph, err := LoadGraph(snapshotPath, dbDir)
+	if err != nil {
+		return nil, err
+	}
+
+	source := graph.Nodes[0]
+	if sourceHex := os.Getenv(SourceEnv); sourceHex != "" {
+		sourceBytes, err := hex.DecodeString(sourceHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source: %w", err)
+		}
+
+		source, err = route.NewVertexFromBytes(sourceBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source: %w", err)
+		}
+	}
+
+	if err := graph.SetSource(source); err != nil {
+		return nil, err
+	}
+
+	return graph, nil
+}
+
+// randomTargets returns n destinations that are picked from the connected
+// nodes of the graph, excluding the source. The same destinations are
+// returned on every call, so that benchmark runs can be compared.
+func (g *Graph) randomTargets(source route.Vertex, n int) []route.Vertex {
+	r := rand.New(rand.NewSource(1))
+
+	targets := make([]route.Vertex, 0, n)
+	for len(targets) < n && len(g.Nodes) > 1 {
+		target := g.Nodes[r.Intn(len(g.Nodes))]
+		if target == source {
+			continue
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets
+}
@@ -0,0 +1,401 @@
+// Package bench contains a harness to benchmark path finding on a real,
+// mainnet-size channel graph.
+//
+// The graph is loaded from a snapshot in the JSON format that is produced by
+// `lncli describegraph`. The benchmarks are skipped unless the path to a
+// snapshot is set in the LND_BENCH_GRAPH environment variable:
+//
+//	lncli describegraph > graph.json
+//	LND_BENCH_GRAPH=graph.json go test -run=^$ -bench=. -benchmem \
+//		-cpuprofile=cpu.out ./routing/bench
+//
+// This package benchmarks route queries through the channel router. Path
+// finding itself is benchmarked on the same snapshot by
+// BenchmarkFindPathSnapshot in the routing package.
+//
+// Importing a snapshot into a graph database takes a while, so the database
+// is kept in a directory next to the snapshot and reused by subsequent runs.
+// It needs to be removed when the snapshot changes. The location can be
+// changed through LND_BENCH_GRAPH_DB. Path finding starts from the node with
+// the most channels, unless a different node is set in LND_BENCH_SOURCE.
+//
+// The benchmarks run path finding with pprof labels attached, so that the
+// resulting profiles can be filtered with pprof's -tagfocus option.
+package bench
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/kvdb"
+	"github.com/ltcsuite/lnd/lnrpc"
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/routing/route"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/wire"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// graphDBName is the file name of the graph database that a snapshot
+	// is imported into.
+	graphDBName = "graph.db"
+
+	// importWorkers is the number of concurrent database writes while
+	// importing a snapshot. Concurrent writes are combined into a single
+	// transaction by the graph's batch scheduler.
+	importWorkers = 256
+
+	// importBatchInterval is the commit interval of the graph's batch
+	// scheduler while importing a snapshot.
+	importBatchInterval = 10 * time.Millisecond
+)
+
+// Graph is a channel graph that was loaded from a snapshot.
+type Graph struct {
+	*channeldb.ChannelGraph
+
+	// Nodes holds all nodes that have at least one channel, ordered by
+	// their number of channels in descending order.
+	Nodes []route.Vertex
+
+	backend kvdb.Backend
+}
+
+// LoadGraph loads the graph snapshot at the given path into a graph database
+// in dbDir. If dbDir already contains a graph database, the snapshot isn't
+// imported again and the existing database is used instead. The graph is
+// loaded with the in-memory graph cache enabled, as path finding in lnd
+// does.
+func LoadGraph(snapshotPath, dbDir string) (*Graph, error) {
+	_, err := os.Stat(filepath.Join(dbDir, graphDBName))
+	exists := err == nil
+
+	backend, err := kvdb.GetBoltBackend(&kvdb.BoltBackendConfig{
+		DBPath:         dbDir,
+		DBFileName:     graphDBName,
+		NoFreelistSync: true,
+		DBTimeout:      kvdb.DefaultDBTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opts := channeldb.DefaultOptions()
+	graph, err := channeldb.NewChannelGraph(
+		backend, opts.RejectCacheSize, opts.ChannelCacheSize,
+		importBatchInterval, opts.PreAllocCacheNumNodes, true, false,
+	)
+	if err != nil {
+		backend.Close()
+		return nil, err
+	}
+
+	if !exists {
+		if err := importSnapshot(graph, snapshotPath); err != nil {
+			// Don't leave a partially imported database behind,
+			// as it would be reused by the next run.
+			backend.Close()
+			os.Remove(filepath.Join(dbDir, graphDBName))
+
+			return nil, fmt.Errorf("unable to import snapshot: %w",
+				err)
+		}
+	}
+
+	nodes, err := connectedNodes(graph)
+	if err != nil {
+		backend.Close()
+		return nil, err
+	}
+
+	if len(nodes) == 0 {
+		backend.Close()
+		return nil, fmt.Errorf("graph snapshot %v contains no "+
+			"channels", snapshotPath)
+	}
+
+	return &Graph{
+		ChannelGraph: graph,
+		Nodes:        nodes,
+		backend:      backend,
+	}, nil
+}
+
+// SetSource marks the given node as our own node, from which path finding
+// starts.
+func (g *Graph) SetSource(source route.Vertex) error {
+	node, err := g.FetchLightningNode(source)
+	if err != nil {
+		return fmt.Errorf("unable to fetch source node %v: %w", source,
+			err)
+	}
+
+	return g.SetSourceNode(node)
+}
+
+// Close closes the underlying graph database.
+func (g *Graph) Close() error {
+	return g.backend.Close()
+}
+
+// connectedNodes returns all nodes of the graph that have at least one
+// channel, ordered by their number of channels in descending order.
+func connectedNodes(graph *channeldb.ChannelGraph) ([]route.Vertex, error) {
+	numChans := make(map[route.Vertex]int)
+	err := graph.ForEachNodeCached(func(node route.Vertex,
+		chans map[uint64]*channeldb.DirectedChannel) error {
+
+		if len(chans) > 0 {
+			numChans[node] = len(chans)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]route.Vertex, 0, len(numChans))
+	for node := range numChans {
+		nodes = append(nodes, node)
+	}
+
+	// Order by public key for nodes with the same number of channels, so
+	// that the order is deterministic.
+	sort.Slice(nodes, func(i, j int) bool {
+		if numChans[nodes[i]] != numChans[nodes[j]] {
+			return numChans[nodes[i]] > numChans[nodes[j]]
+		}
+
+		return bytes.Compare(nodes[i][:], nodes[j][:]) < 0
+	})
+
+	return nodes, nil
+}
+
+// importSnapshot reads the describegraph JSON snapshot at the given path and
+// writes its nodes, channels and policies to the graph database.
+func importSnapshot(graph *channeldb.ChannelGraph, path string) error {
+	snapshotJSON, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var snapshot lnrpc.ChannelGraph
+	err = lnrpc.ProtoJSONUnmarshalOpts.Unmarshal(snapshotJSON, &snapshot)
+	if err != nil {
+		return fmt.Errorf("unable to parse snapshot: %w", err)
+	}
+
+	// Nodes need to be imported before the channels, and channels before
+	// their policies.
+	err = forEachConcurrent(len(snapshot.Nodes), func(i int) error {
+		node, err := unmarshallNode(snapshot.Nodes[i])
+		if err != nil {
+			return err
+		}
+
+		return graph.AddLightningNode(node)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = forEachConcurrent(len(snapshot.Edges), func(i int) error {
+		edge, err := unmarshallEdge(snapshot.Edges[i])
+		if err != nil {
+			return err
+		}
+
+		err = graph.AddChannelEdge(edge)
+		if err != nil && err != channeldb.ErrEdgeAlreadyExist {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = forEachConcurrent(len(snapshot.Edges), func(i int) error {
+		rpcEdge := snapshot.Edges[i]
+
+		policies := []*channeldb.ChannelEdgePolicy{
+			unmarshallPolicy(
+				rpcEdge.ChannelId, rpcEdge.Node1Policy, 0,
+			),
+			unmarshallPolicy(
+				rpcEdge.ChannelId, rpcEdge.Node2Policy,
+				lnwire.ChanUpdateDirection,
+			),
+		}
+		for _, policy := range policies {
+			if policy == nil {
+				continue
+			}
+
+			if err := graph.UpdateEdgePolicy(policy); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// forEachConcurrent calls f for all indices up to n, using a limited number of
+// concurrent workers. The first error that is encountered is returned.
+func forEachConcurrent(n int, f func(i int) error) error {
+	var eg errgroup.Group
+	eg.SetLimit(importWorkers)
+
+	for i := 0; i < n; i++ {
+		i := i
+		eg.Go(func() error {
+			return f(i)
+		})
+	}
+
+	return eg.Wait()
+}
+
+// unmarshallNode converts a node of a describegraph snapshot to a graph node.
+func unmarshallNode(rpcNode *lnrpc.LightningNode) (*channeldb.LightningNode,
+	error) {
+
+	pubKey, err := parsePubKey(rpcNode.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	featureBits := make([]lnwire.FeatureBit, 0, len(rpcNode.Features))
+	for bit := range rpcNode.Features {
+		featureBits = append(featureBits, lnwire.FeatureBit(bit))
+	}
+
+	return &channeldb.LightningNode{
+		PubKeyBytes:          pubKey,
+		HaveNodeAnnouncement: true,
+		LastUpdate:           time.Unix(int64(rpcNode.LastUpdate), 0),
+		Alias:                rpcNode.Alias,
+		Features: lnwire.NewFeatureVector(
+			lnwire.NewRawFeatureVector(featureBits...),
+			lnwire.Features,
+		),
+	}, nil
+}
+
+// unmarshallEdge converts a channel of a describegraph snapshot to a graph
+// channel. The channel doesn't carry an authentication proof, as it isn't
+// needed for path finding.
+func unmarshallEdge(rpcEdge *lnrpc.ChannelEdge) (*channeldb.ChannelEdgeInfo,
+	error) {
+
+	node1, err := parsePubKey(rpcEdge.Node1Pub)
+	if err != nil {
+		return nil, err
+	}
+
+	node2, err := parsePubKey(rpcEdge.Node2Pub)
+	if err != nil {
+		return nil, err
+	}
+
+	chanPoint, err := parseOutPoint(rpcEdge.ChanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &channeldb.ChannelEdgeInfo{
+		ChannelID:        rpcEdge.ChannelId,
+		NodeKey1Bytes:    node1,
+		NodeKey2Bytes:    node2,
+		BitcoinKey1Bytes: node1,
+		BitcoinKey2Bytes: node2,
+		ChannelPoint:     *chanPoint,
+		Capacity:         ltcutil.Amount(rpcEdge.Capacity),
+	}, nil
+}
+
+// unmarshallPolicy converts a policy of a describegraph snapshot to a graph
+// policy for the given direction. Nil is returned if the policy is unknown.
+func unmarshallPolicy(chanID uint64, rpcPolicy *lnrpc.RoutingPolicy,
+	direction lnwire.ChanUpdateChanFlags) *channeldb.ChannelEdgePolicy {
+
+	if rpcPolicy == nil {
+		return nil
+	}
+
+	policy := &channeldb.ChannelEdgePolicy{
+		ChannelID:     chanID,
+		LastUpdate:    time.Unix(int64(rpcPolicy.LastUpdate), 0),
+		ChannelFlags:  direction,
+		TimeLockDelta: uint16(rpcPolicy.TimeLockDelta),
+		MinHTLC:       lnwire.MilliSatoshi(rpcPolicy.MinHtlc),
+		FeeBaseMSat:   lnwire.MilliSatoshi(rpcPolicy.FeeBaseMsat),
+		FeeProportionalMillionths: lnwire.MilliSatoshi(
+			rpcPolicy.FeeRateMilliMsat,
+		),
+	}
+
+	if rpcPolicy.MaxHtlcMsat > 0 {
+		policy.MessageFlags |= lnwire.ChanUpdateRequiredMaxHtlc
+		policy.MaxHTLC = lnwire.MilliSatoshi(rpcPolicy.MaxHtlcMsat)
+	}
+
+	if rpcPolicy.Disabled {
+		policy.ChannelFlags |= lnwire.ChanUpdateDisabled
+	}
+
+	return policy
+}
+
+// parsePubKey parses a hex encoded public key.
+func parsePubKey(pubKeyStr string) ([33]byte, error) {
+	var pubKey [33]byte
+	pubKeyBytes, err := hex.DecodeString(pubKeyStr)
+	if err != nil || len(pubKeyBytes) != 33 {
+		return pubKey, fmt.Errorf("invalid pubkey: %v", pubKeyStr)
+	}
+
+	copy(pubKey[:], pubKeyBytes)
+
+	return pubKey, nil
+}
+
+// parseOutPoint parses an outpoint in the txid:index format.
+func parseOutPoint(s string) (*wire.OutPoint, error) {
+	split := strings.Split(s, ":")
+	if len(split) != 2 {
+		return nil, fmt.Errorf("expecting outpoint to be in format of: " +
+			"txid:index")
+	}
+
+	index, err := strconv.ParseUint(split[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode output index: %w", err)
+	}
+
+	txid, err := chainhash.NewHashFromStr(split[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse hex string: %w", err)
+	}
+
+	return &wire.OutPoint{
+		Hash:  *txid,
+		Index: uint32(index),
+	}, nil
+}
@@ -24,6 +24,10 @@ type ControlTower interface {
 	// completed, and the payment has reached a final settled state.
 	DeleteFailedAttempts(lntypes.Hash) error
 
+	// DeletePayment removes the payment and all of its HTLCs from the db.
+	// Payments that are in flight can't be deleted.
+	DeletePayment(lntypes.Hash) error
+
 	// RegisterAttempt atomically records the provided HTLCAttemptInfo.
 	RegisterAttempt(lntypes.Hash, *channeldb.HTLCAttemptInfo) error
 
@@ -46,6 +50,10 @@ type ControlTower interface {
 	// hash.
 	FetchPayment(paymentHash lntypes.Hash) (*channeldb.MPPayment, error)
 
+	// LookupIdempotencyKey returns the hash of the payment that was
+	// initiated with the given idempotency key.
+	LookupIdempotencyKey(key []byte) (lntypes.Hash, error)
+
 	// FailPayment transitions a payment into the Failed state, and records
 	// the ultimate reason the payment failed. Note that this should only
 	// be called when all active attempts are already failed. After
@@ -163,6 +171,11 @@ func (p *controlTower) DeleteFailedAttempts(paymentHash lntypes.Hash) error {
 	return p.db.DeleteFailedAttempts(paymentHash)
 }
 
+// DeletePayment deletes the payment with the given hash from the DB.
+func (p *controlTower) DeletePayment(paymentHash lntypes.Hash) error {
+	return p.db.DeletePayment(paymentHash)
+}
+
 // RegisterAttempt atomically records the provided HTLCAttemptInfo to the
 // DB.
 func (p *controlTower) RegisterAttempt(paymentHash lntypes.Hash,
@@ -229,6 +242,14 @@ func (p *controlTower) FetchPayment(paymentHash lntypes.Hash) (
 	return p.db.FetchPayment(paymentHash)
 }
 
+// LookupIdempotencyKey returns the hash of the payment that was initiated with
+// the given idempotency key.
+func (p *controlTower) LookupIdempotencyKey(key []byte) (lntypes.Hash,
+	error) {
+
+	return p.db.LookupIdempotencyKey(key)
+}
+
 // FailPayment transitions a payment into the Failed state, and records the
 // reason the payment failed. After invoking this method, InitPayment should
 // return nil on its next call for this payment hash, allowing the switch to
@@ -102,8 +102,10 @@ func (g *CachedGraph) fetchNodeFeatures(nodePub route.Vertex) (
 func (g *CachedGraph) FetchAmountPairCapacity(nodeFrom, nodeTo route.Vertex,
 	amount lnwire.MilliSatoshi) (ltcutil.Amount, error) {
 
-	// Create unified edges for all incoming connections.
-	u := newNodeEdgeUnifier(g.sourceNode(), nodeTo, nil)
+	// Create unified edges for all incoming connections. Inbound fees are
+	// ignored, as the amount that is carried by the channel is already
+	// known.
+	u := newNodeEdgeUnifier(g.sourceNode(), nodeTo, false, nil, nil)
 
 	err := u.addGraphPolicies(g)
 	if err != nil {
@@ -116,7 +118,7 @@ func (g *CachedGraph) FetchAmountPairCapacity(nodeFrom, nodeTo route.Vertex,
 			nodeFrom, nodeTo)
 	}
 
-	edge := edgeUnifier.getEdgeNetwork(amount)
+	edge := edgeUnifier.getEdgeNetwork(amount, 0)
 	if edge == nil {
 		return 0, fmt.Errorf("no edge for node pair %v -> %v "+
 			"(amount %v)", nodeFrom, nodeTo, amount)
@@ -21,9 +21,15 @@ type nodeWithDist struct {
 
 	// amountToReceive is the amount that should be received by this node.
 	// Either as final payment to the final node or as an intermediate
-	// amount that includes also the fees for subsequent hops.
+	// amount that includes also the fees for subsequent hops. The inbound
+	// fee of this node isn't included, because it depends on the channel
+	// over which the htlc arrives.
 	amountToReceive lnwire.MilliSatoshi
 
+	// outboundFee is the fee that this node charges on its outgoing
+	// channel. It is used to cap a negative inbound fee of this node.
+	outboundFee lnwire.MilliSatoshi
+
 	// incomingCltv is the expected absolute expiry height for the incoming
 	// htlc of this node. This value should already include the final cltv
 	// delta.
@@ -202,14 +202,14 @@ func (c *integratedRoutingContext) testPayment(maxParts uint32,
 		func() (routingGraph, func(), error) {
 			return c.graph, func() {}, nil
 		},
-		mc, c.pathFindingCfg,
+		mc, c.pathFindingCfg, DefaultSplitConfig(),
 	)
 	if err != nil {
 		c.t.Fatal(err)
 	}
 
 	// Override default minimum shard amount.
-	session.minShardAmt = lnwire.NewMSatFromSatoshis(5000)
+	session.splitConfig.MinShardAmt = lnwire.NewMSatFromSatoshis(5000)
 
 	// Now the payment control loop starts. It will keep trying routes until
 	// the payment succeeds.
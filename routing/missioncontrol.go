@@ -258,8 +258,33 @@ func (m *MissionControl) init() error {
 		m.applyPaymentResult(result)
 	}
 
+	// Apply the results that were imported from an external source on top
+	// of our own history. Unless they were imported with the force flag
+	// set, only results that are fresher than the ones we learned
+	// ourselves will be used.
+	imported, err := m.store.fetchImported()
+	if err != nil {
+		return err
+	}
+
+	var regular, forced MissionControlSnapshot
+	for _, pair := range imported {
+		if pair.force {
+			forced.Pairs = append(
+				forced.Pairs, pair.MissionControlPairSnapshot,
+			)
+		} else {
+			regular.Pairs = append(
+				regular.Pairs, pair.MissionControlPairSnapshot,
+			)
+		}
+	}
+	m.state.importSnapshot(&regular, false)
+	m.state.importSnapshot(&forced, true)
+
 	log.Debugf("Mission control state reconstruction finished: "+
-		"n=%v, time=%v", len(results), time.Since(start))
+		"n=%v, imported=%v, time=%v", len(results), len(imported),
+		time.Since(start))
 
 	return nil
 }
@@ -353,8 +378,10 @@ func (m *MissionControl) GetHistorySnapshot() *MissionControlSnapshot {
 }
 
 // ImportHistory imports the set of mission control results provided to our
-// in-memory state. These results are not persisted, so will not survive
-// restarts.
+// in-memory state. The results that are used are persisted as well, so that
+// they are applied again after a restart. As the results keep their original
+// timestamps, they are subject to the same time decay as the results we
+// learned ourselves.
 func (m *MissionControl) ImportHistory(history *MissionControlSnapshot,
 	force bool) error {
 
@@ -368,11 +395,13 @@ func (m *MissionControl) ImportHistory(history *MissionControlSnapshot,
 	log.Infof("Importing history snapshot with %v pairs to mission control",
 		len(history.Pairs))
 
-	imported := m.state.importSnapshot(history, force)
+	imported, accepted := m.state.importSnapshot(history, force)
 
 	log.Infof("Imported %v results to mission control", imported)
 
-	return nil
+	// Only the results that were used are persisted, so that results that
+	// are older than our own aren't applied after a restart.
+	return m.store.storeImported(accepted, force)
 }
 
 // GetPairHistorySnapshot returns the stored history for a given node pair.
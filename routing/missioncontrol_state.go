@@ -214,11 +214,15 @@ func (m *missionControlState) getSnapshot() *MissionControlSnapshot {
 
 // importSnapshot takes an existing snapshot and merges it with our current
 // state if the result provided are fresher than our current results. It returns
-// the number of pairs that were used.
+// the number of results that were used, along with the pairs they belong to.
+// The fail or success result of a returned pair is zero if it wasn't used.
 func (m *missionControlState) importSnapshot(snapshot *MissionControlSnapshot,
-	force bool) int {
+	force bool) (int, []MissionControlPairSnapshot) {
 
-	var imported int
+	var (
+		imported int
+		accepted []MissionControlPairSnapshot
+	)
 
 	for _, pair := range snapshot.Pairs {
 		fromNode := pair.Pair.From
@@ -230,21 +234,40 @@ func (m *missionControlState) importSnapshot(snapshot *MissionControlSnapshot,
 		}
 
 		lastResult := results[toNode]
+		acceptedPair := MissionControlPairSnapshot{
+			Pair: pair.Pair,
+		}
 
 		failResult := failPairResult(pair.FailAmt)
-		imported += m.importResult(
+		if m.importResult(
 			lastResult.FailTime, pair.FailTime, failResult,
 			fromNode, toNode, force,
-		)
+		) == 1 {
+
+			imported++
+			acceptedPair.FailTime = pair.FailTime
+			acceptedPair.FailAmt = pair.FailAmt
+		}
 
 		successResult := successPairResult(pair.SuccessAmt)
-		imported += m.importResult(
+		if m.importResult(
 			lastResult.SuccessTime, pair.SuccessTime, successResult,
 			fromNode, toNode, force,
-		)
+		) == 1 {
+
+			imported++
+			acceptedPair.SuccessTime = pair.SuccessTime
+			acceptedPair.SuccessAmt = pair.SuccessAmt
+		}
+
+		if !acceptedPair.FailTime.IsZero() ||
+			!acceptedPair.SuccessTime.IsZero() {
+
+			accepted = append(accepted, acceptedPair)
+		}
 	}
 
-	return imported
+	return imported, accepted
 }
 
 func (m *missionControlState) importResult(currentTs, importedTs time.Time,
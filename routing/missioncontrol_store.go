@@ -6,12 +6,14 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/lnd/kvdb"
 	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/routing/route"
 	"github.com/ltcsuite/ltcd/wire"
 )
 
@@ -20,6 +22,10 @@ var (
 	// stored.
 	resultsKey = []byte("missioncontrol-results")
 
+	// importedKey is the fixed key under which the pair results that were
+	// imported from an external source are stored.
+	importedKey = []byte("missioncontrol-imported")
+
 	// Big endian is the preferred byte order, due to cursor scans over
 	// integer keys iterating in order.
 	byteOrder = binary.BigEndian
@@ -79,6 +85,12 @@ func newMissionControlStore(db kvdb.Backend, maxRecords int,
 				err)
 		}
 
+		_, err = tx.CreateTopLevelBucket(importedKey)
+		if err != nil {
+			return fmt.Errorf("cannot create imported bucket: %v",
+				err)
+		}
+
 		// Collect all keys to be able to quickly calculate the
 		// difference when updating the DB state.
 		c := resultsBucket.ReadCursor()
@@ -113,12 +125,17 @@ func (b *missionControlStore) clear() error {
 	defer b.queueMx.Unlock()
 
 	err := kvdb.Update(b.db, func(tx kvdb.RwTx) error {
-		if err := tx.DeleteTopLevelBucket(resultsKey); err != nil {
-			return err
+		for _, key := range [][]byte{resultsKey, importedKey} {
+			if err := tx.DeleteTopLevelBucket(key); err != nil {
+				return err
+			}
+
+			if _, err := tx.CreateTopLevelBucket(key); err != nil {
+				return err
+			}
 		}
 
-		_, err := tx.CreateTopLevelBucket(resultsKey)
-		return err
+		return nil
 	}, func() {})
 
 	if err != nil {
@@ -158,6 +175,234 @@ func (b *missionControlStore) fetchAll() ([]*paymentResult, error) {
 	return results, nil
 }
 
+// importedPair is a pair result that was imported from an external source,
+// along with whether it was imported with the force flag set.
+type importedPair struct {
+	MissionControlPairSnapshot
+
+	// force indicates whether the result was imported with the force flag
+	// set, in which case it is applied with the flag set after a restart
+	// as well.
+	force bool
+}
+
+// storeImported stores the pair results that were imported from an external
+// source, so that they can be applied again on startup. Results that are zero
+// are skipped, so the fail and success results of a pair can be imported
+// separately. A previously imported result for the same pair is merged with
+// the new one. If there are more than maxRecords imported pairs, the pairs
+// with the oldest results are removed.
+func (b *missionControlStore) storeImported(pairs []MissionControlPairSnapshot,
+	force bool) error {
+
+	return kvdb.Update(b.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(importedKey)
+
+		for _, pair := range pairs {
+			record := &importedPair{
+				MissionControlPairSnapshot: pair,
+				force:                      force,
+			}
+
+			k := importedPairKey(pair.Pair)
+			if v := bucket.Get(k); v != nil {
+				prev, err := deserializeImportedPair(k, v)
+				if err != nil {
+					return err
+				}
+
+				record = mergeImportedPairs(prev, record)
+			}
+
+			v, err := serializeImportedPair(record)
+			if err != nil {
+				return err
+			}
+
+			if err := bucket.Put(k, v); err != nil {
+				return err
+			}
+		}
+
+		return b.pruneImported(bucket)
+	}, func() {})
+}
+
+// mergeImportedPairs merges a newly imported pair result into the one that was
+// previously stored. The fail and success results of the new pair replace the
+// previous ones, unless they are zero. The force flag is always taken from the
+// new pair, so a later regular import isn't replayed as a forced one.
+func mergeImportedPairs(prev, pair *importedPair) *importedPair {
+	merged := *prev
+	merged.force = pair.force
+
+	if !pair.FailTime.IsZero() {
+		merged.FailTime = pair.FailTime
+		merged.FailAmt = pair.FailAmt
+	}
+
+	if !pair.SuccessTime.IsZero() {
+		merged.SuccessTime = pair.SuccessTime
+		merged.SuccessAmt = pair.SuccessAmt
+	}
+
+	return &merged
+}
+
+// pruneImported removes the imported pairs with the oldest results from the
+// bucket, until there are at most maxRecords pairs left.
+func (b *missionControlStore) pruneImported(bucket kvdb.RwBucket) error {
+	if b.maxRecords == 0 {
+		return nil
+	}
+
+	type pairAge struct {
+		key    []byte
+		latest time.Time
+	}
+
+	var pairs []pairAge
+	err := bucket.ForEach(func(k, v []byte) error {
+		pair, err := deserializeImportedPair(k, v)
+		if err != nil {
+			return err
+		}
+
+		latest := pair.FailTime
+		if pair.SuccessTime.After(latest) {
+			latest = pair.SuccessTime
+		}
+
+		pairs = append(pairs, pairAge{
+			key:    append([]byte(nil), k...),
+			latest: latest,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(pairs) <= b.maxRecords {
+		return nil
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].latest.Before(pairs[j].latest)
+	})
+
+	for _, pair := range pairs[:len(pairs)-b.maxRecords] {
+		if err := bucket.Delete(pair.key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchImported returns all imported pair results currently stored in the
+// database.
+func (b *missionControlStore) fetchImported() ([]*importedPair, error) {
+	var pairs []*importedPair
+
+	err := kvdb.View(b.db, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(importedKey)
+
+		return bucket.ForEach(func(k, v []byte) error {
+			pair, err := deserializeImportedPair(k, v)
+			if err != nil {
+				return err
+			}
+
+			pairs = append(pairs, pair)
+
+			return nil
+		})
+	}, func() {
+		pairs = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+// importedPairKey returns the key an imported pair result is stored under.
+func importedPairKey(pair DirectedNodePair) []byte {
+	var key [2 * route.VertexSize]byte
+	copy(key[:], pair.From[:])
+	copy(key[route.VertexSize:], pair.To[:])
+
+	return key[:]
+}
+
+// serializeImportedPair serializes an imported pair result to be stored under
+// its importedPairKey.
+func serializeImportedPair(pair *importedPair) ([]byte, error) {
+	var b bytes.Buffer
+	err := channeldb.WriteElements(
+		&b,
+		serializeTime(pair.FailTime), uint64(pair.FailAmt),
+		serializeTime(pair.SuccessTime), uint64(pair.SuccessAmt),
+		pair.force,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// deserializeImportedPair deserializes an imported pair result.
+func deserializeImportedPair(k, v []byte) (*importedPair, error) {
+	if len(k) != 2*route.VertexSize {
+		return nil, fmt.Errorf("invalid imported pair key length: %v",
+			len(k))
+	}
+
+	var pair importedPair
+	copy(pair.Pair.From[:], k[:route.VertexSize])
+	copy(pair.Pair.To[:], k[route.VertexSize:])
+
+	var failTime, failAmt, successTime, successAmt uint64
+	err := channeldb.ReadElements(
+		bytes.NewReader(v), &failTime, &failAmt, &successTime,
+		&successAmt, &pair.force,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pair.FailTime = deserializeTime(failTime)
+	pair.FailAmt = lnwire.MilliSatoshi(failAmt)
+	pair.SuccessTime = deserializeTime(successTime)
+	pair.SuccessAmt = lnwire.MilliSatoshi(successAmt)
+
+	return &pair, nil
+}
+
+// serializeTime encodes a timestamp as unix nano seconds. The zero time is
+// encoded as zero, so that it survives the round trip.
+func serializeTime(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return uint64(t.UnixNano())
+}
+
+// deserializeTime decodes a timestamp that was encoded with serializeTime.
+func deserializeTime(t uint64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+
+	// Convert time stamps to local time zone for consistent logging.
+	return time.Unix(0, int64(t)).Local()
+}
+
 // serializeResult serializes a payment result and returns a key and value byte
 // slice to insert into the bucket.
 func serializeResult(rp *paymentResult) ([]byte, []byte, error) {
@@ -230,3 +230,165 @@ func TestMissionControlChannelUpdate(t *testing.T) {
 	)
 	ctx.expectP(100, 0)
 }
+
+// TestMissionControlImportHistory tests that imported results are persisted
+// and applied again after a restart, and that they are cleared on reset.
+func TestMissionControlImportHistory(t *testing.T) {
+	ctx := createMcTestContext(t)
+
+	pair := MissionControlPairSnapshot{
+		Pair: NewDirectedNodePair(mcTestNode1, mcTestNode2),
+		TimedPairResult: TimedPairResult{
+			FailTime: mcTestTime.Add(-time.Hour).Local(),
+			FailAmt:  1000,
+		},
+	}
+
+	err := ctx.mc.ImportHistory(&MissionControlSnapshot{
+		Pairs: []MissionControlPairSnapshot{pair},
+	}, false)
+	require.NoError(t, err)
+
+	result := ctx.mc.GetPairHistorySnapshot(mcTestNode1, mcTestNode2)
+	require.Equal(t, pair.TimedPairResult, result)
+
+	// The imported result should be restored after a restart.
+	ctx.restartMc()
+
+	result = ctx.mc.GetPairHistorySnapshot(mcTestNode1, mcTestNode2)
+	require.Equal(t, pair.TimedPairResult, result)
+
+	// An older result that isn't used should not be persisted, so that it
+	// doesn't replace the imported result after a restart.
+	older := pair
+	older.FailTime = mcTestTime.Add(-2 * time.Hour).Local()
+	older.FailAmt = 500
+
+	err = ctx.mc.ImportHistory(&MissionControlSnapshot{
+		Pairs: []MissionControlPairSnapshot{older},
+	}, false)
+	require.NoError(t, err)
+	ctx.restartMc()
+
+	result = ctx.mc.GetPairHistorySnapshot(mcTestNode1, mcTestNode2)
+	require.Equal(t, pair.TimedPairResult, result)
+
+	// Importing only a success result for the pair should merge it with
+	// the previously imported failure, also after a restart.
+	success := MissionControlPairSnapshot{
+		Pair: pair.Pair,
+		TimedPairResult: TimedPairResult{
+			SuccessTime: mcTestTime.Add(-30 * time.Minute).Local(),
+			SuccessAmt:  500,
+		},
+	}
+	err = ctx.mc.ImportHistory(&MissionControlSnapshot{
+		Pairs: []MissionControlPairSnapshot{success},
+	}, false)
+	require.NoError(t, err)
+	ctx.restartMc()
+
+	expected := TimedPairResult{
+		FailTime:    pair.FailTime,
+		FailAmt:     pair.FailAmt,
+		SuccessTime: success.SuccessTime,
+		SuccessAmt:  success.SuccessAmt,
+	}
+	result = ctx.mc.GetPairHistorySnapshot(mcTestNode1, mcTestNode2)
+	require.Equal(t, expected, result)
+
+	imported, err := ctx.mc.store.fetchImported()
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	require.Equal(t, expected, imported[0].TimedPairResult)
+	require.False(t, imported[0].force)
+
+	// After resetting mission control, the imported result should be gone,
+	// also after a restart.
+	require.NoError(t, ctx.mc.ResetHistory())
+	ctx.restartMc()
+
+	result = ctx.mc.GetPairHistorySnapshot(mcTestNode1, mcTestNode2)
+	require.Equal(t, TimedPairResult{}, result)
+}
+
+// TestMissionControlImportForce tests that a result that was imported with the
+// force flag set replaces our own, newer result after a restart as well.
+func TestMissionControlImportForce(t *testing.T) {
+	ctx := createMcTestContext(t)
+
+	ctx.reportFailure(1000, lnwire.NewTemporaryChannelFailure(nil))
+
+	pair := MissionControlPairSnapshot{
+		Pair: NewDirectedNodePair(mcTestNode1, mcTestNode2),
+		TimedPairResult: TimedPairResult{
+			FailTime: mcTestTime.Add(-time.Hour).Local(),
+			FailAmt:  500,
+		},
+	}
+
+	err := ctx.mc.ImportHistory(&MissionControlSnapshot{
+		Pairs: []MissionControlPairSnapshot{pair},
+	}, true)
+	require.NoError(t, err)
+
+	result := ctx.mc.GetPairHistorySnapshot(mcTestNode1, mcTestNode2)
+	require.Equal(t, pair.TimedPairResult, result)
+
+	ctx.restartMc()
+
+	result = ctx.mc.GetPairHistorySnapshot(mcTestNode1, mcTestNode2)
+	require.Equal(t, pair.TimedPairResult, result)
+
+	// A later regular import of the pair clears the force flag, so its
+	// result is only applied if it is fresher than our own.
+	success := MissionControlPairSnapshot{
+		Pair: pair.Pair,
+		TimedPairResult: TimedPairResult{
+			SuccessTime: mcTestTime.Add(-30 * time.Minute).Local(),
+			SuccessAmt:  500,
+		},
+	}
+	err = ctx.mc.ImportHistory(&MissionControlSnapshot{
+		Pairs: []MissionControlPairSnapshot{success},
+	}, false)
+	require.NoError(t, err)
+
+	imported, err := ctx.mc.store.fetchImported()
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	require.False(t, imported[0].force)
+}
+
+// TestMissionControlImportLimit tests that only the pairs with the most recent
+// imported results are kept if there are more than the maximum number of
+// records.
+func TestMissionControlImportLimit(t *testing.T) {
+	ctx := createMcTestContext(t)
+	ctx.mc.store.maxRecords = 1
+
+	older := MissionControlPairSnapshot{
+		Pair: NewDirectedNodePair(mcTestNode1, mcTestNode2),
+		TimedPairResult: TimedPairResult{
+			FailTime: mcTestTime.Add(-2 * time.Hour).Local(),
+			FailAmt:  1000,
+		},
+	}
+	newer := MissionControlPairSnapshot{
+		Pair: NewDirectedNodePair(mcTestNode2, mcTestNode1),
+		TimedPairResult: TimedPairResult{
+			SuccessTime: mcTestTime.Add(-time.Hour).Local(),
+			SuccessAmt:  1000,
+		},
+	}
+
+	err := ctx.mc.ImportHistory(&MissionControlSnapshot{
+		Pairs: []MissionControlPairSnapshot{older, newer},
+	}, false)
+	require.NoError(t, err)
+
+	imported, err := ctx.mc.store.fetchImported()
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	require.Equal(t, newer, imported[0].MissionControlPairSnapshot)
+}
@@ -1,6 +1,7 @@
 package routing
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 
@@ -185,6 +186,10 @@ func (m *mockPaymentSessionOld) GetAdditionalEdgePolicy(_ *btcec.PublicKey,
 	return nil
 }
 
+func (m *mockPaymentSessionOld) ReportChannelFailure(_ uint64,
+	_ lnwire.MilliSatoshi) {
+}
+
 type mockPayerOld struct {
 	sendResult    chan error
 	paymentResult chan *htlcswitch.PaymentResult
@@ -336,6 +341,24 @@ func (m *mockControlTowerOld) DeleteFailedAttempts(phash lntypes.Hash) error {
 	return nil
 }
 
+func (m *mockControlTowerOld) DeletePayment(phash lntypes.Hash) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if _, ok := m.payments[phash]; !ok {
+		return channeldb.ErrPaymentNotInitiated
+	}
+
+	if _, ok := m.failed[phash]; !ok {
+		return channeldb.ErrPaymentInFlight
+	}
+
+	delete(m.payments, phash)
+	delete(m.failed, phash)
+
+	return nil
+}
+
 func (m *mockControlTowerOld) RegisterAttempt(phash lntypes.Hash,
 	a *channeldb.HTLCAttemptInfo) error {
 
@@ -499,6 +522,21 @@ func (m *mockControlTowerOld) FetchPayment(phash lntypes.Hash) (
 	return m.fetchPayment(phash)
 }
 
+func (m *mockControlTowerOld) LookupIdempotencyKey(key []byte) (lntypes.Hash,
+	error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	for phash, p := range m.payments {
+		if bytes.Equal(p.info.IdempotencyKey, key) {
+			return phash, nil
+		}
+	}
+
+	return lntypes.Hash{}, channeldb.ErrIdempotencyKeyNotFound
+}
+
 func (m *mockControlTowerOld) fetchPayment(phash lntypes.Hash) (
 	*channeldb.MPPayment, error) {
 
@@ -684,6 +722,12 @@ func (m *mockPaymentSession) GetAdditionalEdgePolicy(pubKey *btcec.PublicKey,
 	return args.Get(0).(*channeldb.CachedEdgePolicy)
 }
 
+func (m *mockPaymentSession) ReportChannelFailure(channelID uint64,
+	amt lnwire.MilliSatoshi) {
+
+	m.Called(channelID, amt)
+}
+
 type mockControlTower struct {
 	mock.Mock
 	sync.Mutex
@@ -703,6 +747,11 @@ func (m *mockControlTower) DeleteFailedAttempts(phash lntypes.Hash) error {
 	return args.Error(0)
 }
 
+func (m *mockControlTower) DeletePayment(phash lntypes.Hash) error {
+	args := m.Called(phash)
+	return args.Error(0)
+}
+
 func (m *mockControlTower) RegisterAttempt(phash lntypes.Hash,
 	a *channeldb.HTLCAttemptInfo) error {
 
@@ -768,6 +817,13 @@ func (m *mockControlTower) FetchPayment(phash lntypes.Hash) (
 	return payment, args.Error(1)
 }
 
+func (m *mockControlTower) LookupIdempotencyKey(key []byte) (lntypes.Hash,
+	error) {
+
+	args := m.Called(key)
+	return args.Get(0).(lntypes.Hash), args.Error(1)
+}
+
 func (m *mockControlTower) FetchInFlightPayments() (
 	[]*channeldb.MPPayment, error) {
 
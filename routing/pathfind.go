@@ -2,9 +2,11 @@ package routing
 
 import (
 	"container/heap"
+	"context"
 	"errors"
 	"fmt"
 	"math"
+	"runtime/pprof"
 	"time"
 
 	sphinx "github.com/ltcsuite/lightning-onion"
@@ -229,7 +231,21 @@ func newRoute(sourceVertex route.Vertex,
 			// and its policy for the outgoing channel. This policy
 			// is stored as part of the incoming channel of
 			// the next hop.
-			fee = pathEdges[i+1].ComputeFee(amtToForward)
+			outboundFee := pathEdges[i+1].ComputeFee(amtToForward)
+
+			// The current hop also charges an inbound fee for the
+			// channel over which the htlc arrives. It is
+			// calculated on the amount including the outbound fee.
+			// A negative inbound fee can at most cancel out the
+			// outbound fee.
+			inboundFee := edge.InboundFee.CalcFee(
+				amtToForward + outboundFee,
+			)
+			totalFee := int64(outboundFee) + inboundFee
+			if totalFee < 0 {
+				totalFee = 0
+			}
+			fee = lnwire.MilliSatoshi(totalFee)
 
 			// We'll take the total timelock of the preceding hop as
 			// the outgoing timelock or this hop. Then we'll
@@ -326,6 +342,10 @@ type RestrictParams struct {
 	// first hop. If nil, any channel may be used.
 	OutgoingChannelIDs []uint64
 
+	// BlockedFirstHops is the list of peers that must not be used as the
+	// first hop. They may still be used further along the path.
+	BlockedFirstHops []route.Vertex
+
 	// LastHop is the pubkey of the last node before the final destination
 	// is reached. If nil, any node may be used.
 	LastHop *route.Vertex
@@ -352,6 +372,12 @@ type RestrictParams struct {
 	// Metadata is additional data that is sent along with the payment to
 	// the payee.
 	Metadata []byte
+
+	// TempChanFailures maps channels that returned a temporary failure for
+	// the current payment to the smallest amount that failed. Those
+	// channels are not used for that amount or more, but parallel
+	// channels between the same pair of nodes remain eligible.
+	TempChanFailures map[uint64]lnwire.MilliSatoshi
 }
 
 // PathFindingConfig defines global parameters that control the trade-off in
@@ -371,12 +397,37 @@ type PathFindingConfig struct {
 	// MinProbability defines the minimum success probability of the
 	// returned route.
 	MinProbability float64
+
+	// ProfileLabels indicates whether path finding calls are executed with
+	// pprof labels attached. This allows cpu and allocation profiles to
+	// be broken down by path finding mode and payment.
+	ProfileLabels bool
+}
+
+// withProfileLabels executes f. If profile labels are enabled in the path
+// finding config, f is executed with the given key/value pairs attached as
+// pprof labels, so that profiles can be filtered with pprof's -tagfocus
+// option.
+func withProfileLabels(cfg *PathFindingConfig, f func(), labels ...string) {
+	if !cfg.ProfileLabels {
+		f()
+		return
+	}
+
+	pprof.Do(
+		context.Background(), pprof.Labels(labels...),
+		func(context.Context) {
+			f()
+		},
+	)
 }
 
 // getOutgoingBalance returns the maximum available balance in any of the
 // channels of the given node. The second return parameters is the total
-// available balance.
+// available balance. Channels to blocked first hops are not taken into
+// account.
 func getOutgoingBalance(node route.Vertex, outgoingChans map[uint64]struct{},
+	blockedFirstHops map[route.Vertex]struct{},
 	bandwidthHints bandwidthHints,
 	g routingGraph) (lnwire.MilliSatoshi, lnwire.MilliSatoshi, error) {
 
@@ -395,6 +446,11 @@ func getOutgoingBalance(node route.Vertex, outgoingChans map[uint64]struct{},
 			}
 		}
 
+		// Enforce first hop restriction.
+		if _, ok := blockedFirstHops[channel.OtherNode]; ok {
+			return nil
+		}
+
 		bandwidth, ok := bandwidthHints.availableChanBandwidth(
 			chanID, 0,
 		)
@@ -513,13 +569,23 @@ func findPath(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
 		}
 	}
 
+	// Set up blocked first hop map for quicker access.
+	var blockedFirstHopMap map[route.Vertex]struct{}
+	if len(r.BlockedFirstHops) > 0 {
+		blockedFirstHopMap = make(map[route.Vertex]struct{})
+		for _, firstHop := range r.BlockedFirstHops {
+			blockedFirstHopMap[firstHop] = struct{}{}
+		}
+	}
+
 	// If we are routing from ourselves, check that we have enough local
 	// balance available.
 	self := g.graph.sourceNode()
 
 	if source == self {
 		max, total, err := getOutgoingBalance(
-			self, outgoingChanMap, g.bandwidthHints, g.graph,
+			self, outgoingChanMap, blockedFirstHopMap,
+			g.bandwidthHints, g.graph,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -643,8 +709,14 @@ func findPath(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
 		edgesExpanded++
 
 		// Calculate amount that the candidate node would have to send
-		// out.
-		amountToSend := toNodeDist.amountToReceive
+		// out. This includes the inbound fee that the to node charges
+		// for the channel, which may be negative.
+		inboundFee := edge.inboundFeeFor(
+			toNodeDist.amountToReceive, toNodeDist.outboundFee,
+		)
+		amountToSend := lnwire.MilliSatoshi(
+			int64(toNodeDist.amountToReceive) + inboundFee,
+		)
 
 		// Request the success probability for this edge.
 		edgeProbability := r.ProbabilitySource(
@@ -717,8 +789,17 @@ func findPath(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
 		// By adding fromVertex in the route, there will be an extra
 		// weight composed of the fee that this node will charge and
 		// the amount that will be locked for timeLockDelta blocks in
-		// the HTLC that is handed out to fromVertex.
-		weight := edgeWeight(amountToReceive, fee, timeLockDelta)
+		// the HTLC that is handed out to fromVertex. The inbound fee of
+		// the to node is accounted for as well, but a negative total
+		// fee doesn't lower the weight below zero.
+		weightFee := int64(fee) + inboundFee
+		if weightFee < 0 {
+			weightFee = 0
+		}
+		weight := edgeWeight(
+			amountToReceive, lnwire.MilliSatoshi(weightFee),
+			timeLockDelta,
+		)
 
 		// Compute the tentative weight to this new channel/edge
 		// which is the weight from our toNode to the target node
@@ -797,6 +878,7 @@ func findPath(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
 			weight:          tempWeight,
 			node:            fromVertex,
 			amountToReceive: amountToReceive,
+			outboundFee:     fee,
 			incomingCltv:    incomingCltv,
 			probability:     probability,
 			nextHop:         edge.policy,
@@ -860,8 +942,12 @@ func findPath(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
 
 		pivot := partialPath.node
 
-		// Create unified edges for all incoming connections.
-		u := newNodeEdgeUnifier(self, pivot, outgoingChanMap)
+		// Create unified edges for all incoming connections. The
+		// target doesn't charge inbound fees.
+		u := newNodeEdgeUnifier(
+			self, pivot, pivot != target, outgoingChanMap,
+			r.TempChanFailures,
+		)
 
 		err := u.addGraphPolicies(g.graph)
 		if err != nil {
@@ -902,8 +988,17 @@ func findPath(g *graphParams, r *RestrictParams, cfg *PathFindingConfig,
 				continue
 			}
 
+			// Apply first hop restriction if set.
+			if fromNode == source {
+				_, ok := blockedFirstHopMap[pivot]
+				if ok {
+					continue
+				}
+			}
+
 			edge := edgeUnifier.getEdge(
 				amtToSend, g.bandwidthHints,
+				partialPath.outboundFee,
 			)
 
 			if edge == nil {
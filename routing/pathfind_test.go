@@ -17,10 +17,12 @@ import (
 	"time"
 
 	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/channeldb/models"
 	"github.com/ltcsuite/lnd/htlcswitch"
 	"github.com/ltcsuite/lnd/kvdb"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/record"
+	"github.com/ltcsuite/lnd/routing/bench"
 	"github.com/ltcsuite/lnd/routing/route"
 	"github.com/ltcsuite/ltcd/btcec/v2"
 	"github.com/ltcsuite/ltcd/btcec/v2/ecdsa"
@@ -817,6 +819,9 @@ func TestPathFinding(t *testing.T) {
 	}, {
 		name: "restrict last hop",
 		fn:   runRestrictLastHop,
+	}, {
+		name: "restrict first hop",
+		fn:   runRestrictFirstHop,
 	}, {
 		name: "CLTV limit",
 		fn:   runCltvLimit,
@@ -1377,6 +1382,18 @@ func TestNewRoute(t *testing.T) {
 		}
 	}
 
+	// createInboundHop creates a hop into a node that charges the given
+	// inbound fee.
+	createInboundHop := func(baseFee lnwire.MilliSatoshi,
+		feeRate lnwire.MilliSatoshi, timeLockDelta uint16,
+		inboundFee models.InboundFee) *channeldb.CachedEdgePolicy {
+
+		hop := createHop(baseFee, feeRate, 1000000, timeLockDelta)
+		hop.InboundFee = inboundFee
+
+		return hop
+	}
+
 	testCases := []struct {
 		// name identifies the test case in the test output.
 		name string
@@ -1546,6 +1563,57 @@ func TestNewRoute(t *testing.T) {
 			expectedTotalAmount:   101101,
 			expectedTimeLocks:     []uint32{4, 1, 1},
 			expectedTotalTimeLock: 9,
+		}, {
+			// A two hop payment where the intermediate node gives
+			// an inbound discount of 30 msat on its outbound fee
+			// of 130 msat.
+			name:          "two hop with negative inbound fee",
+			paymentAmount: 100000,
+			hops: []*channeldb.CachedEdgePolicy{
+				createInboundHop(
+					0, 1000, 10,
+					models.InboundFee{Base: -30},
+				),
+				createHop(30, 1000, 1000000, 5),
+			},
+			expectedFees:          []lnwire.MilliSatoshi{100, 0},
+			expectedTimeLocks:     []uint32{1, 1},
+			expectedTotalAmount:   100100,
+			expectedTotalTimeLock: 6,
+		}, {
+			// A two hop payment where the inbound discount exceeds
+			// the outbound fee. The total fee can't be negative.
+			name:          "two hop with capped inbound fee",
+			paymentAmount: 100000,
+			hops: []*channeldb.CachedEdgePolicy{
+				createInboundHop(
+					0, 1000, 10,
+					models.InboundFee{Base: -500},
+				),
+				createHop(30, 1000, 1000000, 5),
+			},
+			expectedFees:          []lnwire.MilliSatoshi{0, 0},
+			expectedTimeLocks:     []uint32{1, 1},
+			expectedTotalAmount:   100000,
+			expectedTotalTimeLock: 6,
+		}, {
+			// A two hop payment where the intermediate node charges
+			// an inbound fee on top of its outbound fee. The inbound
+			// fee rate applies to the amount including the outbound
+			// fee.
+			name:          "two hop with positive inbound fee",
+			paymentAmount: 100000,
+			hops: []*channeldb.CachedEdgePolicy{
+				createInboundHop(
+					0, 1000, 10,
+					models.InboundFee{Base: 10, Rate: 1000},
+				),
+				createHop(30, 1000, 1000000, 5),
+			},
+			expectedFees:          []lnwire.MilliSatoshi{240, 0},
+			expectedTimeLocks:     []uint32{1, 1},
+			expectedTotalAmount:   100240,
+			expectedTotalTimeLock: 6,
 		}}
 
 	for _, testCase := range testCases {
@@ -2564,6 +2632,59 @@ func runRestrictLastHop(t *testing.T, useCache bool) {
 	}
 }
 
+// runRestrictFirstHop asserts that blocked first hops aren't used as the first
+// hop of the path, but may still be used further along the path.
+func runRestrictFirstHop(t *testing.T, useCache bool) {
+	// Set up a test graph with three possible paths from source to target.
+	// The path via a is the lowest cost path, the path via b and a is
+	// cheaper than the path via b only.
+	testChannels := []*testChannel{
+		symmetricTestChannel("source", "a", 100000, &testChannelPolicy{
+			Expiry: 144,
+		}, 1),
+		symmetricTestChannel("a", "target", 100000, &testChannelPolicy{
+			Expiry:  144,
+			FeeRate: 400,
+		}, 2),
+		symmetricTestChannel("source", "b", 100000, &testChannelPolicy{
+			Expiry: 144,
+		}, 3),
+		symmetricTestChannel("b", "target", 100000, &testChannelPolicy{
+			Expiry:  144,
+			FeeRate: 800,
+		}, 4),
+		symmetricTestChannel("b", "a", 100000, &testChannelPolicy{
+			Expiry: 144,
+		}, 5),
+	}
+
+	ctx := newPathFindingTestContext(t, useCache, testChannels, "source")
+
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+	target := ctx.keyFromAlias("target")
+
+	// Without restrictions, the path via a is selected.
+	path, err := ctx.findPath(target, paymentAmt)
+	require.NoError(t, err, "unable to find path")
+	ctx.assertPath(path, []uint64{1, 2})
+
+	// Block a as the first hop. This should force pathfinding to go
+	// through b first, from where a can still be used.
+	ctx.restrictParams.BlockedFirstHops = []route.Vertex{
+		ctx.keyFromAlias("a"),
+	}
+	path, err = ctx.findPath(target, paymentAmt)
+	require.NoError(t, err, "unable to find path")
+	ctx.assertPath(path, []uint64{3, 5, 2})
+
+	// With both peers blocked, there is no path left.
+	ctx.restrictParams.BlockedFirstHops = append(
+		ctx.restrictParams.BlockedFirstHops, ctx.keyFromAlias("b"),
+	)
+	_, err = ctx.findPath(target, paymentAmt)
+	require.ErrorIs(t, err, errInsufficientBalance)
+}
+
 // runCltvLimit asserts that a cltv limit is obeyed by the path finding
 // algorithm.
 func runCltvLimit(t *testing.T, useCache bool) {
@@ -3128,3 +3249,79 @@ func dbFindPath(graph *channeldb.ChannelGraph,
 
 	return route, err
 }
+
+// BenchmarkFindPathSnapshot benchmarks path finding on the graph snapshot
+// that is configured for the routing/bench package. It is skipped if no
+// snapshot is configured.
+func BenchmarkFindPathSnapshot(b *testing.B) {
+	graph, source, targets, err := bench.Setup()
+	if err == bench.ErrNoSnapshot {
+		b.Skip(err)
+	}
+	require.NoError(b, err)
+
+	sourceNode, err := graph.SourceNode()
+	require.NoError(b, err)
+
+	routingGraph, err := NewCachedGraph(sourceNode, graph.ChannelGraph)
+	require.NoError(b, err)
+	defer routingGraph.Close()
+
+	estimator, err := NewAprioriEstimator(DefaultAprioriConfig())
+	require.NoError(b, err)
+
+	restrictions := &RestrictParams{
+		ProbabilitySource: func(_, toNode route.Vertex,
+			amt lnwire.MilliSatoshi,
+			capacity ltcutil.Amount) float64 {
+
+			return estimator.PairProbability(
+				time.Now(), nil, toNode, amt, capacity,
+			)
+		},
+		FeeLimit:  noFeeLimit,
+		CltvLimit: math.MaxUint32,
+	}
+
+	cfg := &PathFindingConfig{
+		AttemptCost:    lnwire.NewMSatFromSatoshis(100),
+		AttemptCostPPM: 1000,
+		MinProbability: 0.01,
+		ProfileLabels:  true,
+	}
+
+	params := &graphParams{
+		graph:          routingGraph,
+		bandwidthHints: &mockBandwidthHints{},
+	}
+
+	for _, amt := range bench.Amounts {
+		amt := amt
+
+		b.Run(fmt.Sprintf("amt=%v", amt), func(b *testing.B) {
+			var found int
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				target := targets[i%len(targets)]
+
+				var err error
+				withProfileLabels(cfg, func() {
+					_, _, err = findPath(
+						params, restrictions, cfg,
+						source, target, amt, 0, 0,
+					)
+				}, "pathfinding", "benchmark",
+					"target", target.String())
+
+				if err == nil {
+					found++
+				}
+			}
+
+			b.ReportMetric(float64(found)/float64(b.N), "found/op")
+		})
+	}
+}
@@ -861,6 +861,20 @@ func (p *shardHandler) handleSendError(attempt *channeldb.HTLCAttemptInfo,
 		return failPayment(&internalErrorReason, sendErr)
 	}
 
+	// A temporary channel failure means that the outgoing channel of the
+	// failing node couldn't carry the htlc. We let the payment session
+	// know, so that it can try parallel channels to the same peer for
+	// subsequent shards.
+	_, isTempChanFail := failureMessage.(*lnwire.FailTemporaryChannelFailure)
+	if isTempChanFail && p.paySession != nil &&
+		failureSourceIdx < len(attempt.Route.Hops) {
+
+		hop := attempt.Route.Hops[failureSourceIdx]
+		p.paySession.ReportChannelFailure(
+			hop.ChannelID, hop.AmtToForward,
+		)
+	}
+
 	log.Tracef("Node=%v reported failure when sending htlc",
 		failureSourceIdx)
 
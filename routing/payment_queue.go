@@ -0,0 +1,284 @@
+package routing
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ltcsuite/lnd/lntypes"
+)
+
+const (
+	// DefaultMaxQueuedPayments is the default maximum number of payments
+	// that may wait in the payment queue.
+	DefaultMaxQueuedPayments = 1000
+)
+
+var (
+	// ErrPaymentQueueFull is returned if a payment can't be queued,
+	// because the maximum number of payments is already waiting in the
+	// payment queue.
+	ErrPaymentQueueFull = errors.New("payment queue is full")
+
+	// ErrPaymentQueueCanceled is returned if a payment was canceled by the
+	// caller while it was waiting in the payment queue.
+	ErrPaymentQueueCanceled = errors.New("payment canceled while queued")
+)
+
+// queuedPayment is a payment that is waiting for the payment queue to admit
+// it.
+type queuedPayment struct {
+	// identifier is the identifier of the payment.
+	identifier lntypes.Hash
+
+	// ready is closed when the payment is admitted.
+	ready chan struct{}
+}
+
+// paymentQueue limits the number of payments that are executed concurrently.
+// Payments that exceed the limit are queued and admitted in the order in which
+// they arrived, so that no payment is starved by payments that are dispatched
+// after it.
+type paymentQueue struct {
+	// maxActive is the maximum number of payments that are executed
+	// concurrently. If zero, the number of payments isn't limited.
+	maxActive int
+
+	// maxQueued is the maximum number of payments that may wait in the
+	// queue. If zero, the length of the queue isn't limited.
+	maxQueued int
+
+	// numActive is the number of payments that are currently executed.
+	numActive int
+
+	// waiting holds the payments that are waiting to be admitted, in the
+	// order in which they arrived.
+	waiting []*queuedPayment
+
+	mu sync.Mutex
+}
+
+// newPaymentQueue returns a new payment queue that executes at most maxActive
+// payments concurrently, and lets at most maxQueued payments wait.
+func newPaymentQueue(maxActive, maxQueued uint32) *paymentQueue {
+	return &paymentQueue{
+		maxActive: int(maxActive),
+		maxQueued: int(maxQueued),
+	}
+}
+
+// enqueue adds the payment with the given identifier to the queue. If there
+// is capacity left and no other payments are waiting, the payment is admitted
+// right away. ErrPaymentQueueFull is returned if the payment would have to
+// wait, but the queue is full. The returned payment must be passed to release
+// once it has completed or is abandoned.
+func (q *paymentQueue) enqueue(identifier lntypes.Hash) (*queuedPayment,
+	error) {
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	payment := &queuedPayment{
+		identifier: identifier,
+		ready:      make(chan struct{}),
+	}
+
+	if q.maxActive == 0 ||
+		(q.numActive < q.maxActive && len(q.waiting) == 0) {
+
+		q.numActive++
+		close(payment.ready)
+
+		return payment, nil
+	}
+
+	if q.maxQueued != 0 && len(q.waiting) >= q.maxQueued {
+		return nil, ErrPaymentQueueFull
+	}
+
+	q.waiting = append(q.waiting, payment)
+
+	log.Debugf("Payment %v queued at position %v, limit of %v concurrent "+
+		"payments reached", identifier, len(q.waiting), q.maxActive)
+
+	return payment, nil
+}
+
+// wait blocks until the given payment is admitted. If the cancel or the quit
+// channel is closed before that, ErrPaymentQueueCanceled or
+// ErrRouterShuttingDown is returned. In any case, the payment must be passed
+// to release afterwards.
+func (q *paymentQueue) wait(payment *queuedPayment, cancel,
+	quit <-chan struct{}) error {
+
+	select {
+	case <-payment.ready:
+		return nil
+
+	case <-cancel:
+		return ErrPaymentQueueCanceled
+
+	case <-quit:
+		return ErrRouterShuttingDown
+	}
+}
+
+// acquireImmediate admits a payment regardless of the limit. It is used for
+// payments that already have HTLCs in flight, which can't be held back. The
+// payment still occupies a slot until it is passed to release.
+func (q *paymentQueue) acquireImmediate(
+	identifier lntypes.Hash) *queuedPayment {
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.numActive++
+
+	payment := &queuedPayment{
+		identifier: identifier,
+		ready:      make(chan struct{}),
+	}
+	close(payment.ready)
+
+	return payment
+}
+
+// release signals that the given payment has completed or is abandoned. If the
+// payment was admitted, its slot is passed on to the next waiting payment.
+// Otherwise, it is removed from the queue.
+func (q *paymentQueue) release(payment *queuedPayment) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// The payment may have been admitted concurrently with being
+	// abandoned, in which case we need to pass its slot on.
+	select {
+	case <-payment.ready:
+		q.releaseLocked()
+
+	default:
+		q.removeLocked(payment)
+	}
+}
+
+// releaseLocked releases the slot of an admitted payment.
+//
+// NOTE: The caller must hold the mutex.
+func (q *paymentQueue) releaseLocked() {
+	// If the limit was exceeded by payments that were admitted
+	// immediately, we only pass on the slot once we're back below it.
+	if len(q.waiting) > 0 && q.numActive <= q.maxActive {
+		next := q.waiting[0]
+		q.waiting[0] = nil
+		q.waiting = q.waiting[1:]
+
+		close(next.ready)
+
+		return
+	}
+
+	q.numActive--
+}
+
+// removeLocked removes a waiting payment from the queue.
+//
+// NOTE: The caller must hold the mutex.
+func (q *paymentQueue) removeLocked(payment *queuedPayment) {
+	for i, p := range q.waiting {
+		if p == payment {
+			q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+			return
+		}
+	}
+}
+
+// position returns the one-based position of the payment with the given
+// identifier in the queue. False is returned if the payment isn't waiting.
+func (q *paymentQueue) position(identifier lntypes.Hash) (int, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, p := range q.waiting {
+		if p.identifier == identifier {
+			return i + 1, true
+		}
+	}
+
+	return 0, false
+}
+
+// PaymentQueueStats describes the state of the payment queue.
+type PaymentQueueStats struct {
+	// MaxActive is the maximum number of payments that are executed
+	// concurrently. Zero means that the number isn't limited.
+	MaxActive int
+
+	// NumActive is the number of payments that are currently executed.
+	NumActive int
+
+	// NumQueued is the number of payments that are waiting to be
+	// executed.
+	NumQueued int
+}
+
+// stats returns the current state of the queue.
+func (q *paymentQueue) stats() PaymentQueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return PaymentQueueStats{
+		MaxActive: q.maxActive,
+		NumActive: q.numActive,
+		NumQueued: len(q.waiting),
+	}
+}
+
+// QueuedPayment is a payment that was added to the payment queue of the
+// router. The payment is only registered with the control tower once it is
+// admitted, so that payments that are still waiting when lnd shuts down aren't
+// resumed, and thereby failed, on startup.
+type QueuedPayment struct {
+	queue   *paymentQueue
+	payment *queuedPayment
+	quit    <-chan struct{}
+
+	releaseOnce sync.Once
+}
+
+// QueuePayment adds the payment with the given identifier to the payment
+// queue. ErrPaymentQueueFull is returned if the maximum number of payments is
+// already waiting. The returned payment must be released once it has
+// completed or is abandoned, unless it is passed to SendPaymentAsync.
+func (r *ChannelRouter) QueuePayment(identifier lntypes.Hash) (*QueuedPayment,
+	error) {
+
+	payment, err := r.paymentQueue.enqueue(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QueuedPayment{
+		queue:   r.paymentQueue,
+		payment: payment,
+		quit:    r.quit,
+	}, nil
+}
+
+// Wait blocks until the payment is admitted. If the cancel channel is closed
+// or the router shuts down before that, the payment is removed from the queue
+// and an error is returned.
+func (q *QueuedPayment) Wait(cancel <-chan struct{}) error {
+	err := q.queue.wait(q.payment, cancel, q.quit)
+	if err != nil {
+		q.Release()
+	}
+
+	return err
+}
+
+// Release frees the slot of an admitted payment, or removes a waiting payment
+// from the queue. It is safe to call Release multiple times.
+func (q *QueuedPayment) Release() {
+	q.releaseOnce.Do(func() {
+		q.queue.release(q.payment)
+	})
+}
@@ -0,0 +1,214 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ltcsuite/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// enqueue adds a payment with the given identifier to the queue and asserts
+// that it is accepted.
+func enqueue(t *testing.T, q *paymentQueue, id byte) *queuedPayment {
+	t.Helper()
+
+	p, err := q.enqueue(lntypes.Hash{id})
+	require.NoError(t, err)
+
+	return p
+}
+
+// requireAdmitted asserts that the queued payment is admitted.
+func requireAdmitted(t *testing.T, q *paymentQueue, p *queuedPayment) {
+	t.Helper()
+
+	err := q.wait(p, nil, make(chan struct{}))
+	require.NoError(t, err)
+}
+
+// requireQueued asserts that the queued payment is waiting at the given
+// position.
+func requireQueued(t *testing.T, q *paymentQueue, p *queuedPayment,
+	position int) {
+
+	t.Helper()
+
+	select {
+	case <-p.ready:
+		t.Fatalf("payment %v unexpectedly admitted", p.identifier)
+
+	default:
+	}
+
+	pos, ok := q.position(p.identifier)
+	require.True(t, ok)
+	require.Equal(t, position, pos)
+}
+
+// TestPaymentQueue tests that payments exceeding the concurrency limit are
+// queued and admitted in order.
+func TestPaymentQueue(t *testing.T) {
+	t.Parallel()
+
+	q := newPaymentQueue(2, 0)
+
+	p1 := enqueue(t, q, 1)
+	p2 := enqueue(t, q, 2)
+	p3 := enqueue(t, q, 3)
+	p4 := enqueue(t, q, 4)
+
+	// The first two payments are admitted right away, the others need to
+	// wait.
+	requireAdmitted(t, q, p1)
+	requireAdmitted(t, q, p2)
+	requireQueued(t, q, p3, 1)
+	requireQueued(t, q, p4, 2)
+
+	_, ok := q.position(p1.identifier)
+	require.False(t, ok)
+
+	require.Equal(t, PaymentQueueStats{
+		MaxActive: 2,
+		NumActive: 2,
+		NumQueued: 2,
+	}, q.stats())
+
+	// A payment that is resumed on startup is admitted regardless of the
+	// limit.
+	resumed := q.acquireImmediate(lntypes.Hash{9})
+	requireAdmitted(t, q, resumed)
+
+	// Completing a payment only brings us back to the limit, so no queued
+	// payment is admitted yet.
+	q.release(p1)
+	requireQueued(t, q, p3, 1)
+
+	// Completing another payment admits the first queued payment.
+	q.release(p2)
+	requireAdmitted(t, q, p3)
+	requireQueued(t, q, p4, 1)
+
+	// A payment that is dispatched while others are waiting is queued
+	// behind them.
+	p5 := enqueue(t, q, 5)
+	requireQueued(t, q, p5, 2)
+
+	q.release(resumed)
+	requireAdmitted(t, q, p4)
+	requireQueued(t, q, p5, 1)
+
+	q.release(p3)
+	requireAdmitted(t, q, p5)
+
+	q.release(p4)
+	q.release(p5)
+	require.Equal(t, PaymentQueueStats{MaxActive: 2}, q.stats())
+}
+
+// TestPaymentQueueUnlimited tests that all payments are admitted if the
+// number of concurrent payments isn't limited.
+func TestPaymentQueueUnlimited(t *testing.T) {
+	t.Parallel()
+
+	q := newPaymentQueue(0, 1)
+
+	for i := 0; i < 100; i++ {
+		requireAdmitted(t, q, enqueue(t, q, byte(i)))
+	}
+
+	require.Equal(t, PaymentQueueStats{NumActive: 100}, q.stats())
+}
+
+// TestPaymentQueueFull tests that payments are rejected once the maximum
+// number of payments is waiting, and accepted again once there is room.
+func TestPaymentQueueFull(t *testing.T) {
+	t.Parallel()
+
+	q := newPaymentQueue(1, 2)
+
+	p1 := enqueue(t, q, 1)
+	requireAdmitted(t, q, p1)
+
+	p2 := enqueue(t, q, 2)
+	p3 := enqueue(t, q, 3)
+
+	_, err := q.enqueue(lntypes.Hash{4})
+	require.ErrorIs(t, err, ErrPaymentQueueFull)
+
+	// Admitting a queued payment frees room in the queue.
+	q.release(p1)
+	requireAdmitted(t, q, p2)
+	requireQueued(t, q, p3, 1)
+
+	p4 := enqueue(t, q, 4)
+	requireQueued(t, q, p4, 2)
+}
+
+// TestPaymentQueueCancel tests that a payment that is canceled while waiting
+// is removed from the queue without taking a slot.
+func TestPaymentQueueCancel(t *testing.T) {
+	t.Parallel()
+
+	q := newPaymentQueue(1, 0)
+
+	p1 := enqueue(t, q, 1)
+	requireAdmitted(t, q, p1)
+
+	p2 := enqueue(t, q, 2)
+	p3 := enqueue(t, q, 3)
+
+	cancel := make(chan struct{})
+	close(cancel)
+	err := q.wait(p2, cancel, make(chan struct{}))
+	require.ErrorIs(t, err, ErrPaymentQueueCanceled)
+
+	q.release(p2)
+	requireQueued(t, q, p3, 1)
+
+	// The slot of the first payment is passed on to the payment behind
+	// the canceled one.
+	q.release(p1)
+	requireAdmitted(t, q, p3)
+
+	require.Equal(t, PaymentQueueStats{
+		MaxActive: 1,
+		NumActive: 1,
+	}, q.stats())
+}
+
+// TestPaymentQueueShutdown tests that waiting payments are removed from the
+// queue on shutdown.
+func TestPaymentQueueShutdown(t *testing.T) {
+	t.Parallel()
+
+	q := newPaymentQueue(1, 0)
+
+	p1 := enqueue(t, q, 1)
+	requireAdmitted(t, q, p1)
+
+	p2 := enqueue(t, q, 2)
+	requireQueued(t, q, p2, 1)
+
+	quit := make(chan struct{})
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- q.wait(p2, nil, quit)
+	}()
+
+	close(quit)
+
+	select {
+	case err := <-errChan:
+		require.ErrorIs(t, err, ErrRouterShuttingDown)
+
+	case <-time.After(time.Second):
+		t.Fatal("payment not released on shutdown")
+	}
+
+	q.release(p2)
+	require.Equal(t, PaymentQueueStats{
+		MaxActive: 1,
+		NumActive: 1,
+	}, q.stats())
+}
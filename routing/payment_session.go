@@ -2,6 +2,7 @@ package routing
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/btcsuite/btclog"
 	"github.com/davecgh/go-spew/spew"
@@ -151,6 +152,12 @@ type PaymentSession interface {
 	// if nothing found.
 	GetAdditionalEdgePolicy(pubKey *btcec.PublicKey,
 		channelID uint64) *channeldb.CachedEdgePolicy
+
+	// ReportChannelFailure records that the given channel returned a
+	// temporary failure when forwarding the given amount. Subsequent
+	// routes of this payment won't use the channel for that amount or
+	// more.
+	ReportChannelFailure(channelID uint64, amt lnwire.MilliSatoshi)
 }
 
 // paymentSession is used during an HTLC routings session to prune the local
@@ -180,11 +187,20 @@ type paymentSession struct {
 
 	missionControl MissionController
 
-	// minShardAmt is the amount beyond which we won't try to further split
-	// the payment if no route is found. If the maximum number of htlcs
-	// specified in the payment is one, under no circumstances splitting
-	// will happen and this value remains unused.
-	minShardAmt lnwire.MilliSatoshi
+	// splitConfig defines how the payment is split if no route is found
+	// for the full amount. If the maximum number of htlcs specified in the
+	// payment is one, under no circumstances splitting will happen and
+	// this config remains unused.
+	splitConfig SplitConfig
+
+	// chanFailures maps channels that returned a temporary failure during
+	// this payment to the smallest amount that failed. Unlike mission
+	// control, which penalizes node pairs, this allows path finding to
+	// still use parallel channels between the same nodes.
+	chanFailures map[uint64]lnwire.MilliSatoshi
+
+	// chanFailuresMtx guards chanFailures.
+	chanFailuresMtx sync.Mutex
 
 	// log is a payment session-specific logger.
 	log btclog.Logger
@@ -194,8 +210,8 @@ type paymentSession struct {
 func newPaymentSession(p *LightningPayment,
 	getBandwidthHints func(routingGraph) (bandwidthHints, error),
 	getRoutingGraph func() (routingGraph, func(), error),
-	missionControl MissionController, pathFindingConfig PathFindingConfig) (
-	*paymentSession, error) {
+	missionControl MissionController, pathFindingConfig PathFindingConfig,
+	splitConfig SplitConfig) (*paymentSession, error) {
 
 	edges, err := RouteHintsToEdges(p.RouteHints, p.Target)
 	if err != nil {
@@ -212,7 +228,8 @@ func newPaymentSession(p *LightningPayment,
 		getRoutingGraph:   getRoutingGraph,
 		pathFindingConfig: pathFindingConfig,
 		missionControl:    missionControl,
-		minShardAmt:       DefaultShardMinAmt,
+		splitConfig:       splitConfig,
+		chanFailures:      make(map[uint64]lnwire.MilliSatoshi),
 		log:               build.NewPrefixLog(logPrefix, log),
 	}, nil
 }
@@ -252,12 +269,14 @@ func (p *paymentSession) RequestRoute(maxAmt, feeLimit lnwire.MilliSatoshi,
 		ProbabilitySource:  p.missionControl.GetProbability,
 		FeeLimit:           feeLimit,
 		OutgoingChannelIDs: p.payment.OutgoingChannelIDs,
+		BlockedFirstHops:   p.payment.BlockedFirstHops,
 		LastHop:            p.payment.LastHop,
 		CltvLimit:          cltvLimit,
 		DestCustomRecords:  p.payment.DestCustomRecords,
 		DestFeatures:       p.payment.DestFeatures,
 		PaymentAddr:        p.payment.PaymentAddr,
 		Metadata:           p.payment.Metadata,
+		TempChanFailures:   p.getChannelFailures(),
 	}
 
 	finalHtlcExpiry := int32(height) + int32(finalCltvDelta)
@@ -274,6 +293,14 @@ func (p *paymentSession) RequestRoute(maxAmt, feeLimit lnwire.MilliSatoshi,
 		maxAmt = *p.payment.MaxShardAmt
 	}
 
+	// Keep track of the amount we started out with and the number of
+	// amounts we tried, as the split strategy may base the next shard
+	// amount on them.
+	var (
+		origAmt  = maxAmt
+		attempts uint32
+	)
+
 	for {
 		// Get a routing graph.
 		routingGraph, cleanup, err := p.getRoutingGraph()
@@ -292,71 +319,75 @@ func (p *paymentSession) RequestRoute(maxAmt, feeLimit lnwire.MilliSatoshi,
 			return nil, err
 		}
 
+		// With the adaptive split strategy, we start out with a shard
+		// that can be carried by one of our local channels, rather than
+		// discovering their balances by halving the amount.
+		if attempts == 0 &&
+			p.splitConfig.Strategy == SplitStrategyAdaptive &&
+			p.canSplit(activeShards) {
+
+			shardAmt, err := p.splitConfig.adaptiveShardAmt(
+				maxAmt, routingGraph, bandwidthHints,
+				outgoingChanSet(p.payment.OutgoingChannelIDs),
+				vertexSet(p.payment.BlockedFirstHops),
+			)
+			if err != nil {
+				cleanup()
+				return nil, err
+			}
+
+			if shardAmt != maxAmt {
+				p.log.Debugf("Adapting shard amount from %v to "+
+					"%v to fit local balance", maxAmt,
+					shardAmt)
+
+				maxAmt = shardAmt
+			}
+		}
+		attempts++
+
 		p.log.Debugf("pathfinding for amt=%v", maxAmt)
 
 		sourceVertex := routingGraph.sourceNode()
 
 		// Find a route for the current amount.
-		path, _, err := p.pathFinder(
-			&graphParams{
-				additionalEdges: p.additionalEdges,
-				bandwidthHints:  bandwidthHints,
-				graph:           routingGraph,
-			},
-			restrictions, &p.pathFindingConfig,
-			sourceVertex, p.payment.Target,
-			maxAmt, p.payment.TimePref, finalHtlcExpiry,
-		)
+		var path []*channeldb.CachedEdgePolicy
+		withProfileLabels(&p.pathFindingConfig, func() {
+			path, _, err = p.pathFinder(
+				&graphParams{
+					additionalEdges: p.additionalEdges,
+					bandwidthHints:  bandwidthHints,
+					graph:           routingGraph,
+				},
+				restrictions, &p.pathFindingConfig,
+				sourceVertex, p.payment.Target,
+				maxAmt, p.payment.TimePref, finalHtlcExpiry,
+			)
+		}, "pathfinding", "session",
+			"payment", fmt.Sprintf("%x", p.payment.Identifier()))
 
 		// Close routing graph.
 		cleanup()
 
 		switch {
 		case err == errNoPathFound:
-			// Don't split if this is a legacy payment without mpp
-			// record.
-			if p.payment.PaymentAddr == nil {
-				p.log.Debugf("not splitting because payment " +
-					"address is unspecified")
-
-				return nil, errNoPathFound
-			}
-
-			if p.payment.DestFeatures == nil {
-				p.log.Debug("Not splitting because " +
-					"destination DestFeatures is nil")
-				return nil, errNoPathFound
-			}
-
-			destFeatures := p.payment.DestFeatures
-			if !destFeatures.HasFeature(lnwire.MPPOptional) &&
-				!destFeatures.HasFeature(lnwire.AMPOptional) {
-
-				p.log.Debug("not splitting because " +
-					"destination doesn't declare MPP or AMP")
-
-				return nil, errNoPathFound
-			}
-
-			// No splitting if this is the last shard.
-			isLastShard := activeShards+1 >= p.payment.MaxParts
-			if isLastShard {
-				p.log.Debugf("not splitting because shard "+
-					"limit %v has been reached",
-					p.payment.MaxParts)
-
+			if !p.canSplit(activeShards) {
 				return nil, errNoPathFound
 			}
 
 			// This is where the magic happens. If we can't find a
-			// route, try it for half the amount.
-			maxAmt /= 2
+			// route, try it for a smaller amount as determined by
+			// our split strategy.
+			maxAmt = p.splitConfig.nextShardAmt(
+				maxAmt, origAmt, attempts,
+			)
 
 			// Put a lower bound on the minimum shard size.
-			if maxAmt < p.minShardAmt {
+			minShardAmt := p.splitConfig.MinShardAmt
+			if maxAmt < minShardAmt {
 				p.log.Debugf("not splitting because minimum "+
 					"shard amount %v has been reached",
-					p.minShardAmt)
+					minShardAmt)
 
 				return nil, errNoPathFound
 			}
@@ -400,6 +431,46 @@ func (p *paymentSession) RequestRoute(maxAmt, feeLimit lnwire.MilliSatoshi,
 	}
 }
 
+// canSplit returns whether the payment may be split into another shard, given
+// the number of shards that are currently in flight.
+func (p *paymentSession) canSplit(activeShards uint32) bool {
+	// Don't split if this is a legacy payment without mpp record.
+	if p.payment.PaymentAddr == nil {
+		p.log.Debugf("not splitting because payment " +
+			"address is unspecified")
+
+		return false
+	}
+
+	if p.payment.DestFeatures == nil {
+		p.log.Debug("Not splitting because " +
+			"destination DestFeatures is nil")
+		return false
+	}
+
+	destFeatures := p.payment.DestFeatures
+	if !destFeatures.HasFeature(lnwire.MPPOptional) &&
+		!destFeatures.HasFeature(lnwire.AMPOptional) {
+
+		p.log.Debug("not splitting because " +
+			"destination doesn't declare MPP or AMP")
+
+		return false
+	}
+
+	// No splitting if this is the last shard.
+	isLastShard := activeShards+1 >= p.payment.MaxParts
+	if isLastShard {
+		p.log.Debugf("not splitting because shard "+
+			"limit %v has been reached",
+			p.payment.MaxParts)
+
+		return false
+	}
+
+	return true
+}
+
 // UpdateAdditionalEdge updates the channel edge policy for a private edge. It
 // validates the message signature and checks it's up to date, then applies the
 // updates to the supplied policy. It returns a boolean to indicate whether
@@ -415,10 +486,17 @@ func (p *paymentSession) UpdateAdditionalEdge(msg *lnwire.ChannelUpdate,
 		return false
 	}
 
-	// Update channel policy for the additional edge.
+	// Update channel policy for the additional edge. Besides the fees and
+	// time lock, we also apply the htlc limits so that subsequent shards
+	// respect the max htlc of the hinted channel.
 	policy.TimeLockDelta = msg.TimeLockDelta
 	policy.FeeBaseMSat = lnwire.MilliSatoshi(msg.BaseFee)
 	policy.FeeProportionalMillionths = lnwire.MilliSatoshi(msg.FeeRate)
+	policy.MinHTLC = msg.HtlcMinimumMsat
+	if msg.MessageFlags.HasMaxHtlc() {
+		policy.MessageFlags |= lnwire.ChanUpdateRequiredMaxHtlc
+		policy.MaxHTLC = msg.HtlcMaximumMsat
+	}
 
 	log.Debugf("New private channel update applied: %v",
 		newLogClosure(func() string { return spew.Sdump(msg) }))
@@ -449,3 +527,40 @@ func (p *paymentSession) GetAdditionalEdgePolicy(pubKey *btcec.PublicKey,
 
 	return nil
 }
+
+// ReportChannelFailure records that the given channel returned a temporary
+// failure when forwarding the given amount. Only the smallest failed amount is
+// kept per channel.
+//
+// NOTE: Part of the PaymentSession interface.
+func (p *paymentSession) ReportChannelFailure(channelID uint64,
+	amt lnwire.MilliSatoshi) {
+
+	p.chanFailuresMtx.Lock()
+	defer p.chanFailuresMtx.Unlock()
+
+	failedAmt, ok := p.chanFailures[channelID]
+	if ok && failedAmt <= amt {
+		return
+	}
+
+	p.log.Debugf("Channel %v failed to forward %v, excluding it for this "+
+		"amount and above", channelID, amt)
+
+	p.chanFailures[channelID] = amt
+}
+
+// getChannelFailures returns a copy of the temporary channel failures that
+// were recorded for this payment, so that path finding can use them without
+// holding the lock.
+func (p *paymentSession) getChannelFailures() map[uint64]lnwire.MilliSatoshi {
+	p.chanFailuresMtx.Lock()
+	defer p.chanFailuresMtx.Unlock()
+
+	failures := make(map[uint64]lnwire.MilliSatoshi, len(p.chanFailures))
+	for channelID, amt := range p.chanFailures {
+		failures[channelID] = amt
+	}
+
+	return failures
+}
@@ -41,6 +41,11 @@ type SessionSource struct {
 	// PathFindingConfig defines global parameters that control the
 	// trade-off in path finding between fees and probabiity.
 	PathFindingConfig PathFindingConfig
+
+	// SplitConfig defines how payments are split if no route can be
+	// found for the full amount. If it is nil, the default split config
+	// is used.
+	SplitConfig *SplitConfig
 }
 
 // getRoutingGraph returns a routing graph and a clean-up function for
@@ -71,9 +76,14 @@ func (m *SessionSource) NewPaymentSession(p *LightningPayment) (
 		)
 	}
 
+	splitConfig := DefaultSplitConfig()
+	if m.SplitConfig != nil {
+		splitConfig = *m.SplitConfig
+	}
+
 	session, err := newPaymentSession(
 		p, getBandwidthHints, m.getRoutingGraph,
-		m.MissionControl, m.PathFindingConfig,
+		m.MissionControl, m.PathFindingConfig, splitConfig,
 	)
 	if err != nil {
 		return nil, err
@@ -87,7 +97,8 @@ func (m *SessionSource) NewPaymentSession(p *LightningPayment) (
 // missioncontrol for resumed payment we don't want to make more attempts for.
 func (m *SessionSource) NewPaymentSessionEmpty() PaymentSession {
 	return &paymentSession{
-		empty: true,
+		empty:        true,
+		chanFailures: make(map[uint64]lnwire.MilliSatoshi),
 	}
 }
 
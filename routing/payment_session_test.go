@@ -82,6 +82,7 @@ func TestUpdateAdditionalEdge(t *testing.T) {
 		newFeeBaseMSat = uint32(1100)
 		oldExpiryDelta = uint16(100)
 		newExpiryDelta = uint16(120)
+		newMaxHTLC     = lnwire.MilliSatoshi(500_000)
 
 		payHash lntypes.Hash
 	)
@@ -123,6 +124,7 @@ func TestUpdateAdditionalEdge(t *testing.T) {
 		},
 		&MissionControl{},
 		PathFindingConfig{},
+		DefaultSplitConfig(),
 	)
 	require.NoError(t, err, "failed to create payment session")
 
@@ -149,10 +151,12 @@ func TestUpdateAdditionalEdge(t *testing.T) {
 
 	// Create the channel update message and sign.
 	msg := &lnwire.ChannelUpdate{
-		ShortChannelID: lnwire.NewShortChanIDFromInt(testChannelID),
-		Timestamp:      uint32(time.Now().Unix()),
-		BaseFee:        newFeeBaseMSat,
-		TimeLockDelta:  newExpiryDelta,
+		ShortChannelID:  lnwire.NewShortChanIDFromInt(testChannelID),
+		Timestamp:       uint32(time.Now().Unix()),
+		BaseFee:         newFeeBaseMSat,
+		TimeLockDelta:   newExpiryDelta,
+		MessageFlags:    lnwire.ChanUpdateRequiredMaxHtlc,
+		HtlcMaximumMsat: newMaxHTLC,
 	}
 	signErrChanUpdate(t, priv1, msg)
 
@@ -171,6 +175,37 @@ func TestUpdateAdditionalEdge(t *testing.T) {
 		lnwire.MilliSatoshi(newFeeBaseMSat),
 		policy.FeeBaseMSat, "fee base msat mismatch",
 	)
+	require.True(t, policy.MessageFlags.HasMaxHtlc(), "max htlc not set")
+	require.Equal(t, newMaxHTLC, policy.MaxHTLC, "max htlc mismatch")
+}
+
+// TestReportChannelFailure tests that the payment session keeps track of the
+// smallest amount for which a channel returned a temporary failure.
+func TestReportChannelFailure(t *testing.T) {
+	t.Parallel()
+
+	session := &paymentSession{
+		chanFailures: make(map[uint64]lnwire.MilliSatoshi),
+		log:          log,
+	}
+
+	session.ReportChannelFailure(1, 2000)
+	session.ReportChannelFailure(2, 3000)
+
+	// A larger failed amount doesn't change the recorded one, a smaller
+	// one replaces it.
+	session.ReportChannelFailure(1, 2500)
+	session.ReportChannelFailure(2, 1500)
+
+	failures := session.getChannelFailures()
+	require.Equal(t, map[uint64]lnwire.MilliSatoshi{
+		1: 2000,
+		2: 1500,
+	}, failures)
+
+	// The returned map is a copy that isn't affected by later reports.
+	session.ReportChannelFailure(3, 1000)
+	require.Len(t, failures, 2)
 }
 
 func TestRequestRoute(t *testing.T) {
@@ -203,6 +238,7 @@ func TestRequestRoute(t *testing.T) {
 		},
 		&MissionControl{},
 		PathFindingConfig{},
+		DefaultSplitConfig(),
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -220,6 +256,12 @@ func TestRequestRoute(t *testing.T) {
 			t.Fatal("wrong cltv limit")
 		}
 
+		// We also expect the temporary channel failures of the
+		// session to be passed on.
+		require.Equal(t, map[uint64]lnwire.MilliSatoshi{
+			1: 1000,
+		}, r.TempChanFailures)
+
 		path := []*channeldb.CachedEdgePolicy{
 			{
 				ToNodePubKey: func() route.Vertex {
@@ -234,6 +276,8 @@ func TestRequestRoute(t *testing.T) {
 		return path, 1.0, nil
 	}
 
+	session.ReportChannelFailure(1, 1000)
+
 	route, err := session.RequestRoute(
 		payment.Amount, payment.FeeLimit, 0, height,
 	)
@@ -256,3 +300,208 @@ type sessionGraph struct {
 func (g *sessionGraph) sourceNode() route.Vertex {
 	return route.Vertex{}
 }
+
+// splitGraph is a routing graph that only knows about the channels of the
+// source node.
+type splitGraph struct {
+	sessionGraph
+
+	channels []*channeldb.DirectedChannel
+}
+
+func (g *splitGraph) forEachNodeChannel(_ route.Vertex,
+	cb func(channel *channeldb.DirectedChannel) error) error {
+
+	for _, channel := range g.channels {
+		if err := cb(channel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TestRequestRouteSplitStrategy tests that the payment session splits the
+// amount according to the configured split strategy when no route is found.
+func TestRequestRouteSplitStrategy(t *testing.T) {
+	t.Parallel()
+
+	const (
+		height  = 10
+		million = lnwire.MilliSatoshi(1_000_000)
+	)
+
+	testCases := []struct {
+		name         string
+		splitConfig  SplitConfig
+		balances     map[uint64]lnwire.MilliSatoshi
+		maxRouteAmt  lnwire.MilliSatoshi
+		expectedAmts []lnwire.MilliSatoshi
+		expectedErr  error
+	}{
+		{
+			name:        "halving",
+			splitConfig: DefaultSplitConfig(),
+			maxRouteAmt: 40 * million,
+			expectedAmts: []lnwire.MilliSatoshi{
+				100 * million, 50 * million, 25 * million,
+			},
+		},
+		{
+			name: "halving with higher split factor",
+			splitConfig: SplitConfig{
+				Strategy:    SplitStrategyHalving,
+				MinShardAmt: DefaultShardMinAmt,
+				SplitFactor: 4,
+			},
+			maxRouteAmt: 40 * million,
+			expectedAmts: []lnwire.MilliSatoshi{
+				100 * million, 25 * million,
+			},
+		},
+		{
+			name: "equal",
+			splitConfig: SplitConfig{
+				Strategy:    SplitStrategyEqual,
+				MinShardAmt: DefaultShardMinAmt,
+				SplitFactor: DefaultSplitFactor,
+			},
+			maxRouteAmt: 40 * million,
+			expectedAmts: []lnwire.MilliSatoshi{
+				100 * million, 50 * million, 33_333_333,
+			},
+		},
+		{
+			name: "adaptive",
+			splitConfig: SplitConfig{
+				Strategy:    SplitStrategyAdaptive,
+				MinShardAmt: DefaultShardMinAmt,
+				SplitFactor: DefaultSplitFactor,
+			},
+			balances: map[uint64]lnwire.MilliSatoshi{
+				1: 60 * million,
+				2: 45 * million,
+			},
+			maxRouteAmt: 40 * million,
+			expectedAmts: []lnwire.MilliSatoshi{
+				60 * million, 30 * million,
+			},
+		},
+		{
+			name: "adaptive with insufficient balance",
+			splitConfig: SplitConfig{
+				Strategy:    SplitStrategyAdaptive,
+				MinShardAmt: DefaultShardMinAmt,
+				SplitFactor: DefaultSplitFactor,
+			},
+			balances: map[uint64]lnwire.MilliSatoshi{
+				1: 60 * million,
+			},
+			maxRouteAmt: 40 * million,
+			expectedAmts: []lnwire.MilliSatoshi{
+				100 * million, 50 * million, 25 * million,
+			},
+		},
+		{
+			name:        "minimum shard amount",
+			splitConfig: DefaultSplitConfig(),
+			maxRouteAmt: 5 * million,
+			expectedAmts: []lnwire.MilliSatoshi{
+				100 * million, 50 * million, 25 * million,
+				12_500_000,
+			},
+			expectedErr: errNoPathFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			paymentAddr := [32]byte{1}
+			payment := &LightningPayment{
+				CltvLimit:      30,
+				FinalCLTVDelta: 8,
+				Amount:         100 * million,
+				FeeLimit:       1000,
+				MaxParts:       16,
+				PaymentAddr:    &paymentAddr,
+				DestFeatures: lnwire.NewFeatureVector(
+					lnwire.NewRawFeatureVector(
+						lnwire.MPPOptional,
+					), lnwire.Features,
+				),
+			}
+			require.NoError(t, payment.SetPaymentHash(
+				lntypes.Hash{},
+			))
+
+			graph := &splitGraph{}
+			for chanID := range testCase.balances {
+				graph.channels = append(
+					graph.channels,
+					&channeldb.DirectedChannel{
+						ChannelID: chanID,
+					},
+				)
+			}
+
+			session, err := newPaymentSession(
+				payment,
+				func(routingGraph) (bandwidthHints, error) {
+					return &mockBandwidthHints{
+						hints: testCase.balances,
+					}, nil
+				},
+				func() (routingGraph, func(), error) {
+					return graph, func() {}, nil
+				},
+				&MissionControl{},
+				PathFindingConfig{},
+				testCase.splitConfig,
+			)
+			require.NoError(t, err)
+
+			// Override pathfinder with a mock that only finds a
+			// path for amounts up to the maximum route amount.
+			var amts []lnwire.MilliSatoshi
+			session.pathFinder = func(_ *graphParams,
+				_ *RestrictParams, _ *PathFindingConfig, _,
+				_ route.Vertex, amt lnwire.MilliSatoshi,
+				_ float64, _ int32) (
+				[]*channeldb.CachedEdgePolicy, float64, error) {
+
+				amts = append(amts, amt)
+				if amt > testCase.maxRouteAmt {
+					return nil, 0, errNoPathFound
+				}
+
+				path := []*channeldb.CachedEdgePolicy{{
+					ToNodePubKey: func() route.Vertex {
+						return route.Vertex{}
+					},
+					ToNodeFeatures: payment.DestFeatures,
+				}}
+
+				return path, 1.0, nil
+			}
+
+			rt, err := session.RequestRoute(
+				payment.Amount, payment.FeeLimit, 0, height,
+			)
+			require.Equal(t, testCase.expectedAmts, amts)
+
+			if testCase.expectedErr != nil {
+				require.ErrorIs(t, err, testCase.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(
+				t, amts[len(amts)-1], rt.ReceiverAmt(),
+			)
+		})
+	}
+}
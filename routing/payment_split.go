@@ -0,0 +1,197 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/routing/route"
+)
+
+const (
+	// SplitStrategyHalving is the strategy that divides the amount of a
+	// shard by the split factor every time no route can be found for it.
+	SplitStrategyHalving = "halving"
+
+	// SplitStrategyEqual is the strategy that splits the amount into an
+	// increasing number of equally sized shards every time no route can
+	// be found for it.
+	SplitStrategyEqual = "equal"
+
+	// SplitStrategyAdaptive is the strategy that starts out with a shard
+	// that fits into the local channel with the highest available balance,
+	// and divides it by the split factor from there on.
+	SplitStrategyAdaptive = "adaptive"
+
+	// DefaultSplitStrategy is the default strategy used to split payments.
+	DefaultSplitStrategy = SplitStrategyHalving
+
+	// DefaultSplitFactor is the default factor by which the amount of a
+	// shard is divided when no route can be found for it.
+	DefaultSplitFactor = 2
+)
+
+// SplitConfig defines how a payment is split into multiple shards if no route
+// can be found for the full amount.
+type SplitConfig struct {
+	// Strategy is the splitting strategy to use.
+	Strategy string
+
+	// MinShardAmt is the amount beyond which we won't try to further
+	// split the payment if no route is found.
+	MinShardAmt lnwire.MilliSatoshi
+
+	// SplitFactor is the factor by which the amount of a shard is divided
+	// when no route can be found for it. A higher factor results in
+	// smaller shards after fewer path finding attempts. It is not used by
+	// the equal split strategy.
+	SplitFactor uint32
+}
+
+// DefaultSplitConfig returns the default config for splitting payments.
+func DefaultSplitConfig() SplitConfig {
+	return SplitConfig{
+		Strategy:    DefaultSplitStrategy,
+		MinShardAmt: DefaultShardMinAmt,
+		SplitFactor: DefaultSplitFactor,
+	}
+}
+
+// Validate checks that the split config is sane.
+func (c *SplitConfig) Validate() error {
+	switch c.Strategy {
+	case SplitStrategyHalving, SplitStrategyEqual, SplitStrategyAdaptive:
+
+	default:
+		return fmt.Errorf("unknown split strategy %v", c.Strategy)
+	}
+
+	if c.MinShardAmt == 0 {
+		return errors.New("minimum shard amount must be positive")
+	}
+
+	if c.SplitFactor < 2 {
+		return fmt.Errorf("split factor must be at least 2, got %v",
+			c.SplitFactor)
+	}
+
+	return nil
+}
+
+// String returns a string representation of the split config.
+func (c *SplitConfig) String() string {
+	return fmt.Sprintf("strategy=%v, min_shard_amt=%v, split_factor=%v",
+		c.Strategy, c.MinShardAmt, c.SplitFactor)
+}
+
+// nextShardAmt returns the amount to try next when no route can be found for
+// the current shard amount. The origAmt is the amount that path finding
+// started out with, and attempts is the number of amounts that were already
+// tried for it.
+func (c *SplitConfig) nextShardAmt(shardAmt, origAmt lnwire.MilliSatoshi,
+	attempts uint32) lnwire.MilliSatoshi {
+
+	switch c.Strategy {
+	// Split the original amount into one more equal part than before. The
+	// first attempt is made with the original amount, so that the number
+	// of parts is one more than the number of attempts.
+	case SplitStrategyEqual:
+		return origAmt / lnwire.MilliSatoshi(attempts+1)
+
+	default:
+		return shardAmt / lnwire.MilliSatoshi(c.SplitFactor)
+	}
+}
+
+// localBalances returns the highest and the total available balance of our
+// local channels, optionally restricted to the given outgoing channels and
+// excluding channels to blocked first hops.
+func localBalances(graph routingGraph, bandwidthHints bandwidthHints,
+	outgoingChans map[uint64]struct{},
+	blockedFirstHops map[route.Vertex]struct{}) (lnwire.MilliSatoshi,
+	lnwire.MilliSatoshi, error) {
+
+	var maxBalance, totalBalance lnwire.MilliSatoshi
+
+	cb := func(channel *channeldb.DirectedChannel) error {
+		if len(outgoingChans) > 0 {
+			if _, ok := outgoingChans[channel.ChannelID]; !ok {
+				return nil
+			}
+		}
+
+		if _, ok := blockedFirstHops[channel.OtherNode]; ok {
+			return nil
+		}
+
+		bandwidth, ok := bandwidthHints.availableChanBandwidth(
+			channel.ChannelID, 0,
+		)
+		if !ok {
+			return nil
+		}
+
+		if bandwidth > maxBalance {
+			maxBalance = bandwidth
+		}
+		totalBalance += bandwidth
+
+		return nil
+	}
+
+	err := graph.forEachNodeChannel(graph.sourceNode(), cb)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return maxBalance, totalBalance, nil
+}
+
+// adaptiveShardAmt returns the amount of the initial shard for the adaptive
+// split strategy. If the amount can't be carried by any single local channel,
+// it is reduced to the highest available balance of our local channels. The
+// amount is left unchanged if our total balance is insufficient, in which case
+// path finding will fail the payment.
+func (c *SplitConfig) adaptiveShardAmt(amt lnwire.MilliSatoshi,
+	graph routingGraph, bandwidthHints bandwidthHints,
+	outgoingChans map[uint64]struct{},
+	blockedFirstHops map[route.Vertex]struct{}) (lnwire.MilliSatoshi,
+	error) {
+
+	maxBalance, totalBalance, err := localBalances(
+		graph, bandwidthHints, outgoingChans, blockedFirstHops,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if maxBalance >= amt || totalBalance < amt ||
+		maxBalance < c.MinShardAmt {
+
+		return amt, nil
+	}
+
+	return maxBalance, nil
+}
+
+// outgoingChanSet returns the set of outgoing channels a payment is restricted
+// to.
+func outgoingChanSet(chanIDs []uint64) map[uint64]struct{} {
+	chans := make(map[uint64]struct{}, len(chanIDs))
+	for _, chanID := range chanIDs {
+		chans[chanID] = struct{}{}
+	}
+
+	return chans
+}
+
+// vertexSet returns the set of the given vertices.
+func vertexSet(vertices []route.Vertex) map[route.Vertex]struct{} {
+	set := make(map[route.Vertex]struct{}, len(vertices))
+	for _, vertex := range vertices {
+		set[vertex] = struct{}{}
+	}
+
+	return set
+}
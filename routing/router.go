@@ -2,10 +2,12 @@ package routing
 
 import (
 	"bytes"
+	crand "crypto/rand"
 	goErrors "errors"
 	"fmt"
 	"math"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -18,6 +20,7 @@ import (
 	"github.com/ltcsuite/lnd/batch"
 	"github.com/ltcsuite/lnd/chainntnfs"
 	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/channeldb/models"
 	"github.com/ltcsuite/lnd/clock"
 	"github.com/ltcsuite/lnd/htlcswitch"
 	"github.com/ltcsuite/lnd/input"
@@ -50,6 +53,10 @@ const (
 	// if a channel should be pruned or not.
 	DefaultChannelPruneExpiry = time.Duration(time.Hour * 24 * 14)
 
+	// DefaultGraphPruneInterval is the default interval at which the
+	// channel graph is garbage collected.
+	DefaultGraphPruneInterval = time.Hour
+
 	// DefaultFirstTimePruneDelay is the time we'll wait after startup
 	// before attempting to prune the graph for zombie channels. We don't
 	// do it immediately after startup to allow lnd to start up without
@@ -250,6 +257,12 @@ type FeeSchema struct {
 	// the effective fee rate charged per mSAT will be: (amount *
 	// FeeRate/1,000,000).
 	FeeRate uint32
+
+	// InboundFee is the fee that is charged on top of the outgoing fee
+	// for htlcs arriving over the channel. It is advertised in the
+	// extra data of the channel update. If nil, the current inbound fee
+	// is kept.
+	InboundFee *models.InboundFee
 }
 
 // ChannelPolicy holds the parameters that determine the policy we enforce
@@ -365,6 +378,25 @@ type Config struct {
 	// dated last update.
 	StrictZombiePruning bool
 
+	// MaxChannelsPerNode is the maximum number of channels that are kept
+	// in the graph for any single node other than our own. If a node has
+	// more channels, the ones that were updated least recently are pruned
+	// when garbage collecting the graph and are marked as zombies until
+	// the node is back under the limit. A value of zero disables the
+	// limit.
+	MaxChannelsPerNode uint32
+
+	// MaxConcurrentPayments is the maximum number of payments that are
+	// executed concurrently. Additional payments are queued until one of
+	// the executing payments completes. A value of zero disables the
+	// limit.
+	MaxConcurrentPayments uint32
+
+	// MaxQueuedPayments is the maximum number of payments that may wait
+	// in the payment queue. Payments beyond this limit are rejected. A
+	// value of zero disables the limit.
+	MaxQueuedPayments uint32
+
 	// IsAlias returns whether a passed ShortChannelID is an alias. This is
 	// only used for our local channels.
 	IsAlias func(scid lnwire.ShortChannelID) bool
@@ -452,6 +484,18 @@ type ChannelRouter struct {
 	// announcements over a window of defaultStatInterval.
 	stats *routerStats
 
+	// gcMtx serializes the garbage collection passes over the graph, which
+	// run periodically and can also be forced through
+	// GarbageCollectGraph.
+	gcMtx sync.Mutex
+
+	// gcStats tracks the garbage collection passes over the graph.
+	gcStats *graphGCStats
+
+	// paymentQueue limits the number of payments that are executed
+	// concurrently.
+	paymentQueue *paymentQueue
+
 	quit chan struct{}
 	wg   sync.WaitGroup
 }
@@ -484,7 +528,11 @@ func New(cfg Config) (*ChannelRouter, error) {
 		selfNode:          selfNode,
 		statTicker:        ticker.New(defaultStatInterval),
 		stats:             new(routerStats),
-		quit:              make(chan struct{}),
+		gcStats:           new(graphGCStats),
+		paymentQueue: newPaymentQueue(
+			cfg.MaxConcurrentPayments, cfg.MaxQueuedPayments,
+		),
+		quit: make(chan struct{}),
 	}
 
 	return r, nil
@@ -665,6 +713,14 @@ func (r *ChannelRouter) Start() error {
 			// result for the in-flight attempt is received.
 			paySession := r.cfg.SessionSource.NewPaymentSessionEmpty()
 
+			// The payment already has HTLCs in flight, so it can't
+			// be queued. It does count towards the limit of
+			// concurrent payments though.
+			queued := r.paymentQueue.acquireImmediate(
+				payment.Info.PaymentIdentifier,
+			)
+			defer r.paymentQueue.release(queued)
+
 			// We pass in a zero timeout value, to indicate we
 			// don't need it to timeout. It will stop immediately
 			// after the existing attempt has finished anyway. We
@@ -854,9 +910,15 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 // any "zombie" channels. We consider channels zombies if *both* edges haven't
 // been updated since our zombie horizon. If AssumeChannelValid is present,
 // we'll also consider channels zombies if *both* edges are disabled. This
-// usually signals that a channel has been closed on-chain. We do this
-// periodically to keep a healthy, lively routing table.
+// usually signals that a channel has been closed on-chain. If
+// MaxChannelsPerNode is set, the least recently updated channels of nodes that
+// exceed the limit are pruned as well. We do this periodically to keep a
+// healthy, lively routing table.
 func (r *ChannelRouter) pruneZombieChans() error {
+	r.gcMtx.Lock()
+	defer r.gcMtx.Unlock()
+
+	start := time.Now()
 	chansToPrune := make(map[uint64]struct{})
 	chanExpiry := r.cfg.ChannelPruneExpiry
 
@@ -966,23 +1028,70 @@ func (r *ChannelRouter) pruneZombieChans() error {
 		filterPruneChans(u.Info, u.Policy1, u.Policy2)
 	}
 
-	log.Infof("Pruning %v zombie channels", len(chansToPrune))
-	if len(chansToPrune) == 0 {
+	numZombies := len(chansToPrune)
+
+	// Next, we'll enforce the per-node retention limit on the channels
+	// that remain.
+	var (
+		excessChans map[uint64]struct{}
+		numChans    map[route.Vertex]int
+	)
+	if r.cfg.MaxChannelsPerNode > 0 {
+		excessChans, numChans, err = r.excessNodeChans(chansToPrune)
+		if err != nil {
+			return fmt.Errorf("unable to collect excess channels: "+
+				"%v", err)
+		}
+	}
+	numExcess := len(excessChans)
+
+	// The channels that were pruned before because one of their nodes
+	// exceeded the limit can be added back once both of their nodes are
+	// below it again.
+	if err := r.reviveExcessChans(numChans); err != nil {
+		return fmt.Errorf("unable to revive excess channels: %v", err)
+	}
+
+	log.Infof("Pruning %v zombie channels and %v excess channels",
+		numZombies, numExcess)
+	if numZombies == 0 && numExcess == 0 {
+		log.Debug(r.gcStats.record(start, 0, 0))
 		return nil
 	}
 
 	// With the set of zombie-like channels obtained, we'll do another pass
 	// to delete them from the channel graph.
-	toPrune := make([]uint64, 0, len(chansToPrune))
-	for chanID := range chansToPrune {
-		toPrune = append(toPrune, chanID)
-		log.Tracef("Pruning zombie channel with ChannelID(%v)", chanID)
+	if numZombies > 0 {
+		toPrune := make([]uint64, 0, numZombies)
+		for chanID := range chansToPrune {
+			toPrune = append(toPrune, chanID)
+			log.Tracef("Pruning zombie channel with ChannelID(%v)",
+				chanID)
+		}
+		err = r.cfg.Graph.DeleteChannelEdges(
+			r.cfg.StrictZombiePruning, true, toPrune...,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to delete zombie channels: "+
+				"%v", err)
+		}
 	}
-	err = r.cfg.Graph.DeleteChannelEdges(
-		r.cfg.StrictZombiePruning, true, toPrune...,
-	)
-	if err != nil {
-		return fmt.Errorf("unable to delete zombie channels: %v", err)
+
+	// The excess channels are still alive, so they are marked as zombies
+	// that are only revived once their nodes fall within the retention
+	// limit again. Otherwise gossip would add them right back.
+	if numExcess > 0 {
+		toPrune := make([]uint64, 0, numExcess)
+		for chanID := range excessChans {
+			toPrune = append(toPrune, chanID)
+			log.Tracef("Pruning excess channel with ChannelID(%v)",
+				chanID)
+		}
+		err = r.cfg.Graph.DeleteExcessChannelEdges(toPrune...)
+		if err != nil {
+			return fmt.Errorf("unable to delete excess channels: "+
+				"%v", err)
+		}
 	}
 
 	// With the channels pruned, we'll also attempt to prune any nodes that
@@ -992,9 +1101,195 @@ func (r *ChannelRouter) pruneZombieChans() error {
 		return fmt.Errorf("unable to prune graph nodes: %v", err)
 	}
 
+	log.Info(r.gcStats.record(start, numZombies, numExcess))
+
+	return nil
+}
+
+// nodeChannel describes a channel of a node in the graph, along with the time
+// of its most recent update.
+type nodeChannel struct {
+	chanID     uint64
+	lastUpdate time.Time
+}
+
+// excessNodeChans returns the channels that need to be pruned so that no node
+// other than our own has more than MaxChannelsPerNode channels in the graph.
+// Channels that are already marked to be pruned don't count towards the limit.
+// The number of channels every node retains afterwards is returned as well.
+func (r *ChannelRouter) excessNodeChans(
+	chansToPrune map[uint64]struct{}) (map[uint64]struct{},
+	map[route.Vertex]int, error) {
+
+	nodeChans := make(map[route.Vertex][]nodeChannel)
+	err := r.cfg.Graph.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		e1, e2 *channeldb.ChannelEdgePolicy) error {
+
+		if _, ok := chansToPrune[info.ChannelID]; ok {
+			return nil
+		}
+
+		// Our own channels are never pruned, so they also don't count
+		// towards the limit of our peers.
+		if info.NodeKey1Bytes == r.selfNode.PubKeyBytes ||
+			info.NodeKey2Bytes == r.selfNode.PubKeyBytes {
+
+			return nil
+		}
+
+		channel := nodeChannel{chanID: info.ChannelID}
+		for _, e := range []*channeldb.ChannelEdgePolicy{e1, e2} {
+			if e != nil && e.LastUpdate.After(channel.lastUpdate) {
+				channel.lastUpdate = e.LastUpdate
+			}
+		}
+
+		nodeChans[info.NodeKey1Bytes] = append(
+			nodeChans[info.NodeKey1Bytes], channel,
+		)
+		nodeChans[info.NodeKey2Bytes] = append(
+			nodeChans[info.NodeKey2Bytes], channel,
+		)
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	excess := selectExcessChans(nodeChans, int(r.cfg.MaxChannelsPerNode))
+
+	numChans := make(map[route.Vertex]int, len(nodeChans))
+	for node, channels := range nodeChans {
+		for _, channel := range channels {
+			if _, ok := excess[channel.chanID]; !ok {
+				numChans[node]++
+			}
+		}
+	}
+
+	return excess, numChans, nil
+}
+
+// reviveExcessChans marks the channels that were pruned because one of their
+// nodes exceeded MaxChannelsPerNode as live again once both of their nodes
+// retain fewer channels than the limit, so they can be added back to the graph
+// through gossip. The given number of channels per node is updated
+// accordingly. If the limit is disabled, all of them are revived.
+func (r *ChannelRouter) reviveExcessChans(
+	numChans map[route.Vertex]int) error {
+
+	excessChans, err := r.cfg.Graph.ExcessChans()
+	if err != nil {
+		return err
+	}
+
+	// Channels are revived in a deterministic order, as reviving one
+	// channel may bring its nodes back to the limit.
+	chanIDs := make([]uint64, 0, len(excessChans))
+	for chanID := range excessChans {
+		chanIDs = append(chanIDs, chanID)
+	}
+	sort.Slice(chanIDs, func(i, j int) bool {
+		return chanIDs[i] < chanIDs[j]
+	})
+
+	maxChans := int(r.cfg.MaxChannelsPerNode)
+	for _, chanID := range chanIDs {
+		node1 := route.Vertex(excessChans[chanID][0])
+		node2 := route.Vertex(excessChans[chanID][1])
+
+		if maxChans > 0 && (numChans[node1] >= maxChans ||
+			numChans[node2] >= maxChans) {
+
+			continue
+		}
+
+		log.Debugf("Reviving excess ChannelID(%v), its nodes are "+
+			"within the limit of %v channels again", chanID,
+			maxChans)
+
+		if err := r.cfg.Graph.MarkEdgeLive(chanID); err != nil {
+			return err
+		}
+
+		numChans[node1]++
+		numChans[node2]++
+	}
+
 	return nil
 }
 
+// selectExcessChans selects the channels to prune so that none of the given
+// nodes retains more than maxChans channels. The least recently updated
+// channels of a node are selected first. As pruning a channel also reduces the
+// channel count of its other node, nodes are processed in a deterministic
+// order and channels that were already selected are skipped.
+func selectExcessChans(nodeChans map[route.Vertex][]nodeChannel,
+	maxChans int) map[uint64]struct{} {
+
+	nodes := make([]route.Vertex, 0, len(nodeChans))
+	for node, channels := range nodeChans {
+		if len(channels) > maxChans {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return bytes.Compare(nodes[i][:], nodes[j][:]) < 0
+	})
+
+	excess := make(map[uint64]struct{})
+	for _, node := range nodes {
+		var remaining []nodeChannel
+		for _, channel := range nodeChans[node] {
+			if _, ok := excess[channel.chanID]; !ok {
+				remaining = append(remaining, channel)
+			}
+		}
+
+		if len(remaining) <= maxChans {
+			continue
+		}
+
+		sort.Slice(remaining, func(i, j int) bool {
+			if remaining[i].lastUpdate.Equal(remaining[j].lastUpdate) {
+				return remaining[i].chanID < remaining[j].chanID
+			}
+
+			return remaining[i].lastUpdate.Before(
+				remaining[j].lastUpdate,
+			)
+		})
+
+		for _, channel := range remaining[:len(remaining)-maxChans] {
+			log.Debugf("Node %v exceeds %v channels, collecting "+
+				"ChannelID(%v) to prune", node, maxChans,
+				channel.chanID)
+
+			excess[channel.chanID] = struct{}{}
+		}
+	}
+
+	return excess
+}
+
+// GarbageCollectGraph forces a garbage collection pass over the channel graph,
+// pruning zombie channels and the channels of nodes that exceed the maximum
+// number of channels per node. The updated stats are returned.
+func (r *ChannelRouter) GarbageCollectGraph() (GraphGCStats, error) {
+	if err := r.pruneZombieChans(); err != nil {
+		return GraphGCStats{}, err
+	}
+
+	return r.gcStats.snapshot(), nil
+}
+
+// GraphGCStats returns the stats of the garbage collection passes over the
+// channel graph since startup.
+func (r *ChannelRouter) GraphGCStats() GraphGCStats {
+	return r.gcStats.snapshot()
+}
+
 // handleNetworkUpdate is responsible for processing the update message and
 // notifies topology changes, if any.
 //
@@ -1869,16 +2164,22 @@ func (r *ChannelRouter) FindRoute(source, target route.Vertex,
 		return nil, 0, errors.New("time preference out of range")
 	}
 
-	path, probability, err := findPath(
-		&graphParams{
-			additionalEdges: routeHints,
-			bandwidthHints:  bandwidthHints,
-			graph:           r.cachedGraph,
-		},
-		restrictions,
-		&r.cfg.PathFindingConfig,
-		source, target, amt, timePref, finalHtlcExpiry,
+	var (
+		path        []*channeldb.CachedEdgePolicy
+		probability float64
 	)
+	withProfileLabels(&r.cfg.PathFindingConfig, func() {
+		path, probability, err = findPath(
+			&graphParams{
+				additionalEdges: routeHints,
+				bandwidthHints:  bandwidthHints,
+				graph:           r.cachedGraph,
+			},
+			restrictions,
+			&r.cfg.PathFindingConfig,
+			source, target, amt, timePref, finalHtlcExpiry,
+		)
+	}, "pathfinding", "query", "target", target.String())
 	if err != nil {
 		return nil, 0, err
 	}
@@ -2032,6 +2333,10 @@ type LightningPayment struct {
 	// first hop. If nil, any channel may be used.
 	OutgoingChannelIDs []uint64
 
+	// BlockedFirstHops is the list of peers that must not be used as the
+	// first hop. They may still be used further along the route.
+	BlockedFirstHops []route.Vertex
+
 	// LastHop is the pubkey of the last node before the final destination
 	// is reached. If nil, any node may be used.
 	LastHop *route.Vertex
@@ -2079,6 +2384,11 @@ type LightningPayment struct {
 	// Metadata is additional data that is sent along with the payment to
 	// the payee.
 	Metadata []byte
+
+	// IdempotencyKey is an optional key supplied by the caller. Only a
+	// single payment can be initiated with a given key, which allows
+	// callers to safely retry sending a payment.
+	IdempotencyKey []byte
 }
 
 // AMPOptions houses information that must be known in order to send an AMP
@@ -2131,6 +2441,19 @@ func (l *LightningPayment) Identifier() [32]byte {
 func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte,
 	*route.Route, error) {
 
+	// Wait until the payment queue admits the payment before registering
+	// it with the control tower. The payment timeout only starts once the
+	// payment is admitted.
+	queued, err := r.QueuePayment(payment.Identifier())
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	defer queued.Release()
+
+	if err := queued.Wait(nil); err != nil {
+		return [32]byte{}, nil, err
+	}
+
 	paySession, shardTracker, err := r.PreparePayment(payment)
 	if err != nil {
 		return [32]byte{}, nil, err
@@ -2148,15 +2471,20 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte,
 }
 
 // SendPaymentAsync is the non-blocking version of SendPayment. The payment
-// result needs to be retrieved via the control tower.
+// must have been admitted by the payment queue and prepared with
+// PreparePayment. The slot of the queued payment is released once the payment
+// has completed. The payment result needs to be retrieved via the control
+// tower.
 func (r *ChannelRouter) SendPaymentAsync(payment *LightningPayment,
-	ps PaymentSession, st shards.ShardTracker) error {
+	ps PaymentSession, st shards.ShardTracker,
+	queued *QueuedPayment) error {
 
 	// Since this is the first time this payment is being made, we pass nil
 	// for the existing attempt.
 	r.wg.Add(1)
 	go func() {
 		defer r.wg.Done()
+		defer queued.Release()
 
 		log.Tracef("Dispatching SendPayment for lightning payment: %v",
 			spewPayment(payment))
@@ -2174,6 +2502,21 @@ func (r *ChannelRouter) SendPaymentAsync(payment *LightningPayment,
 	return nil
 }
 
+// PaymentQueuePosition returns the one-based position of the payment with the
+// given identifier in the payment queue. False is returned if the payment
+// isn't waiting in the queue, either because it is already being executed or
+// because it is unknown.
+func (r *ChannelRouter) PaymentQueuePosition(identifier lntypes.Hash) (int,
+	bool) {
+
+	return r.paymentQueue.position(identifier)
+}
+
+// PaymentQueueStats returns the current state of the payment queue.
+func (r *ChannelRouter) PaymentQueueStats() PaymentQueueStats {
+	return r.paymentQueue.stats()
+}
+
 // spewPayment returns a log closures that provides a spewed string
 // representation of the passed payment.
 func spewPayment(payment *LightningPayment) logClosure {
@@ -2217,6 +2560,7 @@ func (r *ChannelRouter) PreparePayment(payment *LightningPayment) (
 		Value:             payment.Amount,
 		CreationTime:      r.cfg.Clock.Now(),
 		PaymentRequest:    payment.PaymentRequest,
+		IdempotencyKey:    payment.IdempotencyKey,
 	}
 
 	// Create a new ShardTracker that we'll use during the life cycle of
@@ -2262,6 +2606,69 @@ func (r *ChannelRouter) SendToRouteSkipTempErr(htlcHash lntypes.Hash,
 	return r.sendToRoute(htlcHash, rt, true)
 }
 
+// ProbeRoute sends a probe along the provided route to test whether it has
+// enough liquidity to carry the route amount. The probe uses a random payment
+// hash, so that it can't be settled by the receiver. The returned attempt
+// holds the failure that terminated the probe, and the boolean indicates
+// whether the probe reached the final hop. As with any other attempt, the
+// result is reported to mission control. Once the probe has failed, its
+// payment is deleted from the database, as it is of no use to anyone.
+func (r *ChannelRouter) ProbeRoute(rt *route.Route) (*channeldb.HTLCAttempt,
+	bool, error) {
+
+	if len(rt.Hops) == 0 {
+		return nil, false, errors.New("cannot probe empty route")
+	}
+
+	var probeHash lntypes.Hash
+	if _, err := crand.Read(probeHash[:]); err != nil {
+		return nil, false, err
+	}
+
+	attempt, err := r.sendToRoute(probeHash, rt, false)
+
+	// If the route turned out to be invalid, the probe payment has been
+	// failed without launching an attempt.
+	if err == route.ErrMaxRouteHopsExceeded ||
+		err == sphinx.ErrMaxRoutingInfoSizeExceeded {
+
+		delErr := r.cfg.Control.DeletePayment(probeHash)
+		if delErr != nil {
+			return nil, false, delErr
+		}
+	}
+	if attempt == nil {
+		return nil, false, err
+	}
+
+	// A probe is only ever expected to fail. If it didn't, we can't tell
+	// anything about the route.
+	if attempt.Failure == nil {
+		return attempt, false, fmt.Errorf("probe %v was not failed",
+			probeHash)
+	}
+
+	// The probe payment has been failed by sendToRoute, so it isn't in
+	// flight anymore and can be deleted.
+	if err := r.cfg.Control.DeletePayment(probeHash); err != nil {
+		return nil, false, err
+	}
+
+	// If the final hop rejected the unknown payment hash, the probe made
+	// it all the way through the route.
+	failure := attempt.Failure
+	_, incorrectDetails := failure.Message.(*lnwire.FailIncorrectDetails)
+	reachedFinalHop := failure.Reason == channeldb.HTLCFailMessage &&
+		int(failure.FailureSourceIndex) == len(rt.Hops) &&
+		incorrectDetails
+
+	log.Debugf("Probe %v along route %v completed: reached_final_hop=%v, "+
+		"failure_source_index=%v, failure=%v", probeHash, rt,
+		reachedFinalHop, failure.FailureSourceIndex, failure.Message)
+
+	return attempt, reachedFinalHop, nil
+}
+
 // sendToRoute attempts to send a payment with the given hash through the
 // provided route. This function is blocking and will return the attempt
 // information as it is stored in the database. For a successful htlc, this
@@ -2793,6 +3200,21 @@ func (r *ChannelRouter) IsStaleEdgePolicy(chanID lnwire.ShortChannelID,
 //
 // NOTE: This method is part of the ChannelGraphSource interface.
 func (r *ChannelRouter) MarkEdgeLive(chanID lnwire.ShortChannelID) error {
+	// A channel that was pruned because one of its nodes exceeds the
+	// per-node channel limit is only revived once its nodes are within
+	// the limit again, which is checked during garbage collection.
+	if r.cfg.MaxChannelsPerNode > 0 {
+		isExcess, err := r.cfg.Graph.IsExcessChan(chanID.ToUint64())
+		if err != nil {
+			return err
+		}
+		if isExcess {
+			return fmt.Errorf("channel %v exceeds the limit of %v "+
+				"channels per node", chanID,
+				r.cfg.MaxChannelsPerNode)
+		}
+	}
+
 	return r.cfg.Graph.MarkEdgeLive(chanID.ToUint64())
 }
 
@@ -2811,10 +3233,16 @@ func (e ErrNoChannel) Error() string {
 
 // BuildRoute returns a fully specified route based on a list of pubkeys. If
 // amount is nil, the minimum routable amount is used. To force a specific
-// outgoing channel, use the outgoingChan parameter.
+// outgoing channel, use the outgoingChan parameter. Hops that are connected
+// through private channels can be reached using the routeHints, and the
+// destCustomRecords are attached to the final hop. If destFeatures is nil, the
+// features of the destination are taken from the graph.
 func (r *ChannelRouter) BuildRoute(amt *lnwire.MilliSatoshi,
 	hops []route.Vertex, outgoingChan *uint64,
-	finalCltvDelta int32, payAddr *[32]byte) (*route.Route, error) {
+	finalCltvDelta int32, payAddr *[32]byte,
+	destCustomRecords record.CustomSet,
+	routeHints map[route.Vertex][]*channeldb.CachedEdgePolicy,
+	destFeatures *lnwire.FeatureVector) (*route.Route, error) {
 
 	log.Tracef("BuildRoute called: hopsCount=%v, amt=%v",
 		len(hops), amt)
@@ -2862,7 +3290,7 @@ func (r *ChannelRouter) BuildRoute(amt *lnwire.MilliSatoshi,
 	sourceNode := r.selfNode.PubKeyBytes
 	unifiers, senderAmt, err := getRouteUnifiers(
 		sourceNode, hops, useMinAmt, runningAmt, outgoingChans,
-		r.cachedGraph, bandwidthHints,
+		r.cachedGraph, bandwidthHints, routeHints,
 	)
 	if err != nil {
 		return nil, err
@@ -2875,6 +3303,33 @@ func (r *ChannelRouter) BuildRoute(amt *lnwire.MilliSatoshi,
 		return nil, err
 	}
 
+	// The forward pass only approximates inbound fees. If an exact amount
+	// was requested, make sure that the receiver gets exactly that.
+	if !useMinAmt {
+		receiverAmt = *amt
+	}
+
+	// The final edge may be a route hint, which doesn't carry the features
+	// of the destination. We therefore set the features explicitly, so
+	// that the final hop payload can be constructed accordingly.
+	if len(pathEdges) > 0 {
+		features := destFeatures
+		if features == nil {
+			features, err = r.cachedGraph.fetchNodeFeatures(
+				hops[len(hops)-1],
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Copy the final edge, as it may be shared with the graph
+		// cache.
+		finalEdge := *pathEdges[len(pathEdges)-1]
+		finalEdge.ToNodeFeatures = features
+		pathEdges[len(pathEdges)-1] = &finalEdge
+	}
+
 	// Build and return the final route.
 	return newRoute(
 		sourceNode, pathEdges, uint32(height),
@@ -2882,22 +3337,29 @@ func (r *ChannelRouter) BuildRoute(amt *lnwire.MilliSatoshi,
 			amt:         receiverAmt,
 			totalAmt:    receiverAmt,
 			cltvDelta:   uint16(finalCltvDelta),
-			records:     nil,
+			records:     destCustomRecords,
 			paymentAddr: payAddr,
 		},
 	)
 }
 
-// getRouteUnifiers returns a list of edge unifiers for the given route.
+// getRouteUnifiers returns a list of edge unifiers for the given route. The
+// additional edges, keyed by their source node, are considered along with the
+// channels known in the graph.
 func getRouteUnifiers(source route.Vertex, hops []route.Vertex,
 	useMinAmt bool, runningAmt lnwire.MilliSatoshi,
 	outgoingChans map[uint64]struct{}, graph routingGraph,
-	bandwidthHints *bandwidthManager) ([]*edgeUnifier, lnwire.MilliSatoshi,
-	error) {
+	bandwidthHints *bandwidthManager,
+	additionalEdges map[route.Vertex][]*channeldb.CachedEdgePolicy) (
+	[]*edgeUnifier, lnwire.MilliSatoshi, error) {
 
 	// Allocate a list that will contain the edge unifiers for this route.
 	unifiers := make([]*edgeUnifier, len(hops))
 
+	// nextOutFee is the outbound fee charged by the to node of the current
+	// hop. It caps negative inbound fees.
+	var nextOutFee lnwire.MilliSatoshi
+
 	// Traverse hops backwards to accumulate fees in the running amounts.
 	for i := len(hops) - 1; i >= 0; i-- {
 		toNode := hops[i]
@@ -2912,14 +3374,29 @@ func getRouteUnifiers(source route.Vertex, hops []route.Vertex,
 		localChan := i == 0
 
 		// Build unified edges for this hop based on the channels known
-		// in the graph.
-		u := newNodeEdgeUnifier(source, toNode, outgoingChans)
+		// in the graph. The final hop doesn't charge inbound fees.
+		u := newNodeEdgeUnifier(
+			source, toNode, i != len(hops)-1, outgoingChans, nil,
+		)
 
 		err := u.addGraphPolicies(graph)
 		if err != nil {
 			return nil, 0, err
 		}
 
+		// Add the hop hints that lead from the previous hop to this
+		// one. As in path finding, hints for our own channels are
+		// ignored, and a high capacity is assumed for them.
+		if fromNode != source {
+			for _, edge := range additionalEdges[fromNode] {
+				if edge.ToNodePubKey() != toNode {
+					continue
+				}
+
+				u.addPolicy(fromNode, edge, fakeHopHintCapacity)
+			}
+		}
+
 		// Exit if there are no channels.
 		edgeUnifier, ok := u.edgeUnifiers[fromNode]
 		if !ok {
@@ -2939,7 +3416,9 @@ func getRouteUnifiers(source route.Vertex, hops []route.Vertex,
 		}
 
 		// Get an edge for the specific amount that we want to forward.
-		edge := edgeUnifier.getEdge(runningAmt, bandwidthHints)
+		edge := edgeUnifier.getEdge(
+			runningAmt, bandwidthHints, nextOutFee,
+		)
 		if edge == nil {
 			log.Errorf("Cannot find policy with amt=%v for node %v",
 				runningAmt, fromNode)
@@ -2950,9 +3429,15 @@ func getRouteUnifiers(source route.Vertex, hops []route.Vertex,
 			}
 		}
 
+		// Add the inbound fee that the to node charges for the
+		// channel.
+		runningAmt = edge.amtWithInboundFee(runningAmt, nextOutFee)
+
 		// Add fee for this hop.
+		nextOutFee = 0
 		if !localChan {
-			runningAmt += edge.policy.ComputeFee(runningAmt)
+			nextOutFee = edge.policy.ComputeFee(runningAmt)
+			runningAmt += nextOutFee
 		}
 
 		log.Tracef("Select channel %v at position %v",
@@ -2975,9 +3460,12 @@ func getPathEdges(source route.Vertex, receiverAmt lnwire.MilliSatoshi,
 	// total amount, we make a forward pass. Because the amount may have
 	// been increased in the backward pass, fees need to be recalculated and
 	// amount ranges re-checked.
-	var pathEdges []*channeldb.CachedEdgePolicy
+	var (
+		pathEdges []*channeldb.CachedEdgePolicy
+		prevEdge  *unifiedEdge
+	)
 	for i, unifier := range unifiers {
-		edge := unifier.getEdge(receiverAmt, bandwidthHints)
+		edge := unifier.getEdge(receiverAmt, bandwidthHints, 0)
 		if edge == nil {
 			fromNode := source
 			if i > 0 {
@@ -2991,6 +3479,22 @@ func getPathEdges(source route.Vertex, receiverAmt lnwire.MilliSatoshi,
 		}
 
 		if i > 0 {
+			// Deduct the inbound fee that the previous hop charges
+			// for its incoming channel. The fee is calculated on
+			// the amount net of the inbound fee, so deducting it
+			// from the incoming amount is an approximation. The
+			// final amounts are recalculated when the route is
+			// built.
+			outFee := edge.policy.ComputeFeeFromIncoming(
+				receiverAmt,
+			)
+			inboundFee := prevEdge.inboundFeeFor(
+				receiverAmt, outFee,
+			)
+			receiverAmt = lnwire.MilliSatoshi(
+				int64(receiverAmt) - inboundFee,
+			)
+
 			// Decrease the amount to send while going forward.
 			receiverAmt -= edge.policy.ComputeFeeFromIncoming(
 				receiverAmt,
@@ -2998,6 +3502,7 @@ func getPathEdges(source route.Vertex, receiverAmt lnwire.MilliSatoshi,
 		}
 
 		pathEdges = append(pathEdges, edge.policy)
+		prevEdge = edge
 	}
 
 	return pathEdges, receiverAmt, nil
@@ -2296,6 +2296,60 @@ func TestPruneChannelGraphStaleEdges(t *testing.T) {
 	}
 }
 
+// TestPruneChannelGraphExcessChans tests that the channels exceeding the
+// per-node retention limit are pruned and kept as zombies until the node is
+// back under the limit.
+func TestPruneChannelGraphExcessChans(t *testing.T) {
+	t.Parallel()
+
+	// Node d has three channels, of which the one with the oldest update
+	// exceeds the limit of two channels per node.
+	testChannels := []*testChannel{
+		symmetricTestChannel("d", "b", 100000, &testChannelPolicy{
+			LastUpdate: time.Now().Add(-time.Hour),
+		}, 1),
+		symmetricTestChannel("d", "c", 100000, &testChannelPolicy{
+			LastUpdate: time.Now(),
+		}, 2),
+		symmetricTestChannel("d", "e", 100000, &testChannelPolicy{
+			LastUpdate: time.Now(),
+		}, 3),
+	}
+
+	testGraph, err := createTestGraphFromChannels(
+		t, true, testChannels, "a",
+	)
+	require.NoError(t, err, "unable to create test graph")
+
+	const startingHeight = 100
+	ctx := createTestCtxFromGraphInstance(
+		t, startingHeight, testGraph, false,
+	)
+	ctx.router.cfg.MaxChannelsPerNode = 2
+
+	require.NoError(t, ctx.router.pruneZombieChans())
+	assertChannelsPruned(t, ctx.graph, testChannels, 1)
+
+	// The excess channel is marked as a zombie that can't be revived by
+	// gossip while the node still exceeds the limit.
+	isZombie, _, _ := ctx.graph.IsZombieEdge(1)
+	require.True(t, isZombie)
+	require.Error(t, ctx.router.MarkEdgeLive(
+		lnwire.NewShortChanIDFromInt(1),
+	))
+
+	// Once one of the node's remaining channels is closed, the excess
+	// channel is revived by the next garbage collection.
+	require.NoError(t, ctx.graph.DeleteChannelEdges(false, false, 3))
+	require.NoError(t, ctx.router.pruneZombieChans())
+
+	isZombie, _, _ = ctx.graph.IsZombieEdge(1)
+	require.False(t, isZombie)
+	isExcess, err := ctx.graph.IsExcessChan(1)
+	require.NoError(t, err)
+	require.False(t, isExcess)
+}
+
 // TestPruneChannelGraphDoubleDisabled test that we can properly prune channels
 // with both edges disabled from our channel graph.
 func TestPruneChannelGraphDoubleDisabled(t *testing.T) {
@@ -2432,6 +2486,77 @@ func testPruneChannelGraphDoubleDisabled(t *testing.T, assumeValid bool) {
 	}
 }
 
+// TestSelectExcessChans tests that the least recently updated channels of
+// nodes that exceed the maximum number of channels per node are selected for
+// pruning, and that pruning a channel is accounted for at both of its nodes.
+func TestSelectExcessChans(t *testing.T) {
+	t.Parallel()
+
+	nodeA := route.Vertex{1}
+	nodeB := route.Vertex{2}
+	nodeC := route.Vertex{3}
+
+	chanAt := func(chanID uint64, updateTime int64) nodeChannel {
+		return nodeChannel{
+			chanID:     chanID,
+			lastUpdate: time.Unix(updateTime, 0),
+		}
+	}
+
+	// Node A has channels 1 and 3 with B and channels 2 and 4 with C. B
+	// and C share channel 5.
+	nodeChans := map[route.Vertex][]nodeChannel{
+		nodeA: {
+			chanAt(4, 4), chanAt(1, 1), chanAt(3, 3), chanAt(2, 2),
+		},
+		nodeB: {chanAt(1, 1), chanAt(3, 3), chanAt(5, 5)},
+		nodeC: {chanAt(2, 2), chanAt(4, 4), chanAt(5, 5)},
+	}
+
+	tests := []struct {
+		name     string
+		maxChans int
+		expected []uint64
+	}{
+		{
+			name:     "no excess",
+			maxChans: 4,
+			expected: []uint64{},
+		},
+		{
+			name:     "single node",
+			maxChans: 3,
+			expected: []uint64{1},
+		},
+		{
+			name:     "pruned at other node",
+			maxChans: 2,
+			expected: []uint64{1, 2},
+		},
+		{
+			name:     "multiple nodes",
+			maxChans: 1,
+			expected: []uint64{1, 2, 3, 4},
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			excess := selectExcessChans(nodeChans, testCase.maxChans)
+
+			expected := make(map[uint64]struct{})
+			for _, chanID := range testCase.expected {
+				expected[chanID] = struct{}{}
+			}
+			require.Equal(t, expected, excess)
+		})
+	}
+}
+
 // TestFindPathFeeWeighting tests that the findPath method will properly prefer
 // routes with lower fees over routes with lower time lock values. This is
 // meant to exercise the fact that the internal findPath method ranks edges
@@ -3135,7 +3260,7 @@ func TestBuildRoute(t *testing.T) {
 
 	// Build the route for the given amount.
 	rt, err := ctx.router.BuildRoute(
-		&amt, hops, nil, 40, &payAddr,
+		&amt, hops, nil, 40, &payAddr, nil, nil, nil,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -3151,7 +3276,7 @@ func TestBuildRoute(t *testing.T) {
 
 	// Build the route for the minimum amount.
 	rt, err = ctx.router.BuildRoute(
-		nil, hops, nil, 40, &payAddr,
+		nil, hops, nil, 40, &payAddr, nil, nil, nil,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -3172,7 +3297,7 @@ func TestBuildRoute(t *testing.T) {
 		ctx.aliases["e"], ctx.aliases["c"],
 	}
 	_, err = ctx.router.BuildRoute(
-		nil, hops, nil, 40, nil,
+		nil, hops, nil, 40, nil, nil, nil, nil,
 	)
 	errNoChannel, ok := err.(ErrNoChannel)
 	if !ok {
@@ -3185,6 +3310,68 @@ func TestBuildRoute(t *testing.T) {
 	if errNoChannel.fromNode != ctx.aliases["a"] {
 		t.Fatalf("unexpected no channel error node")
 	}
+
+	// Extend the route to a private node d that is only reachable through
+	// a hop hint from c.
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	target := route.NewVertex(privKey.PubKey())
+
+	hintNode, err := btcec.ParsePubKey(ctx.aliases["c"][:])
+	require.NoError(t, err)
+
+	hopHint := zpay32.HopHint{
+		NodeID:          hintNode,
+		ChannelID:       99,
+		FeeBaseMSat:     1000,
+		CLTVExpiryDelta: 40,
+	}
+	routeHints, err := RouteHintsToEdges(
+		[][]zpay32.HopHint{{hopHint}}, target,
+	)
+	require.NoError(t, err)
+
+	hops = []route.Vertex{
+		ctx.aliases["b"], ctx.aliases["c"], target,
+	}
+
+	// Without the hints, there is no channel to reach d.
+	_, err = ctx.router.BuildRoute(
+		&amt, hops, nil, 40, &payAddr, nil, nil, nil,
+	)
+	require.ErrorAs(t, err, &errNoChannel)
+	require.Equal(t, 2, errNoChannel.position)
+
+	// The features of d can't be looked up in the graph, so custom records
+	// can't be attached without specifying them explicitly.
+	customRecords := record.CustomSet{
+		record.CustomTypeStart: []byte{1, 2, 3},
+	}
+	_, err = ctx.router.BuildRoute(
+		&amt, hops, nil, 40, nil, customRecords, routeHints, nil,
+	)
+	require.Error(t, err)
+
+	destFeatures := lnwire.NewFeatureVector(
+		lnwire.NewRawFeatureVector(
+			lnwire.TLVOnionPayloadOptional,
+			lnwire.PaymentAddrOptional,
+		), lnwire.Features,
+	)
+	rt, err = ctx.router.BuildRoute(
+		&amt, hops, nil, 40, &payAddr, customRecords, routeHints,
+		destFeatures,
+	)
+	require.NoError(t, err)
+
+	// The hint charges 1 sat to forward 100 sats to d, and the connection
+	// b->c charges 6060 msat to forward the resulting 101 sats.
+	checkHops(rt, []uint64{1, 7, 99}, payAddr)
+	require.EqualValues(t, 107060, rt.TotalAmount)
+
+	lastHop := rt.Hops[len(rt.Hops)-1]
+	require.Equal(t, customRecords, lastHop.CustomRecords)
+	require.False(t, lastHop.LegacyPayload)
 }
 
 // TestGetPathEdges tests that the getPathEdges function returns the expected
@@ -3655,6 +3842,9 @@ func TestSendMPPaymentSucceedOnExtraShards(t *testing.T) {
 		mock.Anything, mock.Anything, mock.Anything, mock.Anything,
 	).Return(shard, nil)
 
+	// The temporary channel failures are reported to the payment session.
+	session.On("ReportChannelFailure", mock.Anything, mock.Anything).Return()
+
 	// Make a new htlc attempt with zero fee and append it to the payment's
 	// HTLCs when calling RegisterAttempt.
 	activeAttempt := makeActiveAttempt(int(paymentAmt/4), 0)
@@ -3869,6 +4059,9 @@ func TestSendMPPaymentFailed(t *testing.T) {
 		mock.Anything, mock.Anything, mock.Anything, mock.Anything,
 	).Return(shard, nil)
 
+	// The temporary channel failures are reported to the payment session.
+	session.On("ReportChannelFailure", mock.Anything, mock.Anything).Return()
+
 	// Make a new htlc attempt with zero fee and append it to the payment's
 	// HTLCs when calling RegisterAttempt.
 	activeAttempt := makeActiveAttempt(int(paymentAmt/4), 0)
@@ -4075,6 +4268,9 @@ func TestSendMPPaymentFailedWithShardsInFlight(t *testing.T) {
 		mock.Anything, mock.Anything, mock.Anything, mock.Anything,
 	).Return(shard, nil)
 
+	// The temporary channel failures are reported to the payment session.
+	session.On("ReportChannelFailure", mock.Anything, mock.Anything).Return()
+
 	// Make a new htlc attempt with zero fee and append it to the payment's
 	// HTLCs when calling RegisterAttempt.
 	activeAttempt := makeActiveAttempt(int(paymentAmt/4), 0)
@@ -4601,3 +4797,131 @@ func TestSendToRouteTempFailure(t *testing.T) {
 	payer.AssertExpectations(t)
 	missionControl.AssertExpectations(t)
 }
+
+// TestProbeRoute tests that a probe is sent along the route and that its
+// failure is interpreted correctly.
+func TestProbeRoute(t *testing.T) {
+	t.Parallel()
+
+	payAmt := lnwire.MilliSatoshi(10000)
+
+	node, err := createTestNode()
+	require.NoError(t, err)
+
+	// Create a simple 1-hop route.
+	hops := []*route.Hop{
+		{
+			ChannelID:    1,
+			PubKeyBytes:  node.PubKeyBytes,
+			AmtToForward: payAmt,
+		},
+	}
+	rt, err := route.NewRouteFromHops(payAmt, 100, node.PubKeyBytes, hops)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		failure         lnwire.FailureMessage
+		failureIndex    int
+		reachedFinalHop bool
+	}{
+		{
+			name:            "reached final hop",
+			failure:         &lnwire.FailIncorrectDetails{},
+			failureIndex:    1,
+			reachedFinalHop: true,
+		},
+		{
+			name:         "insufficient liquidity",
+			failure:      &lnwire.FailTemporaryChannelFailure{},
+			failureIndex: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			controlTower := &mockControlTower{}
+			payer := &mockPaymentAttemptDispatcher{}
+			missionControl := &mockMissionControl{}
+			testClock := clock.NewTestClock(time.Unix(1, 0))
+
+			router := &ChannelRouter{cfg: &Config{
+				Control:        controlTower,
+				Payer:          payer,
+				MissionControl: missionControl,
+				Clock:          testClock,
+				NextPaymentID: func() (uint64, error) {
+					return 0, nil
+				},
+			}}
+
+			failedAttempt := &channeldb.HTLCAttempt{
+				Failure: &channeldb.HTLCFailInfo{
+					Message: testCase.failure,
+					Reason:  channeldb.HTLCFailMessage,
+					FailureSourceIndex: uint32(
+						testCase.failureIndex,
+					),
+				},
+			}
+
+			// The probe uses a random payment hash, so we can't
+			// match on it.
+			controlTower.On(
+				"InitPayment", mock.Anything, mock.Anything,
+			).Return(nil)
+			controlTower.On(
+				"RegisterAttempt", mock.Anything, mock.Anything,
+			).Return(nil)
+			controlTower.On("FailAttempt",
+				mock.Anything, mock.Anything, mock.Anything,
+			).Return(failedAttempt, nil)
+			controlTower.On(
+				"FailPayment", mock.Anything, mock.Anything,
+			).Return(nil)
+
+			// Once failed, the probe payment must be deleted.
+			controlTower.On(
+				"DeletePayment", mock.Anything,
+			).Return(nil)
+
+			payer.On("SendHTLC",
+				mock.Anything, mock.Anything, mock.Anything,
+			).Return(nil)
+
+			payer.resultChan = make(
+				chan *htlcswitch.PaymentResult, 1,
+			)
+			payer.On("GetAttemptResult",
+				mock.Anything, mock.Anything, mock.Anything,
+			).Run(func(_ mock.Arguments) {
+				payer.resultChan <- &htlcswitch.PaymentResult{
+					Error: htlcswitch.NewForwardingError(
+						testCase.failure,
+						testCase.failureIndex,
+					),
+				}
+			})
+
+			// The result of the probe must be reported to mission
+			// control.
+			failureReason := channeldb.FailureReasonPaymentDetails
+			missionControl.On("ReportPaymentFail",
+				mock.Anything, rt, mock.Anything, mock.Anything,
+			).Return(&failureReason, nil)
+
+			attempt, reached, err := router.ProbeRoute(rt)
+			require.NoError(t, err)
+			require.Equal(t, failedAttempt, attempt)
+			require.Equal(t, testCase.reachedFinalHop, reached)
+
+			controlTower.AssertExpectations(t)
+			payer.AssertExpectations(t)
+			missionControl.AssertExpectations(t)
+		})
+	}
+}
@@ -67,3 +67,78 @@ func (g *routerStats) String() string {
 	g.mu.RUnlock()
 	return str
 }
+
+// GraphGCStats summarizes the garbage collection passes over the channel
+// graph, which prune zombie channels and the channels of nodes that exceed the
+// maximum number of channels per node.
+type GraphGCStats struct {
+	// LastRun is the time the last pass was started.
+	LastRun time.Time
+
+	// LastDuration is the time it took to complete the last pass.
+	LastDuration time.Duration
+
+	// LastZombieChans is the number of zombie channels pruned by the last
+	// pass.
+	LastZombieChans uint64
+
+	// LastExcessChans is the number of channels pruned by the last pass
+	// because one of their nodes exceeded the maximum number of channels
+	// per node.
+	LastExcessChans uint64
+
+	// NumRuns is the number of passes since startup.
+	NumRuns uint64
+
+	// TotalZombieChans is the number of zombie channels pruned since
+	// startup.
+	TotalZombieChans uint64
+
+	// TotalExcessChans is the number of excess channels pruned since
+	// startup.
+	TotalExcessChans uint64
+}
+
+// String returns a human-readable description of the last garbage collection
+// pass.
+func (g GraphGCStats) String() string {
+	return fmt.Sprintf("Graph garbage collection pruned zombie_chans=%d "+
+		"excess_chans=%d in %v (runs=%d, total_zombie_chans=%d, "+
+		"total_excess_chans=%d)", g.LastZombieChans, g.LastExcessChans,
+		g.LastDuration, g.NumRuns, g.TotalZombieChans,
+		g.TotalExcessChans)
+}
+
+// graphGCStats tracks the garbage collection passes over the channel graph.
+type graphGCStats struct {
+	stats GraphGCStats
+
+	mu sync.RWMutex
+}
+
+// record adds the result of a garbage collection pass that was started at the
+// given time to the stats, and returns the updated stats.
+func (g *graphGCStats) record(start time.Time, numZombies,
+	numExcess int) GraphGCStats {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.stats.LastRun = start
+	g.stats.LastDuration = time.Since(start)
+	g.stats.LastZombieChans = uint64(numZombies)
+	g.stats.LastExcessChans = uint64(numExcess)
+	g.stats.NumRuns++
+	g.stats.TotalZombieChans += uint64(numZombies)
+	g.stats.TotalExcessChans += uint64(numExcess)
+
+	return g.stats
+}
+
+// snapshot returns a copy of the current stats.
+func (g *graphGCStats) snapshot() GraphGCStats {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.stats
+}
@@ -0,0 +1,252 @@
+package routing
+
+import (
+	"github.com/ltcsuite/lnd/channeldb/models"
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/routing/route"
+	"github.com/ltcsuite/ltcd/btcec/v2"
+)
+
+// maxFilteredChannels is the maximum number of channels a topology filter
+// tracks the last passed policies of. Without a node filter, the policies of
+// the entire graph would otherwise be held for every subscription.
+const maxFilteredChannels = 20000
+
+// filteredPolicy holds the fields of a passed policy update that are compared
+// against subsequent updates of the same channel direction.
+type filteredPolicy struct {
+	minHTLC       lnwire.MilliSatoshi
+	maxHTLC       lnwire.MilliSatoshi
+	baseFee       lnwire.MilliSatoshi
+	feeRate       lnwire.MilliSatoshi
+	inboundFee    models.InboundFee
+	timeLockDelta uint16
+	disabled      bool
+}
+
+// newFilteredPolicy returns the fields of the policy update that are compared
+// by the filter.
+func newFilteredPolicy(update *ChannelEdgeUpdate) filteredPolicy {
+	return filteredPolicy{
+		minHTLC:       update.MinHTLC,
+		maxHTLC:       update.MaxHTLC,
+		baseFee:       update.BaseFee,
+		feeRate:       update.FeeRate,
+		inboundFee:    update.InboundFee,
+		timeLockDelta: update.TimeLockDelta,
+		disabled:      update.Disabled,
+	}
+}
+
+// TopologyFilter filters the topology changes sent to a topology client, so
+// that clients that only track a few nodes aren't sent every update of the
+// channel graph. The filter keeps state across topology changes and must
+// therefore only be used for a single client.
+type TopologyFilter struct {
+	// nodes restricts the updates to these nodes and their channels. If
+	// empty, updates for all nodes are passed.
+	nodes map[route.Vertex]struct{}
+
+	// minBaseFeeDelta and minFeeRateDelta are the minimum changes of the
+	// fees for a policy update that only changes fees to be passed. If
+	// both are zero, all policy updates are passed.
+	minBaseFeeDelta lnwire.MilliSatoshi
+	minFeeRateDelta lnwire.MilliSatoshi
+
+	// channels holds the channels of the filtered nodes. It is used to
+	// pass the closes of these channels, as closed channel summaries
+	// don't carry the nodes involved.
+	channels map[uint64]struct{}
+
+	// policies holds the last passed policy per channel and advertising
+	// node. It is only tracked if fee deltas are set, and holds at most
+	// maxFilteredChannels channels.
+	policies map[uint64]map[route.Vertex]filteredPolicy
+}
+
+// NewTopologyFilter creates a new topology filter. Only updates concerning
+// the given nodes and their channels are passed, unless no nodes are given.
+// Policy updates that only change fees are dropped if neither the base fee
+// changed by at least minBaseFeeDelta nor the fee rate by at least
+// minFeeRateDelta since the last passed update for the channel direction. The
+// same deltas apply to the inbound base fee and fee rate.
+func NewTopologyFilter(nodes []route.Vertex, minBaseFeeDelta,
+	minFeeRateDelta lnwire.MilliSatoshi) *TopologyFilter {
+
+	nodeSet := make(map[route.Vertex]struct{}, len(nodes))
+	for _, node := range nodes {
+		nodeSet[node] = struct{}{}
+	}
+
+	return &TopologyFilter{
+		nodes:           nodeSet,
+		minBaseFeeDelta: minBaseFeeDelta,
+		minFeeRateDelta: minFeeRateDelta,
+		channels:        make(map[uint64]struct{}),
+		policies: make(
+			map[uint64]map[route.Vertex]filteredPolicy,
+		),
+	}
+}
+
+// AddChannel adds a channel of one of the filtered nodes that is already
+// known, so that its close will be passed.
+func (f *TopologyFilter) AddChannel(chanID uint64) {
+	f.channels[chanID] = struct{}{}
+}
+
+// Filter returns the part of the topology change that passes the filter. Nil
+// is returned if nothing passes.
+func (f *TopologyFilter) Filter(change *TopologyChange) *TopologyChange {
+	filtered := &TopologyChange{}
+
+	for _, nodeUpdate := range change.NodeUpdates {
+		if !f.matchesNode(nodeUpdate.IdentityKey) {
+			continue
+		}
+
+		filtered.NodeUpdates = append(
+			filtered.NodeUpdates, nodeUpdate,
+		)
+	}
+
+	for _, edgeUpdate := range change.ChannelEdgeUpdates {
+		if !f.matchesNode(edgeUpdate.AdvertisingNode) &&
+			!f.matchesNode(edgeUpdate.ConnectingNode) {
+
+			continue
+		}
+
+		if len(f.nodes) > 0 {
+			f.channels[edgeUpdate.ChanID] = struct{}{}
+		}
+
+		if !f.significantUpdate(edgeUpdate) {
+			continue
+		}
+
+		filtered.ChannelEdgeUpdates = append(
+			filtered.ChannelEdgeUpdates, edgeUpdate,
+		)
+	}
+
+	for _, closedChan := range change.ClosedChannels {
+		delete(f.policies, closedChan.ChanID)
+
+		if len(f.nodes) > 0 {
+			if _, ok := f.channels[closedChan.ChanID]; !ok {
+				continue
+			}
+			delete(f.channels, closedChan.ChanID)
+		}
+
+		filtered.ClosedChannels = append(
+			filtered.ClosedChannels, closedChan,
+		)
+	}
+
+	if filtered.isEmpty() {
+		return nil
+	}
+
+	return filtered
+}
+
+// matchesNode returns true if updates concerning the given node pass the
+// filter.
+func (f *TopologyFilter) matchesNode(pubKey *btcec.PublicKey) bool {
+	if len(f.nodes) == 0 {
+		return true
+	}
+
+	_, ok := f.nodes[route.NewVertex(pubKey)]
+
+	return ok
+}
+
+// significantUpdate returns true if the policy update differs enough from the
+// last passed update for the same channel direction. If it does, the update is
+// recorded as the last passed one.
+func (f *TopologyFilter) significantUpdate(update *ChannelEdgeUpdate) bool {
+	// Without fee deltas, every policy update is passed.
+	if f.minBaseFeeDelta == 0 && f.minFeeRateDelta == 0 {
+		return true
+	}
+
+	advertisingNode := route.NewVertex(update.AdvertisingNode)
+	policy := newFilteredPolicy(update)
+
+	chanPolicies, ok := f.policies[update.ChanID]
+	if !ok {
+		f.evictPolicies()
+
+		chanPolicies = make(map[route.Vertex]filteredPolicy)
+		f.policies[update.ChanID] = chanPolicies
+	}
+
+	// Pass the update if there's nothing to compare it to, or if anything
+	// other than the fees changed.
+	prev, ok := chanPolicies[advertisingNode]
+	if !ok || prev.timeLockDelta != policy.timeLockDelta ||
+		prev.minHTLC != policy.minHTLC ||
+		prev.maxHTLC != policy.maxHTLC ||
+		prev.disabled != policy.disabled {
+
+		chanPolicies[advertisingNode] = policy
+		return true
+	}
+
+	// Otherwise only pass the update if one of the fees changed by at
+	// least the configured delta.
+	baseFeeChanged := significantDelta(
+		int64(prev.baseFee), int64(policy.baseFee),
+		f.minBaseFeeDelta,
+	)
+	feeRateChanged := significantDelta(
+		int64(prev.feeRate), int64(policy.feeRate),
+		f.minFeeRateDelta,
+	)
+	inboundBaseFeeChanged := significantDelta(
+		int64(prev.inboundFee.Base), int64(policy.inboundFee.Base),
+		f.minBaseFeeDelta,
+	)
+	inboundFeeRateChanged := significantDelta(
+		int64(prev.inboundFee.Rate), int64(policy.inboundFee.Rate),
+		f.minFeeRateDelta,
+	)
+	if !baseFeeChanged && !feeRateChanged && !inboundBaseFeeChanged &&
+		!inboundFeeRateChanged {
+
+		return false
+	}
+
+	chanPolicies[advertisingNode] = policy
+
+	return true
+}
+
+// evictPolicies makes room for the policies of another channel if the filter
+// already tracks maxFilteredChannels channels, by evicting an arbitrary one.
+// The next update of an evicted channel is passed regardless of the fee
+// deltas.
+func (f *TopologyFilter) evictPolicies() {
+	if len(f.policies) < maxFilteredChannels {
+		return
+	}
+
+	for chanID := range f.policies {
+		delete(f.policies, chanID)
+		return
+	}
+}
+
+// significantDelta returns true if the value changed by at least the given
+// minimum delta. A minimum delta of zero passes any change.
+func significantDelta(prev, cur int64, minDelta lnwire.MilliSatoshi) bool {
+	delta := cur - prev
+	if delta < 0 {
+		delta = -delta
+	}
+
+	return delta != 0 && uint64(delta) >= uint64(minDelta)
+}
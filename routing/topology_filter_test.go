@@ -0,0 +1,206 @@
+package routing
+
+import (
+	"testing"
+
+	"github.com/ltcsuite/lnd/channeldb/models"
+	"github.com/ltcsuite/lnd/lnwire"
+	"github.com/ltcsuite/lnd/routing/route"
+	"github.com/ltcsuite/ltcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTopologyFilterNodes tests that the topology filter only passes updates
+// concerning the filtered nodes and their channels.
+func TestTopologyFilterNodes(t *testing.T) {
+	t.Parallel()
+
+	keys := make([]*btcec.PublicKey, 3)
+	for i := range keys {
+		priv, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		keys[i] = priv.PubKey()
+	}
+	alice, bob, carol := keys[0], keys[1], keys[2]
+
+	filter := NewTopologyFilter(
+		[]route.Vertex{route.NewVertex(alice)}, 0, 0,
+	)
+
+	// A channel of alice that is already known when subscribing.
+	filter.AddChannel(1)
+
+	aliceBob := &ChannelEdgeUpdate{
+		ChanID:          2,
+		AdvertisingNode: bob,
+		ConnectingNode:  alice,
+	}
+	bobCarol := &ChannelEdgeUpdate{
+		ChanID:          3,
+		AdvertisingNode: bob,
+		ConnectingNode:  carol,
+	}
+
+	// Only the updates concerning alice should pass.
+	filtered := filter.Filter(&TopologyChange{
+		NodeUpdates: []*NetworkNodeUpdate{
+			{IdentityKey: alice},
+			{IdentityKey: bob},
+		},
+		ChannelEdgeUpdates: []*ChannelEdgeUpdate{aliceBob, bobCarol},
+	})
+	require.NotNil(t, filtered)
+	require.Len(t, filtered.NodeUpdates, 1)
+	require.Equal(t, alice, filtered.NodeUpdates[0].IdentityKey)
+	require.Equal(
+		t, []*ChannelEdgeUpdate{aliceBob}, filtered.ChannelEdgeUpdates,
+	)
+
+	// Closes of the known channel and the channel learned from the update
+	// should pass, the close of the channel between bob and carol
+	// shouldn't.
+	filtered = filter.Filter(&TopologyChange{
+		ClosedChannels: []*ClosedChanSummary{
+			{ChanID: 1}, {ChanID: 2}, {ChanID: 3},
+		},
+	})
+	require.NotNil(t, filtered)
+	require.Equal(t, []*ClosedChanSummary{
+		{ChanID: 1}, {ChanID: 2},
+	}, filtered.ClosedChannels)
+
+	// A change without any updates for alice is dropped entirely.
+	filtered = filter.Filter(&TopologyChange{
+		NodeUpdates:        []*NetworkNodeUpdate{{IdentityKey: carol}},
+		ChannelEdgeUpdates: []*ChannelEdgeUpdate{bobCarol},
+	})
+	require.Nil(t, filtered)
+
+	// Without any nodes, everything is passed.
+	change := &TopologyChange{
+		NodeUpdates:        []*NetworkNodeUpdate{{IdentityKey: carol}},
+		ChannelEdgeUpdates: []*ChannelEdgeUpdate{bobCarol},
+		ClosedChannels:     []*ClosedChanSummary{{ChanID: 3}},
+	}
+	require.Equal(t, change, NewTopologyFilter(nil, 0, 0).Filter(change))
+}
+
+// TestTopologyFilterFeeDelta tests that policy updates that only change fees
+// by less than the configured deltas are dropped.
+func TestTopologyFilterFeeDelta(t *testing.T) {
+	t.Parallel()
+
+	priv, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	node := priv.PubKey()
+
+	filter := NewTopologyFilter(nil, 100, 10)
+
+	update := func(baseFee, feeRate lnwire.MilliSatoshi,
+		disabled bool, inboundFee models.InboundFee) *ChannelEdgeUpdate {
+
+		return &ChannelEdgeUpdate{
+			ChanID:          1,
+			BaseFee:         baseFee,
+			FeeRate:         feeRate,
+			AdvertisingNode: node,
+			ConnectingNode:  node,
+			Disabled:        disabled,
+			InboundFee:      inboundFee,
+		}
+	}
+
+	noInbound := models.InboundFee{}
+
+	testCases := []struct {
+		name   string
+		update *ChannelEdgeUpdate
+		pass   bool
+	}{
+		{
+			name:   "first update",
+			update: update(1000, 100, false, noInbound),
+			pass:   true,
+		},
+		{
+			name:   "small fee change",
+			update: update(1050, 105, false, noInbound),
+			pass:   false,
+		},
+		{
+			name:   "base fee change",
+			update: update(1100, 100, false, noInbound),
+			pass:   true,
+		},
+		{
+			name:   "fee rate change",
+			update: update(1100, 90, false, noInbound),
+			pass:   true,
+		},
+		{
+			name:   "unchanged",
+			update: update(1100, 90, false, noInbound),
+			pass:   false,
+		},
+		{
+			name:   "disabled",
+			update: update(1100, 90, true, noInbound),
+			pass:   true,
+		},
+		{
+			name: "small inbound fee change",
+			update: update(1100, 90, true, models.InboundFee{
+				Base: -50, Rate: -5,
+			}),
+			pass: false,
+		},
+		{
+			name: "inbound base fee change",
+			update: update(1100, 90, true, models.InboundFee{
+				Base: -100, Rate: -5,
+			}),
+			pass: true,
+		},
+		{
+			name: "inbound fee rate change",
+			update: update(1100, 90, true, models.InboundFee{
+				Base: -100, Rate: 5,
+			}),
+			pass: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		filtered := filter.Filter(&TopologyChange{
+			ChannelEdgeUpdates: []*ChannelEdgeUpdate{
+				testCase.update,
+			},
+		})
+		require.Equal(t, testCase.pass, filtered != nil, testCase.name)
+	}
+}
+
+// TestTopologyFilterMaxChannels tests that the topology filter doesn't track
+// the policies of more than maxFilteredChannels channels.
+func TestTopologyFilterMaxChannels(t *testing.T) {
+	t.Parallel()
+
+	priv, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	node := priv.PubKey()
+
+	filter := NewTopologyFilter(nil, 100, 10)
+
+	for chanID := uint64(0); chanID <= maxFilteredChannels; chanID++ {
+		filtered := filter.Filter(&TopologyChange{
+			ChannelEdgeUpdates: []*ChannelEdgeUpdate{{
+				ChanID:          chanID,
+				AdvertisingNode: node,
+				ConnectingNode:  node,
+			}},
+		})
+		require.NotNil(t, filtered)
+	}
+
+	require.Len(t, filter.policies, maxFilteredChannels)
+}
@@ -2,6 +2,7 @@ package routing
 
 import (
 	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/channeldb/models"
 	"github.com/ltcsuite/lnd/lntypes"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/routing/route"
@@ -20,21 +21,34 @@ type nodeEdgeUnifier struct {
 	// toNode is the node for which the edge unifiers are instantiated.
 	toNode route.Vertex
 
+	// useInboundFees indicates whether the inbound fees of toNode are
+	// taken into account. The final hop of a route doesn't charge inbound
+	// fees.
+	useInboundFees bool
+
 	// outChanRestr is an optional outgoing channel restriction for the
 	// local channel to use.
 	outChanRestr map[uint64]struct{}
+
+	// chanFailures is an optional map of channels that returned a
+	// temporary failure, keyed by channel id. The value is the smallest
+	// amount that failed.
+	chanFailures map[uint64]lnwire.MilliSatoshi
 }
 
 // newNodeEdgeUnifier instantiates a new nodeEdgeUnifier object. Channel
 // policies can be added to this object.
-func newNodeEdgeUnifier(sourceNode, toNode route.Vertex,
-	outChanRestr map[uint64]struct{}) *nodeEdgeUnifier {
+func newNodeEdgeUnifier(sourceNode, toNode route.Vertex, useInboundFees bool,
+	outChanRestr map[uint64]struct{},
+	chanFailures map[uint64]lnwire.MilliSatoshi) *nodeEdgeUnifier {
 
 	return &nodeEdgeUnifier{
-		edgeUnifiers: make(map[route.Vertex]*edgeUnifier),
-		toNode:       toNode,
-		sourceNode:   sourceNode,
-		outChanRestr: outChanRestr,
+		edgeUnifiers:   make(map[route.Vertex]*edgeUnifier),
+		toNode:         toNode,
+		useInboundFees: useInboundFees,
+		sourceNode:     sourceNode,
+		outChanRestr:   outChanRestr,
+		chanFailures:   chanFailures,
 	}
 }
 
@@ -61,9 +75,18 @@ func (u *nodeEdgeUnifier) addPolicy(fromNode route.Vertex,
 		u.edgeUnifiers[fromNode] = unifier
 	}
 
+	// The inbound fee of the channel is charged by the to node, unless it
+	// is the final hop.
+	var inboundFee models.InboundFee
+	if u.useInboundFees {
+		inboundFee = edge.InboundFee
+	}
+
 	unifier.edges = append(unifier.edges, &unifiedEdge{
-		policy:   edge,
-		capacity: capacity,
+		policy:     edge,
+		capacity:   capacity,
+		inboundFee: inboundFee,
+		failedAmt:  u.chanFailures[edge.ChannelID],
 	})
 }
 
@@ -95,6 +118,41 @@ func (u *nodeEdgeUnifier) addGraphPolicies(g routingGraph) error {
 type unifiedEdge struct {
 	policy   *channeldb.CachedEdgePolicy
 	capacity ltcutil.Amount
+
+	// inboundFee is the inbound fee that the to node charges for htlcs
+	// arriving over this channel.
+	inboundFee models.InboundFee
+
+	// failedAmt is the smallest amount for which the channel returned a
+	// temporary failure during the current payment. Zero if the channel
+	// didn't fail.
+	failedAmt lnwire.MilliSatoshi
+}
+
+// inboundFeeFor returns the inbound fee that the to node charges for an htlc
+// arriving over the channel. The fee is calculated on the amount that the node
+// receives without the inbound fee, which is the amount it forwards plus its
+// outbound fee. As the total fee of a node can't be negative, the inbound fee
+// never exceeds the outbound fee as a discount.
+func (u *unifiedEdge) inboundFeeFor(netAmtReceived,
+	outboundFee lnwire.MilliSatoshi) int64 {
+
+	inboundFee := u.inboundFee.CalcFee(netAmtReceived)
+	if inboundFee < -int64(outboundFee) {
+		return -int64(outboundFee)
+	}
+
+	return inboundFee
+}
+
+// amtWithInboundFee returns the amount that needs to be sent over the channel
+// for the to node to receive the given amount net of its inbound fee.
+func (u *unifiedEdge) amtWithInboundFee(netAmtReceived,
+	outboundFee lnwire.MilliSatoshi) lnwire.MilliSatoshi {
+
+	inboundFee := u.inboundFeeFor(netAmtReceived, outboundFee)
+
+	return lnwire.MilliSatoshi(int64(netAmtReceived) + inboundFee)
 }
 
 // amtInRange checks whether an amount falls within the valid range for a
@@ -126,6 +184,14 @@ func (u *unifiedEdge) amtInRange(amt lnwire.MilliSatoshi) bool {
 		return false
 	}
 
+	// Skip channels that already failed to carry this amount or less. A
+	// parallel channel to the same peer may still be able to forward it.
+	if u.failedAmt != 0 && amt >= u.failedAmt {
+		log.Tracef("Channel %v failed before: amt=%v, failedAmt=%v",
+			u.policy.ChannelID, amt, u.failedAmt)
+		return false
+	}
+
 	return true
 }
 
@@ -137,21 +203,25 @@ type edgeUnifier struct {
 
 // getEdge returns the optimal unified edge to use for this connection given a
 // specific amount to send. It differentiates between local and network
-// channels.
-func (u *edgeUnifier) getEdge(amt lnwire.MilliSatoshi,
-	bandwidthHints bandwidthHints) *unifiedEdge {
+// channels. The amount is the amount that the to node needs to receive net of
+// its inbound fee, which differs per channel. The outbound fee that the to
+// node charges is needed to cap negative inbound fees.
+func (u *edgeUnifier) getEdge(netAmtReceived lnwire.MilliSatoshi,
+	bandwidthHints bandwidthHints,
+	nextOutFee lnwire.MilliSatoshi) *unifiedEdge {
 
 	if u.localChan {
-		return u.getEdgeLocal(amt, bandwidthHints)
+		return u.getEdgeLocal(netAmtReceived, bandwidthHints, nextOutFee)
 	}
 
-	return u.getEdgeNetwork(amt)
+	return u.getEdgeNetwork(netAmtReceived, nextOutFee)
 }
 
 // getEdgeLocal returns the optimal unified edge to use for this local
 // connection given a specific amount to send.
-func (u *edgeUnifier) getEdgeLocal(amt lnwire.MilliSatoshi,
-	bandwidthHints bandwidthHints) *unifiedEdge {
+func (u *edgeUnifier) getEdgeLocal(netAmtReceived lnwire.MilliSatoshi,
+	bandwidthHints bandwidthHints,
+	nextOutFee lnwire.MilliSatoshi) *unifiedEdge {
 
 	var (
 		bestEdge     *unifiedEdge
@@ -159,6 +229,9 @@ func (u *edgeUnifier) getEdgeLocal(amt lnwire.MilliSatoshi,
 	)
 
 	for _, edge := range u.edges {
+		// Add the inbound fee that the peer charges for this channel.
+		amt := edge.amtWithInboundFee(netAmtReceived, nextOutFee)
+
 		// Check valid amount range for the channel.
 		if !edge.amtInRange(amt) {
 			log.Debugf("Amount %v not in range for edge %v",
@@ -219,8 +292,9 @@ func (u *edgeUnifier) getEdgeLocal(amt lnwire.MilliSatoshi,
 
 		// Update best edge.
 		bestEdge = &unifiedEdge{
-			policy:   edge.policy,
-			capacity: edge.capacity,
+			policy:     edge.policy,
+			capacity:   edge.capacity,
+			inboundFee: edge.inboundFee,
 		}
 	}
 
@@ -231,15 +305,23 @@ func (u *edgeUnifier) getEdgeLocal(amt lnwire.MilliSatoshi,
 // given a specific amount to send. The goal is to return a unified edge with a
 // policy that maximizes the probability of a successful forward in a non-strict
 // forwarding context.
-func (u *edgeUnifier) getEdgeNetwork(amt lnwire.MilliSatoshi) *unifiedEdge {
+func (u *edgeUnifier) getEdgeNetwork(netAmtReceived,
+	nextOutFee lnwire.MilliSatoshi) *unifiedEdge {
+
 	var (
-		bestPolicy  *channeldb.CachedEdgePolicy
-		maxFee      lnwire.MilliSatoshi
-		maxTimelock uint16
-		maxCapMsat  lnwire.MilliSatoshi
+		bestPolicy     *channeldb.CachedEdgePolicy
+		bestInboundFee models.InboundFee
+		maxFee         int64
+		maxTimelock    uint16
+		maxCapMsat     lnwire.MilliSatoshi
 	)
 
 	for _, edge := range u.edges {
+		// Add the inbound fee that the to node charges for this
+		// channel.
+		inboundFee := edge.inboundFeeFor(netAmtReceived, nextOutFee)
+		amt := lnwire.MilliSatoshi(int64(netAmtReceived) + inboundFee)
+
 		// Check valid amount range for the channel.
 		if !edge.amtInRange(amt) {
 			log.Debugf("Amount %v not in range for edge %v",
@@ -275,9 +357,10 @@ func (u *edgeUnifier) getEdgeNetwork(amt lnwire.MilliSatoshi) *unifiedEdge {
 		)
 
 		// Use the policy that results in the highest fee for this
-		// specific amount.
-		fee := edge.policy.ComputeFee(amt)
-		if fee < maxFee {
+		// specific amount. This includes the inbound fee that the to
+		// node charges for the channel.
+		fee := int64(edge.policy.ComputeFee(amt)) + inboundFee
+		if bestPolicy != nil && fee < maxFee {
 			log.Debugf("Skipped edge %v due to it produces less "+
 				"fee: fee=%v, maxFee=%v",
 				edge.policy.ChannelID, fee, maxFee)
@@ -286,6 +369,7 @@ func (u *edgeUnifier) getEdgeNetwork(amt lnwire.MilliSatoshi) *unifiedEdge {
 		maxFee = fee
 
 		bestPolicy = edge.policy
+		bestInboundFee = edge.inboundFee
 	}
 
 	// Return early if no channel matches.
@@ -304,7 +388,10 @@ func (u *edgeUnifier) getEdgeNetwork(amt lnwire.MilliSatoshi) *unifiedEdge {
 	// chance for this node pair. But this is all only needed for nodes that
 	// have distinct policies for channels to the same peer.
 	policyCopy := *bestPolicy
-	modifiedEdge := unifiedEdge{policy: &policyCopy}
+	modifiedEdge := unifiedEdge{
+		policy:     &policyCopy,
+		inboundFee: bestInboundFee,
+	}
 	modifiedEdge.policy.TimeLockDelta = maxTimelock
 	modifiedEdge.capacity = maxCapMsat.ToSatoshis()
 
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/channeldb/models"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/routing/route"
 	"github.com/ltcsuite/ltcd/ltcutil"
@@ -22,6 +23,7 @@ func TestNodeEdgeUnifier(t *testing.T) {
 
 	// Add two channels between the pair of nodes.
 	p1 := channeldb.CachedEdgePolicy{
+		ChannelID:                 1,
 		FeeProportionalMillionths: 100000,
 		FeeBaseMSat:               30,
 		TimeLockDelta:             60,
@@ -30,6 +32,7 @@ func TestNodeEdgeUnifier(t *testing.T) {
 		MinHTLC:                   100,
 	}
 	p2 := channeldb.CachedEdgePolicy{
+		ChannelID:                 2,
 		FeeProportionalMillionths: 190000,
 		FeeBaseMSat:               10,
 		TimeLockDelta:             40,
@@ -40,17 +43,40 @@ func TestNodeEdgeUnifier(t *testing.T) {
 	c1 := ltcutil.Amount(7)
 	c2 := ltcutil.Amount(8)
 
-	unifierFilled := newNodeEdgeUnifier(source, toNode, nil)
+	unifierFilled := newNodeEdgeUnifier(source, toNode, false, nil, nil)
 	unifierFilled.addPolicy(fromNode, &p1, c1)
 	unifierFilled.addPolicy(fromNode, &p2, c2)
 
-	unifierNoCapacity := newNodeEdgeUnifier(source, toNode, nil)
+	unifierNoCapacity := newNodeEdgeUnifier(source, toNode, false, nil, nil)
 	unifierNoCapacity.addPolicy(fromNode, &p1, 0)
 	unifierNoCapacity.addPolicy(fromNode, &p2, 0)
 
-	unifierNoInfo := newNodeEdgeUnifier(source, toNode, nil)
+	unifierNoInfo := newNodeEdgeUnifier(source, toNode, false, nil, nil)
 	unifierNoInfo.addPolicy(fromNode, &channeldb.CachedEdgePolicy{}, 0)
 
+	// The second channel returned a temporary failure for 300 msat.
+	unifierFailed := newNodeEdgeUnifier(
+		source, toNode, false, nil, map[uint64]lnwire.MilliSatoshi{
+			p2.ChannelID: 300,
+		},
+	)
+	unifierFailed.addPolicy(fromNode, &p1, c1)
+	unifierFailed.addPolicy(fromNode, &p2, c2)
+
+	// The to node charges an inbound fee for the second channel.
+	inboundFee := models.InboundFee{Base: 10}
+	p2Inbound := p2
+	p2Inbound.InboundFee = inboundFee
+
+	unifierInbound := newNodeEdgeUnifier(source, toNode, true, nil, nil)
+	unifierInbound.addPolicy(fromNode, &p1, c1)
+	unifierInbound.addPolicy(fromNode, &p2Inbound, c2)
+
+	// If the to node is the final hop, its inbound fees are ignored.
+	unifierFinalHop := newNodeEdgeUnifier(source, toNode, false, nil, nil)
+	unifierFinalHop.addPolicy(fromNode, &p1, c1)
+	unifierFinalHop.addPolicy(fromNode, &p2Inbound, c2)
+
 	tests := []struct {
 		name             string
 		unifier          *nodeEdgeUnifier
@@ -60,6 +86,8 @@ func TestNodeEdgeUnifier(t *testing.T) {
 		expectedTimeLock uint16
 		expectNoPolicy   bool
 		expectedCapacity ltcutil.Amount
+
+		expectedInboundFee models.InboundFee
 	}{
 		{
 			name:           "amount below min htlc",
@@ -114,6 +142,50 @@ func TestNodeEdgeUnifier(t *testing.T) {
 			unifier:          unifierNoInfo,
 			expectedCapacity: 0,
 		},
+		// Below the failed amount, the failed channel is still
+		// considered.
+		{
+			name:             "below failed amount",
+			unifier:          unifierFailed,
+			amount:           200,
+			expectedFeeBase:  p1.FeeBaseMSat,
+			expectedFeeRate:  p1.FeeProportionalMillionths,
+			expectedTimeLock: p1.TimeLockDelta,
+			expectedCapacity: c2,
+		},
+		// For 400 msat, p2 failed before. Only p1 remains, including
+		// its capacity.
+		{
+			name:             "skip failed channel",
+			unifier:          unifierFailed,
+			amount:           400,
+			expectedFeeBase:  p1.FeeBaseMSat,
+			expectedFeeRate:  p1.FeeProportionalMillionths,
+			expectedTimeLock: p1.TimeLockDelta,
+			expectedCapacity: c1,
+		},
+		// For 200 msat, p1 yields the highest outbound fee. But
+		// together with the inbound fee that the to node charges for
+		// the second channel, p2 yields the highest fee.
+		{
+			name:               "use p2 with inbound fee",
+			unifier:            unifierInbound,
+			amount:             200,
+			expectedFeeBase:    p2.FeeBaseMSat,
+			expectedFeeRate:    p2.FeeProportionalMillionths,
+			expectedTimeLock:   p1.TimeLockDelta,
+			expectedCapacity:   c2,
+			expectedInboundFee: inboundFee,
+		},
+		{
+			name:             "ignore inbound fee of final hop",
+			unifier:          unifierFinalHop,
+			amount:           200,
+			expectedFeeBase:  p1.FeeBaseMSat,
+			expectedFeeRate:  p1.FeeProportionalMillionths,
+			expectedTimeLock: p1.TimeLockDelta,
+			expectedCapacity: c2,
+		},
 	}
 
 	for _, test := range tests {
@@ -123,7 +195,7 @@ func TestNodeEdgeUnifier(t *testing.T) {
 			t.Parallel()
 
 			edge := test.unifier.edgeUnifiers[fromNode].getEdge(
-				test.amount, bandwidthHints,
+				test.amount, bandwidthHints, 0,
 			)
 
 			if test.expectNoPolicy {
@@ -141,6 +213,8 @@ func TestNodeEdgeUnifier(t *testing.T) {
 				policy.TimeLockDelta, "timelock")
 			require.Equal(t, test.expectedCapacity, edge.capacity,
 				"capacity")
+			require.Equal(t, test.expectedInboundFee,
+				edge.inboundFee, "inbound fee")
 		})
 	}
 }
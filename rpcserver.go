@@ -1,6 +1,7 @@
 package lnd
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/hex"
@@ -27,6 +28,7 @@ import (
 	"github.com/ltcsuite/lnd/chanbackup"
 	"github.com/ltcsuite/lnd/chanfitness"
 	"github.com/ltcsuite/lnd/channeldb"
+	"github.com/ltcsuite/lnd/channeldb/models"
 	"github.com/ltcsuite/lnd/channelnotifier"
 	"github.com/ltcsuite/lnd/contractcourt"
 	"github.com/ltcsuite/lnd/discovery"
@@ -353,10 +355,18 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/ImportPreimage": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/ListChannels": {{
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/DustExposure": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/SubscribeChannelEvents": {{
 			Entity: "offchain",
 			Action: "read",
@@ -429,6 +439,10 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "info",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/GarbageCollectGraph": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/StopDaemon": {{
 			Entity: "info",
 			Action: "write",
@@ -489,6 +503,15 @@ func MainRPCServerPermissions() map[string][]bakery.Op {
 			Entity: "offchain",
 			Action: "read",
 		}},
+		// The channel database contains our revocation secrets, so
+		// streaming it requires the same permissions as signing.
+		"/lnrpc.Lightning/BackupDatabase": {{
+			Entity: "offchain",
+			Action: "write",
+		}, {
+			Entity: "signer",
+			Action: "generate",
+		}},
 		"/lnrpc.Lightning/ChannelAcceptor": {{
 			Entity: "onchain",
 			Action: "write",
@@ -708,6 +731,8 @@ func (r *rpcServer) addDeps(s *server, macService *macaroons.Service,
 		DefaultFinalCltvDelta:  uint16(r.cfg.Litecoin.TimeLockDelta),
 		SubscribeHtlcEvents:    s.htlcNotifier.SubscribeHtlcEvents,
 		InterceptableForwarder: s.interceptableSwitch,
+		SubscribeResolutionEvents: s.chainArb.
+			SubscribeResolutionEvents,
 		SetChannelEnabled: func(outpoint wire.OutPoint) error {
 			return s.chanStatusMgr.RequestEnable(outpoint, true)
 		},
@@ -715,6 +740,11 @@ func (r *rpcServer) addDeps(s *server, macService *macaroons.Service,
 			return s.chanStatusMgr.RequestDisable(outpoint, true)
 		},
 		SetChannelAuto: s.chanStatusMgr.RequestAuto,
+		DefaultMaxParts: r.cfg.SubRPCServers.RouterRPC.MppConfig.
+			MaxParts,
+		PaymentQueuePosition: s.chanRouter.PaymentQueuePosition,
+		PendingHtlcs:         s.interceptableSwitch.PendingHtlcs,
+		LookupPendingHtlc:    s.interceptableSwitch.LookupPendingHtlc,
 	}
 
 	genInvoiceFeatures := func() *lnwire.FeatureVector {
@@ -746,7 +776,7 @@ func (r *rpcServer) addDeps(s *server, macService *macaroons.Service,
 		r.cfg.net.ResolveTCPAddr, genInvoiceFeatures,
 		genAmpInvoiceFeatures, s.getNodeAnnouncement,
 		s.updateAndBrodcastSelfNode, parseAddr, rpcsLog,
-		s.aliasMgr.GetPeerAlias,
+		s.aliasMgr.GetPeerAlias, s.chanEventStore.GetChanInfo,
 	)
 	if err != nil {
 		return err
@@ -1197,10 +1227,10 @@ func (r *rpcServer) EstimateFee(ctx context.Context,
 
 	resp := &lnrpc.EstimateFeeResponse{
 		FeeSat:      totalFee,
-		SatPerVbyte: uint64(feePerKw.FeePerKVByte() / 1000),
+		SatPerVbyte: uint64(feePerKw.FeePerVByte()),
 
 		// Deprecated field.
-		FeerateSatPerByte: int64(feePerKw.FeePerKVByte() / 1000),
+		FeerateSatPerByte: int64(feePerKw.FeePerVByte()),
 	}
 
 	rpcsLog.Debugf("[estimatefee] fee estimate for conf target %d: %v",
@@ -2489,6 +2519,11 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 		return fmt.Errorf("force closing a channel uses a pre-defined fee")
 	}
 
+	// Only the closing transaction of a cooperative close can be replaced.
+	if in.Force && in.BumpFee {
+		return fmt.Errorf("cannot bump the fee of a force close")
+	}
+
 	force := in.Force
 	index := in.ChannelPoint.OutputIndex
 	txid, err := lnrpc.GetChanPointFundingTxid(in.GetChannelPoint())
@@ -2587,6 +2622,31 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 					Success:     true,
 				}
 			})
+	} else if in.BumpFee {
+		// The closing transaction of a cooperative close with RBF
+		// based close negotiation is replaced with one that pays the
+		// given fee rate, which needs the peer to sign it.
+		chanPeer, err := r.server.FindPeer(channel.IdentityPub)
+		if err != nil {
+			return fmt.Errorf("unable to bump close fee while peer "+
+				"is offline: %w", err)
+		}
+
+		feeRate, err := lnrpc.CalculateFeeRate(
+			uint64(in.SatPerByte), in.SatPerVbyte, // nolint:staticcheck
+			uint32(in.TargetConf), r.server.cc.FeeEstimator,
+		)
+		if err != nil {
+			return err
+		}
+
+		rpcsLog.Debugf("Bumping closing transaction of "+
+			"ChannelPoint(%v) to %v sat/kw", chanPoint,
+			int64(feeRate))
+
+		updateChan, errChan = chanPeer.BumpCoopCloseFee(
+			chanPoint, feeRate,
+		)
 	} else {
 		// If this is a frozen channel, then we only allow the co-op
 		// close to proceed if we were the responder to this channel if
@@ -2667,8 +2727,8 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 			}
 		}
 
-		maxFee := chainfee.SatPerKVByte(
-			in.MaxFeePerVbyte * 1000,
+		maxFee := chainfee.SatPerVByte(
+			in.MaxFeePerVbyte,
 		).FeePerKWeight()
 		updateChan, errChan = r.server.htlcSwitch.CloseLink(
 			chanPoint, contractcourt.CloseRegular, feeRate,
@@ -2795,15 +2855,6 @@ func (r *rpcServer) abandonChan(chanPoint *wire.OutPoint,
 		return err
 	}
 
-	// If this channel was in the process of being closed, but didn't fully
-	// close, then it's possible that the nursery is hanging on to some
-	// state. To err on the side of caution, we'll now attempt to wipe any
-	// state for this channel from the nursery.
-	err = r.server.utxoNursery.RemoveChannel(chanPoint)
-	if err != nil && err != contractcourt.ErrContractNotFound {
-		return err
-	}
-
 	// Finally, notify the backup listeners that the channel can be removed
 	// from any channel backups.
 	r.server.channelNotifier.NotifyClosedChannelEvent(*chanPoint)
@@ -3245,7 +3296,10 @@ func (r *rpcServer) WalletBalance(ctx context.Context,
 		return nil, err
 	}
 
-	var totalBalance, confirmedBalance, unconfirmedBalance ltcutil.Amount
+	var (
+		totalBalance, confirmedBalance  ltcutil.Amount
+		unconfirmedBalance, mwebBalance ltcutil.Amount
+	)
 	rpcAccountBalances := make(
 		map[string]*lnrpc.WalletAccountBalance, len(accounts),
 	)
@@ -3304,6 +3358,16 @@ func (r *rpcServer) WalletBalance(ctx context.Context,
 		unconfirmedBal := totalBal - confirmedBal
 		unconfirmedBalance += unconfirmedBal
 
+		// Get the part of the confirmed balance that was pegged out of
+		// the MWEB.
+		mwebBal, err := r.server.cc.Wallet.MwebBalance(
+			1, account.AccountName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		mwebBalance += mwebBal
+
 		rpcAccountBalances[account.AccountName] = &lnrpc.WalletAccountBalance{
 			ConfirmedBalance:   int64(confirmedBal),
 			UnconfirmedBalance: int64(unconfirmedBal),
@@ -3343,8 +3407,8 @@ func (r *rpcServer) WalletBalance(ctx context.Context,
 	)
 
 	rpcsLog.Debugf("[walletbalance] Total balance=%v (confirmed=%v, "+
-		"unconfirmed=%v)", totalBalance, confirmedBalance,
-		unconfirmedBalance)
+		"unconfirmed=%v, mweb=%v)", totalBalance, confirmedBalance,
+		unconfirmedBalance, mwebBalance)
 
 	return &lnrpc.WalletBalanceResponse{
 		TotalBalance:              int64(totalBalance),
@@ -3353,6 +3417,7 @@ func (r *rpcServer) WalletBalance(ctx context.Context,
 		LockedBalance:             int64(lockedBalance),
 		ReservedBalanceAnchorChan: int64(requiredReserve),
 		AccountBalance:            rpcAccountBalances,
+		MwebBalance:               int64(mwebBalance),
 	}, nil
 }
 
@@ -3619,7 +3684,7 @@ func (r *rpcServer) fetchPendingForceCloseChannels() (pendingForceClose,
 				pendingClose.ChanPoint)
 
 		// If the channel was force closed, then we'll need to query
-		// the utxoNursery for additional information.
+		// the channel arbitrator for additional information.
 		// TODO(halseth): distinguish remote and local case?
 		case channeldb.LocalForceClose, channeldb.RemoteForceClose:
 			forceClose := &lnrpc.PendingChannelsResponse_ForceClosedChannel{
@@ -3627,22 +3692,7 @@ func (r *rpcServer) fetchPendingForceCloseChannels() (pendingForceClose,
 				ClosingTxid: closeTXID,
 			}
 
-			// Fetch reports from both nursery and resolvers. At the
-			// moment this is not an atomic snapshot. This is
-			// planned to be resolved when the nursery is removed
-			// and channel arbitrator will be the single source for
-			// these kind of reports.
-			err := r.nurseryPopulateForceCloseResp(
-				&chanPoint, currentHeight, forceClose,
-			)
-			if err != nil {
-				rpcsLog.Errorf("unable to populate nursery "+
-					"force close resp:%s, %v",
-					chanPoint, err)
-				return nil, 0, err
-			}
-
-			err = r.arbitratorPopulateForceCloseResp(
+			err := r.arbitratorPopulateForceCloseResp(
 				&chanPoint, currentHeight, forceClose,
 			)
 			if err != nil {
@@ -3858,6 +3908,46 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 	return resp, nil
 }
 
+// ImportPreimage adds a preimage that was obtained out-of-band to the preimage
+// cache, so the resolvers of pending incoming HTLCs with the matching payment
+// hash can claim them on-chain.
+func (r *rpcServer) ImportPreimage(_ context.Context,
+	in *lnrpc.ImportPreimageRequest) (*lnrpc.ImportPreimageResponse,
+	error) {
+
+	preimage, err := lntypes.MakePreimage(in.Preimage)
+	if err != nil {
+		return nil, fmt.Errorf("invalid preimage: %w", err)
+	}
+
+	// Only accept the preimage if it can actually be used to claim an
+	// HTLC, so we don't fill the preimage cache with unrelated data.
+	hash := preimage.Hash()
+	chanPoints := r.server.chainArb.FindIncomingContests(hash)
+	if len(chanPoints) == 0 {
+		return nil, fmt.Errorf("no pending incoming htlc found for "+
+			"payment hash %v", hash)
+	}
+
+	// Adding the preimage to the beacon notifies the incoming contest
+	// resolvers, which will then go on to claim their HTLCs.
+	if err := r.server.witnessBeacon.AddPreimages(preimage); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[importpreimage] imported preimage for payment "+
+		"hash %v, claiming htlcs of channels %v", hash, chanPoints)
+
+	resp := &lnrpc.ImportPreimageResponse{}
+	for _, chanPoint := range chanPoints {
+		resp.ChannelPoints = append(
+			resp.ChannelPoints, chanPoint.String(),
+		)
+	}
+
+	return resp, nil
+}
+
 // arbitratorPopulateForceCloseResp populates the pending channels response
 // message with channel resolution information from the contract resolvers.
 func (r *rpcServer) arbitratorPopulateForceCloseResp(chanPoint *wire.OutPoint,
@@ -3938,57 +4028,99 @@ func (r *rpcServer) arbitratorPopulateForceCloseResp(chanPoint *wire.OutPoint,
 		forceClose.RecoveredBalance += int64(report.RecoveredBalance)
 	}
 
+	// Finally, list the individual resolvers together with the sweep
+	// transactions that were broadcast for their outputs.
+	pendingSweeps, err := r.server.sweeper.PendingInputs()
+	if err != nil {
+		return err
+	}
+
+	for _, state := range arbitrator.ResolverStates() {
+		forceClose.Resolvers = append(
+			forceClose.Resolvers,
+			rpcPendingResolver(state, pendingSweeps),
+		)
+	}
+
 	return nil
 }
 
-// nurseryPopulateForceCloseResp populates the pending channels response
-// message with contract resolution information from utxonursery.
-func (r *rpcServer) nurseryPopulateForceCloseResp(chanPoint *wire.OutPoint,
-	currentHeight int32,
-	forceClose *lnrpc.PendingChannelsResponse_ForceClosedChannel) error {
+// rpcPendingResolver converts the state of a contract resolver into its RPC
+// representation. The broadcast txids are looked up in the given set of
+// inputs that are pending in the sweeper.
+func rpcPendingResolver(state *contractcourt.ResolverState,
+	sweeps map[wire.OutPoint]*sweep.PendingInput) *lnrpc.PendingResolver {
 
-	// Query for the maturity state for this force closed channel. If we
-	// didn't have any time-locked outputs, then the nursery may not know of
-	// the contract.
-	nurseryInfo, err := r.server.utxoNursery.NurseryReport(chanPoint)
-	if err == contractcourt.ErrContractNotFound {
-		return nil
-	}
-	if err != nil {
-		return fmt.Errorf("unable to obtain "+
-			"nursery report for ChannelPoint(%v): %v",
-			chanPoint, err)
+	resolver := &lnrpc.PendingResolver{
+		Type:           rpcResolverType(state.Kind),
+		DeadlineHeight: state.DeadlineHeight,
 	}
 
-	// If the nursery knows of this channel, then we can populate
-	// information detailing exactly how much funds are time locked and also
-	// the height in which we can ultimately sweep the funds into the
-	// wallet.
-	forceClose.LimboBalance = int64(nurseryInfo.LimboBalance)
-	forceClose.RecoveredBalance = int64(nurseryInfo.RecoveredBalance)
+	// The sweeper may either sweep the reported output or, for second
+	// level HTLC transactions, the HTLC output on the commitment.
+	var outpoints []wire.OutPoint
+	if state.Report != nil {
+		resolver.Stage = state.Report.Stage
+		resolver.Outpoint = state.Report.Outpoint.String()
+		resolver.AmountSat = int64(state.Report.Amount)
+
+		outpoints = append(outpoints, state.Report.Outpoint)
+	}
+	if state.HtlcPoint != nil {
+		outpoints = append(outpoints, *state.HtlcPoint)
+	}
 
-	for _, htlcReport := range nurseryInfo.Htlcs {
-		// TODO(conner) set incoming flag appropriately after handling
-		// incoming incubation
-		htlc := &lnrpc.PendingHTLC{
-			Incoming:       false,
-			Amount:         int64(htlcReport.Amount),
-			Outpoint:       htlcReport.Outpoint.String(),
-			MaturityHeight: htlcReport.MaturityHeight,
-			Stage:          htlcReport.Stage,
+	seen := make(map[chainhash.Hash]struct{})
+	for _, op := range outpoints {
+		pendingSweep, ok := sweeps[op]
+		if !ok {
+			continue
 		}
 
-		if htlc.MaturityHeight != 0 {
-			htlc.BlocksTilMaturity =
-				int32(htlc.MaturityHeight) -
-					currentHeight
-		}
+		for _, txid := range pendingSweep.SweepTxs {
+			if _, ok := seen[txid]; ok {
+				continue
+			}
+			seen[txid] = struct{}{}
 
-		forceClose.PendingHtlcs = append(forceClose.PendingHtlcs,
-			htlc)
+			resolver.BroadcastTxids = append(
+				resolver.BroadcastTxids, txid.String(),
+			)
+		}
 	}
 
-	return nil
+	return resolver
+}
+
+// rpcResolverType maps a contract resolver kind to its RPC representation.
+func rpcResolverType(
+	kind contractcourt.ResolverKind) lnrpc.PendingResolver_ResolverType {
+
+	switch kind {
+	case contractcourt.ResolverKindCommitSweep:
+		return lnrpc.PendingResolver_COMMIT_SWEEP
+
+	case contractcourt.ResolverKindAnchor:
+		return lnrpc.PendingResolver_ANCHOR_SWEEP
+
+	case contractcourt.ResolverKindHtlcTimeout:
+		return lnrpc.PendingResolver_HTLC_TIMEOUT
+
+	case contractcourt.ResolverKindHtlcOutgoingContest:
+		return lnrpc.PendingResolver_HTLC_OUTGOING_CONTEST
+
+	case contractcourt.ResolverKindHtlcSuccess:
+		return lnrpc.PendingResolver_HTLC_SUCCESS
+
+	case contractcourt.ResolverKindHtlcIncomingContest:
+		return lnrpc.PendingResolver_HTLC_INCOMING_CONTEST
+
+	case contractcourt.ResolverKindBreach:
+		return lnrpc.PendingResolver_BREACH
+
+	default:
+		return lnrpc.PendingResolver_UNKNOWN_RESOLVER
+	}
 }
 
 // ClosedChannels returns a list of all the channels have been closed.
@@ -4172,6 +4304,34 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 	return resp, nil
 }
 
+// DustExposure returns the current dust exposure of all active channels, along
+// with the configured dust thresholds.
+func (r *rpcServer) DustExposure(_ context.Context,
+	_ *lnrpc.DustExposureRequest) (*lnrpc.DustExposureResponse, error) {
+
+	resp := &lnrpc.DustExposureResponse{
+		ChannelThresholdMsat: uint64(lnwire.NewMSatFromSatoshis(
+			ltcutil.Amount(r.cfg.DustThreshold),
+		)),
+		NodeThresholdMsat: uint64(lnwire.NewMSatFromSatoshis(
+			ltcutil.Amount(r.cfg.NodeDustThreshold),
+		)),
+	}
+
+	for _, exposure := range r.server.htlcSwitch.DustExposure() {
+		resp.Channels = append(resp.Channels, &lnrpc.ChannelDustExposure{
+			ChannelPoint:   exposure.ChanPoint.String(),
+			ChanId:         exposure.ShortChanID.ToUint64(),
+			LocalDustMsat:  uint64(exposure.LocalDust),
+			RemoteDustMsat: uint64(exposure.RemoteDust),
+			ExposureMsat:   uint64(exposure.Exposure()),
+		})
+		resp.TotalExposureMsat += uint64(exposure.Exposure())
+	}
+
+	return resp, nil
+}
+
 // rpcCommitmentType takes the channel type and converts it to an rpc commitment
 // type value.
 func rpcCommitmentType(chanType channeldb.ChannelType) lnrpc.CommitmentType {
@@ -4478,20 +4638,7 @@ func (r *rpcServer) createRPCClosedChannel(
 	}
 
 	// Convert the close type to rpc type.
-	switch dbChannel.CloseType {
-	case channeldb.CooperativeClose:
-		closeType = lnrpc.ChannelCloseSummary_COOPERATIVE_CLOSE
-	case channeldb.LocalForceClose:
-		closeType = lnrpc.ChannelCloseSummary_LOCAL_FORCE_CLOSE
-	case channeldb.RemoteForceClose:
-		closeType = lnrpc.ChannelCloseSummary_REMOTE_FORCE_CLOSE
-	case channeldb.BreachClose:
-		closeType = lnrpc.ChannelCloseSummary_BREACH_CLOSE
-	case channeldb.FundingCanceled:
-		closeType = lnrpc.ChannelCloseSummary_FUNDING_CANCELED
-	case channeldb.Abandoned:
-		closeType = lnrpc.ChannelCloseSummary_ABANDONED
-	}
+	closeType = lnrpc.MarshalClosureType(dbChannel.CloseType)
 
 	dbScid := dbChannel.ShortChanID
 
@@ -4564,7 +4711,7 @@ func (r *rpcServer) createRPCClosedChannel(
 	}
 
 	for _, report := range reports {
-		rpcResolution, err := rpcChannelResolution(report)
+		rpcResolution, err := lnrpc.MarshalResolution(report)
 		if err != nil {
 			return nil, err
 		}
@@ -4575,60 +4722,6 @@ func (r *rpcServer) createRPCClosedChannel(
 	return channel, nil
 }
 
-func rpcChannelResolution(report *channeldb.ResolverReport) (*lnrpc.Resolution,
-	error) {
-
-	res := &lnrpc.Resolution{
-		AmountSat: uint64(report.Amount),
-		Outpoint:  lnrpc.MarshalOutPoint(&report.OutPoint),
-	}
-
-	if report.SpendTxID != nil {
-		res.SweepTxid = report.SpendTxID.String()
-	}
-
-	switch report.ResolverType {
-	case channeldb.ResolverTypeAnchor:
-		res.ResolutionType = lnrpc.ResolutionType_ANCHOR
-
-	case channeldb.ResolverTypeIncomingHtlc:
-		res.ResolutionType = lnrpc.ResolutionType_INCOMING_HTLC
-
-	case channeldb.ResolverTypeOutgoingHtlc:
-		res.ResolutionType = lnrpc.ResolutionType_OUTGOING_HTLC
-
-	case channeldb.ResolverTypeCommit:
-		res.ResolutionType = lnrpc.ResolutionType_COMMIT
-
-	default:
-		return nil, fmt.Errorf("unknown resolver type: %v",
-			report.ResolverType)
-	}
-
-	switch report.ResolverOutcome {
-	case channeldb.ResolverOutcomeClaimed:
-		res.Outcome = lnrpc.ResolutionOutcome_CLAIMED
-
-	case channeldb.ResolverOutcomeUnclaimed:
-		res.Outcome = lnrpc.ResolutionOutcome_UNCLAIMED
-
-	case channeldb.ResolverOutcomeAbandoned:
-		res.Outcome = lnrpc.ResolutionOutcome_ABANDONED
-
-	case channeldb.ResolverOutcomeFirstStage:
-		res.Outcome = lnrpc.ResolutionOutcome_FIRST_STAGE
-
-	case channeldb.ResolverOutcomeTimeout:
-		res.Outcome = lnrpc.ResolutionOutcome_TIMEOUT
-
-	default:
-		return nil, fmt.Errorf("unknown outcome: %v",
-			report.ResolverOutcome)
-	}
-
-	return res, nil
-}
-
 // getInitiators returns an initiator enum that provides information about the
 // party that initiated channel's open and close. This information is obtained
 // from the historical channel bucket, so unknown values are returned when the
@@ -4933,6 +5026,7 @@ type rpcPaymentIntent struct {
 	routeHints         [][]zpay32.HopHint
 	outgoingChannelIDs []uint64
 	lastHop            *route.Vertex
+	timePref           float64
 	destFeatures       *lnwire.FeatureVector
 	paymentAddr        *[32]byte
 	payReq             []byte
@@ -4991,6 +5085,12 @@ func (r *rpcServer) extractPaymentIntent(rpcPayReq *rpcPaymentRequest) (rpcPayme
 		payIntent.lastHop = &lastHop
 	}
 
+	// Pass along time preference.
+	if rpcPayReq.TimePref < -1 || rpcPayReq.TimePref > 1 {
+		return payIntent, errors.New("time preference out of range")
+	}
+	payIntent.timePref = rpcPayReq.TimePref
+
 	// Take the CLTV limit from the request if set, otherwise use the max.
 	cltvLimit, err := routerrpc.ValidateCLTVLimit(
 		rpcPayReq.CltvLimit, r.cfg.MaxOutgoingCltvExpiry,
@@ -5220,6 +5320,7 @@ func (r *rpcServer) dispatchPaymentIntent(
 			RouteHints:         payIntent.routeHints,
 			OutgoingChannelIDs: payIntent.outgoingChannelIDs,
 			LastHop:            payIntent.lastHop,
+			TimePref:           payIntent.timePref,
 			PaymentRequest:     payIntent.payReq,
 			PayAttemptTimeout:  routing.DefaultPayAttemptTimeout,
 			DestCustomRecords:  payIntent.destCustomRecords,
@@ -5584,7 +5685,9 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		GenAmpInvoiceFeatures: func() *lnwire.FeatureVector {
 			return r.server.featureMgr.Get(feature.SetInvoiceAmp)
 		},
-		GetAlias: r.server.aliasMgr.GetPeerAlias,
+		GetAlias:       r.server.aliasMgr.GetPeerAlias,
+		StrictHopHints: r.cfg.Invoices.StrictHopHints,
+		GetChanInfo:    r.server.chanEventStore.GetChanInfo,
 	}
 
 	value, err := lnrpc.UnmarshallAmt(invoice.Value, invoice.ValueMsat)
@@ -5727,7 +5830,7 @@ func (r *rpcServer) ListInvoices(ctx context.Context,
 		q.CreationDateEnd = time.Unix(int64(req.CreationDateEnd), 0)
 	}
 
-	invoiceSlice, err := r.server.miscDB.QueryInvoices(q)
+	invoiceSlice, err := r.server.replicaDB.QueryInvoices(q)
 	if err != nil {
 		return nil, fmt.Errorf("unable to query invoices: %v", err)
 	}
@@ -5871,6 +5974,20 @@ func (r *rpcServer) GetTransactions(ctx context.Context,
 		return nil, err
 	}
 
+	// If a label search query was provided, we'll only return the
+	// transactions with a matching label.
+	if req.Label != "" {
+		filtered := make(
+			[]*lnwallet.TransactionDetail, 0, len(transactions),
+		)
+		for _, tx := range transactions {
+			if tx.Label != "" && labels.Match(tx.Label, req.Label) {
+				filtered = append(filtered, tx)
+			}
+		}
+		transactions = filtered
+	}
+
 	return lnrpc.RPCTransactionDetails(transactions), nil
 }
 
@@ -5902,8 +6019,9 @@ func (r *rpcServer) DescribeGraph(ctx context.Context,
 
 	// Obtain the pointer to the global singleton channel graph, this will
 	// provide a consistent view of the graph due to bolt db's
-	// transactional model.
-	graph := r.server.graphDB
+	// transactional model. If a read-only replica is configured, we use it
+	// to take the load off the primary database.
+	graph := r.server.replicaDB.ChannelGraph()
 
 	// First iterate through all the known nodes (connected or unconnected
 	// within the graph), collating their current state into the RPC
@@ -6027,16 +6145,19 @@ func marshalDBRoutingPolicy(
 	disabled := policy.ChannelFlags&lnwire.ChanUpdateDisabled != 0
 
 	customRecords := marshalExtraOpaqueData(policy.ExtraOpaqueData)
+	inboundFee := policy.InboundFee()
 
 	return &lnrpc.RoutingPolicy{
-		TimeLockDelta:    uint32(policy.TimeLockDelta),
-		MinHtlc:          int64(policy.MinHTLC),
-		MaxHtlcMsat:      uint64(policy.MaxHTLC),
-		FeeBaseMsat:      int64(policy.FeeBaseMSat),
-		FeeRateMilliMsat: int64(policy.FeeProportionalMillionths),
-		Disabled:         disabled,
-		LastUpdate:       uint32(policy.LastUpdate.Unix()),
-		CustomRecords:    customRecords,
+		TimeLockDelta:           uint32(policy.TimeLockDelta),
+		MinHtlc:                 int64(policy.MinHTLC),
+		MaxHtlcMsat:             uint64(policy.MaxHTLC),
+		FeeBaseMsat:             int64(policy.FeeBaseMSat),
+		FeeRateMilliMsat:        int64(policy.FeeProportionalMillionths),
+		Disabled:                disabled,
+		LastUpdate:              uint32(policy.LastUpdate.Unix()),
+		CustomRecords:           customRecords,
+		InboundFeeBaseMsat:      inboundFee.Base,
+		InboundFeeRateMilliMsat: inboundFee.Rate,
 	}
 }
 
@@ -6366,6 +6487,31 @@ func (r *rpcServer) GetNetworkInfo(ctx context.Context,
 	return netInfo, nil
 }
 
+// GarbageCollectGraph forces a garbage collection pass over the channel graph,
+// pruning zombie channels and the channels of nodes that exceed the maximum
+// number of channels per node.
+func (r *rpcServer) GarbageCollectGraph(_ context.Context,
+	_ *lnrpc.GarbageCollectGraphRequest) (
+	*lnrpc.GarbageCollectGraphResponse, error) {
+
+	stats, err := r.server.chanRouter.GarbageCollectGraph()
+	if err != nil {
+		return nil, fmt.Errorf("unable to garbage collect graph: %w",
+			err)
+	}
+
+	rpcsLog.Debugf("[gcgraph] %v", stats)
+
+	return &lnrpc.GarbageCollectGraphResponse{
+		NumZombieChansPruned:   stats.LastZombieChans,
+		NumExcessChansPruned:   stats.LastExcessChans,
+		DurationMs:             stats.LastDuration.Milliseconds(),
+		TotalRuns:              stats.NumRuns,
+		TotalZombieChansPruned: stats.TotalZombieChans,
+		TotalExcessChansPruned: stats.TotalExcessChans,
+	}, nil
+}
+
 // StopDaemon will send a shutdown request to the interrupt handler, triggering
 // a graceful shutdown of the daemon.
 func (r *rpcServer) StopDaemon(_ context.Context,
@@ -6399,6 +6545,25 @@ func (r *rpcServer) StopDaemon(_ context.Context,
 func (r *rpcServer) SubscribeChannelGraph(req *lnrpc.GraphTopologySubscription,
 	updateStream lnrpc.Lightning_SubscribeChannelGraphServer) error {
 
+	// Collect the nodes that the caller is interested in.
+	var nodes []route.Vertex
+	if req.OwnChannelsOnly {
+		nodes = append(nodes, r.selfNode)
+	}
+	for _, pubKey := range req.NodePubkeys {
+		node, err := route.NewVertexFromBytes(pubKey)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument,
+				"invalid node pubkey: %v", err)
+		}
+		nodes = append(nodes, node)
+	}
+
+	filter := routing.NewTopologyFilter(
+		nodes, lnwire.MilliSatoshi(req.MinBaseFeeDeltaMsat),
+		lnwire.MilliSatoshi(req.MinFeeRateDeltaPpm),
+	)
+
 	// First, we start by subscribing to a new intent to receive
 	// notifications from the channel router.
 	client, err := r.server.chanRouter.SubscribeTopology()
@@ -6410,6 +6575,21 @@ func (r *rpcServer) SubscribeChannelGraph(req *lnrpc.GraphTopologySubscription,
 	// up once either the server, or client exists.
 	defer client.Cancel()
 
+	// Let the filter know about the existing channels of the nodes, so
+	// that their closes are sent. We do this after subscribing, so that
+	// no channel opened in the meantime is missed.
+	for _, node := range nodes {
+		err := r.server.graphDB.ForEachNodeChannel(nil, node,
+			func(channel *channeldb.DirectedChannel) error {
+				filter.AddChannel(channel.ChannelID)
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	for {
 		select {
 
@@ -6425,6 +6605,13 @@ func (r *rpcServer) SubscribeChannelGraph(req *lnrpc.GraphTopologySubscription,
 				return errors.New("server shutting down")
 			}
 
+			// Skip the change if nothing the caller is interested
+			// in is left after filtering.
+			topChange = filter.Filter(topChange)
+			if topChange == nil {
+				continue
+			}
+
 			// Convert the struct from the channel router into the
 			// form expected by the gRPC service then send it off
 			// to the client.
@@ -6512,6 +6699,10 @@ func marshallTopologyChange(topChange *routing.TopologyChange) *lnrpc.GraphTopol
 			AdvertisingNode: encodeKey(channelUpdate.AdvertisingNode),
 			ConnectingNode:  encodeKey(channelUpdate.ConnectingNode),
 		}
+
+		policy := channelUpdates[i].RoutingPolicy
+		policy.InboundFeeBaseMsat = channelUpdate.InboundFee.Base
+		policy.InboundFeeRateMilliMsat = channelUpdate.InboundFee.Rate
 	}
 
 	closedChans := make([]*lnrpc.ClosedChannelUpdate, len(topChange.ClosedChannels))
@@ -6975,6 +7166,14 @@ func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
 		FeeRate: feeRateFixed,
 	}
 
+	// If an inbound fee was specified, it replaces the current one.
+	if req.InboundFee != nil {
+		feeSchema.InboundFee = &models.InboundFee{
+			Base: req.InboundFee.BaseFeeMsat,
+			Rate: req.InboundFee.FeeRatePpm,
+		}
+	}
+
 	maxHtlc := lnwire.MilliSatoshi(req.MaxHtlcMsat)
 	var minHtlc *lnwire.MilliSatoshi
 	if req.MinHtlcMsatSpecified {
@@ -6991,9 +7190,9 @@ func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
 
 	rpcsLog.Debugf("[updatechanpolicy] updating channel policy "+
 		"base_fee=%v, rate_fixed=%v, time_lock_delta: %v, "+
-		"min_htlc=%v, max_htlc=%v, targets=%v",
+		"min_htlc=%v, max_htlc=%v, inbound_fee=%v, targets=%v",
 		req.BaseFeeMsat, feeRateFixed, req.TimeLockDelta,
-		minHtlc, maxHtlc,
+		minHtlc, maxHtlc, req.InboundFee,
 		spew.Sdump(targetChans))
 
 	// With the scope resolved, we'll now send this to the local channel
@@ -7065,10 +7264,41 @@ func (r *rpcServer) ForwardingHistory(ctx context.Context,
 		IndexOffset:  req.IndexOffset,
 		NumMaxEvents: numEvents,
 	}
-	timeSlice, err := r.server.miscDB.ForwardingLog().Query(eventQuery)
-	if err != nil {
-		return nil, fmt.Errorf("unable to query forwarding log: %v",
-			err)
+
+	var resp *lnrpc.ForwardingHistoryResponse
+	if req.IncludeFailed {
+		var err error
+		resp, err = r.htlcForwardingHistory(eventQuery)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		timeSlice, err := r.server.replicaDB.ForwardingLog().Query(
+			eventQuery,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to query forwarding "+
+				"log: %v", err)
+		}
+
+		// TODO(roasbeef): add settlement latency?
+		//  * use FPE on all records?
+
+		// With the events retrieved, we'll now map them into the
+		// proper proto response.
+		resp = &lnrpc.ForwardingHistoryResponse{
+			ForwardingEvents: make(
+				[]*lnrpc.ForwardingEvent,
+				len(timeSlice.ForwardingEvents),
+			),
+			LastOffsetIndex: timeSlice.LastIndexOffset,
+		}
+		for i, event := range timeSlice.ForwardingEvents {
+			resp.ForwardingEvents[i] = marshallForwardingEvent(
+				event.Timestamp, event.IncomingChanID,
+				event.OutgoingChanID, event.AmtIn, event.AmtOut,
+			)
+		}
 	}
 
 	// chanToPeerAlias caches previously looked up channel information.
@@ -7111,57 +7341,188 @@ func (r *rpcServer) ForwardingHistory(ctx context.Context,
 		return peer.Alias, nil
 	}
 
-	// TODO(roasbeef): add settlement latency?
-	//  * use FPE on all records?
+	if !req.PeerAliasLookup {
+		return resp, nil
+	}
+
+	for _, event := range resp.ForwardingEvents {
+		chanIDIn := lnwire.NewShortChanIDFromInt(event.ChanIdIn)
+		aliasIn, err := getRemoteAlias(chanIDIn)
+		if err != nil {
+			aliasIn = fmt.Sprintf("unable to lookup peer "+
+				"alias: %v", err)
+		}
+
+		chanIDOut := lnwire.NewShortChanIDFromInt(event.ChanIdOut)
+		aliasOut, err := getRemoteAlias(chanIDOut)
+		if err != nil {
+			aliasOut = fmt.Sprintf("unable to lookup peer"+
+				"alias: %v", err)
+		}
+		event.PeerAliasIn = aliasIn
+		event.PeerAliasOut = aliasOut
+	}
+
+	return resp, nil
+}
+
+// htlcForwardingHistory queries the htlc forwarding event log, which holds the
+// final outcome of settled as well as failed forwards.
+func (r *rpcServer) htlcForwardingHistory(
+	q channeldb.ForwardingEventQuery) (*lnrpc.ForwardingHistoryResponse,
+	error) {
+
+	if !r.cfg.StoreHtlcFwdEvents {
+		return nil, status.Error(codes.Unavailable, "cannot include "+
+			"failed forwards with flag "+
+			"--store-htlc-fwd-events=false")
+	}
+
+	fwdLog := r.server.replicaDB.ForwardingLog()
+	timeSlice, err := fwdLog.QueryHtlcForwardEvents(q)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query htlc forwarding "+
+			"log: %v", err)
+	}
 
-	// With the events retrieved, we'll now map them into the proper proto
-	// response.
-	//
-	// TODO(roasbeef): show in ns for the outside?
-	fwdingEvents := make(
-		[]*lnrpc.ForwardingEvent, len(timeSlice.ForwardingEvents),
-	)
 	resp := &lnrpc.ForwardingHistoryResponse{
-		ForwardingEvents: fwdingEvents,
-		LastOffsetIndex:  timeSlice.LastIndexOffset,
-	}
-	for i, event := range timeSlice.ForwardingEvents {
-		amtInMsat := event.AmtIn
-		amtOutMsat := event.AmtOut
-		feeMsat := event.AmtIn - event.AmtOut
-
-		resp.ForwardingEvents[i] = &lnrpc.ForwardingEvent{
-			Timestamp:   uint64(event.Timestamp.Unix()),
-			TimestampNs: uint64(event.Timestamp.UnixNano()),
-			ChanIdIn:    event.IncomingChanID.ToUint64(),
-			ChanIdOut:   event.OutgoingChanID.ToUint64(),
-			AmtIn:       uint64(amtInMsat.ToSatoshis()),
-			AmtOut:      uint64(amtOutMsat.ToSatoshis()),
-			Fee:         uint64(feeMsat.ToSatoshis()),
-			FeeMsat:     uint64(feeMsat),
-			AmtInMsat:   uint64(amtInMsat),
-			AmtOutMsat:  uint64(amtOutMsat),
-		}
-
-		if req.PeerAliasLookup {
-			aliasIn, err := getRemoteAlias(event.IncomingChanID)
-			if err != nil {
-				aliasIn = fmt.Sprintf("unable to lookup peer "+
-					"alias: %v", err)
-			}
-			aliasOut, err := getRemoteAlias(event.OutgoingChanID)
-			if err != nil {
-				aliasOut = fmt.Sprintf("unable to lookup peer"+
-					"alias: %v", err)
-			}
-			resp.ForwardingEvents[i].PeerAliasIn = aliasIn
-			resp.ForwardingEvents[i].PeerAliasOut = aliasOut
+		ForwardingEvents: make(
+			[]*lnrpc.ForwardingEvent,
+			len(timeSlice.HtlcForwardEvents),
+		),
+		LastOffsetIndex: timeSlice.LastIndexOffset,
+	}
+	for i, event := range timeSlice.HtlcForwardEvents {
+		rpcEvent := marshallForwardingEvent(
+			event.Timestamp, event.IncomingChanID,
+			event.OutgoingChanID, event.AmtIn, event.AmtOut,
+		)
+		rpcEvent.HtlcIndexIn = event.IncomingHtlcID
+		if event.OutgoingHtlcID != nil {
+			rpcEvent.HtlcIndexOut = *event.OutgoingHtlcID
+		}
+
+		// A failed forward doesn't earn us any fees.
+		if event.Outcome == channeldb.HtlcForwardFailed {
+			rpcEvent.Outcome = lnrpc.ForwardingEvent_FAILED
+			rpcEvent.Fee = 0
+			rpcEvent.FeeMsat = 0
+			rpcEvent.FailureCode = marshallFailCode(
+				event.FailureCode,
+			)
+			rpcEvent.FailureDetail = event.FailureDetail
 		}
+
+		resp.ForwardingEvents[i] = rpcEvent
 	}
 
 	return resp, nil
 }
 
+// marshallFailCode converts a wire failure code into its rpc representation.
+// The numbering of the rpc failure codes doesn't follow the wire failure codes,
+// so they need to be mapped explicitly.
+func marshallFailCode(code lnwire.FailCode) lnrpc.Failure_FailureCode {
+	switch code {
+	case lnwire.CodeIncorrectOrUnknownPaymentDetails:
+		return lnrpc.Failure_INCORRECT_OR_UNKNOWN_PAYMENT_DETAILS
+
+	case lnwire.CodeIncorrectPaymentAmount:
+		return lnrpc.Failure_INCORRECT_PAYMENT_AMOUNT
+
+	case lnwire.CodeFinalIncorrectCltvExpiry:
+		return lnrpc.Failure_FINAL_INCORRECT_CLTV_EXPIRY
+
+	case lnwire.CodeFinalIncorrectHtlcAmount:
+		return lnrpc.Failure_FINAL_INCORRECT_HTLC_AMOUNT
+
+	case lnwire.CodeFinalExpiryTooSoon:
+		return lnrpc.Failure_FINAL_EXPIRY_TOO_SOON
+
+	case lnwire.CodeInvalidRealm:
+		return lnrpc.Failure_INVALID_REALM
+
+	case lnwire.CodeExpiryTooSoon:
+		return lnrpc.Failure_EXPIRY_TOO_SOON
+
+	case lnwire.CodeInvalidOnionVersion:
+		return lnrpc.Failure_INVALID_ONION_VERSION
+
+	case lnwire.CodeInvalidOnionHmac:
+		return lnrpc.Failure_INVALID_ONION_HMAC
+
+	case lnwire.CodeInvalidOnionKey:
+		return lnrpc.Failure_INVALID_ONION_KEY
+
+	case lnwire.CodeAmountBelowMinimum:
+		return lnrpc.Failure_AMOUNT_BELOW_MINIMUM
+
+	case lnwire.CodeFeeInsufficient:
+		return lnrpc.Failure_FEE_INSUFFICIENT
+
+	case lnwire.CodeIncorrectCltvExpiry:
+		return lnrpc.Failure_INCORRECT_CLTV_EXPIRY
+
+	case lnwire.CodeChannelDisabled:
+		return lnrpc.Failure_CHANNEL_DISABLED
+
+	case lnwire.CodeTemporaryChannelFailure:
+		return lnrpc.Failure_TEMPORARY_CHANNEL_FAILURE
+
+	case lnwire.CodeRequiredNodeFeatureMissing:
+		return lnrpc.Failure_REQUIRED_NODE_FEATURE_MISSING
+
+	case lnwire.CodeRequiredChannelFeatureMissing:
+		return lnrpc.Failure_REQUIRED_CHANNEL_FEATURE_MISSING
+
+	case lnwire.CodeUnknownNextPeer:
+		return lnrpc.Failure_UNKNOWN_NEXT_PEER
+
+	case lnwire.CodeTemporaryNodeFailure:
+		return lnrpc.Failure_TEMPORARY_NODE_FAILURE
+
+	case lnwire.CodePermanentNodeFailure:
+		return lnrpc.Failure_PERMANENT_NODE_FAILURE
+
+	case lnwire.CodePermanentChannelFailure:
+		return lnrpc.Failure_PERMANENT_CHANNEL_FAILURE
+
+	case lnwire.CodeExpiryTooFar:
+		return lnrpc.Failure_EXPIRY_TOO_FAR
+
+	case lnwire.CodeMPPTimeout:
+		return lnrpc.Failure_MPP_TIMEOUT
+
+	case lnwire.CodeInvalidOnionPayload:
+		return lnrpc.Failure_INVALID_ONION_PAYLOAD
+
+	default:
+		return lnrpc.Failure_UNKNOWN_FAILURE
+	}
+}
+
+// marshallForwardingEvent converts the common fields of a forwarding event into
+// its rpc representation.
+func marshallForwardingEvent(timestamp time.Time, chanIDIn,
+	chanIDOut lnwire.ShortChannelID, amtIn,
+	amtOut lnwire.MilliSatoshi) *lnrpc.ForwardingEvent {
+
+	feeMsat := amtIn - amtOut
+
+	return &lnrpc.ForwardingEvent{
+		Timestamp:   uint64(timestamp.Unix()),
+		TimestampNs: uint64(timestamp.UnixNano()),
+		ChanIdIn:    chanIDIn.ToUint64(),
+		ChanIdOut:   chanIDOut.ToUint64(),
+		AmtIn:       uint64(amtIn.ToSatoshis()),
+		AmtOut:      uint64(amtOut.ToSatoshis()),
+		Fee:         uint64(feeMsat.ToSatoshis()),
+		FeeMsat:     uint64(feeMsat),
+		AmtInMsat:   uint64(amtIn),
+		AmtOutMsat:  uint64(amtOut),
+	}
+}
+
 // ExportChannelBackup attempts to return an encrypted static channel backup
 // for the target channel identified by it channel point. The backup is
 // encrypted with a key generated from the aezeed seed of the user. The
@@ -7520,6 +7881,88 @@ func (r *rpcServer) SubscribeChannelBackups(req *lnrpc.ChannelBackupSubscription
 	}
 }
 
+const (
+	// defaultDBBackupChunkSize is the default maximum number of bytes
+	// BackupDatabase sends in a single chunk.
+	defaultDBBackupChunkSize = 64 * 1024
+
+	// maxDBBackupChunkSize is the maximum chunk size a client can request
+	// from BackupDatabase.
+	maxDBBackupChunkSize = 1024 * 1024
+)
+
+// dbBackupStreamWriter is an io.Writer that sends everything that is written to
+// it as chunks of at most chunkSize bytes over a BackupDatabase stream.
+type dbBackupStreamWriter struct {
+	stream    lnrpc.Lightning_BackupDatabaseServer
+	chunkSize int
+	offset    uint64
+}
+
+// Write sends the given bytes to the client.
+func (w *dbBackupStreamWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		n := len(p)
+		if n > w.chunkSize {
+			n = w.chunkSize
+		}
+
+		err := w.stream.Send(&lnrpc.DatabaseBackupChunk{
+			Offset: w.offset,
+			Data:   p[:n],
+		})
+		if err != nil {
+			return written, err
+		}
+
+		w.offset += uint64(n)
+		written += n
+		p = p[n:]
+	}
+
+	return written, nil
+}
+
+// BackupDatabase streams a consistent snapshot of the live channel database in
+// chunks, so a hot backup can be taken without stopping lnd. For bolt
+// databases the result is a regular channel.db file.
+func (r *rpcServer) BackupDatabase(req *lnrpc.DatabaseBackupRequest,
+	stream lnrpc.Lightning_BackupDatabaseServer) error {
+
+	chunkSize := int(req.ChunkSize)
+	switch {
+	case chunkSize == 0:
+		chunkSize = defaultDBBackupChunkSize
+
+	case chunkSize > maxDBBackupChunkSize:
+		return fmt.Errorf("chunk size must not exceed %d bytes",
+			maxDBBackupChunkSize)
+	}
+
+	// We buffer the writes of the database so we don't send a message for
+	// each page that is written.
+	streamWriter := &dbBackupStreamWriter{
+		stream:    stream,
+		chunkSize: chunkSize,
+	}
+	bufWriter := bufio.NewWriterSize(streamWriter, chunkSize)
+
+	n, err := kvdb.StreamBackup(
+		r.server.chanStateDB.GetParentDB(), bufWriter,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to back up database: %v", err)
+	}
+	if err := bufWriter.Flush(); err != nil {
+		return fmt.Errorf("unable to send database backup: %v", err)
+	}
+
+	rpcsLog.Infof("Streamed channel database backup of %d bytes", n)
+
+	return nil
+}
+
 // ChannelAcceptor dispatches a bi-directional streaming RPC in which
 // OpenChannel requests are sent to the client and the client responds with
 // a boolean that tells LND whether or not to accept the channel. This allows
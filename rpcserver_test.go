@@ -3,6 +3,8 @@ package lnd
 import (
 	"testing"
 
+	"github.com/ltcsuite/lnd/lnrpc"
+	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -12,3 +14,24 @@ func TestGetAllPermissions(t *testing.T) {
 	// Currently there are there are 16 entity:action pairs in use.
 	assert.Equal(t, len(perms), 16)
 }
+
+// TestMarshallFailCode tests that wire failure codes are mapped to their rpc
+// counterparts, which are numbered differently.
+func TestMarshallFailCode(t *testing.T) {
+	assert.Equal(
+		t, lnrpc.Failure_TEMPORARY_CHANNEL_FAILURE,
+		marshallFailCode(lnwire.CodeTemporaryChannelFailure),
+	)
+	assert.Equal(
+		t, lnrpc.Failure_FEE_INSUFFICIENT,
+		marshallFailCode(lnwire.CodeFeeInsufficient),
+	)
+	assert.Equal(
+		t, lnrpc.Failure_INVALID_ONION_PAYLOAD,
+		marshallFailCode(lnwire.CodeInvalidOnionPayload),
+	)
+	assert.Equal(
+		t, lnrpc.Failure_UNKNOWN_FAILURE,
+		marshallFailCode(lnwire.CodeInvalidBlinding),
+	)
+}
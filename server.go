@@ -45,6 +45,7 @@ import (
 	"github.com/ltcsuite/lnd/lnrpc/routerrpc"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/lnwallet/chainfee"
+	"github.com/ltcsuite/lnd/lnwallet/chanfunding"
 	"github.com/ltcsuite/lnd/lnwallet/rpcwallet"
 	"github.com/ltcsuite/lnd/lnwire"
 	"github.com/ltcsuite/lnd/nat"
@@ -174,6 +175,10 @@ type server struct {
 	// creating and setting up onion services, etc.
 	torController *tor.Controller
 
+	// onionAddr is the address of our onion service that is currently
+	// included in our node announcement. It's protected by mu.
+	onionAddr *tor.OnionAddr
+
 	// natTraversal is the specific NAT traversal technique used to
 	// automatically set up port forwarding rules in order to advertise to
 	// the network that the node is accepting inbound connections.
@@ -230,6 +235,12 @@ type server struct {
 
 	cc *chainreg.ChainControl
 
+	// commitFeeEstimator and coopCloseFeeEstimator clamp the estimates of
+	// the chain control's fee estimator to the limits configured for
+	// commitment fee updates and cooperative closes respectively.
+	commitFeeEstimator    chainfee.Estimator
+	coopCloseFeeEstimator chainfee.Estimator
+
 	fundingMgr *funding.Manager
 
 	graphDB *channeldb.ChannelGraph
@@ -242,6 +253,12 @@ type server struct {
 	// channel DB that haven't been separated out yet.
 	miscDB *channeldb.DB
 
+	// replicaDB is used for expensive read-only queries. It points to a
+	// read-only replica of miscDB if one is configured and to miscDB
+	// itself otherwise. As replicas are updated asynchronously, it might
+	// lag slightly behind miscDB.
+	replicaDB *channeldb.DB
+
 	aliasMgr *aliasmgr.Manager
 
 	htlcSwitch *htlcswitch.Switch
@@ -270,8 +287,6 @@ type server struct {
 
 	localChanMgr *localchans.Manager
 
-	utxoNursery *contractcourt.UtxoNursery
-
 	sweeper *sweep.UtxoSweeper
 
 	chainArb *contractcourt.ChainArbitrator
@@ -316,6 +331,10 @@ type server struct {
 
 	hostAnn *netann.HostAnnouncer
 
+	// addrDiscoverer, if non-nil, discovers the external addresses of the
+	// node and updates the node announcement if they change.
+	addrDiscoverer *netann.AddrDiscoverer
+
 	// livenessMonitor monitors that lnd has access to critical resources.
 	livenessMonitor *healthcheck.Monitor
 
@@ -540,6 +559,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		NoAnySegwit:              cfg.ProtocolOptions.NoAnySegwit(),
 		CustomFeatures:           cfg.ProtocolOptions.ExperimentalProtocol.CustomFeatures(),
 		NoTaprootChans:           !cfg.ProtocolOptions.TaprootChans,
+		NoRbfCoopClose:           !cfg.ProtocolOptions.RbfCoopClose,
 	})
 	if err != nil {
 		return nil, err
@@ -556,12 +576,20 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		KeysendHoldTime:             cfg.KeysendHoldTime,
 	}
 
+	// Expensive read-only queries are served by the replica if one is
+	// configured.
+	replicaDB := dbs.ChanStateDB
+	if dbs.ReplicaDB != nil {
+		replicaDB = dbs.ReplicaDB
+	}
+
 	s := &server{
 		cfg:            cfg,
 		graphDB:        dbs.GraphDB.ChannelGraph(),
 		chanStateDB:    dbs.ChanStateDB.ChannelStateDB(),
 		addrSource:     dbs.ChanStateDB,
 		miscDB:         dbs.ChanStateDB,
+		replicaDB:      replicaDB,
 		cc:             cc,
 		sigPool:        lnwallet.NewSigPool(cfg.Workers.Sig, cc.Signer),
 		writePool:      writePool,
@@ -625,6 +653,9 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 
 	thresholdSats := ltcutil.Amount(cfg.DustThreshold)
 	thresholdMSats := lnwire.NewMSatFromSatoshis(thresholdSats)
+	nodeThresholdMSats := lnwire.NewMSatFromSatoshis(
+		ltcutil.Amount(cfg.NodeDustThreshold),
+	)
 
 	s.aliasMgr, err = aliasmgr.NewManager(dbs.ChanStateDB)
 	if err != nil {
@@ -651,6 +682,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 			peer.HandleLocalCloseChanReqs(request)
 		},
 		FwdingLog:              dbs.ChanStateDB.ForwardingLog(),
+		StoreHtlcFwdEvents:     cfg.StoreHtlcFwdEvents,
 		SwitchPackager:         channeldb.NewSwitchPackager(),
 		ExtractErrorEncrypter:  s.sphinx.ExtractErrorEncrypter,
 		FetchLastChannelUpdate: s.fetchLastChanUpdate(),
@@ -664,8 +696,12 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		Clock:                  clock.NewDefaultClock(),
 		MailboxDeliveryTimeout: cfg.Htlcswitch.MailboxDeliveryTimeout,
 		DustThreshold:          thresholdMSats,
+		NodeDustThreshold:      nodeThresholdMSats,
 		SignAliasUpdate:        s.signAliasUpdate,
 		IsAlias:                aliasmgr.IsAlias,
+		MaxPendingHtlcsPerPeer: cfg.Htlcswitch.MaxPendingHtlcsPerPeer,
+		HtlcAddRatePerPeer:     cfg.Htlcswitch.HtlcAddRatePerPeer,
+		HtlcAddBurstPerPeer:    cfg.Htlcswitch.HtlcAddBurstPerPeer,
 	}, uint32(currentHeight))
 	if err != nil {
 		return nil, err
@@ -692,6 +728,7 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		ChanStatusSampleInterval: cfg.ChanStatusSampleInterval,
 		ChanEnableTimeout:        cfg.ChanEnableTimeout,
 		ChanDisableTimeout:       cfg.ChanDisableTimeout,
+		UpdateBatchWindow:        cfg.ChanUpdateBatchWindow,
 		OurPubKey:                nodeKeyDesc.PubKey,
 		OurKeyLoc:                nodeKeyDesc.KeyLocator,
 		MessageSigner:            s.nodeSigner,
@@ -750,18 +787,16 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	for idx, ip := range cfg.ExternalIPs {
 		externalIPStrings[idx] = ip.String()
 	}
-	if s.natTraversal != nil {
-		listenPorts := make([]uint16, 0, len(listenAddrs))
-		for _, listenAddr := range listenAddrs {
-			// At this point, the listen addresses should have
-			// already been normalized, so it's safe to ignore the
-			// errors.
-			_, portStr, _ := net.SplitHostPort(listenAddr.String())
-			port, _ := strconv.Atoi(portStr)
-
-			listenPorts = append(listenPorts, uint16(port))
-		}
+	listenPorts := make([]uint16, 0, len(listenAddrs))
+	for _, listenAddr := range listenAddrs {
+		// At this point, the listen addresses should have already been
+		// normalized, so it's safe to ignore the errors.
+		_, portStr, _ := net.SplitHostPort(listenAddr.String())
+		port, _ := strconv.Atoi(portStr)
 
+		listenPorts = append(listenPorts, uint16(port))
+	}
+	if s.natTraversal != nil {
 		ips, err := s.configurePortForwarding(listenPorts...)
 		if err != nil {
 			srvrLog.Errorf("Unable to automatically set up port "+
@@ -788,6 +823,12 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	selfAddrs := make([]net.Addr, 0, len(externalIPs))
 	selfAddrs = append(selfAddrs, externalIPs...)
 
+	// The external DNS address is announced as is and resolved by the
+	// peers connecting to us.
+	if cfg.ExternalDNS != nil {
+		selfAddrs = append(selfAddrs, cfg.ExternalDNS)
+	}
+
 	// As the graph can be obtained at anytime from the network, we won't
 	// replicate it, and instead it'll only be stored locally.
 	chanGraph := dbs.GraphDB.ChannelGraph()
@@ -809,6 +850,19 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	if alias == "" {
 		alias = hex.EncodeToString(serializedPubKey[:10])
 	}
+
+	// The alias and color might have been changed at runtime through the
+	// UpdateNodeAnnouncement RPC. Unless they are explicitly set in the
+	// config, we'll keep announcing the values we announced last.
+	prevSelfNode, err := chanGraph.SourceNode()
+	if err == nil && prevSelfNode.HaveNodeAnnouncement {
+		if cfg.Alias == "" && prevSelfNode.Alias != "" {
+			alias = prevSelfNode.Alias
+		}
+		if !cfg.colorSet {
+			color = prevSelfNode.Color
+		}
+	}
 	nodeAlias, err := lnwire.NewNodeAlias(alias)
 	if err != nil {
 		return nil, err
@@ -873,43 +927,9 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	if cfg.Estimator != nil {
 		estimator = cfg.Estimator
 	} else {
-		switch routingConfig.ProbabilityEstimatorType {
-		case routing.AprioriEstimatorName:
-			aCfg := routingConfig.AprioriConfig
-			aprioriConfig := routing.AprioriConfig{
-				AprioriHopProbability: aCfg.HopProbability,
-				PenaltyHalfLife:       aCfg.PenaltyHalfLife,
-				AprioriWeight:         aCfg.Weight,
-				CapacityFraction:      aCfg.CapacityFraction,
-			}
-
-			estimator, err = routing.NewAprioriEstimator(
-				aprioriConfig,
-			)
-			if err != nil {
-				return nil, err
-			}
-
-		case routing.BimodalEstimatorName:
-			bCfg := routingConfig.BimodalConfig
-			bimodalConfig := routing.BimodalConfig{
-				BimodalNodeWeight: bCfg.NodeWeight,
-				BimodalScaleMsat: lnwire.MilliSatoshi(
-					bCfg.Scale,
-				),
-				BimodalDecayTime: bCfg.DecayTime,
-			}
-
-			estimator, err = routing.NewBimodalEstimator(
-				bimodalConfig,
-			)
-			if err != nil {
-				return nil, err
-			}
-
-		default:
-			return nil, fmt.Errorf("unknown estimator type %v",
-				routingConfig.ProbabilityEstimatorType)
+		estimator, err = routingConfig.NewEstimator()
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -938,18 +958,22 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		),
 		AttemptCostPPM: routingConfig.AttemptCostPPM,
 		MinProbability: routingConfig.MinRouteProbability,
+		ProfileLabels:  routingConfig.ProfilePathFinding,
 	}
 
 	sourceNode, err := chanGraph.SourceNode()
 	if err != nil {
 		return nil, fmt.Errorf("error getting source node: %v", err)
 	}
+
+	splitConfig := routingConfig.NewSplitConfig()
 	paymentSessionSource := &routing.SessionSource{
 		Graph:             chanGraph,
 		SourceNode:        sourceNode,
 		MissionControl:    s.missionControl,
 		GetLink:           s.htlcSwitch.GetLinkByShortID,
 		PathFindingConfig: pathFindingConfig,
+		SplitConfig:       &splitConfig,
 	}
 
 	paymentControl := channeldb.NewPaymentControl(dbs.ChanStateDB)
@@ -959,24 +983,27 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	strictPruning := (cfg.Litecoin.Node == "neutrino" ||
 		cfg.Routing.StrictZombiePruning)
 	s.chanRouter, err = routing.New(routing.Config{
-		Graph:               chanGraph,
-		Chain:               cc.ChainIO,
-		ChainView:           cc.ChainView,
-		Notifier:            cc.ChainNotifier,
-		Payer:               s.htlcSwitch,
-		Control:             s.controlTower,
-		MissionControl:      s.missionControl,
-		SessionSource:       paymentSessionSource,
-		ChannelPruneExpiry:  routing.DefaultChannelPruneExpiry,
-		GraphPruneInterval:  time.Hour,
-		FirstTimePruneDelay: routing.DefaultFirstTimePruneDelay,
-		GetLink:             s.htlcSwitch.GetLinkByShortID,
-		AssumeChannelValid:  cfg.Routing.AssumeChannelValid,
-		NextPaymentID:       sequencer.NextID,
-		PathFindingConfig:   pathFindingConfig,
-		Clock:               clock.NewDefaultClock(),
-		StrictZombiePruning: strictPruning,
-		IsAlias:             aliasmgr.IsAlias,
+		Graph:                 chanGraph,
+		Chain:                 cc.ChainIO,
+		ChainView:             cc.ChainView,
+		Notifier:              cc.ChainNotifier,
+		Payer:                 s.htlcSwitch,
+		Control:               s.controlTower,
+		MissionControl:        s.missionControl,
+		SessionSource:         paymentSessionSource,
+		ChannelPruneExpiry:    cfg.Routing.ZombieExpiry,
+		GraphPruneInterval:    cfg.GcGraphInterval,
+		FirstTimePruneDelay:   routing.DefaultFirstTimePruneDelay,
+		GetLink:               s.htlcSwitch.GetLinkByShortID,
+		AssumeChannelValid:    cfg.Routing.AssumeChannelValid,
+		NextPaymentID:         sequencer.NextID,
+		PathFindingConfig:     pathFindingConfig,
+		Clock:                 clock.NewDefaultClock(),
+		StrictZombiePruning:   strictPruning,
+		MaxChannelsPerNode:    cfg.Routing.MaxChannelsPerNode,
+		MaxConcurrentPayments: cfg.Routing.MaxConcurrentPayments,
+		MaxQueuedPayments:     cfg.Routing.MaxQueuedPayments,
+		IsAlias:               aliasmgr.IsAlias,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't create router: %v", err)
@@ -991,6 +1018,9 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 	if err != nil {
 		return nil, err
 	}
+	nodeAnnRebroadcast := netann.NewNodeAnnRebroadcastSchedule(
+		cfg.Gossip.NodeAnnRebroadcastInterval,
+	)
 
 	s.authGossiper = discovery.New(discovery.Config{
 		Router:                s.chanRouter,
@@ -1010,12 +1040,14 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		TrickleDelay:            time.Millisecond * time.Duration(cfg.TrickleDelay),
 		RetransmitTicker:        ticker.New(time.Minute * 30),
 		RebroadcastInterval:     time.Hour * 24,
+		NodeAnnRebroadcast:      nodeAnnRebroadcast,
 		WaitingProofStore:       waitingProofStore,
 		MessageStore:            gossipMessageStore,
 		AnnSigner:               s.nodeSigner,
 		RotateTicker:            ticker.New(discovery.DefaultSyncerRotationInterval),
 		HistoricalSyncTicker:    ticker.New(cfg.HistoricalSyncInterval),
 		NumActiveSyncers:        cfg.NumGraphSyncPeers,
+		NumHistoricalSyncers:    cfg.Gossip.HistoricalSyncPeers,
 		MinimumBatchSize:        10,
 		SubBatchDelay:           cfg.Gossip.SubBatchDelay,
 		IgnoreHistoricalFilters: cfg.IgnoreHistoricalGossipFilters,
@@ -1031,19 +1063,11 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 
 	s.localChanMgr = &localchans.Manager{
 		ForAllOutgoingChannels:    s.chanRouter.ForAllOutgoingChannels,
-		PropagateChanPolicyUpdate: s.authGossiper.PropagateChanPolicyUpdate,
+		PropagateChanPolicyUpdate: s.propagateChanPolicyUpdate,
 		UpdateForwardingPolicies:  s.htlcSwitch.UpdateForwardingPolicies,
 		FetchChannel:              s.chanStateDB.FetchChannel,
 	}
 
-	utxnStore, err := contractcourt.NewNurseryStore(
-		s.cfg.ActiveNetParams.GenesisHash, dbs.ChanStateDB,
-	)
-	if err != nil {
-		srvrLog.Errorf("unable to create nursery store: %v", err)
-		return nil, err
-	}
-
 	srvrLog.Debugf("Sweeper batch window duration: %v",
 		cfg.Sweeper.BatchWindowDuration)
 
@@ -1055,32 +1079,44 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		return nil, err
 	}
 
+	// Each consumer of fee estimates gets its own filter so that the fee
+	// rates used can be bounded independently.
+	s.commitFeeEstimator = newFeeFilter(
+		cc.FeeEstimator, cfg.Fee.MinCommitFeeRate,
+		cfg.Fee.MaxCommitFeeRate,
+	)
+	s.coopCloseFeeEstimator = newFeeFilter(
+		cc.FeeEstimator, cfg.Fee.MinCoopCloseFeeRate,
+		cfg.Fee.MaxCoopCloseFeeRate,
+	)
+
+	// The sweeper prefers small wallet utxos by default to avoid locking
+	// large ones, so the default largest-first strategy isn't applied.
+	var sweeperCoinSelection chanfunding.CoinSelectionStrategy
+	if cfg.CoinSelectionStrategy != chanfunding.CoinSelectionLargestName {
+		sweeperCoinSelection = cc.Wallet.Cfg.CoinSelectionStrategy
+	}
+
 	s.sweeper = sweep.New(&sweep.UtxoSweeperConfig{
-		FeeEstimator:   cc.FeeEstimator,
+		FeeEstimator: newFeeFilter(
+			cc.FeeEstimator, cfg.Fee.MinSweepFeeRate,
+			cfg.Fee.MaxSweepFeeRate,
+		),
 		GenSweepScript: newSweepPkScriptGen(cc.Wallet),
 		Signer:         cc.Wallet.Cfg.Signer,
 		Wallet:         newSweeperWallet(cc.Wallet),
 		NewBatchTimer: func() <-chan time.Time {
 			return time.NewTimer(cfg.Sweeper.BatchWindowDuration).C
 		},
-		Notifier:             cc.ChainNotifier,
-		Store:                sweeperStore,
-		MaxInputsPerTx:       sweep.DefaultMaxInputsPerTx,
-		MaxSweepAttempts:     sweep.DefaultMaxSweepAttempts,
-		NextAttemptDeltaFunc: sweep.DefaultNextAttemptDeltaFunc,
-		MaxFeeRate:           sweep.DefaultMaxFeeRate,
-		FeeRateBucketSize:    sweep.DefaultFeeRateBucketSize,
-	})
-
-	s.utxoNursery = contractcourt.NewUtxoNursery(&contractcourt.NurseryConfig{
-		ChainIO:             cc.ChainIO,
-		ConfDepth:           1,
-		FetchClosedChannels: s.chanStateDB.FetchClosedChannels,
-		FetchClosedChannel:  s.chanStateDB.FetchClosedChannel,
-		Notifier:            cc.ChainNotifier,
-		PublishTransaction:  cc.Wallet.PublishTransaction,
-		Store:               utxnStore,
-		SweepInput:          s.sweeper.SweepInput,
+		Notifier:              cc.ChainNotifier,
+		Store:                 sweeperStore,
+		MaxInputsPerTx:        cfg.Sweeper.MaxInputsPerTx,
+		MaxSweepWeight:        cfg.Sweeper.MaxSweepWeight,
+		MaxSweepAttempts:      sweep.DefaultMaxSweepAttempts,
+		NextAttemptDeltaFunc:  sweep.DefaultNextAttemptDeltaFunc,
+		MaxFeeRate:            sweep.DefaultMaxFeeRate(),
+		FeeRateBucketSize:     sweep.DefaultFeeRateBucketSize,
+		CoinSelectionStrategy: sweeperCoinSelection,
 	})
 
 	// Construct a closure that wraps the htlcswitch's CloseLink method.
@@ -1111,8 +1147,14 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		Store: contractcourt.NewRetributionStore(
 			dbs.ChanStateDB,
 		),
+		JusticeSplitThreshold: cfg.ContractCourt.JusticeSplitThreshold,
 	})
 
+	peerCloseDelays, err := cfg.ContractCourt.ForceCloseDelay.ParsePeerDelays()
+	if err != nil {
+		return nil, err
+	}
+
 	s.chainArb = contractcourt.NewChainArbitrator(contractcourt.ChainArbitratorConfig{
 		ChainHash:              *s.cfg.ActiveNetParams.GenesisHash,
 		IncomingBroadcastDelta: lncfg.DefaultIncomingBroadcastDelta,
@@ -1128,27 +1170,6 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 			}
 			return nil
 		},
-		IncubateOutputs: func(chanPoint wire.OutPoint,
-			outHtlcRes *lnwallet.OutgoingHtlcResolution,
-			inHtlcRes *lnwallet.IncomingHtlcResolution,
-			broadcastHeight uint32) error {
-
-			var (
-				inRes  []lnwallet.IncomingHtlcResolution
-				outRes []lnwallet.OutgoingHtlcResolution
-			)
-			if inHtlcRes != nil {
-				inRes = append(inRes, *inHtlcRes)
-			}
-			if outHtlcRes != nil {
-				outRes = append(outRes, *outHtlcRes)
-			}
-
-			return s.utxoNursery.IncubateOutputs(
-				chanPoint, outRes, inRes,
-				broadcastHeight,
-			)
-		},
 		PreimageDB:   s.witnessBeacon,
 		Notifier:     cc.ChainNotifier,
 		Mempool:      cc.MempoolNotifier,
@@ -1211,10 +1232,31 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		OnionProcessor:                s.sphinx,
 		PaymentsExpirationGracePeriod: cfg.PaymentsExpirationGracePeriod,
 		IsForwardedHTLC:               s.htlcSwitch.IsForwardedHTLC,
+		IncomingHtlcExpiry:            s.htlcSwitch.IncomingHtlcExpiry,
 		Clock:                         clock.NewDefaultClock(),
 		SubscribeBreachComplete:       s.breachArbiter.SubscribeBreachComplete,
 		PutFinalHtlcOutcome:           s.chanStateDB.PutOnchainFinalHtlcOutcome, //nolint: lll
 		HtlcNotifier:                  s.htlcNotifier,
+		Budget: contractcourt.BudgetConfig{
+			AnchorCPFP: cfg.ContractCourt.Budget.AnchorCPFP,
+			ToLocal:    cfg.ContractCourt.Budget.ToLocal,
+			HTLC:       cfg.ContractCourt.Budget.Htlc,
+
+			AbandonUneconomicalHTLCs: !cfg.ContractCourt.Budget.KeepUneconomical, //nolint:lll
+		},
+		Stagger: contractcourt.StaggerConfig{
+			Interval:    cfg.ContractCourt.Stagger.Interval,
+			UrgentDelta: cfg.ContractCourt.Stagger.UrgentDelta,
+		},
+		ForceCloseDelay: contractcourt.ForceCloseDelayConfig{
+			Delay:      cfg.ContractCourt.ForceCloseDelay.Delay,
+			PeerDelays: peerCloseDelays,
+			MaxBlocks:  cfg.ContractCourt.ForceCloseDelay.MaxBlocks, //nolint:lll
+		},
+		IsPeerOnline: func(peer [33]byte) bool {
+			_, err := s.FindPeerByPubStr(string(peer[:]))
+			return err == nil
+		},
 	}, dbs.ChanStateDB)
 
 	// Select the configuration and furnding parameters for litecoin
@@ -1445,8 +1487,8 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 		NotifyPendingOpenChannelEvent: s.channelNotifier.NotifyPendingOpenChannelEvent,
 		EnableUpfrontShutdown:         cfg.EnableUpfrontShutdown,
 		RegisteredChains:              cfg.registeredChains,
-		MaxAnchorsCommitFeeRate: chainfee.SatPerKVByte(
-			s.cfg.MaxCommitFeeRateAnchors * 1000).FeePerKWeight(),
+		MaxAnchorsCommitFeeRate: chainfee.SatPerVByte(
+			s.cfg.MaxCommitFeeRateAnchors).FeePerKWeight(),
 		DeleteAliasEdge: deleteAliasEdge,
 		AliasManager:    s.aliasMgr,
 	})
@@ -1499,8 +1541,8 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 
 		// We expose the sweep fee rate in sat/vbyte, but the tower
 		// protocol operations on sat/kw.
-		sweepRateSatPerVByte := chainfee.SatPerKVByte(
-			1000 * cfg.WtClient.SweepFeeRate,
+		sweepRateSatPerVByte := chainfee.SatPerVByte(
+			cfg.WtClient.SweepFeeRate,
 		)
 
 		policy.SweepFeeRate = sweepRateSatPerVByte.FeePerKWeight()
@@ -1624,16 +1666,43 @@ func newServer(cfg *Config, listenAddrs []net.Addr,
 			},
 			AdvertisedIPs: advertisedIPs,
 			AnnounceNewIPs: netann.IPAnnouncer(
-				func(modifier ...netann.NodeAnnModifier) (
-					lnwire.NodeAnnouncement, error) {
-
-					return s.genNodeAnnouncement(
-						nil, modifier...,
-					)
-				}),
+				s.updateSelfNodeAnn,
+			),
 		})
 	}
 
+	// If external IP probes are configured, we'll periodically discover
+	// our external addresses and announce them. The NAT traversal device,
+	// if any, is queried first.
+	if len(cfg.ExternalIPProbes) != 0 {
+		var sources []netann.ExternalIPSource
+		if s.natTraversal != nil {
+			sources = append(sources, s.natTraversal)
+		}
+		for _, url := range cfg.ExternalIPProbes {
+			sources = append(sources, netann.NewHTTPIPProbe(
+				url, defaultExternalIPProbeTimeout,
+			))
+		}
+
+		advertisedIPs := make(map[string]struct{})
+		for _, addr := range s.currentNodeAnn.Addresses {
+			advertisedIPs[addr.String()] = struct{}{}
+		}
+
+		s.addrDiscoverer = netann.NewAddrDiscoverer(
+			netann.AddrDiscovererConfig{
+				Sources:       sources,
+				Ports:         listenPorts,
+				RefreshTicker: ticker.New(defaultHostSampleInterval),
+				AdvertisedIPs: advertisedIPs,
+				AnnounceNewIPs: netann.IPAnnouncer(
+					s.updateSelfNodeAnn,
+				),
+			},
+		)
+	}
+
 	// Create liveness monitor.
 	s.createLivenessMonitor(cfg, cc)
 
@@ -1861,6 +1930,14 @@ func (s *server) Start() error {
 			cleanup = cleanup.add(s.hostAnn.Stop)
 		}
 
+		if s.addrDiscoverer != nil {
+			if err := s.addrDiscoverer.Start(); err != nil {
+				startErr = err
+				return
+			}
+			cleanup = cleanup.add(s.addrDiscoverer.Stop)
+		}
+
 		if s.livenessMonitor != nil {
 			if err := s.livenessMonitor.Start(); err != nil {
 				startErr = err
@@ -1938,12 +2015,6 @@ func (s *server) Start() error {
 		}
 		cleanup = cleanup.add(s.sweeper.Stop)
 
-		if err := s.utxoNursery.Start(); err != nil {
-			startErr = err
-			return
-		}
-		cleanup = cleanup.add(s.utxoNursery.Stop)
-
 		if err := s.breachArbiter.Start(); err != nil {
 			startErr = err
 			return
@@ -2251,9 +2322,6 @@ func (s *server) Stop() error {
 		if err := s.breachArbiter.Stop(); err != nil {
 			srvrLog.Warnf("failed to stop breachArbiter: %v", err)
 		}
-		if err := s.utxoNursery.Stop(); err != nil {
-			srvrLog.Warnf("failed to stop utxoNursery: %v", err)
-		}
 		if err := s.authGossiper.Stop(); err != nil {
 			srvrLog.Warnf("failed to stop authGossiper: %v", err)
 		}
@@ -2314,6 +2382,13 @@ func (s *server) Stop() error {
 			}
 		}
 
+		if s.addrDiscoverer != nil {
+			if err := s.addrDiscoverer.Stop(); err != nil {
+				srvrLog.Warnf("unable to shut down address "+
+					"discoverer: %v", err)
+			}
+		}
+
 		if s.livenessMonitor != nil {
 			if err := s.livenessMonitor.Stop(); err != nil {
 				srvrLog.Warnf("unable to shutdown liveness "+
@@ -2431,7 +2506,9 @@ out:
 				}
 			}
 
-			if ip.Equal(s.lastDetectedIP) {
+			// If the address discoverer is active, it takes care
+			// of announcing the external IP.
+			if s.addrDiscoverer != nil || ip.Equal(s.lastDetectedIP) {
 				continue
 			}
 
@@ -2491,22 +2568,15 @@ out:
 			// Then, we'll generate a new timestamped node
 			// announcement with the updated addresses and broadcast
 			// it to our peers.
-			newNodeAnn, err := s.genNodeAnnouncement(
+			err = s.updateAndBrodcastSelfNode(
 				nil, netann.NodeAnnSetAddrs(newAddrs),
 			)
 			if err != nil {
-				srvrLog.Debugf("Unable to generate new node "+
+				srvrLog.Debugf("Unable to update node "+
 					"announcement: %v", err)
 				continue
 			}
 
-			err = s.BroadcastMessage(nil, &newNodeAnn)
-			if err != nil {
-				srvrLog.Debugf("Unable to broadcast new node "+
-					"announcement to peers: %v", err)
-				continue
-			}
-
 			// Finally, update the last IP seen to the current one.
 			s.lastDetectedIP = ip
 		case <-s.quit:
@@ -2838,7 +2908,11 @@ func (s *server) initialPeerBootstrap(ignore map[autopilot.NodeID]struct{},
 }
 
 // createNewHiddenService automatically sets up a v2 or v3 onion service in
-// order to listen for inbound connections over Tor.
+// order to listen for inbound connections over Tor. It's called on startup and
+// each time the connection to Tor was re-established. If the onion service is
+// created with a different address than before, e.g. because its private key
+// was rotated, the previous address is replaced in our node announcement and
+// the new announcement is broadcast to the network.
 func (s *server) createNewHiddenService() error {
 	// Determine the different ports the server is listening on. The onion
 	// service's virtual port will map to these ports and one will be picked
@@ -2878,12 +2952,35 @@ func (s *server) createNewHiddenService() error {
 		return err
 	}
 
+	s.mu.Lock()
+	prevAddr := s.onionAddr
+	s.onionAddr = addr
+	s.mu.Unlock()
+
+	// If the onion service was re-created with the same address, our node
+	// announcement is still up to date.
+	if prevAddr != nil && prevAddr.String() == addr.String() {
+		return nil
+	}
+
+	// If we announced a different onion address before, the onion service
+	// was rotated at runtime, so we'll replace the stale address and let
+	// the network know about the new one right away.
+	if prevAddr != nil {
+		srvrLog.Infof("Onion service address changed from %v to %v, "+
+			"updating node announcement", prevAddr, addr)
+
+		return s.updateAndBrodcastSelfNode(
+			nil, netann.NodeAnnReplaceAddrs(
+				[]net.Addr{prevAddr}, []net.Addr{addr},
+			),
+		)
+	}
+
 	// Now that the onion service has been created, we'll add the onion
 	// address it can be reached at to our list of advertised addresses.
 	newNodeAnn, err := s.genNodeAnnouncement(
-		nil, func(currentAnn *lnwire.NodeAnnouncement) {
-			currentAnn.Addresses = append(currentAnn.Addresses, addr)
-		},
+		nil, netann.NodeAnnReplaceAddrs(nil, []net.Addr{addr}),
 	)
 	if err != nil {
 		return fmt.Errorf("unable to generate new node "+
@@ -3033,6 +3130,19 @@ func (s *server) updateAndBrodcastSelfNode(features *lnwire.RawFeatureVector,
 	return nil
 }
 
+// updateSelfNodeAnn applies the given modifiers to our node announcement,
+// persists and broadcasts it, and returns the new announcement. It is used by
+// the sub-systems that update our addresses at runtime.
+func (s *server) updateSelfNodeAnn(modifiers ...netann.NodeAnnModifier) (
+	lnwire.NodeAnnouncement, error) {
+
+	if err := s.updateAndBrodcastSelfNode(nil, modifiers...); err != nil {
+		return lnwire.NodeAnnouncement{}, err
+	}
+
+	return s.getNodeAnnouncement(), nil
+}
+
 type nodeAddresses struct {
 	pubKey    *btcec.PublicKey
 	addresses []net.Addr
@@ -3114,6 +3224,13 @@ func (s *server) establishPersistentConnections() error {
 				if s.cfg.Tor.Active {
 					addrSet[addr.String()] = addr
 				}
+
+			// Hostnames are only used if we're allowed to resolve
+			// them.
+			case *lnwire.DNSAddress:
+				if !s.cfg.NoDNSPeerAddrs {
+					addrSet[addr.String()] = addr
+				}
 			}
 		}
 
@@ -3132,6 +3249,13 @@ func (s *server) establishPersistentConnections() error {
 					if s.cfg.Tor.Active {
 						addrSet[lnAddress.String()] = lnAddress
 					}
+
+				// Hostnames are only used if we're allowed to
+				// resolve them.
+				case *lnwire.DNSAddress:
+					if !s.cfg.NoDNSPeerAddrs {
+						addrSet[lnAddress.String()] = lnAddress
+					}
 				}
 			}
 		}
@@ -3787,6 +3911,8 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 		}
 	}
 
+	fwdExpEndorsement := !s.cfg.ProtocolOptions.NoExpEndorsement()
+
 	// Now that we've established a connection, create a peer, and it to the
 	// set of currently active peers. Configure the peer with the incoming
 	// and outgoing broadcast deltas to prevent htlcs from being accepted or
@@ -3813,7 +3939,8 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 		AuthGossiper:            s.authGossiper,
 		ChanStatusMgr:           s.chanStatusMgr,
 		ChainIO:                 s.cc.ChainIO,
-		FeeEstimator:            s.cc.FeeEstimator,
+		FeeEstimator:            s.coopCloseFeeEstimator,
+		CommitFeeEstimator:      s.commitFeeEstimator,
 		Signer:                  s.cc.Wallet.Cfg.Signer,
 		SigPool:                 s.sigPool,
 		Wallet:                  s.cc.Wallet,
@@ -3846,11 +3973,14 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 		MaxOutgoingCltvExpiry:   s.cfg.MaxOutgoingCltvExpiry,
 		MaxChannelFeeAllocation: s.cfg.MaxChannelFeeAllocation,
 		CoopCloseTargetConfs:    s.cfg.CoopCloseTargetConfs,
-		MaxAnchorsCommitFeeRate: chainfee.SatPerKVByte(
-			s.cfg.MaxCommitFeeRateAnchors * 1000).FeePerKWeight(),
+		MaxAnchorsCommitFeeRate: chainfee.SatPerVByte(
+			s.cfg.MaxCommitFeeRateAnchors).FeePerKWeight(),
+		ReservedHtlcSlots:      s.cfg.Htlcswitch.ReservedHtlcSlots,
+		FwdExpEndorsement:      fwdExpEndorsement,
 		ChannelCommitInterval:  s.cfg.ChannelCommitInterval,
 		PendingCommitInterval:  s.cfg.PendingCommitInterval,
 		ChannelCommitBatchSize: s.cfg.ChannelCommitBatchSize,
+		ChannelCommitAdaptive:  s.cfg.ChannelCommitAdaptive,
 		HandleCustomMessage:    s.handleCustomMessage,
 		GetAliases:             s.aliasMgr.GetAliases,
 		RequestAlias:           s.aliasMgr.RequestAlias,
@@ -4578,11 +4708,22 @@ func (s *server) fetchNodeAdvertisedAddrs(pub *btcec.PublicKey) ([]net.Addr, err
 		return nil, err
 	}
 
-	if len(node.Addresses) == 0 {
+	// Skip any hostnames if we aren't allowed to resolve them.
+	addrs := make([]net.Addr, 0, len(node.Addresses))
+	for _, addr := range node.Addresses {
+		_, isDNSAddr := addr.(*lnwire.DNSAddress)
+		if isDNSAddr && s.cfg.NoDNSPeerAddrs {
+			continue
+		}
+
+		addrs = append(addrs, addr)
+	}
+
+	if len(addrs) == 0 {
 		return nil, errNoAdvertisedAddr
 	}
 
-	return node.Addresses, nil
+	return addrs, nil
 }
 
 // fetchLastChanUpdate returns a function which is able to retrieve our latest
@@ -4636,6 +4777,26 @@ func (s *server) applyChannelUpdate(update *lnwire.ChannelUpdate,
 	}
 }
 
+// propagateChanPolicyUpdate hands the new policies of our channels to the
+// channel status manager, which combines them with all other changes of the
+// channels made within the update batch window into a single update before
+// persisting and broadcasting it.
+func (s *server) propagateChanPolicyUpdate(
+	edgesToUpdate []discovery.EdgeWithInfo) error {
+
+	for _, edge := range edgesToUpdate {
+		err := s.chanStatusMgr.RequestPolicyUpdate(
+			edge.Info.ChannelPoint, netann.ChanUpdSetPolicy(edge.Edge),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to update policy of "+
+				"channel(%v): %w", edge.Info.ChannelPoint, err)
+		}
+	}
+
+	return nil
+}
+
 // SendCustomMessage sends a custom message to the peer with the specified
 // pubkey.
 func (s *server) SendCustomMessage(peerPub [33]byte, msgType lnwire.MessageType,
@@ -4698,3 +4859,15 @@ func shouldPeerBootstrap(cfg *Config) bool {
 	// covering the bootstrapping process.
 	return !cfg.NoNetBootstrap && !isDevNetwork
 }
+
+// newFeeFilter wraps the given estimator in a chainfee.Filter that clamps its
+// estimates to the given limits in sat/vbyte. A zero limit is ignored.
+func newFeeFilter(estimator chainfee.Estimator, minFeeRate,
+	maxFeeRate uint64) chainfee.Estimator {
+
+	return chainfee.NewFilter(
+		estimator,
+		chainfee.SatPerVByte(minFeeRate).FeePerKWeight(),
+		chainfee.SatPerVByte(maxFeeRate).FeePerKWeight(),
+	)
+}
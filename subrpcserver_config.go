@@ -8,6 +8,7 @@ import (
 	"github.com/btcsuite/btclog"
 	"github.com/ltcsuite/lnd/autopilot"
 	"github.com/ltcsuite/lnd/chainreg"
+	"github.com/ltcsuite/lnd/chanfitness"
 	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/lnd/htlcswitch"
 	"github.com/ltcsuite/lnd/invoices"
@@ -27,10 +28,12 @@ import (
 	"github.com/ltcsuite/lnd/macaroons"
 	"github.com/ltcsuite/lnd/netann"
 	"github.com/ltcsuite/lnd/routing"
+	"github.com/ltcsuite/lnd/routing/route"
 	"github.com/ltcsuite/lnd/sweep"
 	"github.com/ltcsuite/lnd/watchtower"
 	"github.com/ltcsuite/lnd/watchtower/wtclient"
 	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/wire"
 )
 
 // subRPCServerConfigs is special sub-config in the main configuration that
@@ -123,7 +126,9 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 		modifiers ...netann.NodeAnnModifier) error,
 	parseAddr func(addr string) (net.Addr, error),
 	rpcLogger btclog.Logger,
-	getAlias func(lnwire.ChannelID) (lnwire.ShortChannelID, error)) error {
+	getAlias func(lnwire.ChannelID) (lnwire.ShortChannelID, error),
+	getChanInfo func(wire.OutPoint, route.Vertex) (*chanfitness.ChannelInfo,
+		error)) error {
 
 	// First, we'll use reflect to obtain a version of the config struct
 	// that allows us to programmatically inspect its fields.
@@ -221,6 +226,9 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 			subCfgValue.FieldByName("Chain").Set(
 				reflect.ValueOf(cc.ChainIO),
 			)
+			subCfgValue.FieldByName("FeeEstimator").Set(
+				reflect.ValueOf(cc.FeeEstimator),
+			)
 
 		case *invoicesrpc.Config:
 			subCfgValue := extractReflectValue(subCfg)
@@ -262,6 +270,12 @@ func (s *subRPCServerConfigs) PopulateDependencies(cfg *Config,
 			subCfgValue.FieldByName("GetAlias").Set(
 				reflect.ValueOf(getAlias),
 			)
+			subCfgValue.FieldByName("StrictHopHints").Set(
+				reflect.ValueOf(cfg.Invoices.StrictHopHints),
+			)
+			subCfgValue.FieldByName("GetChanInfo").Set(
+				reflect.ValueOf(getChanInfo),
+			)
 
 		case *neutrinorpc.Config:
 			subCfgValue := extractReflectValue(subCfg)
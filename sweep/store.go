@@ -7,6 +7,7 @@ import (
 
 	"github.com/ltcsuite/lnd/kvdb"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/wire"
 )
 
@@ -14,7 +15,10 @@ var (
 	// txHashesBucketKey is the key that points to a bucket containing the
 	// hashes of all sweep txes that were published successfully.
 	//
-	// maps: txHash -> empty slice
+	// maps: txHash -> fee
+	//
+	// The fee is an 8-byte big endian amount. It is empty for txes that
+	// were stored by older versions or migrated from the nursery.
 	txHashesBucketKey = []byte("sweeper-tx-hashes")
 
 	// utxnChainPrefix is the bucket prefix for nursery buckets.
@@ -39,8 +43,13 @@ type SweeperStore interface {
 	// hash.
 	IsOurTx(hash chainhash.Hash) (bool, error)
 
-	// NotifyPublishTx signals that we are about to publish a tx.
-	NotifyPublishTx(*wire.MsgTx) error
+	// NotifyPublishTx signals that we are about to publish a tx that pays
+	// the given fee.
+	NotifyPublishTx(tx *wire.MsgTx, fee ltcutil.Amount) error
+
+	// FetchTxFee returns the fee of a tx published by us. It returns zero
+	// if the tx isn't ours or its fee is unknown.
+	FetchTxFee(hash chainhash.Hash) (ltcutil.Amount, error)
 
 	// ListSweeps lists all the sweeps we have successfully published.
 	ListSweeps() ([]chainhash.Hash, error)
@@ -147,10 +156,12 @@ func migrateTxHashes(tx kvdb.RwTx, txHashesBucket kvdb.RwBucket,
 	return nil
 }
 
-// NotifyPublishTx signals that we are about to publish a tx.
-func (s *sweeperStore) NotifyPublishTx(sweepTx *wire.MsgTx) error {
-	return kvdb.Update(s.db, func(tx kvdb.RwTx) error {
+// NotifyPublishTx signals that we are about to publish a tx that pays the
+// given fee.
+func (s *sweeperStore) NotifyPublishTx(sweepTx *wire.MsgTx,
+	fee ltcutil.Amount) error {
 
+	return kvdb.Update(s.db, func(tx kvdb.RwTx) error {
 		txHashesBucket := tx.ReadWriteBucket(txHashesBucketKey)
 		if txHashesBucket == nil {
 			return errNoTxHashesBucket
@@ -158,10 +169,43 @@ func (s *sweeperStore) NotifyPublishTx(sweepTx *wire.MsgTx) error {
 
 		hash := sweepTx.TxHash()
 
-		return txHashesBucket.Put(hash[:], []byte{})
+		var feeBytes [8]byte
+		byteOrder.PutUint64(feeBytes[:], uint64(fee))
+
+		return txHashesBucket.Put(hash[:], feeBytes[:])
 	}, func() {})
 }
 
+// FetchTxFee returns the fee of a tx published by us. It returns zero if the
+// tx isn't ours or its fee is unknown.
+func (s *sweeperStore) FetchTxFee(hash chainhash.Hash) (ltcutil.Amount,
+	error) {
+
+	var fee ltcutil.Amount
+
+	err := kvdb.View(s.db, func(tx kvdb.RTx) error {
+		txHashesBucket := tx.ReadBucket(txHashesBucketKey)
+		if txHashesBucket == nil {
+			return errNoTxHashesBucket
+		}
+
+		feeBytes := txHashesBucket.Get(hash[:])
+		if len(feeBytes) != 8 {
+			return nil
+		}
+		fee = ltcutil.Amount(byteOrder.Uint64(feeBytes))
+
+		return nil
+	}, func() {
+		fee = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return fee, nil
+}
+
 // IsOurTx determines whether a tx is published by us, based on its
 // hash.
 func (s *sweeperStore) IsOurTx(hash chainhash.Hash) (bool, error) {
@@ -2,19 +2,20 @@ package sweep
 
 import (
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/wire"
 )
 
 // MockSweeperStore is a mock implementation of sweeper store. This type is
 // exported, because it is currently used in nursery tests too.
 type MockSweeperStore struct {
-	ourTxes map[chainhash.Hash]struct{}
+	ourTxes map[chainhash.Hash]ltcutil.Amount
 }
 
 // NewMockSweeperStore returns a new instance.
 func NewMockSweeperStore() *MockSweeperStore {
 	return &MockSweeperStore{
-		ourTxes: make(map[chainhash.Hash]struct{}),
+		ourTxes: make(map[chainhash.Hash]ltcutil.Amount),
 	}
 }
 
@@ -25,14 +26,25 @@ func (s *MockSweeperStore) IsOurTx(hash chainhash.Hash) (bool, error) {
 	return ok, nil
 }
 
-// NotifyPublishTx signals that we are about to publish a tx.
-func (s *MockSweeperStore) NotifyPublishTx(tx *wire.MsgTx) error {
+// NotifyPublishTx signals that we are about to publish a tx that pays the
+// given fee.
+func (s *MockSweeperStore) NotifyPublishTx(tx *wire.MsgTx,
+	fee ltcutil.Amount) error {
+
 	txHash := tx.TxHash()
-	s.ourTxes[txHash] = struct{}{}
+	s.ourTxes[txHash] = fee
 
 	return nil
 }
 
+// FetchTxFee returns the fee of a tx published by us. It returns zero if the
+// tx isn't ours.
+func (s *MockSweeperStore) FetchTxFee(hash chainhash.Hash) (ltcutil.Amount,
+	error) {
+
+	return s.ourTxes[hash], nil
+}
+
 // ListSweeps lists all the sweeps we have successfully published.
 func (s *MockSweeperStore) ListSweeps() ([]chainhash.Hash, error) {
 	var txns []chainhash.Hash
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ltcsuite/lnd/channeldb"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/wire"
 	"github.com/stretchr/testify/require"
 )
@@ -50,7 +51,7 @@ func testStore(t *testing.T, createStore func() (SweeperStore, error)) {
 		},
 	})
 
-	err = store.NotifyPublishTx(&tx1)
+	err = store.NotifyPublishTx(&tx1, 1000)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -63,7 +64,7 @@ func testStore(t *testing.T, createStore func() (SweeperStore, error)) {
 		},
 	})
 
-	err = store.NotifyPublishTx(&tx2)
+	err = store.NotifyPublishTx(&tx2, 2000)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -91,6 +92,15 @@ func testStore(t *testing.T, createStore func() (SweeperStore, error)) {
 		t.Fatal("expected tx to be ours")
 	}
 
+	// The fees of both txes are known.
+	fee, err := store.FetchTxFee(tx1.TxHash())
+	require.NoError(t, err)
+	require.Equal(t, ltcutil.Amount(1000), fee)
+
+	fee, err = store.FetchTxFee(tx2.TxHash())
+	require.NoError(t, err)
+	require.Equal(t, ltcutil.Amount(2000), fee)
+
 	// An different hash should be reported as not being ours.
 	var unknownHash chainhash.Hash
 	ours, err = store.IsOurTx(unknownHash)
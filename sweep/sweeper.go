@@ -15,17 +15,13 @@ import (
 	"github.com/ltcsuite/lnd/labels"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/lnwallet/chainfee"
+	"github.com/ltcsuite/lnd/lnwallet/chanfunding"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
 	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/wire"
 )
 
 const (
-	// DefaultMaxFeeRate is the default maximum fee rate allowed within the
-	// UtxoSweeper. The current value is equivalent to a fee rate of 10,000
-	// sat/vbyte.
-	DefaultMaxFeeRate = chainfee.FeePerKwFloor * 1e4
-
 	// DefaultFeeRateBucketSize is the default size of fee rate buckets
 	// we'll use when clustering inputs into buckets with similar fee rates
 	// within the UtxoSweeper.
@@ -37,8 +33,20 @@ const (
 	//   #1: min = 1 sat/vbyte, max = 10 sat/vbyte
 	//   #2: min = 11 sat/vbyte, max = 20 sat/vbyte...
 	DefaultFeeRateBucketSize = 10
+
+	// maxFeeRateMultiplier is the multiple of the fee floor of the active
+	// chain that is used as the default maximum fee rate.
+	maxFeeRateMultiplier = 1e4
 )
 
+// DefaultMaxFeeRate returns the default maximum fee rate allowed within the
+// UtxoSweeper. It is derived from the fee floor of the active chain, so it
+// must only be called once the floor has been selected. With the default
+// floor, it is equivalent to a fee rate of 10,000 sat/vbyte.
+func DefaultMaxFeeRate() chainfee.SatPerKWeight {
+	return chainfee.FeeFloor() * maxFeeRateMultiplier
+}
+
 var (
 	// ErrRemoteSpend is returned in case an output that we try to sweep is
 	// confirmed in a tx of the remote party.
@@ -62,6 +70,11 @@ var (
 	// it is/has already been stopped.
 	ErrSweeperShuttingDown = errors.New("utxo sweeper shutting down")
 
+	// ErrBudgetExhausted is returned in case an input that is allowed to
+	// be abandoned can't be swept within its budget, even at the minimum
+	// relay fee rate.
+	ErrBudgetExhausted = errors.New("sweep budget exhausted")
+
 	// DefaultMaxSweepAttempts specifies the default maximum number of times
 	// an input is included in a publish attempt before giving up and
 	// returning an error to the caller.
@@ -82,6 +95,27 @@ type Params struct {
 	// ExclusiveGroup is an identifier that, if set, prevents other inputs
 	// with the same identifier from being batched together.
 	ExclusiveGroup *uint64
+
+	// DeadlineHeight is the block height by which the input should be
+	// confirmed. If set and the fee preference is a conf target, the conf
+	// target is capped to the number of blocks left until the deadline,
+	// so the input is swept more aggressively as the deadline approaches.
+	// A value of zero means there's no deadline.
+	DeadlineHeight int32
+
+	// Budget is the maximum fee the input may contribute to a sweep
+	// transaction. For inputs with an unconfirmed parent, the budget also
+	// covers the fee that is needed to bump the parent. The fee rate used
+	// for the input is capped so that the budget is never exceeded, but
+	// never below the minimum relay fee rate. A value of zero means there's
+	// no budget.
+	Budget ltcutil.Amount
+
+	// AbandonUneconomical indicates that the input should be given up on
+	// once its budget doesn't cover the fee of sweeping it at the minimum
+	// relay fee rate. The listeners of such an input receive
+	// ErrBudgetExhausted. This only has an effect if a budget is set.
+	AbandonUneconomical bool
 }
 
 // ParamsUpdate contains a new set of parameters to update a pending sweep with.
@@ -94,17 +128,32 @@ type ParamsUpdate struct {
 	// Force indicates whether the input should be swept regardless of
 	// whether it is economical to do so.
 	Force bool
+
+	// DeadlineHeight is the block height by which the input should be
+	// confirmed. If non-zero, it replaces the deadline height of the
+	// input's parameters, otherwise that one is kept.
+	DeadlineHeight int32
 }
 
 // String returns a human readable interpretation of the sweep parameters.
 func (p Params) String() string {
 	if p.ExclusiveGroup != nil {
-		return fmt.Sprintf("fee=%v, force=%v, exclusive_group=%v",
-			p.Fee, p.Force, *p.ExclusiveGroup)
+		return fmt.Sprintf("fee=%v, force=%v, exclusive_group=%v, "+
+			"deadline_height=%v, budget=%v, abandon=%v", p.Fee,
+			p.Force, *p.ExclusiveGroup, p.DeadlineHeight, p.Budget,
+			p.AbandonUneconomical)
 	}
 
-	return fmt.Sprintf("fee=%v, force=%v, exclusive_group=nil",
-		p.Fee, p.Force)
+	return fmt.Sprintf("fee=%v, force=%v, exclusive_group=nil, "+
+		"deadline_height=%v, budget=%v, abandon=%v", p.Fee, p.Force,
+		p.DeadlineHeight, p.Budget, p.AbandonUneconomical)
+}
+
+// feePreference returns the fee preference to use for the input at the given
+// height. If the input has a deadline that's closer than its conf target, the
+// number of blocks left until the deadline is used as conf target instead.
+func (p Params) feePreference(currentHeight int32) FeePreference {
+	return p.Fee.WithDeadline(p.DeadlineHeight, currentHeight)
 }
 
 // pendingInput is created when an input reaches the main loop for the first
@@ -135,6 +184,15 @@ type pendingInput struct {
 	// lastFeeRate is the most recent fee rate used for this input within a
 	// transaction broadcast to the network.
 	lastFeeRate chainfee.SatPerKWeight
+
+	// lastSweepTx is the hash of the most recent sweep transaction that
+	// included this input. It is used to find the sibling inputs of a
+	// sweep that got invalidated by a third-party spend.
+	lastSweepTx *chainhash.Hash
+
+	// sweepTxs holds the hashes of all sweep transactions that included
+	// this input, oldest first.
+	sweepTxs []chainhash.Hash
 }
 
 // parameters returns the sweep parameters for this input.
@@ -189,6 +247,10 @@ type PendingInput struct {
 
 	// Params contains the sweep parameters for this pending request.
 	Params Params
+
+	// SweepTxs holds the hashes of all transactions broadcast to sweep the
+	// input, oldest first.
+	SweepTxs []chainhash.Hash
 }
 
 // updateReq is an internal message we'll use to represent an external caller's
@@ -236,6 +298,9 @@ type UtxoSweeper struct {
 
 	currentOutputScript []byte
 
+	// relayFeeRate is the relay fee rate of the backend as of the last
+	// block. It is used to bucket inputs by fee rate and is only accessed
+	// by the collector goroutine.
 	relayFeeRate chainfee.SatPerKWeight
 
 	quit chan struct{}
@@ -277,6 +342,10 @@ type UtxoSweeperConfig struct {
 	// created and published.
 	MaxInputsPerTx int
 
+	// MaxSweepWeight specifies the maximum weight of a single sweep tx.
+	// Inputs that don't fit are swept in additional transactions.
+	MaxSweepWeight int64
+
 	// MaxSweepAttempts specifies the maximum number of times an input is
 	// included in a publish attempt before giving up and returning an error
 	// to the caller.
@@ -290,6 +359,11 @@ type UtxoSweeperConfig struct {
 	// UtxoSweeper.
 	MaxFeeRate chainfee.SatPerKWeight
 
+	// CoinSelectionStrategy is the strategy used to select wallet utxos
+	// that are added to sweeps which can't pay for their own fees. If nil,
+	// the smallest utxos are selected first to avoid locking large ones.
+	CoinSelectionStrategy chanfunding.CoinSelectionStrategy
+
 	// FeeRateBucketSize is the default size of fee rate buckets we'll use
 	// when clustering inputs into buckets with similar fee rates within the
 	// UtxoSweeper.
@@ -314,6 +388,11 @@ type Result struct {
 
 	// Tx is the transaction that spent the input.
 	Tx *wire.MsgTx
+
+	// Fee is the fee paid by Tx. It is shared by all inputs that were
+	// swept in the same tx and is zero if Tx isn't ours or its fee is
+	// unknown.
+	Fee ltcutil.Amount
 }
 
 // sweepInputMessage structs are used in the internal channel between the
@@ -345,8 +424,8 @@ func (s *UtxoSweeper) Start() error {
 
 	log.Info("Sweeper starting")
 
-	// Retrieve relay fee for dust limit calculation. Assume that this will
-	// not change from here on.
+	// Retrieve relay fee for fee rate bucketing. It is refreshed on every
+	// new block as the backend's relay policy may change at runtime.
 	s.relayFeeRate = s.cfg.FeeEstimator.RelayFeePerKW()
 
 	// We need to register for block epochs and retry sweeping every block.
@@ -397,9 +476,10 @@ func (s *UtxoSweeper) Start() error {
 }
 
 // RelayFeePerKW returns the minimum fee rate required for transactions to be
-// relayed.
+// relayed. The value is queried from the fee estimator so that it reflects the
+// current relay policy of the backend.
 func (s *UtxoSweeper) RelayFeePerKW() chainfee.SatPerKWeight {
-	return s.relayFeeRate
+	return s.cfg.FeeEstimator.RelayFeePerKW()
 }
 
 // Stop stops sweeper from listening to block epochs and constructing sweep
@@ -480,9 +560,10 @@ func (s *UtxoSweeper) feeRateForPreference(
 	if err != nil {
 		return 0, err
 	}
-	if feeRate < s.relayFeeRate {
+	relayFeeRate := s.RelayFeePerKW()
+	if feeRate < relayFeeRate {
 		return 0, fmt.Errorf("fee preference resulted in invalid fee "+
-			"rate %v, minimum is %v", feeRate, s.relayFeeRate)
+			"rate %v, minimum is %v", feeRate, relayFeeRate)
 	}
 	if feeRate > s.cfg.MaxFeeRate {
 		return 0, fmt.Errorf("fee preference resulted in invalid fee "+
@@ -492,6 +573,100 @@ func (s *UtxoSweeper) feeRateForPreference(
 	return feeRate, nil
 }
 
+// inputFeeRate returns the fee rate to sweep the given input with at the given
+// height. The fee rate is derived from the input's fee preference and capped
+// by its budget, if any.
+func (s *UtxoSweeper) inputFeeRate(inp *pendingInput,
+	currentHeight int32) (chainfee.SatPerKWeight, error) {
+
+	feeRate, err := s.feeRateForPreference(
+		inp.params.feePreference(currentHeight),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if inp.params.Budget == 0 {
+		return feeRate, nil
+	}
+
+	weight, budget, err := packageBudget(inp)
+	if err != nil {
+		return 0, err
+	}
+
+	maxFeeRate := chainfee.SatPerKWeight(budget*1000) /
+		chainfee.SatPerKWeight(weight)
+	if feeRate <= maxFeeRate {
+		return feeRate, nil
+	}
+
+	// We can't go below the relay fee rate, as the sweep transaction
+	// wouldn't propagate otherwise.
+	if relayFeeRate := s.RelayFeePerKW(); maxFeeRate < relayFeeRate {
+		maxFeeRate = relayFeeRate
+	}
+
+	log.Debugf("Capping fee rate of input %v from %v to %v to stay "+
+		"within budget %v", inp.OutPoint(), feeRate, maxFeeRate,
+		inp.params.Budget)
+
+	return maxFeeRate, nil
+}
+
+// packageBudget returns the weight the given input adds to a sweep
+// transaction and the budget that is available to pay for it. If the input is
+// used to bump an unconfirmed parent, the budget needs to cover the fee of the
+// package, minus what the parent already paid.
+func packageBudget(inp *pendingInput) (int64, ltcutil.Amount, error) {
+	var estimator input.TxWeightEstimator
+	err := inp.WitnessType().AddWeightEstimation(&estimator)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	weight := int64(estimator.Weight())
+	budget := inp.params.Budget
+	if parent := inp.UnconfParent(); parent != nil {
+		weight += parent.Weight
+		budget += parent.Fee
+	}
+
+	return weight, budget, nil
+}
+
+// abandonUneconomicalInputs removes all pending inputs that may be abandoned
+// and whose budget doesn't cover the fee of sweeping them at the current relay
+// fee rate. Their listeners are signaled with ErrBudgetExhausted.
+func (s *UtxoSweeper) abandonUneconomicalInputs() {
+	relayFeeRate := s.RelayFeePerKW()
+
+	for op, inp := range s.pendingInputs {
+		if !inp.params.AbandonUneconomical || inp.params.Budget == 0 {
+			continue
+		}
+
+		weight, budget, err := packageBudget(inp)
+		if err != nil {
+			log.Warnf("Unable to determine budget of input %v: %v",
+				op, err)
+			continue
+		}
+
+		minFee := relayFeeRate.FeeForWeight(weight)
+		if minFee <= budget {
+			continue
+		}
+
+		log.Infof("Abandoning input %v: fee %v at relay fee rate %v "+
+			"exceeds budget %v", op, minFee, relayFeeRate,
+			inp.params.Budget)
+
+		op := op
+		s.signalAndRemove(&op, Result{Err: ErrBudgetExhausted})
+	}
+}
+
 // removeLastSweepDescendants removes any transactions from the wallet that
 // spend outputs produced by the passed spendingTx. This needs to be done in
 // cases where we're not the only ones that can sweep an output, but there may
@@ -685,6 +860,17 @@ func (s *UtxoSweeper) collector(blockEpochs <-chan *chainntnfs.BlockEpoch) {
 				continue
 			}
 
+			// Look up the fee we paid for our own tx, so that the
+			// callers can account for it.
+			var fee ltcutil.Amount
+			if isOurTx {
+				fee, err = s.cfg.Store.FetchTxFee(spendHash)
+				if err != nil {
+					log.Errorf("cannot fetch fee of tx "+
+						"%v: %v", spendHash, err)
+				}
+			}
+
 			// If this isn't our transaction, it means someone else
 			// swept outputs that we were attempting to sweep. This
 			// can happen for anchor outputs as well as justice
@@ -728,12 +914,22 @@ func (s *UtxoSweeper) collector(blockEpochs <-chan *chainntnfs.BlockEpoch) {
 				var err error
 				if !isOurTx {
 					err = ErrRemoteSpend
+
+					// The sweep tx this input was part
+					// of can no longer confirm, so the
+					// other inputs it contained can be
+					// retried right away.
+					s.resetSweepSiblings(
+						outpoint, input.lastSweepTx,
+						bestHeight,
+					)
 				}
 
 				// Signal result channels.
 				s.signalAndRemove(&outpoint, Result{
 					Tx:  spend.SpendingTx,
 					Err: err,
+					Fee: fee,
 				})
 
 				// Remove all other inputs in this exclusive
@@ -779,7 +975,7 @@ func (s *UtxoSweeper) collector(blockEpochs <-chan *chainntnfs.BlockEpoch) {
 			// this to ensure any inputs which have had their fee
 			// rate bumped are broadcast first in order enforce the
 			// RBF policy.
-			inputClusters := s.createInputClusters()
+			inputClusters := s.createInputClusters(bestHeight)
 			sort.Slice(inputClusters, func(i, j int) bool {
 				return inputClusters[i].sweepFeeRate >
 					inputClusters[j].sweepFeeRate
@@ -804,6 +1000,20 @@ func (s *UtxoSweeper) collector(blockEpochs <-chan *chainntnfs.BlockEpoch) {
 			log.Debugf("New block: height=%v, sha=%v",
 				epoch.Height, epoch.Hash)
 
+			// Refresh the relay fee rate, the backend may have
+			// raised its floor due to mempool congestion.
+			relayFeeRate := s.cfg.FeeEstimator.RelayFeePerKW()
+			if relayFeeRate != s.relayFeeRate {
+				log.Infof("Relay fee rate changed from %v to %v",
+					s.relayFeeRate, relayFeeRate)
+
+				s.relayFeeRate = relayFeeRate
+			}
+
+			// Give up on the inputs that can no longer be swept
+			// within their budget before we try to sweep again.
+			s.abandonUneconomicalInputs()
+
 			if err := s.scheduleSweep(bestHeight); err != nil {
 				log.Errorf("schedule sweep: %v", err)
 			}
@@ -814,6 +1024,38 @@ func (s *UtxoSweeper) collector(blockEpochs <-chan *chainntnfs.BlockEpoch) {
 	}
 }
 
+// resetSweepSiblings makes all pending inputs that were part of the given sweep
+// transaction, other than the passed outpoint, immediately eligible for
+// publication again. This is called when the outpoint was spent by a third
+// party, which means that our sweep transaction was replaced and the remaining
+// inputs shouldn't have to wait for their back-off period to expire.
+func (s *UtxoSweeper) resetSweepSiblings(spent wire.OutPoint,
+	sweepTx *chainhash.Hash, bestHeight int32) {
+
+	// The input was never part of a published sweep, so there are no
+	// siblings to reset.
+	if sweepTx == nil {
+		return
+	}
+
+	for outpoint, input := range s.pendingInputs {
+		if outpoint == spent || input.lastSweepTx == nil {
+			continue
+		}
+
+		if *input.lastSweepTx != *sweepTx {
+			continue
+		}
+
+		log.Debugf("Input %v was swept together with remotely "+
+			"spent input %v in tx %v, rescheduling at height %v",
+			outpoint, spent, sweepTx, bestHeight)
+
+		input.minPublishHeight = bestHeight
+		input.lastSweepTx = nil
+	}
+}
+
 // removeExclusiveGroup removes all inputs in the given exclusive group. This
 // function is called when one of the exclusive group inputs has been spent. The
 // other inputs won't ever be spendable and can be removed. This also prevents
@@ -886,16 +1128,25 @@ func (s *UtxoSweeper) bucketForFeeRate(
 // inputs known by the UtxoSweeper. It clusters inputs by
 // 1) Required tx locktime
 // 2) Similar fee rates.
-func (s *UtxoSweeper) createInputClusters() []inputCluster {
+//
+// The fee rates are determined at the given height, which matters for inputs
+// that have a deadline.
+func (s *UtxoSweeper) createInputClusters(
+	currentHeight int32) []inputCluster {
+
 	inputs := s.pendingInputs
 
 	// We start by getting the inputs clusters by locktime. Since the
 	// inputs commit to the locktime, they can only be clustered together
 	// if the locktime is equal.
-	lockTimeClusters, nonLockTimeInputs := s.clusterByLockTime(inputs)
+	lockTimeClusters, nonLockTimeInputs := s.clusterByLockTime(
+		inputs, currentHeight,
+	)
 
 	// Cluster the the remaining inputs by sweep fee rate.
-	feeClusters := s.clusterBySweepFeeRate(nonLockTimeInputs)
+	feeClusters := s.clusterBySweepFeeRate(
+		nonLockTimeInputs, currentHeight,
+	)
 
 	// Since the inputs that we clustered by fee rate don't commit to a
 	// specific locktime, we can try to merge a locktime cluster with a fee
@@ -908,8 +1159,8 @@ func (s *UtxoSweeper) createInputClusters() []inputCluster {
 // is determined by calculating the average fee rate of all inputs within that
 // cluster. In addition to the created clusters, inputs that did not specify a
 // required lock time are returned.
-func (s *UtxoSweeper) clusterByLockTime(inputs pendingInputs) ([]inputCluster,
-	pendingInputs) {
+func (s *UtxoSweeper) clusterByLockTime(inputs pendingInputs,
+	currentHeight int32) ([]inputCluster, pendingInputs) {
 
 	locktimes := make(map[uint32]pendingInputs)
 	inputFeeRates := make(map[wire.OutPoint]chainfee.SatPerKWeight)
@@ -933,7 +1184,7 @@ func (s *UtxoSweeper) clusterByLockTime(inputs pendingInputs) ([]inputCluster,
 		locktimes[lt] = p
 
 		// We also get the preferred fee rate for this input.
-		feeRate, err := s.feeRateForPreference(input.params.Fee)
+		feeRate, err := s.inputFeeRate(input, currentHeight)
 		if err != nil {
 			log.Warnf("Skipping input %v: %v", op, err)
 			continue
@@ -969,14 +1220,16 @@ func (s *UtxoSweeper) clusterByLockTime(inputs pendingInputs) ([]inputCluster,
 // and clusters those together with similar fee rates. Each cluster contains a
 // sweep fee rate, which is determined by calculating the average fee rate of
 // all inputs within that cluster.
-func (s *UtxoSweeper) clusterBySweepFeeRate(inputs pendingInputs) []inputCluster {
+func (s *UtxoSweeper) clusterBySweepFeeRate(inputs pendingInputs,
+	currentHeight int32) []inputCluster {
+
 	bucketInputs := make(map[int]*bucketList)
 	inputFeeRates := make(map[wire.OutPoint]chainfee.SatPerKWeight)
 
 	// First, we'll group together all inputs with similar fee rates. This
 	// is done by determining the fee rate bucket they should belong in.
 	for op, input := range inputs {
-		feeRate, err := s.feeRateForPreference(input.params.Fee)
+		feeRate, err := s.inputFeeRate(input, currentHeight)
 		if err != nil {
 			log.Warnf("Skipping input %v: %v", op, err)
 			continue
@@ -1142,7 +1395,7 @@ func (s *UtxoSweeper) scheduleSweep(currentHeight int32) error {
 
 	// We'll only start our timer once we have inputs we're able to sweep.
 	startTimer := false
-	for _, cluster := range s.createInputClusters() {
+	for _, cluster := range s.createInputClusters(currentHeight) {
 		// Examine pending inputs and try to construct lists of inputs.
 		// We don't need to obtain the coin selection lock, because we
 		// just need an indication as to whether we can sweep. More
@@ -1259,7 +1512,8 @@ func (s *UtxoSweeper) getInputLists(cluster inputCluster,
 		allSets, err = generateInputPartitionings(
 			append(retryInputs, newInputs...),
 			cluster.sweepFeeRate, s.cfg.MaxInputsPerTx,
-			s.cfg.Wallet,
+			s.cfg.MaxSweepWeight, s.cfg.Wallet,
+			s.cfg.CoinSelectionStrategy,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("input partitionings: %v", err)
@@ -1269,7 +1523,7 @@ func (s *UtxoSweeper) getInputLists(cluster inputCluster,
 	// Create sets for just the new inputs.
 	newSets, err := generateInputPartitionings(
 		newInputs, cluster.sweepFeeRate, s.cfg.MaxInputsPerTx,
-		s.cfg.Wallet,
+		s.cfg.MaxSweepWeight, s.cfg.Wallet, s.cfg.CoinSelectionStrategy,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("input partitionings: %v", err)
@@ -1283,6 +1537,25 @@ func (s *UtxoSweeper) getInputLists(cluster inputCluster,
 	return append(allSets, newSets...), nil
 }
 
+// sweepTxFee returns the fee paid by a sweep tx that spends (a subset of) the
+// given inputs.
+func sweepTxFee(tx *wire.MsgTx, inputs inputSet) ltcutil.Amount {
+	values := make(map[wire.OutPoint]int64, len(inputs))
+	for _, inp := range inputs {
+		values[*inp.OutPoint()] = inp.SignDesc().Output.Value
+	}
+
+	var fee int64
+	for _, txIn := range tx.TxIn {
+		fee += values[txIn.PreviousOutPoint]
+	}
+	for _, txOut := range tx.TxOut {
+		fee -= txOut.Value
+	}
+
+	return ltcutil.Amount(fee)
+}
+
 // sweep takes a set of preselected inputs, creates a sweep tx and publishes the
 // tx. The output address is only marked as used if the publish succeeds.
 func (s *UtxoSweeper) sweep(inputs inputSet, feeRate chainfee.SatPerKWeight,
@@ -1311,7 +1584,7 @@ func (s *UtxoSweeper) sweep(inputs inputSet, feeRate chainfee.SatPerKWeight,
 	// publish, we loose track of this tx. Even republication on startup
 	// doesn't prevent this, because that call returns a double spend error
 	// then and would also not add the hash to the store.
-	err = s.cfg.Store.NotifyPublishTx(tx)
+	err = s.cfg.Store.NotifyPublishTx(tx, sweepTxFee(tx, inputs))
 	if err != nil {
 		return fmt.Errorf("notify publish tx: %v", err)
 	}
@@ -1347,6 +1620,7 @@ func (s *UtxoSweeper) sweep(inputs inputSet, feeRate chainfee.SatPerKWeight,
 	}
 
 	// Reschedule sweep.
+	sweepHash := tx.TxHash()
 	for _, input := range tx.TxIn {
 		pi, ok := s.pendingInputs[input.PreviousOutPoint]
 		if !ok {
@@ -1360,6 +1634,8 @@ func (s *UtxoSweeper) sweep(inputs inputSet, feeRate chainfee.SatPerKWeight,
 
 		// Record another publish attempt.
 		pi.publishAttempts++
+		pi.lastSweepTx = &sweepHash
+		pi.sweepTxs = append(pi.sweepTxs, sweepHash)
 
 		// We don't care what the result of the publish call was. Even
 		// if it is published successfully, it can still be that it
@@ -1479,6 +1755,9 @@ func (s *UtxoSweeper) handlePendingSweepsReq(
 			BroadcastAttempts:   pendingInput.publishAttempts,
 			NextBroadcastHeight: uint32(pendingInput.minPublishHeight),
 			Params:              pendingInput.params,
+			SweepTxs: append(
+				[]chainhash.Hash(nil), pendingInput.sweepTxs...,
+			),
 		}
 	}
 
@@ -1550,6 +1829,9 @@ func (s *UtxoSweeper) handleUpdateReq(req *updateReq, bestHeight int32) (
 	newParams := pendingInput.params
 	newParams.Fee = req.params.Fee
 	newParams.Force = req.params.Force
+	if req.params.DeadlineHeight != 0 {
+		newParams.DeadlineHeight = req.params.DeadlineHeight
+	}
 
 	log.Debugf("Updating sweep parameters for %v from %v to %v", req.input,
 		pendingInput.params, newParams)
@@ -142,12 +142,13 @@ func createSweeperTestContext(t *testing.T) *sweeperTestContext {
 		},
 		FeeEstimator:     estimator,
 		MaxInputsPerTx:   testMaxInputsPerTx,
+		MaxSweepWeight:   DefaultMaxSweepWeight,
 		MaxSweepAttempts: testMaxSweepAttempts,
 		NextAttemptDeltaFunc: func(attempts int) int32 {
 			// Use delta func without random factor.
 			return 1 << uint(attempts-1)
 		},
-		MaxFeeRate:        DefaultMaxFeeRate,
+		MaxFeeRate:        DefaultMaxFeeRate(),
 		FeeRateBucketSize: DefaultFeeRateBucketSize,
 	})
 
@@ -399,6 +400,11 @@ func TestSuccess(t *testing.T) {
 		if result.Tx.TxHash() != sweepTx.TxHash() {
 			t.Fatalf("expected sweep tx ")
 		}
+
+		// The result reports the fee that the sweep tx paid.
+		fee := spendableInputs[0].SignDesc().Output.Value -
+			sweepTx.TxOut[0].Value
+		require.EqualValues(t, fee, result.Fee)
 	case <-time.After(5 * time.Second):
 		t.Fatalf("no result received")
 	}
@@ -695,6 +701,107 @@ func testRemoteSpend(t *testing.T, postSweep bool) {
 	}
 }
 
+// TestRemoteSpendReplacesSweep asserts that when a third party spends one of
+// the inputs of a published sweep, the remaining inputs of that sweep are
+// retried right away instead of waiting for their back-off to expire.
+func TestRemoteSpendReplacesSweep(t *testing.T) {
+	ctx := createSweeperTestContext(t)
+
+	resultChan1, err := ctx.sweeper.SweepInput(
+		spendableInputs[0], defaultFeePref,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resultChan2, err := ctx.sweeper.SweepInput(
+		spendableInputs[1], defaultFeePref,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx.tick()
+
+	sweepTx := ctx.receiveTx()
+	if len(sweepTx.TxIn) != 2 {
+		t.Fatalf("expected sweep of 2 inputs, got %v",
+			len(sweepTx.TxIn))
+	}
+
+	// Replace our sweep with a remote tx that only spends the first input.
+	ctx.backend.deleteUnconfirmed(sweepTx.TxHash())
+
+	remoteTx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{
+			{
+				PreviousOutPoint: *(spendableInputs[0].OutPoint()),
+			},
+		},
+	}
+	err = ctx.backend.publishTransaction(remoteTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx.backend.mine()
+
+	ctx.expectResult(resultChan1, ErrRemoteSpend)
+
+	// Without a new block, the remaining input should be swept again
+	// because its previous sweep was invalidated.
+	ctx.tick()
+
+	sweepTx = ctx.receiveTx()
+	assertTxSweepsInputs(t, &sweepTx, spendableInputs[1])
+
+	ctx.backend.mine()
+
+	ctx.expectResult(resultChan2, nil)
+
+	ctx.finish(1)
+}
+
+// TestDynamicRelayFee asserts that the sweeper picks up changes of the
+// backend's relay fee at runtime.
+func TestDynamicRelayFee(t *testing.T) {
+	ctx := createSweeperTestContext(t)
+
+	require.Equal(t, chainfee.FeePerKwFloor, ctx.sweeper.RelayFeePerKW())
+
+	// The backend raises its relay fee due to mempool congestion.
+	const newRelayFee = chainfee.SatPerKWeight(1000)
+	ctx.estimator.updateFees(10000, newRelayFee)
+	require.Equal(t, newRelayFee, ctx.sweeper.RelayFeePerKW())
+
+	// A fee rate that was valid before is now rejected as it can't be
+	// relayed anymore.
+	lowFeePref := Params{
+		Fee: FeePreference{FeeRate: chainfee.FeePerKwFloor + 1},
+	}
+	_, err := ctx.sweeper.SweepInput(spendableInputs[0], lowFeePref)
+	require.Error(t, err)
+
+	// A fee rate at the new floor is accepted and swept.
+	resultChan, err := ctx.sweeper.SweepInput(
+		spendableInputs[0], Params{
+			Fee: FeePreference{FeeRate: newRelayFee},
+		},
+	)
+	require.NoError(t, err)
+
+	ctx.tick()
+
+	sweepTx := ctx.receiveTx()
+	assertTxSweepsInputs(t, &sweepTx, spendableInputs[0])
+
+	ctx.backend.mine()
+
+	ctx.expectResult(resultChan, nil)
+
+	ctx.finish(1)
+}
+
 // TestIdempotency asserts that offering the same input multiple times is
 // handled correctly.
 func TestIdempotency(t *testing.T) {
@@ -1186,7 +1293,7 @@ func TestBumpFeeRBF(t *testing.T) {
 
 	// We'll then attempt to bump its fee rate.
 	highFeePref := FeePreference{ConfTarget: 6}
-	highFeeRate := DefaultMaxFeeRate
+	highFeeRate := DefaultMaxFeeRate()
 	ctx.estimator.blocksToFee[highFeePref.ConfTarget] = highFeeRate
 
 	// We should expect to see an error if a fee preference isn't provided.
@@ -1205,6 +1312,15 @@ func TestBumpFeeRBF(t *testing.T) {
 	highFeeTx := ctx.receiveTx()
 	assertTxFeeRate(t, &highFeeTx, highFeeRate, changePk, &input)
 
+	// Both the replaced and the replacing transaction are reported for
+	// the pending input.
+	pendingInputs, err := ctx.sweeper.PendingInputs()
+	require.NoError(t, err)
+	require.Equal(
+		t, []chainhash.Hash{lowFeeTx.TxHash(), highFeeTx.TxHash()},
+		pendingInputs[*input.OutPoint()].SweepTxs,
+	)
+
 	// We'll finish our test by mining the sweep transaction.
 	ctx.backend.mine()
 	ctx.expectResult(sweepResult, nil)
@@ -1213,6 +1329,59 @@ func TestBumpFeeRBF(t *testing.T) {
 	ctx.finish(1)
 }
 
+// TestBumpFeeDeadline ensures that a fee bump can set a deadline for an input
+// the UtxoSweeper is currently attempting to sweep, which caps its conf target
+// to the number of blocks left until the deadline.
+func TestBumpFeeDeadline(t *testing.T) {
+	ctx := createSweeperTestContext(t)
+
+	lowFeePref := FeePreference{ConfTarget: 144}
+	lowFeeRate := chainfee.FeePerKwFloor
+	ctx.estimator.blocksToFee[lowFeePref.ConfTarget] = lowFeeRate
+
+	input := createTestInput(
+		ltcutil.SatoshiPerBitcoin, input.CommitmentTimeLock,
+	)
+	sweepResult, err := ctx.sweeper.SweepInput(
+		&input, Params{Fee: lowFeePref},
+	)
+	require.NoError(t, err)
+
+	changePk, err := ctx.sweeper.cfg.GenSweepScript()
+	require.NoError(t, err)
+
+	// Without a deadline, the input is swept with the conf target of its
+	// fee preference.
+	ctx.tick()
+	lowFeeTx := ctx.receiveTx()
+	assertTxFeeRate(t, &lowFeeTx, lowFeeRate, changePk, &input)
+
+	// We'll now keep the conf target, but set a deadline that is closer.
+	const blocksLeft = 6
+	highFeeRate := DefaultMaxFeeRate()
+	ctx.estimator.blocksToFee[blocksLeft] = highFeeRate
+
+	bumpResult, err := ctx.sweeper.UpdateParams(
+		*input.OutPoint(), ParamsUpdate{
+			Fee:            lowFeePref,
+			DeadlineHeight: mockChainHeight + blocksLeft,
+		},
+	)
+	require.NoError(t, err, "unable to bump input's fee")
+
+	// The replacement transaction should use the fee rate of the blocks
+	// left until the deadline.
+	ctx.tick()
+	highFeeTx := ctx.receiveTx()
+	assertTxFeeRate(t, &highFeeTx, highFeeRate, changePk, &input)
+
+	ctx.backend.mine()
+	ctx.expectResult(sweepResult, nil)
+	ctx.expectResult(bumpResult, nil)
+
+	ctx.finish(1)
+}
+
 // TestExclusiveGroup tests the sweeper exclusive group functionality.
 func TestExclusiveGroup(t *testing.T) {
 	ctx := createSweeperTestContext(t)
@@ -2238,3 +2407,251 @@ func TestSweeperShutdownHandling(t *testing.T) {
 	)
 	require.Error(t, err)
 }
+
+// TestParamsDeadlineFeePreference tests that the conf target of an input is
+// capped to the number of blocks left until its deadline.
+func TestParamsDeadlineFeePreference(t *testing.T) {
+	t.Parallel()
+
+	const currentHeight = 100
+
+	testCases := []struct {
+		name     string
+		params   Params
+		expected FeePreference
+	}{{
+		name: "no deadline",
+		params: Params{
+			Fee: FeePreference{ConfTarget: 6},
+		},
+		expected: FeePreference{ConfTarget: 6},
+	}, {
+		name: "fee rate with deadline",
+		params: Params{
+			Fee:            FeePreference{FeeRate: 1000},
+			DeadlineHeight: currentHeight + 2,
+		},
+		expected: FeePreference{FeeRate: 1000},
+	}, {
+		name: "deadline after conf target",
+		params: Params{
+			Fee:            FeePreference{ConfTarget: 6},
+			DeadlineHeight: currentHeight + 10,
+		},
+		expected: FeePreference{ConfTarget: 6},
+	}, {
+		name: "deadline before conf target",
+		params: Params{
+			Fee:            FeePreference{ConfTarget: 6},
+			DeadlineHeight: currentHeight + 3,
+		},
+		expected: FeePreference{ConfTarget: 3},
+	}, {
+		name: "deadline reached",
+		params: Params{
+			Fee:            FeePreference{ConfTarget: 6},
+			DeadlineHeight: currentHeight - 5,
+		},
+		expected: FeePreference{ConfTarget: 1},
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			feePref := tc.params.feePreference(currentHeight)
+			require.Equal(t, tc.expected, feePref)
+		})
+	}
+}
+
+// TestDefaultMaxFeeRate asserts that the default maximum fee rate follows the
+// fee floor of the active chain.
+//
+// NOTE: This test must not run in parallel as it modifies the global floor
+// profile.
+func TestDefaultMaxFeeRate(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, chainfee.SetFloorProfile(
+			chainfee.DefaultFloorProfile,
+		))
+	})
+
+	require.Equal(
+		t, chainfee.FeePerKwFloor*maxFeeRateMultiplier,
+		DefaultMaxFeeRate(),
+	)
+
+	require.NoError(t, chainfee.SetFloorProfile(chainfee.FloorProfile{
+		FeePerKwFloor:         100,
+		AbsoluteFeePerKwFloor: 100,
+	}))
+	require.Equal(
+		t, chainfee.SatPerKWeight(100*maxFeeRateMultiplier),
+		DefaultMaxFeeRate(),
+	)
+}
+
+// TestInputFeeRateBudget asserts that the fee rate of an input is capped by its
+// budget, but never below the relay fee rate.
+func TestInputFeeRateBudget(t *testing.T) {
+	t.Parallel()
+
+	const (
+		feeRate = chainfee.SatPerKWeight(10000)
+		capRate = chainfee.SatPerKWeight(5000)
+	)
+
+	s := New(&UtxoSweeperConfig{
+		FeeEstimator: newMockFeeEstimator(
+			feeRate, chainfee.FeePerKwFloor,
+		),
+		MaxFeeRate: DefaultMaxFeeRate(),
+	})
+
+	inp := createTestInput(100000, input.CommitmentTimeLock)
+
+	var estimator input.TxWeightEstimator
+	err := inp.WitnessType().AddWeightEstimation(&estimator)
+	require.NoError(t, err)
+	weight := int64(estimator.Weight())
+
+	// The budget that results in exactly capRate for the input.
+	capBudget := capRate.FeeForWeight(weight)
+
+	parent := &input.TxInfo{Fee: 1000, Weight: 1000}
+	parentInp := input.MakeBaseInput(
+		inp.OutPoint(), inp.WitnessType(), inp.SignDesc(), 0, parent,
+	)
+
+	testCases := []struct {
+		name     string
+		input    input.BaseInput
+		budget   ltcutil.Amount
+		expected chainfee.SatPerKWeight
+	}{{
+		name:     "no budget",
+		input:    inp,
+		expected: feeRate,
+	}, {
+		name:     "budget not reached",
+		input:    inp,
+		budget:   feeRate.FeeForWeight(weight),
+		expected: feeRate,
+	}, {
+		name:     "budget reached",
+		input:    inp,
+		budget:   capBudget,
+		expected: capRate,
+	}, {
+		name:     "budget below relay fee",
+		input:    inp,
+		budget:   1,
+		expected: chainfee.FeePerKwFloor,
+	}, {
+		name:  "budget includes parent",
+		input: parentInp,
+		budget: capRate.FeeForWeight(weight+parent.Weight) -
+			parent.Fee,
+		expected: capRate,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pi := &pendingInput{
+				Input: &tc.input,
+				params: Params{
+					Fee:    FeePreference{ConfTarget: 6},
+					Budget: tc.budget,
+				},
+			}
+
+			rate, err := s.inputFeeRate(pi, 100)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, rate)
+		})
+	}
+}
+
+// TestAbandonUneconomicalInputs asserts that only inputs that may be abandoned
+// and whose budget doesn't cover the fee at the relay fee rate are removed.
+func TestAbandonUneconomicalInputs(t *testing.T) {
+	t.Parallel()
+
+	relayFeeRate := chainfee.SatPerKWeight(1000)
+
+	s := New(&UtxoSweeperConfig{
+		FeeEstimator: newMockFeeEstimator(10000, relayFeeRate),
+		MaxFeeRate:   DefaultMaxFeeRate(),
+	})
+
+	var estimator input.TxWeightEstimator
+	inp := createTestInput(1000, input.HtlcOfferedTimeoutSecondLevel)
+	err := inp.WitnessType().AddWeightEstimation(&estimator)
+	require.NoError(t, err)
+	minFee := relayFeeRate.FeeForWeight(int64(estimator.Weight()))
+
+	testCases := []struct {
+		name      string
+		params    Params
+		abandoned bool
+	}{{
+		name: "budget exhausted",
+		params: Params{
+			Budget:              minFee - 1,
+			AbandonUneconomical: true,
+		},
+		abandoned: true,
+	}, {
+		name: "budget sufficient",
+		params: Params{
+			Budget:              minFee,
+			AbandonUneconomical: true,
+		},
+	}, {
+		name: "abandonment disabled",
+		params: Params{
+			Budget: minFee - 1,
+		},
+	}, {
+		name: "no budget",
+		params: Params{
+			AbandonUneconomical: true,
+		},
+	}}
+
+	outpoints := make([]wire.OutPoint, len(testCases))
+	results := make([]chan Result, len(testCases))
+	for i, tc := range testCases {
+		inp := createTestInput(
+			1000, input.HtlcOfferedTimeoutSecondLevel,
+		)
+		outpoints[i] = *inp.OutPoint()
+		results[i] = make(chan Result, 1)
+
+		s.pendingInputs[outpoints[i]] = &pendingInput{
+			Input:     &inp,
+			listeners: []chan Result{results[i]},
+			params:    tc.params,
+		}
+	}
+
+	s.abandonUneconomicalInputs()
+
+	for i, tc := range testCases {
+		_, pending := s.pendingInputs[outpoints[i]]
+		require.Equal(t, !tc.abandoned, pending, tc.name)
+
+		if !tc.abandoned {
+			require.Empty(t, results[i], tc.name)
+			continue
+		}
+
+		result := <-results[i]
+		require.ErrorIs(t, result.Err, ErrBudgetExhausted, tc.name)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/ltcsuite/lnd/input"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/lnwallet/chainfee"
+	"github.com/ltcsuite/lnd/lnwallet/chanfunding"
 	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/txscript"
 	"github.com/ltcsuite/ltcd/wire"
@@ -113,14 +114,24 @@ type txInputSet struct {
 	// the set.
 	maxInputs int
 
+	// maxWeight is the maximum estimated weight of the sweep transaction
+	// created from the set. Inputs that would push the tx weight above
+	// this limit are rejected.
+	maxWeight int64
+
 	// wallet contains wallet functionality required by the input set to
 	// retrieve utxos.
 	wallet Wallet
+
+	// coinSelection is the strategy used to select wallet utxos. If nil,
+	// the smallest utxos are selected first.
+	coinSelection chanfunding.CoinSelectionStrategy
 }
 
 // newTxInputSet constructs a new, empty input set.
 func newTxInputSet(wallet Wallet, feePerKW chainfee.SatPerKWeight,
-	maxInputs int) *txInputSet {
+	maxInputs int, maxWeight int64,
+	coinSelection chanfunding.CoinSelectionStrategy) *txInputSet {
 
 	state := txInputSetState{
 		feeRate: feePerKW,
@@ -128,13 +139,55 @@ func newTxInputSet(wallet Wallet, feePerKW chainfee.SatPerKWeight,
 
 	b := txInputSet{
 		maxInputs:       maxInputs,
+		maxWeight:       maxWeight,
 		wallet:          wallet,
+		coinSelection:   coinSelection,
 		txInputSetState: state,
 	}
 
 	return &b
 }
 
+// arrangeWalletUtxos orders the given wallet utxos by the configured coin
+// selection strategy. Without a strategy, smaller values are put at the start
+// of the slice to avoid locking large utxos for sweeping.
+func (t *txInputSet) arrangeWalletUtxos(
+	utxos []*lnwallet.Utxo) []*lnwallet.Utxo {
+
+	if t.coinSelection == nil {
+		sort.Slice(utxos, func(i, j int) bool {
+			return utxos[i].Value < utxos[j].Value
+		})
+
+		return utxos
+	}
+
+	coins := make([]chanfunding.Coin, 0, len(utxos))
+	utxoByOutPoint := make(map[wire.OutPoint]*lnwallet.Utxo, len(utxos))
+	for _, utxo := range utxos {
+		coins = append(coins, chanfunding.Coin{
+			TxOut: wire.TxOut{
+				Value:    int64(utxo.Value),
+				PkScript: utxo.PkScript,
+			},
+			OutPoint: utxo.OutPoint,
+		})
+		utxoByOutPoint[utxo.OutPoint] = utxo
+	}
+
+	// The wallet inputs need to bring the change output above the dust
+	// limit.
+	target := lnwallet.DustLimitForSize(input.P2TRSize) - t.changeOutput
+
+	arranged := make([]*lnwallet.Utxo, 0, len(utxos))
+	coins = t.coinSelection.ArrangeCoins(coins, target, t.feeRate)
+	for _, coin := range coins {
+		arranged = append(arranged, utxoByOutPoint[coin.OutPoint])
+	}
+
+	return arranged
+}
+
 // enoughInput returns true if we've accumulated enough inputs to pay the fees
 // and have at least one output that meets the dust limit.
 func (t *txInputSet) enoughInput() bool {
@@ -213,8 +266,16 @@ func (t *txInputSet) addToState(inp input.Input,
 	value := ltcutil.Amount(inp.SignDesc().Output.Value)
 	newSet.inputTotal += value
 
-	// Recalculate the tx fee.
-	fee := newSet.weightEstimate(true).fee()
+	// Recalculate the tx fee and reject the input if it would make the
+	// transaction exceed the maximum weight.
+	estimate := newSet.weightEstimate(true)
+	if weight := int64(estimate.weight()); weight > t.maxWeight {
+		log.Debugf("Rejected input=%v, tx weight %v would exceed "+
+			"max weight %v", value, weight, t.maxWeight)
+
+		return nil
+	}
+	fee := estimate.fee()
 
 	// Calculate the new output value.
 	if reqOut != nil {
@@ -329,6 +390,15 @@ func (t *txInputSet) add(input input.Input, constraints addConstraints) bool {
 // whole.
 func (t *txInputSet) addPositiveYieldInputs(sweepableInputs []txInput) {
 	for i, inp := range sweepableInputs {
+		// Stop once the set is full. The remaining inputs will be
+		// added to the next set.
+		if t.isFull() {
+			log.Debugf("Input set full with %d inputs, %d inputs "+
+				"left for the next set", len(t.inputs),
+				len(sweepableInputs)-i)
+			return
+		}
+
 		// Apply relaxed constraints for force sweeps.
 		constraints := constraintsRegular
 		if inp.parameters().Force {
@@ -357,6 +427,17 @@ func (t *txInputSet) addPositiveYieldInputs(sweepableInputs []txInput) {
 	// We managed to add all inputs to the set.
 }
 
+// isFull returns true if no further regular inputs can be added to the set
+// because either the maximum number of inputs or the maximum weight has been
+// reached.
+func (t *txInputSet) isFull() bool {
+	if len(t.inputs) >= t.maxInputs {
+		return true
+	}
+
+	return int64(t.weightEstimate(true).weight()) >= t.maxWeight
+}
+
 // tryAddWalletInputsIfNeeded retrieves utxos from the wallet and tries adding
 // as many as required to bring the tx output value above the given minimum.
 func (t *txInputSet) tryAddWalletInputsIfNeeded() error {
@@ -367,8 +448,7 @@ func (t *txInputSet) tryAddWalletInputsIfNeeded() error {
 	}
 
 	// Retrieve wallet utxos. Only consider confirmed utxos to prevent
-	// problems around RBF rules for unconfirmed inputs. This currently
-	// ignores the configured coin selection strategy.
+	// problems around RBF rules for unconfirmed inputs.
 	utxos, err := t.wallet.ListUnspentWitnessFromDefaultAccount(
 		1, math.MaxInt32,
 	)
@@ -376,14 +456,7 @@ func (t *txInputSet) tryAddWalletInputsIfNeeded() error {
 		return err
 	}
 
-	// Sort the UTXOs by putting smaller values at the start of the slice
-	// to avoid locking large UTXO for sweeping.
-	//
-	// TODO(yy): add more choices to CoinSelectionStrategy and use the
-	// configured value here.
-	sort.Slice(utxos, func(i, j int) bool {
-		return utxos[i].Value < utxos[j].Value
-	})
+	utxos = t.arrangeWalletUtxos(utxos)
 
 	for _, utxo := range utxos {
 		input, err := createWalletTxInput(utxo)
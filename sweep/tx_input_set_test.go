@@ -16,7 +16,9 @@ func TestTxInputSet(t *testing.T) {
 		feeRate   = 1000
 		maxInputs = 10
 	)
-	set := newTxInputSet(nil, feeRate, maxInputs)
+	set := newTxInputSet(
+		nil, feeRate, maxInputs, DefaultMaxSweepWeight, nil,
+	)
 
 	// Create a 300 sat input. The fee to sweep this input to a P2WKH output
 	// is 439 sats. That means that this input yields -139 sats and we
@@ -56,6 +58,36 @@ func TestTxInputSet(t *testing.T) {
 	}
 }
 
+// TestTxInputSetMaxWeight tests that inputs are rejected once the set reaches
+// its maximum weight.
+func TestTxInputSetMaxWeight(t *testing.T) {
+	const (
+		feeRate   = 1000
+		maxInputs = 10
+	)
+
+	// Determine the weight of a tx sweeping two inputs, and use that as
+	// the maximum weight.
+	probe := newTxInputSet(
+		nil, feeRate, maxInputs, DefaultMaxSweepWeight, nil,
+	)
+	require.True(t, probe.add(createP2WKHInput(10000), constraintsRegular))
+	require.True(t, probe.add(createP2WKHInput(10000), constraintsRegular))
+	maxWeight := int64(probe.weightEstimate(true).weight())
+
+	set := newTxInputSet(nil, feeRate, maxInputs, maxWeight, nil)
+	require.True(t, set.add(createP2WKHInput(10000), constraintsRegular))
+	require.False(t, set.isFull())
+	require.True(t, set.add(createP2WKHInput(10000), constraintsRegular))
+	require.True(t, set.isFull())
+
+	// A third input would exceed the maximum weight, even when it is a
+	// forced sweep.
+	require.False(t, set.add(createP2WKHInput(10000), constraintsRegular))
+	require.False(t, set.add(createP2WKHInput(10000), constraintsForce))
+	require.Len(t, set.inputs, 2)
+}
+
 // TestTxInputSetFromWallet tests adding a wallet input to a TxInputSet to reach
 // the dust limit.
 func TestTxInputSetFromWallet(t *testing.T) {
@@ -65,7 +97,9 @@ func TestTxInputSetFromWallet(t *testing.T) {
 	)
 
 	wallet := &mockWallet{}
-	set := newTxInputSet(wallet, feeRate, maxInputs)
+	set := newTxInputSet(
+		wallet, feeRate, maxInputs, DefaultMaxSweepWeight, nil,
+	)
 
 	// Add a 500 sat input to the set. It yields positively, but doesn't
 	// reach the output dust limit.
@@ -134,7 +168,9 @@ func TestTxInputSetRequiredOutput(t *testing.T) {
 		feeRate   = 1000
 		maxInputs = 10
 	)
-	set := newTxInputSet(nil, feeRate, maxInputs)
+	set := newTxInputSet(
+		nil, feeRate, maxInputs, DefaultMaxSweepWeight, nil,
+	)
 
 	// Attempt to add an input with a required txout below the dust limit.
 	// This should fail since we cannot trim such outputs.
@@ -9,6 +9,7 @@ import (
 	"github.com/ltcsuite/lnd/input"
 	"github.com/ltcsuite/lnd/lnwallet"
 	"github.com/ltcsuite/lnd/lnwallet/chainfee"
+	"github.com/ltcsuite/lnd/lnwallet/chanfunding"
 	"github.com/ltcsuite/ltcd/blockchain"
 	"github.com/ltcsuite/ltcd/ltcutil"
 	"github.com/ltcsuite/ltcd/txscript"
@@ -20,6 +21,12 @@ var (
 	// allowed in a single sweep tx. If more need to be swept, multiple txes
 	// are created and published.
 	DefaultMaxInputsPerTx = 100
+
+	// DefaultMaxSweepWeight specifies the default maximum weight of a
+	// single sweep tx. It is kept below the standardness limit of 400,000
+	// weight units so that large sweeps are split into multiple txes
+	// instead of being rejected by the mempool.
+	DefaultMaxSweepWeight int64 = 390_000
 )
 
 // txInput is an interface that provides the input data required for tx
@@ -35,12 +42,14 @@ type inputSet []input.Input
 
 // generateInputPartitionings goes through all given inputs and constructs sets
 // of inputs that can be used to generate a sensible transaction. Each set
-// contains up to the configured maximum number of inputs. Negative yield
+// contains up to the configured maximum number of inputs and stays below the
+// configured maximum tx weight. Negative yield
 // inputs are skipped. No input sets with a total value after fees below the
 // dust limit are returned.
 func generateInputPartitionings(sweepableInputs []txInput,
 	feePerKW chainfee.SatPerKWeight, maxInputsPerTx int,
-	wallet Wallet) ([]inputSet, error) {
+	maxWeight int64, wallet Wallet,
+	coinSelection chanfunding.CoinSelectionStrategy) ([]inputSet, error) {
 
 	// Sort input by yield. We will start constructing input sets starting
 	// with the highest yield inputs. This is to prevent the construction
@@ -81,13 +90,17 @@ func generateInputPartitionings(sweepableInputs []txInput,
 			yields[*sweepableInputs[j].OutPoint()]
 	})
 
-	// Select blocks of inputs up to the configured maximum number.
+	// Select blocks of inputs up to the configured maximum number and
+	// weight.
 	var sets []inputSet
 	for len(sweepableInputs) > 0 {
 		// Start building a set of positive-yield tx inputs under the
 		// condition that the tx will be published with the specified
 		// fee rate.
-		txInputs := newTxInputSet(wallet, feePerKW, maxInputsPerTx)
+		txInputs := newTxInputSet(
+			wallet, feePerKW, maxInputsPerTx, maxWeight,
+			coinSelection,
+		)
 
 		// From the set of sweepable inputs, keep adding inputs to the
 		// input set until the tx output value no longer goes up or the
@@ -39,6 +39,33 @@ func (p FeePreference) String() string {
 	return p.FeeRate.String()
 }
 
+// WithDeadline returns the fee preference to use at the given height for a
+// transaction that should be confirmed by the deadline height. If the deadline
+// is closer than the conf target, the number of blocks left until the
+// deadline is used as conf target instead. Fee preferences with a fixed fee
+// rate and a deadline height of zero, which means there's no deadline, are
+// returned unchanged.
+func (p FeePreference) WithDeadline(deadlineHeight,
+	currentHeight int32) FeePreference {
+
+	if deadlineHeight == 0 || p.ConfTarget == 0 {
+		return p
+	}
+
+	// If the deadline has already been reached, we'll still aim for the
+	// next block.
+	blocksLeft := deadlineHeight - currentHeight
+	if blocksLeft < 1 {
+		blocksLeft = 1
+	}
+
+	if uint32(blocksLeft) >= p.ConfTarget {
+		return p
+	}
+
+	return FeePreference{ConfTarget: uint32(blocksLeft)}
+}
+
 // DetermineFeePerKw will determine the fee in sat/kw that should be paid given
 // an estimator, a confirmation target, and a manual value for sat/byte. A
 // value is chosen based on the two free parameters as one, or both of them can
@@ -72,21 +99,22 @@ func DetermineFeePerKw(feeEstimator chainfee.Estimator,
 	case feePref.FeeRate != 0:
 		feePerKW := feePref.FeeRate
 
-		// Because the user can specify 1 sat/vByte on the RPC
-		// interface, which corresponds to 250 sat/kw, we need to bump
-		// that to the minimum "safe" fee rate which is 253 sat/kw.
-		if feePerKW == chainfee.AbsoluteFeePerKwFloor {
+		// Because the user can specify the minimum relay fee rate on
+		// the RPC interface, which corresponds to the absolute fee
+		// floor of the active chain, we need to bump that to the
+		// minimum "safe" fee rate, which is its fee floor.
+		if feePerKW == chainfee.AbsoluteFeeFloor() {
 			log.Infof("Manual fee rate input of %d sat/kw is "+
 				"too low, using %d sat/kw instead", feePerKW,
-				chainfee.FeePerKwFloor)
-			feePerKW = chainfee.FeePerKwFloor
+				chainfee.FeeFloor())
+			feePerKW = chainfee.FeeFloor()
 		}
 
-		// If that bumped fee rate of at least 253 sat/kw is still lower
-		// than the relay fee rate, we return an error to let the user
-		// know. Note that "Relay fee rate" may mean slightly different
-		// things depending on the backend. For bitcoind, it is
-		// effectively max(relay fee, min mempool fee).
+		// If that bumped fee rate of at least the fee floor is still
+		// lower than the relay fee rate, we return an error to let the
+		// user know. Note that "Relay fee rate" may mean slightly
+		// different things depending on the backend. For bitcoind, it
+		// is effectively max(relay fee, min mempool fee).
 		minFeePerKW := feeEstimator.RelayFeePerKW()
 		if feePerKW < minFeePerKW {
 			return 0, fmt.Errorf("manual fee rate input of %d "+
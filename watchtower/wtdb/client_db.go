@@ -214,12 +214,13 @@ func NewBoltBackendCreator(active bool, dbPath,
 
 	return func(boltCfg *kvdb.BoltConfig) (kvdb.Backend, error) {
 		cfg := &kvdb.BoltBackendConfig{
-			DBPath:            dbPath,
-			DBFileName:        dbFileName,
-			NoFreelistSync:    boltCfg.NoFreelistSync,
-			AutoCompact:       boltCfg.AutoCompact,
-			AutoCompactMinAge: boltCfg.AutoCompactMinAge,
-			DBTimeout:         boltCfg.DBTimeout,
+			DBPath:                      dbPath,
+			DBFileName:                  dbFileName,
+			NoFreelistSync:              boltCfg.NoFreelistSync,
+			AutoCompact:                 boltCfg.AutoCompact,
+			AutoCompactMinAge:           boltCfg.AutoCompactMinAge,
+			AutoCompactMinFragmentation: boltCfg.AutoCompactMinFragmentation,
+			DBTimeout:                   boltCfg.DBTimeout,
 		}
 
 		db, err := kvdb.GetBoltBackend(cfg)